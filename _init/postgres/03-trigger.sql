@@ -25,6 +25,36 @@ CREATE TRIGGER trigger_transfers_updated_at
     FOR EACH ROW
     EXECUTE FUNCTION core.update_updated_at_column();
 
+CREATE TRIGGER trigger_balance_alerts_updated_at
+    BEFORE UPDATE ON core.balance_alerts
+    FOR EACH ROW
+    EXECUTE FUNCTION core.update_updated_at_column();
+
+CREATE TRIGGER trigger_business_rules_updated_at
+    BEFORE UPDATE ON core.business_rules
+    FOR EACH ROW
+    EXECUTE FUNCTION core.update_updated_at_column();
+
+CREATE TRIGGER trigger_notification_preferences_updated_at
+    BEFORE UPDATE ON core.notification_preferences
+    FOR EACH ROW
+    EXECUTE FUNCTION core.update_updated_at_column();
+
+CREATE TRIGGER trigger_settlement_files_updated_at
+    BEFORE UPDATE ON core.settlement_files
+    FOR EACH ROW
+    EXECUTE FUNCTION core.update_updated_at_column();
+
+CREATE TRIGGER trigger_settlement_entries_updated_at
+    BEFORE UPDATE ON core.settlement_entries
+    FOR EACH ROW
+    EXECUTE FUNCTION core.update_updated_at_column();
+
+CREATE TRIGGER trigger_transfer_reference_sequences_updated_at
+    BEFORE UPDATE ON core.transfer_reference_sequences
+    FOR EACH ROW
+    EXECUTE FUNCTION core.update_updated_at_column();
+
 -- Trigger to automatically set completed_at when transfer status changes to completed
 CREATE OR REPLACE FUNCTION core.set_transfer_completed_at()
 RETURNS TRIGGER AS $$
@@ -114,3 +144,43 @@ CREATE TRIGGER trigger_transactions_validation
     FOR EACH ROW
     EXECUTE FUNCTION core.validate_transaction_creation();
 
+
+-- Trigger to chain balance history entries per account.
+-- Each entry gets the next sequence number of its account and a hash over the previous entry's hash and its own
+-- values, so a removed, altered or replayed entry shows up when the chain is verified (see svc-transaction
+-- service.BalanceHistoryChainHash, which must stay in sync with the hash input below).
+CREATE OR REPLACE FUNCTION core.chain_balance_history()
+RETURNS TRIGGER AS $$
+DECLARE
+    v_previous_sequence BIGINT;
+    v_previous_hash VARCHAR(64);
+BEGIN
+    -- Same lock as svc-transaction takes before mutating an account, so writers outside it are serialized too
+    PERFORM pg_advisory_xact_lock(hashtextextended('core.accounts:' || NEW.account_id::TEXT, 0));
+
+    SELECT sequence_number, chain_hash INTO v_previous_sequence, v_previous_hash
+    FROM core.account_balance_history
+    WHERE account_id = NEW.account_id
+    ORDER BY sequence_number DESC
+    LIMIT 1;
+
+    NEW.sequence_number := COALESCE(v_previous_sequence, 0) + 1;
+    NEW.chain_hash := encode(sha256(convert_to(concat_ws('|',
+        COALESCE(v_previous_hash, ''),
+        NEW.account_id::TEXT,
+        NEW.sequence_number::TEXT,
+        COALESCE(NEW.transaction_id::TEXT, ''),
+        NEW.old_balance::TEXT,
+        NEW.new_balance::TEXT,
+        NEW.balance_change::TEXT,
+        NEW.operation
+    ), 'UTF8')), 'hex');
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_balance_history_chain
+    BEFORE INSERT ON core.account_balance_history
+    FOR EACH ROW
+    EXECUTE FUNCTION core.chain_balance_history();
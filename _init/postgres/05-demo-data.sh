@@ -0,0 +1,9 @@
+#!/bin/sh
+# Loads the demo accounts of seed/demo-data.sql, except for APP_PROFILE=realistic which starts with an empty ledger.
+# The postgres image sources this script when it is not executable, so it does not exit.
+
+if [ "$APP_PROFILE" = "realistic" ]; then
+    echo "APP_PROFILE=realistic: skipping the demo data"
+else
+    psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" -f /docker-entrypoint-initdb.d/seed/demo-data.sql
+fi
@@ -0,0 +1,47 @@
+package balance_adapter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FormatCurrencyResponse is the "data" payload returned by GET /currencies/:code/format
+type FormatCurrencyResponse struct {
+	CurrencyCode string `json:"currency_code"`
+	Symbol       string `json:"symbol"`
+	DecimalPlace int    `json:"decimal_place"`
+	Amount       string `json:"amount"`
+	Locale       string `json:"locale,omitempty"`
+	Formatted    string `json:"formatted"`
+}
+
+// FormatCurrency formats an amount for display; an empty locale gives the plain "$100.50" form
+func (adapter *Adapter) FormatCurrency(ctx context.Context, currencyCode string, amount string, locale string) (response *FormatCurrencyResponse, err error) {
+	const op = "balance_adapter.Adapter.FormatCurrency"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"currency": currencyCode,
+		"amount":   amount,
+		"locale":   locale,
+	})
+
+	path := fmt.Sprintf("/currencies/%s/format?amount=%s", url.PathEscape(currencyCode), url.QueryEscape(amount))
+	if locale != "" {
+		path += "&locale=" + url.QueryEscape(locale)
+	}
+
+	response = &FormatCurrencyResponse{}
+	if err = adapter.get(ctx, path, response); err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("response", fmt.Sprintf("%+v", response)).Debug()
+
+	return response, nil
+}
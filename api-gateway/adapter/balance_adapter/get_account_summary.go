@@ -0,0 +1,44 @@
+package balance_adapter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AccountSummary is the "data" payload returned by GET /accounts/:id/summary
+type AccountSummary struct {
+	ID            string `json:"id"`
+	AccountNumber string `json:"account_number"`
+	AccountName   string `json:"account_name"`
+	Balance       string `json:"balance"` // Exact major-unit decimal
+	Currency      string `json:"currency"`
+	Status        string `json:"status"`
+	AccountType   string `json:"account_type"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+	Version       int32  `json:"version"`
+}
+
+// GetAccountSummary returns an account by its account number
+func (adapter *Adapter) GetAccountSummary(ctx context.Context, accountNumber string) (response *AccountSummary, err error) {
+	const op = "balance_adapter.Adapter.GetAccountSummary"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":           op,
+		"account_number": accountNumber,
+	})
+
+	response = &AccountSummary{}
+	if err = adapter.get(ctx, fmt.Sprintf("/accounts/%s/summary", url.PathEscape(accountNumber)), response); err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("response", fmt.Sprintf("%+v", response)).Debug()
+
+	return response, nil
+}
@@ -0,0 +1,52 @@
+package balance_adapter
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AccountBalance is one entry of the "data" payload returned by POST /accounts/balances
+type AccountBalance struct {
+	Account       string `json:"account"` // The account number it was requested by
+	AccountNumber string `json:"account_number"`
+	Balance       string `json:"balance"` // Exact major-unit decimal
+	Currency      string `json:"currency"`
+	Status        string `json:"status"`
+	UpdatedAt     string `json:"updated_at"`
+	Version       int32  `json:"version"`
+}
+
+// GetBalancesResponse is the "data" payload returned by POST /accounts/balances
+type GetBalancesResponse struct {
+	Balances []AccountBalance `json:"balances"`
+	NotFound []string         `json:"not_found"`
+}
+
+// GetBalances returns the balances of many accounts, read by svc-balance with a single query
+func (adapter *Adapter) GetBalances(ctx context.Context, accountNumbers []string) (response *GetBalancesResponse, err error) {
+	const op = "balance_adapter.Adapter.GetBalances"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"accounts": len(accountNumbers),
+	})
+
+	request := struct {
+		Accounts []string `json:"accounts"`
+	}{Accounts: accountNumbers}
+
+	response = &GetBalancesResponse{}
+	if err = adapter.post(ctx, "/accounts/balances", request, response); err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"balances":  len(response.Balances),
+		"not_found": len(response.NotFound),
+	}).Debug()
+
+	return response, nil
+}
@@ -0,0 +1,41 @@
+package balance_adapter
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Currency is a single entry of the svc-balance currency catalog
+type Currency struct {
+	Code         string `json:"code"`
+	Name         string `json:"name"`
+	Symbol       string `json:"symbol"`
+	DecimalPlace int    `json:"decimal_place"`
+	IsActive     bool   `json:"is_active"`
+}
+
+// GetCurrenciesResponse is the "data" payload returned by GET /currencies
+type GetCurrenciesResponse struct {
+	Currencies []Currency `json:"currencies"`
+	Count      int        `json:"count"`
+}
+
+func (adapter *Adapter) GetCurrencies(ctx context.Context) (response *GetCurrenciesResponse, err error) {
+	const op = "balance_adapter.Adapter.GetCurrencies"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	response = &GetCurrenciesResponse{}
+	if err = adapter.get(ctx, "/currencies?include_inactive=true", response); err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("count", response.Count).Debug()
+
+	return response, nil
+}
@@ -0,0 +1,32 @@
+package balance_ops_adapter
+
+import (
+	"api-gateway/adapter/balance_ops_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// Adapter is a wrapper around the svc-balance BalanceOps grpc client
+type Adapter struct {
+	serviceName string
+
+	logger *logrus.Logger
+
+	balanceOpsClient pb.BalanceOpsClient
+}
+
+// NewAdapter creates a new grpc adapter for the svc-balance ops API
+func NewAdapter(
+	serviceName string,
+	logger *logrus.Logger,
+	cc *grpc.ClientConn,
+) *Adapter {
+	return &Adapter{
+		serviceName: serviceName,
+
+		logger: logger,
+
+		balanceOpsClient: pb.NewBalanceOpsClient(cc),
+	}
+}
@@ -0,0 +1,35 @@
+package balance_ops_adapter
+
+import (
+	"context"
+	"fmt"
+
+	"api-gateway/adapter/balance_ops_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+)
+
+func (adapter *Adapter) ListDormantAccounts(ctx context.Context, request *pb.ListDormantAccountsRequest) (response *pb.ListDormantAccountsResponse, err error) {
+	const op = "balance_ops_adapter.Adapter.ListDormantAccounts"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+		"type":    fmt.Sprintf("%T", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	response, err = adapter.balanceOpsClient.ListDormantAccounts(ctx, request)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Lists can be long, so only their size is logged
+	logger.WithField("accounts", len(response.Accounts)).Info()
+
+	return response, nil
+}
@@ -0,0 +1,35 @@
+package flowngine_adapter
+
+import (
+	"context"
+	"fmt"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+)
+
+func (adapter *Adapter) GetTransferTimeline(ctx context.Context, request *pb.GetTransferTimelineRequest) (response *pb.GetTransferTimelineResponse, err error) {
+	const op = "flowngine_adapter.Adapter.GetTransferTimeline"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+		"type":    fmt.Sprintf("%T", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	response, err = adapter.serviceBClient.GetTransferTimeline(ctx, request)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Timelines can be long, so only their size is logged
+	logger.WithField("events", len(response.Events)).Info()
+
+	return response, nil
+}
@@ -0,0 +1,35 @@
+package flowngine_adapter
+
+import (
+	"context"
+	"fmt"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+)
+
+func (adapter *Adapter) ListAdminAudit(ctx context.Context, request *pb.ListAdminAuditRequest) (response *pb.ListAdminAuditResponse, err error) {
+	const op = "flowngine_adapter.Adapter.ListAdminAudit"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+		"type":    fmt.Sprintf("%T", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	response, err = adapter.serviceBClient.ListAdminAudit(ctx, request)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Lists can be long, so only their size is logged
+	logger.WithField("records", len(response.Records)).Info()
+
+	return response, nil
+}
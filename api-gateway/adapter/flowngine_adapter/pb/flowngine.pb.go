@@ -26,34 +26,49 @@ const (
 type TransferStatus int32
 
 const (
-	TransferStatus_TRANSFER_STATUS_UNSPECIFIED TransferStatus = 0
-	TransferStatus_TRANSFER_STATUS_PENDING     TransferStatus = 1
-	TransferStatus_TRANSFER_STATUS_PROCESSING  TransferStatus = 2
-	TransferStatus_TRANSFER_STATUS_COMPLETED   TransferStatus = 3
-	TransferStatus_TRANSFER_STATUS_FAILED      TransferStatus = 4
-	TransferStatus_TRANSFER_STATUS_COMPENSATED TransferStatus = 5
-	TransferStatus_TRANSFER_STATUS_CANCELLED   TransferStatus = 6
+	TransferStatus_TRANSFER_STATUS_UNSPECIFIED       TransferStatus = 0
+	TransferStatus_TRANSFER_STATUS_PENDING           TransferStatus = 1
+	TransferStatus_TRANSFER_STATUS_PROCESSING        TransferStatus = 2
+	TransferStatus_TRANSFER_STATUS_COMPLETED         TransferStatus = 3
+	TransferStatus_TRANSFER_STATUS_FAILED            TransferStatus = 4
+	TransferStatus_TRANSFER_STATUS_COMPENSATED       TransferStatus = 5
+	TransferStatus_TRANSFER_STATUS_CANCELLED         TransferStatus = 6
+	TransferStatus_TRANSFER_STATUS_DELAYED           TransferStatus = 7  // Still running past its SLA
+	TransferStatus_TRANSFER_STATUS_EXPIRED           TransferStatus = 8  // Not approved, funded or triggered within its window; nothing was debited
+	TransferStatus_TRANSFER_STATUS_AWAITING_APPROVAL TransferStatus = 9  // Held until approved through ApproveTransfer
+	TransferStatus_TRANSFER_STATUS_AWAITING_FUNDS    TransferStatus = 10 // Waiting for the source balance to cover the amount
+	TransferStatus_TRANSFER_STATUS_AWAITING_TRIGGER  TransferStatus = 11 // Waiting for the trigger account to receive the trigger amount
 )
 
 // Enum value maps for TransferStatus.
 var (
 	TransferStatus_name = map[int32]string{
-		0: "TRANSFER_STATUS_UNSPECIFIED",
-		1: "TRANSFER_STATUS_PENDING",
-		2: "TRANSFER_STATUS_PROCESSING",
-		3: "TRANSFER_STATUS_COMPLETED",
-		4: "TRANSFER_STATUS_FAILED",
-		5: "TRANSFER_STATUS_COMPENSATED",
-		6: "TRANSFER_STATUS_CANCELLED",
+		0:  "TRANSFER_STATUS_UNSPECIFIED",
+		1:  "TRANSFER_STATUS_PENDING",
+		2:  "TRANSFER_STATUS_PROCESSING",
+		3:  "TRANSFER_STATUS_COMPLETED",
+		4:  "TRANSFER_STATUS_FAILED",
+		5:  "TRANSFER_STATUS_COMPENSATED",
+		6:  "TRANSFER_STATUS_CANCELLED",
+		7:  "TRANSFER_STATUS_DELAYED",
+		8:  "TRANSFER_STATUS_EXPIRED",
+		9:  "TRANSFER_STATUS_AWAITING_APPROVAL",
+		10: "TRANSFER_STATUS_AWAITING_FUNDS",
+		11: "TRANSFER_STATUS_AWAITING_TRIGGER",
 	}
 	TransferStatus_value = map[string]int32{
-		"TRANSFER_STATUS_UNSPECIFIED": 0,
-		"TRANSFER_STATUS_PENDING":     1,
-		"TRANSFER_STATUS_PROCESSING":  2,
-		"TRANSFER_STATUS_COMPLETED":   3,
-		"TRANSFER_STATUS_FAILED":      4,
-		"TRANSFER_STATUS_COMPENSATED": 5,
-		"TRANSFER_STATUS_CANCELLED":   6,
+		"TRANSFER_STATUS_UNSPECIFIED":       0,
+		"TRANSFER_STATUS_PENDING":           1,
+		"TRANSFER_STATUS_PROCESSING":        2,
+		"TRANSFER_STATUS_COMPLETED":         3,
+		"TRANSFER_STATUS_FAILED":            4,
+		"TRANSFER_STATUS_COMPENSATED":       5,
+		"TRANSFER_STATUS_CANCELLED":         6,
+		"TRANSFER_STATUS_DELAYED":           7,
+		"TRANSFER_STATUS_EXPIRED":           8,
+		"TRANSFER_STATUS_AWAITING_APPROVAL": 9,
+		"TRANSFER_STATUS_AWAITING_FUNDS":    10,
+		"TRANSFER_STATUS_AWAITING_TRIGGER":  11,
 	}
 )
 
@@ -84,18 +99,125 @@ func (TransferStatus) EnumDescriptor() ([]byte, []int) {
 	return file_flowngine_proto_rawDescGZIP(), []int{0}
 }
 
+// Execution mode of ExecuteTransfer
+type ExecutionMode int32
+
+const (
+	ExecutionMode_EXECUTION_MODE_UNSPECIFIED ExecutionMode = 0 // Same as ASYNC
+	ExecutionMode_EXECUTION_MODE_ASYNC       ExecutionMode = 1 // Return right after the workflow starts; poll GetTransferStatus for the outcome
+	ExecutionMode_EXECUTION_MODE_SYNC        ExecutionMode = 2 // Wait for the workflow to finish and return its outcome
+)
+
+// Enum value maps for ExecutionMode.
+var (
+	ExecutionMode_name = map[int32]string{
+		0: "EXECUTION_MODE_UNSPECIFIED",
+		1: "EXECUTION_MODE_ASYNC",
+		2: "EXECUTION_MODE_SYNC",
+	}
+	ExecutionMode_value = map[string]int32{
+		"EXECUTION_MODE_UNSPECIFIED": 0,
+		"EXECUTION_MODE_ASYNC":       1,
+		"EXECUTION_MODE_SYNC":        2,
+	}
+)
+
+func (x ExecutionMode) Enum() *ExecutionMode {
+	p := new(ExecutionMode)
+	*p = x
+	return p
+}
+
+func (x ExecutionMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExecutionMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_flowngine_proto_enumTypes[1].Descriptor()
+}
+
+func (ExecutionMode) Type() protoreflect.EnumType {
+	return &file_flowngine_proto_enumTypes[1]
+}
+
+func (x ExecutionMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExecutionMode.Descriptor instead.
+func (ExecutionMode) EnumDescriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{1}
+}
+
+type TaskQueueTrack int32
+
+const (
+	TaskQueueTrack_TASK_QUEUE_TRACK_UNSPECIFIED TaskQueueTrack = 0 // Routed by the cutover percentage of FlowEngine
+	TaskQueueTrack_TASK_QUEUE_TRACK_CURRENT     TaskQueueTrack = 1 // Task queue of the current worker fleet
+	TaskQueueTrack_TASK_QUEUE_TRACK_NEXT        TaskQueueTrack = 2 // Task queue of the fleet being cut over to; the current one while no cutover is configured
+)
+
+// Enum value maps for TaskQueueTrack.
+var (
+	TaskQueueTrack_name = map[int32]string{
+		0: "TASK_QUEUE_TRACK_UNSPECIFIED",
+		1: "TASK_QUEUE_TRACK_CURRENT",
+		2: "TASK_QUEUE_TRACK_NEXT",
+	}
+	TaskQueueTrack_value = map[string]int32{
+		"TASK_QUEUE_TRACK_UNSPECIFIED": 0,
+		"TASK_QUEUE_TRACK_CURRENT":     1,
+		"TASK_QUEUE_TRACK_NEXT":        2,
+	}
+)
+
+func (x TaskQueueTrack) Enum() *TaskQueueTrack {
+	p := new(TaskQueueTrack)
+	*p = x
+	return p
+}
+
+func (x TaskQueueTrack) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskQueueTrack) Descriptor() protoreflect.EnumDescriptor {
+	return file_flowngine_proto_enumTypes[2].Descriptor()
+}
+
+func (TaskQueueTrack) Type() protoreflect.EnumType {
+	return &file_flowngine_proto_enumTypes[2]
+}
+
+func (x TaskQueueTrack) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskQueueTrack.Descriptor instead.
+func (TaskQueueTrack) EnumDescriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{2}
+}
+
 // Transfer request message
+// Exactly one of amount or amount_decimal must be set.
 type ExecuteTransferRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FromAccount   string                 `protobuf:"bytes,1,opt,name=from_account,json=fromAccount,proto3" json:"from_account,omitempty"`
-	ToAccount     string                 `protobuf:"bytes,2,opt,name=to_account,json=toAccount,proto3" json:"to_account,omitempty"`
-	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
-	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	ReferenceId   string                 `protobuf:"bytes,6,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
-	RequestId     string                 `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FromAccount    string                 `protobuf:"bytes,1,opt,name=from_account,json=fromAccount,proto3" json:"from_account,omitempty"`
+	ToAccount      string                 `protobuf:"bytes,2,opt,name=to_account,json=toAccount,proto3" json:"to_account,omitempty"`
+	Amount         int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"` // Minor units of the currency, e.g. 10050 for 100.50 USD or 1050 for 1050 JPY
+	Currency       string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Description    string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	ReferenceId    string                 `protobuf:"bytes,6,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	RequestId      string                 `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`                                           // Identifies the transfer: a retry with the same request_id reports the transfer the first attempt started
+	AmountDecimal  string                 `protobuf:"bytes,8,opt,name=amount_decimal,json=amountDecimal,proto3" json:"amount_decimal,omitempty"`                               // Exact amount in major units, e.g. "100.50"; must fit the currency's decimal places
+	ExecutionMode  ExecutionMode          `protobuf:"varint,9,opt,name=execution_mode,json=executionMode,proto3,enum=pb.ExecutionMode" json:"execution_mode,omitempty"`        // Defaults to ASYNC
+	CallbackUrl    string                 `protobuf:"bytes,10,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`                                    // Receives a webhook when the transfer expires while awaiting approval, funds or its trigger
+	WaitForFunds   bool                   `protobuf:"varint,11,opt,name=wait_for_funds,json=waitForFunds,proto3" json:"wait_for_funds,omitempty"`                              // Wait for incoming credits instead of failing on insufficient funds; expires after a deadline
+	Trigger        *TransferTrigger       `protobuf:"bytes,12,opt,name=trigger,proto3" json:"trigger,omitempty"`                                                               // Start the transfer only once the trigger account has received the trigger amount
+	ToAlias        string                 `protobuf:"bytes,13,opt,name=to_alias,json=toAlias,proto3" json:"to_alias,omitempty"`                                                // Phone number (+14155550101) or email address registered in svc-balance; set instead of to_account
+	TaskQueueTrack TaskQueueTrack         `protobuf:"varint,14,opt,name=task_queue_track,json=taskQueueTrack,proto3,enum=pb.TaskQueueTrack" json:"task_queue_track,omitempty"` // Pins the transfer to the current or the next worker fleet during a task queue cutover
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ExecuteTransferRequest) Reset() {
@@ -177,16 +299,71 @@ func (x *ExecuteTransferRequest) GetRequestId() string {
 	return ""
 }
 
+func (x *ExecuteTransferRequest) GetAmountDecimal() string {
+	if x != nil {
+		return x.AmountDecimal
+	}
+	return ""
+}
+
+func (x *ExecuteTransferRequest) GetExecutionMode() ExecutionMode {
+	if x != nil {
+		return x.ExecutionMode
+	}
+	return ExecutionMode_EXECUTION_MODE_UNSPECIFIED
+}
+
+func (x *ExecuteTransferRequest) GetCallbackUrl() string {
+	if x != nil {
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+func (x *ExecuteTransferRequest) GetWaitForFunds() bool {
+	if x != nil {
+		return x.WaitForFunds
+	}
+	return false
+}
+
+func (x *ExecuteTransferRequest) GetTrigger() *TransferTrigger {
+	if x != nil {
+		return x.Trigger
+	}
+	return nil
+}
+
+func (x *ExecuteTransferRequest) GetToAlias() string {
+	if x != nil {
+		return x.ToAlias
+	}
+	return ""
+}
+
+func (x *ExecuteTransferRequest) GetTaskQueueTrack() TaskQueueTrack {
+	if x != nil {
+		return x.TaskQueueTrack
+	}
+	return TaskQueueTrack_TASK_QUEUE_TRACK_UNSPECIFIED
+}
+
 // Transfer response message
 type ExecuteTransferResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	Status        TransferStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=pb.TransferStatus" json:"status,omitempty"`
-	WorkflowId    string                 `protobuf:"bytes,3,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
-	RunId         string                 `protobuf:"bytes,4,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId     string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Status            TransferStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=pb.TransferStatus" json:"status,omitempty"`
+	WorkflowId        string                 `protobuf:"bytes,3,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	RunId             string                 `protobuf:"bytes,4,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TransferReference string                 `protobuf:"bytes,6,opt,name=transfer_reference,json=transferReference,proto3" json:"transfer_reference,omitempty"` // Human-friendly reference printed on receipts
+	// Set in SYNC mode once the workflow has finished
+	CompletedAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	ErrorMessage        string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	CompensationApplied bool                   `protobuf:"varint,9,opt,name=compensation_applied,json=compensationApplied,proto3" json:"compensation_applied,omitempty"`
+	Fx                  *TransferFX            `protobuf:"bytes,10,opt,name=fx,proto3" json:"fx,omitempty"` // Set when the credit was converted to the currency of the destination account
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *ExecuteTransferResponse) Reset() {
@@ -254,10 +431,46 @@ func (x *ExecuteTransferResponse) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *ExecuteTransferResponse) GetTransferReference() string {
+	if x != nil {
+		return x.TransferReference
+	}
+	return ""
+}
+
+func (x *ExecuteTransferResponse) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *ExecuteTransferResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ExecuteTransferResponse) GetCompensationApplied() bool {
+	if x != nil {
+		return x.CompensationApplied
+	}
+	return false
+}
+
+func (x *ExecuteTransferResponse) GetFx() *TransferFX {
+	if x != nil {
+		return x.Fx
+	}
+	return nil
+}
+
 // Status request message
 type GetTransferStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Transaction ID or transfer reference
+	WaitSeconds   int32                  `protobuf:"varint,2,opt,name=wait_seconds,json=waitSeconds,proto3" json:"wait_seconds,omitempty"`      // Block up to this many seconds (at most 30) for the transfer to reach a terminal state; 0 returns at once
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -299,6 +512,13 @@ func (x *GetTransferStatusRequest) GetTransactionId() string {
 	return ""
 }
 
+func (x *GetTransferStatusRequest) GetWaitSeconds() int32 {
+	if x != nil {
+		return x.WaitSeconds
+	}
+	return 0
+}
+
 // Status response message
 type GetTransferStatusResponse struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -306,7 +526,7 @@ type GetTransferStatusResponse struct {
 	Status            TransferStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=pb.TransferStatus" json:"status,omitempty"`
 	FromAccount       string                 `protobuf:"bytes,3,opt,name=from_account,json=fromAccount,proto3" json:"from_account,omitempty"`
 	ToAccount         string                 `protobuf:"bytes,4,opt,name=to_account,json=toAccount,proto3" json:"to_account,omitempty"`
-	Amount            int64                  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Amount            int64                  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"` // Minor units of the currency
 	Currency          string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
 	Description       string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
 	ReferenceId       string                 `protobuf:"bytes,8,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
@@ -314,6 +534,12 @@ type GetTransferStatusResponse struct {
 	CompletedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
 	WorkflowExecution *WorkflowExecution     `protobuf:"bytes,11,opt,name=workflow_execution,json=workflowExecution,proto3" json:"workflow_execution,omitempty"`
 	ErrorMessage      string                 `protobuf:"bytes,12,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	TransferReference string                 `protobuf:"bytes,13,opt,name=transfer_reference,json=transferReference,proto3" json:"transfer_reference,omitempty"`
+	AmountDecimal     string                 `protobuf:"bytes,14,opt,name=amount_decimal,json=amountDecimal,proto3" json:"amount_decimal,omitempty"` // Major units with the currency's decimal places, e.g. "100.50"
+	Steps             []*TransferStep        `protobuf:"bytes,15,rep,name=steps,proto3" json:"steps,omitempty"`                                      // Saga steps run so far, in order; empty for fast path transfers
+	SlaBreached       bool                   `protobuf:"varint,16,opt,name=sla_breached,json=slaBreached,proto3" json:"sla_breached,omitempty"`      // The transfer ran past its SLA; running transfers are reported as DELAYED
+	Wait              *TransferWait          `protobuf:"bytes,17,opt,name=wait,proto3" json:"wait,omitempty"`                                        // Set while the transfer is AWAITING_APPROVAL or AWAITING_FUNDS
+	Fx                *TransferFX            `protobuf:"bytes,18,opt,name=fx,proto3" json:"fx,omitempty"`                                            // Set when the credit was converted to the currency of the destination account
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -432,29 +658,80 @@ func (x *GetTransferStatusResponse) GetErrorMessage() string {
 	return ""
 }
 
-// Cancel request message
-type CancelTransferRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetTransferStatusResponse) GetTransferReference() string {
+	if x != nil {
+		return x.TransferReference
+	}
+	return ""
 }
 
-func (x *CancelTransferRequest) Reset() {
-	*x = CancelTransferRequest{}
+func (x *GetTransferStatusResponse) GetAmountDecimal() string {
+	if x != nil {
+		return x.AmountDecimal
+	}
+	return ""
+}
+
+func (x *GetTransferStatusResponse) GetSteps() []*TransferStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *GetTransferStatusResponse) GetSlaBreached() bool {
+	if x != nil {
+		return x.SlaBreached
+	}
+	return false
+}
+
+func (x *GetTransferStatusResponse) GetWait() *TransferWait {
+	if x != nil {
+		return x.Wait
+	}
+	return nil
+}
+
+func (x *GetTransferStatusResponse) GetFx() *TransferFX {
+	if x != nil {
+		return x.Fx
+	}
+	return nil
+}
+
+// Conversion applied to the credit of a transfer to an account in another currency. Rates are decimals.
+type TransferFX struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Pair                   string                 `protobuf:"bytes,1,opt,name=pair,proto3" json:"pair,omitempty"`                      // e.g. "EUR/USD"
+	Side                   string                 `protobuf:"bytes,2,opt,name=side,proto3" json:"side,omitempty"`                      // "BID" when selling the base currency, "ASK" when buying it
+	Rate                   string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`                      // Applied rate, converted currency per transfer currency
+	MidRate                string                 `protobuf:"bytes,4,opt,name=mid_rate,json=midRate,proto3" json:"mid_rate,omitempty"` // Quote currency per base currency
+	SpreadBps              int64                  `protobuf:"varint,5,opt,name=spread_bps,json=spreadBps,proto3" json:"spread_bps,omitempty"`
+	MarginBps              int64                  `protobuf:"varint,6,opt,name=margin_bps,json=marginBps,proto3" json:"margin_bps,omitempty"`
+	ConvertedCurrency      string                 `protobuf:"bytes,7,opt,name=converted_currency,json=convertedCurrency,proto3" json:"converted_currency,omitempty"`
+	ConvertedAmount        int64                  `protobuf:"varint,8,opt,name=converted_amount,json=convertedAmount,proto3" json:"converted_amount,omitempty"`                       // Minor units of converted_currency credited to the destination account
+	ConvertedAmountDecimal string                 `protobuf:"bytes,9,opt,name=converted_amount_decimal,json=convertedAmountDecimal,proto3" json:"converted_amount_decimal,omitempty"` // Major units with the currency's decimal places, e.g. "847.46"
+	SpreadAmount           int64                  `protobuf:"varint,10,opt,name=spread_amount,json=spreadAmount,proto3" json:"spread_amount,omitempty"`                               // Cost of the spread and margin in minor units of converted_currency
+	SpreadAmountDecimal    string                 `protobuf:"bytes,11,opt,name=spread_amount_decimal,json=spreadAmountDecimal,proto3" json:"spread_amount_decimal,omitempty"`         // Major units with the currency's decimal places, e.g. "2.54"
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *TransferFX) Reset() {
+	*x = TransferFX{}
 	mi := &file_flowngine_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelTransferRequest) String() string {
+func (x *TransferFX) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelTransferRequest) ProtoMessage() {}
+func (*TransferFX) ProtoMessage() {}
 
-func (x *CancelTransferRequest) ProtoReflect() protoreflect.Message {
+func (x *TransferFX) ProtoReflect() protoreflect.Message {
 	mi := &file_flowngine_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -466,103 +743,116 @@ func (x *CancelTransferRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelTransferRequest.ProtoReflect.Descriptor instead.
-func (*CancelTransferRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TransferFX.ProtoReflect.Descriptor instead.
+func (*TransferFX) Descriptor() ([]byte, []int) {
 	return file_flowngine_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CancelTransferRequest) GetTransactionId() string {
+func (x *TransferFX) GetPair() string {
 	if x != nil {
-		return x.TransactionId
+		return x.Pair
 	}
 	return ""
 }
 
-func (x *CancelTransferRequest) GetReason() string {
+func (x *TransferFX) GetSide() string {
 	if x != nil {
-		return x.Reason
+		return x.Side
 	}
 	return ""
 }
 
-// Cancel response message
-type CancelTransferResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *TransferFX) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
 }
 
-func (x *CancelTransferResponse) Reset() {
-	*x = CancelTransferResponse{}
-	mi := &file_flowngine_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *TransferFX) GetMidRate() string {
+	if x != nil {
+		return x.MidRate
+	}
+	return ""
 }
 
-func (x *CancelTransferResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *TransferFX) GetSpreadBps() int64 {
+	if x != nil {
+		return x.SpreadBps
+	}
+	return 0
 }
 
-func (*CancelTransferResponse) ProtoMessage() {}
+func (x *TransferFX) GetMarginBps() int64 {
+	if x != nil {
+		return x.MarginBps
+	}
+	return 0
+}
 
-func (x *CancelTransferResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_flowngine_proto_msgTypes[5]
+func (x *TransferFX) GetConvertedCurrency() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ConvertedCurrency
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use CancelTransferResponse.ProtoReflect.Descriptor instead.
-func (*CancelTransferResponse) Descriptor() ([]byte, []int) {
-	return file_flowngine_proto_rawDescGZIP(), []int{5}
+func (x *TransferFX) GetConvertedAmount() int64 {
+	if x != nil {
+		return x.ConvertedAmount
+	}
+	return 0
 }
 
-func (x *CancelTransferResponse) GetSuccess() bool {
+func (x *TransferFX) GetConvertedAmountDecimal() string {
 	if x != nil {
-		return x.Success
+		return x.ConvertedAmountDecimal
 	}
-	return false
+	return ""
 }
 
-func (x *CancelTransferResponse) GetMessage() string {
+func (x *TransferFX) GetSpreadAmount() int64 {
 	if x != nil {
-		return x.Message
+		return x.SpreadAmount
+	}
+	return 0
+}
+
+func (x *TransferFX) GetSpreadAmountDecimal() string {
+	if x != nil {
+		return x.SpreadAmountDecimal
 	}
 	return ""
 }
 
-// Workflow execution details
-type WorkflowExecution struct {
+// One activity of the transfer saga and how many attempts it took
+type TransferStep struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
-	RunId         string                 `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Activity      string                 `protobuf:"bytes,1,opt,name=activity,proto3" json:"activity,omitempty"`                    // Activity type, e.g. "DebitAccount"
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                        // "scheduled", "running", "retrying", "completed", "failed", "timed_out" or "cancelled"
+	Attempts      int32                  `protobuf:"varint,3,opt,name=attempts,proto3" json:"attempts,omitempty"`                   // Attempts made so far, including the current one
+	LastError     string                 `protobuf:"bytes,4,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"` // Failure of the latest failed attempt, if any
+	Compensation  bool                   `protobuf:"varint,5,opt,name=compensation,proto3" json:"compensation,omitempty"`           // Undoes an earlier step
+	Summary       string                 `protobuf:"bytes,6,opt,name=summary,proto3" json:"summary,omitempty"`                      // e.g. "DebitAccount completed on attempt 3 after: simulated failure"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *WorkflowExecution) Reset() {
-	*x = WorkflowExecution{}
-	mi := &file_flowngine_proto_msgTypes[6]
+func (x *TransferStep) Reset() {
+	*x = TransferStep{}
+	mi := &file_flowngine_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *WorkflowExecution) String() string {
+func (x *TransferStep) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WorkflowExecution) ProtoMessage() {}
+func (*TransferStep) ProtoMessage() {}
 
-func (x *WorkflowExecution) ProtoReflect() protoreflect.Message {
-	mi := &file_flowngine_proto_msgTypes[6]
+func (x *TransferStep) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -573,37 +863,3723 @@ func (x *WorkflowExecution) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WorkflowExecution.ProtoReflect.Descriptor instead.
-func (*WorkflowExecution) Descriptor() ([]byte, []int) {
-	return file_flowngine_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use TransferStep.ProtoReflect.Descriptor instead.
+func (*TransferStep) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *WorkflowExecution) GetWorkflowId() string {
+func (x *TransferStep) GetActivity() string {
 	if x != nil {
-		return x.WorkflowId
+		return x.Activity
 	}
 	return ""
 }
 
-func (x *WorkflowExecution) GetRunId() string {
+func (x *TransferStep) GetStatus() string {
 	if x != nil {
-		return x.RunId
+		return x.Status
 	}
 	return ""
 }
 
-func (x *WorkflowExecution) GetStatus() string {
+func (x *TransferStep) GetAttempts() int32 {
 	if x != nil {
-		return x.Status
+		return x.Attempts
 	}
-	return ""
+	return 0
+}
+
+func (x *TransferStep) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *TransferStep) GetCompensation() bool {
+	if x != nil {
+		return x.Compensation
+	}
+	return false
+}
+
+func (x *TransferStep) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+// Batch status request message
+type GetTransferStatusesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TransactionIds []string               `protobuf:"bytes,1,rep,name=transaction_ids,json=transactionIds,proto3" json:"transaction_ids,omitempty"` // Transaction IDs or transfer references, at most 100
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetTransferStatusesRequest) Reset() {
+	*x = GetTransferStatusesRequest{}
+	mi := &file_flowngine_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferStatusesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferStatusesRequest) ProtoMessage() {}
+
+func (x *GetTransferStatusesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferStatusesRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferStatusesRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetTransferStatusesRequest) GetTransactionIds() []string {
+	if x != nil {
+		return x.TransactionIds
+	}
+	return nil
+}
+
+// Batch status response message
+type GetTransferStatusesResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Results       []*TransferStatusResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // One per requested ID, in request order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferStatusesResponse) Reset() {
+	*x = GetTransferStatusesResponse{}
+	mi := &file_flowngine_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferStatusesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferStatusesResponse) ProtoMessage() {}
+
+func (x *GetTransferStatusesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferStatusesResponse.ProtoReflect.Descriptor instead.
+func (*GetTransferStatusesResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetTransferStatusesResponse) GetResults() []*TransferStatusResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Status of one transfer of a batch status request; exactly one of transfer or error is set
+type TransferStatusResult struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	RequestedId   string                     `protobuf:"bytes,1,opt,name=requested_id,json=requestedId,proto3" json:"requested_id,omitempty"` // As given in transaction_ids
+	Transfer      *GetTransferStatusResponse `protobuf:"bytes,2,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	Error         *ErrorDetail               `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"` // Why the transfer could not be looked up, e.g. TRANSFER_NOT_FOUND
+	ErrorMessage  string                     `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferStatusResult) Reset() {
+	*x = TransferStatusResult{}
+	mi := &file_flowngine_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferStatusResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferStatusResult) ProtoMessage() {}
+
+func (x *TransferStatusResult) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferStatusResult.ProtoReflect.Descriptor instead.
+func (*TransferStatusResult) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TransferStatusResult) GetRequestedId() string {
+	if x != nil {
+		return x.RequestedId
+	}
+	return ""
+}
+
+func (x *TransferStatusResult) GetTransfer() *GetTransferStatusResponse {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (x *TransferStatusResult) GetError() *ErrorDetail {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *TransferStatusResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// Cancel request message
+type CancelTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTransferRequest) Reset() {
+	*x = CancelTransferRequest{}
+	mi := &file_flowngine_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTransferRequest) ProtoMessage() {}
+
+func (x *CancelTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTransferRequest.ProtoReflect.Descriptor instead.
+func (*CancelTransferRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancelTransferRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *CancelTransferRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Cancel response message
+type CancelTransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTransferResponse) Reset() {
+	*x = CancelTransferResponse{}
+	mi := &file_flowngine_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTransferResponse) ProtoMessage() {}
+
+func (x *CancelTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTransferResponse.ProtoReflect.Descriptor instead.
+func (*CancelTransferResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CancelTransferResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelTransferResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Timeline request message
+type GetTransferTimelineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Transaction ID or transfer reference
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferTimelineRequest) Reset() {
+	*x = GetTransferTimelineRequest{}
+	mi := &file_flowngine_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferTimelineRequest) ProtoMessage() {}
+
+func (x *GetTransferTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferTimelineRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetTransferTimelineRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+// Timeline response message
+type GetTransferTimelineResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	WorkflowId    string                 `protobuf:"bytes,2,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	RunId         string                 `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Events        []*TimelineEvent       `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"` // Oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferTimelineResponse) Reset() {
+	*x = GetTransferTimelineResponse{}
+	mi := &file_flowngine_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferTimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferTimelineResponse) ProtoMessage() {}
+
+func (x *GetTransferTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferTimelineResponse.ProtoReflect.Descriptor instead.
+func (*GetTransferTimelineResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTransferTimelineResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *GetTransferTimelineResponse) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *GetTransferTimelineResponse) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *GetTransferTimelineResponse) GetEvents() []*TimelineEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// One step of a transfer workflow
+type TimelineEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       int64                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"` // ID of the Temporal history event
+	Time          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`                  // e.g. "transfer_started", "activity_completed", "activity_retried"
+	Activity      string                 `protobuf:"bytes,4,opt,name=activity,proto3" json:"activity,omitempty"`          // Activity type, e.g. "DebitAccount"; empty for workflow-level events
+	Attempt       int32                  `protobuf:"varint,5,opt,name=attempt,proto3" json:"attempt,omitempty"`           // Activity attempt the event belongs to
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`            // Human-readable description, including failure messages
+	Compensation  bool                   `protobuf:"varint,7,opt,name=compensation,proto3" json:"compensation,omitempty"` // Part of undoing an earlier step
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimelineEvent) Reset() {
+	*x = TimelineEvent{}
+	mi := &file_flowngine_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimelineEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimelineEvent) ProtoMessage() {}
+
+func (x *TimelineEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimelineEvent.ProtoReflect.Descriptor instead.
+func (*TimelineEvent) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *TimelineEvent) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *TimelineEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *TimelineEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetActivity() string {
+	if x != nil {
+		return x.Activity
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *TimelineEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TimelineEvent) GetCompensation() bool {
+	if x != nil {
+		return x.Compensation
+	}
+	return false
+}
+
+// Narrows compensation audit queries; unset fields leave that dimension unfiltered
+type CompensationFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // pending, completed, failed, timeout or manual_required
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`     // debit_reversal, credit_reversal or manual_adjustment
+	From          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`     // Inclusive lower bound on created_at
+	To            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`         // Exclusive upper bound on created_at
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompensationFilter) Reset() {
+	*x = CompensationFilter{}
+	mi := &file_flowngine_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompensationFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompensationFilter) ProtoMessage() {}
+
+func (x *CompensationFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompensationFilter.ProtoReflect.Descriptor instead.
+func (*CompensationFilter) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CompensationFilter) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CompensationFilter) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CompensationFilter) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *CompensationFilter) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// List compensations request message
+type ListCompensationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *CompensationFilter    `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	WorkflowId    string                 `protobuf:"bytes,2,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to 50, at most 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCompensationsRequest) Reset() {
+	*x = ListCompensationsRequest{}
+	mi := &file_flowngine_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCompensationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCompensationsRequest) ProtoMessage() {}
+
+func (x *ListCompensationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCompensationsRequest.ProtoReflect.Descriptor instead.
+func (*ListCompensationsRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListCompensationsRequest) GetFilter() *CompensationFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListCompensationsRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *ListCompensationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// List compensations response message
+type ListCompensationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Compensations []*CompensationRecord  `protobuf:"bytes,1,rep,name=compensations,proto3" json:"compensations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCompensationsResponse) Reset() {
+	*x = ListCompensationsResponse{}
+	mi := &file_flowngine_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCompensationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCompensationsResponse) ProtoMessage() {}
+
+func (x *ListCompensationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCompensationsResponse.ProtoReflect.Descriptor instead.
+func (*ListCompensationsResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListCompensationsResponse) GetCompensations() []*CompensationRecord {
+	if x != nil {
+		return x.Compensations
+	}
+	return nil
+}
+
+// One compensation audit record
+type CompensationRecord struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	Id                        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WorkflowId                string                 `protobuf:"bytes,2,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	RunId                     string                 `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	TransferId                string                 `protobuf:"bytes,4,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	OriginalTransactionId     string                 `protobuf:"bytes,5,opt,name=original_transaction_id,json=originalTransactionId,proto3" json:"original_transaction_id,omitempty"`
+	CompensationTransactionId string                 `protobuf:"bytes,6,opt,name=compensation_transaction_id,json=compensationTransactionId,proto3" json:"compensation_transaction_id,omitempty"`
+	Reason                    string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	Type                      string                 `protobuf:"bytes,8,opt,name=type,proto3" json:"type,omitempty"`
+	Status                    string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts                  int32                  `protobuf:"varint,10,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	CreatedAt                 *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt                 *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CompletedAt               *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"` // Unset while the compensation is pending
+	FailureReason             string                 `protobuf:"bytes,14,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	TimeoutDurationMs         int32                  `protobuf:"varint,15,opt,name=timeout_duration_ms,json=timeoutDurationMs,proto3" json:"timeout_duration_ms,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *CompensationRecord) Reset() {
+	*x = CompensationRecord{}
+	mi := &file_flowngine_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompensationRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompensationRecord) ProtoMessage() {}
+
+func (x *CompensationRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompensationRecord.ProtoReflect.Descriptor instead.
+func (*CompensationRecord) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CompensationRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CompensationRecord) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *CompensationRecord) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *CompensationRecord) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *CompensationRecord) GetOriginalTransactionId() string {
+	if x != nil {
+		return x.OriginalTransactionId
+	}
+	return ""
+}
+
+func (x *CompensationRecord) GetCompensationTransactionId() string {
+	if x != nil {
+		return x.CompensationTransactionId
+	}
+	return ""
+}
+
+func (x *CompensationRecord) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CompensationRecord) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CompensationRecord) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CompensationRecord) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *CompensationRecord) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CompensationRecord) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *CompensationRecord) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *CompensationRecord) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *CompensationRecord) GetTimeoutDurationMs() int32 {
+	if x != nil {
+		return x.TimeoutDurationMs
+	}
+	return 0
+}
+
+// Compensation stats request message
+type GetCompensationStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *CompensationFilter    `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"` // Without from, the last 24 hours are covered
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCompensationStatsRequest) Reset() {
+	*x = GetCompensationStatsRequest{}
+	mi := &file_flowngine_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCompensationStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCompensationStatsRequest) ProtoMessage() {}
+
+func (x *GetCompensationStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCompensationStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetCompensationStatsRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetCompensationStatsRequest) GetFilter() *CompensationFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// Compensation stats response message
+type GetCompensationStatsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Total           int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Completed       int64                  `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
+	Failed          int64                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Timeout         int64                  `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	ManualRequired  int64                  `protobuf:"varint,5,opt,name=manual_required,json=manualRequired,proto3" json:"manual_required,omitempty"`
+	Pending         int64                  `protobuf:"varint,6,opt,name=pending,proto3" json:"pending,omitempty"`
+	AverageAttempts float64                `protobuf:"fixed64,7,opt,name=average_attempts,json=averageAttempts,proto3" json:"average_attempts,omitempty"`
+	From            *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=from,proto3" json:"from,omitempty"` // Range actually covered
+	To              *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetCompensationStatsResponse) Reset() {
+	*x = GetCompensationStatsResponse{}
+	mi := &file_flowngine_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCompensationStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCompensationStatsResponse) ProtoMessage() {}
+
+func (x *GetCompensationStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCompensationStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetCompensationStatsResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetCompensationStatsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetCompensationStatsResponse) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *GetCompensationStatsResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *GetCompensationStatsResponse) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *GetCompensationStatsResponse) GetManualRequired() int64 {
+	if x != nil {
+		return x.ManualRequired
+	}
+	return 0
+}
+
+func (x *GetCompensationStatsResponse) GetPending() int64 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *GetCompensationStatsResponse) GetAverageAttempts() float64 {
+	if x != nil {
+		return x.AverageAttempts
+	}
+	return 0
+}
+
+func (x *GetCompensationStatsResponse) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetCompensationStatsResponse) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// Account workflows request message
+type ListAccountWorkflowsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	AccountNumber string                 `protobuf:"bytes,2,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"` // Optional, transfers started with the account number are listed too
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountWorkflowsRequest) Reset() {
+	*x = ListAccountWorkflowsRequest{}
+	mi := &file_flowngine_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountWorkflowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountWorkflowsRequest) ProtoMessage() {}
+
+func (x *ListAccountWorkflowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountWorkflowsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountWorkflowsRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListAccountWorkflowsRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ListAccountWorkflowsRequest) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+// Account workflows response message
+type ListAccountWorkflowsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Transfers     []*InFlightTransfer    `protobuf:"bytes,2,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	Pending       []*PendingAmount       `protobuf:"bytes,3,rep,name=pending,proto3" json:"pending,omitempty"`      // One entry per currency
+	Truncated     bool                   `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"` // Too many running transfers to list them all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountWorkflowsResponse) Reset() {
+	*x = ListAccountWorkflowsResponse{}
+	mi := &file_flowngine_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountWorkflowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountWorkflowsResponse) ProtoMessage() {}
+
+func (x *ListAccountWorkflowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountWorkflowsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountWorkflowsResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListAccountWorkflowsResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ListAccountWorkflowsResponse) GetTransfers() []*InFlightTransfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+func (x *ListAccountWorkflowsResponse) GetPending() []*PendingAmount {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+func (x *ListAccountWorkflowsResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// A running transfer workflow that debits or credits an account
+type InFlightTransfer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	RunId         string                 `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	TransactionId string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Direction     string                 `protobuf:"bytes,4,opt,name=direction,proto3" json:"direction,omitempty"` // "outgoing" or "incoming", relative to the requested account
+	FromAccount   string                 `protobuf:"bytes,5,opt,name=from_account,json=fromAccount,proto3" json:"from_account,omitempty"`
+	ToAccount     string                 `protobuf:"bytes,6,opt,name=to_account,json=toAccount,proto3" json:"to_account,omitempty"`
+	Amount        string                 `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"` // Exact major-unit decimal, e.g. "100.50"
+	Currency      string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InFlightTransfer) Reset() {
+	*x = InFlightTransfer{}
+	mi := &file_flowngine_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InFlightTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InFlightTransfer) ProtoMessage() {}
+
+func (x *InFlightTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InFlightTransfer.ProtoReflect.Descriptor instead.
+func (*InFlightTransfer) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *InFlightTransfer) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *InFlightTransfer) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *InFlightTransfer) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *InFlightTransfer) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *InFlightTransfer) GetFromAccount() string {
+	if x != nil {
+		return x.FromAccount
+	}
+	return ""
+}
+
+func (x *InFlightTransfer) GetToAccount() string {
+	if x != nil {
+		return x.ToAccount
+	}
+	return ""
+}
+
+func (x *InFlightTransfer) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *InFlightTransfer) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *InFlightTransfer) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+// Sum of the running transfers of one currency
+type PendingAmount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Outgoing      string                 `protobuf:"bytes,2,opt,name=outgoing,proto3" json:"outgoing,omitempty"` // Exact major-unit decimal
+	Incoming      string                 `protobuf:"bytes,3,opt,name=incoming,proto3" json:"incoming,omitempty"` // Exact major-unit decimal
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingAmount) Reset() {
+	*x = PendingAmount{}
+	mi := &file_flowngine_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingAmount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingAmount) ProtoMessage() {}
+
+func (x *PendingAmount) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingAmount.ProtoReflect.Descriptor instead.
+func (*PendingAmount) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PendingAmount) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PendingAmount) GetOutgoing() string {
+	if x != nil {
+		return x.Outgoing
+	}
+	return ""
+}
+
+func (x *PendingAmount) GetIncoming() string {
+	if x != nil {
+		return x.Incoming
+	}
+	return ""
+}
+
+// List admin audit request message; unset fields leave that dimension unfiltered
+type ListAdminAuditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actor         string                 `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"` // e.g. balance_alert.set_enabled or account.closure.start
+	ResourceType  string                 `protobuf:"bytes,3,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceId    string                 `protobuf:"bytes,4,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`    // Inclusive lower bound on created_at
+	To            *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=to,proto3" json:"to,omitempty"`        // Exclusive upper bound on created_at
+	Limit         int32                  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"` // Defaults to 50, at most 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAdminAuditRequest) Reset() {
+	*x = ListAdminAuditRequest{}
+	mi := &file_flowngine_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAdminAuditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAdminAuditRequest) ProtoMessage() {}
+
+func (x *ListAdminAuditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAdminAuditRequest.ProtoReflect.Descriptor instead.
+func (*ListAdminAuditRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListAdminAuditRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ListAdminAuditRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ListAdminAuditRequest) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *ListAdminAuditRequest) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *ListAdminAuditRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListAdminAuditRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListAdminAuditRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// List admin audit response message
+type ListAdminAuditResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*AdminAuditRecord    `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAdminAuditResponse) Reset() {
+	*x = ListAdminAuditResponse{}
+	mi := &file_flowngine_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAdminAuditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAdminAuditResponse) ProtoMessage() {}
+
+func (x *ListAdminAuditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAdminAuditResponse.ProtoReflect.Descriptor instead.
+func (*ListAdminAuditResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListAdminAuditResponse) GetRecords() []*AdminAuditRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+// One recorded admin call
+type AdminAuditRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Service       string                 `protobuf:"bytes,4,opt,name=service,proto3" json:"service,omitempty"` // Service that handled the call
+	ResourceType  string                 `protobuf:"bytes,5,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceId    string                 `protobuf:"bytes,6,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	Before        string                 `protobuf:"bytes,7,opt,name=before,proto3" json:"before,omitempty"` // JSON state before the call, empty when there was none
+	After         string                 `protobuf:"bytes,8,opt,name=after,proto3" json:"after,omitempty"`   // JSON state after the call, empty when there is none
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminAuditRecord) Reset() {
+	*x = AdminAuditRecord{}
+	mi := &file_flowngine_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminAuditRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminAuditRecord) ProtoMessage() {}
+
+func (x *AdminAuditRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminAuditRecord.ProtoReflect.Descriptor instead.
+func (*AdminAuditRecord) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AdminAuditRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AdminAuditRecord) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AdminAuditRecord) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AdminAuditRecord) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *AdminAuditRecord) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *AdminAuditRecord) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *AdminAuditRecord) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *AdminAuditRecord) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *AdminAuditRecord) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Transfer SLA stats request message
+type GetTransferSLAStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferSLAStatsRequest) Reset() {
+	*x = GetTransferSLAStatsRequest{}
+	mi := &file_flowngine_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferSLAStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferSLAStatsRequest) ProtoMessage() {}
+
+func (x *GetTransferSLAStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferSLAStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferSLAStatsRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{27}
+}
+
+// Transfer SLA stats response message; counts cover the workflows still in Temporal visibility
+type GetTransferSLAStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SlaSeconds    int32                  `protobuf:"varint,1,opt,name=sla_seconds,json=slaSeconds,proto3" json:"sla_seconds,omitempty"` // SLA applied to new transfers, 0 when tracking is disabled
+	Breached      int64                  `protobuf:"varint,2,opt,name=breached,proto3" json:"breached,omitempty"`
+	Delayed       int64                  `protobuf:"varint,3,opt,name=delayed,proto3" json:"delayed,omitempty"` // Breached and still running
+	Aborted       int64                  `protobuf:"varint,4,opt,name=aborted,proto3" json:"aborted,omitempty"` // Breached and stopped, with any debit reversed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferSLAStatsResponse) Reset() {
+	*x = GetTransferSLAStatsResponse{}
+	mi := &file_flowngine_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferSLAStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferSLAStatsResponse) ProtoMessage() {}
+
+func (x *GetTransferSLAStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferSLAStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetTransferSLAStatsResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetTransferSLAStatsResponse) GetSlaSeconds() int32 {
+	if x != nil {
+		return x.SlaSeconds
+	}
+	return 0
+}
+
+func (x *GetTransferSLAStatsResponse) GetBreached() int64 {
+	if x != nil {
+		return x.Breached
+	}
+	return 0
+}
+
+func (x *GetTransferSLAStatsResponse) GetDelayed() int64 {
+	if x != nil {
+		return x.Delayed
+	}
+	return 0
+}
+
+func (x *GetTransferSLAStatsResponse) GetAborted() int64 {
+	if x != nil {
+		return x.Aborted
+	}
+	return 0
+}
+
+// Workflow execution details
+type WorkflowExecution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkflowId    string                 `protobuf:"bytes,1,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	RunId         string                 `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowExecution) Reset() {
+	*x = WorkflowExecution{}
+	mi := &file_flowngine_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowExecution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowExecution) ProtoMessage() {}
+
+func (x *WorkflowExecution) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowExecution.ProtoReflect.Descriptor instead.
+func (*WorkflowExecution) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *WorkflowExecution) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *WorkflowExecution) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *WorkflowExecution) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// Error detail attached to the status of failed calls, so clients do not have to parse status messages
+type ErrorDetail struct {
+	state           protoimpl.MessageState        `protogen:"open.v1"`
+	Code            string                        `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`                                              // Machine-readable error code, e.g. INVALID_PARAMETERS or TRANSFER_NOT_FOUND
+	FieldViolations []*ErrorDetail_FieldViolation `protobuf:"bytes,2,rep,name=field_violations,json=fieldViolations,proto3" json:"field_violations,omitempty"` // Request fields rejected by validation
+	Retryable       bool                          `protobuf:"varint,3,opt,name=retryable,proto3" json:"retryable,omitempty"`                                   // Whether the same request may succeed when retried later
+	TransactionId   string                        `protobuf:"bytes,4,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`       // Existing transfer a TRANSFER_ALREADY_EXISTS request conflicts with
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ErrorDetail) Reset() {
+	*x = ErrorDetail{}
+	mi := &file_flowngine_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorDetail) ProtoMessage() {}
+
+func (x *ErrorDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorDetail.ProtoReflect.Descriptor instead.
+func (*ErrorDetail) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ErrorDetail) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ErrorDetail) GetFieldViolations() []*ErrorDetail_FieldViolation {
+	if x != nil {
+		return x.FieldViolations
+	}
+	return nil
+}
+
+func (x *ErrorDetail) GetRetryable() bool {
+	if x != nil {
+		return x.Retryable
+	}
+	return false
+}
+
+func (x *ErrorDetail) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+// Approve request message
+type ApproveTransferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Transaction ID or transfer reference
+	ApprovedBy    string                 `protobuf:"bytes,2,opt,name=approved_by,json=approvedBy,proto3" json:"approved_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveTransferRequest) Reset() {
+	*x = ApproveTransferRequest{}
+	mi := &file_flowngine_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveTransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveTransferRequest) ProtoMessage() {}
+
+func (x *ApproveTransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveTransferRequest.ProtoReflect.Descriptor instead.
+func (*ApproveTransferRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ApproveTransferRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ApproveTransferRequest) GetApprovedBy() string {
+	if x != nil {
+		return x.ApprovedBy
+	}
+	return ""
+}
+
+// Approve response message
+type ApproveTransferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveTransferResponse) Reset() {
+	*x = ApproveTransferResponse{}
+	mi := &file_flowngine_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveTransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveTransferResponse) ProtoMessage() {}
+
+func (x *ApproveTransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveTransferResponse.ProtoReflect.Descriptor instead.
+func (*ApproveTransferResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ApproveTransferResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ApproveTransferResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// What a running transfer is waiting for before its debit
+type TransferWait struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Reason         string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"` // "approval", "funds" or "trigger"
+	Since          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	Deadline       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=deadline,proto3" json:"deadline,omitempty"`                                   // The transfer expires when nothing changed by then; unset when it never expires
+	BalanceChecks  int32                  `protobuf:"varint,4,opt,name=balance_checks,json=balanceChecks,proto3" json:"balance_checks,omitempty"`   // Balance checks made while waiting for funds
+	NextCheckAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=next_check_at,json=nextCheckAt,proto3" json:"next_check_at,omitempty"`        // Next balance check while waiting for funds
+	TriggerAccount string                 `protobuf:"bytes,6,opt,name=trigger_account,json=triggerAccount,proto3" json:"trigger_account,omitempty"` // Account watched while waiting for the trigger
+	AmountReceived string                 `protobuf:"bytes,7,opt,name=amount_received,json=amountReceived,proto3" json:"amount_received,omitempty"` // Major units credited to the trigger account so far, in the transfer currency
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TransferWait) Reset() {
+	*x = TransferWait{}
+	mi := &file_flowngine_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferWait) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferWait) ProtoMessage() {}
+
+func (x *TransferWait) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferWait.ProtoReflect.Descriptor instead.
+func (*TransferWait) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TransferWait) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TransferWait) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *TransferWait) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *TransferWait) GetBalanceChecks() int32 {
+	if x != nil {
+		return x.BalanceChecks
+	}
+	return 0
+}
+
+func (x *TransferWait) GetNextCheckAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextCheckAt
+	}
+	return nil
+}
+
+func (x *TransferWait) GetTriggerAccount() string {
+	if x != nil {
+		return x.TriggerAccount
+	}
+	return ""
+}
+
+func (x *TransferWait) GetAmountReceived() string {
+	if x != nil {
+		return x.AmountReceived
+	}
+	return ""
+}
+
+// Condition of a conditional transfer: when account receives at least amount_decimal, the transfer is executed
+type TransferTrigger struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	AmountDecimal string                 `protobuf:"bytes,2,opt,name=amount_decimal,json=amountDecimal,proto3" json:"amount_decimal,omitempty"` // Major units in the transfer currency, summed over the credits received by account
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferTrigger) Reset() {
+	*x = TransferTrigger{}
+	mi := &file_flowngine_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferTrigger) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferTrigger) ProtoMessage() {}
+
+func (x *TransferTrigger) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferTrigger.ProtoReflect.Descriptor instead.
+func (*TransferTrigger) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TransferTrigger) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *TransferTrigger) GetAmountDecimal() string {
+	if x != nil {
+		return x.AmountDecimal
+	}
+	return ""
+}
+
+// Batch request message
+// The batch is rejected as a whole when any transfer is invalid.
+type StartTransferBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Transfers     []*TransferBatchItem   `protobuf:"bytes,2,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartTransferBatchRequest) Reset() {
+	*x = StartTransferBatchRequest{}
+	mi := &file_flowngine_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartTransferBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartTransferBatchRequest) ProtoMessage() {}
+
+func (x *StartTransferBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartTransferBatchRequest.ProtoReflect.Descriptor instead.
+func (*StartTransferBatchRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *StartTransferBatchRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *StartTransferBatchRequest) GetTransfers() []*TransferBatchItem {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+// One transfer of a batch; exactly one of amount or amount_decimal must be set
+type TransferBatchItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"` // Identifies the transfer to the caller, e.g. its line in an uploaded file
+	FromAccount   string                 `protobuf:"bytes,2,opt,name=from_account,json=fromAccount,proto3" json:"from_account,omitempty"`
+	ToAccount     string                 `protobuf:"bytes,3,opt,name=to_account,json=toAccount,proto3" json:"to_account,omitempty"`
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`                                   // Minor units of the currency
+	AmountDecimal string                 `protobuf:"bytes,5,opt,name=amount_decimal,json=amountDecimal,proto3" json:"amount_decimal,omitempty"` // Exact amount in major units, e.g. "100.50"
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	ReferenceId   string                 `protobuf:"bytes,8,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferBatchItem) Reset() {
+	*x = TransferBatchItem{}
+	mi := &file_flowngine_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferBatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferBatchItem) ProtoMessage() {}
+
+func (x *TransferBatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferBatchItem.ProtoReflect.Descriptor instead.
+func (*TransferBatchItem) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *TransferBatchItem) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *TransferBatchItem) GetFromAccount() string {
+	if x != nil {
+		return x.FromAccount
+	}
+	return ""
+}
+
+func (x *TransferBatchItem) GetToAccount() string {
+	if x != nil {
+		return x.ToAccount
+	}
+	return ""
+}
+
+func (x *TransferBatchItem) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *TransferBatchItem) GetAmountDecimal() string {
+	if x != nil {
+		return x.AmountDecimal
+	}
+	return ""
+}
+
+func (x *TransferBatchItem) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TransferBatchItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TransferBatchItem) GetReferenceId() string {
+	if x != nil {
+		return x.ReferenceId
+	}
+	return ""
+}
+
+// Batch response message
+type StartTransferBatchResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	BatchId           string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	WorkflowExecution *WorkflowExecution     `protobuf:"bytes,2,opt,name=workflow_execution,json=workflowExecution,proto3" json:"workflow_execution,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Rows              []*TransferBatchRow    `protobuf:"bytes,4,rep,name=rows,proto3" json:"rows,omitempty"` // Every transfer as PENDING, with the transaction ID it runs under
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StartTransferBatchResponse) Reset() {
+	*x = StartTransferBatchResponse{}
+	mi := &file_flowngine_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartTransferBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartTransferBatchResponse) ProtoMessage() {}
+
+func (x *StartTransferBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartTransferBatchResponse.ProtoReflect.Descriptor instead.
+func (*StartTransferBatchResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *StartTransferBatchResponse) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *StartTransferBatchResponse) GetWorkflowExecution() *WorkflowExecution {
+	if x != nil {
+		return x.WorkflowExecution
+	}
+	return nil
+}
+
+func (x *StartTransferBatchResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *StartTransferBatchResponse) GetRows() []*TransferBatchRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+// Outcome of one transfer of a batch
+type TransferBatchRow struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Row               int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	TransactionId     string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	TransferReference string                 `protobuf:"bytes,3,opt,name=transfer_reference,json=transferReference,proto3" json:"transfer_reference,omitempty"`
+	Status            TransferStatus         `protobuf:"varint,4,opt,name=status,proto3,enum=pb.TransferStatus" json:"status,omitempty"`
+	ErrorMessage      string                 `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TransferBatchRow) Reset() {
+	*x = TransferBatchRow{}
+	mi := &file_flowngine_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferBatchRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferBatchRow) ProtoMessage() {}
+
+func (x *TransferBatchRow) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferBatchRow.ProtoReflect.Descriptor instead.
+func (*TransferBatchRow) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *TransferBatchRow) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *TransferBatchRow) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *TransferBatchRow) GetTransferReference() string {
+	if x != nil {
+		return x.TransferReference
+	}
+	return ""
+}
+
+func (x *TransferBatchRow) GetStatus() TransferStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TransferStatus_TRANSFER_STATUS_UNSPECIFIED
+}
+
+func (x *TransferBatchRow) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// Batch progress request message
+type GetTransferBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BatchId       string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransferBatchRequest) Reset() {
+	*x = GetTransferBatchRequest{}
+	mi := &file_flowngine_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferBatchRequest) ProtoMessage() {}
+
+func (x *GetTransferBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetTransferBatchRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetTransferBatchRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+// Batch progress response message
+type GetTransferBatchResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	BatchId           string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	WorkflowExecution *WorkflowExecution     `protobuf:"bytes,2,opt,name=workflow_execution,json=workflowExecution,proto3" json:"workflow_execution,omitempty"` // Status is RUNNING until every transfer of the batch has finished
+	Total             int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Pending           int32                  `protobuf:"varint,4,opt,name=pending,proto3" json:"pending,omitempty"` // Not started yet
+	Processing        int32                  `protobuf:"varint,5,opt,name=processing,proto3" json:"processing,omitempty"`
+	Completed         int32                  `protobuf:"varint,6,opt,name=completed,proto3" json:"completed,omitempty"`
+	Failed            int32                  `protobuf:"varint,7,opt,name=failed,proto3" json:"failed,omitempty"` // Failed, compensated or expired
+	Rows              []*TransferBatchRow    `protobuf:"bytes,8,rep,name=rows,proto3" json:"rows,omitempty"`
+	StartedAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetTransferBatchResponse) Reset() {
+	*x = GetTransferBatchResponse{}
+	mi := &file_flowngine_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransferBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransferBatchResponse) ProtoMessage() {}
+
+func (x *GetTransferBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransferBatchResponse.ProtoReflect.Descriptor instead.
+func (*GetTransferBatchResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetTransferBatchResponse) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *GetTransferBatchResponse) GetWorkflowExecution() *WorkflowExecution {
+	if x != nil {
+		return x.WorkflowExecution
+	}
+	return nil
+}
+
+func (x *GetTransferBatchResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetTransferBatchResponse) GetPending() int32 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *GetTransferBatchResponse) GetProcessing() int32 {
+	if x != nil {
+		return x.Processing
+	}
+	return 0
+}
+
+func (x *GetTransferBatchResponse) GetCompleted() int32 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *GetTransferBatchResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *GetTransferBatchResponse) GetRows() []*TransferBatchRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *GetTransferBatchResponse) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *GetTransferBatchResponse) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+// Pending transaction stats request message
+type GetPendingTransactionStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPendingTransactionStatsRequest) Reset() {
+	*x = GetPendingTransactionStatsRequest{}
+	mi := &file_flowngine_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPendingTransactionStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPendingTransactionStatsRequest) ProtoMessage() {}
+
+func (x *GetPendingTransactionStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPendingTransactionStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetPendingTransactionStatsRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{41}
+}
+
+// Pending transaction stats response message; sweep counts cover the svc-transaction instance that answered since it started
+type GetPendingTransactionStatsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Pending           int64                  `protobuf:"varint,1,opt,name=pending,proto3" json:"pending,omitempty"`
+	Stale             int64                  `protobuf:"varint,2,opt,name=stale,proto3" json:"stale,omitempty"` // Pending for longer than stale_after_seconds, waiting for the next sweep
+	StaleAfterSeconds int64                  `protobuf:"varint,3,opt,name=stale_after_seconds,json=staleAfterSeconds,proto3" json:"stale_after_seconds,omitempty"`
+	SweepRuns         int64                  `protobuf:"varint,4,opt,name=sweep_runs,json=sweepRuns,proto3" json:"sweep_runs,omitempty"`
+	SweptCompleted    int64                  `protobuf:"varint,5,opt,name=swept_completed,json=sweptCompleted,proto3" json:"swept_completed,omitempty"` // Completed because their balance change had been applied
+	SweptFailed       int64                  `protobuf:"varint,6,opt,name=swept_failed,json=sweptFailed,proto3" json:"swept_failed,omitempty"`          // Marked failed because their balance change was never applied
+	SweepErrors       int64                  `protobuf:"varint,7,opt,name=sweep_errors,json=sweepErrors,proto3" json:"sweep_errors,omitempty"`
+	LastSweepAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_sweep_at,json=lastSweepAt,proto3" json:"last_sweep_at,omitempty"` // Unset before the first sweep
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetPendingTransactionStatsResponse) Reset() {
+	*x = GetPendingTransactionStatsResponse{}
+	mi := &file_flowngine_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPendingTransactionStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPendingTransactionStatsResponse) ProtoMessage() {}
+
+func (x *GetPendingTransactionStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPendingTransactionStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetPendingTransactionStatsResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GetPendingTransactionStatsResponse) GetPending() int64 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *GetPendingTransactionStatsResponse) GetStale() int64 {
+	if x != nil {
+		return x.Stale
+	}
+	return 0
+}
+
+func (x *GetPendingTransactionStatsResponse) GetStaleAfterSeconds() int64 {
+	if x != nil {
+		return x.StaleAfterSeconds
+	}
+	return 0
+}
+
+func (x *GetPendingTransactionStatsResponse) GetSweepRuns() int64 {
+	if x != nil {
+		return x.SweepRuns
+	}
+	return 0
+}
+
+func (x *GetPendingTransactionStatsResponse) GetSweptCompleted() int64 {
+	if x != nil {
+		return x.SweptCompleted
+	}
+	return 0
+}
+
+func (x *GetPendingTransactionStatsResponse) GetSweptFailed() int64 {
+	if x != nil {
+		return x.SweptFailed
+	}
+	return 0
+}
+
+func (x *GetPendingTransactionStatsResponse) GetSweepErrors() int64 {
+	if x != nil {
+		return x.SweepErrors
+	}
+	return 0
+}
+
+func (x *GetPendingTransactionStatsResponse) GetLastSweepAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSweepAt
+	}
+	return nil
+}
+
+// Money request message
+// Exactly one of amount or amount_decimal must be set.
+type RequestMoneyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PayeeAccount  string                 `protobuf:"bytes,1,opt,name=payee_account,json=payeeAccount,proto3" json:"payee_account,omitempty"`
+	PayerAccount  string                 `protobuf:"bytes,2,opt,name=payer_account,json=payerAccount,proto3" json:"payer_account,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`                                   // Minor units of the currency
+	AmountDecimal string                 `protobuf:"bytes,4,opt,name=amount_decimal,json=amountDecimal,proto3" json:"amount_decimal,omitempty"` // Exact amount in major units, e.g. "100.50"
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	RequestId     string                 `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"` // A retry with the same request_id reports the money request the first attempt created
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestMoneyRequest) Reset() {
+	*x = RequestMoneyRequest{}
+	mi := &file_flowngine_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestMoneyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestMoneyRequest) ProtoMessage() {}
+
+func (x *RequestMoneyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestMoneyRequest.ProtoReflect.Descriptor instead.
+func (*RequestMoneyRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RequestMoneyRequest) GetPayeeAccount() string {
+	if x != nil {
+		return x.PayeeAccount
+	}
+	return ""
+}
+
+func (x *RequestMoneyRequest) GetPayerAccount() string {
+	if x != nil {
+		return x.PayerAccount
+	}
+	return ""
+}
+
+func (x *RequestMoneyRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RequestMoneyRequest) GetAmountDecimal() string {
+	if x != nil {
+		return x.AmountDecimal
+	}
+	return ""
+}
+
+func (x *RequestMoneyRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RequestMoneyRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *RequestMoneyRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Money request response message
+type RequestMoneyResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	MoneyRequestId    string                 `protobuf:"bytes,1,opt,name=money_request_id,json=moneyRequestId,proto3" json:"money_request_id,omitempty"` // Also the transaction ID of the transfer once the request is accepted
+	WorkflowExecution *WorkflowExecution     `protobuf:"bytes,2,opt,name=workflow_execution,json=workflowExecution,proto3" json:"workflow_execution,omitempty"`
+	Status            string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // PENDING
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Declined as EXPIRED when still pending by then
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RequestMoneyResponse) Reset() {
+	*x = RequestMoneyResponse{}
+	mi := &file_flowngine_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestMoneyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestMoneyResponse) ProtoMessage() {}
+
+func (x *RequestMoneyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestMoneyResponse.ProtoReflect.Descriptor instead.
+func (*RequestMoneyResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RequestMoneyResponse) GetMoneyRequestId() string {
+	if x != nil {
+		return x.MoneyRequestId
+	}
+	return ""
+}
+
+func (x *RequestMoneyResponse) GetWorkflowExecution() *WorkflowExecution {
+	if x != nil {
+		return x.WorkflowExecution
+	}
+	return nil
+}
+
+func (x *RequestMoneyResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RequestMoneyResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RequestMoneyResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// Money request list request message
+type ListMoneyRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`     // "payer" (default) or "payee"
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // Optional filter: PENDING, ACCEPTED, DECLINED or EXPIRED
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMoneyRequestsRequest) Reset() {
+	*x = ListMoneyRequestsRequest{}
+	mi := &file_flowngine_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMoneyRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMoneyRequestsRequest) ProtoMessage() {}
+
+func (x *ListMoneyRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMoneyRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListMoneyRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListMoneyRequestsRequest) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ListMoneyRequestsRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ListMoneyRequestsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// Money request list response message
+type ListMoneyRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       string                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	MoneyRequests []*MoneyRequest        `protobuf:"bytes,3,rep,name=money_requests,json=moneyRequests,proto3" json:"money_requests,omitempty"` // Newest first
+	Truncated     bool                   `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"`                             // Too many money requests to list them all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMoneyRequestsResponse) Reset() {
+	*x = ListMoneyRequestsResponse{}
+	mi := &file_flowngine_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMoneyRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMoneyRequestsResponse) ProtoMessage() {}
+
+func (x *ListMoneyRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMoneyRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListMoneyRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListMoneyRequestsResponse) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *ListMoneyRequestsResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ListMoneyRequestsResponse) GetMoneyRequests() []*MoneyRequest {
+	if x != nil {
+		return x.MoneyRequests
+	}
+	return nil
+}
+
+func (x *ListMoneyRequestsResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// A request from a payee for a payer to transfer an amount
+type MoneyRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MoneyRequestId string                 `protobuf:"bytes,1,opt,name=money_request_id,json=moneyRequestId,proto3" json:"money_request_id,omitempty"`
+	WorkflowId     string                 `protobuf:"bytes,2,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	PayerAccount   string                 `protobuf:"bytes,3,opt,name=payer_account,json=payerAccount,proto3" json:"payer_account,omitempty"`
+	PayeeAccount   string                 `protobuf:"bytes,4,opt,name=payee_account,json=payeeAccount,proto3" json:"payee_account,omitempty"`
+	Amount         string                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"` // Exact major-unit decimal
+	Currency       string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	Description    string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Status         string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"` // PENDING, ACCEPTED, DECLINED or EXPIRED
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MoneyRequest) Reset() {
+	*x = MoneyRequest{}
+	mi := &file_flowngine_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoneyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoneyRequest) ProtoMessage() {}
+
+func (x *MoneyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoneyRequest.ProtoReflect.Descriptor instead.
+func (*MoneyRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *MoneyRequest) GetMoneyRequestId() string {
+	if x != nil {
+		return x.MoneyRequestId
+	}
+	return ""
+}
+
+func (x *MoneyRequest) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *MoneyRequest) GetPayerAccount() string {
+	if x != nil {
+		return x.PayerAccount
+	}
+	return ""
+}
+
+func (x *MoneyRequest) GetPayeeAccount() string {
+	if x != nil {
+		return x.PayeeAccount
+	}
+	return ""
+}
+
+func (x *MoneyRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *MoneyRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *MoneyRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MoneyRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MoneyRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *MoneyRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// Money request answer request message
+type RespondToMoneyRequestRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MoneyRequestId string                 `protobuf:"bytes,1,opt,name=money_request_id,json=moneyRequestId,proto3" json:"money_request_id,omitempty"`
+	Accept         bool                   `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"` // Accepting starts the transfer, declining closes the request
+	Reason         string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`  // Optional reason of a decline
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RespondToMoneyRequestRequest) Reset() {
+	*x = RespondToMoneyRequestRequest{}
+	mi := &file_flowngine_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondToMoneyRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondToMoneyRequestRequest) ProtoMessage() {}
+
+func (x *RespondToMoneyRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondToMoneyRequestRequest.ProtoReflect.Descriptor instead.
+func (*RespondToMoneyRequestRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RespondToMoneyRequestRequest) GetMoneyRequestId() string {
+	if x != nil {
+		return x.MoneyRequestId
+	}
+	return ""
+}
+
+func (x *RespondToMoneyRequestRequest) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+func (x *RespondToMoneyRequestRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Money request answer response message
+type RespondToMoneyRequestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondToMoneyRequestResponse) Reset() {
+	*x = RespondToMoneyRequestResponse{}
+	mi := &file_flowngine_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondToMoneyRequestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondToMoneyRequestResponse) ProtoMessage() {}
+
+func (x *RespondToMoneyRequestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondToMoneyRequestResponse.ProtoReflect.Descriptor instead.
+func (*RespondToMoneyRequestResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *RespondToMoneyRequestResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RespondToMoneyRequestResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Escrow request message
+// Exactly one of amount or amount_decimal must be set.
+type CreateEscrowRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	FromAccount     string                 `protobuf:"bytes,1,opt,name=from_account,json=fromAccount,proto3" json:"from_account,omitempty"`       // Payer
+	ToAccount       string                 `protobuf:"bytes,2,opt,name=to_account,json=toAccount,proto3" json:"to_account,omitempty"`             // Payee
+	Amount          int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`                                   // Minor units of the currency
+	AmountDecimal   string                 `protobuf:"bytes,4,opt,name=amount_decimal,json=amountDecimal,proto3" json:"amount_decimal,omitempty"` // Exact amount in major units, e.g. "100.50"
+	Currency        string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Description     string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	RequestId       string                 `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`                    // A retry with the same request_id reports the escrow the first attempt created
+	Arbiter         string                 `protobuf:"bytes,8,opt,name=arbiter,proto3" json:"arbiter,omitempty"`                                         // Optional party that may release or refund the escrow
+	DeadlineSeconds int32                  `protobuf:"varint,9,opt,name=deadline_seconds,json=deadlineSeconds,proto3" json:"deadline_seconds,omitempty"` // Refunded when still held by then; 0 takes the configured default
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateEscrowRequest) Reset() {
+	*x = CreateEscrowRequest{}
+	mi := &file_flowngine_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEscrowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEscrowRequest) ProtoMessage() {}
+
+func (x *CreateEscrowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEscrowRequest.ProtoReflect.Descriptor instead.
+func (*CreateEscrowRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CreateEscrowRequest) GetFromAccount() string {
+	if x != nil {
+		return x.FromAccount
+	}
+	return ""
+}
+
+func (x *CreateEscrowRequest) GetToAccount() string {
+	if x != nil {
+		return x.ToAccount
+	}
+	return ""
+}
+
+func (x *CreateEscrowRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateEscrowRequest) GetAmountDecimal() string {
+	if x != nil {
+		return x.AmountDecimal
+	}
+	return ""
+}
+
+func (x *CreateEscrowRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateEscrowRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateEscrowRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *CreateEscrowRequest) GetArbiter() string {
+	if x != nil {
+		return x.Arbiter
+	}
+	return ""
+}
+
+func (x *CreateEscrowRequest) GetDeadlineSeconds() int32 {
+	if x != nil {
+		return x.DeadlineSeconds
+	}
+	return 0
+}
+
+// Escrow response message
+type CreateEscrowResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	EscrowId          string                 `protobuf:"bytes,1,opt,name=escrow_id,json=escrowId,proto3" json:"escrow_id,omitempty"`
+	WorkflowExecution *WorkflowExecution     `protobuf:"bytes,2,opt,name=workflow_execution,json=workflowExecution,proto3" json:"workflow_execution,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Deadline          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateEscrowResponse) Reset() {
+	*x = CreateEscrowResponse{}
+	mi := &file_flowngine_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateEscrowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateEscrowResponse) ProtoMessage() {}
+
+func (x *CreateEscrowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateEscrowResponse.ProtoReflect.Descriptor instead.
+func (*CreateEscrowResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CreateEscrowResponse) GetEscrowId() string {
+	if x != nil {
+		return x.EscrowId
+	}
+	return ""
+}
+
+func (x *CreateEscrowResponse) GetWorkflowExecution() *WorkflowExecution {
+	if x != nil {
+		return x.WorkflowExecution
+	}
+	return nil
+}
+
+func (x *CreateEscrowResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CreateEscrowResponse) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+// Escrow decision request message
+type DecideEscrowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EscrowId      string                 `protobuf:"bytes,1,opt,name=escrow_id,json=escrowId,proto3" json:"escrow_id,omitempty"`
+	Decision      string                 `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"`                    // "release" or "refund"
+	DecidedBy     string                 `protobuf:"bytes,3,opt,name=decided_by,json=decidedBy,proto3" json:"decided_by,omitempty"` // The payer may release, the payee refund, the arbiter do either
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideEscrowRequest) Reset() {
+	*x = DecideEscrowRequest{}
+	mi := &file_flowngine_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideEscrowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideEscrowRequest) ProtoMessage() {}
+
+func (x *DecideEscrowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideEscrowRequest.ProtoReflect.Descriptor instead.
+func (*DecideEscrowRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DecideEscrowRequest) GetEscrowId() string {
+	if x != nil {
+		return x.EscrowId
+	}
+	return ""
+}
+
+func (x *DecideEscrowRequest) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *DecideEscrowRequest) GetDecidedBy() string {
+	if x != nil {
+		return x.DecidedBy
+	}
+	return ""
+}
+
+func (x *DecideEscrowRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Escrow decision response message
+type DecideEscrowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideEscrowResponse) Reset() {
+	*x = DecideEscrowResponse{}
+	mi := &file_flowngine_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideEscrowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideEscrowResponse) ProtoMessage() {}
+
+func (x *DecideEscrowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideEscrowResponse.ProtoReflect.Descriptor instead.
+func (*DecideEscrowResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DecideEscrowResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DecideEscrowResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Escrow lookup request message
+type GetEscrowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EscrowId      string                 `protobuf:"bytes,1,opt,name=escrow_id,json=escrowId,proto3" json:"escrow_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEscrowRequest) Reset() {
+	*x = GetEscrowRequest{}
+	mi := &file_flowngine_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEscrowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEscrowRequest) ProtoMessage() {}
+
+func (x *GetEscrowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEscrowRequest.ProtoReflect.Descriptor instead.
+func (*GetEscrowRequest) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetEscrowRequest) GetEscrowId() string {
+	if x != nil {
+		return x.EscrowId
+	}
+	return ""
+}
+
+// Escrow lookup response message
+type GetEscrowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EscrowId      string                 `protobuf:"bytes,1,opt,name=escrow_id,json=escrowId,proto3" json:"escrow_id,omitempty"`
+	WorkflowId    string                 `protobuf:"bytes,2,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // PENDING, HELD, RELEASED, REFUNDED or FAILED
+	FromAccount   string                 `protobuf:"bytes,4,opt,name=from_account,json=fromAccount,proto3" json:"from_account,omitempty"`
+	ToAccount     string                 `protobuf:"bytes,5,opt,name=to_account,json=toAccount,proto3" json:"to_account,omitempty"`
+	Amount        string                 `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"` // Exact major-unit decimal
+	Currency      string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	Arbiter       string                 `protobuf:"bytes,8,opt,name=arbiter,proto3" json:"arbiter,omitempty"`
+	Deadline      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	DecidedBy     string                 `protobuf:"bytes,10,opt,name=decided_by,json=decidedBy,proto3" json:"decided_by,omitempty"` // Unset when refunded at the deadline
+	Reason        string                 `protobuf:"bytes,11,opt,name=reason,proto3" json:"reason,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,12,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	HeldAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=held_at,json=heldAt,proto3" json:"held_at,omitempty"`
+	SettledAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=settled_at,json=settledAt,proto3" json:"settled_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEscrowResponse) Reset() {
+	*x = GetEscrowResponse{}
+	mi := &file_flowngine_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEscrowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEscrowResponse) ProtoMessage() {}
+
+func (x *GetEscrowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEscrowResponse.ProtoReflect.Descriptor instead.
+func (*GetEscrowResponse) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetEscrowResponse) GetEscrowId() string {
+	if x != nil {
+		return x.EscrowId
+	}
+	return ""
+}
+
+func (x *GetEscrowResponse) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *GetEscrowResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetEscrowResponse) GetFromAccount() string {
+	if x != nil {
+		return x.FromAccount
+	}
+	return ""
+}
+
+func (x *GetEscrowResponse) GetToAccount() string {
+	if x != nil {
+		return x.ToAccount
+	}
+	return ""
+}
+
+func (x *GetEscrowResponse) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *GetEscrowResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GetEscrowResponse) GetArbiter() string {
+	if x != nil {
+		return x.Arbiter
+	}
+	return ""
+}
+
+func (x *GetEscrowResponse) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *GetEscrowResponse) GetDecidedBy() string {
+	if x != nil {
+		return x.DecidedBy
+	}
+	return ""
+}
+
+func (x *GetEscrowResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *GetEscrowResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *GetEscrowResponse) GetHeldAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HeldAt
+	}
+	return nil
+}
+
+func (x *GetEscrowResponse) GetSettledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SettledAt
+	}
+	return nil
+}
+
+type ErrorDetail_FieldViolation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ErrorDetail_FieldViolation) Reset() {
+	*x = ErrorDetail_FieldViolation{}
+	mi := &file_flowngine_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ErrorDetail_FieldViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorDetail_FieldViolation) ProtoMessage() {}
+
+func (x *ErrorDetail_FieldViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_flowngine_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorDetail_FieldViolation.ProtoReflect.Descriptor instead.
+func (*ErrorDetail_FieldViolation) Descriptor() ([]byte, []int) {
+	return file_flowngine_proto_rawDescGZIP(), []int{30, 0}
+}
+
+func (x *ErrorDetail_FieldViolation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ErrorDetail_FieldViolation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
 }
 
 var File_flowngine_proto protoreflect.FileDescriptor
 
 const file_flowngine_proto_rawDesc = "" +
 	"\n" +
-	"\x0fflowngine.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf2\x01\n" +
+	"\x0fflowngine.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa4\x04\n" +
 	"\x16ExecuteTransferRequest\x12!\n" +
 	"\ffrom_account\x18\x01 \x01(\tR\vfromAccount\x12\x1d\n" +
 	"\n" +
@@ -613,7 +4589,15 @@ const file_flowngine_proto_rawDesc = "" +
 	"\vdescription\x18\x05 \x01(\tR\vdescription\x12!\n" +
 	"\freference_id\x18\x06 \x01(\tR\vreferenceId\x12\x1d\n" +
 	"\n" +
-	"request_id\x18\a \x01(\tR\trequestId\"\xdf\x01\n" +
+	"request_id\x18\a \x01(\tR\trequestId\x12%\n" +
+	"\x0eamount_decimal\x18\b \x01(\tR\ramountDecimal\x128\n" +
+	"\x0eexecution_mode\x18\t \x01(\x0e2\x11.pb.ExecutionModeR\rexecutionMode\x12!\n" +
+	"\fcallback_url\x18\n" +
+	" \x01(\tR\vcallbackUrl\x12$\n" +
+	"\x0ewait_for_funds\x18\v \x01(\bR\fwaitForFunds\x12-\n" +
+	"\atrigger\x18\f \x01(\v2\x13.pb.TransferTriggerR\atrigger\x12\x19\n" +
+	"\bto_alias\x18\r \x01(\tR\atoAlias\x12<\n" +
+	"\x10task_queue_track\x18\x0e \x01(\x0e2\x12.pb.TaskQueueTrackR\x0etaskQueueTrack\"\xc5\x03\n" +
 	"\x17ExecuteTransferResponse\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12*\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x12.pb.TransferStatusR\x06status\x12\x1f\n" +
@@ -621,9 +4605,16 @@ const file_flowngine_proto_rawDesc = "" +
 	"workflowId\x12\x15\n" +
 	"\x06run_id\x18\x04 \x01(\tR\x05runId\x129\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"A\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12-\n" +
+	"\x12transfer_reference\x18\x06 \x01(\tR\x11transferReference\x12=\n" +
+	"\fcompleted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12#\n" +
+	"\rerror_message\x18\b \x01(\tR\ferrorMessage\x121\n" +
+	"\x14compensation_applied\x18\t \x01(\bR\x13compensationApplied\x12\x1e\n" +
+	"\x02fx\x18\n" +
+	" \x01(\v2\x0e.pb.TransferFXR\x02fx\"d\n" +
 	"\x18GetTransferStatusRequest\x12%\n" +
-	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\x8e\x04\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12!\n" +
+	"\fwait_seconds\x18\x02 \x01(\x05R\vwaitSeconds\"\xf5\x05\n" +
 	"\x19GetTransferStatusResponse\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12*\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x12.pb.TransferStatusR\x06status\x12!\n" +
@@ -639,18 +4630,352 @@ const file_flowngine_proto_rawDesc = "" +
 	"\fcompleted_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12D\n" +
 	"\x12workflow_execution\x18\v \x01(\v2\x15.pb.WorkflowExecutionR\x11workflowExecution\x12#\n" +
-	"\rerror_message\x18\f \x01(\tR\ferrorMessage\"V\n" +
+	"\rerror_message\x18\f \x01(\tR\ferrorMessage\x12-\n" +
+	"\x12transfer_reference\x18\r \x01(\tR\x11transferReference\x12%\n" +
+	"\x0eamount_decimal\x18\x0e \x01(\tR\ramountDecimal\x12&\n" +
+	"\x05steps\x18\x0f \x03(\v2\x10.pb.TransferStepR\x05steps\x12!\n" +
+	"\fsla_breached\x18\x10 \x01(\bR\vslaBreached\x12$\n" +
+	"\x04wait\x18\x11 \x01(\v2\x10.pb.TransferWaitR\x04wait\x12\x1e\n" +
+	"\x02fx\x18\x12 \x01(\v2\x0e.pb.TransferFXR\x02fx\"\x8e\x03\n" +
+	"\n" +
+	"TransferFX\x12\x12\n" +
+	"\x04pair\x18\x01 \x01(\tR\x04pair\x12\x12\n" +
+	"\x04side\x18\x02 \x01(\tR\x04side\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\x12\x19\n" +
+	"\bmid_rate\x18\x04 \x01(\tR\amidRate\x12\x1d\n" +
+	"\n" +
+	"spread_bps\x18\x05 \x01(\x03R\tspreadBps\x12\x1d\n" +
+	"\n" +
+	"margin_bps\x18\x06 \x01(\x03R\tmarginBps\x12-\n" +
+	"\x12converted_currency\x18\a \x01(\tR\x11convertedCurrency\x12)\n" +
+	"\x10converted_amount\x18\b \x01(\x03R\x0fconvertedAmount\x128\n" +
+	"\x18converted_amount_decimal\x18\t \x01(\tR\x16convertedAmountDecimal\x12#\n" +
+	"\rspread_amount\x18\n" +
+	" \x01(\x03R\fspreadAmount\x122\n" +
+	"\x15spread_amount_decimal\x18\v \x01(\tR\x13spreadAmountDecimal\"\xbb\x01\n" +
+	"\fTransferStep\x12\x1a\n" +
+	"\bactivity\x18\x01 \x01(\tR\bactivity\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\x03 \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x04 \x01(\tR\tlastError\x12\"\n" +
+	"\fcompensation\x18\x05 \x01(\bR\fcompensation\x12\x18\n" +
+	"\asummary\x18\x06 \x01(\tR\asummary\"E\n" +
+	"\x1aGetTransferStatusesRequest\x12'\n" +
+	"\x0ftransaction_ids\x18\x01 \x03(\tR\x0etransactionIds\"Q\n" +
+	"\x1bGetTransferStatusesResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.pb.TransferStatusResultR\aresults\"\xc0\x01\n" +
+	"\x14TransferStatusResult\x12!\n" +
+	"\frequested_id\x18\x01 \x01(\tR\vrequestedId\x129\n" +
+	"\btransfer\x18\x02 \x01(\v2\x1d.pb.GetTransferStatusResponseR\btransfer\x12%\n" +
+	"\x05error\x18\x03 \x01(\v2\x0f.pb.ErrorDetailR\x05error\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\"V\n" +
 	"\x15CancelTransferRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\"L\n" +
 	"\x16CancelTransferResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"c\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"C\n" +
+	"\x1aGetTransferTimelineRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\xa7\x01\n" +
+	"\x1bGetTransferTimelineResponse\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x1f\n" +
+	"\vworkflow_id\x18\x02 \x01(\tR\n" +
+	"workflowId\x12\x15\n" +
+	"\x06run_id\x18\x03 \x01(\tR\x05runId\x12)\n" +
+	"\x06events\x18\x04 \x03(\v2\x11.pb.TimelineEventR\x06events\"\xe2\x01\n" +
+	"\rTimelineEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x03R\aeventId\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1a\n" +
+	"\bactivity\x18\x04 \x01(\tR\bactivity\x12\x18\n" +
+	"\aattempt\x18\x05 \x01(\x05R\aattempt\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x12\"\n" +
+	"\fcompensation\x18\a \x01(\bR\fcompensation\"\x9c\x01\n" +
+	"\x12CompensationFilter\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12.\n" +
+	"\x04from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\x81\x01\n" +
+	"\x18ListCompensationsRequest\x12.\n" +
+	"\x06filter\x18\x01 \x01(\v2\x16.pb.CompensationFilterR\x06filter\x12\x1f\n" +
+	"\vworkflow_id\x18\x02 \x01(\tR\n" +
+	"workflowId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"Y\n" +
+	"\x19ListCompensationsResponse\x12<\n" +
+	"\rcompensations\x18\x01 \x03(\v2\x16.pb.CompensationRecordR\rcompensations\"\xe1\x04\n" +
+	"\x12CompensationRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vworkflow_id\x18\x02 \x01(\tR\n" +
+	"workflowId\x12\x15\n" +
+	"\x06run_id\x18\x03 \x01(\tR\x05runId\x12\x1f\n" +
+	"\vtransfer_id\x18\x04 \x01(\tR\n" +
+	"transferId\x126\n" +
+	"\x17original_transaction_id\x18\x05 \x01(\tR\x15originalTransactionId\x12>\n" +
+	"\x1bcompensation_transaction_id\x18\x06 \x01(\tR\x19compensationTransactionId\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x12\n" +
+	"\x04type\x18\b \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\n" +
+	" \x01(\x05R\battempts\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12=\n" +
+	"\fcompleted_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12%\n" +
+	"\x0efailure_reason\x18\x0e \x01(\tR\rfailureReason\x12.\n" +
+	"\x13timeout_duration_ms\x18\x0f \x01(\x05R\x11timeoutDurationMs\"M\n" +
+	"\x1bGetCompensationStatsRequest\x12.\n" +
+	"\x06filter\x18\x01 \x01(\v2\x16.pb.CompensationFilterR\x06filter\"\xce\x02\n" +
+	"\x1cGetCompensationStatsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x1c\n" +
+	"\tcompleted\x18\x02 \x01(\x03R\tcompleted\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x03R\x06failed\x12\x18\n" +
+	"\atimeout\x18\x04 \x01(\x03R\atimeout\x12'\n" +
+	"\x0fmanual_required\x18\x05 \x01(\x03R\x0emanualRequired\x12\x18\n" +
+	"\apending\x18\x06 \x01(\x03R\apending\x12)\n" +
+	"\x10average_attempts\x18\a \x01(\x01R\x0faverageAttempts\x12.\n" +
+	"\x04from\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"c\n" +
+	"\x1bListAccountWorkflowsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12%\n" +
+	"\x0eaccount_number\x18\x02 \x01(\tR\raccountNumber\"\xbc\x01\n" +
+	"\x1cListAccountWorkflowsResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x122\n" +
+	"\ttransfers\x18\x02 \x03(\v2\x14.pb.InFlightTransferR\ttransfers\x12+\n" +
+	"\apending\x18\x03 \x03(\v2\x11.pb.PendingAmountR\apending\x12\x1c\n" +
+	"\ttruncated\x18\x04 \x01(\bR\ttruncated\"\xc0\x02\n" +
+	"\x10InFlightTransfer\x12\x1f\n" +
+	"\vworkflow_id\x18\x01 \x01(\tR\n" +
+	"workflowId\x12\x15\n" +
+	"\x06run_id\x18\x02 \x01(\tR\x05runId\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\x12\x1c\n" +
+	"\tdirection\x18\x04 \x01(\tR\tdirection\x12!\n" +
+	"\ffrom_account\x18\x05 \x01(\tR\vfromAccount\x12\x1d\n" +
+	"\n" +
+	"to_account\x18\x06 \x01(\tR\ttoAccount\x12\x16\n" +
+	"\x06amount\x18\a \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x129\n" +
+	"\n" +
+	"started_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\"c\n" +
+	"\rPendingAmount\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x1a\n" +
+	"\boutgoing\x18\x02 \x01(\tR\boutgoing\x12\x1a\n" +
+	"\bincoming\x18\x03 \x01(\tR\bincoming\"\xfd\x01\n" +
+	"\x15ListAdminAuditRequest\x12\x14\n" +
+	"\x05actor\x18\x01 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12#\n" +
+	"\rresource_type\x18\x03 \x01(\tR\fresourceType\x12\x1f\n" +
+	"\vresource_id\x18\x04 \x01(\tR\n" +
+	"resourceId\x12.\n" +
+	"\x04from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\"H\n" +
+	"\x16ListAdminAuditResponse\x12.\n" +
+	"\arecords\x18\x01 \x03(\v2\x14.pb.AdminAuditRecordR\arecords\"\x99\x02\n" +
+	"\x10AdminAuditRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x18\n" +
+	"\aservice\x18\x04 \x01(\tR\aservice\x12#\n" +
+	"\rresource_type\x18\x05 \x01(\tR\fresourceType\x12\x1f\n" +
+	"\vresource_id\x18\x06 \x01(\tR\n" +
+	"resourceId\x12\x16\n" +
+	"\x06before\x18\a \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\b \x01(\tR\x05after\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x1c\n" +
+	"\x1aGetTransferSLAStatsRequest\"\x8e\x01\n" +
+	"\x1bGetTransferSLAStatsResponse\x12\x1f\n" +
+	"\vsla_seconds\x18\x01 \x01(\x05R\n" +
+	"slaSeconds\x12\x1a\n" +
+	"\bbreached\x18\x02 \x01(\x03R\bbreached\x12\x18\n" +
+	"\adelayed\x18\x03 \x01(\x03R\adelayed\x12\x18\n" +
+	"\aaborted\x18\x04 \x01(\x03R\aaborted\"c\n" +
 	"\x11WorkflowExecution\x12\x1f\n" +
 	"\vworkflow_id\x18\x01 \x01(\tR\n" +
 	"workflowId\x12\x15\n" +
 	"\x06run_id\x18\x02 \x01(\tR\x05runId\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status*\xe9\x01\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"\xfb\x01\n" +
+	"\vErrorDetail\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12I\n" +
+	"\x10field_violations\x18\x02 \x03(\v2\x1e.pb.ErrorDetail.FieldViolationR\x0ffieldViolations\x12\x1c\n" +
+	"\tretryable\x18\x03 \x01(\bR\tretryable\x12%\n" +
+	"\x0etransaction_id\x18\x04 \x01(\tR\rtransactionId\x1aH\n" +
+	"\x0eFieldViolation\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"`\n" +
+	"\x16ApproveTransferRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x1f\n" +
+	"\vapproved_by\x18\x02 \x01(\tR\n" +
+	"approvedBy\"M\n" +
+	"\x17ApproveTransferResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xc9\x02\n" +
+	"\fTransferWait\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x126\n" +
+	"\bdeadline\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12%\n" +
+	"\x0ebalance_checks\x18\x04 \x01(\x05R\rbalanceChecks\x12>\n" +
+	"\rnext_check_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vnextCheckAt\x12'\n" +
+	"\x0ftrigger_account\x18\x06 \x01(\tR\x0etriggerAccount\x12'\n" +
+	"\x0famount_received\x18\a \x01(\tR\x0eamountReceived\"R\n" +
+	"\x0fTransferTrigger\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12%\n" +
+	"\x0eamount_decimal\x18\x02 \x01(\tR\ramountDecimal\"o\n" +
+	"\x19StartTransferBatchRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x123\n" +
+	"\ttransfers\x18\x02 \x03(\v2\x15.pb.TransferBatchItemR\ttransfers\"\x87\x02\n" +
+	"\x11TransferBatchItem\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12!\n" +
+	"\ffrom_account\x18\x02 \x01(\tR\vfromAccount\x12\x1d\n" +
+	"\n" +
+	"to_account\x18\x03 \x01(\tR\ttoAccount\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12%\n" +
+	"\x0eamount_decimal\x18\x05 \x01(\tR\ramountDecimal\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12!\n" +
+	"\freference_id\x18\b \x01(\tR\vreferenceId\"\xe2\x01\n" +
+	"\x1aStartTransferBatchResponse\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\x12D\n" +
+	"\x12workflow_execution\x18\x02 \x01(\v2\x15.pb.WorkflowExecutionR\x11workflowExecution\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12(\n" +
+	"\x04rows\x18\x04 \x03(\v2\x14.pb.TransferBatchRowR\x04rows\"\xcb\x01\n" +
+	"\x10TransferBatchRow\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12-\n" +
+	"\x12transfer_reference\x18\x03 \x01(\tR\x11transferReference\x12*\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x12.pb.TransferStatusR\x06status\x12#\n" +
+	"\rerror_message\x18\x05 \x01(\tR\ferrorMessage\"4\n" +
+	"\x17GetTransferBatchRequest\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\"\xa5\x03\n" +
+	"\x18GetTransferBatchResponse\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\x12D\n" +
+	"\x12workflow_execution\x18\x02 \x01(\v2\x15.pb.WorkflowExecutionR\x11workflowExecution\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12\x18\n" +
+	"\apending\x18\x04 \x01(\x05R\apending\x12\x1e\n" +
+	"\n" +
+	"processing\x18\x05 \x01(\x05R\n" +
+	"processing\x12\x1c\n" +
+	"\tcompleted\x18\x06 \x01(\x05R\tcompleted\x12\x16\n" +
+	"\x06failed\x18\a \x01(\x05R\x06failed\x12(\n" +
+	"\x04rows\x18\b \x03(\v2\x14.pb.TransferBatchRowR\x04rows\x129\n" +
+	"\n" +
+	"started_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"#\n" +
+	"!GetPendingTransactionStatsRequest\"\xd2\x02\n" +
+	"\"GetPendingTransactionStatsResponse\x12\x18\n" +
+	"\apending\x18\x01 \x01(\x03R\apending\x12\x14\n" +
+	"\x05stale\x18\x02 \x01(\x03R\x05stale\x12.\n" +
+	"\x13stale_after_seconds\x18\x03 \x01(\x03R\x11staleAfterSeconds\x12\x1d\n" +
+	"\n" +
+	"sweep_runs\x18\x04 \x01(\x03R\tsweepRuns\x12'\n" +
+	"\x0fswept_completed\x18\x05 \x01(\x03R\x0esweptCompleted\x12!\n" +
+	"\fswept_failed\x18\x06 \x01(\x03R\vsweptFailed\x12!\n" +
+	"\fsweep_errors\x18\a \x01(\x03R\vsweepErrors\x12>\n" +
+	"\rlast_sweep_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vlastSweepAt\"\xfb\x01\n" +
+	"\x13RequestMoneyRequest\x12#\n" +
+	"\rpayee_account\x18\x01 \x01(\tR\fpayeeAccount\x12#\n" +
+	"\rpayer_account\x18\x02 \x01(\tR\fpayerAccount\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12%\n" +
+	"\x0eamount_decimal\x18\x04 \x01(\tR\ramountDecimal\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\a \x01(\tR\trequestId\"\x94\x02\n" +
+	"\x14RequestMoneyResponse\x12(\n" +
+	"\x10money_request_id\x18\x01 \x01(\tR\x0emoneyRequestId\x12D\n" +
+	"\x12workflow_execution\x18\x02 \x01(\v2\x15.pb.WorkflowExecutionR\x11workflowExecution\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"`\n" +
+	"\x18ListMoneyRequestsRequest\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\"\xa0\x01\n" +
+	"\x19ListMoneyRequestsResponse\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\tR\aaccount\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x127\n" +
+	"\x0emoney_requests\x18\x03 \x03(\v2\x10.pb.MoneyRequestR\rmoneyRequests\x12\x1c\n" +
+	"\ttruncated\x18\x04 \x01(\bR\ttruncated\"\x87\x03\n" +
+	"\fMoneyRequest\x12(\n" +
+	"\x10money_request_id\x18\x01 \x01(\tR\x0emoneyRequestId\x12\x1f\n" +
+	"\vworkflow_id\x18\x02 \x01(\tR\n" +
+	"workflowId\x12#\n" +
+	"\rpayer_account\x18\x03 \x01(\tR\fpayerAccount\x12#\n" +
+	"\rpayee_account\x18\x04 \x01(\tR\fpayeeAccount\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"x\n" +
+	"\x1cRespondToMoneyRequestRequest\x12(\n" +
+	"\x10money_request_id\x18\x01 \x01(\tR\x0emoneyRequestId\x12\x16\n" +
+	"\x06accept\x18\x02 \x01(\bR\x06accept\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"S\n" +
+	"\x1dRespondToMoneyRequestResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xb8\x02\n" +
+	"\x13CreateEscrowRequest\x12!\n" +
+	"\ffrom_account\x18\x01 \x01(\tR\vfromAccount\x12\x1d\n" +
+	"\n" +
+	"to_account\x18\x02 \x01(\tR\ttoAccount\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12%\n" +
+	"\x0eamount_decimal\x18\x04 \x01(\tR\ramountDecimal\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\a \x01(\tR\trequestId\x12\x18\n" +
+	"\aarbiter\x18\b \x01(\tR\aarbiter\x12)\n" +
+	"\x10deadline_seconds\x18\t \x01(\x05R\x0fdeadlineSeconds\"\xec\x01\n" +
+	"\x14CreateEscrowResponse\x12\x1b\n" +
+	"\tescrow_id\x18\x01 \x01(\tR\bescrowId\x12D\n" +
+	"\x12workflow_execution\x18\x02 \x01(\v2\x15.pb.WorkflowExecutionR\x11workflowExecution\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x126\n" +
+	"\bdeadline\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\"\x85\x01\n" +
+	"\x13DecideEscrowRequest\x12\x1b\n" +
+	"\tescrow_id\x18\x01 \x01(\tR\bescrowId\x12\x1a\n" +
+	"\bdecision\x18\x02 \x01(\tR\bdecision\x12\x1d\n" +
+	"\n" +
+	"decided_by\x18\x03 \x01(\tR\tdecidedBy\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"J\n" +
+	"\x14DecideEscrowResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"/\n" +
+	"\x10GetEscrowRequest\x12\x1b\n" +
+	"\tescrow_id\x18\x01 \x01(\tR\bescrowId\"\xfd\x03\n" +
+	"\x11GetEscrowResponse\x12\x1b\n" +
+	"\tescrow_id\x18\x01 \x01(\tR\bescrowId\x12\x1f\n" +
+	"\vworkflow_id\x18\x02 \x01(\tR\n" +
+	"workflowId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
+	"\ffrom_account\x18\x04 \x01(\tR\vfromAccount\x12\x1d\n" +
+	"\n" +
+	"to_account\x18\x05 \x01(\tR\ttoAccount\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12\x18\n" +
+	"\aarbiter\x18\b \x01(\tR\aarbiter\x126\n" +
+	"\bdeadline\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12\x1d\n" +
+	"\n" +
+	"decided_by\x18\n" +
+	" \x01(\tR\tdecidedBy\x12\x16\n" +
+	"\x06reason\x18\v \x01(\tR\x06reason\x12#\n" +
+	"\rerror_message\x18\f \x01(\tR\ferrorMessage\x123\n" +
+	"\aheld_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x06heldAt\x129\n" +
+	"\n" +
+	"settled_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tsettledAt*\x94\x03\n" +
 	"\x0eTransferStatus\x12\x1f\n" +
 	"\x1bTRANSFER_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17TRANSFER_STATUS_PENDING\x10\x01\x12\x1e\n" +
@@ -658,12 +4983,43 @@ const file_flowngine_proto_rawDesc = "" +
 	"\x19TRANSFER_STATUS_COMPLETED\x10\x03\x12\x1a\n" +
 	"\x16TRANSFER_STATUS_FAILED\x10\x04\x12\x1f\n" +
 	"\x1bTRANSFER_STATUS_COMPENSATED\x10\x05\x12\x1d\n" +
-	"\x19TRANSFER_STATUS_CANCELLED\x10\x062\xf3\x01\n" +
+	"\x19TRANSFER_STATUS_CANCELLED\x10\x06\x12\x1b\n" +
+	"\x17TRANSFER_STATUS_DELAYED\x10\a\x12\x1b\n" +
+	"\x17TRANSFER_STATUS_EXPIRED\x10\b\x12%\n" +
+	"!TRANSFER_STATUS_AWAITING_APPROVAL\x10\t\x12\"\n" +
+	"\x1eTRANSFER_STATUS_AWAITING_FUNDS\x10\n" +
+	"\x12$\n" +
+	" TRANSFER_STATUS_AWAITING_TRIGGER\x10\v*b\n" +
+	"\rExecutionMode\x12\x1e\n" +
+	"\x1aEXECUTION_MODE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14EXECUTION_MODE_ASYNC\x10\x01\x12\x17\n" +
+	"\x13EXECUTION_MODE_SYNC\x10\x02*k\n" +
+	"\x0eTaskQueueTrack\x12 \n" +
+	"\x1cTASK_QUEUE_TRACK_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18TASK_QUEUE_TRACK_CURRENT\x10\x01\x12\x19\n" +
+	"\x15TASK_QUEUE_TRACK_NEXT\x10\x022\xdc\f\n" +
 	"\n" +
 	"FlowEngine\x12J\n" +
 	"\x0fExecuteTransfer\x12\x1a.pb.ExecuteTransferRequest\x1a\x1b.pb.ExecuteTransferResponse\x12P\n" +
-	"\x11GetTransferStatus\x12\x1c.pb.GetTransferStatusRequest\x1a\x1d.pb.GetTransferStatusResponse\x12G\n" +
-	"\x0eCancelTransfer\x12\x19.pb.CancelTransferRequest\x1a\x1a.pb.CancelTransferResponseB\x06Z\x04./pbb\x06proto3"
+	"\x11GetTransferStatus\x12\x1c.pb.GetTransferStatusRequest\x1a\x1d.pb.GetTransferStatusResponse\x12V\n" +
+	"\x13GetTransferStatuses\x12\x1e.pb.GetTransferStatusesRequest\x1a\x1f.pb.GetTransferStatusesResponse\x12G\n" +
+	"\x0eCancelTransfer\x12\x19.pb.CancelTransferRequest\x1a\x1a.pb.CancelTransferResponse\x12V\n" +
+	"\x13GetTransferTimeline\x12\x1e.pb.GetTransferTimelineRequest\x1a\x1f.pb.GetTransferTimelineResponse\x12P\n" +
+	"\x11ListCompensations\x12\x1c.pb.ListCompensationsRequest\x1a\x1d.pb.ListCompensationsResponse\x12Y\n" +
+	"\x14GetCompensationStats\x12\x1f.pb.GetCompensationStatsRequest\x1a .pb.GetCompensationStatsResponse\x12Y\n" +
+	"\x14ListAccountWorkflows\x12\x1f.pb.ListAccountWorkflowsRequest\x1a .pb.ListAccountWorkflowsResponse\x12G\n" +
+	"\x0eListAdminAudit\x12\x19.pb.ListAdminAuditRequest\x1a\x1a.pb.ListAdminAuditResponse\x12V\n" +
+	"\x13GetTransferSLAStats\x12\x1e.pb.GetTransferSLAStatsRequest\x1a\x1f.pb.GetTransferSLAStatsResponse\x12J\n" +
+	"\x0fApproveTransfer\x12\x1a.pb.ApproveTransferRequest\x1a\x1b.pb.ApproveTransferResponse\x12S\n" +
+	"\x12StartTransferBatch\x12\x1d.pb.StartTransferBatchRequest\x1a\x1e.pb.StartTransferBatchResponse\x12M\n" +
+	"\x10GetTransferBatch\x12\x1b.pb.GetTransferBatchRequest\x1a\x1c.pb.GetTransferBatchResponse\x12k\n" +
+	"\x1aGetPendingTransactionStats\x12%.pb.GetPendingTransactionStatsRequest\x1a&.pb.GetPendingTransactionStatsResponse\x12A\n" +
+	"\fRequestMoney\x12\x17.pb.RequestMoneyRequest\x1a\x18.pb.RequestMoneyResponse\x12P\n" +
+	"\x11ListMoneyRequests\x12\x1c.pb.ListMoneyRequestsRequest\x1a\x1d.pb.ListMoneyRequestsResponse\x12\\\n" +
+	"\x15RespondToMoneyRequest\x12 .pb.RespondToMoneyRequestRequest\x1a!.pb.RespondToMoneyRequestResponse\x12A\n" +
+	"\fCreateEscrow\x12\x17.pb.CreateEscrowRequest\x1a\x18.pb.CreateEscrowResponse\x12A\n" +
+	"\fDecideEscrow\x12\x17.pb.DecideEscrowRequest\x1a\x18.pb.DecideEscrowResponse\x128\n" +
+	"\tGetEscrow\x12\x14.pb.GetEscrowRequest\x1a\x15.pb.GetEscrowResponseB\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_flowngine_proto_rawDescOnce sync.Once
@@ -677,37 +5033,179 @@ func file_flowngine_proto_rawDescGZIP() []byte {
 	return file_flowngine_proto_rawDescData
 }
 
-var file_flowngine_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_flowngine_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_flowngine_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_flowngine_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
 var file_flowngine_proto_goTypes = []any{
-	(TransferStatus)(0),               // 0: pb.TransferStatus
-	(*ExecuteTransferRequest)(nil),    // 1: pb.ExecuteTransferRequest
-	(*ExecuteTransferResponse)(nil),   // 2: pb.ExecuteTransferResponse
-	(*GetTransferStatusRequest)(nil),  // 3: pb.GetTransferStatusRequest
-	(*GetTransferStatusResponse)(nil), // 4: pb.GetTransferStatusResponse
-	(*CancelTransferRequest)(nil),     // 5: pb.CancelTransferRequest
-	(*CancelTransferResponse)(nil),    // 6: pb.CancelTransferResponse
-	(*WorkflowExecution)(nil),         // 7: pb.WorkflowExecution
-	(*timestamppb.Timestamp)(nil),     // 8: google.protobuf.Timestamp
+	(TransferStatus)(0),                        // 0: pb.TransferStatus
+	(ExecutionMode)(0),                         // 1: pb.ExecutionMode
+	(TaskQueueTrack)(0),                        // 2: pb.TaskQueueTrack
+	(*ExecuteTransferRequest)(nil),             // 3: pb.ExecuteTransferRequest
+	(*ExecuteTransferResponse)(nil),            // 4: pb.ExecuteTransferResponse
+	(*GetTransferStatusRequest)(nil),           // 5: pb.GetTransferStatusRequest
+	(*GetTransferStatusResponse)(nil),          // 6: pb.GetTransferStatusResponse
+	(*TransferFX)(nil),                         // 7: pb.TransferFX
+	(*TransferStep)(nil),                       // 8: pb.TransferStep
+	(*GetTransferStatusesRequest)(nil),         // 9: pb.GetTransferStatusesRequest
+	(*GetTransferStatusesResponse)(nil),        // 10: pb.GetTransferStatusesResponse
+	(*TransferStatusResult)(nil),               // 11: pb.TransferStatusResult
+	(*CancelTransferRequest)(nil),              // 12: pb.CancelTransferRequest
+	(*CancelTransferResponse)(nil),             // 13: pb.CancelTransferResponse
+	(*GetTransferTimelineRequest)(nil),         // 14: pb.GetTransferTimelineRequest
+	(*GetTransferTimelineResponse)(nil),        // 15: pb.GetTransferTimelineResponse
+	(*TimelineEvent)(nil),                      // 16: pb.TimelineEvent
+	(*CompensationFilter)(nil),                 // 17: pb.CompensationFilter
+	(*ListCompensationsRequest)(nil),           // 18: pb.ListCompensationsRequest
+	(*ListCompensationsResponse)(nil),          // 19: pb.ListCompensationsResponse
+	(*CompensationRecord)(nil),                 // 20: pb.CompensationRecord
+	(*GetCompensationStatsRequest)(nil),        // 21: pb.GetCompensationStatsRequest
+	(*GetCompensationStatsResponse)(nil),       // 22: pb.GetCompensationStatsResponse
+	(*ListAccountWorkflowsRequest)(nil),        // 23: pb.ListAccountWorkflowsRequest
+	(*ListAccountWorkflowsResponse)(nil),       // 24: pb.ListAccountWorkflowsResponse
+	(*InFlightTransfer)(nil),                   // 25: pb.InFlightTransfer
+	(*PendingAmount)(nil),                      // 26: pb.PendingAmount
+	(*ListAdminAuditRequest)(nil),              // 27: pb.ListAdminAuditRequest
+	(*ListAdminAuditResponse)(nil),             // 28: pb.ListAdminAuditResponse
+	(*AdminAuditRecord)(nil),                   // 29: pb.AdminAuditRecord
+	(*GetTransferSLAStatsRequest)(nil),         // 30: pb.GetTransferSLAStatsRequest
+	(*GetTransferSLAStatsResponse)(nil),        // 31: pb.GetTransferSLAStatsResponse
+	(*WorkflowExecution)(nil),                  // 32: pb.WorkflowExecution
+	(*ErrorDetail)(nil),                        // 33: pb.ErrorDetail
+	(*ApproveTransferRequest)(nil),             // 34: pb.ApproveTransferRequest
+	(*ApproveTransferResponse)(nil),            // 35: pb.ApproveTransferResponse
+	(*TransferWait)(nil),                       // 36: pb.TransferWait
+	(*TransferTrigger)(nil),                    // 37: pb.TransferTrigger
+	(*StartTransferBatchRequest)(nil),          // 38: pb.StartTransferBatchRequest
+	(*TransferBatchItem)(nil),                  // 39: pb.TransferBatchItem
+	(*StartTransferBatchResponse)(nil),         // 40: pb.StartTransferBatchResponse
+	(*TransferBatchRow)(nil),                   // 41: pb.TransferBatchRow
+	(*GetTransferBatchRequest)(nil),            // 42: pb.GetTransferBatchRequest
+	(*GetTransferBatchResponse)(nil),           // 43: pb.GetTransferBatchResponse
+	(*GetPendingTransactionStatsRequest)(nil),  // 44: pb.GetPendingTransactionStatsRequest
+	(*GetPendingTransactionStatsResponse)(nil), // 45: pb.GetPendingTransactionStatsResponse
+	(*RequestMoneyRequest)(nil),                // 46: pb.RequestMoneyRequest
+	(*RequestMoneyResponse)(nil),               // 47: pb.RequestMoneyResponse
+	(*ListMoneyRequestsRequest)(nil),           // 48: pb.ListMoneyRequestsRequest
+	(*ListMoneyRequestsResponse)(nil),          // 49: pb.ListMoneyRequestsResponse
+	(*MoneyRequest)(nil),                       // 50: pb.MoneyRequest
+	(*RespondToMoneyRequestRequest)(nil),       // 51: pb.RespondToMoneyRequestRequest
+	(*RespondToMoneyRequestResponse)(nil),      // 52: pb.RespondToMoneyRequestResponse
+	(*CreateEscrowRequest)(nil),                // 53: pb.CreateEscrowRequest
+	(*CreateEscrowResponse)(nil),               // 54: pb.CreateEscrowResponse
+	(*DecideEscrowRequest)(nil),                // 55: pb.DecideEscrowRequest
+	(*DecideEscrowResponse)(nil),               // 56: pb.DecideEscrowResponse
+	(*GetEscrowRequest)(nil),                   // 57: pb.GetEscrowRequest
+	(*GetEscrowResponse)(nil),                  // 58: pb.GetEscrowResponse
+	(*ErrorDetail_FieldViolation)(nil),         // 59: pb.ErrorDetail.FieldViolation
+	(*timestamppb.Timestamp)(nil),              // 60: google.protobuf.Timestamp
 }
 var file_flowngine_proto_depIdxs = []int32{
-	0, // 0: pb.ExecuteTransferResponse.status:type_name -> pb.TransferStatus
-	8, // 1: pb.ExecuteTransferResponse.created_at:type_name -> google.protobuf.Timestamp
-	0, // 2: pb.GetTransferStatusResponse.status:type_name -> pb.TransferStatus
-	8, // 3: pb.GetTransferStatusResponse.created_at:type_name -> google.protobuf.Timestamp
-	8, // 4: pb.GetTransferStatusResponse.completed_at:type_name -> google.protobuf.Timestamp
-	7, // 5: pb.GetTransferStatusResponse.workflow_execution:type_name -> pb.WorkflowExecution
-	1, // 6: pb.FlowEngine.ExecuteTransfer:input_type -> pb.ExecuteTransferRequest
-	3, // 7: pb.FlowEngine.GetTransferStatus:input_type -> pb.GetTransferStatusRequest
-	5, // 8: pb.FlowEngine.CancelTransfer:input_type -> pb.CancelTransferRequest
-	2, // 9: pb.FlowEngine.ExecuteTransfer:output_type -> pb.ExecuteTransferResponse
-	4, // 10: pb.FlowEngine.GetTransferStatus:output_type -> pb.GetTransferStatusResponse
-	6, // 11: pb.FlowEngine.CancelTransfer:output_type -> pb.CancelTransferResponse
-	9, // [9:12] is the sub-list for method output_type
-	6, // [6:9] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	1,  // 0: pb.ExecuteTransferRequest.execution_mode:type_name -> pb.ExecutionMode
+	37, // 1: pb.ExecuteTransferRequest.trigger:type_name -> pb.TransferTrigger
+	2,  // 2: pb.ExecuteTransferRequest.task_queue_track:type_name -> pb.TaskQueueTrack
+	0,  // 3: pb.ExecuteTransferResponse.status:type_name -> pb.TransferStatus
+	60, // 4: pb.ExecuteTransferResponse.created_at:type_name -> google.protobuf.Timestamp
+	60, // 5: pb.ExecuteTransferResponse.completed_at:type_name -> google.protobuf.Timestamp
+	7,  // 6: pb.ExecuteTransferResponse.fx:type_name -> pb.TransferFX
+	0,  // 7: pb.GetTransferStatusResponse.status:type_name -> pb.TransferStatus
+	60, // 8: pb.GetTransferStatusResponse.created_at:type_name -> google.protobuf.Timestamp
+	60, // 9: pb.GetTransferStatusResponse.completed_at:type_name -> google.protobuf.Timestamp
+	32, // 10: pb.GetTransferStatusResponse.workflow_execution:type_name -> pb.WorkflowExecution
+	8,  // 11: pb.GetTransferStatusResponse.steps:type_name -> pb.TransferStep
+	36, // 12: pb.GetTransferStatusResponse.wait:type_name -> pb.TransferWait
+	7,  // 13: pb.GetTransferStatusResponse.fx:type_name -> pb.TransferFX
+	11, // 14: pb.GetTransferStatusesResponse.results:type_name -> pb.TransferStatusResult
+	6,  // 15: pb.TransferStatusResult.transfer:type_name -> pb.GetTransferStatusResponse
+	33, // 16: pb.TransferStatusResult.error:type_name -> pb.ErrorDetail
+	16, // 17: pb.GetTransferTimelineResponse.events:type_name -> pb.TimelineEvent
+	60, // 18: pb.TimelineEvent.time:type_name -> google.protobuf.Timestamp
+	60, // 19: pb.CompensationFilter.from:type_name -> google.protobuf.Timestamp
+	60, // 20: pb.CompensationFilter.to:type_name -> google.protobuf.Timestamp
+	17, // 21: pb.ListCompensationsRequest.filter:type_name -> pb.CompensationFilter
+	20, // 22: pb.ListCompensationsResponse.compensations:type_name -> pb.CompensationRecord
+	60, // 23: pb.CompensationRecord.created_at:type_name -> google.protobuf.Timestamp
+	60, // 24: pb.CompensationRecord.updated_at:type_name -> google.protobuf.Timestamp
+	60, // 25: pb.CompensationRecord.completed_at:type_name -> google.protobuf.Timestamp
+	17, // 26: pb.GetCompensationStatsRequest.filter:type_name -> pb.CompensationFilter
+	60, // 27: pb.GetCompensationStatsResponse.from:type_name -> google.protobuf.Timestamp
+	60, // 28: pb.GetCompensationStatsResponse.to:type_name -> google.protobuf.Timestamp
+	25, // 29: pb.ListAccountWorkflowsResponse.transfers:type_name -> pb.InFlightTransfer
+	26, // 30: pb.ListAccountWorkflowsResponse.pending:type_name -> pb.PendingAmount
+	60, // 31: pb.InFlightTransfer.started_at:type_name -> google.protobuf.Timestamp
+	60, // 32: pb.ListAdminAuditRequest.from:type_name -> google.protobuf.Timestamp
+	60, // 33: pb.ListAdminAuditRequest.to:type_name -> google.protobuf.Timestamp
+	29, // 34: pb.ListAdminAuditResponse.records:type_name -> pb.AdminAuditRecord
+	60, // 35: pb.AdminAuditRecord.created_at:type_name -> google.protobuf.Timestamp
+	59, // 36: pb.ErrorDetail.field_violations:type_name -> pb.ErrorDetail.FieldViolation
+	60, // 37: pb.TransferWait.since:type_name -> google.protobuf.Timestamp
+	60, // 38: pb.TransferWait.deadline:type_name -> google.protobuf.Timestamp
+	60, // 39: pb.TransferWait.next_check_at:type_name -> google.protobuf.Timestamp
+	39, // 40: pb.StartTransferBatchRequest.transfers:type_name -> pb.TransferBatchItem
+	32, // 41: pb.StartTransferBatchResponse.workflow_execution:type_name -> pb.WorkflowExecution
+	60, // 42: pb.StartTransferBatchResponse.created_at:type_name -> google.protobuf.Timestamp
+	41, // 43: pb.StartTransferBatchResponse.rows:type_name -> pb.TransferBatchRow
+	0,  // 44: pb.TransferBatchRow.status:type_name -> pb.TransferStatus
+	32, // 45: pb.GetTransferBatchResponse.workflow_execution:type_name -> pb.WorkflowExecution
+	41, // 46: pb.GetTransferBatchResponse.rows:type_name -> pb.TransferBatchRow
+	60, // 47: pb.GetTransferBatchResponse.started_at:type_name -> google.protobuf.Timestamp
+	60, // 48: pb.GetTransferBatchResponse.completed_at:type_name -> google.protobuf.Timestamp
+	60, // 49: pb.GetPendingTransactionStatsResponse.last_sweep_at:type_name -> google.protobuf.Timestamp
+	32, // 50: pb.RequestMoneyResponse.workflow_execution:type_name -> pb.WorkflowExecution
+	60, // 51: pb.RequestMoneyResponse.created_at:type_name -> google.protobuf.Timestamp
+	60, // 52: pb.RequestMoneyResponse.expires_at:type_name -> google.protobuf.Timestamp
+	50, // 53: pb.ListMoneyRequestsResponse.money_requests:type_name -> pb.MoneyRequest
+	60, // 54: pb.MoneyRequest.created_at:type_name -> google.protobuf.Timestamp
+	60, // 55: pb.MoneyRequest.expires_at:type_name -> google.protobuf.Timestamp
+	32, // 56: pb.CreateEscrowResponse.workflow_execution:type_name -> pb.WorkflowExecution
+	60, // 57: pb.CreateEscrowResponse.created_at:type_name -> google.protobuf.Timestamp
+	60, // 58: pb.CreateEscrowResponse.deadline:type_name -> google.protobuf.Timestamp
+	60, // 59: pb.GetEscrowResponse.deadline:type_name -> google.protobuf.Timestamp
+	60, // 60: pb.GetEscrowResponse.held_at:type_name -> google.protobuf.Timestamp
+	60, // 61: pb.GetEscrowResponse.settled_at:type_name -> google.protobuf.Timestamp
+	3,  // 62: pb.FlowEngine.ExecuteTransfer:input_type -> pb.ExecuteTransferRequest
+	5,  // 63: pb.FlowEngine.GetTransferStatus:input_type -> pb.GetTransferStatusRequest
+	9,  // 64: pb.FlowEngine.GetTransferStatuses:input_type -> pb.GetTransferStatusesRequest
+	12, // 65: pb.FlowEngine.CancelTransfer:input_type -> pb.CancelTransferRequest
+	14, // 66: pb.FlowEngine.GetTransferTimeline:input_type -> pb.GetTransferTimelineRequest
+	18, // 67: pb.FlowEngine.ListCompensations:input_type -> pb.ListCompensationsRequest
+	21, // 68: pb.FlowEngine.GetCompensationStats:input_type -> pb.GetCompensationStatsRequest
+	23, // 69: pb.FlowEngine.ListAccountWorkflows:input_type -> pb.ListAccountWorkflowsRequest
+	27, // 70: pb.FlowEngine.ListAdminAudit:input_type -> pb.ListAdminAuditRequest
+	30, // 71: pb.FlowEngine.GetTransferSLAStats:input_type -> pb.GetTransferSLAStatsRequest
+	34, // 72: pb.FlowEngine.ApproveTransfer:input_type -> pb.ApproveTransferRequest
+	38, // 73: pb.FlowEngine.StartTransferBatch:input_type -> pb.StartTransferBatchRequest
+	42, // 74: pb.FlowEngine.GetTransferBatch:input_type -> pb.GetTransferBatchRequest
+	44, // 75: pb.FlowEngine.GetPendingTransactionStats:input_type -> pb.GetPendingTransactionStatsRequest
+	46, // 76: pb.FlowEngine.RequestMoney:input_type -> pb.RequestMoneyRequest
+	48, // 77: pb.FlowEngine.ListMoneyRequests:input_type -> pb.ListMoneyRequestsRequest
+	51, // 78: pb.FlowEngine.RespondToMoneyRequest:input_type -> pb.RespondToMoneyRequestRequest
+	53, // 79: pb.FlowEngine.CreateEscrow:input_type -> pb.CreateEscrowRequest
+	55, // 80: pb.FlowEngine.DecideEscrow:input_type -> pb.DecideEscrowRequest
+	57, // 81: pb.FlowEngine.GetEscrow:input_type -> pb.GetEscrowRequest
+	4,  // 82: pb.FlowEngine.ExecuteTransfer:output_type -> pb.ExecuteTransferResponse
+	6,  // 83: pb.FlowEngine.GetTransferStatus:output_type -> pb.GetTransferStatusResponse
+	10, // 84: pb.FlowEngine.GetTransferStatuses:output_type -> pb.GetTransferStatusesResponse
+	13, // 85: pb.FlowEngine.CancelTransfer:output_type -> pb.CancelTransferResponse
+	15, // 86: pb.FlowEngine.GetTransferTimeline:output_type -> pb.GetTransferTimelineResponse
+	19, // 87: pb.FlowEngine.ListCompensations:output_type -> pb.ListCompensationsResponse
+	22, // 88: pb.FlowEngine.GetCompensationStats:output_type -> pb.GetCompensationStatsResponse
+	24, // 89: pb.FlowEngine.ListAccountWorkflows:output_type -> pb.ListAccountWorkflowsResponse
+	28, // 90: pb.FlowEngine.ListAdminAudit:output_type -> pb.ListAdminAuditResponse
+	31, // 91: pb.FlowEngine.GetTransferSLAStats:output_type -> pb.GetTransferSLAStatsResponse
+	35, // 92: pb.FlowEngine.ApproveTransfer:output_type -> pb.ApproveTransferResponse
+	40, // 93: pb.FlowEngine.StartTransferBatch:output_type -> pb.StartTransferBatchResponse
+	43, // 94: pb.FlowEngine.GetTransferBatch:output_type -> pb.GetTransferBatchResponse
+	45, // 95: pb.FlowEngine.GetPendingTransactionStats:output_type -> pb.GetPendingTransactionStatsResponse
+	47, // 96: pb.FlowEngine.RequestMoney:output_type -> pb.RequestMoneyResponse
+	49, // 97: pb.FlowEngine.ListMoneyRequests:output_type -> pb.ListMoneyRequestsResponse
+	52, // 98: pb.FlowEngine.RespondToMoneyRequest:output_type -> pb.RespondToMoneyRequestResponse
+	54, // 99: pb.FlowEngine.CreateEscrow:output_type -> pb.CreateEscrowResponse
+	56, // 100: pb.FlowEngine.DecideEscrow:output_type -> pb.DecideEscrowResponse
+	58, // 101: pb.FlowEngine.GetEscrow:output_type -> pb.GetEscrowResponse
+	82, // [82:102] is the sub-list for method output_type
+	62, // [62:82] is the sub-list for method input_type
+	62, // [62:62] is the sub-list for extension type_name
+	62, // [62:62] is the sub-list for extension extendee
+	0,  // [0:62] is the sub-list for field type_name
 }
 
 func init() { file_flowngine_proto_init() }
@@ -720,8 +5218,8 @@ func file_flowngine_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_flowngine_proto_rawDesc), len(file_flowngine_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   7,
+			NumEnums:      3,
+			NumMessages:   57,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,9 +19,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	FlowEngine_ExecuteTransfer_FullMethodName   = "/pb.FlowEngine/ExecuteTransfer"
-	FlowEngine_GetTransferStatus_FullMethodName = "/pb.FlowEngine/GetTransferStatus"
-	FlowEngine_CancelTransfer_FullMethodName    = "/pb.FlowEngine/CancelTransfer"
+	FlowEngine_ExecuteTransfer_FullMethodName            = "/pb.FlowEngine/ExecuteTransfer"
+	FlowEngine_GetTransferStatus_FullMethodName          = "/pb.FlowEngine/GetTransferStatus"
+	FlowEngine_GetTransferStatuses_FullMethodName        = "/pb.FlowEngine/GetTransferStatuses"
+	FlowEngine_CancelTransfer_FullMethodName             = "/pb.FlowEngine/CancelTransfer"
+	FlowEngine_GetTransferTimeline_FullMethodName        = "/pb.FlowEngine/GetTransferTimeline"
+	FlowEngine_ListCompensations_FullMethodName          = "/pb.FlowEngine/ListCompensations"
+	FlowEngine_GetCompensationStats_FullMethodName       = "/pb.FlowEngine/GetCompensationStats"
+	FlowEngine_ListAccountWorkflows_FullMethodName       = "/pb.FlowEngine/ListAccountWorkflows"
+	FlowEngine_ListAdminAudit_FullMethodName             = "/pb.FlowEngine/ListAdminAudit"
+	FlowEngine_GetTransferSLAStats_FullMethodName        = "/pb.FlowEngine/GetTransferSLAStats"
+	FlowEngine_ApproveTransfer_FullMethodName            = "/pb.FlowEngine/ApproveTransfer"
+	FlowEngine_StartTransferBatch_FullMethodName         = "/pb.FlowEngine/StartTransferBatch"
+	FlowEngine_GetTransferBatch_FullMethodName           = "/pb.FlowEngine/GetTransferBatch"
+	FlowEngine_GetPendingTransactionStats_FullMethodName = "/pb.FlowEngine/GetPendingTransactionStats"
+	FlowEngine_RequestMoney_FullMethodName               = "/pb.FlowEngine/RequestMoney"
+	FlowEngine_ListMoneyRequests_FullMethodName          = "/pb.FlowEngine/ListMoneyRequests"
+	FlowEngine_RespondToMoneyRequest_FullMethodName      = "/pb.FlowEngine/RespondToMoneyRequest"
+	FlowEngine_CreateEscrow_FullMethodName               = "/pb.FlowEngine/CreateEscrow"
+	FlowEngine_DecideEscrow_FullMethodName               = "/pb.FlowEngine/DecideEscrow"
+	FlowEngine_GetEscrow_FullMethodName                  = "/pb.FlowEngine/GetEscrow"
 )
 
 // FlowEngineClient is the client API for FlowEngine service.
@@ -32,10 +49,44 @@ const (
 type FlowEngineClient interface {
 	// ExecuteTransfer starts a transfer workflow
 	ExecuteTransfer(ctx context.Context, in *ExecuteTransferRequest, opts ...grpc.CallOption) (*ExecuteTransferResponse, error)
-	// GetTransferStatus gets the current status of a transfer
+	// GetTransferStatus gets the current status of a transfer, optionally long-polling until it finishes
 	GetTransferStatus(ctx context.Context, in *GetTransferStatusRequest, opts ...grpc.CallOption) (*GetTransferStatusResponse, error)
+	// GetTransferStatuses gets the current status of several transfers in one call, e.g. to refresh a dashboard
+	GetTransferStatuses(ctx context.Context, in *GetTransferStatusesRequest, opts ...grpc.CallOption) (*GetTransferStatusesResponse, error)
 	// CancelTransfer attempts to cancel a pending transfer
 	CancelTransfer(ctx context.Context, in *CancelTransferRequest, opts ...grpc.CallOption) (*CancelTransferResponse, error)
+	// GetTransferTimeline lists the steps of a transfer workflow, read from its Temporal history
+	GetTransferTimeline(ctx context.Context, in *GetTransferTimelineRequest, opts ...grpc.CallOption) (*GetTransferTimelineResponse, error)
+	// ListCompensations lists the compensation audit records kept by svc-transaction, newest first
+	ListCompensations(ctx context.Context, in *ListCompensationsRequest, opts ...grpc.CallOption) (*ListCompensationsResponse, error)
+	// GetCompensationStats counts compensation audit records by outcome
+	GetCompensationStats(ctx context.Context, in *GetCompensationStatsRequest, opts ...grpc.CallOption) (*GetCompensationStatsResponse, error)
+	// ListAccountWorkflows lists the running transfers where an account is the source or the destination
+	ListAccountWorkflows(ctx context.Context, in *ListAccountWorkflowsRequest, opts ...grpc.CallOption) (*ListAccountWorkflowsResponse, error)
+	// ListAdminAudit lists the state-changing admin calls recorded by svc-transaction and svc-balance, newest first
+	ListAdminAudit(ctx context.Context, in *ListAdminAuditRequest, opts ...grpc.CallOption) (*ListAdminAuditResponse, error)
+	// GetTransferSLAStats counts saga transfers that breached their SLA
+	GetTransferSLAStats(ctx context.Context, in *GetTransferSLAStatsRequest, opts ...grpc.CallOption) (*GetTransferSLAStatsResponse, error)
+	// ApproveTransfer releases a transfer held for approval; unapproved transfers expire
+	ApproveTransfer(ctx context.Context, in *ApproveTransferRequest, opts ...grpc.CallOption) (*ApproveTransferResponse, error)
+	// StartTransferBatch starts a workflow that runs every transfer of a batch as its own transfer workflow
+	StartTransferBatch(ctx context.Context, in *StartTransferBatchRequest, opts ...grpc.CallOption) (*StartTransferBatchResponse, error)
+	// GetTransferBatch reports the progress of a batch and the outcome of each of its transfers
+	GetTransferBatch(ctx context.Context, in *GetTransferBatchRequest, opts ...grpc.CallOption) (*GetTransferBatchResponse, error)
+	// GetPendingTransactionStats counts the transactions stuck in pending and what the pending-transaction sweeper did about them
+	GetPendingTransactionStats(ctx context.Context, in *GetPendingTransactionStatsRequest, opts ...grpc.CallOption) (*GetPendingTransactionStatsResponse, error)
+	// RequestMoney asks a payer to transfer an amount to the payee; the transfer runs once the payer accepts
+	RequestMoney(ctx context.Context, in *RequestMoneyRequest, opts ...grpc.CallOption) (*RequestMoneyResponse, error)
+	// ListMoneyRequests lists the money requests of an account as payer or payee
+	ListMoneyRequests(ctx context.Context, in *ListMoneyRequestsRequest, opts ...grpc.CallOption) (*ListMoneyRequestsResponse, error)
+	// RespondToMoneyRequest accepts or declines a pending money request on behalf of its payer
+	RespondToMoneyRequest(ctx context.Context, in *RespondToMoneyRequestRequest, opts ...grpc.CallOption) (*RespondToMoneyRequestResponse, error)
+	// CreateEscrow debits the payer and holds the amount until it is released to the payee or refunded
+	CreateEscrow(ctx context.Context, in *CreateEscrowRequest, opts ...grpc.CallOption) (*CreateEscrowResponse, error)
+	// DecideEscrow releases a held escrow to the payee or refunds it to the payer
+	DecideEscrow(ctx context.Context, in *DecideEscrowRequest, opts ...grpc.CallOption) (*DecideEscrowResponse, error)
+	// GetEscrow reports the state of an escrow
+	GetEscrow(ctx context.Context, in *GetEscrowRequest, opts ...grpc.CallOption) (*GetEscrowResponse, error)
 }
 
 type flowEngineClient struct {
@@ -66,6 +117,16 @@ func (c *flowEngineClient) GetTransferStatus(ctx context.Context, in *GetTransfe
 	return out, nil
 }
 
+func (c *flowEngineClient) GetTransferStatuses(ctx context.Context, in *GetTransferStatusesRequest, opts ...grpc.CallOption) (*GetTransferStatusesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransferStatusesResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_GetTransferStatuses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *flowEngineClient) CancelTransfer(ctx context.Context, in *CancelTransferRequest, opts ...grpc.CallOption) (*CancelTransferResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CancelTransferResponse)
@@ -76,6 +137,166 @@ func (c *flowEngineClient) CancelTransfer(ctx context.Context, in *CancelTransfe
 	return out, nil
 }
 
+func (c *flowEngineClient) GetTransferTimeline(ctx context.Context, in *GetTransferTimelineRequest, opts ...grpc.CallOption) (*GetTransferTimelineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransferTimelineResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_GetTransferTimeline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) ListCompensations(ctx context.Context, in *ListCompensationsRequest, opts ...grpc.CallOption) (*ListCompensationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCompensationsResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_ListCompensations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) GetCompensationStats(ctx context.Context, in *GetCompensationStatsRequest, opts ...grpc.CallOption) (*GetCompensationStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCompensationStatsResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_GetCompensationStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) ListAccountWorkflows(ctx context.Context, in *ListAccountWorkflowsRequest, opts ...grpc.CallOption) (*ListAccountWorkflowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountWorkflowsResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_ListAccountWorkflows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) ListAdminAudit(ctx context.Context, in *ListAdminAuditRequest, opts ...grpc.CallOption) (*ListAdminAuditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAdminAuditResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_ListAdminAudit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) GetTransferSLAStats(ctx context.Context, in *GetTransferSLAStatsRequest, opts ...grpc.CallOption) (*GetTransferSLAStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransferSLAStatsResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_GetTransferSLAStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) ApproveTransfer(ctx context.Context, in *ApproveTransferRequest, opts ...grpc.CallOption) (*ApproveTransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveTransferResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_ApproveTransfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) StartTransferBatch(ctx context.Context, in *StartTransferBatchRequest, opts ...grpc.CallOption) (*StartTransferBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartTransferBatchResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_StartTransferBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) GetTransferBatch(ctx context.Context, in *GetTransferBatchRequest, opts ...grpc.CallOption) (*GetTransferBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransferBatchResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_GetTransferBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) GetPendingTransactionStats(ctx context.Context, in *GetPendingTransactionStatsRequest, opts ...grpc.CallOption) (*GetPendingTransactionStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPendingTransactionStatsResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_GetPendingTransactionStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) RequestMoney(ctx context.Context, in *RequestMoneyRequest, opts ...grpc.CallOption) (*RequestMoneyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestMoneyResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_RequestMoney_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) ListMoneyRequests(ctx context.Context, in *ListMoneyRequestsRequest, opts ...grpc.CallOption) (*ListMoneyRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMoneyRequestsResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_ListMoneyRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) RespondToMoneyRequest(ctx context.Context, in *RespondToMoneyRequestRequest, opts ...grpc.CallOption) (*RespondToMoneyRequestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespondToMoneyRequestResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_RespondToMoneyRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) CreateEscrow(ctx context.Context, in *CreateEscrowRequest, opts ...grpc.CallOption) (*CreateEscrowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateEscrowResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_CreateEscrow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) DecideEscrow(ctx context.Context, in *DecideEscrowRequest, opts ...grpc.CallOption) (*DecideEscrowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DecideEscrowResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_DecideEscrow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *flowEngineClient) GetEscrow(ctx context.Context, in *GetEscrowRequest, opts ...grpc.CallOption) (*GetEscrowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEscrowResponse)
+	err := c.cc.Invoke(ctx, FlowEngine_GetEscrow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FlowEngineServer is the server API for FlowEngine service.
 // All implementations must embed UnimplementedFlowEngineServer
 // for forward compatibility.
@@ -84,10 +305,44 @@ func (c *flowEngineClient) CancelTransfer(ctx context.Context, in *CancelTransfe
 type FlowEngineServer interface {
 	// ExecuteTransfer starts a transfer workflow
 	ExecuteTransfer(context.Context, *ExecuteTransferRequest) (*ExecuteTransferResponse, error)
-	// GetTransferStatus gets the current status of a transfer
+	// GetTransferStatus gets the current status of a transfer, optionally long-polling until it finishes
 	GetTransferStatus(context.Context, *GetTransferStatusRequest) (*GetTransferStatusResponse, error)
+	// GetTransferStatuses gets the current status of several transfers in one call, e.g. to refresh a dashboard
+	GetTransferStatuses(context.Context, *GetTransferStatusesRequest) (*GetTransferStatusesResponse, error)
 	// CancelTransfer attempts to cancel a pending transfer
 	CancelTransfer(context.Context, *CancelTransferRequest) (*CancelTransferResponse, error)
+	// GetTransferTimeline lists the steps of a transfer workflow, read from its Temporal history
+	GetTransferTimeline(context.Context, *GetTransferTimelineRequest) (*GetTransferTimelineResponse, error)
+	// ListCompensations lists the compensation audit records kept by svc-transaction, newest first
+	ListCompensations(context.Context, *ListCompensationsRequest) (*ListCompensationsResponse, error)
+	// GetCompensationStats counts compensation audit records by outcome
+	GetCompensationStats(context.Context, *GetCompensationStatsRequest) (*GetCompensationStatsResponse, error)
+	// ListAccountWorkflows lists the running transfers where an account is the source or the destination
+	ListAccountWorkflows(context.Context, *ListAccountWorkflowsRequest) (*ListAccountWorkflowsResponse, error)
+	// ListAdminAudit lists the state-changing admin calls recorded by svc-transaction and svc-balance, newest first
+	ListAdminAudit(context.Context, *ListAdminAuditRequest) (*ListAdminAuditResponse, error)
+	// GetTransferSLAStats counts saga transfers that breached their SLA
+	GetTransferSLAStats(context.Context, *GetTransferSLAStatsRequest) (*GetTransferSLAStatsResponse, error)
+	// ApproveTransfer releases a transfer held for approval; unapproved transfers expire
+	ApproveTransfer(context.Context, *ApproveTransferRequest) (*ApproveTransferResponse, error)
+	// StartTransferBatch starts a workflow that runs every transfer of a batch as its own transfer workflow
+	StartTransferBatch(context.Context, *StartTransferBatchRequest) (*StartTransferBatchResponse, error)
+	// GetTransferBatch reports the progress of a batch and the outcome of each of its transfers
+	GetTransferBatch(context.Context, *GetTransferBatchRequest) (*GetTransferBatchResponse, error)
+	// GetPendingTransactionStats counts the transactions stuck in pending and what the pending-transaction sweeper did about them
+	GetPendingTransactionStats(context.Context, *GetPendingTransactionStatsRequest) (*GetPendingTransactionStatsResponse, error)
+	// RequestMoney asks a payer to transfer an amount to the payee; the transfer runs once the payer accepts
+	RequestMoney(context.Context, *RequestMoneyRequest) (*RequestMoneyResponse, error)
+	// ListMoneyRequests lists the money requests of an account as payer or payee
+	ListMoneyRequests(context.Context, *ListMoneyRequestsRequest) (*ListMoneyRequestsResponse, error)
+	// RespondToMoneyRequest accepts or declines a pending money request on behalf of its payer
+	RespondToMoneyRequest(context.Context, *RespondToMoneyRequestRequest) (*RespondToMoneyRequestResponse, error)
+	// CreateEscrow debits the payer and holds the amount until it is released to the payee or refunded
+	CreateEscrow(context.Context, *CreateEscrowRequest) (*CreateEscrowResponse, error)
+	// DecideEscrow releases a held escrow to the payee or refunds it to the payer
+	DecideEscrow(context.Context, *DecideEscrowRequest) (*DecideEscrowResponse, error)
+	// GetEscrow reports the state of an escrow
+	GetEscrow(context.Context, *GetEscrowRequest) (*GetEscrowResponse, error)
 	mustEmbedUnimplementedFlowEngineServer()
 }
 
@@ -104,9 +359,60 @@ func (UnimplementedFlowEngineServer) ExecuteTransfer(context.Context, *ExecuteTr
 func (UnimplementedFlowEngineServer) GetTransferStatus(context.Context, *GetTransferStatusRequest) (*GetTransferStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransferStatus not implemented")
 }
+func (UnimplementedFlowEngineServer) GetTransferStatuses(context.Context, *GetTransferStatusesRequest) (*GetTransferStatusesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransferStatuses not implemented")
+}
 func (UnimplementedFlowEngineServer) CancelTransfer(context.Context, *CancelTransferRequest) (*CancelTransferResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelTransfer not implemented")
 }
+func (UnimplementedFlowEngineServer) GetTransferTimeline(context.Context, *GetTransferTimelineRequest) (*GetTransferTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransferTimeline not implemented")
+}
+func (UnimplementedFlowEngineServer) ListCompensations(context.Context, *ListCompensationsRequest) (*ListCompensationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCompensations not implemented")
+}
+func (UnimplementedFlowEngineServer) GetCompensationStats(context.Context, *GetCompensationStatsRequest) (*GetCompensationStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCompensationStats not implemented")
+}
+func (UnimplementedFlowEngineServer) ListAccountWorkflows(context.Context, *ListAccountWorkflowsRequest) (*ListAccountWorkflowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccountWorkflows not implemented")
+}
+func (UnimplementedFlowEngineServer) ListAdminAudit(context.Context, *ListAdminAuditRequest) (*ListAdminAuditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAdminAudit not implemented")
+}
+func (UnimplementedFlowEngineServer) GetTransferSLAStats(context.Context, *GetTransferSLAStatsRequest) (*GetTransferSLAStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransferSLAStats not implemented")
+}
+func (UnimplementedFlowEngineServer) ApproveTransfer(context.Context, *ApproveTransferRequest) (*ApproveTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveTransfer not implemented")
+}
+func (UnimplementedFlowEngineServer) StartTransferBatch(context.Context, *StartTransferBatchRequest) (*StartTransferBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartTransferBatch not implemented")
+}
+func (UnimplementedFlowEngineServer) GetTransferBatch(context.Context, *GetTransferBatchRequest) (*GetTransferBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransferBatch not implemented")
+}
+func (UnimplementedFlowEngineServer) GetPendingTransactionStats(context.Context, *GetPendingTransactionStatsRequest) (*GetPendingTransactionStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPendingTransactionStats not implemented")
+}
+func (UnimplementedFlowEngineServer) RequestMoney(context.Context, *RequestMoneyRequest) (*RequestMoneyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestMoney not implemented")
+}
+func (UnimplementedFlowEngineServer) ListMoneyRequests(context.Context, *ListMoneyRequestsRequest) (*ListMoneyRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMoneyRequests not implemented")
+}
+func (UnimplementedFlowEngineServer) RespondToMoneyRequest(context.Context, *RespondToMoneyRequestRequest) (*RespondToMoneyRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondToMoneyRequest not implemented")
+}
+func (UnimplementedFlowEngineServer) CreateEscrow(context.Context, *CreateEscrowRequest) (*CreateEscrowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateEscrow not implemented")
+}
+func (UnimplementedFlowEngineServer) DecideEscrow(context.Context, *DecideEscrowRequest) (*DecideEscrowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecideEscrow not implemented")
+}
+func (UnimplementedFlowEngineServer) GetEscrow(context.Context, *GetEscrowRequest) (*GetEscrowResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEscrow not implemented")
+}
 func (UnimplementedFlowEngineServer) mustEmbedUnimplementedFlowEngineServer() {}
 func (UnimplementedFlowEngineServer) testEmbeddedByValue()                    {}
 
@@ -164,6 +470,24 @@ func _FlowEngine_GetTransferStatus_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FlowEngine_GetTransferStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).GetTransferStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_GetTransferStatuses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).GetTransferStatuses(ctx, req.(*GetTransferStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FlowEngine_CancelTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CancelTransferRequest)
 	if err := dec(in); err != nil {
@@ -182,6 +506,294 @@ func _FlowEngine_CancelTransfer_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FlowEngine_GetTransferTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).GetTransferTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_GetTransferTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).GetTransferTimeline(ctx, req.(*GetTransferTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_ListCompensations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCompensationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).ListCompensations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_ListCompensations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).ListCompensations(ctx, req.(*ListCompensationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_GetCompensationStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCompensationStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).GetCompensationStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_GetCompensationStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).GetCompensationStats(ctx, req.(*GetCompensationStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_ListAccountWorkflows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountWorkflowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).ListAccountWorkflows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_ListAccountWorkflows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).ListAccountWorkflows(ctx, req.(*ListAccountWorkflowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_ListAdminAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAdminAuditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).ListAdminAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_ListAdminAudit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).ListAdminAudit(ctx, req.(*ListAdminAuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_GetTransferSLAStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferSLAStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).GetTransferSLAStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_GetTransferSLAStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).GetTransferSLAStats(ctx, req.(*GetTransferSLAStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_ApproveTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).ApproveTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_ApproveTransfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).ApproveTransfer(ctx, req.(*ApproveTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_StartTransferBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartTransferBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).StartTransferBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_StartTransferBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).StartTransferBatch(ctx, req.(*StartTransferBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_GetTransferBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).GetTransferBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_GetTransferBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).GetTransferBatch(ctx, req.(*GetTransferBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_GetPendingTransactionStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPendingTransactionStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).GetPendingTransactionStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_GetPendingTransactionStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).GetPendingTransactionStats(ctx, req.(*GetPendingTransactionStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_RequestMoney_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestMoneyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).RequestMoney(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_RequestMoney_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).RequestMoney(ctx, req.(*RequestMoneyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_ListMoneyRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMoneyRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).ListMoneyRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_ListMoneyRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).ListMoneyRequests(ctx, req.(*ListMoneyRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_RespondToMoneyRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondToMoneyRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).RespondToMoneyRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_RespondToMoneyRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).RespondToMoneyRequest(ctx, req.(*RespondToMoneyRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_CreateEscrow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateEscrowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).CreateEscrow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_CreateEscrow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).CreateEscrow(ctx, req.(*CreateEscrowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_DecideEscrow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideEscrowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).DecideEscrow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_DecideEscrow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).DecideEscrow(ctx, req.(*DecideEscrowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FlowEngine_GetEscrow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEscrowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FlowEngineServer).GetEscrow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FlowEngine_GetEscrow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FlowEngineServer).GetEscrow(ctx, req.(*GetEscrowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FlowEngine_ServiceDesc is the grpc.ServiceDesc for FlowEngine service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -197,10 +809,78 @@ var FlowEngine_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransferStatus",
 			Handler:    _FlowEngine_GetTransferStatus_Handler,
 		},
+		{
+			MethodName: "GetTransferStatuses",
+			Handler:    _FlowEngine_GetTransferStatuses_Handler,
+		},
 		{
 			MethodName: "CancelTransfer",
 			Handler:    _FlowEngine_CancelTransfer_Handler,
 		},
+		{
+			MethodName: "GetTransferTimeline",
+			Handler:    _FlowEngine_GetTransferTimeline_Handler,
+		},
+		{
+			MethodName: "ListCompensations",
+			Handler:    _FlowEngine_ListCompensations_Handler,
+		},
+		{
+			MethodName: "GetCompensationStats",
+			Handler:    _FlowEngine_GetCompensationStats_Handler,
+		},
+		{
+			MethodName: "ListAccountWorkflows",
+			Handler:    _FlowEngine_ListAccountWorkflows_Handler,
+		},
+		{
+			MethodName: "ListAdminAudit",
+			Handler:    _FlowEngine_ListAdminAudit_Handler,
+		},
+		{
+			MethodName: "GetTransferSLAStats",
+			Handler:    _FlowEngine_GetTransferSLAStats_Handler,
+		},
+		{
+			MethodName: "ApproveTransfer",
+			Handler:    _FlowEngine_ApproveTransfer_Handler,
+		},
+		{
+			MethodName: "StartTransferBatch",
+			Handler:    _FlowEngine_StartTransferBatch_Handler,
+		},
+		{
+			MethodName: "GetTransferBatch",
+			Handler:    _FlowEngine_GetTransferBatch_Handler,
+		},
+		{
+			MethodName: "GetPendingTransactionStats",
+			Handler:    _FlowEngine_GetPendingTransactionStats_Handler,
+		},
+		{
+			MethodName: "RequestMoney",
+			Handler:    _FlowEngine_RequestMoney_Handler,
+		},
+		{
+			MethodName: "ListMoneyRequests",
+			Handler:    _FlowEngine_ListMoneyRequests_Handler,
+		},
+		{
+			MethodName: "RespondToMoneyRequest",
+			Handler:    _FlowEngine_RespondToMoneyRequest_Handler,
+		},
+		{
+			MethodName: "CreateEscrow",
+			Handler:    _FlowEngine_CreateEscrow_Handler,
+		},
+		{
+			MethodName: "DecideEscrow",
+			Handler:    _FlowEngine_DecideEscrow_Handler,
+		},
+		{
+			MethodName: "GetEscrow",
+			Handler:    _FlowEngine_GetEscrow_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "flowngine.proto",
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"api-gateway/middleware"
+	"api-gateway/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// accountSummaryV2 is returned by GET /api/v2/accounts/:account/summary
+type accountSummaryV2 struct {
+	AccountNumber string `json:"account_number"`
+	AccountName   string `json:"account_name"`
+	Balance       struct {
+		Value    string `json:"value"` // Exact major-unit decimal, e.g. "100.50"
+		Currency string `json:"currency"`
+	} `json:"balance"`
+	Status      string `json:"status"`
+	AccountType string `json:"account_type"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+	Version     int32  `json:"version"`
+	// Pending is null when FlowEngine could not be asked and empty when no transfer is running
+	Pending          []pendingAmountV2 `json:"pending"`
+	PendingTruncated bool              `json:"pending_truncated,omitempty"`
+}
+
+// pendingAmountV2 sums the running transfers of an account in one currency
+type pendingAmountV2 struct {
+	Currency string `json:"currency"`
+	Outgoing string `json:"outgoing"` // Exact major-unit decimal, debited once the transfers complete
+	Incoming string `json:"incoming"` // Exact major-unit decimal, credited once the transfers complete
+}
+
+// GetAccountSummary handles GET /api/v2/accounts/:account/summary. Balances change at any time, so the response is
+// revalidated with its ETag before every use.
+func (api *Api) GetAccountSummary(c *fiber.Ctx) error {
+	const op = "api.Api.GetAccountSummary"
+
+	params := &service.GetAccountSummaryParams{
+		AccountNumber: c.Params("account"),
+		Tenant:        c.Get(HeaderTenant),
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.GetAccountSummary(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		var validationErr *service.TransferValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			return newValidationError(validationErr, nil)
+		case errors.Is(err, service.ErrAccountNotFound):
+			return fiber.NewError(fiber.StatusNotFound, "Account not found")
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get account summary")
+	}
+
+	response := accountSummaryV2{
+		AccountNumber: results.AccountNumber,
+		AccountName:   results.AccountName,
+		Status:        results.Status,
+		AccountType:   results.AccountType,
+		CreatedAt:     results.CreatedAt,
+		UpdatedAt:     results.UpdatedAt,
+		Version:       results.Version,
+	}
+	response.Balance.Value = results.Balance
+	response.Balance.Currency = results.Currency
+	if results.Pending != nil {
+		response.Pending = make([]pendingAmountV2, 0, len(results.Pending))
+		for _, pending := range results.Pending {
+			response.Pending = append(response.Pending, pendingAmountV2(pending))
+		}
+		response.PendingTruncated = results.PendingTruncated
+	}
+
+	c.Set(fiber.HeaderCacheControl, middleware.CacheControlRevalidate)
+
+	return c.JSON(response)
+}
+
+// balancesRequestV2 is the JSON body accepted by POST /api/v2/accounts/balances
+type balancesRequestV2 struct {
+	AccountNumbers []string `json:"account_numbers"`
+}
+
+// accountBalanceV2 is one balance returned by POST /api/v2/accounts/balances
+type accountBalanceV2 struct {
+	AccountNumber string `json:"account_number"`
+	Balance       struct {
+		Value    string `json:"value"` // Exact major-unit decimal, e.g. "100.50"
+		Currency string `json:"currency"`
+	} `json:"balance"`
+	Status    string `json:"status"`
+	UpdatedAt string `json:"updated_at"`
+	Version   int32  `json:"version"`
+}
+
+// GetBalances handles POST /api/v2/accounts/balances, which reads the balances of up to
+// service.MaxGetBalancesAccounts accounts at once. Unknown accounts are listed in not_found.
+func (api *Api) GetBalances(c *fiber.Ctx) error {
+	const op = "api.Api.GetBalances"
+
+	var req balancesRequestV2
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request format")
+	}
+
+	params := &service.GetBalancesParams{
+		AccountNumbers: req.AccountNumbers,
+		Tenant:         c.Get(HeaderTenant),
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"accounts": len(params.AccountNumbers),
+		"tenant":   params.Tenant,
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.GetBalances(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		var validationErr *service.TransferValidationError
+		if errors.As(err, &validationErr) {
+			return newValidationError(validationErr, nil)
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get balances")
+	}
+
+	balances := make([]accountBalanceV2, 0, len(results.Balances))
+	for _, result := range results.Balances {
+		balance := accountBalanceV2{
+			AccountNumber: result.AccountNumber,
+			Status:        result.Status,
+			UpdatedAt:     result.UpdatedAt,
+			Version:       result.Version,
+		}
+		balance.Balance.Value = result.Balance
+		balance.Balance.Currency = result.Currency
+
+		balances = append(balances, balance)
+	}
+
+	return c.JSON(fiber.Map{
+		"balances":  balances,
+		"not_found": results.NotFound,
+	})
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"api-gateway/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// accountReportPage reads the limit and offset query parameters of the account reports
+func accountReportPage(c *fiber.Ctx) (limit, offset int32, err error) {
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > service.MaxAccountReportLimit {
+			return 0, 0, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", service.MaxAccountReportLimit))
+		}
+		limit = int32(parsed)
+	}
+
+	if value := c.Query("offset"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || parsed < 0 {
+			return 0, 0, fiber.NewError(fiber.StatusBadRequest, "offset must be a non-negative integer")
+		}
+		offset = int32(parsed)
+	}
+
+	return limit, offset, nil
+}
+
+// accountReportError maps the errors of the account report routes to HTTP statuses
+func accountReportError(err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidAccountReport):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrAccountReportsUnavailable):
+		return fiber.NewError(fiber.StatusServiceUnavailable, err.Error())
+	}
+
+	return fiber.NewError(fiber.StatusInternalServerError, message)
+}
+
+// ListLowBalanceAccounts handles GET /admin/accounts/low-balance, which pages through the active accounts whose
+// balance is below threshold, lowest first. next_offset is set while there may be more pages.
+func (api *Api) ListLowBalanceAccounts(c *fiber.Ctx) error {
+	const op = "api.Api.ListLowBalanceAccounts"
+
+	threshold := c.Query("threshold")
+	if threshold == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "threshold is required")
+	}
+
+	limit, offset, err := accountReportPage(c)
+	if err != nil {
+		return err
+	}
+
+	params := &service.ListLowBalanceAccountsParams{
+		Threshold: threshold,
+		Limit:     limit,
+		Offset:    offset,
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.ListLowBalanceAccounts(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return accountReportError(err, "Failed to list low balance accounts")
+	}
+
+	return c.JSON(results)
+}
+
+// ListDormantAccounts handles GET /admin/accounts/dormant, which pages through the active accounts without
+// transactions for inactive_days (90 when omitted), longest unused first. next_offset is set while there may be
+// more pages.
+func (api *Api) ListDormantAccounts(c *fiber.Ctx) error {
+	const op = "api.Api.ListDormantAccounts"
+
+	params := &service.ListDormantAccountsParams{}
+
+	if value := c.Query("inactive_days"); value != "" {
+		days, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || days < 1 {
+			return fiber.NewError(fiber.StatusBadRequest, "inactive_days must be a positive integer")
+		}
+		params.InactiveDays = int32(days)
+	}
+
+	limit, offset, err := accountReportPage(c)
+	if err != nil {
+		return err
+	}
+	params.Limit, params.Offset = limit, offset
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.ListDormantAccounts(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return accountReportError(err, "Failed to list dormant accounts")
+	}
+
+	return c.JSON(results)
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"api-gateway/adapter/balance_adapter"
+	"api-gateway/adapter/flowngine_adapter"
+	flowenginepb "api-gateway/adapter/flowngine_adapter/pb"
+	"api-gateway/service"
+	"api-gateway/util/config"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeAccountFlowEngine struct {
+	flowenginepb.UnimplementedFlowEngineServer
+
+	requests chan *flowenginepb.ListAccountWorkflowsRequest
+}
+
+func (engine *fakeAccountFlowEngine) ListAccountWorkflows(ctx context.Context, request *flowenginepb.ListAccountWorkflowsRequest) (*flowenginepb.ListAccountWorkflowsResponse, error) {
+	engine.requests <- request
+
+	return &flowenginepb.ListAccountWorkflowsResponse{
+		AccountId: request.AccountId,
+		Pending: []*flowenginepb.PendingAmount{
+			{Currency: "EUR", Outgoing: "0", Incoming: "84.75"},
+			{Currency: "USD", Outgoing: "110", Incoming: "20"},
+		},
+	}, nil
+}
+
+func TestGetAccountSummaryPending(t *testing.T) {
+	t.Parallel()
+
+	svcBalance := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"status":"success","data":{"id":"550e8400-e29b-41d4-a716-446655440001","account_number":"ACC000000001",
+			"account_name":"Alice","balance":"1000.00","currency":"USD","status":"ACTIVE","account_type":"CHECKING",
+			"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-10-16T08:00:00Z","version":7}}`)
+	}))
+	t.Cleanup(svcBalance.Close)
+
+	engine := &fakeAccountFlowEngine{requests: make(chan *flowenginepb.ListAccountWorkflowsRequest, 1)}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	flowenginepb.RegisterFlowEngineServer(server, engine)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///flowngine",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	flowngineAdapter := flowngine_adapter.NewAdapter("flowngine", logger, conn)
+	balanceAdapter := balance_adapter.NewAdapter("svc-balance", logger, svcBalance.URL, time.Second)
+	app := NewApi(logger, service.NewService(logger, flowngineAdapter, balanceAdapter, nil, config.Receipt{}, config.PaymentRequests{}, nil, nil, config.DuplicateDetection{}, nil), config.Http{}).SetupRoutes(fiber.New())
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/api/v2/accounts/ACC000000001/summary", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	// Transfers may have been started with either, so FlowEngine is asked for both
+	request := <-engine.requests
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440001", request.AccountId)
+	assert.Equal(t, "ACC000000001", request.AccountNumber)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"account_number":"ACC000000001","account_name":"Alice",
+		"balance":{"value":"1000.00","currency":"USD"},
+		"status":"ACTIVE","account_type":"CHECKING",
+		"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-10-16T08:00:00Z","version":7,
+		"pending":[
+			{"currency":"EUR","outgoing":"0","incoming":"84.75"},
+			{"currency":"USD","outgoing":"110","incoming":"20"}
+		]
+	}`, string(body))
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"api-gateway/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// maxAdminAuditLimit caps the limit query parameter of GET /admin/audit
+const maxAdminAuditLimit = 1000
+
+// ListAdminAudit lists recorded state-changing admin calls, newest first, filtered by actor, action,
+// resource_type, resource_id and created-at range. from and to are RFC 3339 timestamps.
+func (api *Api) ListAdminAudit(c *fiber.Ctx) error {
+	const op = "api.Api.ListAdminAudit"
+
+	params := &service.ListAdminAuditParams{
+		Actor:        c.Query("actor"),
+		Action:       c.Query("action"),
+		ResourceType: c.Query("resource_type"),
+		ResourceID:   c.Query("resource_id"),
+	}
+
+	for name, target := range map[string]*time.Time{"from": &params.From, "to": &params.To} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("%s must be an RFC 3339 timestamp", name))
+		}
+		*target = parsed
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxAdminAuditLimit {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxAdminAuditLimit))
+		}
+		params.Limit = int32(limit)
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.ListAdminAudit(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		if errors.Is(err, service.ErrInvalidAdminAuditFilter) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to list admin audit")
+	}
+
+	return c.JSON(results)
+}
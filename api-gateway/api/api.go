@@ -1,38 +1,69 @@
 package api
 
 import (
+	"slices"
+	"time"
+
 	"api-gateway/middleware"
 	"api-gateway/service"
+	"api-gateway/util/config"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 )
 
+// HeaderTenant names the tenant a request is made for; it selects the account number format transfers are checked
+// against, and requests without it use the default format
+const HeaderTenant = "X-Tenant-ID"
+
+// HeaderPossibleDuplicate is set on transfers that repeat a recent transfer, to the transaction ID of the earlier one
+// or to "pending" while that one is still being started
+const HeaderPossibleDuplicate = "X-Possible-Duplicate"
+
 type Api struct {
 	logger *logrus.Logger
 
 	service *service.Service
+
+	httpConfig config.Http
 }
 
 func NewApi(
 	logger *logrus.Logger,
 	service *service.Service,
+	httpConfig config.Http,
 ) *Api {
 	return &Api{
 		logger: logger,
 
 		service: service,
+
+		httpConfig: httpConfig,
 	}
 }
 
+// legacyDeprecation applies to the unversioned routes, which predate /api/v1 and answer with the v1 shapes
+var legacyDeprecation = middleware.DeprecationPolicy{
+	Since:     time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC),
+	Successor: "/api/v1",
+}
+
 func (api *Api) SetupRoutes(app *fiber.App) *fiber.App {
+	// Panic recovery middleware
+	app.Use(middleware.Recover(api.logger))
+
 	// Error handler middleware
 	app.Use(middleware.ErrorHandler())
 
-	// Transfer Routes
-	transfer := app.Group("/transfer")
-	transfer.Post("/", api.Transfer)
-	transfer.Get("/:id", api.GetTransfer)
+	// Versioned Routes
+	v1 := app.Group("/api/v1")
+	api.setupRoutesV1(v1)
+
+	v2 := app.Group("/api/v2")
+	api.setupRoutesV2(v2)
+
+	// Legacy Routes
+	api.setupRoutesV1(app, middleware.Deprecation(legacyDeprecation))
 
 	// Health Check Routes
 	health := app.Group("/health")
@@ -42,5 +73,82 @@ func (api *Api) SetupRoutes(app *fiber.App) *fiber.App {
 	metrics := app.Group("/metrics")
 	metrics.Get("/", api.GetMetrics)
 
+	// Admin Routes
+	admin := app.Group("/admin")
+	admin.Get("/compensations", api.ListCompensations)
+	admin.Get("/compensations/stats", api.GetCompensationStats)
+	admin.Get("/audit", api.ListAdminAudit)
+	admin.Get("/overview", api.GetAdminOverview)
+	admin.Get("/accounts/low-balance", api.ListLowBalanceAccounts)
+	admin.Get("/accounts/dormant", api.ListDormantAccounts)
+
 	return app
 }
+
+// setupRoutesV1 registers the v1 routes, wrapped in the given middlewares. Their response shapes are frozen; changes go to v2.
+func (api *Api) setupRoutesV1(router fiber.Router, middlewares ...fiber.Handler) {
+	// Transfer Routes
+	transfer := router.Group("/transfer", slices.Concat(middlewares, []fiber.Handler{middleware.BodyLimit(api.httpConfig.MaxBodyBytes)})...)
+	transfer.Post("/", api.Transfer)
+	transfer.Get("/:id", middleware.ETag(), api.GetTransfer)
+	transfer.Get("/:id/receipt", api.GetTransferReceipt)
+	transfer.Get("/:id/timeline", api.GetTransferTimeline)
+	transfer.Post("/:id/cancel", api.CancelTransfer)
+	transfer.Post("/:id/approve", api.ApproveTransfer)
+
+	// ISO 20022 Routes (batches of payments need larger bodies)
+	iso20022 := router.Group("/iso20022", slices.Concat(middlewares, []fiber.Handler{middleware.BodyLimit(api.httpConfig.MaxBatchBodyBytes)})...)
+	iso20022.Post("/pain001", api.IngestPain001)
+}
+
+// setupRoutesV2 registers the v2 routes
+func (api *Api) setupRoutesV2(router fiber.Router) {
+	// Transfer Routes
+	transfer := router.Group("/transfer", middleware.BodyLimit(api.httpConfig.MaxBodyBytes))
+	transfer.Post("/", api.TransferV2)
+	transfer.Get("/:id", middleware.ETag(), api.GetTransferV2)
+	transfer.Get("/:id/receipt", api.GetTransferReceipt)
+	transfer.Get("/:id/timeline", api.GetTransferTimeline)
+	transfer.Post("/:id/cancel", api.CancelTransfer)
+	transfer.Post("/:id/approve", api.ApproveTransfer)
+
+	// Bulk Transfer Routes (uploaded files need larger bodies)
+	transfers := router.Group("/transfers", middleware.BodyLimit(api.httpConfig.MaxBatchBodyBytes))
+	transfers.Post("/upload", api.UploadTransfersV2)
+	transfers.Post("/status", api.GetTransferStatusesV2)
+
+	uploads := router.Group("/uploads")
+	uploads.Get("/:id", api.GetTransferUploadV2)
+
+	// Account Routes
+	accounts := router.Group("/accounts")
+	accounts.Post("/balances", api.GetBalances)
+	accounts.Get("/:account/summary", middleware.ETag(), api.GetAccountSummary)
+
+	// Currency Routes
+	currencies := router.Group("/currencies")
+	currencies.Get("/", middleware.ETag(), api.ListCurrencies)
+
+	// Payment Request Routes (QR codes): the payee creates a signed payload, the payer pays it after scanning
+	paymentRequests := router.Group("/payment-requests", middleware.BodyLimit(api.httpConfig.MaxBodyBytes))
+	paymentRequests.Post("/", api.CreatePaymentRequest)
+	paymentRequests.Post("/pay", api.PayPaymentRequest)
+
+	// Money Request Routes: the payee asks the payer for money, the payer accepts it into a transfer or declines it
+	moneyRequests := router.Group("/money-requests", middleware.BodyLimit(api.httpConfig.MaxBodyBytes))
+	moneyRequests.Post("/", api.RequestMoney)
+	moneyRequests.Get("/", api.ListMoneyRequests)
+	moneyRequests.Post("/:id/accept", api.AcceptMoneyRequest)
+	moneyRequests.Post("/:id/decline", api.DeclineMoneyRequest)
+
+	// Escrow Routes: the payer is debited up front, the amount is released to the payee or refunded by its deadline
+	escrows := router.Group("/escrows", middleware.BodyLimit(api.httpConfig.MaxBodyBytes))
+	escrows.Post("/", api.CreateEscrow)
+	escrows.Get("/:id", api.GetEscrow)
+	escrows.Post("/:id/release", api.ReleaseEscrow)
+	escrows.Post("/:id/refund", api.RefundEscrow)
+
+	// ISO 20022 Routes (batches of payments need larger bodies)
+	iso20022 := router.Group("/iso20022", middleware.BodyLimit(api.httpConfig.MaxBatchBodyBytes))
+	iso20022.Post("/pain001", api.IngestPain001)
+}
@@ -0,0 +1,160 @@
+package api
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api-gateway/util/config"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupRoutesDeprecation(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Malformed bodies are rejected before the service is called, so no service is needed
+	app := (&Api{logger: logger}).SetupRoutes(fiber.New())
+
+	tests := []struct {
+		path           string
+		wantDeprecated bool
+		wantSuccessor  string
+	}{
+		{path: "/transfer", wantDeprecated: true, wantSuccessor: `</api/v1/transfer>; rel="successor-version"`},
+		{path: "/api/v1/transfer", wantDeprecated: false},
+		{path: "/api/v2/transfer", wantDeprecated: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodPost, tt.path, strings.NewReader(`{`))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			assert.Equal(t, tt.wantDeprecated, resp.Header.Get("Deprecation") != "")
+			assert.Equal(t, tt.wantSuccessor, resp.Header.Get(fiber.HeaderLink))
+		})
+	}
+}
+
+func TestSetupRoutesBodyLimit(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	httpConfig := config.Http{MaxBodyBytes: 1024, MaxBatchBodyBytes: 4096}
+	app := (&Api{logger: logger, httpConfig: httpConfig}).SetupRoutes(fiber.New())
+
+	// Larger than the single transfer limit but within the batch limit, and not a valid document
+	body := strings.Repeat("x", 2048)
+
+	tests := []struct {
+		path       string
+		wantStatus int
+	}{
+		{path: "/transfer", wantStatus: fiber.StatusRequestEntityTooLarge},
+		{path: "/api/v1/transfer", wantStatus: fiber.StatusRequestEntityTooLarge},
+		{path: "/api/v2/transfer", wantStatus: fiber.StatusRequestEntityTooLarge},
+		{path: "/iso20022/pain001", wantStatus: fiber.StatusBadRequest},
+		{path: "/api/v2/iso20022/pain001", wantStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodPost, tt.path, strings.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestGetTransferRejectsInvalidWaitSeconds(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// The query is rejected before the service is called, so no service is needed
+	app := (&Api{logger: logger}).SetupRoutes(fiber.New())
+
+	for _, query := range []string{"wait_seconds=-1", "wait_seconds=31"} {
+		t.Run(query, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/api/v2/transfer/tx-1?"+query, nil))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestApproveTransferRequiresApprover(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// The body is rejected before the service is called, so no service is needed
+	app := (&Api{logger: logger}).SetupRoutes(fiber.New())
+
+	for _, body := range []string{`{}`, `{"approved_by":""}`, `{`} {
+		t.Run(body, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodPost, "/api/v2/transfer/tx-1/approve", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestAdminCompensationsRejectsInvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// The query is rejected before the service is called, so no service is needed
+	app := (&Api{logger: logger}).SetupRoutes(fiber.New())
+
+	tests := []string{
+		"/admin/compensations?from=yesterday",
+		"/admin/compensations?to=2025-06-01",
+		"/admin/compensations?limit=0",
+		"/admin/compensations?limit=1001",
+		"/admin/compensations/stats?from=2025-06-01T00:00:00",
+		"/admin/audit?from=yesterday",
+		"/admin/audit?limit=1001",
+	}
+
+	for _, target := range tests {
+		t.Run(target, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, target, nil))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
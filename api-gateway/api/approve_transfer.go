@@ -0,0 +1,52 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"api-gateway/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// ApproveTransfer releases a transfer held for approval by FlowEngine; transfers left unapproved expire.
+func (api *Api) ApproveTransfer(c *fiber.Ctx) error {
+	const op = "api.Api.ApproveTransfer"
+
+	var request struct {
+		ApprovedBy string `json:"approved_by"`
+	}
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+	if request.ApprovedBy == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "approved_by is required")
+	}
+
+	params := &service.ApproveTransferParams{
+		TransactionID: c.Params("id"),
+		ApprovedBy:    request.ApprovedBy,
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.ApproveTransfer(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		if errors.Is(err, service.ErrTransferNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to approve transfer")
+	}
+
+	return c.JSON(results)
+}
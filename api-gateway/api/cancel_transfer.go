@@ -0,0 +1,54 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"api-gateway/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// CancelTransfer asks FlowEngine to cancel a running transfer; completed debits are compensated by the workflow.
+func (api *Api) CancelTransfer(c *fiber.Ctx) error {
+	const op = "api.Api.CancelTransfer"
+
+	var request struct {
+		Reason string `json:"reason"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	params := &service.CancelTransferParams{
+		TransactionID: c.Params("id"),
+		Reason:        request.Reason,
+	}
+	if params.Reason == "" {
+		params.Reason = "Cancelled through the API gateway"
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.CancelTransfer(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		if errors.Is(err, service.ErrTransferNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to cancel transfer")
+	}
+
+	return c.JSON(results)
+}
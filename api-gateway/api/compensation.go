@@ -0,0 +1,120 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"api-gateway/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// maxCompensationsLimit caps the limit query parameter of GET /admin/compensations
+const maxCompensationsLimit = 1000
+
+// compensationFilter reads the status, type, from and to query parameters. from and to are RFC 3339 timestamps.
+func compensationFilter(c *fiber.Ctx) (service.CompensationFilter, error) {
+	filter := service.CompensationFilter{
+		Status: c.Query("status"),
+		Type:   c.Query("type"),
+	}
+
+	for name, target := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("%s must be an RFC 3339 timestamp", name))
+		}
+		*target = parsed
+	}
+
+	return filter, nil
+}
+
+// ListCompensations lists compensation audit records, newest first, filtered by status, type, workflow_id and
+// created-at range.
+func (api *Api) ListCompensations(c *fiber.Ctx) error {
+	const op = "api.Api.ListCompensations"
+
+	filter, err := compensationFilter(c)
+	if err != nil {
+		return err
+	}
+
+	params := &service.ListCompensationsParams{
+		Filter:     filter,
+		WorkflowID: c.Query("workflow_id"),
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxCompensationsLimit {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxCompensationsLimit))
+		}
+		params.Limit = int32(limit)
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.ListCompensations(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		if errors.Is(err, service.ErrInvalidCompensationFilter) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to list compensations")
+	}
+
+	return c.JSON(results)
+}
+
+// GetCompensationStats counts compensation audit records by outcome, filtered by status, type and created-at range.
+// Without from, the last 24 hours are counted.
+func (api *Api) GetCompensationStats(c *fiber.Ctx) error {
+	const op = "api.Api.GetCompensationStats"
+
+	filter, err := compensationFilter(c)
+	if err != nil {
+		return err
+	}
+
+	params := &service.GetCompensationStatsParams{
+		Filter: filter,
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.GetCompensationStats(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		if errors.Is(err, service.ErrInvalidCompensationFilter) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get compensation stats")
+	}
+
+	return c.JSON(results)
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"errors"
+
+	"api-gateway/middleware"
+	"api-gateway/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// currencyV2 is a currency transfers can be made in
+type currencyV2 struct {
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	Symbol        string `json:"symbol"`
+	DecimalPlaces int    `json:"decimal_places"`
+}
+
+// currenciesV2 is returned by GET /api/v2/currencies
+type currenciesV2 struct {
+	Currencies []currencyV2 `json:"currencies"`
+}
+
+// ListCurrencies handles GET /api/v2/currencies with the active currencies ordered by code. The list comes from
+// the catalog the gateway refreshes every few minutes, so clients may cache it for as long.
+func (api *Api) ListCurrencies(c *fiber.Ctx) error {
+	const op = "api.Api.ListCurrencies"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	logger.Info()
+
+	// Call service
+	currencies, err := api.service.ListCurrencies(c.Context())
+	if err != nil {
+		logger.WithError(err).Error()
+
+		if errors.Is(err, service.ErrCurrencyCatalogUnavailable) {
+			return fiber.NewError(fiber.StatusServiceUnavailable, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to list currencies")
+	}
+
+	response := currenciesV2{Currencies: make([]currencyV2, 0, len(currencies))}
+	for _, currency := range currencies {
+		response.Currencies = append(response.Currencies, currencyV2{
+			Code:          currency.Code,
+			Name:          currency.Name,
+			Symbol:        currency.Symbol,
+			DecimalPlaces: currency.DecimalPlace,
+		})
+	}
+
+	c.Set(fiber.HeaderCacheControl, middleware.CacheControlCatalog)
+
+	return c.JSON(response)
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HeaderRequestTimeout lets clients say how long they are willing to wait for a response
+const HeaderRequestTimeout = "X-Request-Timeout"
+
+var errInvalidRequestTimeout = errors.New("X-Request-Timeout must be a positive number of seconds or a duration such as 1500ms")
+
+// requestTimeout reads the X-Request-Timeout header, given in seconds ("30", "2.5") or as a duration ("1500ms").
+// It returns 0 when the header is absent.
+func requestTimeout(c *fiber.Ctx) (time.Duration, error) {
+	value := strings.TrimSpace(c.Get(HeaderRequestTimeout))
+	if value == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		seconds, parseErr := strconv.ParseFloat(value, 64)
+		if parseErr != nil {
+			return 0, errInvalidRequestTimeout
+		}
+
+		timeout = time.Duration(seconds * float64(time.Second))
+	}
+
+	if timeout <= 0 {
+		return 0, errInvalidRequestTimeout
+	}
+
+	return timeout, nil
+}
+
+// transferStatusURL returns the URL a transfer created at the current path can be polled at
+func transferStatusURL(c *fiber.Ctx, id string) string {
+	return strings.TrimSuffix(c.Path(), "/") + "/" + url.PathEscape(id)
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		header  string
+		want    time.Duration
+		wantErr bool
+	}{
+		{header: "", want: 0},
+		{header: "30", want: 30 * time.Second},
+		{header: "2.5", want: 2500 * time.Millisecond},
+		{header: "1500ms", want: 1500 * time.Millisecond},
+		{header: " 1m ", want: time.Minute},
+		{header: "0", wantErr: true},
+		{header: "-5", wantErr: true},
+		{header: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			t.Parallel()
+
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				got, err := requestTimeout(c)
+				if tt.wantErr {
+					assert.ErrorIs(t, err, errInvalidRequestTimeout)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, tt.want, got)
+				}
+
+				return nil
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(HeaderRequestTimeout, tt.header)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+		})
+	}
+}
+
+func TestTransferRejectsInvalidRequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// The header is rejected before the service is called, so no service is needed
+	app := (&Api{logger: logger}).SetupRoutes(fiber.New())
+
+	req := httptest.NewRequest(fiber.MethodPost, "/api/v2/transfer", strings.NewReader(`{}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(HeaderRequestTimeout, "soon")
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"api-gateway/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTransferResults() *service.TransferResults {
+	completedAt := "2026-10-16T10:00:05Z"
+	compensated := false
+
+	return &service.TransferResults{
+		TransactionID:       "0f8fad5b-d9cb-469f-a165-70867728950e",
+		Status:              "TRANSFER_STATUS_COMPLETED",
+		FromAccount:         "ACC001000001",
+		ToAccount:           "ACC001000002",
+		Amount:              10050,
+		AmountDecimal:       "100.50",
+		Currency:            "USD",
+		Description:         "Rent",
+		ReferenceID:         "REF-1",
+		CreatedAt:           "2026-10-16T10:00:00Z",
+		EstimatedCompletion: "2026-10-16T10:02:00Z",
+		CompletedAt:         &completedAt,
+		CompensationApplied: &compensated,
+		WorkflowID:          "transfer_workflow_0f8fad5b",
+		RunID:               "run-1",
+		TransferReference:   "261016-001-000042-2",
+	}
+}
+
+func TestTransferResponseV1MatchesServiceResults(t *testing.T) {
+	t.Parallel()
+
+	results := testTransferResults()
+
+	// v1 must keep serializing exactly like the service results it was extracted from
+	want, err := json.Marshal(results)
+	require.NoError(t, err)
+	got, err := json.Marshal(newTransferResponseV1(results))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(want), string(got))
+}
+
+func TestGetTransferResponseV1MatchesServiceResults(t *testing.T) {
+	t.Parallel()
+
+	results := &service.GetTransferResults{
+		TransactionID: "0f8fad5b-d9cb-469f-a165-70867728950e",
+		Status:        "TRANSFER_STATUS_COMPENSATED",
+		Amount:        1050,
+		AmountDecimal: "1050",
+		Currency:      "JPY",
+	}
+	results.WorkflowExecution.WorkflowID = "transfer_workflow_0f8fad5b"
+	results.WorkflowExecution.Status = "COMPLETED"
+
+	want, err := json.Marshal(results)
+	require.NoError(t, err)
+	got, err := json.Marshal(newGetTransferResponseV1(results))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(want), string(got))
+}
+
+func TestTransferV2(t *testing.T) {
+	t.Parallel()
+
+	response := newTransferV2(testTransferResults())
+
+	assert.Equal(t, "COMPLETED", response.Status)
+	assert.Equal(t, moneyV2{MinorUnits: 10050, Value: "100.50", Currency: "USD"}, response.Amount)
+	assert.Equal(t, moneyV2{MinorUnits: 0, Value: "0.00", Currency: "USD"}, response.Fee)
+	assert.Nil(t, response.FX)
+	assert.Equal(t, "2026-10-16T10:00:05Z", response.CompletedAt)
+	assert.Equal(t, "transfer_workflow_0f8fad5b", response.Workflow.WorkflowID)
+
+	encoded, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"fx":null`)
+	assert.NotContains(t, string(encoded), `"possible_duplicate"`)
+}
+
+func TestTransferV2PossibleDuplicate(t *testing.T) {
+	t.Parallel()
+
+	results := testTransferResults()
+	results.PossibleDuplicate = &service.PossibleDuplicate{
+		TransactionID: "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+		CreatedAt:     time.Date(2026, time.October, 16, 9, 58, 0, 0, time.UTC),
+	}
+
+	response := newTransferV2(results)
+	assert.Equal(t, &possibleDuplicateV2{TransactionID: "7c9e6679-7425-40de-944b-e07fc1f90ae7", CreatedAt: "2026-10-16T09:58:00Z"}, response.PossibleDuplicate)
+
+	// v1 is frozen, the duplicate is only flagged by the response header there
+	encoded, err := json.Marshal(newTransferResponseV1(results))
+	require.NoError(t, err)
+	assert.NotContains(t, string(encoded), "possible_duplicate")
+}
+
+func TestTransferV2FromStatus(t *testing.T) {
+	t.Parallel()
+
+	results := &service.GetTransferResults{
+		Status:        "TRANSFER_STATUS_COMPENSATED",
+		Amount:        1050,
+		AmountDecimal: "1050",
+		Currency:      "JPY",
+		Steps: []service.TransferStep{
+			{Activity: "DebitAccount", Status: "completed", Attempts: 3, LastError: "simulated failure", Summary: "DebitAccount completed on attempt 3 after: simulated failure"},
+		},
+	}
+
+	response := newTransferV2FromStatus(results)
+
+	assert.Equal(t, "COMPENSATED", response.Status)
+	assert.True(t, response.CompensationApplied)
+	assert.Equal(t, moneyV2{MinorUnits: 0, Value: "0", Currency: "JPY"}, response.Fee)
+	assert.Equal(t, []stepV2{
+		{Activity: "DebitAccount", Status: "completed", Attempts: 3, LastError: "simulated failure", Summary: "DebitAccount completed on attempt 3 after: simulated failure"},
+	}, response.Steps)
+}
+
+func TestTransferV2FromStatusConverted(t *testing.T) {
+	t.Parallel()
+
+	results := &service.GetTransferResults{
+		Status:        "TRANSFER_STATUS_COMPLETED",
+		Amount:        100000,
+		AmountDecimal: "1000.00",
+		Currency:      "USD",
+		FX: &service.TransferFX{
+			Pair:                   "EUR/USD",
+			Side:                   "ASK",
+			Rate:                   "0.847458",
+			MidRate:                "1.176471",
+			SpreadBps:              10,
+			MarginBps:              25,
+			ConvertedCurrency:      "EUR",
+			ConvertedAmount:        84746,
+			ConvertedAmountDecimal: "847.46",
+			SpreadAmount:           254,
+			SpreadAmountDecimal:    "2.54",
+		},
+	}
+
+	response := newTransferV2FromStatus(results)
+
+	require.NotNil(t, response.FX)
+	assert.Equal(t, "EUR/USD", response.FX.Pair)
+	assert.Equal(t, "ASK", response.FX.Side)
+	assert.Equal(t, int64(10), response.FX.SpreadBps)
+	assert.Equal(t, int64(25), response.FX.MarginBps)
+	assert.Equal(t, moneyV2{MinorUnits: 100000, Value: "1000.00", Currency: "USD"}, response.FX.SourceAmount)
+	assert.Equal(t, moneyV2{MinorUnits: 84746, Value: "847.46", Currency: "EUR"}, response.FX.ConvertedAmount)
+	assert.Equal(t, moneyV2{MinorUnits: 254, Value: "2.54", Currency: "EUR"}, response.FX.SpreadAmount)
+
+	// v1 is frozen, the conversion is only reported by v2
+	encoded, err := json.Marshal(newGetTransferResponseV1(results))
+	require.NoError(t, err)
+	assert.NotContains(t, string(encoded), "EUR/USD")
+}
+
+func TestTransferV2FromStatusAwaitingFunds(t *testing.T) {
+	t.Parallel()
+
+	results := &service.GetTransferResults{
+		Status: "TRANSFER_STATUS_AWAITING_FUNDS",
+		Wait: &service.TransferWait{
+			Reason:        "funds",
+			Since:         "2026-01-02T10:00:00Z",
+			Deadline:      "2026-01-03T10:00:00Z",
+			BalanceChecks: 3,
+			NextCheckAt:   "2026-01-02T10:07:00Z",
+		},
+	}
+
+	response := newTransferV2FromStatus(results)
+
+	assert.Equal(t, "AWAITING_FUNDS", response.Status)
+	require.NotNil(t, response.Wait)
+	assert.Equal(t, waitV2{
+		Reason:        "funds",
+		Since:         "2026-01-02T10:00:00Z",
+		Deadline:      "2026-01-03T10:00:00Z",
+		BalanceChecks: 3,
+		NextCheckAt:   "2026-01-02T10:07:00Z",
+	}, *response.Wait)
+}
+
+func TestTransferRequestV2ToParams(t *testing.T) {
+	t.Parallel()
+
+	var req transferRequestV2
+	require.NoError(t, json.Unmarshal([]byte(`{"from_account":"ACC001000001","to_account":"ACC001000002","amount":{"value":"100.50","currency":"USD"}}`), &req))
+
+	params := req.toParams()
+	require.NotNil(t, params.AmountDecimal)
+	assert.Equal(t, "100.50", *params.AmountDecimal)
+	assert.Equal(t, "USD", params.Currency)
+	assert.Zero(t, params.Amount)
+
+	// A missing value is left unset so the service reports it
+	req.Amount.Value = ""
+	assert.Nil(t, req.toParams().AmountDecimal)
+}
+
+func TestTransferRequestV2ToParamsTrigger(t *testing.T) {
+	t.Parallel()
+
+	var req transferRequestV2
+	require.NoError(t, json.Unmarshal([]byte(`{"from_account":"ACC001000001","to_account":"ACC001000002","amount":{"value":"100.50","currency":"USD"},"trigger":{"account":"ACC001000003","value":"500.00"}}`), &req))
+
+	params := req.toParams()
+	require.NotNil(t, params.Trigger)
+	assert.Equal(t, service.TransferTrigger{Account: "ACC001000003", AmountDecimal: "500.00"}, *params.Trigger)
+
+	req.Trigger = nil
+	assert.Nil(t, req.toParams().Trigger)
+}
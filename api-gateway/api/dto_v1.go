@@ -0,0 +1,126 @@
+package api
+
+import (
+	"api-gateway/service"
+)
+
+// v1 DTOs keep the original response shapes of the gateway. They are frozen: new fields go to v2.
+
+// transferRequestV1 is the JSON body accepted by POST /api/v1/transfer (and the legacy POST /transfer)
+type transferRequestV1 struct {
+	FromAccount       string  `json:"from_account" validate:"required,min=12,max=12"`
+	ToAccount         string  `json:"to_account" validate:"required,min=12,max=12"`
+	Amount            int     `json:"amount" validate:"required_without=AmountDecimal,omitempty,min=1,max=1000000000"` // Minor units of the currency (cents, yen)
+	AmountDecimal     *string `json:"amount_decimal" validate:"required_without=Amount,omitempty,max=32"`              // Major units, e.g. "100.50"
+	Currency          string  `json:"currency" validate:"required,min=3,max=3"`
+	Description       *string `json:"description" validate:"max=100"`
+	ReferenceID       *string `json:"reference_id" validate:"max=50"`
+	WaitForCompletion *bool   `json:"wait_for_completion"`
+}
+
+func (req transferRequestV1) toParams() *service.TransferParams {
+	// Default to async mode if not specified
+	waitForCompletion := false
+	if req.WaitForCompletion != nil {
+		waitForCompletion = *req.WaitForCompletion
+	}
+
+	return &service.TransferParams{
+		FromAccount:       req.FromAccount,
+		ToAccount:         req.ToAccount,
+		Amount:            req.Amount,
+		AmountDecimal:     req.AmountDecimal,
+		Currency:          req.Currency,
+		Description:       req.Description,
+		ReferenceID:       req.ReferenceID,
+		WaitForCompletion: waitForCompletion,
+	}
+}
+
+// transferResponseV1 is returned by POST /api/v1/transfer
+type transferResponseV1 struct {
+	TransactionID       string  `json:"transaction_id"`
+	Status              string  `json:"status"`
+	FromAccount         string  `json:"from_account"`
+	ToAccount           string  `json:"to_account"`
+	Amount              int     `json:"amount"`
+	AmountDecimal       string  `json:"amount_decimal"`
+	Currency            string  `json:"currency"`
+	Description         string  `json:"description"`
+	ReferenceID         string  `json:"reference_id"`
+	CreatedAt           string  `json:"created_at"`
+	EstimatedCompletion string  `json:"estimated_completion"`
+	CompletedAt         *string `json:"completed_at,omitempty"`
+	ErrorMessage        string  `json:"error_message,omitempty"`
+	CompensationApplied *bool   `json:"compensation_applied,omitempty"`
+	WorkflowID          string  `json:"workflow_id"`
+	RunID               string  `json:"run_id"`
+	TransferReference   string  `json:"transfer_reference,omitempty"`
+}
+
+func newTransferResponseV1(results *service.TransferResults) transferResponseV1 {
+	return transferResponseV1{
+		TransactionID:       results.TransactionID,
+		Status:              results.Status,
+		FromAccount:         results.FromAccount,
+		ToAccount:           results.ToAccount,
+		Amount:              results.Amount,
+		AmountDecimal:       results.AmountDecimal,
+		Currency:            results.Currency,
+		Description:         results.Description,
+		ReferenceID:         results.ReferenceID,
+		CreatedAt:           results.CreatedAt,
+		EstimatedCompletion: results.EstimatedCompletion,
+		CompletedAt:         results.CompletedAt,
+		ErrorMessage:        results.ErrorMessage,
+		CompensationApplied: results.CompensationApplied,
+		WorkflowID:          results.WorkflowID,
+		RunID:               results.RunID,
+		TransferReference:   results.TransferReference,
+	}
+}
+
+// getTransferResponseV1 is returned by GET /api/v1/transfer/:id
+type getTransferResponseV1 struct {
+	TransactionID     string              `json:"transaction_id"`
+	Status            string              `json:"status"`
+	FromAccount       string              `json:"from_account"`
+	ToAccount         string              `json:"to_account"`
+	Amount            int                 `json:"amount"`
+	AmountDecimal     string              `json:"amount_decimal"`
+	Currency          string              `json:"currency"`
+	Description       string              `json:"description"`
+	ReferenceID       string              `json:"reference_id"`
+	CreatedAt         string              `json:"created_at"`
+	CompletedAt       string              `json:"completed_at"`
+	WorkflowExecution workflowExecutionV1 `json:"workflow_execution"`
+	TransferReference string              `json:"transfer_reference,omitempty"`
+}
+
+type workflowExecutionV1 struct {
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+	Status     string `json:"status"`
+}
+
+func newGetTransferResponseV1(results *service.GetTransferResults) getTransferResponseV1 {
+	return getTransferResponseV1{
+		TransactionID: results.TransactionID,
+		Status:        results.Status,
+		FromAccount:   results.FromAccount,
+		ToAccount:     results.ToAccount,
+		Amount:        results.Amount,
+		AmountDecimal: results.AmountDecimal,
+		Currency:      results.Currency,
+		Description:   results.Description,
+		ReferenceID:   results.ReferenceID,
+		CreatedAt:     results.CreatedAt,
+		CompletedAt:   results.CompletedAt,
+		WorkflowExecution: workflowExecutionV1{
+			WorkflowID: results.WorkflowExecution.WorkflowID,
+			RunID:      results.WorkflowExecution.RunID,
+			Status:     results.WorkflowExecution.Status,
+		},
+		TransferReference: results.TransferReference,
+	}
+}
@@ -0,0 +1,369 @@
+package api
+
+import (
+	"strings"
+	"time"
+
+	"api-gateway/service"
+)
+
+// v2 DTOs group amounts into money objects, shorten status names and reserve a fee field.
+
+// moneyV2 is an amount in a single currency, given both in minor units and as an exact decimal
+type moneyV2 struct {
+	MinorUnits int64  `json:"minor_units"`
+	Value      string `json:"value"` // Major units with the currency's decimal places, e.g. "100.50"
+	Currency   string `json:"currency"`
+}
+
+// fxV2 describes a currency conversion applied to a transfer
+type fxV2 struct {
+	Pair            string  `json:"pair"` // e.g. "EUR/USD"
+	Side            string  `json:"side"` // "BID" when selling the base currency, "ASK" when buying it
+	Rate            string  `json:"rate"` // Applied rate, converted currency per source currency
+	MidRate         string  `json:"mid_rate"`
+	SpreadBps       int64   `json:"spread_bps"`
+	MarginBps       int64   `json:"margin_bps"`
+	SourceAmount    moneyV2 `json:"source_amount"`
+	ConvertedAmount moneyV2 `json:"converted_amount"`
+	SpreadAmount    moneyV2 `json:"spread_amount"` // What the spread and margin cost, in the converted currency
+}
+
+// newFXV2 returns the conversion applied to a transfer of source, nil for same-currency transfers
+func newFXV2(fx *service.TransferFX, source moneyV2) *fxV2 {
+	if fx == nil {
+		return nil
+	}
+
+	return &fxV2{
+		Pair:            fx.Pair,
+		Side:            fx.Side,
+		Rate:            fx.Rate,
+		MidRate:         fx.MidRate,
+		SpreadBps:       fx.SpreadBps,
+		MarginBps:       fx.MarginBps,
+		SourceAmount:    source,
+		ConvertedAmount: moneyV2{MinorUnits: fx.ConvertedAmount, Value: fx.ConvertedAmountDecimal, Currency: fx.ConvertedCurrency},
+		SpreadAmount:    moneyV2{MinorUnits: fx.SpreadAmount, Value: fx.SpreadAmountDecimal, Currency: fx.ConvertedCurrency},
+	}
+}
+
+// transferRequestV2 is the JSON body accepted by POST /api/v2/transfer
+type transferRequestV2 struct {
+	FromAccount string `json:"from_account" validate:"required,min=12,max=12"`
+	ToAccount   string `json:"to_account" validate:"required_without=ToAlias,omitempty,min=12,max=12"`
+	ToAlias     string `json:"to_alias" validate:"omitempty,max=320"` // Phone number such as +14155550101 or email address, instead of to_account
+	Amount      struct {
+		Value    string `json:"value" validate:"required,max=32"` // Major units, e.g. "100.50"
+		Currency string `json:"currency" validate:"required,min=3,max=3"`
+	} `json:"amount"`
+	Description       *string `json:"description" validate:"max=100"`
+	ReferenceID       *string `json:"reference_id" validate:"max=50"`
+	WaitForCompletion bool    `json:"wait_for_completion"`
+	CallbackURL       *string `json:"callback_url" validate:"omitempty,url"` // Receives a webhook if the transfer expires awaiting approval, funds or its trigger
+	WaitForFunds      bool    `json:"wait_for_funds"`                        // Wait for incoming credits instead of failing on insufficient funds
+	Trigger           *struct {
+		Account string `json:"account" validate:"required,max=64"` // Account number or ID that has to be credited
+		Value   string `json:"value" validate:"required,max=32"`   // Major units in the transfer currency, e.g. "500.00"
+	} `json:"trigger"` // Hold the transfer until the account has received the value
+	ConfirmDuplicate bool `json:"confirm_duplicate"` // Start the transfer even if it repeats a recent one without a reference_id
+}
+
+func (req transferRequestV2) toParams() *service.TransferParams {
+	params := &service.TransferParams{
+		FromAccount:       req.FromAccount,
+		ToAccount:         req.ToAccount,
+		ToAlias:           req.ToAlias,
+		Currency:          req.Amount.Currency,
+		Description:       req.Description,
+		ReferenceID:       req.ReferenceID,
+		WaitForCompletion: req.WaitForCompletion,
+		CallbackURL:       req.CallbackURL,
+		WaitForFunds:      req.WaitForFunds,
+		ConfirmDuplicate:  req.ConfirmDuplicate,
+	}
+
+	if req.Trigger != nil {
+		params.Trigger = &service.TransferTrigger{Account: req.Trigger.Account, AmountDecimal: req.Trigger.Value}
+	}
+
+	// Leaving AmountDecimal unset lets the service report the missing amount
+	if req.Amount.Value != "" {
+		params.AmountDecimal = &req.Amount.Value
+	}
+
+	return params
+}
+
+// transferV2 is returned by both POST /api/v2/transfer and GET /api/v2/transfer/:id
+type transferV2 struct {
+	TransactionID       string               `json:"transaction_id"`
+	TransferReference   string               `json:"transfer_reference,omitempty"`
+	Status              string               `json:"status"` // e.g. "COMPLETED"
+	FromAccount         string               `json:"from_account"`
+	ToAccount           string               `json:"to_account"`
+	Amount              moneyV2              `json:"amount"`
+	Fee                 moneyV2              `json:"fee"` // Zero while transfers are free of charge
+	FX                  *fxV2                `json:"fx"`  // Null for same-currency transfers
+	Description         string               `json:"description"`
+	ReferenceID         string               `json:"reference_id"`
+	CreatedAt           string               `json:"created_at"`
+	EstimatedCompletion string               `json:"estimated_completion,omitempty"`
+	CompletedAt         string               `json:"completed_at,omitempty"`
+	ErrorMessage        string               `json:"error_message,omitempty"`
+	CompensationApplied bool                 `json:"compensation_applied"`
+	Workflow            workflowV2           `json:"workflow"`
+	StatusURL           string               `json:"status_url,omitempty"`         // Set on 202 Accepted, where the outcome can be polled
+	Steps               []stepV2             `json:"steps,omitempty"`              // Saga steps with their attempts, returned by GET
+	Wait                *waitV2              `json:"wait,omitempty"`               // Set by GET while the status is AWAITING_APPROVAL, AWAITING_FUNDS or AWAITING_TRIGGER
+	PossibleDuplicate   *possibleDuplicateV2 `json:"possible_duplicate,omitempty"` // Set by POST when the transfer repeats a recent one
+}
+
+// possibleDuplicateV2 is a recent transfer with the same accounts, amount and currency, started before this one
+type possibleDuplicateV2 struct {
+	TransactionID string `json:"transaction_id,omitempty"` // Empty while the earlier transfer is still being started
+	CreatedAt     string `json:"created_at"`
+}
+
+// waitV2 is what a transfer waits for before its debit, e.g. funds re-checked until the deadline
+type waitV2 struct {
+	Reason         string `json:"reason"` // "approval", "funds" or "trigger"
+	Since          string `json:"since"`
+	Deadline       string `json:"deadline,omitempty"`
+	BalanceChecks  int32  `json:"balance_checks,omitempty"`
+	NextCheckAt    string `json:"next_check_at,omitempty"`
+	TriggerAccount string `json:"trigger_account,omitempty"`
+	AmountReceived string `json:"amount_received,omitempty"` // Credited to the trigger account so far, e.g. "120.00"
+}
+
+// stepV2 is one activity of the transfer saga, so clients can show e.g. "Debit succeeded on attempt 3"
+type stepV2 struct {
+	Activity     string `json:"activity"`
+	Status       string `json:"status"`
+	Attempts     int32  `json:"attempts"`
+	LastError    string `json:"last_error,omitempty"`
+	Compensation bool   `json:"compensation"`
+	Summary      string `json:"summary"`
+}
+
+type workflowV2 struct {
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+	Status     string `json:"status,omitempty"`
+}
+
+func newTransferV2(results *service.TransferResults) transferV2 {
+	response := transferV2{
+		TransactionID:       results.TransactionID,
+		TransferReference:   results.TransferReference,
+		Status:              transferStatusV2(results.Status),
+		FromAccount:         results.FromAccount,
+		ToAccount:           results.ToAccount,
+		Amount:              moneyV2{MinorUnits: int64(results.Amount), Value: results.AmountDecimal, Currency: results.Currency},
+		Fee:                 zeroMoneyV2(results.AmountDecimal, results.Currency),
+		Description:         results.Description,
+		ReferenceID:         results.ReferenceID,
+		CreatedAt:           results.CreatedAt,
+		EstimatedCompletion: results.EstimatedCompletion,
+		ErrorMessage:        results.ErrorMessage,
+		Workflow: workflowV2{
+			WorkflowID: results.WorkflowID,
+			RunID:      results.RunID,
+		},
+	}
+
+	if results.CompletedAt != nil {
+		response.CompletedAt = *results.CompletedAt
+	}
+	if results.CompensationApplied != nil {
+		response.CompensationApplied = *results.CompensationApplied
+	}
+	response.FX = newFXV2(results.FX, response.Amount)
+	if results.PossibleDuplicate != nil {
+		response.PossibleDuplicate = &possibleDuplicateV2{
+			TransactionID: results.PossibleDuplicate.TransactionID,
+			CreatedAt:     results.PossibleDuplicate.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	return response
+}
+
+func newTransferV2FromStatus(results *service.GetTransferResults) transferV2 {
+	response := transferV2{
+		TransactionID:       results.TransactionID,
+		TransferReference:   results.TransferReference,
+		Status:              transferStatusV2(results.Status),
+		FromAccount:         results.FromAccount,
+		ToAccount:           results.ToAccount,
+		Amount:              moneyV2{MinorUnits: int64(results.Amount), Value: results.AmountDecimal, Currency: results.Currency},
+		Fee:                 zeroMoneyV2(results.AmountDecimal, results.Currency),
+		Description:         results.Description,
+		ReferenceID:         results.ReferenceID,
+		CreatedAt:           results.CreatedAt,
+		CompletedAt:         results.CompletedAt,
+		CompensationApplied: results.Status == "TRANSFER_STATUS_COMPENSATED",
+		Workflow: workflowV2{
+			WorkflowID: results.WorkflowExecution.WorkflowID,
+			RunID:      results.WorkflowExecution.RunID,
+			Status:     results.WorkflowExecution.Status,
+		},
+	}
+
+	response.FX = newFXV2(results.FX, response.Amount)
+
+	for _, step := range results.Steps {
+		response.Steps = append(response.Steps, stepV2(step))
+	}
+	if results.Wait != nil {
+		wait := waitV2(*results.Wait)
+		response.Wait = &wait
+	}
+
+	return response
+}
+
+// transferStatusV2 drops the enum prefix of FlowEngine statuses, e.g. TRANSFER_STATUS_COMPLETED -> COMPLETED
+func transferStatusV2(status string) string {
+	return strings.TrimPrefix(status, "TRANSFER_STATUS_")
+}
+
+// zeroMoneyV2 returns a zero amount written with the same decimal places as value, e.g. "100.50" -> "0.00"
+func zeroMoneyV2(value string, currency string) moneyV2 {
+	zero := "0"
+	if _, fraction, ok := strings.Cut(value, "."); ok {
+		zero += "." + strings.Repeat("0", len(fraction))
+	}
+
+	return moneyV2{Value: zero, Currency: currency}
+}
+
+// transferUploadV2 is returned by POST /api/v2/transfers/upload
+type transferUploadV2 struct {
+	UploadID  string                `json:"upload_id,omitempty"` // Unset when every row was rejected
+	Accepted  int                   `json:"accepted"`
+	Rejected  int                   `json:"rejected"`
+	Rows      []transferUploadRowV2 `json:"rows"`
+	Workflow  *workflowV2           `json:"workflow,omitempty"`
+	CreatedAt string                `json:"created_at,omitempty"`
+	StatusURL string                `json:"status_url,omitempty"` // Where the progress of the upload can be polled
+}
+
+// transferUploadProgressV2 is returned by GET /api/v2/uploads/:id
+type transferUploadProgressV2 struct {
+	UploadID    string                `json:"upload_id"`
+	Total       int                   `json:"total"` // Accepted rows only; rejected rows are reported by the upload
+	Pending     int                   `json:"pending"`
+	Processing  int                   `json:"processing"`
+	Completed   int                   `json:"completed"`
+	Failed      int                   `json:"failed"` // Failed, compensated or expired
+	Rows        []transferUploadRowV2 `json:"rows"`
+	Workflow    workflowV2            `json:"workflow"`
+	StartedAt   string                `json:"started_at,omitempty"`
+	CompletedAt string                `json:"completed_at,omitempty"`
+}
+
+// transferUploadRowV2 is the outcome of one line of an uploaded CSV
+type transferUploadRowV2 struct {
+	Row               int32  `json:"row"`    // Line in the file, the header being line 1
+	Status            string `json:"status"` // e.g. "PENDING", "COMPLETED" or "REJECTED"
+	TransactionID     string `json:"transaction_id,omitempty"`
+	TransferReference string `json:"transfer_reference,omitempty"`
+	ErrorMessage      string `json:"error_message,omitempty"`
+}
+
+func newTransferUploadV2(results *service.UploadTransfersResults) transferUploadV2 {
+	response := transferUploadV2{
+		UploadID:  results.UploadID,
+		Accepted:  results.Accepted,
+		Rejected:  results.Rejected,
+		Rows:      newTransferUploadRowsV2(results.Rows),
+		CreatedAt: results.CreatedAt,
+	}
+
+	if results.WorkflowID != "" {
+		response.Workflow = &workflowV2{WorkflowID: results.WorkflowID, RunID: results.RunID}
+	}
+
+	return response
+}
+
+func newTransferUploadProgressV2(results *service.GetTransferUploadResults) transferUploadProgressV2 {
+	return transferUploadProgressV2{
+		UploadID:   results.UploadID,
+		Total:      results.Total,
+		Pending:    results.Pending,
+		Processing: results.Processing,
+		Completed:  results.Completed,
+		Failed:     results.Failed,
+		Rows:       newTransferUploadRowsV2(results.Rows),
+		Workflow: workflowV2{
+			WorkflowID: results.WorkflowExecution.WorkflowID,
+			RunID:      results.WorkflowExecution.RunID,
+			Status:     results.WorkflowExecution.Status,
+		},
+		StartedAt:   results.StartedAt,
+		CompletedAt: results.CompletedAt,
+	}
+}
+
+func newTransferUploadRowsV2(rows []service.TransferUploadRow) []transferUploadRowV2 {
+	response := make([]transferUploadRowV2, 0, len(rows))
+	for _, row := range rows {
+		response = append(response, transferUploadRowV2{
+			Row:               row.Row,
+			Status:            transferStatusV2(row.Status),
+			TransactionID:     row.TransactionID,
+			TransferReference: row.TransferReference,
+			ErrorMessage:      row.ErrorMessage,
+		})
+	}
+
+	return response
+}
+
+// transferStatusesRequestV2 is the JSON body accepted by POST /api/v2/transfers/status
+type transferStatusesRequestV2 struct {
+	TransactionIDs []string `json:"transaction_ids"` // Transaction IDs or transfer references, at most 100
+}
+
+// transferStatusesV2 is returned by POST /api/v2/transfers/status
+type transferStatusesV2 struct {
+	Transfers []transferStatusItemV2 `json:"transfers"` // One per requested ID, in request order
+}
+
+// transferStatusItemV2 is the status of one requested transfer; either transfer or error is set
+type transferStatusItemV2 struct {
+	RequestedID string                 `json:"requested_id"`
+	Transfer    *transferV2            `json:"transfer,omitempty"`
+	Error       *transferLookupErrorV2 `json:"error,omitempty"`
+}
+
+// transferLookupErrorV2 tells why a transfer could not be looked up, with the codes of the error responses
+type transferLookupErrorV2 struct {
+	Code      string `json:"code"` // e.g. TRANSFER_NOT_FOUND
+	Message   string `json:"message"`
+	Retryable bool   `json:"retryable"`
+}
+
+func newTransferStatusesV2(results *service.GetTransfersResults) transferStatusesV2 {
+	response := transferStatusesV2{Transfers: make([]transferStatusItemV2, 0, len(results.Transfers))}
+	for _, item := range results.Transfers {
+		status := transferStatusItemV2{RequestedID: item.RequestedID}
+		if item.Transfer != nil {
+			transfer := newTransferV2FromStatus(item.Transfer)
+			status.Transfer = &transfer
+		}
+		if item.Error != nil {
+			status.Error = &transferLookupErrorV2{
+				Code:      item.Error.Code,
+				Message:   item.Error.Message,
+				Retryable: item.Error.Retryable,
+			}
+		}
+
+		response.Transfers = append(response.Transfers, status)
+	}
+
+	return response
+}
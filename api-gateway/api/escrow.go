@@ -0,0 +1,200 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"api-gateway/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/status"
+)
+
+// escrowRequestV2 is the JSON body accepted by POST /api/v2/escrows
+type escrowRequestV2 struct {
+	FromAccount string `json:"from_account" validate:"required,min=12,max=12"`
+	ToAccount   string `json:"to_account" validate:"required,min=12,max=12"`
+	Amount      struct {
+		Value    string `json:"value" validate:"required,max=32"` // Major units, e.g. "12.50"
+		Currency string `json:"currency" validate:"required,min=3,max=3"`
+	} `json:"amount"`
+	Description     *string `json:"description" validate:"max=100"`
+	Arbiter         string  `json:"arbiter"`
+	DeadlineSeconds int     `json:"deadline_seconds"` // Omitted for the FlowEngine default
+}
+
+// escrowV2 is an escrow as returned by the v2 escrow routes
+type escrowV2 struct {
+	EscrowID     string  `json:"escrow_id"`
+	FromAccount  string  `json:"from_account"`
+	ToAccount    string  `json:"to_account"`
+	Amount       moneyV2 `json:"amount"`
+	Description  string  `json:"description,omitempty"`
+	Arbiter      string  `json:"arbiter,omitempty"`
+	Status       string  `json:"status"`
+	CreatedAt    string  `json:"created_at,omitempty"`
+	Deadline     string  `json:"deadline"`
+	DecidedBy    string  `json:"decided_by,omitempty"`
+	Reason       string  `json:"reason,omitempty"`
+	ErrorMessage string  `json:"error_message,omitempty"`
+	HeldAt       string  `json:"held_at,omitempty"`
+	SettledAt    string  `json:"settled_at,omitempty"`
+}
+
+// escrowV2Fields maps the field names of escrow violations to their place in the v2 request body
+var escrowV2Fields = map[string]string{
+	"amount":         "amount.value",
+	"amount_decimal": "amount.value",
+	"currency":       "amount.currency",
+}
+
+func newEscrowV2(escrow *service.Escrow) escrowV2 {
+	return escrowV2{
+		EscrowID:     escrow.EscrowID,
+		FromAccount:  escrow.FromAccount,
+		ToAccount:    escrow.ToAccount,
+		Amount:       moneyV2{MinorUnits: escrow.Amount, Value: escrow.AmountDecimal, Currency: escrow.Currency},
+		Description:  escrow.Description,
+		Arbiter:      escrow.Arbiter,
+		Status:       escrow.Status,
+		CreatedAt:    escrow.CreatedAt,
+		Deadline:     escrow.Deadline,
+		DecidedBy:    escrow.DecidedBy,
+		Reason:       escrow.Reason,
+		ErrorMessage: escrow.ErrorMessage,
+		HeldAt:       escrow.HeldAt,
+		SettledAt:    escrow.SettledAt,
+	}
+}
+
+// escrowError maps the errors of the escrow routes to HTTP statuses. FlowEngine statuses are left to the error
+// middleware, which reports their error code and rejected fields.
+func escrowError(err error, message string) error {
+	var validationErr *service.TransferValidationError
+	if errors.As(err, &validationErr) {
+		return newValidationError(validationErr, escrowV2Fields)
+	}
+
+	switch {
+	case errors.Is(err, service.ErrEscrowNotFound):
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrCurrencyCatalogUnavailable):
+		return fiber.NewError(fiber.StatusServiceUnavailable, err.Error())
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	return fiber.NewError(fiber.StatusInternalServerError, message)
+}
+
+// CreateEscrow handles POST /api/v2/escrows. The payer is debited at once; the amount is released through
+// POST /api/v2/escrows/:id/release, refunded through /refund, and refunded anyway once the deadline passes.
+func (api *Api) CreateEscrow(c *fiber.Ctx) error {
+	const op = "api.Api.CreateEscrow"
+
+	var req escrowRequestV2
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request format")
+	}
+
+	params := &service.CreateEscrowParams{
+		FromAccount:     req.FromAccount,
+		ToAccount:       req.ToAccount,
+		AmountDecimal:   req.Amount.Value,
+		Currency:        req.Amount.Currency,
+		Description:     req.Description,
+		Arbiter:         req.Arbiter,
+		DeadlineSeconds: req.DeadlineSeconds,
+		Tenant:          c.Get(HeaderTenant),
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.CreateEscrow(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return escrowError(err, "Failed to create escrow")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(newEscrowV2(results))
+}
+
+// GetEscrow handles GET /api/v2/escrows/:id
+func (api *Api) GetEscrow(c *fiber.Ctx) error {
+	const op = "api.Api.GetEscrow"
+
+	escrowID := c.Params("id")
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":      op,
+		"escrow_id": escrowID,
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.GetEscrow(c.Context(), escrowID)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return escrowError(err, "Failed to get escrow")
+	}
+
+	return c.JSON(newEscrowV2(results))
+}
+
+// ReleaseEscrow handles POST /api/v2/escrows/:id/release on behalf of the payer or the arbiter named in decided_by
+func (api *Api) ReleaseEscrow(c *fiber.Ctx) error {
+	return api.decideEscrow(c, service.EscrowDecisionRelease)
+}
+
+// RefundEscrow handles POST /api/v2/escrows/:id/refund on behalf of the payee or the arbiter named in decided_by
+func (api *Api) RefundEscrow(c *fiber.Ctx) error {
+	return api.decideEscrow(c, service.EscrowDecisionRefund)
+}
+
+func (api *Api) decideEscrow(c *fiber.Ctx, decision string) error {
+	const op = "api.Api.DecideEscrow"
+
+	var request struct {
+		DecidedBy string `json:"decided_by"`
+		Reason    string `json:"reason"`
+	}
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	params := &service.DecideEscrowParams{
+		EscrowID:  c.Params("id"),
+		Decision:  decision,
+		DecidedBy: request.DecidedBy,
+		Reason:    request.Reason,
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.DecideEscrow(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return escrowError(err, "Failed to decide escrow")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(results)
+}
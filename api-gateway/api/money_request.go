@@ -0,0 +1,211 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"api-gateway/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/status"
+)
+
+// moneyRequestRequestV2 is the JSON body accepted by POST /api/v2/money-requests
+type moneyRequestRequestV2 struct {
+	PayeeAccount string `json:"payee_account" validate:"required,min=12,max=12"`
+	PayerAccount string `json:"payer_account" validate:"required,min=12,max=12"`
+	Amount       struct {
+		Value    string `json:"value" validate:"required,max=32"` // Major units, e.g. "12.50"
+		Currency string `json:"currency" validate:"required,min=3,max=3"`
+	} `json:"amount"`
+	Description *string `json:"description" validate:"max=100"`
+}
+
+// moneyRequestV2 is a money request as returned by the v2 money request routes
+type moneyRequestV2 struct {
+	MoneyRequestID string  `json:"money_request_id"` // Transaction ID of the transfer once accepted
+	PayerAccount   string  `json:"payer_account"`
+	PayeeAccount   string  `json:"payee_account"`
+	Amount         moneyV2 `json:"amount"`
+	Description    string  `json:"description,omitempty"`
+	Status         string  `json:"status"`
+	CreatedAt      string  `json:"created_at"`
+	ExpiresAt      string  `json:"expires_at"`
+}
+
+// moneyRequestsV2 is returned by GET /api/v2/money-requests
+type moneyRequestsV2 struct {
+	Account       string           `json:"account"`
+	Role          string           `json:"role"`
+	MoneyRequests []moneyRequestV2 `json:"money_requests"`
+	Truncated     bool             `json:"truncated"`
+}
+
+// moneyRequestV2Fields maps the field names of money request violations to their place in the v2 request body
+var moneyRequestV2Fields = map[string]string{
+	"amount":         "amount.value",
+	"amount_decimal": "amount.value",
+	"currency":       "amount.currency",
+}
+
+func newMoneyRequestV2(moneyRequest *service.MoneyRequest) moneyRequestV2 {
+	return moneyRequestV2{
+		MoneyRequestID: moneyRequest.MoneyRequestID,
+		PayerAccount:   moneyRequest.PayerAccount,
+		PayeeAccount:   moneyRequest.PayeeAccount,
+		Amount:         moneyV2{MinorUnits: moneyRequest.Amount, Value: moneyRequest.AmountDecimal, Currency: moneyRequest.Currency},
+		Description:    moneyRequest.Description,
+		Status:         moneyRequest.Status,
+		CreatedAt:      moneyRequest.CreatedAt,
+		ExpiresAt:      moneyRequest.ExpiresAt,
+	}
+}
+
+// moneyRequestError maps the errors of the money request routes to HTTP statuses. FlowEngine statuses are left
+// to the error middleware, which reports their error code and rejected fields.
+func moneyRequestError(err error, message string) error {
+	var validationErr *service.TransferValidationError
+	if errors.As(err, &validationErr) {
+		return newValidationError(validationErr, moneyRequestV2Fields)
+	}
+
+	switch {
+	case errors.Is(err, service.ErrMoneyRequestNotFound):
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrCurrencyCatalogUnavailable):
+		return fiber.NewError(fiber.StatusServiceUnavailable, err.Error())
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	return fiber.NewError(fiber.StatusInternalServerError, message)
+}
+
+// RequestMoney handles POST /api/v2/money-requests. The payer accepts or declines the request through
+// POST /api/v2/money-requests/:id/accept and /decline; unanswered requests expire.
+func (api *Api) RequestMoney(c *fiber.Ctx) error {
+	const op = "api.Api.RequestMoney"
+
+	var req moneyRequestRequestV2
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request format")
+	}
+
+	params := &service.RequestMoneyParams{
+		PayeeAccount:  req.PayeeAccount,
+		PayerAccount:  req.PayerAccount,
+		AmountDecimal: req.Amount.Value,
+		Currency:      req.Amount.Currency,
+		Description:   req.Description,
+		Tenant:        c.Get(HeaderTenant),
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.RequestMoney(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return moneyRequestError(err, "Failed to request money")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(newMoneyRequestV2(results))
+}
+
+// ListMoneyRequests handles GET /api/v2/money-requests?account=...&role=payer|payee&status=..., newest first.
+// Payers list the requests waiting for them with role=payer&status=PENDING.
+func (api *Api) ListMoneyRequests(c *fiber.Ctx) error {
+	const op = "api.Api.ListMoneyRequests"
+
+	params := &service.ListMoneyRequestsParams{
+		Account: c.Query("account"),
+		Role:    c.Query("role"),
+		Status:  c.Query("status"),
+	}
+	if params.Account == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "account is required")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.ListMoneyRequests(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return moneyRequestError(err, "Failed to list money requests")
+	}
+
+	response := moneyRequestsV2{
+		Account:       results.Account,
+		Role:          results.Role,
+		MoneyRequests: make([]moneyRequestV2, 0, len(results.MoneyRequests)),
+		Truncated:     results.Truncated,
+	}
+	for i := range results.MoneyRequests {
+		response.MoneyRequests = append(response.MoneyRequests, newMoneyRequestV2(&results.MoneyRequests[i]))
+	}
+
+	return c.JSON(response)
+}
+
+// AcceptMoneyRequest handles POST /api/v2/money-requests/:id/accept, which starts the requested transfer under the
+// ID of the money request
+func (api *Api) AcceptMoneyRequest(c *fiber.Ctx) error {
+	return api.respondToMoneyRequest(c, true)
+}
+
+// DeclineMoneyRequest handles POST /api/v2/money-requests/:id/decline with an optional reason
+func (api *Api) DeclineMoneyRequest(c *fiber.Ctx) error {
+	return api.respondToMoneyRequest(c, false)
+}
+
+func (api *Api) respondToMoneyRequest(c *fiber.Ctx, accept bool) error {
+	const op = "api.Api.RespondToMoneyRequest"
+
+	var request struct {
+		Reason string `json:"reason"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	params := &service.RespondToMoneyRequestParams{
+		MoneyRequestID: c.Params("id"),
+		Accept:         accept,
+		Reason:         request.Reason,
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.RespondToMoneyRequest(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return moneyRequestError(err, "Failed to respond to money request")
+	}
+
+	return c.JSON(results)
+}
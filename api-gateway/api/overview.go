@@ -0,0 +1,24 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetAdminOverview reports transfer SLA breaches, the compensations of the last 24 hours and the transactions stuck in pending
+func (api *Api) GetAdminOverview(c *fiber.Ctx) error {
+	const op = "api.Api.GetAdminOverview"
+
+	logger := api.logger.WithField("[op]", op)
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.GetAdminOverview(c.Context())
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get admin overview")
+	}
+
+	return c.JSON(results)
+}
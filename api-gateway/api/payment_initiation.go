@@ -0,0 +1,66 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"api-gateway/service"
+	"api-gateway/util/iso20022"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// IngestPain001 accepts an ISO 20022 pain.001 XML message and responds with a pain.002 status report
+func (api *Api) IngestPain001(c *fiber.Ctx) error {
+	const op = "api.Api.IngestPain001"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]": op,
+		"size": len(c.Body()),
+	})
+
+	logger.Info()
+
+	document, err := iso20022.ParsePain001(c.Body())
+	if err != nil {
+		logger.WithError(err).Warn("Rejecting pain.001 message")
+
+		// A message that cannot be decoded has no identifiers to report on
+		if document == nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid pain.001 document")
+		}
+
+		report := iso20022.NewPain002Document(uuid.New().String(), document.CustomerCreditTransferInitiation.GroupHeader, time.Now())
+		report.Reject(err.Error())
+
+		return sendPain002(c, fiber.StatusUnprocessableEntity, report)
+	}
+
+	params := &service.IngestPaymentInitiationParams{
+		Document: document,
+		Tenant:   c.Get(HeaderTenant),
+	}
+
+	// Call service
+	results, err := api.service.IngestPaymentInitiation(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}
+
+	return sendPain002(c, fiber.StatusOK, results.Report)
+}
+
+func sendPain002(c *fiber.Ctx, status int, report *iso20022.Pain002Document) error {
+	body, err := report.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to render pain.002 status report: %w", err)
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationXMLCharsetUTF8)
+
+	return c.Status(status).Send(body)
+}
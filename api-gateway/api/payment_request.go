@@ -0,0 +1,138 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"api-gateway/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// paymentRequestRequestV2 is the JSON body accepted by POST /api/v2/payment-requests
+type paymentRequestRequestV2 struct {
+	ToAccount string `json:"to_account" validate:"required,min=12,max=12"`
+	Amount    struct {
+		Value    string `json:"value" validate:"required,max=32"` // Major units, e.g. "12.50"
+		Currency string `json:"currency" validate:"required,min=3,max=3"`
+	} `json:"amount"`
+	Description   *string `json:"description" validate:"max=100"`
+	ExpirySeconds int     `json:"expiry_seconds"` // 0 takes the configured default
+}
+
+// paymentRequestV2 is returned by POST /api/v2/payment-requests
+type paymentRequestV2 struct {
+	PaymentRequestID string  `json:"payment_request_id"`
+	Payload          string  `json:"payload"` // Text to render as the QR code
+	ToAccount        string  `json:"to_account"`
+	Amount           moneyV2 `json:"amount"`
+	Description      string  `json:"description,omitempty"`
+	ExpiresAt        string  `json:"expires_at"`
+}
+
+// payPaymentRequestRequestV2 is the JSON body accepted by POST /api/v2/payment-requests/pay
+type payPaymentRequestRequestV2 struct {
+	Payload           string `json:"payload" validate:"required"` // Text of the scanned QR code
+	FromAccount       string `json:"from_account" validate:"required,min=12,max=12"`
+	WaitForCompletion bool   `json:"wait_for_completion"`
+}
+
+// paymentRequestV2Fields maps the field names of payment request violations to their place in the v2 request body
+var paymentRequestV2Fields = map[string]string{
+	"amount_decimal": "amount.value",
+	"currency":       "amount.currency",
+}
+
+// CreatePaymentRequest handles POST /api/v2/payment-requests
+func (api *Api) CreatePaymentRequest(c *fiber.Ctx) error {
+	const op = "api.Api.CreatePaymentRequest"
+
+	var req paymentRequestRequestV2
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request format")
+	}
+
+	params := &service.CreatePaymentRequestParams{
+		ToAccount:     req.ToAccount,
+		AmountDecimal: req.Amount.Value,
+		Currency:      req.Amount.Currency,
+		Description:   req.Description,
+		ExpirySeconds: req.ExpirySeconds,
+		Tenant:        c.Get(HeaderTenant),
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	results, err := api.service.CreatePaymentRequest(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		var validationErr *service.TransferValidationError
+		if errors.As(err, &validationErr) {
+			return newValidationError(validationErr, paymentRequestV2Fields)
+		}
+
+		switch {
+		case errors.Is(err, service.ErrPaymentRequestsUnavailable), errors.Is(err, service.ErrCurrencyCatalogUnavailable):
+			return fiber.NewError(fiber.StatusServiceUnavailable, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to create payment request")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(paymentRequestV2{
+		PaymentRequestID: results.PaymentRequestID,
+		Payload:          results.Payload,
+		ToAccount:        results.ToAccount,
+		Amount:           moneyV2{MinorUnits: results.Amount, Value: results.AmountDecimal, Currency: results.Currency},
+		Description:      results.Description,
+		ExpiresAt:        results.ExpiresAt,
+	})
+}
+
+// PayPaymentRequest handles POST /api/v2/payment-requests/pay. The scanned payload is checked before anything
+// is sent to FlowEngine; the transfer itself is started and reported like POST /api/v2/transfer.
+func (api *Api) PayPaymentRequest(c *fiber.Ctx) error {
+	const op = "api.Api.PayPaymentRequest"
+
+	var req payPaymentRequestRequestV2
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request format")
+	}
+
+	params, err := api.service.PaymentRequestTransfer(&service.PayPaymentRequestParams{
+		Payload:           req.Payload,
+		FromAccount:       req.FromAccount,
+		WaitForCompletion: req.WaitForCompletion,
+	})
+	if err != nil {
+		api.logger.WithField("[op]", op).WithError(err).Warn("Payment request rejected")
+
+		switch {
+		case errors.Is(err, service.ErrPaymentRequestsUnavailable):
+			return fiber.NewError(fiber.StatusServiceUnavailable, err.Error())
+		case errors.Is(err, service.ErrPaymentRequestExpired):
+			return fiber.NewError(fiber.StatusGone, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	results, err := api.transfer(c, params, transferRequestV2Fields)
+	if err != nil {
+		return err
+	}
+
+	response := newTransferV2(results)
+	if results.Accepted {
+		response.StatusURL = transferStatusURL(c, results.TransactionID)
+
+		c.Status(fiber.StatusAccepted).Location(response.StatusURL)
+	}
+
+	return c.JSON(response)
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"api-gateway/service"
+	"api-gateway/util/receipt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/text/language"
+)
+
+// GetTransferReceipt returns the signed receipt of a completed transfer.
+// JSON is returned by default; PDF is returned for ?format=pdf or an Accept header of application/pdf.
+// ?locale= (e.g. de-DE) formats the amount as in that locale.
+func (api *Api) GetTransferReceipt(c *fiber.Ctx) error {
+	const op = "api.Api.GetTransferReceipt"
+
+	params := &service.GetTransferReceiptParams{
+		TransactionID: c.Params("id"),
+		Locale:        c.Query("locale"),
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	format := strings.ToLower(c.Query("format"))
+	if format == "" && c.Accepts(fiber.MIMEApplicationJSON, "application/pdf") == "application/pdf" {
+		format = "pdf"
+	}
+	if format != "" && format != "json" && format != "pdf" {
+		return fiber.NewError(fiber.StatusBadRequest, "Unsupported receipt format")
+	}
+
+	if params.Locale != "" {
+		if _, err := language.Parse(params.Locale); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid locale, expected a BCP 47 tag such as de-DE")
+		}
+	}
+
+	// Call service
+	results, err := api.service.GetTransferReceipt(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		switch {
+		case errors.Is(err, service.ErrTransferNotCompleted):
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrReceiptFormatting):
+			return fiber.NewError(fiber.StatusBadGateway, err.Error())
+		}
+
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}
+
+	c.Set("X-Receipt-Signature", fmt.Sprintf("%s;key_id=%s;%s", results.Signature.Algorithm, results.Signature.KeyID, results.Signature.Value))
+
+	if format == "pdf" {
+		c.Set(fiber.HeaderContentType, "application/pdf")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`inline; filename="receipt-%s.pdf"`, results.ReceiptNumber))
+		// The gateway-wide CSP of "default-src 'none'" would stop browsers from rendering the PDF inline
+		c.Response().Header.Del(fiber.HeaderContentSecurityPolicy)
+
+		return c.Send(receipt.RenderPDF(results))
+	}
+
+	return c.JSON(results)
+}
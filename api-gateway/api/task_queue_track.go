@@ -0,0 +1,28 @@
+package api
+
+import (
+	"errors"
+	"strings"
+
+	"api-gateway/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HeaderTaskQueueTrack pins a transfer to the current or the next worker fleet of FlowEngine during a task queue
+// cutover, e.g. to smoke-test a new fleet before any traffic is shifted to it
+const HeaderTaskQueueTrack = "X-Task-Queue-Track"
+
+var errInvalidTaskQueueTrack = errors.New("X-Task-Queue-Track must be current or next")
+
+// taskQueueTrack reads the X-Task-Queue-Track header. It returns "" when the header is absent, which leaves the
+// transfer to the cutover percent of FlowEngine.
+func taskQueueTrack(c *fiber.Ctx) (string, error) {
+	value := strings.ToLower(strings.TrimSpace(c.Get(HeaderTaskQueueTrack)))
+	switch value {
+	case "", service.TaskQueueTrackCurrent, service.TaskQueueTrackNext:
+		return value, nil
+	default:
+		return "", errInvalidTaskQueueTrack
+	}
+}
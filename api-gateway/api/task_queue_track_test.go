@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskQueueTrack(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		header  string
+		want    string
+		wantErr bool
+	}{
+		{header: "", want: ""},
+		{header: "current", want: "current"},
+		{header: " Next ", want: "next"},
+		{header: "blue", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			t.Parallel()
+
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				got, err := taskQueueTrack(c)
+				if tt.wantErr {
+					assert.ErrorIs(t, err, errInvalidTaskQueueTrack)
+				} else {
+					assert.NoError(t, err)
+					assert.Equal(t, tt.want, got)
+				}
+
+				return nil
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(HeaderTaskQueueTrack, tt.header)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"api-gateway/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// GetTransferTimeline returns the steps of a transfer saga (activities scheduled, retried, failed and compensated),
+// assembled from its Temporal workflow history.
+func (api *Api) GetTransferTimeline(c *fiber.Ctx) error {
+	const op = "api.Api.GetTransferTimeline"
+
+	params := &service.GetTransferTimelineParams{
+		TransactionID: c.Params("id"),
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.GetTransferTimeline(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		if errors.Is(err, service.ErrTransferNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get transfer timeline")
+	}
+
+	return c.JSON(results)
+}
@@ -1,78 +1,203 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
+	"api-gateway/middleware"
 	"api-gateway/service"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// Transfer handles POST /api/v1/transfer and the legacy POST /transfer
 func (api *Api) Transfer(c *fiber.Ctx) error {
-	const op = "api.Api.Transfer"
+	var req transferRequestV1
 
-	var req struct {
-		FromAccount       string  `json:"from_account" validate:"required,min=12,max=12"`
-		ToAccount         string  `json:"to_account" validate:"required,min=12,max=12"`
-		Amount            int     `json:"amount" validate:"required,min=1,max=1000000000"`
-		Currency          string  `json:"currency" validate:"required,min=3,max=3"`
-		Description       *string `json:"description" validate:"max=100"`
-		ReferenceID       *string `json:"reference_id" validate:"max=50"`
-		WaitForCompletion *bool   `json:"wait_for_completion"`
+	// Parse request body
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request format")
+	}
+
+	results, err := api.transfer(c, req.toParams(), nil)
+	if err != nil {
+		return err
 	}
 
+	if results.Accepted {
+		c.Status(fiber.StatusAccepted).Location(transferStatusURL(c, results.TransactionID))
+	}
+
+	return c.JSON(newTransferResponseV1(results))
+}
+
+// TransferV2 handles POST /api/v2/transfer
+func (api *Api) TransferV2(c *fiber.Ctx) error {
+	var req transferRequestV2
+
 	// Parse request body
 	if err := c.BodyParser(&req); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid request format")
 	}
 
-	// Default to async mode if not specified
-	waitForCompletion := false
-	if req.WaitForCompletion != nil {
-		waitForCompletion = *req.WaitForCompletion
+	results, err := api.transfer(c, req.toParams(), transferRequestV2Fields)
+	if err != nil {
+		return err
 	}
 
-	// Create params
-	params := &service.TransferParams{
-		FromAccount:       req.FromAccount,
-		ToAccount:         req.ToAccount,
-		Amount:            req.Amount,
-		Currency:          req.Currency,
-		Description:       req.Description,
-		ReferenceID:       req.ReferenceID,
-		WaitForCompletion: waitForCompletion,
+	response := newTransferV2(results)
+	if results.Accepted {
+		response.StatusURL = transferStatusURL(c, results.TransactionID)
+
+		c.Status(fiber.StatusAccepted).Location(response.StatusURL)
+	}
+
+	return c.JSON(response)
+}
+
+// transfer calls the service on behalf of every API version and maps its errors to HTTP statuses. fields renames
+// the v1 field names of rejected fields to those of the calling version; nil keeps them.
+func (api *Api) transfer(c *fiber.Ctx, params *service.TransferParams, fields map[string]string) (*service.TransferResults, error) {
+	const op = "api.Api.Transfer"
+
+	params.Tenant = c.Get(HeaderTenant)
+
+	track, err := taskQueueTrack(c)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
+	params.TaskQueueTrack = track
 
 	logger := api.logger.WithFields(logrus.Fields{
 		"[op]":   op,
+		"path":   c.Path(),
 		"params": fmt.Sprintf("%+v", params),
 	})
 
 	logger.Info()
 
+	// Honor the client's timeout for the FlowEngine call and for how long sync mode waits
+	timeout, err := requestTimeout(c)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	var ctx context.Context = c.Context()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	// Call service
-	results, err := api.service.Transfer(c.Context(), params)
+	results, err := api.service.Transfer(ctx, params)
 	if err != nil {
 		logger.WithError(err).Error()
 
-		return c.SendStatus(fiber.StatusInternalServerError)
+		var validationErr *service.TransferValidationError
+		if errors.As(err, &validationErr) {
+			return nil, newValidationError(validationErr, fields)
+		}
+
+		switch {
+		case errors.Is(err, service.ErrDeadlineExceeded):
+			return nil, fiber.NewError(fiber.StatusGatewayTimeout, service.ErrDeadlineExceeded.Error())
+		case errors.Is(err, service.ErrCurrencyCatalogUnavailable):
+			return nil, fiber.NewError(fiber.StatusServiceUnavailable, err.Error())
+		case errors.Is(err, service.ErrPossibleDuplicateTransfer):
+			return nil, fiber.NewError(fiber.StatusConflict, err.Error())
+		case status.Code(err) == codes.AlreadyExists:
+			// Rendered by the error middleware as a 409 carrying the transaction_id of the original transfer
+			return nil, err
+		}
+
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to execute transfer")
+	}
+
+	// Flagged for every API version; v2 clients get the details in the response body as well
+	if results.PossibleDuplicate != nil {
+		duplicateOf := results.PossibleDuplicate.TransactionID
+		if duplicateOf == "" {
+			duplicateOf = "pending"
+		}
+		c.Set(HeaderPossibleDuplicate, duplicateOf)
+	}
+
+	return results, nil
+}
+
+// transferRequestV2Fields maps the v1 names of transfer request fields to their place in the v2 request body
+var transferRequestV2Fields = map[string]string{
+	"amount":                 "amount.value",
+	"amount_decimal":         "amount.value",
+	"currency":               "amount.currency",
+	"trigger.amount_decimal": "trigger.value",
+}
+
+// newValidationError turns the rejected fields of a transfer request into a 400 response, naming them as in the
+// request body of the API version
+func newValidationError(err *service.TransferValidationError, fields map[string]string) *middleware.ValidationError {
+	validationErr := &middleware.ValidationError{Details: err.Error()}
+	for _, violation := range err.Violations {
+		field := violation.Field
+		if renamed, ok := fields[field]; ok {
+			field = renamed
+		}
+
+		validationErr.FieldViolations = append(validationErr.FieldViolations, middleware.FieldViolation{
+			Field:       field,
+			Description: violation.Description,
+		})
 	}
 
-	return c.JSON(results)
+	return validationErr
 }
 
+// maxWaitSeconds is the longest wait_seconds accepted by GET /transfer/:id
+const maxWaitSeconds = 30
+
+// GetTransfer handles GET /api/v1/transfer/:id and the legacy GET /transfer/:id
 func (api *Api) GetTransfer(c *fiber.Ctx) error {
+	results, err := api.getTransfer(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(newGetTransferResponseV1(results))
+}
+
+// GetTransferV2 handles GET /api/v2/transfer/:id
+func (api *Api) GetTransferV2(c *fiber.Ctx) error {
+	results, err := api.getTransfer(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(newTransferV2FromStatus(results))
+}
+
+// getTransfer calls the service on behalf of every API version
+func (api *Api) getTransfer(c *fiber.Ctx) (*service.GetTransferResults, error) {
 	const op = "api.Api.GetTransfer"
 
-	id := c.Params("id")
+	// wait_seconds long-polls until the transfer finishes; FlowEngine caps the wait at 30 seconds
+	waitSeconds := c.QueryInt("wait_seconds", 0)
+	if waitSeconds < 0 || waitSeconds > maxWaitSeconds {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("wait_seconds must be between 0 and %d", maxWaitSeconds))
+	}
 
 	params := &service.GetTransferParams{
-		TransactionID: id,
+		TransactionID: c.Params("id"),
+		WaitSeconds:   int32(waitSeconds),
 	}
 
 	logger := api.logger.WithFields(logrus.Fields{
 		"[op]":   op,
+		"path":   c.Path(),
 		"params": fmt.Sprintf("%+v", params),
 	})
 
@@ -83,8 +208,15 @@ func (api *Api) GetTransfer(c *fiber.Ctx) error {
 	if err != nil {
 		logger.WithError(err).Error()
 
-		return c.SendStatus(fiber.StatusInternalServerError)
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to get transfer")
+	}
+
+	// A finished transfer never changes again, a running one may change with the next poll
+	if service.IsTerminalTransferStatus(results.Status) {
+		c.Set(fiber.HeaderCacheControl, middleware.CacheControlImmutable)
+	} else {
+		c.Set(fiber.HeaderCacheControl, middleware.CacheControlRevalidate)
 	}
 
-	return c.JSON(results)
+	return results, nil
 }
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"errors"
+
+	"api-gateway/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// GetTransferStatusesV2 handles POST /api/v2/transfers/status. Dashboards refresh up to 100 transfers with one
+// request; a transfer that cannot be looked up is reported in its own entry instead of failing the request.
+func (api *Api) GetTransferStatusesV2(c *fiber.Ctx) error {
+	const op = "api.Api.GetTransferStatusesV2"
+
+	var req transferStatusesRequestV2
+
+	// Parse request body
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request format")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":      op,
+		"transfers": len(req.TransactionIDs),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.GetTransfers(c.Context(), &service.GetTransfersParams{TransactionIDs: req.TransactionIDs})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTransferStatusesRequest) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		logger.WithError(err).Error()
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get transfer statuses")
+	}
+
+	return c.JSON(newTransferStatusesV2(results))
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"api-gateway/adapter/balance_adapter"
+	"api-gateway/middleware"
+	"api-gateway/service"
+	"api-gateway/util/config"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTransferBindingApp returns an app that binds POST /transfer bodies the same way Api.Transfer does
+// and echoes the bound request back
+func newTransferBindingApp() *fiber.App {
+	app := fiber.New()
+	app.Post("/transfer", func(c *fiber.Ctx) error {
+		var req transferRequestV1
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request format")
+		}
+
+		return c.JSON(req)
+	})
+
+	return app
+}
+
+func bindTransferRequest(t *testing.T, app *fiber.App, body string) (transferRequestV1, bool) {
+	t.Helper()
+
+	httpReq := httptest.NewRequest(fiber.MethodPost, "/transfer", strings.NewReader(body))
+	httpReq.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+	resp, err := app.Test(httpReq)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusOK {
+		return transferRequestV1{}, false
+	}
+
+	var req transferRequestV1
+	if err := json.NewDecoder(resp.Body).Decode(&req); err != nil {
+		t.Fatalf("failed to decode bound request: %v", err)
+	}
+
+	return req, true
+}
+
+func FuzzTransferRequestBinding(f *testing.F) {
+	for _, seed := range []string{
+		`{"from_account":"ACC001000001","to_account":"ACC001000002","amount":10050,"currency":"USD"}`,
+		`{"from_account":"ACC001000001","to_account":"ACC001000002","amount":100.5,"currency":"USD"}`,
+		`{"from_account":"ACC001000001","to_account":"ACC001000002","amount_decimal":"100.50","currency":"USD"}`,
+		`{"amount":1e2}`,
+		`{"amount":"10050"}`,
+		`{"amount":9223372036854775808}`,
+		`{"amount":-1,"description":null,"reference_id":"ref","wait_for_completion":true}`,
+		`{"currency":"€£"}`,
+		`[]`,
+		`{`,
+		``,
+	} {
+		f.Add(seed)
+	}
+
+	app := newTransferBindingApp()
+
+	f.Fuzz(func(t *testing.T, body string) {
+		req, bound := bindTransferRequest(t, app, body)
+		if !bound {
+			return
+		}
+
+		// Whatever the gateway accepted must re-encode to the same request, i.e. nothing was truncated or rounded
+		encoded, err := json.Marshal(req)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+
+		var decoded transferRequestV1
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			t.Fatalf("json.Unmarshal(%s) error = %v", encoded, err)
+		}
+
+		if !reflect.DeepEqual(req, decoded) {
+			t.Errorf("binding %q = %+v, re-encoded as %+v", body, req, decoded)
+		}
+
+		// A fractional amount must be rejected rather than truncated to whole minor units
+		var raw struct {
+			Amount json.Number `json:"amount"`
+		}
+		if err := json.Unmarshal([]byte(body), &raw); err == nil && raw.Amount != "" {
+			if raw.Amount.String() != "0" && strings.ContainsAny(raw.Amount.String(), ".eE") && req.Amount != 0 {
+				t.Errorf("binding %q accepted non-integer amount %s as %d", body, raw.Amount, req.Amount)
+			}
+		}
+	})
+}
+
+func TestTransferReportsFieldViolations(t *testing.T) {
+	t.Parallel()
+
+	svcBalance := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"status":"success","data":{"currencies":[{"code":"JPY","name":"Japanese Yen","symbol":"¥","decimal_place":0,"is_active":true}],"count":1}}`)
+	}))
+	t.Cleanup(svcBalance.Close)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Rejected requests never reach FlowEngine, so no FlowEngine adapter is needed
+	balanceAdapter := balance_adapter.NewAdapter("svc-balance", logger, svcBalance.URL, time.Second)
+	app := NewApi(logger, service.NewService(logger, nil, balanceAdapter, nil, config.Receipt{}, config.PaymentRequests{}, nil, nil, config.DuplicateDetection{}, nil), config.Http{}).SetupRoutes(fiber.New())
+
+	tests := []struct {
+		path string
+		body string
+		want []middleware.FieldViolation
+	}{
+		{
+			path: "/api/v1/transfer",
+			body: `{"from_account":"ACC001000001","to_account":"ACC001000001","amount_decimal":"10.50","currency":"JPY"}`,
+			want: []middleware.FieldViolation{
+				{Field: "to_account", Description: "from_account and to_account must differ"},
+				{Field: "amount_decimal", Description: "amount exceeds currency decimal places: 10.50 allows 0"},
+			},
+		},
+		{
+			path: "/api/v2/transfer",
+			body: `{"from_account":"ACC001000001","to_account":"ACC001000002","amount":{"value":"10.50","currency":"XYZ"}}`,
+			want: []middleware.FieldViolation{
+				{Field: "amount.currency", Description: "unsupported currency: XYZ"},
+			},
+		},
+		{
+			path: "/api/v2/transfer",
+			body: `{"from_account":"ACC001000001","to_account":"ACC001000002","amount":{"value":"10.50","currency":"JPY"}}`,
+			want: []middleware.FieldViolation{
+				{Field: "amount.value", Description: "amount exceeds currency decimal places: 10.50 allows 0"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			var body middleware.ErrorResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+			assert.Equal(t, "INVALID_PARAMETERS", body.Code)
+			assert.Equal(t, tt.want, body.FieldViolations)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"api-gateway/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// transferUploadFormField is the multipart form field that carries the CSV; a bare text/csv body is accepted as well
+const transferUploadFormField = "file"
+
+// UploadTransfersV2 handles POST /api/v2/transfers/upload. Every row of the CSV is validated before the response is
+// sent; valid rows are started as one batch, whose progress is served by GET /api/v2/uploads/:id.
+func (api *Api) UploadTransfersV2(c *fiber.Ctx) error {
+	const op = "api.Api.UploadTransfersV2"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]": op,
+		"size": len(c.Body()),
+	})
+
+	logger.Info()
+
+	data, err := transferUploadBody(c)
+	if err != nil {
+		logger.WithError(err).Warn("Rejecting transfer upload")
+
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	// Call service
+	results, err := api.service.UploadTransfers(c.Context(), &service.UploadTransfersParams{CSV: data, Tenant: c.Get(HeaderTenant)})
+	if err != nil {
+		logger.WithError(err).Error()
+
+		switch {
+		case errors.Is(err, service.ErrInvalidTransferUpload):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrCurrencyCatalogUnavailable):
+			return fiber.NewError(fiber.StatusServiceUnavailable, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to upload transfers")
+	}
+
+	response := newTransferUploadV2(results)
+
+	// Nothing was started when every row was rejected
+	if results.UploadID == "" {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(response)
+	}
+
+	response.StatusURL = transferUploadStatusURL(c, results.UploadID)
+
+	c.Status(fiber.StatusAccepted).Location(response.StatusURL)
+
+	return c.JSON(response)
+}
+
+// GetTransferUploadV2 handles GET /api/v2/uploads/:id
+func (api *Api) GetTransferUploadV2(c *fiber.Ctx) error {
+	const op = "api.Api.GetTransferUploadV2"
+
+	params := &service.GetTransferUploadParams{
+		UploadID: c.Params("id"),
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.GetTransferUpload(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		if errors.Is(err, service.ErrTransferUploadNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get transfer upload")
+	}
+
+	return c.JSON(newTransferUploadProgressV2(results))
+}
+
+// transferUploadBody returns the uploaded CSV, from the multipart form field or from the raw body
+func transferUploadBody(c *fiber.Ctx) ([]byte, error) {
+	if !strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		return c.Body(), nil
+	}
+
+	header, err := c.FormFile(transferUploadFormField)
+	if err != nil {
+		return nil, fmt.Errorf("multipart upload has no %q file", transferUploadFormField)
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
+
+// transferUploadStatusURL is where the progress of an upload started from POST .../transfers/upload can be polled
+func transferUploadStatusURL(c *fiber.Ctx, id string) string {
+	return strings.TrimSuffix(c.Path(), "/transfers/upload") + "/uploads/" + url.PathEscape(id)
+}
@@ -2,9 +2,15 @@ package main
 
 import (
 	"fmt"
+	"time"
 
+	"api-gateway/adapter/balance_adapter"
+	"api-gateway/adapter/balance_ops_adapter"
 	"api-gateway/adapter/flowngine_adapter"
+	"api-gateway/util/accountnumber"
 	"api-gateway/util/config"
+	"api-gateway/util/objectstore"
+	"api-gateway/util/statuscache"
 
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
@@ -16,13 +22,19 @@ import (
 func createFlowngineAdapter(config config.Flowngine, logger *logrus.Logger) (*flowngine_adapter.Adapter, error) {
 	address := fmt.Sprintf("%s:%d", config.Host, config.Port)
 
-	conn, err := grpc.NewClient(
-		address,
+	connOpts, err := grpcDialOptions(config.Grpc, time.Duration(config.TimeoutSeconds)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := append([]grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithStatsHandler(otelgrpc.NewClientHandler(
 			otelgrpc.WithPropagators(propagation.TraceContext{}),
 		)),
-	)
+	}, connOpts...)
+
+	conn, err := grpc.NewClient(address, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("error connecting to %s grpc server: %w", config.Name, err)
 	}
@@ -31,3 +43,126 @@ func createFlowngineAdapter(config config.Flowngine, logger *logrus.Logger) (*fl
 
 	return grpcAdapter, nil
 }
+
+func createBalanceAdapter(config config.SvcBalance, logger *logrus.Logger) *balance_adapter.Adapter {
+	baseURL := fmt.Sprintf("http://%s:%d", config.Host, config.Port)
+
+	return balance_adapter.NewAdapter(config.Name, logger, baseURL, time.Duration(config.TimeoutSeconds)*time.Second)
+}
+
+// createBalanceOpsAdapter connects to the ops gRPC API of svc-balance; without a gRPC port it returns nil and the
+// admin account reports answer 503
+func createBalanceOpsAdapter(config config.SvcBalance, logger *logrus.Logger) (*balance_ops_adapter.Adapter, error) {
+	if config.GrpcPort == 0 {
+		logger.Info("No svc-balance gRPC port configured, the admin account reports are disabled")
+
+		return nil, nil
+	}
+
+	address := fmt.Sprintf("%s:%d", config.Host, config.GrpcPort)
+
+	connOpts, err := grpcDialOptions(config.Grpc, time.Duration(config.TimeoutSeconds)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+			otelgrpc.WithPropagators(propagation.TraceContext{}),
+		)),
+	}, connOpts...)
+
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s grpc server: %w", config.Name, err)
+	}
+
+	return balance_ops_adapter.NewAdapter(config.Name, logger, conn), nil
+}
+
+func createObjectStore(logger *logrus.Logger, config config.Storage) (objectstore.Store, error) {
+	store, err := objectstore.New(objectstore.Options{
+		Backend:  config.Backend,
+		LocalDir: config.LocalDir,
+		S3: objectstore.S3Options{
+			Endpoint:        config.S3.Endpoint,
+			Region:          config.S3.Region,
+			Bucket:          config.S3.Bucket,
+			AccessKeyID:     config.S3.AccessKeyID,
+			SecretAccessKey: config.S3.SecretAccessKey,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create object store: %w", err)
+	}
+
+	if store == nil {
+		logger.Info("No storage backend configured, transfer uploads are not archived and receipts are kept in memory only")
+
+		return nil, nil
+	}
+
+	logger.WithField("backend", config.Backend).Info("Object store created successfully")
+
+	return store, nil
+}
+
+func createStatusCache(logger *logrus.Logger, config config.StatusCache) (statuscache.Cache, error) {
+	cache, err := statuscache.New(statuscache.Options{
+		Backend:  config.Backend,
+		TTL:      time.Duration(config.TTLSeconds) * time.Second,
+		Capacity: config.Capacity,
+		Redis: statuscache.RedisOptions{
+			Addr:      config.Redis.Addr,
+			Password:  config.Redis.Password,
+			DB:        config.Redis.DB,
+			KeyPrefix: config.Redis.KeyPrefix,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create status cache: %w", err)
+	}
+
+	if cache == nil {
+		logger.Info("No status cache configured, every transfer status read is sent to FlowEngine")
+
+		return nil, nil
+	}
+
+	logger.WithField("backend", config.Backend).Info("Status cache created successfully")
+
+	return cache, nil
+}
+
+// defaultAccountNumberFormat is the format of every tenant when none is configured
+var defaultAccountNumberFormat = accountnumber.Format{MinLength: 12, MaxLength: 12}
+
+// createAccountNumberValidator returns the validator of transfer accounts; relaxed returns none, accepting any account
+// number (such as the demo accounts ACC001 to ACC010)
+func createAccountNumberValidator(config config.AccountNumbers, relaxed bool) (*accountnumber.Validator, error) {
+	if relaxed {
+		return nil, nil
+	}
+
+	formats := []accountnumber.Format{defaultAccountNumberFormat}
+	if len(config.Formats) > 0 {
+		formats = make([]accountnumber.Format, 0, len(config.Formats))
+		for _, format := range config.Formats {
+			formats = append(formats, accountnumber.Format{
+				Tenant:    format.Tenant,
+				Prefix:    format.Prefix,
+				MinLength: format.MinLength,
+				MaxLength: format.MaxLength,
+				Checksum:  format.Checksum,
+			})
+		}
+	}
+
+	validator, err := accountnumber.NewValidator(formats)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account number validator: %w", err)
+	}
+
+	return validator, nil
+}
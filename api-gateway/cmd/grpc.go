@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"api-gateway/util/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/keepalive"
+)
+
+// grpcDialOptions converts the connection settings of a gRPC client to dial options. Zero values keep the gRPC
+// defaults, so a client without settings behaves as before.
+func grpcDialOptions(config config.GrpcClient, timeout time.Duration) ([]grpc.DialOption, error) {
+	var opts []grpc.DialOption
+
+	if config.Keepalive.TimeSeconds > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                time.Duration(config.Keepalive.TimeSeconds) * time.Second,
+			Timeout:             time.Duration(config.Keepalive.TimeoutSeconds) * time.Second,
+			PermitWithoutStream: config.Keepalive.PermitWithoutStream,
+		}))
+	}
+
+	var callOpts []grpc.CallOption
+	if config.MaxRecvMsgBytes > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(config.MaxRecvMsgBytes))
+	}
+	if config.MaxSendMsgBytes > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(config.MaxSendMsgBytes))
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+
+	if config.ConnectTimeoutSeconds > 0 {
+		opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: time.Duration(config.ConnectTimeoutSeconds) * time.Second,
+		}))
+	}
+
+	if config.Retry.MaxAttempts > 1 {
+		serviceConfig, err := grpcRetryServiceConfig(config.Retry)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, grpc.WithDefaultServiceConfig(serviceConfig))
+	}
+
+	if timeout > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(defaultTimeoutUnary(timeout)))
+	}
+
+	return opts, nil
+}
+
+// grpcRetryServiceConfig returns a service config retrying every method on UNAVAILABLE, the code of calls lost with
+// their connection ("transport is closing") and of services that are briefly unavailable. The retry throttling
+// budget stops retries while most calls fail, so an outage is not multiplied by max_attempts.
+func grpcRetryServiceConfig(retry config.GrpcRetry) (string, error) {
+	initialBackoff := retry.InitialBackoffMs
+	if initialBackoff <= 0 {
+		initialBackoff = 100
+	}
+	maxBackoff := retry.MaxBackoffMs
+	if maxBackoff <= 0 {
+		maxBackoff = 2000
+	}
+	multiplier := retry.BackoffMultiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	budgetTokens := retry.BudgetTokens
+	if budgetTokens <= 0 {
+		budgetTokens = 10
+	}
+	tokenRatio := retry.BudgetTokenRatio
+	if tokenRatio <= 0 {
+		tokenRatio = 0.1
+	}
+
+	serviceConfig := map[string]interface{}{
+		"methodConfig": []map[string]interface{}{{
+			"name": []map[string]interface{}{{}}, // Every method of every service
+			"retryPolicy": map[string]interface{}{
+				"maxAttempts":          retry.MaxAttempts,
+				"initialBackoff":       fmt.Sprintf("%.3fs", float64(initialBackoff)/1000),
+				"maxBackoff":           fmt.Sprintf("%.3fs", float64(maxBackoff)/1000),
+				"backoffMultiplier":    multiplier,
+				"retryableStatusCodes": []string{"UNAVAILABLE"},
+			},
+		}},
+		"retryThrottling": map[string]interface{}{
+			"maxTokens":  budgetTokens,
+			"tokenRatio": tokenRatio,
+		},
+	}
+
+	data, err := json.Marshal(serviceConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode grpc retry policy: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// defaultTimeoutUnary gives calls made without a deadline one of timeout, so a call on a stalled connection fails
+// instead of hanging. Calls with an earlier or later deadline keep theirs.
+func defaultTimeoutUnary(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
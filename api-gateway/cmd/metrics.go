@@ -7,6 +7,8 @@ import (
 	"runtime"
 	"time"
 
+	"api-gateway/util/crash"
+
 	"github.com/sirupsen/logrus"
 )
 
@@ -131,6 +133,10 @@ api_gateway_memory_sys_bytes %d
 # TYPE api_gateway_gc_cycles_total counter
 api_gateway_gc_cycles_total %d
 
+# HELP api_gateway_panics_recovered_total Total number of panics recovered by request and activity handlers
+# TYPE api_gateway_panics_recovered_total counter
+api_gateway_panics_recovered_total %d
+
 # HELP api_gateway_last_scrape_timestamp_seconds Timestamp of last metrics scrape
 # TYPE api_gateway_last_scrape_timestamp_seconds gauge
 api_gateway_last_scrape_timestamp_seconds %d
@@ -140,6 +146,7 @@ api_gateway_last_scrape_timestamp_seconds %d
 		memStats.TotalAlloc,
 		memStats.Sys,
 		memStats.NumGC,
+		crash.Total(),
 		time.Now().Unix(),
 	)
 
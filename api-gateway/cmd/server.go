@@ -3,31 +3,86 @@ package main
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"time"
 
 	"api-gateway/api"
+	"api-gateway/middleware"
+	"api-gateway/util/config"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/gofiber/fiber/v2/middleware/helmet"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
-func runRestServer(port int, api *api.Api) {
+func runRestServer(port int, httpConfig config.Http, logger *logrus.Logger, api *api.Api) {
 	// Init fiber app
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		// Hard cap for every route; per-route limits are enforced by middleware.BodyLimit
+		BodyLimit: max(httpConfig.MaxBodyBytes, httpConfig.MaxBatchBodyBytes, fiber.DefaultBodyLimit),
+	})
 
 	// CORS middleware configuration
 	corsConfig := cors.Config{
-		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
+		AllowOrigins:     httpConfig.Cors.AllowOrigins,
+		AllowMethods:     httpConfig.Cors.AllowMethods,
+		AllowHeaders:     httpConfig.Cors.AllowHeaders,
+		ExposeHeaders:    httpConfig.Cors.ExposeHeaders,
+		AllowCredentials: httpConfig.Cors.AllowCredentials,
+		MaxAge:           httpConfig.Cors.MaxAgeSeconds,
+	}
+	if corsConfig.AllowOrigins == "" {
+		corsConfig.AllowOrigins = "*"
+	}
+	if corsConfig.AllowHeaders == "" {
+		corsConfig.AllowHeaders = "Origin, Content-Type, Accept, Authorization"
 	}
 
 	app.Use(cors.New(corsConfig))
 
+	// Security headers middleware configuration
+	app.Use(helmet.New(helmet.Config{
+		XFrameOptions:         "DENY",
+		HSTSMaxAge:            httpConfig.HSTSMaxAgeSeconds,
+		ContentSecurityPolicy: httpConfig.CSP,
+	}))
+
+	// Request ID middleware, reusing the caller's X-Request-ID when present
+	app.Use(requestid.New())
+
+	// Access log middleware configuration
+	app.Use(middleware.AccessLog(logger, middleware.AccessLogConfig{
+		SuccessSampleRate: httpConfig.AccessLog.SuccessSampleRate,
+		SlowThreshold:     time.Duration(httpConfig.AccessLog.SlowThresholdMs) * time.Millisecond,
+	}))
+
+	// Compression middleware, inside the access log so bytes_out is the size actually sent
+	compress, err := middleware.Compress(middleware.CompressConfig{
+		Level:    httpConfig.Compression.Level,
+		MinBytes: httpConfig.Compression.MinBytes,
+	})
+	if err != nil {
+		log.Printf("invalid http.compression: %v", err)
+
+		os.Exit(1)
+	}
+	app.Use(compress)
+
 	// Endpoint definitions
 	app = api.SetupRoutes(app)
 
 	// start the server
-	err := app.Listen(fmt.Sprintf(":%d", port))
+	if httpConfig.H2C {
+		err = listenH2C(app, port, httpConfig)
+	} else {
+		err = app.Listen(fmt.Sprintf(":%d", port))
+	}
 	if err != nil {
 		log.Printf("failed to listen at port: %v!", port)
 
@@ -36,3 +91,20 @@ func runRestServer(port int, api *api.Api) {
 
 	log.Printf("rest server started successfully 🚀")
 }
+
+// listenH2C serves the app over net/http, which speaks cleartext HTTP/2 (prior knowledge or h2c upgrade) besides
+// HTTP/1.1; fasthttp, which fiber listens with, only speaks HTTP/1.1. Requests are converted to fiber requests, so
+// every route and middleware behaves the same, at the cost of a copy of each request and response.
+func listenH2C(app *fiber.App, port int, httpConfig config.Http) error {
+	handler := http.MaxBytesHandler(adaptor.FiberApp(app), int64(max(httpConfig.MaxBodyBytes, httpConfig.MaxBatchBodyBytes, fiber.DefaultBodyLimit)))
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           h2c.NewHandler(handler, &http2.Server{}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log.Printf("rest server listening with h2c at port %d", port)
+
+	return server.ListenAndServe()
+}
@@ -38,6 +38,11 @@ func start() {
 		os.Exit(1)
 	}
 
+	// --- Apply profile (APP_PROFILE) ---
+	if !config.Profile.VerboseLogging() {
+		logger.Level = logrus.InfoLevel
+	}
+
 	logger.WithFields(logrus.Fields{
 		"[op]":   op,
 		"config": fmt.Sprintf("%+v", config),
@@ -58,8 +63,62 @@ func start() {
 		os.Exit(1)
 	}
 
+	// --- Init balance adapter ---
+	balanceAdapter := createBalanceAdapter(config.SvcBalance, logger)
+
+	// --- Init balance ops adapter for the admin account reports ---
+	balanceOpsAdapter, err := createBalanceOpsAdapter(config.SvcBalance, logger)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
+	// --- Init object store for uploaded files and receipts ---
+	objectStore, err := createObjectStore(logger, config.Storage)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
+	// --- Init status cache for finished transfers ---
+	statusCache, err := createStatusCache(logger, config.StatusCache)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
+	// --- Init account number validator ---
+	accountNumbers, err := createAccountNumberValidator(config.AccountNumbers, config.Profile.RelaxedValidation())
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
 	// --- Init service layer ---
-	service := service.NewService(logger, flowngineAdapter)
+	// The learning profile replays the same transfers over and over, so possible duplicates are flagged rather than held
+	duplicateDetection := config.DuplicateDetection
+	if config.Profile.RelaxedValidation() && duplicateDetection.Mode == service.DuplicateDetectionConfirm {
+		duplicateDetection.Mode = service.DuplicateDetectionWarn
+	}
+
+	service := service.NewService(logger, flowngineAdapter, balanceAdapter, balanceOpsAdapter, config.Receipt, config.PaymentRequests, objectStore, accountNumbers, duplicateDetection, statusCache)
+	service.SetTaskQueueTrackHeader(config.Flowngine.TaskQueueTrackHeader)
 
 	// --- Init metrics server for Prometheus ---
 	metricsServer := NewMetricsServer(logger, 8080)
@@ -74,10 +133,10 @@ func start() {
 	}()
 
 	// --- Init api layer ---
-	api := api.NewApi(logger, service)
+	api := api.NewApi(logger, service, config.Http)
 
 	// --- Run server(s) ---
-	runRestServer(config.App.Port, api)
+	runRestServer(config.App.Port, config.Http, logger, api)
 
 	// --- Wait for signal ---
 	ch := make(chan os.Signal, 1)
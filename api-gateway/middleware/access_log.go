@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// AccessLogConfig controls which requests are written to the access log
+type AccessLogConfig struct {
+	SuccessSampleRate float64       // Fraction of fast 1xx-3xx requests that are logged, from 0 (none) to 1 (all)
+	SlowThreshold     time.Duration // Requests taking at least this long are always logged; 0 disables slow flagging
+}
+
+// sampleRandom is replaced in tests to make sampling deterministic
+var sampleRandom = rand.Float64
+
+// AccessLog writes one structured entry per request with its latency, status, matched route, caller and request ID.
+// 4xx and 5xx responses and slow requests are always logged; other requests are sampled at SuccessSampleRate.
+// It must be registered after the request ID middleware and before any middleware that writes error responses.
+func AccessLog(logger *logrus.Logger, config AccessLogConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		err := c.Next()
+		if err != nil {
+			// Let the app's error handler write the response now so the logged status is the one the client gets
+			if handlerErr := c.App().Config().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		latency := time.Since(start)
+		status := c.Response().StatusCode()
+		slow := config.SlowThreshold > 0 && latency >= config.SlowThreshold
+
+		if status < fiber.StatusBadRequest && !slow && sampleRandom() >= config.SuccessSampleRate {
+			return nil
+		}
+
+		entry := logger.WithFields(logrus.Fields{
+			"[op]":       "middleware.AccessLog",
+			"request_id": c.GetRespHeader(fiber.HeaderXRequestID),
+			"method":     c.Method(),
+			"route":      c.Route().Path,
+			"path":       c.Path(),
+			"status":     status,
+			"latency_ms": latency.Milliseconds(),
+			"ip":         c.IP(),
+			"user_agent": c.Get(fiber.HeaderUserAgent),
+			"bytes_out":  len(c.Response().Body()),
+			"slow":       slow,
+		})
+
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			entry.Error("request failed")
+		case status >= fiber.StatusBadRequest:
+			entry.Warn("request rejected")
+		case slow:
+			entry.Warn("slow request")
+		default:
+			entry.Info("request completed")
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessLog(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		sample     float64 // Value returned by sampleRandom
+		wantLogged bool
+		wantLevel  logrus.Level
+		wantStatus int
+		wantSlow   bool
+	}{
+		{name: "sampled success", path: "/ok", sample: 0.05, wantLogged: true, wantLevel: logrus.InfoLevel, wantStatus: fiber.StatusOK},
+		{name: "unsampled success", path: "/ok", sample: 0.5, wantLogged: false},
+		{name: "client error", path: "/bad", sample: 0.5, wantLogged: true, wantLevel: logrus.WarnLevel, wantStatus: fiber.StatusBadRequest},
+		{name: "server error", path: "/fail", sample: 0.5, wantLogged: true, wantLevel: logrus.ErrorLevel, wantStatus: fiber.StatusInternalServerError},
+		{name: "slow success", path: "/slow", sample: 0.5, wantLogged: true, wantLevel: logrus.WarnLevel, wantStatus: fiber.StatusOK, wantSlow: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampleRandom = func() float64 { return tt.sample }
+			t.Cleanup(func() { sampleRandom = rand.Float64 })
+
+			logger, hook := logtest.NewNullLogger()
+			logger.SetOutput(io.Discard)
+
+			app := fiber.New()
+			app.Use(requestid.New())
+			app.Use(AccessLog(logger, AccessLogConfig{SuccessSampleRate: 0.1, SlowThreshold: 20 * time.Millisecond}))
+			app.Get("/ok", func(c *fiber.Ctx) error { return c.SendString("ok") })
+			app.Get("/bad", func(c *fiber.Ctx) error { return fiber.NewError(fiber.StatusBadRequest, "bad") })
+			app.Get("/fail", func(c *fiber.Ctx) error { return fiber.ErrInternalServerError })
+			app.Get("/slow", func(c *fiber.Ctx) error {
+				time.Sleep(25 * time.Millisecond)
+				return c.SendString("ok")
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, tt.path, nil)
+			req.Header.Set(fiber.HeaderXRequestID, "req-123")
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			if !tt.wantLogged {
+				assert.Empty(t, hook.AllEntries())
+				return
+			}
+
+			require.Len(t, hook.AllEntries(), 1)
+			entry := hook.LastEntry()
+			assert.Equal(t, tt.wantLevel, entry.Level)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantStatus, entry.Data["status"])
+			assert.Equal(t, tt.path, entry.Data["route"])
+			assert.Equal(t, "req-123", entry.Data["request_id"])
+			assert.Equal(t, tt.wantSlow, entry.Data["slow"])
+		})
+	}
+}
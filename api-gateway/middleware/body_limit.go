@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit rejects requests whose body is larger than limit bytes with 413 Request Entity Too Large.
+// The server-wide fiber.Config.BodyLimit must be at least as large as the biggest per-route limit,
+// since fasthttp rejects larger bodies before any route runs. A limit of 0 or less disables the check.
+func BodyLimit(limit int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if limit <= 0 {
+			return c.Next()
+		}
+
+		// Content-Length is -1 for chunked bodies, which are only known once read
+		if c.Request().Header.ContentLength() > limit || len(c.Body()) > limit {
+			return fiber.NewError(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("Request body exceeds %d bytes", limit))
+		}
+
+		return c.Next()
+	}
+}
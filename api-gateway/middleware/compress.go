@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// CompressConfig selects how responses are compressed
+type CompressConfig struct {
+	Level    string // off, best_speed, default or best_compression; empty is off
+	MinBytes int    // Bodies smaller than this are sent as they are; fasthttp never compresses below 200 bytes
+}
+
+// compressLevels maps CompressConfig.Level to the brotli and gzip/deflate levels passed to fasthttp
+var compressLevels = map[string][2]int{
+	"best_speed":       {fasthttp.CompressBrotliBestSpeed, fasthttp.CompressBestSpeed},
+	"default":          {fasthttp.CompressBrotliDefaultCompression, fasthttp.CompressDefaultCompression},
+	"best_compression": {fasthttp.CompressBrotliBestCompression, fasthttp.CompressBestCompression},
+}
+
+// Compress compresses response bodies of at least MinBytes with brotli, gzip or deflate, preferring them in that
+// order among the encodings the client accepts. Bodies that already have a Content-Encoding and content types that
+// do not compress, such as images, are left alone. It returns an error for an unknown level.
+func Compress(config CompressConfig) (fiber.Handler, error) {
+	if config.Level == "" || config.Level == "off" {
+		return func(c *fiber.Ctx) error { return c.Next() }, nil
+	}
+
+	levels, ok := compressLevels[config.Level]
+	if !ok {
+		return nil, fmt.Errorf("unknown compression level %q", config.Level)
+	}
+
+	compressor := fasthttp.CompressHandlerBrotliLevel(func(*fasthttp.RequestCtx) {}, levels[0], levels[1])
+
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		if c.Response().IsBodyStream() || len(c.Response().Body()) >= config.MinBytes {
+			compressor(c.Context())
+		}
+
+		return nil
+	}, nil
+}
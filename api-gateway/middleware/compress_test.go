@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statementExport returns a JSON body shaped like a statement export or a long transfer list, with entries rows
+func statementExport(entries int) []byte {
+	type entry struct {
+		TransactionID string `json:"transaction_id"`
+		Status        string `json:"status"`
+		FromAccount   string `json:"from_account"`
+		ToAccount     string `json:"to_account"`
+		Amount        string `json:"amount"`
+		Currency      string `json:"currency"`
+		Description   string `json:"description"`
+		CreatedAt     string `json:"created_at"`
+	}
+
+	createdAt := time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC)
+	rows := make([]entry, 0, entries)
+	for i := range entries {
+		rows = append(rows, entry{
+			TransactionID: fmt.Sprintf("txn_%08x-4c1d-4e2f-9a3b-%012d", i*7919, i),
+			Status:        "TRANSFER_STATUS_COMPLETED",
+			FromAccount:   fmt.Sprintf("ACC%09d", 1000+i%17),
+			ToAccount:     fmt.Sprintf("ACC%09d", 2000+i%23),
+			Amount:        fmt.Sprintf("%d.%02d", 10+i*37%9000, i%100),
+			Currency:      "USD",
+			Description:   fmt.Sprintf("Invoice %d payment", 4000+i),
+			CreatedAt:     createdAt.Add(time.Duration(i) * time.Minute).Format(time.RFC3339),
+		})
+	}
+
+	body, _ := json.Marshal(map[string]any{"transfers": rows})
+
+	return body
+}
+
+func newCompressTestApp(t testing.TB, config CompressConfig, body []byte) *fiber.App {
+	t.Helper()
+
+	compress, err := Compress(config)
+	require.NoError(t, err)
+
+	app := fiber.New()
+	app.Use(compress)
+	app.Get("/statement", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.Send(body)
+	})
+
+	return app
+}
+
+func TestCompress(t *testing.T) {
+	t.Parallel()
+
+	body := statementExport(200)
+
+	tests := []struct {
+		name           string
+		config         CompressConfig
+		acceptEncoding string
+		wantEncoding   string
+	}{
+		{name: "brotli preferred", config: CompressConfig{Level: "default"}, acceptEncoding: "gzip, deflate, br", wantEncoding: "br"},
+		{name: "gzip", config: CompressConfig{Level: "best_speed"}, acceptEncoding: "gzip", wantEncoding: "gzip"},
+		{name: "not accepted", config: CompressConfig{Level: "default"}, acceptEncoding: "", wantEncoding: ""},
+		{name: "below min_bytes", config: CompressConfig{Level: "default", MinBytes: len(body) + 1}, acceptEncoding: "gzip", wantEncoding: ""},
+		{name: "off", config: CompressConfig{Level: "off"}, acceptEncoding: "gzip", wantEncoding: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newCompressTestApp(t, tt.config, body)
+
+			req := httptest.NewRequest(fiber.MethodGet, "/statement", nil)
+			req.Header.Set(fiber.HeaderAcceptEncoding, tt.acceptEncoding)
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			received, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantEncoding, resp.Header.Get(fiber.HeaderContentEncoding))
+			if tt.wantEncoding == "" {
+				assert.Equal(t, body, received)
+			} else {
+				assert.Less(t, len(received), len(body)/4, "JSON lists compress at least fourfold")
+				assert.Contains(t, resp.Header.Get(fiber.HeaderVary), fiber.HeaderAcceptEncoding)
+			}
+		})
+	}
+
+	_, err := Compress(CompressConfig{Level: "fastest"})
+	assert.ErrorContains(t, err, "unknown compression level")
+}
+
+// BenchmarkCompressStatementExport compresses a 1000-row statement export and reports the bytes sent per response
+// next to the uncompressed size, e.g. go test ./middleware -run '^$' -bench CompressStatementExport
+func BenchmarkCompressStatementExport(b *testing.B) {
+	body := statementExport(1000)
+
+	for _, level := range []string{"off", "best_speed", "default", "best_compression"} {
+		for _, encoding := range []string{"gzip", "br"} {
+			if level == "off" && encoding == "br" {
+				continue
+			}
+
+			b.Run(level+"/"+encoding, func(b *testing.B) {
+				app := newCompressTestApp(b, CompressConfig{Level: level}, body)
+
+				sent := 0
+				for range b.N {
+					req := httptest.NewRequest(fiber.MethodGet, "/statement", nil)
+					req.Header.Set(fiber.HeaderAcceptEncoding, encoding)
+
+					resp, err := app.Test(req, -1)
+					if err != nil {
+						b.Fatal(err)
+					}
+					received, err := io.Copy(io.Discard, resp.Body)
+					resp.Body.Close()
+					if err != nil {
+						b.Fatal(err)
+					}
+					sent = int(received)
+				}
+
+				b.ReportMetric(float64(len(body)), "raw-bytes")
+				b.ReportMetric(float64(sent), "sent-bytes")
+				b.ReportMetric(100*(1-float64(sent)/float64(len(body))), "%saved")
+			})
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DeprecationPolicy describes a deprecated group of routes and where clients should move to
+type DeprecationPolicy struct {
+	Since     time.Time // When the routes were deprecated
+	Sunset    time.Time // When the routes may be removed; zero if not scheduled yet
+	Successor string    // Path prefix of the replacement routes, e.g. "/api/v1"
+}
+
+// Deprecation marks every response of the routes it wraps as deprecated.
+// It sets the Deprecation (RFC 9745) and Sunset (RFC 8594) headers, and a successor-version Link
+// pointing at the same path under the successor prefix.
+func Deprecation(policy DeprecationPolicy) fiber.Handler {
+	deprecation := fmt.Sprintf("@%d", policy.Since.Unix())
+
+	sunset := ""
+	if !policy.Sunset.IsZero() {
+		sunset = policy.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	return func(c *fiber.Ctx) error {
+		// Set headers before the handler runs so they also reach error responses
+		c.Set("Deprecation", deprecation)
+		if sunset != "" {
+			c.Set("Sunset", sunset)
+		}
+		if policy.Successor != "" {
+			c.Append(fiber.HeaderLink, fmt.Sprintf(`<%s%s>; rel="successor-version"`, policy.Successor, c.OriginalURL()))
+		}
+
+		return c.Next()
+	}
+}
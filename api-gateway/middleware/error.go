@@ -5,8 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 
+	"api-gateway/adapter/flowngine_adapter/pb"
+	"api-gateway/util/i18n"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/lib/pq"
 	"google.golang.org/grpc/codes"
@@ -15,9 +19,29 @@ import (
 
 // ErrorResponse represents the standard error response structure
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Code    string `json:"code,omitempty"`
-	Details string `json:"details,omitempty"`
+	Error           string           `json:"error"`
+	Code            string           `json:"code,omitempty"`
+	Details         string           `json:"details,omitempty"`
+	Retryable       bool             `json:"retryable,omitempty"`
+	TransactionID   string           `json:"transaction_id,omitempty"` // Existing transfer a conflicting request refers to
+	FieldViolations []FieldViolation `json:"field_violations,omitempty"`
+}
+
+// FieldViolation is a request field rejected by a backend service
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// ValidationError is returned by handlers to reject a request with the fields that failed validation. It is
+// answered with 400 INVALID_PARAMETERS, the same response as validation errors reported by backend services.
+type ValidationError struct {
+	Details         string
+	FieldViolations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	return e.Details
 }
 
 // ErrorHandler creates a middleware for centralized error handling
@@ -26,14 +50,14 @@ func ErrorHandler() fiber.Handler {
 		// Forward to next handler
 		err := c.Next()
 
-		// Check if response was written
-		if len(c.Response().Body()) == 0 {
+		// Check if response was written; 304 Not Modified has no body by definition
+		if len(c.Response().Body()) == 0 && c.Response().StatusCode() != fiber.StatusNotModified {
 			if err == nil {
 				// No error but no response sent - this is a handler bug
 				log.Printf("Warning: Handler didn't send any response for %s %s\n",
 					c.Method(), c.Path())
 
-				return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
+				return writeError(c, fiber.StatusInternalServerError, ErrorResponse{
 					Error: "Internal server error: no response sent",
 					Code:  "INTERNAL_ERROR",
 				})
@@ -48,6 +72,34 @@ func ErrorHandler() fiber.Handler {
 	}
 }
 
+// writeError sends the error response in the language negotiated from the Accept-Language header. Codes, field
+// names and details stay as they are; only the human-facing message is translated.
+func writeError(c *fiber.Ctx, statusCode int, response ErrorResponse) error {
+	lang := i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage))
+
+	c.Vary(fiber.HeaderAcceptLanguage)
+	c.Set(fiber.HeaderContentLanguage, lang)
+
+	return c.Status(statusCode).JSON(localizeErrorResponse(lang, statusCode, response))
+}
+
+// localizeErrorResponse translates the message of the response. Messages without a translation, typically
+// validation errors that quote the request, are replaced by the translated status text and kept in the details.
+func localizeErrorResponse(lang string, statusCode int, response ErrorResponse) ErrorResponse {
+	translated, ok := i18n.Translate(lang, response.Error)
+	if ok {
+		response.Error = translated
+		return response
+	}
+
+	if response.Details == "" {
+		response.Details = response.Error
+	}
+	response.Error, _ = i18n.Translate(lang, http.StatusText(statusCode))
+
+	return response
+}
+
 // handleError processes different error types and returns appropriate HTTP responses
 func handleError(c *fiber.Ctx, err error) error {
 	// Log the original error for debugging
@@ -56,12 +108,22 @@ func handleError(c *fiber.Ctx, err error) error {
 	// Handle Fiber errors first (highest priority)
 	var fiberErr *fiber.Error
 	if errors.As(err, &fiberErr) {
-		return c.Status(fiberErr.Code).JSON(ErrorResponse{
+		return writeError(c, fiberErr.Code, ErrorResponse{
 			Error: fiberErr.Message,
 			Code:  getFiberErrorCode(fiberErr.Code),
 		})
 	}
 
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return writeError(c, fiber.StatusBadRequest, ErrorResponse{
+			Error:           "Invalid request parameters",
+			Code:            "INVALID_PARAMETERS",
+			Details:         validationErr.Details,
+			FieldViolations: validationErr.FieldViolations,
+		})
+	}
+
 	// Handle gRPC status errors
 	if grpcStatus, ok := status.FromError(err); ok {
 		return handleGRPCError(c, grpcStatus)
@@ -69,14 +131,14 @@ func handleError(c *fiber.Ctx, err error) error {
 
 	// Handle context errors
 	if errors.Is(err, context.DeadlineExceeded) {
-		return c.Status(fiber.StatusGatewayTimeout).JSON(ErrorResponse{
+		return writeError(c, fiber.StatusGatewayTimeout, ErrorResponse{
 			Error: "Request timeout",
 			Code:  "TIMEOUT_ERROR",
 		})
 	}
 
 	if errors.Is(err, context.Canceled) {
-		return c.Status(fiber.StatusRequestTimeout).JSON(ErrorResponse{
+		return writeError(c, fiber.StatusRequestTimeout, ErrorResponse{
 			Error: "Request was cancelled",
 			Code:  "CANCELLED_ERROR",
 		})
@@ -91,7 +153,7 @@ func handleError(c *fiber.Ctx, err error) error {
 	// Handle business logic errors based on error message patterns
 	errorMsg := err.Error()
 	if businessErr := handleBusinessLogicError(errorMsg); businessErr != nil {
-		return c.Status(businessErr.StatusCode).JSON(ErrorResponse{
+		return writeError(c, businessErr.StatusCode, ErrorResponse{
 			Error:   businessErr.Message,
 			Code:    businessErr.Code,
 			Details: extractErrorDetails(errorMsg),
@@ -99,7 +161,7 @@ func handleError(c *fiber.Ctx, err error) error {
 	}
 
 	// Default error response
-	return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
+	return writeError(c, fiber.StatusInternalServerError, ErrorResponse{
 		Error: "Internal server error",
 		Code:  "INTERNAL_ERROR",
 	})
@@ -134,6 +196,22 @@ func handleBusinessLogicError(errorMsg string) *BusinessError {
 		}
 	}
 
+	if strings.Contains(errorMsg, "alias not found") {
+		return &BusinessError{
+			StatusCode: fiber.StatusNotFound,
+			Message:    "Alias not found",
+			Code:       "ALIAS_NOT_FOUND",
+		}
+	}
+
+	if strings.Contains(errorMsg, "account type restriction") {
+		return &BusinessError{
+			StatusCode: fiber.StatusUnprocessableEntity,
+			Message:    "Not allowed for the account type",
+			Code:       "ACCOUNT_TYPE_RESTRICTION",
+		}
+	}
+
 	if strings.Contains(errorMsg, "account validation failed") {
 		return &BusinessError{
 			StatusCode: fiber.StatusUnprocessableEntity,
@@ -285,21 +363,54 @@ func handleGRPCError(c *fiber.Ctx, grpcStatus *status.Status) error {
 		message = "Internal server error"
 	}
 
+	// Prefer the structured detail attached by the service over guessing from the message
+	if detail := grpcErrorDetail(grpcStatus); detail != nil {
+		response := ErrorResponse{
+			Error:         message,
+			Code:          errorCode,
+			Details:       grpcStatus.Message(),
+			Retryable:     detail.GetRetryable(),
+			TransactionID: detail.GetTransactionId(),
+		}
+		if detail.GetCode() != "" {
+			response.Code = detail.GetCode()
+		}
+		for _, violation := range detail.GetFieldViolations() {
+			response.FieldViolations = append(response.FieldViolations, FieldViolation{
+				Field:       violation.GetField(),
+				Description: violation.GetDescription(),
+			})
+		}
+
+		return writeError(c, statusCode, response)
+	}
+
 	// Use gRPC message if it's more descriptive than our default
 	if grpcStatus.Message() != "" && len(grpcStatus.Message()) > len(message) {
-		return c.Status(statusCode).JSON(ErrorResponse{
+		return writeError(c, statusCode, ErrorResponse{
 			Error:   message,
 			Code:    errorCode,
 			Details: grpcStatus.Message(),
 		})
 	}
 
-	return c.Status(statusCode).JSON(ErrorResponse{
+	return writeError(c, statusCode, ErrorResponse{
 		Error: message,
 		Code:  errorCode,
 	})
 }
 
+// grpcErrorDetail returns the ErrorDetail attached to a gRPC status, or nil when the service sent none
+func grpcErrorDetail(grpcStatus *status.Status) *pb.ErrorDetail {
+	for _, detail := range grpcStatus.Details() {
+		if errorDetail, ok := detail.(*pb.ErrorDetail); ok {
+			return errorDetail
+		}
+	}
+
+	return nil
+}
+
 // handleDatabaseError maps PostgreSQL errors to HTTP responses
 func handleDatabaseError(c *fiber.Ctx, pqErr *pq.Error) error {
 	var statusCode int
@@ -345,7 +456,7 @@ func handleDatabaseError(c *fiber.Ctx, pqErr *pq.Error) error {
 		errorCode = "DATABASE_ERROR"
 	}
 
-	return c.Status(statusCode).JSON(ErrorResponse{
+	return writeError(c, statusCode, ErrorResponse{
 		Error:   message,
 		Code:    errorCode,
 		Details: fmt.Sprintf("Database error: %s", pqErr.Code),
@@ -369,6 +480,8 @@ func getFiberErrorCode(statusCode int) string {
 		return "REQUEST_TIMEOUT"
 	case fiber.StatusConflict:
 		return "CONFLICT"
+	case fiber.StatusRequestEntityTooLarge:
+		return "REQUEST_TOO_LARGE"
 	case fiber.StatusUnprocessableEntity:
 		return "UNPROCESSABLE_ENTITY"
 	case fiber.StatusTooManyRequests:
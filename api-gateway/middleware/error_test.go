@@ -0,0 +1,191 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandleGRPCError(t *testing.T) {
+	withDetail := func(st *status.Status, detail *pb.ErrorDetail) error {
+		st, err := st.WithDetails(detail)
+		require.NoError(t, err)
+		return st.Err()
+	}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		want       ErrorResponse
+	}{
+		{
+			name: "field violations",
+			err: withDetail(status.New(codes.InvalidArgument, "invalid parameters: from_account is required"), &pb.ErrorDetail{
+				Code: "INVALID_PARAMETERS",
+				FieldViolations: []*pb.ErrorDetail_FieldViolation{
+					{Field: "from_account", Description: "from_account is required"},
+				},
+			}),
+			wantStatus: fiber.StatusBadRequest,
+			want: ErrorResponse{
+				Error:   "Invalid request parameters",
+				Code:    "INVALID_PARAMETERS",
+				Details: "invalid parameters: from_account is required",
+				FieldViolations: []FieldViolation{
+					{Field: "from_account", Description: "from_account is required"},
+				},
+			},
+		},
+		{
+			name:       "retryable",
+			err:        withDetail(status.New(codes.Unavailable, "starting up"), &pb.ErrorDetail{Code: "SERVICE_UNAVAILABLE", Retryable: true}),
+			wantStatus: fiber.StatusServiceUnavailable,
+			want: ErrorResponse{
+				Error:     "Service temporarily unavailable",
+				Code:      "SERVICE_UNAVAILABLE",
+				Details:   "starting up",
+				Retryable: true,
+			},
+		},
+		{
+			name:       "short message with detail",
+			err:        withDetail(status.New(codes.NotFound, "gone"), &pb.ErrorDetail{Code: "TRANSFER_NOT_FOUND"}),
+			wantStatus: fiber.StatusNotFound,
+			want: ErrorResponse{
+				Error:   "Resource not found",
+				Code:    "TRANSFER_NOT_FOUND",
+				Details: "gone",
+			},
+		},
+		{
+			name:       "already exists",
+			err:        withDetail(status.New(codes.AlreadyExists, "transfer already exists: transaction tx-1 was started for this request_id"), &pb.ErrorDetail{Code: "TRANSFER_ALREADY_EXISTS", TransactionId: "tx-1"}),
+			wantStatus: fiber.StatusConflict,
+			want: ErrorResponse{
+				Error:         "Resource already exists",
+				Code:          "TRANSFER_ALREADY_EXISTS",
+				Details:       "transfer already exists: transaction tx-1 was started for this request_id",
+				TransactionID: "tx-1",
+			},
+		},
+		{
+			name:       "no detail",
+			err:        status.Error(codes.NotFound, "transfer workflow not found: abc"),
+			wantStatus: fiber.StatusNotFound,
+			want: ErrorResponse{
+				Error:   "Resource not found",
+				Code:    "NOT_FOUND",
+				Details: "transfer workflow not found: abc",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(ErrorHandler())
+			app.Get("/", func(c *fiber.Ctx) error { return tt.err })
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			var body ErrorResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.want, body)
+		})
+	}
+}
+
+func TestErrorHandlerLocalization(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		err            error
+		wantLanguage   string
+		want           ErrorResponse
+	}{
+		{
+			name:         "english by default",
+			err:          fiber.NewError(fiber.StatusBadRequest, "Invalid request body"),
+			wantLanguage: "en",
+			want:         ErrorResponse{Error: "Invalid request body", Code: "BAD_REQUEST"},
+		},
+		{
+			name:           "translated message",
+			acceptLanguage: "id-ID,id;q=0.9",
+			err:            fiber.NewError(fiber.StatusBadRequest, "Invalid request body"),
+			wantLanguage:   "id",
+			want:           ErrorResponse{Error: "Isi permintaan tidak valid", Code: "BAD_REQUEST"},
+		},
+		{
+			name:           "validation summary keeps codes and field violations",
+			acceptLanguage: "es",
+			err: func() error {
+				st, err := status.New(codes.InvalidArgument, "invalid parameters: from_account is required").WithDetails(&pb.ErrorDetail{
+					Code:            "INVALID_PARAMETERS",
+					FieldViolations: []*pb.ErrorDetail_FieldViolation{{Field: "from_account", Description: "from_account is required"}},
+				})
+				require.NoError(t, err)
+				return st.Err()
+			}(),
+			wantLanguage: "es",
+			want: ErrorResponse{
+				Error:           "Parámetros de solicitud no válidos",
+				Code:            "INVALID_PARAMETERS",
+				Details:         "invalid parameters: from_account is required",
+				FieldViolations: []FieldViolation{{Field: "from_account", Description: "from_account is required"}},
+			},
+		},
+		{
+			name:           "untranslated message falls back to the status text",
+			acceptLanguage: "es",
+			err:            fiber.NewError(fiber.StatusBadRequest, "wait_seconds must be between 0 and 30"),
+			wantLanguage:   "es",
+			want:           ErrorResponse{Error: "Solicitud incorrecta", Code: "BAD_REQUEST", Details: "wait_seconds must be between 0 and 30"},
+		},
+		{
+			name:           "unsupported language",
+			acceptLanguage: "fr-FR",
+			err:            errors.New("insufficient funds"),
+			wantLanguage:   "en",
+			want:           ErrorResponse{Error: "Insufficient funds", Code: "INSUFFICIENT_FUNDS", Details: "insufficient funds"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(ErrorHandler())
+			app.Get("/", func(c *fiber.Ctx) error { return tt.err })
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set(fiber.HeaderAcceptLanguage, tt.acceptLanguage)
+			}
+
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			var body ErrorResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+			assert.Equal(t, tt.want, body)
+			assert.Equal(t, tt.wantLanguage, resp.Header.Get(fiber.HeaderContentLanguage))
+			assert.Contains(t, resp.Header.Get(fiber.HeaderVary), fiber.HeaderAcceptLanguage)
+		})
+	}
+}
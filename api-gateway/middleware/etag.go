@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Cache-Control values of cacheable responses, by how often the resource changes
+const (
+	CacheControlImmutable  = "private, max-age=86400, immutable" // Never changes again, e.g. a finished transfer
+	CacheControlCatalog    = "public, max-age=300"               // Reference data the gateway itself caches for 5 minutes, e.g. currencies
+	CacheControlRevalidate = "private, no-cache"                 // Changes at any time; caches revalidate with the ETag before every use
+)
+
+// ETag tags successful GET and HEAD responses with an ETag hashed from their body, and answers requests whose
+// If-None-Match lists it with 304 Not Modified and no body. The ETag is weak since Compress may encode the body
+// differently for each client after it was hashed. Handlers set Cache-Control to say how long a response
+// stays fresh; responses without one are sent with CacheControlRevalidate. The handler still runs on every request,
+// so a 304 saves the transfer of the body, not the work behind it.
+func ETag() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		if (c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead) || c.Response().StatusCode() != fiber.StatusOK {
+			return nil
+		}
+
+		sum := sha256.Sum256(c.Response().Body())
+		etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+
+		c.Set(fiber.HeaderETag, etag)
+		if len(c.Response().Header.Peek(fiber.HeaderCacheControl)) == 0 {
+			c.Set(fiber.HeaderCacheControl, CacheControlRevalidate)
+		}
+
+		if etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+			c.Status(fiber.StatusNotModified)
+			c.Response().ResetBody()
+		}
+
+		return nil
+	}
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, using the weak comparison of RFC 9110 section 13.1.2
+func etagMatches(ifNoneMatch, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
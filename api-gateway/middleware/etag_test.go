@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestETag(t *testing.T) {
+	t.Parallel()
+
+	body := `{"status":"COMPLETED"}`
+
+	app := fiber.New()
+	app.Use(ErrorHandler())
+	app.Get("/immutable", ETag(), func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderCacheControl, CacheControlImmutable)
+		return c.SendString(body)
+	})
+	app.Get("/default", ETag(), func(c *fiber.Ctx) error { return c.SendString(body) })
+	app.Get("/missing", ETag(), func(c *fiber.Ctx) error { return fiber.NewError(fiber.StatusNotFound, "missing") })
+
+	get := func(path, ifNoneMatch string) (*httptestResponse, error) {
+		req := httptest.NewRequest(fiber.MethodGet, path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set(fiber.HeaderIfNoneMatch, ifNoneMatch)
+		}
+
+		resp, err := app.Test(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		responseBody, err := io.ReadAll(resp.Body)
+
+		return &httptestResponse{status: resp.StatusCode, etag: resp.Header.Get(fiber.HeaderETag), cacheControl: resp.Header.Get(fiber.HeaderCacheControl), body: string(responseBody)}, err
+	}
+
+	first, err := get("/immutable", "")
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, first.status)
+	assert.Equal(t, body, first.body)
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, first.etag)
+	assert.Equal(t, CacheControlImmutable, first.cacheControl)
+
+	for _, ifNoneMatch := range []string{first.etag, strings.TrimPrefix(first.etag, "W/"), `"other", ` + first.etag, "*"} {
+		revalidated, err := get("/immutable", ifNoneMatch)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotModified, revalidated.status, ifNoneMatch)
+		assert.Empty(t, revalidated.body)
+		assert.Equal(t, first.etag, revalidated.etag)
+		assert.Equal(t, CacheControlImmutable, revalidated.cacheControl)
+	}
+
+	changed, err := get("/immutable", `"other"`)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, changed.status)
+	assert.Equal(t, body, changed.body)
+
+	// Same body, same ETag; responses without a Cache-Control are revalidated
+	other, err := get("/default", "")
+	require.NoError(t, err)
+	assert.Equal(t, first.etag, other.etag)
+	assert.Equal(t, CacheControlRevalidate, other.cacheControl)
+
+	missing, err := get("/missing", "*")
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, missing.status)
+	assert.Empty(t, missing.etag)
+}
+
+type httptestResponse struct {
+	status       int
+	etag         string
+	cacheControl string
+	body         string
+}
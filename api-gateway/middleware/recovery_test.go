@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"api-gateway/util/crash"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecover(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	app := fiber.New()
+	app.Use(Recover(logger))
+	app.Get("/panic", func(c *fiber.Ctx) error { panic("boom") })
+
+	before := crash.Total()
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/panic", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, "INTERNAL_ERROR", body.Code)
+	assert.Equal(t, int64(1), crash.Total()-before)
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"api-gateway/adapter/balance_adapter"
+	flowenginepb "api-gateway/adapter/flowngine_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrAccountNotFound is returned for account numbers svc-balance has no account for
+var ErrAccountNotFound = errors.New("account not found")
+
+type GetAccountSummaryParams struct {
+	AccountNumber string `json:"account_number"`
+	Tenant        string `json:"-"` // Selects the account number format; empty for the default
+}
+
+// AccountSummary is an account with its balance and the amounts of its running transfers. Version changes with
+// every balance or status change; the pending amounts do not bump it.
+type AccountSummary struct {
+	AccountNumber string `json:"account_number"`
+	AccountName   string `json:"account_name"`
+	Balance       string `json:"balance"` // Exact major-unit decimal
+	Currency      string `json:"currency"`
+	Status        string `json:"status"`
+	AccountType   string `json:"account_type"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+	Version       int32  `json:"version"`
+
+	// Pending has one entry per currency of running transfers, empty when none is running and nil when FlowEngine
+	// could not be asked
+	Pending          []PendingAmount `json:"pending"`
+	PendingTruncated bool            `json:"pending_truncated"` // Too many running transfers to sum them all
+}
+
+// PendingAmount sums the running transfers of an account in one currency
+type PendingAmount struct {
+	Currency string `json:"currency"`
+	Outgoing string `json:"outgoing"` // Exact major-unit decimal
+	Incoming string `json:"incoming"` // Exact major-unit decimal
+}
+
+// GetAccountSummary returns an account with its balance from svc-balance and its pending amounts from FlowEngine
+func (service *Service) GetAccountSummary(ctx context.Context, params *GetAccountSummaryParams) (*AccountSummary, error) {
+	const op = "service.Service.GetAccountSummary"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Getting account summary from svc-balance")
+
+	if err := service.validateAccountNumber(params.Tenant, "account_number", params.AccountNumber); err != nil {
+		violations := &TransferValidationError{}
+		violations.add("account_number", nil, "%v", err)
+
+		return nil, violations
+	}
+
+	response, err := service.balanceAdapter.GetAccountSummary(ctx, params.AccountNumber)
+	if err != nil {
+		var responseErr *balance_adapter.ResponseError
+		if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound {
+			err = fmt.Errorf("%w: %s", ErrAccountNotFound, params.AccountNumber)
+		} else {
+			err = fmt.Errorf("failed to get account summary from svc-balance: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &AccountSummary{
+		AccountNumber: response.AccountNumber,
+		AccountName:   response.AccountName,
+		Balance:       response.Balance,
+		Currency:      response.Currency,
+		Status:        response.Status,
+		AccountType:   response.AccountType,
+		CreatedAt:     response.CreatedAt,
+		UpdatedAt:     response.UpdatedAt,
+		Version:       response.Version,
+	}
+
+	// Transfers may have been started with the account ID or the account number, so both are matched. The balance
+	// is still worth returning when FlowEngine is down, the pending amounts are left unset instead.
+	workflows, err := service.flowngineAdapter.ListAccountWorkflows(ctx, &flowenginepb.ListAccountWorkflowsRequest{
+		AccountId:     response.ID,
+		AccountNumber: response.AccountNumber,
+	})
+	if err != nil {
+		logger.WithError(err).Warn("Failed to get pending amounts from FlowEngine")
+
+		return results, nil
+	}
+
+	results.Pending = make([]PendingAmount, 0, len(workflows.Pending))
+	for _, pending := range workflows.Pending {
+		results.Pending = append(results.Pending, PendingAmount{
+			Currency: pending.Currency,
+			Outgoing: pending.Outgoing,
+			Incoming: pending.Incoming,
+		})
+	}
+	results.PendingTruncated = workflows.Truncated
+
+	return results, nil
+}
+
+// MaxGetBalancesAccounts caps the accounts of one GetBalances call; svc-balance allows no more either
+const MaxGetBalancesAccounts = 200
+
+type GetBalancesParams struct {
+	AccountNumbers []string `json:"account_numbers"`
+	Tenant         string   `json:"-"` // Selects the account number format; empty for the default
+}
+
+// AccountBalance is the balance of one account returned by GetBalances
+type AccountBalance struct {
+	AccountNumber string `json:"account_number"`
+	Balance       string `json:"balance"` // Exact major-unit decimal
+	Currency      string `json:"currency"`
+	Status        string `json:"status"`
+	UpdatedAt     string `json:"updated_at"`
+	Version       int32  `json:"version"`
+}
+
+// GetBalancesResults holds the balances in the order the accounts were requested, each account once. Accounts
+// svc-balance has no account for are listed in NotFound.
+type GetBalancesResults struct {
+	Balances []AccountBalance `json:"balances"`
+	NotFound []string         `json:"not_found"`
+}
+
+// GetBalances returns the balances of many accounts with a single svc-balance call, so dashboards need not check
+// them one by one
+func (service *Service) GetBalances(ctx context.Context, params *GetBalancesParams) (*GetBalancesResults, error) {
+	const op = "service.Service.GetBalances"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"accounts": len(params.AccountNumbers),
+		"tenant":   params.Tenant,
+	})
+
+	logger.Info("Getting balances from svc-balance")
+
+	violations := &TransferValidationError{}
+
+	switch {
+	case len(params.AccountNumbers) == 0:
+		violations.add("account_numbers", nil, "account_numbers is required")
+	case len(params.AccountNumbers) > MaxGetBalancesAccounts:
+		violations.add("account_numbers", nil, "at most %d account_numbers can be read at once", MaxGetBalancesAccounts)
+	}
+
+	for i, accountNumber := range params.AccountNumbers {
+		field := fmt.Sprintf("account_numbers[%d]", i)
+		if err := service.validateAccountNumber(params.Tenant, field, accountNumber); err != nil {
+			violations.add(field, nil, "%v", err)
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		logger.WithError(violations).Warn("Balances query rejected")
+
+		return nil, violations
+	}
+
+	response, err := service.balanceAdapter.GetBalances(ctx, params.AccountNumbers)
+	if err != nil {
+		err = fmt.Errorf("failed to get balances from svc-balance: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &GetBalancesResults{
+		Balances: make([]AccountBalance, 0, len(response.Balances)),
+		NotFound: response.NotFound,
+	}
+	if results.NotFound == nil {
+		results.NotFound = []string{}
+	}
+	for _, balance := range response.Balances {
+		results.Balances = append(results.Balances, AccountBalance{
+			AccountNumber: balance.AccountNumber,
+			Balance:       balance.Balance,
+			Currency:      balance.Currency,
+			Status:        balance.Status,
+			UpdatedAt:     balance.UpdatedAt,
+			Version:       balance.Version,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"api-gateway/adapter/balance_ops_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultAccountReportLimit is the page size of the account reports when none is requested
+	DefaultAccountReportLimit = 50
+
+	// MaxAccountReportLimit caps the page size of the account reports; svc-balance allows no more either
+	MaxAccountReportLimit = 500
+)
+
+var (
+	// ErrInvalidAccountReport is returned when svc-balance rejects the threshold, idle period or page of a report
+	ErrInvalidAccountReport = errors.New("invalid account report")
+
+	// ErrAccountReportsUnavailable is returned when no svc-balance gRPC port is configured
+	ErrAccountReportsUnavailable = errors.New("account reports are not configured")
+)
+
+type ListLowBalanceAccountsParams struct {
+	Threshold string `json:"threshold"` // Exact decimal compared with the balance in the currency of each account
+	Limit     int32  `json:"limit"`
+	Offset    int32  `json:"offset"`
+}
+
+type ListDormantAccountsParams struct {
+	InactiveDays int32 `json:"inactive_days"` // 0 for the svc-balance default of 90 days
+	Limit        int32 `json:"limit"`
+	Offset       int32 `json:"offset"`
+}
+
+// ReportAccount is an account listed by an account report
+type ReportAccount struct {
+	AccountNumber     string `json:"account_number"`
+	AccountName       string `json:"account_name"`
+	Balance           string `json:"balance"` // Exact major-unit decimal
+	Currency          string `json:"currency"`
+	Status            string `json:"status,omitempty"`
+	CreatedAt         string `json:"created_at,omitempty"`
+	UpdatedAt         string `json:"updated_at,omitempty"`
+	LastTransactionAt string `json:"last_transaction_at,omitempty"` // Dormant accounts that ever transacted only
+}
+
+// AccountReport is one page of an account report. NextOffset is set when the page is full, so the next page may
+// hold more accounts.
+type AccountReport struct {
+	Accounts   []ReportAccount `json:"accounts"`
+	Count      int             `json:"count"`
+	Limit      int32           `json:"limit"`
+	Offset     int32           `json:"offset"`
+	NextOffset *int32          `json:"next_offset,omitempty"`
+}
+
+// ListLowBalanceAccounts returns one page of the active accounts below the threshold, lowest balance first
+func (service *Service) ListLowBalanceAccounts(ctx context.Context, params *ListLowBalanceAccountsParams) (*AccountReport, error) {
+	const op = "service.Service.ListLowBalanceAccounts"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Listing low balance accounts from svc-balance")
+
+	if service.balanceOpsAdapter == nil {
+		return nil, ErrAccountReportsUnavailable
+	}
+
+	limit := accountReportLimit(params.Limit)
+
+	// Call balance ops adapter
+	response, err := service.balanceOpsAdapter.ListLowBalanceAccounts(ctx, &pb.ListLowBalanceAccountsRequest{
+		Threshold: params.Threshold,
+		Limit:     limit,
+		Offset:    params.Offset,
+	})
+	if err != nil {
+		err = accountReportError(err, "failed to list low balance accounts from svc-balance")
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	accounts := make([]ReportAccount, 0, len(response.Accounts))
+	for _, account := range response.Accounts {
+		accounts = append(accounts, ReportAccount{
+			AccountNumber: account.AccountNumber,
+			AccountName:   account.AccountName,
+			Balance:       account.Balance,
+			Currency:      account.Currency,
+			Status:        account.Status,
+			CreatedAt:     reportTime(account.CreatedAt),
+			UpdatedAt:     reportTime(account.UpdatedAt),
+		})
+	}
+
+	results := newAccountReport(accounts, limit, params.Offset)
+
+	logger.WithField("accounts", results.Count).Info("Low balance accounts listed successfully")
+
+	return results, nil
+}
+
+// ListDormantAccounts returns one page of the active accounts without transactions for the idle period, longest
+// unused first
+func (service *Service) ListDormantAccounts(ctx context.Context, params *ListDormantAccountsParams) (*AccountReport, error) {
+	const op = "service.Service.ListDormantAccounts"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Listing dormant accounts from svc-balance")
+
+	if service.balanceOpsAdapter == nil {
+		return nil, ErrAccountReportsUnavailable
+	}
+
+	limit := accountReportLimit(params.Limit)
+
+	// Call balance ops adapter
+	response, err := service.balanceOpsAdapter.ListDormantAccounts(ctx, &pb.ListDormantAccountsRequest{
+		InactiveDays: params.InactiveDays,
+		Limit:        limit,
+		Offset:       params.Offset,
+	})
+	if err != nil {
+		err = accountReportError(err, "failed to list dormant accounts from svc-balance")
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	accounts := make([]ReportAccount, 0, len(response.Accounts))
+	for _, account := range response.Accounts {
+		accounts = append(accounts, ReportAccount{
+			AccountNumber:     account.AccountNumber,
+			AccountName:       account.AccountName,
+			Balance:           account.Balance,
+			Currency:          account.Currency,
+			CreatedAt:         reportTime(account.CreatedAt),
+			LastTransactionAt: reportTime(account.LastTransactionAt),
+		})
+	}
+
+	results := newAccountReport(accounts, limit, params.Offset)
+
+	logger.WithField("accounts", results.Count).Info("Dormant accounts listed successfully")
+
+	return results, nil
+}
+
+func accountReportLimit(limit int32) int32 {
+	if limit == 0 {
+		return DefaultAccountReportLimit
+	}
+
+	return limit
+}
+
+func newAccountReport(accounts []ReportAccount, limit, offset int32) *AccountReport {
+	report := &AccountReport{
+		Accounts: accounts,
+		Count:    len(accounts),
+		Limit:    limit,
+		Offset:   offset,
+	}
+	if int32(len(accounts)) == limit {
+		next := offset + limit
+		report.NextOffset = &next
+	}
+
+	return report
+}
+
+// accountReportError maps the INVALID_ARGUMENT of svc-balance to ErrInvalidAccountReport and wraps other errors
+func accountReportError(err error, message string) error {
+	if status.Code(err) == codes.InvalidArgument {
+		return fmt.Errorf("%w: %s", ErrInvalidAccountReport, status.Convert(err).Message())
+	}
+
+	return fmt.Errorf("%s: %w", message, err)
+}
+
+// reportTime formats a timestamp of an account report; unset timestamps stay empty
+func reportTime(timestamp *timestamppb.Timestamp) string {
+	if timestamp == nil {
+		return ""
+	}
+
+	return timestamp.AsTime().Format(time.RFC3339)
+}
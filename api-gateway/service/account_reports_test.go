@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"api-gateway/adapter/balance_ops_adapter"
+	"api-gateway/adapter/balance_ops_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeBalanceOps serves the account reports of svc-balance from fixed accounts
+type fakeBalanceOps struct {
+	pb.UnimplementedBalanceOpsServer
+
+	lowBalanceRequests []*pb.ListLowBalanceAccountsRequest
+	dormantRequests    []*pb.ListDormantAccountsRequest
+}
+
+func (ops *fakeBalanceOps) ListLowBalanceAccounts(ctx context.Context, request *pb.ListLowBalanceAccountsRequest) (*pb.ListLowBalanceAccountsResponse, error) {
+	ops.lowBalanceRequests = append(ops.lowBalanceRequests, request)
+
+	if request.Threshold == "lots" {
+		return nil, status.Error(codes.InvalidArgument, "threshold must be a decimal number")
+	}
+
+	return &pb.ListLowBalanceAccountsResponse{Accounts: []*pb.LowBalanceAccount{
+		{AccountNumber: "ACC000000001", Balance: "1.5", Currency: "USD", Status: "active", CreatedAt: timestamppb.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))},
+		{AccountNumber: "ACC000000002", Balance: "9", Currency: "EUR", Status: "active"},
+	}}, nil
+}
+
+func (ops *fakeBalanceOps) ListDormantAccounts(ctx context.Context, request *pb.ListDormantAccountsRequest) (*pb.ListDormantAccountsResponse, error) {
+	ops.dormantRequests = append(ops.dormantRequests, request)
+
+	return &pb.ListDormantAccountsResponse{Accounts: []*pb.DormantAccount{
+		{AccountNumber: "ACC000000003", Balance: "0", Currency: "USD"},
+	}}, nil
+}
+
+func newAccountReportTestService(t *testing.T) (*Service, *fakeBalanceOps) {
+	t.Helper()
+
+	ops := &fakeBalanceOps{}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	pb.RegisterBalanceOpsServer(server, ops)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///svc-balance",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return &Service{logger: logger, balanceOpsAdapter: balance_ops_adapter.NewAdapter("svc-balance", logger, conn)}, ops
+}
+
+func TestListLowBalanceAccounts(t *testing.T) {
+	t.Parallel()
+
+	service, ops := newAccountReportTestService(t)
+
+	report, err := service.ListLowBalanceAccounts(context.Background(), &ListLowBalanceAccountsParams{Threshold: "10", Limit: 2, Offset: 4})
+	require.NoError(t, err)
+
+	assert.Equal(t, "10", ops.lowBalanceRequests[0].Threshold)
+	assert.Equal(t, 2, report.Count)
+	assert.Equal(t, "2026-01-01T00:00:00Z", report.Accounts[0].CreatedAt)
+	assert.Empty(t, report.Accounts[1].CreatedAt, "unset timestamps stay empty")
+	require.NotNil(t, report.NextOffset, "a full page may be followed by another")
+	assert.Equal(t, int32(6), *report.NextOffset)
+
+	report, err = service.ListLowBalanceAccounts(context.Background(), &ListLowBalanceAccountsParams{Threshold: "10"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(DefaultAccountReportLimit), ops.lowBalanceRequests[1].Limit)
+	assert.Nil(t, report.NextOffset, "a partial page is the last")
+
+	_, err = service.ListLowBalanceAccounts(context.Background(), &ListLowBalanceAccountsParams{Threshold: "lots"})
+	assert.ErrorIs(t, err, ErrInvalidAccountReport)
+}
+
+func TestListDormantAccounts(t *testing.T) {
+	t.Parallel()
+
+	service, ops := newAccountReportTestService(t)
+
+	report, err := service.ListDormantAccounts(context.Background(), &ListDormantAccountsParams{InactiveDays: 30, Limit: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(30), ops.dormantRequests[0].InactiveDays)
+	assert.Equal(t, []ReportAccount{{AccountNumber: "ACC000000003", Balance: "0", Currency: "USD"}}, report.Accounts)
+	require.NotNil(t, report.NextOffset)
+	assert.Equal(t, int32(1), *report.NextOffset)
+
+	// Without a svc-balance gRPC port the reports are disabled
+	service.balanceOpsAdapter = nil
+	_, err = service.ListDormantAccounts(context.Background(), &ListDormantAccountsParams{})
+	assert.ErrorIs(t, err, ErrAccountReportsUnavailable)
+}
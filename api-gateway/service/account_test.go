@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"api-gateway/adapter/balance_adapter"
+	"api-gateway/adapter/flowngine_adapter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestGetBalances(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newCurrencyTestService(t)
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Accounts []string `json:"accounts"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+strings.Join(body.Accounts, ","))
+
+		io.WriteString(w, `{"status":"success","message":"Balances retrieved successfully","data":{
+			"balances":[{"account":"ACC000000002","account_number":"ACC000000002","balance":"2500.50","currency":"EUR","status":"ACTIVE","updated_at":"2026-10-16T08:00:00Z","version":3}],
+			"not_found":["ACC000000404"]}}`)
+	}))
+	t.Cleanup(server.Close)
+	service.balanceAdapter = balance_adapter.NewAdapter("svc-balance", service.logger, server.URL, time.Second)
+
+	results, err := service.GetBalances(context.Background(), &GetBalancesParams{AccountNumbers: []string{"ACC000000002", "ACC000000404"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"POST /accounts/balances ACC000000002,ACC000000404"}, requests, "one svc-balance call for all accounts")
+	assert.Equal(t, []AccountBalance{{
+		AccountNumber: "ACC000000002", Balance: "2500.50", Currency: "EUR", Status: "ACTIVE", UpdatedAt: "2026-10-16T08:00:00Z", Version: 3,
+	}}, results.Balances)
+	assert.Equal(t, []string{"ACC000000404"}, results.NotFound)
+
+	tooMany := make([]string, MaxGetBalancesAccounts+1)
+	for i := range tooMany {
+		tooMany[i] = "ACC000000002"
+	}
+
+	for name, tc := range map[string]struct {
+		params *GetBalancesParams
+		fields []string
+	}{
+		"empty":            {params: &GetBalancesParams{}, fields: []string{"account_numbers"}},
+		"too many":         {params: &GetBalancesParams{AccountNumbers: tooMany}, fields: []string{"account_numbers"}},
+		"invalid number":   {params: &GetBalancesParams{AccountNumbers: []string{"ACC000000002", "SHORT"}}, fields: []string{"account_numbers[1]"}},
+		"tenant checksums": {params: &GetBalancesParams{AccountNumbers: []string{"ACM00000000001"}, Tenant: "acme"}, fields: []string{"account_numbers[0]"}},
+	} {
+		_, err := service.GetBalances(context.Background(), tc.params)
+
+		var violations *TransferValidationError
+		require.True(t, errors.As(err, &violations), name)
+		assert.Equal(t, tc.fields, violationFields(violations), name)
+	}
+	assert.Len(t, requests, 1, "rejected queries do not reach svc-balance")
+}
+
+func TestGetAccountSummaryWithoutFlowEngine(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newCurrencyTestService(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"status":"success","data":{"id":"550e8400-e29b-41d4-a716-446655440001","account_number":"ACC000000001",
+			"balance":"1000.00","currency":"USD","status":"ACTIVE","version":7}}`)
+	}))
+	t.Cleanup(server.Close)
+	service.balanceAdapter = balance_adapter.NewAdapter("svc-balance", service.logger, server.URL, time.Second)
+
+	// Nothing listens on the connection, so every FlowEngine call fails
+	conn, err := grpc.NewClient("passthrough:///flowngine",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return nil, errors.New("flowngine is down") }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	service.flowngineAdapter = flowngine_adapter.NewAdapter("flowngine", service.logger, conn)
+
+	results, err := service.GetAccountSummary(context.Background(), &GetAccountSummaryParams{AccountNumber: "ACC000000001"})
+	require.NoError(t, err, "the balance is returned without the pending amounts")
+
+	assert.Equal(t, "1000.00", results.Balance)
+	assert.Equal(t, int32(7), results.Version)
+	assert.Nil(t, results.Pending)
+}
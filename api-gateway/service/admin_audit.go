@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrInvalidAdminAuditFilter is returned when FlowEngine rejects an admin audit filter, e.g. from after to
+var ErrInvalidAdminAuditFilter = errors.New("invalid admin audit filter")
+
+// ListAdminAuditParams narrows the admin audit; zero-valued fields leave that dimension unfiltered.
+// From is inclusive and To is exclusive.
+type ListAdminAuditParams struct {
+	Actor        string    `json:"actor,omitempty"`
+	Action       string    `json:"action,omitempty"`
+	ResourceType string    `json:"resource_type,omitempty"`
+	ResourceID   string    `json:"resource_id,omitempty"`
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	Limit        int32     `json:"limit"`
+}
+
+type ListAdminAuditResults struct {
+	Records []AdminAuditRecord `json:"records"`
+	Count   int                `json:"count"`
+}
+
+// AdminAuditRecord is one recorded state-changing admin call, with the state before and after it as JSON
+type AdminAuditRecord struct {
+	ID           string          `json:"id"`
+	Actor        string          `json:"actor"`
+	Action       string          `json:"action"`
+	Service      string          `json:"service"`
+	ResourceType string          `json:"resource_type"`
+	ResourceID   string          `json:"resource_id,omitempty"`
+	Before       json.RawMessage `json:"before,omitempty"`
+	After        json.RawMessage `json:"after,omitempty"`
+	CreatedAt    string          `json:"created_at"`
+}
+
+func (service *Service) ListAdminAudit(ctx context.Context, params *ListAdminAuditParams) (results *ListAdminAuditResults, err error) {
+	const op = "service.Service.ListAdminAudit"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Listing admin audit from FlowEngine")
+
+	request := &pb.ListAdminAuditRequest{
+		Actor:        params.Actor,
+		Action:       params.Action,
+		ResourceType: params.ResourceType,
+		ResourceId:   params.ResourceID,
+		Limit:        params.Limit,
+	}
+	if !params.From.IsZero() {
+		request.From = timestamppb.New(params.From)
+	}
+	if !params.To.IsZero() {
+		request.To = timestamppb.New(params.To)
+	}
+
+	// Call FlowEngine adapter
+	response, err := service.flowngineAdapter.ListAdminAudit(ctx, request)
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			err = fmt.Errorf("%w: %s", ErrInvalidAdminAuditFilter, status.Convert(err).Message())
+		} else {
+			err = fmt.Errorf("failed to list admin audit from FlowEngine: %w", err)
+		}
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results = &ListAdminAuditResults{
+		Records: make([]AdminAuditRecord, 0, len(response.Records)),
+	}
+
+	for _, record := range response.Records {
+		results.Records = append(results.Records, AdminAuditRecord{
+			ID:           record.Id,
+			Actor:        record.Actor,
+			Action:       record.Action,
+			Service:      record.Service,
+			ResourceType: record.ResourceType,
+			ResourceID:   record.ResourceId,
+			Before:       json.RawMessage(record.Before),
+			After:        json.RawMessage(record.After),
+			CreatedAt:    record.CreatedAt.AsTime().Format(time.RFC3339Nano),
+		})
+	}
+	results.Count = len(results.Records)
+
+	logger.WithField("records", results.Count).Info("Admin audit listed successfully")
+
+	return results, nil
+}
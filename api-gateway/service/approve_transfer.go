@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ApproveTransferParams struct {
+	TransactionID string `json:"transaction_id"` // Transaction ID or transfer reference
+	ApprovedBy    string `json:"approved_by"`
+}
+
+type ApproveTransferResults struct {
+	TransactionID string `json:"transaction_id"`
+	Success       bool   `json:"success"`
+	Message       string `json:"message"`
+}
+
+// ApproveTransfer releases a transfer FlowEngine holds for approval. Transfers that are not waiting anymore,
+// e.g. because they expired, are reported as ErrTransferNotFound.
+func (service *Service) ApproveTransfer(ctx context.Context, params *ApproveTransferParams) (results *ApproveTransferResults, err error) {
+	const op = "service.Service.ApproveTransfer"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Approving transfer through FlowEngine")
+
+	// Call FlowEngine adapter
+	approveResponse, err := service.flowngineAdapter.ApproveTransfer(ctx, &pb.ApproveTransferRequest{
+		TransactionId: params.TransactionID,
+		ApprovedBy:    params.ApprovedBy,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = fmt.Errorf("%w: %s", ErrTransferNotFound, params.TransactionID)
+		} else {
+			err = fmt.Errorf("failed to approve transfer through FlowEngine: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results = &ApproveTransferResults{
+		TransactionID: params.TransactionID,
+		Success:       approveResponse.Success,
+		Message:       approveResponse.Message,
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Transfer approved")
+
+	return results, nil
+}
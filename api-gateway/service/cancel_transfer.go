@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CancelTransferParams struct {
+	TransactionID string `json:"transaction_id"` // Transaction ID or transfer reference
+	Reason        string `json:"reason"`
+}
+
+type CancelTransferResults struct {
+	TransactionID string `json:"transaction_id"`
+	Success       bool   `json:"success"`
+	Message       string `json:"message"`
+}
+
+func (service *Service) CancelTransfer(ctx context.Context, params *CancelTransferParams) (results *CancelTransferResults, err error) {
+	const op = "service.Service.CancelTransfer"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Cancelling transfer through FlowEngine")
+
+	// Call FlowEngine adapter
+	cancelResponse, err := service.flowngineAdapter.CancelTransfer(ctx, &pb.CancelTransferRequest{
+		TransactionId: params.TransactionID,
+		Reason:        params.Reason,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = fmt.Errorf("%w: %s", ErrTransferNotFound, params.TransactionID)
+		} else {
+			err = fmt.Errorf("failed to cancel transfer through FlowEngine: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results = &CancelTransferResults{
+		TransactionID: params.TransactionID,
+		Success:       cancelResponse.Success,
+		Message:       cancelResponse.Message,
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Transfer cancellation requested")
+
+	return results, nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrInvalidCompensationFilter is returned when FlowEngine rejects a compensation filter, e.g. an unknown status
+var ErrInvalidCompensationFilter = errors.New("invalid compensation filter")
+
+// CompensationFilter narrows the compensation audit; zero-valued fields leave that dimension unfiltered.
+// From is inclusive and To is exclusive.
+type CompensationFilter struct {
+	Status string    `json:"status,omitempty"`
+	Type   string    `json:"type,omitempty"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+}
+
+func (filter CompensationFilter) toPb() *pb.CompensationFilter {
+	result := &pb.CompensationFilter{
+		Status: filter.Status,
+		Type:   filter.Type,
+	}
+	if !filter.From.IsZero() {
+		result.From = timestamppb.New(filter.From)
+	}
+	if !filter.To.IsZero() {
+		result.To = timestamppb.New(filter.To)
+	}
+
+	return result
+}
+
+type ListCompensationsParams struct {
+	Filter     CompensationFilter `json:"filter"`
+	WorkflowID string             `json:"workflow_id"`
+	Limit      int32              `json:"limit"`
+}
+
+type ListCompensationsResults struct {
+	Compensations []Compensation `json:"compensations"`
+	Count         int            `json:"count"`
+}
+
+// Compensation is one compensation audit record: an attempt to undo a debit after a transfer failed
+type Compensation struct {
+	ID                        string `json:"id"`
+	WorkflowID                string `json:"workflow_id"`
+	RunID                     string `json:"run_id"`
+	TransferID                string `json:"transfer_id,omitempty"`
+	OriginalTransactionID     string `json:"original_transaction_id,omitempty"`
+	CompensationTransactionID string `json:"compensation_transaction_id,omitempty"`
+	Reason                    string `json:"reason"`
+	Type                      string `json:"type"`
+	Status                    string `json:"status"`
+	Attempts                  int32  `json:"attempts"`
+	CreatedAt                 string `json:"created_at"`
+	UpdatedAt                 string `json:"updated_at"`
+	CompletedAt               string `json:"completed_at,omitempty"`
+	FailureReason             string `json:"failure_reason,omitempty"`
+	TimeoutDurationMs         int32  `json:"timeout_duration_ms,omitempty"`
+}
+
+type GetCompensationStatsParams struct {
+	Filter CompensationFilter `json:"filter"`
+}
+
+type GetCompensationStatsResults struct {
+	Total           int64   `json:"total"`
+	Completed       int64   `json:"completed"`
+	Failed          int64   `json:"failed"`
+	Timeout         int64   `json:"timeout"`
+	ManualRequired  int64   `json:"manual_required"`
+	Pending         int64   `json:"pending"`
+	AverageAttempts float64 `json:"average_attempts"`
+	From            string  `json:"from"`
+	To              string  `json:"to"`
+}
+
+func (service *Service) ListCompensations(ctx context.Context, params *ListCompensationsParams) (results *ListCompensationsResults, err error) {
+	const op = "service.Service.ListCompensations"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Listing compensations from FlowEngine")
+
+	// Call FlowEngine adapter
+	response, err := service.flowngineAdapter.ListCompensations(ctx, &pb.ListCompensationsRequest{
+		Filter:     params.Filter.toPb(),
+		WorkflowId: params.WorkflowID,
+		Limit:      params.Limit,
+	})
+	if err != nil {
+		err = compensationError(err, "failed to list compensations from FlowEngine")
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results = &ListCompensationsResults{
+		Compensations: make([]Compensation, 0, len(response.Compensations)),
+	}
+
+	for _, record := range response.Compensations {
+		compensation := Compensation{
+			ID:                        record.Id,
+			WorkflowID:                record.WorkflowId,
+			RunID:                     record.RunId,
+			TransferID:                record.TransferId,
+			OriginalTransactionID:     record.OriginalTransactionId,
+			CompensationTransactionID: record.CompensationTransactionId,
+			Reason:                    record.Reason,
+			Type:                      record.Type,
+			Status:                    record.Status,
+			Attempts:                  record.Attempts,
+			CreatedAt:                 record.CreatedAt.AsTime().Format(time.RFC3339Nano),
+			UpdatedAt:                 record.UpdatedAt.AsTime().Format(time.RFC3339Nano),
+			FailureReason:             record.FailureReason,
+			TimeoutDurationMs:         record.TimeoutDurationMs,
+		}
+		if record.CompletedAt != nil {
+			compensation.CompletedAt = record.CompletedAt.AsTime().Format(time.RFC3339Nano)
+		}
+
+		results.Compensations = append(results.Compensations, compensation)
+	}
+	results.Count = len(results.Compensations)
+
+	logger.WithField("compensations", results.Count).Info("Compensations listed successfully")
+
+	return results, nil
+}
+
+func (service *Service) GetCompensationStats(ctx context.Context, params *GetCompensationStatsParams) (results *GetCompensationStatsResults, err error) {
+	const op = "service.Service.GetCompensationStats"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Getting compensation stats from FlowEngine")
+
+	// Call FlowEngine adapter
+	response, err := service.flowngineAdapter.GetCompensationStats(ctx, &pb.GetCompensationStatsRequest{
+		Filter: params.Filter.toPb(),
+	})
+	if err != nil {
+		err = compensationError(err, "failed to get compensation stats from FlowEngine")
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results = &GetCompensationStatsResults{
+		Total:           response.Total,
+		Completed:       response.Completed,
+		Failed:          response.Failed,
+		Timeout:         response.Timeout,
+		ManualRequired:  response.ManualRequired,
+		Pending:         response.Pending,
+		AverageAttempts: response.AverageAttempts,
+		From:            response.From.AsTime().Format(time.RFC3339Nano),
+		To:              response.To.AsTime().Format(time.RFC3339Nano),
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Compensation stats retrieved successfully")
+
+	return results, nil
+}
+
+// compensationError maps an InvalidArgument from FlowEngine to ErrInvalidCompensationFilter
+func compensationError(err error, message string) error {
+	if status.Code(err) == codes.InvalidArgument {
+		return fmt.Errorf("%w: %s", ErrInvalidCompensationFilter, status.Convert(err).Message())
+	}
+
+	return fmt.Errorf("%s: %w", message, err)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"api-gateway/adapter/balance_adapter"
+	"api-gateway/util/money"
+)
+
+// currencyCatalogTTL is how long the svc-balance currency catalog is reused before it is fetched again
+const currencyCatalogTTL = 5 * time.Minute
+
+// maxTransferAmount is the largest transfer amount accepted by the gateway, in minor units
+const maxTransferAmount = 1000000000
+
+var (
+	// ErrUnsupportedCurrency is returned when the currency is missing from the catalog or inactive
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	// ErrInvalidTransferAmount is returned when the amount is missing, malformed, ambiguous or out of range
+	ErrInvalidTransferAmount = money.ErrInvalidAmount
+	// ErrInvalidAmountScale is returned when a decimal amount is finer than the currency's minor unit, e.g. "10.50" JPY
+	ErrInvalidAmountScale = money.ErrAmountScale
+	// ErrCurrencyCatalogUnavailable is returned when the catalog could not be fetched and no copy is cached
+	ErrCurrencyCatalogUnavailable = errors.New("currency catalog unavailable")
+)
+
+// currencyCatalog caches the svc-balance currency catalog by currency code
+type currencyCatalog struct {
+	mutex      sync.Mutex
+	currencies map[string]balance_adapter.Currency
+	fetchedAt  time.Time
+}
+
+// lookupCurrency returns the catalog entry for a currency, refreshing the cached catalog once it is older than currencyCatalogTTL.
+// A stale catalog is still used when svc-balance cannot be reached.
+func (service *Service) lookupCurrency(ctx context.Context, code string) (balance_adapter.Currency, error) {
+	catalog := &service.currencyCatalog
+
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+
+	if err := service.refreshCurrencyCatalog(ctx); err != nil {
+		return balance_adapter.Currency{}, err
+	}
+
+	currency, ok := catalog.currencies[code]
+	if !ok || !currency.IsActive {
+		return balance_adapter.Currency{}, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, code)
+	}
+
+	return currency, nil
+}
+
+// ListCurrencies returns the active currencies of the catalog ordered by code, from the same cached copy
+// transfers are checked against
+func (service *Service) ListCurrencies(ctx context.Context) ([]balance_adapter.Currency, error) {
+	catalog := &service.currencyCatalog
+
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+
+	if err := service.refreshCurrencyCatalog(ctx); err != nil {
+		return nil, err
+	}
+
+	currencies := make([]balance_adapter.Currency, 0, len(catalog.currencies))
+	for _, currency := range catalog.currencies {
+		if currency.IsActive {
+			currencies = append(currencies, currency)
+		}
+	}
+	slices.SortFunc(currencies, func(a, b balance_adapter.Currency) int { return strings.Compare(a.Code, b.Code) })
+
+	return currencies, nil
+}
+
+// refreshCurrencyCatalog fetches the catalog when it is missing or older than currencyCatalogTTL. The caller holds
+// the catalog mutex.
+func (service *Service) refreshCurrencyCatalog(ctx context.Context) error {
+	catalog := &service.currencyCatalog
+
+	if catalog.currencies != nil && time.Since(catalog.fetchedAt) <= currencyCatalogTTL {
+		return nil
+	}
+
+	response, err := service.balanceAdapter.GetCurrencies(ctx)
+	switch {
+	case err == nil:
+		catalog.currencies = make(map[string]balance_adapter.Currency, len(response.Currencies))
+		for _, currency := range response.Currencies {
+			catalog.currencies[currency.Code] = currency
+		}
+		catalog.fetchedAt = time.Now()
+	case catalog.currencies == nil:
+		return fmt.Errorf("%w: %v", ErrCurrencyCatalogUnavailable, err)
+	default:
+		service.logger.WithError(err).Warn("Failed to refresh currency catalog, using cached copy")
+	}
+
+	return nil
+}
+
+// resolveTransferAmount validates a transfer amount against its currency and returns it in minor units,
+// together with its major-unit decimal representation.
+// Exactly one of amount (minor units) or amountDecimal (major units, e.g. "100.50") must be set.
+func (service *Service) resolveTransferAmount(ctx context.Context, amount int, amountDecimal *string, code string) (int64, string, error) {
+	currency, err := service.lookupCurrency(ctx, code)
+	if err != nil {
+		return 0, "", err
+	}
+
+	var minorUnits int64
+	switch {
+	case amount != 0 && amountDecimal != nil:
+		return 0, "", fmt.Errorf("%w: only one of amount or amount_decimal can be set", ErrInvalidTransferAmount)
+	case amountDecimal != nil:
+		if minorUnits, err = money.ParseMinorUnits(*amountDecimal, currency.DecimalPlace); err != nil {
+			return 0, "", err
+		}
+	default:
+		minorUnits = int64(amount)
+	}
+
+	if minorUnits <= 0 || minorUnits > maxTransferAmount {
+		return 0, "", fmt.Errorf("%w: amount must be between 1 and %d minor units", ErrInvalidTransferAmount, maxTransferAmount)
+	}
+
+	return minorUnits, money.FormatMinorUnits(minorUnits, currency.DecimalPlace), nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"api-gateway/adapter/balance_adapter"
+	"api-gateway/util/accountnumber"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCurrencyCatalog = `{"status":"success","message":"Supported currencies retrieved successfully","data":{"currencies":[
+	{"code":"USD","name":"US Dollar","symbol":"$","decimal_place":2,"is_active":true},
+	{"code":"JPY","name":"Japanese Yen","symbol":"¥","decimal_place":0,"is_active":true},
+	{"code":"BTC","name":"Bitcoin","symbol":"₿","decimal_place":8,"is_active":false}
+],"count":3}}`
+
+// newCurrencyTestService returns a service whose balance adapter talks to a fake svc-balance.
+// Requests fail with 503 while available is false; calls counts the catalog requests. Account numbers are 12
+// characters, except for tenant acme whose numbers carry a Luhn check digit.
+func newCurrencyTestService(t *testing.T) (service *Service, available *atomic.Bool, calls *atomic.Int32) {
+	t.Helper()
+
+	available = &atomic.Bool{}
+	available.Store(true)
+	calls = &atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			io.WriteString(w, `{"error":"unavailable"}`)
+			return
+		}
+
+		io.WriteString(w, testCurrencyCatalog)
+	}))
+	t.Cleanup(server.Close)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	accountNumbers, err := accountnumber.NewValidator([]accountnumber.Format{
+		{MinLength: 12, MaxLength: 12},
+		{Tenant: "acme", Prefix: "ACM", MinLength: 14, MaxLength: 14, Checksum: accountnumber.ChecksumLuhn},
+	})
+	require.NoError(t, err)
+
+	service = &Service{
+		logger:         logger,
+		balanceAdapter: balance_adapter.NewAdapter("svc-balance", logger, server.URL, time.Second),
+		accountNumbers: accountNumbers,
+	}
+
+	return service, available, calls
+}
+
+func TestResolveTransferAmount(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newCurrencyTestService(t)
+
+	decimalAmount := func(value string) *string { return &value }
+
+	tests := []struct {
+		name           string
+		amount         int
+		amountDecimal  *string
+		currency       string
+		wantMinorUnits int64
+		wantDecimal    string
+		wantErr        error
+	}{
+		{name: "usd_minor_units", amount: 10050, currency: "USD", wantMinorUnits: 10050, wantDecimal: "100.50"},
+		{name: "jpy_minor_units", amount: 1050, currency: "JPY", wantMinorUnits: 1050, wantDecimal: "1050"},
+		{name: "usd_decimal", amountDecimal: decimalAmount("100.5"), currency: "USD", wantMinorUnits: 10050, wantDecimal: "100.50"},
+		{name: "jpy_decimal", amountDecimal: decimalAmount("1050"), currency: "JPY", wantMinorUnits: 1050, wantDecimal: "1050"},
+		{name: "jpy_fractional_yen", amountDecimal: decimalAmount("10.50"), currency: "JPY", wantErr: ErrInvalidAmountScale},
+		{name: "usd_fractional_cents", amountDecimal: decimalAmount("100.505"), currency: "USD", wantErr: ErrInvalidAmountScale},
+		{name: "both_set", amount: 10050, amountDecimal: decimalAmount("100.50"), currency: "USD", wantErr: ErrInvalidTransferAmount},
+		{name: "neither_set", currency: "USD", wantErr: ErrInvalidTransferAmount},
+		{name: "malformed_decimal", amountDecimal: decimalAmount("1e2"), currency: "USD", wantErr: ErrInvalidTransferAmount},
+		{name: "above_maximum", amountDecimal: decimalAmount("10000000.01"), currency: "USD", wantErr: ErrInvalidTransferAmount},
+		{name: "inactive_currency", amount: 100, currency: "BTC", wantErr: ErrUnsupportedCurrency},
+		{name: "unknown_currency", amount: 100, currency: "XYZ", wantErr: ErrUnsupportedCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minorUnits, amountDecimal, err := service.resolveTransferAmount(context.Background(), tt.amount, tt.amountDecimal, tt.currency)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMinorUnits, minorUnits)
+			assert.Equal(t, tt.wantDecimal, amountDecimal)
+		})
+	}
+}
+
+func TestLookupCurrencyCaching(t *testing.T) {
+	t.Parallel()
+
+	service, available, calls := newCurrencyTestService(t)
+	ctx := context.Background()
+
+	available.Store(false)
+	_, err := service.lookupCurrency(ctx, "USD")
+	require.ErrorIs(t, err, ErrCurrencyCatalogUnavailable)
+
+	available.Store(true)
+	_, err = service.lookupCurrency(ctx, "USD")
+	require.NoError(t, err)
+	_, err = service.lookupCurrency(ctx, "JPY")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load(), "catalog should be served from cache within the TTL")
+
+	// Once expired, a failed refresh falls back to the cached catalog
+	service.currencyCatalog.fetchedAt = time.Now().Add(-2 * currencyCatalogTTL)
+	available.Store(false)
+	currency, err := service.lookupCurrency(ctx, "JPY")
+	require.NoError(t, err)
+	assert.Equal(t, 0, currency.DecimalPlace)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestListCurrencies(t *testing.T) {
+	t.Parallel()
+
+	service, _, calls := newCurrencyTestService(t)
+
+	currencies, err := service.ListCurrencies(context.Background())
+	require.NoError(t, err)
+
+	codes := make([]string, 0, len(currencies))
+	for _, currency := range currencies {
+		codes = append(codes, currency.Code)
+	}
+	assert.Equal(t, []string{"JPY", "USD"}, codes, "inactive currencies are left out")
+
+	// Served from the catalog transfers are checked against
+	_, err = service.lookupCurrency(context.Background(), "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// maxSyncWait caps how long a sync mode transfer is awaited, also when the client allows more
+	maxSyncWait = 5 * time.Minute
+
+	// minSyncWait is the smallest budget worth waiting with; below it the transfer is accepted asynchronously
+	minSyncWait = time.Second
+
+	// responseReserve is kept back from the client's deadline to write the response before the client gives up
+	responseReserve = 250 * time.Millisecond
+)
+
+// ErrDeadlineExceeded is returned when the request's deadline passes before FlowEngine accepts the transfer
+var ErrDeadlineExceeded = errors.New("request deadline exceeded before the transfer was accepted")
+
+// syncWaitBudget returns how long a sync mode transfer may be awaited within the deadline of ctx
+func syncWaitBudget(ctx context.Context) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return maxSyncWait
+	}
+
+	return min(time.Until(deadline)-responseReserve, maxSyncWait)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncWaitBudget(t *testing.T) {
+	t.Run("no deadline", func(t *testing.T) {
+		assert.Equal(t, maxSyncWait, syncWaitBudget(context.Background()))
+	})
+
+	t.Run("deadline keeps a reserve for the response", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		got := syncWaitBudget(ctx)
+		assert.LessOrEqual(t, got, 10*time.Second-responseReserve)
+		assert.Greater(t, got, 9*time.Second)
+	})
+
+	t.Run("long deadline is capped", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+
+		assert.Equal(t, maxSyncWait, syncWaitBudget(ctx))
+	})
+
+	t.Run("short deadline is below the sync threshold", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+
+		assert.Less(t, syncWaitBudget(ctx), minSyncWait)
+	})
+}
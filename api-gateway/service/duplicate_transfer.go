@@ -0,0 +1,147 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Modes of duplicate transfer detection
+const (
+	DuplicateDetectionOff     = "off"     // Every transfer is started
+	DuplicateDetectionWarn    = "warn"    // Possible duplicates are started and flagged in the response
+	DuplicateDetectionConfirm = "confirm" // Possible duplicates are rejected until the client confirms them
+)
+
+// ErrPossibleDuplicateTransfer is returned in confirm mode for a transfer that repeats a recent one without a
+// reference ID or confirm_duplicate
+var ErrPossibleDuplicateTransfer = errors.New("possible duplicate transfer")
+
+// PossibleDuplicate is a recent transfer with the same accounts, amount and currency as the requested one
+type PossibleDuplicate struct {
+	TransactionID string    `json:"transaction_id,omitempty"` // Empty while the earlier transfer is still being started
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// claimTransfer records a transfer about to be started when duplicate detection is on and the transfer has no
+// reference ID. It returns the recent transfer it may duplicate; claimed is nil when nothing was recorded, which
+// for a possible duplicate means it has to be held for confirmation.
+func (service *Service) claimTransfer(params *TransferParams, validated *validatedTransfer) (duplicate *PossibleDuplicate, claimed *recentTransfer) {
+	if service.recentTransfers == nil || (params.ReferenceID != nil && *params.ReferenceID != "") {
+		return nil, nil
+	}
+
+	hold := service.duplicateDetection == DuplicateDetectionConfirm && !params.ConfirmDuplicate
+
+	return service.recentTransfers.claim(newDuplicateKey(params, validated), time.Now(), hold)
+}
+
+// releaseTransfer forgets a claimed transfer that could not be started
+func (service *Service) releaseTransfer(params *TransferParams, validated *validatedTransfer, claimed *recentTransfer) {
+	if claimed != nil {
+		service.recentTransfers.release(newDuplicateKey(params, validated), claimed)
+	}
+}
+
+// duplicateTransactionID names a possible duplicate in messages
+func duplicateTransactionID(duplicate *PossibleDuplicate) string {
+	if duplicate.TransactionID == "" {
+		return "(still starting)"
+	}
+
+	return duplicate.TransactionID
+}
+
+// duplicateKey is what two transfers have in common to count as possible duplicates
+type duplicateKey struct {
+	fromAccount      string
+	toAccount        string
+	toAlias          string
+	amountMinorUnits int64
+	currency         string
+}
+
+func newDuplicateKey(params *TransferParams, validated *validatedTransfer) duplicateKey {
+	return duplicateKey{
+		fromAccount:      params.FromAccount,
+		toAccount:        params.ToAccount,
+		toAlias:          params.ToAlias,
+		amountMinorUnits: validated.amountMinorUnits,
+		currency:         params.Currency,
+	}
+}
+
+// recentTransfer is the latest transfer started for a duplicateKey
+type recentTransfer struct {
+	transactionID string
+	createdAt     time.Time
+}
+
+// recentTransfers remembers the transfers this gateway started within the detection window. It is kept in memory:
+// a restart or another gateway instance does not know the transfers started before or elsewhere.
+type recentTransfers struct {
+	mutex     sync.Mutex
+	window    time.Duration
+	transfers map[duplicateKey]*recentTransfer
+	lastSweep time.Time
+}
+
+func newRecentTransfers(window time.Duration) *recentTransfers {
+	return &recentTransfers{
+		window:    window,
+		transfers: make(map[duplicateKey]*recentTransfer),
+	}
+}
+
+// claim returns the transfer a new transfer for key may duplicate, if any, and records the new transfer in its place.
+// With hold set, a possible duplicate is not recorded, as it is not going to be started; claimed is nil then.
+func (r *recentTransfers) claim(key duplicateKey, now time.Time, hold bool) (duplicate *PossibleDuplicate, claimed *recentTransfer) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.sweep(now)
+
+	if previous, ok := r.transfers[key]; ok && now.Sub(previous.createdAt) < r.window {
+		duplicate = &PossibleDuplicate{TransactionID: previous.transactionID, CreatedAt: previous.createdAt}
+		if hold {
+			return duplicate, nil
+		}
+	}
+
+	claimed = &recentTransfer{createdAt: now}
+	r.transfers[key] = claimed
+
+	return duplicate, claimed
+}
+
+// complete sets the transaction ID of a claimed transfer once it has been started
+func (r *recentTransfers) complete(claimed *recentTransfer, transactionID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	claimed.transactionID = transactionID
+}
+
+// release forgets a claimed transfer that could not be started, unless a later transfer has replaced it
+func (r *recentTransfers) release(key duplicateKey, claimed *recentTransfer) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.transfers[key] == claimed {
+		delete(r.transfers, key)
+	}
+}
+
+// sweep drops the transfers that left the window, at most once per window
+func (r *recentTransfers) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < r.window {
+		return
+	}
+
+	for key, transfer := range r.transfers {
+		if now.Sub(transfer.createdAt) >= r.window {
+			delete(r.transfers, key)
+		}
+	}
+	r.lastSweep = now
+}
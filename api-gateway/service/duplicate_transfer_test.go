@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecentTransfers(t *testing.T) {
+	t.Parallel()
+
+	key := duplicateKey{fromAccount: "ACC001000001", toAccount: "ACC001000002", amountMinorUnits: 10050, currency: "USD"}
+	start := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+
+	t.Run("within the window", func(t *testing.T) {
+		recent := newRecentTransfers(5 * time.Minute)
+
+		duplicate, first := recent.claim(key, start, false)
+		assert.Nil(t, duplicate)
+		require.NotNil(t, first)
+		recent.complete(first, "tx-1")
+
+		duplicate, second := recent.claim(key, start.Add(time.Minute), false)
+		require.NotNil(t, duplicate)
+		assert.Equal(t, PossibleDuplicate{TransactionID: "tx-1", CreatedAt: start}, *duplicate)
+		assert.NotNil(t, second)
+
+		// A different amount is a different payment
+		otherKey := key
+		otherKey.amountMinorUnits = 10051
+		duplicate, _ = recent.claim(otherKey, start.Add(time.Minute), false)
+		assert.Nil(t, duplicate)
+	})
+
+	t.Run("after the window", func(t *testing.T) {
+		recent := newRecentTransfers(5 * time.Minute)
+
+		_, first := recent.claim(key, start, false)
+		recent.complete(first, "tx-1")
+
+		duplicate, _ := recent.claim(key, start.Add(5*time.Minute), false)
+		assert.Nil(t, duplicate)
+	})
+
+	t.Run("held duplicates are not recorded", func(t *testing.T) {
+		recent := newRecentTransfers(5 * time.Minute)
+
+		_, first := recent.claim(key, start, true)
+		recent.complete(first, "tx-1")
+
+		duplicate, claimed := recent.claim(key, start.Add(4*time.Minute), true)
+		require.NotNil(t, duplicate)
+		assert.Nil(t, claimed)
+
+		// The window still runs from the first transfer
+		duplicate, _ = recent.claim(key, start.Add(6*time.Minute), true)
+		assert.Nil(t, duplicate)
+	})
+
+	t.Run("released transfers are forgotten", func(t *testing.T) {
+		recent := newRecentTransfers(5 * time.Minute)
+
+		_, first := recent.claim(key, start, false)
+		recent.release(key, first)
+
+		duplicate, _ := recent.claim(key, start.Add(time.Second), false)
+		assert.Nil(t, duplicate)
+	})
+
+	t.Run("pending transfers count", func(t *testing.T) {
+		recent := newRecentTransfers(5 * time.Minute)
+
+		recent.claim(key, start, false)
+
+		duplicate, _ := recent.claim(key, start.Add(time.Second), false)
+		require.NotNil(t, duplicate)
+		assert.Empty(t, duplicate.TransactionID)
+	})
+}
+
+func TestTransferHoldsPossibleDuplicates(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newCurrencyTestService(t)
+	service.duplicateDetection = DuplicateDetectionConfirm
+	service.recentTransfers = newRecentTransfers(5 * time.Minute)
+
+	text := func(value string) *string { return &value }
+	params := &TransferParams{FromAccount: "ACC001000001", ToAccount: "ACC001000002", AmountDecimal: text("100.50"), Currency: "USD"}
+
+	validated, err := service.validateTransfer(context.Background(), params)
+	require.NoError(t, err)
+	_, earlier := service.recentTransfers.claim(newDuplicateKey(params, validated), time.Now(), false)
+	service.recentTransfers.complete(earlier, "tx-1")
+
+	_, err = service.Transfer(context.Background(), params)
+	assert.ErrorIs(t, err, ErrPossibleDuplicateTransfer)
+	assert.True(t, strings.Contains(err.Error(), "matches transfer tx-1"), err.Error())
+
+	// Confirmed duplicates and transfers with a reference ID go through to FlowEngine
+	confirmed := *params
+	confirmed.ConfirmDuplicate = true
+	duplicate, claimed := service.claimTransfer(&confirmed, validated)
+	assert.NotNil(t, duplicate)
+	assert.NotNil(t, claimed)
+
+	referenced := *params
+	referenced.ReferenceID = text("INV-2026-001")
+	duplicate, claimed = service.claimTransfer(&referenced, validated)
+	assert.Nil(t, duplicate)
+	assert.Nil(t, claimed)
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrEscrowNotFound is returned for escrows that do not exist, or that are not held anymore when deciding them
+var ErrEscrowNotFound = errors.New("escrow not found")
+
+// Decisions on a held escrow
+const (
+	EscrowDecisionRelease = "release"
+	EscrowDecisionRefund  = "refund"
+)
+
+type CreateEscrowParams struct {
+	FromAccount     string  `json:"from_account"`   // Payer
+	ToAccount       string  `json:"to_account"`     // Payee
+	AmountDecimal   string  `json:"amount_decimal"` // Major units, e.g. "100.50"
+	Currency        string  `json:"currency"`
+	Description     *string `json:"description"`
+	Arbiter         string  `json:"arbiter"`          // Optional party that may release or refund
+	DeadlineSeconds int     `json:"deadline_seconds"` // 0 takes the FlowEngine default
+	Tenant          string  `json:"-"`                // Selects the account number format; empty for the default
+}
+
+type DecideEscrowParams struct {
+	EscrowID  string `json:"escrow_id"`
+	Decision  string `json:"decision"` // release or refund
+	DecidedBy string `json:"decided_by"`
+	Reason    string `json:"reason"`
+}
+
+type DecideEscrowResults struct {
+	EscrowID string `json:"escrow_id"`
+	Success  bool   `json:"success"`
+	Message  string `json:"message"`
+}
+
+// Escrow is an amount debited from a payer and held until it is released to the payee or refunded
+type Escrow struct {
+	EscrowID      string `json:"escrow_id"`
+	FromAccount   string `json:"from_account"`
+	ToAccount     string `json:"to_account"`
+	Amount        int64  `json:"amount"`         // Minor units of the currency
+	AmountDecimal string `json:"amount_decimal"` // Major units with the currency's decimal places
+	Currency      string `json:"currency"`
+	Description   string `json:"description,omitempty"`
+	Arbiter       string `json:"arbiter,omitempty"`
+	Status        string `json:"status"` // PENDING, HELD, RELEASED, REFUNDED or FAILED
+	CreatedAt     string `json:"created_at,omitempty"`
+	Deadline      string `json:"deadline"`
+	DecidedBy     string `json:"decided_by,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+	ErrorMessage  string `json:"error_message,omitempty"`
+	HeldAt        string `json:"held_at,omitempty"`
+	SettledAt     string `json:"settled_at,omitempty"`
+}
+
+// CreateEscrow debits the payer and has FlowEngine hold the amount until the payer releases it, the payee refunds
+// it or the arbiter does either. An escrow still held at its deadline is refunded.
+func (service *Service) CreateEscrow(ctx context.Context, params *CreateEscrowParams) (*Escrow, error) {
+	const op = "service.Service.CreateEscrow"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Creating escrow through FlowEngine")
+
+	violations := &TransferValidationError{}
+
+	for _, account := range []struct{ field, number string }{
+		{"from_account", params.FromAccount},
+		{"to_account", params.ToAccount},
+	} {
+		if account.number == "" {
+			violations.add(account.field, nil, "%s is required", account.field)
+		} else if err := service.validateAccountNumber(params.Tenant, account.field, account.number); err != nil {
+			violations.add(account.field, nil, "%v", err)
+		}
+	}
+
+	if params.FromAccount != "" && params.FromAccount == params.ToAccount {
+		violations.add("to_account", nil, "from_account and to_account cannot be the same")
+	}
+
+	if params.Arbiter != "" && (params.Arbiter == params.FromAccount || params.Arbiter == params.ToAccount) {
+		violations.add("arbiter", nil, "arbiter must be neither the payer nor the payee")
+	}
+
+	if params.DeadlineSeconds < 0 {
+		violations.add("deadline_seconds", nil, "deadline_seconds cannot be negative")
+	}
+
+	description := ""
+	if params.Description != nil {
+		description = *params.Description
+		if len(description) > maxDescriptionLength {
+			violations.add("description", nil, "description must be at most %d characters", maxDescriptionLength)
+		}
+	}
+
+	var amountMinorUnits int64
+	var amountDecimal string
+	if params.Currency == "" {
+		violations.add("currency", ErrUnsupportedCurrency, "currency is required")
+	} else if _, err := service.lookupCurrency(ctx, params.Currency); err != nil {
+		if errors.Is(err, ErrCurrencyCatalogUnavailable) {
+			return nil, err
+		}
+
+		violations.add("currency", ErrUnsupportedCurrency, "%v", err)
+	} else {
+		amountMinorUnits, amountDecimal, err = service.resolveTransferAmount(ctx, 0, &params.AmountDecimal, params.Currency)
+		if err != nil {
+			violations.add("amount_decimal", amountErrorCause(err), "%v", err)
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		logger.WithError(violations).Warn("Escrow rejected")
+
+		return nil, violations
+	}
+
+	// Call FlowEngine adapter
+	response, err := service.flowngineAdapter.CreateEscrow(ctx, &pb.CreateEscrowRequest{
+		FromAccount:     params.FromAccount,
+		ToAccount:       params.ToAccount,
+		Amount:          amountMinorUnits,
+		Currency:        params.Currency,
+		Description:     description,
+		RequestId:       uuid.New().String(),
+		Arbiter:         params.Arbiter,
+		DeadlineSeconds: int32(params.DeadlineSeconds),
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to create escrow through FlowEngine: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &Escrow{
+		EscrowID:      response.EscrowId,
+		FromAccount:   params.FromAccount,
+		ToAccount:     params.ToAccount,
+		Amount:        amountMinorUnits,
+		AmountDecimal: amountDecimal,
+		Currency:      params.Currency,
+		Description:   description,
+		Arbiter:       params.Arbiter,
+		Status:        "PENDING",
+		CreatedAt:     response.CreatedAt.AsTime().Format(time.RFC3339),
+		Deadline:      response.Deadline.AsTime().Format(time.RFC3339),
+	}
+
+	logger.WithField("escrow_id", results.EscrowID).Info("Escrow created")
+
+	return results, nil
+}
+
+// DecideEscrow releases a held escrow to the payee or refunds it to the payer. FlowEngine ignores decisions from
+// parties that may not take them, so a successful call only means the decision was delivered.
+func (service *Service) DecideEscrow(ctx context.Context, params *DecideEscrowParams) (*DecideEscrowResults, error) {
+	const op = "service.Service.DecideEscrow"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Deciding escrow through FlowEngine")
+
+	if params.DecidedBy == "" {
+		violations := &TransferValidationError{}
+		violations.add("decided_by", nil, "decided_by is required")
+
+		return nil, violations
+	}
+
+	// Call FlowEngine adapter
+	response, err := service.flowngineAdapter.DecideEscrow(ctx, &pb.DecideEscrowRequest{
+		EscrowId:  params.EscrowID,
+		Decision:  params.Decision,
+		DecidedBy: params.DecidedBy,
+		Reason:    params.Reason,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = fmt.Errorf("%w: %s", ErrEscrowNotFound, params.EscrowID)
+		} else {
+			err = fmt.Errorf("failed to decide escrow through FlowEngine: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &DecideEscrowResults{
+		EscrowID: params.EscrowID,
+		Success:  response.Success,
+		Message:  response.Message,
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Escrow decided")
+
+	return results, nil
+}
+
+// GetEscrow reports the state of an escrow
+func (service *Service) GetEscrow(ctx context.Context, escrowID string) (*Escrow, error) {
+	const op = "service.Service.GetEscrow"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":      op,
+		"escrow_id": escrowID,
+	})
+
+	logger.Info("Getting escrow through FlowEngine")
+
+	// Call FlowEngine adapter
+	response, err := service.flowngineAdapter.GetEscrow(ctx, &pb.GetEscrowRequest{EscrowId: escrowID})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = fmt.Errorf("%w: %s", ErrEscrowNotFound, escrowID)
+		} else {
+			err = fmt.Errorf("failed to get escrow through FlowEngine: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	amountMinorUnits, amountDecimal, err := service.resolveTransferAmount(ctx, 0, &response.Amount, response.Currency)
+	if err != nil {
+		err = fmt.Errorf("failed to read the amount of escrow %s: %w", escrowID, err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &Escrow{
+		EscrowID:      response.EscrowId,
+		FromAccount:   response.FromAccount,
+		ToAccount:     response.ToAccount,
+		Amount:        amountMinorUnits,
+		AmountDecimal: amountDecimal,
+		Currency:      response.Currency,
+		Arbiter:       response.Arbiter,
+		Status:        response.Status,
+		Deadline:      response.Deadline.AsTime().Format(time.RFC3339),
+		DecidedBy:     response.DecidedBy,
+		Reason:        response.Reason,
+		ErrorMessage:  response.ErrorMessage,
+	}
+	if response.HeldAt != nil {
+		results.HeldAt = response.HeldAt.AsTime().Format(time.RFC3339)
+	}
+	if response.SettledAt != nil {
+		results.SettledAt = response.SettledAt.AsTime().Format(time.RFC3339)
+	}
+
+	logger.WithField("status", results.Status).Info("Escrow retrieved")
+
+	return results, nil
+}
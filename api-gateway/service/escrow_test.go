@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateEscrowRejectsInvalidRequests(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newCurrencyTestService(t)
+
+	// Rejected before FlowEngine is called, which this service has no adapter for
+	_, err := service.CreateEscrow(context.Background(), &CreateEscrowParams{
+		FromAccount:     "ACC001000001",
+		ToAccount:       "ACC001000002",
+		AmountDecimal:   "10.505",
+		Currency:        "USD",
+		Arbiter:         "ACC001000001",
+		DeadlineSeconds: -1,
+	})
+
+	var validationErr *TransferValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{"arbiter", "deadline_seconds", "amount_decimal"}, violationFields(validationErr))
+
+	_, err = service.DecideEscrow(context.Background(), &DecideEscrowParams{EscrowID: "escrow-1", Decision: EscrowDecisionRelease})
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{"decided_by"}, violationFields(validationErr))
+}
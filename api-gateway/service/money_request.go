@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrMoneyRequestNotFound is returned for money requests that do not exist or are not pending anymore
+var ErrMoneyRequestNotFound = errors.New("money request not found")
+
+type RequestMoneyParams struct {
+	PayeeAccount  string  `json:"payee_account"`
+	PayerAccount  string  `json:"payer_account"`
+	AmountDecimal string  `json:"amount_decimal"` // Major units, e.g. "100.50"
+	Currency      string  `json:"currency"`
+	Description   *string `json:"description"`
+	Tenant        string  `json:"-"` // Selects the account number format; empty for the default
+}
+
+type ListMoneyRequestsParams struct {
+	Account string `json:"account"`
+	Role    string `json:"role"`   // payer (default) or payee
+	Status  string `json:"status"` // Optional filter, e.g. PENDING
+}
+
+type ListMoneyRequestsResults struct {
+	Account       string         `json:"account"`
+	Role          string         `json:"role"`
+	MoneyRequests []MoneyRequest `json:"money_requests"`
+	Truncated     bool           `json:"truncated"`
+}
+
+// MoneyRequest is a request from a payee for a payer to transfer an amount. Once accepted, MoneyRequestID is also
+// the transaction ID of the transfer.
+type MoneyRequest struct {
+	MoneyRequestID string `json:"money_request_id"`
+	PayerAccount   string `json:"payer_account"`
+	PayeeAccount   string `json:"payee_account"`
+	Amount         int64  `json:"amount"`         // Minor units of the currency
+	AmountDecimal  string `json:"amount_decimal"` // Major units with the currency's decimal places
+	Currency       string `json:"currency"`
+	Description    string `json:"description,omitempty"`
+	Status         string `json:"status"` // PENDING, ACCEPTED, DECLINED or EXPIRED
+	CreatedAt      string `json:"created_at"`
+	ExpiresAt      string `json:"expires_at"`
+}
+
+type RespondToMoneyRequestParams struct {
+	MoneyRequestID string `json:"money_request_id"`
+	Accept         bool   `json:"accept"`
+	Reason         string `json:"reason"`
+}
+
+type RespondToMoneyRequestResults struct {
+	MoneyRequestID string `json:"money_request_id"`
+	Success        bool   `json:"success"`
+	Message        string `json:"message"`
+}
+
+// RequestMoney asks the payer to transfer an amount to the payee. FlowEngine holds the request until the payer
+// accepts or declines it, or until it expires.
+func (service *Service) RequestMoney(ctx context.Context, params *RequestMoneyParams) (*MoneyRequest, error) {
+	const op = "service.Service.RequestMoney"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Requesting money through FlowEngine")
+
+	violations := &TransferValidationError{}
+
+	for _, account := range []struct{ field, number string }{
+		{"payee_account", params.PayeeAccount},
+		{"payer_account", params.PayerAccount},
+	} {
+		if account.number == "" {
+			violations.add(account.field, nil, "%s is required", account.field)
+		} else if err := service.validateAccountNumber(params.Tenant, account.field, account.number); err != nil {
+			violations.add(account.field, nil, "%v", err)
+		}
+	}
+
+	if params.PayerAccount != "" && params.PayerAccount == params.PayeeAccount {
+		violations.add("payer_account", nil, "payer_account and payee_account cannot be the same")
+	}
+
+	description := ""
+	if params.Description != nil {
+		description = *params.Description
+		if len(description) > maxDescriptionLength {
+			violations.add("description", nil, "description must be at most %d characters", maxDescriptionLength)
+		}
+	}
+
+	var amountMinorUnits int64
+	var amountDecimal string
+	if params.Currency == "" {
+		violations.add("currency", ErrUnsupportedCurrency, "currency is required")
+	} else if _, err := service.lookupCurrency(ctx, params.Currency); err != nil {
+		if errors.Is(err, ErrCurrencyCatalogUnavailable) {
+			return nil, err
+		}
+
+		violations.add("currency", ErrUnsupportedCurrency, "%v", err)
+	} else {
+		amountMinorUnits, amountDecimal, err = service.resolveTransferAmount(ctx, 0, &params.AmountDecimal, params.Currency)
+		if err != nil {
+			violations.add("amount_decimal", amountErrorCause(err), "%v", err)
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		logger.WithError(violations).Warn("Money request rejected")
+
+		return nil, violations
+	}
+
+	// Call FlowEngine adapter
+	response, err := service.flowngineAdapter.RequestMoney(ctx, &pb.RequestMoneyRequest{
+		PayeeAccount: params.PayeeAccount,
+		PayerAccount: params.PayerAccount,
+		Amount:       amountMinorUnits,
+		Currency:     params.Currency,
+		Description:  description,
+		RequestId:    uuid.New().String(),
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to request money through FlowEngine: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &MoneyRequest{
+		MoneyRequestID: response.MoneyRequestId,
+		PayerAccount:   params.PayerAccount,
+		PayeeAccount:   params.PayeeAccount,
+		Amount:         amountMinorUnits,
+		AmountDecimal:  amountDecimal,
+		Currency:       params.Currency,
+		Description:    description,
+		Status:         response.Status,
+		CreatedAt:      response.CreatedAt.AsTime().Format(time.RFC3339),
+		ExpiresAt:      response.ExpiresAt.AsTime().Format(time.RFC3339),
+	}
+
+	logger.WithField("money_request_id", results.MoneyRequestID).Info("Money request created")
+
+	return results, nil
+}
+
+// ListMoneyRequests lists the money requests of an account, those it is asked to pay or those it sent
+func (service *Service) ListMoneyRequests(ctx context.Context, params *ListMoneyRequestsParams) (*ListMoneyRequestsResults, error) {
+	const op = "service.Service.ListMoneyRequests"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Listing money requests through FlowEngine")
+
+	// Call FlowEngine adapter
+	response, err := service.flowngineAdapter.ListMoneyRequests(ctx, &pb.ListMoneyRequestsRequest{
+		Account: params.Account,
+		Role:    params.Role,
+		Status:  params.Status,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to list money requests through FlowEngine: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &ListMoneyRequestsResults{
+		Account:       response.Account,
+		Role:          response.Role,
+		MoneyRequests: make([]MoneyRequest, 0, len(response.MoneyRequests)),
+		Truncated:     response.Truncated,
+	}
+
+	for _, moneyRequest := range response.MoneyRequests {
+		amountMinorUnits, amountDecimal, err := service.resolveTransferAmount(ctx, 0, &moneyRequest.Amount, moneyRequest.Currency)
+		if err != nil {
+			err = fmt.Errorf("failed to read the amount of money request %s: %w", moneyRequest.MoneyRequestId, err)
+
+			logger.WithError(err).Error()
+
+			return nil, err
+		}
+
+		results.MoneyRequests = append(results.MoneyRequests, MoneyRequest{
+			MoneyRequestID: moneyRequest.MoneyRequestId,
+			PayerAccount:   moneyRequest.PayerAccount,
+			PayeeAccount:   moneyRequest.PayeeAccount,
+			Amount:         amountMinorUnits,
+			AmountDecimal:  amountDecimal,
+			Currency:       moneyRequest.Currency,
+			Description:    moneyRequest.Description,
+			Status:         moneyRequest.Status,
+			CreatedAt:      moneyRequest.CreatedAt.AsTime().Format(time.RFC3339),
+			ExpiresAt:      moneyRequest.ExpiresAt.AsTime().Format(time.RFC3339),
+		})
+	}
+
+	logger.WithField("money_requests", len(results.MoneyRequests)).Info("Money requests listed")
+
+	return results, nil
+}
+
+// RespondToMoneyRequest accepts or declines a pending money request. Accepting starts the transfer under the ID
+// of the money request, so its progress is read like any other transfer.
+func (service *Service) RespondToMoneyRequest(ctx context.Context, params *RespondToMoneyRequestParams) (*RespondToMoneyRequestResults, error) {
+	const op = "service.Service.RespondToMoneyRequest"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Responding to money request through FlowEngine")
+
+	// Call FlowEngine adapter
+	response, err := service.flowngineAdapter.RespondToMoneyRequest(ctx, &pb.RespondToMoneyRequestRequest{
+		MoneyRequestId: params.MoneyRequestID,
+		Accept:         params.Accept,
+		Reason:         params.Reason,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = fmt.Errorf("%w: %s", ErrMoneyRequestNotFound, params.MoneyRequestID)
+		} else {
+			err = fmt.Errorf("failed to respond to money request through FlowEngine: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &RespondToMoneyRequestResults{
+		MoneyRequestID: params.MoneyRequestID,
+		Success:        response.Success,
+		Message:        response.Message,
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Money request answered")
+
+	return results, nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestMoneyRejectsInvalidRequests(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newCurrencyTestService(t)
+
+	// Rejected before FlowEngine is called, which this service has no adapter for
+	_, err := service.RequestMoney(context.Background(), &RequestMoneyParams{
+		PayeeAccount:  "ACC001000002",
+		PayerAccount:  "ACC001000002",
+		AmountDecimal: "10.505",
+		Currency:      "USD",
+	})
+
+	var validationErr *TransferValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{"payer_account", "amount_decimal"}, violationFields(validationErr))
+
+	_, err = service.RequestMoney(context.Background(), &RequestMoneyParams{AmountDecimal: "1", Currency: "USD"})
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{"payee_account", "payer_account"}, violationFields(validationErr))
+}
+
+func violationFields(err *TransferValidationError) []string {
+	fields := make([]string, 0, len(err.Violations))
+	for _, violation := range err.Violations {
+		fields = append(fields, violation.Field)
+	}
+
+	return fields
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+)
+
+// AdminOverview summarises the health of transfer processing for operators
+type AdminOverview struct {
+	TransferSLA         TransferSLAStats            `json:"transfer_sla"`
+	Compensations       GetCompensationStatsResults `json:"compensations"` // Last 24 hours
+	PendingTransactions PendingTransactionStats     `json:"pending_transactions"`
+}
+
+// PendingTransactionStats counts the transactions stuck in pending and what the pending-transaction sweeper did about them.
+// Sweep counts cover the svc-transaction instance that answered, since it started.
+type PendingTransactionStats struct {
+	Pending           int64      `json:"pending"`
+	Stale             int64      `json:"stale"` // Pending beyond stale_after_seconds, left for the next sweep
+	StaleAfterSeconds int64      `json:"stale_after_seconds"`
+	SweepRuns         int64      `json:"sweep_runs"`
+	SweptCompleted    int64      `json:"swept_completed"` // Completed because their balance change had been applied
+	SweptFailed       int64      `json:"swept_failed"`    // Marked failed because their balance change was never applied
+	SweepErrors       int64      `json:"sweep_errors"`
+	LastSweepAt       *time.Time `json:"last_sweep_at,omitempty"`
+}
+
+// TransferSLAStats counts saga transfers that ran past their SLA, within the Temporal visibility retention
+type TransferSLAStats struct {
+	SLASeconds int32 `json:"sla_seconds"` // 0 when SLA tracking is disabled
+	Breached   int64 `json:"breached"`
+	Delayed    int64 `json:"delayed"` // Breached and still running
+	Aborted    int64 `json:"aborted"` // Breached and stopped, with any debit reversed
+}
+
+func (service *Service) GetAdminOverview(ctx context.Context) (results *AdminOverview, err error) {
+	const op = "service.Service.GetAdminOverview"
+
+	logger := service.logger.WithField("[op]", op)
+
+	logger.Info("Getting admin overview from FlowEngine")
+
+	// Call FlowEngine adapter
+	slaResponse, err := service.flowngineAdapter.GetTransferSLAStats(ctx, &pb.GetTransferSLAStatsRequest{})
+	if err != nil {
+		err = fmt.Errorf("failed to get transfer SLA stats from FlowEngine: %w", err)
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	compensations, err := service.GetCompensationStats(ctx, &GetCompensationStatsParams{})
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	pendingResponse, err := service.flowngineAdapter.GetPendingTransactionStats(ctx, &pb.GetPendingTransactionStatsRequest{})
+	if err != nil {
+		err = fmt.Errorf("failed to get pending transaction stats from FlowEngine: %w", err)
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results = &AdminOverview{
+		TransferSLA: TransferSLAStats{
+			SLASeconds: slaResponse.SlaSeconds,
+			Breached:   slaResponse.Breached,
+			Delayed:    slaResponse.Delayed,
+			Aborted:    slaResponse.Aborted,
+		},
+		Compensations: *compensations,
+		PendingTransactions: PendingTransactionStats{
+			Pending:           pendingResponse.Pending,
+			Stale:             pendingResponse.Stale,
+			StaleAfterSeconds: pendingResponse.StaleAfterSeconds,
+			SweepRuns:         pendingResponse.SweepRuns,
+			SweptCompleted:    pendingResponse.SweptCompleted,
+			SweptFailed:       pendingResponse.SweptFailed,
+			SweepErrors:       pendingResponse.SweepErrors,
+		},
+	}
+	if pendingResponse.LastSweepAt != nil {
+		lastSweepAt := pendingResponse.LastSweepAt.AsTime()
+		results.PendingTransactions.LastSweepAt = &lastSweepAt
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Admin overview retrieved successfully")
+
+	return results, nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+	"api-gateway/util/iso20022"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type IngestPaymentInitiationParams struct {
+	Document *iso20022.Pain001Document
+	Tenant   string // Selects the account number format; empty for the default
+}
+
+type IngestPaymentInitiationResults struct {
+	Report    *iso20022.Pain002Document
+	Accepted  int
+	Rejected  int
+	Transfers []*TransferResults
+}
+
+// IngestPaymentInitiation maps every credit transfer in a pain.001 message to a transfer workflow
+// and reports the per-transaction outcome as a pain.002 status report
+func (service *Service) IngestPaymentInitiation(ctx context.Context, params *IngestPaymentInitiationParams) (results *IngestPaymentInitiationResults, err error) {
+	const op = "service.Service.IngestPaymentInitiation"
+
+	initiation := params.Document.CustomerCreditTransferInitiation
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"message_id": initiation.GroupHeader.MessageID,
+	})
+
+	logger.Info("Ingesting pain.001 payment initiation")
+
+	report := iso20022.NewPain002Document(uuid.New().String(), initiation.GroupHeader, time.Now())
+
+	results = &IngestPaymentInitiationResults{
+		Report: report,
+	}
+
+	for _, payment := range initiation.PaymentInformation {
+		paymentStatus := iso20022.OriginalPaymentInformation{
+			OriginalPaymentInformationID: payment.PaymentInformationID,
+		}
+
+		for _, tx := range payment.Transactions {
+			txStatus := iso20022.TransactionInfoStatus{
+				StatusID:              uuid.New().String(),
+				OriginalInstructionID: tx.InstructionID,
+				OriginalEndToEndID:    tx.EndToEndID,
+			}
+
+			transfer, err := service.initiateCreditTransfer(ctx, params.Tenant, payment, tx)
+			if err != nil {
+				logger.WithError(err).WithField("end_to_end_id", tx.EndToEndID).Warn("Credit transfer rejected")
+
+				txStatus.TransactionStatus = iso20022.StatusRejected
+				txStatus.StatusReasonInformation = []iso20022.StatusReasonInfo{{AdditionalInformation: err.Error()}}
+				results.Rejected++
+			} else {
+				txStatus.TransactionStatus = transferStatusToISO20022(transfer.Status)
+				results.Transfers = append(results.Transfers, transfer)
+				if txStatus.TransactionStatus == iso20022.StatusRejected {
+					results.Rejected++
+				} else {
+					results.Accepted++
+				}
+			}
+
+			paymentStatus.Transactions = append(paymentStatus.Transactions, txStatus)
+		}
+
+		report.AddPaymentInformation(paymentStatus)
+	}
+
+	report.Finalize()
+
+	logger.WithFields(logrus.Fields{
+		"accepted":     results.Accepted,
+		"rejected":     results.Rejected,
+		"group_status": report.CustomerPaymentStatusReport.OriginalGroupInformation.GroupStatus,
+	}).Info("pain.001 payment initiation ingested")
+
+	return results, nil
+}
+
+// initiateCreditTransfer validates a single credit transfer instruction and starts its transfer workflow
+func (service *Service) initiateCreditTransfer(ctx context.Context, tenant string, payment iso20022.PaymentInformation, tx iso20022.CreditTransferTransaction) (*TransferResults, error) {
+	fromAccount := payment.DebtorAccount.Identifier()
+	toAccount := tx.CreditorAccount.Identifier()
+
+	if err := service.validateAccountNumber(tenant, "debtor account", fromAccount); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	if err := service.validateAccountNumber(tenant, "creditor account", toAccount); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	if len(tx.InstructedAmount.Currency) != 3 {
+		return nil, fmt.Errorf("invalid parameters: instructed amount currency must be 3 characters")
+	}
+
+	if tx.EndToEndID == "" {
+		return nil, fmt.Errorf("invalid parameters: end-to-end ID is required")
+	}
+
+	// The instructed amount is a major-unit decimal; Transfer checks it against the currency's decimal places
+	amount := strings.TrimSpace(tx.InstructedAmount.Value)
+
+	// The end-to-end ID travels with the transfer so it can be reconciled by the originator
+	referenceID := truncate(tx.EndToEndID, 50)
+	description := truncate(tx.RemittanceInformation, 100)
+
+	return service.Transfer(ctx, &TransferParams{
+		FromAccount:   fromAccount,
+		ToAccount:     toAccount,
+		AmountDecimal: &amount,
+		Currency:      tx.InstructedAmount.Currency,
+		Description:   &description,
+		ReferenceID:   &referenceID,
+		Tenant:        tenant,
+	})
+}
+
+// transferStatusToISO20022 maps a FlowEngine transfer status to an ISO 20022 transaction status code
+func transferStatusToISO20022(status string) string {
+	switch status {
+	case pb.TransferStatus_TRANSFER_STATUS_COMPLETED.String():
+		return iso20022.StatusAcceptedSettlementCompleted
+	case pb.TransferStatus_TRANSFER_STATUS_FAILED.String(),
+		pb.TransferStatus_TRANSFER_STATUS_COMPENSATED.String(),
+		pb.TransferStatus_TRANSFER_STATUS_CANCELLED.String(),
+		pb.TransferStatus_TRANSFER_STATUS_EXPIRED.String():
+		return iso20022.StatusRejected
+	case pb.TransferStatus_TRANSFER_STATUS_PENDING.String():
+		return iso20022.StatusAcceptedTechnicalValidation
+	default:
+		return iso20022.StatusAcceptedSettlementInProcess
+	}
+}
+
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+
+	return value[:max]
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"api-gateway/util/paymentrequest"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Expiry limits of payment requests used when the configuration leaves them out
+const (
+	defaultPaymentRequestExpiry = 15 * time.Minute
+	maxPaymentRequestExpiry     = 24 * time.Hour
+)
+
+var (
+	// ErrPaymentRequestsUnavailable is returned when no payment request signing key is configured
+	ErrPaymentRequestsUnavailable = errors.New("payment requests are not configured")
+	// ErrInvalidPaymentRequest is returned for payloads that are not a payment request signed by this gateway
+	ErrInvalidPaymentRequest = errors.New("invalid payment request")
+	// ErrPaymentRequestExpired is returned for payment requests paid after their expiry
+	ErrPaymentRequestExpired = errors.New("payment request expired")
+)
+
+type CreatePaymentRequestParams struct {
+	ToAccount     string  `json:"to_account"`
+	AmountDecimal string  `json:"amount_decimal"` // Major units, e.g. "100.50"
+	Currency      string  `json:"currency"`
+	Description   *string `json:"description"`
+	ExpirySeconds int     `json:"expiry_seconds"` // 0 takes the configured default
+	Tenant        string  `json:"-"`              // Selects the account number format; empty for the default
+}
+
+type CreatePaymentRequestResults struct {
+	PaymentRequestID string `json:"payment_request_id"`
+	Payload          string `json:"payload"` // Text of the QR code
+	ToAccount        string `json:"to_account"`
+	Amount           int64  `json:"amount"`         // Minor units of the currency
+	AmountDecimal    string `json:"amount_decimal"` // Major units with the currency's decimal places
+	Currency         string `json:"currency"`
+	Description      string `json:"description,omitempty"`
+	ExpiresAt        string `json:"expires_at"`
+}
+
+type PayPaymentRequestParams struct {
+	Payload           string `json:"payload"`
+	FromAccount       string `json:"from_account"`
+	WaitForCompletion bool   `json:"wait_for_completion"`
+}
+
+// CreatePaymentRequest signs a request to be paid into ToAccount. The returned payload carries the whole request,
+// so nothing is stored: any gateway holding the signing key can pay it once until it expires.
+func (service *Service) CreatePaymentRequest(ctx context.Context, params *CreatePaymentRequestParams) (*CreatePaymentRequestResults, error) {
+	const op = "service.Service.CreatePaymentRequest"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	if service.paymentRequests.SigningKey == "" {
+		return nil, ErrPaymentRequestsUnavailable
+	}
+
+	violations := &TransferValidationError{}
+
+	if params.ToAccount == "" {
+		violations.add("to_account", nil, "to_account is required")
+	} else if err := service.validateAccountNumber(params.Tenant, "to_account", params.ToAccount); err != nil {
+		violations.add("to_account", nil, "%v", err)
+	}
+
+	description := ""
+	if params.Description != nil {
+		description = *params.Description
+		if len(description) > maxDescriptionLength {
+			violations.add("description", nil, "description must be at most %d characters", maxDescriptionLength)
+		}
+	}
+
+	expiry := paymentRequestExpiry(service.paymentRequests.DefaultExpirySeconds, defaultPaymentRequestExpiry)
+	maxExpiry := paymentRequestExpiry(service.paymentRequests.MaxExpirySeconds, maxPaymentRequestExpiry)
+	switch {
+	case params.ExpirySeconds < 0 || time.Duration(params.ExpirySeconds)*time.Second > maxExpiry:
+		violations.add("expiry_seconds", nil, "expiry_seconds must be between 1 and %d", int(maxExpiry.Seconds()))
+	case params.ExpirySeconds > 0:
+		expiry = time.Duration(params.ExpirySeconds) * time.Second
+	}
+
+	var amountMinorUnits int64
+	var amountDecimal string
+	if params.Currency == "" {
+		violations.add("currency", ErrUnsupportedCurrency, "currency is required")
+	} else if _, err := service.lookupCurrency(ctx, params.Currency); err != nil {
+		if errors.Is(err, ErrCurrencyCatalogUnavailable) {
+			return nil, err
+		}
+
+		violations.add("currency", ErrUnsupportedCurrency, "%v", err)
+	} else {
+		amountMinorUnits, amountDecimal, err = service.resolveTransferAmount(ctx, 0, &params.AmountDecimal, params.Currency)
+		if err != nil {
+			violations.add("amount_decimal", amountErrorCause(err), "%v", err)
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		logger.WithError(violations).Warn("Payment request rejected")
+
+		return nil, violations
+	}
+
+	request := paymentrequest.PaymentRequest{
+		ID:          uuid.New().String(),
+		ToAccount:   params.ToAccount,
+		Amount:      amountDecimal,
+		Currency:    params.Currency,
+		Description: description,
+		ExpiresAt:   time.Now().Add(expiry).UTC().Truncate(time.Second),
+	}
+
+	payload, err := request.Encode([]byte(service.paymentRequests.SigningKey))
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("payment_request_id", request.ID).Info("Payment request created")
+
+	return &CreatePaymentRequestResults{
+		PaymentRequestID: request.ID,
+		Payload:          payload,
+		ToAccount:        request.ToAccount,
+		Amount:           amountMinorUnits,
+		AmountDecimal:    request.Amount,
+		Currency:         request.Currency,
+		Description:      request.Description,
+		ExpiresAt:        request.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// PaymentRequestTransfer verifies the signature and expiry of a scanned payment request and returns the transfer
+// that pays it from FromAccount. The payment request ID is the reference ID of the transfer, so the payee can
+// match the credit to the request, and the FlowEngine request ID is derived from it, so a payload scanned again
+// reaches the transfer that paid it rather than starting another one.
+func (service *Service) PaymentRequestTransfer(params *PayPaymentRequestParams) (*TransferParams, error) {
+	if service.paymentRequests.SigningKey == "" {
+		return nil, ErrPaymentRequestsUnavailable
+	}
+
+	request, err := paymentrequest.Decode(params.Payload, []byte(service.paymentRequests.SigningKey), time.Now())
+	switch {
+	case errors.Is(err, paymentrequest.ErrExpired):
+		return nil, fmt.Errorf("%w: %s expired at %s", ErrPaymentRequestExpired, request.ID, request.ExpiresAt.Format(time.RFC3339))
+	case err != nil:
+		return nil, ErrInvalidPaymentRequest
+	}
+
+	transfer := &TransferParams{
+		FromAccount:       params.FromAccount,
+		ToAccount:         request.ToAccount,
+		AmountDecimal:     &request.Amount,
+		Currency:          request.Currency,
+		ReferenceID:       &request.ID,
+		RequestID:         paymentRequestTransferID(request.ID),
+		WaitForCompletion: params.WaitForCompletion,
+	}
+	if request.Description != "" {
+		transfer.Description = &request.Description
+	}
+
+	return transfer, nil
+}
+
+// paymentRequestTransferID is the FlowEngine request ID of the transfer paying a payment request
+func paymentRequestTransferID(paymentRequestID string) string {
+	return "payment_request_" + paymentRequestID
+}
+
+// paymentRequestExpiry converts a configured number of seconds, falling back to fallback when it is not positive
+func paymentRequestExpiry(seconds int, fallback time.Duration) time.Duration {
+	if seconds <= 0 {
+		return fallback
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"api-gateway/adapter/flowngine_adapter"
+	flowenginepb "api-gateway/adapter/flowngine_adapter/pb"
+	"api-gateway/util/config"
+	"api-gateway/util/paymentrequest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestPaymentRequests(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newCurrencyTestService(t)
+	service.paymentRequests = config.PaymentRequests{SigningKey: "test-signing-key", MaxExpirySeconds: 3600}
+
+	text := func(value string) *string { return &value }
+
+	t.Run("created_request_pays_its_amount", func(t *testing.T) {
+		created, err := service.CreatePaymentRequest(context.Background(), &CreatePaymentRequestParams{
+			ToAccount:     "ACC001000002",
+			AmountDecimal: "12.5",
+			Currency:      "USD",
+			Description:   text("Coffee beans"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1250), created.Amount)
+		assert.Equal(t, "12.50", created.AmountDecimal)
+
+		expiresAt, err := time.Parse(time.RFC3339, created.ExpiresAt)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(defaultPaymentRequestExpiry), expiresAt, time.Minute)
+
+		transfer, err := service.PaymentRequestTransfer(&PayPaymentRequestParams{Payload: created.Payload, FromAccount: "ACC001000001"})
+		require.NoError(t, err)
+		assert.Equal(t, "ACC001000001", transfer.FromAccount)
+		assert.Equal(t, "ACC001000002", transfer.ToAccount)
+		assert.Equal(t, "12.50", *transfer.AmountDecimal)
+		assert.Equal(t, "USD", transfer.Currency)
+		assert.Equal(t, "Coffee beans", *transfer.Description)
+		assert.Equal(t, created.PaymentRequestID, *transfer.ReferenceID)
+	})
+
+	t.Run("rejects_invalid_requests", func(t *testing.T) {
+		_, err := service.CreatePaymentRequest(context.Background(), &CreatePaymentRequestParams{
+			ToAccount:     "ACC1",
+			AmountDecimal: "10.505",
+			Currency:      "USD",
+			ExpirySeconds: 7200,
+		})
+
+		var validationErr *TransferValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []FieldViolation{
+			{Field: "to_account", Description: "to_account must be 12 characters"},
+			{Field: "expiry_seconds", Description: "expiry_seconds must be between 1 and 3600"},
+			{Field: "amount_decimal", Description: "amount exceeds currency decimal places: 10.505 allows 2"},
+		}, validationErr.Violations)
+	})
+
+	t.Run("rejects_tampered_and_expired_payloads", func(t *testing.T) {
+		expired, err := paymentrequest.PaymentRequest{
+			ID:        "request-1",
+			ToAccount: "ACC001000002",
+			Amount:    "1.00",
+			Currency:  "USD",
+			ExpiresAt: time.Now().Add(-time.Second),
+		}.Encode([]byte("test-signing-key"))
+		require.NoError(t, err)
+
+		_, err = service.PaymentRequestTransfer(&PayPaymentRequestParams{Payload: expired, FromAccount: "ACC001000001"})
+		assert.ErrorIs(t, err, ErrPaymentRequestExpired)
+
+		forged, err := paymentrequest.PaymentRequest{
+			ID:        "request-2",
+			ToAccount: "ACC001000003",
+			Amount:    "1.00",
+			Currency:  "USD",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}.Encode([]byte("other-key"))
+		require.NoError(t, err)
+
+		_, err = service.PaymentRequestTransfer(&PayPaymentRequestParams{Payload: forged, FromAccount: "ACC001000001"})
+		assert.ErrorIs(t, err, ErrInvalidPaymentRequest)
+	})
+
+	t.Run("unavailable_without_signing_key", func(t *testing.T) {
+		unconfigured, _, _ := newCurrencyTestService(t)
+
+		_, err := unconfigured.CreatePaymentRequest(context.Background(), &CreatePaymentRequestParams{ToAccount: "ACC001000002", AmountDecimal: "1", Currency: "USD"})
+		assert.ErrorIs(t, err, ErrPaymentRequestsUnavailable)
+
+		_, err = unconfigured.PaymentRequestTransfer(&PayPaymentRequestParams{Payload: "FLOWPAY1.e30.x"})
+		assert.ErrorIs(t, err, ErrPaymentRequestsUnavailable)
+	})
+}
+
+// fakeFlowEngine starts one transfer per request ID and rejects the requests that repeat one, like FlowEngine under
+// the reject_duplicate workflow ID conflict policy
+type fakeFlowEngine struct {
+	flowenginepb.UnimplementedFlowEngineServer
+
+	mutex     sync.Mutex
+	transfers map[string]string // Transaction ID by request ID
+}
+
+func (engine *fakeFlowEngine) ExecuteTransfer(ctx context.Context, request *flowenginepb.ExecuteTransferRequest) (*flowenginepb.ExecuteTransferResponse, error) {
+	engine.mutex.Lock()
+	defer engine.mutex.Unlock()
+
+	if transactionID, ok := engine.transfers[request.RequestId]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "transfer already exists: transaction %s was started for this request_id", transactionID)
+	}
+
+	transactionID := uuid.New().String()
+	engine.transfers[request.RequestId] = transactionID
+
+	return &flowenginepb.ExecuteTransferResponse{TransactionId: transactionID, Status: flowenginepb.TransferStatus_TRANSFER_STATUS_PENDING}, nil
+}
+
+func TestPaymentRequestPaidOnce(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newCurrencyTestService(t)
+	service.paymentRequests = config.PaymentRequests{SigningKey: "test-signing-key"}
+	// Duplicate detection skips transfers with a reference ID, so it cannot be what stops the replay
+	service.recentTransfers = newRecentTransfers(time.Hour)
+
+	engine := &fakeFlowEngine{transfers: make(map[string]string)}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	flowenginepb.RegisterFlowEngineServer(server, engine)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///flowngine",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	service.flowngineAdapter = flowngine_adapter.NewAdapter("flowngine", service.logger, conn)
+
+	created, err := service.CreatePaymentRequest(context.Background(), &CreatePaymentRequestParams{
+		ToAccount:     "ACC001000002",
+		AmountDecimal: "12.50",
+		Currency:      "USD",
+	})
+	require.NoError(t, err)
+
+	pay := func() (*TransferResults, error) {
+		transfer, err := service.PaymentRequestTransfer(&PayPaymentRequestParams{Payload: created.Payload, FromAccount: "ACC001000001"})
+		require.NoError(t, err)
+
+		return service.Transfer(context.Background(), transfer)
+	}
+
+	paid, err := pay()
+	require.NoError(t, err)
+	assert.Equal(t, created.PaymentRequestID, paid.ReferenceID)
+
+	// The replayed payload carries the same request ID, so FlowEngine does not start a second transfer
+	_, err = pay()
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.ErrorContains(t, err, paid.TransactionID)
+	assert.Len(t, engine.transfers, 1)
+}
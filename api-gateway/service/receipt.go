@@ -0,0 +1,217 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+	"api-gateway/util/objectstore"
+	"api-gateway/util/receipt"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrTransferNotCompleted is returned when a receipt is requested for a transfer that has not completed
+	ErrTransferNotCompleted = errors.New("transfer is not completed")
+	// ErrReceiptFormatting is returned when the amount could not be formatted by svc-balance
+	ErrReceiptFormatting = errors.New("failed to format receipt amount")
+)
+
+type GetTransferReceiptParams struct {
+	TransactionID string `json:"transaction_id"` // Transaction ID or transfer reference
+	Locale        string `json:"locale"`         // Locale of the formatted amount, e.g. de-DE; empty for the default form
+}
+
+// receiptArchivePrefix is the object store key prefix of generated receipts
+const receiptArchivePrefix = "receipts"
+
+// GetTransferReceipt returns the signed receipt of a completed transfer.
+// Receipts are generated once and then served from the cache under both the transaction ID and the transfer reference.
+// With an object store configured they are persisted as well, so the same receipt is served after a restart or once
+// the cache has evicted it.
+func (service *Service) GetTransferReceipt(ctx context.Context, params *GetTransferReceiptParams) (results *receipt.Receipt, err error) {
+	const op = "service.Service.GetTransferReceipt"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	if cached, ok := service.receiptCache.Get(receiptCacheKey(params.TransactionID, params.Locale)); ok {
+		logger.Debug("Serving cached receipt")
+
+		return cached, nil
+	}
+
+	if stored, ok := service.loadStoredReceipt(ctx, logger, params.TransactionID, params.Locale); ok {
+		logger.Debug("Serving stored receipt")
+
+		return stored, nil
+	}
+
+	statusResponse, err := service.getTransferStatus(ctx, logger, &pb.GetTransferStatusRequest{
+		TransactionId: params.TransactionID,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to get transfer status from FlowEngine: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	if statusResponse.Status != pb.TransferStatus_TRANSFER_STATUS_COMPLETED {
+		return nil, fmt.Errorf("%w: status is %s", ErrTransferNotCompleted, statusResponse.Status.String())
+	}
+
+	value := statusResponse.AmountDecimal
+
+	formatted, err := service.balanceAdapter.FormatCurrency(ctx, statusResponse.Currency, value, params.Locale)
+	if err != nil {
+		err = fmt.Errorf("%w: %v", ErrReceiptFormatting, err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results = &receipt.Receipt{
+		ReceiptNumber:     statusResponse.TransactionId,
+		TransactionID:     statusResponse.TransactionId,
+		TransferReference: statusResponse.TransferReference,
+		Status:            statusResponse.Status.String(),
+		From:              receipt.Party{Account: statusResponse.FromAccount},
+		To:                receipt.Party{Account: statusResponse.ToAccount},
+		Amount: receipt.Amount{
+			MinorUnits: statusResponse.Amount,
+			Value:      value,
+			Currency:   statusResponse.Currency,
+			Formatted:  formatted.Formatted,
+		},
+		Description: statusResponse.Description,
+		ReferenceID: statusResponse.ReferenceId,
+		CreatedAt:   statusResponse.CreatedAt.AsTime().Format(time.RFC3339),
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	if statusResponse.TransferReference != "" {
+		results.ReceiptNumber = statusResponse.TransferReference
+	}
+
+	if statusResponse.CompletedAt != nil {
+		results.CompletedAt = statusResponse.CompletedAt.AsTime().Format(time.RFC3339)
+	}
+
+	if statusResponse.WorkflowExecution != nil {
+		results.Workflow.WorkflowID = statusResponse.WorkflowExecution.WorkflowId
+		results.Workflow.RunID = statusResponse.WorkflowExecution.RunId
+	}
+
+	if err := results.Sign(service.receiptConfig.KeyID, []byte(service.receiptConfig.SigningKey)); err != nil {
+		err = fmt.Errorf("failed to sign receipt: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// The formatted amount depends on the locale, so each locale has its own receipt
+	keys := []string{receiptCacheKey(results.TransactionID, params.Locale)}
+	if results.TransferReference != "" {
+		keys = append(keys, receiptCacheKey(results.TransferReference, params.Locale))
+	}
+	service.receiptCache.Put(results, keys...)
+
+	service.storeReceipt(ctx, logger, results, params.Locale)
+
+	logger.WithField("receipt_number", results.ReceiptNumber).Info("Transfer receipt generated successfully")
+
+	return results, nil
+}
+
+// receiptCacheKey is the cache key of the receipt of a transfer in a locale
+func receiptCacheKey(id, locale string) string {
+	if locale == "" {
+		return id
+	}
+
+	return id + "@" + locale
+}
+
+// receiptStoreKey is the object store key of the receipt of a transfer in a locale. The parts come from the request
+// path and are not cleaned, so IDs containing path elements yield keys the store rejects instead of other objects.
+func receiptStoreKey(id, locale string) string {
+	if locale == "" {
+		locale = "default"
+	}
+
+	return receiptArchivePrefix + "/" + id + "/" + locale + ".json"
+}
+
+// loadStoredReceipt reads a previously generated receipt from the object store and caches it. Receipts that are
+// missing, unreadable or no longer verify with the configured key (after a key rotation) are reported as absent so
+// they are generated again.
+func (service *Service) loadStoredReceipt(ctx context.Context, logger *logrus.Entry, id, locale string) (*receipt.Receipt, bool) {
+	if service.objectStore == nil {
+		return nil, false
+	}
+
+	body, err := service.objectStore.Get(ctx, receiptStoreKey(id, locale))
+	if err != nil {
+		if !errors.Is(err, objectstore.ErrNotFound) && !errors.Is(err, objectstore.ErrInvalidKey) {
+			logger.WithError(err).Warn("Failed to read stored receipt")
+		}
+
+		return nil, false
+	}
+	defer body.Close()
+
+	var stored receipt.Receipt
+	if err := json.NewDecoder(body).Decode(&stored); err != nil {
+		logger.WithError(err).Warn("Ignoring unreadable stored receipt")
+
+		return nil, false
+	}
+
+	if err := stored.Verify([]byte(service.receiptConfig.SigningKey)); err != nil {
+		logger.WithError(err).Info("Regenerating stored receipt that does not verify with the current key")
+
+		return nil, false
+	}
+
+	service.receiptCache.Put(&stored, receiptCacheKey(id, locale))
+
+	return &stored, true
+}
+
+// storeReceipt persists a generated receipt under both the transaction ID and the transfer reference. Failures are
+// logged only; the receipt is still served from the cache.
+func (service *Service) storeReceipt(ctx context.Context, logger *logrus.Entry, result *receipt.Receipt, locale string) {
+	if service.objectStore == nil {
+		return
+	}
+
+	ids := []string{result.TransactionID}
+	if result.TransferReference != "" {
+		ids = append(ids, result.TransferReference)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to marshal receipt for storage")
+
+		return
+	}
+
+	for _, id := range ids {
+		_, err := service.objectStore.Put(ctx, receiptStoreKey(id, locale), bytes.NewReader(data), int64(len(data)), "application/json")
+		if err != nil {
+			logger.WithError(err).WithField("id", id).Warn("Failed to store receipt")
+		}
+	}
+}
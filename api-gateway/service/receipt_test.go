@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"api-gateway/util/config"
+	"api-gateway/util/objectstore"
+	"api-gateway/util/receipt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoredReceipts(t *testing.T) {
+	t.Parallel()
+
+	local, err := objectstore.NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	entry := logrus.NewEntry(logger)
+
+	newService := func(signingKey string) *Service {
+		return NewService(logger, nil, nil, nil, config.Receipt{SigningKey: signingKey, KeyID: "k1", CacheSize: 10}, config.PaymentRequests{}, local, nil, config.DuplicateDetection{}, nil)
+	}
+
+	generated := &receipt.Receipt{
+		ReceiptNumber:     "TRF-1",
+		TransactionID:     "txn-1",
+		TransferReference: "TRF-1",
+		Status:            "TRANSFER_STATUS_COMPLETED",
+		Amount:            receipt.Amount{MinorUnits: 1050, Value: "10.50", Currency: "EUR", Formatted: "10,50 €"},
+	}
+	require.NoError(t, generated.Sign("k1", []byte("secret")))
+
+	newService("secret").storeReceipt(context.Background(), entry, generated, "de-DE")
+
+	// A restarted gateway serves the stored receipt under either ID, in the locale it was generated in only
+	service := newService("secret")
+	for _, id := range []string{"txn-1", "TRF-1"} {
+		stored, ok := service.loadStoredReceipt(context.Background(), entry, id, "de-DE")
+		require.True(t, ok, id)
+		assert.Equal(t, generated, stored)
+
+		cached, ok := service.receiptCache.Get(receiptCacheKey(id, "de-DE"))
+		require.True(t, ok, id)
+		assert.Equal(t, generated, cached)
+	}
+
+	_, ok := service.loadStoredReceipt(context.Background(), entry, "txn-1", "")
+	assert.False(t, ok)
+
+	// After a key rotation the receipt is generated again
+	_, ok = newService("rotated").loadStoredReceipt(context.Background(), entry, "txn-1", "de-DE")
+	assert.False(t, ok)
+
+	_, ok = newService("secret").loadStoredReceipt(context.Background(), entry, "../txn-1", "de-DE")
+	assert.False(t, ok)
+}
@@ -1,7 +1,16 @@
 package service
 
 import (
+	"time"
+
+	"api-gateway/adapter/balance_adapter"
+	"api-gateway/adapter/balance_ops_adapter"
 	"api-gateway/adapter/flowngine_adapter"
+	"api-gateway/util/accountnumber"
+	"api-gateway/util/config"
+	"api-gateway/util/objectstore"
+	"api-gateway/util/receipt"
+	"api-gateway/util/statuscache"
 
 	"github.com/sirupsen/logrus"
 )
@@ -9,16 +18,75 @@ import (
 type Service struct {
 	logger *logrus.Logger
 
-	flowngineAdapter *flowngine_adapter.Adapter
+	flowngineAdapter  *flowngine_adapter.Adapter
+	balanceAdapter    *balance_adapter.Adapter
+	balanceOpsAdapter *balance_ops_adapter.Adapter // svc-balance ops API; nil disables the admin account reports
+
+	receiptConfig config.Receipt
+	receiptCache  *receipt.Cache
+
+	paymentRequests config.PaymentRequests
+
+	objectStore objectstore.Store // Archive of uploaded files and generated receipts; nil disables both
+
+	statusCache statuscache.Cache // Status responses of finished transfers; nil sends every status read to FlowEngine
+
+	accountNumbers *accountnumber.Validator // Per-tenant account number formats; nil accepts any account number
+
+	currencyCatalog currencyCatalog
+
+	duplicateDetection string           // DuplicateDetectionWarn or DuplicateDetectionConfirm; off leaves recentTransfers nil
+	recentTransfers    *recentTransfers // Transfers started within the duplicate detection window
+
+	taskQueueTrackHeader bool // Forward the task queue track of transfers to FlowEngine; ignored otherwise
 }
 
 func NewService(
 	logger *logrus.Logger,
 	flowngineAdapter *flowngine_adapter.Adapter,
+	balanceAdapter *balance_adapter.Adapter,
+	balanceOpsAdapter *balance_ops_adapter.Adapter,
+	receiptConfig config.Receipt,
+	paymentRequests config.PaymentRequests,
+	objectStore objectstore.Store,
+	accountNumbers *accountnumber.Validator,
+	duplicateDetection config.DuplicateDetection,
+	statusCache statuscache.Cache,
 ) *Service {
-	return &Service{
+	service := &Service{
 		logger: logger,
 
-		flowngineAdapter: flowngineAdapter,
+		flowngineAdapter:  flowngineAdapter,
+		balanceAdapter:    balanceAdapter,
+		balanceOpsAdapter: balanceOpsAdapter,
+
+		receiptConfig: receiptConfig,
+		receiptCache:  receipt.NewCache(receiptConfig.CacheSize),
+
+		paymentRequests: paymentRequests,
+
+		objectStore: objectStore,
+
+		statusCache: statusCache,
+
+		accountNumbers: accountNumbers,
 	}
+
+	switch duplicateDetection.Mode {
+	case DuplicateDetectionWarn, DuplicateDetectionConfirm:
+		if duplicateDetection.WindowSeconds > 0 {
+			service.duplicateDetection = duplicateDetection.Mode
+			service.recentTransfers = newRecentTransfers(time.Duration(duplicateDetection.WindowSeconds) * time.Second)
+		}
+	case "", DuplicateDetectionOff:
+	default:
+		logger.WithField("mode", duplicateDetection.Mode).Warn("Unknown duplicate detection mode, duplicate transfers are not detected")
+	}
+
+	return service
+}
+
+// SetTaskQueueTrackHeader sets whether the task queue track requested for a transfer is forwarded to FlowEngine
+func (service *Service) SetTaskQueueTrackHeader(enabled bool) {
+	service.taskQueueTrackHeader = enabled
 }
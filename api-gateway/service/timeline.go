@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrTransferNotFound is returned when FlowEngine has no workflow for a transfer
+var ErrTransferNotFound = errors.New("transfer workflow not found")
+
+type GetTransferTimelineParams struct {
+	TransactionID string `json:"transaction_id"` // Transaction ID or transfer reference
+}
+
+type GetTransferTimelineResults struct {
+	TransactionID string          `json:"transaction_id"`
+	WorkflowID    string          `json:"workflow_id"`
+	RunID         string          `json:"run_id"`
+	Events        []TimelineEvent `json:"events"`
+}
+
+// TimelineEvent is one step of a transfer saga, e.g. an activity that was scheduled, retried or compensated
+type TimelineEvent struct {
+	EventID      int64  `json:"event_id"`
+	Time         string `json:"time"`
+	Type         string `json:"type"`
+	Activity     string `json:"activity,omitempty"`
+	Attempt      int32  `json:"attempt,omitempty"`
+	Message      string `json:"message"`
+	Compensation bool   `json:"compensation"`
+}
+
+func (service *Service) GetTransferTimeline(ctx context.Context, params *GetTransferTimelineParams) (results *GetTransferTimelineResults, err error) {
+	const op = "service.Service.GetTransferTimeline"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Getting transfer timeline from FlowEngine")
+
+	// Call FlowEngine adapter
+	timelineResponse, err := service.flowngineAdapter.GetTransferTimeline(ctx, &pb.GetTransferTimelineRequest{
+		TransactionId: params.TransactionID,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = fmt.Errorf("%w: %s", ErrTransferNotFound, params.TransactionID)
+		} else {
+			err = fmt.Errorf("failed to get transfer timeline from FlowEngine: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results = &GetTransferTimelineResults{
+		TransactionID: timelineResponse.TransactionId,
+		WorkflowID:    timelineResponse.WorkflowId,
+		RunID:         timelineResponse.RunId,
+		Events:        make([]TimelineEvent, 0, len(timelineResponse.Events)),
+	}
+
+	for _, event := range timelineResponse.Events {
+		results.Events = append(results.Events, TimelineEvent{
+			EventID:      event.EventId,
+			Time:         event.Time.AsTime().Format(time.RFC3339Nano),
+			Type:         event.Type,
+			Activity:     event.Activity,
+			Attempt:      event.Attempt,
+			Message:      event.Message,
+			Compensation: event.Compensation,
+		})
+	}
+
+	logger.WithField("events", len(results.Events)).Info("Transfer timeline retrieved successfully")
+
+	return results, nil
+}
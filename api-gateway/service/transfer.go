@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,16 +10,52 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// ErrInvalidCallbackURL is returned when the callback URL of a transfer is not an absolute http or https URL
+var ErrInvalidCallbackURL = errors.New("callback_url must be an absolute http or https URL")
+
+// ErrInvalidTransferTrigger is returned when a conditional transfer has no trigger account or an invalid trigger amount
+var ErrInvalidTransferTrigger = errors.New("invalid transfer trigger")
+
+// Worker fleets a transfer can be pinned to during a task queue cutover of FlowEngine
+const (
+	TaskQueueTrackCurrent = "current"
+	TaskQueueTrackNext    = "next"
+)
+
+// taskQueueTracks maps the task queue tracks to those of FlowEngine
+var taskQueueTracks = map[string]pb.TaskQueueTrack{
+	TaskQueueTrackCurrent: pb.TaskQueueTrack_TASK_QUEUE_TRACK_CURRENT,
+	TaskQueueTrackNext:    pb.TaskQueueTrack_TASK_QUEUE_TRACK_NEXT,
+}
+
 type TransferParams struct {
-	FromAccount       string  `json:"from_account"`
-	ToAccount         string  `json:"to_account"`
-	Amount            int     `json:"amount"`
-	Currency          string  `json:"currency"`
-	Description       *string `json:"description"`
-	ReferenceID       *string `json:"reference_id"`
-	WaitForCompletion bool    `json:"wait_for_completion"` // Sync vs async mode
+	FromAccount       string           `json:"from_account"`
+	ToAccount         string           `json:"to_account"`
+	ToAlias           string           `json:"to_alias"`       // Phone number or email address of the destination; alternative to ToAccount
+	Amount            int              `json:"amount"`         // Minor units of the currency (cents, yen)
+	AmountDecimal     *string          `json:"amount_decimal"` // Major units, e.g. "100.50"; alternative to Amount
+	Currency          string           `json:"currency"`
+	Description       *string          `json:"description"`
+	ReferenceID       *string          `json:"reference_id"`
+	WaitForCompletion bool             `json:"wait_for_completion"` // Sync vs async mode
+	CallbackURL       *string          `json:"callback_url"`        // Notified when the transfer expires awaiting approval, funds or its trigger
+	WaitForFunds      bool             `json:"wait_for_funds"`      // Wait for incoming credits instead of failing on insufficient funds
+	Trigger           *TransferTrigger `json:"trigger"`             // Hold the transfer until an account has received an amount
+	Tenant            string           `json:"-"`                   // Selects the account number format; empty for the default
+	TaskQueueTrack    string           `json:"-"`                   // TaskQueueTrackCurrent or TaskQueueTrackNext pins the transfer to a worker fleet; empty follows the cutover
+	ConfirmDuplicate  bool             `json:"confirm_duplicate"`   // Start the transfer even if it repeats a recent one
+	RequestID         string           `json:"-"`                   // Idempotency key sent to FlowEngine; empty generates one per call
+}
+
+// TransferTrigger makes a transfer conditional: it is executed once Account (number or ID) has received
+// AmountDecimal in the transfer currency, counting credits from the moment the transfer is created
+type TransferTrigger struct {
+	Account       string `json:"account"`
+	AmountDecimal string `json:"amount_decimal"` // Major units, e.g. "500.00"
 }
 
 type TransferResults struct {
@@ -26,18 +63,26 @@ type TransferResults struct {
 	Status              string `json:"status"`
 	FromAccount         string `json:"from_account"`
 	ToAccount           string `json:"to_account"`
-	Amount              int    `json:"amount"`
+	Amount              int    `json:"amount"`         // Minor units of the currency
+	AmountDecimal       string `json:"amount_decimal"` // Major units with the currency's decimal places
 	Currency            string `json:"currency"`
 	Description         string `json:"description"`
 	ReferenceID         string `json:"reference_id"`
 	CreatedAt           string `json:"created_at"`
 	EstimatedCompletion string `json:"estimated_completion"`
 	// Fields for sync mode (when WaitForCompletion=true)
-	CompletedAt         *string `json:"completed_at,omitempty"`
-	ErrorMessage        string  `json:"error_message,omitempty"`
-	CompensationApplied *bool   `json:"compensation_applied,omitempty"`
-	WorkflowID          string  `json:"workflow_id"`
-	RunID               string  `json:"run_id"`
+	CompletedAt         *string     `json:"completed_at,omitempty"`
+	ErrorMessage        string      `json:"error_message,omitempty"`
+	CompensationApplied *bool       `json:"compensation_applied,omitempty"`
+	WorkflowID          string      `json:"workflow_id"`
+	RunID               string      `json:"run_id"`
+	TransferReference   string      `json:"transfer_reference,omitempty"` // Printed on receipts, accepted by GetTransfer
+	FX                  *TransferFX `json:"-"`                            // Conversion applied to the credit, only exposed by v2; set in sync mode
+	// PossibleDuplicate is set when the transfer repeats a recent one and was started anyway, see duplicate_transfer.go
+	PossibleDuplicate *PossibleDuplicate `json:"-"`
+	// Accepted is set when sync mode was requested but the request's time budget ran out before completion;
+	// the transfer keeps running and its outcome has to be polled
+	Accepted bool `json:"-"`
 }
 
 func (service *Service) Transfer(ctx context.Context, params *TransferParams) (results *TransferResults, err error) {
@@ -50,8 +95,31 @@ func (service *Service) Transfer(ctx context.Context, params *TransferParams) (r
 
 	logger.Info("Initiating transfer through FlowEngine")
 
-	// Generate request ID for idempotency
-	requestID := uuid.New().String()
+	// Reject requests the currency or the request limits rule out before a workflow is started
+	validated, err := service.validateTransfer(ctx, params)
+	if err != nil {
+		logger.WithError(err).Warn("Transfer request rejected")
+
+		return nil, err
+	}
+
+	// Without a reference ID, repeating the accounts, amount and currency of a recent transfer looks like a double
+	// submission rather than a second payment
+	duplicate, claimed := service.claimTransfer(params, validated)
+	if duplicate != nil && claimed == nil {
+		err = fmt.Errorf("%w: matches transfer %s started at %s; resend with confirm_duplicate set to true or with a reference_id to proceed",
+			ErrPossibleDuplicateTransfer, duplicateTransactionID(duplicate), duplicate.CreatedAt.Format(time.RFC3339))
+
+		logger.WithError(err).Warn("Transfer held for confirmation")
+
+		return nil, err
+	}
+
+	// Generate request ID for idempotency unless the caller derived one, e.g. from a payment request
+	requestID := params.RequestID
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
 
 	// Set default values for optional fields
 	description := ""
@@ -66,25 +134,52 @@ func (service *Service) Transfer(ctx context.Context, params *TransferParams) (r
 
 	// Create FlowEngine request
 	flowEngineRequest := &pb.ExecuteTransferRequest{
-		FromAccount: params.FromAccount,
-		ToAccount:   params.ToAccount,
-		Amount:      int64(params.Amount),
-		Currency:    params.Currency,
-		Description: description,
-		ReferenceId: referenceID,
-		RequestId:   requestID,
+		FromAccount:  params.FromAccount,
+		ToAccount:    params.ToAccount,
+		ToAlias:      params.ToAlias,
+		Amount:       validated.amountMinorUnits,
+		Currency:     params.Currency,
+		Description:  description,
+		ReferenceId:  referenceID,
+		RequestId:    requestID,
+		CallbackUrl:  validated.callbackURL,
+		WaitForFunds: params.WaitForFunds,
+		Trigger:      validated.trigger,
+		// The gateway polls for completion itself, so it can still answer 202 when the client's time budget runs out
+		ExecutionMode: pb.ExecutionMode_EXECUTION_MODE_ASYNC,
+	}
+
+	// Pinning is for operators warming a new fleet, so clients can only pin transfers where it is allowed
+	if service.taskQueueTrackHeader {
+		flowEngineRequest.TaskQueueTrack = taskQueueTracks[params.TaskQueueTrack]
 	}
 
 	// Call FlowEngine adapter
 	flowEngineResponse, err := service.flowngineAdapter.ExecuteTransfer(ctx, flowEngineRequest)
 	if err != nil {
-		err = fmt.Errorf("failed to execute transfer via FlowEngine: %w", err)
+		service.releaseTransfer(params, validated, claimed)
+
+		switch {
+		case status.Code(err) == codes.DeadlineExceeded || errors.Is(ctx.Err(), context.DeadlineExceeded):
+			err = fmt.Errorf("%w: %w", ErrDeadlineExceeded, err)
+		case status.Code(err) == codes.AlreadyExists:
+			// Left as the FlowEngine status, whose detail names the transfer the request_id already started
+		default:
+			err = fmt.Errorf("failed to execute transfer via FlowEngine: %w", err)
+		}
 
 		logger.WithError(err).Error()
 
 		return nil, err
 	}
 
+	if claimed != nil {
+		service.recentTransfers.complete(claimed, flowEngineResponse.TransactionId)
+	}
+	if duplicate != nil {
+		logger.WithField("possible_duplicate_of", duplicateTransactionID(duplicate)).Warn("Possible duplicate transfer started")
+	}
+
 	// Convert status enum to string
 	statusString := flowEngineResponse.Status.String()
 
@@ -100,7 +195,8 @@ func (service *Service) Transfer(ctx context.Context, params *TransferParams) (r
 		Status:              statusString,
 		FromAccount:         params.FromAccount,
 		ToAccount:           params.ToAccount,
-		Amount:              params.Amount,
+		Amount:              int(validated.amountMinorUnits),
+		AmountDecimal:       validated.amountDecimal,
 		Currency:            params.Currency,
 		Description:         description,
 		ReferenceID:         referenceID,
@@ -108,25 +204,37 @@ func (service *Service) Transfer(ctx context.Context, params *TransferParams) (r
 		EstimatedCompletion: estimatedCompletion,
 		WorkflowID:          flowEngineResponse.WorkflowId,
 		RunID:               flowEngineResponse.RunId,
+		TransferReference:   flowEngineResponse.TransferReference,
+		PossibleDuplicate:   duplicate,
 	}
 
-	// For sync mode, wait for completion
+	// For sync mode, wait for completion as long as the request's time budget allows
 	if params.WaitForCompletion {
-		logger.Info("Waiting for transfer completion (sync mode)")
+		budget := syncWaitBudget(ctx)
+		if budget < minSyncWait {
+			logger.WithField("budget", budget.String()).Info("Time budget too short to wait, accepting transfer asynchronously")
+
+			results.Accepted = true
+
+			return results, nil
+		}
+
+		logger.WithField("budget", budget.String()).Info("Waiting for transfer completion (sync mode)")
 
-		// Poll for status updates until completion or timeout
+		// Poll for status updates until completion or until the budget is spent
 		ticker := time.NewTicker(500 * time.Millisecond)
 		defer ticker.Stop()
 
-		timeout := time.After(5 * time.Minute) // 5 minute timeout for sync mode
+		timeout := time.NewTimer(budget)
+		defer timeout.Stop()
 
 		for {
 			select {
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			case <-timeout:
-				logger.Warn("Transfer completion timeout reached")
-				results.ErrorMessage = "Transfer completion timeout reached"
+			case <-timeout.C:
+				logger.Warn("Time budget spent before transfer completion, accepting transfer asynchronously")
+				results.Accepted = true
 				return results, nil
 			case <-ticker.C:
 				// Check status
@@ -143,7 +251,8 @@ func (service *Service) Transfer(ctx context.Context, params *TransferParams) (r
 				if statusResponse.Status == pb.TransferStatus_TRANSFER_STATUS_COMPLETED ||
 					statusResponse.Status == pb.TransferStatus_TRANSFER_STATUS_FAILED ||
 					statusResponse.Status == pb.TransferStatus_TRANSFER_STATUS_COMPENSATED ||
-					statusResponse.Status == pb.TransferStatus_TRANSFER_STATUS_CANCELLED {
+					statusResponse.Status == pb.TransferStatus_TRANSFER_STATUS_CANCELLED ||
+					statusResponse.Status == pb.TransferStatus_TRANSFER_STATUS_EXPIRED {
 
 					// Set completion time
 					if statusResponse.CompletedAt != nil {
@@ -156,6 +265,8 @@ func (service *Service) Transfer(ctx context.Context, params *TransferParams) (r
 						results.ErrorMessage = statusResponse.ErrorMessage
 					}
 
+					results.FX = newTransferFX(statusResponse.Fx)
+
 					// Set compensation flag
 					if statusResponse.Status == pb.TransferStatus_TRANSFER_STATUS_COMPENSATED {
 						compensated := true
@@ -184,7 +295,8 @@ func (service *Service) checkTransferStatus(ctx context.Context, transactionID s
 }
 
 type GetTransferParams struct {
-	TransactionID string `json:"transaction_id"`
+	TransactionID string `json:"transaction_id"` // Transaction ID or transfer reference
+	WaitSeconds   int32  `json:"wait_seconds"`   // Long-poll up to this many seconds for a terminal state
 }
 
 type GetTransferResults struct {
@@ -192,7 +304,8 @@ type GetTransferResults struct {
 	Status            string `json:"status"`
 	FromAccount       string `json:"from_account"`
 	ToAccount         string `json:"to_account"`
-	Amount            int    `json:"amount"`
+	Amount            int    `json:"amount"`         // Minor units of the currency
+	AmountDecimal     string `json:"amount_decimal"` // Major units with the currency's decimal places
 	Currency          string `json:"currency"`
 	Description       string `json:"description"`
 	ReferenceID       string `json:"reference_id"`
@@ -203,6 +316,46 @@ type GetTransferResults struct {
 		RunID      string `json:"run_id"`
 		Status     string `json:"status"`
 	} `json:"workflow_execution"`
+	TransferReference string         `json:"transfer_reference,omitempty"`
+	Steps             []TransferStep `json:"-"` // Saga steps with their attempts, only exposed by v2; empty for fast path transfers
+	Wait              *TransferWait  `json:"-"` // What the transfer waits for before its debit, only exposed by v2
+	FX                *TransferFX    `json:"-"` // Conversion applied to the credit, only exposed by v2
+}
+
+// TransferFX is the conversion applied to the credit of a transfer to an account in another currency
+type TransferFX struct {
+	Pair                   string `json:"pair"` // e.g. EUR/USD
+	Side                   string `json:"side"` // BID when selling the base currency, ASK when buying it
+	Rate                   string `json:"rate"` // Applied rate, converted currency per transfer currency
+	MidRate                string `json:"mid_rate"`
+	SpreadBps              int64  `json:"spread_bps"`
+	MarginBps              int64  `json:"margin_bps"`
+	ConvertedCurrency      string `json:"converted_currency"`
+	ConvertedAmount        int64  `json:"converted_amount"`         // Minor units of ConvertedCurrency
+	ConvertedAmountDecimal string `json:"converted_amount_decimal"` // Major units with the currency's decimal places
+	SpreadAmount           int64  `json:"spread_amount"`            // Cost of the spread and margin, minor units of ConvertedCurrency
+	SpreadAmountDecimal    string `json:"spread_amount_decimal"`
+}
+
+// TransferWait is what a running transfer waits for before its debit, with RFC 3339 timestamps
+type TransferWait struct {
+	Reason         string `json:"reason"` // "approval", "funds" or "trigger"
+	Since          string `json:"since"`
+	Deadline       string `json:"deadline,omitempty"`
+	BalanceChecks  int32  `json:"balance_checks,omitempty"`
+	NextCheckAt    string `json:"next_check_at,omitempty"`
+	TriggerAccount string `json:"trigger_account,omitempty"`
+	AmountReceived string `json:"amount_received,omitempty"` // Credited to the trigger account so far, in major units
+}
+
+// TransferStep is one activity of the transfer saga, e.g. a debit that succeeded on its third attempt
+type TransferStep struct {
+	Activity     string `json:"activity"`
+	Status       string `json:"status"`
+	Attempts     int32  `json:"attempts"`
+	LastError    string `json:"last_error,omitempty"`
+	Compensation bool   `json:"compensation"`
+	Summary      string `json:"summary"`
 }
 
 func (service *Service) GetTransfer(ctx context.Context, params *GetTransferParams) (results *GetTransferResults, err error) {
@@ -218,10 +371,11 @@ func (service *Service) GetTransfer(ctx context.Context, params *GetTransferPara
 	// Create FlowEngine request
 	statusRequest := &pb.GetTransferStatusRequest{
 		TransactionId: params.TransactionID,
+		WaitSeconds:   params.WaitSeconds,
 	}
 
-	// Call FlowEngine adapter
-	statusResponse, err := service.flowngineAdapter.GetTransferStatus(ctx, statusRequest)
+	// Call FlowEngine adapter, unless the transfer has finished and its status is cached
+	statusResponse, err := service.getTransferStatus(ctx, logger, statusRequest)
 	if err != nil {
 		err = fmt.Errorf("failed to get transfer status from FlowEngine: %w", err)
 
@@ -230,6 +384,15 @@ func (service *Service) GetTransfer(ctx context.Context, params *GetTransferPara
 		return nil, err
 	}
 
+	results = newGetTransferResults(statusResponse)
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Transfer status retrieved successfully")
+
+	return results, nil
+}
+
+// newGetTransferResults converts a FlowEngine status response to the status of a transfer
+func newGetTransferResults(statusResponse *pb.GetTransferStatusResponse) *GetTransferResults {
 	// Convert timestamps to strings
 	createdAt := statusResponse.CreatedAt.AsTime().Format(time.RFC3339)
 	completedAt := ""
@@ -238,17 +401,19 @@ func (service *Service) GetTransfer(ctx context.Context, params *GetTransferPara
 	}
 
 	// Initialize results
-	results = &GetTransferResults{
-		TransactionID: statusResponse.TransactionId,
-		Status:        statusResponse.Status.String(),
-		FromAccount:   statusResponse.FromAccount,
-		ToAccount:     statusResponse.ToAccount,
-		Amount:        int(statusResponse.Amount),
-		Currency:      statusResponse.Currency,
-		Description:   statusResponse.Description,
-		ReferenceID:   statusResponse.ReferenceId,
-		CreatedAt:     createdAt,
-		CompletedAt:   completedAt,
+	results := &GetTransferResults{
+		TransactionID:     statusResponse.TransactionId,
+		Status:            statusResponse.Status.String(),
+		FromAccount:       statusResponse.FromAccount,
+		ToAccount:         statusResponse.ToAccount,
+		Amount:            int(statusResponse.Amount),
+		AmountDecimal:     statusResponse.AmountDecimal,
+		Currency:          statusResponse.Currency,
+		Description:       statusResponse.Description,
+		ReferenceID:       statusResponse.ReferenceId,
+		CreatedAt:         createdAt,
+		CompletedAt:       completedAt,
+		TransferReference: statusResponse.TransferReference,
 	}
 
 	// Set workflow execution details
@@ -258,7 +423,55 @@ func (service *Service) GetTransfer(ctx context.Context, params *GetTransferPara
 		results.WorkflowExecution.Status = statusResponse.WorkflowExecution.Status
 	}
 
-	logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Transfer status retrieved successfully")
+	for _, step := range statusResponse.Steps {
+		results.Steps = append(results.Steps, TransferStep{
+			Activity:     step.Activity,
+			Status:       step.Status,
+			Attempts:     step.Attempts,
+			LastError:    step.LastError,
+			Compensation: step.Compensation,
+			Summary:      step.Summary,
+		})
+	}
 
-	return results, nil
+	results.FX = newTransferFX(statusResponse.Fx)
+
+	if wait := statusResponse.Wait; wait != nil {
+		results.Wait = &TransferWait{
+			Reason:         wait.Reason,
+			Since:          wait.Since.AsTime().Format(time.RFC3339),
+			BalanceChecks:  wait.BalanceChecks,
+			TriggerAccount: wait.TriggerAccount,
+			AmountReceived: wait.AmountReceived,
+		}
+		if wait.Deadline != nil {
+			results.Wait.Deadline = wait.Deadline.AsTime().Format(time.RFC3339)
+		}
+		if wait.NextCheckAt != nil {
+			results.Wait.NextCheckAt = wait.NextCheckAt.AsTime().Format(time.RFC3339)
+		}
+	}
+
+	return results
+}
+
+// newTransferFX converts the conversion FlowEngine reports for a transfer, nil when none was applied
+func newTransferFX(fx *pb.TransferFX) *TransferFX {
+	if fx == nil {
+		return nil
+	}
+
+	return &TransferFX{
+		Pair:                   fx.Pair,
+		Side:                   fx.Side,
+		Rate:                   fx.Rate,
+		MidRate:                fx.MidRate,
+		SpreadBps:              fx.SpreadBps,
+		MarginBps:              fx.MarginBps,
+		ConvertedCurrency:      fx.ConvertedCurrency,
+		ConvertedAmount:        fx.ConvertedAmount,
+		ConvertedAmountDecimal: fx.ConvertedAmountDecimal,
+		SpreadAmount:           fx.SpreadAmount,
+		SpreadAmountDecimal:    fx.SpreadAmountDecimal,
+	}
 }
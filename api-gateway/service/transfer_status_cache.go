@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+)
+
+// transferStatusCacheKeyPrefix is the status cache key prefix of transfer status responses
+const transferStatusCacheKeyPrefix = "transfer-status:"
+
+// terminalTransferStatuses are the statuses a transfer never leaves, so their responses can be cached
+var terminalTransferStatuses = map[pb.TransferStatus]bool{
+	pb.TransferStatus_TRANSFER_STATUS_COMPLETED:   true,
+	pb.TransferStatus_TRANSFER_STATUS_FAILED:      true,
+	pb.TransferStatus_TRANSFER_STATUS_COMPENSATED: true,
+	pb.TransferStatus_TRANSFER_STATUS_CANCELLED:   true,
+	pb.TransferStatus_TRANSFER_STATUS_EXPIRED:     true,
+}
+
+// IsTerminalTransferStatus reports whether a transfer status, as reported in GetTransferResults, is one the transfer
+// never leaves
+func IsTerminalTransferStatus(status string) bool {
+	value, ok := pb.TransferStatus_value[status]
+
+	return ok && terminalTransferStatuses[pb.TransferStatus(value)]
+}
+
+// getTransferStatus returns the status of a transfer from the status cache or, on a miss, from FlowEngine. Responses
+// of finished transfers are cached under the requested ID, the transaction ID and the transfer reference, so polling
+// a finished transfer by either ID no longer reaches FlowEngine. The cache only saves load: when it fails the
+// request goes to FlowEngine as if it were a miss.
+func (service *Service) getTransferStatus(ctx context.Context, logger *logrus.Entry, request *pb.GetTransferStatusRequest) (*pb.GetTransferStatusResponse, error) {
+	if cached, ok := service.cachedTransferStatus(ctx, logger, request.TransactionId); ok {
+		logger.Debug("Serving cached transfer status")
+
+		return cached, nil
+	}
+
+	response, err := service.flowngineAdapter.GetTransferStatus(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	service.cacheTransferStatus(ctx, logger, request.TransactionId, response)
+
+	return response, nil
+}
+
+// cachedTransferStatus returns the cached status of a finished transfer
+func (service *Service) cachedTransferStatus(ctx context.Context, logger *logrus.Entry, transactionID string) (*pb.GetTransferStatusResponse, bool) {
+	if service.statusCache == nil {
+		return nil, false
+	}
+
+	cached, ok, err := service.statusCache.Get(ctx, transferStatusCacheKeyPrefix+transactionID)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to read transfer status cache, asking FlowEngine")
+
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+
+	response := &pb.GetTransferStatusResponse{}
+	if err := proto.Unmarshal(cached, response); err != nil {
+		logger.WithError(err).Warn("Discarding unreadable cached transfer status")
+
+		return nil, false
+	}
+
+	return response, true
+}
+
+// cacheTransferStatus stores the response of a finished transfer under each of its IDs; responses of transfers that
+// are still running are not cached
+func (service *Service) cacheTransferStatus(ctx context.Context, logger *logrus.Entry, requestedID string, response *pb.GetTransferStatusResponse) {
+	if service.statusCache == nil || !terminalTransferStatuses[response.Status] {
+		return
+	}
+
+	value, err := proto.Marshal(response)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to encode transfer status for the cache")
+
+		return
+	}
+
+	stored := map[string]bool{}
+	for _, id := range []string{requestedID, response.TransactionId, response.TransferReference} {
+		if id == "" || stored[id] {
+			continue
+		}
+		stored[id] = true
+
+		if err := service.statusCache.Set(ctx, transferStatusCacheKeyPrefix+id, value); err != nil {
+			logger.WithError(fmt.Errorf("failed to cache transfer status of %s: %w", id, err)).Warn()
+
+			return
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+	"api-gateway/util/config"
+	"api-gateway/util/statuscache"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCachedTransferStatus(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	entry := logrus.NewEntry(logger)
+
+	cache := statuscache.NewMemory(10, time.Hour)
+	// No FlowEngine adapter: every status below must come from the cache
+	service := NewService(logger, nil, nil, nil, config.Receipt{}, config.PaymentRequests{}, nil, nil, config.DuplicateDetection{}, cache)
+
+	finished := &pb.GetTransferStatusResponse{
+		TransactionId:     "txn-1",
+		TransferReference: "TRF-1",
+		Status:            pb.TransferStatus_TRANSFER_STATUS_COMPENSATED,
+		AmountDecimal:     "10.50",
+	}
+	service.cacheTransferStatus(context.Background(), entry, "txn-1", finished)
+
+	for _, id := range []string{"txn-1", "TRF-1"} {
+		response, err := service.getTransferStatus(context.Background(), entry, &pb.GetTransferStatusRequest{TransactionId: id})
+		require.NoError(t, err, id)
+		assert.True(t, proto.Equal(finished, response), id)
+	}
+
+	results, err := service.GetTransfer(context.Background(), &GetTransferParams{TransactionID: "TRF-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "txn-1", results.TransactionID)
+	assert.Equal(t, "TRANSFER_STATUS_COMPENSATED", results.Status)
+
+	assert.False(t, terminalTransferStatuses[pb.TransferStatus_TRANSFER_STATUS_PROCESSING])
+}
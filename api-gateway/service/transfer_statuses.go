@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxTransferStatusesIDs caps the transfers of one GetTransfers call, like FlowEngine does
+const maxTransferStatusesIDs = 100
+
+// ErrInvalidTransferStatusesRequest is returned when no transfer, too many transfers or an empty ID is requested
+var ErrInvalidTransferStatusesRequest = errors.New("invalid transfer statuses request")
+
+type GetTransfersParams struct {
+	TransactionIDs []string `json:"transaction_ids"` // Transaction IDs or transfer references
+}
+
+// TransferLookupError tells why one transfer of a GetTransfers call could not be looked up
+type TransferLookupError struct {
+	Code      string `json:"code"` // e.g. TRANSFER_NOT_FOUND
+	Message   string `json:"message"`
+	Retryable bool   `json:"retryable"`
+}
+
+// GetTransfersItem is the status of one requested transfer; either Transfer or Error is set
+type GetTransfersItem struct {
+	RequestedID string               `json:"requested_id"`
+	Transfer    *GetTransferResults  `json:"transfer,omitempty"`
+	Error       *TransferLookupError `json:"error,omitempty"`
+}
+
+type GetTransfersResults struct {
+	Transfers []GetTransfersItem `json:"transfers"` // In the order of the requested IDs
+}
+
+// GetTransfers returns the status of several transfers with one FlowEngine call. Finished transfers found in the
+// status cache are served from it and only the others are sent to FlowEngine.
+func (service *Service) GetTransfers(ctx context.Context, params *GetTransfersParams) (*GetTransfersResults, error) {
+	const op = "service.Service.GetTransfers"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":      op,
+		"transfers": len(params.TransactionIDs),
+	})
+
+	logger.Info("Getting transfer statuses from FlowEngine")
+
+	if err := validateGetTransfersParams(params); err != nil {
+		return nil, err
+	}
+
+	statuses := map[string]*pb.TransferStatusResult{}
+	var missing []string
+	for _, transactionID := range params.TransactionIDs {
+		if _, seen := statuses[transactionID]; seen {
+			continue
+		}
+
+		if cached, ok := service.cachedTransferStatus(ctx, logger, transactionID); ok {
+			statuses[transactionID] = &pb.TransferStatusResult{RequestedId: transactionID, Transfer: cached}
+
+			continue
+		}
+
+		statuses[transactionID] = nil
+		missing = append(missing, transactionID)
+	}
+
+	if len(missing) > 0 {
+		response, err := service.flowngineAdapter.GetTransferStatuses(ctx, &pb.GetTransferStatusesRequest{TransactionIds: missing})
+		if err != nil {
+			err = fmt.Errorf("failed to get transfer statuses from FlowEngine: %w", err)
+
+			logger.WithError(err).Error()
+
+			return nil, err
+		}
+
+		for _, result := range response.Results {
+			statuses[result.RequestedId] = result
+
+			if result.Transfer != nil {
+				service.cacheTransferStatus(ctx, logger, result.RequestedId, result.Transfer)
+			}
+		}
+	}
+
+	results := &GetTransfersResults{Transfers: make([]GetTransfersItem, 0, len(params.TransactionIDs))}
+	for _, transactionID := range params.TransactionIDs {
+		results.Transfers = append(results.Transfers, newGetTransfersItem(transactionID, statuses[transactionID]))
+	}
+
+	logger.WithFields(logrus.Fields{
+		"cached":  len(statuses) - len(missing),
+		"fetched": len(missing),
+	}).Info("Transfer statuses retrieved successfully")
+
+	return results, nil
+}
+
+// newGetTransfersItem converts the FlowEngine result of one requested transfer
+func newGetTransfersItem(transactionID string, result *pb.TransferStatusResult) GetTransfersItem {
+	item := GetTransfersItem{RequestedID: transactionID}
+
+	switch {
+	case result == nil:
+		item.Error = &TransferLookupError{Code: "INTERNAL_ERROR", Message: "FlowEngine returned no status for the transfer", Retryable: true}
+	case result.Transfer != nil:
+		item.Transfer = newGetTransferResults(result.Transfer)
+	default:
+		item.Error = &TransferLookupError{
+			Code:      result.GetError().GetCode(),
+			Message:   result.ErrorMessage,
+			Retryable: result.GetError().GetRetryable(),
+		}
+		if item.Error.Code == "" {
+			item.Error.Code = "INTERNAL_ERROR"
+		}
+	}
+
+	return item
+}
+
+func validateGetTransfersParams(params *GetTransfersParams) error {
+	if len(params.TransactionIDs) == 0 {
+		return fmt.Errorf("%w: transaction_ids is required", ErrInvalidTransferStatusesRequest)
+	}
+
+	if len(params.TransactionIDs) > maxTransferStatusesIDs {
+		return fmt.Errorf("%w: transaction_ids cannot have more than %d entries", ErrInvalidTransferStatusesRequest, maxTransferStatusesIDs)
+	}
+
+	for i, transactionID := range params.TransactionIDs {
+		if transactionID == "" {
+			return fmt.Errorf("%w: transaction_ids[%d] is empty", ErrInvalidTransferStatusesRequest, i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+	"api-gateway/util/config"
+	"api-gateway/util/statuscache"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTransfersFromCache(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cache := statuscache.NewMemory(10, time.Hour)
+	// No FlowEngine adapter: transfers that are all cached do not reach FlowEngine
+	service := NewService(logger, nil, nil, nil, config.Receipt{}, config.PaymentRequests{}, nil, nil, config.DuplicateDetection{}, cache)
+
+	for _, id := range []string{"txn-1", "txn-2"} {
+		service.cacheTransferStatus(context.Background(), logrus.NewEntry(logger), id, &pb.GetTransferStatusResponse{
+			TransactionId: id,
+			Status:        pb.TransferStatus_TRANSFER_STATUS_COMPLETED,
+		})
+	}
+
+	results, err := service.GetTransfers(context.Background(), &GetTransfersParams{TransactionIDs: []string{"txn-2", "txn-1", "txn-2"}})
+	require.NoError(t, err)
+	require.Len(t, results.Transfers, 3)
+	assert.Equal(t, "txn-2", results.Transfers[0].Transfer.TransactionID)
+	assert.Equal(t, "txn-1", results.Transfers[1].Transfer.TransactionID)
+	assert.Equal(t, "txn-2", results.Transfers[2].RequestedID)
+
+	for _, ids := range [][]string{nil, {"txn-1", ""}, make([]string, maxTransferStatusesIDs+1)} {
+		_, err := service.GetTransfers(context.Background(), &GetTransfersParams{TransactionIDs: ids})
+		assert.ErrorIs(t, err, ErrInvalidTransferStatusesRequest)
+	}
+}
+
+func TestNewGetTransfersItem(t *testing.T) {
+	t.Parallel()
+
+	item := newGetTransfersItem("TRF-1", &pb.TransferStatusResult{
+		RequestedId:  "TRF-1",
+		Error:        &pb.ErrorDetail{Code: "TRANSFER_NOT_FOUND"},
+		ErrorMessage: "transfer not found: TRF-1",
+	})
+	assert.Nil(t, item.Transfer)
+	assert.Equal(t, &TransferLookupError{Code: "TRANSFER_NOT_FOUND", Message: "transfer not found: TRF-1"}, item.Error)
+
+	item = newGetTransfersItem("TRF-2", nil)
+	assert.Equal(t, "INTERNAL_ERROR", item.Error.Code)
+	assert.True(t, item.Error.Retryable)
+}
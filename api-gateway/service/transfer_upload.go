@@ -0,0 +1,375 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrInvalidTransferUpload is returned when an uploaded file is not a CSV of transfers or is rejected as a whole
+	ErrInvalidTransferUpload = errors.New("invalid transfer upload")
+	// ErrTransferUploadNotFound is returned when FlowEngine has no batch for an upload ID
+	ErrTransferUploadNotFound = errors.New("transfer upload not found")
+)
+
+// Columns of an uploaded transfer CSV. The header row names them, in any order; description and reference_id are optional.
+var (
+	transferUploadColumns         = []string{"from_account", "to_account", "amount", "currency"}
+	transferUploadOptionalColumns = []string{"description", "reference_id"}
+)
+
+// Transfer upload row statuses that are not FlowEngine transfer statuses
+const TransferUploadRowRejected = "REJECTED" // The row failed validation and no transfer was started for it
+
+// transferUploadArchivePrefix is the object store key prefix of archived transfer uploads
+const transferUploadArchivePrefix = "transfer-uploads"
+
+type UploadTransfersParams struct {
+	CSV    []byte
+	Tenant string // Selects the account number format; empty for the default
+}
+
+type UploadTransfersResults struct {
+	UploadID   string              `json:"upload_id"` // Empty when every row was rejected and no batch was started
+	WorkflowID string              `json:"workflow_id"`
+	RunID      string              `json:"run_id"`
+	CreatedAt  string              `json:"created_at"`
+	Accepted   int                 `json:"accepted"`
+	Rejected   int                 `json:"rejected"`
+	Rows       []TransferUploadRow `json:"rows"` // In file order
+}
+
+// TransferUploadRow is the outcome of one data row of an uploaded CSV. Row is its line in the file, the header being line 1.
+type TransferUploadRow struct {
+	Row               int32  `json:"row"`
+	Status            string `json:"status"` // FlowEngine transfer status, or TransferUploadRowRejected
+	TransactionID     string `json:"transaction_id,omitempty"`
+	TransferReference string `json:"transfer_reference,omitempty"`
+	ErrorMessage      string `json:"error_message,omitempty"`
+}
+
+// UploadTransfers validates every row of a CSV of transfers and starts one batch workflow for the valid rows.
+// Invalid rows are reported as rejected instead of failing the upload.
+func (service *Service) UploadTransfers(ctx context.Context, params *UploadTransfersParams) (results *UploadTransfersResults, err error) {
+	const op = "service.Service.UploadTransfers"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]": op,
+		"size": len(params.CSV),
+	})
+
+	logger.Info("Uploading transfers to FlowEngine")
+
+	records, err := parseTransferUpload(params.CSV)
+	if err != nil {
+		logger.WithError(err).Warn("Transfer upload rejected")
+
+		return nil, err
+	}
+
+	results = &UploadTransfersResults{}
+
+	request := &pb.StartTransferBatchRequest{
+		RequestId: uuid.New().String(),
+	}
+
+	for _, record := range records {
+		row := TransferUploadRow{Row: record.row}
+
+		rowErr := record.err
+		var transfer *pb.TransferBatchItem
+		if rowErr == nil {
+			transfer, rowErr = service.validateTransferUploadRecord(ctx, params.Tenant, record)
+		}
+		if rowErr != nil {
+			// Without the catalog no row can be validated, so the upload fails as a whole
+			if errors.Is(rowErr, ErrCurrencyCatalogUnavailable) {
+				logger.WithError(rowErr).Error()
+
+				return nil, rowErr
+			}
+
+			row.Status = TransferUploadRowRejected
+			row.ErrorMessage = rowErr.Error()
+			results.Rejected++
+		} else {
+			request.Transfers = append(request.Transfers, transfer)
+			results.Accepted++
+		}
+
+		results.Rows = append(results.Rows, row)
+	}
+
+	if len(request.Transfers) == 0 {
+		logger.WithField("rejected", results.Rejected).Warn("Every row of the transfer upload was rejected")
+
+		return results, nil
+	}
+
+	// Call FlowEngine adapter
+	response, err := service.flowngineAdapter.StartTransferBatch(ctx, request)
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			err = fmt.Errorf("%w: %s", ErrInvalidTransferUpload, status.Convert(err).Message())
+		} else {
+			err = fmt.Errorf("failed to start transfer batch via FlowEngine: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results.UploadID = response.BatchId
+	results.WorkflowID = response.WorkflowExecution.GetWorkflowId()
+	results.RunID = response.WorkflowExecution.GetRunId()
+	results.CreatedAt = response.CreatedAt.AsTime().Format(time.RFC3339)
+
+	started := make(map[int32]*pb.TransferBatchRow, len(response.Rows))
+	for _, row := range response.Rows {
+		started[row.Row] = row
+	}
+	for i, row := range results.Rows {
+		if batchRow, ok := started[row.Row]; ok {
+			results.Rows[i].Status = batchRow.Status.String()
+			results.Rows[i].TransactionID = batchRow.TransactionId
+			results.Rows[i].TransferReference = batchRow.TransferReference
+		}
+	}
+
+	service.archiveTransferUpload(ctx, logger, results.UploadID, params.CSV)
+
+	logger.WithFields(logrus.Fields{
+		"upload_id": results.UploadID,
+		"accepted":  results.Accepted,
+		"rejected":  results.Rejected,
+	}).Info("Transfer upload started")
+
+	return results, nil
+}
+
+// archiveTransferUpload keeps the uploaded file, as received, next to the batch it started so operators can trace a
+// batch back to its file. The batch is already running, so a failed copy is only logged.
+func (service *Service) archiveTransferUpload(ctx context.Context, logger *logrus.Entry, uploadID string, data []byte) {
+	if service.objectStore == nil {
+		return
+	}
+
+	key := path.Join(transferUploadArchivePrefix, uploadID+".csv")
+
+	location, err := service.objectStore.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "text/csv")
+	if err != nil {
+		logger.WithError(err).WithField("key", key).Warn("Failed to archive transfer upload")
+
+		return
+	}
+
+	logger.WithField("location", location).Info("Transfer upload archived")
+}
+
+// transferUploadRecord is a data row of an uploaded CSV, keyed by column name
+type transferUploadRecord struct {
+	row    int32
+	fields map[string]string
+	err    error // Set when the row could not be read, e.g. it has the wrong number of fields
+}
+
+// parseTransferUpload reads the header and data rows of an uploaded CSV. Rows with the wrong number of fields are
+// returned with an error so they can be reported; anything else that is not valid CSV rejects the whole file.
+func parseTransferUpload(data []byte) ([]transferUploadRecord, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidTransferUpload)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransferUpload, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("%w: duplicate column %q", ErrInvalidTransferUpload, name)
+		}
+		columns[name] = i
+	}
+	for _, name := range transferUploadColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidTransferUpload, name)
+		}
+	}
+
+	var records []transferUploadRecord
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTransferUpload, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := transferUploadRecord{row: int32(line)}
+
+		if err != nil {
+			record.err = fmt.Errorf("row has %d fields, expected %d", len(fields), len(header))
+		} else {
+			record.fields = make(map[string]string, len(columns))
+			for _, name := range slices.Concat(transferUploadColumns, transferUploadOptionalColumns) {
+				if i, ok := columns[name]; ok {
+					record.fields[name] = strings.TrimSpace(fields[i])
+				}
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: file has no transfers", ErrInvalidTransferUpload)
+	}
+
+	return records, nil
+}
+
+// validateTransferUploadRecord checks a row the way POST /api/v2/transfer checks a request and maps it to a batch item
+func (service *Service) validateTransferUploadRecord(ctx context.Context, tenant string, record transferUploadRecord) (*pb.TransferBatchItem, error) {
+	fields := record.fields
+
+	for _, field := range []string{"from_account", "to_account"} {
+		if fields[field] == "" {
+			return nil, fmt.Errorf("%s is required", field)
+		}
+
+		if err := service.validateAccountNumber(tenant, field, fields[field]); err != nil {
+			return nil, err
+		}
+	}
+
+	if fields["from_account"] == fields["to_account"] {
+		return nil, fmt.Errorf("from_account and to_account must differ")
+	}
+
+	if len(fields["description"]) > 100 {
+		return nil, fmt.Errorf("description must be at most 100 characters")
+	}
+
+	if len(fields["reference_id"]) > 50 {
+		return nil, fmt.Errorf("reference_id must be at most 50 characters")
+	}
+
+	// The amount column is a major-unit decimal, e.g. "100.50"
+	amountDecimal := fields["amount"]
+	amount, _, err := service.resolveTransferAmount(ctx, 0, &amountDecimal, fields["currency"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.TransferBatchItem{
+		Row:         record.row,
+		FromAccount: fields["from_account"],
+		ToAccount:   fields["to_account"],
+		Amount:      amount,
+		Currency:    fields["currency"],
+		Description: fields["description"],
+		ReferenceId: fields["reference_id"],
+	}, nil
+}
+
+type GetTransferUploadParams struct {
+	UploadID string `json:"upload_id"`
+}
+
+type GetTransferUploadResults struct {
+	UploadID          string `json:"upload_id"`
+	WorkflowExecution struct {
+		WorkflowID string `json:"workflow_id"`
+		RunID      string `json:"run_id"`
+		Status     string `json:"status"` // RUNNING until every transfer of the upload has finished
+	} `json:"workflow_execution"`
+	Total       int                 `json:"total"` // Accepted rows; rejected rows are only reported by the upload itself
+	Pending     int                 `json:"pending"`
+	Processing  int                 `json:"processing"`
+	Completed   int                 `json:"completed"`
+	Failed      int                 `json:"failed"` // Failed, compensated or expired
+	Rows        []TransferUploadRow `json:"rows"`
+	StartedAt   string              `json:"started_at"`
+	CompletedAt string              `json:"completed_at"`
+}
+
+// GetTransferUpload reports the progress of an upload and the outcome of each of its accepted rows
+func (service *Service) GetTransferUpload(ctx context.Context, params *GetTransferUploadParams) (results *GetTransferUploadResults, err error) {
+	const op = "service.Service.GetTransferUpload"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Getting transfer upload from FlowEngine")
+
+	// Call FlowEngine adapter
+	response, err := service.flowngineAdapter.GetTransferBatch(ctx, &pb.GetTransferBatchRequest{BatchId: params.UploadID})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			err = fmt.Errorf("%w: %s", ErrTransferUploadNotFound, params.UploadID)
+		} else {
+			err = fmt.Errorf("failed to get transfer batch from FlowEngine: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results = &GetTransferUploadResults{
+		UploadID:   response.BatchId,
+		Total:      int(response.Total),
+		Pending:    int(response.Pending),
+		Processing: int(response.Processing),
+		Completed:  int(response.Completed),
+		Failed:     int(response.Failed),
+	}
+	results.WorkflowExecution.WorkflowID = response.WorkflowExecution.GetWorkflowId()
+	results.WorkflowExecution.RunID = response.WorkflowExecution.GetRunId()
+	results.WorkflowExecution.Status = response.WorkflowExecution.GetStatus()
+
+	if response.StartedAt != nil {
+		results.StartedAt = response.StartedAt.AsTime().Format(time.RFC3339)
+	}
+	if response.CompletedAt != nil {
+		results.CompletedAt = response.CompletedAt.AsTime().Format(time.RFC3339)
+	}
+
+	for _, row := range response.Rows {
+		results.Rows = append(results.Rows, TransferUploadRow{
+			Row:               row.Row,
+			Status:            row.Status.String(),
+			TransactionID:     row.TransactionId,
+			TransferReference: row.TransferReference,
+			ErrorMessage:      row.ErrorMessage,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransferUpload(t *testing.T) {
+	t.Parallel()
+
+	records, err := parseTransferUpload([]byte("\ufeffCurrency,from_account,to_account,amount,reference_id\n" +
+		"USD,ACC000000001,ACC000000002,100.50,payroll-1\n" +
+		"USD,ACC000000001\n" +
+		"\n" +
+		"JPY, ACC000000001,ACC000000003,500,\n"))
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	assert.Equal(t, int32(2), records[0].row)
+	assert.Equal(t, map[string]string{
+		"from_account": "ACC000000001",
+		"to_account":   "ACC000000002",
+		"amount":       "100.50",
+		"currency":     "USD",
+		"reference_id": "payroll-1",
+	}, records[0].fields)
+
+	assert.Equal(t, int32(3), records[1].row)
+	assert.EqualError(t, records[1].err, "row has 2 fields, expected 5")
+
+	// Blank lines are skipped but still count towards the row numbers
+	assert.Equal(t, int32(5), records[2].row)
+	assert.Equal(t, "ACC000000001", records[2].fields["from_account"])
+
+	for name, data := range map[string]string{
+		"empty":          "",
+		"header_only":    "from_account,to_account,amount,currency\n",
+		"missing_column": "from_account,to_account,amount\nACC000000001,ACC000000002,1\n",
+		"duplicate":      "from_account,to_account,amount,currency,amount\n",
+		"bare_quote":     "from_account,to_account,amount,currency\nACC000000001,ACC\"000000002,1,USD\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseTransferUpload([]byte(data))
+			assert.ErrorIs(t, err, ErrInvalidTransferUpload)
+		})
+	}
+}
+
+func TestUploadTransfersRejectsInvalidRows(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newCurrencyTestService(t)
+
+	// No row is valid, so no batch is started and FlowEngine is never called
+	results, err := service.UploadTransfers(context.Background(), &UploadTransfersParams{CSV: []byte(
+		"from_account,to_account,amount,currency\n" +
+			"ACC000000001,ACC000000002,10.505,USD\n" +
+			"ACC000000001,ACC000000002,1,XXX\n" +
+			"ACC0001,ACC000000002,1,USD\n" +
+			"ACC000000001,ACC000000001,1,USD\n" +
+			"ACC000000001,ACC000000002,0,USD\n",
+	)})
+	require.NoError(t, err)
+
+	assert.Empty(t, results.UploadID)
+	assert.Equal(t, 0, results.Accepted)
+	assert.Equal(t, 5, results.Rejected)
+	require.Len(t, results.Rows, 5)
+
+	for i, row := range results.Rows {
+		assert.Equal(t, int32(i+2), row.Row)
+		assert.Equal(t, TransferUploadRowRejected, row.Status)
+		assert.NotEmpty(t, row.ErrorMessage)
+	}
+	assert.Contains(t, results.Rows[1].ErrorMessage, "unsupported currency")
+	assert.Equal(t, "from_account must be 12 characters", results.Rows[2].ErrorMessage)
+}
+
+func TestValidateTransferUploadRecord(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newCurrencyTestService(t)
+
+	item, err := service.validateTransferUploadRecord(context.Background(), "", transferUploadRecord{row: 7, fields: map[string]string{
+		"from_account": "ACC000000001",
+		"to_account":   "ACC000000002",
+		"amount":       "100.50",
+		"currency":     "USD",
+		"description":  "March payroll",
+	}})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(7), item.Row)
+	assert.Equal(t, int64(10050), item.Amount)
+	assert.Equal(t, "March payroll", item.Description)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"api-gateway/adapter/flowngine_adapter/pb"
+	"api-gateway/util/accountnumber"
+)
+
+// Limits of the free-text fields of a transfer request, matching the columns they are stored in
+const (
+	maxDescriptionLength    = 100
+	maxReferenceIDLength    = 50
+	maxTriggerAccountLength = 64
+	maxToAliasLength        = 320 // core.account_aliases.alias in svc-balance
+)
+
+// ErrInvalidTransferRequest is wrapped by TransferValidationError, for callers that only need to know the request
+// was rejected
+var ErrInvalidTransferRequest = errors.New("invalid transfer request")
+
+// FieldViolation is a request field rejected by the gateway, named as in the v1 request body
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// TransferValidationError lists every field of a transfer request rejected before a workflow is started.
+// It also matches the sentinel errors of its violations, e.g. ErrUnsupportedCurrency or ErrInvalidAmountScale.
+type TransferValidationError struct {
+	Violations []FieldViolation
+
+	causes []error
+}
+
+func (e *TransferValidationError) Error() string {
+	descriptions := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		descriptions = append(descriptions, violation.Description)
+	}
+
+	return fmt.Sprintf("%v: %s", ErrInvalidTransferRequest, strings.Join(descriptions, "; "))
+}
+
+func (e *TransferValidationError) Unwrap() []error {
+	return append([]error{ErrInvalidTransferRequest}, e.causes...)
+}
+
+// add records a violation of field; cause is the sentinel error it matches, or nil
+func (e *TransferValidationError) add(field string, cause error, format string, args ...any) {
+	e.Violations = append(e.Violations, FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+	if cause != nil {
+		e.causes = append(e.causes, cause)
+	}
+}
+
+// validatedTransfer is a transfer request that passed validation, with its amounts resolved against the currency
+type validatedTransfer struct {
+	amountMinorUnits int64
+	amountDecimal    string
+	callbackURL      string
+	trigger          *pb.TransferTrigger
+}
+
+// validateTransfer checks a transfer request against the currency catalog and the request limits, so clients get
+// every problem of the request at once instead of a failed workflow. Only ErrCurrencyCatalogUnavailable is
+// returned as is; everything else the client can fix is a *TransferValidationError.
+func (service *Service) validateTransfer(ctx context.Context, params *TransferParams) (*validatedTransfer, error) {
+	violations := &TransferValidationError{}
+
+	validateAccount := func(field, account string) {
+		switch {
+		case account == "":
+			violations.add(field, nil, "%s is required", field)
+		default:
+			if err := service.validateAccountNumber(params.Tenant, field, account); err != nil {
+				violations.add(field, accountnumber.ErrInvalidAccountNumber, "%v", err)
+			}
+		}
+	}
+	validateAccount("from_account", params.FromAccount)
+	// Aliases are resolved by the transfer workflow, which reports an unknown alias as ALIAS_NOT_FOUND
+	switch {
+	case params.ToAlias == "":
+		validateAccount("to_account", params.ToAccount)
+	case params.ToAccount != "":
+		violations.add("to_alias", nil, "set either to_account or to_alias, not both")
+	case len(params.ToAlias) > maxToAliasLength:
+		violations.add("to_alias", nil, "to_alias must be at most %d characters", maxToAliasLength)
+	}
+	if params.FromAccount != "" && params.FromAccount == params.ToAccount {
+		violations.add("to_account", nil, "from_account and to_account must differ")
+	}
+
+	if params.Description != nil && len(*params.Description) > maxDescriptionLength {
+		violations.add("description", nil, "description must be at most %d characters", maxDescriptionLength)
+	}
+	if params.ReferenceID != nil && len(*params.ReferenceID) > maxReferenceIDLength {
+		violations.add("reference_id", nil, "reference_id must be at most %d characters", maxReferenceIDLength)
+	}
+
+	if params.Trigger != nil {
+		switch {
+		case params.Trigger.Account == "":
+			violations.add("trigger.account", ErrInvalidTransferTrigger, "trigger.account is required")
+		case len(params.Trigger.Account) > maxTriggerAccountLength:
+			violations.add("trigger.account", ErrInvalidTransferTrigger, "trigger.account must be at most %d characters", maxTriggerAccountLength)
+		}
+	}
+
+	validated := &validatedTransfer{}
+
+	if params.CallbackURL != nil {
+		validated.callbackURL = *params.CallbackURL
+
+		if parsed, err := url.Parse(validated.callbackURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			violations.add("callback_url", ErrInvalidCallbackURL, "%v", ErrInvalidCallbackURL)
+		}
+	}
+
+	// Amounts can only be checked once the currency is known to be supported
+	if params.Currency == "" {
+		violations.add("currency", ErrUnsupportedCurrency, "currency is required")
+	} else if _, err := service.lookupCurrency(ctx, params.Currency); err != nil {
+		if errors.Is(err, ErrCurrencyCatalogUnavailable) {
+			return nil, err
+		}
+
+		violations.add("currency", ErrUnsupportedCurrency, "%v", err)
+	} else {
+		amountField := "amount"
+		if params.AmountDecimal != nil {
+			amountField = "amount_decimal"
+		}
+
+		validated.amountMinorUnits, validated.amountDecimal, err = service.resolveTransferAmount(ctx, params.Amount, params.AmountDecimal, params.Currency)
+		if err != nil {
+			violations.add(amountField, amountErrorCause(err), "%v", err)
+		}
+
+		// The trigger amount is checked against the transfer currency like the transfer amount itself
+		if params.Trigger != nil {
+			_, triggerDecimal, err := service.resolveTransferAmount(ctx, 0, &params.Trigger.AmountDecimal, params.Currency)
+			if err != nil {
+				violations.add("trigger.amount_decimal", ErrInvalidTransferTrigger, "trigger amount: %v", err)
+				violations.causes = append(violations.causes, amountErrorCause(err))
+			}
+			validated.trigger = &pb.TransferTrigger{Account: params.Trigger.Account, AmountDecimal: triggerDecimal}
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return nil, violations
+	}
+
+	return validated, nil
+}
+
+// validateAccountNumber checks an account number against the format of tenant. The error names the rejected field,
+// e.g. "to_account has an invalid check digit".
+func (service *Service) validateAccountNumber(tenant, field, accountNumber string) error {
+	err := service.accountNumbers.Validate(tenant, accountNumber)
+
+	var formatErr *accountnumber.FormatError
+	if errors.As(err, &formatErr) {
+		return fmt.Errorf("%s %s", field, formatErr.Reason)
+	}
+
+	return err
+}
+
+// amountErrorCause returns the sentinel error an amount was rejected with
+func amountErrorCause(err error) error {
+	if errors.Is(err, ErrInvalidAmountScale) {
+		return ErrInvalidAmountScale
+	}
+
+	return ErrInvalidTransferAmount
+}
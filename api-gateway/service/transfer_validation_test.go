@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"api-gateway/util/accountnumber"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTransfer(t *testing.T) {
+	t.Parallel()
+
+	service, _, _ := newCurrencyTestService(t)
+
+	text := func(value string) *string { return &value }
+	validParams := func() *TransferParams {
+		return &TransferParams{FromAccount: "ACC001000001", ToAccount: "ACC001000002", AmountDecimal: text("100.5"), Currency: "USD"}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		params := validParams()
+		params.CallbackURL = text("https://example.com/hooks")
+		params.Trigger = &TransferTrigger{Account: "ACC001000003", AmountDecimal: "500"}
+
+		validated, err := service.validateTransfer(context.Background(), params)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10050), validated.amountMinorUnits)
+		assert.Equal(t, "100.50", validated.amountDecimal)
+		assert.Equal(t, "https://example.com/hooks", validated.callbackURL)
+		assert.Equal(t, "ACC001000003", validated.trigger.Account)
+		assert.Equal(t, "500.00", validated.trigger.AmountDecimal)
+	})
+
+	t.Run("reports every rejected field", func(t *testing.T) {
+		params := &TransferParams{
+			FromAccount: "ACC1",
+			Amount:      100,
+			Currency:    "USD",
+			Description: text(strings.Repeat("x", 101)),
+			ReferenceID: text(strings.Repeat("x", 51)),
+			CallbackURL: text("ftp://example.com"),
+			Trigger:     &TransferTrigger{AmountDecimal: "1.005"},
+		}
+
+		_, err := service.validateTransfer(context.Background(), params)
+
+		var validationErr *TransferValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []FieldViolation{
+			{Field: "from_account", Description: "from_account must be 12 characters"},
+			{Field: "to_account", Description: "to_account is required"},
+			{Field: "description", Description: "description must be at most 100 characters"},
+			{Field: "reference_id", Description: "reference_id must be at most 50 characters"},
+			{Field: "trigger.account", Description: "trigger.account is required"},
+			{Field: "callback_url", Description: "callback_url must be an absolute http or https URL"},
+			{Field: "trigger.amount_decimal", Description: "trigger amount: amount exceeds currency decimal places: 1.005 allows 2"},
+		}, validationErr.Violations)
+		assert.ErrorIs(t, err, ErrInvalidTransferRequest)
+		assert.ErrorIs(t, err, ErrInvalidCallbackURL)
+		assert.ErrorIs(t, err, ErrInvalidTransferTrigger)
+		assert.ErrorIs(t, err, ErrInvalidAmountScale)
+	})
+
+	t.Run("currency and amount scale", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			currency  string
+			amount    *string
+			wantField string
+			wantErr   error
+		}{
+			{name: "missing_currency", amount: text("1"), wantField: "currency", wantErr: ErrUnsupportedCurrency},
+			{name: "unknown_currency", currency: "XYZ", amount: text("1"), wantField: "currency", wantErr: ErrUnsupportedCurrency},
+			{name: "inactive_currency", currency: "BTC", amount: text("1"), wantField: "currency", wantErr: ErrUnsupportedCurrency},
+			{name: "fractional_yen", currency: "JPY", amount: text("10.50"), wantField: "amount_decimal", wantErr: ErrInvalidAmountScale},
+			{name: "missing_amount", currency: "USD", wantField: "amount", wantErr: ErrInvalidTransferAmount},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				params := validParams()
+				params.Currency, params.AmountDecimal = tt.currency, tt.amount
+
+				_, err := service.validateTransfer(context.Background(), params)
+
+				var validationErr *TransferValidationError
+				require.ErrorAs(t, err, &validationErr)
+				require.Len(t, validationErr.Violations, 1)
+				assert.Equal(t, tt.wantField, validationErr.Violations[0].Field)
+				assert.ErrorIs(t, err, tt.wantErr)
+			})
+		}
+	})
+
+	t.Run("same account", func(t *testing.T) {
+		params := validParams()
+		params.ToAccount = params.FromAccount
+
+		_, err := service.validateTransfer(context.Background(), params)
+
+		var validationErr *TransferValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []FieldViolation{{Field: "to_account", Description: "from_account and to_account must differ"}}, validationErr.Violations)
+	})
+
+	t.Run("to_alias", func(t *testing.T) {
+		params := validParams()
+		params.ToAccount, params.ToAlias = "", "+14155550101"
+
+		_, err := service.validateTransfer(context.Background(), params)
+		require.NoError(t, err)
+
+		params.ToAccount = "ACC001000002"
+		_, err = service.validateTransfer(context.Background(), params)
+
+		var validationErr *TransferValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []FieldViolation{{Field: "to_alias", Description: "set either to_account or to_alias, not both"}}, validationErr.Violations)
+	})
+
+	t.Run("tenant account number format", func(t *testing.T) {
+		params := validParams()
+		params.Tenant = "acme"
+		params.FromAccount, params.ToAccount = "ACM79927398713", "ACM79927398731"
+
+		_, err := service.validateTransfer(context.Background(), params)
+
+		var validationErr *TransferValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []FieldViolation{{Field: "to_account", Description: "to_account has an invalid check digit"}}, validationErr.Violations)
+		assert.ErrorIs(t, err, accountnumber.ErrInvalidAccountNumber)
+
+		params.ToAccount = "ACM12345678903"
+		_, err = service.validateTransfer(context.Background(), params)
+		assert.NoError(t, err)
+	})
+
+	t.Run("catalog unavailable", func(t *testing.T) {
+		unavailableService, unavailable, _ := newCurrencyTestService(t)
+		unavailable.Store(false)
+
+		_, err := unavailableService.validateTransfer(context.Background(), validParams())
+		assert.ErrorIs(t, err, ErrCurrencyCatalogUnavailable)
+
+		var validationErr *TransferValidationError
+		assert.False(t, errors.As(err, &validationErr))
+	})
+
+}
@@ -0,0 +1,105 @@
+package accountnumber
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	validator, err := NewValidator([]Format{
+		{Prefix: "ACC", MinLength: 12, MaxLength: 12},
+		{Tenant: "acme", Prefix: "ACM", MinLength: 14, MaxLength: 14, Checksum: ChecksumLuhn},
+		{Tenant: "globex", MinLength: 12, MaxLength: 12, Checksum: ChecksumMod97},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		tenant        string
+		accountNumber string
+		reason        string // Empty when the number is valid
+	}{
+		{"default format", "", "ACC000000001", ""},
+		{"tenant without a format uses the default", "initech", "ACC000000001", ""},
+		{"default too short", "", "ACC0001", "must be 12 characters"},
+		{"default wrong prefix", "", "XYZ000000001", "must start with ACC"},
+		{"luhn valid", "acme", "ACM79927398713", ""},
+		{"luhn mistyped digit", "acme", "ACM79927398714", "has an invalid check digit"},
+		{"luhn swapped digits", "acme", "ACM79927398731", "has an invalid check digit"},
+		{"luhn letters after the prefix", "acme", "ACM7992739871X", "has an invalid check digit"},
+		{"luhn default number", "acme", "ACC000000001", "must be 14 characters"},
+		{"mod97 valid", "globex", "123456789092", ""},
+		{"mod97 letters", "globex", "AB123456715Z", "has an invalid check digit"},
+		{"mod97 mistyped digit", "globex", "123456789192", "has an invalid check digit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.tenant, tt.accountNumber)
+			if tt.reason == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var formatErr *FormatError
+			require.True(t, errors.As(err, &formatErr), "got %v", err)
+			assert.Equal(t, tt.reason, formatErr.Reason)
+			assert.ErrorIs(t, err, ErrInvalidAccountNumber)
+		})
+	}
+}
+
+func TestValidateWithoutFormat(t *testing.T) {
+	var validator *Validator
+	assert.NoError(t, validator.Validate("", "anything"))
+
+	validator, err := NewValidator([]Format{{Tenant: "acme", Checksum: ChecksumLuhn}})
+	require.NoError(t, err)
+	assert.NoError(t, validator.Validate("", "anything"))
+	assert.Error(t, validator.Validate("acme", "anything"))
+}
+
+func TestNewValidatorRejectsInvalidFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		formats []Format
+	}{
+		{"duplicate tenant", []Format{{Tenant: "acme"}, {Tenant: "acme"}}},
+		{"unknown checksum", []Format{{Checksum: "crc32"}}},
+		{"negative length", []Format{{MinLength: -1}}},
+		{"max below min", []Format{{MinLength: 12, MaxLength: 10}}},
+		{"prefix fills the number", []Format{{Prefix: "ACC", MaxLength: 3}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewValidator(tt.formats)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRegisterChecksum(t *testing.T) {
+	RegisterChecksum("even", func(body string) bool { return (body[len(body)-1]-'0')%2 == 0 })
+
+	validator, err := NewValidator([]Format{{Checksum: "even"}})
+	require.NoError(t, err)
+
+	assert.NoError(t, validator.Validate("", "1234"))
+	assert.Error(t, validator.Validate("", "1235"))
+}
+
+func TestChecksums(t *testing.T) {
+	assert.True(t, Luhn("79927398713"))
+	assert.False(t, Luhn("79927398710"))
+	assert.False(t, Luhn("7"))
+
+	assert.True(t, Mod97("123456789092"))
+	assert.True(t, Mod97("AB123456715"))
+	assert.True(t, Mod97("ab123456715"))
+	assert.False(t, Mod97("AB123456716"))
+	assert.False(t, Mod97("12-3456789092"))
+}
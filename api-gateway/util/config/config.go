@@ -3,13 +3,28 @@ package config
 import (
 	"fmt"
 
+	"profile"
+
 	"github.com/spf13/viper"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	App       App       `mapstructure:"app"`
-	Flowngine Flowngine `mapstructure:"flowngine"`
+	App        App        `mapstructure:"app"`
+	Http       Http       `mapstructure:"http"`
+	Flowngine  Flowngine  `mapstructure:"flowngine"`
+	SvcBalance SvcBalance `mapstructure:"svc_balance"`
+	Receipt    Receipt    `mapstructure:"receipt"`
+	Storage    Storage    `mapstructure:"storage"`
+
+	PaymentRequests PaymentRequests `mapstructure:"payment_requests"`
+
+	StatusCache StatusCache `mapstructure:"status_cache"`
+
+	AccountNumbers     AccountNumbers     `mapstructure:"account_numbers"`
+	DuplicateDetection DuplicateDetection `mapstructure:"duplicate_detection"`
+
+	Profile profile.Profile `mapstructure:"-"` // From APP_PROFILE rather than config.json
 }
 
 // LoadConfig reads configuration from file or environment variables.
@@ -31,5 +46,10 @@ func LoadConfig(path string) (config Config, err error) {
 		return config, fmt.Errorf("failed to unmarshal configuration: %s", err)
 	}
 
+	config.Profile, err = profile.Load()
+	if err != nil {
+		return config, err
+	}
+
 	return
 }
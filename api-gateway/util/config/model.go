@@ -8,10 +8,164 @@ type App struct {
 	Port int    `mapstructure:"port"`
 }
 
+// Http config
+
+// Http controls cross-origin access, security headers, request body limits, compression, protocols and access
+// logging of the REST server
+type Http struct {
+	Cors              Cors        `mapstructure:"cors"`
+	AccessLog         AccessLog   `mapstructure:"access_log"`
+	HSTSMaxAgeSeconds int         `mapstructure:"hsts_max_age_seconds"` // Sent on HTTPS requests only; 0 disables Strict-Transport-Security
+	CSP               string      `mapstructure:"content_security_policy"`
+	MaxBodyBytes      int         `mapstructure:"max_body_bytes"`       // Default request body limit
+	MaxBatchBodyBytes int         `mapstructure:"max_batch_body_bytes"` // Override for batch endpoints such as pain.001 ingestion
+	Compression       Compression `mapstructure:"compression"`
+	H2C               bool        `mapstructure:"h2c"` // Also serve cleartext HTTP/2, for load balancers speaking HTTP/2 to the gateway
+}
+
+// Compression controls compression of response bodies
+type Compression struct {
+	Level    string `mapstructure:"level"`     // off, best_speed, default or best_compression; empty is off
+	MinBytes int    `mapstructure:"min_bytes"` // Smaller bodies are not compressed
+}
+
+// Cors is the cross-origin resource sharing policy
+type Cors struct {
+	AllowOrigins     string `mapstructure:"allow_origins"` // Comma-separated list, "*" allows any origin
+	AllowMethods     string `mapstructure:"allow_methods"`
+	AllowHeaders     string `mapstructure:"allow_headers"`
+	ExposeHeaders    string `mapstructure:"expose_headers"`
+	AllowCredentials bool   `mapstructure:"allow_credentials"` // Cannot be combined with a "*" origin
+	MaxAgeSeconds    int    `mapstructure:"max_age_seconds"`
+}
+
+// AccessLog controls sampling of the per-request access log
+type AccessLog struct {
+	SuccessSampleRate float64 `mapstructure:"success_sample_rate"` // Fraction of successful requests logged; errors are always logged
+	SlowThresholdMs   int     `mapstructure:"slow_threshold_ms"`   // Requests at least this slow are always logged; 0 disables it
+}
+
 // Flowngine config
 
 type Flowngine struct {
-	Name string `mapstructure:"name"`
-	Host string `mapstructure:"host"`
-	Port int    `mapstructure:"port"`
+	Name           string     `mapstructure:"name"`
+	Host           string     `mapstructure:"host"`
+	Port           int        `mapstructure:"port"`
+	TimeoutSeconds int        `mapstructure:"timeout_seconds"` // Deadline of calls made without one; 0 leaves them unbounded
+	Grpc           GrpcClient `mapstructure:"grpc"`
+
+	TaskQueueTrackHeader bool `mapstructure:"task_queue_track_header"` // Forward X-Task-Queue-Track of transfers, pinning them to a worker fleet
+}
+
+// GrpcClient config
+
+// GrpcKeepalive makes the client ping an idle connection so a connection dropped by a proxy or a restarted server is
+// noticed before a call is sent on it
+type GrpcKeepalive struct {
+	TimeSeconds         int  `mapstructure:"time_seconds"`          // Ping after this long without activity; 0 disables pings
+	TimeoutSeconds      int  `mapstructure:"timeout_seconds"`       // Close the connection when a ping is not answered in time; 0 is 20 seconds
+	PermitWithoutStream bool `mapstructure:"permit_without_stream"` // Ping while no call is in flight; the server must allow it
+}
+
+// GrpcRetry retries calls that failed with UNAVAILABLE, within a budget shared by all calls of the connection
+type GrpcRetry struct {
+	MaxAttempts       int     `mapstructure:"max_attempts"`       // Including the first; 0 or 1 disables retries, at most 5
+	InitialBackoffMs  int     `mapstructure:"initial_backoff_ms"` // 0 is 100
+	MaxBackoffMs      int     `mapstructure:"max_backoff_ms"`     // 0 is 2000
+	BackoffMultiplier float64 `mapstructure:"backoff_multiplier"` // 0 is 2
+	BudgetTokens      int     `mapstructure:"budget_tokens"`      // Failures drain one token each; retries stop below half; 0 is 10
+	BudgetTokenRatio  float64 `mapstructure:"budget_token_ratio"` // Tokens given back by each success; 0 is 0.1
+}
+
+type GrpcClient struct {
+	Keepalive             GrpcKeepalive `mapstructure:"keepalive"`
+	Retry                 GrpcRetry     `mapstructure:"retry"`
+	MaxRecvMsgBytes       int           `mapstructure:"max_recv_msg_bytes"`      // 0 is 4 MiB
+	MaxSendMsgBytes       int           `mapstructure:"max_send_msg_bytes"`      // 0 is unlimited
+	ConnectTimeoutSeconds int           `mapstructure:"connect_timeout_seconds"` // Deadline of each connection attempt; 0 is 20 seconds
+}
+
+// SvcBalance config
+
+type SvcBalance struct {
+	Name           string     `mapstructure:"name"`
+	Host           string     `mapstructure:"host"`
+	Port           int        `mapstructure:"port"`
+	TimeoutSeconds int        `mapstructure:"timeout_seconds"`
+	GrpcPort       int        `mapstructure:"grpc_port"` // Ops gRPC API behind the admin account reports; 0 disables them
+	Grpc           GrpcClient `mapstructure:"grpc"`
+}
+
+// Receipt config
+
+// Receipt controls how transfer receipts are signed and cached
+type Receipt struct {
+	SigningKey string `mapstructure:"signing_key"` // HMAC-SHA256 secret shared with receipt verifiers
+	KeyID      string `mapstructure:"key_id"`      // Identifies the signing key so it can be rotated
+	CacheSize  int    `mapstructure:"cache_size"`  // Maximum number of generated receipts kept in memory
+}
+
+// Storage config
+
+type S3Storage struct {
+	Endpoint        string `mapstructure:"endpoint"` // e.g. https://s3.eu-west-1.amazonaws.com or http://minio:9000
+	Region          string `mapstructure:"region"`   // Empty is us-east-1
+	Bucket          string `mapstructure:"bucket"`
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+}
+
+type Storage struct {
+	Backend  string    `mapstructure:"backend"`   // local or s3; empty disables upload archiving and receipt persistence
+	LocalDir string    `mapstructure:"local_dir"` // Root directory of the local backend
+	S3       S3Storage `mapstructure:"s3"`
+}
+
+// AccountNumbers config
+
+// AccountNumberFormat describes the account numbers of one tenant
+type AccountNumberFormat struct {
+	Tenant    string `mapstructure:"tenant"` // Empty for the default format
+	Prefix    string `mapstructure:"prefix"`
+	MinLength int    `mapstructure:"min_length"` // Including the prefix; 0 for no minimum
+	MaxLength int    `mapstructure:"max_length"` // Including the prefix; 0 for no maximum
+	Checksum  string `mapstructure:"checksum"`   // none, luhn or mod97
+}
+
+type AccountNumbers struct {
+	Formats []AccountNumberFormat `mapstructure:"formats"` // Empty keeps the 12-character account numbers of every tenant
+}
+
+// PaymentRequests config
+
+// PaymentRequests controls the signed payloads of QR-code payment requests
+type PaymentRequests struct {
+	SigningKey           string `mapstructure:"signing_key"`            // HMAC-SHA256 secret; payment requests are disabled without it
+	DefaultExpirySeconds int    `mapstructure:"default_expiry_seconds"` // Expiry of requests created without one; 0 is 15 minutes
+	MaxExpirySeconds     int    `mapstructure:"max_expiry_seconds"`     // Longest expiry a request may ask for; 0 is 24 hours
+}
+
+// DuplicateDetection config
+
+// DuplicateDetection flags transfers that repeat a recent transfer without a reference ID
+type DuplicateDetection struct {
+	Mode          string `mapstructure:"mode"`           // off, warn or confirm; empty is off
+	WindowSeconds int    `mapstructure:"window_seconds"` // How long a transfer is remembered; 0 disables detection
+}
+
+// StatusCache config
+
+type RedisStatusCache struct {
+	Addr      string `mapstructure:"addr"` // host:port
+	Password  string `mapstructure:"password"`
+	DB        int    `mapstructure:"db"`
+	KeyPrefix string `mapstructure:"key_prefix"`
+}
+
+// StatusCache keeps the status of finished transfers so repeated polling is not sent to FlowEngine
+type StatusCache struct {
+	Backend    string           `mapstructure:"backend"`     // memory or redis; empty disables the cache
+	TTLSeconds int              `mapstructure:"ttl_seconds"` // 0 is 24 hours
+	Capacity   int              `mapstructure:"capacity"`    // Entries kept by the memory backend; 0 is 10000
+	Redis      RedisStatusCache `mapstructure:"redis"`
 }
@@ -0,0 +1,31 @@
+// Package crash records recovered panics, so they are logged and counted instead of taking the process down.
+package crash
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+var recovered atomic.Int64
+
+// Report logs a recovered panic value with the stack trace of the panicking goroutine and counts it.
+// It must be called from the deferred function that recovered, while the stack still shows where the panic happened.
+// The returned error describes the panic without the stack, for passing on to callers.
+func Report(logger *logrus.Entry, value any) error {
+	recovered.Add(1)
+
+	logger.WithFields(logrus.Fields{
+		"panic": fmt.Sprint(value),
+		"stack": string(debug.Stack()),
+	}).Error("Recovered from panic")
+
+	return fmt.Errorf("panic: %v", value)
+}
+
+// Total returns the number of panics recovered since the process started
+func Total() int64 {
+	return recovered.Load()
+}
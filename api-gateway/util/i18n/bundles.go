@@ -0,0 +1,163 @@
+package i18n
+
+// bundles holds the translations of the human-facing error messages of the gateway, keyed by language and then by
+// the English message. Messages with request-specific parts, such as most validation errors, are not listed: the
+// error middleware shows the translated status text for them instead.
+var bundles = map[string]map[string]string{
+	Indonesian: {
+		// Status texts, used for messages without a translation
+		"Bad Request":              "Permintaan tidak valid",
+		"Unauthorized":             "Tidak terautentikasi",
+		"Forbidden":                "Akses ditolak",
+		"Not Found":                "Tidak ditemukan",
+		"Method Not Allowed":       "Metode tidak diizinkan",
+		"Request Timeout":          "Waktu permintaan habis",
+		"Conflict":                 "Konflik",
+		"Precondition Failed":      "Prasyarat tidak terpenuhi",
+		"Request Entity Too Large": "Permintaan terlalu besar",
+		"Unsupported Media Type":   "Jenis media tidak didukung",
+		"Unprocessable Entity":     "Permintaan tidak dapat diproses",
+		"Too Many Requests":        "Terlalu banyak permintaan",
+		"Internal Server Error":    "Kesalahan server internal",
+		"Not Implemented":          "Belum diimplementasikan",
+		"Bad Gateway":              "Gateway tidak valid",
+		"Service Unavailable":      "Layanan tidak tersedia",
+		"Gateway Timeout":          "Waktu gateway habis",
+
+		// Error middleware
+		"Internal server error":                   "Kesalahan server internal",
+		"Internal server error: no response sent": "Kesalahan server internal: tidak ada respons yang dikirim",
+		"Request timeout":                         "Waktu permintaan habis",
+		"Request was cancelled":                   "Permintaan dibatalkan",
+		"Invalid request parameters":              "Parameter permintaan tidak valid",
+		"Account not found":                       "Rekening tidak ditemukan",
+		"Account validation failed":               "Validasi rekening gagal",
+		"Not allowed for the account type":        "Tidak diizinkan untuk jenis rekening ini",
+		"Alias not found":                         "Alias tidak ditemukan",
+		"Insufficient funds":                      "Saldo tidak mencukupi",
+		"Invalid or unsupported currency":         "Mata uang tidak valid atau tidak didukung",
+		"Transaction processing failed":           "Pemrosesan transaksi gagal",
+		"Duplicate transaction":                   "Transaksi duplikat",
+		"Transfer not found":                      "Transfer tidak ditemukan",
+		"Transfer processing failed":              "Pemrosesan transfer gagal",
+		"Unauthorized access":                     "Akses tidak sah",
+		"Access forbidden":                        "Akses ditolak",
+		"Resource not found":                      "Sumber daya tidak ditemukan",
+		"Resource already exists":                 "Sumber daya sudah ada",
+		"Permission denied":                       "Izin ditolak",
+		"Authentication required":                 "Autentikasi diperlukan",
+		"Rate limit exceeded":                     "Batas permintaan terlampaui",
+		"Precondition failed":                     "Prasyarat tidak terpenuhi",
+		"Parameter out of range":                  "Parameter di luar rentang",
+		"Feature not implemented":                 "Fitur belum diimplementasikan",
+		"Service temporarily unavailable":         "Layanan sementara tidak tersedia",
+		"Data loss detected":                      "Kehilangan data terdeteksi",
+		"Unknown error occurred":                  "Terjadi kesalahan yang tidak diketahui",
+		"Referenced resource does not exist":      "Sumber daya yang dirujuk tidak ada",
+		"Required field is missing":               "Kolom wajib tidak diisi",
+		"Data constraint violation":               "Pelanggaran batasan data",
+		"Database configuration error":            "Kesalahan konfigurasi basis data",
+		"Database schema error":                   "Kesalahan skema basis data",
+		"Database connection failed":              "Koneksi basis data gagal",
+		"Database query timeout":                  "Waktu kueri basis data habis",
+		"Database error":                          "Kesalahan basis data",
+
+		// Handlers
+		"Invalid request body":                                       "Isi permintaan tidak valid",
+		"Invalid request format":                                     "Format permintaan tidak valid",
+		"approved_by is required":                                    "approved_by wajib diisi",
+		"Invalid pain.001 document":                                  "Dokumen pain.001 tidak valid",
+		"Unsupported receipt format":                                 "Format bukti transfer tidak didukung",
+		"Invalid locale, expected a BCP 47 tag such as de-DE":        "Locale tidak valid, gunakan tag BCP 47 seperti de-DE",
+		"request deadline exceeded before the transfer was accepted": "batas waktu permintaan terlampaui sebelum transfer diterima",
+		"Failed to execute transfer":                                 "Gagal menjalankan transfer",
+		"Failed to get transfer":                                     "Gagal mengambil transfer",
+		"Failed to approve transfer":                                 "Gagal menyetujui transfer",
+		"Failed to cancel transfer":                                  "Gagal membatalkan transfer",
+		"Failed to get transfer timeline":                            "Gagal mengambil linimasa transfer",
+		"Failed to upload transfers":                                 "Gagal mengunggah transfer",
+		"Failed to get transfer upload":                              "Gagal mengambil unggahan transfer",
+		"Failed to list compensations":                               "Gagal mengambil daftar kompensasi",
+		"Failed to get compensation stats":                           "Gagal mengambil statistik kompensasi",
+		"Failed to list admin audit":                                 "Gagal mengambil audit admin",
+		"Failed to get admin overview":                               "Gagal mengambil ringkasan admin",
+	},
+	Spanish: {
+		// Status texts, used for messages without a translation
+		"Bad Request":              "Solicitud incorrecta",
+		"Unauthorized":             "No autenticado",
+		"Forbidden":                "Prohibido",
+		"Not Found":                "No encontrado",
+		"Method Not Allowed":       "Método no permitido",
+		"Request Timeout":          "Tiempo de espera de la solicitud agotado",
+		"Conflict":                 "Conflicto",
+		"Precondition Failed":      "Precondición fallida",
+		"Request Entity Too Large": "Solicitud demasiado grande",
+		"Unsupported Media Type":   "Tipo de contenido no admitido",
+		"Unprocessable Entity":     "Solicitud no procesable",
+		"Too Many Requests":        "Demasiadas solicitudes",
+		"Internal Server Error":    "Error interno del servidor",
+		"Not Implemented":          "No implementado",
+		"Bad Gateway":              "Puerta de enlace incorrecta",
+		"Service Unavailable":      "Servicio no disponible",
+		"Gateway Timeout":          "Tiempo de espera de la puerta de enlace agotado",
+
+		// Error middleware
+		"Internal server error":                   "Error interno del servidor",
+		"Internal server error: no response sent": "Error interno del servidor: no se envió ninguna respuesta",
+		"Request timeout":                         "Tiempo de espera de la solicitud agotado",
+		"Request was cancelled":                   "La solicitud fue cancelada",
+		"Invalid request parameters":              "Parámetros de solicitud no válidos",
+		"Account not found":                       "Cuenta no encontrada",
+		"Account validation failed":               "La validación de la cuenta falló",
+		"Not allowed for the account type":        "No permitido para el tipo de cuenta",
+		"Alias not found":                         "Alias no encontrado",
+		"Insufficient funds":                      "Fondos insuficientes",
+		"Invalid or unsupported currency":         "Moneda no válida o no admitida",
+		"Transaction processing failed":           "El procesamiento de la transacción falló",
+		"Duplicate transaction":                   "Transacción duplicada",
+		"Transfer not found":                      "Transferencia no encontrada",
+		"Transfer processing failed":              "El procesamiento de la transferencia falló",
+		"Unauthorized access":                     "Acceso no autorizado",
+		"Access forbidden":                        "Acceso prohibido",
+		"Resource not found":                      "Recurso no encontrado",
+		"Resource already exists":                 "El recurso ya existe",
+		"Permission denied":                       "Permiso denegado",
+		"Authentication required":                 "Se requiere autenticación",
+		"Rate limit exceeded":                     "Límite de solicitudes excedido",
+		"Precondition failed":                     "Precondición fallida",
+		"Parameter out of range":                  "Parámetro fuera de rango",
+		"Feature not implemented":                 "Funcionalidad no implementada",
+		"Service temporarily unavailable":         "Servicio temporalmente no disponible",
+		"Data loss detected":                      "Se detectó pérdida de datos",
+		"Unknown error occurred":                  "Ocurrió un error desconocido",
+		"Referenced resource does not exist":      "El recurso referenciado no existe",
+		"Required field is missing":               "Falta un campo obligatorio",
+		"Data constraint violation":               "Violación de una restricción de datos",
+		"Database configuration error":            "Error de configuración de la base de datos",
+		"Database schema error":                   "Error de esquema de la base de datos",
+		"Database connection failed":              "La conexión a la base de datos falló",
+		"Database query timeout":                  "Tiempo de espera de la consulta agotado",
+		"Database error":                          "Error de base de datos",
+
+		// Handlers
+		"Invalid request body":                                       "Cuerpo de la solicitud no válido",
+		"Invalid request format":                                     "Formato de la solicitud no válido",
+		"approved_by is required":                                    "approved_by es obligatorio",
+		"Invalid pain.001 document":                                  "Documento pain.001 no válido",
+		"Unsupported receipt format":                                 "Formato de comprobante no admitido",
+		"Invalid locale, expected a BCP 47 tag such as de-DE":        "Configuración regional no válida, se espera una etiqueta BCP 47 como de-DE",
+		"request deadline exceeded before the transfer was accepted": "se agotó el plazo de la solicitud antes de que se aceptara la transferencia",
+		"Failed to execute transfer":                                 "No se pudo ejecutar la transferencia",
+		"Failed to get transfer":                                     "No se pudo obtener la transferencia",
+		"Failed to approve transfer":                                 "No se pudo aprobar la transferencia",
+		"Failed to cancel transfer":                                  "No se pudo cancelar la transferencia",
+		"Failed to get transfer timeline":                            "No se pudo obtener la cronología de la transferencia",
+		"Failed to upload transfers":                                 "No se pudieron cargar las transferencias",
+		"Failed to get transfer upload":                              "No se pudo obtener la carga de transferencias",
+		"Failed to list compensations":                               "No se pudieron listar las compensaciones",
+		"Failed to get compensation stats":                           "No se pudieron obtener las estadísticas de compensaciones",
+		"Failed to list admin audit":                                 "No se pudo listar la auditoría de administración",
+		"Failed to get admin overview":                               "No se pudo obtener el resumen de administración",
+	},
+}
@@ -0,0 +1,52 @@
+package i18n
+
+import (
+	"golang.org/x/text/language"
+)
+
+// Languages the gateway answers in. English is the source language of every message; the others have a bundle.
+const (
+	English    = "en"
+	Indonesian = "id"
+	Spanish    = "es"
+)
+
+// supported lists the languages in matcher order; the first one is the fallback
+var supported = []string{English, Indonesian, Spanish}
+
+var matcher = language.NewMatcher([]language.Tag{language.English, language.Indonesian, language.Spanish})
+
+// Negotiate picks the supported language that best matches an Accept-Language header, e.g. "id-ID,id;q=0.9,en;q=0.8".
+// Empty, malformed or unsupported headers fall back to English.
+func Negotiate(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return English
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return English
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return English
+	}
+
+	return supported[index]
+}
+
+// Translate returns the message in lang. Messages are looked up by their English text; ok is false, and the message
+// is returned unchanged, when the bundle of lang has no translation for it.
+func Translate(lang, message string) (translated string, ok bool) {
+	if lang == English {
+		return message, true
+	}
+
+	translated, ok = bundles[lang][message]
+	if !ok {
+		return message, false
+	}
+
+	return translated, true
+}
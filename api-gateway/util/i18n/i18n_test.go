@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{"", English},
+		{"id", Indonesian},
+		{"id-ID,id;q=0.9,en;q=0.8", Indonesian},
+		{"es-MX", Spanish},
+		{"fr-FR,es;q=0.5", Spanish},
+		{"en-GB,es;q=0.5", English},
+		{"fr-FR", English},
+		{"*", English},
+		{"not a language;;", English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			assert.Equal(t, tt.want, Negotiate(tt.acceptLanguage))
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	translated, ok := Translate(Spanish, "Insufficient funds")
+	assert.True(t, ok)
+	assert.Equal(t, "Fondos insuficientes", translated)
+
+	translated, ok = Translate(English, "Insufficient funds")
+	assert.True(t, ok)
+	assert.Equal(t, "Insufficient funds", translated)
+
+	translated, ok = Translate(Indonesian, "from_account must be 12 characters")
+	assert.False(t, ok)
+	assert.Equal(t, "from_account must be 12 characters", translated)
+}
+
+func TestBundlesAreComplete(t *testing.T) {
+	// Every bundle translates the same messages, so no language silently falls back more often than the others
+	for lang, bundle := range bundles {
+		for other, otherBundle := range bundles {
+			for message := range otherBundle {
+				_, ok := bundle[message]
+				assert.True(t, ok, "%s bundle has no translation of %q, which the %s bundle has", lang, message, other)
+			}
+		}
+	}
+}
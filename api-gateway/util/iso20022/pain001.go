@@ -0,0 +1,165 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Pain001Document is the root of an ISO 20022 CustomerCreditTransferInitiation (pain.001) message.
+// Only the elements needed to drive transfers are mapped; everything else is ignored by the decoder.
+type Pain001Document struct {
+	XMLName                          xml.Name                         `xml:"Document"`
+	CustomerCreditTransferInitiation CustomerCreditTransferInitiation `xml:"CstmrCdtTrfInitn"`
+}
+
+// CustomerCreditTransferInitiation holds the group header and payment information blocks
+type CustomerCreditTransferInitiation struct {
+	GroupHeader        GroupHeader          `xml:"GrpHdr"`
+	PaymentInformation []PaymentInformation `xml:"PmtInf"`
+}
+
+// GroupHeader identifies the message and carries its control totals
+type GroupHeader struct {
+	MessageID            string `xml:"MsgId"`
+	CreationDateTime     string `xml:"CreDtTm"`
+	NumberOfTransactions string `xml:"NbOfTxs"`
+	ControlSum           string `xml:"CtrlSum"`
+	InitiatingPartyName  string `xml:"InitgPty>Nm"`
+}
+
+// PaymentInformation groups credit transfers sharing the same debtor account
+type PaymentInformation struct {
+	PaymentInformationID string                      `xml:"PmtInfId"`
+	PaymentMethod        string                      `xml:"PmtMtd"`
+	DebtorName           string                      `xml:"Dbtr>Nm"`
+	DebtorAccount        Account                     `xml:"DbtrAcct"`
+	Transactions         []CreditTransferTransaction `xml:"CdtTrfTxInf"`
+}
+
+// CreditTransferTransaction is a single credit transfer instruction
+type CreditTransferTransaction struct {
+	InstructionID         string           `xml:"PmtId>InstrId"`
+	EndToEndID            string           `xml:"PmtId>EndToEndId"`
+	InstructedAmount      InstructedAmount `xml:"Amt>InstdAmt"`
+	CreditorName          string           `xml:"Cdtr>Nm"`
+	CreditorAccount       Account          `xml:"CdtrAcct"`
+	RemittanceInformation string           `xml:"RmtInf>Ustrd"`
+}
+
+// InstructedAmount is an amount with its currency attribute
+type InstructedAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// Account identifies an account either by IBAN or by a proprietary identifier
+type Account struct {
+	IBAN  string `xml:"Id>IBAN"`
+	Other string `xml:"Id>Othr>Id"`
+}
+
+// Identifier returns the account identifier, preferring the proprietary ID used by this system
+func (a Account) Identifier() string {
+	if a.Other != "" {
+		return strings.TrimSpace(a.Other)
+	}
+
+	return strings.TrimSpace(a.IBAN)
+}
+
+// ParsePain001 decodes and validates a pain.001 message
+func ParsePain001(data []byte) (*Pain001Document, error) {
+	var document Pain001Document
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("malformed pain.001 XML: %w", err)
+	}
+
+	if err := ValidatePain001(&document); err != nil {
+		return &document, err
+	}
+
+	return &document, nil
+}
+
+// ValidatePain001 checks the group header control totals against the transactions in the message
+func ValidatePain001(document *Pain001Document) error {
+	initiation := document.CustomerCreditTransferInitiation
+
+	if initiation.GroupHeader.MessageID == "" {
+		return fmt.Errorf("GrpHdr/MsgId is required")
+	}
+
+	if len(initiation.PaymentInformation) == 0 {
+		return fmt.Errorf("at least one PmtInf block is required")
+	}
+
+	var count int
+	var sum int64
+	for _, payment := range initiation.PaymentInformation {
+		for _, tx := range payment.Transactions {
+			minor, err := ParseAmountMinorUnits(tx.InstructedAmount.Value)
+			if err != nil {
+				return fmt.Errorf("invalid amount for EndToEndId %s: %w", tx.EndToEndID, err)
+			}
+			count++
+			sum += minor
+		}
+	}
+
+	if count == 0 {
+		return fmt.Errorf("message contains no CdtTrfTxInf entries")
+	}
+
+	if declared := strings.TrimSpace(initiation.GroupHeader.NumberOfTransactions); declared != "" {
+		n, err := strconv.Atoi(declared)
+		if err != nil || n != count {
+			return fmt.Errorf("GrpHdr/NbOfTxs is %s but message contains %d transactions", declared, count)
+		}
+	}
+
+	if declared := strings.TrimSpace(initiation.GroupHeader.ControlSum); declared != "" {
+		controlSum, err := ParseAmountMinorUnits(declared)
+		if err != nil || controlSum != sum {
+			return fmt.Errorf("GrpHdr/CtrlSum is %s but transactions add up to %s", declared, FormatMinorUnits(sum))
+		}
+	}
+
+	return nil
+}
+
+// ParseAmountMinorUnits converts an ISO 20022 decimal amount (e.g. "1250.50") to minor units.
+// At most two fraction digits are accepted, matching the minor-unit convention used by the gateway.
+func ParseAmountMinorUnits(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("amount is empty")
+	}
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if len(fraction) > 2 {
+		return 0, fmt.Errorf("amount %s has more than 2 fraction digits", value)
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	major, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %s", value)
+	}
+	minor, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %s", value)
+	}
+
+	if major < 0 || (major == 0 && minor == 0) {
+		return 0, fmt.Errorf("amount must be positive")
+	}
+
+	return major*100 + minor, nil
+}
+
+// FormatMinorUnits renders minor units as an ISO 20022 decimal amount
+func FormatMinorUnits(amount int64) string {
+	return fmt.Sprintf("%d.%02d", amount/100, amount%100)
+}
@@ -0,0 +1,155 @@
+package iso20022
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const samplePain001 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09">
+  <CstmrCdtTrfInitn>
+    <GrpHdr>
+      <MsgId>MSG-001</MsgId>
+      <CreDtTm>2026-10-15T09:00:00Z</CreDtTm>
+      <NbOfTxs>2</NbOfTxs>
+      <CtrlSum>125.50</CtrlSum>
+      <InitgPty><Nm>ACME Corp</Nm></InitgPty>
+    </GrpHdr>
+    <PmtInf>
+      <PmtInfId>PMT-001</PmtInfId>
+      <PmtMtd>TRF</PmtMtd>
+      <Dbtr><Nm>ACME Corp</Nm></Dbtr>
+      <DbtrAcct><Id><Othr><Id>ACC001000001</Id></Othr></Id></DbtrAcct>
+      <CdtTrfTxInf>
+        <PmtId><InstrId>INS-1</InstrId><EndToEndId>E2E-1</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">100.00</InstdAmt></Amt>
+        <Cdtr><Nm>Jane</Nm></Cdtr>
+        <CdtrAcct><Id><Othr><Id>ACC001000002</Id></Othr></Id></CdtrAcct>
+        <RmtInf><Ustrd>Invoice 42</Ustrd></RmtInf>
+      </CdtTrfTxInf>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E-2</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">25.5</InstdAmt></Amt>
+        <CdtrAcct><Id><IBAN>GB33BUKB20201555555555</IBAN></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>`
+
+func TestParsePain001(t *testing.T) {
+	t.Parallel()
+
+	document, err := ParsePain001([]byte(samplePain001))
+	require.NoError(t, err)
+
+	initiation := document.CustomerCreditTransferInitiation
+	assert.Equal(t, "MSG-001", initiation.GroupHeader.MessageID)
+	require.Len(t, initiation.PaymentInformation, 1)
+
+	payment := initiation.PaymentInformation[0]
+	assert.Equal(t, "ACC001000001", payment.DebtorAccount.Identifier())
+	require.Len(t, payment.Transactions, 2)
+	assert.Equal(t, "ACC001000002", payment.Transactions[0].CreditorAccount.Identifier())
+	assert.Equal(t, "Invoice 42", payment.Transactions[0].RemittanceInformation)
+	assert.Equal(t, "USD", payment.Transactions[0].InstructedAmount.Currency)
+	assert.Equal(t, "GB33BUKB20201555555555", payment.Transactions[1].CreditorAccount.Identifier())
+}
+
+func TestParsePain001_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParsePain001([]byte("<Document><CstmrCdtTrfInitn>"))
+	assert.ErrorContains(t, err, "malformed pain.001 XML")
+
+	document, err := ParsePain001([]byte(`<Document><CstmrCdtTrfInitn><GrpHdr><MsgId>M</MsgId><NbOfTxs>3</NbOfTxs></GrpHdr>
+		<PmtInf><CdtTrfTxInf><Amt><InstdAmt Ccy="USD">1.00</InstdAmt></Amt></CdtTrfTxInf></PmtInf></CstmrCdtTrfInitn></Document>`))
+	assert.ErrorContains(t, err, "NbOfTxs is 3")
+	require.NotNil(t, document)
+	assert.Equal(t, "M", document.CustomerCreditTransferInitiation.GroupHeader.MessageID)
+}
+
+func TestParseAmountMinorUnits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value    string
+		expected int64
+		wantErr  bool
+	}{
+		{"100", 10000, false},
+		{"100.5", 10050, false},
+		{"0.01", 1, false},
+		{" 12.34 ", 1234, false},
+		{"1.234", 0, true},
+		{"0.00", 0, true},
+		{"-5.00", 0, true},
+		{"abc", 0, true},
+		{"", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			amount, err := ParseAmountMinorUnits(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, amount)
+			assert.Equal(t, tt.expected, mustParse(t, FormatMinorUnits(amount)))
+		})
+	}
+}
+
+func TestAggregateStatus(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, StatusAcceptedSettlementCompleted, AggregateStatus([]string{StatusAcceptedSettlementCompleted}))
+	assert.Equal(t, StatusAcceptedTechnicalValidation, AggregateStatus([]string{StatusAcceptedSettlementCompleted, StatusAcceptedTechnicalValidation, StatusAcceptedSettlementInProcess}))
+	assert.Equal(t, StatusPartiallyAccepted, AggregateStatus([]string{StatusRejected, StatusAcceptedSettlementInProcess}))
+	assert.Equal(t, StatusRejected, AggregateStatus([]string{StatusRejected, StatusRejected}))
+	assert.Equal(t, StatusRejected, AggregateStatus(nil))
+}
+
+func TestPain002Document(t *testing.T) {
+	t.Parallel()
+
+	report := NewPain002Document("RPT-1", GroupHeader{MessageID: "MSG-001", NumberOfTransactions: "2"}, time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC))
+	report.AddPaymentInformation(OriginalPaymentInformation{
+		OriginalPaymentInformationID: "PMT-001",
+		Transactions: []TransactionInfoStatus{
+			{OriginalEndToEndID: "E2E-1", TransactionStatus: StatusAcceptedSettlementInProcess},
+			{OriginalEndToEndID: "E2E-2", TransactionStatus: StatusRejected, StatusReasonInformation: []StatusReasonInfo{{AdditionalInformation: "account not found"}}},
+		},
+	})
+	report.Finalize()
+
+	body, err := report.Marshal()
+	require.NoError(t, err)
+
+	xmlBody := string(body)
+	assert.Contains(t, xmlBody, `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.002.001.10">`)
+	assert.Contains(t, xmlBody, "<OrgnlMsgId>MSG-001</OrgnlMsgId>")
+	assert.Contains(t, xmlBody, "<GrpSts>PART</GrpSts>")
+	assert.Contains(t, xmlBody, "<PmtInfSts>PART</PmtInfSts>")
+	assert.Contains(t, xmlBody, "<AddtlInf>account not found</AddtlInf>")
+	assert.Contains(t, xmlBody, "<CreDtTm>2026-10-15T09:00:00Z</CreDtTm>")
+
+	rejected := NewPain002Document("RPT-2", GroupHeader{MessageID: "MSG-002"}, time.Now())
+	rejected.Reject("GrpHdr/CtrlSum mismatch")
+	rejected.Finalize()
+	assert.Equal(t, StatusRejected, rejected.CustomerPaymentStatusReport.OriginalGroupInformation.GroupStatus)
+}
+
+func mustParse(t *testing.T, value string) int64 {
+	t.Helper()
+
+	amount, err := ParseAmountMinorUnits(value)
+	require.NoError(t, err)
+
+	return amount
+}
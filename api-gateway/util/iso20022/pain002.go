@@ -0,0 +1,182 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Pain002Namespace is the XML namespace of the status report version produced by the gateway
+const Pain002Namespace = "urn:iso:std:iso:20022:tech:xsd:pain.002.001.10"
+
+// Pain001MessageNameID is the message name reported back as OrgnlMsgNmId
+const Pain001MessageNameID = "pain.001.001.09"
+
+// External payment status codes (ExternalPaymentGroupStatus1Code / ExternalPaymentTransactionStatus1Code)
+const (
+	StatusAcceptedTechnicalValidation = "ACTC"
+	StatusAcceptedSettlementInProcess = "ACSP"
+	StatusAcceptedSettlementCompleted = "ACSC"
+	StatusPartiallyAccepted           = "PART"
+	StatusPending                     = "PDNG"
+	StatusRejected                    = "RJCT"
+)
+
+// Pain002Document is the root of an ISO 20022 CustomerPaymentStatusReport (pain.002) message
+type Pain002Document struct {
+	XMLName                     xml.Name                    `xml:"Document"`
+	Namespace                   string                      `xml:"xmlns,attr"`
+	CustomerPaymentStatusReport CustomerPaymentStatusReport `xml:"CstmrPmtStsRpt"`
+}
+
+// CustomerPaymentStatusReport holds the report header and the status of the original message
+type CustomerPaymentStatusReport struct {
+	GroupHeader                    StatusGroupHeader            `xml:"GrpHdr"`
+	OriginalGroupInformation       OriginalGroupInformation     `xml:"OrgnlGrpInfAndSts"`
+	OriginalPaymentInformationList []OriginalPaymentInformation `xml:"OrgnlPmtInfAndSts,omitempty"`
+}
+
+// StatusGroupHeader identifies the status report
+type StatusGroupHeader struct {
+	MessageID        string `xml:"MsgId"`
+	CreationDateTime string `xml:"CreDtTm"`
+}
+
+// OriginalGroupInformation references the original pain.001 message and carries its overall status
+type OriginalGroupInformation struct {
+	OriginalMessageID            string             `xml:"OrgnlMsgId"`
+	OriginalMessageNameID        string             `xml:"OrgnlMsgNmId"`
+	OriginalNumberOfTransactions string             `xml:"OrgnlNbOfTxs,omitempty"`
+	GroupStatus                  string             `xml:"GrpSts"`
+	StatusReasonInformation      []StatusReasonInfo `xml:"StsRsnInf,omitempty"`
+}
+
+// OriginalPaymentInformation carries the status of one original PmtInf block
+type OriginalPaymentInformation struct {
+	OriginalPaymentInformationID string                  `xml:"OrgnlPmtInfId"`
+	PaymentInformationStatus     string                  `xml:"PmtInfSts,omitempty"`
+	Transactions                 []TransactionInfoStatus `xml:"TxInfAndSts"`
+}
+
+// TransactionInfoStatus carries the status of one original credit transfer
+type TransactionInfoStatus struct {
+	StatusID                string             `xml:"StsId,omitempty"`
+	OriginalInstructionID   string             `xml:"OrgnlInstrId,omitempty"`
+	OriginalEndToEndID      string             `xml:"OrgnlEndToEndId"`
+	TransactionStatus       string             `xml:"TxSts"`
+	StatusReasonInformation []StatusReasonInfo `xml:"StsRsnInf,omitempty"`
+}
+
+// StatusReasonInfo explains a rejection in free text
+type StatusReasonInfo struct {
+	AdditionalInformation string `xml:"AddtlInf"`
+}
+
+// NewPain002Document creates an empty status report for the given original message
+func NewPain002Document(messageID string, original GroupHeader, createdAt time.Time) *Pain002Document {
+	return &Pain002Document{
+		Namespace: Pain002Namespace,
+		CustomerPaymentStatusReport: CustomerPaymentStatusReport{
+			GroupHeader: StatusGroupHeader{
+				MessageID:        messageID,
+				CreationDateTime: createdAt.UTC().Format(time.RFC3339),
+			},
+			OriginalGroupInformation: OriginalGroupInformation{
+				OriginalMessageID:            original.MessageID,
+				OriginalMessageNameID:        Pain001MessageNameID,
+				OriginalNumberOfTransactions: original.NumberOfTransactions,
+			},
+		},
+	}
+}
+
+// Reject marks the whole original message as rejected with the given reason
+func (document *Pain002Document) Reject(reason string) {
+	group := &document.CustomerPaymentStatusReport.OriginalGroupInformation
+	group.GroupStatus = StatusRejected
+	group.StatusReasonInformation = []StatusReasonInfo{{AdditionalInformation: reason}}
+}
+
+// AddPaymentInformation appends a payment block and derives its status from its transactions
+func (document *Pain002Document) AddPaymentInformation(payment OriginalPaymentInformation) {
+	payment.PaymentInformationStatus = AggregateStatus(transactionStatuses(payment.Transactions))
+	document.CustomerPaymentStatusReport.OriginalPaymentInformationList = append(document.CustomerPaymentStatusReport.OriginalPaymentInformationList, payment)
+}
+
+// Finalize derives the group status from every reported transaction
+func (document *Pain002Document) Finalize() {
+	report := &document.CustomerPaymentStatusReport
+	if report.OriginalGroupInformation.GroupStatus == StatusRejected {
+		return
+	}
+
+	var statuses []string
+	for _, payment := range report.OriginalPaymentInformationList {
+		statuses = append(statuses, transactionStatuses(payment.Transactions)...)
+	}
+
+	report.OriginalGroupInformation.GroupStatus = AggregateStatus(statuses)
+}
+
+// Marshal renders the status report with an XML declaration
+func (document *Pain002Document) Marshal() ([]byte, error) {
+	body, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+// AggregateStatus collapses transaction statuses into a single group or payment status:
+// all rejected is RJCT, a mix of rejected and accepted is PART, and otherwise the least advanced accepted status wins.
+func AggregateStatus(statuses []string) string {
+	if len(statuses) == 0 {
+		return StatusRejected
+	}
+
+	var rejected int
+	aggregate := StatusAcceptedSettlementCompleted
+	for _, status := range statuses {
+		switch status {
+		case StatusRejected:
+			rejected++
+		case StatusAcceptedSettlementCompleted:
+		default:
+			if statusRank(status) < statusRank(aggregate) {
+				aggregate = status
+			}
+		}
+	}
+
+	switch rejected {
+	case 0:
+		return aggregate
+	case len(statuses):
+		return StatusRejected
+	default:
+		return StatusPartiallyAccepted
+	}
+}
+
+// statusRank orders accepted statuses by how far the payment has progressed
+func statusRank(status string) int {
+	switch status {
+	case StatusPending:
+		return 0
+	case StatusAcceptedTechnicalValidation:
+		return 1
+	case StatusAcceptedSettlementInProcess:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func transactionStatuses(transactions []TransactionInfoStatus) []string {
+	statuses := make([]string, 0, len(transactions))
+	for _, tx := range transactions {
+		statuses = append(statuses, tx.TransactionStatus)
+	}
+
+	return statuses
+}
@@ -0,0 +1,77 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	// ErrInvalidAmount is returned when a decimal amount is malformed, not positive or out of range
+	ErrInvalidAmount = errors.New("invalid amount")
+	// ErrAmountScale is returned when a decimal amount is finer than the currency's minor unit, e.g. "10.50" JPY
+	ErrAmountScale = errors.New("amount exceeds currency decimal places")
+)
+
+// ParseMinorUnits converts a positive major-unit decimal string (e.g. "100.50") to minor units of a currency
+// with the given number of decimal places. Trailing zeros beyond the currency's decimal places are accepted,
+// any other extra digit is rejected rather than rounded.
+func ParseMinorUnits(value string, decimalPlaces int) (int64, error) {
+	whole, fraction, hasPoint := strings.Cut(value, ".")
+	if whole == "" || (hasPoint && fraction == "") || !isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("%w: %q is not a decimal number", ErrInvalidAmount, value)
+	}
+
+	if extra := strings.TrimRight(fraction, "0"); len(extra) > decimalPlaces {
+		return 0, fmt.Errorf("%w: %s allows %d", ErrAmountScale, value, decimalPlaces)
+	}
+	if len(fraction) > decimalPlaces {
+		fraction = fraction[:decimalPlaces]
+	}
+	fraction += strings.Repeat("0", decimalPlaces-len(fraction))
+
+	var minorUnits int64
+	for _, digit := range whole + fraction {
+		if minorUnits > (math.MaxInt64-int64(digit-'0'))/10 {
+			return 0, fmt.Errorf("%w: %s is out of range", ErrInvalidAmount, value)
+		}
+		minorUnits = minorUnits*10 + int64(digit-'0')
+	}
+
+	if minorUnits == 0 {
+		return 0, fmt.Errorf("%w: amount must be positive", ErrInvalidAmount)
+	}
+
+	return minorUnits, nil
+}
+
+// FormatMinorUnits renders minor units as a major-unit decimal string with the currency's decimal places,
+// e.g. 10050 with 2 places -> "100.50" and 1050 with 0 places -> "1050"
+func FormatMinorUnits(amount int64, decimalPlaces int) string {
+	sign := ""
+	magnitude := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		magnitude = -magnitude
+	}
+
+	digits := fmt.Sprintf("%0*d", decimalPlaces+1, magnitude)
+	if decimalPlaces == 0 {
+		return sign + digits
+	}
+
+	point := len(digits) - decimalPlaces
+
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
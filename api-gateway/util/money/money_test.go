@@ -0,0 +1,91 @@
+package money
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMinorUnits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value         string
+		decimalPlaces int
+		expected      int64
+		wantErr       error
+	}{
+		{"100.50", 2, 10050, nil},
+		{"100.5", 2, 10050, nil},
+		{"100", 2, 10000, nil},
+		{"0.01", 2, 1, nil},
+		{"100.500", 2, 10050, nil},
+		{"1050", 0, 1050, nil},
+		{"1050.00", 0, 1050, nil},
+		{"0.00000001", 8, 1, nil},
+		{"100.505", 2, 0, ErrAmountScale},
+		{"10.50", 0, 0, ErrAmountScale},
+		{"0", 2, 0, ErrInvalidAmount},
+		{"0.00", 2, 0, ErrInvalidAmount},
+		{"-5.00", 2, 0, ErrInvalidAmount},
+		{"+5.00", 2, 0, ErrInvalidAmount},
+		{"1e2", 2, 0, ErrInvalidAmount},
+		{".5", 2, 0, ErrInvalidAmount},
+		{"5.", 2, 0, ErrInvalidAmount},
+		{" 5", 2, 0, ErrInvalidAmount},
+		{"", 2, 0, ErrInvalidAmount},
+		{"92233720368547758.07", 2, math.MaxInt64, nil},
+		{"92233720368547758.08", 2, 0, ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			amount, err := ParseMinorUnits(tt.value, tt.decimalPlaces)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, amount)
+		})
+	}
+}
+
+func TestFormatMinorUnits(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "100.50", FormatMinorUnits(10050, 2))
+	assert.Equal(t, "0.01", FormatMinorUnits(1, 2))
+	assert.Equal(t, "-0.05", FormatMinorUnits(-5, 2))
+	assert.Equal(t, "1050", FormatMinorUnits(1050, 0))
+	assert.Equal(t, "0.00000001", FormatMinorUnits(1, 8))
+	assert.Equal(t, "-92233720368547758.08", FormatMinorUnits(math.MinInt64, 2))
+}
+
+func FuzzMinorUnitsRoundTrip(f *testing.F) {
+	for _, seed := range []int64{1, 99, 10050, math.MaxInt64} {
+		f.Add(seed, uint8(2))
+	}
+	f.Add(int64(1050), uint8(0))
+
+	f.Fuzz(func(t *testing.T, amount int64, places uint8) {
+		if amount <= 0 {
+			return
+		}
+		decimalPlaces := int(places % 9)
+
+		formatted := FormatMinorUnits(amount, decimalPlaces)
+		parsed, err := ParseMinorUnits(formatted, decimalPlaces)
+		if err != nil {
+			t.Fatalf("ParseMinorUnits(%q, %d) error = %v", formatted, decimalPlaces, err)
+		}
+		if parsed != amount {
+			t.Errorf("ParseMinorUnits(FormatMinorUnits(%d, %d)) = %d", amount, decimalPlaces, parsed)
+		}
+	})
+}
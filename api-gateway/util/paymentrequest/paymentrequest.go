@@ -0,0 +1,91 @@
+package paymentrequest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Prefix starts every payment request payload, so scanners can tell payment requests from other QR codes and
+// the format can be versioned
+const Prefix = "FLOWPAY1"
+
+var (
+	// ErrInvalidPayload is returned for payloads that are not a payment request or whose signature does not match
+	ErrInvalidPayload = errors.New("invalid payment request")
+	// ErrExpired is returned for payment requests scanned after their expiry
+	ErrExpired = errors.New("payment request expired")
+)
+
+// PaymentRequest is what a payee asks to be paid, carried in a QR code as a signed payload
+type PaymentRequest struct {
+	ID          string    `json:"id"`
+	ToAccount   string    `json:"to_account"`
+	Amount      string    `json:"amount"` // Major units with the currency's decimal places, e.g. "100.50"
+	Currency    string    `json:"currency"`
+	Description string    `json:"description,omitempty"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// Encode signs the payment request with the given key and returns its payload: the prefix, the request JSON and
+// its HMAC-SHA256, the latter two base64url-encoded and separated by dots. The payload is the text of the QR code.
+func (request PaymentRequest) Encode(key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", fmt.Errorf("payment request signing key is not configured")
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal payment request: %w", err)
+	}
+
+	signed := Prefix + "." + base64.RawURLEncoding.EncodeToString(body)
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac(key, signed)), nil
+}
+
+// Decode verifies the signature of a payload and returns its payment request. Requests past their expiry at now
+// are returned along with ErrExpired.
+func Decode(payload string, key []byte, now time.Time) (*PaymentRequest, error) {
+	payload = strings.TrimSpace(payload)
+
+	separator := strings.LastIndexByte(payload, '.')
+	if separator < 0 || !strings.HasPrefix(payload, Prefix+".") {
+		return nil, ErrInvalidPayload
+	}
+	signed, signature := payload[:separator], payload[separator+1:]
+
+	expected, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac(key, signed), expected) {
+		return nil, ErrInvalidPayload
+	}
+
+	body, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(signed, Prefix+"."))
+	if err != nil {
+		return nil, ErrInvalidPayload
+	}
+
+	var request PaymentRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, ErrInvalidPayload
+	}
+
+	if !now.Before(request.ExpiresAt) {
+		return &request, fmt.Errorf("%w at %s", ErrExpired, request.ExpiresAt.Format(time.RFC3339))
+	}
+
+	return &request, nil
+}
+
+// mac computes the HMAC-SHA256 of the signed part of a payload
+func mac(key []byte, signed string) []byte {
+	hash := hmac.New(sha256.New, key)
+	hash.Write([]byte(signed))
+
+	return hash.Sum(nil)
+}
@@ -0,0 +1,77 @@
+package paymentrequest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPaymentRequest() PaymentRequest {
+	return PaymentRequest{
+		ID:          "3f2c1a9e-7b4d-4c1e-9a55-2d8e6f0b1c7a",
+		ToAccount:   "ACC001000002",
+		Amount:      "12.50",
+		Currency:    "USD",
+		Description: "Coffee beans",
+		ExpiresAt:   time.Date(2025, 3, 14, 10, 15, 0, 0, time.UTC),
+	}
+}
+
+func TestEncodeAndDecode(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("test-signing-key")
+	now := time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	payload, err := testPaymentRequest().Encode(key)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(payload, Prefix+"."))
+
+	request, err := Decode(payload, key, now)
+	require.NoError(t, err)
+	assert.Equal(t, testPaymentRequest(), *request)
+
+	_, err = Decode(payload, []byte("other-key"), now)
+	assert.ErrorIs(t, err, ErrInvalidPayload)
+
+	request, err = Decode(payload, key, now.Add(15*time.Minute))
+	assert.ErrorIs(t, err, ErrExpired)
+	assert.NotNil(t, request)
+}
+
+func TestDecodeRejectsTamperedPayloads(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("test-signing-key")
+	now := time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	payload, err := testPaymentRequest().Encode(key)
+	require.NoError(t, err)
+
+	tampered := testPaymentRequest()
+	tampered.Amount = "1250.00"
+	tamperedPayload, err := tampered.Encode([]byte("attacker-key"))
+	require.NoError(t, err)
+	signature := payload[strings.LastIndexByte(payload, '.'):]
+
+	for name, payload := range map[string]string{
+		"empty":             "",
+		"other_qr_code":     "https://example.com",
+		"missing_signature": Prefix + ".e30",
+		"swapped_body":      tamperedPayload[:strings.LastIndexByte(tamperedPayload, '.')] + signature,
+		"bad_base64":        Prefix + ".!!!" + signature,
+	} {
+		_, err := Decode(payload, key, now)
+		assert.ErrorIs(t, err, ErrInvalidPayload, name)
+	}
+}
+
+func TestEncodeRequiresKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := testPaymentRequest().Encode(nil)
+	assert.Error(t, err)
+}
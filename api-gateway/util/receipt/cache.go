@@ -0,0 +1,56 @@
+package receipt
+
+import "sync"
+
+// Cache keeps generated receipts in memory so a receipt is generated once and then served as-is.
+// When full, the oldest receipt is evicted.
+type Cache struct {
+	mutex    sync.Mutex
+	capacity int
+	receipts map[string]*Receipt
+	order    []string
+}
+
+// NewCache creates a cache holding at most capacity receipts
+func NewCache(capacity int) *Cache {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &Cache{
+		capacity: capacity,
+		receipts: make(map[string]*Receipt, capacity),
+	}
+}
+
+// Get returns the receipt cached under key
+func (cache *Cache) Get(key string) (*Receipt, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	receipt, ok := cache.receipts[key]
+
+	return receipt, ok
+}
+
+// Put caches a receipt under each of the given keys, e.g. its transaction ID and transfer reference
+func (cache *Cache) Put(receipt *Receipt, keys ...string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+
+		if _, exists := cache.receipts[key]; !exists {
+			cache.order = append(cache.order, key)
+		}
+		cache.receipts[key] = receipt
+	}
+
+	for len(cache.order) > cache.capacity {
+		delete(cache.receipts, cache.order[0])
+		cache.order = cache.order[1:]
+	}
+}
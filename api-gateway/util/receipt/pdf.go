@@ -0,0 +1,120 @@
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Page layout in PDF points (A4 portrait)
+const (
+	pageWidth   = 595
+	pageHeight  = 842
+	marginLeft  = 50
+	valueColumn = 190
+	lineHeight  = 18
+)
+
+// winAnsi maps the non-ASCII characters used by currency symbols to their WinAnsiEncoding code
+var winAnsi = map[rune]byte{
+	'€': 0x80,
+	'£': 0xA3,
+	'¥': 0xA5,
+}
+
+// RenderPDF renders the receipt as a single-page PDF document using the standard Helvetica fonts
+func RenderPDF(receipt *Receipt) []byte {
+	var content bytes.Buffer
+
+	y := pageHeight - 70
+	writeText(&content, "F2", 20, marginLeft, y, "Transfer Receipt")
+	y -= 2 * lineHeight
+
+	rows := [][2]string{
+		{"Receipt number", receipt.ReceiptNumber},
+		{"Transaction ID", receipt.TransactionID},
+		{"Status", receipt.Status},
+		{"From account", receipt.From.Account},
+		{"To account", receipt.To.Account},
+		{"Amount", fmt.Sprintf("%s (%s)", receipt.Amount.Formatted, receipt.Amount.Currency)},
+		{"Description", receipt.Description},
+		{"Reference ID", receipt.ReferenceID},
+		{"Created at", receipt.CreatedAt},
+		{"Completed at", receipt.CompletedAt},
+		{"Workflow ID", receipt.Workflow.WorkflowID},
+		{"Run ID", receipt.Workflow.RunID},
+		{"Generated at", receipt.GeneratedAt},
+	}
+
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+
+		writeText(&content, "F2", 11, marginLeft, y, row[0])
+		writeText(&content, "F1", 11, valueColumn, y, row[1])
+		y -= lineHeight
+	}
+
+	if receipt.Signature != nil {
+		y -= lineHeight
+		writeText(&content, "F2", 9, marginLeft, y, fmt.Sprintf("Signature (%s, key %s)", receipt.Signature.Algorithm, receipt.Signature.KeyID))
+		y -= lineHeight - 6
+		writeText(&content, "F1", 8, marginLeft, y, receipt.Signature.Value)
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>", pageWidth, pageHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	var document bytes.Buffer
+	document.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = document.Len()
+		fmt.Fprintf(&document, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := document.Len()
+	fmt.Fprintf(&document, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&document, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&document, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return document.Bytes()
+}
+
+// writeText appends a text-showing operation to a content stream
+func writeText(content *bytes.Buffer, font string, size int, x int, y int, text string) {
+	fmt.Fprintf(content, "BT /%s %d Tf %d %d Td (%s) Tj ET\n", font, size, x, y, escapeText(text))
+}
+
+// escapeText encodes text as a PDF literal string in WinAnsiEncoding.
+// Characters the standard fonts cannot display are replaced with '?'.
+func escapeText(text string) string {
+	var builder strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			builder.WriteByte('\\')
+			builder.WriteRune(r)
+		case r >= 0x20 && r < 0x7F:
+			builder.WriteRune(r)
+		default:
+			if code, ok := winAnsi[r]; ok {
+				fmt.Fprintf(&builder, "\\%03o", code)
+			} else {
+				builder.WriteByte('?')
+			}
+		}
+	}
+
+	return builder.String()
+}
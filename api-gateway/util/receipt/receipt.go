@@ -0,0 +1,119 @@
+package receipt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SignatureAlgorithm is the only algorithm receipts are signed with
+const SignatureAlgorithm = "HMAC-SHA256"
+
+// ErrInvalidSignature is returned when a receipt does not match its signature
+var ErrInvalidSignature = errors.New("invalid receipt signature")
+
+// Receipt is the proof of a completed transfer handed to customers
+type Receipt struct {
+	ReceiptNumber     string     `json:"receipt_number"` // Transfer reference when available, otherwise the transaction ID
+	TransactionID     string     `json:"transaction_id"`
+	TransferReference string     `json:"transfer_reference,omitempty"`
+	Status            string     `json:"status"`
+	From              Party      `json:"from"`
+	To                Party      `json:"to"`
+	Amount            Amount     `json:"amount"`
+	Description       string     `json:"description,omitempty"`
+	ReferenceID       string     `json:"reference_id,omitempty"`
+	CreatedAt         string     `json:"created_at"`
+	CompletedAt       string     `json:"completed_at"`
+	Workflow          Workflow   `json:"workflow"`
+	GeneratedAt       string     `json:"generated_at"`
+	Signature         *Signature `json:"signature,omitempty"`
+}
+
+// Party is one side of the transfer
+type Party struct {
+	Account string `json:"account"`
+}
+
+// Amount is the transferred amount, both raw and formatted for display
+type Amount struct {
+	MinorUnits int64  `json:"minor_units"`
+	Value      string `json:"value"`
+	Currency   string `json:"currency"`
+	Formatted  string `json:"formatted"`
+}
+
+// Workflow identifies the Temporal execution that processed the transfer
+type Workflow struct {
+	WorkflowID string `json:"workflow_id,omitempty"`
+	RunID      string `json:"run_id,omitempty"`
+}
+
+// Signature proves that the receipt was issued by this gateway and has not been altered
+type Signature struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id"`
+	Value     string `json:"value"` // Hex-encoded MAC over the receipt JSON without the signature
+}
+
+// Sign computes the signature of the receipt with the given key and attaches it
+func (receipt *Receipt) Sign(keyID string, key []byte) error {
+	if len(key) == 0 {
+		return fmt.Errorf("receipt signing key is not configured")
+	}
+
+	mac, err := receipt.mac(key)
+	if err != nil {
+		return err
+	}
+
+	receipt.Signature = &Signature{
+		Algorithm: SignatureAlgorithm,
+		KeyID:     keyID,
+		Value:     hex.EncodeToString(mac),
+	}
+
+	return nil
+}
+
+// Verify checks the attached signature against the given key
+func (receipt *Receipt) Verify(key []byte) error {
+	if receipt.Signature == nil || receipt.Signature.Algorithm != SignatureAlgorithm {
+		return ErrInvalidSignature
+	}
+
+	expected, err := hex.DecodeString(receipt.Signature.Value)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac, err := receipt.mac(key)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal(mac, expected) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// mac computes the HMAC of the receipt JSON with the signature left out
+func (receipt *Receipt) mac(key []byte) ([]byte, error) {
+	unsigned := *receipt
+	unsigned.Signature = nil
+
+	payload, err := json.Marshal(unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal receipt: %w", err)
+	}
+
+	hash := hmac.New(sha256.New, key)
+	hash.Write(payload)
+
+	return hash.Sum(nil), nil
+}
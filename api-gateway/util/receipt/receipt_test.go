@@ -0,0 +1,99 @@
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testReceipt() *Receipt {
+	return &Receipt{
+		ReceiptNumber:     "250314-001-000042-2",
+		TransactionID:     "0f8fad5b-d9cb-469f-a165-70867728950e",
+		TransferReference: "250314-001-000042-2",
+		Status:            "TRANSFER_STATUS_COMPLETED",
+		From:              Party{Account: "ACC001000001"},
+		To:                Party{Account: "ACC001000002"},
+		Amount:            Amount{MinorUnits: 10050, Value: "100.5", Currency: "EUR", Formatted: "€100.50"},
+		Description:       "Rent (March)",
+		CreatedAt:         "2025-03-14T09:30:00Z",
+		CompletedAt:       "2025-03-14T09:30:02Z",
+		Workflow:          Workflow{WorkflowID: "transfer_workflow_0f8fad5b", RunID: "run-1"},
+		GeneratedAt:       "2025-03-14T10:00:00Z",
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("test-signing-key")
+
+	receipt := testReceipt()
+	require.NoError(t, receipt.Sign("key-1", key))
+	require.NotNil(t, receipt.Signature)
+	assert.Equal(t, SignatureAlgorithm, receipt.Signature.Algorithm)
+	assert.Equal(t, "key-1", receipt.Signature.KeyID)
+	assert.Len(t, receipt.Signature.Value, 64)
+
+	assert.NoError(t, receipt.Verify(key))
+	assert.ErrorIs(t, receipt.Verify([]byte("other-key")), ErrInvalidSignature)
+
+	tampered := *receipt
+	tampered.Amount.MinorUnits = 1005000
+	assert.ErrorIs(t, tampered.Verify(key), ErrInvalidSignature)
+}
+
+func TestSignRequiresKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Error(t, testReceipt().Sign("key-1", nil))
+}
+
+func TestRenderPDF(t *testing.T) {
+	t.Parallel()
+
+	receipt := testReceipt()
+	require.NoError(t, receipt.Sign("key-1", []byte("test-signing-key")))
+
+	document := RenderPDF(receipt)
+
+	assert.True(t, bytes.HasPrefix(document, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(document, []byte("%%EOF\n")))
+	assert.Contains(t, string(document), `(\200100.50 \(EUR\))`)
+	assert.Contains(t, string(document), `(Rent \(March\))`)
+	assert.Contains(t, string(document), receipt.Signature.Value)
+
+	// The xref table must point at the start of every object
+	for i := 1; i <= 6; i++ {
+		assert.Contains(t, string(document), fmt.Sprintf("%d 0 obj", i))
+	}
+}
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	cache := NewCache(2)
+
+	first := testReceipt()
+	cache.Put(first, first.TransactionID, first.TransferReference)
+
+	cached, ok := cache.Get(first.TransferReference)
+	require.True(t, ok)
+	assert.Same(t, first, cached)
+
+	second := testReceipt()
+	second.TransactionID = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	cache.Put(second, second.TransactionID, "")
+
+	_, ok = cache.Get(first.TransactionID)
+	assert.False(t, ok, "oldest key should be evicted")
+
+	_, ok = cache.Get(first.TransferReference)
+	assert.True(t, ok)
+
+	_, ok = cache.Get(second.TransactionID)
+	assert.True(t, ok)
+}
@@ -0,0 +1,89 @@
+package statuscache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory is a least recently used cache held by the process. Each gateway instance has its own.
+type Memory struct {
+	mutex    sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	order    *list.List // Most recently used first
+	now      func() time.Time
+}
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemory creates a cache holding at most capacity values for ttl each
+func NewMemory(capacity int, ttl time.Duration) *Memory {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &Memory{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element, capacity),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+// Get returns the value cached under key and marks it as recently used
+func (memory *Memory) Get(_ context.Context, key string) ([]byte, bool, error) {
+	memory.mutex.Lock()
+	defer memory.mutex.Unlock()
+
+	element, ok := memory.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*memoryEntry)
+	if !memory.now().Before(entry.expiresAt) {
+		memory.order.Remove(element)
+		delete(memory.entries, key)
+
+		return nil, false, nil
+	}
+
+	memory.order.MoveToFront(element)
+
+	return entry.value, true, nil
+}
+
+// Set caches value under key, evicting the least recently used values when the cache is full
+func (memory *Memory) Set(_ context.Context, key string, value []byte) error {
+	memory.mutex.Lock()
+	defer memory.mutex.Unlock()
+
+	expiresAt := memory.now().Add(memory.ttl)
+
+	if element, ok := memory.entries[key]; ok {
+		entry := element.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		memory.order.MoveToFront(element)
+
+		return nil
+	}
+
+	memory.entries[key] = memory.order.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+
+	for memory.order.Len() > memory.capacity {
+		oldest := memory.order.Back()
+		memory.order.Remove(oldest)
+		delete(memory.entries, oldest.Value.(*memoryEntry).key)
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package statuscache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRedisPoolSize = 8
+	defaultRedisTimeout  = time.Second
+)
+
+// RedisOptions configures a Redis server shared by the gateway instances
+type RedisOptions struct {
+	Addr      string // host:port
+	Password  string // Empty skips AUTH
+	DB        int
+	KeyPrefix string        // Prepended to every key, e.g. "api-gateway:"
+	PoolSize  int           // Idle connections kept open; 0 is 8
+	Timeout   time.Duration // Dial and per-command timeout when ctx has no earlier deadline; 0 is 1 second
+}
+
+// Redis stores values in Redis with GET and SET PX over the RESP protocol. Values expire on the server, so every
+// gateway instance sees a value once any of them has stored it.
+type Redis struct {
+	options RedisOptions
+	ttl     time.Duration
+	idle    chan *redisConn
+	dialer  net.Dialer
+}
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// errRedisNil is the null bulk string Redis replies with for a missing key
+var errRedisNil = errors.New("redis: nil")
+
+// NewRedis returns a Redis cache keeping values for ttl. Connections are opened on first use.
+func NewRedis(options RedisOptions, ttl time.Duration) (*Redis, error) {
+	if options.Addr == "" {
+		return nil, fmt.Errorf("redis address is required")
+	}
+
+	if options.PoolSize <= 0 {
+		options.PoolSize = defaultRedisPoolSize
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultRedisTimeout
+	}
+
+	return &Redis{
+		options: options,
+		ttl:     ttl,
+		idle:    make(chan *redisConn, options.PoolSize),
+		dialer:  net.Dialer{Timeout: options.Timeout},
+	}, nil
+}
+
+// Get returns the value stored under key
+func (redis *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := redis.do(ctx, "GET", redis.options.KeyPrefix+key)
+	if errors.Is(err, errRedisNil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+// Set stores value under key with the TTL of the cache
+func (redis *Redis) Set(ctx context.Context, key string, value []byte) error {
+	_, err := redis.do(ctx, "SET", redis.options.KeyPrefix+key, string(value), "PX", strconv.FormatInt(redis.ttl.Milliseconds(), 10))
+
+	return err
+}
+
+// do sends one command on a pooled connection and returns its reply. Connections that fail are closed instead of
+// being returned to the pool, since a partly read reply would be taken for the reply of the next command.
+func (redis *Redis) do(ctx context.Context, args ...string) ([]byte, error) {
+	conn, err := redis.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(redis.options.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	reply, err := conn.command(deadline, args...)
+	if err != nil && !errors.Is(err, errRedisNil) {
+		conn.conn.Close()
+
+		return nil, fmt.Errorf("redis %s failed: %w", args[0], err)
+	}
+
+	redis.release(conn)
+
+	return reply, err
+}
+
+// conn takes an idle connection or dials a new one, authenticating and selecting the database
+func (redis *Redis) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-redis.idle:
+		return conn, nil
+	default:
+	}
+
+	netConn, err := redis.dialer.DialContext(ctx, "tcp", redis.options.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	conn := &redisConn{conn: netConn, reader: bufio.NewReader(netConn)}
+	deadline := time.Now().Add(redis.options.Timeout)
+
+	if redis.options.Password != "" {
+		if _, err := conn.command(deadline, "AUTH", redis.options.Password); err != nil {
+			netConn.Close()
+
+			return nil, fmt.Errorf("redis AUTH failed: %w", err)
+		}
+	}
+
+	if redis.options.DB != 0 {
+		if _, err := conn.command(deadline, "SELECT", strconv.Itoa(redis.options.DB)); err != nil {
+			netConn.Close()
+
+			return nil, fmt.Errorf("redis SELECT failed: %w", err)
+		}
+	}
+
+	return conn, nil
+}
+
+// release returns a connection to the pool, closing it when the pool is full
+func (redis *Redis) release(conn *redisConn) {
+	select {
+	case redis.idle <- conn:
+	default:
+		conn.conn.Close()
+	}
+}
+
+// command writes args as a RESP array of bulk strings and reads the reply
+func (conn *redisConn) command(deadline time.Time, args ...string) ([]byte, error) {
+	if err := conn.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	request := make([]byte, 0, 64)
+	request = append(request, '*')
+	request = strconv.AppendInt(request, int64(len(args)), 10)
+	request = append(request, '\r', '\n')
+	for _, arg := range args {
+		request = append(request, '$')
+		request = strconv.AppendInt(request, int64(len(arg)), 10)
+		request = append(request, '\r', '\n')
+		request = append(request, arg...)
+		request = append(request, '\r', '\n')
+	}
+
+	if _, err := conn.conn.Write(request); err != nil {
+		return nil, err
+	}
+
+	return conn.readReply()
+}
+
+// readReply reads a simple string, error, integer or bulk string reply
+func (conn *redisConn) readReply() ([]byte, error) {
+	line, err := conn.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, fmt.Errorf("empty reply")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return nil, fmt.Errorf("%s", line[1:])
+	case '$':
+		size, err := strconv.Atoi(string(line[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bulk string length: %q", line[1:])
+		}
+		if size < 0 {
+			return nil, errRedisNil
+		}
+
+		value := make([]byte, size+2)
+		if _, err := io.ReadFull(conn.reader, value); err != nil {
+			return nil, err
+		}
+
+		return value[:size], nil
+	default:
+		return nil, fmt.Errorf("unsupported reply type %q", line[0])
+	}
+}
+
+// readLine reads one CRLF-terminated line without the terminator
+func (conn *redisConn) readLine() ([]byte, error) {
+	line, err := conn.reader.ReadSlice('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("malformed reply line")
+	}
+
+	return line[:len(line)-2], nil
+}
@@ -0,0 +1,61 @@
+// Package statuscache keeps responses that no longer change, such as the status of a finished transfer, in memory
+// or in Redis so repeated reads are not sent to the backing service.
+package statuscache
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Backend names accepted by the status cache config
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+const (
+	defaultCapacity = 10000
+	defaultTTL      = 24 * time.Hour
+)
+
+// Cache stores opaque values under string keys for the TTL it was created with
+type Cache interface {
+	// Get returns the value stored under key; ok is false when there is none or it has expired
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+
+	// Set stores value under key, replacing any existing value
+	Set(ctx context.Context, key string, value []byte) error
+}
+
+// Options selects and configures a backend
+type Options struct {
+	Backend  string        // BackendMemory, BackendRedis or empty for none
+	TTL      time.Duration // How long a value is kept; 0 is 24 hours
+	Capacity int           // Values kept by the memory backend before the least recently used are evicted; 0 is 10000
+	Redis    RedisOptions
+}
+
+// New returns the Cache of the configured backend, or nil when no backend is configured
+func New(options Options) (Cache, error) {
+	ttl := options.TTL
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+
+	switch options.Backend {
+	case "":
+		return nil, nil
+	case BackendMemory:
+		capacity := options.Capacity
+		if capacity <= 0 {
+			capacity = defaultCapacity
+		}
+
+		return NewMemory(capacity, ttl), nil
+	case BackendRedis:
+		return NewRedis(options.Redis, ttl)
+	default:
+		return nil, fmt.Errorf("unsupported status cache backend: %s", options.Backend)
+	}
+}
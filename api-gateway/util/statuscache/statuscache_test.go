@@ -0,0 +1,197 @@
+package statuscache
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	cache := NewMemory(2, time.Minute)
+	cache.now = func() time.Time { return now }
+
+	require.NoError(t, cache.Set(ctx, "a", []byte("1")))
+	require.NoError(t, cache.Set(ctx, "b", []byte("2")))
+
+	// Reading a makes b the least recently used, so c evicts b
+	value, ok, err := cache.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "1", string(value))
+
+	require.NoError(t, cache.Set(ctx, "c", []byte("3")))
+
+	_, ok, _ = cache.Get(ctx, "b")
+	assert.False(t, ok)
+	_, ok, _ = cache.Get(ctx, "c")
+	assert.True(t, ok)
+
+	now = now.Add(time.Minute)
+
+	_, ok, _ = cache.Get(ctx, "a")
+	assert.False(t, ok, "expired values are not served")
+	assert.Equal(t, 1, cache.order.Len())
+}
+
+// fakeRedis serves GET, SET, AUTH and SELECT from a map, ignoring expiry but recording the PX argument of SET
+type fakeRedis struct {
+	mu       sync.Mutex
+	values   map[string]string
+	commands []string
+}
+
+func (fake *fakeRedis) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			defer conn.Close()
+
+			reader := bufio.NewReader(conn)
+			for {
+				args, err := readFakeCommand(reader)
+				if err != nil {
+					return
+				}
+
+				fake.mu.Lock()
+				fake.commands = append(fake.commands, strings.Join(args, " "))
+				reply := "+OK\r\n"
+				switch strings.ToUpper(args[0]) {
+				case "GET":
+					value, ok := fake.values[args[1]]
+					if ok {
+						reply = "$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n"
+					} else {
+						reply = "$-1\r\n"
+					}
+				case "SET":
+					fake.values[args[1]] = args[2]
+				case "AUTH":
+					if args[1] != "secret" {
+						reply = "-WRONGPASS invalid password\r\n"
+					}
+				}
+				fake.mu.Unlock()
+
+				if _, err := io.WriteString(conn, reply); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+func readFakeCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, 0, count)
+	for range count {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+
+		value := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, value); err != nil {
+			return nil, err
+		}
+
+		args = append(args, string(value[:size]))
+	}
+
+	return args, nil
+}
+
+func TestRedis(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	fake := &fakeRedis{values: map[string]string{}}
+	go fake.serve(listener)
+
+	ctx := context.Background()
+
+	cache, err := New(Options{
+		Backend: BackendRedis,
+		TTL:     time.Hour,
+		Redis:   RedisOptions{Addr: listener.Addr().String(), Password: "secret", DB: 2, KeyPrefix: "gw:"},
+	})
+	require.NoError(t, err)
+
+	_, ok, err := cache.Get(ctx, "transfer-1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	value := []byte("binary\r\n\x00value")
+	require.NoError(t, cache.Set(ctx, "transfer-1", value))
+
+	cached, ok, err := cache.Get(ctx, "transfer-1")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, value, cached)
+
+	fake.mu.Lock()
+	assert.Equal(t, []string{"AUTH secret", "SELECT 2", "GET gw:transfer-1"}, fake.commands[:3])
+	assert.Equal(t, "SET gw:transfer-1 "+string(value)+" PX 3600000", fake.commands[3])
+	assert.Len(t, fake.commands, 5, "the connection is reused")
+	fake.mu.Unlock()
+
+	wrongPassword, err := NewRedis(RedisOptions{Addr: listener.Addr().String(), Password: "wrong"}, time.Hour)
+	require.NoError(t, err)
+
+	_, _, err = wrongPassword.Get(ctx, "transfer-1")
+	assert.ErrorContains(t, err, "WRONGPASS")
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	cache, err := New(Options{})
+	require.NoError(t, err)
+	assert.Nil(t, cache)
+
+	cache, err = New(Options{Backend: BackendMemory})
+	require.NoError(t, err)
+	assert.Equal(t, defaultCapacity, cache.(*Memory).capacity)
+	assert.Equal(t, defaultTTL, cache.(*Memory).ttl)
+
+	_, err = New(Options{Backend: BackendRedis})
+	assert.Error(t, err)
+
+	_, err = New(Options{Backend: "memcached"})
+	assert.Error(t, err)
+}
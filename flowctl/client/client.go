@@ -0,0 +1,112 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HeaderActor names the caller of state-changing admin calls, as read by svc-balance and svc-transaction
+const HeaderActor = "X-Actor"
+
+// Client is a small JSON-over-HTTP client for one of the demo services
+type Client struct {
+	baseURL string
+	actor   string
+
+	httpClient *http.Client
+}
+
+// NewClient creates a client for the service at baseURL
+func NewClient(baseURL string, actor string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		actor:   actor,
+
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// Error is a non-2xx response. Body holds the response as returned by the service.
+type Error struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (err *Error) Error() string {
+	return fmt.Sprintf("%s %s: %d %s", err.Method, err.URL, err.StatusCode, err.Body)
+}
+
+// Get sends a GET request and decodes the JSON response into out
+func (client *Client) Get(ctx context.Context, path string, out any) error {
+	return client.Do(ctx, http.MethodGet, path, nil, out)
+}
+
+// Post sends a POST request with body encoded as JSON (nil sends no body) and decodes the JSON response into out
+func (client *Client) Post(ctx context.Context, path string, body any, out any) error {
+	return client.Do(ctx, http.MethodPost, path, body, out)
+}
+
+// Do sends a request and decodes the JSON response into out; out may be nil
+func (client *Client) Do(ctx context.Context, method string, path string, body any, out any) error {
+	url := client.baseURL + path
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if client.actor != "" {
+		request.Header.Set(HeaderActor, client.actor)
+	}
+
+	response, err := client.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, url, err)
+	}
+	defer response.Body.Close()
+
+	payload, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response of %s %s: %w", method, url, err)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return &Error{Method: method, URL: url, StatusCode: response.StatusCode, Body: strings.TrimSpace(string(payload))}
+	}
+
+	if out == nil || len(payload) == 0 {
+		return nil
+	}
+
+	if raw, ok := out.(*json.RawMessage); ok {
+		*raw = payload
+		return nil
+	}
+
+	if err := json.Unmarshal(payload, out); err != nil {
+		return fmt.Errorf("failed to decode response of %s %s: %w", method, url, err)
+	}
+
+	return nil
+}
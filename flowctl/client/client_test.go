@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientDo(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(HeaderActor) != "tester" {
+			t.Errorf("%s = %q, want tester", HeaderActor, r.Header.Get(HeaderActor))
+		}
+
+		switch r.URL.Path {
+		case "/ok":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"status":"success"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", "tester", time.Second)
+
+	var response struct {
+		Status string `json:"status"`
+	}
+	if err := client.Post(context.Background(), "/ok", map[string]string{"key": "value"}, &response); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if response.Status != "success" {
+		t.Errorf("Post() decoded status %q, want success", response.Status)
+	}
+
+	err := client.Get(context.Background(), "/missing", nil)
+
+	var clientErr *Error
+	if !errors.As(err, &clientErr) || clientErr.StatusCode != http.StatusNotFound || clientErr.Body != `{"error":"not found"}` {
+		t.Errorf("Get() error = %v, want a 404 Error carrying the response body", err)
+	}
+}
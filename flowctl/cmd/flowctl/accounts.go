@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+
+	"flowctl/demo"
+)
+
+func accounts(ctx context.Context, clients clients, args []string) error {
+	action, args, err := subcommand("accounts", args, "seed", "list")
+	if err != nil {
+		return err
+	}
+
+	if action == "seed" {
+		return seedAccounts(ctx, clients, args)
+	}
+
+	return listAccounts(ctx, clients, args)
+}
+
+func seedAccounts(ctx context.Context, clients clients, args []string) error {
+	flags := flag.NewFlagSet("accounts seed", flag.ContinueOnError)
+	file := flags.String("file", "", "JSON array of accounts to open instead of the demo accounts")
+
+	if _, err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	seed := demo.DemoAccounts
+	if *file != "" {
+		content, err := os.ReadFile(*file)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", *file, err)
+		}
+		if err := json.Unmarshal(content, &seed); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", *file, err)
+		}
+	}
+
+	var (
+		results []json.RawMessage
+		errs    []error
+	)
+	for _, account := range seed {
+		if account.OpeningBalance == "" {
+			account.OpeningBalance = "0"
+		}
+
+		var response struct {
+			Data json.RawMessage `json:"data"`
+		}
+
+		// opening_balance is sent as a JSON number so svc-transaction reads it as a decimal
+		err := clients.transaction.Post(ctx, "/accounts", map[string]any{
+			"account_number":  account.AccountNumber,
+			"account_name":    account.AccountName,
+			"currency":        account.Currency,
+			"account_type":    account.AccountType,
+			"opening_balance": json.Number(account.OpeningBalance),
+		}, &response)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("account %s: %w", account.AccountNumber, err))
+			continue
+		}
+
+		results = append(results, response.Data)
+	}
+
+	if err := printJSON(results); err != nil {
+		return err
+	}
+
+	return errors.Join(errs...)
+}
+
+func listAccounts(ctx context.Context, clients clients, args []string) error {
+	flags := flag.NewFlagSet("accounts list", flag.ContinueOnError)
+	status := flags.String("status", "active", "account status: active, inactive, suspended or closed")
+	limit := flags.Int("limit", 50, "page size")
+	offset := flags.Int("offset", 0, "accounts to skip")
+
+	if _, err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("status", *status)
+	query.Set("limit", strconv.Itoa(*limit))
+	query.Set("offset", strconv.Itoa(*offset))
+
+	var response struct {
+		Accounts json.RawMessage `json:"accounts"`
+	}
+	if err := clients.balance.Get(ctx, "/accounts?"+query.Encode(), &response); err != nil {
+		return err
+	}
+
+	return printJSON(response.Accounts)
+}
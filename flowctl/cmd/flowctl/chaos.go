@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"flowctl/client"
+)
+
+func chaos(ctx context.Context, clients clients, args []string) error {
+	action, args, err := subcommand("chaos", args, "enable", "disable")
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet("chaos "+action, flag.ContinueOnError)
+	service := flags.String("service", "all", "service whose failure simulation to switch: balance, transaction, flowngine or all")
+
+	if _, err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	targets := map[string]*client.Client{}
+	switch *service {
+	case "balance":
+		targets["svc-balance"] = clients.balance
+	case "transaction":
+		targets["svc-transaction"] = clients.transaction
+	case "flowngine":
+		targets["flowngine"] = clients.flowngine
+	case "all":
+		targets["svc-balance"] = clients.balance
+		targets["svc-transaction"] = clients.transaction
+		targets["flowngine"] = clients.flowngine
+	default:
+		return fmt.Errorf("unknown service %q, expected balance, transaction, flowngine or all", *service)
+	}
+
+	results := map[string]json.RawMessage{}
+	for name, target := range targets {
+		var response struct {
+			FailureSimulation json.RawMessage `json:"failure_simulation"`
+		}
+		if err := target.Post(ctx, "/failure-simulation/"+action, nil, &response); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		results[name] = response.FailureSimulation
+	}
+
+	return printJSON(results)
+}
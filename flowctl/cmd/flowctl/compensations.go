@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+func compensations(ctx context.Context, clients clients, args []string) error {
+	action, args, err := subcommand("compensations", args, "list", "retry")
+	if err != nil {
+		return err
+	}
+
+	if action == "list" {
+		return listCompensations(ctx, clients, args)
+	}
+
+	return retryCompensation(ctx, clients, args)
+}
+
+func listCompensations(ctx context.Context, clients clients, args []string) error {
+	flags := flag.NewFlagSet("compensations list", flag.ContinueOnError)
+	status := flags.String("status", "", "pending, completed, failed, timeout or manual_required")
+	compensationType := flags.String("type", "", "debit_reversal, credit_reversal or manual_adjustment")
+	workflowID := flags.String("workflow", "", "only compensations of this workflow")
+	limit := flags.Int("limit", 50, "maximum records")
+
+	if _, err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	for name, value := range map[string]string{"status": *status, "type": *compensationType, "workflow_id": *workflowID} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	query.Set("limit", strconv.Itoa(*limit))
+
+	var response json.RawMessage
+	if err := clients.gateway.Get(ctx, "/admin/compensations?"+query.Encode(), &response); err != nil {
+		return err
+	}
+
+	return printJSON(response)
+}
+
+func retryCompensation(ctx context.Context, clients clients, args []string) error {
+	flags := flag.NewFlagSet("compensations retry", flag.ContinueOnError)
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: flowctl compensations retry <workflow_id>")
+	}
+
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := clients.transaction.Post(ctx, "/compensation-audit/workflow/"+url.PathEscape(positional[0])+"/retry", nil, &response); err != nil {
+		return err
+	}
+
+	return printJSON(response.Data)
+}
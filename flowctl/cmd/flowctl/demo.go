@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"time"
+
+	"flowctl/demo"
+	"flowctl/tui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runDemo starts the guided learning mode: pick a scenario, watch its saga steps, compare the balances
+func runDemo(ctx context.Context, clients clients, args []string) error {
+	flags := flag.NewFlagSet("demo", flag.ContinueOnError)
+	noColor := flags.Bool("no-color", os.Getenv("NO_COLOR") != "", "draw without ANSI colors")
+	poll := flags.Duration("poll", 500*time.Millisecond, "how often the transfer status is read")
+
+	if _, err := parseFlags(flags, args); err != nil {
+		return err
+	}
+
+	runner := demo.NewRunner(demo.Clients{
+		Gateway:     clients.gateway,
+		Balance:     clients.balance,
+		Transaction: clients.transaction,
+	})
+	runner.PollInterval = *poll
+
+	model := tui.NewModel(ctx, runner, demo.Scenarios, os.Stdout, !*noColor)
+
+	_, err := tea.NewProgram(model, tea.WithContext(ctx), tea.WithAltScreen()).Run()
+	if errors.Is(err, tea.ErrProgramKilled) && errors.Is(ctx.Err(), context.Canceled) {
+		return nil
+	}
+
+	return err
+}
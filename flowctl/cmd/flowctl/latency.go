@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/url"
+
+	"flowctl/client"
+)
+
+func latency(ctx context.Context, clients clients, args []string) error {
+	action, args, err := subcommand("latency", args, "show", "set")
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet("latency "+action, flag.ContinueOnError)
+	service := flags.String("service", "all", "service whose latency profile to show or switch: balance, transaction or all")
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if action == "set" && len(positional) != 1 {
+		return fmt.Errorf("usage: flowctl latency set <profile> [-service balance|transaction|all]")
+	}
+
+	targets := map[string]*client.Client{}
+	switch *service {
+	case "balance":
+		targets["svc-balance"] = clients.balance
+	case "transaction":
+		targets["svc-transaction"] = clients.transaction
+	case "all":
+		targets["svc-balance"] = clients.balance
+		targets["svc-transaction"] = clients.transaction
+	default:
+		return fmt.Errorf("unknown service %q, expected balance, transaction or all", *service)
+	}
+
+	results := map[string]json.RawMessage{}
+	for name, target := range targets {
+		var response struct {
+			LatencyProfile json.RawMessage `json:"latency_profile"`
+		}
+
+		if action == "set" {
+			err = target.Post(ctx, "/latency-profile/"+url.PathEscape(positional[0]), nil, &response)
+		} else {
+			err = target.Get(ctx, "/latency-profile", &response)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		results[name] = response.LatencyProfile
+	}
+
+	return printJSON(results)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"flowctl/client"
+	"flowctl/util/config"
+)
+
+// clients holds one client per service flowctl talks to
+type clients struct {
+	gateway     *client.Client
+	balance     *client.Client
+	transaction *client.Client
+	flowngine   *client.Client
+	actor       string // FLOWCTL_ACTOR
+}
+
+func main() {
+	flag.Usage = help
+	flag.Parse()
+
+	cmds := map[string]func(ctx context.Context, clients clients, args []string) error{
+		"transfer":      transfer,
+		"accounts":      accounts,
+		"chaos":         chaos,
+		"latency":       latency,
+		"compensations": compensations,
+		"demo":          runDemo,
+		"scenario":      scenarios,
+	}
+
+	cmdFunc, ok := cmds[flag.Arg(0)]
+	if !ok {
+		help()
+		if flag.Arg(0) == "help" {
+			return
+		}
+		os.Exit(2)
+	}
+
+	config, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	err = cmdFunc(ctx, clients{
+		gateway:     client.NewClient(config.GatewayURL, config.Actor, config.Timeout),
+		balance:     client.NewClient(config.BalanceURL, config.Actor, config.Timeout),
+		transaction: client.NewClient(config.TransactionURL, config.Actor, config.Timeout),
+		flowngine:   client.NewClient(config.FlowngineURL, config.Actor, config.Timeout),
+		actor:       config.Actor,
+	}, flag.Args()[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func help() {
+	divider := "| %s | %s |\n"
+	header := "| %-45s | %-60s |\n"
+	row := "| %-45s | %-60s |\n"
+
+	output :=
+		fmt.Sprintf(divider, strings.Repeat("-", 45), strings.Repeat("-", 60)) +
+			fmt.Sprintf(header, "Usage", "Description") +
+			fmt.Sprintf(divider, strings.Repeat("-", 45), strings.Repeat("-", 60)) +
+			fmt.Sprintf(row, "help", "show this help message") +
+			fmt.Sprintf(row, "transfer create -from -to|-to-alias -amount", "start a transfer (-wait blocks until it finishes)") +
+			fmt.Sprintf(row, "transfer status <id> [-wait seconds]", "show a transfer and its saga steps") +
+			fmt.Sprintf(row, "transfer cancel <id> [-reason]", "cancel a running transfer") +
+			fmt.Sprintf(row, "transfer approve <id> [-by]", "approve a transfer held for approval") +
+			fmt.Sprintf(row, "accounts seed [-file accounts.json]", "open the demo accounts (existing ones are kept)") +
+			fmt.Sprintf(row, "accounts list [-status] [-limit] [-offset]", "list accounts with their balances") +
+			fmt.Sprintf(row, "chaos enable|disable [-service]", "switch failure simulation on or off") +
+			fmt.Sprintf(row, "latency show|set <profile> [-service]", "show or switch the simulated activity latency") +
+			fmt.Sprintf(row, "compensations list [-status] [-type] [-limit]", "list compensation audit records") +
+			fmt.Sprintf(row, "compensations retry <workflow_id>", "retry a failed compensation") +
+			fmt.Sprintf(row, "demo [-no-color] [-poll 500ms]", "guided scenarios with live saga steps and balances") +
+			fmt.Sprintf(row, "scenario run [-run regexp] [paths...]", "run YAML scenarios and check their assertions") +
+			fmt.Sprintf(row, "scenario validate [paths...]", "check YAML scenarios without running them") +
+			fmt.Sprintf(divider, strings.Repeat("_", 45), strings.Repeat("_", 60))
+
+	environment := "Environment: FLOWCTL_GATEWAY_URL, FLOWCTL_BALANCE_URL, FLOWCTL_TRANSACTION_URL, FLOWCTL_FLOWNGINE_URL, FLOWCTL_ACTOR, FLOWCTL_TIMEOUT_SECONDS"
+
+	fmt.Fprintln(os.Stderr, output)
+	fmt.Fprintln(os.Stderr, environment)
+}
+
+// printJSON writes a response to stdout as indented JSON, so the output can be piped to jq
+func printJSON(value any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
+
+// subcommand splits args into the action and its remaining arguments
+func subcommand(group string, args []string, actions ...string) (string, []string, error) {
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("usage: flowctl %s <%s>", group, strings.Join(actions, "|"))
+	}
+
+	for _, action := range actions {
+		if args[0] == action {
+			return action, args[1:], nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("unknown %s command %q, expected one of: %s", group, args[0], strings.Join(actions, ", "))
+}
+
+// parseFlags parses flags that may come before or after the positional arguments, e.g. "status <id> -wait 10"
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		if flags.NArg() == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"flowctl/demo"
+	"flowctl/scenario"
+)
+
+func scenarios(ctx context.Context, clients clients, args []string) error {
+	action, args, err := subcommand("scenario", args, "run", "validate")
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet("scenario "+action, flag.ContinueOnError)
+	run := flags.String("run", "", "only run scenarios whose name matches this regular expression")
+	timeout := flags.Duration("timeout", 2*time.Minute, "time limit for each scenario")
+
+	paths, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		paths = []string{"scenarios"}
+	}
+
+	loaded, err := scenario.LoadAll(paths...)
+	if err != nil {
+		return err
+	}
+
+	if *run != "" {
+		pattern, err := regexp.Compile(*run)
+		if err != nil {
+			return fmt.Errorf("invalid -run: %w", err)
+		}
+
+		filtered := loaded[:0]
+		for _, s := range loaded {
+			if pattern.MatchString(s.Name) {
+				filtered = append(filtered, s)
+			}
+		}
+		loaded = filtered
+	}
+
+	if action == "validate" {
+		for _, s := range loaded {
+			fmt.Printf("ok   %s\n", s.Name)
+		}
+		return nil
+	}
+
+	runner := demo.NewRunner(demo.Clients{
+		Gateway:     clients.gateway,
+		Balance:     clients.balance,
+		Transaction: clients.transaction,
+	})
+	runner.Timeout = *timeout
+
+	engine := scenario.NewEngine(runner)
+
+	// Output follows go test, so scenario runs read like test runs
+	failed := 0
+	for _, s := range loaded {
+		fmt.Printf("=== RUN   %s\n", s.Name)
+
+		scenarioCtx, cancel := context.WithTimeout(ctx, *timeout)
+		report := engine.Run(scenarioCtx, s)
+		cancel()
+
+		if report.Passed() {
+			fmt.Printf("--- PASS: %s (%.2fs)\n", s.Name, report.Duration.Seconds())
+			continue
+		}
+
+		failed++
+		fmt.Printf("--- FAIL: %s (%.2fs)\n", s.Name, report.Duration.Seconds())
+		if report.Err != nil {
+			fmt.Printf("    %v\n", report.Err)
+		}
+		for _, failure := range report.Failures {
+			fmt.Printf("    %s\n", failure)
+		}
+
+		if errors.Is(ctx.Err(), context.Canceled) {
+			break
+		}
+	}
+
+	if failed > 0 {
+		fmt.Fprintln(os.Stderr, "FAIL")
+		return fmt.Errorf("%d of %d scenarios failed", failed, len(loaded))
+	}
+
+	fmt.Println("PASS")
+	return nil
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/url"
+)
+
+func transfer(ctx context.Context, clients clients, args []string) error {
+	action, args, err := subcommand("transfer", args, "create", "status", "cancel", "approve")
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "create":
+		return createTransfer(ctx, clients, args)
+	case "status":
+		return getTransferStatus(ctx, clients, args)
+	case "approve":
+		return approveTransfer(ctx, clients, args)
+	default:
+		return cancelTransfer(ctx, clients, args)
+	}
+}
+
+func createTransfer(ctx context.Context, clients clients, args []string) error {
+	flags := flag.NewFlagSet("transfer create", flag.ContinueOnError)
+	from := flags.String("from", "", "source account number (required)")
+	to := flags.String("to", "", "destination account number (required unless -to-alias is set)")
+	toAlias := flags.String("to-alias", "", "destination phone number or email address, instead of -to")
+	amount := flags.String("amount", "", "amount in major units, e.g. 100.50 (required)")
+	currency := flags.String("currency", "USD", "ISO 4217 currency code")
+	description := flags.String("description", "", "transfer description")
+	reference := flags.String("reference", "", "caller reference")
+	callback := flags.String("callback", "", "webhook URL notified if the transfer expires awaiting approval, funds or its trigger")
+	waitForFunds := flags.Bool("wait-for-funds", false, "wait for incoming credits instead of failing on insufficient funds")
+	triggerAccount := flags.String("trigger-account", "", "hold the transfer until this account has received -trigger-amount")
+	triggerAmount := flags.String("trigger-amount", "", "amount the trigger account has to receive, in major units")
+	wait := flags.Bool("wait", false, "wait for the transfer to finish")
+
+	if _, err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *from == "" || (*to == "") == (*toAlias == "") || *amount == "" {
+		return fmt.Errorf("transfer create requires -from, one of -to or -to-alias, and -amount")
+	}
+	if (*triggerAccount == "") != (*triggerAmount == "") {
+		return fmt.Errorf("-trigger-account and -trigger-amount must be set together")
+	}
+
+	request := map[string]any{
+		"from_account":        *from,
+		"amount":              map[string]any{"value": *amount, "currency": *currency},
+		"wait_for_completion": *wait,
+		"wait_for_funds":      *waitForFunds,
+	}
+	if *toAlias != "" {
+		request["to_alias"] = *toAlias
+	} else {
+		request["to_account"] = *to
+	}
+	if *description != "" {
+		request["description"] = *description
+	}
+	if *reference != "" {
+		request["reference_id"] = *reference
+	}
+	if *callback != "" {
+		request["callback_url"] = *callback
+	}
+	if *triggerAccount != "" {
+		request["trigger"] = map[string]any{"account": *triggerAccount, "value": *triggerAmount}
+	}
+
+	var response json.RawMessage
+	if err := clients.gateway.Post(ctx, "/api/v2/transfer", request, &response); err != nil {
+		return err
+	}
+
+	return printJSON(response)
+}
+
+func getTransferStatus(ctx context.Context, clients clients, args []string) error {
+	flags := flag.NewFlagSet("transfer status", flag.ContinueOnError)
+	wait := flags.Int("wait", 0, "long-poll up to this many seconds (max 30) for the transfer to finish")
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: flowctl transfer status <transaction_id|reference> [-wait seconds]")
+	}
+
+	path := "/api/v2/transfer/" + url.PathEscape(positional[0])
+	if *wait > 0 {
+		path += fmt.Sprintf("?wait_seconds=%d", *wait)
+	}
+
+	var response json.RawMessage
+	if err := clients.gateway.Get(ctx, path, &response); err != nil {
+		return err
+	}
+
+	return printJSON(response)
+}
+
+func cancelTransfer(ctx context.Context, clients clients, args []string) error {
+	flags := flag.NewFlagSet("transfer cancel", flag.ContinueOnError)
+	reason := flags.String("reason", "Cancelled with flowctl", "cancellation reason")
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: flowctl transfer cancel <transaction_id|reference> [-reason text]")
+	}
+
+	var response json.RawMessage
+	err = clients.gateway.Post(ctx, "/api/v2/transfer/"+url.PathEscape(positional[0])+"/cancel", map[string]any{"reason": *reason}, &response)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(response)
+}
+
+func approveTransfer(ctx context.Context, clients clients, args []string) error {
+	flags := flag.NewFlagSet("transfer approve", flag.ContinueOnError)
+	by := flags.String("by", "", "approver recorded on the transfer (defaults to FLOWCTL_ACTOR)")
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: flowctl transfer approve <transaction_id|reference> [-by approver]")
+	}
+
+	approver := *by
+	if approver == "" {
+		approver = clients.actor
+	}
+
+	var response json.RawMessage
+	err = clients.gateway.Post(ctx, "/api/v2/transfer/"+url.PathEscape(positional[0])+"/approve", map[string]any{"approved_by": approver}, &response)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(response)
+}
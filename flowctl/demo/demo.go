@@ -0,0 +1,425 @@
+// Package demo runs the guided learning scenarios: it prepares the accounts and the failure simulation,
+// starts a transfer through the gateway and reports the saga steps as the workflow progresses.
+package demo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"flowctl/client"
+)
+
+// Clients are the services a scenario talks to
+type Clients struct {
+	Gateway     *client.Client
+	Balance     *client.Client
+	Transaction *client.Client
+}
+
+// Account is an account opened before a scenario runs; opening an existing account is a no-op
+type Account struct {
+	AccountNumber  string `json:"account_number" yaml:"account_number"`
+	AccountName    string `json:"account_name" yaml:"account_name"`
+	Currency       string `json:"currency" yaml:"currency"`
+	AccountType    string `json:"account_type,omitempty" yaml:"account_type,omitempty"` // checking or savings; empty for checking
+	OpeningBalance string `json:"opening_balance" yaml:"opening_balance"`
+}
+
+// DemoAccounts are the accounts every built-in scenario relies on. Account numbers have the 12 characters
+// the gateway expects; 111111111111 and 222222222222 are targeted by the svc-transaction failure rules.
+var DemoAccounts = []Account{
+	{AccountNumber: "DEMO00000001", AccountName: "Demo Alice Checking", Currency: "USD", OpeningBalance: "5000.00"},
+	{AccountNumber: "DEMO00000002", AccountName: "Demo Bob Checking", Currency: "USD", OpeningBalance: "1000.00"},
+	{AccountNumber: "DEMO00000003", AccountName: "Demo Carol Savings", Currency: "USD", OpeningBalance: "25000.00"},
+	{AccountNumber: "DEMO00000004", AccountName: "Demo Empty Account", Currency: "USD", OpeningBalance: "0"},
+	{AccountNumber: "DEMO00000005", AccountName: "Demo Euro Account", Currency: "EUR", OpeningBalance: "2500.00"},
+	{AccountNumber: "111111111111", AccountName: "Demo Failing Debits", Currency: "USD", OpeningBalance: "1000.00"},
+	{AccountNumber: "222222222222", AccountName: "Demo Failing Credits", Currency: "USD", OpeningBalance: "0"},
+}
+
+// Scenario is one guided transfer
+type Scenario struct {
+	Name        string
+	Title       string
+	Description string // What the learner should watch for
+	Chaos       bool   // Failure simulation on (true) or off (false) while the scenario runs
+	FromAccount string
+	ToAccount   string
+	Amount      string // Major units, e.g. "100.00"
+	Currency    string
+}
+
+// Scenarios are the built-in guided scenarios, in menu order
+var Scenarios = []Scenario{
+	{
+		Name:        "happy-path",
+		Title:       "Happy path",
+		Description: "Failure simulation is off: the debit and the credit each succeed on their first attempt.",
+		FromAccount: "DEMO00000001",
+		ToAccount:   "DEMO00000002",
+		Amount:      "100.00",
+		Currency:    "USD",
+	},
+	{
+		Name:        "credit-failure-compensation",
+		Title:       "Credit failure with compensation",
+		Description: "Credits to 222222222222 fail: watch the credit retry, then the debit get compensated if it keeps failing.",
+		Chaos:       true,
+		FromAccount: "DEMO00000001",
+		ToAccount:   "222222222222",
+		Amount:      "50.00",
+		Currency:    "USD",
+	},
+	{
+		Name:        "retry-exhaustion",
+		Title:       "Retry exhaustion",
+		Description: "Debits from 111111111111 fail: watch the attempts climb until the retry policy gives up or the rule runs out.",
+		Chaos:       true,
+		FromAccount: "111111111111",
+		ToAccount:   "DEMO00000002",
+		Amount:      "25.00",
+		Currency:    "USD",
+	},
+}
+
+// terminalStatuses are the v2 transfer statuses after which the workflow no longer changes
+var terminalStatuses = []string{"COMPLETED", "FAILED", "COMPENSATED", "CANCELLED", "EXPIRED"}
+
+// Step is one activity of the transfer saga as reported by GET /api/v2/transfer/:id
+type Step struct {
+	Activity     string `json:"activity"`
+	Status       string `json:"status"`
+	Attempts     int32  `json:"attempts"`
+	LastError    string `json:"last_error,omitempty"`
+	Compensation bool   `json:"compensation"`
+	Summary      string `json:"summary"`
+}
+
+// Transfer is the part of the v2 transfer representation a scenario follows
+type Transfer struct {
+	TransactionID       string `json:"transaction_id"`
+	Status              string `json:"status"`
+	ErrorMessage        string `json:"error_message,omitempty"`
+	CompensationApplied bool   `json:"compensation_applied"`
+	Workflow            struct {
+		WorkflowID string `json:"workflow_id"`
+		Status     string `json:"status"`
+	} `json:"workflow"`
+	Steps []Step `json:"steps"`
+}
+
+// Terminal reports whether the transfer has finished
+func (transfer Transfer) Terminal() bool {
+	return slices.Contains(terminalStatuses, transfer.Status)
+}
+
+// Balance is an account balance as listed by svc-balance
+type Balance struct {
+	AccountNumber string          `json:"account_number"`
+	Balance       json.RawMessage `json:"balance"`
+	Currency      string          `json:"currency"`
+}
+
+// Amount returns the balance in major units, e.g. "5000.00"
+func (balance Balance) Amount() string {
+	return trimQuotes(balance.Balance)
+}
+
+// Result is the outcome of a scenario run
+type Result struct {
+	Scenario Scenario
+	Transfer Transfer
+	Before   map[string]string // Balance by account number
+	After    map[string]string
+}
+
+// Progress is called whenever the observed transfer changes
+type Progress func(transfer Transfer)
+
+// Runner runs scenarios against the demo services
+type Runner struct {
+	clients Clients
+
+	// PollInterval is how often the transfer status is read while the workflow runs
+	PollInterval time.Duration
+	// Timeout bounds a whole scenario run
+	Timeout time.Duration
+}
+
+// NewRunner creates a scenario runner
+func NewRunner(clients Clients) *Runner {
+	return &Runner{
+		clients: clients,
+
+		PollInterval: 500 * time.Millisecond,
+		Timeout:      2 * time.Minute,
+	}
+}
+
+// SeedAccounts opens the given accounts; existing ones are left untouched
+func (runner *Runner) SeedAccounts(ctx context.Context, accounts []Account) error {
+	var errs []error
+	for _, account := range accounts {
+		openingBalance := account.OpeningBalance
+		if openingBalance == "" {
+			openingBalance = "0"
+		}
+
+		// opening_balance is sent as a JSON number so svc-transaction reads it as a decimal
+		err := runner.clients.Transaction.Post(ctx, "/accounts", map[string]any{
+			"account_number":  account.AccountNumber,
+			"account_name":    account.AccountName,
+			"currency":        account.Currency,
+			"account_type":    account.AccountType,
+			"opening_balance": json.Number(openingBalance),
+		}, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("account %s: %w", account.AccountNumber, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// SetChaos switches the failure simulation of both services on or off
+func (runner *Runner) SetChaos(ctx context.Context, enabled bool) error {
+	action := "disable"
+	if enabled {
+		action = "enable"
+	}
+
+	if err := runner.clients.Balance.Post(ctx, "/failure-simulation/"+action, nil, nil); err != nil {
+		return fmt.Errorf("svc-balance: %w", err)
+	}
+	if err := runner.clients.Transaction.Post(ctx, "/failure-simulation/"+action, nil, nil); err != nil {
+		return fmt.Errorf("svc-transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ResetChaos resets the failure simulation of both services: counters are cleared and every rule is back
+// to its default
+func (runner *Runner) ResetChaos(ctx context.Context) error {
+	if err := runner.clients.Balance.Post(ctx, "/failure-simulation/reset", nil, nil); err != nil {
+		return fmt.Errorf("svc-balance: %w", err)
+	}
+	if err := runner.clients.Transaction.Post(ctx, "/failure-simulation/reset", nil, nil); err != nil {
+		return fmt.Errorf("svc-transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FailureRules returns the names of the learning failure rules of a service ("balance" or "transaction")
+func (runner *Runner) FailureRules(ctx context.Context, service string) ([]string, error) {
+	target, err := runner.failureSimulationClient(service)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Scenarios []struct {
+			Name string `json:"name"`
+		} `json:"scenarios"`
+	}
+	if err := target.Get(ctx, "/failure-simulation/scenarios", &response); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(response.Scenarios))
+	for _, scenario := range response.Scenarios {
+		names = append(names, scenario.Name)
+	}
+
+	return names, nil
+}
+
+// SetFailureRule switches a single failure rule of a service ("balance" or "transaction") on or off
+func (runner *Runner) SetFailureRule(ctx context.Context, service, rule string, enabled bool) error {
+	target, err := runner.failureSimulationClient(service)
+	if err != nil {
+		return err
+	}
+
+	action := "disable"
+	if enabled {
+		action = "enable"
+	}
+
+	return target.Post(ctx, "/failure-simulation/rules/"+url.PathEscape(rule)+"/"+action, nil, nil)
+}
+
+func (runner *Runner) failureSimulationClient(service string) (*client.Client, error) {
+	switch service {
+	case "balance":
+		return runner.clients.Balance, nil
+	case "transaction":
+		return runner.clients.Transaction, nil
+	default:
+		return nil, fmt.Errorf("unknown service %q, expected balance or transaction", service)
+	}
+}
+
+// Balances returns the balances of the given accounts formatted for display, keyed by account number
+func (runner *Runner) Balances(ctx context.Context, accountNumbers ...string) (map[string]string, error) {
+	accounts, err := runner.AccountBalances(ctx, accountNumbers...)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]string, len(accounts))
+	for accountNumber, account := range accounts {
+		balances[accountNumber] = fmt.Sprintf("%s %s", account.Amount(), account.Currency)
+	}
+
+	return balances, nil
+}
+
+// AccountBalances returns the given accounts as listed by svc-balance, keyed by account number.
+// Accounts that do not exist are left out.
+func (runner *Runner) AccountBalances(ctx context.Context, accountNumbers ...string) (map[string]Balance, error) {
+	balances := make(map[string]Balance, len(accountNumbers))
+
+	for _, status := range []string{"active", "suspended", "inactive"} {
+		var response struct {
+			Accounts []Balance `json:"accounts"`
+		}
+		query := url.Values{"status": {status}, "limit": {"500"}}
+		if err := runner.clients.Balance.Get(ctx, "/accounts?"+query.Encode(), &response); err != nil {
+			return nil, err
+		}
+
+		for _, account := range response.Accounts {
+			if slices.Contains(accountNumbers, account.AccountNumber) {
+				balances[account.AccountNumber] = account
+			}
+		}
+	}
+
+	return balances, nil
+}
+
+// TransferRequest is a transfer started by a scenario
+type TransferRequest struct {
+	FromAccount string
+	ToAccount   string
+	Amount      string // Major units, e.g. "100.00"
+	Currency    string
+	Description string
+}
+
+// StartTransfer starts a transfer without waiting for it to finish and returns its transaction ID
+func (runner *Runner) StartTransfer(ctx context.Context, request TransferRequest) (string, error) {
+	var response Transfer
+	err := runner.clients.Gateway.Post(ctx, "/api/v2/transfer", map[string]any{
+		"from_account": request.FromAccount,
+		"to_account":   request.ToAccount,
+		"amount":       map[string]any{"value": request.Amount, "currency": request.Currency},
+		"description":  request.Description,
+	}, &response)
+	if err != nil {
+		return "", err
+	}
+
+	return response.TransactionID, nil
+}
+
+// GetTransfer reads the transfer status including its saga steps
+func (runner *Runner) GetTransfer(ctx context.Context, transactionID string) (Transfer, error) {
+	var transfer Transfer
+	err := runner.clients.Gateway.Get(ctx, "/api/v2/transfer/"+url.PathEscape(transactionID), &transfer)
+
+	return transfer, err
+}
+
+// Follow polls the transfer until it finishes, calling progress whenever its status or steps change
+func (runner *Runner) Follow(ctx context.Context, transactionID string, progress Progress) (Transfer, error) {
+	ticker := time.NewTicker(runner.PollInterval)
+	defer ticker.Stop()
+
+	var last []byte
+	for {
+		transfer, err := runner.GetTransfer(ctx, transactionID)
+		if err != nil {
+			return transfer, err
+		}
+
+		if snapshot, _ := json.Marshal(transfer); string(snapshot) != string(last) {
+			last = snapshot
+			if progress != nil {
+				progress(transfer)
+			}
+		}
+
+		if transfer.Terminal() {
+			return transfer, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return transfer, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run prepares and runs a scenario: seeds the demo accounts, switches the failure simulation, records the
+// balances, starts the transfer, follows it to the end and records the balances again
+func (runner *Runner) Run(ctx context.Context, scenario Scenario, progress Progress) (*Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, runner.Timeout)
+	defer cancel()
+
+	if err := runner.SeedAccounts(ctx, DemoAccounts); err != nil {
+		return nil, fmt.Errorf("failed to seed demo accounts: %w", err)
+	}
+	if err := runner.SetChaos(ctx, scenario.Chaos); err != nil {
+		return nil, fmt.Errorf("failed to switch failure simulation: %w", err)
+	}
+
+	result := &Result{Scenario: scenario}
+
+	var err error
+	result.Before, err = runner.Balances(ctx, scenario.FromAccount, scenario.ToAccount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read balances: %w", err)
+	}
+
+	transactionID, err := runner.StartTransfer(ctx, TransferRequest{
+		FromAccount: scenario.FromAccount,
+		ToAccount:   scenario.ToAccount,
+		Amount:      scenario.Amount,
+		Currency:    scenario.Currency,
+		Description: "flowctl demo: " + scenario.Title,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transfer: %w", err)
+	}
+
+	result.Transfer, err = runner.Follow(ctx, transactionID, progress)
+	if err != nil {
+		return result, fmt.Errorf("failed to follow transfer %s: %w", transactionID, err)
+	}
+
+	result.After, err = runner.Balances(ctx, scenario.FromAccount, scenario.ToAccount)
+	if err != nil {
+		return result, fmt.Errorf("failed to read balances: %w", err)
+	}
+
+	return result, nil
+}
+
+// trimQuotes returns a JSON string or number without its quotes, e.g. "\"5000\"" -> "5000"
+func trimQuotes(raw json.RawMessage) string {
+	var value string
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value
+	}
+
+	return string(raw)
+}
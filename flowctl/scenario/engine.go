@@ -0,0 +1,262 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"flowctl/demo"
+)
+
+// Outcome is the end state of a scenario run that assertions are checked against
+type Outcome struct {
+	Transfers map[string]demo.Transfer // By transfer name
+	Before    map[string]string        // Balance in major units by account number, before the first transfer
+	After     map[string]string
+}
+
+// Report is the result of running one scenario
+type Report struct {
+	Name     string
+	Duration time.Duration
+	Outcome  Outcome
+	Failures []string // Failed assertions; empty when the scenario passed
+	Err      error    // Set when the scenario could not run to its end
+}
+
+// Passed reports whether the scenario ran and every assertion held
+func (report *Report) Passed() bool {
+	return report.Err == nil && len(report.Failures) == 0
+}
+
+// Engine runs scenarios against the demo services
+type Engine struct {
+	runner *demo.Runner
+}
+
+// NewEngine creates a scenario engine on top of a demo runner
+func NewEngine(runner *demo.Runner) *Engine {
+	return &Engine{
+		runner: runner,
+	}
+}
+
+// Run resets the failure simulation, seeds the accounts, switches the failure rules, executes the transfers
+// one after the other and checks the assertions. The failure simulation is reset again afterwards, so one
+// scenario's rules do not leak into the next.
+func (engine *Engine) Run(ctx context.Context, scenario *Scenario) *Report {
+	start := time.Now()
+	report := &Report{Name: scenario.Name}
+
+	report.Outcome, report.Err = engine.execute(ctx, scenario)
+	if report.Err == nil {
+		report.Failures = Check(scenario, report.Outcome)
+	}
+
+	if err := engine.runner.ResetChaos(context.WithoutCancel(ctx)); err != nil && report.Err == nil {
+		report.Err = fmt.Errorf("failed to reset failure simulation: %w", err)
+	}
+
+	report.Duration = time.Since(start)
+
+	return report
+}
+
+func (engine *Engine) execute(ctx context.Context, scenario *Scenario) (Outcome, error) {
+	outcome := Outcome{Transfers: map[string]demo.Transfer{}}
+
+	if err := engine.runner.ResetChaos(ctx); err != nil {
+		return outcome, fmt.Errorf("failed to reset failure simulation: %w", err)
+	}
+	if err := engine.runner.SeedAccounts(ctx, scenario.Accounts); err != nil {
+		return outcome, fmt.Errorf("failed to seed accounts: %w", err)
+	}
+	if err := engine.runner.SetChaos(ctx, scenario.Chaos != nil && *scenario.Chaos); err != nil {
+		return outcome, fmt.Errorf("failed to switch failure simulation: %w", err)
+	}
+	if scenario.IsolateRules {
+		if err := engine.isolateRules(ctx, scenario.FailureRules); err != nil {
+			return outcome, err
+		}
+	}
+	for _, rule := range scenario.FailureRules {
+		enabled := rule.Enabled == nil || *rule.Enabled
+		if err := engine.runner.SetFailureRule(ctx, rule.Service, rule.Rule, enabled); err != nil {
+			return outcome, fmt.Errorf("failed to switch failure rule %s: %w", rule.Rule, err)
+		}
+	}
+
+	accountNumbers := scenario.accountNumbers()
+
+	var err error
+	if outcome.Before, err = engine.balances(ctx, accountNumbers); err != nil {
+		return outcome, fmt.Errorf("failed to read balances: %w", err)
+	}
+
+	for _, transfer := range scenario.Transfers {
+		description := transfer.Description
+		if description == "" {
+			description = fmt.Sprintf("flowctl scenario %s: %s", scenario.Name, transfer.Name)
+		}
+
+		transactionID, err := engine.runner.StartTransfer(ctx, demo.TransferRequest{
+			FromAccount: transfer.From,
+			ToAccount:   transfer.To,
+			Amount:      transfer.Amount,
+			Currency:    transfer.Currency,
+			Description: description,
+		})
+		if err != nil {
+			return outcome, fmt.Errorf("failed to start transfer %s: %w", transfer.Name, err)
+		}
+
+		outcome.Transfers[transfer.Name], err = engine.runner.Follow(ctx, transactionID, nil)
+		if err != nil {
+			return outcome, fmt.Errorf("failed to follow transfer %s: %w", transfer.Name, err)
+		}
+	}
+
+	if outcome.After, err = engine.balances(ctx, accountNumbers); err != nil {
+		return outcome, fmt.Errorf("failed to read balances: %w", err)
+	}
+
+	return outcome, nil
+}
+
+// isolateRules disables every failure rule of both services that the scenario does not list
+func (engine *Engine) isolateRules(ctx context.Context, listed []FailureRule) error {
+	for _, service := range []string{"balance", "transaction"} {
+		rules, err := engine.runner.FailureRules(ctx, service)
+		if err != nil {
+			return fmt.Errorf("failed to list %s failure rules: %w", service, err)
+		}
+
+		for _, rule := range rules {
+			if slices.ContainsFunc(listed, func(l FailureRule) bool { return l.Service == service && l.Rule == rule }) {
+				continue
+			}
+
+			if err := engine.runner.SetFailureRule(ctx, service, rule, false); err != nil {
+				return fmt.Errorf("failed to disable failure rule %s: %w", rule, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (engine *Engine) balances(ctx context.Context, accountNumbers []string) (map[string]string, error) {
+	accounts, err := engine.runner.AccountBalances(ctx, accountNumbers...)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]string, len(accounts))
+	for accountNumber, account := range accounts {
+		balances[accountNumber] = account.Amount()
+	}
+
+	return balances, nil
+}
+
+// Check evaluates the scenario's assertions against an outcome and returns one message per failed assertion
+func Check(scenario *Scenario, outcome Outcome) []string {
+	var failures []string
+	fail := func(i int, format string, args ...any) {
+		failures = append(failures, fmt.Sprintf("assertions[%d]: ", i)+fmt.Sprintf(format, args...))
+	}
+
+	for i, assertion := range scenario.Assertions {
+		if assertion.Transfer != "" {
+			transfer, ok := outcome.Transfers[assertion.Transfer]
+			if !ok {
+				fail(i, "transfer %s did not run", assertion.Transfer)
+				continue
+			}
+
+			if assertion.Status != "" && !strings.EqualFold(transfer.Status, assertion.Status) {
+				fail(i, "transfer %s status = %s, want %s", assertion.Transfer, transfer.Status, assertion.Status)
+			}
+			if assertion.CompensationApplied != nil && transfer.CompensationApplied != *assertion.CompensationApplied {
+				fail(i, "transfer %s compensation_applied = %t, want %t", assertion.Transfer, transfer.CompensationApplied, *assertion.CompensationApplied)
+			}
+			if assertion.Step != nil && !matchesAnyStep(transfer.Steps, *assertion.Step) {
+				fail(i, "transfer %s has no step matching %+v", assertion.Transfer, *assertion.Step)
+			}
+
+			continue
+		}
+
+		before, after := outcome.Before[assertion.Account], outcome.After[assertion.Account]
+		if after == "" {
+			fail(i, "account %s not found", assertion.Account)
+			continue
+		}
+
+		if assertion.Balance != "" && !equalDecimals(after, assertion.Balance) {
+			fail(i, "account %s balance = %s, want %s", assertion.Account, after, assertion.Balance)
+		}
+		if assertion.BalanceChange != "" {
+			change, err := subtractDecimals(after, before)
+			if err != nil {
+				fail(i, "account %s: %v", assertion.Account, err)
+			} else if !equalDecimals(change, assertion.BalanceChange) {
+				fail(i, "account %s balance change = %s, want %s", assertion.Account, change, assertion.BalanceChange)
+			}
+		}
+	}
+
+	return failures
+}
+
+func matchesAnyStep(steps []demo.Step, want StepAssert) bool {
+	for _, step := range steps {
+		if want.Activity != "" && step.Activity != want.Activity {
+			continue
+		}
+		if want.Status != "" && !strings.EqualFold(step.Status, want.Status) {
+			continue
+		}
+		if step.Attempts < want.MinAttempts {
+			continue
+		}
+		if want.Compensation != nil && step.Compensation != *want.Compensation {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func equalDecimals(a, b string) bool {
+	x, okX := new(big.Rat).SetString(a)
+	y, okY := new(big.Rat).SetString(b)
+
+	return okX && okY && x.Cmp(y) == 0
+}
+
+// subtractDecimals returns a - b; a missing balance (an account opened by the scenario) counts as zero
+func subtractDecimals(a, b string) (string, error) {
+	if b == "" {
+		b = "0"
+	}
+
+	x, ok := new(big.Rat).SetString(a)
+	if !ok {
+		return "", fmt.Errorf("balance %q is not a decimal", a)
+	}
+	y, ok := new(big.Rat).SetString(b)
+	if !ok {
+		return "", fmt.Errorf("balance %q is not a decimal", b)
+	}
+
+	// Eight places covers every currency exponent; trailing zeros are dropped, e.g. "-100.00000000" -> "-100"
+	change := new(big.Rat).Sub(x, y).FloatString(8)
+
+	return strings.TrimSuffix(strings.TrimRight(change, "0"), "."), nil
+}
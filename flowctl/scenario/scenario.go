@@ -0,0 +1,227 @@
+// Package scenario runs learning scenarios written in YAML: the accounts to seed, the failure rules to switch,
+// the transfers to execute and the assertions on the end state. New scenarios need no Go code, just a file in
+// flowctl/scenarios.
+package scenario
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"flowctl/demo"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario is one YAML scenario file
+type Scenario struct {
+	Name         string         `yaml:"name"`
+	Description  string         `yaml:"description"`
+	Chaos        *bool          `yaml:"chaos"`         // Failure simulation on or off for the run; defaults to off
+	IsolateRules bool           `yaml:"isolate_rules"` // Disable every rule not listed in failure_rules, so random failures cannot interfere
+	Accounts     []demo.Account `yaml:"accounts"`
+	FailureRules []FailureRule  `yaml:"failure_rules"`
+	Transfers    []Transfer     `yaml:"transfers"`
+	Assertions   []Assertion    `yaml:"assertions"`
+}
+
+// FailureRule switches one of the hardcoded failure rules of a service for the run
+type FailureRule struct {
+	Service string `yaml:"service"` // "balance" or "transaction"
+	Rule    string `yaml:"rule"`    // Rule name as listed by GET /failure-simulation/scenarios
+	Enabled *bool  `yaml:"enabled"` // Defaults to true
+}
+
+// Transfer is executed through the gateway; transfers run one after the other, each to its end
+type Transfer struct {
+	Name        string `yaml:"name"` // Referenced by assertions
+	From        string `yaml:"from"`
+	To          string `yaml:"to"`
+	Amount      string `yaml:"amount"`
+	Currency    string `yaml:"currency"`
+	Description string `yaml:"description"`
+}
+
+// Assertion checks the end state of either a transfer or an account balance
+type Assertion struct {
+	// Transfer assertions
+	Transfer            string      `yaml:"transfer"`
+	Status              string      `yaml:"status"` // e.g. COMPLETED or COMPENSATED
+	CompensationApplied *bool       `yaml:"compensation_applied"`
+	Step                *StepAssert `yaml:"step"`
+
+	// Account assertions
+	Account       string `yaml:"account"`
+	Balance       string `yaml:"balance"`        // Balance after the run, e.g. "4900.00"
+	BalanceChange string `yaml:"balance_change"` // Balance after minus balance before, e.g. "-100"
+}
+
+// StepAssert checks one saga step of a transfer
+type StepAssert struct {
+	Activity     string `yaml:"activity"`
+	Status       string `yaml:"status"` // e.g. completed or failed
+	MinAttempts  int32  `yaml:"min_attempts"`
+	Compensation *bool  `yaml:"compensation"`
+}
+
+// Load reads and validates a scenario file
+func Load(path string) (*Scenario, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var scenario Scenario
+	if err := yaml.Unmarshal(content, &scenario); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if err := scenario.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &scenario, nil
+}
+
+// LoadAll loads every scenario in the given files and directories; directories are read for *.yaml and
+// *.yml files in name order
+func LoadAll(paths ...string) ([]*Scenario, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		for _, pattern := range []string{"*.yaml", "*.yml"} {
+			matches, err := filepath.Glob(filepath.Join(path, pattern))
+			if err != nil {
+				return nil, err
+			}
+			sort.Strings(matches)
+			files = append(files, matches...)
+		}
+	}
+
+	scenarios := make([]*Scenario, 0, len(files))
+	for _, file := range files {
+		scenario, err := Load(file)
+		if err != nil {
+			return nil, err
+		}
+
+		scenarios = append(scenarios, scenario)
+	}
+
+	return scenarios, nil
+}
+
+// Validate checks that the scenario can run: every field it needs is set and every assertion refers
+// to a transfer or account the scenario knows about
+func (scenario *Scenario) Validate() error {
+	var errs []error
+
+	if scenario.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if len(scenario.Transfers) == 0 {
+		errs = append(errs, errors.New("at least one transfer is required"))
+	}
+
+	for i, account := range scenario.Accounts {
+		if account.AccountNumber == "" || account.Currency == "" {
+			errs = append(errs, fmt.Errorf("accounts[%d]: account_number and currency are required", i))
+		}
+		if account.OpeningBalance != "" && !isDecimal(account.OpeningBalance) {
+			errs = append(errs, fmt.Errorf("accounts[%d]: opening_balance %q is not a decimal", i, account.OpeningBalance))
+		}
+	}
+
+	for i, rule := range scenario.FailureRules {
+		if rule.Service != "balance" && rule.Service != "transaction" {
+			errs = append(errs, fmt.Errorf("failure_rules[%d]: service must be balance or transaction", i))
+		}
+		if rule.Rule == "" {
+			errs = append(errs, fmt.Errorf("failure_rules[%d]: rule is required", i))
+		}
+	}
+
+	var transfers []string
+	for i, transfer := range scenario.Transfers {
+		if transfer.Name == "" || transfer.From == "" || transfer.To == "" || transfer.Currency == "" {
+			errs = append(errs, fmt.Errorf("transfers[%d]: name, from, to and currency are required", i))
+		}
+		if !isDecimal(transfer.Amount) {
+			errs = append(errs, fmt.Errorf("transfers[%d]: amount %q is not a decimal", i, transfer.Amount))
+		}
+		if slices.Contains(transfers, transfer.Name) {
+			errs = append(errs, fmt.Errorf("transfers[%d]: duplicate name %q", i, transfer.Name))
+		}
+
+		transfers = append(transfers, transfer.Name)
+	}
+
+	for i, assertion := range scenario.Assertions {
+		switch {
+		case assertion.Transfer != "" && assertion.Account != "":
+			errs = append(errs, fmt.Errorf("assertions[%d]: set either transfer or account, not both", i))
+		case assertion.Transfer != "":
+			if !slices.Contains(transfers, assertion.Transfer) {
+				errs = append(errs, fmt.Errorf("assertions[%d]: unknown transfer %q", i, assertion.Transfer))
+			}
+			if assertion.Status == "" && assertion.CompensationApplied == nil && assertion.Step == nil {
+				errs = append(errs, fmt.Errorf("assertions[%d]: nothing to check on transfer %q", i, assertion.Transfer))
+			}
+		case assertion.Account != "":
+			if assertion.Balance == "" && assertion.BalanceChange == "" {
+				errs = append(errs, fmt.Errorf("assertions[%d]: balance or balance_change is required", i))
+			}
+			for _, value := range []string{assertion.Balance, assertion.BalanceChange} {
+				if value != "" && !isDecimal(value) {
+					errs = append(errs, fmt.Errorf("assertions[%d]: %q is not a decimal", i, value))
+				}
+			}
+		default:
+			errs = append(errs, fmt.Errorf("assertions[%d]: transfer or account is required", i))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// accountNumbers returns every account the scenario touches, so their balances can be compared
+func (scenario *Scenario) accountNumbers() []string {
+	var accountNumbers []string
+	add := func(accountNumber string) {
+		if accountNumber != "" && !slices.Contains(accountNumbers, accountNumber) {
+			accountNumbers = append(accountNumbers, accountNumber)
+		}
+	}
+
+	for _, account := range scenario.Accounts {
+		add(account.AccountNumber)
+	}
+	for _, transfer := range scenario.Transfers {
+		add(transfer.From)
+		add(transfer.To)
+	}
+	for _, assertion := range scenario.Assertions {
+		add(assertion.Account)
+	}
+
+	return accountNumbers
+}
+
+func isDecimal(value string) bool {
+	_, ok := new(big.Rat).SetString(value)
+
+	return ok
+}
@@ -0,0 +1,98 @@
+package scenario
+
+import (
+	"strings"
+	"testing"
+
+	"flowctl/demo"
+)
+
+func TestLoadAllShippedScenarios(t *testing.T) {
+	t.Parallel()
+
+	scenarios, err := LoadAll("../scenarios")
+	if err != nil {
+		t.Fatalf("LoadAll() error = %v", err)
+	}
+	if len(scenarios) == 0 {
+		t.Fatal("LoadAll() found no scenarios in ../scenarios")
+	}
+
+	names := map[string]bool{}
+	for _, scenario := range scenarios {
+		if names[scenario.Name] {
+			t.Errorf("duplicate scenario name %q", scenario.Name)
+		}
+		names[scenario.Name] = true
+	}
+}
+
+func TestScenarioValidate(t *testing.T) {
+	t.Parallel()
+
+	scenario := &Scenario{
+		Transfers: []Transfer{
+			{Name: "t1", From: "DEMO00000001", To: "DEMO00000002", Amount: "ten", Currency: "USD"},
+		},
+		FailureRules: []FailureRule{{Service: "flowngine", Rule: "x"}},
+		Assertions: []Assertion{
+			{Transfer: "t2", Status: "COMPLETED"},
+			{Account: "DEMO00000001"},
+		},
+	}
+
+	err := scenario.Validate()
+	if err == nil {
+		t.Fatal("Validate() error = nil, want errors")
+	}
+
+	for _, want := range []string{"name is required", "amount \"ten\"", "service must be", "unknown transfer \"t2\"", "balance or balance_change"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() error missing %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	yes := true
+	scenario := &Scenario{
+		Assertions: []Assertion{
+			{Transfer: "t1", Status: "COMPENSATED", CompensationApplied: &yes},
+			{Transfer: "t1", Step: &StepAssert{Activity: "CreditAccount", Status: "failed", MinAttempts: 3}},
+			{Account: "DEMO00000001", BalanceChange: "0"},
+			{Account: "DEMO00000002", Balance: "1000"},
+		},
+	}
+
+	outcome := Outcome{
+		Transfers: map[string]demo.Transfer{
+			"t1": {
+				Status:              "COMPENSATED",
+				CompensationApplied: true,
+				Steps: []demo.Step{
+					{Activity: "DebitAccount", Status: "completed", Attempts: 1},
+					{Activity: "CreditAccount", Status: "failed", Attempts: 3},
+				},
+			},
+		},
+		Before: map[string]string{"DEMO00000001": "5000.00", "DEMO00000002": "1000.00"},
+		After:  map[string]string{"DEMO00000001": "5000", "DEMO00000002": "1000.00"},
+	}
+
+	if failures := Check(scenario, outcome); len(failures) != 0 {
+		t.Errorf("Check() = %v, want no failures", failures)
+	}
+
+	outcome.After["DEMO00000001"] = "4950.00"
+	outcome.Transfers["t1"] = demo.Transfer{Status: "COMPLETED"}
+
+	failures := Check(scenario, outcome)
+	if len(failures) != 4 {
+		t.Fatalf("Check() = %d failures, want 4: %v", len(failures), failures)
+	}
+	if !strings.Contains(failures[3], "balance change = -50, want 0") {
+		t.Errorf("Check() balance change failure = %q", failures[3])
+	}
+}
@@ -0,0 +1,374 @@
+// Package tui is the bubbletea program of the guided demo: a scenario menu, the saga steps of the running transfer
+// updated live, and the balances before and after the transfer.
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"flowctl/demo"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// statusColors maps transfer statuses (e.g. "COMPLETED") and step statuses (e.g. "retrying"), upper-cased,
+// to the ANSI color they are drawn in
+var statusColors = map[string]lipgloss.Color{
+	"COMPLETED":         "2",
+	"FAILED":            "1",
+	"TIMED_OUT":         "1",
+	"COMPENSATED":       "3",
+	"CANCELLED":         "3",
+	"EXPIRED":           "3",
+	"RETRYING":          "3",
+	"PROCESSING":        "6",
+	"RUNNING":           "6",
+	"PENDING":           "8",
+	"DELAYED":           "8",
+	"AWAITING_APPROVAL": "8",
+	"AWAITING_FUNDS":    "8",
+	"AWAITING_TRIGGER":  "8",
+	"SCHEDULED":         "8",
+}
+
+// Runner runs a scenario, reporting the transfer through progress; *demo.Runner implements it
+type Runner interface {
+	Run(ctx context.Context, scenario demo.Scenario, progress demo.Progress) (*demo.Result, error)
+}
+
+type state int
+
+const (
+	stateMenu state = iota
+	stateRunning
+	stateResult
+)
+
+// transferMsg carries a change of the followed transfer
+type transferMsg struct {
+	run      int
+	transfer demo.Transfer
+}
+
+// resultMsg carries the end of a scenario run
+type resultMsg struct {
+	run    int
+	result *demo.Result
+	err    error
+}
+
+// Model is the bubbletea model of the guided demo
+type Model struct {
+	ctx       context.Context
+	runner    Runner
+	scenarios []demo.Scenario
+	styles    styles
+
+	state  state
+	cursor int
+
+	// run numbers the scenario runs so the messages of a cancelled run are ignored
+	run      int
+	cancel   context.CancelFunc
+	updates  chan tea.Msg
+	scenario demo.Scenario
+	transfer demo.Transfer
+	result   *demo.Result
+	err      error
+}
+
+// NewModel creates the demo model; out is the terminal the program draws to and color turns ANSI styles on
+func NewModel(ctx context.Context, runner Runner, scenarios []demo.Scenario, out io.Writer, color bool) Model {
+	return Model{
+		ctx:       ctx,
+		runner:    runner,
+		scenarios: scenarios,
+		styles:    newStyles(out, color),
+	}
+}
+
+func (model Model) Init() tea.Cmd {
+	return nil
+}
+
+func (model Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return model.updateKey(msg)
+
+	case transferMsg:
+		if msg.run != model.run || model.state != stateRunning {
+			return model, nil
+		}
+		model.transfer = msg.transfer
+
+		return model, waitForUpdate(model.updates)
+
+	case resultMsg:
+		if msg.run != model.run || model.state != stateRunning {
+			return model, nil
+		}
+		model.cancel()
+		model.state = stateResult
+		model.result = msg.result
+		model.err = msg.err
+		if model.result == nil {
+			model.result = &demo.Result{Scenario: model.scenario, Transfer: model.transfer}
+		}
+
+		return model, nil
+	}
+
+	return model, nil
+}
+
+func (model Model) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+
+	switch model.state {
+	case stateMenu:
+		switch key {
+		case "ctrl+c", "q", "esc":
+			return model, tea.Quit
+		case "up", "k":
+			model.cursor = (model.cursor + len(model.scenarios) - 1) % len(model.scenarios)
+		case "down", "j", "tab":
+			model.cursor = (model.cursor + 1) % len(model.scenarios)
+		case "enter", " ":
+			return model.start(model.scenarios[model.cursor])
+		default:
+			if index, err := strconv.Atoi(key); err == nil && index >= 1 && index <= len(model.scenarios) {
+				model.cursor = index - 1
+				return model.start(model.scenarios[model.cursor])
+			}
+		}
+
+	case stateRunning:
+		// Stop following: the run ends with the context error and its result is shown
+		if key == "ctrl+c" || key == "esc" || key == "q" {
+			model.cancel()
+		}
+
+	case stateResult:
+		switch key {
+		case "ctrl+c", "q":
+			return model, tea.Quit
+		case "enter", "esc", " ":
+			model.state = stateMenu
+			model.result = nil
+			model.err = nil
+		}
+	}
+
+	return model, nil
+}
+
+// start runs the scenario in the background; its progress and result come back as messages
+func (model Model) start(scenario demo.Scenario) (tea.Model, tea.Cmd) {
+	ctx, cancel := context.WithCancel(model.ctx)
+
+	model.run++
+	model.state = stateRunning
+	model.cancel = cancel
+	model.scenario = scenario
+	model.transfer = demo.Transfer{}
+	model.updates = make(chan tea.Msg, 16)
+
+	run, updates, runner := model.run, model.updates, model.runner
+	go func() {
+		result, err := runner.Run(ctx, scenario, func(transfer demo.Transfer) {
+			select {
+			case updates <- transferMsg{run: run, transfer: transfer}:
+			case <-ctx.Done():
+			}
+		})
+		if errors.Is(err, context.Canceled) {
+			err = errors.New("stopped following the transfer")
+		}
+
+		updates <- resultMsg{run: run, result: result, err: err}
+	}()
+
+	return model, waitForUpdate(updates)
+}
+
+// waitForUpdate delivers the next message of the running scenario
+func waitForUpdate(updates chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-updates
+	}
+}
+
+func (model Model) View() string {
+	switch model.state {
+	case stateRunning:
+		return model.styles.RenderScenario(model.scenario) + model.styles.RenderTransfer(model.transfer)
+
+	case stateResult:
+		var builder strings.Builder
+
+		builder.WriteString(model.styles.RenderScenario(model.result.Scenario))
+		if model.result.Transfer.TransactionID != "" {
+			builder.WriteString(model.styles.RenderTransfer(model.result.Transfer))
+		}
+		if model.result.Before != nil {
+			builder.WriteString(model.styles.RenderBalances(model.result.Before, model.result.After))
+		}
+		if model.err != nil {
+			fmt.Fprintf(&builder, "\n%s\n", model.styles.err.Render("Error: "+model.err.Error()))
+		}
+		builder.WriteString(model.styles.help.Render("\nenter: back to the menu  q: quit") + "\n")
+
+		return builder.String()
+
+	default:
+		return model.styles.RenderMenu(model.scenarios, model.cursor)
+	}
+}
+
+// styles renders the screens of the demo
+type styles struct {
+	renderer *lipgloss.Renderer
+
+	title, dim, selected, err, help lipgloss.Style
+}
+
+func newStyles(out io.Writer, color bool) styles {
+	renderer := lipgloss.NewRenderer(out)
+	if !color {
+		renderer.SetColorProfile(termenv.Ascii)
+	}
+
+	return styles{
+		renderer: renderer,
+
+		title:    renderer.NewStyle().Bold(true),
+		dim:      renderer.NewStyle().Faint(true),
+		selected: renderer.NewStyle().Bold(true).Foreground(lipgloss.Color("6")),
+		err:      renderer.NewStyle().Foreground(lipgloss.Color("1")),
+		help:     renderer.NewStyle().Faint(true),
+	}
+}
+
+// RenderMenu renders the scenario menu with the cursor on scenarios[cursor]
+func (styles styles) RenderMenu(scenarios []demo.Scenario, cursor int) string {
+	var builder strings.Builder
+
+	builder.WriteString(styles.title.Render("Temporal flow demo: guided scenarios") + "\n\n")
+	for i, scenario := range scenarios {
+		pointer, title := " ", styles.title.Render(scenario.Title)
+		if i == cursor {
+			pointer, title = styles.selected.Render(">"), styles.selected.Render(scenario.Title)
+		}
+
+		fmt.Fprintf(&builder, "%s %d) %s\n", pointer, i+1, title)
+		fmt.Fprintf(&builder, "     %s\n", styles.dim.Render(scenario.Description))
+	}
+	builder.WriteString(styles.help.Render("\n↑/↓: move  enter or 1-"+strconv.Itoa(len(scenarios))+": run  q: quit") + "\n")
+
+	return builder.String()
+}
+
+// RenderScenario renders the heading of a running scenario
+func (styles styles) RenderScenario(scenario demo.Scenario) string {
+	chaos := "off"
+	if scenario.Chaos {
+		chaos = "on"
+	}
+
+	return fmt.Sprintf("%s\n%s\n\nTransfer %s %s from %s to %s (failure simulation %s)\n\n",
+		styles.title.Render(scenario.Title),
+		styles.dim.Render(scenario.Description),
+		scenario.Amount, scenario.Currency, scenario.FromAccount, scenario.ToAccount, chaos,
+	)
+}
+
+// RenderTransfer renders the transfer status and one line per saga step
+func (styles styles) RenderTransfer(transfer demo.Transfer) string {
+	var builder strings.Builder
+
+	if transfer.TransactionID == "" {
+		builder.WriteString(styles.dim.Render("  preparing the accounts and starting the transfer...") + "\n")
+		return builder.String()
+	}
+
+	fmt.Fprintf(&builder, "Transaction %s  workflow %s  status %s\n\n",
+		transfer.TransactionID, transfer.Workflow.WorkflowID, styles.status(transfer.Status))
+
+	if len(transfer.Steps) == 0 {
+		builder.WriteString(styles.dim.Render("  waiting for the first activity...") + "\n")
+	}
+	for _, step := range transfer.Steps {
+		activity := step.Activity
+		if step.Compensation {
+			activity += " (compensation)"
+		}
+
+		fmt.Fprintf(&builder, "  %-36s %-22s attempts %d\n", activity, styles.status(step.Status), step.Attempts)
+		if step.LastError != "" {
+			fmt.Fprintf(&builder, "      %s\n", styles.err.Render(step.LastError))
+		}
+	}
+
+	if transfer.ErrorMessage != "" {
+		fmt.Fprintf(&builder, "\n%s\n", styles.err.Render(transfer.ErrorMessage))
+	}
+	if !transfer.Terminal() {
+		builder.WriteString(styles.help.Render("\nrunning, press esc to stop following") + "\n")
+	}
+
+	return builder.String()
+}
+
+// RenderBalances renders the balances before and after the transfer side by side
+func (styles styles) RenderBalances(before, after map[string]string) string {
+	accountNumbers := make([]string, 0, len(before))
+	for accountNumber := range before {
+		accountNumbers = append(accountNumbers, accountNumber)
+	}
+	for accountNumber := range after {
+		if _, ok := before[accountNumber]; !ok {
+			accountNumbers = append(accountNumbers, accountNumber)
+		}
+	}
+	sort.Strings(accountNumbers)
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "\n%s\n", styles.title.Render("Balances"))
+	fmt.Fprintf(&builder, "  %-14s %-20s %-20s\n", "Account", "Before", "After")
+	for _, accountNumber := range accountNumbers {
+		fmt.Fprintf(&builder, "  %-14s %-20s %-20s\n", accountNumber, orDash(before[accountNumber]), orDash(after[accountNumber]))
+	}
+
+	return builder.String()
+}
+
+// status renders a status in its color, padded to the same width whether or not color is on
+func (styles styles) status(status string) string {
+	if status == "" {
+		status = "UNKNOWN"
+	}
+
+	style := styles.renderer.NewStyle()
+	if color, ok := statusColors[strings.ToUpper(status)]; ok {
+		style = style.Foreground(color)
+	}
+
+	return style.Render(fmt.Sprintf("%-12s", status))
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"flowctl/demo"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fakeRunner reports each transfer of steps, then returns result
+type fakeRunner struct {
+	steps  []demo.Transfer
+	result *demo.Result
+}
+
+func (runner fakeRunner) Run(ctx context.Context, scenario demo.Scenario, progress demo.Progress) (*demo.Result, error) {
+	for _, transfer := range runner.steps {
+		progress(transfer)
+	}
+
+	return runner.result, nil
+}
+
+func newTestModel(runner Runner) Model {
+	return NewModel(context.Background(), runner, demo.Scenarios, &bytes.Buffer{}, false)
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "up":
+		return tea.KeyMsg{Type: tea.KeyUp}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+}
+
+// drain feeds the messages of cmd back into the model until the run it started has finished
+func drain(t *testing.T, model Model, cmd tea.Cmd) Model {
+	t.Helper()
+
+	for cmd != nil {
+		updated, next := model.Update(cmd())
+		model, cmd = updated.(Model), next
+	}
+
+	return model
+}
+
+func TestModelMenu(t *testing.T) {
+	t.Parallel()
+
+	model := newTestModel(fakeRunner{})
+
+	updated, _ := model.Update(key("down"))
+	updated, _ = updated.Update(key("down"))
+	updated, _ = updated.Update(key("down"))
+	if cursor := updated.(Model).cursor; cursor != 0 {
+		t.Errorf("cursor after moving past the last scenario = %d, want 0", cursor)
+	}
+
+	updated, _ = updated.Update(key("up"))
+	if cursor := updated.(Model).cursor; cursor != len(demo.Scenarios)-1 {
+		t.Errorf("cursor after moving above the first scenario = %d, want %d", cursor, len(demo.Scenarios)-1)
+	}
+
+	view := updated.View()
+	if !strings.Contains(view, "> 3) "+demo.Scenarios[2].Title) {
+		t.Errorf("View() does not point at the selected scenario:\n%s", view)
+	}
+
+	_, cmd := updated.Update(key("q"))
+	if cmd == nil {
+		t.Fatal("q in the menu returned no command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Errorf("q in the menu did not quit")
+	}
+}
+
+func TestModelRun(t *testing.T) {
+	t.Parallel()
+
+	scenario := demo.Scenarios[1]
+	compensated := demo.Transfer{
+		TransactionID: "tx-1",
+		Status:        "COMPENSATED",
+		Steps: []demo.Step{
+			{Activity: "DebitAccount", Status: "completed", Attempts: 1},
+			{Activity: "CreditAccount", Status: "failed", Attempts: 3, LastError: "simulated failure"},
+			{Activity: "CompensateDebit", Status: "completed", Attempts: 1, Compensation: true},
+		},
+	}
+	runner := fakeRunner{
+		steps: []demo.Transfer{{TransactionID: "tx-1", Status: "PROCESSING"}, compensated},
+		result: &demo.Result{
+			Scenario: scenario,
+			Transfer: compensated,
+			Before:   map[string]string{scenario.FromAccount: "5000.00 USD"},
+			After:    map[string]string{scenario.FromAccount: "5000.00 USD"},
+		},
+	}
+
+	updated, cmd := newTestModel(runner).Update(key("2"))
+	model := updated.(Model)
+	if model.state != stateRunning || model.scenario.Name != scenario.Name {
+		t.Fatalf("pressing 2 did not start %s", scenario.Name)
+	}
+
+	model = drain(t, model, cmd)
+	if model.state != stateResult {
+		t.Fatalf("state after the run = %v, want the result", model.state)
+	}
+
+	view := model.View()
+	for _, want := range []string{"tx-1", "COMPENSATED", "CompensateDebit (compensation)", "Balances", "5000.00 USD"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q in:\n%s", want, view)
+		}
+	}
+
+	updated, _ = model.Update(key("enter"))
+	if updated.(Model).state != stateMenu {
+		t.Errorf("enter on the result did not return to the menu")
+	}
+}
+
+func TestRenderTransfer(t *testing.T) {
+	t.Parallel()
+
+	styles := newStyles(&bytes.Buffer{}, false)
+
+	transfer := demo.Transfer{
+		TransactionID: "tx-1",
+		Status:        "COMPENSATED",
+		Steps: []demo.Step{
+			{Activity: "DebitAccount", Status: "completed", Attempts: 1},
+			{Activity: "CreditAccount", Status: "failed", Attempts: 3, LastError: "simulated failure"},
+			{Activity: "CompensateDebit", Status: "completed", Attempts: 1, Compensation: true},
+		},
+	}
+
+	output := styles.RenderTransfer(transfer)
+
+	for _, want := range []string{"tx-1", "COMPENSATED", "CreditAccount", "attempts 3", "simulated failure", "CompensateDebit (compensation)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("RenderTransfer() missing %q in:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "running") {
+		t.Errorf("RenderTransfer() shows a finished transfer as running:\n%s", output)
+	}
+	if strings.Contains(output, "\033[") {
+		t.Errorf("RenderTransfer() wrote ANSI escapes with color off")
+	}
+}
+
+func TestRenderBalances(t *testing.T) {
+	t.Parallel()
+
+	styles := newStyles(&bytes.Buffer{}, false)
+
+	output := styles.RenderBalances(
+		map[string]string{"DEMO00000001": "5000.00 USD", "DEMO00000002": "1000.00 USD"},
+		map[string]string{"DEMO00000001": "4900.00 USD"},
+	)
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("RenderBalances() = %d lines, want 4:\n%s", len(lines), output)
+	}
+	if !strings.Contains(lines[2], "5000.00 USD") || !strings.Contains(lines[2], "4900.00 USD") {
+		t.Errorf("RenderBalances() first account line = %q", lines[2])
+	}
+	if !strings.HasSuffix(strings.TrimSpace(lines[3]), "-") {
+		t.Errorf("RenderBalances() missing after balance should render as -, got %q", lines[3])
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config holds the endpoints flowctl talks to. Every field is read from an environment variable,
+// falling back to the docker-compose defaults so the demo works without any setup.
+type Config struct {
+	GatewayURL     string        // FLOWCTL_GATEWAY_URL: api-gateway REST API
+	BalanceURL     string        // FLOWCTL_BALANCE_URL: svc-balance REST API
+	TransactionURL string        // FLOWCTL_TRANSACTION_URL: svc-transaction REST API
+	FlowngineURL   string        // FLOWCTL_FLOWNGINE_URL: flowngine metrics port, which serves its failure simulation
+	Actor          string        // FLOWCTL_ACTOR: sent as X-Actor and recorded in the admin audit
+	Timeout        time.Duration // FLOWCTL_TIMEOUT_SECONDS: per request
+}
+
+// LoadConfig reads the configuration from the environment
+func LoadConfig() (config Config, err error) {
+	config = Config{
+		GatewayURL:     getEnv("FLOWCTL_GATEWAY_URL", "http://localhost:4000"),
+		BalanceURL:     getEnv("FLOWCTL_BALANCE_URL", "http://localhost:4020"),
+		TransactionURL: getEnv("FLOWCTL_TRANSACTION_URL", "http://localhost:4010"),
+		FlowngineURL:   getEnv("FLOWCTL_FLOWNGINE_URL", "http://localhost:8083"),
+		Actor:          getEnv("FLOWCTL_ACTOR", "flowctl"),
+	}
+
+	timeoutSeconds, err := strconv.Atoi(getEnv("FLOWCTL_TIMEOUT_SECONDS", "35"))
+	if err != nil || timeoutSeconds <= 0 {
+		return config, fmt.Errorf("FLOWCTL_TIMEOUT_SECONDS must be a positive number of seconds")
+	}
+	config.Timeout = time.Duration(timeoutSeconds) * time.Second
+
+	return config, nil
+}
+
+func getEnv(key string, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+
+	return fallback
+}
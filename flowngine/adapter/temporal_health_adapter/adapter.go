@@ -0,0 +1,162 @@
+package temporal_health_adapter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+)
+
+// Task queue types, as reported in TaskQueueTypeInfo
+const (
+	TaskQueueTypeWorkflow = "workflow"
+	TaskQueueTypeActivity = "activity"
+)
+
+// taskQueueTypes maps the task queue types to their SDK values
+var taskQueueTypes = map[string]client.TaskQueueType{
+	TaskQueueTypeWorkflow: client.TaskQueueTypeWorkflow,
+	TaskQueueTypeActivity: client.TaskQueueTypeActivity,
+}
+
+// Adapter reads the health of a Temporal namespace and its task queues through the Temporal frontend
+type Adapter struct {
+	logger *logrus.Logger
+
+	client    client.Client
+	namespace string
+}
+
+// NewAdapter creates a health adapter of namespace; an empty namespace is the default one
+func NewAdapter(logger *logrus.Logger, temporalClient client.Client, namespace string) *Adapter {
+	if namespace == "" {
+		namespace = client.DefaultNamespace
+	}
+
+	return &Adapter{
+		logger: logger,
+
+		client:    temporalClient,
+		namespace: namespace,
+	}
+}
+
+// NamespaceInfo is the state of the namespace
+type NamespaceInfo struct {
+	Name             string `json:"name"`
+	State            string `json:"state"`
+	RetentionSeconds int64  `json:"retention_seconds"`
+}
+
+// Poller is a worker that polled the task queue recently
+type Poller struct {
+	Identity       string    `json:"identity"`
+	LastAccessTime time.Time `json:"last_access_time"`
+}
+
+// TaskQueueTypeInfo is the workers and backlog of one type of a task queue, summed over its build IDs
+type TaskQueueTypeInfo struct {
+	Type              string   `json:"type"` // workflow or activity
+	Pollers           []Poller `json:"pollers"`
+	Backlog           int64    `json:"backlog"`             // Approximate tasks waiting for a worker
+	BacklogAgeSeconds float64  `json:"backlog_age_seconds"` // Age of the oldest waiting task: the schedule-to-start latency tasks see now
+	TasksAddRate      float64  `json:"tasks_add_rate"`      // Tasks added per second over the last 30 seconds
+	TasksDispatchRate float64  `json:"tasks_dispatch_rate"` // Tasks handed to workers per second over the last 30 seconds
+}
+
+// CheckHealth returns an error when the Temporal frontend does not answer
+func (adapter *Adapter) CheckHealth(ctx context.Context) error {
+	if _, err := adapter.client.CheckHealth(ctx, &client.CheckHealthRequest{}); err != nil {
+		return fmt.Errorf("temporal frontend unhealthy: %w", err)
+	}
+
+	return nil
+}
+
+// DescribeNamespace returns the state and retention of the namespace
+func (adapter *Adapter) DescribeNamespace(ctx context.Context) (*NamespaceInfo, error) {
+	response, err := adapter.client.WorkflowService().DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: adapter.namespace,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe namespace %s: %w", adapter.namespace, err)
+	}
+
+	info := &NamespaceInfo{
+		Name:  response.GetNamespaceInfo().GetName(),
+		State: response.GetNamespaceInfo().GetState().String(),
+	}
+	if retention := response.GetConfig().GetWorkflowExecutionRetentionTtl(); retention != nil {
+		info.RetentionSeconds = int64(retention.AsDuration().Seconds())
+	}
+
+	return info, nil
+}
+
+// DescribeTaskQueue returns the pollers and backlog of every type in types of the task queue named name
+func (adapter *Adapter) DescribeTaskQueue(ctx context.Context, name string, types []string) ([]TaskQueueTypeInfo, error) {
+	sdkTypes := make([]client.TaskQueueType, 0, len(types))
+	for _, taskQueueType := range types {
+		sdkType, ok := taskQueueTypes[taskQueueType]
+		if !ok {
+			return nil, fmt.Errorf("unknown task queue type %q", taskQueueType)
+		}
+		sdkTypes = append(sdkTypes, sdkType)
+	}
+
+	description, err := adapter.client.DescribeTaskQueueEnhanced(ctx, client.DescribeTaskQueueEnhancedOptions{
+		TaskQueue:      name,
+		TaskQueueTypes: sdkTypes,
+		ReportPollers:  true,
+		ReportStats:    true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe task queue %s: %w", name, err)
+	}
+
+	infos := make([]TaskQueueTypeInfo, 0, len(types))
+	for i, taskQueueType := range types {
+		infos = append(infos, summarizeTaskQueueType(taskQueueType, sdkTypes[i], description))
+	}
+
+	return infos, nil
+}
+
+// summarizeTaskQueueType sums the backlog and rates of one type over the build IDs of description, takes the age of
+// its oldest task and lists each poller once
+func summarizeTaskQueueType(taskQueueType string, sdkType client.TaskQueueType, description client.TaskQueueDescription) TaskQueueTypeInfo {
+	info := TaskQueueTypeInfo{Type: taskQueueType, Pollers: []Poller{}}
+
+	pollers := map[string]Poller{}
+	for _, version := range description.VersionsInfo {
+		typeInfo, ok := version.TypesInfo[sdkType]
+		if !ok {
+			continue
+		}
+
+		for _, poller := range typeInfo.Pollers {
+			if seen, ok := pollers[poller.Identity]; !ok || poller.LastAccessTime.After(seen.LastAccessTime) {
+				pollers[poller.Identity] = Poller{Identity: poller.Identity, LastAccessTime: poller.LastAccessTime}
+			}
+		}
+
+		if stats := typeInfo.Stats; stats != nil {
+			info.Backlog += stats.ApproximateBacklogCount
+			info.BacklogAgeSeconds = max(info.BacklogAgeSeconds, stats.ApproximateBacklogAge.Seconds())
+			info.TasksAddRate += float64(stats.TasksAddRate)
+			info.TasksDispatchRate += float64(stats.TasksDispatchRate)
+		}
+	}
+
+	for _, poller := range pollers {
+		info.Pollers = append(info.Pollers, poller)
+	}
+	slices.SortFunc(info.Pollers, func(a, b Poller) int { return strings.Compare(a.Identity, b.Identity) })
+
+	return info
+}
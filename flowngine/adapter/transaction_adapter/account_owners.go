@@ -0,0 +1,33 @@
+package transaction_adapter
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AccountOwnerRecord is one element of the "data" payload returned by GET /accounts/:id/owners
+type AccountOwnerRecord struct {
+	AccountID string `json:"account_id"`
+	OwnerID   string `json:"owner_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+func (adapter *Adapter) ListAccountOwners(ctx context.Context, accountID string) (response []AccountOwnerRecord, err error) {
+	const op = "transaction_adapter.Adapter.ListAccountOwners"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": accountID,
+	})
+
+	if err = adapter.do(ctx, http.MethodGet, "/accounts/"+url.PathEscape(accountID)+"/owners", nil, &response); err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,98 @@
+package transaction_adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Adapter is a wrapper around the svc-transaction REST API
+type Adapter struct {
+	serviceName string
+
+	logger *logrus.Logger
+
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewAdapter creates a new REST adapter for svc-transaction
+func NewAdapter(
+	serviceName string,
+	logger *logrus.Logger,
+	baseURL string,
+	timeout time.Duration,
+) *Adapter {
+	return &Adapter{
+		serviceName: serviceName,
+
+		logger: logger,
+
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// ResponseError is returned when svc-transaction answers with a non-2xx status code
+type ResponseError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("svc-transaction returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// envelope mirrors the {"message", "data"} / {"error"} response shape used by svc-transaction
+type envelope struct {
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Error   string          `json:"error"`
+}
+
+// do sends a JSON request and decodes the "data" field of the response into out
+func (adapter *Adapter) do(ctx context.Context, method string, path string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, adapter.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := adapter.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", adapter.serviceName, err)
+	}
+	defer resp.Body.Close()
+
+	var env envelope
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &ResponseError{StatusCode: resp.StatusCode, Message: env.Error}
+	}
+
+	if out != nil && len(env.Data) > 0 {
+		if err := json.Unmarshal(env.Data, out); err != nil {
+			return fmt.Errorf("failed to decode response data: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package transaction_adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AdminAuditQuery holds the query parameters of GET /admin-audit.
+// Zero-valued fields are left out of the query string.
+type AdminAuditQuery struct {
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	From         time.Time
+	To           time.Time
+	Limit        int32
+}
+
+func (query AdminAuditQuery) encode() string {
+	values := url.Values{}
+	for name, value := range map[string]string{
+		"actor":         query.Actor,
+		"action":        query.Action,
+		"resource_type": query.ResourceType,
+		"resource_id":   query.ResourceID,
+	} {
+		if value != "" {
+			values.Set(name, value)
+		}
+	}
+	if !query.From.IsZero() {
+		values.Set("from", query.From.Format(time.RFC3339))
+	}
+	if !query.To.IsZero() {
+		values.Set("to", query.To.Format(time.RFC3339))
+	}
+	if query.Limit > 0 {
+		values.Set("limit", strconv.Itoa(int(query.Limit)))
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	return "?" + values.Encode()
+}
+
+// AdminAuditRecord is one element of the "data" payload returned by GET /admin-audit
+type AdminAuditRecord struct {
+	ID           string          `json:"id"`
+	Actor        string          `json:"actor"`
+	Action       string          `json:"action"`
+	Service      string          `json:"service"`
+	ResourceType string          `json:"resource_type"`
+	ResourceID   string          `json:"resource_id"`
+	Before       json.RawMessage `json:"before"`
+	After        json.RawMessage `json:"after"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+func (adapter *Adapter) ListAdminAudits(ctx context.Context, query AdminAuditQuery) (response []AdminAuditRecord, err error) {
+	const op = "transaction_adapter.Adapter.ListAdminAudits"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":  op,
+		"query": query.encode(),
+	})
+
+	if err = adapter.do(ctx, http.MethodGet, "/admin-audit"+query.encode(), nil, &response); err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,117 @@
+package transaction_adapter
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CompensationAuditQuery holds the query parameters of the /compensation-audit endpoints.
+// Zero-valued fields are left out of the query string.
+type CompensationAuditQuery struct {
+	Status     string
+	Type       string
+	WorkflowID string
+	From       time.Time
+	To         time.Time
+	Limit      int32
+}
+
+func (query CompensationAuditQuery) encode() string {
+	values := url.Values{}
+	if query.Status != "" {
+		values.Set("status", query.Status)
+	}
+	if query.Type != "" {
+		values.Set("type", query.Type)
+	}
+	if query.WorkflowID != "" {
+		values.Set("workflow_id", query.WorkflowID)
+	}
+	if !query.From.IsZero() {
+		values.Set("from", query.From.Format(time.RFC3339))
+	}
+	if !query.To.IsZero() {
+		values.Set("to", query.To.Format(time.RFC3339))
+	}
+	if query.Limit > 0 {
+		values.Set("limit", strconv.Itoa(int(query.Limit)))
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	return "?" + values.Encode()
+}
+
+// CompensationAuditRecord is one element of the "data" payload returned by GET /compensation-audit
+type CompensationAuditRecord struct {
+	ID                        string     `json:"id"`
+	WorkflowID                string     `json:"workflow_id"`
+	RunID                     string     `json:"run_id"`
+	TransferID                string     `json:"transfer_id"`
+	OriginalTransactionID     string     `json:"original_transaction_id"`
+	CompensationTransactionID string     `json:"compensation_transaction_id"`
+	CompensationReason        string     `json:"compensation_reason"`
+	CompensationType          string     `json:"compensation_type"`
+	CompensationStatus        string     `json:"compensation_status"`
+	CompensationAttempts      int32      `json:"compensation_attempts"`
+	CreatedAt                 time.Time  `json:"created_at"`
+	UpdatedAt                 time.Time  `json:"updated_at"`
+	CompletedAt               *time.Time `json:"completed_at"`
+	FailureReason             string     `json:"failure_reason"`
+	TimeoutDurationMs         int32      `json:"timeout_duration_ms"`
+}
+
+// CompensationStatsResponse is the "data" payload returned by GET /compensation-audit/stats
+type CompensationStatsResponse struct {
+	TotalCompensations     int64     `json:"total_compensations"`
+	CompletedCompensations int64     `json:"completed_compensations"`
+	FailedCompensations    int64     `json:"failed_compensations"`
+	TimeoutCompensations   int64     `json:"timeout_compensations"`
+	ManualCompensations    int64     `json:"manual_compensations"`
+	PendingCompensations   int64     `json:"pending_compensations"`
+	AverageAttempts        float64   `json:"average_attempts"`
+	From                   time.Time `json:"from"`
+	To                     time.Time `json:"to"`
+}
+
+func (adapter *Adapter) ListCompensationAudits(ctx context.Context, query CompensationAuditQuery) (response []CompensationAuditRecord, err error) {
+	const op = "transaction_adapter.Adapter.ListCompensationAudits"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":  op,
+		"query": query.encode(),
+	})
+
+	if err = adapter.do(ctx, http.MethodGet, "/compensation-audit"+query.encode(), nil, &response); err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (adapter *Adapter) GetCompensationStats(ctx context.Context, query CompensationAuditQuery) (response *CompensationStatsResponse, err error) {
+	const op = "transaction_adapter.Adapter.GetCompensationStats"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":  op,
+		"query": query.encode(),
+	})
+
+	response = &CompensationStatsResponse{}
+	if err = adapter.do(ctx, http.MethodGet, "/compensation-audit/stats"+query.encode(), nil, response); err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,77 @@
+package transaction_adapter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+)
+
+// InternalTransferRequest is the request body for POST /internal-transfers
+type InternalTransferRequest struct {
+	TransferID    string `json:"transfer_id"`
+	FromAccountID string `json:"from_account_id"`
+	ToAccountID   string `json:"to_account_id"`
+	Amount        string `json:"amount"`
+	Currency      string `json:"currency"`
+	Description   string `json:"description,omitempty"`
+	RequestedBy   string `json:"requested_by,omitempty"`
+}
+
+// InternalTransferResponse is the "data" payload returned by the internal transfer endpoints
+type InternalTransferResponse struct {
+	TransferID          string  `json:"transfer_id"`
+	Status              string  `json:"status"`
+	FromAccountID       string  `json:"from_account_id"`
+	ToAccountID         string  `json:"to_account_id"`
+	Amount              string  `json:"amount"`
+	Currency            string  `json:"currency"`
+	Description         *string `json:"description,omitempty"`
+	DebitTransactionID  string  `json:"debit_transaction_id"`
+	CreditTransactionID string  `json:"credit_transaction_id"`
+	CreatedAt           string  `json:"created_at"`
+	CompletedAt         *string `json:"completed_at,omitempty"`
+	DurationMs          int64   `json:"duration_ms"`
+}
+
+func (adapter *Adapter) ExecuteInternalTransfer(ctx context.Context, request *InternalTransferRequest) (response *InternalTransferResponse, err error) {
+	const op = "transaction_adapter.Adapter.ExecuteInternalTransfer"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	response = &InternalTransferResponse{}
+	if err = adapter.do(ctx, http.MethodPost, "/internal-transfers", request, response); err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("response", fmt.Sprintf("%+v", response)).Info()
+
+	return response, nil
+}
+
+func (adapter *Adapter) GetInternalTransfer(ctx context.Context, transferID string) (response *InternalTransferResponse, err error) {
+	const op = "transaction_adapter.Adapter.GetInternalTransfer"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"transfer_id": transferID,
+	})
+
+	response = &InternalTransferResponse{}
+	if err = adapter.do(ctx, http.MethodGet, "/internal-transfers/"+url.PathEscape(transferID), nil, response); err != nil {
+		logger.WithError(err).Debug()
+
+		return nil, err
+	}
+
+	return response, nil
+}
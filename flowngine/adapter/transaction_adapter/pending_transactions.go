@@ -0,0 +1,38 @@
+package transaction_adapter
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// PendingTransactionStatsResponse is the "data" payload returned by GET /pending-transactions/stats
+type PendingTransactionStatsResponse struct {
+	Pending           int64      `json:"pending"`
+	Stale             int64      `json:"stale"`
+	StaleAfterSeconds int64      `json:"stale_after_seconds"`
+	SweepRuns         int64      `json:"sweep_runs"`
+	SweptCompleted    int64      `json:"swept_completed"`
+	SweptFailed       int64      `json:"swept_failed"`
+	SweepErrors       int64      `json:"sweep_errors"`
+	LastSweepAt       *time.Time `json:"last_sweep_at"`
+}
+
+func (adapter *Adapter) GetPendingTransactionStats(ctx context.Context) (response *PendingTransactionStatsResponse, err error) {
+	const op = "transaction_adapter.Adapter.GetPendingTransactionStats"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	response = &PendingTransactionStatsResponse{}
+	if err = adapter.do(ctx, http.MethodGet, "/pending-transactions/stats", nil, response); err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return response, nil
+}
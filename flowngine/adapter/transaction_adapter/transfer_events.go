@@ -0,0 +1,45 @@
+package transaction_adapter
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TransferEventResponse is one element of the "data" payload returned by GET /transfer-events/:transfer_id
+type TransferEventResponse struct {
+	TransferID     string `json:"transfer_id"`
+	WorkflowID     string `json:"workflow_id"`
+	RunID          string `json:"run_id"`
+	SequenceNumber int32  `json:"sequence_number"`
+	EventType      string `json:"event_type"`
+	Status         string `json:"status"`
+	Step           string `json:"step,omitempty"`
+	FromAccount    string `json:"from_account"`
+	ToAccount      string `json:"to_account"`
+	Amount         string `json:"amount"`
+	Currency       string `json:"currency"`
+	Description    string `json:"description"`
+	ErrorMessage   string `json:"error_message,omitempty"`
+	OccurredAt     string `json:"occurred_at"`
+	RecordedAt     string `json:"recorded_at"`
+}
+
+func (adapter *Adapter) ListTransferEvents(ctx context.Context, transferID string) (response []TransferEventResponse, err error) {
+	const op = "transaction_adapter.Adapter.ListTransferEvents"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"transfer_id": transferID,
+	})
+
+	if err = adapter.do(ctx, http.MethodGet, "/transfer-events/"+url.PathEscape(transferID), nil, &response); err != nil {
+		logger.WithError(err).Debug()
+
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,46 @@
+package transaction_adapter
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TransferReadModelResponse is the "data" payload returned by GET /transfers/:transfer_id
+type TransferReadModelResponse struct {
+	TransferID    string `json:"transfer_id"`
+	WorkflowID    string `json:"workflow_id"`
+	RunID         string `json:"run_id"`
+	Status        string `json:"status"`
+	LastEventType string `json:"last_event_type"`
+	LastStep      string `json:"last_step,omitempty"`
+	FromAccount   string `json:"from_account"`
+	ToAccount     string `json:"to_account"`
+	Amount        string `json:"amount"`
+	Currency      string `json:"currency"`
+	Description   string `json:"description"`
+	ErrorMessage  string `json:"error_message,omitempty"`
+	StartedAt     string `json:"started_at"`
+	FinishedAt    string `json:"finished_at,omitempty"`
+	UpdatedAt     string `json:"updated_at"`
+	ProjectedAt   string `json:"projected_at"`
+}
+
+func (adapter *Adapter) GetTransferReadModel(ctx context.Context, transferID string) (response *TransferReadModelResponse, err error) {
+	const op = "transaction_adapter.Adapter.GetTransferReadModel"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"transfer_id": transferID,
+	})
+
+	if err = adapter.do(ctx, http.MethodGet, "/transfers/"+url.PathEscape(transferID), nil, &response); err != nil {
+		logger.WithError(err).Debug()
+
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,84 @@
+package transaction_adapter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/sirupsen/logrus"
+)
+
+// AssignTransferReferenceRequest is the request body for POST /transfer-references
+type AssignTransferReferenceRequest struct {
+	TransferID string `json:"transfer_id"`
+	BranchCode string `json:"branch_code"`
+}
+
+// TransferReferenceResponse is the "data" payload returned by the transfer reference endpoints
+type TransferReferenceResponse struct {
+	Reference      string `json:"reference"`
+	TransferID     string `json:"transfer_id"`
+	BranchCode     string `json:"branch_code"`
+	BusinessDate   string `json:"business_date"`
+	SequenceNumber int32  `json:"sequence_number"`
+	CreatedAt      string `json:"created_at"`
+}
+
+func (adapter *Adapter) AssignTransferReference(ctx context.Context, request *AssignTransferReferenceRequest) (response *TransferReferenceResponse, err error) {
+	const op = "transaction_adapter.Adapter.AssignTransferReference"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	response = &TransferReferenceResponse{}
+	if err = adapter.do(ctx, http.MethodPost, "/transfer-references", request, response); err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("response", fmt.Sprintf("%+v", response)).Info()
+
+	return response, nil
+}
+
+func (adapter *Adapter) GetTransferReference(ctx context.Context, reference string) (response *TransferReferenceResponse, err error) {
+	const op = "transaction_adapter.Adapter.GetTransferReference"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":      op,
+		"reference": reference,
+	})
+
+	response = &TransferReferenceResponse{}
+	if err = adapter.do(ctx, http.MethodGet, "/transfer-references/"+url.PathEscape(reference), nil, response); err != nil {
+		logger.WithError(err).Debug()
+
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (adapter *Adapter) GetTransferReferenceByTransferID(ctx context.Context, transferID string) (response *TransferReferenceResponse, err error) {
+	const op = "transaction_adapter.Adapter.GetTransferReferenceByTransferID"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"transfer_id": transferID,
+	})
+
+	response = &TransferReferenceResponse{}
+	if err = adapter.do(ctx, http.MethodGet, "/transfer-references/transfers/"+url.PathEscape(transferID), nil, response); err != nil {
+		logger.WithError(err).Debug()
+
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"flowngine/api/pb"
+	"flowngine/service"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (api *Api) ListAdminAudit(ctx context.Context, request *pb.ListAdminAuditRequest) (*pb.ListAdminAuditResponse, error) {
+	const op = "api.Api.ListAdminAudit"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.ListAdminAuditParams{
+		Actor:        request.Actor,
+		Action:       request.Action,
+		ResourceType: request.ResourceType,
+		ResourceID:   request.ResourceId,
+		Limit:        request.Limit,
+	}
+	if request.GetFrom() != nil {
+		params.From = request.GetFrom().AsTime()
+	}
+	if request.GetTo() != nil {
+		params.To = request.GetTo().AsTime()
+	}
+
+	results, err := api.service.ListAdminAudit(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.ListAdminAuditResponse{
+		Records: make([]*pb.AdminAuditRecord, 0, len(results.Records)),
+	}
+
+	for _, record := range results.Records {
+		response.Records = append(response.Records, &pb.AdminAuditRecord{
+			Id:           record.ID,
+			Actor:        record.Actor,
+			Action:       record.Action,
+			Service:      record.Service,
+			ResourceType: record.ResourceType,
+			ResourceId:   record.ResourceID,
+			Before:       string(record.Before),
+			After:        string(record.After),
+			CreatedAt:    timestamppb.New(record.CreatedAt),
+		})
+	}
+
+	logger.WithField("records", len(response.Records)).Info()
+
+	return response, nil
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"flowngine/api/pb"
+	"flowngine/service"
+
+	"github.com/sirupsen/logrus"
+)
+
+func (api *Api) ApproveTransfer(ctx context.Context, request *pb.ApproveTransferRequest) (*pb.ApproveTransferResponse, error) {
+	const op = "api.Api.ApproveTransfer"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.ApproveTransferParams{
+		TransactionID: request.TransactionId,
+		ApprovedBy:    request.ApprovedBy,
+	}
+
+	results, err := api.service.ApproveTransfer(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.ApproveTransferResponse{
+		Success: results.Success,
+		Message: results.Message,
+	}
+
+	logger.WithField("response", fmt.Sprintf("%+v", response)).Info()
+
+	return response, nil
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"flowngine/api/pb"
+	"flowngine/service"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (api *Api) ListCompensations(ctx context.Context, request *pb.ListCompensationsRequest) (*pb.ListCompensationsResponse, error) {
+	const op = "api.Api.ListCompensations"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.ListCompensationsParams{
+		Filter:     newCompensationFilter(request.GetFilter()),
+		WorkflowID: request.WorkflowId,
+		Limit:      request.Limit,
+	}
+
+	results, err := api.service.ListCompensations(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.ListCompensationsResponse{
+		Compensations: make([]*pb.CompensationRecord, 0, len(results.Compensations)),
+	}
+
+	for _, record := range results.Compensations {
+		compensation := &pb.CompensationRecord{
+			Id:                        record.ID,
+			WorkflowId:                record.WorkflowID,
+			RunId:                     record.RunID,
+			TransferId:                record.TransferID,
+			OriginalTransactionId:     record.OriginalTransactionID,
+			CompensationTransactionId: record.CompensationTransactionID,
+			Reason:                    record.CompensationReason,
+			Type:                      record.CompensationType,
+			Status:                    record.CompensationStatus,
+			Attempts:                  record.CompensationAttempts,
+			CreatedAt:                 timestamppb.New(record.CreatedAt),
+			UpdatedAt:                 timestamppb.New(record.UpdatedAt),
+			FailureReason:             record.FailureReason,
+			TimeoutDurationMs:         record.TimeoutDurationMs,
+		}
+		if record.CompletedAt != nil {
+			compensation.CompletedAt = timestamppb.New(*record.CompletedAt)
+		}
+
+		response.Compensations = append(response.Compensations, compensation)
+	}
+
+	logger.WithField("compensations", len(response.Compensations)).Info()
+
+	return response, nil
+}
+
+func (api *Api) GetCompensationStats(ctx context.Context, request *pb.GetCompensationStatsRequest) (*pb.GetCompensationStatsResponse, error) {
+	const op = "api.Api.GetCompensationStats"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.GetCompensationStatsParams{
+		Filter: newCompensationFilter(request.GetFilter()),
+	}
+
+	results, err := api.service.GetCompensationStats(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.GetCompensationStatsResponse{
+		Total:           results.Total,
+		Completed:       results.Completed,
+		Failed:          results.Failed,
+		Timeout:         results.Timeout,
+		ManualRequired:  results.ManualRequired,
+		Pending:         results.Pending,
+		AverageAttempts: results.AverageAttempts,
+		From:            timestamppb.New(results.From),
+		To:              timestamppb.New(results.To),
+	}
+
+	logger.WithField("response", fmt.Sprintf("%+v", response)).Info()
+
+	return response, nil
+}
+
+// newCompensationFilter converts the wire filter; unset timestamps stay zero so they are not applied
+func newCompensationFilter(filter *pb.CompensationFilter) service.CompensationFilter {
+	result := service.CompensationFilter{
+		Status: filter.GetStatus(),
+		Type:   filter.GetType(),
+	}
+	if filter.GetFrom() != nil {
+		result.From = filter.GetFrom().AsTime()
+	}
+	if filter.GetTo() != nil {
+		result.To = filter.GetTo().AsTime()
+	}
+
+	return result
+}
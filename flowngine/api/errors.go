@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"flowngine/api/pb"
+	"flowngine/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serviceError maps a service error to the status code and error detail returned to clients
+type serviceError struct {
+	err       error
+	code      codes.Code
+	errorCode string
+	retryable bool
+}
+
+var serviceErrors = []serviceError{
+	{err: service.ErrInvalidParameters, code: codes.InvalidArgument, errorCode: "INVALID_PARAMETERS"},
+	{err: service.ErrInvalidCompensationFilter, code: codes.InvalidArgument, errorCode: "INVALID_FILTER"},
+	{err: service.ErrInvalidAdminAuditFilter, code: codes.InvalidArgument, errorCode: "INVALID_FILTER"},
+	{err: service.ErrInvalidAccountID, code: codes.InvalidArgument, errorCode: "INVALID_ACCOUNT_ID"},
+	{err: service.ErrTransferNotFound, code: codes.NotFound, errorCode: "TRANSFER_NOT_FOUND"},
+	{err: service.ErrTransferAlreadyExists, code: codes.AlreadyExists, errorCode: "TRANSFER_ALREADY_EXISTS"},
+	{err: service.ErrTransferBatchNotFound, code: codes.NotFound, errorCode: "TRANSFER_BATCH_NOT_FOUND"},
+	{err: service.ErrMoneyRequestNotFound, code: codes.NotFound, errorCode: "MONEY_REQUEST_NOT_FOUND"},
+	{err: service.ErrMoneyRequestAlreadyExists, code: codes.AlreadyExists, errorCode: "MONEY_REQUEST_ALREADY_EXISTS"},
+	{err: service.ErrEscrowNotFound, code: codes.NotFound, errorCode: "ESCROW_NOT_FOUND"},
+	{err: service.ErrTemporalUnavailable, code: codes.Unavailable, errorCode: "SERVICE_UNAVAILABLE", retryable: true},
+	{err: service.ErrAccountOwnersUnavailable, code: codes.Unavailable, errorCode: "SERVICE_UNAVAILABLE", retryable: true},
+	{err: context.DeadlineExceeded, code: codes.DeadlineExceeded, errorCode: "TIMEOUT", retryable: true},
+}
+
+// ErrorDetailUnary is a gRPC unary interceptor that turns service errors into statuses carrying an ErrorDetail,
+// so the gateway can map them without parsing messages. Errors that are already statuses, or that have no
+// mapping, are returned unchanged.
+func (api *Api) ErrorDetailUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		err = statusError(err)
+	}
+
+	return resp, err
+}
+
+// statusError returns err as a status with an ErrorDetail when it matches one of the serviceErrors
+func statusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code, detail, ok := serviceErrorDetail(err)
+	if !ok {
+		return err
+	}
+
+	return withErrorDetail(status.New(code, err.Error()), detail)
+}
+
+// serviceErrorDetail returns the status code and ErrorDetail of err when it matches one of the serviceErrors
+func serviceErrorDetail(err error) (codes.Code, *pb.ErrorDetail, bool) {
+	for _, mapping := range serviceErrors {
+		if !errors.Is(err, mapping.err) {
+			continue
+		}
+
+		detail := &pb.ErrorDetail{
+			Code:      mapping.errorCode,
+			Retryable: mapping.retryable,
+		}
+
+		var violation *service.FieldViolation
+		if errors.As(err, &violation) {
+			detail.FieldViolations = append(detail.FieldViolations, &pb.ErrorDetail_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+
+		var exists *service.TransferExistsError
+		if errors.As(err, &exists) {
+			detail.TransactionId = exists.TransactionID
+		}
+
+		return mapping.code, detail, true
+	}
+
+	return codes.Unknown, nil, false
+}
+
+// withErrorDetail attaches detail to st, falling back to st alone if the detail cannot be marshalled
+func withErrorDetail(st *status.Status, detail *pb.ErrorDetail) error {
+	if withDetail, err := st.WithDetails(detail); err == nil {
+		st = withDetail
+	}
+
+	return st.Err()
+}
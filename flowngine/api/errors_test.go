@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"flowngine/api/pb"
+	"flowngine/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      codes.Code
+		wantMessage   string // Defaults to the message of err
+		wantDetail    *pb.ErrorDetail
+		wantViolation *pb.ErrorDetail_FieldViolation
+	}{
+		{
+			name:          "field violation",
+			err:           fmt.Errorf("%w: %w", service.ErrInvalidParameters, &service.FieldViolation{Field: "from_account", Description: "from_account is required"}),
+			wantCode:      codes.InvalidArgument,
+			wantDetail:    &pb.ErrorDetail{Code: "INVALID_PARAMETERS"},
+			wantViolation: &pb.ErrorDetail_FieldViolation{Field: "from_account", Description: "from_account is required"},
+		},
+		{
+			name:       "not found",
+			err:        fmt.Errorf("%w: transfer_workflow_abc", service.ErrTransferNotFound),
+			wantCode:   codes.NotFound,
+			wantDetail: &pb.ErrorDetail{Code: "TRANSFER_NOT_FOUND"},
+		},
+		{
+			name:       "already exists",
+			err:        fmt.Errorf("failed to start workflow: %w", &service.TransferExistsError{TransactionID: "tx-1", WorkflowID: "transfer_workflow_tx-1"}),
+			wantCode:   codes.AlreadyExists,
+			wantDetail: &pb.ErrorDetail{Code: "TRANSFER_ALREADY_EXISTS", TransactionId: "tx-1"},
+		},
+		{
+			name:       "retryable",
+			err:        service.ErrTemporalUnavailable,
+			wantCode:   codes.Unavailable,
+			wantDetail: &pb.ErrorDetail{Code: "SERVICE_UNAVAILABLE", Retryable: true},
+		},
+		{
+			name:       "deadline",
+			err:        fmt.Errorf("failed to query workflow: %w", context.DeadlineExceeded),
+			wantCode:   codes.DeadlineExceeded,
+			wantDetail: &pb.ErrorDetail{Code: "TIMEOUT", Retryable: true},
+		},
+		{
+			name:     "unmapped",
+			err:      errors.New("failed to start workflow"),
+			wantCode: codes.Unknown,
+		},
+		{
+			name:        "already a status",
+			err:         status.Error(codes.PermissionDenied, "denied"),
+			wantCode:    codes.PermissionDenied,
+			wantMessage: "denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(statusError(tt.err))
+
+			if st.Code() != tt.wantCode {
+				t.Errorf("statusError() code = %v, want %v", st.Code(), tt.wantCode)
+			}
+			wantMessage := tt.wantMessage
+			if wantMessage == "" {
+				wantMessage = tt.err.Error()
+			}
+			if st.Message() != wantMessage {
+				t.Errorf("statusError() message = %q, want %q", st.Message(), wantMessage)
+			}
+
+			details := st.Details()
+			if tt.wantDetail == nil {
+				if len(details) != 0 {
+					t.Errorf("statusError() details = %v, want none", details)
+				}
+				return
+			}
+
+			if len(details) != 1 {
+				t.Fatalf("statusError() details = %v, want one ErrorDetail", details)
+			}
+			detail, ok := details[0].(*pb.ErrorDetail)
+			if !ok {
+				t.Fatalf("statusError() detail = %T, want *pb.ErrorDetail", details[0])
+			}
+			if detail.GetCode() != tt.wantDetail.GetCode() || detail.GetRetryable() != tt.wantDetail.GetRetryable() ||
+				detail.GetTransactionId() != tt.wantDetail.GetTransactionId() {
+				t.Errorf("statusError() detail = %v, want %v", detail, tt.wantDetail)
+			}
+
+			violations := detail.GetFieldViolations()
+			if tt.wantViolation == nil {
+				if len(violations) != 0 {
+					t.Errorf("statusError() field violations = %v, want none", violations)
+				}
+				return
+			}
+			if len(violations) != 1 || violations[0].GetField() != tt.wantViolation.GetField() || violations[0].GetDescription() != tt.wantViolation.GetDescription() {
+				t.Errorf("statusError() field violations = %v, want %v", violations, tt.wantViolation)
+			}
+		})
+	}
+}
+
+func TestTransferStatusError(t *testing.T) {
+	if detail := transferStatusError(fmt.Errorf("%w: tx-1", service.ErrTransferNotFound)); detail.GetCode() != "TRANSFER_NOT_FOUND" {
+		t.Errorf("transferStatusError() code = %q, want TRANSFER_NOT_FOUND", detail.GetCode())
+	}
+
+	if detail := transferStatusError(errors.New("failed to describe workflow")); detail.GetCode() != "INTERNAL_ERROR" || detail.GetRetryable() {
+		t.Errorf("transferStatusError() detail = %v, want INTERNAL_ERROR", detail)
+	}
+}
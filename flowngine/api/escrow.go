@@ -0,0 +1,163 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"flowngine/api/pb"
+	"flowngine/service"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (api *Api) CreateEscrow(ctx context.Context, request *pb.CreateEscrowRequest) (*pb.CreateEscrowResponse, error) {
+	const op = "api.Api.CreateEscrow"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.CreateEscrowParams{
+		FromAccount:     request.FromAccount,
+		ToAccount:       request.ToAccount,
+		Amount:          request.Amount,
+		AmountDecimal:   request.AmountDecimal,
+		Currency:        request.Currency,
+		Description:     request.Description,
+		RequestID:       request.RequestId,
+		Arbiter:         request.Arbiter,
+		DeadlineSeconds: int(request.DeadlineSeconds),
+	}
+
+	results, err := api.service.CreateEscrow(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, results.CreatedAt)
+	if err != nil {
+		err = fmt.Errorf("failed to parse created_at timestamp: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	deadline, err := time.Parse(time.RFC3339, results.Deadline)
+	if err != nil {
+		err = fmt.Errorf("failed to parse deadline timestamp: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.CreateEscrowResponse{
+		EscrowId: results.EscrowID,
+		WorkflowExecution: &pb.WorkflowExecution{
+			WorkflowId: results.WorkflowID,
+			RunId:      results.RunID,
+			Status:     "RUNNING",
+		},
+		CreatedAt: timestamppb.New(createdAt),
+		Deadline:  timestamppb.New(deadline),
+	}
+
+	logger.WithField("escrow_id", response.EscrowId).Info()
+
+	return response, nil
+}
+
+func (api *Api) DecideEscrow(ctx context.Context, request *pb.DecideEscrowRequest) (*pb.DecideEscrowResponse, error) {
+	const op = "api.Api.DecideEscrow"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.DecideEscrowParams{
+		EscrowID:  request.EscrowId,
+		Decision:  request.Decision,
+		DecidedBy: request.DecidedBy,
+		Reason:    request.Reason,
+	}
+
+	results, err := api.service.DecideEscrow(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.DecideEscrowResponse{
+		Success: results.Success,
+		Message: results.Message,
+	}
+
+	logger.WithField("response", fmt.Sprintf("%+v", response)).Info()
+
+	return response, nil
+}
+
+func (api *Api) GetEscrow(ctx context.Context, request *pb.GetEscrowRequest) (*pb.GetEscrowResponse, error) {
+	const op = "api.Api.GetEscrow"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.GetEscrowParams{
+		EscrowID: request.EscrowId,
+	}
+
+	results, err := api.service.GetEscrow(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.GetEscrowResponse{
+		EscrowId:     results.EscrowID,
+		WorkflowId:   results.WorkflowID,
+		Status:       results.Status,
+		FromAccount:  results.FromAccount,
+		ToAccount:    results.ToAccount,
+		Amount:       results.Amount,
+		Currency:     results.Currency,
+		Arbiter:      results.Arbiter,
+		Deadline:     timestamppb.New(results.Deadline),
+		DecidedBy:    results.DecidedBy,
+		Reason:       results.Reason,
+		ErrorMessage: results.ErrorMessage,
+	}
+	if results.HeldAt != nil {
+		response.HeldAt = timestamppb.New(*results.HeldAt)
+	}
+	if results.SettledAt != nil {
+		response.SettledAt = timestamppb.New(*results.SettledAt)
+	}
+
+	logger.WithField("status", response.Status).Info()
+
+	return response, nil
+}
@@ -7,11 +7,18 @@ import (
 
 	"flowngine/api/pb"
 	"flowngine/service"
+	"flowngine/util/taskqueue"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// taskQueueTracks maps the task queue tracks of the API to those of the service; UNSPECIFIED is left to the cutover
+var taskQueueTracks = map[pb.TaskQueueTrack]string{
+	pb.TaskQueueTrack_TASK_QUEUE_TRACK_CURRENT: taskqueue.TrackCurrent,
+	pb.TaskQueueTrack_TASK_QUEUE_TRACK_NEXT:    taskqueue.TrackNext,
+}
+
 func (api *Api) ExecuteTransfer(ctx context.Context, request *pb.ExecuteTransferRequest) (*pb.ExecuteTransferResponse, error) {
 	const op = "api.Api.ExecuteTransfer"
 
@@ -27,13 +34,27 @@ func (api *Api) ExecuteTransfer(ctx context.Context, request *pb.ExecuteTransfer
 
 	// Call service
 	params := &service.ExecuteTransferParams{
-		FromAccount: request.FromAccount,
-		ToAccount:   request.ToAccount,
-		Amount:      request.Amount,
-		Currency:    request.Currency,
-		Description: request.Description,
-		ReferenceID: request.ReferenceId,
-		RequestID:   request.RequestId,
+		FromAccount:   request.FromAccount,
+		ToAccount:     request.ToAccount,
+		ToAlias:       request.ToAlias,
+		Amount:        request.Amount,
+		AmountDecimal: request.AmountDecimal,
+		Currency:      request.Currency,
+		Description:   request.Description,
+		ReferenceID:   request.ReferenceId,
+		RequestID:     request.RequestId,
+		CallbackURL:   request.CallbackUrl,
+		WaitForFunds:  request.WaitForFunds,
+		// ASYNC is the default, so only an explicit SYNC waits for the workflow
+		WaitForCompletion: request.ExecutionMode == pb.ExecutionMode_EXECUTION_MODE_SYNC,
+		TaskQueueTrack:    taskQueueTracks[request.TaskQueueTrack],
+	}
+
+	if trigger := request.GetTrigger(); trigger != nil {
+		params.Trigger = &service.TransferTrigger{
+			Account:       trigger.Account,
+			AmountDecimal: trigger.AmountDecimal,
+		}
 	}
 
 	results, err := api.service.ExecuteTransfer(ctx, params)
@@ -57,6 +78,25 @@ func (api *Api) ExecuteTransfer(ctx context.Context, request *pb.ExecuteTransfer
 		return nil, err
 	}
 	response.CreatedAt = timestamppb.New(createdAt)
+	response.TransferReference = results.TransferReference
+
+	// Outcome of a finished transfer (SYNC mode and fast path)
+	if results.CompletedAt != nil {
+		completedAt, err := time.Parse(time.RFC3339, *results.CompletedAt)
+		if err != nil {
+			err = fmt.Errorf("failed to parse completed_at timestamp: %w", err)
+
+			logger.WithError(err).Error()
+
+			return nil, err
+		}
+		response.CompletedAt = timestamppb.New(completedAt)
+	}
+	response.ErrorMessage = results.ErrorMessage
+	if results.CompensationApplied != nil {
+		response.CompensationApplied = *results.CompensationApplied
+	}
+	response.Fx = newTransferFX(results.FX)
 
 	logger.WithField("request", fmt.Sprintf("%+v", request)).Info()
 
@@ -22,12 +22,10 @@ func (api *Api) GetTransferStatus(ctx context.Context, request *pb.GetTransferSt
 
 	logger.Info()
 
-	// Initialize response
-	response := &pb.GetTransferStatusResponse{}
-
 	// Call service
 	params := &service.GetTransferStatusParams{
 		TransactionID: request.TransactionId,
+		WaitSeconds:   request.WaitSeconds,
 	}
 
 	results, err := api.service.GetTransferStatus(ctx, params)
@@ -37,42 +35,99 @@ func (api *Api) GetTransferStatus(ctx context.Context, request *pb.GetTransferSt
 		return nil, err
 	}
 
-	// Set response
+	response, err := newGetTransferStatusResponse(results)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("request", fmt.Sprintf("%+v", request)).Info()
+
+	return response, nil
+}
+
+// newGetTransferStatusResponse converts the status of a transfer to its gRPC representation
+func newGetTransferStatusResponse(results *service.GetTransferStatusResults) (*pb.GetTransferStatusResponse, error) {
+	response := &pb.GetTransferStatusResponse{}
+
 	response.TransactionId = results.TransactionID
 	response.Status = pb.TransferStatus(pb.TransferStatus_value[results.Status])
 	response.FromAccount = results.FromAccount
 	response.ToAccount = results.ToAccount
 	response.Amount = results.Amount
+	response.AmountDecimal = results.AmountDecimal
 	response.Currency = results.Currency
 	response.Description = results.Description
 	response.ReferenceId = results.ReferenceID
 	createdAt, err := time.Parse(time.RFC3339, results.CreatedAt)
 	if err != nil {
-		err = fmt.Errorf("failed to parse created_at timestamp: %w", err)
-
-		logger.WithError(err).Error()
-		logger.WithError(err).Error()
-
-		return nil, err
+		return nil, fmt.Errorf("failed to parse created_at timestamp: %w", err)
 	}
 	response.CreatedAt = timestamppb.New(createdAt)
-	completedAt, err := time.Parse(time.RFC3339, results.CompletedAt)
-	if err != nil {
-		err = fmt.Errorf("failed to parse completed_at timestamp: %w", err)
-
-		logger.WithError(err).Error()
-
-		return nil, err
+	// Transfers that are still running have no completion time yet
+	if results.CompletedAt != "" {
+		completedAt, err := time.Parse(time.RFC3339, results.CompletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse completed_at timestamp: %w", err)
+		}
+		response.CompletedAt = timestamppb.New(completedAt)
 	}
-	response.CompletedAt = timestamppb.New(completedAt)
 	response.WorkflowExecution = &pb.WorkflowExecution{
 		WorkflowId: results.WorkflowExecution.WorkflowID,
 		RunId:      results.WorkflowExecution.RunID,
 		Status:     results.WorkflowExecution.Status,
 	}
 	response.ErrorMessage = results.ErrorMessage
-
-	logger.WithField("request", fmt.Sprintf("%+v", request)).Info()
+	response.TransferReference = results.TransferReference
+	response.SlaBreached = results.SLABreached
+	response.Fx = newTransferFX(results.FX)
+	if results.Wait != nil {
+		response.Wait = &pb.TransferWait{
+			Reason:         results.Wait.Reason,
+			Since:          timestamppb.New(results.Wait.Since),
+			BalanceChecks:  int32(results.Wait.BalanceChecks),
+			TriggerAccount: results.Wait.TriggerAccount,
+			AmountReceived: results.Wait.AmountReceived,
+		}
+		if results.Wait.Deadline != nil {
+			response.Wait.Deadline = timestamppb.New(*results.Wait.Deadline)
+		}
+		if results.Wait.NextCheckAt != nil {
+			response.Wait.NextCheckAt = timestamppb.New(*results.Wait.NextCheckAt)
+		}
+	}
+	for _, step := range results.Steps {
+		response.Steps = append(response.Steps, &pb.TransferStep{
+			Activity:     step.Activity,
+			Status:       step.Status,
+			Attempts:     step.Attempts,
+			LastError:    step.LastError,
+			Compensation: step.Compensation,
+			Summary:      step.Summary,
+		})
+	}
 
 	return response, nil
 }
+
+// newTransferFX converts the conversion applied to a transfer to its response message, nil when none was applied
+func newTransferFX(fx *service.TransferFXResults) *pb.TransferFX {
+	if fx == nil {
+		return nil
+	}
+
+	return &pb.TransferFX{
+		Pair:                   fx.Pair,
+		Side:                   fx.Side,
+		Rate:                   fx.Rate,
+		MidRate:                fx.MidRate,
+		SpreadBps:              fx.SpreadBps,
+		MarginBps:              fx.MarginBps,
+		ConvertedCurrency:      fx.ConvertedCurrency,
+		ConvertedAmount:        fx.ConvertedAmount,
+		ConvertedAmountDecimal: fx.ConvertedAmountDecimal,
+		SpreadAmount:           fx.SpreadAmount,
+		SpreadAmountDecimal:    fx.SpreadAmountDecimal,
+	}
+}
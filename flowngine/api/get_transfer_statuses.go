@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+
+	"flowngine/api/pb"
+	"flowngine/service"
+
+	"github.com/sirupsen/logrus"
+)
+
+func (api *Api) GetTransferStatuses(ctx context.Context, request *pb.GetTransferStatusesRequest) (*pb.GetTransferStatusesResponse, error) {
+	const op = "api.Api.GetTransferStatuses"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":      op,
+		"transfers": len(request.TransactionIds),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.GetTransferStatusesParams{
+		TransactionIDs: request.TransactionIds,
+	}
+
+	results, err := api.service.GetTransferStatuses(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.GetTransferStatusesResponse{}
+	for _, item := range results.Items {
+		result := &pb.TransferStatusResult{RequestedId: item.RequestedID}
+
+		err := item.Err
+		if err == nil {
+			result.Transfer, err = newGetTransferStatusResponse(item.Transfer)
+		}
+		if err != nil {
+			result.Error = transferStatusError(err)
+			result.ErrorMessage = err.Error()
+		}
+
+		response.Results = append(response.Results, result)
+	}
+
+	logger.WithField("results", len(response.Results)).Info()
+
+	return response, nil
+}
+
+// transferStatusError returns the ErrorDetail of a transfer that failed its lookup in a batch; errors without a
+// mapping are reported as INTERNAL_ERROR, like the gateway reports them for single lookups
+func transferStatusError(err error) *pb.ErrorDetail {
+	if _, detail, ok := serviceErrorDetail(err); ok {
+		return detail
+	}
+
+	return &pb.ErrorDetail{Code: "INTERNAL_ERROR"}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"flowngine/api/pb"
+	"flowngine/service"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (api *Api) GetTransferTimeline(ctx context.Context, request *pb.GetTransferTimelineRequest) (*pb.GetTransferTimelineResponse, error) {
+	const op = "api.Api.GetTransferTimeline"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.GetTransferTimelineParams{
+		TransactionID: request.TransactionId,
+	}
+
+	results, err := api.service.GetTransferTimeline(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.GetTransferTimelineResponse{
+		TransactionId: results.TransactionID,
+		WorkflowId:    results.WorkflowID,
+		RunId:         results.RunID,
+		Events:        make([]*pb.TimelineEvent, 0, len(results.Events)),
+	}
+
+	for _, event := range results.Events {
+		response.Events = append(response.Events, &pb.TimelineEvent{
+			EventId:      event.EventID,
+			Time:         timestamppb.New(event.Time),
+			Type:         event.Type,
+			Activity:     event.Activity,
+			Attempt:      event.Attempt,
+			Message:      event.Message,
+			Compensation: event.Compensation,
+		})
+	}
+
+	logger.WithField("events", len(response.Events)).Info()
+
+	return response, nil
+}
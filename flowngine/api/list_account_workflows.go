@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"flowngine/api/pb"
+	"flowngine/service"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (api *Api) ListAccountWorkflows(ctx context.Context, request *pb.ListAccountWorkflowsRequest) (*pb.ListAccountWorkflowsResponse, error) {
+	const op = "api.Api.ListAccountWorkflows"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.ListAccountWorkflowsParams{
+		AccountID:     request.AccountId,
+		AccountNumber: request.AccountNumber,
+	}
+
+	results, err := api.service.ListAccountWorkflows(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.ListAccountWorkflowsResponse{
+		AccountId: results.AccountID,
+		Transfers: make([]*pb.InFlightTransfer, 0, len(results.Transfers)),
+		Pending:   make([]*pb.PendingAmount, 0, len(results.Pending)),
+		Truncated: results.Truncated,
+	}
+
+	for _, transfer := range results.Transfers {
+		response.Transfers = append(response.Transfers, &pb.InFlightTransfer{
+			WorkflowId:    transfer.WorkflowID,
+			RunId:         transfer.RunID,
+			TransactionId: transfer.TransactionID,
+			Direction:     transfer.Direction,
+			FromAccount:   transfer.FromAccount,
+			ToAccount:     transfer.ToAccount,
+			Amount:        transfer.Amount.String(),
+			Currency:      transfer.Currency,
+			StartedAt:     timestamppb.New(transfer.StartedAt),
+		})
+	}
+
+	for _, pending := range results.Pending {
+		response.Pending = append(response.Pending, &pb.PendingAmount{
+			Currency: pending.Currency,
+			Outgoing: pending.Outgoing.String(),
+			Incoming: pending.Incoming.String(),
+		})
+	}
+
+	logger.WithField("transfers", len(response.Transfers)).Info()
+
+	return response, nil
+}
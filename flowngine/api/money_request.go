@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"flowngine/api/pb"
+	"flowngine/service"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (api *Api) RequestMoney(ctx context.Context, request *pb.RequestMoneyRequest) (*pb.RequestMoneyResponse, error) {
+	const op = "api.Api.RequestMoney"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.RequestMoneyParams{
+		PayeeAccount:  request.PayeeAccount,
+		PayerAccount:  request.PayerAccount,
+		Amount:        request.Amount,
+		AmountDecimal: request.AmountDecimal,
+		Currency:      request.Currency,
+		Description:   request.Description,
+		RequestID:     request.RequestId,
+	}
+
+	results, err := api.service.RequestMoney(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, results.CreatedAt)
+	if err != nil {
+		err = fmt.Errorf("failed to parse created_at timestamp: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, results.ExpiresAt)
+	if err != nil {
+		err = fmt.Errorf("failed to parse expires_at timestamp: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.RequestMoneyResponse{
+		MoneyRequestId: results.MoneyRequestID,
+		WorkflowExecution: &pb.WorkflowExecution{
+			WorkflowId: results.WorkflowID,
+			RunId:      results.RunID,
+			Status:     "RUNNING",
+		},
+		Status:    results.Status,
+		CreatedAt: timestamppb.New(createdAt),
+		ExpiresAt: timestamppb.New(expiresAt),
+	}
+
+	logger.WithField("money_request_id", response.MoneyRequestId).Info()
+
+	return response, nil
+}
+
+func (api *Api) ListMoneyRequests(ctx context.Context, request *pb.ListMoneyRequestsRequest) (*pb.ListMoneyRequestsResponse, error) {
+	const op = "api.Api.ListMoneyRequests"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.ListMoneyRequestsParams{
+		Account: request.Account,
+		Role:    request.Role,
+		Status:  request.Status,
+	}
+
+	results, err := api.service.ListMoneyRequests(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.ListMoneyRequestsResponse{
+		Account:       results.Account,
+		Role:          results.Role,
+		MoneyRequests: make([]*pb.MoneyRequest, 0, len(results.MoneyRequests)),
+		Truncated:     results.Truncated,
+	}
+
+	for _, moneyRequest := range results.MoneyRequests {
+		response.MoneyRequests = append(response.MoneyRequests, &pb.MoneyRequest{
+			MoneyRequestId: moneyRequest.MoneyRequestID,
+			WorkflowId:     moneyRequest.WorkflowID,
+			PayerAccount:   moneyRequest.PayerAccount,
+			PayeeAccount:   moneyRequest.PayeeAccount,
+			Amount:         moneyRequest.Amount.String(),
+			Currency:       moneyRequest.Currency,
+			Description:    moneyRequest.Description,
+			Status:         moneyRequest.Status,
+			CreatedAt:      timestamppb.New(moneyRequest.CreatedAt),
+			ExpiresAt:      timestamppb.New(moneyRequest.ExpiresAt),
+		})
+	}
+
+	logger.WithField("money_requests", len(response.MoneyRequests)).Info()
+
+	return response, nil
+}
+
+func (api *Api) RespondToMoneyRequest(ctx context.Context, request *pb.RespondToMoneyRequestRequest) (*pb.RespondToMoneyRequestResponse, error) {
+	const op = "api.Api.RespondToMoneyRequest"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.RespondToMoneyRequestParams{
+		MoneyRequestID: request.MoneyRequestId,
+		Accept:         request.Accept,
+		Reason:         request.Reason,
+	}
+
+	results, err := api.service.RespondToMoneyRequest(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.RespondToMoneyRequestResponse{
+		Success: results.Success,
+		Message: results.Message,
+	}
+
+	logger.WithField("response", fmt.Sprintf("%+v", response)).Info()
+
+	return response, nil
+}
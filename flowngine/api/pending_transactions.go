@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"flowngine/api/pb"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (api *Api) GetPendingTransactionStats(ctx context.Context, request *pb.GetPendingTransactionStatsRequest) (*pb.GetPendingTransactionStatsResponse, error) {
+	const op = "api.Api.GetPendingTransactionStats"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.GetPendingTransactionStats(ctx)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.GetPendingTransactionStatsResponse{
+		Pending:           results.Pending,
+		Stale:             results.Stale,
+		StaleAfterSeconds: results.StaleAfterSeconds,
+		SweepRuns:         results.SweepRuns,
+		SweptCompleted:    results.SweptCompleted,
+		SweptFailed:       results.SweptFailed,
+		SweepErrors:       results.SweepErrors,
+	}
+	if results.LastSweepAt != nil {
+		response.LastSweepAt = timestamppb.New(*results.LastSweepAt)
+	}
+
+	logger.WithField("response", fmt.Sprintf("%+v", response)).Info()
+
+	return response, nil
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+
+	"flowngine/api/pb"
+	"flowngine/util/crash"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoverUnary is a gRPC unary interceptor that turns a panic in a handler into an INTERNAL error,
+// logging its stack trace and counting it in the panic metric.
+func (api *Api) RecoverUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = api.recovered(info.FullMethod, r)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// RecoverStream is the streaming counterpart of RecoverUnary
+func (api *Api) RecoverStream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = api.recovered(info.FullMethod, r)
+		}
+	}()
+
+	return handler(srv, stream)
+}
+
+func (api *Api) recovered(method string, value any) error {
+	crash.Report(api.logger.WithFields(logrus.Fields{
+		"[op]":   "api.Api.recovered",
+		"method": method,
+	}), value)
+
+	// The panic value may carry internal details, so it is only logged
+	return withErrorDetail(status.New(codes.Internal, "internal server error"), &pb.ErrorDetail{Code: "INTERNAL_ERROR"})
+}
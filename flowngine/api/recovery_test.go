@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"flowngine/util/crash"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecoverUnary(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	api := &Api{logger: logger}
+	info := &grpc.UnaryServerInfo{FullMethod: "/flowngine.FlowEngine/ExecuteTransfer"}
+
+	t.Run("panic", func(t *testing.T) {
+		before := crash.Total()
+
+		_, err := api.RecoverUnary(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			panic("boom")
+		})
+
+		if status.Code(err) != codes.Internal {
+			t.Errorf("RecoverUnary() code = %v, want %v", status.Code(err), codes.Internal)
+		}
+		if got := crash.Total() - before; got != 1 {
+			t.Errorf("panic count increased by %d, want 1", got)
+		}
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		resp, err := api.RecoverUnary(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			return "ok", nil
+		})
+
+		if err != nil || resp != "ok" {
+			t.Errorf("RecoverUnary() = %v, %v, want ok, nil", resp, err)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"flowngine/api/pb"
+	"flowngine/service"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (api *Api) StartTransferBatch(ctx context.Context, request *pb.StartTransferBatchRequest) (*pb.StartTransferBatchResponse, error) {
+	const op = "api.Api.StartTransferBatch"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"request_id": request.RequestId,
+		"transfers":  len(request.Transfers),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.StartTransferBatchParams{
+		RequestID: request.RequestId,
+	}
+	for _, item := range request.Transfers {
+		params.Transfers = append(params.Transfers, service.TransferBatchItem{
+			Row: item.Row,
+			Transfer: service.ExecuteTransferParams{
+				FromAccount:   item.FromAccount,
+				ToAccount:     item.ToAccount,
+				Amount:        item.Amount,
+				AmountDecimal: item.AmountDecimal,
+				Currency:      item.Currency,
+				Description:   item.Description,
+				ReferenceID:   item.ReferenceId,
+			},
+		})
+	}
+
+	results, err := api.service.StartTransferBatch(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, results.CreatedAt)
+	if err != nil {
+		err = fmt.Errorf("failed to parse created_at timestamp: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.StartTransferBatchResponse{
+		BatchId: results.BatchID,
+		WorkflowExecution: &pb.WorkflowExecution{
+			WorkflowId: results.WorkflowID,
+			RunId:      results.RunID,
+			Status:     "RUNNING",
+		},
+		CreatedAt: timestamppb.New(createdAt),
+		Rows:      transferBatchRows(results.Rows),
+	}
+
+	logger.WithField("batch_id", response.BatchId).Info()
+
+	return response, nil
+}
+
+func (api *Api) GetTransferBatch(ctx context.Context, request *pb.GetTransferBatchRequest) (*pb.GetTransferBatchResponse, error) {
+	const op = "api.Api.GetTransferBatch"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := &service.GetTransferBatchParams{
+		BatchID: request.BatchId,
+	}
+
+	results, err := api.service.GetTransferBatch(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.GetTransferBatchResponse{
+		BatchId: request.BatchId,
+		WorkflowExecution: &pb.WorkflowExecution{
+			WorkflowId: results.WorkflowID,
+			Status:     results.Status,
+		},
+		Total:      int32(results.Total),
+		Pending:    int32(results.Pending),
+		Processing: int32(results.Processing),
+		Completed:  int32(results.Completed),
+		Failed:     int32(results.Failed),
+		Rows:       transferBatchRows(results.Rows),
+		StartedAt:  timestamppb.New(results.StartedAt),
+	}
+	if results.CompletedAt != nil {
+		response.CompletedAt = timestamppb.New(*results.CompletedAt)
+	}
+
+	logger.WithFields(logrus.Fields{
+		"status":    response.WorkflowExecution.Status,
+		"completed": response.Completed,
+		"failed":    response.Failed,
+	}).Info()
+
+	return response, nil
+}
+
+// transferBatchRows maps the rows of a batch, whose statuses are proto TransferStatus names
+func transferBatchRows(rows []service.TransferBatchRow) []*pb.TransferBatchRow {
+	response := make([]*pb.TransferBatchRow, 0, len(rows))
+	for _, row := range rows {
+		response = append(response, &pb.TransferBatchRow{
+			Row:               row.Row,
+			TransactionId:     row.TransactionID,
+			TransferReference: row.TransferReference,
+			Status:            pb.TransferStatus(pb.TransferStatus_value[row.Status]),
+			ErrorMessage:      row.ErrorMessage,
+		})
+	}
+
+	return response
+}
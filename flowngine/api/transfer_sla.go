@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"flowngine/api/pb"
+
+	"github.com/sirupsen/logrus"
+)
+
+func (api *Api) GetTransferSLAStats(ctx context.Context, request *pb.GetTransferSLAStatsRequest) (*pb.GetTransferSLAStatsResponse, error) {
+	const op = "api.Api.GetTransferSLAStats"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	results, err := api.service.GetTransferSLAStats(ctx)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.GetTransferSLAStatsResponse{
+		SlaSeconds: int32(results.SLASeconds),
+		Breached:   results.Breached,
+		Delayed:    results.Delayed,
+		Aborted:    results.Aborted,
+	}
+
+	logger.WithField("response", fmt.Sprintf("%+v", response)).Info()
+
+	return response, nil
+}
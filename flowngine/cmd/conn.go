@@ -1,9 +1,14 @@
 package main
 
 import (
+	"flowngine/adapter/temporal_health_adapter"
+	"flowngine/adapter/transaction_adapter"
 	"flowngine/util/config"
+	"flowngine/util/taskqueue"
 	"fmt"
+	"time"
 
+	"github.com/sirupsen/logrus"
 	"go.temporal.io/sdk/client"
 )
 
@@ -18,3 +23,37 @@ func createTemporalClient(config config.Temporal) (client.Client, error) {
 
 	return temporalClient, nil
 }
+
+func createTransactionAdapter(config config.SvcTransaction, logger *logrus.Logger) *transaction_adapter.Adapter {
+	timeout := time.Duration(config.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	baseURL := fmt.Sprintf("http://%s:%d", config.Host, config.Port)
+
+	return transaction_adapter.NewAdapter(config.Name, logger, baseURL, timeout)
+}
+
+func createTemporalHealthAdapter(temporalClient client.Client, config config.Temporal, logger *logrus.Logger) *temporal_health_adapter.Adapter {
+	return temporal_health_adapter.NewAdapter(logger, temporalClient, config.Namespace)
+}
+
+func createTaskQueueRouter(config config.TransferTaskQueue) (*taskqueue.Router, error) {
+	name := config.Name
+	if name == "" {
+		name = "transfer-task-queue"
+	}
+
+	var next string
+	if config.Cutover.Suffix != "" {
+		next = taskqueue.Name(name, config.Cutover.Suffix)
+	}
+
+	router, err := taskqueue.NewRouter(taskqueue.Name(name, config.Suffix), next, config.Cutover.Percent)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transfer task queue: %w", err)
+	}
+
+	return router, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"flowngine/service"
+
+	"github.com/sirupsen/logrus"
+)
+
+// registerExternalSettlementRoutes serves the callback the partner bank posts settlements to on the metrics port.
+// The partner is an HTTP client outside the bank, not a caller of FlowEngine's gRPC API.
+func (ms *MetricsServer) registerExternalSettlementRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /callbacks/external-settlements", ms.handleExternalSettlementCallback)
+}
+
+// handleExternalSettlementCallback completes the settlement activity the callback's token belongs to. Client
+// errors tell the partner to stop retrying; server errors are retried by the partner.
+func (ms *MetricsServer) handleExternalSettlementCallback(w http.ResponseWriter, r *http.Request) {
+	const op = "MetricsServer.handleExternalSettlementCallback"
+
+	logger := ms.logger.WithField("[op]", op)
+
+	var params service.CompleteExternalSettlementParams
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		logger.WithError(err).Warn("Invalid settlement callback")
+
+		ms.writeJSON(w, http.StatusBadRequest, map[string]any{
+			"status":  "error",
+			"message": "invalid settlement callback: " + err.Error(),
+		})
+		return
+	}
+
+	results, err := ms.service.CompleteExternalSettlement(r.Context(), &params)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrInvalidParameters):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrExternalSettlementNotAwaited):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrTemporalUnavailable):
+			status = http.StatusServiceUnavailable
+		}
+
+		logger.WithError(err).WithFields(logrus.Fields{
+			"settlement_id": params.SettlementID,
+			"status_code":   status,
+		}).Warn()
+
+		ms.writeJSON(w, status, map[string]any{
+			"status":  "error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ms.writeJSON(w, http.StatusOK, map[string]any{
+		"status":  "success",
+		"message": results.Message,
+	})
+}
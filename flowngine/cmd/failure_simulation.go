@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"flowngine/service"
+
+	"github.com/sirupsen/logrus"
+)
+
+// headerActor names the caller of state-changing failure simulation calls, as sent by flowctl
+const headerActor = "X-Actor"
+
+// registerFailureSimulationRoutes serves FlowEngine's failure simulation on the metrics port, with the same paths
+// and responses as the /failure-simulation REST API of svc-balance and svc-transaction. FlowEngine's own API is
+// gRPC for the gateway, which has no business switching chaos rules.
+func (ms *MetricsServer) registerFailureSimulationRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /failure-simulation/stats", ms.handleFailureSimulationStats)
+	mux.HandleFunc("POST /failure-simulation/reset", ms.handleResetFailureSimulation)
+	mux.HandleFunc("POST /failure-simulation/enable", ms.handleSetFailureSimulationEnabled(true))
+	mux.HandleFunc("POST /failure-simulation/disable", ms.handleSetFailureSimulationEnabled(false))
+	mux.HandleFunc("GET /failure-simulation/scenarios", ms.handleLearningScenarios)
+	mux.HandleFunc("POST /failure-simulation/rules/{name}/enable", ms.handleSetFailureRuleEnabled(true))
+	mux.HandleFunc("POST /failure-simulation/rules/{name}/disable", ms.handleSetFailureRuleEnabled(false))
+}
+
+// handleFailureSimulationStats returns statistics about the failure simulation
+func (ms *MetricsServer) handleFailureSimulationStats(w http.ResponseWriter, r *http.Request) {
+	ms.writeJSON(w, http.StatusOK, map[string]any{
+		"status":             "success",
+		"message":            "Failure simulation statistics retrieved successfully",
+		"failure_simulation": ms.service.GetFailureSimulationStats(),
+	})
+}
+
+// handleResetFailureSimulation resets the failure simulation state
+func (ms *MetricsServer) handleResetFailureSimulation(w http.ResponseWriter, r *http.Request) {
+	const op = "MetricsServer.handleResetFailureSimulation"
+
+	ms.logger.WithFields(logrus.Fields{
+		"[op]":  op,
+		"actor": r.Header.Get(headerActor),
+	}).Info("Resetting failure simulation state")
+
+	ms.service.ResetFailureSimulation()
+
+	ms.writeJSON(w, http.StatusOK, map[string]any{
+		"status":  "success",
+		"message": "Failure simulation state reset successfully",
+	})
+}
+
+// handleSetFailureSimulationEnabled switches the failure simulation on or off; rules and counters are kept
+func (ms *MetricsServer) handleSetFailureSimulationEnabled(enabled bool) http.HandlerFunc {
+	const op = "MetricsServer.handleSetFailureSimulationEnabled"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ms.logger.WithFields(logrus.Fields{
+			"[op]":    op,
+			"actor":   r.Header.Get(headerActor),
+			"enabled": enabled,
+		}).Info("Switching failure simulation")
+
+		ms.service.SetFailureSimulationEnabled(enabled)
+
+		ms.writeJSON(w, http.StatusOK, map[string]any{
+			"status":             "success",
+			"message":            fmt.Sprintf("Failure simulation enabled=%t", enabled),
+			"failure_simulation": ms.service.GetFailureSimulationStats(),
+		})
+	}
+}
+
+// handleSetFailureRuleEnabled switches a single failure rule on or off until the next reset
+func (ms *MetricsServer) handleSetFailureRuleEnabled(enabled bool) http.HandlerFunc {
+	const op = "MetricsServer.handleSetFailureRuleEnabled"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+
+		logger := ms.logger.WithFields(logrus.Fields{
+			"[op]":    op,
+			"actor":   r.Header.Get(headerActor),
+			"rule":    name,
+			"enabled": enabled,
+		})
+
+		logger.Info("Switching failure rule")
+
+		if err := ms.service.SetFailureRuleEnabled(name, enabled); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, service.ErrFailureRuleNotFound) {
+				status = http.StatusNotFound
+			}
+
+			logger.WithError(err).Warn()
+
+			ms.writeJSON(w, status, map[string]any{
+				"status":  "error",
+				"message": err.Error(),
+			})
+			return
+		}
+
+		ms.writeJSON(w, http.StatusOK, map[string]any{
+			"status":    "success",
+			"message":   fmt.Sprintf("Failure rule %s enabled=%t", name, enabled),
+			"scenarios": ms.service.GetLearningScenarios(),
+		})
+	}
+}
+
+// handleLearningScenarios returns the available failure scenarios
+func (ms *MetricsServer) handleLearningScenarios(w http.ResponseWriter, r *http.Request) {
+	ms.writeJSON(w, http.StatusOK, map[string]any{
+		"status":      "success",
+		"message":     "Learning scenarios retrieved successfully",
+		"scenarios":   ms.service.GetLearningScenarios(),
+		"description": "These are hardcoded workflow start and signal failures designed to demonstrate how callers of Temporal handle them",
+	})
+}
+
+// writeJSON writes body as a JSON response with the given status code
+func (ms *MetricsServer) writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		ms.logger.WithError(err).Error("Failed to write JSON response")
+	}
+}
@@ -7,21 +7,29 @@ import (
 	"runtime"
 	"time"
 
+	"flowngine/service"
+	"flowngine/util/crash"
+
 	"github.com/sirupsen/logrus"
 )
 
+// transferSLAStatsTimeout bounds the visibility queries made for the transfer SLA metrics on every scrape
+const transferSLAStatsTimeout = 2 * time.Second
+
 // MetricsServer provides a dedicated HTTP server for Prometheus metrics collection
 type MetricsServer struct {
-	logger *logrus.Logger
-	server *http.Server
-	port   int
+	logger  *logrus.Logger
+	server  *http.Server
+	port    int
+	service *service.Service
 }
 
 // NewMetricsServer creates a new metrics server instance
-func NewMetricsServer(logger *logrus.Logger, port int) *MetricsServer {
+func NewMetricsServer(logger *logrus.Logger, port int, service *service.Service) *MetricsServer {
 	return &MetricsServer{
-		logger: logger,
-		port:   port,
+		logger:  logger,
+		port:    port,
+		service: service,
 	}
 }
 
@@ -38,6 +46,18 @@ func (ms *MetricsServer) Start(ctx context.Context) error {
 	// Health check for metrics server itself
 	mux.HandleFunc("/metrics/health", ms.handleMetricsHealth)
 
+	// Failure simulation of workflow starts and signals (for learning and testing)
+	ms.registerFailureSimulationRoutes(mux)
+
+	// Partner bank callbacks completing external settlements
+	ms.registerExternalSettlementRoutes(mux)
+
+	// Namespace and task queue health of Temporal (pollers, backlog, schedule-to-start latency)
+	ms.registerTemporalHealthRoutes(mux)
+
+	// Blue/green cutover of the transfer task queue
+	ms.registerTaskQueueCutoverRoutes(mux)
+
 	// Create HTTP server
 	ms.server = &http.Server{
 		Addr:    fmt.Sprintf(":%d", ms.port),
@@ -131,6 +151,10 @@ flowngine_memory_sys_bytes %d
 # TYPE flowngine_gc_cycles_total counter
 flowngine_gc_cycles_total %d
 
+# HELP flowngine_panics_recovered_total Total number of panics recovered by request and activity handlers
+# TYPE flowngine_panics_recovered_total counter
+flowngine_panics_recovered_total %d
+
 # HELP flowngine_last_scrape_timestamp_seconds Timestamp of last metrics scrape
 # TYPE flowngine_last_scrape_timestamp_seconds gauge
 flowngine_last_scrape_timestamp_seconds %d
@@ -140,8 +164,12 @@ flowngine_last_scrape_timestamp_seconds %d
 		memStats.TotalAlloc,
 		memStats.Sys,
 		memStats.NumGC,
+		crash.Total(),
 		time.Now().Unix(),
 	)
+	metrics += ms.transferSLAMetrics(r.Context())
+	metrics += ms.temporalHealthMetrics(r.Context())
+	metrics += ms.taskQueueCutoverMetrics()
 
 	if _, err := w.Write([]byte(metrics)); err != nil {
 		logger.WithError(err).Error("Failed to write metrics response")
@@ -152,6 +180,39 @@ flowngine_last_scrape_timestamp_seconds %d
 	logger.Debug("Metrics served successfully")
 }
 
+// transferSLAMetrics renders the transfer SLA breach counts, which come from Temporal visibility.
+// They are left out while Temporal cannot be queried rather than reported as zero.
+func (ms *MetricsServer) transferSLAMetrics(ctx context.Context) string {
+	ctx, cancel := context.WithTimeout(ctx, transferSLAStatsTimeout)
+	defer cancel()
+
+	stats, err := ms.service.GetTransferSLAStats(ctx)
+	if err != nil {
+		ms.logger.WithError(err).Debug("Skipping transfer SLA metrics")
+		return ""
+	}
+
+	return fmt.Sprintf(`
+# HELP flowngine_transfer_sla_seconds SLA applied to new saga transfers, 0 when tracking is disabled
+# TYPE flowngine_transfer_sla_seconds gauge
+flowngine_transfer_sla_seconds %d
+
+# HELP flowngine_transfer_sla_breaches Saga transfers that breached their SLA, within the Temporal visibility retention
+# TYPE flowngine_transfer_sla_breaches gauge
+flowngine_transfer_sla_breaches{sla_status="delayed"} %d
+flowngine_transfer_sla_breaches{sla_status="aborted"} %d
+
+# HELP flowngine_transfers_delayed Saga transfers still running past their SLA
+# TYPE flowngine_transfers_delayed gauge
+flowngine_transfers_delayed %d
+`,
+		stats.SLASeconds,
+		stats.Breached-stats.Aborted,
+		stats.Aborted,
+		stats.Delayed,
+	)
+}
+
 // handleMetricsHealth provides health check for the metrics server
 func (ms *MetricsServer) handleMetricsHealth(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
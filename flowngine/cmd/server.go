@@ -8,6 +8,7 @@ import (
 
 	"flowngine/api"
 	"flowngine/api/pb"
+	"flowngine/util/config"
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel/propagation"
@@ -15,13 +16,18 @@ import (
 	"google.golang.org/grpc/reflection"
 )
 
-func runGrpcServer(port int, server *api.Api) *grpc.Server {
+func runGrpcServer(port int, grpcConfig config.Grpc, server *api.Api) *grpc.Server {
 	// Create new gRPC server
 	opts := []grpc.ServerOption{
 		grpc.StatsHandler(otelgrpc.NewServerHandler(
 			otelgrpc.WithPropagators(propagation.TraceContext{}),
 		)),
+		// Recover panics so a failing handler returns INTERNAL instead of crashing the server,
+		// and attach an ErrorDetail to the errors of the service
+		grpc.ChainUnaryInterceptor(server.RecoverUnary, server.ErrorDetailUnary),
+		grpc.ChainStreamInterceptor(server.RecoverStream),
 	}
+	opts = append(opts, grpcServerOptions(grpcConfig)...)
 	grpcServer := grpc.NewServer(opts...)
 
 	// Register gRPC services
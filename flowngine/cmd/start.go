@@ -11,6 +11,7 @@ import (
 	"flowngine/api"
 	"flowngine/service"
 	"flowngine/util/config"
+	"flowngine/util/failure"
 
 	"github.com/sirupsen/logrus"
 )
@@ -39,6 +40,11 @@ func start() {
 		os.Exit(1)
 	}
 
+	// --- Apply profile (APP_PROFILE) ---
+	if !config.Profile.VerboseLogging() {
+		logger.Level = logrus.InfoLevel
+	}
+
 	logger.WithFields(logrus.Fields{
 		"[op]":   op,
 		"config": fmt.Sprintf("%+v", config),
@@ -48,8 +54,32 @@ func start() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// --- Init svc-transaction adapter (used by the fast path) ---
+	transactionAdapter := createTransactionAdapter(config.SvcTransaction, logger)
+
+	// --- Init service layer with nil Temporal client initially ---
+	service := service.NewService(logger, config, nil, transactionAdapter)
+	service.SetFailureQuota(failure.Quota{
+		MaxFailurePercent: config.FailureSimulation.MaxFailurePercent,
+		MinOperations:     config.FailureSimulation.MinOperations,
+	})
+	if enabled, ok := config.Profile.FailureSimulation(); ok {
+		service.SetFailureSimulationEnabled(enabled)
+	}
+
+	// --- Init task queue router (blue/green cutover of the transfer worker fleet) ---
+	taskQueueRouter, err := createTaskQueueRouter(config.Temporal.TransferTaskQueue)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"error": err.Error(),
+		}).Error("Failed to create task queue router")
+		os.Exit(1)
+	}
+	service.SetTaskQueueRouter(taskQueueRouter)
+
 	// --- Init metrics server for Prometheus ---
-	metricsServer := NewMetricsServer(logger, 8080)
+	metricsServer := NewMetricsServer(logger, 8080, service)
 	go func() {
 		if err := metricsServer.Start(ctx); err != nil {
 			logger.WithFields(logrus.Fields{
@@ -60,14 +90,11 @@ func start() {
 		}
 	}()
 
-	// --- Init service layer with nil Temporal client initially ---
-	service := service.NewService(logger, config, nil)
-
 	// --- Init api layer ---
 	api := api.NewApi(logger, service)
 
 	// --- Start gRPC server in background ---
-	go runGrpcServer(config.App.Port, api)
+	go runGrpcServer(config.App.Port, config.Grpc, api)
 
 	logger.WithField("port", config.App.Port).Info("🚀 gRPC server started - ready to accept requests!")
 
@@ -94,6 +121,15 @@ func start() {
 
 			// Update service with Temporal client
 			service.SetTemporalClient(temporalClient)
+			service.SetTemporalHealthAdapter(createTemporalHealthAdapter(temporalClient, config.Temporal, logger))
+
+			// Transfers are started with custom search attributes, which the namespace must know
+			if err := service.RegisterSearchAttributes(ctx); err != nil {
+				logger.WithFields(logrus.Fields{
+					"[op]":  op,
+					"error": err.Error(),
+				}).Error("Failed to register search attributes, transfers will not start until they exist")
+			}
 
 			// Keep the connection alive until context is cancelled
 			<-ctx.Done()
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"flowngine/service"
+
+	"github.com/sirupsen/logrus"
+)
+
+// registerTaskQueueCutoverRoutes serves the blue/green cutover of the transfer task queue: a new worker fleet is
+// warmed on the next task queue, then new transfers are shifted to it step by step
+func (ms *MetricsServer) registerTaskQueueCutoverRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /task-queue-cutover", ms.handleGetTaskQueueCutover)
+	mux.HandleFunc("POST /task-queue-cutover", ms.handleSetTaskQueueCutover)
+}
+
+// handleGetTaskQueueCutover returns the current and next task queues, the cutover percent and the routed starts
+func (ms *MetricsServer) handleGetTaskQueueCutover(w http.ResponseWriter, r *http.Request) {
+	cutover, err := ms.service.GetTaskQueueCutover()
+	if err != nil {
+		ms.writeJSON(w, http.StatusServiceUnavailable, map[string]any{
+			"status":  "error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ms.writeJSON(w, http.StatusOK, map[string]any{
+		"status":             "success",
+		"message":            "Task queue cutover retrieved successfully",
+		"task_queue_cutover": cutover,
+	})
+}
+
+// handleSetTaskQueueCutover sets the share of new transfers started on the next task queue, from a {"percent": N}
+// body. The change lasts until the next restart, which applies the configured percent again.
+func (ms *MetricsServer) handleSetTaskQueueCutover(w http.ResponseWriter, r *http.Request) {
+	const op = "MetricsServer.handleSetTaskQueueCutover"
+
+	var body struct {
+		Percent *int `json:"percent"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Percent == nil {
+		ms.writeJSON(w, http.StatusBadRequest, map[string]any{
+			"status":  "error",
+			"message": `body must be {"percent": 0-100}`,
+		})
+		return
+	}
+
+	logger := ms.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"actor":   r.Header.Get(headerActor),
+		"percent": *body.Percent,
+	})
+
+	logger.Info("Changing task queue cutover")
+
+	cutover, err := ms.service.SetTaskQueueCutoverPercent(*body.Percent)
+	if err != nil {
+		status := http.StatusServiceUnavailable
+		if errors.Is(err, service.ErrInvalidParameters) {
+			status = http.StatusBadRequest
+		}
+
+		logger.WithError(err).Warn()
+
+		ms.writeJSON(w, status, map[string]any{
+			"status":  "error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	ms.writeJSON(w, http.StatusOK, map[string]any{
+		"status":             "success",
+		"message":            fmt.Sprintf("%d%% of new transfers now start on %s", cutover.Percent, cutover.Next),
+		"task_queue_cutover": cutover,
+	})
+}
+
+// taskQueueCutoverMetrics renders the cutover percent and the workflow starts routed to each transfer task queue
+func (ms *MetricsServer) taskQueueCutoverMetrics() string {
+	cutover, err := ms.service.GetTaskQueueCutover()
+	if err != nil {
+		return ""
+	}
+
+	taskQueues := make([]string, 0, len(cutover.Starts))
+	for taskQueue := range cutover.Starts {
+		taskQueues = append(taskQueues, taskQueue)
+	}
+	sort.Strings(taskQueues)
+
+	var starts strings.Builder
+	for _, taskQueue := range taskQueues {
+		fmt.Fprintf(&starts, "flowngine_transfer_task_queue_starts_total{task_queue=%q} %d\n", taskQueue, cutover.Starts[taskQueue])
+	}
+
+	return fmt.Sprintf(`
+# HELP flowngine_task_queue_cutover_percent Share of new transfers started on the next transfer task queue
+# TYPE flowngine_task_queue_cutover_percent gauge
+flowngine_task_queue_cutover_percent{current=%q,next=%q} %d
+
+# HELP flowngine_transfer_task_queue_starts_total Transfer, batch, money request and escrow workflow starts routed to each task queue
+# TYPE flowngine_transfer_task_queue_starts_total counter
+%s`,
+		cutover.Current,
+		cutover.Next,
+		cutover.Percent,
+		starts.String(),
+	)
+}
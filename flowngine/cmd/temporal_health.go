@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// temporalHealthTimeout bounds the Temporal calls made for the health endpoint and for the metrics on every scrape
+const temporalHealthTimeout = 2 * time.Second
+
+// registerTemporalHealthRoutes serves the health of the Temporal namespace and of the task queues FlowEngine and the
+// services depend on, so worker capacity can be checked without the Temporal UI
+func (ms *MetricsServer) registerTemporalHealthRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /temporal/health", ms.handleTemporalHealth)
+}
+
+// handleTemporalHealth returns the namespace state and the pollers and backlog of every watched task queue. It
+// answers 503 when Temporal cannot be reached or a task queue is unhealthy, so it can back a readiness probe.
+func (ms *MetricsServer) handleTemporalHealth(w http.ResponseWriter, r *http.Request) {
+	const op = "MetricsServer.handleTemporalHealth"
+
+	logger := ms.logger.WithField("[op]", op)
+
+	ctx, cancel := context.WithTimeout(r.Context(), temporalHealthTimeout)
+	defer cancel()
+
+	health, err := ms.service.GetTemporalHealth(ctx)
+	if err != nil {
+		logger.WithError(err).Warn()
+
+		ms.writeJSON(w, http.StatusServiceUnavailable, map[string]any{
+			"status":  "error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if !health.Healthy {
+		ms.writeJSON(w, http.StatusServiceUnavailable, map[string]any{
+			"status":          "error",
+			"message":         "Temporal is reachable but not every task queue is served",
+			"temporal_health": health,
+		})
+		return
+	}
+
+	ms.writeJSON(w, http.StatusOK, map[string]any{
+		"status":          "success",
+		"message":         "Temporal health retrieved successfully",
+		"temporal_health": health,
+	})
+}
+
+// temporalHealthMetrics renders the pollers and backlog of the watched task queues. Only flowngine_temporal_up is
+// reported while Temporal cannot be queried, rather than the task queues as empty.
+func (ms *MetricsServer) temporalHealthMetrics(ctx context.Context) string {
+	ctx, cancel := context.WithTimeout(ctx, temporalHealthTimeout)
+	defer cancel()
+
+	health, err := ms.service.GetTemporalHealth(ctx)
+	if err != nil {
+		ms.logger.WithError(err).Debug("Skipping Temporal task queue metrics")
+
+		return `
+# HELP flowngine_temporal_up Whether the Temporal frontend and namespace answer
+# TYPE flowngine_temporal_up gauge
+flowngine_temporal_up 0
+`
+	}
+
+	var pollers, backlog, backlogAge, addRate, dispatchRate, healthy strings.Builder
+	for _, taskQueue := range health.TaskQueues {
+		fmt.Fprintf(&healthy, "flowngine_temporal_task_queue_healthy{task_queue=%q} %d\n", taskQueue.Name, boolGauge(taskQueue.Healthy))
+
+		for _, info := range taskQueue.Types {
+			labels := fmt.Sprintf("{task_queue=%q,type=%q}", taskQueue.Name, info.Type)
+
+			fmt.Fprintf(&pollers, "flowngine_temporal_task_queue_pollers%s %d\n", labels, len(info.Pollers))
+			fmt.Fprintf(&backlog, "flowngine_temporal_task_queue_backlog%s %d\n", labels, info.Backlog)
+			fmt.Fprintf(&backlogAge, "flowngine_temporal_task_queue_backlog_age_seconds%s %g\n", labels, info.BacklogAgeSeconds)
+			fmt.Fprintf(&addRate, "flowngine_temporal_task_queue_tasks_add_rate%s %g\n", labels, info.TasksAddRate)
+			fmt.Fprintf(&dispatchRate, "flowngine_temporal_task_queue_tasks_dispatch_rate%s %g\n", labels, info.TasksDispatchRate)
+		}
+	}
+
+	return fmt.Sprintf(`
+# HELP flowngine_temporal_up Whether the Temporal frontend and namespace answer
+# TYPE flowngine_temporal_up gauge
+flowngine_temporal_up 1
+
+# HELP flowngine_temporal_task_queue_healthy Whether every type of the task queue has pollers and no task waits too long
+# TYPE flowngine_temporal_task_queue_healthy gauge
+%s
+# HELP flowngine_temporal_task_queue_pollers Workers that polled the task queue recently
+# TYPE flowngine_temporal_task_queue_pollers gauge
+%s
+# HELP flowngine_temporal_task_queue_backlog Approximate tasks waiting for a worker
+# TYPE flowngine_temporal_task_queue_backlog gauge
+%s
+# HELP flowngine_temporal_task_queue_backlog_age_seconds Age of the oldest task waiting for a worker (schedule-to-start latency)
+# TYPE flowngine_temporal_task_queue_backlog_age_seconds gauge
+%s
+# HELP flowngine_temporal_task_queue_tasks_add_rate Tasks added per second over the last 30 seconds
+# TYPE flowngine_temporal_task_queue_tasks_add_rate gauge
+%s
+# HELP flowngine_temporal_task_queue_tasks_dispatch_rate Tasks handed to workers per second over the last 30 seconds
+# TYPE flowngine_temporal_task_queue_tasks_dispatch_rate gauge
+%s`,
+		healthy.String(),
+		pollers.String(),
+		backlog.String(),
+		backlogAge.String(),
+		addRate.String(),
+		dispatchRate.String(),
+	)
+}
+
+// boolGauge renders a boolean as a Prometheus gauge value
+func boolGauge(value bool) int {
+	if value {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"flowngine/adapter/transaction_adapter"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrInvalidAdminAuditFilter is returned when svc-transaction rejects an admin audit filter
+var ErrInvalidAdminAuditFilter = errors.New("invalid admin audit filter")
+
+type ListAdminAuditParams struct {
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	From         time.Time
+	To           time.Time
+	Limit        int32
+}
+
+type ListAdminAuditResults struct {
+	Records []transaction_adapter.AdminAuditRecord
+}
+
+func (svc *Service) ListAdminAudit(ctx context.Context, params *ListAdminAuditParams) (*ListAdminAuditResults, error) {
+	const op = "service.Service.ListAdminAudit"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	if params.Limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", ErrInvalidAdminAuditFilter)
+	}
+
+	records, err := svc.transactionAdapter.ListAdminAudits(ctx, transaction_adapter.AdminAuditQuery{
+		Actor:        params.Actor,
+		Action:       params.Action,
+		ResourceType: params.ResourceType,
+		ResourceID:   params.ResourceID,
+		From:         params.From,
+		To:           params.To,
+		Limit:        params.Limit,
+	})
+	if err != nil {
+		var responseErr *transaction_adapter.ResponseError
+		if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusBadRequest {
+			err = fmt.Errorf("%w: %s", ErrInvalidAdminAuditFilter, responseErr.Message)
+		} else {
+			err = fmt.Errorf("failed to read admin audit: %w", err)
+		}
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return &ListAdminAuditResults{Records: records}, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAdminAuditForwardsFilter(t *testing.T) {
+	t.Parallel()
+
+	to := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	svc := newCompensationAuditTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/admin-audit", r.URL.Path)
+		assert.Equal(t, "ops@example.com", r.URL.Query().Get("actor"))
+		assert.Equal(t, "balance_alert", r.URL.Query().Get("resource_type"))
+		assert.Equal(t, "2025-06-01T00:00:00Z", r.URL.Query().Get("to"))
+		assert.False(t, r.URL.Query().Has("action"))
+		assert.False(t, r.URL.Query().Has("from"))
+		assert.Equal(t, "20", r.URL.Query().Get("limit"))
+
+		_, _ = w.Write([]byte(`{"message":"ok","data":[{"id":"a1","actor":"ops@example.com","action":"balance_alert.set_enabled","service":"svc-balance","resource_type":"balance_alert","resource_id":"alert-1","before":{"enabled":true},"after":{"enabled":false}}],"count":1}`))
+	})
+
+	results, err := svc.ListAdminAudit(context.Background(), &ListAdminAuditParams{
+		Actor:        "ops@example.com",
+		ResourceType: "balance_alert",
+		To:           to,
+		Limit:        20,
+	})
+	require.NoError(t, err)
+	require.Len(t, results.Records, 1)
+	assert.Equal(t, "svc-balance", results.Records[0].Service)
+	assert.JSONEq(t, `{"enabled":true}`, string(results.Records[0].Before))
+	assert.JSONEq(t, `{"enabled":false}`, string(results.Records[0].After))
+}
+
+func TestListAdminAuditRejectedFilter(t *testing.T) {
+	t.Parallel()
+
+	svc := newCompensationAuditTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid admin audit filter: from must be before to"}`))
+	})
+
+	_, err := svc.ListAdminAudit(context.Background(), &ListAdminAuditParams{})
+	require.ErrorIs(t, err, ErrInvalidAdminAuditFilter)
+	assert.Contains(t, err.Error(), "from must be before to")
+}
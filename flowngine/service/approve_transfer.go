@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"flowngine/util/failure"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/serviceerror"
+)
+
+type ApproveTransferParams struct {
+	TransactionID string `json:"transaction_id"` // Transaction ID or transfer reference
+	ApprovedBy    string `json:"approved_by"`
+}
+
+type ApproveTransferResults struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// ApproveTransfer signals a transfer held for approval to go ahead. Transfers that already finished, e.g. because
+// they expired, are reported as not found.
+func (svc *Service) ApproveTransfer(ctx context.Context, params *ApproveTransferParams) (*ApproveTransferResults, error) {
+	const op = "service.Service.ApproveTransfer"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Approving transfer")
+
+	// Validate input parameters
+	if err := validateApproveTransferParams(params); err != nil {
+		err = invalidParameters(err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	if svc.temporalClient == nil {
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn("ApproveTransfer request received but Temporal client not ready")
+
+		return nil, err
+	}
+
+	transactionID, _, err := svc.resolveTransferReference(ctx, params.TransactionID)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	workflowID := transferWorkflowID(transactionID)
+
+	err = svc.simulateSignalFailure(ctx)
+	if err == nil {
+		err = svc.temporalClient.SignalWorkflow(ctx, workflowID, "", ApproveTransferSignalName, ApproveTransferSignal{
+			ApprovedBy: params.ApprovedBy,
+		})
+	}
+	if errors.Is(err, failure.ErrDropped) {
+		// The caller is told the transfer was approved, so it expires awaiting the approval
+		logger.WithField("workflow_id", workflowID).Warn("Transfer approval signal dropped by failure simulation")
+	} else if err != nil {
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			err = fmt.Errorf("%w: %s is not running", ErrTransferNotFound, workflowID)
+		} else {
+			err = fmt.Errorf("failed to signal workflow: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &ApproveTransferResults{
+		Success: true,
+		Message: fmt.Sprintf("Transfer %s approved by %s", transactionID, params.ApprovedBy),
+	}
+
+	logger.WithField("workflow_id", workflowID).Info("Transfer approval signalled")
+
+	return results, nil
+}
+
+// validateApproveTransferParams validates the input parameters for transfer approval
+func validateApproveTransferParams(params *ApproveTransferParams) error {
+	if params.TransactionID == "" {
+		return newFieldViolation("transaction_id", "transaction_id is required")
+	}
+
+	if params.ApprovedBy == "" {
+		return newFieldViolation("approved_by", "approved_by is required")
+	}
+
+	return nil
+}
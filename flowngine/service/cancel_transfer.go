@@ -30,7 +30,7 @@ func (svc *Service) CancelTransfer(ctx context.Context, params *CancelTransferPa
 
 	// Validate input parameters
 	if err := validateCancelTransferParams(params); err != nil {
-		err = fmt.Errorf("invalid parameters: %w", err)
+		err = invalidParameters(err)
 
 		logger.WithError(err).Error()
 
@@ -38,7 +38,7 @@ func (svc *Service) CancelTransfer(ctx context.Context, params *CancelTransferPa
 	}
 
 	// Generate workflow ID from transaction ID (following the same pattern as ExecuteTransfer)
-	workflowID := fmt.Sprintf("transfer_workflow_%s", params.TransactionID)
+	workflowID := transferWorkflowID(params.TransactionID)
 
 	logger.Info("Cancelling Temporal workflow", "workflow_id", workflowID, "transaction_id", params.TransactionID, "reason", params.Reason)
 
@@ -82,11 +82,11 @@ func (svc *Service) CancelTransfer(ctx context.Context, params *CancelTransferPa
 // validateCancelTransferParams validates the input parameters for transfer cancellation
 func validateCancelTransferParams(params *CancelTransferParams) error {
 	if params.TransactionID == "" {
-		return fmt.Errorf("transaction_id is required")
+		return newFieldViolation("transaction_id", "transaction_id is required")
 	}
 
 	if params.Reason == "" {
-		return fmt.Errorf("reason is required")
+		return newFieldViolation("reason", "reason is required")
 	}
 
 	return nil
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"flowngine/adapter/transaction_adapter"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrInvalidCompensationFilter is returned when svc-transaction rejects a compensation audit filter
+var ErrInvalidCompensationFilter = errors.New("invalid compensation filter")
+
+// CompensationFilter narrows compensation audit queries; zero-valued fields leave that dimension unfiltered
+type CompensationFilter struct {
+	Status string
+	Type   string
+	From   time.Time
+	To     time.Time
+}
+
+type ListCompensationsParams struct {
+	Filter     CompensationFilter
+	WorkflowID string
+	Limit      int32
+}
+
+type ListCompensationsResults struct {
+	Compensations []transaction_adapter.CompensationAuditRecord
+}
+
+type GetCompensationStatsParams struct {
+	Filter CompensationFilter
+}
+
+type GetCompensationStatsResults struct {
+	Total           int64
+	Completed       int64
+	Failed          int64
+	Timeout         int64
+	ManualRequired  int64
+	Pending         int64
+	AverageAttempts float64
+	From            time.Time
+	To              time.Time
+}
+
+func (svc *Service) ListCompensations(ctx context.Context, params *ListCompensationsParams) (*ListCompensationsResults, error) {
+	const op = "service.Service.ListCompensations"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	if params.Limit < 0 {
+		return nil, fmt.Errorf("%w: limit must not be negative", ErrInvalidCompensationFilter)
+	}
+
+	records, err := svc.transactionAdapter.ListCompensationAudits(ctx, transaction_adapter.CompensationAuditQuery{
+		Status:     params.Filter.Status,
+		Type:       params.Filter.Type,
+		WorkflowID: params.WorkflowID,
+		From:       params.Filter.From,
+		To:         params.Filter.To,
+		Limit:      params.Limit,
+	})
+	if err != nil {
+		err = compensationAuditError(err)
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return &ListCompensationsResults{Compensations: records}, nil
+}
+
+func (svc *Service) GetCompensationStats(ctx context.Context, params *GetCompensationStatsParams) (*GetCompensationStatsResults, error) {
+	const op = "service.Service.GetCompensationStats"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	stats, err := svc.transactionAdapter.GetCompensationStats(ctx, transaction_adapter.CompensationAuditQuery{
+		Status: params.Filter.Status,
+		Type:   params.Filter.Type,
+		From:   params.Filter.From,
+		To:     params.Filter.To,
+	})
+	if err != nil {
+		err = compensationAuditError(err)
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return &GetCompensationStatsResults{
+		Total:           stats.TotalCompensations,
+		Completed:       stats.CompletedCompensations,
+		Failed:          stats.FailedCompensations,
+		Timeout:         stats.TimeoutCompensations,
+		ManualRequired:  stats.ManualCompensations,
+		Pending:         stats.PendingCompensations,
+		AverageAttempts: stats.AverageAttempts,
+		From:            stats.From,
+		To:              stats.To,
+	}, nil
+}
+
+// compensationAuditError turns a filter rejected by svc-transaction into ErrInvalidCompensationFilter
+func compensationAuditError(err error) error {
+	var responseErr *transaction_adapter.ResponseError
+	if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusBadRequest {
+		return fmt.Errorf("%w: %s", ErrInvalidCompensationFilter, responseErr.Message)
+	}
+
+	return fmt.Errorf("failed to read compensation audit: %w", err)
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"flowngine/adapter/transaction_adapter"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCompensationAuditTestService(t *testing.T, handler http.HandlerFunc) *Service {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	logger := logrus.New()
+
+	return &Service{
+		logger:             logger,
+		transactionAdapter: transaction_adapter.NewAdapter("svc-transaction", logger, server.URL, time.Second),
+	}
+}
+
+func TestListCompensationsForwardsFilter(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	svc := newCompensationAuditTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/compensation-audit", r.URL.Path)
+		assert.Equal(t, "failed", r.URL.Query().Get("status"))
+		assert.Equal(t, "debit_reversal", r.URL.Query().Get("type"))
+		assert.Equal(t, "2025-06-01T00:00:00Z", r.URL.Query().Get("from"))
+		assert.False(t, r.URL.Query().Has("to"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+
+		_, _ = w.Write([]byte(`{"message":"ok","data":[{"id":"a1","workflow_id":"transfer-1","compensation_status":"failed","compensation_attempts":3}],"count":1}`))
+	})
+
+	results, err := svc.ListCompensations(context.Background(), &ListCompensationsParams{
+		Filter: CompensationFilter{Status: "failed", Type: "debit_reversal", From: from},
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, results.Compensations, 1)
+	assert.Equal(t, "transfer-1", results.Compensations[0].WorkflowID)
+	assert.Equal(t, int32(3), results.Compensations[0].CompensationAttempts)
+	assert.Nil(t, results.Compensations[0].CompletedAt)
+}
+
+func TestGetCompensationStatsRejectedFilter(t *testing.T) {
+	t.Parallel()
+
+	svc := newCompensationAuditTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid compensation audit filter: unknown status \"done\""}`))
+	})
+
+	_, err := svc.GetCompensationStats(context.Background(), &GetCompensationStatsParams{
+		Filter: CompensationFilter{Status: "done"},
+	})
+	require.ErrorIs(t, err, ErrInvalidCompensationFilter)
+	assert.Contains(t, err.Error(), "unknown status")
+}
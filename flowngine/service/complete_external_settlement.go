@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/temporal"
+)
+
+// ErrExternalSettlementNotAwaited is returned for partner callbacks whose activity no longer waits for them, e.g.
+// because the attempt timed out and the settlement was resubmitted with a new task token
+var ErrExternalSettlementNotAwaited = errors.New("no settlement activity awaits this callback")
+
+// Statuses of a settlement the partner bank calls back with
+const (
+	externalSettlementSettled  = "settled"
+	externalSettlementRejected = "rejected"
+	externalSettlementRecalled = "recalled"
+)
+
+// CompleteExternalSettlementParams is the callback of the partner bank for a settlement that is no longer pending
+type CompleteExternalSettlementParams struct {
+	CallbackToken []byte     `json:"callback_token"` // Task token of the SettleExternalTransfer activity, base64 encoded
+	SettlementID  string     `json:"settlement_id"`
+	Status        string     `json:"status"` // settled, rejected or recalled
+	Reason        string     `json:"reason,omitempty"`
+	SettledAt     *time.Time `json:"settled_at,omitempty"`
+}
+
+type CompleteExternalSettlementResults struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// CompleteExternalSettlement completes a SettleExternalTransfer activity left pending for the partner bank's
+// callback. A settled settlement completes it with the result svc-transaction would have returned; any other
+// status fails it as rejected, so the saga compensates the debit.
+func (svc *Service) CompleteExternalSettlement(ctx context.Context, params *CompleteExternalSettlementParams) (*CompleteExternalSettlementResults, error) {
+	const op = "service.Service.CompleteExternalSettlement"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":          op,
+		"settlement_id": params.SettlementID,
+		"status":        params.Status,
+	})
+
+	logger.Info("Completing external settlement")
+
+	// Validate input parameters
+	if err := validateCompleteExternalSettlementParams(params); err != nil {
+		err = invalidParameters(err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	if svc.temporalClient == nil {
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn("CompleteExternalSettlement request received but Temporal client not ready")
+
+		return nil, err
+	}
+
+	var err error
+	if params.Status == externalSettlementSettled {
+		result := map[string]interface{}{
+			"settlement_id": params.SettlementID,
+			"status":        params.Status,
+		}
+		if params.SettledAt != nil {
+			result["settled_at"] = params.SettledAt.Format(time.RFC3339)
+		}
+
+		err = svc.temporalClient.CompleteActivity(ctx, params.CallbackToken, result, nil)
+	} else {
+		err = svc.temporalClient.CompleteActivity(ctx, params.CallbackToken, nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("settlement %s is %s: %s", params.SettlementID, params.Status, params.Reason),
+			externalSettlementRejectedErrorType,
+			nil,
+		))
+	}
+	if err != nil {
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			err = fmt.Errorf("%w: settlement %s", ErrExternalSettlementNotAwaited, params.SettlementID)
+		} else {
+			err = fmt.Errorf("failed to complete activity: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &CompleteExternalSettlementResults{
+		Success: true,
+		Message: fmt.Sprintf("Settlement %s completed as %s", params.SettlementID, params.Status),
+	}
+
+	logger.Info("External settlement completed")
+
+	return results, nil
+}
+
+// validateCompleteExternalSettlementParams validates the input parameters of a partner callback
+func validateCompleteExternalSettlementParams(params *CompleteExternalSettlementParams) error {
+	if len(params.CallbackToken) == 0 {
+		return newFieldViolation("callback_token", "callback_token is required")
+	}
+
+	if params.SettlementID == "" {
+		return newFieldViolation("settlement_id", "settlement_id is required")
+	}
+
+	switch params.Status {
+	case externalSettlementSettled, externalSettlementRejected, externalSettlementRecalled:
+	default:
+		return newFieldViolation("status", "status must be settled, rejected or recalled, got %q", params.Status)
+	}
+
+	return nil
+}
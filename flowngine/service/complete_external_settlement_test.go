@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/mocks"
+	"go.temporal.io/sdk/temporal"
+)
+
+func newCompleteExternalSettlementTestService(temporalClient *mocks.Client) *Service {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	return &Service{
+		logger:         logger,
+		temporalClient: temporalClient,
+	}
+}
+
+func TestCompleteExternalSettlement(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	settledAt := time.Date(2025, 6, 2, 10, 0, 5, 0, time.UTC)
+	token := []byte("task-token")
+
+	t.Run("settled_completes_activity", func(t *testing.T) {
+		t.Parallel()
+
+		temporalClient := &mocks.Client{}
+		temporalClient.On("CompleteActivity", mock.Anything, token, map[string]interface{}{
+			"settlement_id": "idempotency-123-settle",
+			"status":        "settled",
+			"settled_at":    "2025-06-02T10:00:05Z",
+		}, nil).Return(nil).Once()
+
+		svc := newCompleteExternalSettlementTestService(temporalClient)
+
+		results, err := svc.CompleteExternalSettlement(ctx, &CompleteExternalSettlementParams{
+			CallbackToken: token,
+			SettlementID:  "idempotency-123-settle",
+			Status:        "settled",
+			SettledAt:     &settledAt,
+		})
+		require.NoError(t, err)
+		assert.True(t, results.Success)
+		temporalClient.AssertExpectations(t)
+	})
+
+	t.Run("recalled_fails_activity_as_rejected", func(t *testing.T) {
+		t.Parallel()
+
+		temporalClient := &mocks.Client{}
+		temporalClient.On("CompleteActivity", mock.Anything, token, nil, mock.MatchedBy(func(err error) bool {
+			var applicationErr *temporal.ApplicationError
+			return errors.As(err, &applicationErr) &&
+				applicationErr.Type() == externalSettlementRejectedErrorType &&
+				applicationErr.NonRetryable()
+		})).Return(nil).Once()
+
+		svc := newCompleteExternalSettlementTestService(temporalClient)
+
+		_, err := svc.CompleteExternalSettlement(ctx, &CompleteExternalSettlementParams{
+			CallbackToken: token,
+			SettlementID:  "idempotency-123-settle",
+			Status:        "recalled",
+		})
+		require.NoError(t, err)
+		temporalClient.AssertExpectations(t)
+	})
+
+	t.Run("activity_no_longer_waiting", func(t *testing.T) {
+		t.Parallel()
+
+		temporalClient := &mocks.Client{}
+		temporalClient.On("CompleteActivity", mock.Anything, token, mock.Anything, nil).Return(serviceerror.NewNotFound("activity not found")).Once()
+
+		svc := newCompleteExternalSettlementTestService(temporalClient)
+
+		_, err := svc.CompleteExternalSettlement(ctx, &CompleteExternalSettlementParams{
+			CallbackToken: token,
+			SettlementID:  "idempotency-123-settle",
+			Status:        "settled",
+		})
+		assert.ErrorIs(t, err, ErrExternalSettlementNotAwaited)
+	})
+
+	t.Run("pending_is_invalid", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newCompleteExternalSettlementTestService(&mocks.Client{})
+
+		_, err := svc.CompleteExternalSettlement(ctx, &CompleteExternalSettlementParams{
+			CallbackToken: token,
+			SettlementID:  "idempotency-123-settle",
+			Status:        "pending",
+		})
+		assert.ErrorIs(t, err, ErrInvalidParameters)
+	})
+}
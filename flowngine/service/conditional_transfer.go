@@ -0,0 +1,160 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// IncomingCreditSignalName is the signal svc-transaction sends to the conditional transfers watching an account
+// whenever that account is credited
+const IncomingCreditSignalName = "incoming_credit"
+
+// IncomingCreditSignal is the payload of IncomingCreditSignalName. The account is given both ways, since a
+// trigger may name it by either.
+type IncomingCreditSignal struct {
+	TransactionID string          `json:"transaction_id"` // Credit transaction; credits signalled twice are counted once
+	AccountID     string          `json:"account_id"`
+	AccountNumber string          `json:"account_number"`
+	Amount        decimal.Decimal `json:"amount"`
+	Currency      string          `json:"currency"`
+}
+
+// ConditionalTransferParams defines the input parameters for the conditional transfer workflow: once
+// TriggerAccount has received TriggerAmount, Transfer is executed like any other transfer
+type ConditionalTransferParams struct {
+	Transfer             TransferWorkflowParams `json:"transfer"`
+	TriggerAccount       string                 `json:"trigger_account"`
+	TriggerAmount        decimal.Decimal        `json:"trigger_amount"`                   // In the transfer currency; credits in other currencies are ignored
+	TriggerExpirySeconds int                    `json:"trigger_expiry_seconds,omitempty"` // 0 waits until the workflow times out
+}
+
+// conditionalTransferWorkflow waits for the trigger account of a transfer to receive the trigger amount, driven by
+// the IncomingCreditSignalName signals svc-transaction sends for every credit, and then runs the transfer saga.
+// A transfer that is not triggered in time expires without being debited.
+func conditionalTransferWorkflow(ctx workflow.Context, params ConditionalTransferParams) (*TransferWorkflowResults, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting conditionalTransferWorkflow", "transfer_id", params.Transfer.TransferID, "trigger_account", params.TriggerAccount, "trigger_amount", params.TriggerAmount)
+
+	results := newTransferWorkflowResults(ctx, params.Transfer)
+
+	if err := validateConditionalTransferParams(params); err != nil {
+		logger.Error("Invalid workflow parameters", "error", err)
+		results.Status = "failed"
+		results.ErrorMessage = fmt.Sprintf("validation failed: %v", err)
+		completedAt := workflow.Now(ctx)
+		results.CompletedAt = &completedAt
+		return results, err
+	}
+
+	waits, err := newTransferWaits(ctx)
+	if err != nil {
+		logger.Error("Failed to register transfer wait query", "error", err)
+		results.Status = "failed"
+		results.ErrorMessage = fmt.Sprintf("failed to register transfer wait query: %v", err)
+		completedAt := workflow.Now(ctx)
+		results.CompletedAt = &completedAt
+		return results, err
+	}
+
+	if !awaitTransferTrigger(ctx, params, waits) {
+		reason := fmt.Sprintf("%s did not receive %s %s within %ds",
+			params.TriggerAccount, formatMajorUnits(params.TriggerAmount, params.Transfer.Currency), params.Transfer.Currency, params.TriggerExpirySeconds)
+		expireTransfer(ctx, params.Transfer, results, reason)
+		return results, nil
+	}
+
+	// Stop svc-transaction from signalling a transfer that no longer watches the account
+	if err := workflow.UpsertTypedSearchAttributes(ctx, triggerAccountSearchAttribute.ValueUnset()); err != nil {
+		logger.Warn("Failed to clear transfer trigger account", "error", err)
+	}
+
+	return transferWorkflow(ctx, params.Transfer)
+}
+
+// awaitTransferTrigger adds up the credits of the trigger account until they reach the trigger amount. It returns
+// false when the trigger expires first.
+func awaitTransferTrigger(ctx workflow.Context, params ConditionalTransferParams, waits *transferWaits) bool {
+	logger := workflow.GetLogger(ctx)
+
+	expiry := &transferExpiry{}
+	if params.TriggerExpirySeconds > 0 {
+		expiry.deadline = workflow.Now(ctx).Add(time.Duration(params.TriggerExpirySeconds) * time.Second)
+	}
+
+	received := decimal.Zero
+	wait := waits.start(ctx, TransferWaitReasonTrigger, expiry)
+	wait.TriggerAccount = params.TriggerAccount
+	wait.AmountReceived = formatMajorUnits(received, params.Transfer.Currency)
+	defer waits.done()
+
+	logger.Info("Transfer waiting for its trigger", "transfer_id", params.Transfer.TransferID, "trigger_account", params.TriggerAccount, "expires_at", expiry.deadline)
+
+	timerCtx, stopTimer := workflow.WithCancel(ctx)
+	defer stopTimer()
+	timer := expiry.newTimer(timerCtx)
+
+	credits := workflow.GetSignalChannel(ctx, IncomingCreditSignalName)
+	counted := map[string]bool{}
+
+	for received.LessThan(params.TriggerAmount) {
+		expired := false
+
+		selector := workflow.NewSelector(ctx)
+		selector.AddReceive(credits, func(channel workflow.ReceiveChannel, more bool) {
+			var credit IncomingCreditSignal
+			channel.Receive(ctx, &credit)
+
+			if !triggerCounts(params, credit) || counted[credit.TransactionID] {
+				logger.Info("Ignoring credit that does not count towards the trigger", "transfer_id", params.Transfer.TransferID, "credit", credit)
+				return
+			}
+			counted[credit.TransactionID] = true
+
+			received = received.Add(credit.Amount)
+			wait.AmountReceived = formatMajorUnits(received, params.Transfer.Currency)
+
+			logger.Info("Trigger account credited", "transfer_id", params.Transfer.TransferID, "credit_transaction_id", credit.TransactionID, "received", received)
+		})
+		if timer != nil {
+			selector.AddFuture(timer, func(workflow.Future) { expired = true })
+		}
+		selector.Select(ctx)
+
+		if expired {
+			return false
+		}
+	}
+
+	logger.Info("Transfer triggered", "transfer_id", params.Transfer.TransferID, "received", received)
+
+	return true
+}
+
+// triggerCounts reports whether a credit counts towards the trigger of a conditional transfer
+func triggerCounts(params ConditionalTransferParams, credit IncomingCreditSignal) bool {
+	if credit.AccountNumber != params.TriggerAccount && credit.AccountID != params.TriggerAccount {
+		return false
+	}
+
+	return credit.TransactionID != "" && credit.Currency == params.Transfer.Currency && credit.Amount.IsPositive()
+}
+
+// validateConditionalTransferParams validates the input parameters for the conditional transfer workflow
+func validateConditionalTransferParams(params ConditionalTransferParams) error {
+	if err := validateTransferWorkflowParams(params.Transfer); err != nil {
+		return err
+	}
+
+	if params.TriggerAccount == "" {
+		return fmt.Errorf("trigger_account is required")
+	}
+
+	if !params.TriggerAmount.IsPositive() {
+		return fmt.Errorf("trigger_amount must be positive")
+	}
+
+	return validateCurrencyAmount(params.TriggerAmount, params.Transfer.Currency)
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalTransferWorkflow(t *testing.T) {
+	t.Parallel()
+
+	conditionalParams := func() ConditionalTransferParams {
+		return ConditionalTransferParams{
+			Transfer:             validTransferWorkflowParams(),
+			TriggerAccount:       "account-from",
+			TriggerAmount:        decimal.NewFromInt(500),
+			TriggerExpirySeconds: 24 * 3600,
+		}
+	}
+
+	credit := func(transactionID, accountNumber string, amount int64, currency string) IncomingCreditSignal {
+		return IncomingCreditSignal{
+			TransactionID: transactionID,
+			AccountNumber: accountNumber,
+			Amount:        decimal.NewFromInt(amount),
+			Currency:      currency,
+		}
+	}
+
+	t.Run("transfer_runs_once_credits_reach_trigger_amount", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(map[string]interface{}{"sufficient_funds": true}, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(map[string]interface{}{"transaction_id": "debit-transaction-123"}, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(map[string]interface{}{"transaction_id": "credit-transaction-123"}, nil).Once()
+
+		signals := []IncomingCreditSignal{
+			credit("credit-1", "account-from", 300, "USD"),
+			credit("credit-1", "account-from", 300, "USD"), // Signalled twice, counted once
+			credit("credit-2", "account-other", 300, "USD"),
+			credit("credit-3", "account-from", 300, "EUR"),
+			credit("credit-4", "account-from", 200, "USD"),
+		}
+		for i, signal := range signals {
+			env.RegisterDelayedCallback(func() {
+				env.SignalWorkflow(IncomingCreditSignalName, signal)
+			}, time.Duration(i+1)*time.Hour)
+		}
+
+		var wait *TransferWaitState
+		env.RegisterDelayedCallback(func() {
+			value, err := env.QueryWorkflow(TransferWaitQueryType)
+			require.NoError(t, err)
+			require.NoError(t, value.Get(&wait))
+		}, 4*time.Hour+30*time.Minute)
+
+		env.ExecuteWorkflow(conditionalTransferWorkflow, conditionalParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "completed", results.Status)
+
+		require.NotNil(t, wait)
+		assert.Equal(t, TransferWaitReasonTrigger, wait.Reason)
+		assert.Equal(t, "account-from", wait.TriggerAccount)
+		assert.Equal(t, "300.00", wait.AmountReceived)
+	})
+
+	t.Run("untriggered_transfer_expires_without_debit", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.RegisterDelayedCallback(func() {
+			env.SignalWorkflow(IncomingCreditSignalName, credit("credit-1", "account-from", 100, "USD"))
+		}, time.Hour)
+
+		env.ExecuteWorkflow(conditionalTransferWorkflow, conditionalParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "expired", results.Status)
+		assert.Equal(t, "account-from did not receive 500.00 USD within 86400s", results.ErrorMessage)
+		require.NotNil(t, results.CompletedAt)
+		assert.Equal(t, 24*time.Hour, results.CompletedAt.Sub(results.StartedAt))
+		env.AssertNotCalled(t, "CheckBalance", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid_trigger_fails_before_waiting", func(t *testing.T) {
+		t.Parallel()
+
+		params := conditionalParams()
+		params.TriggerAmount = decimal.RequireFromString("0.001")
+
+		env := newTransferWorkflowTestEnv(t)
+		env.ExecuteWorkflow(conditionalTransferWorkflow, params)
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.Error(t, env.GetWorkflowError())
+	})
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// currencyDecimalPlaces lists the supported currencies with the decimal places of the svc-balance currency catalog
+var currencyDecimalPlaces = map[string]int32{
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"JPY": 0,
+	"CAD": 2,
+	"AUD": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"SGD": 2,
+	"HKD": 2,
+}
+
+// validateCurrencyAmount checks that the currency is supported and that the amount fits its minor units,
+// e.g. 10.50 JPY is rejected because yen have no fractional part
+func validateCurrencyAmount(amount decimal.Decimal, currency string) error {
+	places, ok := currencyDecimalPlaces[currency]
+	if !ok {
+		return fmt.Errorf("unsupported currency: %s", currency)
+	}
+
+	if !amount.Equal(amount.Truncate(places)) {
+		return fmt.Errorf("amount %s exceeds %d decimal places allowed for %s", amount.String(), places, currency)
+	}
+
+	return nil
+}
+
+// transferAmount is a transfer amount in both representations accepted by the API
+type transferAmount struct {
+	Decimal    decimal.Decimal // Major units, e.g. 100.50 USD
+	MinorUnits int64           // Minor units of the currency, e.g. 10050 cents or 1050 yen
+}
+
+// resolveTransferAmount reads the amount from whichever of amount (minor units) or amount_decimal (major units) is set.
+// Exactly one of them must be provided.
+func resolveTransferAmount(params *ExecuteTransferParams) (transferAmount, error) {
+	if params.Amount != 0 && params.AmountDecimal != "" {
+		return transferAmount{}, newFieldViolation("amount_decimal", "only one of amount or amount_decimal can be set")
+	}
+
+	if params.AmountDecimal != "" {
+		amount, err := decimal.NewFromString(params.AmountDecimal)
+		if err != nil {
+			return transferAmount{}, newFieldViolation("amount_decimal", "invalid amount_decimal: %s", params.AmountDecimal)
+		}
+
+		if !amount.IsPositive() {
+			return transferAmount{}, newFieldViolation("amount_decimal", "amount must be positive")
+		}
+
+		minorUnits, err := decimalToMinorUnits(amount, params.Currency)
+		if err != nil {
+			return transferAmount{}, &FieldViolation{Field: "amount_decimal", Description: err.Error()}
+		}
+
+		return transferAmount{Decimal: amount, MinorUnits: minorUnits}, nil
+	}
+
+	if params.Amount <= 0 {
+		return transferAmount{}, newFieldViolation("amount", "amount must be positive")
+	}
+
+	amount, err := minorUnitsToDecimal(params.Amount, params.Currency)
+	if err != nil {
+		return transferAmount{}, &FieldViolation{Field: "currency", Description: err.Error()}
+	}
+
+	return transferAmount{Decimal: amount, MinorUnits: params.Amount}, nil
+}
+
+// minorUnitsToDecimal converts an amount in the currency's minor units to major units, e.g. 10050 USD -> 100.50, 1050 JPY -> 1050
+func minorUnitsToDecimal(amount int64, currency string) (decimal.Decimal, error) {
+	places, ok := currencyDecimalPlaces[currency]
+	if !ok {
+		return decimal.Zero, fmt.Errorf("unsupported currency: %s", currency)
+	}
+
+	return decimal.New(amount, -places), nil
+}
+
+// decimalToMinorUnits converts a major-unit amount to the currency's minor units.
+// Amounts finer than the minor unit, or too large for int64, are rejected rather than rounded.
+func decimalToMinorUnits(amount decimal.Decimal, currency string) (int64, error) {
+	if err := validateCurrencyAmount(amount, currency); err != nil {
+		return 0, err
+	}
+
+	minorUnits := amount.Shift(currencyDecimalPlaces[currency])
+	if !minorUnits.BigInt().IsInt64() {
+		return 0, fmt.Errorf("amount %s is out of range", amount.String())
+	}
+
+	return minorUnits.IntPart(), nil
+}
+
+// formatMajorUnits formats an amount with exactly the currency's decimal places, e.g. "100.50" USD or "1050" JPY
+func formatMajorUnits(amount decimal.Decimal, currency string) string {
+	places, ok := currencyDecimalPlaces[currency]
+	if !ok {
+		return amount.String()
+	}
+
+	return amount.StringFixed(places)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCurrencyAmount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		amount   string
+		currency string
+		errorMsg string
+	}{
+		{name: "usd_cents", amount: "100.50", currency: "USD"},
+		{name: "usd_trailing_zeros", amount: "100.500", currency: "USD"},
+		{name: "usd_fractional_cents", amount: "100.505", currency: "USD", errorMsg: "amount 100.505 exceeds 2 decimal places allowed for USD"},
+		{name: "jpy_whole_yen", amount: "1050", currency: "JPY"},
+		{name: "jpy_fractional_yen", amount: "10.5", currency: "JPY", errorMsg: "amount 10.5 exceeds 0 decimal places allowed for JPY"},
+		{name: "unsupported_currency", amount: "10", currency: "IDR", errorMsg: "unsupported currency: IDR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateCurrencyAmount(decimal.RequireFromString(tt.amount), tt.currency)
+			if tt.errorMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errorMsg)
+			}
+		})
+	}
+}
+
+func TestResolveTransferAmount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		amount         int64
+		amountDecimal  string
+		currency       string
+		wantDecimal    string
+		wantMinorUnits int64
+		errorMsg       string
+	}{
+		{name: "usd_minor_units", amount: 10050, currency: "USD", wantDecimal: "100.5", wantMinorUnits: 10050},
+		{name: "jpy_minor_units", amount: 1050, currency: "JPY", wantDecimal: "1050", wantMinorUnits: 1050},
+		{name: "usd_decimal", amountDecimal: "100.50", currency: "USD", wantDecimal: "100.5", wantMinorUnits: 10050},
+		{name: "jpy_decimal", amountDecimal: "1050", currency: "JPY", wantDecimal: "1050", wantMinorUnits: 1050},
+		{name: "both_set", amount: 10050, amountDecimal: "100.50", currency: "USD", errorMsg: "only one of amount or amount_decimal can be set"},
+		{name: "neither_set", currency: "USD", errorMsg: "amount must be positive"},
+		{name: "negative_minor_units", amount: -1, currency: "USD", errorMsg: "amount must be positive"},
+		{name: "negative_decimal", amountDecimal: "-1.00", currency: "USD", errorMsg: "amount must be positive"},
+		{name: "malformed_decimal", amountDecimal: "1,00", currency: "USD", errorMsg: "invalid amount_decimal: 1,00"},
+		{name: "fractional_yen", amountDecimal: "10.50", currency: "JPY", errorMsg: "amount 10.5 exceeds 0 decimal places allowed for JPY"},
+		{name: "fractional_cents", amountDecimal: "0.001", currency: "USD", errorMsg: "amount 0.001 exceeds 2 decimal places allowed for USD"},
+		{name: "out_of_range", amountDecimal: "92233720368547758.08", currency: "USD", errorMsg: "amount 92233720368547758.08 is out of range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			amount, err := resolveTransferAmount(&ExecuteTransferParams{
+				Amount:        tt.amount,
+				AmountDecimal: tt.amountDecimal,
+				Currency:      tt.currency,
+			})
+			if tt.errorMsg != "" {
+				assert.EqualError(t, err, tt.errorMsg)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDecimal, amount.Decimal.String())
+			assert.Equal(t, tt.wantMinorUnits, amount.MinorUnits)
+		})
+	}
+}
+
+func TestMinorUnitsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for currency := range currencyDecimalPlaces {
+		for _, minorUnits := range []int64{1, 99, 10050, 1000000000} {
+			amount, err := minorUnitsToDecimal(minorUnits, currency)
+			assert.NoError(t, err)
+
+			back, err := decimalToMinorUnits(amount, currency)
+			assert.NoError(t, err)
+			assert.Equal(t, minorUnits, back, "%d %s", minorUnits, currency)
+		}
+	}
+
+	assert.Equal(t, "100.50", formatMajorUnits(decimal.RequireFromString("100.5"), "USD"))
+	assert.Equal(t, "1050", formatMajorUnits(decimal.NewFromInt(1050), "JPY"))
+}
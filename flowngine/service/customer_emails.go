@@ -0,0 +1,53 @@
+package service
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// Event types of the emails sent to account holders, matching the event types accepted by svc-balance
+const (
+	transferCompletedEventType   = "transfer.completed"
+	transferCompensatedEventType = "transfer.compensated"
+)
+
+// notifyTransferOutcome emails the account holders of a finished transfer through the SendTransferNotification
+// activity of svc-balance. The outcome of the transfer is already settled, so a failed email is only logged.
+func notifyTransferOutcome(ctx workflow.Context, params TransferWorkflowParams, eventType, reason string, occurredAt time.Time) {
+	if !params.NotifyCustomers {
+		return
+	}
+
+	logger := workflow.GetLogger(ctx)
+	workflowInfo := workflow.GetInfo(ctx)
+
+	// Relays recover quickly or not at all, so delivery is attempted a few times and then given up
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2,
+			MaximumAttempts:    3,
+		},
+	})
+
+	notificationParams := map[string]interface{}{
+		"event_type":   eventType,
+		"transfer_id":  params.TransferID,
+		"from_account": params.FromAccount,
+		"to_account":   params.ToAccount,
+		"amount":       params.Amount,
+		"currency":     params.Currency,
+		"description":  params.Description,
+		"reason":       reason,
+		"occurred_at":  occurredAt,
+		"workflow_id":  workflowInfo.WorkflowExecution.ID,
+		"run_id":       workflowInfo.WorkflowExecution.RunID,
+	}
+
+	if err := workflow.ExecuteActivity(ctx, "SendTransferNotification", notificationParams).Get(ctx, nil); err != nil {
+		logger.Error("Failed to send transfer notification", "transfer_id", params.TransferID, "event_type", eventType, "error", err)
+	}
+}
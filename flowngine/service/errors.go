@@ -0,0 +1,37 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidParameters is wrapped by errors of requests rejected by their validation
+var ErrInvalidParameters = errors.New("invalid parameters")
+
+// ErrTemporalUnavailable is returned while the Temporal client is still connecting
+var ErrTemporalUnavailable = errors.New("temporal client not available - service is starting up")
+
+// ErrAccountOwnersUnavailable is returned when the owners of the source account of a transfer that may need
+// dual authorization cannot be looked up
+var ErrAccountOwnersUnavailable = errors.New("account owners not available")
+
+// FieldViolation is a validation error of a single request field
+type FieldViolation struct {
+	Field       string // Field name as it appears in the request, e.g. from_account
+	Description string
+}
+
+func (v *FieldViolation) Error() string {
+	return v.Description
+}
+
+// newFieldViolation returns a FieldViolation of field described by a printf-style message
+func newFieldViolation(field, format string, args ...any) error {
+	return &FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)}
+}
+
+// invalidParameters wraps a validation error so that it matches ErrInvalidParameters,
+// keeping the "invalid parameters: ..." message clients already see
+func invalidParameters(err error) error {
+	return fmt.Errorf("%w: %w", ErrInvalidParameters, err)
+}
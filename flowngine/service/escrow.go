@@ -0,0 +1,611 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"flowngine/util/failure"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/workflow"
+)
+
+// EscrowDecisionSignalName is the signal that releases escrowed funds to the payee or refunds them to the payer
+const EscrowDecisionSignalName = "escrow_decision"
+
+// EscrowStateQueryType is the query that reports the state of a running escrow
+const EscrowStateQueryType = "escrow_state"
+
+// escrowQueryTimeout bounds the state query, which blocks while no worker picks up the workflow
+const escrowQueryTimeout = 5 * time.Second
+
+// Deadlines of escrows used when the configuration leaves them out
+const (
+	defaultEscrowDeadline = 7 * 24 * time.Hour
+	maxEscrowDeadline     = 30 * 24 * time.Hour
+)
+
+// escrowWorkflowIDPrefix tells escrow workflows apart from the other workflows of the namespace
+const escrowWorkflowIDPrefix = "escrow_workflow_"
+
+// Decisions of EscrowDecisionSignal
+const (
+	EscrowDecisionRelease = "release"
+	EscrowDecisionRefund  = "refund"
+)
+
+// Statuses of an escrow
+const (
+	EscrowStatusPending  = "PENDING"  // The payer has not been debited yet
+	EscrowStatusHeld     = "HELD"     // Debited from the payer, waiting for a decision or the deadline
+	EscrowStatusReleased = "RELEASED" // Credited to the payee
+	EscrowStatusRefunded = "REFUNDED" // Returned to the payer, by decision, at the deadline or after a failed release
+	EscrowStatusFailed   = "FAILED"   // Nothing was held, or a refund failed and needs manual reconciliation
+)
+
+// ErrEscrowNotFound is returned when no escrow workflow exists for an escrow ID, or when a decision is sent to an
+// escrow that is not held anymore
+var ErrEscrowNotFound = errors.New("escrow not found")
+
+// CreateEscrowParams holds an amount from FromAccount until it is released to ToAccount or refunded. The payer
+// may release it, the payee refund it, and the arbiter, when there is one, do either.
+type CreateEscrowParams struct {
+	FromAccount     string `json:"from_account"`   // Payer
+	ToAccount       string `json:"to_account"`     // Payee
+	Amount          int64  `json:"amount"`         // Minor units of the currency (cents, yen); set either Amount or AmountDecimal
+	AmountDecimal   string `json:"amount_decimal"` // Exact major-unit decimal, e.g. "100.50"
+	Currency        string `json:"currency"`
+	Description     string `json:"description"`
+	RequestID       string `json:"request_id"`
+	Arbiter         string `json:"arbiter"`          // Optional party that may release or refund, e.g. a dispute desk
+	DeadlineSeconds int    `json:"deadline_seconds"` // 0 takes the configured default
+}
+
+type CreateEscrowResults struct {
+	EscrowID   string `json:"escrow_id"`
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+	CreatedAt  string `json:"created_at"`
+	Deadline   string `json:"deadline"`
+}
+
+type DecideEscrowParams struct {
+	EscrowID  string `json:"escrow_id"`
+	Decision  string `json:"decision"`   // release or refund
+	DecidedBy string `json:"decided_by"` // The payer or payee account, or the arbiter
+	Reason    string `json:"reason"`
+}
+
+type DecideEscrowResults struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type GetEscrowParams struct {
+	EscrowID string `json:"escrow_id"`
+}
+
+// EscrowWorkflowParams defines the input parameters for the escrow workflow. Transfer is the payment held; its
+// TransferID is the ID of the escrow.
+type EscrowWorkflowParams struct {
+	Transfer        TransferWorkflowParams `json:"transfer"`
+	Arbiter         string                 `json:"arbiter,omitempty"`
+	DeadlineSeconds int                    `json:"deadline_seconds"`
+}
+
+// EscrowDecisionSignal is the payload of EscrowDecisionSignalName
+type EscrowDecisionSignal struct {
+	Decision  string `json:"decision"`
+	DecidedBy string `json:"decided_by"`
+	Reason    string `json:"reason"`
+}
+
+// EscrowState is the result of EscrowStateQueryType and of the escrow workflow
+type EscrowState struct {
+	EscrowID     string     `json:"escrow_id"`
+	Status       string     `json:"status"`
+	FromAccount  string     `json:"from_account"`
+	ToAccount    string     `json:"to_account"`
+	Amount       string     `json:"amount"` // Exact major-unit decimal
+	Currency     string     `json:"currency"`
+	Arbiter      string     `json:"arbiter,omitempty"`
+	Deadline     time.Time  `json:"deadline"`
+	DecidedBy    string     `json:"decided_by,omitempty"` // Unset when refunded at the deadline
+	Reason       string     `json:"reason,omitempty"`
+	ErrorMessage string     `json:"error_message,omitempty"`
+	HeldAt       *time.Time `json:"held_at,omitempty"`
+	SettledAt    *time.Time `json:"settled_at,omitempty"` // Released, refunded or failed
+}
+
+type GetEscrowResults struct {
+	EscrowState
+	WorkflowID string `json:"workflow_id"`
+}
+
+// escrowWorkflowID is the ID of the workflow of an escrow
+func escrowWorkflowID(escrowID string) string {
+	return escrowWorkflowIDPrefix + escrowID
+}
+
+// CreateEscrow starts the workflow of an escrow, which debits the payer and holds the amount until a decision or
+// its deadline. The escrow ID is derived from the request ID like a transaction ID, so a retried request finds the
+// escrow it created.
+func (svc *Service) CreateEscrow(ctx context.Context, params *CreateEscrowParams) (*CreateEscrowResults, error) {
+	const op = "service.Service.CreateEscrow"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Creating escrow")
+
+	transfer, deadline, err := svc.validateCreateEscrowParams(params)
+	if err != nil {
+		err = invalidParameters(err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	amount, err := resolveTransferAmount(transfer)
+	if err != nil {
+		err = invalidParameters(err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	if svc.temporalClient == nil {
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn("CreateEscrow request received but Temporal client not ready")
+
+		return nil, err
+	}
+
+	// Escrows are released without an approval step, so amounts that would need one are not escrowed
+	approvers, err := svc.jointAccountApprovers(ctx, params.FromAccount, amount.MinorUnits)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+	if len(approvers) > 0 || requiresApproval(svc.config.TransferApproval, amount.MinorUnits) {
+		err := invalidParameters(newFieldViolation("amount", "amounts that need approval cannot be escrowed"))
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	escrowID := transferTransactionID(params.RequestID)
+	workflowID := escrowWorkflowID(escrowID)
+
+	workflowParams := EscrowWorkflowParams{
+		Transfer:        svc.newTransferWorkflowParams(transfer, escrowID, amount, nil),
+		Arbiter:         params.Arbiter,
+		DeadlineSeconds: int(deadline / time.Second),
+	}
+
+	// The workflow outlives the deadline by the time a release or refund may take
+	workflowOptions := client.StartWorkflowOptions{
+		ID:                       workflowID,
+		TaskQueue:                svc.transferTaskQueue(workflowID, ""),
+		WorkflowIDReusePolicy:    enums.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE,
+		WorkflowIDConflictPolicy: enums.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING, // A retried request returns the running one
+		WorkflowExecutionTimeout: deadline + time.Minute*10,
+		TypedSearchAttributes:    transferSearchAttributes(workflowParams.Transfer),
+		Memo:                     transferMemo(workflowParams.Transfer),
+		StartDelay:               svc.simulatedStartDelay(params.FromAccount),
+	}
+
+	if err := svc.simulateWorkflowStartFailure(ctx, params.FromAccount); err != nil {
+		err = fmt.Errorf("failed to start workflow: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	workflowRun, err := svc.temporalClient.ExecuteWorkflow(ctx, workflowOptions, escrowWorkflow, workflowParams)
+	if err != nil {
+		var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+		if errors.As(err, &alreadyStarted) {
+			err = fmt.Errorf("%w: escrow %s was already settled", ErrTransferAlreadyExists, escrowID)
+		} else {
+			err = fmt.Errorf("failed to start workflow: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	createdAt := time.Now()
+	results := &CreateEscrowResults{
+		EscrowID:   escrowID,
+		WorkflowID: workflowID,
+		RunID:      workflowRun.GetRunID(),
+		CreatedAt:  createdAt.Format(time.RFC3339),
+		Deadline:   createdAt.Add(deadline).Format(time.RFC3339),
+	}
+
+	logger.WithFields(logrus.Fields{
+		"escrow_id":   escrowID,
+		"workflow_id": workflowID,
+		"run_id":      results.RunID,
+	}).Info("Escrow started")
+
+	return results, nil
+}
+
+// DecideEscrow signals a release or refund decision to a held escrow. Whether the sender may take the decision is
+// checked by the workflow, which ignores decisions it does not accept.
+func (svc *Service) DecideEscrow(ctx context.Context, params *DecideEscrowParams) (*DecideEscrowResults, error) {
+	const op = "service.Service.DecideEscrow"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Deciding escrow")
+
+	if err := validateDecideEscrowParams(params); err != nil {
+		err = invalidParameters(err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	if svc.temporalClient == nil {
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn("DecideEscrow request received but Temporal client not ready")
+
+		return nil, err
+	}
+
+	workflowID := escrowWorkflowID(params.EscrowID)
+
+	err := svc.simulateSignalFailure(ctx)
+	if err == nil {
+		err = svc.temporalClient.SignalWorkflow(ctx, workflowID, "", EscrowDecisionSignalName, EscrowDecisionSignal{
+			Decision:  params.Decision,
+			DecidedBy: params.DecidedBy,
+			Reason:    params.Reason,
+		})
+	}
+	if errors.Is(err, failure.ErrDropped) {
+		// The caller is told the decision was sent, so the escrow is refunded at its deadline
+		logger.WithField("workflow_id", workflowID).Warn("Escrow decision signal dropped by failure simulation")
+	} else if err != nil {
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			err = fmt.Errorf("%w: %s is not held", ErrEscrowNotFound, params.EscrowID)
+		} else {
+			err = fmt.Errorf("failed to signal workflow: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &DecideEscrowResults{
+		Success: true,
+		Message: fmt.Sprintf("Escrow %s %s requested by %s", params.EscrowID, params.Decision, params.DecidedBy),
+	}
+
+	logger.WithField("workflow_id", workflowID).Info("Escrow decision signalled")
+
+	return results, nil
+}
+
+// GetEscrow reports the state of an escrow
+func (svc *Service) GetEscrow(ctx context.Context, params *GetEscrowParams) (*GetEscrowResults, error) {
+	const op = "service.Service.GetEscrow"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Getting escrow")
+
+	if params.EscrowID == "" {
+		err := invalidParameters(newFieldViolation("escrow_id", "escrow_id is required"))
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	if svc.temporalClient == nil {
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn("GetEscrow request received but Temporal client not ready")
+
+		return nil, err
+	}
+
+	workflowID := escrowWorkflowID(params.EscrowID)
+
+	description, err := svc.temporalClient.DescribeWorkflowExecution(ctx, workflowID, "")
+	if err != nil {
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			err = fmt.Errorf("%w: %s", ErrEscrowNotFound, params.EscrowID)
+		} else {
+			err = fmt.Errorf("failed to describe workflow: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &GetEscrowResults{WorkflowID: workflowID}
+
+	// A settled escrow returns its state as the workflow result; any other escrow is queried for it
+	if description.GetWorkflowExecutionInfo().GetStatus() == enums.WORKFLOW_EXECUTION_STATUS_COMPLETED {
+		err = svc.temporalClient.GetWorkflow(ctx, workflowID, "").Get(ctx, &results.EscrowState)
+	} else {
+		err = svc.queryEscrowState(ctx, workflowID, &results.EscrowState)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to get escrow state: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("status", results.Status).Info("Escrow retrieved")
+
+	return results, nil
+}
+
+// queryEscrowState queries the state of a running escrow from its workflow
+func (svc *Service) queryEscrowState(ctx context.Context, workflowID string, state *EscrowState) error {
+	queryCtx, cancel := context.WithTimeout(ctx, escrowQueryTimeout)
+	defer cancel()
+
+	value, err := svc.temporalClient.QueryWorkflow(queryCtx, workflowID, "", EscrowStateQueryType)
+	if err != nil {
+		return fmt.Errorf("failed to query escrow: %w", err)
+	}
+
+	return value.Get(state)
+}
+
+// validateCreateEscrowParams validates an escrow and returns the payment it holds and its deadline
+func (svc *Service) validateCreateEscrowParams(params *CreateEscrowParams) (*ExecuteTransferParams, time.Duration, error) {
+	transfer := &ExecuteTransferParams{
+		FromAccount:   params.FromAccount,
+		ToAccount:     params.ToAccount,
+		Amount:        params.Amount,
+		AmountDecimal: params.AmountDecimal,
+		Currency:      params.Currency,
+		Description:   params.Description,
+		RequestID:     params.RequestID,
+	}
+
+	// Escrows are paid to an account; aliases are resolved by the transfer saga only
+	if params.ToAccount == "" {
+		return nil, 0, newFieldViolation("to_account", "to_account is required")
+	}
+
+	if err := validateExecuteTransferParams(transfer); err != nil {
+		return nil, 0, err
+	}
+
+	if params.Arbiter != "" && (params.Arbiter == params.FromAccount || params.Arbiter == params.ToAccount) {
+		return nil, 0, newFieldViolation("arbiter", "arbiter must be neither the payer nor the payee")
+	}
+
+	deadline := escrowDeadline(svc.config.Escrow.DefaultDeadlineSeconds, defaultEscrowDeadline)
+	maxDeadline := escrowDeadline(svc.config.Escrow.MaxDeadlineSeconds, maxEscrowDeadline)
+	switch {
+	case params.DeadlineSeconds < 0 || time.Duration(params.DeadlineSeconds)*time.Second > maxDeadline:
+		return nil, 0, newFieldViolation("deadline_seconds", "deadline_seconds must be between 1 and %d", int(maxDeadline.Seconds()))
+	case params.DeadlineSeconds > 0:
+		deadline = time.Duration(params.DeadlineSeconds) * time.Second
+	}
+
+	return transfer, min(deadline, maxDeadline), nil
+}
+
+// escrowDeadline returns the configured deadline, or fallback when none is configured
+func escrowDeadline(seconds int, fallback time.Duration) time.Duration {
+	if seconds <= 0 {
+		return fallback
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// validateDecideEscrowParams validates the input parameters for an escrow decision
+func validateDecideEscrowParams(params *DecideEscrowParams) error {
+	if params.EscrowID == "" {
+		return newFieldViolation("escrow_id", "escrow_id is required")
+	}
+
+	if params.Decision != EscrowDecisionRelease && params.Decision != EscrowDecisionRefund {
+		return newFieldViolation("decision", "decision must be %s or %s", EscrowDecisionRelease, EscrowDecisionRefund)
+	}
+
+	if params.DecidedBy == "" {
+		return newFieldViolation("decided_by", "decided_by is required")
+	}
+
+	return nil
+}
+
+// acceptEscrowDecision decides whether the sender of a decision may take it: the payer may release, the payee
+// refund, and the arbiter do either
+func acceptEscrowDecision(params EscrowWorkflowParams, decision EscrowDecisionSignal) (bool, string) {
+	switch {
+	case decision.DecidedBy == "":
+		return false, "decision has no sender"
+	case params.Arbiter != "" && decision.DecidedBy == params.Arbiter:
+		return decision.Decision == EscrowDecisionRelease || decision.Decision == EscrowDecisionRefund, "unknown decision"
+	case decision.Decision == EscrowDecisionRelease:
+		return decision.DecidedBy == params.Transfer.FromAccount, "only the payer or the arbiter can release"
+	case decision.Decision == EscrowDecisionRefund:
+		return decision.DecidedBy == params.Transfer.ToAccount, "only the payee or the arbiter can refund"
+	default:
+		return false, "unknown decision"
+	}
+}
+
+// escrowWorkflow debits the payer of an escrow and holds the amount until an accepted EscrowDecisionSignalName
+// releases it to the payee or refunds it, whichever comes first. An escrow still held at its deadline is refunded.
+// Refunds and failed releases reverse the debit through CompensateDebit.
+func escrowWorkflow(ctx workflow.Context, params EscrowWorkflowParams) (*EscrowState, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting escrowWorkflow", "escrow_id", params.Transfer.TransferID, "from_account", params.Transfer.FromAccount, "to_account", params.Transfer.ToAccount, "amount", params.Transfer.Amount)
+
+	workflowInfo := workflow.GetInfo(ctx)
+	transfer := params.Transfer
+
+	state := &EscrowState{
+		EscrowID:    transfer.TransferID,
+		Status:      EscrowStatusPending,
+		FromAccount: transfer.FromAccount,
+		ToAccount:   transfer.ToAccount,
+		Amount:      transfer.Amount.String(),
+		Currency:    transfer.Currency,
+		Arbiter:     params.Arbiter,
+		Deadline:    workflow.Now(ctx).Add(time.Duration(params.DeadlineSeconds) * time.Second),
+	}
+
+	settle := func(status, errorMessage string) (*EscrowState, error) {
+		settledAt := workflow.Now(ctx)
+		state.Status = status
+		state.ErrorMessage = errorMessage
+		state.SettledAt = &settledAt
+
+		logger.Info("Escrow settled", "escrow_id", state.EscrowID, "status", status, "decided_by", state.DecidedBy, "error_message", errorMessage)
+
+		return state, nil
+	}
+
+	if err := workflow.SetQueryHandler(ctx, EscrowStateQueryType, func() (*EscrowState, error) {
+		return state, nil
+	}); err != nil {
+		logger.Error("Failed to register escrow state query", "error", err)
+		return nil, err
+	}
+
+	if err := validateTransferWorkflowParams(transfer); err != nil {
+		logger.Error("Invalid workflow parameters", "error", err)
+		return settle(EscrowStatusFailed, fmt.Sprintf("validation failed: %v", err))
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, transferActivityOptions())
+
+	// Step 1: Hold the amount by debiting the payer
+	debitParams := map[string]interface{}{
+		"account_id":      transfer.FromAccount,
+		"amount":          transfer.Amount,
+		"currency":        transfer.Currency,
+		"description":     fmt.Sprintf("Escrow for %s: %s", transfer.ToAccount, transfer.Description),
+		"reference_id":    transfer.TransferID,
+		"idempotency_key": fmt.Sprintf("%s-debit", transfer.IdempotencyKey),
+		"transfer_id":     transfer.TransferID,
+		"workflow_id":     workflowInfo.WorkflowExecution.ID,
+		"run_id":          workflowInfo.WorkflowExecution.RunID,
+	}
+
+	var debitResult map[string]interface{}
+	if err := workflow.ExecuteActivity(ctx, "DebitAccount", debitParams).Get(ctx, &debitResult); err != nil {
+		logger.Error("Escrow debit failed", "error", err)
+		return settle(EscrowStatusFailed, fmt.Sprintf("debit account failed: %v", err))
+	}
+
+	heldAt := workflow.Now(ctx)
+	state.Status = EscrowStatusHeld
+	state.HeldAt = &heldAt
+
+	logger.Info("Escrow held", "escrow_id", state.EscrowID, "deadline", state.Deadline)
+
+	// Step 2: Wait for an accepted decision or the deadline
+	timerCtx, stopTimer := workflow.WithCancel(ctx)
+	defer stopTimer()
+
+	expired := false
+	var decision EscrowDecisionSignal
+	selector := workflow.NewSelector(ctx)
+	selector.AddReceive(workflow.GetSignalChannel(ctx, EscrowDecisionSignalName), func(channel workflow.ReceiveChannel, more bool) {
+		channel.Receive(ctx, &decision)
+	})
+	selector.AddFuture(workflow.NewTimer(timerCtx, max(state.Deadline.Sub(workflow.Now(ctx)), 0)), func(workflow.Future) { expired = true })
+
+	for {
+		decision = EscrowDecisionSignal{}
+		selector.Select(ctx)
+		if expired {
+			decision = EscrowDecisionSignal{Decision: EscrowDecisionRefund, Reason: "escrow deadline passed"}
+			break
+		}
+
+		if accepted, reason := acceptEscrowDecision(params, decision); !accepted {
+			logger.Warn("Escrow decision ignored", "escrow_id", state.EscrowID, "decision", decision.Decision, "decided_by", decision.DecidedBy, "reason", reason)
+			continue
+		}
+
+		state.DecidedBy = decision.DecidedBy
+		break
+	}
+	state.Reason = decision.Reason
+
+	// Step 3: Release to the payee, refunding the payer when the credit fails
+	if decision.Decision == EscrowDecisionRelease {
+		creditParams := map[string]interface{}{
+			"account_id":      transfer.ToAccount,
+			"amount":          transfer.Amount,
+			"currency":        transfer.Currency,
+			"description":     fmt.Sprintf("Escrow from %s: %s", transfer.FromAccount, transfer.Description),
+			"reference_id":    transfer.TransferID,
+			"idempotency_key": fmt.Sprintf("%s-credit", transfer.IdempotencyKey),
+			"transfer_id":     transfer.TransferID,
+			"workflow_id":     workflowInfo.WorkflowExecution.ID,
+			"run_id":          workflowInfo.WorkflowExecution.RunID,
+		}
+
+		var creditResult map[string]interface{}
+		err := workflow.ExecuteActivity(ctx, "CreditAccount", creditParams).Get(ctx, &creditResult)
+		if err == nil {
+			return settle(EscrowStatusReleased, "")
+		}
+
+		logger.Error("Escrow release failed, refunding", "error", err)
+
+		if compensationErr := compensateDebit(ctx, transfer, debitResult, fmt.Sprintf("Escrow release to %s failed: %v", transfer.ToAccount, err)); compensationErr != nil {
+			return settle(EscrowStatusFailed, fmt.Sprintf("release failed and refund failed, manual reconciliation required: credit_error=%v, compensation_error=%v", err, compensationErr))
+		}
+
+		return settle(EscrowStatusRefunded, fmt.Sprintf("release failed: %v", err))
+	}
+
+	// Step 3: Refund the payer
+	reason := fmt.Sprintf("Escrow refunded by %s", state.DecidedBy)
+	if expired {
+		reason = "Escrow refunded at its deadline"
+	}
+	if err := compensateDebit(ctx, transfer, debitResult, reason); err != nil {
+		return settle(EscrowStatusFailed, fmt.Sprintf("refund failed, manual reconciliation required: %v", err))
+	}
+
+	return settle(EscrowStatusRefunded, "")
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"flowngine/util/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func validEscrowWorkflowParams() EscrowWorkflowParams {
+	return EscrowWorkflowParams{Transfer: validTransferWorkflowParams(), Arbiter: "account-arbiter", DeadlineSeconds: 3600}
+}
+
+func TestEscrowWorkflow_ReleasedByPayer(t *testing.T) {
+	t.Parallel()
+
+	env := newTransferWorkflowTestEnv(t)
+	env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(map[string]interface{}{"transaction_id": "debit-transaction-123"}, nil).Once()
+	env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(map[string]interface{}{"transaction_id": "credit-transaction-123"}, nil).Once()
+
+	params := validEscrowWorkflowParams()
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(EscrowDecisionSignalName, EscrowDecisionSignal{Decision: EscrowDecisionRelease, DecidedBy: params.Transfer.FromAccount, Reason: "goods received"})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(escrowWorkflow, params)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var state EscrowState
+	require.NoError(t, env.GetWorkflowResult(&state))
+	assert.Equal(t, EscrowStatusReleased, state.Status)
+	assert.Equal(t, params.Transfer.FromAccount, state.DecidedBy)
+	assert.Equal(t, "goods received", state.Reason)
+	assert.NotNil(t, state.HeldAt)
+	assert.NotNil(t, state.SettledAt)
+}
+
+func TestEscrowWorkflow_RefundedByArbiter(t *testing.T) {
+	t.Parallel()
+
+	env := newTransferWorkflowTestEnv(t)
+	env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(map[string]interface{}{"transaction_id": "debit-transaction-123"}, nil).Once()
+	env.OnActivity("CompensateDebit", mock.Anything, mock.Anything).Return(map[string]interface{}{"transaction_id": "compensation-123"}, nil).Once()
+
+	params := validEscrowWorkflowParams()
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(EscrowDecisionSignalName, EscrowDecisionSignal{Decision: EscrowDecisionRefund, DecidedBy: params.Arbiter})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(escrowWorkflow, params)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var state EscrowState
+	require.NoError(t, env.GetWorkflowResult(&state))
+	assert.Equal(t, EscrowStatusRefunded, state.Status)
+	assert.Equal(t, params.Arbiter, state.DecidedBy)
+}
+
+func TestEscrowWorkflow_RefundedAtDeadline(t *testing.T) {
+	t.Parallel()
+
+	env := newTransferWorkflowTestEnv(t)
+	env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(map[string]interface{}{"transaction_id": "debit-transaction-123"}, nil).Once()
+	env.OnActivity("CompensateDebit", mock.Anything, mock.Anything).Return(map[string]interface{}{"transaction_id": "compensation-123"}, nil).Once()
+
+	// Neither party may decide what the other is owed, so both decisions are ignored
+	params := validEscrowWorkflowParams()
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(EscrowDecisionSignalName, EscrowDecisionSignal{Decision: EscrowDecisionRelease, DecidedBy: params.Transfer.ToAccount})
+		env.SignalWorkflow(EscrowDecisionSignalName, EscrowDecisionSignal{Decision: EscrowDecisionRefund, DecidedBy: params.Transfer.FromAccount})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(escrowWorkflow, params)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var state EscrowState
+	require.NoError(t, env.GetWorkflowResult(&state))
+	assert.Equal(t, EscrowStatusRefunded, state.Status)
+	assert.Empty(t, state.DecidedBy)
+	assert.Equal(t, "escrow deadline passed", state.Reason)
+}
+
+func TestEscrowWorkflow_ReleaseFailureRefunds(t *testing.T) {
+	t.Parallel()
+
+	env := newTransferWorkflowTestEnv(t)
+	env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(map[string]interface{}{"transaction_id": "debit-transaction-123"}, nil).Once()
+	env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(nil, errors.New("account closed"))
+	env.OnActivity("CompensateDebit", mock.Anything, mock.Anything).Return(map[string]interface{}{"transaction_id": "compensation-123"}, nil).Once()
+
+	params := validEscrowWorkflowParams()
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(EscrowDecisionSignalName, EscrowDecisionSignal{Decision: EscrowDecisionRelease, DecidedBy: params.Arbiter})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(escrowWorkflow, params)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var state EscrowState
+	require.NoError(t, env.GetWorkflowResult(&state))
+	assert.Equal(t, EscrowStatusRefunded, state.Status)
+	assert.Contains(t, state.ErrorMessage, "release failed")
+}
+
+func TestEscrowWorkflow_DebitFailure(t *testing.T) {
+	t.Parallel()
+
+	env := newTransferWorkflowTestEnv(t)
+	env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(nil, errors.New("insufficient funds"))
+
+	env.ExecuteWorkflow(escrowWorkflow, validEscrowWorkflowParams())
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var state EscrowState
+	require.NoError(t, env.GetWorkflowResult(&state))
+	assert.Equal(t, EscrowStatusFailed, state.Status)
+	assert.Nil(t, state.HeldAt)
+}
+
+func TestValidateCreateEscrowParams(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{config: config.Config{Escrow: config.Escrow{MaxDeadlineSeconds: 86400}}}
+
+	valid := func() CreateEscrowParams {
+		return CreateEscrowParams{FromAccount: "acc-payer", ToAccount: "acc-payee", AmountDecimal: "25.00", Currency: "USD", RequestID: "request-123"}
+	}
+
+	params := valid()
+	_, deadline, err := svc.validateCreateEscrowParams(&params)
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, deadline, "the default deadline is capped by the maximum")
+
+	params.DeadlineSeconds = 600
+	_, deadline, err = svc.validateCreateEscrowParams(&params)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, deadline)
+
+	for name, tt := range map[string]struct {
+		modify    func(*CreateEscrowParams)
+		wantField string
+	}{
+		"missing_payee":     {modify: func(p *CreateEscrowParams) { p.ToAccount = "" }, wantField: "to_account"},
+		"arbiter_is_payer":  {modify: func(p *CreateEscrowParams) { p.Arbiter = p.FromAccount }, wantField: "arbiter"},
+		"arbiter_is_payee":  {modify: func(p *CreateEscrowParams) { p.Arbiter = p.ToAccount }, wantField: "arbiter"},
+		"deadline_too_far":  {modify: func(p *CreateEscrowParams) { p.DeadlineSeconds = 86401 }, wantField: "deadline_seconds"},
+		"negative_deadline": {modify: func(p *CreateEscrowParams) { p.DeadlineSeconds = -1 }, wantField: "deadline_seconds"},
+		"request_id":        {modify: func(p *CreateEscrowParams) { p.RequestID = "" }, wantField: "request_id"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			params := valid()
+			tt.modify(&params)
+
+			_, _, err := svc.validateCreateEscrowParams(&params)
+
+			var violation *FieldViolation
+			require.ErrorAs(t, err, &violation)
+			assert.Equal(t, tt.wantField, violation.Field)
+		})
+	}
+}
@@ -3,35 +3,52 @@ package service
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
-	"github.com/google/uuid"
+	"flowngine/util/taskqueue"
+
 	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/enums/v1"
 	"go.temporal.io/sdk/client"
 )
 
 type ExecuteTransferParams struct {
-	FromAccount       string `json:"from_account"`
-	ToAccount         string `json:"to_account"`
-	Amount            int64  `json:"amount"`
-	Currency          string `json:"currency"`
-	Description       string `json:"description"`
-	ReferenceID       string `json:"reference_id"`
-	RequestID         string `json:"request_id"`
-	WaitForCompletion bool   `json:"wait_for_completion"`
+	FromAccount       string           `json:"from_account"`
+	ToAccount         string           `json:"to_account"`
+	ToAlias           string           `json:"to_alias"`       // Phone number or email address of the destination; set either ToAccount or ToAlias
+	Amount            int64            `json:"amount"`         // Minor units of the currency (cents, yen); set either Amount or AmountDecimal
+	AmountDecimal     string           `json:"amount_decimal"` // Exact major-unit decimal, e.g. "100.50"
+	Currency          string           `json:"currency"`
+	Description       string           `json:"description"`
+	ReferenceID       string           `json:"reference_id"`
+	RequestID         string           `json:"request_id"`
+	WaitForCompletion bool             `json:"wait_for_completion"` // Set by EXECUTION_MODE_SYNC
+	CallbackURL       string           `json:"callback_url"`        // Notified when the transfer expires awaiting approval, funds or its trigger
+	WaitForFunds      bool             `json:"wait_for_funds"`      // Wait for incoming credits instead of failing on insufficient funds
+	Trigger           *TransferTrigger `json:"trigger,omitempty"`   // Execute the transfer only once the trigger account has been credited
+	TaskQueueTrack    string           `json:"task_queue_track"`    // current or next pins the workflow to a fleet during a task queue cutover; empty follows the cutover percent
+}
+
+// TransferTrigger is the condition of a conditional transfer
+type TransferTrigger struct {
+	Account       string `json:"account"`        // Account number or ID of the watched account
+	AmountDecimal string `json:"amount_decimal"` // Major units in the transfer currency, summed over the credits received
 }
 
 type ExecuteTransferResults struct {
-	TransactionID       string           `json:"transaction_id"`
-	Status              string           `json:"status"`
-	WorkflowID          string           `json:"workflow_id"`
-	RunID               string           `json:"run_id"`
-	CreatedAt           string           `json:"created_at"`
-	CompletedAt         *string          `json:"completed_at,omitempty"`
-	FinalAmount         *decimal.Decimal `json:"final_amount,omitempty"`
-	ErrorMessage        string           `json:"error_message,omitempty"`
-	CompensationApplied *bool            `json:"compensation_applied,omitempty"`
+	TransactionID       string             `json:"transaction_id"`
+	Status              string             `json:"status"`
+	WorkflowID          string             `json:"workflow_id"`
+	RunID               string             `json:"run_id"`
+	CreatedAt           string             `json:"created_at"`
+	CompletedAt         *string            `json:"completed_at,omitempty"`
+	FinalAmount         *decimal.Decimal   `json:"final_amount,omitempty"`
+	ErrorMessage        string             `json:"error_message,omitempty"`
+	CompensationApplied *bool              `json:"compensation_applied,omitempty"`
+	TransferReference   string             `json:"transfer_reference,omitempty"`
+	FX                  *TransferFXResults `json:"fx,omitempty"` // Set in SYNC mode when the credit was converted
 }
 
 func (svc *Service) ExecuteTransfer(ctx context.Context, params *ExecuteTransferParams) (*ExecuteTransferResults, error) {
@@ -44,54 +61,110 @@ func (svc *Service) ExecuteTransfer(ctx context.Context, params *ExecuteTransfer
 
 	logger.Info("Starting transfer execution", "sync_mode", params.WaitForCompletion)
 
-	// Check if Temporal client is available
-	if svc.temporalClient == nil {
-		err := fmt.Errorf("temporal client not available - service is starting up")
+	// Validate input parameters
+	if err := validateExecuteTransferParams(params); err != nil {
+		err = invalidParameters(err)
 
-		logger.WithError(err).Warn("Transfer request received but Temporal client not ready")
+		logger.WithError(err).Error()
 
 		return nil, err
 	}
 
-	// Validate input parameters
-	if err := validateExecuteTransferParams(params); err != nil {
-		err = fmt.Errorf("invalid parameters: %w", err)
+	amount, err := resolveTransferAmount(params)
+	if err != nil {
+		err = invalidParameters(err)
 
 		logger.WithError(err).Error()
 
 		return nil, err
 	}
 
-	// Generate transaction and workflow IDs
-	transactionID := uuid.New().String()
-	workflowID := fmt.Sprintf("transfer_workflow_%s", transactionID)
-	idempotencyKey := fmt.Sprintf("%s_%s", params.RequestID, transactionID)
+	// Derive transaction and workflow IDs from the request, so a retried request finds the transfer it started
+	transactionID := transferTransactionID(params.RequestID)
+	workflowID := transferWorkflowID(transactionID)
+
+	// Issue the human-friendly reference printed on receipts
+	transferReference := svc.assignTransferReference(ctx, transactionID)
 
-	// Convert amount to decimal (from minor units to major units for internal processing)
-	amountDecimal := decimal.NewFromInt(params.Amount).Div(decimal.NewFromInt(100))
+	// Large debits from joint accounts need two of the owners to approve
+	approvers, err := svc.jointAccountApprovers(ctx, params.FromAccount, amount.MinorUnits)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Transfers held for approval need the workflow to wait for the approval signal
+	holdForApproval := requiresApproval(svc.config.TransferApproval, amount.MinorUnits) || len(approvers) > 0
+
+	// Waiting for funds needs the workflow to re-check the balance, the fast path fails at once
+	waitForFunds := fundsWaitEnabled(svc.config.FundsWait, params.WaitForFunds)
+
+	// Small transfers skip the saga and run as a single DB transaction in svc-transaction, unless the saga has to
+	// settle them with the partner bank or resolve the alias they are addressed to
+	if !holdForApproval && !waitForFunds && params.Trigger == nil && params.ToAlias == "" && !svc.config.ExternalSettlement.Enabled &&
+		shouldUseFastPath(svc.config.FastPath, params.Currency, amount.MinorUnits) {
+		results, err := svc.executeFastPathTransfer(ctx, params, amount.Decimal, transactionID)
+		if err != nil {
+			return nil, err
+		}
+		results.TransferReference = transferReference
+
+		return results, nil
+	}
+
+	// Check if Temporal client is available
+	if svc.temporalClient == nil {
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn("Transfer request received but Temporal client not ready")
+
+		return nil, err
+	}
 
 	// Prepare workflow parameters
-	workflowParams := TransferWorkflowParams{
-		TransferID:     transactionID,
-		FromAccount:    params.FromAccount,
-		ToAccount:      params.ToAccount,
-		Amount:         amountDecimal,
-		Currency:       params.Currency,
-		Description:    params.Description,
-		IdempotencyKey: idempotencyKey,
-		RequestedBy:    params.RequestID,
+	workflowParams := svc.newTransferWorkflowParams(params, transactionID, amount, approvers)
+	workflowTimeout := transferWorkflowTimeout(workflowParams)
+
+	// Conditional transfers run the same saga once their trigger account has received the trigger amount
+	var workflowFunc, workflowArgs any = transferWorkflow, workflowParams
+	searchAttributes := transferSearchAttributes(workflowParams)
+	if params.Trigger != nil {
+		conditionalParams := ConditionalTransferParams{
+			Transfer:             workflowParams,
+			TriggerAccount:       params.Trigger.Account,
+			TriggerAmount:        decimal.RequireFromString(params.Trigger.AmountDecimal), // Checked by validateExecuteTransferParams
+			TriggerExpirySeconds: svc.config.ConditionalTransfer.ExpirySeconds,
+		}
+
+		workflowFunc, workflowArgs = conditionalTransferWorkflow, conditionalParams
+		searchAttributes = conditionalTransferSearchAttributes(conditionalParams)
+		workflowTimeout += time.Duration(conditionalParams.TriggerExpirySeconds) * time.Second
 	}
 
 	// Configure workflow options
 	workflowOptions := client.StartWorkflowOptions{
 		ID:                       workflowID,
-		TaskQueue:                "transfer-task-queue",
-		WorkflowExecutionTimeout: time.Minute * 10,
-		WorkflowRunTimeout:       time.Minute * 5,
+		TaskQueue:                svc.transferTaskQueue(workflowID, params.TaskQueueTrack),
+		WorkflowIDReusePolicy:    enums.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE, // A finished transfer is never executed again
+		WorkflowExecutionTimeout: workflowTimeout,
+		WorkflowRunTimeout:       workflowTimeout, // Beyond the transfer SLA, so a breach is tracked rather than cut short
+		// Lets ListAccountWorkflows find the transfer by either account
+		TypedSearchAttributes: searchAttributes,
+		Memo:                  transferMemo(workflowParams),
+		StartDelay:            svc.simulatedStartDelay(params.FromAccount),
 	}
 
 	logger.Info("Starting Temporal workflow", "workflow_id", workflowID, "transaction_id", transactionID)
 
+	if err := svc.simulateWorkflowStartFailure(ctx, params.FromAccount); err != nil {
+		err = fmt.Errorf("failed to start workflow: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
 	// 🎯 THIS IS THE TEMPORAL MAGIC!
 	// Just start the workflow - Temporal handles ALL the complexity:
 	// - State persistence
@@ -99,7 +172,8 @@ func (svc *Service) ExecuteTransfer(ctx context.Context, params *ExecuteTransfer
 	// - Error handling
 	// - Retries and timeouts
 	// - Compensation logic
-	workflowRun, err := svc.temporalClient.ExecuteWorkflow(ctx, workflowOptions, transferWorkflow, workflowParams)
+	// A retry of a request that already started its workflow attaches to it instead of failing
+	workflowRun, attached, err := svc.startTransferWorkflow(ctx, workflowOptions, params.RequestID, workflowFunc, workflowArgs)
 	if err != nil {
 		err = fmt.Errorf("failed to start workflow: %w", err)
 
@@ -110,14 +184,19 @@ func (svc *Service) ExecuteTransfer(ctx context.Context, params *ExecuteTransfer
 
 	runID := workflowRun.GetRunID()
 
-	logger.Info("🎉 Temporal workflow started!", "workflow_id", workflowID, "run_id", runID, "transaction_id", transactionID)
+	if attached {
+		logger.Info("🔗 Attached to the transfer workflow an earlier attempt started", "workflow_id", workflowID, "run_id", runID, "transaction_id", transactionID)
+	} else {
+		logger.Info("🎉 Temporal workflow started!", "workflow_id", workflowID, "run_id", runID, "transaction_id", transactionID)
+	}
 
 	// Initialize base results
 	results := &ExecuteTransferResults{
-		TransactionID: transactionID,
-		WorkflowID:    workflowID,
-		RunID:         runID,
-		CreatedAt:     time.Now().Format(time.RFC3339),
+		TransactionID:     transactionID,
+		WorkflowID:        workflowID,
+		RunID:             runID,
+		CreatedAt:         time.Now().Format(time.RFC3339),
+		TransferReference: transferReference,
 	}
 
 	if params.WaitForCompletion {
@@ -141,7 +220,7 @@ func (svc *Service) ExecuteTransfer(ctx context.Context, params *ExecuteTransfer
 		// 🎉 Workflow completed successfully!
 		logger.Info("✅ Workflow completed successfully (SYNC mode)", "status", workflowResult.Status)
 
-		results.Status = workflowResult.Status
+		results.Status = mapWorkflowStatus(workflowResult)
 		if workflowResult.CompletedAt != nil {
 			completedAt := workflowResult.CompletedAt.Format(time.RFC3339)
 			results.CompletedAt = &completedAt
@@ -149,9 +228,20 @@ func (svc *Service) ExecuteTransfer(ctx context.Context, params *ExecuteTransfer
 		results.FinalAmount = &workflowResult.Amount
 		results.ErrorMessage = workflowResult.ErrorMessage
 		results.CompensationApplied = &workflowResult.CompensationApplied
+		results.FX = newTransferFXResults(workflowResult.FX)
 
 		logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Transfer execution completed synchronously")
 
+	} else if attached {
+		// 🔗 ASYNC MODE, attached: Report where the transfer the first attempt started has got to
+		if err := svc.attachedTransferStatus(ctx, results); err != nil {
+			logger.WithError(err).Warn("Failed to read the state of the attached transfer, reporting it as processing")
+
+			results.Status = "TRANSFER_STATUS_PROCESSING"
+		}
+
+		logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Transfer execution attached - client should poll GetTransferStatus")
+
 	} else {
 		// 🚀 ASYNC MODE: Return immediately (Current Implementation)
 		logger.Info("🚀 Returning immediately (ASYNC mode) - client must poll for status")
@@ -163,46 +253,142 @@ func (svc *Service) ExecuteTransfer(ctx context.Context, params *ExecuteTransfer
 	return results, nil
 }
 
+// newTransferWorkflowParams prepares the saga of a transfer, applying the approval, funds wait, SLA, customer
+// email, external settlement and transfer event settings. Approvers are the owners of a joint source account
+// whose approval the transfer needs, see jointAccountApprovers.
+func (svc *Service) newTransferWorkflowParams(params *ExecuteTransferParams, transactionID string, amount transferAmount, approvers []string) TransferWorkflowParams {
+	workflowParams := TransferWorkflowParams{
+		TransferID:     transactionID,
+		FromAccount:    params.FromAccount,
+		ToAccount:      params.ToAccount,
+		ToAlias:        params.ToAlias,
+		Amount:         amount.Decimal,
+		Currency:       params.Currency,
+		Description:    params.Description,
+		IdempotencyKey: fmt.Sprintf("%s_%s", params.RequestID, transactionID),
+		RequestedBy:    params.RequestID,
+
+		SLASeconds:       svc.config.TransferSLA.TimeoutSeconds,
+		AbortOnSLABreach: svc.config.TransferSLA.AbortOnBreach,
+
+		RequiresApproval: requiresApproval(svc.config.TransferApproval, amount.MinorUnits),
+		ExpirySeconds:    svc.config.TransferApproval.ExpirySeconds,
+		CallbackURL:      params.CallbackURL,
+
+		WaitForFunds:                 fundsWaitEnabled(svc.config.FundsWait, params.WaitForFunds),
+		FundsCheckIntervalSeconds:    svc.config.FundsWait.InitialIntervalSeconds,
+		FundsCheckBackoffCoefficient: svc.config.FundsWait.BackoffCoefficient,
+		FundsCheckMaxIntervalSeconds: svc.config.FundsWait.MaximumIntervalSeconds,
+		FundsWaitSeconds:             svc.config.FundsWait.MaxWaitSeconds,
+
+		NotifyCustomers: svc.config.CustomerEmails.Enabled,
+
+		ExternalSettlement:            svc.config.ExternalSettlement.Enabled,
+		ExternalSettlementCallbackURL: svc.config.ExternalSettlement.CallbackURL,
+
+		RecordEvents: svc.config.TransferEvents.Enabled,
+	}
+
+	if len(approvers) > 0 {
+		workflowParams.RequiresApproval = true
+		workflowParams.RequiredApprovals = jointAccountApprovals
+		workflowParams.Approvers = approvers
+	}
+
+	return workflowParams
+}
+
+// transferWorkflowTimeout gives waiting transfers their approval and funds windows, and externally settled
+// transfers the time of a settlement and a recall, on top of the time the saga itself may take
+func transferWorkflowTimeout(params TransferWorkflowParams) time.Duration {
+	timeout := time.Minute * 10
+	if params.RequiresApproval {
+		timeout += time.Duration(params.ExpirySeconds) * time.Second
+	}
+	if params.WaitForFunds {
+		timeout += time.Duration(params.FundsWaitSeconds) * time.Second
+	}
+	if params.ExternalSettlement {
+		timeout += 2 * externalSettlementTimeout
+	}
+
+	return timeout
+}
+
+// mapWorkflowStatus maps the outcome of a finished transfer workflow to the proto TransferStatus name
+func mapWorkflowStatus(result TransferWorkflowResults) string {
+	switch {
+	case result.Status == "completed":
+		return "TRANSFER_STATUS_COMPLETED"
+	case result.Status == "expired":
+		return "TRANSFER_STATUS_EXPIRED"
+	case result.CompensationApplied:
+		return "TRANSFER_STATUS_COMPENSATED"
+	default:
+		return "TRANSFER_STATUS_FAILED"
+	}
+}
+
 // validateExecuteTransferParams validates the input parameters for transfer execution
 func validateExecuteTransferParams(params *ExecuteTransferParams) error {
 	if params.FromAccount == "" {
-		return fmt.Errorf("from_account is required")
+		return newFieldViolation("from_account", "from_account is required")
 	}
 
-	if params.ToAccount == "" {
-		return fmt.Errorf("to_account is required")
+	if params.ToAccount == "" && params.ToAlias == "" {
+		return newFieldViolation("to_account", "to_account is required unless to_alias is set")
 	}
 
-	if params.FromAccount == params.ToAccount {
-		return fmt.Errorf("from_account and to_account cannot be the same")
+	if params.ToAccount != "" && params.ToAlias != "" {
+		return newFieldViolation("to_alias", "set either to_account or to_alias, not both")
 	}
 
-	if params.Amount <= 0 {
-		return fmt.Errorf("amount must be positive")
+	if params.FromAccount == params.ToAccount {
+		return newFieldViolation("to_account", "from_account and to_account cannot be the same")
 	}
 
 	if params.Currency == "" {
-		return fmt.Errorf("currency is required")
+		return newFieldViolation("currency", "currency is required")
+	}
+
+	if _, ok := currencyDecimalPlaces[params.Currency]; !ok {
+		return newFieldViolation("currency", "unsupported currency: %s", params.Currency)
 	}
 
 	if params.RequestID == "" {
-		return fmt.Errorf("request_id is required")
+		return newFieldViolation("request_id", "request_id is required")
 	}
 
-	// Note: WaitForCompletion is optional and defaults to false (async mode)
-	// - false: Async mode - returns immediately, client polls GetTransferStatus
-	// - true: Sync mode - waits for workflow completion, returns final result
+	if params.TaskQueueTrack != "" && params.TaskQueueTrack != taskqueue.TrackCurrent && params.TaskQueueTrack != taskqueue.TrackNext {
+		return newFieldViolation("task_queue_track", "task_queue_track must be %s or %s", taskqueue.TrackCurrent, taskqueue.TrackNext)
+	}
 
-	// Validate currency format (basic validation)
-	validCurrencies := map[string]bool{
-		"USD": true, "EUR": true, "GBP": true, "JPY": true,
-		"CAD": true, "AUD": true, "CHF": true, "CNY": true,
-		"SGD": true, "HKD": true, "IDR": true,
+	if params.CallbackURL != "" {
+		callbackURL, err := url.Parse(params.CallbackURL)
+		if err != nil || (callbackURL.Scheme != "http" && callbackURL.Scheme != "https") || callbackURL.Host == "" {
+			return newFieldViolation("callback_url", "callback_url must be an absolute http or https URL")
+		}
 	}
 
-	if !validCurrencies[params.Currency] {
-		return fmt.Errorf("unsupported currency: %s", params.Currency)
+	if params.Trigger != nil {
+		if params.Trigger.Account == "" {
+			return newFieldViolation("trigger.account", "trigger.account is required")
+		}
+
+		triggerAmount, err := decimal.NewFromString(params.Trigger.AmountDecimal)
+		if err != nil || !triggerAmount.IsPositive() {
+			return newFieldViolation("trigger.amount_decimal", "trigger.amount_decimal must be a positive decimal")
+		}
+		if err := validateCurrencyAmount(triggerAmount, params.Currency); err != nil {
+			return newFieldViolation("trigger.amount_decimal", "%v", err)
+		}
 	}
 
+	// Note: WaitForCompletion is optional and defaults to false (async mode)
+	// - false: Async mode (EXECUTION_MODE_ASYNC) - returns immediately, client polls GetTransferStatus
+	// - true: Sync mode (EXECUTION_MODE_SYNC) - waits for workflow completion, returns final result
+
+	// The amount itself is checked by resolveTransferAmount, which also converts it
+
 	return nil
 }
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"testing"
+
+	"flowngine/util/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapWorkflowStatus(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "TRANSFER_STATUS_COMPLETED", mapWorkflowStatus(TransferWorkflowResults{Status: "completed"}))
+	assert.Equal(t, "TRANSFER_STATUS_FAILED", mapWorkflowStatus(TransferWorkflowResults{Status: "failed"}))
+	assert.Equal(t, "TRANSFER_STATUS_COMPENSATED", mapWorkflowStatus(TransferWorkflowResults{Status: "failed", CompensationApplied: true}))
+	assert.Equal(t, "TRANSFER_STATUS_EXPIRED", mapWorkflowStatus(TransferWorkflowResults{Status: "expired"}))
+}
+
+func TestRequiresApproval(t *testing.T) {
+	t.Parallel()
+
+	approval := config.TransferApproval{ThresholdAmount: 1000000, ExpirySeconds: 3600}
+
+	assert.False(t, requiresApproval(approval, 999999))
+	assert.True(t, requiresApproval(approval, 1000000))
+	assert.False(t, requiresApproval(config.TransferApproval{}, 1000000), "a zero threshold disables approval")
+}
+
+func TestFundsWaitEnabled(t *testing.T) {
+	t.Parallel()
+
+	fundsWait := config.FundsWait{InitialIntervalSeconds: 30, BackoffCoefficient: 2, MaxWaitSeconds: 3600}
+
+	assert.True(t, fundsWaitEnabled(fundsWait, true))
+	assert.False(t, fundsWaitEnabled(fundsWait, false))
+	assert.False(t, fundsWaitEnabled(config.FundsWait{}, true), "a zero wait disables waiting")
+}
+
+func TestValidateExecuteTransferParams_CallbackURL(t *testing.T) {
+	t.Parallel()
+
+	params := func(callbackURL string) *ExecuteTransferParams {
+		return &ExecuteTransferParams{
+			FromAccount: "account-from",
+			ToAccount:   "account-to",
+			Amount:      10000,
+			Currency:    "USD",
+			RequestID:   "request-123",
+			CallbackURL: callbackURL,
+		}
+	}
+
+	assert.NoError(t, validateExecuteTransferParams(params("")))
+	assert.NoError(t, validateExecuteTransferParams(params("https://example.com/hooks/transfers")))
+	for _, callbackURL := range []string{"example.com/hooks", "ftp://example.com/hooks", "https://"} {
+		var violation *FieldViolation
+		if assert.ErrorAs(t, validateExecuteTransferParams(params(callbackURL)), &violation, callbackURL) {
+			assert.Equal(t, "callback_url", violation.Field)
+		}
+	}
+}
+
+func TestValidateExecuteTransferParams_Trigger(t *testing.T) {
+	t.Parallel()
+
+	params := func(trigger *TransferTrigger) *ExecuteTransferParams {
+		return &ExecuteTransferParams{
+			FromAccount: "account-from",
+			ToAccount:   "account-to",
+			Amount:      10000,
+			Currency:    "JPY",
+			RequestID:   "request-123",
+			Trigger:     trigger,
+		}
+	}
+
+	assert.NoError(t, validateExecuteTransferParams(params(nil)))
+	assert.NoError(t, validateExecuteTransferParams(params(&TransferTrigger{Account: "account-from", AmountDecimal: "5000"})))
+
+	for field, trigger := range map[string]*TransferTrigger{
+		"trigger.account":        {AmountDecimal: "5000"},
+		"trigger.amount_decimal": {Account: "account-from", AmountDecimal: "50.5"},
+	} {
+		var violation *FieldViolation
+		if assert.ErrorAs(t, validateExecuteTransferParams(params(trigger)), &violation, field) {
+			assert.Equal(t, field, violation.Field)
+		}
+	}
+	for _, amount := range []string{"", "abc", "0", "-5"} {
+		var violation *FieldViolation
+		if assert.ErrorAs(t, validateExecuteTransferParams(params(&TransferTrigger{Account: "account-from", AmountDecimal: amount})), &violation, amount) {
+			assert.Equal(t, "trigger.amount_decimal", violation.Field)
+		}
+	}
+}
+
+func TestValidateExecuteTransferParams_ToAlias(t *testing.T) {
+	t.Parallel()
+
+	params := func(toAccount, toAlias string) *ExecuteTransferParams {
+		return &ExecuteTransferParams{
+			FromAccount: "account-from",
+			ToAccount:   toAccount,
+			ToAlias:     toAlias,
+			Amount:      10000,
+			Currency:    "USD",
+			RequestID:   "request-123",
+		}
+	}
+
+	assert.NoError(t, validateExecuteTransferParams(params("account-to", "")))
+	assert.NoError(t, validateExecuteTransferParams(params("", "+14155550101")))
+
+	for field, p := range map[string]*ExecuteTransferParams{
+		"to_account": params("", ""),
+		"to_alias":   params("account-to", "+14155550101"),
+	} {
+		var violation *FieldViolation
+		if assert.ErrorAs(t, validateExecuteTransferParams(p), &violation, field) {
+			assert.Equal(t, field, violation.Field)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// externalSettlementRecallRefused is the status of a settlement whose recall the partner bank refused
+const externalSettlementRecallRefused = "recall_refused"
+
+// externalSettlementRejectedErrorType is the error type of settlements the partner bank rejected, as returned by
+// svc-transaction and by CompleteExternalSettlement
+const externalSettlementRejectedErrorType = "EXTERNAL_SETTLEMENT_REJECTED"
+
+// externalSettlementNotRecalledErrorType fails transfers whose credit failed after the partner bank had settled them
+// and that the partner did not return. The payer stays debited until the transfer is reconciled by hand.
+const externalSettlementNotRecalledErrorType = "EXTERNAL_SETTLEMENT_NOT_RECALLED"
+
+// externalSettlementTimeout bounds each call to the partner bank, a settlement or a recall, including its retries
+const externalSettlementTimeout = 5 * time.Minute
+
+// externalSettlementCallbackTimeout bounds each attempt of a settlement completed by a partner callback, from the
+// submission to the callback. An attempt whose callback never comes is retried, resubmitting the settlement.
+const externalSettlementCallbackTimeout = 2 * time.Minute
+
+// externalSettlementActivityOptions gives the partner bank more time than the services of the bank itself: it is
+// slow, goes down, and keeps settlements pending for a while, all of which are retried. A rejected settlement and
+// a settlement ID reused for another transfer are final. Attempts completed by a callback also wait for the
+// partner to settle.
+func externalSettlementActivityOptions(ctx workflow.Context, callback bool) workflow.Context {
+	startToCloseTimeout := 30 * time.Second
+	if callback {
+		startToCloseTimeout = externalSettlementCallbackTimeout
+	}
+
+	return workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout:    startToCloseTimeout,
+		ScheduleToCloseTimeout: externalSettlementTimeout,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    2 * time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    30 * time.Second,
+			MaximumAttempts:    10,
+			NonRetryableErrorTypes: []string{
+				externalSettlementRejectedErrorType,
+				"IDEMPOTENCY_CONFLICT",
+			},
+		},
+	})
+}
+
+// externalSettlementID identifies the settlement of a transfer at the partner bank. It is derived from the
+// idempotency key, so a settlement resubmitted after a lost reply is recognised as the same one.
+func externalSettlementID(params TransferWorkflowParams) string {
+	return fmt.Sprintf("%s-settle", params.IdempotencyKey)
+}
+
+// settleExternalTransfer settles a debited transfer with the partner bank through the SettleExternalTransfer
+// activity of svc-transaction, returning once the partner has settled it. With a callback URL the activity is
+// completed by CompleteExternalSettlement when the partner calls back, rather than by svc-transaction.
+func settleExternalTransfer(ctx workflow.Context, params TransferWorkflowParams) (map[string]interface{}, error) {
+	workflowInfo := workflow.GetInfo(ctx)
+
+	settlementParams := map[string]interface{}{
+		"settlement_id":       externalSettlementID(params),
+		"transfer_id":         params.TransferID,
+		"beneficiary_account": params.ToAccount,
+		"amount":              params.Amount,
+		"currency":            params.Currency,
+		"workflow_id":         workflowInfo.WorkflowExecution.ID,
+		"run_id":              workflowInfo.WorkflowExecution.RunID,
+	}
+	callback := params.ExternalSettlementCallbackURL != ""
+	if callback {
+		settlementParams["callback_url"] = params.ExternalSettlementCallbackURL
+	}
+
+	var settlementResult map[string]interface{}
+	err := workflow.ExecuteActivity(externalSettlementActivityOptions(ctx, callback), "SettleExternalTransfer", settlementParams).Get(ctx, &settlementResult)
+
+	return settlementResult, err
+}
+
+// recallExternalSettlement asks the partner bank to return the funds of a settlement. It fails when the recall
+// fails or the partner answers recall_refused; recalled and not_found both mean nothing is settled any more.
+func recallExternalSettlement(ctx workflow.Context, params TransferWorkflowParams, reason string) error {
+	logger := workflow.GetLogger(ctx)
+	workflowInfo := workflow.GetInfo(ctx)
+
+	recallParams := map[string]interface{}{
+		"settlement_id": externalSettlementID(params),
+		"transfer_id":   params.TransferID,
+		"reason":        reason,
+		"workflow_id":   workflowInfo.WorkflowExecution.ID,
+		"run_id":        workflowInfo.WorkflowExecution.RunID,
+	}
+
+	var recallResult map[string]interface{}
+	if err := workflow.ExecuteActivity(externalSettlementActivityOptions(ctx, false), "RecallExternalSettlement", recallParams).Get(ctx, &recallResult); err != nil {
+		logger.Error("External settlement recall failed", "error", err)
+		return err
+	}
+
+	logger.Info("External settlement recall answered", "recall_result", recallResult)
+
+	if status, _ := recallResult["status"].(string); status == externalSettlementRecallRefused {
+		reason, _ := recallResult["reason"].(string)
+		return fmt.Errorf("partner bank refused to recall settlement %s: %s", externalSettlementID(params), reason)
+	}
+
+	return nil
+}
+
+// newExternalSettlementNotRecalledError is returned by transfer workflows left for manual reconciliation
+func newExternalSettlementNotRecalledError(params TransferWorkflowParams, creditErr, recallErr error) error {
+	return temporal.NewNonRetryableApplicationError(
+		fmt.Sprintf("credit failed and settlement %s was not recalled, manual reconciliation required: %v", externalSettlementID(params), recallErr),
+		externalSettlementNotRecalledErrorType,
+		creditErr,
+	)
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+)
+
+func TestTransferWorkflowExternalSettlement(t *testing.T) {
+	t.Parallel()
+
+	sufficientFunds := map[string]interface{}{"sufficient_funds": true}
+	debitResult := map[string]interface{}{"transaction_id": "debit-transaction-123"}
+	creditResult := map[string]interface{}{"transaction_id": "credit-transaction-123"}
+	compensationResult := map[string]interface{}{"transaction_id": "compensation-transaction-123"}
+	settlementResult := map[string]interface{}{"settlement_id": "idempotency-123-settle", "status": "settled"}
+
+	externalParams := func() TransferWorkflowParams {
+		params := validTransferWorkflowParams()
+		params.ExternalSettlement = true
+		return params
+	}
+
+	t.Run("settles_before_credit", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("SettleExternalTransfer", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["settlement_id"] == "idempotency-123-settle" && params["beneficiary_account"] == "account-to"
+		})).Return(settlementResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, externalParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "completed", results.Status)
+	})
+
+	t.Run("callback_url_is_sent_to_the_partner", func(t *testing.T) {
+		t.Parallel()
+
+		params := externalParams()
+		params.ExternalSettlementCallbackURL = "http://flowngine:8080/callbacks/external-settlements"
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("SettleExternalTransfer", mock.Anything, mock.MatchedBy(func(settlementParams map[string]interface{}) bool {
+			return settlementParams["callback_url"] == params.ExternalSettlementCallbackURL
+		})).Return(settlementResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, params)
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+	})
+
+	t.Run("disabled_skips_settlement", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+		env.AssertNotCalled(t, "SettleExternalTransfer", mock.Anything, mock.Anything)
+	})
+
+	t.Run("pending_settlement_is_retried", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("SettleExternalTransfer", mock.Anything, mock.Anything).
+			Return(nil, errors.New("external settlement pending")).Times(4)
+		env.OnActivity("SettleExternalTransfer", mock.Anything, mock.Anything).Return(settlementResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, externalParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+	})
+
+	t.Run("rejected_settlement_compensates_debit", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("SettleExternalTransfer", mock.Anything, mock.Anything).
+			Return(nil, nonRetryableError("EXTERNAL_SETTLEMENT_REJECTED")).Once()
+		env.OnActivity("CompensateDebit", mock.Anything, mock.Anything).Return(compensationResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, externalParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+
+		var applicationErr *temporal.ApplicationError
+		require.ErrorAs(t, err, &applicationErr)
+		assert.Equal(t, "EXTERNAL_SETTLEMENT_REJECTED", applicationErr.Type())
+		env.AssertNotCalled(t, "CreditAccount", mock.Anything, mock.Anything)
+		env.AssertNotCalled(t, "RecallExternalSettlement", mock.Anything, mock.Anything)
+	})
+
+	t.Run("credit_failure_recalls_then_compensates", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("SettleExternalTransfer", mock.Anything, mock.Anything).Return(settlementResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).
+			Return(nil, nonRetryableError("ACCOUNT_BLOCKED")).Once()
+		env.OnActivity("RecallExternalSettlement", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["settlement_id"] == "idempotency-123-settle"
+		})).Return(map[string]interface{}{"status": "recalled"}, nil).Once()
+		env.OnActivity("CompensateDebit", mock.Anything, mock.Anything).Return(compensationResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, externalParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+
+		var applicationErr *temporal.ApplicationError
+		require.ErrorAs(t, err, &applicationErr)
+		assert.Equal(t, "ACCOUNT_BLOCKED", applicationErr.Type())
+	})
+
+	t.Run("refused_recall_leaves_debit_for_manual_reconciliation", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("SettleExternalTransfer", mock.Anything, mock.Anything).Return(settlementResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).
+			Return(nil, nonRetryableError("ACCOUNT_BLOCKED")).Once()
+		env.OnActivity("RecallExternalSettlement", mock.Anything, mock.Anything).
+			Return(map[string]interface{}{"status": "recall_refused", "reason": "beneficiary bank refused to return the funds"}, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, externalParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+
+		var applicationErr *temporal.ApplicationError
+		require.ErrorAs(t, err, &applicationErr)
+		assert.Equal(t, "EXTERNAL_SETTLEMENT_NOT_RECALLED", applicationErr.Type())
+		assert.Contains(t, err.Error(), "beneficiary bank refused to return the funds")
+
+		// Compensating would pay the transfer out twice, once by the partner and once back to the payer
+		env.AssertNotCalled(t, "CompensateDebit", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"flowngine/util/failure"
+)
+
+// Operations of FlowEngine that failure rules can target. They cover what happens around a workflow rather than
+// inside its activities, which svc-balance and svc-transaction simulate on their own.
+const (
+	failureOperationStartWorkflow        = "StartWorkflow"        // Starting a transfer or batch workflow
+	failureOperationScheduleWorkflowTask = "ScheduleWorkflowTask" // Slow rules defer the first workflow task by DelayMs
+	failureOperationSignalWorkflow       = "SignalWorkflow"       // Signalling a running transfer, e.g. its approval
+)
+
+// Learning-focused failure simulation rules
+// These are hardcoded scenarios showing how the gateway and its clients handle workflows that fail to start,
+// start late or never receive a signal. Only the account-targeted scenario is enabled by default, so the random
+// ones do not get in the way of other demos; enable them with flowctl chaos or /failure-simulation/rules.
+var learningFailureRules = []failure.Rule{
+	// Scenario 1: Workflow start failures for a specific account, retried by the caller
+	{
+		Name:        "workflow_start_failure",
+		Enabled:     true,
+		Type:        "error",
+		Probability: 1.0, // Always fail for this account
+		Operations:  []string{failureOperationStartWorkflow},
+		Accounts:    []string{"777777777777"}, // Specific test account (payer)
+		Message:     "Workflow could not be started, demonstrating gateway-side start failure handling",
+		MaxCount:    3, // The fourth attempt goes through
+	},
+
+	// Scenario 2: Random workflow start failures
+	{
+		Name:        "random_workflow_start_failures",
+		Enabled:     false,
+		Type:        "error",
+		Probability: 0.1, // 10% chance
+		Operations:  []string{failureOperationStartWorkflow},
+		Accounts:    []string{"*"},
+		Message:     "Random workflow start failure demonstrating client retries",
+		MaxCount:    5,
+	},
+
+	// Scenario 3: Workflow starts hanging until the caller gives up
+	{
+		Name:        "workflow_start_timeout",
+		Enabled:     false,
+		Type:        "timeout",
+		Probability: 0.2, // 20% chance
+		Operations:  []string{failureOperationStartWorkflow},
+		Accounts:    []string{"*"},
+		TimeoutMs:   15000, // Longer than clients usually allow with X-Request-Timeout
+		Message:     "Workflow start timeout demonstrating unknown outcomes at the gateway",
+		MaxCount:    3,
+	},
+
+	// Scenario 4: Delayed task scheduling, the transfer is accepted but its saga starts late
+	{
+		Name:        "delayed_workflow_tasks",
+		Enabled:     false,
+		Type:        "slow",
+		Probability: 0.25, // 25% chance
+		Operations:  []string{failureOperationScheduleWorkflowTask},
+		Accounts:    []string{"*"},
+		DelayMs:     30000, // First workflow task scheduled 30 seconds after the start
+		Message:     "Delayed workflow task demonstrating transfers that stay pending",
+		MaxCount:    5,
+	},
+
+	// Scenario 5: Dropped signals, the approval is acknowledged but never reaches the workflow
+	{
+		Name:        "dropped_approval_signals",
+		Enabled:     false,
+		Type:        "drop",
+		Probability: 1.0,
+		Operations:  []string{failureOperationSignalWorkflow},
+		Accounts:    []string{"*"},
+		Message:     "Dropped signal demonstrating approvals that expire despite being acknowledged",
+		MaxCount:    1, // Only once per session
+	},
+}
+
+// simulateWorkflowStartFailure injects the StartWorkflow failures of the payer's account before a workflow is
+// started. Its errors are returned like any other failed start.
+func (svc *Service) simulateWorkflowStartFailure(ctx context.Context, accountID string) error {
+	return svc.failureSimulator.SimulateFailure(ctx, failureOperationStartWorkflow, accountID, learningFailureRules)
+}
+
+// simulatedStartDelay returns how long the first task of a workflow started for the payer's account is deferred,
+// zero unless a ScheduleWorkflowTask rule matches
+func (svc *Service) simulatedStartDelay(accountID string) time.Duration {
+	rule, ok := svc.failureSimulator.Match(failureOperationScheduleWorkflowTask, accountID, learningFailureRules)
+	if !ok || rule.Type != "slow" {
+		return 0
+	}
+
+	return time.Duration(rule.DelayMs) * time.Millisecond
+}
+
+// simulateSignalFailure injects the SignalWorkflow failures before a signal is sent. It returns failure.ErrDropped
+// when the signal is to be acknowledged without being sent.
+func (svc *Service) simulateSignalFailure(ctx context.Context) error {
+	return svc.failureSimulator.SimulateFailure(ctx, failureOperationSignalWorkflow, "", learningFailureRules)
+}
+
+// GetFailureSimulationStats returns statistics about failure simulation
+// This helps track how many failures have been injected during the learning session
+func (svc *Service) GetFailureSimulationStats() map[string]any {
+	stats := svc.failureSimulator.GetStats()
+
+	// Add learning context to stats
+	stats["learning_mode"] = true
+	stats["total_rules"] = len(learningFailureRules)
+	stats["enabled_rules"] = svc.countEnabledRules()
+
+	return stats
+}
+
+// SetFailureQuota caps the share of operations the failure rules fail per minute
+func (svc *Service) SetFailureQuota(quota failure.Quota) {
+	svc.failureSimulator.SetQuota(quota)
+}
+
+// ResetFailureSimulation resets the failure simulation state
+// Useful for starting a new learning session with fresh statistics
+func (svc *Service) ResetFailureSimulation() {
+	svc.failureSimulator.Reset()
+}
+
+// SetFailureSimulationEnabled switches failure injection on or off for the whole service.
+// Disabling it keeps the rules and counters, so enabling it again resumes the learning session.
+func (svc *Service) SetFailureSimulationEnabled(enabled bool) {
+	svc.failureSimulator.SetEnabled(enabled)
+}
+
+// ErrFailureRuleNotFound is returned when a rule name matches none of the learning rules
+var ErrFailureRuleNotFound = errors.New("failure rule not found")
+
+// SetFailureRuleEnabled switches a single learning rule on or off until ResetFailureSimulation restores the
+// hardcoded defaults
+func (svc *Service) SetFailureRuleEnabled(name string, enabled bool) error {
+	for _, rule := range learningFailureRules {
+		if rule.Name == name {
+			svc.failureSimulator.SetRuleEnabled(name, enabled)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrFailureRuleNotFound, name)
+}
+
+// countEnabledRules counts how many rules are currently enabled
+func (svc *Service) countEnabledRules() int {
+	count := 0
+	for _, rule := range learningFailureRules {
+		if svc.failureSimulator.RuleEnabled(rule) {
+			count++
+		}
+	}
+	return count
+}
+
+// GetLearningScenarios returns a description of available failure scenarios
+// This helps developers understand what failure scenarios are available for testing
+func (svc *Service) GetLearningScenarios() []map[string]any {
+	scenarios := make([]map[string]any, 0, len(learningFailureRules))
+
+	for _, rule := range learningFailureRules {
+		scenario := map[string]any{
+			"name":        rule.Name,
+			"enabled":     svc.failureSimulator.RuleEnabled(rule),
+			"type":        rule.Type,
+			"probability": rule.Probability,
+			"operations":  rule.Operations,
+			"accounts":    rule.Accounts,
+			"description": rule.Message,
+		}
+
+		if rule.DelayMs > 0 {
+			scenario["delay_ms"] = rule.DelayMs
+		}
+		if rule.TimeoutMs > 0 {
+			scenario["timeout_ms"] = rule.TimeoutMs
+		}
+		if rule.MaxCount > 0 {
+			scenario["max_count"] = rule.MaxCount
+		}
+
+		scenarios = append(scenarios, scenario)
+	}
+
+	return scenarios
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"flowngine/util/failure"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+)
+
+func newFailureSimulationTestService(temporalClient client.Client) *Service {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	return &Service{
+		logger:           logger,
+		temporalClient:   temporalClient,
+		failureSimulator: failure.NewSimulator(logger),
+	}
+}
+
+func TestExecuteTransfer_SimulatedStartFailure(t *testing.T) {
+	t.Parallel()
+
+	run := &mocks.WorkflowRun{}
+	run.On("GetRunID").Return("run-1")
+
+	temporalClient := &mocks.Client{}
+	temporalClient.On("DescribeWorkflowExecution", mock.Anything, mock.Anything, "").Return(nil, serviceerror.NewNotFound("workflow not found"))
+	temporalClient.On("SignalWithStartWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(run, nil)
+	onTransferHistory(t, temporalClient, false)
+
+	svc := newFailureSimulationTestService(temporalClient)
+	params := &ExecuteTransferParams{FromAccount: "777777777777", ToAccount: "ACC001000002", Amount: 1000, Currency: "USD", RequestID: "req-1"}
+
+	// The start fails before Temporal is called, up to the rule's MaxCount
+	for range 3 {
+		_, err := svc.ExecuteTransfer(context.Background(), params)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to start workflow: DEMO_WORKFLOW_FAILURE")
+	}
+	temporalClient.AssertNotCalled(t, "SignalWithStartWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	results, err := svc.ExecuteTransfer(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, "run-1", results.RunID)
+
+	// Other payers are not affected
+	svc.ResetFailureSimulation()
+	other := *params
+	other.FromAccount = "ACC001000001"
+	_, err = svc.ExecuteTransfer(context.Background(), &other)
+	assert.NoError(t, err)
+}
+
+func TestSimulatedStartDelay(t *testing.T) {
+	t.Parallel()
+
+	svc := newFailureSimulationTestService(nil)
+	assert.Zero(t, svc.simulatedStartDelay("ACC001000001"), "disabled by default")
+
+	// The rule defers a quarter of the starts
+	require.NoError(t, svc.SetFailureRuleEnabled("delayed_workflow_tasks", true))
+	for range 100 {
+		if delay := svc.simulatedStartDelay("ACC001000001"); delay > 0 {
+			assert.Equal(t, 30000, int(delay.Milliseconds()))
+			return
+		}
+	}
+	t.Fatal("delayed_workflow_tasks never matched")
+}
+
+func TestApproveTransfer_SimulatedDroppedSignal(t *testing.T) {
+	t.Parallel()
+
+	temporalClient := &mocks.Client{}
+	temporalClient.On("SignalWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	svc := newFailureSimulationTestService(temporalClient)
+	require.NoError(t, svc.SetFailureRuleEnabled("dropped_approval_signals", true))
+
+	params := &ApproveTransferParams{TransactionID: "9b2f7c64-4f7e-4a53-9c1a-0d7d1c1f5e21", ApprovedBy: "ops"}
+
+	// The approval is acknowledged without being signalled
+	results, err := svc.ApproveTransfer(context.Background(), params)
+	require.NoError(t, err)
+	assert.True(t, results.Success)
+	temporalClient.AssertNotCalled(t, "SignalWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// MaxCount is 1, so the next approval goes through
+	_, err = svc.ApproveTransfer(context.Background(), params)
+	require.NoError(t, err)
+	temporalClient.AssertNumberOfCalls(t, "SignalWorkflow", 1)
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"flowngine/adapter/transaction_adapter"
+	"flowngine/util/config"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// shouldUseFastPath decides whether a transfer bypasses the Temporal saga.
+// Only small transfers are eligible, against the limit of their currency in its minor units; currencies without a
+// limit always use the saga. Currency consistency of both accounts is enforced by svc-transaction.
+func shouldUseFastPath(fastPath config.FastPath, currency string, amountMinorUnits int64) bool {
+	if !fastPath.Enabled {
+		return false
+	}
+
+	return amountMinorUnits > 0 && amountMinorUnits <= fastPath.MaxAmount(currency)
+}
+
+// executeFastPathTransfer performs the transfer synchronously via svc-transaction in a single DB transaction
+func (svc *Service) executeFastPathTransfer(ctx context.Context, params *ExecuteTransferParams, amount decimal.Decimal, transactionID string) (*ExecuteTransferResults, error) {
+	const op = "service.Service.executeFastPathTransfer"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":           op,
+		"transaction_id": transactionID,
+	})
+
+	logger.Info("⚡ Executing transfer on fast path (no Temporal workflow)")
+
+	startedAt := time.Now()
+
+	results := &ExecuteTransferResults{
+		TransactionID: transactionID,
+		CreatedAt:     startedAt.Format(time.RFC3339),
+	}
+
+	response, err := svc.transactionAdapter.ExecuteInternalTransfer(ctx, &transaction_adapter.InternalTransferRequest{
+		TransferID:    transactionID,
+		FromAccountID: params.FromAccount,
+		ToAccountID:   params.ToAccount,
+		Amount:        amount.String(),
+		Currency:      params.Currency,
+		Description:   params.Description,
+		RequestedBy:   params.RequestID,
+	})
+	if err != nil {
+		// Business rejections are final results: nothing was committed, so there is nothing to compensate
+		var responseErr *transaction_adapter.ResponseError
+		if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusUnprocessableEntity {
+			completedAt := time.Now().Format(time.RFC3339)
+
+			results.Status = "TRANSFER_STATUS_FAILED"
+			results.CompletedAt = &completedAt
+			results.ErrorMessage = responseErr.Message
+
+			logger.WithField("duration_ms", time.Since(startedAt).Milliseconds()).Warn("Fast path transfer rejected")
+
+			return results, nil
+		}
+
+		err = fmt.Errorf("fast path transfer failed: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results.Status = mapFastPathStatus(response.Status)
+	results.CompletedAt = response.CompletedAt
+	results.FinalAmount = &amount
+
+	compensationApplied := false
+	results.CompensationApplied = &compensationApplied
+
+	logger.WithFields(logrus.Fields{
+		"status":      results.Status,
+		"duration_ms": time.Since(startedAt).Milliseconds(),
+		"db_time_ms":  response.DurationMs,
+	}).Info("✅ Fast path transfer completed")
+
+	return results, nil
+}
+
+// getFastPathTransferStatus looks up a transfer executed on the fast path.
+// It returns (nil, nil) when svc-transaction has no such transfer so callers can fall back to Temporal.
+func (svc *Service) getFastPathTransferStatus(ctx context.Context, transactionID string) (*GetTransferStatusResults, error) {
+	response, err := svc.transactionAdapter.GetInternalTransfer(ctx, transactionID)
+	if err != nil {
+		var responseErr *transaction_adapter.ResponseError
+		if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	amount, err := decimal.NewFromString(response.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount from svc-transaction: %w", err)
+	}
+
+	amountMinorUnits, err := decimalToMinorUnits(amount, response.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount from svc-transaction: %w", err)
+	}
+
+	results := &GetTransferStatusResults{
+		TransactionID: transactionID,
+		Status:        mapFastPathStatus(response.Status),
+		FromAccount:   response.FromAccountID,
+		ToAccount:     response.ToAccountID,
+		Amount:        amountMinorUnits,
+		AmountDecimal: formatMajorUnits(amount, response.Currency),
+		Currency:      response.Currency,
+		ReferenceID:   transactionID,
+		CreatedAt:     response.CreatedAt,
+	}
+
+	if response.Description != nil {
+		results.Description = *response.Description
+	}
+	if response.CompletedAt != nil {
+		results.CompletedAt = *response.CompletedAt
+	}
+
+	return results, nil
+}
+
+// mapFastPathStatus maps a core.transfer_status value to the proto TransferStatus name
+func mapFastPathStatus(status string) string {
+	switch status {
+	case "completed":
+		return "TRANSFER_STATUS_COMPLETED"
+	case "failed":
+		return "TRANSFER_STATUS_FAILED"
+	case "cancelled":
+		return "TRANSFER_STATUS_CANCELLED"
+	case "processing":
+		return "TRANSFER_STATUS_PROCESSING"
+	default:
+		return "TRANSFER_STATUS_PENDING"
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"testing"
+
+	"flowngine/util/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldUseFastPath(t *testing.T) {
+	t.Parallel()
+
+	// Viper lowercases the currency codes of the config file
+	enabled := config.FastPath{Enabled: true, MaxAmounts: map[string]int64{"usd": 100000, "jpy": 150000}}
+
+	tests := []struct {
+		name     string
+		fastPath config.FastPath
+		currency string
+		amount   int64
+		expected bool
+	}{
+		{"Disabled policy always uses saga", config.FastPath{Enabled: false, MaxAmounts: map[string]int64{"USD": 100000}}, "USD", 100, false},
+		{"Small amount uses fast path", enabled, "USD", 100, true},
+		{"Amount at limit uses fast path", enabled, "USD", 100000, true},
+		{"Amount above limit uses saga", enabled, "USD", 100001, false},
+		{"Limit is in the minor units of the currency", enabled, "JPY", 150000, true},
+		{"Currency without a limit uses saga", enabled, "EUR", 100, false},
+		{"No limits disables fast path", config.FastPath{Enabled: true}, "USD", 100, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, shouldUseFastPath(tt.fastPath, tt.currency, tt.amount))
+		})
+	}
+}
+
+func TestMapFastPathStatus(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "TRANSFER_STATUS_COMPLETED", mapFastPathStatus("completed"))
+	assert.Equal(t, "TRANSFER_STATUS_FAILED", mapFastPathStatus("failed"))
+	assert.Equal(t, "TRANSFER_STATUS_CANCELLED", mapFastPathStatus("cancelled"))
+	assert.Equal(t, "TRANSFER_STATUS_PROCESSING", mapFastPathStatus("processing"))
+	assert.Equal(t, "TRANSFER_STATUS_PENDING", mapFastPathStatus("pending"))
+}
@@ -2,15 +2,24 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
 )
 
+// maxStatusWait caps how long GetTransferStatus blocks for a transfer to finish
+const maxStatusWait = 30 * time.Second
+
+// errWorkflowRunning is returned by waitForWorkflowResult when the workflow has not finished in time
+var errWorkflowRunning = errors.New("workflow is still running")
+
 type GetTransferStatusParams struct {
 	TransactionID string `json:"transaction_id"`
+	WaitSeconds   int32  `json:"wait_seconds"` // Long-poll up to this many seconds for a terminal state; capped at maxStatusWait
 }
 
 type GetTransferStatusResults struct {
@@ -18,7 +27,8 @@ type GetTransferStatusResults struct {
 	Status            string `json:"status"`
 	FromAccount       string `json:"from_account"`
 	ToAccount         string `json:"to_account"`
-	Amount            int64  `json:"amount"`
+	Amount            int64  `json:"amount"`         // Minor units of the currency
+	AmountDecimal     string `json:"amount_decimal"` // Major units with the currency's decimal places, e.g. "100.50"
 	Currency          string `json:"currency"`
 	Description       string `json:"description"`
 	ReferenceID       string `json:"reference_id"`
@@ -29,7 +39,12 @@ type GetTransferStatusResults struct {
 		RunID      string `json:"run_id"`
 		Status     string `json:"status"`
 	} `json:"workflow_execution"`
-	ErrorMessage string `json:"error_message"`
+	ErrorMessage      string             `json:"error_message"`
+	TransferReference string             `json:"transfer_reference"`
+	Steps             []TransferStep     `json:"steps"`          // Saga steps with their attempts; empty for fast path transfers
+	SLABreached       bool               `json:"sla_breached"`   // Running transfers past their SLA are reported as DELAYED
+	Wait              *TransferWaitState `json:"wait,omitempty"` // Set for transfers reported as AWAITING_APPROVAL or AWAITING_FUNDS
+	FX                *TransferFXResults `json:"fx,omitempty"`   // Set when the credit was converted to the currency of the destination account
 }
 
 func (svc *Service) GetTransferStatus(ctx context.Context, params *GetTransferStatusParams) (*GetTransferStatusResults, error) {
@@ -42,37 +57,93 @@ func (svc *Service) GetTransferStatus(ctx context.Context, params *GetTransferSt
 
 	logger.Info("Getting transfer status")
 
-	// Check if Temporal client is available
-	if svc.temporalClient == nil {
-		err := fmt.Errorf("temporal client not available - service is starting up")
+	// Validate input parameters
+	if err := validateGetTransferStatusParams(params); err != nil {
+		err = invalidParameters(err)
 
-		logger.WithError(err).Warn("GetTransferStatus request received but Temporal client not ready")
+		logger.WithError(err).Error()
 
 		return nil, err
 	}
 
-	// Validate input parameters
-	if err := validateGetTransferStatusParams(params); err != nil {
-		err = fmt.Errorf("invalid parameters: %w", err)
-
+	// Support agents may look a transfer up by the reference printed on its receipt
+	transactionID, transferReference, err := svc.resolveTransferReference(ctx, params.TransactionID)
+	if err != nil {
 		logger.WithError(err).Error()
 
 		return nil, err
 	}
+	if transferReference == "" {
+		transferReference = svc.lookupTransferReference(ctx, transactionID)
+	}
+
+	// Transfers executed on the fast path have no workflow - look them up in svc-transaction first
+	if svc.config.FastPath.Enabled {
+		fastPathResults, err := svc.getFastPathTransferStatus(ctx, transactionID)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to look up fast path transfer, falling back to Temporal")
+		} else if fastPathResults != nil {
+			fastPathResults.TransferReference = transferReference
+
+			logger.WithField("status", fastPathResults.Status).Info("📊 Transfer status retrieved from fast path record")
+
+			return fastPathResults, nil
+		}
+	}
+
+	// Finished saga transfers are served from the read model of svc-transaction, keeping status reads off Temporal
+	if svc.config.TransferReadModel.Enabled {
+		readModelResults, err := svc.getReadModelTransferStatus(ctx, transactionID)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to look up transfer read model, falling back to Temporal")
+		} else if readModelResults != nil {
+			readModelResults.TransferReference = transferReference
+
+			logger.WithField("status", readModelResults.Status).Info("📊 Transfer status retrieved from transfer read model")
+
+			return readModelResults, nil
+		}
+	}
+
+	// Check if Temporal client is available
+	if svc.temporalClient == nil {
+		if results := svc.recordedTransferStatus(ctx, logger, transactionID, transferReference); results != nil {
+			return results, nil
+		}
+
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn("GetTransferStatus request received but Temporal client not ready")
+
+		return nil, err
+	}
 
 	// Generate workflow ID from transaction ID (following the same pattern as ExecuteTransfer)
-	workflowID := fmt.Sprintf("transfer_workflow_%s", params.TransactionID)
+	workflowID := transferWorkflowID(transactionID)
 
-	logger.Info("Querying workflow status from Temporal", "workflow_id", workflowID, "transaction_id", params.TransactionID)
+	logger.Info("Querying workflow status from Temporal", "workflow_id", workflowID, "transaction_id", transactionID)
 
 	// 🎯 THIS IS THE TEMPORAL MAGIC!
 	// Query workflow status directly from Temporal - no custom database needed!
 	// Temporal maintains ALL workflow state, execution history, and status
 	workflowRun := svc.temporalClient.GetWorkflow(ctx, workflowID, "")
 
-	// Try to get the workflow result
+	// Try to get the workflow result, long-polling for it when the client asked to wait
 	var workflowResult TransferWorkflowResults
-	workflowErr := workflowRun.Get(ctx, &workflowResult)
+	workflowErr := svc.waitForWorkflowResult(ctx, workflowRun, statusWait(params.WaitSeconds), &workflowResult)
+
+	// Attempt counts are extra detail, so the status is still returned when the history cannot be read
+	steps, err := svc.getTransferSteps(ctx, workflowID, errors.Is(workflowErr, errWorkflowRunning))
+	if err != nil {
+		logger.WithError(err).Warn("Failed to read transfer steps from workflow history")
+	}
+
+	// Transfers Temporal cannot tell about any more are reported as their workflow recorded them
+	if workflowErr != nil && !errors.Is(workflowErr, errWorkflowRunning) && temporalLookupFailed(workflowErr) {
+		if results := svc.recordedTransferStatus(ctx, logger, transactionID, transferReference); results != nil {
+			return results, nil
+		}
+	}
 
 	if workflowErr != nil {
 		// Workflow might still be running or failed
@@ -81,7 +152,7 @@ func (svc *Service) GetTransferStatus(ctx context.Context, params *GetTransferSt
 		// For running workflows, return pending status
 		// Temporal Web UI will show you the real-time execution progress!
 		results := &GetTransferStatusResults{
-			TransactionID: params.TransactionID,
+			TransactionID: transactionID,
 			Status:        "TRANSFER_STATUS_PROCESSING",
 			FromAccount:   "", // Would be available from workflow history if needed
 			ToAccount:     "",
@@ -100,26 +171,55 @@ func (svc *Service) GetTransferStatus(ctx context.Context, params *GetTransferSt
 				RunID:      workflowRun.GetRunID(),
 				Status:     "RUNNING",
 			},
-			ErrorMessage: "",
+			ErrorMessage:      "",
+			TransferReference: transferReference,
+			Steps:             steps,
+		}
+
+		// A transfer past its SLA is marked by its workflow through the TransferSLAStatus search attribute
+		slaStatus, err := svc.transferSLAStatus(ctx, workflowID)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to read transfer SLA status")
+		} else if slaStatus != "" {
+			results.Status = "TRANSFER_STATUS_DELAYED"
+			results.Description = "Transfer is taking longer than its SLA"
+			results.SLABreached = true
 		}
 
-		logger.Info("📊 Workflow status from Temporal: PROCESSING")
+		// A transfer waiting before its debit reports what it waits for, ahead of a breached SLA
+		wait, err := svc.transferWait(ctx, workflowID)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to query transfer wait state")
+		} else if wait != nil {
+			results.Status, results.Description = transferWaitStatus(wait)
+			results.Wait = wait
+		}
+
+		logger.WithField("status", results.Status).Info("📊 Workflow status from Temporal")
 		return results, nil
 	}
 
 	// 🎉 Workflow completed! All data comes from Temporal
 	// Convert amount back to minor units for API response
-	amountMinorUnits := workflowResult.Amount.Mul(decimal.NewFromInt(100)).IntPart()
+	amountMinorUnits, err := decimalToMinorUnits(workflowResult.Amount, workflowResult.Currency)
+	if err != nil {
+		err = fmt.Errorf("invalid workflow amount: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
 
 	results := &GetTransferStatusResults{
-		TransactionID: params.TransactionID,
-		Status:        workflowResult.Status,
+		TransactionID: transactionID,
+		Status:        mapWorkflowStatus(workflowResult),
 		FromAccount:   workflowResult.FromAccount,
 		ToAccount:     workflowResult.ToAccount,
 		Amount:        amountMinorUnits,
+		AmountDecimal: formatMajorUnits(workflowResult.Amount, workflowResult.Currency),
 		Currency:      workflowResult.Currency,
 		Description:   workflowResult.Description,
-		ReferenceID:   params.TransactionID,
+		ReferenceID:   transactionID,
 		CreatedAt:     workflowResult.StartedAt.Format(time.RFC3339),
 		WorkflowExecution: struct {
 			WorkflowID string `json:"workflow_id"`
@@ -130,7 +230,11 @@ func (svc *Service) GetTransferStatus(ctx context.Context, params *GetTransferSt
 			RunID:      workflowResult.RunID,
 			Status:     "COMPLETED",
 		},
-		ErrorMessage: workflowResult.ErrorMessage,
+		ErrorMessage:      workflowResult.ErrorMessage,
+		TransferReference: transferReference,
+		Steps:             steps,
+		SLABreached:       workflowResult.SLABreached,
+		FX:                newTransferFXResults(workflowResult.FX),
 	}
 
 	if workflowResult.CompletedAt != nil {
@@ -138,17 +242,54 @@ func (svc *Service) GetTransferStatus(ctx context.Context, params *GetTransferSt
 	}
 
 	logger.Info("🎉 Workflow status retrieved directly from Temporal - no custom database needed!",
-		"transaction_id", params.TransactionID,
+		"transaction_id", transactionID,
 		"status", results.Status,
 		"workflow_id", workflowID)
 
 	return results, nil
 }
 
+// statusWait converts the requested wait to a duration, capped at maxStatusWait
+func statusWait(waitSeconds int32) time.Duration {
+	return min(time.Duration(waitSeconds)*time.Second, maxStatusWait)
+}
+
+// waitForWorkflowResult fetches the result of a transfer workflow, waiting up to wait for a running one to finish.
+// Without a wait it only fetches the result of a finished workflow, since Get blocks until the workflow completes.
+// It returns errWorkflowRunning when the workflow is still running.
+func (svc *Service) waitForWorkflowResult(ctx context.Context, workflowRun client.WorkflowRun, wait time.Duration, result *TransferWorkflowResults) error {
+	if wait <= 0 {
+		description, err := svc.temporalClient.DescribeWorkflowExecution(ctx, workflowRun.GetID(), workflowRun.GetRunID())
+		if err != nil {
+			return fmt.Errorf("failed to describe workflow: %w", err)
+		}
+
+		if description.GetWorkflowExecutionInfo().GetStatus() == enums.WORKFLOW_EXECUTION_STATUS_RUNNING {
+			return errWorkflowRunning
+		}
+
+		return workflowRun.Get(ctx, result)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+
+	err := workflowRun.Get(waitCtx, result)
+	if err != nil && waitCtx.Err() != nil && ctx.Err() == nil {
+		return errWorkflowRunning
+	}
+
+	return err
+}
+
 // validateGetTransferStatusParams validates the input parameters for status checking
 func validateGetTransferStatusParams(params *GetTransferStatusParams) error {
 	if params.TransactionID == "" {
-		return fmt.Errorf("transaction_id is required")
+		return newFieldViolation("transaction_id", "transaction_id is required")
+	}
+
+	if params.WaitSeconds < 0 {
+		return newFieldViolation("wait_seconds", "wait_seconds cannot be negative")
 	}
 
 	return nil
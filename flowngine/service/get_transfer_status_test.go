@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/mocks"
+)
+
+func TestStatusWait(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, time.Duration(0), statusWait(0))
+	assert.Equal(t, 5*time.Second, statusWait(5))
+	assert.Equal(t, maxStatusWait, statusWait(3600))
+}
+
+func TestWaitForWorkflowResult(t *testing.T) {
+	t.Parallel()
+
+	describe := func(status enums.WorkflowExecutionStatus) *workflowservice.DescribeWorkflowExecutionResponse {
+		return &workflowservice.DescribeWorkflowExecutionResponse{
+			WorkflowExecutionInfo: &workflow.WorkflowExecutionInfo{Status: status},
+		}
+	}
+
+	newRun := func() *mocks.WorkflowRun {
+		run := &mocks.WorkflowRun{}
+		run.On("GetID").Return("transfer_workflow_tx-1")
+		run.On("GetRunID").Return("run-1")
+		return run
+	}
+
+	t.Run("no wait returns at once for a running workflow", func(t *testing.T) {
+		t.Parallel()
+
+		temporalClient := &mocks.Client{}
+		temporalClient.On("DescribeWorkflowExecution", mock.Anything, "transfer_workflow_tx-1", "run-1").
+			Return(describe(enums.WORKFLOW_EXECUTION_STATUS_RUNNING), nil)
+		run := newRun()
+
+		svc := &Service{temporalClient: temporalClient}
+
+		var result TransferWorkflowResults
+		err := svc.waitForWorkflowResult(context.Background(), run, 0, &result)
+
+		assert.ErrorIs(t, err, errWorkflowRunning)
+		run.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("no wait fetches the result of a finished workflow", func(t *testing.T) {
+		t.Parallel()
+
+		temporalClient := &mocks.Client{}
+		temporalClient.On("DescribeWorkflowExecution", mock.Anything, "transfer_workflow_tx-1", "run-1").
+			Return(describe(enums.WORKFLOW_EXECUTION_STATUS_COMPLETED), nil)
+		run := newRun()
+		run.On("Get", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*TransferWorkflowResults).Status = "completed"
+		}).Return(nil)
+
+		svc := &Service{temporalClient: temporalClient}
+
+		var result TransferWorkflowResults
+		err := svc.waitForWorkflowResult(context.Background(), run, 0, &result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "completed", result.Status)
+	})
+
+	t.Run("wait gives up when the workflow does not finish in time", func(t *testing.T) {
+		t.Parallel()
+
+		run := newRun()
+		run.On("Get", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).Return(context.DeadlineExceeded)
+
+		svc := &Service{temporalClient: &mocks.Client{}}
+
+		var result TransferWorkflowResults
+		err := svc.waitForWorkflowResult(context.Background(), run, 50*time.Millisecond, &result)
+
+		assert.ErrorIs(t, err, errWorkflowRunning)
+	})
+
+	t.Run("wait returns as soon as the workflow finishes", func(t *testing.T) {
+		t.Parallel()
+
+		run := newRun()
+		run.On("Get", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*TransferWorkflowResults).Status = "completed"
+		}).Return(nil)
+
+		svc := &Service{temporalClient: &mocks.Client{}}
+
+		var result TransferWorkflowResults
+		start := time.Now()
+		err := svc.waitForWorkflowResult(context.Background(), run, 10*time.Second, &result)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "completed", result.Status)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxTransferStatusesIDs caps the transfers of one GetTransferStatuses call
+	maxTransferStatusesIDs = 100
+
+	// transferStatusesConcurrency is how many transfers of one GetTransferStatuses call are looked up at a time
+	transferStatusesConcurrency = 8
+)
+
+type GetTransferStatusesParams struct {
+	TransactionIDs []string `json:"transaction_ids"` // Transaction IDs or transfer references
+}
+
+// TransferStatusesItem is the outcome of looking up one transfer of a GetTransferStatuses call. Either Transfer or
+// Err is set.
+type TransferStatusesItem struct {
+	RequestedID string                    `json:"requested_id"`
+	Transfer    *GetTransferStatusResults `json:"transfer,omitempty"`
+	Err         error                     `json:"-"`
+}
+
+type GetTransferStatusesResults struct {
+	Items []TransferStatusesItem `json:"items"` // In the order of the requested IDs
+}
+
+// GetTransferStatuses looks up several transfers the way GetTransferStatus does, without waiting for any of them to
+// finish. A transfer that cannot be looked up fails its own item only; the call fails for invalid parameters alone.
+func (svc *Service) GetTransferStatuses(ctx context.Context, params *GetTransferStatusesParams) (*GetTransferStatusesResults, error) {
+	const op = "service.Service.GetTransferStatuses"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":      op,
+		"transfers": len(params.TransactionIDs),
+	})
+
+	logger.Info("Getting transfer statuses")
+
+	if err := validateGetTransferStatusesParams(params); err != nil {
+		err = invalidParameters(err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := getTransferStatuses(ctx, params.TransactionIDs, func(ctx context.Context, transactionID string) (*GetTransferStatusResults, error) {
+		return svc.GetTransferStatus(ctx, &GetTransferStatusParams{TransactionID: transactionID})
+	})
+
+	return results, nil
+}
+
+// getTransferStatuses runs lookup for every distinct ID, transferStatusesConcurrency at a time, and reports the
+// outcome of repeated IDs once per occurrence
+func getTransferStatuses(ctx context.Context, transactionIDs []string, lookup func(context.Context, string) (*GetTransferStatusResults, error)) *GetTransferStatusesResults {
+	outcomes := map[string]*TransferStatusesItem{}
+	for _, transactionID := range transactionIDs {
+		outcomes[transactionID] = &TransferStatusesItem{RequestedID: transactionID}
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, transferStatusesConcurrency)
+	for _, item := range outcomes {
+		wg.Add(1)
+		slots <- struct{}{}
+
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			item.Transfer, item.Err = lookup(ctx, item.RequestedID)
+		}()
+	}
+	wg.Wait()
+
+	results := &GetTransferStatusesResults{Items: make([]TransferStatusesItem, 0, len(transactionIDs))}
+	for _, transactionID := range transactionIDs {
+		results.Items = append(results.Items, *outcomes[transactionID])
+	}
+
+	return results
+}
+
+func validateGetTransferStatusesParams(params *GetTransferStatusesParams) error {
+	if len(params.TransactionIDs) == 0 {
+		return newFieldViolation("transaction_ids", "transaction_ids is required")
+	}
+
+	if len(params.TransactionIDs) > maxTransferStatusesIDs {
+		return newFieldViolation("transaction_ids", "transaction_ids cannot have more than %d entries", maxTransferStatusesIDs)
+	}
+
+	for i, transactionID := range params.TransactionIDs {
+		if transactionID == "" {
+			return newFieldViolation(fmt.Sprintf("transaction_ids[%d]", i), "transaction_ids[%d] is empty", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTransferStatuses(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	lookups := map[string]int{}
+	lookup := func(_ context.Context, transactionID string) (*GetTransferStatusResults, error) {
+		mu.Lock()
+		lookups[transactionID]++
+		mu.Unlock()
+
+		if strings.HasPrefix(transactionID, "missing") {
+			return nil, fmt.Errorf("%w: %s", ErrTransferNotFound, transactionID)
+		}
+
+		return &GetTransferStatusResults{TransactionID: transactionID, Status: "TRANSFER_STATUS_COMPLETED"}, nil
+	}
+
+	ids := []string{"tx-1", "missing-1", "tx-2", "tx-1"}
+	for i := range 20 {
+		ids = append(ids, fmt.Sprintf("tx-%d", i+3))
+	}
+
+	results := getTransferStatuses(context.Background(), ids, lookup)
+	require.Len(t, results.Items, len(ids))
+
+	for i, item := range results.Items {
+		assert.Equal(t, ids[i], item.RequestedID)
+	}
+	assert.Equal(t, "tx-1", results.Items[0].Transfer.TransactionID)
+	assert.ErrorIs(t, results.Items[1].Err, ErrTransferNotFound)
+	assert.Nil(t, results.Items[1].Transfer)
+	assert.Equal(t, results.Items[0], results.Items[3])
+	assert.Equal(t, 1, lookups["tx-1"], "repeated IDs are looked up once")
+	assert.Len(t, lookups, len(ids)-1)
+}
+
+func TestValidateGetTransferStatusesParams(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validateGetTransferStatusesParams(&GetTransferStatusesParams{TransactionIDs: []string{"tx-1"}}))
+
+	tooMany := make([]string, maxTransferStatusesIDs+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("tx-%d", i)
+	}
+
+	for name, ids := range map[string][]string{
+		"empty":    nil,
+		"too many": tooMany,
+		"blank id": {"tx-1", ""},
+	} {
+		var violation *FieldViolation
+		assert.ErrorAs(t, validateGetTransferStatusesParams(&GetTransferStatusesParams{TransactionIDs: ids}), &violation, name)
+	}
+}
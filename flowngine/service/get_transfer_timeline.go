@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+)
+
+// ErrTransferNotFound is returned when a transfer has no workflow, e.g. because it ran on the fast path
+var ErrTransferNotFound = errors.New("transfer workflow not found")
+
+// compensationActivities undo an earlier step of the transfer saga
+var compensationActivities = map[string]bool{
+	"CompensateDebit":          true,
+	"RecallExternalSettlement": true,
+}
+
+type GetTransferTimelineParams struct {
+	TransactionID string `json:"transaction_id"` // Transaction ID or transfer reference
+}
+
+type GetTransferTimelineResults struct {
+	TransactionID string          `json:"transaction_id"`
+	WorkflowID    string          `json:"workflow_id"`
+	RunID         string          `json:"run_id"`
+	Events        []TimelineEvent `json:"events"` // Oldest first
+}
+
+// TimelineEvent is one user-facing step of a transfer workflow
+type TimelineEvent struct {
+	EventID      int64     `json:"event_id"`
+	Time         time.Time `json:"time"`
+	Type         string    `json:"type"`     // e.g. "transfer_started", "activity_completed", "activity_retried"
+	Activity     string    `json:"activity"` // Empty for workflow-level events
+	Attempt      int32     `json:"attempt"`
+	Message      string    `json:"message"`
+	Compensation bool      `json:"compensation"`
+}
+
+func (svc *Service) GetTransferTimeline(ctx context.Context, params *GetTransferTimelineParams) (*GetTransferTimelineResults, error) {
+	const op = "service.Service.GetTransferTimeline"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Getting transfer timeline")
+
+	if params.TransactionID == "" {
+		err := invalidParameters(newFieldViolation("transaction_id", "transaction_id is required"))
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Support agents may look a transfer up by the reference printed on its receipt
+	transactionID, _, err := svc.resolveTransferReference(ctx, params.TransactionID)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Check if Temporal client is available
+	if svc.temporalClient == nil {
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn("GetTransferTimeline request received but Temporal client not ready")
+
+		return nil, err
+	}
+
+	workflowID := transferWorkflowID(transactionID)
+
+	events, err := svc.readWorkflowHistory(ctx, workflowID)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &GetTransferTimelineResults{
+		TransactionID: transactionID,
+		WorkflowID:    workflowID,
+		Events:        buildTimeline(events),
+	}
+	if len(events) > 0 {
+		results.RunID = events[0].GetWorkflowExecutionStartedEventAttributes().GetOriginalExecutionRunId()
+	}
+
+	logger.WithField("events", len(results.Events)).Info("Transfer timeline assembled from workflow history")
+
+	return results, nil
+}
+
+// readWorkflowHistory reads the full history of the latest run of a workflow; Temporal keeps every step of the saga.
+// ErrTransferNotFound is returned when the workflow does not exist.
+func (svc *Service) readWorkflowHistory(ctx context.Context, workflowID string) ([]*history.HistoryEvent, error) {
+	iterator := svc.temporalClient.GetWorkflowHistory(ctx, workflowID, "", false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+
+	var events []*history.HistoryEvent
+	for iterator.HasNext() {
+		event, err := iterator.Next()
+		if err != nil {
+			var notFound *serviceerror.NotFound
+			if errors.As(err, &notFound) {
+				return nil, fmt.Errorf("%w: %s (fast path transfers run without a workflow)", ErrTransferNotFound, workflowID)
+			}
+
+			return nil, fmt.Errorf("failed to read workflow history: %w", err)
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// buildTimeline maps Temporal history events to timeline events. Workflow task bookkeeping is left out.
+// Activity retries are not separate history events: the started event of the final attempt carries the
+// attempt number and the last failure, and is reported as "activity_retried".
+func buildTimeline(events []*history.HistoryEvent) []TimelineEvent {
+	timeline := []TimelineEvent{}
+
+	// Completion events only reference the scheduled and started events of their activity
+	activities := map[int64]string{}
+	attempts := map[int64]int32{}
+
+	for _, event := range events {
+		entry := TimelineEvent{
+			EventID: event.GetEventId(),
+			Time:    event.GetEventTime().AsTime(),
+		}
+
+		switch event.GetEventType() {
+		case enums.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED:
+			entry.Type = "transfer_started"
+			entry.Message = "Transfer workflow started"
+
+		case enums.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+			attributes := event.GetActivityTaskScheduledEventAttributes()
+			activities[event.GetEventId()] = attributes.GetActivityType().GetName()
+
+			entry.Type = "activity_scheduled"
+			entry.Activity = attributes.GetActivityType().GetName()
+			entry.Message = fmt.Sprintf("%s scheduled", entry.Activity)
+
+		case enums.EVENT_TYPE_ACTIVITY_TASK_STARTED:
+			attributes := event.GetActivityTaskStartedEventAttributes()
+			attempts[attributes.GetScheduledEventId()] = attributes.GetAttempt()
+
+			entry.Activity = activities[attributes.GetScheduledEventId()]
+			entry.Attempt = attributes.GetAttempt()
+			if entry.Attempt > 1 {
+				entry.Type = "activity_retried"
+				entry.Message = fmt.Sprintf("%s started attempt %d, last failure: %s", entry.Activity, entry.Attempt, attributes.GetLastFailure().GetMessage())
+			} else {
+				entry.Type = "activity_started"
+				entry.Message = fmt.Sprintf("%s started", entry.Activity)
+			}
+
+		case enums.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+			scheduledEventID := event.GetActivityTaskCompletedEventAttributes().GetScheduledEventId()
+
+			entry.Type = "activity_completed"
+			entry.Activity = activities[scheduledEventID]
+			entry.Attempt = attempts[scheduledEventID]
+			entry.Message = fmt.Sprintf("%s completed", entry.Activity)
+
+		case enums.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+			attributes := event.GetActivityTaskFailedEventAttributes()
+
+			entry.Type = "activity_failed"
+			entry.Activity = activities[attributes.GetScheduledEventId()]
+			entry.Attempt = attempts[attributes.GetScheduledEventId()]
+			entry.Message = fmt.Sprintf("%s failed: %s", entry.Activity, attributes.GetFailure().GetMessage())
+
+		case enums.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+			scheduledEventID := event.GetActivityTaskTimedOutEventAttributes().GetScheduledEventId()
+
+			entry.Type = "activity_timed_out"
+			entry.Activity = activities[scheduledEventID]
+			entry.Attempt = attempts[scheduledEventID]
+			entry.Message = fmt.Sprintf("%s timed out", entry.Activity)
+
+		case enums.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+			scheduledEventID := event.GetActivityTaskCanceledEventAttributes().GetScheduledEventId()
+
+			entry.Type = "activity_cancelled"
+			entry.Activity = activities[scheduledEventID]
+			entry.Attempt = attempts[scheduledEventID]
+			entry.Message = fmt.Sprintf("%s cancelled", entry.Activity)
+
+		case enums.EVENT_TYPE_WORKFLOW_EXECUTION_CANCEL_REQUESTED:
+			entry.Type = "cancel_requested"
+			entry.Message = "Cancellation requested"
+
+		case enums.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:
+			entry.Type = "transfer_completed"
+			entry.Message = "Transfer workflow completed"
+
+		case enums.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+			entry.Type = "transfer_failed"
+			entry.Message = fmt.Sprintf("Transfer workflow failed: %s", event.GetWorkflowExecutionFailedEventAttributes().GetFailure().GetMessage())
+
+		case enums.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:
+			entry.Type = "transfer_timed_out"
+			entry.Message = "Transfer workflow timed out"
+
+		case enums.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED:
+			entry.Type = "transfer_cancelled"
+			entry.Message = "Transfer workflow cancelled"
+
+		case enums.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:
+			entry.Type = "transfer_terminated"
+			entry.Message = fmt.Sprintf("Transfer workflow terminated: %s", event.GetWorkflowExecutionTerminatedEventAttributes().GetReason())
+
+		default:
+			continue
+		}
+
+		entry.Compensation = compensationActivities[entry.Activity]
+
+		timeline = append(timeline, entry)
+	}
+
+	return timeline
+}
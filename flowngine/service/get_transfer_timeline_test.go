@@ -0,0 +1,107 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/failure/v1"
+	"go.temporal.io/api/history/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBuildTimeline(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+	event := func(id int64, eventType enums.EventType) *history.HistoryEvent {
+		return &history.HistoryEvent{
+			EventId:   id,
+			EventTime: timestamppb.New(start.Add(time.Duration(id) * time.Second)),
+			EventType: eventType,
+		}
+	}
+	scheduled := func(id int64, activity string) *history.HistoryEvent {
+		e := event(id, enums.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED)
+		e.Attributes = &history.HistoryEvent_ActivityTaskScheduledEventAttributes{
+			ActivityTaskScheduledEventAttributes: &history.ActivityTaskScheduledEventAttributes{ActivityType: &common.ActivityType{Name: activity}},
+		}
+		return e
+	}
+	started := func(id, scheduledID int64, attempt int32, lastFailure string) *history.HistoryEvent {
+		e := event(id, enums.EVENT_TYPE_ACTIVITY_TASK_STARTED)
+		attributes := &history.ActivityTaskStartedEventAttributes{ScheduledEventId: scheduledID, Attempt: attempt}
+		if lastFailure != "" {
+			attributes.LastFailure = &failure.Failure{Message: lastFailure}
+		}
+		e.Attributes = &history.HistoryEvent_ActivityTaskStartedEventAttributes{ActivityTaskStartedEventAttributes: attributes}
+		return e
+	}
+	completed := func(id, scheduledID int64) *history.HistoryEvent {
+		e := event(id, enums.EVENT_TYPE_ACTIVITY_TASK_COMPLETED)
+		e.Attributes = &history.HistoryEvent_ActivityTaskCompletedEventAttributes{
+			ActivityTaskCompletedEventAttributes: &history.ActivityTaskCompletedEventAttributes{ScheduledEventId: scheduledID},
+		}
+		return e
+	}
+	failed := func(id, scheduledID int64, message string) *history.HistoryEvent {
+		e := event(id, enums.EVENT_TYPE_ACTIVITY_TASK_FAILED)
+		e.Attributes = &history.HistoryEvent_ActivityTaskFailedEventAttributes{
+			ActivityTaskFailedEventAttributes: &history.ActivityTaskFailedEventAttributes{ScheduledEventId: scheduledID, Failure: &failure.Failure{Message: message}},
+		}
+		return e
+	}
+
+	// Debit succeeds on its second attempt, credit fails and the debit is compensated
+	timeline := buildTimeline([]*history.HistoryEvent{
+		event(1, enums.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED),
+		event(2, enums.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED),
+		scheduled(5, "DebitAccount"),
+		started(6, 5, 2, "connection refused"),
+		completed(7, 5),
+		scheduled(10, "CreditAccount"),
+		started(11, 10, 1, ""),
+		failed(12, 10, "account closed"),
+		scheduled(15, "CompensateDebit"),
+		started(16, 15, 1, ""),
+		completed(17, 15),
+		event(20, enums.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED),
+	})
+
+	types := make([]string, 0, len(timeline))
+	for _, entry := range timeline {
+		types = append(types, entry.Type)
+	}
+	assert.Equal(t, []string{
+		"transfer_started",
+		"activity_scheduled", "activity_retried", "activity_completed",
+		"activity_scheduled", "activity_started", "activity_failed",
+		"activity_scheduled", "activity_started", "activity_completed",
+		"transfer_failed",
+	}, types)
+
+	require.Len(t, timeline, 11)
+
+	retried := timeline[2]
+	assert.Equal(t, "DebitAccount", retried.Activity)
+	assert.Equal(t, int32(2), retried.Attempt)
+	assert.Equal(t, "DebitAccount started attempt 2, last failure: connection refused", retried.Message)
+	assert.Equal(t, start.Add(6*time.Second), retried.Time)
+
+	assert.Equal(t, int32(2), timeline[3].Attempt, "completion carries the attempt of its started event")
+	assert.Equal(t, "CreditAccount failed: account closed", timeline[6].Message)
+
+	for i, entry := range timeline {
+		assert.Equal(t, entry.Activity == "CompensateDebit", entry.Compensation, "entry %d", i)
+	}
+}
+
+func TestBuildTimelineEmptyHistory(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, buildTimeline(nil))
+	assert.NotNil(t, buildTimeline(nil), "an empty timeline is serialized as [] rather than null")
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"flowngine/util/config"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// recordTransferApprovalActivity stores an approval of a transfer in core.transfer_approvals of svc-transaction
+const recordTransferApprovalActivity = "RecordTransferApproval"
+
+// jointAccountApprovals is the number of distinct owners that approve a large debit from a joint account
+const jointAccountApprovals = 2
+
+// TransferApproval is an approval accepted by a transfer workflow held for approval
+type TransferApproval struct {
+	ApprovedBy string    `json:"approved_by"`
+	ApprovedAt time.Time `json:"approved_at"` // Workflow time the approval was accepted
+}
+
+// requiresJointApproval decides whether a debit from an account with the given number of owners needs the
+// approval of two of them
+func requiresJointApproval(approval config.TransferApproval, owners int, amountMinorUnits int64) bool {
+	return approval.JointThresholdAmount > 0 && owners >= jointAccountApprovals && amountMinorUnits >= approval.JointThresholdAmount
+}
+
+// jointAccountApprovers returns the owners of the source account that may approve the transfer when the source
+// is a joint account and the amount needs dual authorization, and nil otherwise. The owners are only looked up
+// from the joint threshold on, so dual authorization costs small transfers nothing.
+func (svc *Service) jointAccountApprovers(ctx context.Context, fromAccount string, amountMinorUnits int64) ([]string, error) {
+	const op = "service.Service.jointAccountApprovers"
+
+	approval := svc.config.TransferApproval
+	if approval.JointThresholdAmount <= 0 || amountMinorUnits < approval.JointThresholdAmount {
+		return nil, nil
+	}
+
+	owners, err := svc.transactionAdapter.ListAccountOwners(ctx, fromAccount)
+	if err != nil {
+		// Releasing the transfer on a single approval could bypass dual authorization, so it is not started
+		svc.logger.WithFields(logrus.Fields{
+			"[op]":         op,
+			"from_account": fromAccount,
+		}).WithError(err).Error()
+
+		return nil, fmt.Errorf("%w: %w", ErrAccountOwnersUnavailable, err)
+	}
+
+	if !requiresJointApproval(approval, len(owners), amountMinorUnits) {
+		return nil, nil
+	}
+
+	approvers := make([]string, 0, len(owners))
+	for _, owner := range owners {
+		approvers = append(approvers, owner.OwnerID)
+	}
+
+	return approvers, nil
+}
+
+// acceptTransferApproval decides whether an approval signal counts towards the approvals a transfer needs.
+// Repeated approvals of the same approver count once, and transfers from joint accounts only count approvals
+// of the owners of the account.
+func acceptTransferApproval(params TransferWorkflowParams, approvals []TransferApproval, approvedBy string) (bool, string) {
+	for _, approval := range approvals {
+		if approval.ApprovedBy == approvedBy {
+			return false, "already approved by this approver"
+		}
+	}
+
+	if len(params.Approvers) == 0 {
+		return true, ""
+	}
+
+	for _, approver := range params.Approvers {
+		if approver == approvedBy {
+			return true, ""
+		}
+	}
+
+	return false, "approver is not an owner of the source account"
+}
+
+// recordTransferApproval stores an accepted approval in svc-transaction. The approval has already been accepted
+// by the workflow, so a failure to record it is logged only.
+func recordTransferApproval(ctx workflow.Context, params TransferWorkflowParams, approval TransferApproval) {
+	logger := workflow.GetLogger(ctx)
+	workflowInfo := workflow.GetInfo(ctx)
+
+	// svc-transaction recovers quickly or not at all, so recording is attempted a few times and then given up
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:        time.Second,
+			BackoffCoefficient:     2,
+			MaximumAttempts:        5,
+			NonRetryableErrorTypes: []string{"INVALID_TRANSFER_APPROVAL"},
+		},
+	})
+
+	approvalParams := map[string]interface{}{
+		"transfer_id": params.TransferID,
+		"workflow_id": workflowInfo.WorkflowExecution.ID,
+		"approved_by": approval.ApprovedBy,
+		"approved_at": approval.ApprovedAt,
+	}
+
+	if err := workflow.ExecuteActivity(ctx, recordTransferApprovalActivity, approvalParams).Get(ctx, nil); err != nil {
+		logger.Warn("Failed to record transfer approval", "transfer_id", params.TransferID, "approved_by", approval.ApprovedBy, "error", err)
+	}
+}
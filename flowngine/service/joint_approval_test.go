@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"flowngine/adapter/transaction_adapter"
+	"flowngine/util/config"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJointAccountApprovers(t *testing.T) {
+	t.Parallel()
+
+	newService := func(t *testing.T, handler http.HandlerFunc) *Service {
+		server := httptest.NewServer(handler)
+		t.Cleanup(server.Close)
+
+		logger := logrus.New()
+		logger.SetLevel(logrus.ErrorLevel)
+
+		return &Service{
+			logger:             logger,
+			config:             config.Config{TransferApproval: config.TransferApproval{JointThresholdAmount: 100000}},
+			transactionAdapter: transaction_adapter.NewAdapter("svc-transaction", logger, server.URL, time.Second),
+		}
+	}
+
+	owners := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/accounts/acc-1/owners", r.URL.Path)
+
+			_, _ = w.Write([]byte(body))
+		}
+	}
+
+	t.Run("joint_account_above_threshold", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newService(t, owners(`{"message":"ok","data":[{"account_id":"acc-1","owner_id":"owner-1"},{"account_id":"acc-1","owner_id":"owner-2"}]}`))
+
+		approvers, err := svc.jointAccountApprovers(context.Background(), "acc-1", 100000)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"owner-1", "owner-2"}, approvers)
+	})
+
+	t.Run("below_threshold_skips_lookup", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newService(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		})
+
+		approvers, err := svc.jointAccountApprovers(context.Background(), "acc-1", 99999)
+		require.NoError(t, err)
+		assert.Nil(t, approvers)
+	})
+
+	t.Run("single_owner", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newService(t, owners(`{"message":"ok","data":[{"account_id":"acc-1","owner_id":"owner-1"}]}`))
+
+		approvers, err := svc.jointAccountApprovers(context.Background(), "acc-1", 500000)
+		require.NoError(t, err)
+		assert.Nil(t, approvers)
+	})
+
+	t.Run("lookup_failure_rejects_transfer", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newService(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"Failed to retrieve account owners"}`))
+		})
+
+		_, err := svc.jointAccountApprovers(context.Background(), "acc-1", 500000)
+		assert.ErrorIs(t, err, ErrAccountOwnersUnavailable)
+	})
+}
+
+func TestAcceptTransferApproval(t *testing.T) {
+	t.Parallel()
+
+	joint := TransferWorkflowParams{Approvers: []string{"owner-1", "owner-2"}}
+	approved := []TransferApproval{{ApprovedBy: "owner-1"}}
+
+	accepted, _ := acceptTransferApproval(joint, approved, "owner-2")
+	assert.True(t, accepted)
+
+	accepted, reason := acceptTransferApproval(joint, approved, "owner-1")
+	assert.False(t, accepted)
+	assert.Equal(t, "already approved by this approver", reason)
+
+	accepted, reason = acceptTransferApproval(joint, nil, "teller-1")
+	assert.False(t, accepted)
+	assert.Equal(t, "approver is not an owner of the source account", reason)
+
+	accepted, _ = acceptTransferApproval(TransferWorkflowParams{}, nil, "teller-1")
+	assert.True(t, accepted, "transfers that are not joint accept any approver")
+}
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/converter"
+)
+
+// maxAccountWorkflows caps how many in-flight transfers ListAccountWorkflows reads for one account
+const maxAccountWorkflows = 1000
+
+// ErrInvalidAccountID is returned when an account ID cannot be used in a visibility query
+var ErrInvalidAccountID = errors.New("invalid account id")
+
+// Directions of an in-flight transfer relative to the queried account
+const (
+	TransferDirectionOutgoing = "outgoing"
+	TransferDirectionIncoming = "incoming"
+)
+
+type ListAccountWorkflowsParams struct {
+	AccountID     string `json:"account_id"`
+	AccountNumber string `json:"account_number"` // Optional, transfers may have been started with either
+}
+
+type ListAccountWorkflowsResults struct {
+	AccountID string             `json:"account_id"`
+	Transfers []InFlightTransfer `json:"transfers"`
+	Pending   []PendingAmount    `json:"pending"`   // One entry per currency, sorted by currency
+	Truncated bool               `json:"truncated"` // More than maxAccountWorkflows transfers are running
+}
+
+// InFlightTransfer is a running transfer workflow that debits or credits the queried account
+type InFlightTransfer struct {
+	WorkflowID    string          `json:"workflow_id"`
+	RunID         string          `json:"run_id"`
+	TransactionID string          `json:"transaction_id"`
+	Direction     string          `json:"direction"`
+	FromAccount   string          `json:"from_account"`
+	ToAccount     string          `json:"to_account"`
+	Amount        decimal.Decimal `json:"amount"`
+	Currency      string          `json:"currency"`
+	StartedAt     time.Time       `json:"started_at"`
+}
+
+// PendingAmount sums the in-flight transfers of one currency
+type PendingAmount struct {
+	Currency string          `json:"currency"`
+	Outgoing decimal.Decimal `json:"outgoing"`
+	Incoming decimal.Decimal `json:"incoming"`
+}
+
+// ListAccountWorkflows lists the running transfer workflows where the account is the source or the destination,
+// found through the TransferSourceAccount and TransferDestinationAccount search attributes.
+func (svc *Service) ListAccountWorkflows(ctx context.Context, params *ListAccountWorkflowsParams) (*ListAccountWorkflowsResults, error) {
+	const op = "service.Service.ListAccountWorkflows"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	query, err := accountWorkflowsQuery(params.AccountID, params.AccountNumber)
+	if err != nil {
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	if svc.temporalClient == nil {
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	results := &ListAccountWorkflowsResults{
+		AccountID: params.AccountID,
+		Transfers: []InFlightTransfer{},
+		Pending:   []PendingAmount{},
+	}
+
+	var nextPageToken []byte
+	for {
+		response, err := svc.temporalClient.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:         query,
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to list workflows: %w", err)
+
+			logger.WithError(err).Error()
+
+			return nil, err
+		}
+
+		for _, execution := range response.Executions {
+			if len(results.Transfers) == maxAccountWorkflows {
+				results.Truncated = true
+				break
+			}
+
+			transfer, err := newInFlightTransfer(params, execution.GetExecution(), execution.GetMemo(), execution.GetStartTime().AsTime())
+			if err != nil {
+				// Workflows started before the memo existed cannot be summed; they are skipped rather than guessed
+				logger.WithError(err).WithField("workflow_id", execution.GetExecution().GetWorkflowId()).Warn("Skipping transfer without a readable memo")
+
+				continue
+			}
+
+			results.Transfers = append(results.Transfers, transfer)
+		}
+
+		nextPageToken = response.NextPageToken
+		if len(nextPageToken) == 0 || results.Truncated {
+			break
+		}
+	}
+
+	results.Pending = sumPendingAmounts(results.Transfers)
+
+	logger.WithFields(logrus.Fields{
+		"transfers": len(results.Transfers),
+		"truncated": results.Truncated,
+	}).Info()
+
+	return results, nil
+}
+
+// accountWorkflowsQuery builds the visibility query for running transfers of an account, matching its ID and, when
+// given, its account number. Both are interpolated into the query, so quotes and backslashes are rejected.
+func accountWorkflowsQuery(accountID, accountNumber string) (string, error) {
+	if accountID == "" {
+		return "", fmt.Errorf("%w: account_id is required", ErrInvalidAccountID)
+	}
+
+	accounts := []string{accountID}
+	if accountNumber != "" && accountNumber != accountID {
+		accounts = append(accounts, accountNumber)
+	}
+
+	quoted := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		if strings.ContainsAny(account, `'"\`) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidAccountID, account)
+		}
+		quoted = append(quoted, "'"+account+"'")
+	}
+	in := strings.Join(quoted, ", ")
+
+	return fmt.Sprintf("%s AND ExecutionStatus = 'Running' AND (%s IN (%s) OR %s IN (%s))",
+		transferWorkflowTypes,
+		sourceAccountSearchAttribute.GetName(), in,
+		destinationAccountSearchAttribute.GetName(), in,
+	), nil
+}
+
+func newInFlightTransfer(params *ListAccountWorkflowsParams, execution *commonpb.WorkflowExecution, memo *commonpb.Memo, startedAt time.Time) (InFlightTransfer, error) {
+	fields := map[string]string{}
+	for _, key := range []string{memoFromAccount, memoToAccount, memoAmount, memoCurrency} {
+		payload, ok := memo.GetFields()[key]
+		if !ok {
+			return InFlightTransfer{}, fmt.Errorf("memo has no %s", key)
+		}
+
+		var value string
+		if err := converter.GetDefaultDataConverter().FromPayload(payload, &value); err != nil {
+			return InFlightTransfer{}, fmt.Errorf("failed to decode memo %s: %w", key, err)
+		}
+		fields[key] = value
+	}
+
+	amount, err := decimal.NewFromString(fields[memoAmount])
+	if err != nil {
+		return InFlightTransfer{}, fmt.Errorf("failed to parse memo amount: %w", err)
+	}
+
+	transfer := InFlightTransfer{
+		WorkflowID:    execution.GetWorkflowId(),
+		RunID:         execution.GetRunId(),
+		TransactionID: transferTransactionIDOf(execution.GetWorkflowId()),
+		Direction:     TransferDirectionIncoming,
+		FromAccount:   fields[memoFromAccount],
+		ToAccount:     fields[memoToAccount],
+		Amount:        amount,
+		Currency:      fields[memoCurrency],
+		StartedAt:     startedAt,
+	}
+	if transfer.FromAccount == params.AccountID || (params.AccountNumber != "" && transfer.FromAccount == params.AccountNumber) {
+		transfer.Direction = TransferDirectionOutgoing
+	}
+
+	return transfer, nil
+}
+
+func sumPendingAmounts(transfers []InFlightTransfer) []PendingAmount {
+	byCurrency := map[string]*PendingAmount{}
+	for _, transfer := range transfers {
+		pending, ok := byCurrency[transfer.Currency]
+		if !ok {
+			pending = &PendingAmount{Currency: transfer.Currency}
+			byCurrency[transfer.Currency] = pending
+		}
+
+		if transfer.Direction == TransferDirectionOutgoing {
+			pending.Outgoing = pending.Outgoing.Add(transfer.Amount)
+		} else {
+			pending.Incoming = pending.Incoming.Add(transfer.Amount)
+		}
+	}
+
+	result := make([]PendingAmount, 0, len(byCurrency))
+	for _, pending := range byCurrency {
+		result = append(result, *pending)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Currency < result[j].Currency })
+
+	return result
+}
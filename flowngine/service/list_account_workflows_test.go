@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/mocks"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAccountWorkflowsQuery(t *testing.T) {
+	t.Parallel()
+
+	query, err := accountWorkflowsQuery("acc-1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "WorkflowType IN ('transferWorkflow', 'conditionalTransferWorkflow') AND ExecutionStatus = 'Running' AND (TransferSourceAccount IN ('acc-1') OR TransferDestinationAccount IN ('acc-1'))", query)
+
+	query, err = accountWorkflowsQuery("acc-1", "ACC000000001")
+	require.NoError(t, err)
+	assert.Equal(t, "WorkflowType IN ('transferWorkflow', 'conditionalTransferWorkflow') AND ExecutionStatus = 'Running' AND (TransferSourceAccount IN ('acc-1', 'ACC000000001') OR TransferDestinationAccount IN ('acc-1', 'ACC000000001'))", query)
+
+	for _, accountID := range []string{"", "acc' OR '1'='1", `acc\`} {
+		_, err := accountWorkflowsQuery(accountID, "")
+		assert.ErrorIs(t, err, ErrInvalidAccountID, accountID)
+	}
+	_, err = accountWorkflowsQuery("acc-1", "ACC' OR '1'='1")
+	assert.ErrorIs(t, err, ErrInvalidAccountID)
+}
+
+func TestListAccountWorkflows(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	execution := func(transactionID string, params TransferWorkflowParams) *workflow.WorkflowExecutionInfo {
+		fields := map[string]*commonpb.Payload{}
+		for key, value := range transferMemo(params) {
+			payload, err := converter.GetDefaultDataConverter().ToPayload(value)
+			require.NoError(t, err)
+			fields[key] = payload
+		}
+
+		return &workflow.WorkflowExecutionInfo{
+			Execution: &commonpb.WorkflowExecution{WorkflowId: "transfer_workflow_" + transactionID, RunId: "run-" + transactionID},
+			StartTime: timestamppb.New(startedAt),
+			Memo:      &commonpb.Memo{Fields: fields},
+		}
+	}
+
+	temporalClient := &mocks.Client{}
+	temporalClient.On("ListWorkflow", mock.Anything, mock.MatchedBy(func(request *workflowservice.ListWorkflowExecutionsRequest) bool {
+		return len(request.NextPageToken) == 0
+	})).Return(&workflowservice.ListWorkflowExecutionsResponse{
+		Executions: []*workflow.WorkflowExecutionInfo{
+			execution("tx-1", TransferWorkflowParams{FromAccount: "acc-1", ToAccount: "acc-2", Amount: decimal.RequireFromString("100.50"), Currency: "USD"}),
+			execution("tx-2", TransferWorkflowParams{FromAccount: "acc-3", ToAccount: "acc-1", Amount: decimal.RequireFromString("20"), Currency: "USD"}),
+			// Started through the gateway, which passes account numbers
+			execution("tx-5", TransferWorkflowParams{FromAccount: "ACC000000001", ToAccount: "acc-3", Amount: decimal.RequireFromString("9.50"), Currency: "USD"}),
+		},
+		NextPageToken: []byte("page-2"),
+	}, nil)
+	temporalClient.On("ListWorkflow", mock.Anything, mock.MatchedBy(func(request *workflowservice.ListWorkflowExecutionsRequest) bool {
+		return string(request.NextPageToken) == "page-2"
+	})).Return(&workflowservice.ListWorkflowExecutionsResponse{
+		Executions: []*workflow.WorkflowExecutionInfo{
+			execution("tx-3", TransferWorkflowParams{FromAccount: "acc-1", ToAccount: "acc-4", Amount: decimal.RequireFromString("5000"), Currency: "JPY"}),
+			// Started before transfers carried a memo
+			{Execution: &commonpb.WorkflowExecution{WorkflowId: "transfer_workflow_tx-4"}},
+		},
+	}, nil)
+
+	svc := &Service{logger: logrus.New(), temporalClient: temporalClient}
+
+	results, err := svc.ListAccountWorkflows(context.Background(), &ListAccountWorkflowsParams{AccountID: "acc-1", AccountNumber: "ACC000000001"})
+	require.NoError(t, err)
+
+	require.Len(t, results.Transfers, 4)
+	assert.Equal(t, "tx-1", results.Transfers[0].TransactionID)
+	assert.Equal(t, TransferDirectionOutgoing, results.Transfers[0].Direction)
+	assert.Equal(t, TransferDirectionIncoming, results.Transfers[1].Direction)
+	assert.Equal(t, TransferDirectionOutgoing, results.Transfers[2].Direction)
+	assert.Equal(t, startedAt, results.Transfers[0].StartedAt)
+	assert.False(t, results.Truncated)
+
+	require.Len(t, results.Pending, 2)
+	assert.Equal(t, "JPY", results.Pending[0].Currency)
+	assert.Equal(t, "5000", results.Pending[0].Outgoing.String())
+	assert.Equal(t, "USD", results.Pending[1].Currency)
+	assert.Equal(t, "110", results.Pending[1].Outgoing.String())
+	assert.Equal(t, "20", results.Pending[1].Incoming.String())
+}
@@ -0,0 +1,616 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"flowngine/util/failure"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// RespondToMoneyRequestSignalName is the signal with which the payer accepts or declines a money request
+const RespondToMoneyRequestSignalName = "respond_to_money_request"
+
+// Statuses of a money request, kept in the MoneyRequestStatus search attribute of its workflow
+const (
+	MoneyRequestStatusPending  = "PENDING"
+	MoneyRequestStatusAccepted = "ACCEPTED" // The transfer workflow of the request was started
+	MoneyRequestStatusDeclined = "DECLINED"
+	MoneyRequestStatusExpired  = "EXPIRED" // Declined because the payer did not respond in time
+)
+
+// Roles of the queried account in ListMoneyRequests
+const (
+	MoneyRequestRolePayer = "payer"
+	MoneyRequestRolePayee = "payee"
+)
+
+// defaultMoneyRequestExpiry applies when no money_request.expiry_seconds is configured, so that no request
+// waits forever
+const defaultMoneyRequestExpiry = 24 * time.Hour
+
+// maxMoneyRequests caps how many money requests ListMoneyRequests reads for one account
+const maxMoneyRequests = 1000
+
+// moneyRequestWorkflowIDPrefix tells money request workflows apart from the other workflows of the namespace
+const moneyRequestWorkflowIDPrefix = "money_request_workflow_"
+
+// moneyRequestStatusSearchAttribute is upserted by a money request workflow whenever its status changes
+var moneyRequestStatusSearchAttribute = temporal.NewSearchAttributeKeyKeyword("MoneyRequestStatus")
+
+// Memo keys of money request workflows on top of the transfer memo keys
+const (
+	memoDescription = "description"
+	memoExpiresAt   = "expires_at"
+)
+
+// ErrMoneyRequestNotFound is returned for money requests that do not exist or were already answered or expired
+var ErrMoneyRequestNotFound = errors.New("money request not found")
+
+// ErrMoneyRequestAlreadyExists is returned for a retried RequestMoney whose money request was already answered
+var ErrMoneyRequestAlreadyExists = errors.New("money request already exists")
+
+// RequestMoneyParams asks PayerAccount to transfer the amount to PayeeAccount. The transfer runs only once the
+// payer accepts the request.
+type RequestMoneyParams struct {
+	PayeeAccount  string `json:"payee_account"`
+	PayerAccount  string `json:"payer_account"`
+	Amount        int64  `json:"amount"`         // Minor units of the currency (cents, yen); set either Amount or AmountDecimal
+	AmountDecimal string `json:"amount_decimal"` // Exact major-unit decimal, e.g. "100.50"
+	Currency      string `json:"currency"`
+	Description   string `json:"description"`
+	RequestID     string `json:"request_id"`
+}
+
+type RequestMoneyResults struct {
+	MoneyRequestID string `json:"money_request_id"` // Also the transaction ID of the transfer once the request is accepted
+	WorkflowID     string `json:"workflow_id"`
+	RunID          string `json:"run_id"`
+	Status         string `json:"status"`
+	CreatedAt      string `json:"created_at"`
+	ExpiresAt      string `json:"expires_at"`
+}
+
+type RespondToMoneyRequestParams struct {
+	MoneyRequestID string `json:"money_request_id"`
+	Accept         bool   `json:"accept"`
+	Reason         string `json:"reason"` // Optional, given to the payee of a declined request
+}
+
+type RespondToMoneyRequestResults struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type ListMoneyRequestsParams struct {
+	Account string `json:"account"`
+	Role    string `json:"role"`   // payer (default) lists the requests to pay, payee the requests sent
+	Status  string `json:"status"` // Optional filter, e.g. PENDING
+}
+
+type ListMoneyRequestsResults struct {
+	Account       string         `json:"account"`
+	Role          string         `json:"role"`
+	MoneyRequests []MoneyRequest `json:"money_requests"` // Newest first
+	Truncated     bool           `json:"truncated"`      // More than maxMoneyRequests requests match
+}
+
+// MoneyRequest is a money request as listed by ListMoneyRequests, read from the memo and search attributes of its
+// workflow
+type MoneyRequest struct {
+	MoneyRequestID string          `json:"money_request_id"`
+	WorkflowID     string          `json:"workflow_id"`
+	PayerAccount   string          `json:"payer_account"`
+	PayeeAccount   string          `json:"payee_account"`
+	Amount         decimal.Decimal `json:"amount"`
+	Currency       string          `json:"currency"`
+	Description    string          `json:"description"`
+	Status         string          `json:"status"`
+	CreatedAt      time.Time       `json:"created_at"`
+	ExpiresAt      time.Time       `json:"expires_at"`
+}
+
+// MoneyRequestWorkflowParams defines the input parameters for the money request workflow. Transfer is the saga run
+// once the payer accepts; its TransferID is the ID of the money request.
+type MoneyRequestWorkflowParams struct {
+	Transfer      TransferWorkflowParams `json:"transfer"`
+	ExpirySeconds int                    `json:"expiry_seconds"`
+}
+
+// MoneyRequestWorkflowResults is the outcome of a money request workflow
+type MoneyRequestWorkflowResults struct {
+	MoneyRequestID string     `json:"money_request_id"`
+	Status         string     `json:"status"`
+	TransactionID  string     `json:"transaction_id,omitempty"` // Transfer started by an accepted request
+	Reason         string     `json:"reason,omitempty"`
+	RespondedAt    *time.Time `json:"responded_at,omitempty"`
+}
+
+// MoneyRequestResponseSignal is the payload of RespondToMoneyRequestSignalName
+type MoneyRequestResponseSignal struct {
+	Accept bool   `json:"accept"`
+	Reason string `json:"reason"`
+}
+
+// moneyRequestWorkflowID is the ID of the workflow of a money request
+func moneyRequestWorkflowID(moneyRequestID string) string {
+	return moneyRequestWorkflowIDPrefix + moneyRequestID
+}
+
+// RequestMoney starts the workflow of a money request, which waits for the payer to accept or decline it. The
+// money request ID is derived from the request ID like a transaction ID, so a retried request finds the money
+// request it created and an accepted request transfers under that same ID.
+func (svc *Service) RequestMoney(ctx context.Context, params *RequestMoneyParams) (*RequestMoneyResults, error) {
+	const op = "service.Service.RequestMoney"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Requesting money")
+
+	transfer, err := validateRequestMoneyParams(params)
+	if err != nil {
+		err = invalidParameters(err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	amount, err := resolveTransferAmount(transfer)
+	if err != nil {
+		err = invalidParameters(err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	if svc.temporalClient == nil {
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn("RequestMoney request received but Temporal client not ready")
+
+		return nil, err
+	}
+
+	// Owners of a joint payer account approve the transfer once accepted, as for any other transfer from it
+	approvers, err := svc.jointAccountApprovers(ctx, params.PayerAccount, amount.MinorUnits)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	moneyRequestID := transferTransactionID(params.RequestID)
+	workflowID := moneyRequestWorkflowID(moneyRequestID)
+
+	expiry := defaultMoneyRequestExpiry
+	if svc.config.MoneyRequest.ExpirySeconds > 0 {
+		expiry = time.Duration(svc.config.MoneyRequest.ExpirySeconds) * time.Second
+	}
+
+	workflowParams := MoneyRequestWorkflowParams{
+		Transfer:      svc.newTransferWorkflowParams(transfer, moneyRequestID, amount, approvers),
+		ExpirySeconds: int(expiry / time.Second),
+	}
+
+	createdAt := time.Now()
+	expiresAt := createdAt.Add(expiry)
+
+	memo := transferMemo(workflowParams.Transfer)
+	memo[memoDescription] = params.Description
+	memo[memoExpiresAt] = expiresAt.Format(time.RFC3339)
+
+	// The workflow only waits for the response; the transfer it starts has its own timeout
+	workflowOptions := client.StartWorkflowOptions{
+		ID:                       workflowID,
+		TaskQueue:                svc.transferTaskQueue(workflowID, ""),
+		WorkflowIDReusePolicy:    enums.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE,
+		WorkflowIDConflictPolicy: enums.WORKFLOW_ID_CONFLICT_POLICY_USE_EXISTING, // A retried request returns the running one
+		WorkflowExecutionTimeout: expiry + time.Minute*10,
+		// Lets ListMoneyRequests find the request by payer or payee
+		TypedSearchAttributes: temporal.NewSearchAttributes(
+			sourceAccountSearchAttribute.ValueSet(params.PayerAccount),
+			destinationAccountSearchAttribute.ValueSet(params.PayeeAccount),
+			moneyRequestStatusSearchAttribute.ValueSet(MoneyRequestStatusPending),
+		),
+		Memo:       memo,
+		StartDelay: svc.simulatedStartDelay(params.PayerAccount),
+	}
+
+	if err := svc.simulateWorkflowStartFailure(ctx, params.PayerAccount); err != nil {
+		err = fmt.Errorf("failed to start workflow: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	workflowRun, err := svc.temporalClient.ExecuteWorkflow(ctx, workflowOptions, requestMoneyWorkflow, workflowParams)
+	if err != nil {
+		var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+		if errors.As(err, &alreadyStarted) {
+			err = fmt.Errorf("%w: %s was already answered", ErrMoneyRequestAlreadyExists, moneyRequestID)
+		} else {
+			err = fmt.Errorf("failed to start workflow: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &RequestMoneyResults{
+		MoneyRequestID: moneyRequestID,
+		WorkflowID:     workflowID,
+		RunID:          workflowRun.GetRunID(),
+		Status:         MoneyRequestStatusPending,
+		CreatedAt:      createdAt.Format(time.RFC3339),
+		ExpiresAt:      expiresAt.Format(time.RFC3339),
+	}
+
+	logger.WithFields(logrus.Fields{
+		"money_request_id": moneyRequestID,
+		"workflow_id":      workflowID,
+		"run_id":           results.RunID,
+	}).Info("Money request started")
+
+	return results, nil
+}
+
+// RespondToMoneyRequest signals the payer's answer to a pending money request. Requests that were already
+// answered or expired are reported as not found.
+func (svc *Service) RespondToMoneyRequest(ctx context.Context, params *RespondToMoneyRequestParams) (*RespondToMoneyRequestResults, error) {
+	const op = "service.Service.RespondToMoneyRequest"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Responding to money request")
+
+	if params.MoneyRequestID == "" {
+		err := invalidParameters(newFieldViolation("money_request_id", "money_request_id is required"))
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	if svc.temporalClient == nil {
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn("RespondToMoneyRequest request received but Temporal client not ready")
+
+		return nil, err
+	}
+
+	workflowID := moneyRequestWorkflowID(params.MoneyRequestID)
+
+	err := svc.simulateSignalFailure(ctx)
+	if err == nil {
+		err = svc.temporalClient.SignalWorkflow(ctx, workflowID, "", RespondToMoneyRequestSignalName, MoneyRequestResponseSignal{
+			Accept: params.Accept,
+			Reason: params.Reason,
+		})
+	}
+	if errors.Is(err, failure.ErrDropped) {
+		// The payer is told the request was answered, so it expires awaiting the response
+		logger.WithField("workflow_id", workflowID).Warn("Money request response signal dropped by failure simulation")
+	} else if err != nil {
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			err = fmt.Errorf("%w: %s is not pending", ErrMoneyRequestNotFound, params.MoneyRequestID)
+		} else {
+			err = fmt.Errorf("failed to signal workflow: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	response := "declined"
+	if params.Accept {
+		response = "accepted"
+	}
+
+	results := &RespondToMoneyRequestResults{
+		Success: true,
+		Message: fmt.Sprintf("Money request %s %s", params.MoneyRequestID, response),
+	}
+
+	logger.WithField("workflow_id", workflowID).Info("Money request response signalled")
+
+	return results, nil
+}
+
+// ListMoneyRequests lists the money requests of an account as payer or payee, found through the
+// TransferSourceAccount and TransferDestinationAccount search attributes of their workflows
+func (svc *Service) ListMoneyRequests(ctx context.Context, params *ListMoneyRequestsParams) (*ListMoneyRequestsResults, error) {
+	const op = "service.Service.ListMoneyRequests"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	role := params.Role
+	if role == "" {
+		role = MoneyRequestRolePayer
+	}
+
+	query, err := moneyRequestsQuery(params.Account, role, params.Status)
+	if err != nil {
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	if svc.temporalClient == nil {
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	results := &ListMoneyRequestsResults{
+		Account:       params.Account,
+		Role:          role,
+		MoneyRequests: []MoneyRequest{},
+	}
+
+	var nextPageToken []byte
+	for {
+		response, err := svc.temporalClient.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:         query,
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to list workflows: %w", err)
+
+			logger.WithError(err).Error()
+
+			return nil, err
+		}
+
+		for _, execution := range response.Executions {
+			if len(results.MoneyRequests) == maxMoneyRequests {
+				results.Truncated = true
+				break
+			}
+
+			request, err := newMoneyRequest(execution.GetExecution(), execution.GetMemo(), execution.GetSearchAttributes(), execution.GetStartTime().AsTime())
+			if err != nil {
+				logger.WithError(err).WithField("workflow_id", execution.GetExecution().GetWorkflowId()).Warn("Skipping money request without a readable memo")
+
+				continue
+			}
+
+			results.MoneyRequests = append(results.MoneyRequests, request)
+		}
+
+		nextPageToken = response.NextPageToken
+		if len(nextPageToken) == 0 || results.Truncated {
+			break
+		}
+	}
+
+	logger.WithFields(logrus.Fields{
+		"money_requests": len(results.MoneyRequests),
+		"truncated":      results.Truncated,
+	}).Info()
+
+	return results, nil
+}
+
+// moneyRequestsQuery builds the visibility query for the money requests of an account. The account and status are
+// interpolated into the query, so quotes and backslashes are rejected.
+func moneyRequestsQuery(account, role, status string) (string, error) {
+	if account == "" {
+		return "", invalidParameters(newFieldViolation("account", "account is required"))
+	}
+	if strings.ContainsAny(account, `'"\`) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidAccountID, account)
+	}
+
+	var accountAttribute temporal.SearchAttributeKeyKeyword
+	switch role {
+	case MoneyRequestRolePayer:
+		accountAttribute = sourceAccountSearchAttribute
+	case MoneyRequestRolePayee:
+		accountAttribute = destinationAccountSearchAttribute
+	default:
+		return "", invalidParameters(newFieldViolation("role", "role must be %s or %s", MoneyRequestRolePayer, MoneyRequestRolePayee))
+	}
+
+	query := fmt.Sprintf("WorkflowType = 'requestMoneyWorkflow' AND %s = '%s'", accountAttribute.GetName(), account)
+
+	switch status {
+	case "":
+	case MoneyRequestStatusPending, MoneyRequestStatusAccepted, MoneyRequestStatusDeclined, MoneyRequestStatusExpired:
+		query += fmt.Sprintf(" AND %s = '%s'", moneyRequestStatusSearchAttribute.GetName(), status)
+	default:
+		return "", invalidParameters(newFieldViolation("status", "unsupported status: %s", status))
+	}
+
+	return query + " ORDER BY StartTime DESC", nil
+}
+
+func newMoneyRequest(execution *commonpb.WorkflowExecution, memo *commonpb.Memo, searchAttributes *commonpb.SearchAttributes, createdAt time.Time) (MoneyRequest, error) {
+	dataConverter := converter.GetDefaultDataConverter()
+
+	fields := map[string]string{}
+	for _, key := range []string{memoFromAccount, memoToAccount, memoAmount, memoCurrency, memoDescription, memoExpiresAt} {
+		payload, ok := memo.GetFields()[key]
+		if !ok {
+			return MoneyRequest{}, fmt.Errorf("memo has no %s", key)
+		}
+
+		var value string
+		if err := dataConverter.FromPayload(payload, &value); err != nil {
+			return MoneyRequest{}, fmt.Errorf("failed to decode memo %s: %w", key, err)
+		}
+		fields[key] = value
+	}
+
+	amount, err := decimal.NewFromString(fields[memoAmount])
+	if err != nil {
+		return MoneyRequest{}, fmt.Errorf("failed to parse memo amount: %w", err)
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, fields[memoExpiresAt])
+	if err != nil {
+		return MoneyRequest{}, fmt.Errorf("failed to parse memo expires_at: %w", err)
+	}
+
+	var status string
+	if payload, ok := searchAttributes.GetIndexedFields()[moneyRequestStatusSearchAttribute.GetName()]; ok {
+		if err := dataConverter.FromPayload(payload, &status); err != nil {
+			return MoneyRequest{}, fmt.Errorf("failed to decode money request status: %w", err)
+		}
+	}
+
+	return MoneyRequest{
+		MoneyRequestID: strings.TrimPrefix(execution.GetWorkflowId(), moneyRequestWorkflowIDPrefix),
+		WorkflowID:     execution.GetWorkflowId(),
+		PayerAccount:   fields[memoFromAccount],
+		PayeeAccount:   fields[memoToAccount],
+		Amount:         amount,
+		Currency:       fields[memoCurrency],
+		Description:    fields[memoDescription],
+		Status:         status,
+		CreatedAt:      createdAt,
+		ExpiresAt:      expiresAt,
+	}, nil
+}
+
+// validateRequestMoneyParams validates a money request and returns the transfer it asks for, from the payer to
+// the payee
+func validateRequestMoneyParams(params *RequestMoneyParams) (*ExecuteTransferParams, error) {
+	if params.PayeeAccount == "" {
+		return nil, newFieldViolation("payee_account", "payee_account is required")
+	}
+
+	if params.PayerAccount == "" {
+		return nil, newFieldViolation("payer_account", "payer_account is required")
+	}
+
+	if params.PayerAccount == params.PayeeAccount {
+		return nil, newFieldViolation("payer_account", "payer_account and payee_account cannot be the same")
+	}
+
+	transfer := &ExecuteTransferParams{
+		FromAccount:   params.PayerAccount,
+		ToAccount:     params.PayeeAccount,
+		Amount:        params.Amount,
+		AmountDecimal: params.AmountDecimal,
+		Currency:      params.Currency,
+		Description:   params.Description,
+		RequestID:     params.RequestID,
+	}
+
+	// The accounts were checked above, so any violation is of a field named the same in both requests
+	if err := validateExecuteTransferParams(transfer); err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// requestMoneyWorkflow waits for the payer to accept or decline a money request. An accepted request starts the
+// transfer saga as a child transfer workflow and completes once it has started; a request left unanswered until
+// its expiry is declined as expired. Only the first response counts.
+func requestMoneyWorkflow(ctx workflow.Context, params MoneyRequestWorkflowParams) (*MoneyRequestWorkflowResults, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting requestMoneyWorkflow", "money_request_id", params.Transfer.TransferID, "payer_account", params.Transfer.FromAccount, "payee_account", params.Transfer.ToAccount)
+
+	results := &MoneyRequestWorkflowResults{
+		MoneyRequestID: params.Transfer.TransferID,
+		Status:         MoneyRequestStatusPending,
+	}
+
+	if err := validateTransferWorkflowParams(params.Transfer); err != nil {
+		logger.Error("Invalid workflow parameters", "error", err)
+		return results, err
+	}
+
+	timerCtx, stopTimer := workflow.WithCancel(ctx)
+	defer stopTimer()
+
+	expired := false
+	var response MoneyRequestResponseSignal
+	selector := workflow.NewSelector(ctx)
+	selector.AddReceive(workflow.GetSignalChannel(ctx, RespondToMoneyRequestSignalName), func(channel workflow.ReceiveChannel, more bool) {
+		channel.Receive(ctx, &response)
+	})
+	selector.AddFuture(workflow.NewTimer(timerCtx, time.Duration(params.ExpirySeconds)*time.Second), func(workflow.Future) { expired = true })
+	selector.Select(ctx)
+
+	respondedAt := workflow.Now(ctx)
+	results.RespondedAt = &respondedAt
+
+	switch {
+	case expired:
+		results.Status = MoneyRequestStatusExpired
+		results.Reason = fmt.Sprintf("not answered within %ds", params.ExpirySeconds)
+	case !response.Accept:
+		results.Status = MoneyRequestStatusDeclined
+		results.Reason = response.Reason
+	default:
+		results.Status = MoneyRequestStatusAccepted
+	}
+
+	if err := workflow.UpsertTypedSearchAttributes(ctx, moneyRequestStatusSearchAttribute.ValueSet(results.Status)); err != nil {
+		logger.Warn("Failed to update money request status", "error", err)
+	}
+
+	if results.Status != MoneyRequestStatusAccepted {
+		logger.Info("Money request declined", "money_request_id", results.MoneyRequestID, "status", results.Status, "reason", results.Reason)
+		return results, nil
+	}
+
+	// The transfer keeps the workflow ID of a standalone transfer, so GetTransferStatus reports it as usual.
+	// Abandoned on close, so the saga outlives the request that started it.
+	timeout := transferWorkflowTimeout(params.Transfer)
+	childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID:               transferWorkflowID(params.Transfer.TransferID),
+		WorkflowExecutionTimeout: timeout,
+		WorkflowRunTimeout:       timeout,
+		ParentClosePolicy:        enums.PARENT_CLOSE_POLICY_ABANDON,
+		TypedSearchAttributes:    transferSearchAttributes(params.Transfer),
+		Memo:                     transferMemo(params.Transfer),
+	})
+
+	child := workflow.ExecuteChildWorkflow(childCtx, transferWorkflow, params.Transfer)
+	if err := child.GetChildWorkflowExecution().Get(ctx, nil); err != nil {
+		logger.Error("Failed to start money request transfer", "money_request_id", results.MoneyRequestID, "error", err)
+		return results, fmt.Errorf("failed to start transfer: %w", err)
+	}
+
+	results.TransactionID = params.Transfer.TransferID
+
+	logger.Info("Money request accepted", "money_request_id", results.MoneyRequestID, "transaction_id", results.TransactionID)
+
+	return results, nil
+}
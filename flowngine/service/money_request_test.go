@@ -0,0 +1,142 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func validMoneyRequestWorkflowParams() MoneyRequestWorkflowParams {
+	return MoneyRequestWorkflowParams{Transfer: validTransferWorkflowParams(), ExpirySeconds: 3600}
+}
+
+func TestRequestMoneyWorkflow_Accepted(t *testing.T) {
+	t.Parallel()
+
+	env := newTransferWorkflowTestEnv(t)
+	env.RegisterWorkflow(transferWorkflow)
+
+	params := validMoneyRequestWorkflowParams()
+	transferID := mock.MatchedBy(func(transfer TransferWorkflowParams) bool { return transfer.TransferID == params.Transfer.TransferID })
+	env.OnWorkflow(transferWorkflow, mock.Anything, transferID).Return(&TransferWorkflowResults{Status: "completed"}, nil).Once()
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(RespondToMoneyRequestSignalName, MoneyRequestResponseSignal{Accept: true})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(requestMoneyWorkflow, params)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var results MoneyRequestWorkflowResults
+	require.NoError(t, env.GetWorkflowResult(&results))
+	assert.Equal(t, MoneyRequestStatusAccepted, results.Status)
+	assert.Equal(t, params.Transfer.TransferID, results.TransactionID)
+	assert.NotNil(t, results.RespondedAt)
+}
+
+func TestRequestMoneyWorkflow_Declined(t *testing.T) {
+	t.Parallel()
+
+	env := newTransferWorkflowTestEnv(t)
+
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(RespondToMoneyRequestSignalName, MoneyRequestResponseSignal{Reason: "not mine"})
+	}, time.Minute)
+
+	env.ExecuteWorkflow(requestMoneyWorkflow, validMoneyRequestWorkflowParams())
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var results MoneyRequestWorkflowResults
+	require.NoError(t, env.GetWorkflowResult(&results))
+	assert.Equal(t, MoneyRequestStatusDeclined, results.Status)
+	assert.Equal(t, "not mine", results.Reason)
+	assert.Empty(t, results.TransactionID)
+}
+
+func TestRequestMoneyWorkflow_Expired(t *testing.T) {
+	t.Parallel()
+
+	env := newTransferWorkflowTestEnv(t)
+
+	// A response after the expiry comes too late
+	env.RegisterDelayedCallback(func() {
+		env.SignalWorkflow(RespondToMoneyRequestSignalName, MoneyRequestResponseSignal{Accept: true})
+	}, 2*time.Hour)
+
+	env.ExecuteWorkflow(requestMoneyWorkflow, validMoneyRequestWorkflowParams())
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var results MoneyRequestWorkflowResults
+	require.NoError(t, env.GetWorkflowResult(&results))
+	assert.Equal(t, MoneyRequestStatusExpired, results.Status)
+	assert.Empty(t, results.TransactionID)
+}
+
+func TestMoneyRequestsQuery(t *testing.T) {
+	t.Parallel()
+
+	query, err := moneyRequestsQuery("acc-1", MoneyRequestRolePayer, MoneyRequestStatusPending)
+	require.NoError(t, err)
+	assert.Equal(t, "WorkflowType = 'requestMoneyWorkflow' AND TransferSourceAccount = 'acc-1' AND MoneyRequestStatus = 'PENDING' ORDER BY StartTime DESC", query)
+
+	query, err = moneyRequestsQuery("acc-1", MoneyRequestRolePayee, "")
+	require.NoError(t, err)
+	assert.Equal(t, "WorkflowType = 'requestMoneyWorkflow' AND TransferDestinationAccount = 'acc-1' ORDER BY StartTime DESC", query)
+
+	_, err = moneyRequestsQuery("acc' OR '1'='1", MoneyRequestRolePayer, "")
+	assert.ErrorIs(t, err, ErrInvalidAccountID)
+
+	for _, tt := range []struct{ account, role, status string }{
+		{"", MoneyRequestRolePayer, ""},
+		{"acc-1", "owner", ""},
+		{"acc-1", MoneyRequestRolePayer, "PAID"},
+	} {
+		_, err := moneyRequestsQuery(tt.account, tt.role, tt.status)
+		assert.ErrorIs(t, err, ErrInvalidParameters, tt)
+	}
+}
+
+func TestValidateRequestMoneyParams(t *testing.T) {
+	t.Parallel()
+
+	valid := func() RequestMoneyParams {
+		return RequestMoneyParams{PayeeAccount: "acc-payee", PayerAccount: "acc-payer", AmountDecimal: "25.00", Currency: "USD", RequestID: "request-123"}
+	}
+
+	params := valid()
+	transfer, err := validateRequestMoneyParams(&params)
+	require.NoError(t, err)
+	assert.Equal(t, "acc-payer", transfer.FromAccount)
+	assert.Equal(t, "acc-payee", transfer.ToAccount)
+
+	for name, tt := range map[string]struct {
+		modify    func(*RequestMoneyParams)
+		wantField string
+	}{
+		"missing_payee": {modify: func(p *RequestMoneyParams) { p.PayeeAccount = "" }, wantField: "payee_account"},
+		"missing_payer": {modify: func(p *RequestMoneyParams) { p.PayerAccount = "" }, wantField: "payer_account"},
+		"same_accounts": {modify: func(p *RequestMoneyParams) { p.PayerAccount = p.PayeeAccount }, wantField: "payer_account"},
+		"currency":      {modify: func(p *RequestMoneyParams) { p.Currency = "XXX" }, wantField: "currency"},
+		"request_id":    {modify: func(p *RequestMoneyParams) { p.RequestID = "" }, wantField: "request_id"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			params := valid()
+			tt.modify(&params)
+
+			_, err := validateRequestMoneyParams(&params)
+
+			var violation *FieldViolation
+			require.ErrorAs(t, err, &violation)
+			assert.Equal(t, tt.wantField, violation.Field)
+		})
+	}
+}
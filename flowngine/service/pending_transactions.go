@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type GetPendingTransactionStatsResults struct {
+	Pending           int64
+	Stale             int64 // Pending beyond StaleAfterSeconds, left for the next sweep
+	StaleAfterSeconds int64
+	SweepRuns         int64
+	SweptCompleted    int64
+	SweptFailed       int64
+	SweepErrors       int64
+	LastSweepAt       *time.Time // Nil before the first sweep
+}
+
+// GetPendingTransactionStats reads the pending transaction counts and sweeper outcomes kept by svc-transaction
+func (svc *Service) GetPendingTransactionStats(ctx context.Context) (*GetPendingTransactionStatsResults, error) {
+	const op = "service.Service.GetPendingTransactionStats"
+
+	logger := svc.logger.WithField("[op]", op)
+
+	stats, err := svc.transactionAdapter.GetPendingTransactionStats(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to read pending transaction stats: %w", err)
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"pending": stats.Pending,
+		"stale":   stats.Stale,
+	}).Debug()
+
+	return &GetPendingTransactionStatsResults{
+		Pending:           stats.Pending,
+		Stale:             stats.Stale,
+		StaleAfterSeconds: stats.StaleAfterSeconds,
+		SweepRuns:         stats.SweepRuns,
+		SweptCompleted:    stats.SweptCompleted,
+		SweptFailed:       stats.SweptFailed,
+		SweepErrors:       stats.SweepErrors,
+		LastSweepAt:       stats.LastSweepAt,
+	}, nil
+}
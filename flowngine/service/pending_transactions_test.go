@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPendingTransactionStats(t *testing.T) {
+	t.Parallel()
+
+	svc := newCompensationAuditTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/pending-transactions/stats", r.URL.Path)
+
+		_, _ = w.Write([]byte(`{"message":"ok","data":{"pending":7,"stale":2,"stale_after_seconds":900,"sweep_runs":4,"swept_completed":3,"swept_failed":1,"sweep_errors":0,"last_sweep_at":"2025-06-01T12:00:00Z"}}`))
+	})
+
+	results, err := svc.GetPendingTransactionStats(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(7), results.Pending)
+	assert.Equal(t, int64(2), results.Stale)
+	assert.Equal(t, int64(900), results.StaleAfterSeconds)
+	assert.Equal(t, int64(3), results.SweptCompleted)
+	assert.Equal(t, int64(1), results.SweptFailed)
+	require.NotNil(t, results.LastSweepAt)
+	assert.Equal(t, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), results.LastSweepAt.UTC())
+}
+
+func TestGetPendingTransactionStatsBeforeFirstSweep(t *testing.T) {
+	t.Parallel()
+
+	svc := newCompensationAuditTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"message":"ok","data":{"pending":0,"stale":0,"stale_after_seconds":900,"sweep_runs":0,"swept_completed":0,"swept_failed":0,"sweep_errors":0}}`))
+	})
+
+	results, err := svc.GetPendingTransactionStats(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, results.LastSweepAt)
+}
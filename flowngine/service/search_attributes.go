@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/operatorservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+)
+
+// Custom search attributes set on every transfer workflow, so in-flight transfers can be found by account
+var (
+	sourceAccountSearchAttribute      = temporal.NewSearchAttributeKeyKeyword("TransferSourceAccount")
+	destinationAccountSearchAttribute = temporal.NewSearchAttributeKeyKeyword("TransferDestinationAccount")
+)
+
+// triggerAccountSearchAttribute is set on conditional transfers while they wait for their trigger account to be
+// credited, so svc-transaction can find the workflows to signal. It is cleared once the transfer is triggered.
+var triggerAccountSearchAttribute = temporal.NewSearchAttributeKeyKeyword("TransferTriggerAccount")
+
+// transferWorkflowTypes matches both workflow types that run the transfer saga in visibility queries
+const transferWorkflowTypes = "WorkflowType IN ('transferWorkflow', 'conditionalTransferWorkflow')"
+
+// slaStatusSearchAttribute is upserted by a transfer workflow once it breaches its SLA, see transfer_sla.go
+var slaStatusSearchAttribute = temporal.NewSearchAttributeKeyKeyword("TransferSLAStatus")
+
+// Memo keys of transfer workflows; the memo carries what ListAccountWorkflows reports without reading histories
+const (
+	memoFromAccount = "from_account"
+	memoToAccount   = "to_account"
+	memoAmount      = "amount"
+	memoCurrency    = "currency"
+)
+
+func transferSearchAttributes(params TransferWorkflowParams) temporal.SearchAttributes {
+	return temporal.NewSearchAttributes(
+		sourceAccountSearchAttribute.ValueSet(params.FromAccount),
+		destinationAccountSearchAttribute.ValueSet(params.ToAccount),
+	)
+}
+
+func conditionalTransferSearchAttributes(params ConditionalTransferParams) temporal.SearchAttributes {
+	return temporal.NewSearchAttributes(
+		sourceAccountSearchAttribute.ValueSet(params.Transfer.FromAccount),
+		destinationAccountSearchAttribute.ValueSet(params.Transfer.ToAccount),
+		triggerAccountSearchAttribute.ValueSet(params.TriggerAccount),
+	)
+}
+
+func transferMemo(params TransferWorkflowParams) map[string]any {
+	return map[string]any{
+		memoFromAccount: params.FromAccount,
+		memoToAccount:   params.ToAccount,
+		memoAmount:      params.Amount.String(),
+		memoCurrency:    params.Currency,
+	}
+}
+
+// RegisterSearchAttributes adds the transfer search attributes to the namespace if they are missing.
+// Workflows cannot start with unknown search attributes, so this runs whenever a Temporal client is connected.
+func (svc *Service) RegisterSearchAttributes(ctx context.Context) error {
+	const op = "service.Service.RegisterSearchAttributes"
+
+	// The client falls back to the default namespace when none is configured; operator requests do not
+	namespace := svc.config.Temporal.Namespace
+	if namespace == "" {
+		namespace = client.DefaultNamespace
+	}
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":      op,
+		"namespace": namespace,
+	})
+
+	operator := svc.temporalClient.OperatorService()
+
+	existing, err := operator.ListSearchAttributes(ctx, &operatorservice.ListSearchAttributesRequest{
+		Namespace: namespace,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list search attributes: %w", err)
+	}
+
+	missing := map[string]enumspb.IndexedValueType{}
+	for _, key := range []temporal.SearchAttributeKeyKeyword{sourceAccountSearchAttribute, destinationAccountSearchAttribute, slaStatusSearchAttribute, triggerAccountSearchAttribute, moneyRequestStatusSearchAttribute} {
+		if _, ok := existing.GetCustomAttributes()[key.GetName()]; !ok {
+			missing[key.GetName()] = enumspb.INDEXED_VALUE_TYPE_KEYWORD
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if _, err := operator.AddSearchAttributes(ctx, &operatorservice.AddSearchAttributesRequest{
+		Namespace:        namespace,
+		SearchAttributes: missing,
+	}); err != nil {
+		return fmt.Errorf("failed to add search attributes: %w", err)
+	}
+
+	logger.WithField("added", len(missing)).Info("Registered transfer search attributes")
+
+	return nil
+}
@@ -1,7 +1,11 @@
 package service
 
 import (
+	"flowngine/adapter/temporal_health_adapter"
+	"flowngine/adapter/transaction_adapter"
 	"flowngine/util/config"
+	"flowngine/util/failure"
+	"flowngine/util/taskqueue"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -15,18 +19,30 @@ type Service struct {
 	config config.Config
 
 	temporalClient client.Client
+
+	transactionAdapter    *transaction_adapter.Adapter
+	temporalHealthAdapter *temporal_health_adapter.Adapter
+
+	failureSimulator *failure.Simulator
+
+	taskQueueRouter *taskqueue.Router
 }
 
 func NewService(
 	logger *logrus.Logger,
 	config config.Config,
 	temporalClient client.Client,
+	transactionAdapter *transaction_adapter.Adapter,
 ) *Service {
 	service := &Service{
 		logger: logger,
 		config: config,
 
 		temporalClient: temporalClient,
+
+		transactionAdapter: transactionAdapter,
+
+		failureSimulator: failure.NewSimulator(logger),
 	}
 
 	// Initialize the global ActivityOptionsProvider for workflows
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+
+	"flowngine/util/taskqueue"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultTransferTaskQueue is the task queue transfers are started on when none is configured
+const defaultTransferTaskQueue = "transfer-task-queue"
+
+// ErrTaskQueueCutoverUnavailable is returned when the task queue router has not been set
+var ErrTaskQueueCutoverUnavailable = errors.New("task queue cutover not available - service is starting up")
+
+// SetTaskQueueRouter sets the router picking the task queue of new transfer, batch, money request and escrow workflows
+func (s *Service) SetTaskQueueRouter(router *taskqueue.Router) {
+	s.taskQueueRouter = router
+}
+
+// GetTaskQueueCutover returns the current and next transfer task queues, the share of new workflows started on the
+// next one and the starts routed to each
+func (s *Service) GetTaskQueueCutover() (*taskqueue.State, error) {
+	if s.taskQueueRouter == nil {
+		return nil, ErrTaskQueueCutoverUnavailable
+	}
+
+	state := s.taskQueueRouter.State()
+
+	return &state, nil
+}
+
+// SetTaskQueueCutoverPercent shifts percent of the new workflows to the next transfer task queue. Workflows started
+// before keep running on their task queue.
+func (s *Service) SetTaskQueueCutoverPercent(percent int) (*taskqueue.State, error) {
+	const op = "service.Service.SetTaskQueueCutoverPercent"
+
+	logger := s.logger.WithField("[op]", op)
+
+	if s.taskQueueRouter == nil {
+		return nil, ErrTaskQueueCutoverUnavailable
+	}
+
+	if err := s.taskQueueRouter.SetPercent(percent); err != nil {
+		return nil, invalidParameters(err)
+	}
+
+	state := s.taskQueueRouter.State()
+
+	logger.WithField("percent", state.Percent).Info("Task queue cutover changed")
+
+	return &state, nil
+}
+
+// transferTaskQueue returns the task queue to start the workflow workflowID on. track pins the start to the current
+// or the next fleet; any other value leaves it to the cutover percent.
+func (s *Service) transferTaskQueue(workflowID, track string) string {
+	if s.taskQueueRouter == nil {
+		return defaultTransferTaskQueue
+	}
+
+	taskQueue, routedTrack := s.taskQueueRouter.Route(workflowID, track)
+
+	s.logger.WithFields(logrus.Fields{
+		"workflow_id": workflowID,
+		"task_queue":  taskQueue,
+		"track":       routedTrack,
+	}).Debug("Routed workflow start")
+
+	return taskQueue
+}
+
+// transferTaskQueues returns the task queues transfers are started on: the current one and, during a cutover, the
+// next one
+func (s *Service) transferTaskQueues() []string {
+	if s.taskQueueRouter == nil {
+		return []string{defaultTransferTaskQueue}
+	}
+
+	return s.taskQueueRouter.TaskQueues()
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"flowngine/adapter/temporal_health_adapter"
+	"flowngine/util/config"
+
+	"github.com/sirupsen/logrus"
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+// defaultMaxBacklogAge is how long a task may wait for a worker before its task queue is reported unhealthy
+const defaultMaxBacklogAge = 30 * time.Second
+
+// TaskQueueHealth is the workers and backlog of one watched task queue
+type TaskQueueHealth struct {
+	Name     string                                      `json:"name"`
+	Healthy  bool                                        `json:"healthy"`
+	Problems []string                                    `json:"problems,omitempty"`
+	Types    []temporal_health_adapter.TaskQueueTypeInfo `json:"types"`
+}
+
+// TemporalHealth is the health of the namespace and of the watched task queues
+type TemporalHealth struct {
+	Healthy              bool                                   `json:"healthy"`
+	Namespace            *temporal_health_adapter.NamespaceInfo `json:"namespace"`
+	MaxBacklogAgeSeconds float64                                `json:"max_backlog_age_seconds"`
+	TaskQueues           []TaskQueueHealth                      `json:"task_queues"`
+	CheckedAt            time.Time                              `json:"checked_at"`
+}
+
+// SetTemporalHealthAdapter sets the adapter the Temporal health is read through (set once Temporal is connected)
+func (s *Service) SetTemporalHealthAdapter(adapter *temporal_health_adapter.Adapter) {
+	s.temporalHealthAdapter = adapter
+}
+
+// GetTemporalHealth reads the state of the namespace and the pollers and backlog of every watched task queue. A
+// task queue is unhealthy when a task type has no poller or a task has waited too long for a worker; a task queue
+// that cannot be described is reported unhealthy rather than failing the whole check.
+func (s *Service) GetTemporalHealth(ctx context.Context) (*TemporalHealth, error) {
+	const op = "service.Service.GetTemporalHealth"
+
+	logger := s.logger.WithField("[op]", op)
+
+	if s.temporalHealthAdapter == nil {
+		return nil, ErrTemporalUnavailable
+	}
+
+	if err := s.temporalHealthAdapter.CheckHealth(ctx); err != nil {
+		logger.WithError(err).Warn()
+		return nil, err
+	}
+
+	namespace, err := s.temporalHealthAdapter.DescribeNamespace(ctx)
+	if err != nil {
+		logger.WithError(err).Warn()
+		return nil, err
+	}
+
+	maxBacklogAge := s.maxBacklogAge()
+
+	health := &TemporalHealth{
+		Healthy:              namespace.State == enumspb.NAMESPACE_STATE_REGISTERED.String(),
+		Namespace:            namespace,
+		MaxBacklogAgeSeconds: maxBacklogAge.Seconds(),
+		CheckedAt:            time.Now().UTC(),
+	}
+
+	for _, taskQueue := range s.healthTaskQueues() {
+		queueHealth := TaskQueueHealth{Name: taskQueue.Name}
+
+		types, err := s.temporalHealthAdapter.DescribeTaskQueue(ctx, taskQueue.Name, taskQueue.Types)
+		if err != nil {
+			logger.WithError(err).WithField("task_queue", taskQueue.Name).Warn("Failed to describe task queue")
+
+			queueHealth.Problems = []string{err.Error()}
+		} else {
+			queueHealth.Types = types
+			queueHealth.Problems = taskQueueProblems(types, maxBacklogAge)
+		}
+
+		queueHealth.Healthy = len(queueHealth.Problems) == 0
+		health.Healthy = health.Healthy && queueHealth.Healthy
+		health.TaskQueues = append(health.TaskQueues, queueHealth)
+	}
+
+	logger.WithFields(logrus.Fields{
+		"healthy":     health.Healthy,
+		"task_queues": len(health.TaskQueues),
+	}).Debug()
+
+	return health, nil
+}
+
+// healthTaskQueues returns the configured task queues with their types filled in, or the workflow tasks of the
+// queues transfers are started on when none is configured
+func (s *Service) healthTaskQueues() []config.TemporalHealthTaskQueue {
+	taskQueues := s.config.TemporalHealth.TaskQueues
+	if len(taskQueues) == 0 {
+		for _, name := range s.transferTaskQueues() {
+			taskQueues = append(taskQueues, config.TemporalHealthTaskQueue{Name: name, Types: []string{temporal_health_adapter.TaskQueueTypeWorkflow}})
+		}
+	}
+
+	resolved := make([]config.TemporalHealthTaskQueue, 0, len(taskQueues))
+	for _, taskQueue := range taskQueues {
+		if len(taskQueue.Types) == 0 {
+			taskQueue.Types = []string{temporal_health_adapter.TaskQueueTypeWorkflow, temporal_health_adapter.TaskQueueTypeActivity}
+		}
+		resolved = append(resolved, taskQueue)
+	}
+
+	return resolved
+}
+
+func (s *Service) maxBacklogAge() time.Duration {
+	if s.config.TemporalHealth.MaxBacklogAgeSeconds <= 0 {
+		return defaultMaxBacklogAge
+	}
+
+	return time.Duration(s.config.TemporalHealth.MaxBacklogAgeSeconds) * time.Second
+}
+
+// taskQueueProblems lists what keeps the task types of a queue from being served: no worker polling a type, or its
+// oldest task waiting longer than maxBacklogAge
+func taskQueueProblems(types []temporal_health_adapter.TaskQueueTypeInfo, maxBacklogAge time.Duration) []string {
+	var problems []string
+	for _, info := range types {
+		if len(info.Pollers) == 0 {
+			problems = append(problems, fmt.Sprintf("no %s pollers", info.Type))
+		}
+
+		if info.BacklogAgeSeconds > maxBacklogAge.Seconds() {
+			problems = append(problems, fmt.Sprintf("%s tasks wait %.0fs for a worker, more than %.0fs", info.Type, info.BacklogAgeSeconds, maxBacklogAge.Seconds()))
+		}
+	}
+
+	return problems
+}
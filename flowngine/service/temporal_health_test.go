@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"flowngine/adapter/temporal_health_adapter"
+	"flowngine/util/config"
+	"flowngine/util/taskqueue"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskQueueProblems(t *testing.T) {
+	t.Parallel()
+
+	poller := []temporal_health_adapter.Poller{{Identity: "worker@host"}}
+
+	tests := []struct {
+		name  string
+		types []temporal_health_adapter.TaskQueueTypeInfo
+		want  []string
+	}{
+		{
+			name: "served",
+			types: []temporal_health_adapter.TaskQueueTypeInfo{
+				{Type: "workflow", Pollers: poller, Backlog: 3, BacklogAgeSeconds: 2},
+				{Type: "activity", Pollers: poller},
+			},
+		},
+		{
+			name: "no_pollers",
+			types: []temporal_health_adapter.TaskQueueTypeInfo{
+				{Type: "workflow", Pollers: poller},
+				{Type: "activity", Pollers: []temporal_health_adapter.Poller{}},
+			},
+			want: []string{"no activity pollers"},
+		},
+		{
+			name: "backlog_too_old",
+			types: []temporal_health_adapter.TaskQueueTypeInfo{
+				{Type: "workflow", Backlog: 40, BacklogAgeSeconds: 45},
+			},
+			want: []string{"no workflow pollers", "workflow tasks wait 45s for a worker, more than 30s"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, taskQueueProblems(tt.types, 30*time.Second))
+		})
+	}
+}
+
+func TestHealthTaskQueues(t *testing.T) {
+	t.Parallel()
+
+	s := &Service{}
+	assert.Equal(t, []config.TemporalHealthTaskQueue{
+		{Name: "transfer-task-queue", Types: []string{"workflow"}},
+	}, s.healthTaskQueues())
+	assert.Equal(t, 30*time.Second, s.maxBacklogAge())
+
+	// During a cutover both fleets are watched
+	router, err := taskqueue.NewRouter("transfer-task-queue-v1", "transfer-task-queue-v2", 10)
+	require.NoError(t, err)
+	s.SetTaskQueueRouter(router)
+	assert.Equal(t, []config.TemporalHealthTaskQueue{
+		{Name: "transfer-task-queue-v1", Types: []string{"workflow"}},
+		{Name: "transfer-task-queue-v2", Types: []string{"workflow"}},
+	}, s.healthTaskQueues())
+
+	s.config.TemporalHealth = config.TemporalHealth{
+		TaskQueues:           []config.TemporalHealthTaskQueue{{Name: "balance-task-queue"}},
+		MaxBacklogAgeSeconds: 5,
+	}
+	assert.Equal(t, []config.TemporalHealthTaskQueue{
+		{Name: "balance-task-queue", Types: []string{"workflow", "activity"}},
+	}, s.healthTaskQueues())
+	assert.Equal(t, 5*time.Second, s.maxBacklogAge())
+}
+
+func TestGetTemporalHealthWithoutTemporal(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	s := &Service{logger: logger}
+
+	_, err := s.GetTemporalHealth(context.Background())
+	require.ErrorIs(t, err, ErrTemporalUnavailable)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"fmt"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+// resolveAccountAliasActivity resolves a phone number or email address to its account in svc-balance
+const resolveAccountAliasActivity = "ResolveAccountAlias"
+
+// resolvedAccountAlias is the account svc-balance resolved the alias of a transfer to
+type resolvedAccountAlias struct {
+	Alias         string `json:"alias"`
+	AccountID     string `json:"account_id"`
+	AccountNumber string `json:"account_number"`
+	Currency      string `json:"currency"`
+}
+
+// resolveTransferAlias resolves the alias a transfer is addressed to into the account it credits, and points the
+// destination search attribute and memo of the workflow at that account. Unknown aliases fail the activity with
+// the non-retryable ALIAS_NOT_FOUND error type.
+func resolveTransferAlias(ctx workflow.Context, params TransferWorkflowParams) (string, error) {
+	logger := workflow.GetLogger(ctx)
+
+	var resolved resolvedAccountAlias
+	if err := workflow.ExecuteActivity(ctx, resolveAccountAliasActivity, map[string]interface{}{
+		"alias": params.ToAlias,
+	}).Get(ctx, &resolved); err != nil {
+		return "", err
+	}
+
+	if resolved.AccountID == params.FromAccount || resolved.AccountNumber == params.FromAccount {
+		return "", fmt.Errorf("alias %s resolves to the source account", resolved.Alias)
+	}
+
+	// Lets ListAccountWorkflows find the transfer by the account the alias resolved to
+	if err := workflow.UpsertTypedSearchAttributes(ctx, destinationAccountSearchAttribute.ValueSet(resolved.AccountID)); err != nil {
+		logger.Warn("Failed to set transfer destination account", "error", err)
+	}
+	if err := workflow.UpsertMemo(ctx, map[string]interface{}{memoToAccount: resolved.AccountID}); err != nil {
+		logger.Warn("Failed to set transfer destination memo", "error", err)
+	}
+
+	return resolved.AccountID, nil
+}
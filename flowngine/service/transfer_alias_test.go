@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferWorkflowAlias(t *testing.T) {
+	t.Parallel()
+
+	sufficientFunds := map[string]interface{}{"sufficient_funds": true}
+	debitResult := map[string]interface{}{"transaction_id": "debit-transaction-123"}
+	creditResult := map[string]interface{}{"transaction_id": "credit-transaction-123"}
+
+	aliasParams := func() TransferWorkflowParams {
+		params := validTransferWorkflowParams()
+		params.ToAccount = ""
+		params.ToAlias = "+14155550101"
+		return params
+	}
+
+	t.Run("alias_is_resolved_before_the_balance_check", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("ResolveAccountAlias", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["alias"] == "+14155550101"
+		})).Return(map[string]interface{}{"alias": "+14155550101", "account_id": "account-to", "account_number": "ACC001", "currency": "USD"}, nil).Once()
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["account_id"] == "account-to"
+		})).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, aliasParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "completed", results.Status)
+		assert.Equal(t, "account-to", results.ToAccount)
+	})
+
+	t.Run("unknown_alias_fails_before_anything_is_debited", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("ResolveAccountAlias", mock.Anything, mock.Anything).
+			Return(nil, nonRetryableError("ALIAS_NOT_FOUND")).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, aliasParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.Error(t, env.GetWorkflowError())
+		assert.Contains(t, env.GetWorkflowError().Error(), "ALIAS_NOT_FOUND")
+		env.AssertNotCalled(t, "CheckBalance", mock.Anything, mock.Anything)
+		env.AssertNotCalled(t, "DebitAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("alias_of_the_source_account_fails", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("ResolveAccountAlias", mock.Anything, mock.Anything).
+			Return(map[string]interface{}{"alias": "+14155550101", "account_id": "account-from", "account_number": "ACC002", "currency": "USD"}, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, aliasParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.Error(t, env.GetWorkflowError())
+		assert.Contains(t, env.GetWorkflowError().Error(), "resolves to the source account")
+		env.AssertNotCalled(t, "CheckBalance", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,421 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/workflow"
+)
+
+// TransferBatchProgressQueryType is the query that reports the rows of a running transfer batch
+const TransferBatchProgressQueryType = "transfer_batch_progress"
+
+// transferBatchQueryTimeout bounds the progress query, which blocks while no worker picks up the workflow
+const transferBatchQueryTimeout = 5 * time.Second
+
+// ErrTransferBatchNotFound is returned when no batch workflow exists for a batch ID
+var ErrTransferBatchNotFound = errors.New("transfer batch not found")
+
+type StartTransferBatchParams struct {
+	RequestID string              `json:"request_id"`
+	Transfers []TransferBatchItem `json:"transfers"`
+}
+
+// TransferBatchItem is one transfer of a batch. Row identifies it to the caller, e.g. its line in an uploaded file.
+type TransferBatchItem struct {
+	Row      int32                 `json:"row"`
+	Transfer ExecuteTransferParams `json:"transfer"` // RequestID is taken from the batch
+}
+
+type StartTransferBatchResults struct {
+	BatchID    string             `json:"batch_id"`
+	WorkflowID string             `json:"workflow_id"`
+	RunID      string             `json:"run_id"`
+	CreatedAt  string             `json:"created_at"`
+	Rows       []TransferBatchRow `json:"rows"` // Every transfer as PENDING, with the transaction ID it will run under
+}
+
+// TransferBatchParams defines the input parameters for the transfer batch workflow
+type TransferBatchParams struct {
+	BatchID                string                      `json:"batch_id"`
+	Transfers              []TransferBatchWorkflowItem `json:"transfers"`
+	MaxConcurrentTransfers int                         `json:"max_concurrent_transfers"`
+}
+
+// TransferBatchWorkflowItem is a transfer of a batch with its saga prepared
+type TransferBatchWorkflowItem struct {
+	Row               int32                  `json:"row"`
+	TransferReference string                 `json:"transfer_reference,omitempty"`
+	Transfer          TransferWorkflowParams `json:"transfer"`
+}
+
+// TransferBatchProgress is the result of TransferBatchProgressQueryType and of the transfer batch workflow
+type TransferBatchProgress struct {
+	BatchID     string             `json:"batch_id"`
+	Total       int                `json:"total"`
+	Pending     int                `json:"pending"`    // Not started yet, waiting for a free slot
+	Processing  int                `json:"processing"` // Transfer workflow running
+	Completed   int                `json:"completed"`
+	Failed      int                `json:"failed"` // Failed, compensated or expired
+	Rows        []TransferBatchRow `json:"rows"`
+	StartedAt   time.Time          `json:"started_at"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+}
+
+// TransferBatchRow is the outcome of one transfer of a batch, with its status as a proto TransferStatus name
+type TransferBatchRow struct {
+	Row               int32  `json:"row"`
+	TransactionID     string `json:"transaction_id"`
+	TransferReference string `json:"transfer_reference,omitempty"`
+	Status            string `json:"status"`
+	ErrorMessage      string `json:"error_message,omitempty"`
+}
+
+type GetTransferBatchParams struct {
+	BatchID string `json:"batch_id"`
+}
+
+type GetTransferBatchResults struct {
+	TransferBatchProgress
+	WorkflowID string `json:"workflow_id"`
+	Status     string `json:"status"` // Workflow execution status, e.g. RUNNING or COMPLETED
+}
+
+// StartTransferBatch validates a batch of transfers and starts a workflow that runs each of them as its own transfer
+// workflow. The batch is rejected as a whole when any transfer is invalid, so callers validate rows up front.
+func (svc *Service) StartTransferBatch(ctx context.Context, params *StartTransferBatchParams) (*StartTransferBatchResults, error) {
+	const op = "service.Service.StartTransferBatch"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"request_id": params.RequestID,
+		"transfers":  len(params.Transfers),
+	})
+
+	logger.Info("Starting transfer batch")
+
+	amounts, err := svc.validateStartTransferBatchParams(params)
+	if err != nil {
+		err = invalidParameters(err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	if svc.temporalClient == nil {
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn("StartTransferBatch request received but Temporal client not ready")
+
+		return nil, err
+	}
+
+	batchID := uuid.New().String()
+	workflowID := fmt.Sprintf("transfer_batch_workflow_%s", batchID)
+
+	workflowParams := TransferBatchParams{
+		BatchID:                batchID,
+		MaxConcurrentTransfers: svc.config.TransferBatch.MaxConcurrentTransfers,
+	}
+	results := &StartTransferBatchResults{
+		BatchID:    batchID,
+		WorkflowID: workflowID,
+	}
+
+	for i, item := range params.Transfers {
+		transactionID := uuid.New().String()
+
+		transfer := item.Transfer
+		transfer.RequestID = params.RequestID
+
+		approvers, err := svc.jointAccountApprovers(ctx, transfer.FromAccount, amounts[i].MinorUnits)
+		if err != nil {
+			logger.WithError(err).WithField("row", item.Row).Error()
+
+			return nil, err
+		}
+
+		workflowItem := TransferBatchWorkflowItem{
+			Row:               item.Row,
+			TransferReference: svc.assignTransferReference(ctx, transactionID),
+			Transfer:          svc.newTransferWorkflowParams(&transfer, transactionID, amounts[i], approvers),
+		}
+		workflowParams.Transfers = append(workflowParams.Transfers, workflowItem)
+		results.Rows = append(results.Rows, TransferBatchRow{
+			Row:               item.Row,
+			TransactionID:     transactionID,
+			TransferReference: workflowItem.TransferReference,
+			Status:            "TRANSFER_STATUS_PENDING",
+		})
+	}
+
+	// No timeout: every transfer workflow of the batch has its own, so the batch ends once the last one does
+	workflowOptions := client.StartWorkflowOptions{
+		ID:         workflowID,
+		TaskQueue:  svc.transferTaskQueue(workflowID, ""),
+		StartDelay: svc.simulatedStartDelay(""),
+	}
+
+	if err := svc.simulateWorkflowStartFailure(ctx, ""); err != nil {
+		err = fmt.Errorf("failed to start workflow: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	workflowRun, err := svc.temporalClient.ExecuteWorkflow(ctx, workflowOptions, transferBatchWorkflow, workflowParams)
+	if err != nil {
+		err = fmt.Errorf("failed to start workflow: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results.RunID = workflowRun.GetRunID()
+	results.CreatedAt = time.Now().Format(time.RFC3339)
+
+	logger.WithFields(logrus.Fields{
+		"batch_id":    batchID,
+		"workflow_id": workflowID,
+		"run_id":      results.RunID,
+	}).Info("Transfer batch started")
+
+	return results, nil
+}
+
+// GetTransferBatch reports the progress of a transfer batch and the outcome of each of its transfers
+func (svc *Service) GetTransferBatch(ctx context.Context, params *GetTransferBatchParams) (*GetTransferBatchResults, error) {
+	const op = "service.Service.GetTransferBatch"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info("Getting transfer batch")
+
+	if params.BatchID == "" {
+		err := invalidParameters(newFieldViolation("batch_id", "batch_id is required"))
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	if svc.temporalClient == nil {
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn("GetTransferBatch request received but Temporal client not ready")
+
+		return nil, err
+	}
+
+	workflowID := fmt.Sprintf("transfer_batch_workflow_%s", params.BatchID)
+
+	description, err := svc.temporalClient.DescribeWorkflowExecution(ctx, workflowID, "")
+	if err != nil {
+		var notFound *serviceerror.NotFound
+		if errors.As(err, &notFound) {
+			err = fmt.Errorf("%w: %s", ErrTransferBatchNotFound, params.BatchID)
+		} else {
+			err = fmt.Errorf("failed to describe workflow: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	executionStatus := description.GetWorkflowExecutionInfo().GetStatus()
+	results := &GetTransferBatchResults{
+		WorkflowID: workflowID,
+		Status:     workflowExecutionStatusName(executionStatus),
+	}
+
+	// A finished batch returns its progress as the workflow result; any other batch is queried for it
+	if executionStatus == enums.WORKFLOW_EXECUTION_STATUS_COMPLETED {
+		err = svc.temporalClient.GetWorkflow(ctx, workflowID, "").Get(ctx, &results.TransferBatchProgress)
+	} else {
+		err = svc.queryTransferBatchProgress(ctx, workflowID, &results.TransferBatchProgress)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to get transfer batch progress: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"status":    results.Status,
+		"completed": results.Completed,
+		"failed":    results.Failed,
+	}).Info("Transfer batch retrieved")
+
+	return results, nil
+}
+
+// queryTransferBatchProgress queries the rows of a transfer batch from its workflow
+func (svc *Service) queryTransferBatchProgress(ctx context.Context, workflowID string, progress *TransferBatchProgress) error {
+	queryCtx, cancel := context.WithTimeout(ctx, transferBatchQueryTimeout)
+	defer cancel()
+
+	value, err := svc.temporalClient.QueryWorkflow(queryCtx, workflowID, "", TransferBatchProgressQueryType)
+	if err != nil {
+		return fmt.Errorf("failed to query transfer batch: %w", err)
+	}
+
+	return value.Get(progress)
+}
+
+// workflowExecutionStatusName drops the enum prefix of an execution status, e.g. WORKFLOW_EXECUTION_STATUS_RUNNING -> RUNNING
+func workflowExecutionStatusName(status enums.WorkflowExecutionStatus) string {
+	return strings.TrimPrefix(status.String(), "WORKFLOW_EXECUTION_STATUS_")
+}
+
+// validateStartTransferBatchParams validates every transfer of a batch and returns their resolved amounts. Field
+// violations name the transfer, e.g. transfers[3].to_account.
+func (svc *Service) validateStartTransferBatchParams(params *StartTransferBatchParams) ([]transferAmount, error) {
+	if params.RequestID == "" {
+		return nil, newFieldViolation("request_id", "request_id is required")
+	}
+
+	if len(params.Transfers) == 0 {
+		return nil, newFieldViolation("transfers", "at least one transfer is required")
+	}
+
+	if maxTransfers := svc.config.TransferBatch.MaxTransfers; maxTransfers > 0 && len(params.Transfers) > maxTransfers {
+		return nil, newFieldViolation("transfers", "a batch can hold at most %d transfers, got %d", maxTransfers, len(params.Transfers))
+	}
+
+	amounts := make([]transferAmount, 0, len(params.Transfers))
+	for i, item := range params.Transfers {
+		transfer := item.Transfer
+		transfer.RequestID = params.RequestID
+
+		// Batch transfers run unattended, so they cannot wait for a trigger
+		if transfer.Trigger != nil {
+			return nil, newFieldViolation(fmt.Sprintf("transfers[%d].trigger", i), "transfers of a batch cannot have a trigger")
+		}
+
+		err := validateExecuteTransferParams(&transfer)
+		if err == nil {
+			var amount transferAmount
+			if amount, err = resolveTransferAmount(&transfer); err == nil {
+				amounts = append(amounts, amount)
+				continue
+			}
+		}
+
+		var violation *FieldViolation
+		if errors.As(err, &violation) {
+			return nil, newFieldViolation(fmt.Sprintf("transfers[%d].%s", i, violation.Field), "row %d: %s", item.Row, violation.Description)
+		}
+
+		return nil, err
+	}
+
+	return amounts, nil
+}
+
+// transferBatchWorkflow runs every transfer of a batch as a child transfer workflow, at most
+// MaxConcurrentTransfers at a time. A failed transfer does not stop the batch; its outcome is recorded in its row.
+func transferBatchWorkflow(ctx workflow.Context, params TransferBatchParams) (*TransferBatchProgress, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting transferBatchWorkflow", "batch_id", params.BatchID, "transfers", len(params.Transfers))
+
+	progress := &TransferBatchProgress{
+		BatchID:   params.BatchID,
+		Total:     len(params.Transfers),
+		Pending:   len(params.Transfers),
+		StartedAt: workflow.Now(ctx),
+	}
+	for _, item := range params.Transfers {
+		progress.Rows = append(progress.Rows, TransferBatchRow{
+			Row:               item.Row,
+			TransactionID:     item.Transfer.TransferID,
+			TransferReference: item.TransferReference,
+			Status:            "TRANSFER_STATUS_PENDING",
+		})
+	}
+
+	if err := workflow.SetQueryHandler(ctx, TransferBatchProgressQueryType, func() (*TransferBatchProgress, error) {
+		return progress, nil
+	}); err != nil {
+		logger.Error("Failed to register transfer batch progress query", "error", err)
+		return nil, err
+	}
+
+	maxConcurrent := max(params.MaxConcurrentTransfers, 1)
+	selector := workflow.NewSelector(ctx)
+	running := 0
+
+	for i, item := range params.Transfers {
+		if running == maxConcurrent {
+			selector.Select(ctx)
+			running--
+		}
+
+		// Each transfer keeps the workflow ID of a standalone transfer, so GetTransferStatus reports it as usual.
+		// Abandoned on close, so cancelling the batch never cuts a saga short between its debit and credit.
+		timeout := transferWorkflowTimeout(item.Transfer)
+		childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+			WorkflowID:               transferWorkflowID(item.Transfer.TransferID),
+			WorkflowExecutionTimeout: timeout,
+			WorkflowRunTimeout:       timeout,
+			ParentClosePolicy:        enums.PARENT_CLOSE_POLICY_ABANDON,
+			TypedSearchAttributes:    transferSearchAttributes(item.Transfer),
+			Memo:                     transferMemo(item.Transfer),
+		})
+
+		row := &progress.Rows[i]
+		row.Status = "TRANSFER_STATUS_PROCESSING"
+		progress.Pending--
+		progress.Processing++
+
+		selector.AddFuture(workflow.ExecuteChildWorkflow(childCtx, transferWorkflow, item.Transfer), func(future workflow.Future) {
+			var result TransferWorkflowResults
+			err := future.Get(ctx, &result)
+
+			progress.Processing--
+			if err != nil {
+				row.Status = "TRANSFER_STATUS_FAILED"
+				row.ErrorMessage = err.Error()
+			} else {
+				row.Status = mapWorkflowStatus(result)
+				row.ErrorMessage = result.ErrorMessage
+			}
+
+			if row.Status == "TRANSFER_STATUS_COMPLETED" {
+				progress.Completed++
+			} else {
+				progress.Failed++
+			}
+
+			logger.Info("Batch transfer finished", "batch_id", params.BatchID, "row", row.Row, "transaction_id", row.TransactionID, "status", row.Status)
+		})
+		running++
+	}
+
+	for ; running > 0; running-- {
+		selector.Select(ctx)
+	}
+
+	completedAt := workflow.Now(ctx)
+	progress.CompletedAt = &completedAt
+
+	logger.Info("Transfer batch finished", "batch_id", params.BatchID, "completed", progress.Completed, "failed", progress.Failed)
+
+	return progress, nil
+}
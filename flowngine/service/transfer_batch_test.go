@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"flowngine/util/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferBatchWorkflow(t *testing.T) {
+	t.Parallel()
+
+	env := newTransferWorkflowTestEnv(t)
+	env.RegisterWorkflow(transferWorkflow)
+
+	params := TransferBatchParams{BatchID: "batch-123", MaxConcurrentTransfers: 2}
+	for i := range 3 {
+		transfer := validTransferWorkflowParams()
+		transfer.TransferID = fmt.Sprintf("transfer-%d", i+1)
+		params.Transfers = append(params.Transfers, TransferBatchWorkflowItem{Row: int32(i + 2), Transfer: transfer})
+	}
+
+	transferID := func(id string) any {
+		return mock.MatchedBy(func(params TransferWorkflowParams) bool { return params.TransferID == id })
+	}
+	env.OnWorkflow(transferWorkflow, mock.Anything, transferID("transfer-1")).Return(&TransferWorkflowResults{Status: "completed"}, nil).After(2 * time.Minute).Once()
+	env.OnWorkflow(transferWorkflow, mock.Anything, transferID("transfer-2")).Return(&TransferWorkflowResults{Status: "failed", CompensationApplied: true, ErrorMessage: "credit failed"}, nil).After(4 * time.Minute).Once()
+	env.OnWorkflow(transferWorkflow, mock.Anything, transferID("transfer-3")).Return(nil, errors.New("workflow timed out")).Once()
+
+	// Only two transfers run at a time, so the third waits for the first to finish
+	var progress *TransferBatchProgress
+	env.RegisterDelayedCallback(func() {
+		value, err := env.QueryWorkflow(TransferBatchProgressQueryType)
+		require.NoError(t, err)
+		require.NoError(t, value.Get(&progress))
+	}, time.Minute)
+
+	env.ExecuteWorkflow(transferBatchWorkflow, params)
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	require.NotNil(t, progress)
+	assert.Equal(t, 1, progress.Pending)
+	assert.Equal(t, 2, progress.Processing)
+	assert.Equal(t, "TRANSFER_STATUS_PENDING", progress.Rows[2].Status)
+
+	var results TransferBatchProgress
+	require.NoError(t, env.GetWorkflowResult(&results))
+	assert.Equal(t, 3, results.Total)
+	assert.Equal(t, 0, results.Pending)
+	assert.Equal(t, 0, results.Processing)
+	assert.Equal(t, 1, results.Completed)
+	assert.Equal(t, 2, results.Failed)
+	assert.NotNil(t, results.CompletedAt)
+	assert.Equal(t, []TransferBatchRow{
+		{Row: 2, TransactionID: "transfer-1", Status: "TRANSFER_STATUS_COMPLETED"},
+		{Row: 3, TransactionID: "transfer-2", Status: "TRANSFER_STATUS_COMPENSATED", ErrorMessage: "credit failed"},
+		{Row: 4, TransactionID: "transfer-3", Status: "TRANSFER_STATUS_FAILED", ErrorMessage: results.Rows[2].ErrorMessage},
+	}, results.Rows)
+	assert.Contains(t, results.Rows[2].ErrorMessage, "workflow timed out")
+}
+
+func TestValidateStartTransferBatchParams(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{config: config.Config{TransferBatch: config.TransferBatch{MaxTransfers: 3}}}
+
+	item := func(row int32, amountDecimal string) TransferBatchItem {
+		return TransferBatchItem{Row: row, Transfer: ExecuteTransferParams{
+			FromAccount:   "account-from",
+			ToAccount:     "account-to",
+			AmountDecimal: amountDecimal,
+			Currency:      "USD",
+		}}
+	}
+
+	amounts, err := svc.validateStartTransferBatchParams(&StartTransferBatchParams{
+		RequestID: "request-123",
+		Transfers: []TransferBatchItem{item(2, "100.50"), item(3, "7")},
+	})
+	require.NoError(t, err)
+	require.Len(t, amounts, 2)
+	assert.Equal(t, int64(10050), amounts[0].MinorUnits)
+	assert.Equal(t, int64(700), amounts[1].MinorUnits)
+
+	triggered := item(2, "1")
+	triggered.Transfer.Trigger = &TransferTrigger{Account: "account-from", AmountDecimal: "1"}
+
+	for name, tt := range map[string]struct {
+		params    StartTransferBatchParams
+		wantField string
+	}{
+		"missing_request_id": {params: StartTransferBatchParams{Transfers: []TransferBatchItem{item(2, "1")}}, wantField: "request_id"},
+		"empty":              {params: StartTransferBatchParams{RequestID: "request-123"}, wantField: "transfers"},
+		"too_many":           {params: StartTransferBatchParams{RequestID: "request-123", Transfers: []TransferBatchItem{item(2, "1"), item(3, "1"), item(4, "1"), item(5, "1")}}, wantField: "transfers"},
+		"invalid_amount":     {params: StartTransferBatchParams{RequestID: "request-123", Transfers: []TransferBatchItem{item(2, "1"), item(3, "1.005")}}, wantField: "transfers[1].amount_decimal"},
+		"trigger":            {params: StartTransferBatchParams{RequestID: "request-123", Transfers: []TransferBatchItem{triggered}}, wantField: "transfers[0].trigger"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.validateStartTransferBatchParams(&tt.params)
+
+			var violation *FieldViolation
+			require.ErrorAs(t, err, &violation)
+			assert.Equal(t, tt.wantField, violation.Field)
+		})
+	}
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"flowngine/adapter/transaction_adapter"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// recordTransferEventActivity stores a state change of a transfer in core.transfer_events of svc-transaction
+const recordTransferEventActivity = "RecordTransferEvent"
+
+// Types of the state changes recorded for a transfer, as accepted by svc-transaction
+const (
+	transferEventStarted       = "transfer_started"
+	transferEventStepCompleted = "step_completed"
+	transferEventStepFailed    = "step_failed"
+	transferEventFinished      = "transfer_finished"
+)
+
+// transferEventSteps are the saga activities whose outcome is recorded. Balance checks and notifications leave
+// the transfer where it was, so they are not.
+var transferEventSteps = map[string]bool{
+	"DebitAccount":             true,
+	"SettleExternalTransfer":   true,
+	"CreditAccount":            true,
+	"CompensateDebit":          true,
+	"RecallExternalSettlement": true,
+}
+
+// TransferEventsInterceptor records the state changes of transfer workflows started with RecordEvents: their
+// start, the outcome of every saga step and their final status. Workers hosting transferWorkflow and
+// conditionalTransferWorkflow register it through worker.Options.Interceptors; other workflows pass through.
+//
+// Each change is stored by the RecordTransferEvent activity of svc-transaction before the workflow carries on, so
+// the events are in order. A change that cannot be recorded is logged and skipped rather than failing the transfer.
+type TransferEventsInterceptor struct {
+	interceptor.WorkerInterceptorBase
+}
+
+// NewTransferEventsInterceptor creates the worker interceptor recording transfer state changes
+func NewTransferEventsInterceptor() *TransferEventsInterceptor {
+	return &TransferEventsInterceptor{}
+}
+
+func (*TransferEventsInterceptor) InterceptWorkflow(ctx workflow.Context, next interceptor.WorkflowInboundInterceptor) interceptor.WorkflowInboundInterceptor {
+	return &transferEventsWorkflowInbound{
+		WorkflowInboundInterceptorBase: interceptor.WorkflowInboundInterceptorBase{Next: next},
+		recorder:                       &transferEventRecorder{},
+	}
+}
+
+// transferEventsWorkflowInbound records the start and the final status of a transfer workflow
+type transferEventsWorkflowInbound struct {
+	interceptor.WorkflowInboundInterceptorBase
+
+	recorder *transferEventRecorder
+}
+
+func (inbound *transferEventsWorkflowInbound) Init(outbound interceptor.WorkflowOutboundInterceptor) error {
+	inbound.recorder.outbound = outbound
+
+	return inbound.Next.Init(&transferEventsWorkflowOutbound{
+		WorkflowOutboundInterceptorBase: interceptor.WorkflowOutboundInterceptorBase{Next: outbound},
+		recorder:                        inbound.recorder,
+	})
+}
+
+func (inbound *transferEventsWorkflowInbound) ExecuteWorkflow(ctx workflow.Context, in *interceptor.ExecuteWorkflowInput) (interface{}, error) {
+	params, ok := recordedTransferParams(in.Args)
+	if !ok {
+		return inbound.Next.ExecuteWorkflow(ctx, in)
+	}
+
+	inbound.recorder.params = &params
+	inbound.recorder.record(ctx, transferEventStarted, "processing", "", "")
+
+	result, err := inbound.Next.ExecuteWorkflow(ctx, in)
+
+	// Recorded even when the transfer was cancelled
+	disconnectedCtx, cancel := workflow.NewDisconnectedContext(ctx)
+	defer cancel()
+
+	status, errorMessage := finishedTransferStatus(result, err)
+	inbound.recorder.record(disconnectedCtx, transferEventFinished, status, "", errorMessage)
+
+	return result, err
+}
+
+// transferEventsWorkflowOutbound records the outcome of every saga step once its activity has finished
+type transferEventsWorkflowOutbound struct {
+	interceptor.WorkflowOutboundInterceptorBase
+
+	recorder *transferEventRecorder
+}
+
+func (outbound *transferEventsWorkflowOutbound) ExecuteActivity(ctx workflow.Context, activityType string, args ...interface{}) workflow.Future {
+	future := outbound.Next.ExecuteActivity(ctx, activityType, args...)
+	if outbound.recorder.params == nil || !transferEventSteps[activityType] {
+		return future
+	}
+
+	// The workflow sees the step finish only once its outcome has been recorded. The result is passed on still
+	// encoded, so the workflow decodes it as if it came from the activity itself.
+	recorded, settable := workflow.NewFuture(ctx)
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		var result *commonpb.Payloads
+		err := future.Get(ctx, &result)
+		if err != nil {
+			outbound.recorder.record(ctx, transferEventStepFailed, "processing", activityType, err.Error())
+		} else {
+			outbound.recorder.record(ctx, transferEventStepCompleted, "processing", activityType, "")
+		}
+
+		settable.Set(result, err)
+	})
+
+	return recorded
+}
+
+// transferEventRecorder numbers and stores the state changes of one transfer workflow run
+type transferEventRecorder struct {
+	outbound interceptor.WorkflowOutboundInterceptor
+	params   *TransferWorkflowParams // Nil unless the transfer records its events
+	sequence int32
+}
+
+// record stores a state change through the outbound interceptor below the recording one, so recording is not
+// recorded itself
+func (recorder *transferEventRecorder) record(ctx workflow.Context, eventType, status, step, errorMessage string) {
+	params := recorder.params
+	if params == nil {
+		return
+	}
+
+	recorder.sequence++
+
+	logger := workflow.GetLogger(ctx)
+	workflowInfo := workflow.GetInfo(ctx)
+
+	// svc-transaction recovers quickly or not at all, so recording is attempted a few times and then given up
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:        time.Second,
+			BackoffCoefficient:     2,
+			MaximumAttempts:        5,
+			NonRetryableErrorTypes: []string{"INVALID_TRANSFER_EVENT"},
+		},
+	})
+
+	eventParams := map[string]interface{}{
+		"transfer_id":     params.TransferID,
+		"workflow_id":     workflowInfo.WorkflowExecution.ID,
+		"run_id":          workflowInfo.WorkflowExecution.RunID,
+		"sequence_number": recorder.sequence,
+		"event_type":      eventType,
+		"status":          status,
+		"step":            step,
+		"from_account":    params.FromAccount,
+		"to_account":      params.ToAccount,
+		"amount":          params.Amount,
+		"currency":        params.Currency,
+		"description":     params.Description,
+		"error_message":   errorMessage,
+		"occurred_at":     workflow.Now(ctx),
+	}
+
+	if err := recorder.outbound.ExecuteActivity(ctx, recordTransferEventActivity, eventParams).Get(ctx, nil); err != nil {
+		logger.Warn("Failed to record transfer event", "transfer_id", params.TransferID, "event_type", eventType, "sequence_number", recorder.sequence, "error", err)
+	}
+}
+
+// recordedTransferParams returns the transfer of a transfer workflow that records its events
+func recordedTransferParams(args []interface{}) (TransferWorkflowParams, bool) {
+	if len(args) != 1 {
+		return TransferWorkflowParams{}, false
+	}
+
+	var params TransferWorkflowParams
+	switch arg := args[0].(type) {
+	case TransferWorkflowParams:
+		params = arg
+	case ConditionalTransferParams:
+		params = arg.Transfer
+	default:
+		return TransferWorkflowParams{}, false
+	}
+
+	return params, params.RecordEvents
+}
+
+// finishedTransferStatus returns the recorded status of a finished transfer workflow, as reported by
+// mapWorkflowStatus, and its error message
+func finishedTransferStatus(result interface{}, err error) (status, errorMessage string) {
+	results, ok := result.(*TransferWorkflowResults)
+	if !ok || results == nil {
+		if err != nil {
+			return "failed", err.Error()
+		}
+
+		return "failed", ""
+	}
+
+	switch {
+	case results.Status == "completed", results.Status == "expired":
+		return results.Status, results.ErrorMessage
+	case results.CompensationApplied:
+		return "compensated", results.ErrorMessage
+	default:
+		return "failed", results.ErrorMessage
+	}
+}
+
+// recordedTransferStatuses maps the recorded status of a transfer to the status reported by GetTransferStatus
+var recordedTransferStatuses = map[string]string{
+	"processing":  "TRANSFER_STATUS_PROCESSING",
+	"completed":   "TRANSFER_STATUS_COMPLETED",
+	"failed":      "TRANSFER_STATUS_FAILED",
+	"compensated": "TRANSFER_STATUS_COMPENSATED",
+	"expired":     "TRANSFER_STATUS_EXPIRED",
+}
+
+// temporalLookupFailed reports whether a transfer workflow could not be read from Temporal at all: the server is
+// unreachable, or the workflow has been removed from history after the retention period
+func temporalLookupFailed(err error) bool {
+	var notFound *serviceerror.NotFound
+	var unavailable *serviceerror.Unavailable
+	var deadlineExceeded *serviceerror.DeadlineExceeded
+
+	return errors.As(err, &notFound) || errors.As(err, &unavailable) || errors.As(err, &deadlineExceeded)
+}
+
+// recordedTransferStatus reports a transfer from its recorded events when they are enabled. It returns nil when the
+// transfer recorded none or they cannot be read, leaving the caller to report it otherwise.
+func (svc *Service) recordedTransferStatus(ctx context.Context, logger *logrus.Entry, transactionID, transferReference string) *GetTransferStatusResults {
+	if !svc.config.TransferEvents.Enabled {
+		return nil
+	}
+
+	results, err := svc.getRecordedTransferStatus(ctx, transactionID)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to read recorded transfer events")
+
+		return nil
+	}
+	if results == nil {
+		return nil
+	}
+
+	results.TransferReference = transferReference
+
+	logger.WithField("status", results.Status).Info("📊 Transfer status retrieved from recorded transfer events")
+
+	return results
+}
+
+// getRecordedTransferStatus builds the status of a saga transfer from the state changes its workflow recorded in
+// svc-transaction. It returns nil when none were recorded.
+func (svc *Service) getRecordedTransferStatus(ctx context.Context, transactionID string) (*GetTransferStatusResults, error) {
+	events, err := svc.transactionAdapter.ListTransferEvents(ctx, transactionID)
+	if err != nil {
+		var responseErr *transaction_adapter.ResponseError
+		if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return buildRecordedTransferStatus(transactionID, events)
+}
+
+// buildRecordedTransferStatus reports a transfer as its latest recorded event left it
+func buildRecordedTransferStatus(transactionID string, events []transaction_adapter.TransferEventResponse) (*GetTransferStatusResults, error) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	first, latest := events[0], events[len(events)-1]
+
+	amount, err := decimal.NewFromString(latest.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount from svc-transaction: %w", err)
+	}
+
+	amountMinorUnits, err := decimalToMinorUnits(amount, latest.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount from svc-transaction: %w", err)
+	}
+
+	status, ok := recordedTransferStatuses[latest.Status]
+	if !ok {
+		status = "TRANSFER_STATUS_PROCESSING"
+	}
+
+	results := &GetTransferStatusResults{
+		TransactionID: transactionID,
+		Status:        status,
+		FromAccount:   latest.FromAccount,
+		ToAccount:     latest.ToAccount,
+		Amount:        amountMinorUnits,
+		AmountDecimal: formatMajorUnits(amount, latest.Currency),
+		Currency:      latest.Currency,
+		Description:   latest.Description,
+		ReferenceID:   transactionID,
+		CreatedAt:     formatRecordedTime(first.OccurredAt),
+		ErrorMessage:  latest.ErrorMessage,
+	}
+	results.WorkflowExecution.WorkflowID = latest.WorkflowID
+	results.WorkflowExecution.RunID = latest.RunID
+	results.WorkflowExecution.Status = "RUNNING"
+
+	if latest.EventType == transferEventFinished {
+		results.CompletedAt = formatRecordedTime(latest.OccurredAt)
+		results.WorkflowExecution.Status = "COMPLETED"
+	}
+
+	return results, nil
+}
+
+// formatRecordedTime converts a recorded timestamp to the RFC 3339 seconds used by GetTransferStatus
+func formatRecordedTime(value string) string {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return value
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"flowngine/adapter/transaction_adapter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+)
+
+// recordedTransferEvents captures the params of the RecordTransferEvent activity in the order it ran
+type recordedTransferEvents struct {
+	mu     sync.Mutex
+	events []map[string]interface{}
+}
+
+func (recorded *recordedTransferEvents) record(_ context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	recorded.mu.Lock()
+	defer recorded.mu.Unlock()
+
+	recorded.events = append(recorded.events, params)
+
+	return map[string]interface{}{"recorded": true}, nil
+}
+
+// newTransferEventsTestEnv creates a transfer workflow test environment whose worker records transfer events
+func newTransferEventsTestEnv(t *testing.T) (*testsuite.TestWorkflowEnvironment, *recordedTransferEvents) {
+	env := newTransferWorkflowTestEnv(t)
+	env.SetWorkerOptions(worker.Options{
+		Interceptors: []interceptor.WorkerInterceptor{NewTransferEventsInterceptor()},
+	})
+
+	recorded := &recordedTransferEvents{}
+	env.OnActivity(recordTransferEventActivity, mock.Anything, mock.Anything).Return(recorded.record).Maybe()
+
+	return env, recorded
+}
+
+func TestTransferEventsInterceptor(t *testing.T) {
+	t.Parallel()
+
+	sufficientFunds := map[string]interface{}{"sufficient_funds": true}
+	debitResult := map[string]interface{}{"transaction_id": "debit-transaction-123"}
+	creditResult := map[string]interface{}{"transaction_id": "credit-transaction-123"}
+	compensationResult := map[string]interface{}{"transaction_id": "compensation-transaction-123"}
+
+	t.Run("records_successful_transfer", func(t *testing.T) {
+		t.Parallel()
+
+		env, recorded := newTransferEventsTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+
+		params := validTransferWorkflowParams()
+		params.RecordEvents = true
+
+		env.ExecuteWorkflow(transferWorkflow, params)
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		require.Len(t, recorded.events, 4)
+
+		expected := []struct{ eventType, status, step string }{
+			{transferEventStarted, "processing", ""},
+			{transferEventStepCompleted, "processing", "DebitAccount"},
+			{transferEventStepCompleted, "processing", "CreditAccount"},
+			{transferEventFinished, "completed", ""},
+		}
+		for i, want := range expected {
+			event := recorded.events[i]
+			assert.Equal(t, want.eventType, event["event_type"], "event %d", i)
+			assert.Equal(t, want.status, event["status"], "event %d", i)
+			assert.Equal(t, want.step, event["step"], "event %d", i)
+			assert.EqualValues(t, i+1, event["sequence_number"], "event %d", i)
+			assert.Equal(t, "transfer-123", event["transfer_id"])
+			assert.Equal(t, "100", event["amount"])
+		}
+	})
+
+	t.Run("records_compensated_transfer", func(t *testing.T) {
+		t.Parallel()
+
+		env, recorded := newTransferEventsTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(nil, nonRetryableError("ACCOUNT_BLOCKED")).Once()
+		env.OnActivity("CompensateDebit", mock.Anything, mock.Anything).Return(compensationResult, nil).Once()
+
+		params := validTransferWorkflowParams()
+		params.RecordEvents = true
+
+		env.ExecuteWorkflow(transferWorkflow, params)
+
+		require.True(t, env.IsWorkflowCompleted())
+
+		var steps []string
+		for _, event := range recorded.events {
+			steps = append(steps, event["event_type"].(string)+":"+event["step"].(string))
+		}
+		assert.Equal(t, []string{
+			"transfer_started:",
+			"step_completed:DebitAccount",
+			"step_failed:CreditAccount",
+			"step_completed:CompensateDebit",
+			"transfer_finished:",
+		}, steps)
+
+		finished := recorded.events[len(recorded.events)-1]
+		assert.Equal(t, "compensated", finished["status"])
+		assert.NotEmpty(t, finished["error_message"])
+	})
+
+	t.Run("skips_transfers_without_record_events", func(t *testing.T) {
+		t.Parallel()
+
+		env, recorded := newTransferEventsTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+		assert.Empty(t, recorded.events)
+	})
+
+	t.Run("recording_failures_do_not_fail_the_transfer", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.SetWorkerOptions(worker.Options{
+			Interceptors: []interceptor.WorkerInterceptor{NewTransferEventsInterceptor()},
+		})
+		env.OnActivity(recordTransferEventActivity, mock.Anything, mock.Anything).Return(nil, nonRetryableError("INVALID_TRANSFER_EVENT"))
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+
+		params := validTransferWorkflowParams()
+		params.RecordEvents = true
+
+		env.ExecuteWorkflow(transferWorkflow, params)
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "completed", results.Status)
+	})
+}
+
+func TestBuildRecordedTransferStatus(t *testing.T) {
+	t.Parallel()
+
+	started := transaction_adapter.TransferEventResponse{
+		TransferID:     "transfer-123",
+		WorkflowID:     "transfer_workflow_transfer-123",
+		RunID:          "run-1",
+		SequenceNumber: 1,
+		EventType:      transferEventStarted,
+		Status:         "processing",
+		FromAccount:    "ACC001000001",
+		ToAccount:      "ACC001000002",
+		Amount:         "100.5",
+		Currency:       "USD",
+		Description:    "Rent",
+		OccurredAt:     "2026-03-01T09:30:00.123456Z",
+	}
+
+	t.Run("no_events", func(t *testing.T) {
+		t.Parallel()
+
+		results, err := buildRecordedTransferStatus("transfer-123", nil)
+		require.NoError(t, err)
+		assert.Nil(t, results)
+	})
+
+	t.Run("running_transfer", func(t *testing.T) {
+		t.Parallel()
+
+		debited := started
+		debited.SequenceNumber = 2
+		debited.EventType = transferEventStepCompleted
+		debited.Step = "DebitAccount"
+		debited.OccurredAt = "2026-03-01T09:30:01Z"
+
+		results, err := buildRecordedTransferStatus("transfer-123", []transaction_adapter.TransferEventResponse{started, debited})
+		require.NoError(t, err)
+		assert.Equal(t, "TRANSFER_STATUS_PROCESSING", results.Status)
+		assert.Equal(t, int64(10050), results.Amount)
+		assert.Equal(t, "100.50", results.AmountDecimal)
+		assert.Equal(t, "2026-03-01T09:30:00Z", results.CreatedAt)
+		assert.Empty(t, results.CompletedAt)
+		assert.Equal(t, "RUNNING", results.WorkflowExecution.Status)
+	})
+
+	t.Run("finished_transfer", func(t *testing.T) {
+		t.Parallel()
+
+		finished := started
+		finished.SequenceNumber = 5
+		finished.EventType = transferEventFinished
+		finished.Status = "compensated"
+		finished.ErrorMessage = "account blocked"
+		finished.OccurredAt = "2026-03-01T09:30:05Z"
+
+		results, err := buildRecordedTransferStatus("transfer-123", []transaction_adapter.TransferEventResponse{started, finished})
+		require.NoError(t, err)
+		assert.Equal(t, "TRANSFER_STATUS_COMPENSATED", results.Status)
+		assert.Equal(t, "account blocked", results.ErrorMessage)
+		assert.Equal(t, "2026-03-01T09:30:05Z", results.CompletedAt)
+		assert.Equal(t, "COMPLETED", results.WorkflowExecution.Status)
+		assert.Equal(t, "run-1", results.WorkflowExecution.RunID)
+	})
+}
+
+func TestTemporalLookupFailed(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, temporalLookupFailed(serviceerror.NewNotFound("workflow not found")))
+	assert.True(t, temporalLookupFailed(fmt.Errorf("failed to describe workflow: %w", serviceerror.NewUnavailable("connection refused"))))
+	assert.False(t, temporalLookupFailed(errWorkflowRunning))
+	assert.False(t, temporalLookupFailed(errors.New("workflow failed")))
+}
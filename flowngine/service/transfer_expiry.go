@@ -0,0 +1,168 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"flowngine/util/config"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// ApproveTransferSignalName is the signal that releases a transfer held for approval
+const ApproveTransferSignalName = "approve_transfer"
+
+// transferExpiredEventType is the type of the webhook event sent to the callback URL of an expired transfer
+const transferExpiredEventType = "transfer.expired"
+
+// transferExpiriesMetric counts expired transfers through the metrics handler of the worker running the workflow
+const transferExpiriesMetric = "transfer_expiries"
+
+// ApproveTransferSignal is the payload of ApproveTransferSignalName
+type ApproveTransferSignal struct {
+	ApprovedBy string `json:"approved_by"`
+}
+
+// transferExpiry is the window a transfer may wait before its debit. The approval window is measured from the
+// start of the workflow, the funds window from the first balance check that fell short.
+type transferExpiry struct {
+	deadline time.Time // Zero when the transfer never expires
+}
+
+// requiresApproval decides whether a saga transfer is held until it is approved
+func requiresApproval(approval config.TransferApproval, amountMinorUnits int64) bool {
+	return approval.ThresholdAmount > 0 && amountMinorUnits >= approval.ThresholdAmount
+}
+
+// newTransferExpiry starts the expiry window of a transfer workflow
+func newTransferExpiry(ctx workflow.Context, params TransferWorkflowParams) *transferExpiry {
+	expiry := &transferExpiry{}
+	if params.ExpirySeconds > 0 {
+		expiry.deadline = workflow.Now(ctx).Add(time.Duration(params.ExpirySeconds) * time.Second)
+	}
+
+	return expiry
+}
+
+// newTimer returns a timer firing at the deadline, or nil when the transfer never expires
+func (expiry *transferExpiry) newTimer(ctx workflow.Context) workflow.Future {
+	if expiry.deadline.IsZero() {
+		return nil
+	}
+
+	return workflow.NewTimer(ctx, max(expiry.deadline.Sub(workflow.Now(ctx)), 0))
+}
+
+// awaitTransferApproval blocks until the transfer has the approvals it needs through ApproveTransferSignalName,
+// adding each accepted approval to results. Transfers from joint accounts need RequiredApprovals distinct
+// owners, any other held transfer a single approval. It returns false when the expiry window ends first.
+func awaitTransferApproval(ctx workflow.Context, params TransferWorkflowParams, results *TransferWorkflowResults, expiry *transferExpiry, waits *transferWaits) bool {
+	logger := workflow.GetLogger(ctx)
+
+	required := max(params.RequiredApprovals, 1)
+	logger.Info("Transfer held for approval", "transfer_id", params.TransferID, "required_approvals", required, "expires_at", expiry.deadline)
+
+	state := waits.start(ctx, TransferWaitReasonApproval, expiry)
+	state.RequiredApprovals = required
+	defer waits.done()
+
+	timerCtx, stopTimer := workflow.WithCancel(ctx)
+	defer stopTimer()
+
+	expired := false
+	var signal ApproveTransferSignal
+	selector := workflow.NewSelector(ctx)
+	selector.AddReceive(workflow.GetSignalChannel(ctx, ApproveTransferSignalName), func(channel workflow.ReceiveChannel, more bool) {
+		channel.Receive(ctx, &signal)
+	})
+	if timer := expiry.newTimer(timerCtx); timer != nil {
+		selector.AddFuture(timer, func(workflow.Future) { expired = true })
+	}
+
+	for len(results.Approvals) < required {
+		signal = ApproveTransferSignal{}
+		selector.Select(ctx)
+		if expired {
+			return false
+		}
+
+		if accepted, reason := acceptTransferApproval(params, results.Approvals, signal.ApprovedBy); !accepted {
+			logger.Warn("Transfer approval ignored", "transfer_id", params.TransferID, "approved_by", signal.ApprovedBy, "reason", reason)
+			continue
+		}
+
+		approval := TransferApproval{ApprovedBy: signal.ApprovedBy, ApprovedAt: workflow.Now(ctx)}
+		results.Approvals = append(results.Approvals, approval)
+		state.Approvals = results.Approvals
+
+		logger.Info("Transfer approved", "transfer_id", params.TransferID, "approved_by", signal.ApprovedBy, "approvals", len(results.Approvals), "required_approvals", required)
+
+		// Only dual authorization is recorded in svc-transaction, so transfers held before it existed replay unchanged
+		if len(params.Approvers) > 0 {
+			recordTransferApproval(ctx, params, approval)
+		}
+	}
+
+	return true
+}
+
+// expireTransfer marks a transfer that ran out of its expiry window before anything was debited as expired,
+// and notifies the callback URL given when the transfer was requested. A failed notification is logged only,
+// since the transfer has already expired.
+func expireTransfer(ctx workflow.Context, params TransferWorkflowParams, results *TransferWorkflowResults, reason string) {
+	logger := workflow.GetLogger(ctx)
+
+	expiredAt := workflow.Now(ctx)
+	results.Status = "expired"
+	results.ErrorMessage = reason
+	results.CompletedAt = &expiredAt
+
+	workflow.GetMetricsHandler(ctx).Counter(transferExpiriesMetric).Inc(1)
+
+	logger.Warn("Transfer expired", "transfer_id", params.TransferID, "reason", reason)
+
+	if params.CallbackURL == "" {
+		return
+	}
+
+	if err := notifyTransferEvent(ctx, params, transferExpiredEventType, reason, expiredAt); err != nil {
+		logger.Error("Failed to notify transfer expiry", "transfer_id", params.TransferID, "callback_url", params.CallbackURL, "error", err)
+	}
+}
+
+// notifyTransferEvent posts a transfer event to the callback URL of the transfer through the NotifyTransferEvent
+// activity of svc-balance
+func notifyTransferEvent(ctx workflow.Context, params TransferWorkflowParams, eventType, reason string, occurredAt time.Time) error {
+	workflowInfo := workflow.GetInfo(ctx)
+
+	// Webhook receivers are outside our control, so delivery is attempted a few times and then given up
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2,
+			MaximumAttempts:    3,
+		},
+	})
+
+	notifyParams := map[string]interface{}{
+		"destination":  params.CallbackURL,
+		"event_type":   eventType,
+		"transfer_id":  params.TransferID,
+		"from_account": params.FromAccount,
+		"to_account":   params.ToAccount,
+		"amount":       params.Amount,
+		"currency":     params.Currency,
+		"reason":       reason,
+		"occurred_at":  occurredAt,
+		"workflow_id":  workflowInfo.WorkflowExecution.ID,
+		"run_id":       workflowInfo.WorkflowExecution.RunID,
+	}
+
+	if err := workflow.ExecuteActivity(ctx, "NotifyTransferEvent", notifyParams).Get(ctx, nil); err != nil {
+		return fmt.Errorf("failed to deliver %s webhook: %w", eventType, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"flowngine/util/config"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// insufficientFundsErrorType is the application error type svc-balance returns when the balance falls short
+const insufficientFundsErrorType = "INSUFFICIENT_FUNDS"
+
+// fundsWaitEnabled decides whether a transfer requested with wait_for_funds may actually wait. Waiting needs
+// a deadline, so it is disabled when the configured wait is 0.
+func fundsWaitEnabled(fundsWait config.FundsWait, waitForFunds bool) bool {
+	return waitForFunds && fundsWait.MaxWaitSeconds > 0
+}
+
+// checkBalance runs the CheckBalance activity of a transfer. Transfers waiting for funds repeat it with backoff
+// while the balance falls short, so incoming credits can still cover the amount. It reports expired when the
+// balance still falls short at the end of the funds wait.
+func checkBalance(ctx workflow.Context, params TransferWorkflowParams, waits *transferWaits) (balanceResult map[string]interface{}, expired bool, err error) {
+	logger := workflow.GetLogger(ctx)
+	workflowInfo := workflow.GetInfo(ctx)
+
+	balanceCheckParams := map[string]interface{}{
+		"account_id":      params.FromAccount,
+		"required_amount": params.Amount,
+		"currency":        params.Currency,
+		"transfer_id":     params.TransferID,
+		"workflow_id":     workflowInfo.WorkflowExecution.ID,
+		"run_id":          workflowInfo.WorkflowExecution.RunID,
+	}
+
+	defer waits.done()
+
+	var wait *TransferWaitState
+	var expiry *transferExpiry
+	interval := fundsCheckInterval(params)
+
+	for {
+		balanceResult = nil
+		err = workflow.ExecuteActivity(ctx, "CheckBalance", balanceCheckParams).Get(ctx, &balanceResult)
+		if !params.WaitForFunds || !insufficientFunds(balanceResult, err) {
+			return balanceResult, false, err
+		}
+
+		now := workflow.Now(ctx)
+		if wait == nil {
+			// The funds wait starts at the first shortfall, so time spent awaiting approval does not count
+			expiry = &transferExpiry{deadline: now.Add(time.Duration(params.FundsWaitSeconds) * time.Second)}
+			wait = waits.start(ctx, TransferWaitReasonFunds, expiry)
+
+			logger.Info("Waiting for funds", "transfer_id", params.TransferID, "account_id", params.FromAccount, "expires_at", expiry.deadline)
+		}
+		wait.BalanceChecks++
+
+		if !now.Before(expiry.deadline) {
+			return balanceResult, true, err
+		}
+
+		nextCheckAt := now.Add(interval)
+		if nextCheckAt.After(expiry.deadline) {
+			nextCheckAt = expiry.deadline
+		}
+		wait.NextCheckAt = &nextCheckAt
+
+		logger.Info("Balance still short, checking again later", "transfer_id", params.TransferID, "balance_checks", wait.BalanceChecks, "next_check_at", nextCheckAt)
+
+		if err := workflow.Sleep(ctx, nextCheckAt.Sub(now)); err != nil {
+			return nil, false, err
+		}
+
+		interval = nextFundsCheckInterval(params, interval)
+	}
+}
+
+// insufficientFunds reports whether a balance check failed only because the balance falls short of the amount
+func insufficientFunds(balanceResult map[string]interface{}, err error) bool {
+	if err != nil {
+		var applicationErr *temporal.ApplicationError
+		return errors.As(err, &applicationErr) && applicationErr.Type() == insufficientFundsErrorType
+	}
+
+	sufficientFunds, _ := balanceResult["sufficient_funds"].(bool)
+
+	return !sufficientFunds
+}
+
+// fundsCheckInterval is the delay before the first balance re-check, at least a second
+func fundsCheckInterval(params TransferWorkflowParams) time.Duration {
+	return max(time.Duration(params.FundsCheckIntervalSeconds)*time.Second, time.Second)
+}
+
+// nextFundsCheckInterval grows the delay between balance re-checks by the backoff coefficient, up to its cap
+func nextFundsCheckInterval(params TransferWorkflowParams, interval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * max(params.FundsCheckBackoffCoefficient, 1))
+	if params.FundsCheckMaxIntervalSeconds > 0 {
+		next = min(next, time.Duration(params.FundsCheckMaxIntervalSeconds)*time.Second)
+	}
+
+	return next
+}
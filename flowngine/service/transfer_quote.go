@@ -0,0 +1,105 @@
+package service
+
+import (
+	"github.com/shopspring/decimal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// quoteTransferActivity prices the credit of a transfer in the currency of its destination account in svc-balance
+const quoteTransferActivity = "QuoteTransfer"
+
+// TransferFX is the conversion applied to the credit of a transfer to an account in another currency
+type TransferFX struct {
+	Pair              string          `json:"pair"`     // e.g. EUR/USD
+	Side              string          `json:"side"`     // BID when selling the base currency, ASK when buying it
+	Rate              decimal.Decimal `json:"rate"`     // Applied rate, converted currency per transfer currency
+	MidRate           decimal.Decimal `json:"mid_rate"` // Quote currency per base currency
+	SpreadBps         int64           `json:"spread_bps"`
+	MarginBps         int64           `json:"margin_bps"`
+	SpreadAmount      decimal.Decimal `json:"spread_amount"` // Cost of the spread and margin, in the converted currency
+	ConvertedAmount   decimal.Decimal `json:"converted_amount"`
+	ConvertedCurrency string          `json:"converted_currency"`
+}
+
+// transferQuote is the quote svc-balance returned for the credit of a transfer
+type transferQuote struct {
+	ConvertedAmount   decimal.Decimal `json:"converted_amount"`
+	ToCurrency        string          `json:"to_currency"`
+	ExchangeRate      decimal.Decimal `json:"exchange_rate"`
+	ConversionApplied bool            `json:"conversion_applied"`
+	Pair              string          `json:"pair"`
+	MidRate           decimal.Decimal `json:"mid_rate"`
+	Side              string          `json:"side"`
+	SpreadBps         int64           `json:"spread_bps"`
+	MarginBps         int64           `json:"margin_bps"`
+	SpreadAmount      decimal.Decimal `json:"spread_amount"`
+}
+
+// quoteTransfer quotes the credit of a transfer before its debit, so the rate is fixed for the rest of the saga. It
+// returns nil when the destination account holds the transfer currency and the amount is credited as is.
+func quoteTransfer(ctx workflow.Context, params TransferWorkflowParams) (*TransferFX, error) {
+	var quote transferQuote
+	if err := workflow.ExecuteActivity(ctx, quoteTransferActivity, map[string]interface{}{
+		"to_account": params.ToAccount,
+		"amount":     params.Amount,
+		"currency":   params.Currency,
+	}).Get(ctx, &quote); err != nil {
+		return nil, err
+	}
+
+	if !quote.ConversionApplied {
+		return nil, nil
+	}
+
+	return &TransferFX{
+		Pair:              quote.Pair,
+		Side:              quote.Side,
+		Rate:              quote.ExchangeRate,
+		MidRate:           quote.MidRate,
+		SpreadBps:         quote.SpreadBps,
+		MarginBps:         quote.MarginBps,
+		SpreadAmount:      quote.SpreadAmount,
+		ConvertedAmount:   quote.ConvertedAmount,
+		ConvertedCurrency: quote.ToCurrency,
+	}, nil
+}
+
+// TransferFXResults is the conversion applied to a transfer as reported to clients, with amounts in both minor
+// units and major units of the converted currency
+type TransferFXResults struct {
+	Pair                   string `json:"pair"`
+	Side                   string `json:"side"`
+	Rate                   string `json:"rate"`
+	MidRate                string `json:"mid_rate"`
+	SpreadBps              int64  `json:"spread_bps"`
+	MarginBps              int64  `json:"margin_bps"`
+	ConvertedCurrency      string `json:"converted_currency"`
+	ConvertedAmount        int64  `json:"converted_amount"`         // Minor units of ConvertedCurrency
+	ConvertedAmountDecimal string `json:"converted_amount_decimal"` // Major units, e.g. "847.46"
+	SpreadAmount           int64  `json:"spread_amount"`            // Minor units of ConvertedCurrency
+	SpreadAmountDecimal    string `json:"spread_amount_decimal"`    // Major units, e.g. "2.54"
+}
+
+// newTransferFXResults reports the conversion a transfer workflow applied, nil when it applied none
+func newTransferFXResults(fx *TransferFX) *TransferFXResults {
+	if fx == nil {
+		return nil
+	}
+
+	// svc-balance rounds both amounts to the decimal places of the converted currency
+	places := currencyDecimalPlaces[fx.ConvertedCurrency]
+
+	return &TransferFXResults{
+		Pair:                   fx.Pair,
+		Side:                   fx.Side,
+		Rate:                   fx.Rate.String(),
+		MidRate:                fx.MidRate.String(),
+		SpreadBps:              fx.SpreadBps,
+		MarginBps:              fx.MarginBps,
+		ConvertedCurrency:      fx.ConvertedCurrency,
+		ConvertedAmount:        fx.ConvertedAmount.Shift(places).IntPart(),
+		ConvertedAmountDecimal: formatMajorUnits(fx.ConvertedAmount, fx.ConvertedCurrency),
+		SpreadAmount:           fx.SpreadAmount.Shift(places).IntPart(),
+		SpreadAmountDecimal:    formatMajorUnits(fx.SpreadAmount, fx.ConvertedCurrency),
+	}
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransferFXResults(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, newTransferFXResults(nil))
+
+	results := newTransferFXResults(&TransferFX{
+		Pair:              "EUR/USD",
+		Side:              "ASK",
+		Rate:              decimal.RequireFromString("0.8475"),
+		MidRate:           decimal.RequireFromString("1.1765"),
+		SpreadBps:         10,
+		MarginBps:         25,
+		SpreadAmount:      decimal.RequireFromString("2.5"),
+		ConvertedAmount:   decimal.RequireFromString("847.5"),
+		ConvertedCurrency: "EUR",
+	})
+	require.NotNil(t, results)
+	assert.Equal(t, "0.8475", results.Rate)
+	assert.Equal(t, int64(84750), results.ConvertedAmount)
+	assert.Equal(t, "847.50", results.ConvertedAmountDecimal)
+	assert.Equal(t, int64(250), results.SpreadAmount)
+	assert.Equal(t, "2.50", results.SpreadAmountDecimal)
+
+	jpy := newTransferFXResults(&TransferFX{ConvertedAmount: decimal.RequireFromString("15630"), ConvertedCurrency: "JPY"})
+	assert.Equal(t, int64(15630), jpy.ConvertedAmount)
+	assert.Equal(t, "15630", jpy.ConvertedAmountDecimal)
+}
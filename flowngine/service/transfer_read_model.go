@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"flowngine/adapter/transaction_adapter"
+
+	"github.com/shopspring/decimal"
+)
+
+// getReadModelTransferStatus reports a finished saga transfer from the read model svc-transaction projects from
+// the recorded transfer events. It returns nil for transfers that are not in the read model or still running, whose
+// live state (steps, SLA, wait) only Temporal has.
+func (svc *Service) getReadModelTransferStatus(ctx context.Context, transactionID string) (*GetTransferStatusResults, error) {
+	transfer, err := svc.transactionAdapter.GetTransferReadModel(ctx, transactionID)
+	if err != nil {
+		var responseErr *transaction_adapter.ResponseError
+		if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if transfer == nil || transfer.FinishedAt == "" {
+		return nil, nil
+	}
+
+	return buildReadModelTransferStatus(transactionID, transfer)
+}
+
+// buildReadModelTransferStatus converts a finished transfer of the read model to its status
+func buildReadModelTransferStatus(transactionID string, transfer *transaction_adapter.TransferReadModelResponse) (*GetTransferStatusResults, error) {
+	amount, err := decimal.NewFromString(transfer.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount from svc-transaction: %w", err)
+	}
+
+	amountMinorUnits, err := decimalToMinorUnits(amount, transfer.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount from svc-transaction: %w", err)
+	}
+
+	status, ok := recordedTransferStatuses[transfer.Status]
+	if !ok {
+		return nil, fmt.Errorf("unknown transfer status from svc-transaction: %q", transfer.Status)
+	}
+
+	results := &GetTransferStatusResults{
+		TransactionID: transactionID,
+		Status:        status,
+		FromAccount:   transfer.FromAccount,
+		ToAccount:     transfer.ToAccount,
+		Amount:        amountMinorUnits,
+		AmountDecimal: formatMajorUnits(amount, transfer.Currency),
+		Currency:      transfer.Currency,
+		Description:   transfer.Description,
+		ReferenceID:   transactionID,
+		CreatedAt:     formatRecordedTime(transfer.StartedAt),
+		CompletedAt:   formatRecordedTime(transfer.FinishedAt),
+		ErrorMessage:  transfer.ErrorMessage,
+	}
+	results.WorkflowExecution.WorkflowID = transfer.WorkflowID
+	results.WorkflowExecution.RunID = transfer.RunID
+	results.WorkflowExecution.Status = "COMPLETED"
+
+	return results, nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+
+	"flowngine/adapter/transaction_adapter"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildReadModelTransferStatus(t *testing.T) {
+	t.Parallel()
+
+	transfer := &transaction_adapter.TransferReadModelResponse{
+		TransferID:    "transfer-123",
+		WorkflowID:    "transfer_workflow_transfer-123",
+		RunID:         "run-1",
+		Status:        "compensated",
+		LastEventType: transferEventFinished,
+		FromAccount:   "ACC001000001",
+		ToAccount:     "ACC001000002",
+		Amount:        "100.5",
+		Currency:      "USD",
+		Description:   "Rent",
+		ErrorMessage:  "account blocked",
+		StartedAt:     "2026-03-01T09:30:00.123456Z",
+		FinishedAt:    "2026-03-01T09:30:05.5Z",
+	}
+
+	results, err := buildReadModelTransferStatus("transfer-123", transfer)
+	require.NoError(t, err)
+	assert.Equal(t, "TRANSFER_STATUS_COMPENSATED", results.Status)
+	assert.Equal(t, int64(10050), results.Amount)
+	assert.Equal(t, "100.50", results.AmountDecimal)
+	assert.Equal(t, "2026-03-01T09:30:00Z", results.CreatedAt)
+	assert.Equal(t, "2026-03-01T09:30:05Z", results.CompletedAt)
+	assert.Equal(t, "account blocked", results.ErrorMessage)
+	assert.Equal(t, "COMPLETED", results.WorkflowExecution.Status)
+	assert.Equal(t, "run-1", results.WorkflowExecution.RunID)
+
+	transfer.Status = "settled"
+	_, err = buildReadModelTransferStatus("transfer-123", transfer)
+	assert.Error(t, err)
+}
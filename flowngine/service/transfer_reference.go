@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"flowngine/adapter/transaction_adapter"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// assignTransferReference requests the human-friendly reference of a new transfer from svc-transaction.
+// A reference is a convenience for support agents, so failures are logged and the transfer proceeds without one.
+func (svc *Service) assignTransferReference(ctx context.Context, transactionID string) string {
+	const op = "service.Service.assignTransferReference"
+
+	if !svc.config.TransferReference.Enabled {
+		return ""
+	}
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":           op,
+		"transaction_id": transactionID,
+	})
+
+	response, err := svc.transactionAdapter.AssignTransferReference(ctx, &transaction_adapter.AssignTransferReferenceRequest{
+		TransferID: transactionID,
+		BranchCode: svc.config.TransferReference.BranchCode,
+	})
+	if err != nil {
+		logger.WithError(err).Warn("Failed to assign transfer reference, continuing without one")
+
+		return ""
+	}
+
+	return response.Reference
+}
+
+// resolveTransferReference maps the identifier given to GetTransferStatus to a transaction ID.
+// Transaction IDs are UUIDs; anything else is treated as a transfer reference and looked up in svc-transaction.
+func (svc *Service) resolveTransferReference(ctx context.Context, identifier string) (transactionID string, reference string, err error) {
+	if _, err := uuid.Parse(identifier); err == nil || !svc.config.TransferReference.Enabled {
+		return identifier, "", nil
+	}
+
+	response, err := svc.transactionAdapter.GetTransferReference(ctx, identifier)
+	if err != nil {
+		var responseErr *transaction_adapter.ResponseError
+		if errors.As(err, &responseErr) {
+			switch responseErr.StatusCode {
+			case http.StatusNotFound:
+				return "", "", fmt.Errorf("transfer reference not found: %s", identifier)
+			case http.StatusBadRequest:
+				return "", "", fmt.Errorf("invalid transfer reference: %s", responseErr.Message)
+			}
+		}
+
+		return "", "", fmt.Errorf("failed to resolve transfer reference: %w", err)
+	}
+
+	return response.TransferID, response.Reference, nil
+}
+
+// lookupTransferReference returns the reference issued for a transaction, or an empty string if it has none
+func (svc *Service) lookupTransferReference(ctx context.Context, transactionID string) string {
+	if !svc.config.TransferReference.Enabled {
+		return ""
+	}
+
+	response, err := svc.transactionAdapter.GetTransferReferenceByTransferID(ctx, transactionID)
+	if err != nil {
+		return ""
+	}
+
+	return response.Reference
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"flowngine/util/config"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTransferReferencePassesThroughTransactionIDs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		enabled    bool
+		identifier string
+	}{
+		{"UUID is a transaction ID", true, "0f8fad5b-d9cb-469f-a165-70867728950e"},
+		{"References disabled", false, "250314-001-000042-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// No adapter is configured, so any call to svc-transaction would panic
+			svc := &Service{
+				logger: logrus.New(),
+				config: config.Config{TransferReference: config.TransferReference{Enabled: tt.enabled, BranchCode: "001"}},
+			}
+
+			transactionID, reference, err := svc.resolveTransferReference(context.Background(), tt.identifier)
+			require.NoError(t, err)
+			assert.Equal(t, tt.identifier, transactionID)
+			assert.Empty(t, reference)
+		})
+	}
+}
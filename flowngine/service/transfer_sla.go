@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// Values of the TransferSLAStatus search attribute. Transfers within their SLA do not carry it.
+const (
+	TransferSLAStatusDelayed = "DELAYED" // Breached and left to finish
+	TransferSLAStatusAborted = "ABORTED" // Breached and stopped, with any debit reversed
+)
+
+// transferSLABreachedErrorType is the application error type of transfers aborted on an SLA breach
+const transferSLABreachedErrorType = "TRANSFER_SLA_BREACHED"
+
+// transferSLABreachesMetric counts SLA breaches through the metrics handler of the worker running the workflow
+const transferSLABreachesMetric = "transfer_sla_breaches"
+
+// transferSLA tracks the SLA timer of one transfer workflow
+type transferSLA struct {
+	breached bool
+	abort    bool
+	stop     workflow.CancelFunc
+}
+
+// startTransferSLA starts the SLA timer of a transfer workflow. When the timer fires before stop is called,
+// the transfer is marked through the TransferSLAStatus search attribute and an alert is logged and counted.
+// Activities in flight are left to finish, so aborting only takes effect at the next step of the saga.
+func startTransferSLA(ctx workflow.Context, params TransferWorkflowParams, results *TransferWorkflowResults) *transferSLA {
+	sla := &transferSLA{stop: func() {}}
+	if params.SLASeconds <= 0 {
+		return sla
+	}
+
+	timerCtx, stop := workflow.WithCancel(ctx)
+	sla.stop = stop
+
+	timeout := time.Duration(params.SLASeconds) * time.Second
+	timer := workflow.NewTimer(timerCtx, timeout)
+
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		if err := timer.Get(ctx, nil); err != nil {
+			return // Stopped when the transfer finished in time
+		}
+
+		sla.breached = true
+		sla.abort = params.AbortOnSLABreach
+		results.SLABreached = true
+
+		status := TransferSLAStatusDelayed
+		if sla.abort {
+			status = TransferSLAStatusAborted
+		}
+
+		logger := workflow.GetLogger(ctx)
+		if err := workflow.UpsertTypedSearchAttributes(ctx, slaStatusSearchAttribute.ValueSet(status)); err != nil {
+			logger.Error("Failed to mark transfer SLA breach", "error", err)
+		}
+
+		workflow.GetMetricsHandler(ctx).WithTags(map[string]string{"sla_status": status}).Counter(transferSLABreachesMetric).Inc(1)
+
+		logger.Warn("ALERT: transfer SLA breached",
+			"alert", "transfer_sla_breached",
+			"transfer_id", params.TransferID,
+			"sla", timeout,
+			"sla_status", status)
+	})
+
+	return sla
+}
+
+// aborted reports whether the saga must stop before its next step
+func (sla *transferSLA) aborted() bool {
+	return sla.breached && sla.abort
+}
+
+// newTransferSLABreachedError is returned by transfer workflows aborted on an SLA breach
+func newTransferSLABreachedError(params TransferWorkflowParams) error {
+	return temporal.NewNonRetryableApplicationError(
+		fmt.Sprintf("transfer exceeded its SLA of %ds", params.SLASeconds),
+		transferSLABreachedErrorType,
+		nil,
+	)
+}
+
+type GetTransferSLAStatsResults struct {
+	SLASeconds int   `json:"sla_seconds"`
+	Breached   int64 `json:"breached"` // Transfers that breached their SLA, within the visibility retention
+	Delayed    int64 `json:"delayed"`  // Breached transfers still running
+	Aborted    int64 `json:"aborted"`  // Breached transfers stopped with their debit reversed
+}
+
+// GetTransferSLAStats counts transfer workflows by their TransferSLAStatus search attribute
+func (svc *Service) GetTransferSLAStats(ctx context.Context) (*GetTransferSLAStatsResults, error) {
+	const op = "service.Service.GetTransferSLAStats"
+
+	logger := svc.logger.WithField("[op]", op)
+
+	if svc.temporalClient == nil {
+		err := ErrTemporalUnavailable
+
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	results := &GetTransferSLAStatsResults{SLASeconds: svc.config.TransferSLA.TimeoutSeconds}
+
+	name := slaStatusSearchAttribute.GetName()
+	for query, target := range map[string]*int64{
+		fmt.Sprintf("%s IN ('%s', '%s')", name, TransferSLAStatusDelayed, TransferSLAStatusAborted): &results.Breached,
+		fmt.Sprintf("%s = '%s' AND ExecutionStatus = 'Running'", name, TransferSLAStatusDelayed):    &results.Delayed,
+		fmt.Sprintf("%s = '%s'", name, TransferSLAStatusAborted):                                    &results.Aborted,
+	} {
+		response, err := svc.temporalClient.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+			Query: transferWorkflowTypes + " AND " + query,
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to count transfer workflows: %w", err)
+
+			logger.WithError(err).Error()
+
+			return nil, err
+		}
+		*target = response.GetCount()
+	}
+
+	logger.WithFields(logrus.Fields{
+		"breached": results.Breached,
+		"delayed":  results.Delayed,
+		"aborted":  results.Aborted,
+	}).Debug()
+
+	return results, nil
+}
+
+// transferSLAStatus returns the TransferSLAStatus of a transfer workflow, empty while it is within its SLA
+func (svc *Service) transferSLAStatus(ctx context.Context, workflowID string) (string, error) {
+	response, err := svc.temporalClient.DescribeWorkflowExecution(ctx, workflowID, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to describe workflow: %w", err)
+	}
+
+	payload, ok := response.GetWorkflowExecutionInfo().GetSearchAttributes().GetIndexedFields()[slaStatusSearchAttribute.GetName()]
+	if !ok {
+		return "", nil
+	}
+
+	var status string
+	if err := converter.GetDefaultDataConverter().FromPayload(payload, &status); err != nil {
+		return "", fmt.Errorf("failed to decode %s: %w", slaStatusSearchAttribute.GetName(), err)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/workflow"
+)
+
+// TransferRequestedSignalName is sent with every start of a transfer workflow. A retried ExecuteTransfer that
+// races its first attempt signals the workflow that attempt started instead of failing to start a second one.
+const TransferRequestedSignalName = "transfer_requested"
+
+// TransferRequestedSignal is the payload of TransferRequestedSignalName
+type TransferRequestedSignal struct {
+	RequestID   string    `json:"request_id"`
+	AttemptID   string    `json:"attempt_id"` // Tells apart the attempts of a request racing each other to the start
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// Policies for an ExecuteTransfer whose transfer workflow exists already, i.e. a retry or replay of its request_id
+const (
+	// WorkflowIDConflictUseExisting attaches the request to the existing workflow and reports its transfer
+	WorkflowIDConflictUseExisting = "use_existing"
+	// WorkflowIDConflictRejectDuplicate fails the request with ErrTransferAlreadyExists
+	WorkflowIDConflictRejectDuplicate = "reject_duplicate"
+)
+
+// ErrTransferAlreadyExists is returned under the reject_duplicate policy for a request whose transfer was started
+// already. The error is a *TransferExistsError naming the original transfer.
+var ErrTransferAlreadyExists = errors.New("transfer already exists")
+
+// TransferExistsError reports the transfer an earlier request with the same request_id started
+type TransferExistsError struct {
+	TransactionID string
+	WorkflowID    string
+}
+
+func (e *TransferExistsError) Error() string {
+	return fmt.Sprintf("%s: transaction %s was started for this request_id", ErrTransferAlreadyExists, e.TransactionID)
+}
+
+func (e *TransferExistsError) Is(target error) bool {
+	return target == ErrTransferAlreadyExists
+}
+
+// transferTransactionIDNamespace scopes the transaction IDs derived from request IDs
+var transferTransactionIDNamespace = uuid.MustParse("4f1c2a8e-7d35-4b6a-9e0f-2c8d5b71a3e4")
+
+// transferTransactionID derives the transaction ID of a transfer from the ID of the request that executes it, so
+// every retry of a request, e.g. by the gateway after a timeout, addresses the same transfer workflow
+func transferTransactionID(requestID string) string {
+	return uuid.NewSHA1(transferTransactionIDNamespace, []byte(requestID)).String()
+}
+
+// transferWorkflowIDPrefix tells transfer workflows apart from the other workflows of the namespace
+const transferWorkflowIDPrefix = "transfer_workflow_"
+
+// transferWorkflowID is the ID of the workflow of a transfer: its transaction ID with transferWorkflowIDPrefix.
+// Standalone transfers and the transfers of a batch share the scheme, so every transfer is addressed the same way
+// whichever started it.
+func transferWorkflowID(transactionID string) string {
+	return transferWorkflowIDPrefix + transactionID
+}
+
+// transferTransactionIDOf returns the transaction ID of a transfer workflow
+func transferTransactionIDOf(workflowID string) string {
+	return strings.TrimPrefix(workflowID, transferWorkflowIDPrefix)
+}
+
+// startTransferWorkflow starts a transfer workflow, or resolves the conflict with the one an earlier attempt of the
+// same request started according to the workflow ID conflict policy. Workflow IDs are never reused, so a finished
+// transfer is never executed again.
+//
+// Under use_existing, a workflow that exists already, running or finished, is returned with attached set rather
+// than failing with "workflow already started"; signal-with-start covers attempts racing each other to the start,
+// and the attempts whose signal was not the first of the run are attached. Under reject_duplicate, the start fails
+// with a *TransferExistsError instead.
+func (svc *Service) startTransferWorkflow(ctx context.Context, options client.StartWorkflowOptions, requestID string, workflowFunc, workflowArgs any) (workflowRun client.WorkflowRun, attached bool, err error) {
+	if svc.config.WorkflowIDConflict.Policy == WorkflowIDConflictRejectDuplicate {
+		// Without it a running workflow is returned as if it had just been started
+		options.WorkflowExecutionErrorWhenAlreadyStarted = true
+
+		workflowRun, err = svc.temporalClient.ExecuteWorkflow(ctx, options, workflowFunc, workflowArgs)
+		if err != nil {
+			var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+			if errors.As(err, &alreadyStarted) {
+				return nil, false, &TransferExistsError{TransactionID: transferTransactionIDOf(options.ID), WorkflowID: options.ID}
+			}
+
+			return nil, false, err
+		}
+
+		return workflowRun, false, nil
+	}
+
+	_, err = svc.temporalClient.DescribeWorkflowExecution(ctx, options.ID, "")
+	if err == nil {
+		return svc.temporalClient.GetWorkflow(ctx, options.ID, ""), true, nil
+	}
+
+	var notFound *serviceerror.NotFound
+	if !errors.As(err, &notFound) {
+		return nil, false, fmt.Errorf("failed to look up existing workflow: %w", err)
+	}
+
+	signal := TransferRequestedSignal{RequestID: requestID, AttemptID: uuid.NewString(), RequestedAt: time.Now()}
+
+	workflowRun, err = svc.temporalClient.SignalWithStartWorkflow(ctx, options.ID, TransferRequestedSignalName, signal, options, workflowFunc, workflowArgs)
+	if err != nil {
+		// Started and finished by another attempt since the lookup
+		var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+		if errors.As(err, &alreadyStarted) {
+			return svc.temporalClient.GetWorkflow(ctx, options.ID, ""), true, nil
+		}
+
+		return nil, false, err
+	}
+
+	// Signal-with-start also succeeds on the run a concurrent attempt has just started
+	started, err := svc.startedTransferWorkflow(ctx, options.ID, workflowRun.GetRunID(), signal.AttemptID)
+	if err != nil {
+		// The run exists either way; it is reported as started, as it was before the check
+		svc.logger.WithError(err).WithField("workflow_id", options.ID).Warn("Failed to tell a started transfer workflow from an attached one")
+
+		return workflowRun, false, nil
+	}
+
+	return workflowRun, !started, nil
+}
+
+// startedTransferWorkflow reports whether a run of a transfer workflow was started by the attempt with attemptID.
+// Signal-with-start records the signal of the attempt that starts a run right after its start event, so the first
+// transfer request of the history is the starting one.
+func (svc *Service) startedTransferWorkflow(ctx context.Context, workflowID, runID, attemptID string) (bool, error) {
+	iterator := svc.temporalClient.GetWorkflowHistory(ctx, workflowID, runID, false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+
+	for iterator.HasNext() {
+		event, err := iterator.Next()
+		if err != nil {
+			return false, fmt.Errorf("failed to read workflow history: %w", err)
+		}
+
+		attributes := event.GetWorkflowExecutionSignaledEventAttributes()
+		if attributes.GetSignalName() != TransferRequestedSignalName {
+			continue
+		}
+
+		var request TransferRequestedSignal
+		if err := converter.GetDefaultDataConverter().FromPayloads(attributes.GetInput(), &request); err != nil {
+			return false, fmt.Errorf("failed to decode transfer request: %w", err)
+		}
+
+		return request.AttemptID == attemptID, nil
+	}
+
+	return false, fmt.Errorf("no %s signal in the history of run %s", TransferRequestedSignalName, runID)
+}
+
+// attachedTransferStatus reports the current state of a transfer an earlier attempt of the request started
+func (svc *Service) attachedTransferStatus(ctx context.Context, results *ExecuteTransferResults) error {
+	status, err := svc.GetTransferStatus(ctx, &GetTransferStatusParams{TransactionID: results.TransactionID})
+	if err != nil {
+		return err
+	}
+
+	results.Status = status.Status
+	results.CreatedAt = status.CreatedAt
+	results.ErrorMessage = status.ErrorMessage
+	if status.CompletedAt != "" {
+		results.CompletedAt = &status.CompletedAt
+	}
+	if status.TransferReference != "" {
+		results.TransferReference = status.TransferReference
+	}
+
+	return nil
+}
+
+// receiveTransferRequests logs the requests that started or attached to a transfer workflow. Draining the signal
+// keeps it from being reported as unhandled when the workflow completes.
+func receiveTransferRequests(ctx workflow.Context) {
+	logger := workflow.GetLogger(ctx)
+
+	requests := workflow.GetSignalChannel(ctx, TransferRequestedSignalName)
+
+	workflow.Go(ctx, func(ctx workflow.Context) {
+		for {
+			var request TransferRequestedSignal
+			requests.Receive(ctx, &request)
+
+			logger.Info("Transfer requested", "request_id", request.RequestID, "requested_at", request.RequestedAt)
+		}
+	})
+}
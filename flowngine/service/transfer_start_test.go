@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/mocks"
+)
+
+// transferHistory iterates the history events of a fake workflow run
+type transferHistory struct {
+	events []*history.HistoryEvent
+}
+
+func (it *transferHistory) HasNext() bool {
+	return len(it.events) > 0
+}
+
+func (it *transferHistory) Next() (*history.HistoryEvent, error) {
+	event := it.events[0]
+	it.events = it.events[1:]
+
+	return event, nil
+}
+
+// onTransferHistory answers GetWorkflowHistory with a run whose first transfer request is the signal of the latest
+// SignalWithStartWorkflow call, i.e. a run that call started, or with one a racing attempt started
+func onTransferHistory(t *testing.T, temporalClient *mocks.Client, startedByRacingAttempt bool) {
+	t.Helper()
+
+	temporalClient.On("GetWorkflowHistory", mock.Anything, mock.Anything, mock.Anything, false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT).Return(
+		func(ctx context.Context, workflowID, runID string, isLongPoll bool, filterType enums.HistoryEventFilterType) client.HistoryEventIterator {
+			var signal TransferRequestedSignal
+			for _, call := range temporalClient.Calls {
+				if call.Method == "SignalWithStartWorkflow" {
+					signal = call.Arguments.Get(3).(TransferRequestedSignal)
+				}
+			}
+			if startedByRacingAttempt {
+				signal.AttemptID = uuid.NewString()
+			}
+
+			input, err := converter.GetDefaultDataConverter().ToPayloads(signal)
+			require.NoError(t, err)
+
+			return &transferHistory{events: []*history.HistoryEvent{
+				{EventId: 1, EventType: enums.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED},
+				{EventId: 2, EventType: enums.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED, Attributes: &history.HistoryEvent_WorkflowExecutionSignaledEventAttributes{
+					WorkflowExecutionSignaledEventAttributes: &history.WorkflowExecutionSignaledEventAttributes{SignalName: TransferRequestedSignalName, Input: input},
+				}},
+			}}
+		})
+}
+
+func TestTransferTransactionID(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, transferTransactionID("req-1"), transferTransactionID("req-1"), "retries address the same transfer")
+	assert.NotEqual(t, transferTransactionID("req-1"), transferTransactionID("req-2"))
+}
+
+func TestExecuteTransfer_StartOrAttach(t *testing.T) {
+	t.Parallel()
+
+	params := func() *ExecuteTransferParams {
+		return &ExecuteTransferParams{FromAccount: "ACC001000001", ToAccount: "ACC001000002", Amount: 1000, Currency: "USD", RequestID: "req-1"}
+	}
+	workflowID := "transfer_workflow_" + transferTransactionID("req-1")
+	anyStart := []interface{}{mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything}
+
+	t.Run("starts_new_transfer", func(t *testing.T) {
+		t.Parallel()
+
+		run := &mocks.WorkflowRun{}
+		run.On("GetRunID").Return("run-1")
+
+		temporalClient := &mocks.Client{}
+		temporalClient.On("DescribeWorkflowExecution", mock.Anything, workflowID, "").Return(nil, serviceerror.NewNotFound("workflow not found"))
+		temporalClient.On("SignalWithStartWorkflow", mock.Anything, workflowID, TransferRequestedSignalName, mock.MatchedBy(func(signal TransferRequestedSignal) bool {
+			return signal.RequestID == "req-1"
+		}), mock.MatchedBy(func(options client.StartWorkflowOptions) bool {
+			return options.WorkflowIDReusePolicy == enums.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE
+		}), mock.Anything, mock.Anything).Return(run, nil).Once()
+		onTransferHistory(t, temporalClient, false)
+
+		svc := newFailureSimulationTestService(temporalClient)
+		svc.SetFailureSimulationEnabled(false)
+
+		results, err := svc.ExecuteTransfer(context.Background(), params())
+		require.NoError(t, err)
+		assert.Equal(t, transferTransactionID("req-1"), results.TransactionID)
+		assert.Equal(t, "TRANSFER_STATUS_PENDING", results.Status, "the run this attempt started is not reported as attached")
+		temporalClient.AssertExpectations(t)
+	})
+
+	t.Run("attaches_to_existing_transfer", func(t *testing.T) {
+		t.Parallel()
+
+		run := &mocks.WorkflowRun{}
+		run.On("GetRunID").Return("run-1")
+		run.On("Get", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(1).(*TransferWorkflowResults) = TransferWorkflowResults{TransferID: transferTransactionID("req-1"), Status: "completed"}
+		}).Return(nil)
+
+		temporalClient := &mocks.Client{}
+		temporalClient.On("DescribeWorkflowExecution", mock.Anything, workflowID, "").Return(&workflowservice.DescribeWorkflowExecutionResponse{}, nil)
+		temporalClient.On("GetWorkflow", mock.Anything, workflowID, "").Return(run)
+
+		svc := newFailureSimulationTestService(temporalClient)
+		svc.SetFailureSimulationEnabled(false)
+
+		retry := params()
+		retry.WaitForCompletion = true
+
+		results, err := svc.ExecuteTransfer(context.Background(), retry)
+		require.NoError(t, err)
+		assert.Equal(t, "run-1", results.RunID)
+		assert.Equal(t, "TRANSFER_STATUS_COMPLETED", results.Status)
+		temporalClient.AssertNotCalled(t, "SignalWithStartWorkflow", anyStart...)
+	})
+
+	t.Run("attaches_when_finished_since_lookup", func(t *testing.T) {
+		t.Parallel()
+
+		run := &mocks.WorkflowRun{}
+		run.On("GetRunID").Return("run-1")
+
+		temporalClient := &mocks.Client{}
+		temporalClient.On("DescribeWorkflowExecution", mock.Anything, workflowID, "").Return(nil, serviceerror.NewNotFound("workflow not found"))
+		temporalClient.On("SignalWithStartWorkflow", anyStart...).Return(nil, serviceerror.NewWorkflowExecutionAlreadyStarted("workflow already finished", "", "run-1"))
+		temporalClient.On("GetWorkflow", mock.Anything, workflowID, "").Return(run)
+
+		svc := newFailureSimulationTestService(temporalClient)
+
+		workflowRun, attached, err := svc.startTransferWorkflow(context.Background(), client.StartWorkflowOptions{ID: workflowID}, "req-1", transferWorkflow, TransferWorkflowParams{})
+		require.NoError(t, err)
+		assert.True(t, attached)
+		assert.Equal(t, "run-1", workflowRun.GetRunID())
+	})
+
+	t.Run("attaches_to_transfer_racing_attempt_started", func(t *testing.T) {
+		t.Parallel()
+
+		run := &mocks.WorkflowRun{}
+		run.On("GetRunID").Return("run-1")
+
+		// Both attempts found no workflow; the other one's signal-with-start started it first
+		temporalClient := &mocks.Client{}
+		temporalClient.On("DescribeWorkflowExecution", mock.Anything, workflowID, "").Return(nil, serviceerror.NewNotFound("workflow not found"))
+		temporalClient.On("SignalWithStartWorkflow", anyStart...).Return(run, nil)
+		onTransferHistory(t, temporalClient, true)
+
+		svc := newFailureSimulationTestService(temporalClient)
+
+		workflowRun, attached, err := svc.startTransferWorkflow(context.Background(), client.StartWorkflowOptions{ID: workflowID}, "req-1", transferWorkflow, TransferWorkflowParams{})
+		require.NoError(t, err)
+		assert.True(t, attached)
+		assert.Equal(t, "run-1", workflowRun.GetRunID())
+		temporalClient.AssertCalled(t, "GetWorkflowHistory", mock.Anything, workflowID, "run-1", false, enums.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	})
+
+	t.Run("rejects_duplicate", func(t *testing.T) {
+		t.Parallel()
+
+		temporalClient := &mocks.Client{}
+		temporalClient.On("ExecuteWorkflow", mock.Anything, mock.MatchedBy(func(options client.StartWorkflowOptions) bool {
+			return options.ID == workflowID && options.WorkflowExecutionErrorWhenAlreadyStarted
+		}), mock.Anything, mock.Anything).Return(nil, serviceerror.NewWorkflowExecutionAlreadyStarted("workflow already started", "", "run-1"))
+
+		svc := newFailureSimulationTestService(temporalClient)
+		svc.SetFailureSimulationEnabled(false)
+		svc.config.WorkflowIDConflict.Policy = WorkflowIDConflictRejectDuplicate
+
+		_, err := svc.ExecuteTransfer(context.Background(), params())
+		require.ErrorIs(t, err, ErrTransferAlreadyExists)
+
+		var exists *TransferExistsError
+		require.ErrorAs(t, err, &exists)
+		assert.Equal(t, transferTransactionID("req-1"), exists.TransactionID)
+		assert.Equal(t, workflowID, exists.WorkflowID)
+		temporalClient.AssertNotCalled(t, "SignalWithStartWorkflow", anyStart...)
+	})
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/workflow/v1"
+)
+
+// TransferStep is one activity of the transfer saga with its attempt count, so retries can be shown to users
+type TransferStep struct {
+	Activity     string `json:"activity"`
+	Status       string `json:"status"` // scheduled, running, retrying, completed, failed, timed_out or cancelled
+	Attempts     int32  `json:"attempts"`
+	LastError    string `json:"last_error,omitempty"`
+	Compensation bool   `json:"compensation"`
+	Summary      string `json:"summary"`
+}
+
+// getTransferSteps reads the saga steps of a transfer workflow from its history.
+// The history only records an activity's attempts once it finishes, so for a running workflow
+// the attempts of in-flight activities are taken from its pending activities.
+func (svc *Service) getTransferSteps(ctx context.Context, workflowID string, running bool) ([]TransferStep, error) {
+	events, err := svc.readWorkflowHistory(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*workflow.PendingActivityInfo
+	if running {
+		description, err := svc.temporalClient.DescribeWorkflowExecution(ctx, workflowID, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe workflow: %w", err)
+		}
+
+		pending = description.GetPendingActivities()
+	}
+
+	return buildTransferSteps(events, pending), nil
+}
+
+// buildTransferSteps turns activity history events into one step per scheduled activity, in scheduling order
+func buildTransferSteps(events []*history.HistoryEvent, pending []*workflow.PendingActivityInfo) []TransferStep {
+	steps := []TransferStep{}
+	index := map[int64]int{} // Scheduled event ID -> position in steps
+
+	for _, event := range events {
+		switch event.GetEventType() {
+		case enums.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+			activity := event.GetActivityTaskScheduledEventAttributes().GetActivityType().GetName()
+
+			index[event.GetEventId()] = len(steps)
+			steps = append(steps, TransferStep{
+				Activity:     activity,
+				Status:       "scheduled",
+				Compensation: compensationActivities[activity],
+			})
+
+		case enums.EVENT_TYPE_ACTIVITY_TASK_STARTED:
+			attributes := event.GetActivityTaskStartedEventAttributes()
+			if i, ok := index[attributes.GetScheduledEventId()]; ok {
+				steps[i].Status = "running"
+				steps[i].Attempts = attributes.GetAttempt()
+				steps[i].LastError = attributes.GetLastFailure().GetMessage()
+			}
+
+		case enums.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+			if i, ok := index[event.GetActivityTaskCompletedEventAttributes().GetScheduledEventId()]; ok {
+				steps[i].Status = "completed"
+			}
+
+		case enums.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+			attributes := event.GetActivityTaskFailedEventAttributes()
+			if i, ok := index[attributes.GetScheduledEventId()]; ok {
+				steps[i].Status = "failed"
+				steps[i].LastError = attributes.GetFailure().GetMessage()
+			}
+
+		case enums.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+			attributes := event.GetActivityTaskTimedOutEventAttributes()
+			if i, ok := index[attributes.GetScheduledEventId()]; ok {
+				steps[i].Status = "timed_out"
+				steps[i].LastError = attributes.GetFailure().GetMessage()
+			}
+
+		case enums.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+			if i, ok := index[event.GetActivityTaskCanceledEventAttributes().GetScheduledEventId()]; ok {
+				steps[i].Status = "cancelled"
+			}
+		}
+	}
+
+	// Activities run one at a time, so a pending activity is the unfinished step of the same type
+	for _, activity := range pending {
+		for i := range steps {
+			if steps[i].Activity != activity.GetActivityType().GetName() || (steps[i].Status != "scheduled" && steps[i].Status != "running") {
+				continue
+			}
+
+			steps[i].Attempts = activity.GetAttempt()
+			steps[i].LastError = activity.GetLastFailure().GetMessage()
+			if steps[i].Attempts > 1 {
+				steps[i].Status = "retrying"
+			} else if activity.GetState() == enums.PENDING_ACTIVITY_STATE_STARTED {
+				steps[i].Status = "running"
+			}
+		}
+	}
+
+	for i := range steps {
+		steps[i].Summary = summarizeTransferStep(steps[i])
+	}
+
+	return steps
+}
+
+// summarizeTransferStep describes a step for demo UIs, e.g. "DebitAccount completed on attempt 3 after: simulated failure"
+func summarizeTransferStep(step TransferStep) string {
+	switch {
+	case step.Status == "scheduled":
+		return fmt.Sprintf("%s waiting to start", step.Activity)
+	case step.Status == "retrying":
+		return fmt.Sprintf("%s retrying, attempt %d after: %s", step.Activity, step.Attempts, step.LastError)
+	case step.Status == "failed" || step.Status == "timed_out":
+		return fmt.Sprintf("%s %s on attempt %d: %s", step.Activity, strings.ReplaceAll(step.Status, "_", " "), step.Attempts, step.LastError)
+	case step.Attempts > 1 && step.LastError != "":
+		return fmt.Sprintf("%s %s on attempt %d after: %s", step.Activity, step.Status, step.Attempts, step.LastError)
+	default:
+		return fmt.Sprintf("%s %s on attempt %d", step.Activity, step.Status, step.Attempts)
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/failure/v1"
+	"go.temporal.io/api/history/v1"
+	"go.temporal.io/api/workflow/v1"
+)
+
+func TestBuildTransferSteps(t *testing.T) {
+	t.Parallel()
+
+	scheduled := func(id int64, activity string) *history.HistoryEvent {
+		return &history.HistoryEvent{
+			EventId:   id,
+			EventType: enums.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED,
+			Attributes: &history.HistoryEvent_ActivityTaskScheduledEventAttributes{
+				ActivityTaskScheduledEventAttributes: &history.ActivityTaskScheduledEventAttributes{ActivityType: &common.ActivityType{Name: activity}},
+			},
+		}
+	}
+	started := func(id, scheduledID int64, attempt int32, lastFailure string) *history.HistoryEvent {
+		attributes := &history.ActivityTaskStartedEventAttributes{ScheduledEventId: scheduledID, Attempt: attempt}
+		if lastFailure != "" {
+			attributes.LastFailure = &failure.Failure{Message: lastFailure}
+		}
+		return &history.HistoryEvent{
+			EventId:    id,
+			EventType:  enums.EVENT_TYPE_ACTIVITY_TASK_STARTED,
+			Attributes: &history.HistoryEvent_ActivityTaskStartedEventAttributes{ActivityTaskStartedEventAttributes: attributes},
+		}
+	}
+	completed := func(id, scheduledID int64) *history.HistoryEvent {
+		return &history.HistoryEvent{
+			EventId:   id,
+			EventType: enums.EVENT_TYPE_ACTIVITY_TASK_COMPLETED,
+			Attributes: &history.HistoryEvent_ActivityTaskCompletedEventAttributes{
+				ActivityTaskCompletedEventAttributes: &history.ActivityTaskCompletedEventAttributes{ScheduledEventId: scheduledID},
+			},
+		}
+	}
+	failed := func(id, scheduledID int64, message string) *history.HistoryEvent {
+		return &history.HistoryEvent{
+			EventId:   id,
+			EventType: enums.EVENT_TYPE_ACTIVITY_TASK_FAILED,
+			Attributes: &history.HistoryEvent_ActivityTaskFailedEventAttributes{
+				ActivityTaskFailedEventAttributes: &history.ActivityTaskFailedEventAttributes{ScheduledEventId: scheduledID, Failure: &failure.Failure{Message: message}},
+			},
+		}
+	}
+
+	t.Run("finished workflow", func(t *testing.T) {
+		t.Parallel()
+
+		steps := buildTransferSteps([]*history.HistoryEvent{
+			scheduled(5, "CheckBalance"),
+			started(6, 5, 1, ""),
+			completed(7, 5),
+			scheduled(8, "DebitAccount"),
+			started(9, 8, 3, "simulated failure"),
+			completed(10, 8),
+			scheduled(11, "CreditAccount"),
+			started(12, 11, 1, ""),
+			failed(13, 11, "account closed"),
+			scheduled(14, "CompensateDebit"),
+			started(15, 14, 1, ""),
+			completed(16, 14),
+		}, nil)
+
+		assert.Equal(t, []TransferStep{
+			{Activity: "CheckBalance", Status: "completed", Attempts: 1, Summary: "CheckBalance completed on attempt 1"},
+			{Activity: "DebitAccount", Status: "completed", Attempts: 3, LastError: "simulated failure", Summary: "DebitAccount completed on attempt 3 after: simulated failure"},
+			{Activity: "CreditAccount", Status: "failed", Attempts: 1, LastError: "account closed", Summary: "CreditAccount failed on attempt 1: account closed"},
+			{Activity: "CompensateDebit", Status: "completed", Attempts: 1, Compensation: true, Summary: "CompensateDebit completed on attempt 1"},
+		}, steps)
+	})
+
+	t.Run("running workflow takes attempts from pending activities", func(t *testing.T) {
+		t.Parallel()
+
+		steps := buildTransferSteps([]*history.HistoryEvent{
+			scheduled(5, "CheckBalance"),
+			started(6, 5, 1, ""),
+			completed(7, 5),
+			scheduled(8, "DebitAccount"),
+		}, []*workflow.PendingActivityInfo{{
+			ActivityType: &common.ActivityType{Name: "DebitAccount"},
+			State:        enums.PENDING_ACTIVITY_STATE_SCHEDULED,
+			Attempt:      2,
+			LastFailure:  &failure.Failure{Message: "simulated failure"},
+		}})
+
+		assert.Len(t, steps, 2)
+		assert.Equal(t, TransferStep{
+			Activity:  "DebitAccount",
+			Status:    "retrying",
+			Attempts:  2,
+			LastError: "simulated failure",
+			Summary:   "DebitAccount retrying, attempt 2 after: simulated failure",
+		}, steps[1])
+	})
+
+	t.Run("no activities", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []TransferStep{}, buildTransferSteps(nil, nil))
+	})
+}
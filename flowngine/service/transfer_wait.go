@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.temporal.io/sdk/workflow"
+)
+
+// TransferWaitQueryType is the query that reports what a running transfer workflow is waiting for
+const TransferWaitQueryType = "transfer_wait"
+
+// transferWaitQueryTimeout bounds the query, which blocks while no worker picks up the workflow
+const transferWaitQueryTimeout = 5 * time.Second
+
+// Reasons a transfer waits before its debit
+const (
+	TransferWaitReasonApproval = "approval" // Held until ApproveTransferSignalName
+	TransferWaitReasonFunds    = "funds"    // Re-checking the balance until it covers the amount
+	TransferWaitReasonTrigger  = "trigger"  // Adding up IncomingCreditSignalName until the trigger amount is reached
+)
+
+// TransferWaitState is the result of TransferWaitQueryType. It is nil while the transfer is not waiting.
+type TransferWaitState struct {
+	Reason         string     `json:"reason"`
+	Since          time.Time  `json:"since"`
+	Deadline       *time.Time `json:"deadline,omitempty"`        // Unset when the wait never expires
+	BalanceChecks  int        `json:"balance_checks,omitempty"`  // Failed balance checks so far, while waiting for funds
+	NextCheckAt    *time.Time `json:"next_check_at,omitempty"`   // While waiting for funds
+	TriggerAccount string     `json:"trigger_account,omitempty"` // While waiting for the trigger
+	AmountReceived string     `json:"amount_received,omitempty"` // Credited to the trigger account so far, in major units
+
+	RequiredApprovals int                `json:"required_approvals,omitempty"` // While waiting for approval
+	Approvals         []TransferApproval `json:"approvals,omitempty"`          // Accepted so far, while waiting for approval
+}
+
+// transferWaits holds the state reported by TransferWaitQueryType
+type transferWaits struct {
+	current *TransferWaitState
+}
+
+// newTransferWaits registers TransferWaitQueryType on a transfer workflow
+func newTransferWaits(ctx workflow.Context) (*transferWaits, error) {
+	waits := &transferWaits{}
+	err := workflow.SetQueryHandler(ctx, TransferWaitQueryType, func() (*TransferWaitState, error) {
+		return waits.current, nil
+	})
+
+	return waits, err
+}
+
+// start reports a new wait, which lasts until done is called
+func (waits *transferWaits) start(ctx workflow.Context, reason string, expiry *transferExpiry) *TransferWaitState {
+	state := &TransferWaitState{Reason: reason, Since: workflow.Now(ctx)}
+	if !expiry.deadline.IsZero() {
+		deadline := expiry.deadline
+		state.Deadline = &deadline
+	}
+	waits.current = state
+
+	return state
+}
+
+// done reports that the transfer is no longer waiting
+func (waits *transferWaits) done() {
+	waits.current = nil
+}
+
+// transferWait queries what a running transfer workflow is waiting for. It returns nil when it is not waiting.
+func (svc *Service) transferWait(ctx context.Context, workflowID string) (*TransferWaitState, error) {
+	queryCtx, cancel := context.WithTimeout(ctx, transferWaitQueryTimeout)
+	defer cancel()
+
+	value, err := svc.temporalClient.QueryWorkflow(queryCtx, workflowID, "", TransferWaitQueryType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transfer wait: %w", err)
+	}
+
+	var wait *TransferWaitState
+	if err := value.Get(&wait); err != nil {
+		return nil, fmt.Errorf("failed to decode transfer wait: %w", err)
+	}
+
+	return wait, nil
+}
+
+// transferWaitStatus maps what a running transfer waits for to the proto TransferStatus name and a description
+func transferWaitStatus(wait *TransferWaitState) (string, string) {
+	switch wait.Reason {
+	case TransferWaitReasonFunds:
+		return "TRANSFER_STATUS_AWAITING_FUNDS", "Transfer is waiting for the source balance to cover the amount"
+	case TransferWaitReasonTrigger:
+		return "TRANSFER_STATUS_AWAITING_TRIGGER", fmt.Sprintf("Transfer is waiting for %s to receive the trigger amount", wait.TriggerAccount)
+	default:
+		if wait.RequiredApprovals > 1 {
+			return "TRANSFER_STATUS_AWAITING_APPROVAL", fmt.Sprintf("Transfer is waiting for approval (%d of %d approvals)", len(wait.Approvals), wait.RequiredApprovals)
+		}
+
+		return "TRANSFER_STATUS_AWAITING_APPROVAL", "Transfer is waiting for approval"
+	}
+}
@@ -17,28 +17,64 @@ type TransferWorkflowParams struct {
 	TransferID     string          `json:"transfer_id"`
 	FromAccount    string          `json:"from_account"`
 	ToAccount      string          `json:"to_account"`
+	ToAlias        string          `json:"to_alias,omitempty"` // Phone number or email address resolved to ToAccount as the first step
 	Amount         decimal.Decimal `json:"amount"`
 	Currency       string          `json:"currency"`
 	Description    string          `json:"description"`
 	IdempotencyKey string          `json:"idempotency_key"`
 	RequestedBy    string          `json:"requested_by"`
+
+	// SLA tracking is fixed when the transfer starts, so configuration changes never alter a running workflow
+	SLASeconds       int  `json:"sla_seconds,omitempty"` // 0 disables SLA tracking
+	AbortOnSLABreach bool `json:"abort_on_sla_breach,omitempty"`
+
+	// Transfers held for approval wait for ApproveTransferSignalName before their balance check, and expire
+	// when the expiry window ends first. Transfers from joint accounts need RequiredApprovals distinct approvals
+	// from Approvers, the owners of the source account.
+	RequiresApproval  bool     `json:"requires_approval,omitempty"`
+	RequiredApprovals int      `json:"required_approvals,omitempty"` // 0 needs a single approval from anyone
+	Approvers         []string `json:"approvers,omitempty"`
+	ExpirySeconds     int      `json:"expiry_seconds,omitempty"` // 0 lets a held transfer wait until the workflow times out
+	CallbackURL       string   `json:"callback_url,omitempty"`   // Receives a webhook when the transfer expires
+
+	// Transfers waiting for funds re-check the balance with backoff instead of failing on insufficient funds,
+	// and expire when the balance still falls short FundsWaitSeconds after the first check
+	WaitForFunds                 bool    `json:"wait_for_funds,omitempty"`
+	FundsCheckIntervalSeconds    int     `json:"funds_check_interval_seconds,omitempty"`
+	FundsCheckBackoffCoefficient float64 `json:"funds_check_backoff_coefficient,omitempty"`
+	FundsCheckMaxIntervalSeconds int     `json:"funds_check_max_interval_seconds,omitempty"` // 0 leaves the interval uncapped
+	FundsWaitSeconds             int     `json:"funds_wait_seconds,omitempty"`
+
+	// Account holders are emailed when the transfer completes or is compensated
+	NotifyCustomers bool `json:"notify_customers,omitempty"`
+
+	// The transfer is settled with the partner bank between the debit and the credit, which calls back to
+	// ExternalSettlementCallbackURL when set rather than being polled
+	ExternalSettlement            bool   `json:"external_settlement,omitempty"`
+	ExternalSettlementCallbackURL string `json:"external_settlement_callback_url,omitempty"`
+
+	// State changes are recorded in svc-transaction by TransferEventsInterceptor
+	RecordEvents bool `json:"record_events,omitempty"`
 }
 
 // TransferWorkflowResults defines the output results from the transfer workflow
 type TransferWorkflowResults struct {
-	TransferID          string          `json:"transfer_id"`
-	Status              string          `json:"status"`
-	FromAccount         string          `json:"from_account"`
-	ToAccount           string          `json:"to_account"`
-	Amount              decimal.Decimal `json:"amount"`
-	Currency            string          `json:"currency"`
-	Description         string          `json:"description"`
-	StartedAt           time.Time       `json:"started_at"`
-	CompletedAt         *time.Time      `json:"completed_at,omitempty"`
-	ErrorMessage        string          `json:"error_message,omitempty"`
-	CompensationApplied bool            `json:"compensation_applied"`
-	WorkflowID          string          `json:"workflow_id"`
-	RunID               string          `json:"run_id"`
+	TransferID          string             `json:"transfer_id"`
+	Status              string             `json:"status"`
+	FromAccount         string             `json:"from_account"`
+	ToAccount           string             `json:"to_account"`
+	Amount              decimal.Decimal    `json:"amount"`
+	Currency            string             `json:"currency"`
+	Description         string             `json:"description"`
+	StartedAt           time.Time          `json:"started_at"`
+	CompletedAt         *time.Time         `json:"completed_at,omitempty"`
+	ErrorMessage        string             `json:"error_message,omitempty"`
+	CompensationApplied bool               `json:"compensation_applied"`
+	SLABreached         bool               `json:"sla_breached"`
+	Approvals           []TransferApproval `json:"approvals,omitempty"` // Accepted approvals of a transfer held for approval
+	FX                  *TransferFX        `json:"fx,omitempty"`        // Set when the credit was converted to the currency of the destination account
+	WorkflowID          string             `json:"workflow_id"`
+	RunID               string             `json:"run_id"`
 }
 
 // transferWorkflow orchestrates the money transfer process using the orchestration-based saga pattern
@@ -46,21 +82,12 @@ func transferWorkflow(ctx workflow.Context, params TransferWorkflowParams) (*Tra
 	logger := workflow.GetLogger(ctx)
 	logger.Info("Starting TransferWorkflow", "transfer_id", params.TransferID, "from_account", params.FromAccount, "to_account", params.ToAccount, "amount", params.Amount)
 
+	// Log the requests that started or attached to the transfer
+	receiveTransferRequests(ctx)
+
 	// Initialize workflow results
 	workflowInfo := workflow.GetInfo(ctx)
-	results := &TransferWorkflowResults{
-		TransferID:          params.TransferID,
-		Status:              "processing",
-		FromAccount:         params.FromAccount,
-		ToAccount:           params.ToAccount,
-		Amount:              params.Amount,
-		Currency:            params.Currency,
-		Description:         params.Description,
-		StartedAt:           workflow.Now(ctx),
-		CompensationApplied: false,
-		WorkflowID:          workflowInfo.WorkflowExecution.ID,
-		RunID:               workflowInfo.WorkflowExecution.RunID,
-	}
+	results := newTransferWorkflowResults(ctx, params)
 
 	// Validate workflow parameters
 	if err := validateTransferWorkflowParams(params); err != nil {
@@ -72,46 +99,54 @@ func transferWorkflow(ctx workflow.Context, params TransferWorkflowParams) (*Tra
 		return results, err
 	}
 
-	// PERFORMANCE OPTIMIZATION: Configure optimized activity options for banking operations from configuration
-	var activityOptions workflow.ActivityOptions
-	if ActivityOptionsProvider != nil {
-		activityOptions = ActivityOptionsProvider()
-	} else {
-		// Fallback to default banking-optimized options if configuration is not available
-		activityOptions = workflow.ActivityOptions{
-			StartToCloseTimeout: time.Minute * 2,  // Banking operations should complete within 2 minutes
-			HeartbeatTimeout:    time.Second * 30, // Heartbeat every 30 seconds for monitoring
-			RetryPolicy: &temporal.RetryPolicy{
-				InitialInterval:    time.Millisecond * 500, // Start with 500ms retry interval (faster for banking)
-				BackoffCoefficient: 1.5,                    // Moderate backoff to prevent thundering herd
-				MaximumInterval:    time.Second * 15,       // Max 15 seconds between retries (banking needs quick response)
-				MaximumAttempts:    3,                      // Fail fast for banking operations
-				NonRetryableErrorTypes: []string{ // Don't retry these banking-specific errors
-					"INSUFFICIENT_FUNDS",
-					"ACCOUNT_NOT_FOUND",
-					"INVALID_CURRENCY",
-					"ACCOUNT_BLOCKED",
-				},
-			},
-			ScheduleToCloseTimeout: time.Minute * 3,  // Total time including queuing
-			ScheduleToStartTimeout: time.Second * 30, // Max time in queue before starting
+	ctx = workflow.WithActivityOptions(ctx, transferActivityOptions())
+
+	// Resolve the phone number or email address the transfer is addressed to before anything else happens
+	if params.ToAlias != "" && params.ToAccount == "" {
+		logger.Info("Resolving destination alias", "to_alias", params.ToAlias)
+		toAccount, err := resolveTransferAlias(ctx, params)
+		if err != nil {
+			logger.Error("Alias resolution failed", "error", err)
+			results.Status = "failed"
+			results.ErrorMessage = fmt.Sprintf("alias resolution failed: %v", err)
+			completedAt := workflow.Now(ctx)
+			results.CompletedAt = &completedAt
+			return results, err
 		}
+
+		params.ToAccount = toAccount
+		results.ToAccount = toAccount
 	}
-	ctx = workflow.WithActivityOptions(ctx, activityOptions)
 
-	// Step 1: Check Balance
-	logger.Info("Step 1: Checking balance", "account_id", params.FromAccount)
-	balanceCheckParams := map[string]interface{}{
-		"account_id":      params.FromAccount,
-		"required_amount": params.Amount,
-		"currency":        params.Currency,
-		"transfer_id":     params.TransferID,
-		"workflow_id":     workflowInfo.WorkflowExecution.ID,
-		"run_id":          workflowInfo.WorkflowExecution.RunID,
+	// Reports through TransferWaitQueryType whether the transfer awaits approval or funds
+	waits, err := newTransferWaits(ctx)
+	if err != nil {
+		logger.Error("Failed to register transfer wait query", "error", err)
+		results.Status = "failed"
+		results.ErrorMessage = fmt.Sprintf("failed to register transfer wait query: %v", err)
+		completedAt := workflow.Now(ctx)
+		results.CompletedAt = &completedAt
+		return results, err
+	}
+
+	// Business SLA: marks the transfer DELAYED once it runs too long, and may stop it at the next step
+	sla := startTransferSLA(ctx, params, results)
+	defer sla.stop()
+
+	// Step 0: Wait for approval. Nothing has been debited yet, so an expired transfer needs no compensation.
+	expiry := newTransferExpiry(ctx, params)
+	if params.RequiresApproval && !awaitTransferApproval(ctx, params, results, expiry, waits) {
+		expireTransfer(ctx, params, results, fmt.Sprintf("transfer was not approved within %ds", params.ExpirySeconds))
+		return results, nil
 	}
 
-	var balanceResult map[string]interface{}
-	err := workflow.ExecuteActivity(ctx, "CheckBalance", balanceCheckParams).Get(ctx, &balanceResult)
+	// Step 1: Check Balance, waiting for incoming credits when the transfer asked for it
+	logger.Info("Step 1: Checking balance", "account_id", params.FromAccount)
+	balanceResult, fundsExpired, err := checkBalance(ctx, params, waits)
+	if fundsExpired {
+		expireTransfer(ctx, params, results, fmt.Sprintf("funds did not cover the transfer within %ds", params.FundsWaitSeconds))
+		return results, nil
+	}
 	if err != nil {
 		logger.Error("Balance check failed", "error", err)
 		results.Status = "failed"
@@ -133,6 +168,33 @@ func transferWorkflow(ctx workflow.Context, params TransferWorkflowParams) (*Tra
 
 	logger.Info("Balance check successful", "balance_result", balanceResult)
 
+	// Step 1b: Quote the credit in the currency of the destination account
+	fx, err := quoteTransfer(ctx, params)
+	if err != nil {
+		logger.Error("Transfer quote failed", "error", err)
+		results.Status = "failed"
+		results.ErrorMessage = fmt.Sprintf("transfer quote failed: %v", err)
+		completedAt := workflow.Now(ctx)
+		results.CompletedAt = &completedAt
+		return results, err
+	}
+
+	creditAmount, creditCurrency := params.Amount, params.Currency
+	if fx != nil {
+		logger.Info("Credit converted", "pair", fx.Pair, "side", fx.Side, "rate", fx.Rate, "spread_bps", fx.SpreadBps, "converted_amount", fx.ConvertedAmount)
+		creditAmount, creditCurrency = fx.ConvertedAmount, fx.ConvertedCurrency
+		results.FX = fx
+	}
+
+	if sla.aborted() {
+		logger.Warn("Transfer SLA breached before debit, aborting")
+		results.Status = "failed"
+		results.ErrorMessage = "transfer SLA breached before debit"
+		completedAt := workflow.Now(ctx)
+		results.CompletedAt = &completedAt
+		return results, newTransferSLABreachedError(params)
+	}
+
 	// Step 2: Debit Account
 	logger.Info("Step 2: Debiting account", "account_id", params.FromAccount, "amount", params.Amount)
 	debitParams := map[string]interface{}{
@@ -160,12 +222,66 @@ func transferWorkflow(ctx workflow.Context, params TransferWorkflowParams) (*Tra
 
 	logger.Info("Debit account successful", "debit_result", debitResult)
 
+	if sla.aborted() {
+		logger.Warn("Transfer SLA breached before credit, aborting with compensation")
+
+		compensationErr := compensateDebit(ctx, params, debitResult, fmt.Sprintf("Transfer exceeded its SLA of %ds", params.SLASeconds))
+		if compensationErr != nil {
+			results.Status = "failed"
+			results.ErrorMessage = fmt.Sprintf("transfer SLA breached and compensation failed: %v", compensationErr)
+		} else {
+			results.Status = "failed"
+			results.ErrorMessage = "transfer SLA breached before credit"
+			results.CompensationApplied = true
+		}
+
+		completedAt := workflow.Now(ctx)
+		results.CompletedAt = &completedAt
+
+		if results.CompensationApplied {
+			notifyTransferOutcome(ctx, params, transferCompensatedEventType, results.ErrorMessage, completedAt)
+		}
+
+		return results, newTransferSLABreachedError(params)
+	}
+
+	// Step 2b: Settle with the partner bank. Nothing is settled when it fails, so only the debit is compensated.
+	if params.ExternalSettlement {
+		logger.Info("Step 2b: Settling with partner bank", "settlement_id", externalSettlementID(params))
+
+		settlementResult, err := settleExternalTransfer(ctx, params)
+		if err != nil {
+			logger.Error("External settlement failed, executing compensation", "error", err)
+
+			compensationErr := compensateDebit(ctx, params, debitResult, fmt.Sprintf("External settlement of %s failed: %v", params.TransferID, err))
+			if compensationErr != nil {
+				results.Status = "failed"
+				results.ErrorMessage = fmt.Sprintf("external settlement failed and compensation failed: settlement_error=%v, compensation_error=%v", err, compensationErr)
+			} else {
+				results.Status = "failed"
+				results.ErrorMessage = fmt.Sprintf("external settlement failed: %v", err)
+				results.CompensationApplied = true
+			}
+
+			completedAt := workflow.Now(ctx)
+			results.CompletedAt = &completedAt
+
+			if results.CompensationApplied {
+				notifyTransferOutcome(ctx, params, transferCompensatedEventType, results.ErrorMessage, completedAt)
+			}
+
+			return results, err
+		}
+
+		logger.Info("External settlement successful", "settlement_result", settlementResult)
+	}
+
 	// Step 3: Credit Account (with compensation logic if it fails)
-	logger.Info("Step 3: Crediting account", "account_id", params.ToAccount, "amount", params.Amount)
+	logger.Info("Step 3: Crediting account", "account_id", params.ToAccount, "amount", creditAmount, "currency", creditCurrency)
 	creditParams := map[string]interface{}{
 		"account_id":      params.ToAccount,
-		"amount":          params.Amount,
-		"currency":        params.Currency,
+		"amount":          creditAmount,
+		"currency":        creditCurrency,
 		"description":     fmt.Sprintf("Transfer from %s: %s", params.FromAccount, params.Description),
 		"reference_id":    params.TransferID,
 		"idempotency_key": fmt.Sprintf("%s-credit", params.IdempotencyKey),
@@ -179,28 +295,23 @@ func transferWorkflow(ctx workflow.Context, params TransferWorkflowParams) (*Tra
 	if err != nil {
 		logger.Error("Credit account failed, executing compensation", "error", err)
 
-		// Execute compensation: reverse the debit
-		compensationParams := map[string]interface{}{
-			"original_transaction_id": debitResult["transaction_id"],
-			"account_id":              params.FromAccount,
-			"amount":                  params.Amount,
-			"currency":                params.Currency,
-			"compensation_reason":     fmt.Sprintf("Credit to %s failed: %v", params.ToAccount, err),
-			"reference_id":            params.TransferID,
-			"idempotency_key":         fmt.Sprintf("%s-compensate", params.IdempotencyKey),
-			"transfer_id":             params.TransferID,
-			"workflow_id":             workflowInfo.WorkflowExecution.ID,
-			"run_id":                  workflowInfo.WorkflowExecution.RunID,
+		// The partner bank has to return the settled funds before the debit can be compensated. When it refuses,
+		// or cannot be reached, the money is outside the saga's control and compensating would pay it out twice.
+		if params.ExternalSettlement {
+			if recallErr := recallExternalSettlement(ctx, params, fmt.Sprintf("Credit to %s failed: %v", params.ToAccount, err)); recallErr != nil {
+				results.Status = "failed"
+				results.ErrorMessage = fmt.Sprintf("credit failed and the external settlement could not be recalled, manual reconciliation required: credit_error=%v, recall_error=%v", err, recallErr)
+				completedAt := workflow.Now(ctx)
+				results.CompletedAt = &completedAt
+				return results, newExternalSettlementNotRecalledError(params, err, recallErr)
+			}
 		}
 
-		var compensationResult map[string]interface{}
-		compensationErr := workflow.ExecuteActivity(ctx, "CompensateDebit", compensationParams).Get(ctx, &compensationResult)
+		compensationErr := compensateDebit(ctx, params, debitResult, fmt.Sprintf("Credit to %s failed: %v", params.ToAccount, err))
 		if compensationErr != nil {
-			logger.Error("Compensation failed", "error", compensationErr)
 			results.Status = "failed"
 			results.ErrorMessage = fmt.Sprintf("credit failed and compensation failed: credit_error=%v, compensation_error=%v", err, compensationErr)
 		} else {
-			logger.Info("Compensation successful", "compensation_result", compensationResult)
 			results.Status = "failed"
 			results.ErrorMessage = fmt.Sprintf("credit account failed: %v", err)
 			results.CompensationApplied = true
@@ -208,6 +319,11 @@ func transferWorkflow(ctx workflow.Context, params TransferWorkflowParams) (*Tra
 
 		completedAt := workflow.Now(ctx)
 		results.CompletedAt = &completedAt
+
+		if results.CompensationApplied {
+			notifyTransferOutcome(ctx, params, transferCompensatedEventType, results.ErrorMessage, completedAt)
+		}
+
 		return results, err
 	}
 
@@ -219,6 +335,8 @@ func transferWorkflow(ctx workflow.Context, params TransferWorkflowParams) (*Tra
 	completedAt := workflow.Now(ctx)
 	results.CompletedAt = &completedAt
 
+	notifyTransferOutcome(ctx, params, transferCompletedEventType, "", completedAt)
+
 	logger.Info("TransferWorkflow completed successfully",
 		"transfer_id", params.TransferID,
 		"debit_result", debitResult,
@@ -228,6 +346,88 @@ func transferWorkflow(ctx workflow.Context, params TransferWorkflowParams) (*Tra
 	return results, nil
 }
 
+// transferActivityOptions returns the activity options of the transfer saga from configuration, or
+// banking-optimized defaults when the configuration is not available
+func transferActivityOptions() workflow.ActivityOptions {
+	// PERFORMANCE OPTIMIZATION: Configure optimized activity options for banking operations from configuration
+	if ActivityOptionsProvider != nil {
+		return ActivityOptionsProvider()
+	}
+
+	// Fallback to default banking-optimized options if configuration is not available
+	return workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute * 2,  // Banking operations should complete within 2 minutes
+		HeartbeatTimeout:    time.Second * 30, // Heartbeat every 30 seconds for monitoring
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Millisecond * 500, // Start with 500ms retry interval (faster for banking)
+			BackoffCoefficient: 1.5,                    // Moderate backoff to prevent thundering herd
+			MaximumInterval:    time.Second * 15,       // Max 15 seconds between retries (banking needs quick response)
+			MaximumAttempts:    3,                      // Fail fast for banking operations
+			NonRetryableErrorTypes: []string{ // Don't retry these banking-specific errors
+				"INSUFFICIENT_FUNDS",
+				"ACCOUNT_NOT_FOUND",
+				"INVALID_CURRENCY",
+				"ACCOUNT_BLOCKED",
+				"IDEMPOTENCY_CONFLICT",     // An idempotency key reused for a different debit, credit or compensation
+				"BUSINESS_RULE_VIOLATION",  // A debit failing a business rule of severity error, e.g. a transaction limit
+				"ACCOUNT_TYPE_RESTRICTION", // A debit past the monthly withdrawal limit of a savings account
+				"ALIAS_NOT_FOUND",          // A phone number or email address no account is registered under
+				"INVALID_ALIAS",            // A to_alias that is neither an international phone number nor an email address
+			},
+		},
+		ScheduleToCloseTimeout: time.Minute * 3,  // Total time including queuing
+		ScheduleToStartTimeout: time.Second * 30, // Max time in queue before starting
+	}
+}
+
+// newTransferWorkflowResults initializes the results of a transfer that is being processed
+func newTransferWorkflowResults(ctx workflow.Context, params TransferWorkflowParams) *TransferWorkflowResults {
+	workflowInfo := workflow.GetInfo(ctx)
+
+	return &TransferWorkflowResults{
+		TransferID:          params.TransferID,
+		Status:              "processing",
+		FromAccount:         params.FromAccount,
+		ToAccount:           params.ToAccount,
+		Amount:              params.Amount,
+		Currency:            params.Currency,
+		Description:         params.Description,
+		StartedAt:           workflow.Now(ctx),
+		CompensationApplied: false,
+		WorkflowID:          workflowInfo.WorkflowExecution.ID,
+		RunID:               workflowInfo.WorkflowExecution.RunID,
+	}
+}
+
+// compensateDebit reverses the debit of a transfer that cannot be completed
+func compensateDebit(ctx workflow.Context, params TransferWorkflowParams, debitResult map[string]interface{}, reason string) error {
+	logger := workflow.GetLogger(ctx)
+	workflowInfo := workflow.GetInfo(ctx)
+
+	compensationParams := map[string]interface{}{
+		"original_transaction_id": debitResult["transaction_id"],
+		"account_id":              params.FromAccount,
+		"amount":                  params.Amount,
+		"currency":                params.Currency,
+		"compensation_reason":     reason,
+		"reference_id":            params.TransferID,
+		"idempotency_key":         fmt.Sprintf("%s-compensate", params.IdempotencyKey),
+		"transfer_id":             params.TransferID,
+		"workflow_id":             workflowInfo.WorkflowExecution.ID,
+		"run_id":                  workflowInfo.WorkflowExecution.RunID,
+	}
+
+	var compensationResult map[string]interface{}
+	if err := workflow.ExecuteActivity(ctx, "CompensateDebit", compensationParams).Get(ctx, &compensationResult); err != nil {
+		logger.Error("Compensation failed", "error", err)
+		return err
+	}
+
+	logger.Info("Compensation successful", "compensation_result", compensationResult)
+
+	return nil
+}
+
 // validateTransferWorkflowParams validates the input parameters for the transfer workflow
 func validateTransferWorkflowParams(params TransferWorkflowParams) error {
 	if params.TransferID == "" {
@@ -238,8 +438,8 @@ func validateTransferWorkflowParams(params TransferWorkflowParams) error {
 		return fmt.Errorf("from_account is required")
 	}
 
-	if params.ToAccount == "" {
-		return fmt.Errorf("to_account is required")
+	if params.ToAccount == "" && params.ToAlias == "" {
+		return fmt.Errorf("to_account is required unless to_alias is set")
 	}
 
 	if params.FromAccount == params.ToAccount {
@@ -258,16 +458,5 @@ func validateTransferWorkflowParams(params TransferWorkflowParams) error {
 		return fmt.Errorf("idempotency_key is required")
 	}
 
-	// Validate currency format (basic validation)
-	validCurrencies := map[string]bool{
-		"USD": true, "EUR": true, "GBP": true, "JPY": true,
-		"CAD": true, "AUD": true, "CHF": true, "CNY": true,
-		"SGD": true, "HKD": true,
-	}
-
-	if !validCurrencies[params.Currency] {
-		return fmt.Errorf("unsupported currency: %s", params.Currency)
-	}
-
-	return nil
+	return validateCurrencyAmount(params.Amount, params.Currency)
 }
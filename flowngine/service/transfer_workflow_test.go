@@ -1,10 +1,22 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/log"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
 )
 
 func TestValidateTransferWorkflowParams(t *testing.T) {
@@ -334,8 +346,8 @@ func TestWorkflowParameterEdgeCases(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
-	t.Run("very_small_amount", func(t *testing.T) {
-		amount, _ := decimal.NewFromString("0.000001")
+	t.Run("smallest_minor_unit", func(t *testing.T) {
+		amount, _ := decimal.NewFromString("0.01")
 		params := TransferWorkflowParams{
 			TransferID:     "transfer-123",
 			FromAccount:    "account-from",
@@ -349,6 +361,38 @@ func TestWorkflowParameterEdgeCases(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("amount_below_minor_unit", func(t *testing.T) {
+		amount, _ := decimal.NewFromString("0.000001")
+		params := TransferWorkflowParams{
+			TransferID:     "transfer-123",
+			FromAccount:    "account-from",
+			ToAccount:      "account-to",
+			Amount:         amount,
+			Currency:       "USD",
+			IdempotencyKey: "idempotency-123",
+		}
+
+		err := validateTransferWorkflowParams(params)
+		assert.EqualError(t, err, "amount 0.000001 exceeds 2 decimal places allowed for USD")
+	})
+
+	t.Run("fractional_yen", func(t *testing.T) {
+		params := TransferWorkflowParams{
+			TransferID:     "transfer-123",
+			FromAccount:    "account-from",
+			ToAccount:      "account-to",
+			Amount:         decimal.RequireFromString("10.50"),
+			Currency:       "JPY",
+			IdempotencyKey: "idempotency-123",
+		}
+
+		err := validateTransferWorkflowParams(params)
+		assert.EqualError(t, err, "amount 10.5 exceeds 0 decimal places allowed for JPY")
+
+		params.Amount = decimal.NewFromInt(1050)
+		assert.NoError(t, validateTransferWorkflowParams(params))
+	})
+
 	t.Run("long_account_names", func(t *testing.T) {
 		longAccountName := "very-long-account-name-that-might-be-used-in-some-systems-with-detailed-naming-conventions"
 		params := TransferWorkflowParams{
@@ -379,3 +423,928 @@ func TestWorkflowParameterEdgeCases(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+// newTransferWorkflowTestEnv creates a test workflow environment with the svc-balance and svc-transaction
+// activities registered under the names used by transferWorkflow
+func newTransferWorkflowTestEnv(t testing.TB) *testsuite.TestWorkflowEnvironment {
+	var suite testsuite.WorkflowTestSuite
+	if _, ok := t.(*testing.B); ok {
+		// Keep workflow logging out of benchmark output and timings
+		suite.SetLogger(log.NewStructuredLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	}
+	env := suite.NewTestWorkflowEnvironment()
+
+	for _, name := range []string{"CheckBalance", "DebitAccount", "CreditAccount", "CompensateDebit", "NotifyTransferEvent", "SendTransferNotification", "SettleExternalTransfer", "RecallExternalSettlement", "RecordTransferEvent", "RecordTransferApproval", "ResolveAccountAlias", "QuoteTransfer"} {
+		env.RegisterActivityWithOptions(
+			func(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+				return nil, nil
+			},
+			activity.RegisterOptions{Name: name},
+		)
+	}
+
+	t.Cleanup(func() { env.AssertExpectations(t) })
+
+	return env
+}
+
+func validTransferWorkflowParams() TransferWorkflowParams {
+	return TransferWorkflowParams{
+		TransferID:     "transfer-123",
+		FromAccount:    "account-from",
+		ToAccount:      "account-to",
+		Amount:         decimal.NewFromFloat(100.00),
+		Currency:       "USD",
+		Description:    "Test transfer",
+		IdempotencyKey: "idempotency-123",
+		RequestedBy:    "user-123",
+	}
+}
+
+func nonRetryableError(errType string) error {
+	return temporal.NewNonRetryableApplicationError(errType, errType, nil)
+}
+
+func TestTransferWorkflowOrchestration(t *testing.T) {
+	t.Parallel()
+
+	sufficientFunds := map[string]interface{}{"sufficient_funds": true}
+	debitResult := map[string]interface{}{"transaction_id": "debit-transaction-123"}
+	creditResult := map[string]interface{}{"transaction_id": "credit-transaction-123"}
+	compensationResult := map[string]interface{}{"transaction_id": "compensation-transaction-123"}
+
+	t.Run("successful_transfer", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["account_id"] == "account-from" && params["idempotency_key"] == "idempotency-123-debit"
+		})).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["account_id"] == "account-to" && params["idempotency_key"] == "idempotency-123-credit"
+		})).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "completed", results.Status)
+		assert.Equal(t, "transfer-123", results.TransferID)
+		assert.True(t, results.Amount.Equal(decimal.NewFromFloat(100.00)))
+		assert.False(t, results.CompensationApplied)
+		assert.NotNil(t, results.CompletedAt)
+		assert.Empty(t, results.ErrorMessage)
+		env.AssertNotCalled(t, "CompensateDebit", mock.Anything, mock.Anything)
+	})
+
+	t.Run("cross_currency_transfer_credits_converted_amount", func(t *testing.T) {
+		t.Parallel()
+
+		quote := map[string]interface{}{
+			"converted_amount":   "84.75",
+			"to_currency":        "EUR",
+			"exchange_rate":      "0.8475",
+			"conversion_applied": true,
+			"pair":               "EUR/USD",
+			"mid_rate":           "1.1765",
+			"side":               "ASK",
+			"spread_bps":         10,
+			"margin_bps":         25,
+			"spread_amount":      "0.25",
+		}
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("QuoteTransfer", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["to_account"] == "account-to" && params["currency"] == "USD"
+		})).Return(quote, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["amount"] == "100" && params["currency"] == "USD"
+		})).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["amount"] == "84.75" && params["currency"] == "EUR"
+		})).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "completed", results.Status)
+		assert.True(t, results.Amount.Equal(decimal.NewFromFloat(100.00)))
+		require.NotNil(t, results.FX)
+		assert.Equal(t, "EUR/USD", results.FX.Pair)
+		assert.Equal(t, "ASK", results.FX.Side)
+		assert.Equal(t, int64(10), results.FX.SpreadBps)
+		assert.Equal(t, int64(25), results.FX.MarginBps)
+		assert.Equal(t, "0.25", results.FX.SpreadAmount.String())
+		assert.Equal(t, "84.75", results.FX.ConvertedAmount.String())
+		assert.Equal(t, "EUR", results.FX.ConvertedCurrency)
+	})
+
+	t.Run("same_currency_transfer_has_no_fx", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("QuoteTransfer", mock.Anything, mock.Anything).
+			Return(map[string]interface{}{"converted_amount": "100", "to_currency": "USD", "conversion_applied": false}, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["currency"] == "USD"
+		})).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Nil(t, results.FX)
+	})
+
+	t.Run("quote_failure_stops_before_debit", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("QuoteTransfer", mock.Anything, mock.Anything).Return(nil, nonRetryableError("INVALID_CURRENCY")).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "INVALID_CURRENCY")
+		env.AssertNotCalled(t, "DebitAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid_params_skip_all_activities", func(t *testing.T) {
+		t.Parallel()
+
+		params := validTransferWorkflowParams()
+		params.Currency = "INVALID"
+
+		env := newTransferWorkflowTestEnv(t)
+		env.ExecuteWorkflow(transferWorkflow, params)
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported currency: INVALID")
+		env.AssertNotCalled(t, "CheckBalance", mock.Anything, mock.Anything)
+	})
+
+	t.Run("insufficient_funds_stop_before_debit", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).
+			Return(map[string]interface{}{"sufficient_funds": false}, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "insufficient funds")
+		env.AssertNotCalled(t, "DebitAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("non_retryable_balance_error_is_not_retried", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).
+			Return(nil, nonRetryableError("ACCOUNT_NOT_FOUND")).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+
+		var applicationErr *temporal.ApplicationError
+		require.ErrorAs(t, err, &applicationErr)
+		assert.Equal(t, "ACCOUNT_NOT_FOUND", applicationErr.Type())
+		env.AssertNotCalled(t, "DebitAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("transient_balance_error_is_retried", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).
+			Return(nil, errors.New("svc-balance unavailable")).Once()
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+	})
+
+	t.Run("debit_failure_skips_credit_and_compensation", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).
+			Return(nil, nonRetryableError("ACCOUNT_BLOCKED")).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.Error(t, env.GetWorkflowError())
+		env.AssertNotCalled(t, "CreditAccount", mock.Anything, mock.Anything)
+		env.AssertNotCalled(t, "CompensateDebit", mock.Anything, mock.Anything)
+	})
+
+	t.Run("credit_failure_compensates_debit", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).
+			Return(nil, nonRetryableError("ACCOUNT_NOT_FOUND")).Once()
+		env.OnActivity("CompensateDebit", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["original_transaction_id"] == "debit-transaction-123" &&
+				params["account_id"] == "account-from" &&
+				params["idempotency_key"] == "idempotency-123-compensate"
+		})).Return(compensationResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+
+		var applicationErr *temporal.ApplicationError
+		require.ErrorAs(t, err, &applicationErr)
+		assert.Equal(t, "ACCOUNT_NOT_FOUND", applicationErr.Type())
+	})
+
+	t.Run("compensation_failure_returns_credit_error", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).
+			Return(nil, nonRetryableError("INVALID_CURRENCY")).Once()
+		env.OnActivity("CompensateDebit", mock.Anything, mock.Anything).
+			Return(nil, nonRetryableError("ACCOUNT_BLOCKED")).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+
+		var applicationErr *temporal.ApplicationError
+		require.ErrorAs(t, err, &applicationErr)
+		assert.Equal(t, "INVALID_CURRENCY", applicationErr.Type())
+	})
+
+	t.Run("balance_check_timeout_fails_after_retries", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).
+			Return(nil, temporal.NewTimeoutError(enumspb.TIMEOUT_TYPE_START_TO_CLOSE, nil)).Times(3)
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+		assert.True(t, temporal.IsTimeoutError(err))
+		env.AssertNotCalled(t, "DebitAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("credit_timeout_compensates_debit", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).
+			Return(nil, temporal.NewTimeoutError(enumspb.TIMEOUT_TYPE_START_TO_CLOSE, nil)).Times(3)
+		env.OnActivity("CompensateDebit", mock.Anything, mock.Anything).Return(compensationResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+		assert.True(t, temporal.IsTimeoutError(err))
+	})
+
+	t.Run("workflow_run_timeout", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.SetWorkflowRunTimeout(30 * time.Second)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).
+			After(time.Minute).Return(sufficientFunds, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+		assert.True(t, temporal.IsTimeoutError(err))
+		env.AssertNotCalled(t, "DebitAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("cancellation_during_debit_skips_credit", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).
+			After(time.Minute).Return(debitResult, nil).Once()
+		env.RegisterDelayedCallback(env.CancelWorkflow, 10*time.Second)
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+		assert.True(t, temporal.IsCanceledError(err))
+		env.AssertNotCalled(t, "CreditAccount", mock.Anything, mock.Anything)
+		env.AssertNotCalled(t, "CompensateDebit", mock.Anything, mock.Anything)
+	})
+
+	t.Run("cancellation_before_start_skips_all_activities", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.RegisterDelayedCallback(env.CancelWorkflow, 0)
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+		assert.True(t, temporal.IsCanceledError(err))
+		env.AssertNotCalled(t, "CheckBalance", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTransferWorkflowSLA(t *testing.T) {
+	t.Parallel()
+
+	sufficientFunds := map[string]interface{}{"sufficient_funds": true}
+	debitResult := map[string]interface{}{"transaction_id": "debit-transaction-123"}
+	creditResult := map[string]interface{}{"transaction_id": "credit-transaction-123"}
+	compensationResult := map[string]interface{}{"transaction_id": "compensation-transaction-123"}
+
+	slaParams := func(abort bool) TransferWorkflowParams {
+		params := validTransferWorkflowParams()
+		params.SLASeconds = 300
+		params.AbortOnSLABreach = abort
+		return params
+	}
+
+	t.Run("within_sla", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, slaParams(true))
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "completed", results.Status)
+		assert.False(t, results.SLABreached)
+		env.AssertNotCalled(t, "UpsertTypedSearchAttributes", mock.Anything)
+	})
+
+	t.Run("breach_marks_transfer_delayed", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnUpsertTypedSearchAttributes(
+			temporal.NewSearchAttributes(slaStatusSearchAttribute.ValueSet(TransferSLAStatusDelayed)),
+		).Return(nil).Once()
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).
+			After(6*time.Minute).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, slaParams(false))
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "completed", results.Status)
+		assert.True(t, results.SLABreached)
+		env.AssertNotCalled(t, "CompensateDebit", mock.Anything, mock.Anything)
+	})
+
+	t.Run("breach_during_balance_check_aborts_before_debit", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnUpsertTypedSearchAttributes(
+			temporal.NewSearchAttributes(slaStatusSearchAttribute.ValueSet(TransferSLAStatusAborted)),
+		).Return(nil).Once()
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).
+			After(6*time.Minute).Return(sufficientFunds, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, slaParams(true))
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+
+		var applicationErr *temporal.ApplicationError
+		require.ErrorAs(t, err, &applicationErr)
+		assert.Equal(t, transferSLABreachedErrorType, applicationErr.Type())
+		env.AssertNotCalled(t, "DebitAccount", mock.Anything, mock.Anything)
+		env.AssertNotCalled(t, "CompensateDebit", mock.Anything, mock.Anything)
+	})
+
+	t.Run("breach_during_debit_aborts_with_compensation", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnUpsertTypedSearchAttributes(
+			temporal.NewSearchAttributes(slaStatusSearchAttribute.ValueSet(TransferSLAStatusAborted)),
+		).Return(nil).Once()
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).
+			After(6*time.Minute).Return(debitResult, nil).Once()
+		env.OnActivity("CompensateDebit", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["original_transaction_id"] == "debit-transaction-123" &&
+				params["compensation_reason"] == "Transfer exceeded its SLA of 300s"
+		})).Return(compensationResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, slaParams(true))
+
+		require.True(t, env.IsWorkflowCompleted())
+		err := env.GetWorkflowError()
+		require.Error(t, err)
+
+		var applicationErr *temporal.ApplicationError
+		require.ErrorAs(t, err, &applicationErr)
+		assert.Equal(t, transferSLABreachedErrorType, applicationErr.Type())
+		env.AssertNotCalled(t, "CreditAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("breach_during_credit_lets_transfer_complete", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnUpsertTypedSearchAttributes(mock.Anything).Return(nil).Once()
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).
+			After(6*time.Minute).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, slaParams(true))
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "completed", results.Status)
+		assert.True(t, results.SLABreached)
+		env.AssertNotCalled(t, "CompensateDebit", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTransferWorkflowApproval(t *testing.T) {
+	t.Parallel()
+
+	sufficientFunds := map[string]interface{}{"sufficient_funds": true}
+	debitResult := map[string]interface{}{"transaction_id": "debit-transaction-123"}
+	creditResult := map[string]interface{}{"transaction_id": "credit-transaction-123"}
+
+	heldParams := func(callbackURL string) TransferWorkflowParams {
+		params := validTransferWorkflowParams()
+		params.RequiresApproval = true
+		params.ExpirySeconds = 3600
+		params.CallbackURL = callbackURL
+		return params
+	}
+
+	t.Run("approved_transfer_completes", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.RegisterDelayedCallback(func() {
+			env.SignalWorkflow(ApproveTransferSignalName, ApproveTransferSignal{ApprovedBy: "supervisor-1"})
+		}, 10*time.Minute)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, heldParams("https://example.com/hooks"))
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "completed", results.Status)
+		env.AssertNotCalled(t, "NotifyTransferEvent", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unapproved_transfer_expires_and_notifies_caller", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("NotifyTransferEvent", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["destination"] == "https://example.com/hooks" &&
+				params["event_type"] == transferExpiredEventType &&
+				params["transfer_id"] == "transfer-123"
+		})).Return(nil, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, heldParams("https://example.com/hooks"))
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "expired", results.Status)
+		assert.Equal(t, "transfer was not approved within 3600s", results.ErrorMessage)
+		require.NotNil(t, results.CompletedAt)
+		assert.Equal(t, time.Hour, results.CompletedAt.Sub(results.StartedAt))
+		env.AssertNotCalled(t, "CheckBalance", mock.Anything, mock.Anything)
+		env.AssertNotCalled(t, "DebitAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed_notification_does_not_fail_expiry", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("NotifyTransferEvent", mock.Anything, mock.Anything).
+			Return(nil, errors.New("webhook returned status 500")).Times(3)
+
+		env.ExecuteWorkflow(transferWorkflow, heldParams("https://example.com/hooks"))
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "expired", results.Status)
+	})
+
+	t.Run("expiry_without_callback_sends_no_webhook", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+
+		env.ExecuteWorkflow(transferWorkflow, heldParams(""))
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "expired", results.Status)
+		env.AssertNotCalled(t, "NotifyTransferEvent", mock.Anything, mock.Anything)
+	})
+
+	jointParams := func() TransferWorkflowParams {
+		params := heldParams("")
+		params.RequiredApprovals = 2
+		params.Approvers = []string{"owner-1", "owner-2"}
+		return params
+	}
+
+	t.Run("joint_transfer_completes_after_two_distinct_owners_approve", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		for delay, approver := range map[time.Duration]string{
+			10 * time.Minute: "owner-1",
+			20 * time.Minute: "owner-1",  // Repeated approvals count once
+			30 * time.Minute: "teller-1", // Not an owner of the source account
+			40 * time.Minute: "owner-2",
+		} {
+			env.RegisterDelayedCallback(func() {
+				env.SignalWorkflow(ApproveTransferSignalName, ApproveTransferSignal{ApprovedBy: approver})
+			}, delay)
+		}
+		env.OnActivity("RecordTransferApproval", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["transfer_id"] == "transfer-123" && (params["approved_by"] == "owner-1" || params["approved_by"] == "owner-2")
+		})).Return(nil, nil).Twice()
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, jointParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "completed", results.Status)
+		require.Len(t, results.Approvals, 2)
+		assert.Equal(t, "owner-1", results.Approvals[0].ApprovedBy)
+		assert.Equal(t, 10*time.Minute, results.Approvals[0].ApprovedAt.Sub(results.StartedAt))
+		assert.Equal(t, "owner-2", results.Approvals[1].ApprovedBy)
+		assert.Equal(t, 40*time.Minute, results.Approvals[1].ApprovedAt.Sub(results.StartedAt))
+	})
+
+	t.Run("joint_transfer_with_one_approval_expires", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.RegisterDelayedCallback(func() {
+			env.SignalWorkflow(ApproveTransferSignalName, ApproveTransferSignal{ApprovedBy: "owner-2"})
+		}, 10*time.Minute)
+
+		env.ExecuteWorkflow(transferWorkflow, jointParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "expired", results.Status)
+		require.Len(t, results.Approvals, 1)
+		env.AssertNotCalled(t, "DebitAccount", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTransferWorkflowFundsWait(t *testing.T) {
+	t.Parallel()
+
+	sufficientFunds := map[string]interface{}{"sufficient_funds": true}
+	debitResult := map[string]interface{}{"transaction_id": "debit-transaction-123"}
+	creditResult := map[string]interface{}{"transaction_id": "credit-transaction-123"}
+
+	waitingParams := func() TransferWorkflowParams {
+		params := validTransferWorkflowParams()
+		params.WaitForFunds = true
+		params.FundsCheckIntervalSeconds = 60
+		params.FundsCheckBackoffCoefficient = 2
+		params.FundsCheckMaxIntervalSeconds = 600
+		params.FundsWaitSeconds = 3600
+		return params
+	}
+
+	t.Run("transfer_completes_once_funds_arrive", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).
+			Return(nil, nonRetryableError("INSUFFICIENT_FUNDS")).Twice()
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+
+		// Between the second check at 1m and the third at 3m
+		var wait *TransferWaitState
+		env.RegisterDelayedCallback(func() {
+			value, err := env.QueryWorkflow(TransferWaitQueryType)
+			require.NoError(t, err)
+			require.NoError(t, value.Get(&wait))
+		}, 2*time.Minute)
+
+		env.ExecuteWorkflow(transferWorkflow, waitingParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "completed", results.Status)
+		require.NotNil(t, results.CompletedAt)
+		assert.Equal(t, 3*time.Minute, results.CompletedAt.Sub(results.StartedAt))
+
+		require.NotNil(t, wait)
+		assert.Equal(t, TransferWaitReasonFunds, wait.Reason)
+		assert.Equal(t, 2, wait.BalanceChecks)
+		require.NotNil(t, wait.Deadline)
+		assert.Equal(t, time.Hour, wait.Deadline.Sub(results.StartedAt))
+		require.NotNil(t, wait.NextCheckAt)
+		assert.Equal(t, 3*time.Minute, wait.NextCheckAt.Sub(results.StartedAt))
+	})
+
+	t.Run("unfunded_transfer_expires_at_deadline", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).
+			Return(map[string]interface{}{"sufficient_funds": false}, nil)
+
+		env.ExecuteWorkflow(transferWorkflow, waitingParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "expired", results.Status)
+		assert.Equal(t, "funds did not cover the transfer within 3600s", results.ErrorMessage)
+		require.NotNil(t, results.CompletedAt)
+		assert.Equal(t, time.Hour, results.CompletedAt.Sub(results.StartedAt))
+		// Checks at 0, 1m, 3m, 7m, 15m, then every 10m up to 55m, and a last one at the deadline
+		env.AssertNumberOfCalls(t, "CheckBalance", 10)
+		env.AssertNotCalled(t, "DebitAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("other_balance_errors_are_not_waited_out", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).
+			Return(nil, nonRetryableError("ACCOUNT_BLOCKED")).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, waitingParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.Error(t, env.GetWorkflowError())
+		env.AssertNotCalled(t, "DebitAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("approval_wait_is_reported_by_query", func(t *testing.T) {
+		t.Parallel()
+
+		params := waitingParams()
+		params.RequiresApproval = true
+		params.ExpirySeconds = 600
+
+		env := newTransferWorkflowTestEnv(t)
+
+		var wait *TransferWaitState
+		env.RegisterDelayedCallback(func() {
+			value, err := env.QueryWorkflow(TransferWaitQueryType)
+			require.NoError(t, err)
+			require.NoError(t, value.Get(&wait))
+		}, time.Minute)
+
+		env.ExecuteWorkflow(transferWorkflow, params)
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NotNil(t, wait)
+		assert.Equal(t, TransferWaitReasonApproval, wait.Reason)
+		assert.Zero(t, wait.BalanceChecks)
+		require.NotNil(t, wait.Deadline)
+		assert.Equal(t, 10*time.Minute, wait.Deadline.Sub(wait.Since))
+
+		// Nothing is reported once the transfer stopped waiting
+		value, err := env.QueryWorkflow(TransferWaitQueryType)
+		require.NoError(t, err)
+		var after *TransferWaitState
+		require.NoError(t, value.Get(&after))
+		assert.Nil(t, after)
+	})
+}
+
+// BenchmarkTransferWorkflow measures the latency from starting a transfer workflow to its completion
+// in the test environment, with all activities succeeding immediately
+func BenchmarkTransferWorkflow(b *testing.B) {
+	params := validTransferWorkflowParams()
+
+	b.ReportAllocs()
+	for range b.N {
+		env := newTransferWorkflowTestEnv(b)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).
+			Return(map[string]interface{}{"sufficient_funds": true}, nil)
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).
+			Return(map[string]interface{}{"transaction_id": "debit-transaction-123"}, nil)
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).
+			Return(map[string]interface{}{"transaction_id": "credit-transaction-123"}, nil)
+
+		env.ExecuteWorkflow(transferWorkflow, params)
+
+		if err := env.GetWorkflowError(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestTransferWorkflowCustomerEmails(t *testing.T) {
+	t.Parallel()
+
+	sufficientFunds := map[string]interface{}{"sufficient_funds": true}
+	debitResult := map[string]interface{}{"transaction_id": "debit-transaction-123"}
+	creditResult := map[string]interface{}{"transaction_id": "credit-transaction-123"}
+	compensationResult := map[string]interface{}{"transaction_id": "compensation-transaction-123"}
+
+	notifiedParams := func() TransferWorkflowParams {
+		params := validTransferWorkflowParams()
+		params.NotifyCustomers = true
+		return params
+	}
+
+	t.Run("completed_transfer_is_emailed", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+		env.OnActivity("SendTransferNotification", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["event_type"] == transferCompletedEventType &&
+				params["transfer_id"] == "transfer-123" &&
+				params["from_account"] == "account-from" &&
+				params["to_account"] == "account-to"
+		})).Return(nil, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, notifiedParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+	})
+
+	t.Run("compensated_transfer_is_emailed", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).
+			Return(nil, nonRetryableError("ACCOUNT_NOT_FOUND")).Once()
+		env.OnActivity("CompensateDebit", mock.Anything, mock.Anything).Return(compensationResult, nil).Once()
+		env.OnActivity("SendTransferNotification", mock.Anything, mock.MatchedBy(func(params map[string]interface{}) bool {
+			return params["event_type"] == transferCompensatedEventType && params["reason"] != ""
+		})).Return(nil, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, notifiedParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.Error(t, env.GetWorkflowError())
+	})
+
+	t.Run("failed_compensation_is_not_emailed", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).
+			Return(nil, nonRetryableError("ACCOUNT_NOT_FOUND")).Once()
+		env.OnActivity("CompensateDebit", mock.Anything, mock.Anything).
+			Return(nil, nonRetryableError("ACCOUNT_BLOCKED")).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, notifiedParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.Error(t, env.GetWorkflowError())
+		env.AssertNotCalled(t, "SendTransferNotification", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed_email_does_not_fail_transfer", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+		env.OnActivity("SendTransferNotification", mock.Anything, mock.Anything).
+			Return(nil, errors.New("smtp relay unavailable")).Times(3)
+
+		env.ExecuteWorkflow(transferWorkflow, notifiedParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		var results TransferWorkflowResults
+		require.NoError(t, env.GetWorkflowResult(&results))
+		assert.Equal(t, "completed", results.Status)
+	})
+
+	t.Run("disabled_emails_are_not_sent", func(t *testing.T) {
+		t.Parallel()
+
+		env := newTransferWorkflowTestEnv(t)
+		env.OnActivity("CheckBalance", mock.Anything, mock.Anything).Return(sufficientFunds, nil).Once()
+		env.OnActivity("DebitAccount", mock.Anything, mock.Anything).Return(debitResult, nil).Once()
+		env.OnActivity("CreditAccount", mock.Anything, mock.Anything).Return(creditResult, nil).Once()
+
+		env.ExecuteWorkflow(transferWorkflow, validTransferWorkflowParams())
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+		env.AssertNotCalled(t, "SendTransferNotification", mock.Anything, mock.Anything)
+	})
+}
@@ -3,13 +3,35 @@ package config
 import (
 	"fmt"
 
+	"profile"
+
 	"github.com/spf13/viper"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	App      App      `mapstructure:"app"`
-	Temporal Temporal `mapstructure:"temporal"`
+	App                 App                 `mapstructure:"app"`
+	Grpc                Grpc                `mapstructure:"grpc"`
+	Temporal            Temporal            `mapstructure:"temporal"`
+	SvcTransaction      SvcTransaction      `mapstructure:"svc_transaction"`
+	FastPath            FastPath            `mapstructure:"fast_path"`
+	TransferReference   TransferReference   `mapstructure:"transfer_reference"`
+	WorkflowIDConflict  WorkflowIDConflict  `mapstructure:"workflow_id_conflict"`
+	TransferSLA         TransferSLA         `mapstructure:"transfer_sla"`
+	TransferApproval    TransferApproval    `mapstructure:"transfer_approval"`
+	FundsWait           FundsWait           `mapstructure:"funds_wait"`
+	ConditionalTransfer ConditionalTransfer `mapstructure:"conditional_transfer"`
+	TransferBatch       TransferBatch       `mapstructure:"transfer_batch"`
+	MoneyRequest        MoneyRequest        `mapstructure:"money_request"`
+	Escrow              Escrow              `mapstructure:"escrow"`
+	CustomerEmails      CustomerEmails      `mapstructure:"customer_emails"`
+	ExternalSettlement  ExternalSettlement  `mapstructure:"external_settlement"`
+	TransferEvents      TransferEvents      `mapstructure:"transfer_events"`
+	TransferReadModel   TransferReadModel   `mapstructure:"transfer_read_model"`
+	FailureSimulation   FailureSimulation   `mapstructure:"failure_simulation"`
+	TemporalHealth      TemporalHealth      `mapstructure:"temporal_health"`
+
+	Profile profile.Profile `mapstructure:"-"` // From APP_PROFILE rather than config.json
 }
 
 // LoadConfig reads configuration from file or environment variables.
@@ -31,5 +53,10 @@ func LoadConfig(path string) (config Config, err error) {
 		return config, fmt.Errorf("failed to unmarshal configuration: %s", err)
 	}
 
+	config.Profile, err = profile.Load()
+	if err != nil {
+		return config, err
+	}
+
 	return
 }
@@ -1,5 +1,7 @@
 package config
 
+import "strings"
+
 // App config
 
 type App struct {
@@ -11,9 +13,25 @@ type App struct {
 // Temporal config
 
 type Temporal struct {
-	HostPort        string                  `mapstructure:"host_port"`
-	Namespace       string                  `mapstructure:"namespace"`
-	ActivityOptions TemporalActivityOptions `mapstructure:"activity_options"`
+	HostPort          string                  `mapstructure:"host_port"`
+	Namespace         string                  `mapstructure:"namespace"`
+	ActivityOptions   TemporalActivityOptions `mapstructure:"activity_options"`
+	TransferTaskQueue TransferTaskQueue       `mapstructure:"transfer_task_queue"`
+}
+
+// TransferTaskQueue names the task queue transfers are started on. A new worker fleet is warmed on the queue of
+// Cutover.Suffix and new transfers are shifted to it gradually, while running workflows finish on the queue they
+// started on.
+type TransferTaskQueue struct {
+	Name    string           `mapstructure:"name"`   // Empty is transfer-task-queue
+	Suffix  string           `mapstructure:"suffix"` // Of the current fleet, e.g. v1 for transfer-task-queue-v1; empty uses name as is
+	Cutover TaskQueueCutover `mapstructure:"cutover"`
+}
+
+// TaskQueueCutover is the fleet new transfers are being shifted to
+type TaskQueueCutover struct {
+	Suffix  string `mapstructure:"suffix"`  // Of the next fleet; empty when no cutover is in progress
+	Percent int    `mapstructure:"percent"` // Share of new transfers started on the next fleet, 0-100; changed at runtime on POST /task-queue-cutover
 }
 
 // TemporalActivityOptions defines configuration for activity execution behavior
@@ -33,3 +51,190 @@ type TemporalRetryPolicy struct {
 	MaximumAttempts        int      `mapstructure:"maximum_attempts"`
 	NonRetryableErrorTypes []string `mapstructure:"non_retryable_error_types"`
 }
+
+// SvcTransaction config
+
+type SvcTransaction struct {
+	Name           string `mapstructure:"name"`
+	Host           string `mapstructure:"host"`
+	Port           int    `mapstructure:"port"`
+	TimeoutSeconds int    `mapstructure:"timeout_seconds"`
+}
+
+// FastPath config
+
+// FastPath controls when transfers bypass the Temporal saga and are executed by
+// svc-transaction as a single database transaction
+type FastPath struct {
+	Enabled    bool             `mapstructure:"enabled"`
+	MaxAmounts map[string]int64 `mapstructure:"max_amounts"` // Inclusive limit by currency code, in its minor units
+}
+
+// MaxAmount returns the fast path limit of currency in its minor units, zero when the currency is not listed.
+// Viper lowercases map keys, so the code is matched without regard to case.
+func (fastPath FastPath) MaxAmount(currency string) int64 {
+	for code, maxAmount := range fastPath.MaxAmounts {
+		if strings.EqualFold(code, currency) {
+			return maxAmount
+		}
+	}
+
+	return 0
+}
+
+// TransferReference config
+
+// TransferReference controls the human-friendly reference numbers issued by svc-transaction for every transfer
+type TransferReference struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	BranchCode string `mapstructure:"branch_code"` // 3 digits, embedded in every reference
+}
+
+// WorkflowIDConflict config
+
+// WorkflowIDConflict decides what ExecuteTransfer does when the workflow of its request_id exists already, i.e. the
+// request is a retry or a replay. Transfer workflow IDs are derived from the request_id and never reused.
+type WorkflowIDConflict struct {
+	Policy string `mapstructure:"policy"` // use_existing (default) attaches to the transfer, reject_duplicate fails with ALREADY_EXISTS
+}
+
+// TransferSLA config
+
+// TransferSLA is the business deadline of a saga transfer, measured from the start of its workflow
+type TransferSLA struct {
+	TimeoutSeconds int  `mapstructure:"timeout_seconds"` // 0 disables SLA tracking
+	AbortOnBreach  bool `mapstructure:"abort_on_breach"` // Stop the saga at its next step and reverse any debit
+}
+
+// TransferApproval config
+
+// TransferApproval holds large saga transfers until they are approved. Transfers left unapproved for the
+// expiry window expire without being debited. Transfers from joint accounts, i.e. accounts with two or more
+// owners, need the approval of two distinct owners from JointThresholdAmount on.
+type TransferApproval struct {
+	ThresholdAmount      int64 `mapstructure:"threshold_amount"`       // Inclusive, in minor units; 0 disables approval
+	JointThresholdAmount int64 `mapstructure:"joint_threshold_amount"` // Inclusive, in minor units; 0 disables dual authorization
+	ExpirySeconds        int   `mapstructure:"expiry_seconds"`         // Measured from the start of the transfer workflow
+}
+
+// FundsWait config
+
+// FundsWait lets transfers requested with wait_for_funds wait for incoming credits instead of failing on
+// insufficient funds. The balance is re-checked with backoff until it covers the amount or the wait ends.
+type FundsWait struct {
+	InitialIntervalSeconds int     `mapstructure:"initial_interval_seconds"` // Delay before the first re-check
+	BackoffCoefficient     float64 `mapstructure:"backoff_coefficient"`      // Growth of the delay between re-checks
+	MaximumIntervalSeconds int     `mapstructure:"maximum_interval_seconds"` // Cap on the delay between re-checks
+	MaxWaitSeconds         int     `mapstructure:"max_wait_seconds"`         // Measured from the first failed check; 0 disables waiting
+}
+
+// ConditionalTransfer config
+
+// ConditionalTransfer bounds how long a transfer with a trigger waits for its trigger account to receive the
+// trigger amount. Transfers not triggered in time expire without being debited.
+type ConditionalTransfer struct {
+	ExpirySeconds int `mapstructure:"expiry_seconds"` // Measured from the start of the workflow
+}
+
+// TransferBatch config
+
+// TransferBatch bounds the batches of transfers started by StartTransferBatch, e.g. bulk payouts uploaded as CSV
+type TransferBatch struct {
+	MaxTransfers           int `mapstructure:"max_transfers"`            // Largest batch accepted
+	MaxConcurrentTransfers int `mapstructure:"max_concurrent_transfers"` // Transfers of a batch running at the same time
+}
+
+// MoneyRequest config
+
+// MoneyRequest bounds how long a request for money waits for the payer to accept or decline it. Requests left
+// unanswered are declined as expired.
+type MoneyRequest struct {
+	ExpirySeconds int `mapstructure:"expiry_seconds"` // Measured from the creation of the request
+}
+
+// Escrow config
+
+// Escrow bounds how long escrowed funds stay held from the payer. Escrows neither released nor refunded by their
+// deadline are refunded.
+type Escrow struct {
+	DefaultDeadlineSeconds int `mapstructure:"default_deadline_seconds"` // Used when the request sets no deadline
+	MaxDeadlineSeconds     int `mapstructure:"max_deadline_seconds"`     // Longest deadline a request may set
+}
+
+// CustomerEmails config
+
+// CustomerEmails controls the emails sent to account holders when a saga transfer completes or is compensated.
+// Delivery, preferences and the suppression list are handled by the SendTransferNotification activity of svc-balance.
+type CustomerEmails struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// ExternalSettlement config
+
+// ExternalSettlement adds a step to the transfer saga that settles the transfer with a partner bank (svc-external-bank,
+// called by the SettleExternalTransfer activity of svc-transaction) between the debit and the credit. Transfers
+// then always run as sagas, since the fast path cannot settle externally.
+type ExternalSettlement struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	CallbackURL string `mapstructure:"callback_url"` // FlowEngine's settlement callback as the partner reaches it; empty to poll instead
+}
+
+// TransferEvents config
+
+// TransferEvents records the state changes of saga transfers in core.transfer_events of svc-transaction, through
+// the RecordTransferEvent activity called by TransferEventsInterceptor. GetTransferStatus falls back to the
+// recorded events when Temporal cannot be reached or no longer holds the workflow.
+type TransferEvents struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// TransferReadModel config
+
+// TransferReadModel serves the status of finished saga transfers from core.transfer_read_model of svc-transaction,
+// projected there from the recorded transfer events, instead of reading their workflow result from Temporal
+type TransferReadModel struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// FailureSimulation config
+
+// FailureSimulation caps the workflow start and signal failures injected by FlowEngine's own failure rules
+type FailureSimulation struct {
+	MaxFailurePercent float64 `mapstructure:"max_failure_percent"` // Operations failed per minute as a percentage of all operations; 0 for no cap
+	MinOperations     int     `mapstructure:"min_operations"`      // Operations of the minute before the cap applies
+}
+
+// TemporalHealth config
+
+// TemporalHealthTaskQueue is a task queue whose workers are watched, with the task types they are expected to poll
+type TemporalHealthTaskQueue struct {
+	Name  string   `mapstructure:"name"`
+	Types []string `mapstructure:"types"` // workflow and/or activity; empty is both
+}
+
+// TemporalHealth reports the namespace and the pollers and backlog of the watched task queues on GET /temporal/health
+// and in the metrics of the metrics port
+type TemporalHealth struct {
+	TaskQueues           []TemporalHealthTaskQueue `mapstructure:"task_queues"`             // Empty watches the workflow tasks of the current and next transfer task queues
+	MaxBacklogAgeSeconds int                       `mapstructure:"max_backlog_age_seconds"` // A task waiting longer for a worker marks its queue unhealthy; 0 is 30
+}
+
+// Grpc config
+
+// GrpcServerKeepalive configures the HTTP/2 pings of the gRPC server and how long connections are kept
+type GrpcServerKeepalive struct {
+	TimeSeconds                  int  `mapstructure:"time_seconds"`                     // Ping a client after this long without activity; 0 is 2 hours
+	TimeoutSeconds               int  `mapstructure:"timeout_seconds"`                  // Close the connection when a ping is not answered in time; 0 is 20 seconds
+	MinTimeSeconds               int  `mapstructure:"min_time_seconds"`                 // Clients pinging more often are disconnected (too_many_pings); 0 is 5 minutes
+	PermitWithoutStream          bool `mapstructure:"permit_without_stream"`            // Accept client pings while no call is in flight
+	MaxConnectionIdleSeconds     int  `mapstructure:"max_connection_idle_seconds"`      // Close connections idle this long; 0 keeps them
+	MaxConnectionAgeSeconds      int  `mapstructure:"max_connection_age_seconds"`       // Close connections this old so clients rebalance; 0 keeps them
+	MaxConnectionAgeGraceSeconds int  `mapstructure:"max_connection_age_grace_seconds"` // Time given to calls in flight on an aged connection; 0 is unlimited
+}
+
+type Grpc struct {
+	Keepalive                GrpcServerKeepalive `mapstructure:"keepalive"`
+	MaxRecvMsgBytes          int                 `mapstructure:"max_recv_msg_bytes"`         // 0 is 4 MiB
+	MaxSendMsgBytes          int                 `mapstructure:"max_send_msg_bytes"`         // 0 is unlimited
+	ConnectionTimeoutSeconds int                 `mapstructure:"connection_timeout_seconds"` // Deadline of the connection handshake; 0 is 120 seconds
+}
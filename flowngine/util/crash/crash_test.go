@@ -0,0 +1,45 @@
+package crash
+
+import (
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestReport(t *testing.T) {
+	logger, hook := logtest.NewNullLogger()
+	logger.SetOutput(io.Discard)
+
+	before := Total()
+
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = Report(logrus.NewEntry(logger), r)
+			}
+		}()
+
+		panic("boom")
+	}()
+
+	if err == nil || err.Error() != "panic: boom" {
+		t.Errorf("Report() error = %v, want panic: boom", err)
+	}
+	if got := Total() - before; got != 1 {
+		t.Errorf("Total() increased by %d, want 1", got)
+	}
+
+	entry := hook.LastEntry()
+	if entry == nil {
+		t.Fatal("Report() did not log")
+	}
+	if entry.Level != logrus.ErrorLevel {
+		t.Errorf("log level = %v, want error", entry.Level)
+	}
+	if stack, _ := entry.Data["stack"].(string); stack == "" {
+		t.Error("log entry has no stack trace")
+	}
+}
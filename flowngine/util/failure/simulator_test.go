@@ -0,0 +1,437 @@
+package failure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulator_SimulateFailure(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel) // Reduce log noise in tests
+
+	simulator := NewSimulator(logger)
+
+	tests := []struct {
+		name        string
+		rules       []Rule
+		operation   string
+		accountID   string
+		expectError bool
+	}{
+		{
+			name:        "should_not_fail_when_no_rules",
+			rules:       []Rule{},
+			operation:   "StartWorkflow",
+			accountID:   "123456789012",
+			expectError: false,
+		},
+		{
+			name: "should_not_fail_when_rule_disabled",
+			rules: []Rule{
+				{
+					Name:        "disabled_rule",
+					Enabled:     false,
+					Type:        "error",
+					Probability: 1.0,
+					Operations:  []string{"StartWorkflow"},
+					Accounts:    []string{"*"},
+				},
+			},
+			operation:   "StartWorkflow",
+			accountID:   "123456789012",
+			expectError: false,
+		},
+		{
+			name: "should_fail_when_rule_matches",
+			rules: []Rule{
+				{
+					Name:        "test_failure",
+					Enabled:     true,
+					Type:        "error",
+					Probability: 1.0, // Always fail
+					Operations:  []string{"StartWorkflow"},
+					Accounts:    []string{"*"},
+					Message:     "Test failure",
+				},
+			},
+			operation:   "StartWorkflow",
+			accountID:   "123456789012",
+			expectError: true,
+		},
+		{
+			name: "should_not_fail_when_operation_does_not_match",
+			rules: []Rule{
+				{
+					Name:        "different_operation",
+					Enabled:     true,
+					Type:        "error",
+					Probability: 1.0,
+					Operations:  []string{"SignalWorkflow"},
+					Accounts:    []string{"*"},
+				},
+			},
+			operation:   "StartWorkflow",
+			accountID:   "123456789012",
+			expectError: false,
+		},
+		{
+			name: "should_not_fail_when_account_does_not_match",
+			rules: []Rule{
+				{
+					Name:        "specific_account",
+					Enabled:     true,
+					Type:        "error",
+					Probability: 1.0,
+					Operations:  []string{"StartWorkflow"},
+					Accounts:    []string{"987654321098"},
+				},
+			},
+			operation:   "StartWorkflow",
+			accountID:   "123456789012",
+			expectError: false,
+		},
+		{
+			name: "should_respect_max_count",
+			rules: []Rule{
+				{
+					Name:        "limited_failures",
+					Enabled:     true,
+					Type:        "error",
+					Probability: 1.0,
+					Operations:  []string{"StartWorkflow"},
+					Accounts:    []string{"*"},
+					MaxCount:    1,
+				},
+			},
+			operation:   "StartWorkflow",
+			accountID:   "123456789012",
+			expectError: true, // First call should fail
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset simulator for each test
+			simulator.Reset()
+
+			ctx := context.Background()
+			err := simulator.SimulateFailure(ctx, tt.operation, tt.accountID, tt.rules)
+
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSimulator_SlowFailure(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	rules := []Rule{
+		{
+			Name:        "slow_operation",
+			Enabled:     true,
+			Type:        "slow",
+			Probability: 1.0,
+			Operations:  []string{"StartWorkflow"},
+			Accounts:    []string{"*"},
+			DelayMs:     100, // 100ms delay
+		},
+	}
+
+	ctx := context.Background()
+	start := time.Now()
+
+	err := simulator.SimulateFailure(ctx, "StartWorkflow", "123456789012", rules)
+
+	duration := time.Since(start)
+
+	// Should not return an error for slow type
+	assert.NoError(t, err)
+
+	// Should take at least 100ms
+	assert.GreaterOrEqual(t, duration.Milliseconds(), int64(100))
+}
+
+func TestSimulator_GetStats(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	// Initial stats
+	stats := simulator.GetStats()
+	assert.NotNil(t, stats)
+	assert.Contains(t, stats, "occurrences")
+	assert.Contains(t, stats, "uptime_ms")
+
+	// Trigger a failure
+	rules := []Rule{
+		{
+			Name:        "test_rule",
+			Enabled:     true,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"StartWorkflow"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+	simulator.SimulateFailure(ctx, "StartWorkflow", "123456789012", rules)
+
+	// Check stats updated
+	stats = simulator.GetStats()
+	occurrences := stats["occurrences"].(map[string]int)
+	assert.Equal(t, 1, occurrences["test_rule"])
+}
+
+func TestSimulator_Reset(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	// Trigger a failure
+	rules := []Rule{
+		{
+			Name:        "test_rule",
+			Enabled:     true,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"StartWorkflow"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+	simulator.SimulateFailure(ctx, "StartWorkflow", "123456789012", rules)
+
+	// Verify failure was recorded
+	stats := simulator.GetStats()
+	occurrences := stats["occurrences"].(map[string]int)
+	assert.Equal(t, 1, occurrences["test_rule"])
+
+	// Reset
+	simulator.Reset()
+
+	// Verify stats were reset
+	stats = simulator.GetStats()
+	occurrences = stats["occurrences"].(map[string]int)
+	assert.Equal(t, 0, occurrences["test_rule"])
+}
+
+func TestSimulator_SetEnabled(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	rules := []Rule{
+		{
+			Name:        "test_rule",
+			Enabled:     true,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"StartWorkflow"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+
+	// Disabled simulator injects nothing and counts nothing
+	simulator.SetEnabled(false)
+	assert.NoError(t, simulator.SimulateFailure(ctx, "StartWorkflow", "123456789012", rules))
+	assert.Equal(t, false, simulator.GetStats()["enabled"])
+	assert.Equal(t, 0, simulator.GetStats()["occurrences"].(map[string]int)["test_rule"])
+
+	// Enabling it again resumes injection
+	simulator.SetEnabled(true)
+	assert.Error(t, simulator.SimulateFailure(ctx, "StartWorkflow", "123456789012", rules))
+	assert.Equal(t, true, simulator.GetStats()["enabled"])
+}
+
+func TestSimulator_SetRuleEnabled(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	rules := []Rule{
+		{
+			Name:        "off_by_default",
+			Enabled:     false,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"StartWorkflow"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+
+	assert.NoError(t, simulator.SimulateFailure(ctx, "StartWorkflow", "123456789012", rules))
+
+	// An override turns the rule on without changing the rule itself
+	simulator.SetRuleEnabled("off_by_default", true)
+	assert.True(t, simulator.RuleEnabled(rules[0]))
+	assert.Error(t, simulator.SimulateFailure(ctx, "StartWorkflow", "123456789012", rules))
+
+	// Reset restores the rule's own Enabled field
+	simulator.Reset()
+	assert.False(t, simulator.RuleEnabled(rules[0]))
+	assert.NoError(t, simulator.SimulateFailure(ctx, "StartWorkflow", "123456789012", rules))
+}
+
+func TestSimulator_Quota(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+	simulator.SetQuota(Quota{MaxFailurePercent: 25, MinOperations: 4})
+
+	rules := []Rule{
+		{
+			Name:        "always_fail",
+			Enabled:     true,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"Fail"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+
+	// Below MinOperations every failure fires
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+
+	simulator.Reset()
+	simulator.SetQuota(Quota{MaxFailurePercent: 25, MinOperations: 4})
+
+	for range 3 {
+		assert.NoError(t, simulator.SimulateFailure(ctx, "Other", "123456789012", rules))
+	}
+
+	// 1 failure in 4 operations is within 25%, a second one in 5 is not
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+	assert.NoError(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+
+	// 2 in 9 is within 25% again
+	for range 3 {
+		assert.NoError(t, simulator.SimulateFailure(ctx, "Other", "123456789012", rules))
+	}
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+
+	stats := simulator.GetStats()
+	assert.Equal(t, 9, stats["window_operations"])
+	assert.Equal(t, 2, stats["window_failures"])
+	assert.Equal(t, 1, stats["suppressed_failures"])
+	assert.Equal(t, 2, stats["occurrences"].(map[string]int)["always_fail"])
+
+	// A new minute starts a new window
+	simulator.countOperation(simulator.windowStart.Add(quotaWindow))
+	assert.Equal(t, 1, simulator.windowOperations)
+	assert.Equal(t, 0, simulator.windowFailures)
+}
+
+func TestSimulator_Trip(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	rules := []Rule{
+		{
+			Name:        "always_fail",
+			Enabled:     true,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"*"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+
+	simulator.Trip("backlog too large")
+	assert.False(t, simulator.Enabled())
+	assert.NoError(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+	assert.Equal(t, "backlog too large", simulator.GetStats()["disabled_reason"])
+
+	// Switching it on again clears the reason
+	simulator.SetEnabled(true)
+	assert.True(t, simulator.Enabled())
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+	assert.NotContains(t, simulator.GetStats(), "disabled_reason")
+}
+
+func TestSimulator_Match(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	rules := []Rule{
+		{
+			Name:        "deferred_start",
+			Enabled:     true,
+			Type:        "slow",
+			Probability: 1.0,
+			Operations:  []string{"ScheduleWorkflowTask"},
+			Accounts:    []string{"*"},
+			DelayMs:     5000,
+			MaxCount:    1,
+		},
+	}
+
+	// The rule is returned for the caller to apply, and counted
+	rule, ok := simulator.Match("ScheduleWorkflowTask", "123456789012", rules)
+	assert.True(t, ok)
+	assert.Equal(t, 5000, rule.DelayMs)
+	assert.Equal(t, 1, simulator.GetStats()["occurrences"].(map[string]int)["deferred_start"])
+
+	_, ok = simulator.Match("ScheduleWorkflowTask", "123456789012", rules)
+	assert.False(t, ok, "MaxCount reached")
+
+	_, ok = simulator.Match("StartWorkflow", "123456789012", rules)
+	assert.False(t, ok, "other operation")
+}
+
+func TestSimulator_DropFailure(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+	simulator.SetQuota(Quota{MaxFailurePercent: 50, MinOperations: 2})
+
+	rules := []Rule{
+		{
+			Name:        "dropped_signals",
+			Enabled:     true,
+			Type:        "drop",
+			Probability: 1.0,
+			Operations:  []string{"SignalWorkflow"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+
+	assert.ErrorIs(t, simulator.SimulateFailure(ctx, "SignalWorkflow", "", rules), ErrDropped)
+
+	// Dropped operations count as failures for the quota
+	assert.NoError(t, simulator.SimulateFailure(ctx, "SignalWorkflow", "", rules))
+	assert.Equal(t, 1, simulator.GetStats()["suppressed_failures"])
+}
@@ -0,0 +1,143 @@
+package taskqueue
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// Tracks a workflow start can be routed to
+const (
+	TrackCurrent = "current" // Task queue of the worker fleet serving traffic today
+	TrackNext    = "next"    // Task queue of the fleet being warmed and cut over to
+)
+
+// Name returns the task queue of a fleet: base with suffix appended, e.g. transfer-task-queue-v2, or base itself when
+// suffix is empty
+func Name(base, suffix string) string {
+	if suffix == "" {
+		return base
+	}
+
+	return base + "-" + suffix
+}
+
+// State is the cutover of a Router and the workflow starts it routed since the process started
+type State struct {
+	Current string           `json:"current"`
+	Next    string           `json:"next,omitempty"` // Empty while no cutover is configured
+	Percent int              `json:"percent"`        // Share of the unpinned starts routed to Next
+	Starts  map[string]int64 `json:"starts"`         // By task queue
+}
+
+// Router picks the task queue new workflows are started on during a blue/green cutover. Only starts are routed: a
+// workflow keeps the task queue it was started on, so running workflows stay with the fleet that started them.
+type Router struct {
+	current string
+	next    string
+	percent int
+	starts  map[string]int64
+	mutex   sync.Mutex
+}
+
+// NewRouter creates a router sending percent of the new workflows to next and the rest to current. An empty next, or
+// one equal to current, means no cutover is in progress.
+func NewRouter(current, next string, percent int) (*Router, error) {
+	if current == "" {
+		return nil, fmt.Errorf("current task queue is required")
+	}
+	if next == current {
+		next = ""
+	}
+
+	router := &Router{
+		current: current,
+		next:    next,
+		starts:  map[string]int64{},
+	}
+	if err := router.SetPercent(percent); err != nil {
+		return nil, err
+	}
+
+	return router, nil
+}
+
+// SetPercent changes the share of new workflows routed to the next task queue
+func (r *Router) SetPercent(percent int) error {
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("cutover percent must be between 0 and 100, got %d", percent)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.next == "" && percent > 0 {
+		return fmt.Errorf("no next task queue is configured to cut over to")
+	}
+
+	r.percent = percent
+
+	return nil
+}
+
+// Route returns the task queue to start the workflow workflowID on and the track it belongs to. A start pinned to a
+// track goes to its task queue, the current one standing in for next while no cutover is configured. Unpinned starts
+// are split by a hash of workflowID, so a retried start picks the same queue as the first attempt.
+func (r *Router) Route(workflowID, track string) (string, string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if track != TrackCurrent && track != TrackNext {
+		track = TrackCurrent
+		if bucket(workflowID) < r.percent {
+			track = TrackNext
+		}
+	}
+
+	taskQueue := r.current
+	if track == TrackNext && r.next != "" {
+		taskQueue = r.next
+	}
+
+	r.starts[taskQueue]++
+
+	return taskQueue, track
+}
+
+// TaskQueues returns the current task queue and, during a cutover, the next one
+func (r *Router) TaskQueues() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.next == "" {
+		return []string{r.current}
+	}
+
+	return []string{r.current, r.next}
+}
+
+// State returns a copy of the cutover and of the start counts
+func (r *Router) State() State {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	starts := make(map[string]int64, len(r.starts))
+	for taskQueue, count := range r.starts {
+		starts[taskQueue] = count
+	}
+
+	return State{
+		Current: r.current,
+		Next:    r.next,
+		Percent: r.percent,
+		Starts:  starts,
+	}
+}
+
+// bucket maps workflowID to one of 100 buckets
+func bucket(workflowID string) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(workflowID))
+
+	return int(hash.Sum32() % 100)
+}
@@ -0,0 +1,88 @@
+package taskqueue
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestName(t *testing.T) {
+	if got := Name("transfer-task-queue", ""); got != "transfer-task-queue" {
+		t.Errorf("Name() = %s, want transfer-task-queue", got)
+	}
+	if got := Name("transfer-task-queue", "v2"); got != "transfer-task-queue-v2" {
+		t.Errorf("Name() = %s, want transfer-task-queue-v2", got)
+	}
+}
+
+func TestRoute(t *testing.T) {
+	router, err := NewRouter("tq-v1", "tq-v2", 30)
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+
+	routed := map[string]int{}
+	for i := range 1000 {
+		workflowID := fmt.Sprintf("transfer-%d", i)
+
+		taskQueue, track := router.Route(workflowID, "")
+		routed[taskQueue]++
+
+		if again, _ := router.Route(workflowID, ""); again != taskQueue {
+			t.Fatalf("Route(%s) = %s then %s, want the same task queue", workflowID, taskQueue, again)
+		}
+		if (track == TrackNext) != (taskQueue == "tq-v2") {
+			t.Fatalf("Route(%s) = %s on track %s", workflowID, taskQueue, track)
+		}
+	}
+
+	if routed["tq-v2"] < 200 || routed["tq-v2"] > 400 {
+		t.Errorf("Route() sent %d of 1000 starts to tq-v2, want about 300", routed["tq-v2"])
+	}
+
+	if taskQueue, _ := router.Route("transfer-pinned", TrackNext); taskQueue != "tq-v2" {
+		t.Errorf("Route(next) = %s, want tq-v2", taskQueue)
+	}
+	if taskQueue, _ := router.Route("transfer-pinned", TrackCurrent); taskQueue != "tq-v1" {
+		t.Errorf("Route(current) = %s, want tq-v1", taskQueue)
+	}
+
+	state := router.State()
+	if state.Starts["tq-v1"]+state.Starts["tq-v2"] != 2002 {
+		t.Errorf("State().Starts = %v, want 2002 starts", state.Starts)
+	}
+}
+
+func TestRouteWithoutCutover(t *testing.T) {
+	router, err := NewRouter("tq", "", 0)
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+
+	if taskQueue, track := router.Route("transfer-1", TrackNext); taskQueue != "tq" || track != TrackNext {
+		t.Errorf("Route(next) = %s, %s, want tq, next", taskQueue, track)
+	}
+	if err := router.SetPercent(50); err == nil {
+		t.Error("SetPercent() without a next task queue succeeded, want an error")
+	}
+	if got := router.TaskQueues(); len(got) != 1 {
+		t.Errorf("TaskQueues() = %v, want only the current one", got)
+	}
+}
+
+func TestSetPercent(t *testing.T) {
+	if _, err := NewRouter("tq-v1", "tq-v2", 101); err == nil {
+		t.Error("NewRouter() with 101 percent succeeded, want an error")
+	}
+
+	router, err := NewRouter("tq-v1", "tq-v2", 0)
+	if err != nil {
+		t.Fatalf("NewRouter() error = %v", err)
+	}
+
+	if err := router.SetPercent(100); err != nil {
+		t.Fatalf("SetPercent() error = %v", err)
+	}
+	if taskQueue, _ := router.Route("transfer-1", ""); taskQueue != "tq-v2" {
+		t.Errorf("Route() at 100 percent = %s, want tq-v2", taskQueue)
+	}
+}
@@ -0,0 +1,54 @@
+// Package numeric converts amounts between decimal.Decimal and the pgtype.Numeric of the NUMERIC columns, and checks
+// them against the precision of those columns. svc-balance and svc-transaction require this module through a replace
+// directive, so both read and write amounts the same way.
+package numeric
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrNull is returned when converting a NULL numeric
+	ErrNull = errors.New("numeric value is null")
+	// ErrNotFinite is returned when converting NaN or ±Infinity, which decimal.Decimal cannot represent
+	ErrNotFinite = errors.New("numeric value is not a finite number")
+)
+
+// FromDecimal converts a decimal to a numeric with the same sign, digits and scale
+func FromDecimal(d decimal.Decimal) pgtype.Numeric {
+	return pgtype.Numeric{
+		Int:   d.Coefficient(),
+		Exp:   d.Exponent(),
+		Valid: true,
+	}
+}
+
+// FromDecimalPtr converts an optional decimal to a numeric, mapping nil to NULL
+func FromDecimalPtr(d *decimal.Decimal) pgtype.Numeric {
+	if d == nil {
+		return pgtype.Numeric{}
+	}
+
+	return FromDecimal(*d)
+}
+
+// ToDecimal converts a numeric to a decimal. NULL, NaN and ±Infinity return an error.
+func ToDecimal(n pgtype.Numeric) (decimal.Decimal, error) {
+	if !n.Valid {
+		return decimal.Zero, ErrNull
+	}
+
+	if n.NaN || n.InfinityModifier != pgtype.Finite {
+		return decimal.Zero, ErrNotFinite
+	}
+
+	// pgx treats a nil coefficient as zero
+	if n.Int == nil {
+		return decimal.Zero, nil
+	}
+
+	return decimal.NewFromBigInt(n.Int, n.Exp), nil
+}
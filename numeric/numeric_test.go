@@ -0,0 +1,217 @@
+package numeric
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// maxFuzzExponent bounds fuzzed exponents so String() does not allocate gigabytes of zeros
+const maxFuzzExponent = 1000
+
+// pgText renders a numeric the way PostgreSQL would, independently of this package
+func pgText(t testing.TB, n pgtype.Numeric) string {
+	t.Helper()
+
+	text, err := n.MarshalJSON()
+	require.NoError(t, err)
+
+	return string(text)
+}
+
+func TestFromDecimal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    string
+		wantInt  string
+		wantExp  int32
+		wantText string
+	}{
+		{name: "zero", value: "0", wantInt: "0", wantExp: 0, wantText: "0"},
+		{name: "integer", value: "42", wantInt: "42", wantExp: 0, wantText: "42"},
+		{name: "scaled", value: "100.50", wantInt: "10050", wantExp: -2, wantText: "100.50"},
+		{name: "negative", value: "-7", wantInt: "-7", wantExp: 0, wantText: "-7"},
+		{name: "negative_scaled", value: "-100.50", wantInt: "-10050", wantExp: -2, wantText: "-100.50"},
+		{name: "negative_fraction", value: "-0.05", wantInt: "-5", wantExp: -2, wantText: "-0.05"},
+		{name: "positive_exponent", value: "1.23e5", wantInt: "123", wantExp: 3, wantText: "123000"},
+		{name: "tiny", value: "0.00000000000000000001", wantInt: "1", wantExp: -20, wantText: "0.00000000000000000001"},
+		{
+			name:     "beyond_int64",
+			value:    "-123456789012345678901234567890.0001",
+			wantInt:  "-1234567890123456789012345678900001",
+			wantExp:  -4,
+			wantText: "-123456789012345678901234567890.0001",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			n := FromDecimal(decimal.RequireFromString(tt.value))
+
+			require.True(t, n.Valid)
+			assert.Equal(t, tt.wantInt, n.Int.String())
+			assert.Equal(t, tt.wantExp, n.Exp)
+			assert.False(t, n.NaN)
+			assert.Equal(t, pgtype.Finite, n.InfinityModifier)
+			assert.Equal(t, tt.wantText, pgText(t, n))
+		})
+	}
+}
+
+func TestFromDecimalDoesNotAlias(t *testing.T) {
+	t.Parallel()
+
+	d := decimal.RequireFromString("12.34")
+	n := FromDecimal(d)
+	n.Int.Neg(n.Int)
+
+	assert.Equal(t, "12.34", d.String())
+}
+
+func TestFromDecimalPtr(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, FromDecimalPtr(nil).Valid)
+
+	d := decimal.RequireFromString("-9.99")
+	n := FromDecimalPtr(&d)
+	require.True(t, n.Valid)
+	assert.Equal(t, "-9.99", pgText(t, n))
+}
+
+func TestToDecimal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		numeric pgtype.Numeric
+		want    string
+		wantErr error
+	}{
+		{name: "null", numeric: pgtype.Numeric{}, wantErr: ErrNull},
+		{name: "nan", numeric: pgtype.Numeric{NaN: true, Valid: true}, wantErr: ErrNotFinite},
+		{name: "infinity", numeric: pgtype.Numeric{InfinityModifier: pgtype.Infinity, Valid: true}, wantErr: ErrNotFinite},
+		{name: "negative_infinity", numeric: pgtype.Numeric{InfinityModifier: pgtype.NegativeInfinity, Valid: true}, wantErr: ErrNotFinite},
+		{name: "nil_coefficient", numeric: pgtype.Numeric{Valid: true}, want: "0"},
+		{name: "scaled", numeric: pgtype.Numeric{Int: big.NewInt(10050), Exp: -2, Valid: true}, want: "100.5"},
+		{name: "negative_scaled", numeric: pgtype.Numeric{Int: big.NewInt(-10050), Exp: -2, Valid: true}, want: "-100.5"},
+		{name: "positive_exponent", numeric: pgtype.Numeric{Int: big.NewInt(123), Exp: 2, Valid: true}, want: "12300"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ToDecimal(tt.numeric)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, got.Equal(decimal.RequireFromString(tt.want)), "got %s, want %s", got, tt.want)
+		})
+	}
+}
+
+// TestRoundTrip scans PostgreSQL text into a numeric the way pgx does and converts it there and back again
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, text := range []string{
+		"0", "0.0000", "1", "-1", "100.50", "-100.50", "-0.0500", "5000.0000", "12300", "-12300",
+		"9999999999999999999999999999999999.9999", "-0.000000000000000000000000000001", "0.00000000000000000001", "150000000000000000000",
+	} {
+		t.Run(text, func(t *testing.T) {
+			t.Parallel()
+
+			var scanned pgtype.Numeric
+			require.NoError(t, scanned.Scan(text))
+
+			d, err := ToDecimal(scanned)
+			require.NoError(t, err)
+			assert.True(t, d.Equal(decimal.RequireFromString(text)), "ToDecimal(%s) = %s", text, d)
+
+			roundTripped := FromDecimal(d)
+			assert.True(t, decimal.RequireFromString(pgText(t, roundTripped)).Equal(d), "FromDecimal(%s) = %s", d, pgText(t, roundTripped))
+		})
+	}
+
+	for _, text := range []string{"NaN", "Infinity", "-Infinity"} {
+		var scanned pgtype.Numeric
+		require.NoError(t, scanned.Scan(text))
+
+		_, err := ToDecimal(scanned)
+		assert.ErrorIs(t, err, ErrNotFinite, text)
+	}
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	for _, seed := range []string{"0", "100.50", "-0.05", "0.00001", "1E+5", "123e2", "-123.45", "12345678901234567890.123456789"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, value string) {
+		d, err := decimal.NewFromString(value)
+		if err != nil || d.Exponent() > maxFuzzExponent || d.Exponent() < -maxFuzzExponent {
+			t.Skip()
+		}
+
+		n := FromDecimal(d)
+
+		result, err := ToDecimal(n)
+		if err != nil {
+			t.Fatalf("ToDecimal(%s) error = %v", value, err)
+		}
+
+		if !result.Equal(d) {
+			t.Errorf("round trip of %s = %s, lost precision", d.String(), result.String())
+		}
+
+		if text := pgText(t, n); !decimal.RequireFromString(text).Equal(d) {
+			t.Errorf("FromDecimal(%s) renders as %s", d.String(), text)
+		}
+	})
+}
+
+func FuzzToDecimal(f *testing.F) {
+	f.Add([]byte{0x30, 0x39}, false, int32(-2))
+	f.Add([]byte{0x05}, true, int32(-2))
+	f.Add([]byte{0x7b}, false, int32(2))
+	f.Add([]byte{}, false, int32(0))
+
+	f.Fuzz(func(t *testing.T, magnitude []byte, negative bool, exp int32) {
+		if exp > maxFuzzExponent || exp < -maxFuzzExponent {
+			t.Skip()
+		}
+
+		coefficient := new(big.Int).SetBytes(magnitude)
+		if negative {
+			coefficient.Neg(coefficient)
+		}
+
+		n := pgtype.Numeric{Int: coefficient, Exp: exp, Valid: true}
+
+		result, err := ToDecimal(n)
+		if err != nil {
+			t.Fatalf("ToDecimal(%se%d) error = %v", coefficient.String(), exp, err)
+		}
+
+		expected, err := decimal.NewFromString(pgText(t, n))
+		if err != nil {
+			t.Fatalf("decimal.NewFromString(%s) error = %v", pgText(t, n), err)
+		}
+
+		if !result.Equal(expected) {
+			t.Errorf("ToDecimal(%se%d) = %s, want %s", coefficient.String(), exp, result.String(), expected.String())
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package numeric
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrPrecisionExceeded is returned for values a NUMERIC column would round or reject: more fractional digits than
+// its scale, or more integer digits than its precision leaves room for
+var ErrPrecisionExceeded = errors.New("precision exceeded")
+
+// Precision is the precision and scale of a NUMERIC(precision, scale) column
+type Precision struct {
+	Precision int32 // Total significant digits
+	Scale     int32 // Digits after the decimal point
+}
+
+// DefaultPrecision matches the DECIMAL(19,4) amount and balance columns of the core schema
+var DefaultPrecision = Precision{Precision: 19, Scale: 4}
+
+// Validate rejects precisions Postgres would not accept for a column
+func (p Precision) Validate() error {
+	if p.Precision < 1 || p.Precision > 1000 {
+		return fmt.Errorf("precision must be between 1 and 1000, got %d", p.Precision)
+	}
+	if p.Scale < 0 || p.Scale > p.Precision {
+		return fmt.Errorf("scale must be between 0 and the precision %d, got %d", p.Precision, p.Scale)
+	}
+
+	return nil
+}
+
+// Check returns ErrPrecisionExceeded when d cannot be stored in the column as it is. Trailing zeros do not count,
+// so 1.50000 fits a scale of 4.
+func (p Precision) Check(d decimal.Decimal) error {
+	if !d.Equal(d.Truncate(p.Scale)) {
+		return fmt.Errorf("%w: %s has more than %d decimal places", ErrPrecisionExceeded, d.String(), p.Scale)
+	}
+
+	limit := decimal.New(1, p.Precision-p.Scale)
+	if d.Abs().GreaterThanOrEqual(limit) {
+		return fmt.Errorf("%w: %s has more than %d integer digits", ErrPrecisionExceeded, d.String(), p.Precision-p.Scale)
+	}
+
+	return nil
+}
+
+// String formats p as a column type, e.g. NUMERIC(19,4)
+func (p Precision) String() string {
+	return fmt.Sprintf("NUMERIC(%d,%d)", p.Precision, p.Scale)
+}
@@ -0,0 +1,52 @@
+package numeric
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrecisionCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "zero", value: "0"},
+		{name: "full_scale", value: "100.1234"},
+		{name: "trailing_zeros", value: "1.50000000"},
+		{name: "largest", value: "999999999999999.9999"},
+		{name: "largest_negative", value: "-999999999999999.9999"},
+		{name: "too_many_decimals", value: "0.00001", wantErr: true},
+		{name: "rounded_by_column", value: "100.12345", wantErr: true},
+		{name: "too_many_integer_digits", value: "1000000000000000", wantErr: true},
+		{name: "too_many_integer_digits_negative", value: "-1000000000000000.5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := DefaultPrecision.Check(decimal.RequireFromString(tt.value))
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrPrecisionExceeded)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPrecisionValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, DefaultPrecision.Validate())
+	assert.NoError(t, Precision{Precision: 4, Scale: 4}.Validate())
+	assert.Error(t, Precision{}.Validate())
+	assert.Error(t, Precision{Precision: 10, Scale: 11}.Validate())
+	assert.Error(t, Precision{Precision: 10, Scale: -1}.Validate())
+	assert.Equal(t, "NUMERIC(19,4)", DefaultPrecision.String())
+}
@@ -0,0 +1,6 @@
+-- Reference data every profile needs, as in _init/postgres/04-data.sql
+
+-- Business validation rules, the values that were hardcoded before they became configurable
+INSERT INTO business_rules (name, kind, currency, threshold, severity) VALUES
+    ('name_format', 'min_account_name_length', NULL, 2, 'warning'),
+    ('transaction_limits', 'max_transaction_amount', NULL, 100000.0000, 'warning');
@@ -0,0 +1,171 @@
+package pgsqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// timestampFormat is the strftime format of the timestamps SQLite writes, the same text as formatTime
+const timestampFormat = "'%Y-%m-%d %H:%M:%f+00:00'"
+
+// rewrite is one rule of Translate
+type rewrite struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// rewrites translate the Postgres the generated queries use to SQLite, in order. They work on the query text, so
+// they assume the casts, functions and keywords they match do not appear in string literals.
+var rewrites = []rewrite{
+	// The tables are not in a schema
+	{regexp.MustCompile(`\bcore\.`), ""},
+
+	// Values are stored in the types the arguments are converted to, so casts are dropped
+	{regexp.MustCompile(`::[A-Za-z_][A-Za-z0-9_.]*(\[\])?`), ""},
+
+	// Timestamps are UTC text in the format of formatTime
+	{regexp.MustCompile(`(?i)\bNOW\(\)\s*-\s*INTERVAL\s*'([^']+)'`), "strftime(" + timestampFormat + ", 'now', '-$1')"},
+	{regexp.MustCompile(`(?i)\bNOW\(\)`), "strftime(" + timestampFormat + ", 'now')"},
+
+	// Array arguments are JSON arrays
+	{regexp.MustCompile(`(?i)=\s*ANY\((\$\d+)\)`), "IN (SELECT value FROM json_each($1))"},
+	{regexp.MustCompile(`(?i)<>\s*ALL\((\$\d+)\)`), "NOT IN (SELECT value FROM json_each($1))"},
+	{regexp.MustCompile(`(?i)\bcardinality\(`), "json_array_length("},
+
+	// LIKE is case insensitive, and MIN with several arguments is the scalar minimum
+	{regexp.MustCompile(`(?i)\bILIKE\b`), "LIKE"},
+	{regexp.MustCompile(`(?i)\bLEAST\(`), "MIN("},
+
+	// Transactions hold the database lock, so rows are never locked one by one
+	{regexp.MustCompile(`(?i)\s*\bFOR UPDATE(\s+OF\s+\w+)?(\s+SKIP LOCKED)?`), ""},
+}
+
+// queryNamePattern matches the first line sqlc writes at the top of a generated query
+var queryNamePattern = regexp.MustCompile(`^-- name: (\w+)`)
+
+// Translate rewrites a query of the generated sqlc code to SQLite. Queries the rules do not cover, such as those
+// updating JSONB values, are given in Options.Queries instead.
+func Translate(sql string) string {
+	for _, rule := range rewrites {
+		sql = rule.pattern.ReplaceAllString(sql, rule.replacement)
+	}
+
+	return sql
+}
+
+// queryName returns the sqlc name of a generated query, or "" for other query text
+func queryName(sql string) string {
+	if match := queryNamePattern.FindStringSubmatch(sql); match != nil {
+		return match[1]
+	}
+
+	return ""
+}
+
+// dmlPattern finds the statement of a query starting with common table expressions, which SQLite only allows to
+// select
+var dmlPattern = regexp.MustCompile(`(?i)\b(INSERT|UPDATE|DELETE)\b`)
+
+// commandTag returns the Postgres command tag of a statement, so RowsAffected of :execrows queries reads the count
+func commandTag(sql string, rowsAffected int64) pgconn.CommandTag {
+	verb := ""
+	for _, line := range strings.Split(sql, "\n") {
+		if line = strings.TrimSpace(line); line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+
+		verb = strings.ToUpper(strings.Fields(line)[0])
+		break
+	}
+
+	if verb == "WITH" {
+		verb = "SELECT"
+		if match := dmlPattern.FindString(sql); match != "" {
+			verb = strings.ToUpper(match)
+		}
+	}
+
+	switch verb {
+	case "INSERT":
+		return pgconn.NewCommandTag(fmt.Sprintf("INSERT 0 %d", rowsAffected))
+	case "UPDATE", "DELETE", "SELECT":
+		return pgconn.NewCommandTag(fmt.Sprintf("%s %d", verb, rowsAffected))
+	default:
+		return pgconn.NewCommandTag(verb)
+	}
+}
+
+// returningPattern splits an INSERT or UPDATE statement returning rows into the statement, its table and the columns
+// it returns
+var returningPattern = regexp.MustCompile(`(?is)^((?:\s*--[^\n]*\n)*\s*(?:INSERT\s+INTO|UPDATE)\s+(\w+).*)\bRETURNING\b(.*)$`)
+
+// statement is the SQLite text of a query
+type statement struct {
+	sql string
+
+	// table and returning are set for INSERT and UPDATE statements with a RETURNING clause. RETURNING of SQLite
+	// shows the row before the AFTER triggers of schema.sql, which do what BEFORE triggers do in Postgres, so the
+	// rows are read again once the statement is done.
+	table, returning string
+}
+
+func newStatement(sql string) *statement {
+	match := returningPattern.FindStringSubmatch(sql)
+	if match == nil {
+		return &statement{sql: sql}
+	}
+
+	return &statement{sql: match[1], table: match[2], returning: strings.TrimSpace(match[3])}
+}
+
+// query returns the text run by Query: for a statement returning rows, it returns the rowids of the rows instead
+func (s *statement) query() string {
+	if s.returning == "" {
+		return s.sql
+	}
+
+	return s.sql + "RETURNING " + s.table + ".rowid"
+}
+
+// readReturned reads the rowids returned by the query of s and selects the returned columns of those rows, in the
+// same order
+func (s *statement) readReturned(ctx context.Context, conn conn, rowids *sql.Rows) (*sql.Rows, error) {
+	var ids []int64
+	for rowids.Next() {
+		var id int64
+		if err := rowids.Scan(&id); err != nil {
+			rowids.Close()
+			return nil, pgError(err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rowids.Err(); err != nil {
+		rowids.Close()
+		return nil, pgError(err)
+	}
+	if err := rowids.Close(); err != nil {
+		return nil, pgError(err)
+	}
+
+	idArray, err := json.Marshal(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlRows, err := conn.QueryContext(ctx, fmt.Sprintf(`WITH returned_rows (returned_position, returned_rowid) AS (
+    SELECT key, value FROM json_each($1)
+)
+SELECT %s FROM %s JOIN returned_rows ON %[2]s.rowid = returned_rows.returned_rowid
+ORDER BY returned_rows.returned_position`, s.returning, s.table), string(idArray))
+	if err != nil {
+		return nil, pgError(err)
+	}
+
+	return sqlRows, nil
+}
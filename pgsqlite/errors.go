@@ -0,0 +1,103 @@
+package pgsqlite
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Postgres error codes the services check, and those of the other violations for the logs
+const (
+	uniqueViolationCode      = "23505"
+	foreignKeyViolationCode  = "23503"
+	checkViolationCode       = "23514"
+	notNullViolationCode     = "23502"
+	raiseExceptionCode       = "P0001"
+	serializationFailureCode = "40001"
+)
+
+// sqliteMessagePattern takes the message of SQLite out of a driver error: "<result>: <message> (<code>)"
+var sqliteMessagePattern = regexp.MustCompile(`^[^:]*: (.*) \(\d+\)( \(SQLITE_BUSY\))?$`)
+
+// uniqueIndexPattern matches the columns or index of a failed UNIQUE constraint
+var uniqueIndexPattern = regexp.MustCompile(`^UNIQUE constraint failed: (?:index '(\w+)'|(.*))$`)
+
+// pgError converts the constraint, trigger and locking errors of SQLite to the *pgconn.PgError Postgres returns
+// for them, so callers checking the code or constraint of an error keep working. Other errors are returned as they
+// are.
+func pgError(err error) error {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+
+	message := sqliteErr.Error()
+	if match := sqliteMessagePattern.FindStringSubmatch(message); match != nil {
+		message = match[1]
+	}
+
+	switch code := sqliteErr.Code(); {
+	case code == sqlite3.SQLITE_CONSTRAINT_UNIQUE || code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+		constraint := uniqueConstraintName(message, code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY)
+
+		return &pgconn.PgError{
+			Severity:       "ERROR",
+			Code:           uniqueViolationCode,
+			Message:        fmt.Sprintf("duplicate key value violates unique constraint %q", constraint),
+			Detail:         message,
+			ConstraintName: constraint,
+		}
+	case code == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+		return &pgconn.PgError{Severity: "ERROR", Code: foreignKeyViolationCode, Message: message}
+	case code == sqlite3.SQLITE_CONSTRAINT_CHECK:
+		constraint := strings.TrimPrefix(message, "CHECK constraint failed: ")
+
+		return &pgconn.PgError{
+			Severity:       "ERROR",
+			Code:           checkViolationCode,
+			Message:        fmt.Sprintf("new row violates check constraint %q", constraint),
+			ConstraintName: constraint,
+		}
+	case code == sqlite3.SQLITE_CONSTRAINT_NOTNULL:
+		return &pgconn.PgError{Severity: "ERROR", Code: notNullViolationCode, Message: message}
+	case code == sqlite3.SQLITE_CONSTRAINT_TRIGGER:
+		// RAISE in the triggers of schema.sql, with the message of the Postgres trigger
+		return &pgconn.PgError{Severity: "ERROR", Code: raiseExceptionCode, Message: message}
+	case code&0xff == sqlite3.SQLITE_BUSY || code&0xff == sqlite3.SQLITE_LOCKED:
+		// Another process held the database lock for longer than the busy timeout
+		return &pgconn.PgError{Severity: "ERROR", Code: serializationFailureCode, Message: message}
+	}
+
+	return err
+}
+
+// uniqueConstraintName returns the name Postgres gives the constraint of a UNIQUE failure: the index of an expression,
+// table_pkey for a primary key and table_columns_key otherwise
+func uniqueConstraintName(message string, primaryKey bool) string {
+	match := uniqueIndexPattern.FindStringSubmatch(message)
+	if match == nil {
+		return ""
+	}
+	if match[1] != "" {
+		return match[1]
+	}
+
+	// table.column, table.column
+	var table string
+	var columns []string
+	for _, column := range strings.Split(match[2], ", ") {
+		table, column, _ = strings.Cut(column, ".")
+		columns = append(columns, column)
+	}
+
+	if primaryKey {
+		return table + "_pkey"
+	}
+
+	return table + "_" + strings.Join(columns, "_") + "_key"
+}
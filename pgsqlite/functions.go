@@ -0,0 +1,49 @@
+package pgsqlite
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+
+	"modernc.org/sqlite"
+)
+
+// The Postgres functions schema.sql uses, registered for every connection the driver opens
+func init() {
+	sqlite.MustRegisterScalarFunction("gen_random_uuid", 0, genRandomUUID)
+	sqlite.MustRegisterDeterministicScalarFunction("sha256_hex", 1, sha256Hex)
+}
+
+// genRandomUUID returns the text of a random (version 4) UUID
+func genRandomUUID(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return nil, err
+	}
+
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return formatUUID(b), nil
+}
+
+// sha256Hex returns the hex SHA-256 of the UTF-8 text of its argument, as encode(sha256(convert_to(x, 'UTF8')), 'hex')
+func sha256Hex(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	var text string
+	switch arg := args[0].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		text = arg
+	case []byte:
+		text = string(arg)
+	default:
+		text = fmt.Sprint(arg)
+	}
+
+	sum := sha256.Sum256([]byte(text))
+
+	return hex.EncodeToString(sum[:]), nil
+}
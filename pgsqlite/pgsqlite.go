@@ -0,0 +1,298 @@
+// Package pgsqlite runs the queries sqlc generates for Postgres on a SQLite database, so a service keeps its data in
+// a single file without a Postgres server. A DB takes the place of the pgx pool behind sqlc.New: it rewrites each
+// query to the SQLite dialect, converts the pgtype arguments and results, and reports constraint violations as the
+// *pgconn.PgError the services already handle.
+//
+// The schema of schema.sql follows the migrations of the services, with the same tables, constraints and triggers,
+// so svc-transaction and svc-balance can share one file the way they share one Postgres database.
+package pgsqlite
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "modernc.org/sqlite" // Pure Go driver, so the services still build with CGO_ENABLED=0
+)
+
+// schemaVersion is the user_version of a database with the tables of schema.sql
+const schemaVersion = 1
+
+var (
+	//go:embed schema.sql
+	schemaSQL string
+
+	//go:embed data.sql
+	dataSQL string
+
+	//go:embed demo-data.sql
+	demoDataSQL string
+)
+
+// parameterPattern matches the parameters of a query
+var parameterPattern = regexp.MustCompile(`\$(\d+)`)
+
+// Options configures Open
+type Options struct {
+	// DemoData loads the demo accounts of _init/postgres/seed/demo-data.sql when the database is created
+	DemoData bool
+
+	// Queries holds the SQLite text of the queries the dialect rewrite cannot translate, by sqlc query name. They use
+	// the $N parameters of the Postgres text.
+	Queries map[string]string
+}
+
+// DBTX is implemented by DB and Tx; both satisfy the DBTX interface of the generated sqlc packages
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// DB is a SQLite database running Postgres queries. It is safe for concurrent use; transactions take the database
+// lock when they begin, so they run one at a time across every process using the file.
+type DB struct {
+	db      *sql.DB
+	queries map[string]string
+	mutex   sync.Mutex
+
+	statements sync.Map // Postgres query text to *statement
+}
+
+// Open opens the SQLite database at path, creating it with the schema and reference data when it does not exist
+func Open(ctx context.Context, path string, options Options) (*DB, error) {
+	if path == "" {
+		return nil, errors.New("sqlite database path is required")
+	}
+
+	dsn := "file:" + path + "?" + url.Values{
+		"_pragma": {"busy_timeout(10000)", "journal_mode(WAL)", "synchronous(NORMAL)", "foreign_keys(1)"},
+		"_txlock": {"immediate"},
+	}.Encode()
+
+	sqlDB, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+
+	db := &DB{db: sqlDB, queries: options.Queries}
+
+	if err := db.createSchema(ctx, options.DemoData); err != nil {
+		sqlDB.Close()
+
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// createSchema creates the tables of a new database. The check runs in a transaction, so of two services opening
+// the same new file only the first one creates them.
+func (db *DB) createSchema(ctx context.Context, demoData bool) error {
+	return db.WithTx(ctx, func(tx *Tx) error {
+		var version int
+		if err := tx.tx.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+			return fmt.Errorf("failed to read sqlite schema version: %w", pgError(err))
+		}
+
+		switch {
+		case version == schemaVersion:
+			return nil
+		case version > schemaVersion:
+			return fmt.Errorf("sqlite schema version %d is newer than %d: upgrade the service", version, schemaVersion)
+		}
+
+		scripts := []string{schemaSQL, dataSQL}
+		if demoData {
+			scripts = append(scripts, demoDataSQL)
+		}
+
+		for _, script := range scripts {
+			if _, err := tx.tx.ExecContext(ctx, script); err != nil {
+				return fmt.Errorf("failed to create sqlite schema: %w", pgError(err))
+			}
+		}
+
+		if _, err := tx.tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", schemaVersion)); err != nil {
+			return fmt.Errorf("failed to set sqlite schema version: %w", pgError(err))
+		}
+
+		return nil
+	})
+}
+
+// Close closes the database
+func (db *DB) Close() error {
+	return db.db.Close()
+}
+
+// Exec runs a query returning no rows
+func (db *DB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return db.exec(ctx, db.db, sql, args)
+}
+
+// Query runs a query returning rows
+func (db *DB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return db.query(ctx, db.db, sql, args)
+}
+
+// QueryRow runs a query returning at most one row; Scan returns pgx.ErrNoRows when there is none
+func (db *DB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return db.queryRow(ctx, db.db, sql, args)
+}
+
+// Prepare checks that the SQLite text of a query compiles against the schema, without running it. The driver only
+// compiles a statement when it runs, so the query is compiled by EXPLAIN, with NULL for its parameters.
+func (db *DB) Prepare(ctx context.Context, sql string) error {
+	translated := db.statement(sql).query()
+
+	var args []any
+	for _, match := range parameterPattern.FindAllStringSubmatch(translated, -1) {
+		if n, _ := strconv.Atoi(match[1]); n > len(args) {
+			args = make([]any, n)
+		}
+	}
+
+	rows, err := db.db.QueryContext(ctx, "EXPLAIN "+translated, args...)
+	if err != nil {
+		return pgError(err)
+	}
+
+	return rows.Close()
+}
+
+// WithTx runs fn in a transaction, committed when fn returns nil and rolled back otherwise. A panic in fn rolls the
+// transaction back and is re-raised.
+func (db *DB) WithTx(ctx context.Context, fn func(*Tx) error) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	sqlTx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", pgError(err))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			_ = sqlTx.Rollback()
+			panic(r)
+		}
+	}()
+
+	if err := fn(&Tx{db: db, tx: sqlTx}); err != nil {
+		if rbErr := sqlTx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			return fmt.Errorf("RollbackTx() error = %v: Original error = %w", rbErr, err)
+		}
+
+		return err
+	}
+
+	if err := sqlTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", pgError(err))
+	}
+
+	return nil
+}
+
+// Tx is a transaction started by DB.WithTx
+type Tx struct {
+	db *DB
+	tx *sql.Tx
+}
+
+// Exec runs a query returning no rows in the transaction
+func (tx *Tx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return tx.db.exec(ctx, tx.tx, sql, args)
+}
+
+// Query runs a query returning rows in the transaction
+func (tx *Tx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return tx.db.query(ctx, tx.tx, sql, args)
+}
+
+// QueryRow runs a query returning at most one row in the transaction
+func (tx *Tx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return tx.db.queryRow(ctx, tx.tx, sql, args)
+}
+
+// conn is implemented by *sql.DB and *sql.Tx
+type conn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func (db *DB) exec(ctx context.Context, conn conn, sql string, args []any) (pgconn.CommandTag, error) {
+	values, err := argValues(args)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+
+	result, err := conn.ExecContext(ctx, db.statement(sql).sql, values...)
+	if err != nil {
+		return pgconn.CommandTag{}, pgError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return pgconn.CommandTag{}, pgError(err)
+	}
+
+	return commandTag(sql, rowsAffected), nil
+}
+
+func (db *DB) query(ctx context.Context, conn conn, sql string, args []any) (pgx.Rows, error) {
+	values, err := argValues(args)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := db.statement(sql)
+
+	sqlRows, err := conn.QueryContext(ctx, statement.query(), values...)
+	if err != nil {
+		return nil, pgError(err)
+	}
+
+	if statement.returning != "" {
+		if sqlRows, err = statement.readReturned(ctx, conn, sqlRows); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := newRows(sqlRows)
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (db *DB) queryRow(ctx context.Context, conn conn, sql string, args []any) pgx.Row {
+	rows, err := db.query(ctx, conn, sql, args)
+
+	return &row{rows: rows, err: err}
+}
+
+// statement returns the SQLite statement of a query, from Options.Queries or the dialect rewrite
+func (db *DB) statement(sql string) *statement {
+	if cached, ok := db.statements.Load(sql); ok {
+		return cached.(*statement)
+	}
+
+	translated, ok := db.queries[queryName(sql)]
+	if !ok {
+		translated = Translate(sql)
+	}
+
+	cached, _ := db.statements.LoadOrStore(sql, newStatement(translated))
+
+	return cached.(*statement)
+}
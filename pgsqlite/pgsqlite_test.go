@@ -0,0 +1,354 @@
+package pgsqlite
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// openTestDB opens a new database in a temporary directory
+func openTestDB(t *testing.T, options Options) (*DB, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := Open(context.Background(), path, options)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db, path
+}
+
+// count returns the number of rows of a table
+func count(t *testing.T, db *DB, table string) int64 {
+	t.Helper()
+
+	var n int64
+	if err := db.QueryRow(context.Background(), "SELECT COUNT(*) FROM core."+table).Scan(&n); err != nil {
+		t.Fatalf("count %s error = %v", table, err)
+	}
+
+	return n
+}
+
+func TestOpen(t *testing.T) {
+	ctx := context.Background()
+
+	db, path := openTestDB(t, Options{DemoData: true})
+
+	if n := count(t, db, "accounts"); n != 10 {
+		t.Errorf("%d demo accounts, want 10", n)
+	}
+	if n := count(t, db, "business_rules"); n != 2 {
+		t.Errorf("%d business rules, want 2", n)
+	}
+
+	// A second process opening the file finds the schema and does not load the data again
+	other, err := Open(ctx, path, Options{DemoData: true})
+	if err != nil {
+		t.Fatalf("Open() of an existing database error = %v", err)
+	}
+	defer other.Close()
+
+	if n := count(t, other, "accounts"); n != 10 {
+		t.Errorf("%d accounts after opening again, want 10", n)
+	}
+
+	empty, _ := openTestDB(t, Options{})
+	if n := count(t, empty, "accounts"); n != 0 {
+		t.Errorf("%d accounts without demo data, want 0", n)
+	}
+	if n := count(t, empty, "business_rules"); n != 2 {
+		t.Errorf("%d business rules without demo data, want 2", n)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		postgres, sqlite string
+	}{
+		{"SELECT id FROM core.accounts WHERE id = $1::UUID", "SELECT id FROM accounts WHERE id = $1"},
+		{"WHERE created_at < NOW() - INTERVAL '5 minutes'", "WHERE created_at < strftime('%Y-%m-%d %H:%M:%f+00:00', 'now', '-5 minutes')"},
+		{"SET updated_at = NOW()", "SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')"},
+		{"WHERE id = ANY($1::UUID[])", "WHERE id IN (SELECT value FROM json_each($1))"},
+		{"AND (COALESCE(cardinality($2::TEXT[]), 0) = 0", "AND (COALESCE(json_array_length($2), 0) = 0"},
+		{"WHERE name ILIKE $1::TEXT || '%'", "WHERE name LIKE $1 || '%'"},
+		{"started_at = LEAST(a, b)", "started_at = MIN(a, b)"},
+		{"WHERE id = $1\nFOR UPDATE OF t SKIP LOCKED", "WHERE id = $1"},
+	}
+
+	for _, test := range tests {
+		if got := Translate(test.postgres); got != test.sqlite {
+			t.Errorf("Translate(%q) = %q, want %q", test.postgres, got, test.sqlite)
+		}
+	}
+}
+
+func TestQueries(t *testing.T) {
+	ctx := context.Background()
+
+	db, _ := openTestDB(t, Options{
+		Queries: map[string]string{"AccountName": "SELECT account_name || '!' FROM accounts WHERE id = $1"},
+	})
+
+	accountID := pgtype.UUID{Bytes: [16]byte{1, 2, 3}, Valid: true}
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 6_000_000, time.UTC)
+
+	tag, err := db.Exec(ctx, `INSERT INTO core.accounts (id, account_number, account_name, balance, created_at)
+VALUES ($1, $2, $3, $4, $5)`, accountID, "ACC100", "Test", pgtype.Numeric{Int: bigInt(12345), Exp: -2, Valid: true}, createdAt)
+	if err != nil {
+		t.Fatalf("insert error = %v", err)
+	}
+	if tag.RowsAffected() != 1 || !tag.Insert() {
+		t.Errorf("insert command tag = %q", tag)
+	}
+
+	var (
+		id      pgtype.UUID
+		balance pgtype.Numeric
+		created pgtype.Timestamptz
+		updated time.Time
+		number  string
+	)
+	err = db.QueryRow(ctx, "SELECT id, balance, created_at, updated_at, account_number FROM core.accounts WHERE id = $1",
+		accountID).Scan(&id, &balance, &created, &updated, &number)
+	if err != nil {
+		t.Fatalf("select error = %v", err)
+	}
+	if id != accountID || number != "ACC100" {
+		t.Errorf("id, account_number = %v, %s", id, number)
+	}
+	if f, _ := balance.Float64Value(); f.Float64 != 123.45 {
+		t.Errorf("balance = %v, want 123.45", f.Float64)
+	}
+	if !created.Time.Equal(createdAt) {
+		t.Errorf("created_at = %v, want %v", created.Time, createdAt)
+	}
+	if time.Since(updated) > time.Minute {
+		t.Errorf("updated_at = %v, want the time of the insert", updated)
+	}
+
+	var name string
+	if err := db.QueryRow(ctx, "-- name: AccountName :one\nSELECT 'not overridden'", accountID).Scan(&name); err != nil || name != "Test!" {
+		t.Errorf("overridden query = %q, %v", name, err)
+	}
+
+	if err := db.QueryRow(ctx, "SELECT id FROM core.accounts WHERE id = $1", pgtype.UUID{}).Scan(&id); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("select of no row error = %v, want pgx.ErrNoRows", err)
+	}
+
+	rows, err := db.Query(ctx, "SELECT account_number FROM core.accounts WHERE account_number = ANY($1::TEXT[])", []string{"ACC100", "ACC999"})
+	if err != nil {
+		t.Fatalf("select with an array error = %v", err)
+	}
+	numbers, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil || len(numbers) != 1 {
+		t.Errorf("select with an array = %v, %v", numbers, err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	ctx := context.Background()
+
+	db, _ := openTestDB(t, Options{DemoData: true})
+
+	tests := []struct {
+		name       string
+		sql        string
+		args       []any
+		code       string
+		constraint string
+		message    string
+	}{
+		{
+			name:       "unique column",
+			sql:        "INSERT INTO core.accounts (account_number, account_name) VALUES ('ACC001', 'Duplicate')",
+			code:       uniqueViolationCode,
+			constraint: "accounts_account_number_key",
+		},
+		{
+			name:       "primary key",
+			sql:        "INSERT INTO core.account_owners (account_id, owner_id) VALUES ('550e8400-e29b-41d4-a716-446655440003', 'tech-corp-cfo')",
+			code:       uniqueViolationCode,
+			constraint: "account_owners_pkey",
+		},
+		{
+			name:       "unique expression",
+			sql:        "INSERT INTO core.business_rules (name, kind, threshold, severity) VALUES ('name_format', 'min_balance', 1, 'error')",
+			code:       uniqueViolationCode,
+			constraint: "idx_business_rules_name_currency",
+		},
+		{
+			name:       "check",
+			sql:        "UPDATE core.accounts SET balance = -1 WHERE account_number = 'ACC001'",
+			code:       checkViolationCode,
+			constraint: "accounts_balance_check",
+		},
+		{
+			name: "foreign key",
+			sql:  "INSERT INTO core.account_aliases (alias, alias_type, account_id) VALUES ('a@example.com', 'email', '00000000-0000-0000-0000-000000000000')",
+			code: foreignKeyViolationCode,
+		},
+		{
+			name:    "trigger",
+			sql:     "UPDATE core.accounts SET balance = 1 WHERE account_number = 'ACC007'",
+			code:    raiseExceptionCode,
+			message: "Cannot modify balance for account with status: suspended",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := db.Exec(ctx, test.sql, test.args...)
+
+		var pgErr *pgconn.PgError
+		if !errors.As(err, &pgErr) {
+			t.Errorf("%s: error = %v, want a *pgconn.PgError", test.name, err)
+			continue
+		}
+		if pgErr.Code != test.code || pgErr.ConstraintName != test.constraint {
+			t.Errorf("%s: code, constraint = %s, %s, want %s, %s", test.name, pgErr.Code, pgErr.ConstraintName, test.code, test.constraint)
+		}
+		if test.message != "" && pgErr.Message != test.message {
+			t.Errorf("%s: message = %q, want %q", test.name, pgErr.Message, test.message)
+		}
+	}
+}
+
+func TestWithTx(t *testing.T) {
+	ctx := context.Background()
+
+	db, _ := openTestDB(t, Options{})
+
+	insert := "INSERT INTO core.accounts (account_number, account_name) VALUES ($1, 'Test')"
+
+	errRollback := errors.New("roll back")
+	err := db.WithTx(ctx, func(tx *Tx) error {
+		if _, err := tx.Exec(ctx, insert, "ACC100"); err != nil {
+			t.Fatalf("insert error = %v", err)
+		}
+
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Errorf("WithTx() error = %v, want the error of the callback", err)
+	}
+
+	recovered := func() (recovered any) {
+		defer func() {
+			recovered = recover()
+		}()
+
+		_ = db.WithTx(ctx, func(tx *Tx) error {
+			if _, err := tx.Exec(ctx, insert, "ACC101"); err != nil {
+				t.Fatalf("insert error = %v", err)
+			}
+
+			panic(errRollback)
+		})
+
+		return nil
+	}()
+	if recovered != errRollback {
+		t.Errorf("WithTx() recovered %v, want the panic of the callback re-raised", recovered)
+	}
+
+	if n := count(t, db, "accounts"); n != 0 {
+		t.Errorf("%d accounts after the rollbacks, want 0", n)
+	}
+
+	if err := db.WithTx(ctx, func(tx *Tx) error {
+		_, err := tx.Exec(ctx, insert, "ACC102")
+		return err
+	}); err != nil {
+		t.Fatalf("WithTx() error = %v", err)
+	}
+
+	if n := count(t, db, "accounts"); n != 1 {
+		t.Errorf("%d accounts after the commit, want 1", n)
+	}
+}
+
+func TestBalanceHistoryChain(t *testing.T) {
+	ctx := context.Background()
+
+	db, _ := openTestDB(t, Options{DemoData: true})
+
+	const accountID = "550e8400-e29b-41d4-a716-446655440001"
+
+	_, err := db.Exec(ctx, `INSERT INTO core.account_balance_history (account_id, old_balance, new_balance, balance_change, operation)
+VALUES ($1, 5000, 4999.9, -0.1, 'debit')`, accountID)
+	if err != nil {
+		t.Fatalf("insert error = %v", err)
+	}
+
+	rows, err := db.Query(ctx, `SELECT sequence_number, chain_hash, COALESCE(transaction_id, ''), old_balance, new_balance, balance_change, operation
+FROM core.account_balance_history WHERE account_id = $1 ORDER BY sequence_number`, accountID)
+	if err != nil {
+		t.Fatalf("select error = %v", err)
+	}
+	defer rows.Close()
+
+	// The hash input of chain_balance_history, with the balances as DECIMAL(19,4) text
+	previousHash := ""
+	var sequence int64
+	for rows.Next() {
+		var (
+			sequenceNumber                        int64
+			chainHash, transactionID, operation   string
+			oldBalance, newBalance, balanceChange pgtype.Numeric
+		)
+		if err := rows.Scan(&sequenceNumber, &chainHash, &transactionID, &oldBalance, &newBalance, &balanceChange, &operation); err != nil {
+			t.Fatalf("scan error = %v", err)
+		}
+
+		sequence++
+		if sequenceNumber != sequence {
+			t.Errorf("sequence_number = %d, want %d", sequenceNumber, sequence)
+		}
+
+		input := strings.Join([]string{
+			previousHash, accountID, itoa(sequenceNumber), transactionID,
+			fixed4(oldBalance), fixed4(newBalance), fixed4(balanceChange), operation,
+		}, "|")
+		sum := sha256.Sum256([]byte(input))
+		if want := hex.EncodeToString(sum[:]); chainHash != want {
+			t.Errorf("entry %d: chain_hash = %s, want %s", sequenceNumber, chainHash, want)
+		}
+
+		previousHash = chainHash
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("rows error = %v", err)
+	}
+	if sequence != 4 {
+		t.Errorf("%d entries, want the 3 demo entries and the new one", sequence)
+	}
+}
+
+func bigInt(n int64) *big.Int {
+	return big.NewInt(n)
+}
+
+func itoa(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+// fixed4 returns the DECIMAL(19,4) text of a numeric
+func fixed4(n pgtype.Numeric) string {
+	f, _ := n.Float64Value()
+
+	return strconv.FormatFloat(f.Float64, 'f', 4, 64)
+}
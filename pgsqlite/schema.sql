@@ -0,0 +1,495 @@
+-- The core schema of _init/postgres (01-ddl, 03-trigger) and the store migrations, in SQLite.
+--
+-- Tables keep the names, columns and column order of Postgres, without the core schema the queries are rewritten
+-- not to name. Types follow the values the driver writes:
+--   UUID, enums, VARCHAR, JSONB      TEXT; enums keep their values with a CHECK
+--   DECIMAL                          NUMERIC, computed as 64-bit floats
+--   TIMESTAMP WITH TIME ZONE         TEXT, UTC with milliseconds: 2006-01-02 15:04:05.000+00:00
+--   DATE                             TEXT: 2006-01-02
+--   BOOLEAN                          INTEGER 0 or 1
+-- Constraints carry the names Postgres gives them, so the errors the services inspect name the same constraint.
+-- The functions of 02-functions.sql are not used by the services and are left out.
+
+-- Table definitions
+
+CREATE TABLE accounts (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    account_number TEXT NOT NULL CONSTRAINT accounts_account_number_key UNIQUE,
+    account_name TEXT NOT NULL,
+    balance NUMERIC NOT NULL DEFAULT 0.0000 CONSTRAINT accounts_balance_check CHECK (balance >= 0),
+    currency TEXT NOT NULL DEFAULT 'USD' CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD', 'CHF', 'CNY', 'SGD', 'HKD')),
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'inactive', 'suspended', 'closed')),
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    version INTEGER NOT NULL DEFAULT 1, -- For optimistic locking
+    account_type TEXT NOT NULL DEFAULT 'checking' CHECK (account_type IN ('checking', 'savings'))
+);
+
+CREATE TABLE transactions (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    account_id TEXT NOT NULL REFERENCES accounts(id) ON DELETE RESTRICT,
+    transaction_type TEXT NOT NULL CHECK (transaction_type IN ('debit', 'credit')),
+    amount NUMERIC NOT NULL CONSTRAINT transactions_amount_check CHECK (amount > 0),
+    currency TEXT NOT NULL CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD', 'CHF', 'CNY', 'SGD', 'HKD')),
+    description TEXT,
+    reference_id TEXT, -- External reference (e.g., transfer ID)
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'completed', 'failed', 'cancelled')),
+    idempotency_key TEXT CONSTRAINT transactions_idempotency_key_key UNIQUE, -- For idempotent operations
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    completed_at TEXT,
+    metadata TEXT -- Additional transaction metadata
+);
+
+CREATE TABLE transfers (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    transfer_id TEXT NOT NULL CONSTRAINT transfers_transfer_id_key UNIQUE, -- External transfer identifier
+    from_account_id TEXT NOT NULL REFERENCES accounts(id) ON DELETE RESTRICT,
+    to_account_id TEXT NOT NULL REFERENCES accounts(id) ON DELETE RESTRICT,
+    amount NUMERIC NOT NULL CONSTRAINT transfers_amount_check CHECK (amount > 0),
+    currency TEXT NOT NULL CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD', 'CHF', 'CNY', 'SGD', 'HKD')),
+    description TEXT,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed', 'failed', 'cancelled')),
+    debit_transaction_id TEXT REFERENCES transactions(id),
+    credit_transaction_id TEXT REFERENCES transactions(id),
+    workflow_id TEXT, -- Temporal workflow ID
+    run_id TEXT, -- Temporal run ID
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    completed_at TEXT,
+    failed_at TEXT,
+    failure_reason TEXT,
+    metadata TEXT, -- Additional transfer metadata
+    CONSTRAINT chk_transfers_different_accounts CHECK (from_account_id != to_account_id)
+);
+
+CREATE TABLE account_balance_history (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    account_id TEXT NOT NULL REFERENCES accounts(id),
+    transaction_id TEXT REFERENCES transactions(id),
+    old_balance NUMERIC NOT NULL,
+    new_balance NUMERIC NOT NULL,
+    balance_change NUMERIC NOT NULL,
+    operation TEXT NOT NULL, -- 'debit', 'credit', 'adjustment'
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    created_by TEXT, -- Service or user that made the change
+    sequence_number INTEGER NOT NULL DEFAULT 0, -- Set by trigger_balance_history_chain
+    chain_hash TEXT NOT NULL DEFAULT '', -- Set by trigger_balance_history_chain
+    CONSTRAINT account_balance_history_account_id_sequence_number_key UNIQUE (account_id, sequence_number)
+);
+
+CREATE TABLE compensation_audit_trail (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    workflow_id TEXT NOT NULL,
+    run_id TEXT NOT NULL,
+    transfer_id TEXT, -- Reference to the transfer being compensated
+    original_transaction_id TEXT REFERENCES transactions(id),
+    compensation_transaction_id TEXT REFERENCES transactions(id),
+    compensation_reason TEXT NOT NULL,
+    compensation_type TEXT NOT NULL CHECK (compensation_type IN ('debit_reversal', 'credit_reversal', 'manual_adjustment')),
+    compensation_status TEXT NOT NULL DEFAULT 'pending' CHECK (compensation_status IN ('pending', 'completed', 'failed', 'timeout', 'manual_required')),
+    compensation_attempts INTEGER NOT NULL DEFAULT 1,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    completed_at TEXT,
+    failure_reason TEXT,
+    timeout_duration_ms INTEGER, -- Timeout that occurred (if any)
+    metadata TEXT -- Additional compensation context
+);
+
+CREATE TABLE account_closure_audit_trail (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    account_id TEXT NOT NULL REFERENCES accounts(id),
+    workflow_id TEXT NOT NULL,
+    run_id TEXT NOT NULL,
+    step TEXT NOT NULL, -- 'blocked', 'drained', 'swept', 'closed', 'reopened'
+    details TEXT, -- Step-specific context such as the swept amount
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CONSTRAINT account_closure_audit_trail_run_id_step_key UNIQUE (run_id, step)
+);
+
+CREATE TABLE account_erasure_certificates (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    account_id TEXT NOT NULL CONSTRAINT account_erasure_certificates_account_id_key UNIQUE REFERENCES accounts(id),
+    workflow_id TEXT NOT NULL,
+    run_id TEXT NOT NULL,
+    requested_by TEXT,
+    closed_at TEXT NOT NULL, -- Start of the retention period that had to elapse
+    scrubbed_rows TEXT NOT NULL, -- Rows scrubbed per table
+    digest TEXT NOT NULL, -- SHA-256 over the certificate fields
+    erased_at TEXT NOT NULL
+);
+
+CREATE TABLE admin_audit (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    actor TEXT NOT NULL, -- From the X-Actor header
+    action TEXT NOT NULL, -- e.g. 'balance_alert.update', 'account_closure.start'
+    service TEXT NOT NULL, -- Service that handled the call
+    resource_type TEXT NOT NULL,
+    resource_id TEXT,
+    before_payload TEXT, -- NULL when the resource did not exist before the call
+    after_payload TEXT, -- NULL when the call removed the resource
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE balance_alerts (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    account_id TEXT NOT NULL REFERENCES accounts(id),
+    threshold NUMERIC NOT NULL CONSTRAINT balance_alerts_threshold_check CHECK (threshold >= 0),
+    webhook_url TEXT NOT NULL,
+    enabled INTEGER NOT NULL DEFAULT TRUE,
+    triggered INTEGER NOT NULL DEFAULT FALSE, -- Set while the balance stays below the threshold
+    last_triggered_at TEXT,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE business_rules (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL CONSTRAINT business_rules_kind_check CHECK (kind IN ('max_transaction_amount', 'min_account_name_length', 'min_balance')),
+    currency TEXT CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD', 'CHF', 'CNY', 'SGD', 'HKD')), -- NULL for any currency
+    threshold NUMERIC NOT NULL,
+    severity TEXT NOT NULL CONSTRAINT business_rules_severity_check CHECK (severity IN ('error', 'warning')),
+    enabled INTEGER NOT NULL DEFAULT TRUE,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE fx_rates (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    currency TEXT NOT NULL CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD', 'CHF', 'CNY', 'SGD', 'HKD')),
+    rate_to_usd NUMERIC NOT NULL CONSTRAINT fx_rates_rate_to_usd_check CHECK (rate_to_usd > 0), -- Units of the currency per USD
+    source TEXT NOT NULL,
+    fetched_at TEXT NOT NULL
+);
+
+CREATE TABLE settlement_files (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    file_reference TEXT NOT NULL CONSTRAINT settlement_files_file_reference_key UNIQUE, -- Also used as the ISO 20022 message ID
+    format TEXT NOT NULL CHECK (format IN ('csv', 'pacs008')),
+    status TEXT NOT NULL DEFAULT 'generated' CHECK (status IN ('generated', 'acknowledged', 'partially_acknowledged', 'rejected')),
+    transfer_count INTEGER NOT NULL CONSTRAINT settlement_files_transfer_count_check CHECK (transfer_count > 0),
+    control_sum NUMERIC NOT NULL,
+    content TEXT NOT NULL,
+    checksum TEXT NOT NULL, -- SHA-256 of content
+    acknowledgement_reference TEXT,
+    acknowledged_at TEXT,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE settlement_entries (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    settlement_file_id TEXT NOT NULL REFERENCES settlement_files(id),
+    transfer_id TEXT NOT NULL CONSTRAINT settlement_entries_transfer_id_key UNIQUE REFERENCES transfers(transfer_id), -- A transfer is settled at most once
+    settlement_status TEXT NOT NULL DEFAULT 'pending' CHECK (settlement_status IN ('pending', 'settled', 'rejected')),
+    rejection_reason TEXT,
+    settled_at TEXT,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE transfer_reference_sequences (
+    branch_code TEXT NOT NULL,
+    business_date TEXT NOT NULL,
+    last_sequence INTEGER NOT NULL DEFAULT 0,
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CONSTRAINT transfer_reference_sequences_pkey PRIMARY KEY (branch_code, business_date)
+);
+
+CREATE TABLE transfer_references (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    reference TEXT NOT NULL CONSTRAINT transfer_references_reference_key UNIQUE, -- YYMMDD-BBB-NNNNNN-C
+    transfer_id TEXT NOT NULL CONSTRAINT transfer_references_transfer_id_key UNIQUE, -- Saga transfers have no transfers row, so no foreign key
+    branch_code TEXT NOT NULL,
+    business_date TEXT NOT NULL,
+    sequence_number INTEGER NOT NULL CONSTRAINT transfer_references_sequence_number_check CHECK (sequence_number > 0),
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CONSTRAINT transfer_references_branch_code_business_date_sequence_number_key UNIQUE (branch_code, business_date, sequence_number)
+);
+
+CREATE TABLE idempotency_keys (
+    idempotency_key TEXT CONSTRAINT idempotency_keys_pkey PRIMARY KEY,
+    scope TEXT NOT NULL, -- Operation that used the key: debit, credit, compensation or internal_transfer
+    request_hash TEXT NOT NULL,
+    response_snapshot TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    expires_at TEXT NOT NULL
+);
+
+CREATE TABLE notification_preferences (
+    account_id TEXT CONSTRAINT notification_preferences_pkey PRIMARY KEY REFERENCES accounts(id),
+    email TEXT NOT NULL,
+    notify_completed INTEGER NOT NULL DEFAULT TRUE,
+    notify_compensated INTEGER NOT NULL DEFAULT TRUE,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE notification_suppressions (
+    email TEXT CONSTRAINT notification_suppressions_pkey PRIMARY KEY, -- Lower case
+    reason TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE eod_balances (
+    account_id TEXT NOT NULL REFERENCES accounts(id),
+    business_date TEXT NOT NULL,
+    currency TEXT NOT NULL CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD', 'CHF', 'CNY', 'SGD', 'HKD')),
+    closing_balance NUMERIC NOT NULL,
+    entry_count INTEGER NOT NULL, -- Balance history entries of the day
+    last_sequence_number INTEGER NOT NULL, -- Last balance history entry included; 0 before the first one
+    computed_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CONSTRAINT eod_balances_pkey PRIMARY KEY (account_id, business_date)
+);
+
+CREATE TABLE account_aliases (
+    alias TEXT CONSTRAINT account_aliases_pkey PRIMARY KEY, -- Normalized: E.164 phone number or lower case email address
+    alias_type TEXT NOT NULL CONSTRAINT account_aliases_alias_type_check CHECK (alias_type IN ('phone', 'email')),
+    account_id TEXT NOT NULL REFERENCES accounts(id),
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+-- position is the rowid, so SQLite numbers the events in the order they are recorded as the identity column does
+CREATE TABLE transfer_events (
+    id TEXT NOT NULL CONSTRAINT transfer_events_pkey UNIQUE DEFAULT (gen_random_uuid()),
+    position INTEGER PRIMARY KEY AUTOINCREMENT, -- Order in which the events were recorded, read by projections
+    transfer_id TEXT NOT NULL, -- Saga transfers have no transfers row, so no foreign key
+    workflow_id TEXT NOT NULL,
+    run_id TEXT NOT NULL,
+    sequence_number INTEGER NOT NULL CONSTRAINT transfer_events_sequence_number_check CHECK (sequence_number > 0),
+    event_type TEXT NOT NULL, -- transfer_started, step_completed, step_failed or transfer_finished
+    status TEXT NOT NULL, -- processing, completed, failed, compensated or expired
+    step TEXT, -- Saga activity of step events, e.g. DebitAccount
+    from_account TEXT NOT NULL,
+    to_account TEXT NOT NULL,
+    amount NUMERIC NOT NULL,
+    currency TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    error_message TEXT,
+    occurred_at TEXT NOT NULL, -- Workflow time of the state change
+    recorded_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CONSTRAINT transfer_events_workflow_id_run_id_sequence_number_key UNIQUE (workflow_id, run_id, sequence_number)
+);
+
+CREATE TABLE transfer_read_model (
+    transfer_id TEXT CONSTRAINT transfer_read_model_pkey PRIMARY KEY,
+    workflow_id TEXT NOT NULL,
+    run_id TEXT NOT NULL,
+    status TEXT NOT NULL, -- processing, completed, failed, compensated or expired
+    last_event_type TEXT NOT NULL,
+    last_step TEXT,
+    from_account TEXT NOT NULL,
+    to_account TEXT NOT NULL,
+    amount NUMERIC NOT NULL,
+    currency TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    error_message TEXT,
+    started_at TEXT NOT NULL,
+    finished_at TEXT,
+    updated_at TEXT NOT NULL, -- Workflow time of the latest event
+    event_position INTEGER NOT NULL, -- transfer_events.position of the latest event
+    projected_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE projection_checkpoints (
+    projection TEXT CONSTRAINT projection_checkpoints_pkey PRIMARY KEY,
+    position INTEGER NOT NULL DEFAULT 0,
+    updated_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE account_owners (
+    account_id TEXT NOT NULL REFERENCES accounts(id),
+    owner_id TEXT NOT NULL, -- Matches the approved_by of the transfer approvals given by the owner
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CONSTRAINT account_owners_pkey PRIMARY KEY (account_id, owner_id)
+);
+
+CREATE TABLE transfer_approvals (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    transfer_id TEXT NOT NULL, -- Saga transfers have no transfers row, so no foreign key
+    workflow_id TEXT NOT NULL,
+    approved_by TEXT NOT NULL,
+    approved_at TEXT NOT NULL, -- Workflow time the approval was accepted
+    recorded_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    CONSTRAINT transfer_approvals_transfer_id_approved_by_key UNIQUE (transfer_id, approved_by)
+);
+
+-- Migration 0002 of svc-transaction
+CREATE TABLE pending_adjustments (
+    id TEXT PRIMARY KEY DEFAULT (gen_random_uuid()),
+    account_id TEXT NOT NULL REFERENCES accounts(id),
+    amount NUMERIC NOT NULL CONSTRAINT pending_adjustments_amount_check CHECK (amount <> 0),
+    currency TEXT NOT NULL CHECK (currency IN ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD', 'CHF', 'CNY', 'SGD', 'HKD')),
+    reason TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CONSTRAINT pending_adjustments_status_check CHECK (status IN ('pending', 'approved', 'rejected')),
+    requested_by TEXT NOT NULL,
+    requested_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    reviewed_by TEXT,
+    reviewed_at TEXT,
+    review_note TEXT,
+    transaction_id TEXT REFERENCES transactions(id),
+    CONSTRAINT pending_adjustments_check CHECK (status <> 'approved' OR reviewed_by <> requested_by) -- Maker and checker are different operators
+);
+
+-- Index definitions; the trigram indexes of the account search have no SQLite counterpart
+
+CREATE INDEX idx_accounts_status ON accounts(status);
+CREATE INDEX idx_accounts_currency ON accounts(currency);
+CREATE INDEX idx_accounts_created_at ON accounts(created_at);
+
+CREATE INDEX idx_transactions_account_id ON transactions(account_id);
+CREATE INDEX idx_transactions_status ON transactions(status);
+CREATE INDEX idx_transactions_reference_id ON transactions(reference_id);
+CREATE INDEX idx_transactions_created_at ON transactions(created_at);
+CREATE INDEX idx_transactions_account_created ON transactions(account_id, created_at);
+CREATE INDEX idx_transactions_original_transaction_id ON transactions(metadata->>'original_transaction_id') WHERE transaction_type = 'credit';
+
+CREATE INDEX idx_transfers_from_account ON transfers(from_account_id);
+CREATE INDEX idx_transfers_to_account ON transfers(to_account_id);
+CREATE INDEX idx_transfers_status ON transfers(status);
+CREATE INDEX idx_transfers_workflow_id ON transfers(workflow_id);
+CREATE INDEX idx_transfers_created_at ON transfers(created_at);
+
+CREATE INDEX idx_balance_history_transaction_id ON account_balance_history(transaction_id);
+CREATE INDEX idx_balance_history_created_at ON account_balance_history(created_at);
+CREATE INDEX idx_balance_history_account_created ON account_balance_history(account_id, created_at);
+
+CREATE INDEX idx_compensation_workflow_id ON compensation_audit_trail(workflow_id);
+CREATE INDEX idx_compensation_transfer_id ON compensation_audit_trail(transfer_id);
+CREATE INDEX idx_compensation_original_tx ON compensation_audit_trail(original_transaction_id);
+CREATE INDEX idx_compensation_status ON compensation_audit_trail(compensation_status);
+CREATE INDEX idx_compensation_created_at ON compensation_audit_trail(created_at);
+
+CREATE INDEX idx_admin_audit_created_at ON admin_audit(created_at);
+CREATE INDEX idx_admin_audit_actor_created ON admin_audit(actor, created_at);
+CREATE INDEX idx_admin_audit_resource ON admin_audit(resource_type, resource_id);
+
+CREATE INDEX idx_account_closure_account_created ON account_closure_audit_trail(account_id, created_at);
+
+CREATE INDEX idx_balance_alerts_account_id ON balance_alerts(account_id);
+CREATE INDEX idx_balance_alerts_enabled ON balance_alerts(enabled);
+
+CREATE UNIQUE INDEX idx_business_rules_name_currency ON business_rules(name, COALESCE(currency, ''));
+
+CREATE INDEX idx_fx_rates_currency_fetched_at ON fx_rates(currency, fetched_at DESC);
+
+CREATE INDEX idx_settlement_files_created_at ON settlement_files(created_at);
+CREATE INDEX idx_settlement_entries_file_id ON settlement_entries(settlement_file_id);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+CREATE INDEX idx_eod_balances_business_date ON eod_balances(business_date);
+
+CREATE INDEX idx_account_aliases_account_id ON account_aliases(account_id);
+
+CREATE INDEX idx_transfer_events_transfer_id ON transfer_events(transfer_id, occurred_at);
+
+CREATE INDEX idx_transfer_read_model_from_account ON transfer_read_model(from_account, started_at DESC);
+CREATE INDEX idx_transfer_read_model_to_account ON transfer_read_model(to_account, started_at DESC);
+CREATE INDEX idx_transfer_read_model_started ON transfer_read_model(started_at DESC, transfer_id DESC);
+
+CREATE INDEX idx_pending_adjustments_status_requested ON pending_adjustments(status, requested_at DESC);
+CREATE INDEX idx_pending_adjustments_account_id ON pending_adjustments(account_id, requested_at DESC);
+
+-- Trigger definitions. SQLite triggers cannot change the row being written, so the BEFORE UPDATE triggers of
+-- Postgres that set columns run AFTER and update the row again.
+
+CREATE TRIGGER trigger_accounts_updated_at AFTER UPDATE ON accounts FOR EACH ROW BEGIN
+    UPDATE accounts SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER trigger_transactions_updated_at AFTER UPDATE ON transactions FOR EACH ROW BEGIN
+    UPDATE transactions SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER trigger_transfers_updated_at AFTER UPDATE ON transfers FOR EACH ROW BEGIN
+    UPDATE transfers SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER trigger_balance_alerts_updated_at AFTER UPDATE ON balance_alerts FOR EACH ROW BEGIN
+    UPDATE balance_alerts SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER trigger_business_rules_updated_at AFTER UPDATE ON business_rules FOR EACH ROW BEGIN
+    UPDATE business_rules SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER trigger_notification_preferences_updated_at AFTER UPDATE ON notification_preferences FOR EACH ROW BEGIN
+    UPDATE notification_preferences SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER trigger_settlement_files_updated_at AFTER UPDATE ON settlement_files FOR EACH ROW BEGIN
+    UPDATE settlement_files SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER trigger_settlement_entries_updated_at AFTER UPDATE ON settlement_entries FOR EACH ROW BEGIN
+    UPDATE settlement_entries SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+CREATE TRIGGER trigger_transfer_reference_sequences_updated_at AFTER UPDATE ON transfer_reference_sequences FOR EACH ROW BEGIN
+    UPDATE transfer_reference_sequences SET updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+-- Set completed_at and failed_at when a transfer reaches that status
+CREATE TRIGGER trigger_transfers_completion AFTER UPDATE OF status ON transfers FOR EACH ROW BEGIN
+    UPDATE transfers SET completed_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')
+    WHERE rowid = NEW.rowid AND NEW.status = 'completed' AND OLD.status != 'completed';
+
+    UPDATE transfers SET failed_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')
+    WHERE rowid = NEW.rowid AND NEW.status = 'failed' AND OLD.status != 'failed';
+END;
+
+-- Set completed_at when a transaction completes
+CREATE TRIGGER trigger_transactions_completion AFTER UPDATE OF status ON transactions FOR EACH ROW
+WHEN NEW.status = 'completed' AND OLD.status != 'completed' BEGIN
+    UPDATE transactions SET completed_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now') WHERE rowid = NEW.rowid;
+END;
+
+-- Only active accounts change balance; RAISE takes no expression, so each status has its message
+CREATE TRIGGER trigger_accounts_validation BEFORE UPDATE OF balance ON accounts FOR EACH ROW
+WHEN OLD.balance != NEW.balance AND NEW.status != 'active' BEGIN
+    SELECT RAISE(ABORT, 'Cannot modify balance for account with status: inactive') WHERE NEW.status = 'inactive';
+    SELECT RAISE(ABORT, 'Cannot modify balance for account with status: suspended') WHERE NEW.status = 'suspended';
+    SELECT RAISE(ABORT, 'Cannot modify balance for account with status: closed') WHERE NEW.status = 'closed';
+END;
+
+-- Transactions are only created for active accounts
+CREATE TRIGGER trigger_transactions_validation BEFORE INSERT ON transactions FOR EACH ROW BEGIN
+    SELECT RAISE(ABORT, 'Account not found') WHERE NOT EXISTS (SELECT 1 FROM accounts WHERE id = NEW.account_id);
+    SELECT RAISE(ABORT, 'Cannot create transaction for account with status: inactive')
+    WHERE (SELECT status FROM accounts WHERE id = NEW.account_id) = 'inactive';
+    SELECT RAISE(ABORT, 'Cannot create transaction for account with status: suspended')
+    WHERE (SELECT status FROM accounts WHERE id = NEW.account_id) = 'suspended';
+    SELECT RAISE(ABORT, 'Cannot create transaction for account with status: closed')
+    WHERE (SELECT status FROM accounts WHERE id = NEW.account_id) = 'closed';
+END;
+
+-- Chain balance history entries per account: the next sequence number of the account and a hash over the previous
+-- entry's hash and the entry's values, with the same input as Postgres (see svc-transaction
+-- service.BalanceHistoryChainHash). The input is joined with || because concat_ws of SQLite also skips empty
+-- strings. Writers already run one at a time, so no lock is taken.
+CREATE TRIGGER trigger_balance_history_chain AFTER INSERT ON account_balance_history FOR EACH ROW BEGIN
+    UPDATE account_balance_history
+    SET sequence_number = (
+        SELECT COALESCE(MAX(h.sequence_number), 0) + 1 FROM account_balance_history h WHERE h.account_id = NEW.account_id
+    )
+    WHERE rowid = NEW.rowid;
+
+    UPDATE account_balance_history
+    SET chain_hash = sha256_hex(
+        COALESCE((
+            SELECT h.chain_hash FROM account_balance_history h
+            WHERE h.account_id = NEW.account_id AND h.sequence_number = account_balance_history.sequence_number - 1
+        ), '')
+        || '|' || account_id
+        || '|' || sequence_number
+        || '|' || COALESCE(transaction_id, '')
+        || '|' || printf('%.4f', old_balance)
+        || '|' || printf('%.4f', new_balance)
+        || '|' || printf('%.4f', balance_change)
+        || '|' || operation
+    )
+    WHERE rowid = NEW.rowid;
+END;
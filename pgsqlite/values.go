@@ -0,0 +1,463 @@
+package pgsqlite
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// timeLayout is the text of timestamps: UTC with milliseconds, so they sort as text the way they sort as times
+const timeLayout = "2006-01-02 15:04:05.000-07:00"
+
+// dateLayout is the text of dates
+const dateLayout = "2006-01-02"
+
+// timeLayouts are the layouts timestamps are parsed from: those written by the service, by SQLite and by hand
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	dateLayout,
+}
+
+// numericDigits is the most fractional digits of a numeric result. Numerics are computed as 64-bit floats, so
+// longer fractions are rounding errors of sums and products rather than digits of the value.
+const numericDigits = 10
+
+// formatTime returns the text of a timestamp
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+// parseTime parses the text of a timestamp
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("cannot parse timestamp %q", s)
+}
+
+// formatUUID returns the canonical text of a UUID, the form Postgres prints
+func formatUUID(b [16]byte) string {
+	s := hex.EncodeToString(b[:])
+
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:32]
+}
+
+// formatNumeric returns the decimal text of a numeric result
+func formatNumeric(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if point := strings.IndexByte(s, '.'); point >= 0 && len(s)-point-1 > numericDigits {
+		s = strings.TrimRight(strconv.FormatFloat(f, 'f', numericDigits, 64), "0")
+		s = strings.TrimSuffix(s, ".")
+	}
+
+	return s
+}
+
+// argValues converts query arguments to the values stored in SQLite
+func argValues(args []any) ([]any, error) {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		value, err := argValue(arg)
+		if err != nil {
+			return nil, fmt.Errorf("argument $%d: %w", i+1, err)
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// argValue converts a query argument: UUIDs, timestamps and dates to text, numerics to floats, booleans to integers
+// and arrays to JSON arrays
+func argValue(arg any) (any, error) {
+	switch arg := arg.(type) {
+	case nil:
+		return nil, nil
+	case pgtype.UUID:
+		if !arg.Valid {
+			return nil, nil
+		}
+		return formatUUID(arg.Bytes), nil
+	case pgtype.Timestamptz:
+		if !arg.Valid {
+			return nil, nil
+		}
+		return formatTime(arg.Time), nil
+	case time.Time:
+		return formatTime(arg), nil
+	case pgtype.Date:
+		if !arg.Valid {
+			return nil, nil
+		}
+		return arg.Time.Format(dateLayout), nil
+	case pgtype.Numeric:
+		if !arg.Valid {
+			return nil, nil
+		}
+		f, err := arg.Float64Value()
+		if err != nil {
+			return nil, err
+		}
+		return f.Float64, nil
+	case []byte:
+		if arg == nil {
+			return nil, nil
+		}
+		return string(arg), nil
+	case bool:
+		if arg {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case driver.Valuer:
+		value, err := arg.Value()
+		if err != nil {
+			return nil, err
+		}
+		return argValue(value)
+	}
+
+	value := reflect.ValueOf(arg)
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return int64(value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	case reflect.Bool:
+		return argValue(value.Bool())
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil, nil
+		}
+		return argValue(value.Elem().Interface())
+	case reflect.Slice:
+		if value.IsNil() {
+			return nil, nil
+		}
+
+		elements := make([]any, value.Len())
+		for i := range elements {
+			element, err := argValue(value.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = element
+		}
+
+		array, err := json.Marshal(elements)
+		if err != nil {
+			return nil, err
+		}
+		return string(array), nil
+	}
+
+	return nil, fmt.Errorf("unsupported argument type %T", arg)
+}
+
+// scanValue stores a value read from SQLite in dest, a pointer given to pgx Rows.Scan by the generated code
+func scanValue(dest, src any) error {
+	switch dest := dest.(type) {
+	case *pgtype.Numeric:
+		switch src := src.(type) {
+		case int64:
+			*dest = pgtype.Numeric{Int: big.NewInt(src), Valid: true}
+			return nil
+		case float64:
+			return dest.Scan(formatNumeric(src))
+		}
+		return dest.Scan(src)
+	case *pgtype.Timestamptz:
+		if s, ok := src.(string); ok {
+			t, err := parseTime(s)
+			if err != nil {
+				return err
+			}
+			*dest = pgtype.Timestamptz{Time: t, Valid: true}
+			return nil
+		}
+		return dest.Scan(src)
+	case *time.Time:
+		s, ok := src.(string)
+		if !ok {
+			return fmt.Errorf("cannot scan %T into *time.Time", src)
+		}
+		t, err := parseTime(s)
+		if err != nil {
+			return err
+		}
+		*dest = t
+		return nil
+	case *pgtype.Date:
+		if s, ok := src.(string); ok && len(s) > len(dateLayout) {
+			src = s[:len(dateLayout)]
+		}
+		return dest.Scan(src)
+	case *bool:
+		switch src := src.(type) {
+		case int64:
+			*dest = src != 0
+			return nil
+		case bool:
+			*dest = src
+			return nil
+		}
+	case *string:
+		switch src := src.(type) {
+		case string:
+			*dest = src
+			return nil
+		case []byte:
+			*dest = string(src)
+			return nil
+		case int64:
+			*dest = strconv.FormatInt(src, 10)
+			return nil
+		case float64:
+			*dest = formatNumeric(src)
+			return nil
+		}
+	case *[]byte:
+		switch src := src.(type) {
+		case nil:
+			*dest = nil
+			return nil
+		case string:
+			*dest = []byte(src)
+			return nil
+		case []byte:
+			*dest = append([]byte(nil), src...)
+			return nil
+		}
+	case *int32:
+		if n, ok := integer(src); ok {
+			*dest = int32(n)
+			return nil
+		}
+	case *int64:
+		if n, ok := integer(src); ok {
+			*dest = n
+			return nil
+		}
+	case *float64:
+		switch src := src.(type) {
+		case int64:
+			*dest = float64(src)
+			return nil
+		case float64:
+			*dest = src
+			return nil
+		}
+	case *[]string:
+		switch src := src.(type) {
+		case nil:
+			*dest = nil
+			return nil
+		case string:
+			return json.Unmarshal([]byte(src), dest)
+		}
+	case *any:
+		// Like pgx, which returns numeric results as pgtype.Numeric
+		switch src := src.(type) {
+		case int64:
+			*dest = pgtype.Numeric{Int: big.NewInt(src), Valid: true}
+		case float64:
+			var n pgtype.Numeric
+			if err := n.Scan(formatNumeric(src)); err != nil {
+				return err
+			}
+			*dest = n
+		default:
+			*dest = src
+		}
+		return nil
+	case sql.Scanner:
+		return dest.Scan(src)
+	}
+
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", dest)
+	}
+
+	return fmt.Errorf("cannot scan %T into %T", src, dest)
+}
+
+// integer returns the integer value of a column, which SQLite may hold as a float after arithmetic
+func integer(src any) (int64, bool) {
+	switch src := src.(type) {
+	case int64:
+		return src, true
+	case float64:
+		if src == float64(int64(src)) {
+			return int64(src), true
+		}
+	}
+
+	return 0, false
+}
+
+// rows implements pgx.Rows over the rows of a SQLite query
+type rows struct {
+	rows    *sql.Rows
+	columns []string
+	values  []any
+	count   int64
+	err     error
+	closed  bool
+}
+
+func newRows(sqlRows *sql.Rows) (*rows, error) {
+	columns, err := sqlRows.Columns()
+	if err != nil {
+		sqlRows.Close()
+
+		return nil, pgError(err)
+	}
+
+	return &rows{rows: sqlRows, columns: columns, values: make([]any, len(columns))}, nil
+}
+
+func (r *rows) Close() {
+	if r.closed {
+		return
+	}
+
+	r.closed = true
+	if err := r.rows.Close(); err != nil && r.err == nil {
+		r.err = pgError(err)
+	}
+}
+
+func (r *rows) Err() error {
+	return r.err
+}
+
+func (r *rows) CommandTag() pgconn.CommandTag {
+	return pgconn.NewCommandTag(fmt.Sprintf("SELECT %d", r.count))
+}
+
+func (r *rows) FieldDescriptions() []pgconn.FieldDescription {
+	descriptions := make([]pgconn.FieldDescription, len(r.columns))
+	for i, column := range r.columns {
+		descriptions[i] = pgconn.FieldDescription{Name: column}
+	}
+
+	return descriptions
+}
+
+func (r *rows) Next() bool {
+	if r.closed {
+		return false
+	}
+
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			r.err = pgError(err)
+		}
+		r.Close()
+
+		return false
+	}
+
+	pointers := make([]any, len(r.values))
+	for i := range r.values {
+		pointers[i] = &r.values[i]
+	}
+
+	if err := r.rows.Scan(pointers...); err != nil {
+		r.err = pgError(err)
+		r.Close()
+
+		return false
+	}
+
+	r.count++
+
+	return true
+}
+
+func (r *rows) Scan(dest ...any) error {
+	if len(dest) != len(r.values) {
+		err := fmt.Errorf("number of field descriptions must equal number of destinations, got %d and %d", len(r.values), len(dest))
+		r.err = err
+		r.Close()
+
+		return err
+	}
+
+	for i, value := range r.values {
+		if err := scanValue(dest[i], value); err != nil {
+			err = fmt.Errorf("can't scan into dest[%d] (col: %s): %w", i, r.columns[i], err)
+			r.err = err
+			r.Close()
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *rows) Values() ([]any, error) {
+	values := make([]any, len(r.values))
+	copy(values, r.values)
+
+	return values, nil
+}
+
+func (r *rows) RawValues() [][]byte {
+	return nil
+}
+
+func (r *rows) Conn() *pgx.Conn {
+	return nil
+}
+
+// row implements pgx.Row over the first row of a query
+type row struct {
+	rows pgx.Rows
+	err  error
+}
+
+func (r *row) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	defer r.rows.Close()
+
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+
+		return pgx.ErrNoRows
+	}
+
+	if err := r.rows.Scan(dest...); err != nil {
+		return err
+	}
+
+	r.rows.Close()
+
+	return r.rows.Err()
+}
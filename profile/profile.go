@@ -0,0 +1,62 @@
+// Package profile reads APP_PROFILE, the switch between the learning and realistic setups of the whole stack. Every
+// service requires this module through a replace directive, so one file decides what each profile turns on.
+package profile
+
+import (
+	"fmt"
+	"os"
+)
+
+// Env names the environment variable selecting the profile of every service of the stack
+const Env = "APP_PROFILE"
+
+// Profiles of the stack
+const (
+	Learning  = "learning"  // Classroom: failure simulation, relaxed validation, debug logs and the demo accounts
+	Realistic = "realistic" // Reference implementation: no failure simulation, strict validation, info logs, no demo data
+)
+
+// Profile switches the behaviors that tell a classroom deployment from a reference one, for all services at once.
+// Without a profile, config.json alone decides, with debug logs and the demo accounts.
+type Profile struct {
+	Name string // Learning, Realistic or empty
+}
+
+// Load returns the profile named by APP_PROFILE
+func Load() (Profile, error) {
+	switch name := os.Getenv(Env); name {
+	case "", Learning, Realistic:
+		return Profile{Name: name}, nil
+	default:
+		return Profile{}, fmt.Errorf("unknown %s %q: use %s or %s", Env, name, Learning, Realistic)
+	}
+}
+
+// FailureSimulation returns whether failure simulation runs: learning switches it on and realistic off whatever
+// config.json says; ok is false without a profile, leaving it to config.json
+func (p Profile) FailureSimulation() (enabled, ok bool) {
+	switch p.Name {
+	case Learning:
+		return true, true
+	case Realistic:
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// RelaxedValidation reports whether requests are validated leniently: account numbers are not checked against their
+// formats, business rules of severity error only warn, and possible duplicate transfers are flagged rather than held
+func (p Profile) RelaxedValidation() bool {
+	return p.Name == Learning
+}
+
+// VerboseLogging reports whether services log at debug level rather than info
+func (p Profile) VerboseLogging() bool {
+	return p.Name != Realistic
+}
+
+// DemoData reports whether stores start with the demo accounts and history
+func (p Profile) DemoData() bool {
+	return p.Name != Realistic
+}
@@ -0,0 +1,46 @@
+package profile
+
+import "testing"
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		env               string
+		failureSimulation bool
+		configDecides     bool
+		relaxed           bool
+		verbose           bool
+		demoData          bool
+	}{
+		{env: "", configDecides: true, verbose: true, demoData: true},
+		{env: Learning, failureSimulation: true, relaxed: true, verbose: true, demoData: true},
+		{env: Realistic},
+	}
+
+	for _, test := range tests {
+		t.Setenv(Env, test.env)
+
+		profile, err := Load()
+		if err != nil {
+			t.Fatalf("Load() with %s=%q error = %v", Env, test.env, err)
+		}
+
+		enabled, ok := profile.FailureSimulation()
+		if enabled != test.failureSimulation || ok == test.configDecides {
+			t.Errorf("%q: FailureSimulation() = %v, %v", test.env, enabled, ok)
+		}
+		if profile.RelaxedValidation() != test.relaxed {
+			t.Errorf("%q: RelaxedValidation() = %v", test.env, profile.RelaxedValidation())
+		}
+		if profile.VerboseLogging() != test.verbose {
+			t.Errorf("%q: VerboseLogging() = %v", test.env, profile.VerboseLogging())
+		}
+		if profile.DemoData() != test.demoData {
+			t.Errorf("%q: DemoData() = %v", test.env, profile.DemoData())
+		}
+	}
+
+	t.Setenv(Env, "chaos")
+	if _, err := Load(); err == nil {
+		t.Error("Load() accepted an unknown profile")
+	}
+}
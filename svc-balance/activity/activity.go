@@ -1,11 +1,43 @@
 package activity
 
 import (
+	"context"
+	"errors"
+
 	"svc-balance/service"
+	"svc-balance/util/workerinterceptor"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/temporal"
+)
+
+// Application error types of business failures, matching the non-retryable error types the transfer workflow
+// is configured with in flowngine
+const (
+	ErrorTypeInsufficientFunds = "INSUFFICIENT_FUNDS"
+	ErrorTypeAccountNotFound   = "ACCOUNT_NOT_FOUND"
+	ErrorTypeInvalidCurrency   = "INVALID_CURRENCY"
+	ErrorTypeAccountBlocked    = "ACCOUNT_BLOCKED"
+	ErrorTypeAliasNotFound     = "ALIAS_NOT_FOUND"
+	ErrorTypeInvalidAlias      = "INVALID_ALIAS"
+	ErrorTypePrecisionExceeded = "PRECISION_EXCEEDED"
 )
 
+// businessErrorTypes maps the service errors that retrying cannot fix to their application error types
+var businessErrorTypes = []struct {
+	err       error
+	errorType string
+}{
+	{service.ErrInsufficientFunds, ErrorTypeInsufficientFunds},
+	{service.ErrAccountNotFound, ErrorTypeAccountNotFound},
+	{service.ErrInvalidCurrency, ErrorTypeInvalidCurrency},
+	{service.ErrAccountBlocked, ErrorTypeAccountBlocked},
+	{service.ErrAliasNotFound, ErrorTypeAliasNotFound},
+	{service.ErrInvalidAccountAlias, ErrorTypeInvalidAlias},
+	{service.ErrPrecisionExceeded, ErrorTypePrecisionExceeded},
+}
+
 type Activity struct {
 	logger *logrus.Logger
 
@@ -27,5 +59,37 @@ func NewActivity(
 func (api *Activity) GetActivities() []any {
 	return []any{
 		api.CheckBalance,
+		api.NotifyTransferEvent,
+		api.QuoteTransfer,
+		api.ResolveAccountAlias,
+		api.SendTransferNotification,
+	}
+}
+
+// activityError wraps business failures in non-retryable application errors typed by businessErrorTypes, so the
+// workflow fails the step at once. Any other error is returned as is and retried as a transient fault.
+func activityError(err error) error {
+	for _, business := range businessErrorTypes {
+		if errors.Is(err, business.err) {
+			return temporal.NewNonRetryableApplicationError(err.Error(), business.errorType, err)
+		}
 	}
+
+	return err
+}
+
+// activityLogger returns the logger the worker interceptor prepared for the running activity, carrying its
+// activity, workflow, account and transfer fields
+func (api *Activity) activityLogger(ctx context.Context) *logrus.Entry {
+	return workerinterceptor.Logger(ctx, api.logger)
+}
+
+// parseAccount splits the account of a transfer activity into an account ID or an account number. Transfers are
+// started with either, e.g. the gateway and the demo scenarios pass account numbers.
+func parseAccount(account string) (*uuid.UUID, *string) {
+	if accountID, err := uuid.Parse(account); err == nil {
+		return &accountID, nil
+	}
+
+	return nil, &account
 }
@@ -1,9 +1,15 @@
 package activity
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
+	"svc-balance/service"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
 )
 
 func TestRegisterActivities(t *testing.T) {
@@ -13,5 +19,29 @@ func TestRegisterActivities(t *testing.T) {
 	activities := api.GetActivities()
 
 	assert.NotNil(t, activities)
-	assert.Len(t, activities, 1, "Expected exactly 1 activity to be registered")
+	assert.Len(t, activities, 5, "Expected exactly 5 activities to be registered")
+}
+
+func TestActivityError(t *testing.T) {
+	for err, errorType := range map[error]string{
+		service.ErrInsufficientFunds:   ErrorTypeInsufficientFunds,
+		service.ErrAccountNotFound:     ErrorTypeAccountNotFound,
+		service.ErrInvalidCurrency:     ErrorTypeInvalidCurrency,
+		service.ErrAccountBlocked:      ErrorTypeAccountBlocked,
+		service.ErrAliasNotFound:       ErrorTypeAliasNotFound,
+		service.ErrInvalidAccountAlias: ErrorTypeInvalidAlias,
+		service.ErrPrecisionExceeded:   ErrorTypePrecisionExceeded,
+	} {
+		wrapped := activityError(fmt.Errorf("balance check failed: %w", err))
+
+		var applicationErr *temporal.ApplicationError
+		require.ErrorAs(t, wrapped, &applicationErr)
+		assert.Equal(t, errorType, applicationErr.Type())
+		assert.True(t, applicationErr.NonRetryable())
+		assert.ErrorIs(t, wrapped, err)
+	}
+
+	// Transient faults stay plain errors, so the retry policy applies
+	transient := errors.New("connection refused")
+	assert.Same(t, transient, activityError(transient))
 }
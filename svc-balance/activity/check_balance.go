@@ -6,9 +6,7 @@ import (
 
 	"svc-balance/service"
 
-	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
-	"github.com/sirupsen/logrus"
 	"go.temporal.io/sdk/activity"
 )
 
@@ -36,48 +34,18 @@ type CheckBalanceActivityResults struct {
 
 // CheckBalance is the Temporal activity that handles CheckBalance requests
 func (api *Activity) CheckBalance(ctx context.Context, params CheckBalanceActivityParams) (*CheckBalanceActivityResults, error) {
-	const op = "activity.Activity.CheckBalance"
-
-	// Get activity info for logging
-	activityInfo := activity.GetInfo(ctx)
-
-	logger := api.logger.WithFields(logrus.Fields{
-		"[op]":          op,
-		"activity_id":   activityInfo.ActivityID,
-		"activity_type": activityInfo.ActivityType.Name,
-		"workflow_id":   params.WorkflowID,
-		"run_id":        params.RunID,
-		"transfer_id":   params.TransferID,
-		"account_id":    params.AccountID,
-	})
-
-	logger.WithField("message", "Starting CheckBalance activity").Info()
-
-	// PERFORMANCE OPTIMIZATION: Record heartbeat for long-running activity monitoring
-	activity.RecordHeartbeat(ctx, "CheckBalance_started")
-
-	// FAILURE SIMULATION: Check if we should inject a failure
-	if err := api.service.SimulateFailure(ctx, "CheckBalance", params.AccountID); err != nil {
-		logger.WithError(err).Warn("Failure simulation triggered")
-		return nil, err
-	}
+	logger := api.activityLogger(ctx)
 
 	// PERFORMANCE OPTIMIZATION: Record heartbeat before parsing
 	activity.RecordHeartbeat(ctx, "CheckBalance_parsing")
 
-	// Parse account ID
-	accountID, err := uuid.Parse(params.AccountID)
-	if err != nil {
-		err = fmt.Errorf("invalid account_id format: %w", err)
-
-		logger.WithError(err).Error("Failed to parse account ID")
-
-		return nil, err
-	}
+	// The account is an account ID or an account number
+	accountID, accountNumber := parseAccount(params.AccountID)
 
 	// Convert to service parameters
 	serviceParams := service.CheckBalanceParams{
-		AccountID:        &accountID,
+		AccountID:        accountID,
+		AccountNumber:    accountNumber,
 		RequiredAmount:   &params.RequiredAmount,
 		ExpectedCurrency: &params.Currency,
 		IncludeDetails:   false, // Keep it simple for workflow activities
@@ -93,7 +61,16 @@ func (api *Activity) CheckBalance(ctx context.Context, params CheckBalanceActivi
 
 		logger.WithError(err).Error()
 
-		return nil, err
+		return nil, activityError(err)
+	}
+
+	// A transfer cannot go ahead from a blocked account, in another currency or without the funds
+	if err := result.Failure(params.Currency); err != nil {
+		err = fmt.Errorf("balance check failed: %w", err)
+
+		logger.WithError(err).Warn()
+
+		return nil, activityError(err)
 	}
 
 	// PERFORMANCE OPTIMIZATION: Record heartbeat after service completion
@@ -106,7 +83,7 @@ func (api *Activity) CheckBalance(ctx context.Context, params CheckBalanceActivi
 		RequiredAmount:  params.RequiredAmount,
 		SufficientFunds: result.SufficientFunds,
 		Currency:        result.Currency,
-		CheckedAt:       activityInfo.StartedTime.Format("2006-01-02T15:04:05Z07:00"),
+		CheckedAt:       activity.GetInfo(ctx).StartedTime.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
 	logger.WithField("result", fmt.Sprintf("%+v", activityResult)).Info()
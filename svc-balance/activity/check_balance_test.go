@@ -1,10 +1,18 @@
 package activity
 
 import (
+	"io"
 	"testing"
 
+	"svc-balance/service"
+	"svc-balance/store/memory"
+
 	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
 )
 
 func TestCheckBalanceActivityParams(t *testing.T) {
@@ -42,3 +50,38 @@ func TestCheckBalanceActivityResults(t *testing.T) {
 	assert.Equal(t, "USD", results.Currency)
 	assert.Equal(t, "2023-12-01T10:30:00Z", results.CheckedAt)
 }
+
+func TestCheckBalanceAcceptsAccountNumbers(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	api := NewActivity(logger, service.NewService(logger, memory.NewStore(logger, true), service.FXPricing{}, service.RoundingPolicy{}, 0))
+
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestActivityEnvironment()
+	env.RegisterActivity(api.CheckBalance)
+
+	// The gateway and the demo scenarios start transfers with account numbers rather than account IDs
+	for _, account := range []string{"550e8400-e29b-41d4-a716-446655440001", "ACC001"} {
+		value, err := env.ExecuteActivity(api.CheckBalance, CheckBalanceActivityParams{
+			AccountID:      account,
+			RequiredAmount: decimal.RequireFromString("100"),
+			Currency:       "USD",
+		})
+		require.NoError(t, err, account)
+
+		var result CheckBalanceActivityResults
+		require.NoError(t, value.Get(&result))
+		assert.Equal(t, "550e8400-e29b-41d4-a716-446655440001", result.AccountID, account)
+		assert.True(t, result.SufficientFunds, account)
+	}
+
+	_, err := env.ExecuteActivity(api.CheckBalance, CheckBalanceActivityParams{
+		AccountID:      "ACC404",
+		RequiredAmount: decimal.RequireFromString("100"),
+		Currency:       "USD",
+	})
+	var applicationErr *temporal.ApplicationError
+	require.ErrorAs(t, err, &applicationErr)
+	assert.Equal(t, ErrorTypeAccountNotFound, applicationErr.Type())
+}
@@ -0,0 +1,65 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-balance/service"
+
+	"github.com/shopspring/decimal"
+	"go.temporal.io/sdk/temporal"
+)
+
+// ErrorTypeInvalidTransferEvent is the application error type of transfer events that can never be delivered
+const ErrorTypeInvalidTransferEvent = "INVALID_TRANSFER_EVENT"
+
+// NotifyTransferEventActivityParams defines parameters for the NotifyTransferEvent activity
+// This matches the structure sent by the transfer workflow
+type NotifyTransferEventActivityParams struct {
+	Destination string          `json:"destination"`
+	EventType   string          `json:"event_type"`
+	TransferID  string          `json:"transfer_id"`
+	FromAccount string          `json:"from_account"`
+	ToAccount   string          `json:"to_account"`
+	Amount      decimal.Decimal `json:"amount"`
+	Currency    string          `json:"currency"`
+	Reason      string          `json:"reason"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	WorkflowID  string          `json:"workflow_id"`
+	RunID       string          `json:"run_id"`
+}
+
+// NotifyTransferEvent is the Temporal activity that posts transfer events, such as an expiry, to the callback URL
+// of the transfer. Delivery failures are returned as is, so the workflow's retry policy decides how often to try.
+func (api *Activity) NotifyTransferEvent(ctx context.Context, params NotifyTransferEventActivityParams) error {
+	logger := api.activityLogger(ctx)
+
+	err := api.service.NotifyTransferEvent(ctx, service.NotifyTransferEventParams{
+		Destination: params.Destination,
+		EventType:   params.EventType,
+		TransferID:  params.TransferID,
+		FromAccount: params.FromAccount,
+		ToAccount:   params.ToAccount,
+		Amount:      params.Amount,
+		Currency:    params.Currency,
+		Reason:      params.Reason,
+		OccurredAt:  params.OccurredAt,
+	})
+	if err != nil {
+		err = fmt.Errorf("transfer event notification failed: %w", err)
+
+		logger.WithError(err).Warn()
+
+		if errors.Is(err, service.ErrInvalidTransferEvent) {
+			return temporal.NewNonRetryableApplicationError(err.Error(), ErrorTypeInvalidTransferEvent, err)
+		}
+
+		return err
+	}
+
+	logger.WithField("event_type", params.EventType).Info("Transfer event delivered")
+
+	return nil
+}
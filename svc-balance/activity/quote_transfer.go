@@ -0,0 +1,40 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+
+	"svc-balance/service"
+
+	"github.com/shopspring/decimal"
+)
+
+// QuoteTransferActivityParams defines parameters for the QuoteTransfer activity
+type QuoteTransferActivityParams struct {
+	ToAccount string          `json:"to_account"`
+	Amount    decimal.Decimal `json:"amount"`
+	Currency  string          `json:"currency"`
+}
+
+// QuoteTransfer is the Temporal activity that prices the credit of a transfer in the currency of its destination
+// account, with the spread and margin of the pair when the currencies differ
+func (api *Activity) QuoteTransfer(ctx context.Context, params QuoteTransferActivityParams) (*service.ConvertCurrencyResults, error) {
+	logger := api.activityLogger(ctx)
+
+	result, err := api.service.QuoteTransfer(ctx, service.QuoteTransferParams{
+		ToAccount: params.ToAccount,
+		Amount:    params.Amount,
+		Currency:  params.Currency,
+	})
+	if err != nil {
+		err = fmt.Errorf("transfer quote failed: %w", err)
+
+		logger.WithError(err).Warn()
+
+		return nil, activityError(err)
+	}
+
+	logger.WithField("result", fmt.Sprintf("%+v", result)).Info("Transfer quoted")
+
+	return result, nil
+}
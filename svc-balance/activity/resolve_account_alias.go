@@ -0,0 +1,43 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+)
+
+// ResolveAccountAliasActivityParams defines parameters for the ResolveAccountAlias activity
+type ResolveAccountAliasActivityParams struct {
+	Alias string `json:"alias"`
+}
+
+// ResolveAccountAliasActivityResults defines the account an alias resolved to
+type ResolveAccountAliasActivityResults struct {
+	Alias         string `json:"alias"` // Normalized form of the requested alias
+	AccountID     string `json:"account_id"`
+	AccountNumber string `json:"account_number"`
+	Currency      string `json:"currency"`
+}
+
+// ResolveAccountAlias is the Temporal activity that resolves the phone number or email address a transfer is
+// addressed to into the account it is credited to
+func (api *Activity) ResolveAccountAlias(ctx context.Context, params ResolveAccountAliasActivityParams) (*ResolveAccountAliasActivityResults, error) {
+	logger := api.activityLogger(ctx)
+
+	resolved, err := api.service.ResolveAccountAlias(ctx, params.Alias)
+	if err != nil {
+		err = fmt.Errorf("alias resolution failed: %w", err)
+
+		logger.WithError(err).Warn()
+
+		return nil, activityError(err)
+	}
+
+	logger.WithField("account_number", resolved.AccountNumber).Info("Account alias resolved")
+
+	return &ResolveAccountAliasActivityResults{
+		Alias:         resolved.Alias,
+		AccountID:     resolved.AccountID.String(),
+		AccountNumber: resolved.AccountNumber,
+		Currency:      resolved.Currency,
+	}, nil
+}
@@ -0,0 +1,62 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-balance/service"
+
+	"github.com/shopspring/decimal"
+	"go.temporal.io/sdk/temporal"
+)
+
+// SendTransferNotificationActivityParams defines parameters for the SendTransferNotification activity
+// This matches the structure sent by the transfer workflow
+type SendTransferNotificationActivityParams struct {
+	EventType   string          `json:"event_type"`
+	TransferID  string          `json:"transfer_id"`
+	FromAccount string          `json:"from_account"`
+	ToAccount   string          `json:"to_account"`
+	Amount      decimal.Decimal `json:"amount"`
+	Currency    string          `json:"currency"`
+	Description string          `json:"description"`
+	Reason      string          `json:"reason"`
+	OccurredAt  time.Time       `json:"occurred_at"`
+	WorkflowID  string          `json:"workflow_id"`
+	RunID       string          `json:"run_id"`
+}
+
+// SendTransferNotification is the Temporal activity that emails the account holders of a completed or compensated
+// transfer. Events it can never send, such as unknown event types, fail without retries; send failures are retried.
+func (api *Activity) SendTransferNotification(ctx context.Context, params SendTransferNotificationActivityParams) (*service.SendTransferNotificationResults, error) {
+	logger := api.activityLogger(ctx)
+
+	results, err := api.service.SendTransferNotification(ctx, service.SendTransferNotificationParams{
+		EventType:   params.EventType,
+		TransferID:  params.TransferID,
+		FromAccount: params.FromAccount,
+		ToAccount:   params.ToAccount,
+		Amount:      params.Amount,
+		Currency:    params.Currency,
+		Description: params.Description,
+		Reason:      params.Reason,
+		OccurredAt:  params.OccurredAt,
+	})
+	if err != nil {
+		err = fmt.Errorf("transfer notification failed: %w", err)
+
+		logger.WithError(err).Warn()
+
+		if errors.Is(err, service.ErrInvalidTransferEvent) {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), ErrorTypeInvalidTransferEvent, err)
+		}
+
+		return nil, err
+	}
+
+	logger.WithField("event_type", params.EventType).Info("Transfer notification processed")
+
+	return results, nil
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"errors"
+	"net/url"
+
+	"svc-balance/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// RegisterAccountAliasRequest represents the request body for pointing a phone number or email address to an account
+type RegisterAccountAliasRequest struct {
+	Alias     string `json:"alias"` // International phone number such as +14155550101, or an email address
+	AccountID string `json:"account_id"`
+}
+
+// RegisterAccountAlias handles POST /aliases
+func (api *Api) RegisterAccountAlias(c *fiber.Ctx) error {
+	const op = "api.Api.RegisterAccountAlias"
+
+	var request RegisterAccountAliasRequest
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	accountID, err := uuid.Parse(request.AccountID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": accountID.String(),
+	})
+
+	accountAlias, err := api.service.RegisterAccountAlias(c.Context(), accountID, request.Alias)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidAccountAlias):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrAccountNotFound):
+			return fiber.NewError(fiber.StatusNotFound, "Account not found")
+		case errors.Is(err, service.ErrAccountAliasTaken):
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to register account alias")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to register account alias")
+	}
+
+	api.recordAdminAction(c, service.AdminAction{
+		Actor:        adminActor(c),
+		Action:       service.AdminActionRegisterAccountAlias,
+		ResourceType: "account_alias",
+		ResourceID:   accountAlias.Alias,
+		After:        accountAlias,
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Account alias registered successfully",
+		"data":    accountAlias,
+	})
+}
+
+// ResolveAccountAlias handles GET /aliases/:alias
+func (api *Api) ResolveAccountAlias(c *fiber.Ctx) error {
+	const op = "api.Api.ResolveAccountAlias"
+
+	value, err := url.PathUnescape(c.Params("alias"))
+	if err != nil || value == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid alias")
+	}
+
+	resolved, err := api.service.ResolveAccountAlias(c.Context(), value)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidAccountAlias):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrAliasNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		api.logger.WithField("[op]", op).WithError(err).Error("Failed to resolve account alias")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to resolve account alias")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Account alias resolved successfully",
+		"data":    resolved,
+	})
+}
+
+// DeleteAccountAlias handles DELETE /aliases/:alias
+func (api *Api) DeleteAccountAlias(c *fiber.Ctx) error {
+	const op = "api.Api.DeleteAccountAlias"
+
+	value, err := url.PathUnescape(c.Params("alias"))
+	if err != nil || value == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid alias")
+	}
+
+	// Recorded as the before payload of the audit entry; a missing alias fails the delete below
+	before, _ := api.service.ResolveAccountAlias(c.Context(), value)
+
+	if err := api.service.DeleteAccountAlias(c.Context(), value); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidAccountAlias):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrAliasNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		api.logger.WithField("[op]", op).WithError(err).Error("Failed to delete account alias")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to delete account alias")
+	}
+
+	resourceID := value
+	if before != nil {
+		resourceID = before.Alias
+	}
+
+	api.recordAdminAction(c, service.AdminAction{
+		Actor:        adminActor(c),
+		Action:       service.AdminActionDeleteAccountAlias,
+		ResourceType: "account_alias",
+		ResourceID:   resourceID,
+		Before:       before,
+	})
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Account alias deleted successfully",
+	})
+}
+
+// ListAccountAliases handles GET /accounts/:id/aliases
+func (api *Api) ListAccountAliases(c *fiber.Ctx) error {
+	const op = "api.Api.ListAccountAliases"
+
+	accountID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	aliases, err := api.service.ListAccountAliases(c.Context(), accountID)
+	if err != nil {
+		api.logger.WithFields(logrus.Fields{
+			"[op]":       op,
+			"account_id": accountID.String(),
+		}).WithError(err).Error("Failed to list account aliases")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve account aliases")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Account aliases retrieved successfully",
+		"data":    aliases,
+		"count":   len(aliases),
+	})
+}
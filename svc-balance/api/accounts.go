@@ -0,0 +1,139 @@
+package api
+
+import (
+	"errors"
+
+	"svc-balance/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// ListAccounts handles GET /accounts
+//
+// Query parameters: status (defaults to "active"), limit and offset. With query, the accounts whose account number
+// contains it or whose name starts with it are searched instead, optionally filtered by currency and status.
+func (api *Api) ListAccounts(c *fiber.Ctx) error {
+	const op = "api.Api.ListAccounts"
+
+	if c.Query("query") != "" {
+		return api.searchAccounts(c)
+	}
+
+	params := service.ListAccountsParams{
+		Status: c.Query("status"),
+		Limit:  int32(c.QueryInt("limit", 0)),
+		Offset: int32(c.QueryInt("offset", 0)),
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": params,
+	})
+
+	accounts, err := api.service.ListAccounts(c.Context(), params)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidListAccountsQuery) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to list accounts")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to list accounts")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":   "success",
+		"message":  "Accounts retrieved successfully",
+		"accounts": accounts,
+	})
+}
+
+func (api *Api) searchAccounts(c *fiber.Ctx) error {
+	const op = "api.Api.SearchAccounts"
+
+	params := service.SearchAccountsParams{
+		Query:    c.Query("query"),
+		Currency: c.Query("currency"),
+		Status:   c.Query("status"),
+		Limit:    int32(c.QueryInt("limit", 0)),
+		Offset:   int32(c.QueryInt("offset", 0)),
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": params,
+	})
+
+	accounts, err := api.service.SearchAccounts(c.Context(), params)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidListAccountsQuery) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to search accounts")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to search accounts")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":   "success",
+		"message":  "Accounts retrieved successfully",
+		"accounts": accounts,
+	})
+}
+
+// GetAccountSummary handles GET /accounts/:id/summary, where id is the account ID or its account number
+func (api *Api) GetAccountSummary(c *fiber.Ctx) error {
+	const op = "api.Api.GetAccountSummary"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"account": c.Params("id"),
+	})
+
+	summary, err := api.service.GetAccountSummary(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, service.ErrAccountNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Account not found")
+		}
+
+		logger.WithError(err).Error("Failed to get account summary")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve account summary")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Account summary retrieved successfully",
+		"data":    summary,
+	})
+}
+
+// GetBalances handles POST /accounts/balances with a body of {"accounts": [...]}, each an account ID or number
+func (api *Api) GetBalances(c *fiber.Ctx) error {
+	const op = "api.Api.GetBalances"
+
+	var params service.GetBalancesParams
+	if err := c.BodyParser(&params); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"accounts": len(params.Accounts),
+	})
+
+	balances, err := api.service.GetBalances(c.Context(), params)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidGetBalancesQuery) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to get balances")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve balances")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Balances retrieved successfully",
+		"data":    balances,
+	})
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"svc-balance/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// HeaderActor carries the identity of the operator making an admin call
+const HeaderActor = "X-Actor"
+
+// anonymousActor is recorded when an admin call names no actor
+const anonymousActor = "anonymous"
+
+// adminActor returns the actor of an admin call from the X-Actor header, or "anonymous"
+func adminActor(c *fiber.Ctx) string {
+	if actor := c.Get(HeaderActor); actor != "" {
+		return actor
+	}
+
+	return anonymousActor
+}
+
+// recordAdminAction records a state-changing admin call once it has succeeded. The call has already taken
+// effect, so a failure to record it is logged rather than returned.
+func (api *Api) recordAdminAction(c *fiber.Ctx, action service.AdminAction) {
+	if err := api.service.RecordAdminAction(c.Context(), action); err != nil {
+		api.logger.WithFields(logrus.Fields{
+			"[op]":        "api.Api.recordAdminAction",
+			"action":      action.Action,
+			"resource_id": action.ResourceID,
+		}).WithError(err).Error("Failed to record admin action")
+	}
+}
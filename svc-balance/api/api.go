@@ -3,6 +3,7 @@ package api
 import (
 	"svc-balance/middleware"
 	"svc-balance/service"
+	"svc-balance/store/migrations"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
@@ -11,33 +12,101 @@ import (
 type Api struct {
 	logger *logrus.Logger
 
-	service *service.Service
+	service  *service.Service
+	migrator *migrations.Migrator
 }
 
 func NewApi(
 	logger *logrus.Logger,
 	service *service.Service,
+	migrator *migrations.Migrator,
 ) *Api {
 	return &Api{
 		logger: logger,
 
-		service: service,
+		service:  service,
+		migrator: migrator,
 	}
 }
 
 func (api *Api) SetupRoutes(app *fiber.App) *fiber.App {
+	// Panic recovery middleware
+	app.Use(middleware.Recover(api.logger))
+
 	// Error handler middleware
 	app.Use(middleware.ErrorHandler())
 
 	// Health Routes
 	health := app.Group("/health")
 	health.Get("/", api.Health)
+	app.Get("/healthz", api.Healthz)
 
 	// Failure Simulation Routes (for testing and monitoring)
 	failureSimulation := app.Group("/failure-simulation")
 	failureSimulation.Get("/stats", api.GetFailureSimulationStats)
 	failureSimulation.Post("/reset", api.ResetFailureSimulation)
+	failureSimulation.Post("/enable", api.EnableFailureSimulation)
+	failureSimulation.Post("/disable", api.DisableFailureSimulation)
 	failureSimulation.Get("/scenarios", api.GetLearningScenarios)
+	failureSimulation.Post("/rules/:name/enable", api.EnableFailureRule)
+	failureSimulation.Post("/rules/:name/disable", api.DisableFailureRule)
+
+	// Simulated Latency Routes (base processing time added to every activity)
+	latencyProfile := app.Group("/latency-profile")
+	latencyProfile.Get("/", api.GetLatencyProfile)
+	latencyProfile.Post("/:name", api.SetLatencyProfile)
+
+	// Admin Routes (worker scaling hints for HPA or KEDA)
+	admin := app.Group("/admin")
+	admin.Get("/scaling", api.GetScalingHints)
+
+	// Balance Alert Routes
+	balanceAlerts := app.Group("/balance-alerts")
+	balanceAlerts.Post("/", api.CreateBalanceAlert)
+	balanceAlerts.Get("/", api.ListBalanceAlerts)
+	balanceAlerts.Post("/evaluate", api.EvaluateBalanceAlerts)
+	balanceAlerts.Get("/:id", api.GetBalanceAlert)
+	balanceAlerts.Patch("/:id", api.UpdateBalanceAlert)
+	balanceAlerts.Delete("/:id", api.DeleteBalanceAlert)
+
+	// Business Rule Routes (soft validation rules shared with svc-transaction)
+	businessRules := app.Group("/business-rules")
+	businessRules.Post("/", api.CreateBusinessRule)
+	businessRules.Get("/", api.ListBusinessRules)
+	businessRules.Get("/:id", api.GetBusinessRule)
+	businessRules.Put("/:id", api.UpdateBusinessRule)
+	businessRules.Delete("/:id", api.DeleteBusinessRule)
+
+	// Account Routes
+	accounts := app.Group("/accounts")
+	accounts.Get("/", api.ListAccounts)
+	accounts.Post("/balances", api.GetBalances)
+	accounts.Get("/:id/summary", api.GetAccountSummary)
+	accounts.Get("/:id/balance-history", api.GetBalanceHistory)
+	accounts.Get("/:id/closing-balances", api.GetClosingBalances)
+	accounts.Get("/:id/notification-preferences", api.GetNotificationPreference)
+	accounts.Put("/:id/notification-preferences", api.SetNotificationPreference)
+	accounts.Delete("/:id/notification-preferences", api.DeleteNotificationPreference)
+	accounts.Get("/:id/aliases", api.ListAccountAliases)
+
+	// Account Alias Routes (phone numbers and email addresses transfers can be sent to instead of an account)
+	aliases := app.Group("/aliases")
+	aliases.Post("/", api.RegisterAccountAlias)
+	aliases.Get("/:alias", api.ResolveAccountAlias)
+	aliases.Delete("/:alias", api.DeleteAccountAlias)
+
+	// Notification Suppression Routes (addresses never emailed, e.g. after a bounce)
+	notificationSuppressions := app.Group("/notification-suppressions")
+	notificationSuppressions.Post("/", api.AddNotificationSuppression)
+	notificationSuppressions.Get("/", api.ListNotificationSuppressions)
+	notificationSuppressions.Delete("/:email", api.RemoveNotificationSuppression)
+
+	// Currency Routes
+	currencies := app.Group("/currencies")
+	currencies.Get("/", api.GetSupportedCurrencies)
+	currencies.Get("/convert", api.ConvertCurrency)
+	currencies.Get("/:code/format", api.FormatCurrency)
+	currencies.Post("/:code/split", api.SplitAmount)
 
 	return app
 }
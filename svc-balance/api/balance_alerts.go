@@ -0,0 +1,262 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+
+	"svc-balance/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateBalanceAlertRequest represents the request body for registering a balance alert
+type CreateBalanceAlertRequest struct {
+	AccountID  string `json:"account_id"`
+	Threshold  string `json:"threshold"`
+	WebhookURL string `json:"webhook_url"`
+}
+
+// UpdateBalanceAlertRequest represents the request body for enabling or disabling a balance alert
+type UpdateBalanceAlertRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// CreateBalanceAlert handles POST /balance-alerts
+func (api *Api) CreateBalanceAlert(c *fiber.Ctx) error {
+	const op = "api.Api.CreateBalanceAlert"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	var request CreateBalanceAlertRequest
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	accountID, err := uuid.Parse(request.AccountID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account_id")
+	}
+
+	threshold, err := decimal.NewFromString(request.Threshold)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid threshold")
+	}
+
+	alert, err := api.service.CreateBalanceAlert(c.Context(), service.CreateBalanceAlertParams{
+		AccountID:  accountID,
+		Threshold:  threshold,
+		WebhookURL: request.WebhookURL,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to create balance alert")
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	api.recordAdminAction(c, service.AdminAction{
+		Actor:        adminActor(c),
+		Action:       service.AdminActionCreateBalanceAlert,
+		ResourceType: "balance_alert",
+		ResourceID:   alert.ID.String(),
+		After:        alert,
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Balance alert created successfully",
+		"data":    alert,
+	})
+}
+
+// ListBalanceAlerts handles GET /balance-alerts
+func (api *Api) ListBalanceAlerts(c *fiber.Ctx) error {
+	const op = "api.Api.ListBalanceAlerts"
+
+	params := service.ListBalanceAlertsParams{
+		Limit:  50, // Default limit
+		Offset: 0,
+	}
+
+	if accountIDParam := c.Query("account_id"); accountIDParam != "" {
+		accountID, err := uuid.Parse(accountIDParam)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid account_id")
+		}
+		params.AccountID = &accountID
+	}
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.ParseInt(limitParam, 10, 32); err == nil && parsedLimit > 0 && parsedLimit <= 1000 {
+			params.Limit = int32(parsedLimit)
+		}
+	}
+	if offsetParam := c.Query("offset"); offsetParam != "" {
+		if parsedOffset, err := strconv.ParseInt(offsetParam, 10, 32); err == nil && parsedOffset >= 0 {
+			params.Offset = int32(parsedOffset)
+		}
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": params,
+	})
+
+	alerts, err := api.service.ListBalanceAlerts(c.Context(), params)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list balance alerts")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve balance alerts")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Balance alerts retrieved successfully",
+		"data":    alerts,
+		"count":   len(alerts),
+	})
+}
+
+// GetBalanceAlert handles GET /balance-alerts/:id
+func (api *Api) GetBalanceAlert(c *fiber.Ctx) error {
+	const op = "api.Api.GetBalanceAlert"
+
+	alertID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid alert ID")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"alert_id": alertID.String(),
+	})
+
+	alert, err := api.service.GetBalanceAlert(c.Context(), alertID)
+	if err != nil {
+		if errors.Is(err, service.ErrBalanceAlertNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to get balance alert")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve balance alert")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Balance alert retrieved successfully",
+		"data":    alert,
+	})
+}
+
+// UpdateBalanceAlert handles PATCH /balance-alerts/:id
+func (api *Api) UpdateBalanceAlert(c *fiber.Ctx) error {
+	const op = "api.Api.UpdateBalanceAlert"
+
+	alertID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid alert ID")
+	}
+
+	var request UpdateBalanceAlertRequest
+	if err := c.BodyParser(&request); err != nil || request.Enabled == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Request body must contain 'enabled'")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"alert_id": alertID.String(),
+		"enabled":  *request.Enabled,
+	})
+
+	// Recorded as the before payload of the audit entry; a missing alert fails the update below
+	before, _ := api.service.GetBalanceAlert(c.Context(), alertID)
+
+	alert, err := api.service.SetBalanceAlertEnabled(c.Context(), alertID, *request.Enabled)
+	if err != nil {
+		if errors.Is(err, service.ErrBalanceAlertNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to update balance alert")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to update balance alert")
+	}
+
+	api.recordAdminAction(c, service.AdminAction{
+		Actor:        adminActor(c),
+		Action:       service.AdminActionSetBalanceAlertEnabled,
+		ResourceType: "balance_alert",
+		ResourceID:   alertID.String(),
+		Before:       before,
+		After:        alert,
+	})
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Balance alert updated successfully",
+		"data":    alert,
+	})
+}
+
+// DeleteBalanceAlert handles DELETE /balance-alerts/:id
+func (api *Api) DeleteBalanceAlert(c *fiber.Ctx) error {
+	const op = "api.Api.DeleteBalanceAlert"
+
+	alertID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid alert ID")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"alert_id": alertID.String(),
+	})
+
+	// Recorded as the before payload of the audit entry; a missing alert fails the delete below
+	before, _ := api.service.GetBalanceAlert(c.Context(), alertID)
+
+	if err := api.service.DeleteBalanceAlert(c.Context(), alertID); err != nil {
+		if errors.Is(err, service.ErrBalanceAlertNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to delete balance alert")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to delete balance alert")
+	}
+
+	api.recordAdminAction(c, service.AdminAction{
+		Actor:        adminActor(c),
+		Action:       service.AdminActionDeleteBalanceAlert,
+		ResourceType: "balance_alert",
+		ResourceID:   alertID.String(),
+		Before:       before,
+	})
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Balance alert deleted successfully",
+	})
+}
+
+// EvaluateBalanceAlerts handles POST /balance-alerts/evaluate and runs an evaluation pass immediately
+func (api *Api) EvaluateBalanceAlerts(c *fiber.Ctx) error {
+	const op = "api.Api.EvaluateBalanceAlerts"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	logger.Info("Evaluating balance alerts on demand")
+
+	results, err := api.service.EvaluateBalanceAlerts(c.Context())
+	if err != nil {
+		logger.WithError(err).Error("Failed to evaluate balance alerts")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to evaluate balance alerts")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Balance alerts evaluated successfully",
+		"data":    results,
+	})
+}
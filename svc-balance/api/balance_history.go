@@ -0,0 +1,78 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"svc-balance/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// GetBalanceHistory handles GET /accounts/:id/balance-history
+//
+// Query parameters: operation (comma-separated, e.g. "debit,credit"), from and to (RFC 3339), cursor and limit.
+func (api *Api) GetBalanceHistory(c *fiber.Ctx) error {
+	const op = "api.Api.GetBalanceHistory"
+
+	accountID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	params := service.GetBalanceHistoryParams{
+		AccountID: accountID,
+		Cursor:    c.Query("cursor"),
+	}
+
+	if operationParam := c.Query("operation"); operationParam != "" {
+		params.Operations = strings.Split(operationParam, ",")
+	}
+	for name, target := range map[string]**time.Time{"from": &params.From, "to": &params.To} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid "+name+", expected an RFC 3339 timestamp")
+		}
+		*target = &parsed
+	}
+	if limitParam := c.Query("limit"); limitParam != "" {
+		parsedLimit, err := strconv.ParseInt(limitParam, 10, 32)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid limit")
+		}
+		params.Limit = int32(parsedLimit)
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": params,
+	})
+
+	history, err := api.service.GetBalanceHistory(c.Context(), params)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidBalanceHistoryQuery):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrAccountNotFound):
+			return fiber.NewError(fiber.StatusNotFound, "Account not found")
+		}
+
+		logger.WithError(err).Error("Failed to get balance history")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve balance history")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Balance history retrieved successfully",
+		"data":    history,
+	})
+}
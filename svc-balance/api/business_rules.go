@@ -0,0 +1,208 @@
+package api
+
+import (
+	"errors"
+
+	"svc-balance/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// CreateBusinessRuleRequest represents the request body for adding a business rule
+type CreateBusinessRuleRequest struct {
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`               // max_transaction_amount, min_account_name_length or min_balance
+	Currency  string `json:"currency,omitempty"` // Empty for any currency
+	Threshold string `json:"threshold"`
+	Severity  string `json:"severity"` // error or warning
+	Enabled   *bool  `json:"enabled"`  // Default: true
+}
+
+// UpdateBusinessRuleRequest represents the request body for changing a business rule
+type UpdateBusinessRuleRequest struct {
+	Threshold string `json:"threshold"`
+	Severity  string `json:"severity"`
+	Enabled   *bool  `json:"enabled"`
+}
+
+// CreateBusinessRule handles POST /business-rules
+func (api *Api) CreateBusinessRule(c *fiber.Ctx) error {
+	const op = "api.Api.CreateBusinessRule"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	var request CreateBusinessRuleRequest
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	threshold, err := decimal.NewFromString(request.Threshold)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid threshold")
+	}
+
+	enabled := true
+	if request.Enabled != nil {
+		enabled = *request.Enabled
+	}
+
+	rule, err := api.service.CreateBusinessRule(c.Context(), service.CreateBusinessRuleParams{
+		Name:      request.Name,
+		Kind:      request.Kind,
+		Currency:  request.Currency,
+		Threshold: threshold,
+		Severity:  request.Severity,
+		Enabled:   enabled,
+		Actor:     adminActor(c),
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidBusinessRule) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to create business rule")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to create business rule")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Business rule created successfully",
+		"data":    rule,
+	})
+}
+
+// ListBusinessRules handles GET /business-rules
+func (api *Api) ListBusinessRules(c *fiber.Ctx) error {
+	const op = "api.Api.ListBusinessRules"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	rules, err := api.service.ListBusinessRules(c.Context())
+	if err != nil {
+		logger.WithError(err).Error("Failed to list business rules")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve business rules")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Business rules retrieved successfully",
+		"data":    rules,
+		"count":   len(rules),
+	})
+}
+
+// GetBusinessRule handles GET /business-rules/:id
+func (api *Api) GetBusinessRule(c *fiber.Ctx) error {
+	const op = "api.Api.GetBusinessRule"
+
+	ruleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid rule ID")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"rule_id": ruleID.String(),
+	})
+
+	rule, err := api.service.GetBusinessRule(c.Context(), ruleID)
+	if err != nil {
+		if errors.Is(err, service.ErrBusinessRuleNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to get business rule")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve business rule")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Business rule retrieved successfully",
+		"data":    rule,
+	})
+}
+
+// UpdateBusinessRule handles PUT /business-rules/:id
+func (api *Api) UpdateBusinessRule(c *fiber.Ctx) error {
+	const op = "api.Api.UpdateBusinessRule"
+
+	ruleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid rule ID")
+	}
+
+	var request UpdateBusinessRuleRequest
+	if err := c.BodyParser(&request); err != nil || request.Enabled == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Request body must contain 'threshold', 'severity' and 'enabled'")
+	}
+
+	threshold, err := decimal.NewFromString(request.Threshold)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid threshold")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"rule_id": ruleID.String(),
+	})
+
+	rule, err := api.service.UpdateBusinessRule(c.Context(), ruleID, service.UpdateBusinessRuleParams{
+		Threshold: threshold,
+		Severity:  request.Severity,
+		Enabled:   *request.Enabled,
+		Actor:     adminActor(c),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBusinessRuleNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrInvalidBusinessRule):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to update business rule")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to update business rule")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Business rule updated successfully",
+		"data":    rule,
+	})
+}
+
+// DeleteBusinessRule handles DELETE /business-rules/:id
+func (api *Api) DeleteBusinessRule(c *fiber.Ctx) error {
+	const op = "api.Api.DeleteBusinessRule"
+
+	ruleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid rule ID")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"rule_id": ruleID.String(),
+	})
+
+	if err := api.service.DeleteBusinessRule(c.Context(), ruleID, adminActor(c)); err != nil {
+		if errors.Is(err, service.ErrBusinessRuleNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to delete business rule")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to delete business rule")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Business rule deleted successfully",
+	})
+}
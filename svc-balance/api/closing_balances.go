@@ -0,0 +1,63 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"svc-balance/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// GetClosingBalances handles GET /accounts/:id/closing-balances
+//
+// Query parameters: from and to (business dates as YYYY-MM-DD, both inclusive); to defaults to yesterday and from to
+// 31 days before to.
+func (api *Api) GetClosingBalances(c *fiber.Ctx) error {
+	const op = "api.Api.GetClosingBalances"
+
+	accountID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	params := service.GetClosingBalancesParams{AccountID: accountID}
+	for name, target := range map[string]*time.Time{"from": &params.From, "to": &params.To} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(service.BusinessDateFormat, value)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid "+name+", expected a date as YYYY-MM-DD")
+		}
+		*target = parsed
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": params,
+	})
+
+	balances, err := api.service.GetClosingBalances(c.Context(), params)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidClosingBalanceQuery):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrAccountNotFound):
+			return fiber.NewError(fiber.StatusNotFound, "Account not found")
+		}
+
+		logger.WithError(err).Error("Failed to get closing balances")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve closing balances")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Closing balances retrieved successfully",
+		"data":    balances,
+	})
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"svc-balance/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// GetSupportedCurrencies handles GET /currencies?include_inactive=
+func (api *Api) GetSupportedCurrencies(c *fiber.Ctx) error {
+	const op = "api.Api.GetSupportedCurrencies"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	result, err := api.service.GetSupportedCurrencies(c.Context(), service.GetSupportedCurrenciesParams{
+		IncludeInactive: c.QueryBool("include_inactive"),
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to get supported currencies")
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Supported currencies retrieved successfully",
+		"data":    result,
+	})
+}
+
+// FormatCurrency handles GET /currencies/:code/format?amount=&locale=
+func (api *Api) FormatCurrency(c *fiber.Ctx) error {
+	const op = "api.Api.FormatCurrency"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"currency": c.Params("code"),
+	})
+
+	amount, err := decimal.NewFromString(c.Query("amount"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid amount")
+	}
+
+	result, err := api.service.FormatCurrency(c.Context(), service.FormatCurrencyParams{
+		Amount:       amount,
+		CurrencyCode: c.Params("code"),
+		Locale:       c.Query("locale"),
+	})
+	if err != nil {
+		logger.WithError(err).Warn("Failed to format currency amount")
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Currency amount formatted successfully",
+		"data":    result,
+	})
+}
+
+// ConvertCurrency handles GET /currencies/convert?amount=&from=&to=&as_of=&tenant= and quotes the conversion with its
+// mid/bid/ask rates. With as_of (RFC 3339), the rates recorded at that time are used.
+func (api *Api) ConvertCurrency(c *fiber.Ctx) error {
+	const op = "api.Api.ConvertCurrency"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	amount, err := decimal.NewFromString(c.Query("amount"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid amount")
+	}
+
+	if c.Query("as_of") != "" {
+		return api.convertCurrencyAsOf(c, logger, amount)
+	}
+
+	result, err := api.service.ConvertCurrency(c.Context(), service.ConvertCurrencyParams{
+		Amount:       amount,
+		FromCurrency: c.Query("from"),
+		ToCurrency:   c.Query("to"),
+		Tenant:       c.Query("tenant"),
+	})
+	if err != nil {
+		logger.WithError(err).Warn("Failed to convert currency amount")
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Currency amount converted successfully",
+		"data":    result,
+	})
+}
+
+func (api *Api) convertCurrencyAsOf(c *fiber.Ctx, logger *logrus.Entry, amount decimal.Decimal) error {
+	asOf, err := time.Parse(time.RFC3339, c.Query("as_of"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid as_of, expected RFC 3339")
+	}
+
+	result, err := api.service.ConvertCurrencyAsOf(c.Context(), service.ConvertCurrencyAsOfParams{
+		Amount:       amount,
+		FromCurrency: c.Query("from"),
+		ToCurrency:   c.Query("to"),
+		AsOf:         asOf,
+		Tenant:       c.Query("tenant"),
+	})
+	if err != nil {
+		logger.WithError(err).Warn("Failed to convert currency amount as of a past time")
+
+		switch {
+		case errors.Is(err, service.ErrInvalidConversionParams):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrFXRateNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to convert currency amount")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Currency amount converted successfully",
+		"data":    result,
+	})
+}
+
+// splitAmountRequest is the body of POST /currencies/:code/split
+type splitAmountRequest struct {
+	Amount  decimal.Decimal   `json:"amount"`
+	Weights []decimal.Decimal `json:"weights"`
+	Tenant  string            `json:"tenant"`
+}
+
+// SplitAmount handles POST /currencies/:code/split and splits an amount into weighted legs plus a remainder line
+func (api *Api) SplitAmount(c *fiber.Ctx) error {
+	const op = "api.Api.SplitAmount"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"currency": c.Params("code"),
+	})
+
+	var request splitAmountRequest
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	result, err := api.service.SplitAmount(c.Context(), service.SplitAmountParams{
+		Amount:   request.Amount,
+		Currency: c.Params("code"),
+		Weights:  request.Weights,
+		Tenant:   request.Tenant,
+	})
+	if err != nil {
+		logger.WithError(err).Warn("Failed to split amount")
+
+		if errors.Is(err, service.ErrInvalidSplit) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to split amount")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Amount split successfully",
+		"data":    result,
+	})
+}
@@ -1,6 +1,11 @@
 package api
 
 import (
+	"errors"
+	"fmt"
+
+	"svc-balance/service"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 )
@@ -36,14 +41,112 @@ func (api *Api) ResetFailureSimulation(c *fiber.Ctx) error {
 	logger.Info("Resetting failure simulation state")
 
 	// Reset simulation state
+	before := api.service.GetFailureSimulationStats()
 	api.service.ResetFailureSimulation()
 
+	api.recordAdminAction(c, service.AdminAction{
+		Actor:        adminActor(c),
+		Action:       service.AdminActionResetFailureSimulation,
+		ResourceType: "failure_simulation",
+		Before:       before,
+		After:        api.service.GetFailureSimulationStats(),
+	})
+
 	return c.JSON(fiber.Map{
 		"status":  "success",
 		"message": "Failure simulation state reset successfully",
 	})
 }
 
+// EnableFailureSimulation switches the failure simulation back on
+func (api *Api) EnableFailureSimulation(c *fiber.Ctx) error {
+	return api.setFailureSimulationEnabled(c, true)
+}
+
+// DisableFailureSimulation switches the failure simulation off; rules and counters are kept
+func (api *Api) DisableFailureSimulation(c *fiber.Ctx) error {
+	return api.setFailureSimulationEnabled(c, false)
+}
+
+func (api *Api) setFailureSimulationEnabled(c *fiber.Ctx, enabled bool) error {
+	const op = "api.Api.setFailureSimulationEnabled"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"enabled": enabled,
+	})
+
+	logger.Info("Switching failure simulation")
+
+	before := api.service.GetFailureSimulationStats()
+	api.service.SetFailureSimulationEnabled(enabled)
+	after := api.service.GetFailureSimulationStats()
+
+	api.recordAdminAction(c, service.AdminAction{
+		Actor:        adminActor(c),
+		Action:       service.AdminActionSetFailureSimulationEnabled,
+		ResourceType: "failure_simulation",
+		Before:       before,
+		After:        after,
+	})
+
+	return c.JSON(fiber.Map{
+		"status":             "success",
+		"message":            fmt.Sprintf("Failure simulation enabled=%t", enabled),
+		"failure_simulation": after,
+	})
+}
+
+// EnableFailureRule switches a single failure rule on until the next reset
+func (api *Api) EnableFailureRule(c *fiber.Ctx) error {
+	return api.setFailureRuleEnabled(c, true)
+}
+
+// DisableFailureRule switches a single failure rule off until the next reset
+func (api *Api) DisableFailureRule(c *fiber.Ctx) error {
+	return api.setFailureRuleEnabled(c, false)
+}
+
+func (api *Api) setFailureRuleEnabled(c *fiber.Ctx, enabled bool) error {
+	const op = "api.Api.setFailureRuleEnabled"
+
+	name := c.Params("name")
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"rule":    name,
+		"enabled": enabled,
+	})
+
+	logger.Info("Switching failure rule")
+
+	before := api.service.GetLearningScenarios()
+	if err := api.service.SetFailureRuleEnabled(name, enabled); err != nil {
+		if errors.Is(err, service.ErrFailureRuleNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to switch failure rule")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to switch failure rule")
+	}
+	after := api.service.GetLearningScenarios()
+
+	api.recordAdminAction(c, service.AdminAction{
+		Actor:        adminActor(c),
+		Action:       service.AdminActionSetFailureRuleEnabled,
+		ResourceType: "failure_rule",
+		ResourceID:   name,
+		Before:       before,
+		After:        after,
+	})
+
+	return c.JSON(fiber.Map{
+		"status":    "success",
+		"message":   fmt.Sprintf("Failure rule %s enabled=%t", name, enabled),
+		"scenarios": after,
+	})
+}
+
 // GetLearningScenarios returns available failure scenarios for learning
 func (api *Api) GetLearningScenarios(c *fiber.Ctx) error {
 	const op = "api.Api.GetLearningScenarios"
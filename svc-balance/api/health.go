@@ -7,3 +7,37 @@ import (
 func (api *Api) Health(c *fiber.Ctx) error {
 	return c.SendString("ok")
 }
+
+// Healthz reports the schema version next to the service status. Pending migrations mean the code expects a schema
+// the database does not have yet, so the service answers 503 until they are applied.
+func (api *Api) Healthz(c *fiber.Ctx) error {
+	// The in-memory store has no schema to migrate
+	if api.migrator == nil {
+		return c.JSON(fiber.Map{
+			"status": "ok",
+			"store":  "memory",
+		})
+	}
+
+	status, err := api.migrator.Status(c.Context())
+	if err != nil {
+		api.logger.WithError(err).Error("Failed to read the schema version")
+
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"status": "unavailable",
+			"error":  "failed to read the schema version",
+		})
+	}
+
+	if len(status.Pending) > 0 {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"status": "migrations_pending",
+			"schema": status,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "ok",
+		"schema": status,
+	})
+}
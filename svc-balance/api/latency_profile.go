@@ -0,0 +1,67 @@
+package api
+
+import (
+	"errors"
+
+	"svc-balance/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// GetLatencyProfile returns the active simulated latency profile and the available ones
+func (api *Api) GetLatencyProfile(c *fiber.Ctx) error {
+	const op = "api.Api.GetLatencyProfile"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	logger.Info("Getting latency profile")
+
+	return c.JSON(fiber.Map{
+		"status":          "success",
+		"message":         "Latency profile retrieved successfully",
+		"latency_profile": api.service.GetLatencyProfile(),
+	})
+}
+
+// SetLatencyProfile switches the simulated latency of this service's activities
+func (api *Api) SetLatencyProfile(c *fiber.Ctx) error {
+	const op = "api.Api.SetLatencyProfile"
+
+	name := c.Params("name")
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"profile": name,
+	})
+
+	logger.Info("Switching latency profile")
+
+	before := api.service.GetLatencyProfile()
+	if err := api.service.SetLatencyProfile(name); err != nil {
+		if errors.Is(err, service.ErrUnknownLatencyProfile) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to switch latency profile")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to switch latency profile")
+	}
+	after := api.service.GetLatencyProfile()
+
+	api.recordAdminAction(c, service.AdminAction{
+		Actor:        adminActor(c),
+		Action:       service.AdminActionSetLatencyProfile,
+		ResourceType: "latency_profile",
+		ResourceID:   name,
+		Before:       before["active"],
+		After:        after["active"],
+	})
+
+	return c.JSON(fiber.Map{
+		"status":          "success",
+		"message":         "Latency profile switched to " + name,
+		"latency_profile": after,
+	})
+}
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+
+	"svc-balance/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// SetNotificationPreferenceRequest represents the request body for setting the notification preference of an
+// account; omitted flags default to true
+type SetNotificationPreferenceRequest struct {
+	Email             string `json:"email"`
+	NotifyCompleted   *bool  `json:"notify_completed"`
+	NotifyCompensated *bool  `json:"notify_compensated"`
+}
+
+// AddNotificationSuppressionRequest represents the request body for suppressing an address
+type AddNotificationSuppressionRequest struct {
+	Email  string `json:"email"`
+	Reason string `json:"reason"` // e.g. bounce, complaint or opt_out
+}
+
+// SetNotificationPreference handles PUT /accounts/:id/notification-preferences
+func (api *Api) SetNotificationPreference(c *fiber.Ctx) error {
+	const op = "api.Api.SetNotificationPreference"
+
+	accountID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	var request SetNotificationPreferenceRequest
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": accountID.String(),
+	})
+
+	params := service.SetNotificationPreferenceParams{
+		AccountID:         accountID,
+		Email:             request.Email,
+		NotifyCompleted:   request.NotifyCompleted == nil || *request.NotifyCompleted,
+		NotifyCompensated: request.NotifyCompensated == nil || *request.NotifyCompensated,
+	}
+
+	// Recorded as the before payload of the audit entry; absent for the first preference of an account
+	before, _ := api.service.GetNotificationPreference(c.Context(), accountID)
+
+	preference, err := api.service.SetNotificationPreference(c.Context(), params)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidNotificationPreference):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrAccountNotFound):
+			return fiber.NewError(fiber.StatusNotFound, "Account not found")
+		}
+
+		logger.WithError(err).Error("Failed to set notification preference")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to set notification preference")
+	}
+
+	api.recordAdminAction(c, service.AdminAction{
+		Actor:        adminActor(c),
+		Action:       service.AdminActionSetNotificationPreference,
+		ResourceType: "notification_preference",
+		ResourceID:   accountID.String(),
+		Before:       before,
+		After:        preference,
+	})
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Notification preference saved successfully",
+		"data":    preference,
+	})
+}
+
+// GetNotificationPreference handles GET /accounts/:id/notification-preferences
+func (api *Api) GetNotificationPreference(c *fiber.Ctx) error {
+	const op = "api.Api.GetNotificationPreference"
+
+	accountID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": accountID.String(),
+	})
+
+	preference, err := api.service.GetNotificationPreference(c.Context(), accountID)
+	if err != nil {
+		if errors.Is(err, service.ErrNotificationPreferenceNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to get notification preference")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve notification preference")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Notification preference retrieved successfully",
+		"data":    preference,
+	})
+}
+
+// DeleteNotificationPreference handles DELETE /accounts/:id/notification-preferences
+func (api *Api) DeleteNotificationPreference(c *fiber.Ctx) error {
+	const op = "api.Api.DeleteNotificationPreference"
+
+	accountID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": accountID.String(),
+	})
+
+	// Recorded as the before payload of the audit entry; a missing preference fails the delete below
+	before, _ := api.service.GetNotificationPreference(c.Context(), accountID)
+
+	if err := api.service.DeleteNotificationPreference(c.Context(), accountID); err != nil {
+		if errors.Is(err, service.ErrNotificationPreferenceNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to delete notification preference")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to delete notification preference")
+	}
+
+	api.recordAdminAction(c, service.AdminAction{
+		Actor:        adminActor(c),
+		Action:       service.AdminActionDeleteNotificationPreference,
+		ResourceType: "notification_preference",
+		ResourceID:   accountID.String(),
+		Before:       before,
+	})
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Notification preference deleted successfully",
+	})
+}
+
+// AddNotificationSuppression handles POST /notification-suppressions
+func (api *Api) AddNotificationSuppression(c *fiber.Ctx) error {
+	const op = "api.Api.AddNotificationSuppression"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	var request AddNotificationSuppressionRequest
+	if err := c.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	suppression, err := api.service.AddNotificationSuppression(c.Context(), request.Email, request.Reason)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidNotificationPreference) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to add notification suppression")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to add notification suppression")
+	}
+
+	api.recordAdminAction(c, service.AdminAction{
+		Actor:        adminActor(c),
+		Action:       service.AdminActionAddNotificationSuppression,
+		ResourceType: "notification_suppression",
+		ResourceID:   suppression.Email,
+		After:        suppression,
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Address suppressed successfully",
+		"data":    suppression,
+	})
+}
+
+// ListNotificationSuppressions handles GET /notification-suppressions
+func (api *Api) ListNotificationSuppressions(c *fiber.Ctx) error {
+	const op = "api.Api.ListNotificationSuppressions"
+
+	limit, offset := int32(50), int32(0) // Default page
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.ParseInt(limitParam, 10, 32); err == nil && parsedLimit > 0 && parsedLimit <= 1000 {
+			limit = int32(parsedLimit)
+		}
+	}
+	if offsetParam := c.Query("offset"); offsetParam != "" {
+		if parsedOffset, err := strconv.ParseInt(offsetParam, 10, 32); err == nil && parsedOffset >= 0 {
+			offset = int32(parsedOffset)
+		}
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"limit":  limit,
+		"offset": offset,
+	})
+
+	suppressions, err := api.service.ListNotificationSuppressions(c.Context(), limit, offset)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list notification suppressions")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve notification suppressions")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Notification suppressions retrieved successfully",
+		"data":    suppressions,
+		"count":   len(suppressions),
+	})
+}
+
+// RemoveNotificationSuppression handles DELETE /notification-suppressions/:email
+func (api *Api) RemoveNotificationSuppression(c *fiber.Ctx) error {
+	const op = "api.Api.RemoveNotificationSuppression"
+
+	email, err := url.PathUnescape(c.Params("email"))
+	if err != nil || email == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid email")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	if err := api.service.RemoveNotificationSuppression(c.Context(), email); err != nil {
+		if errors.Is(err, service.ErrNotificationSuppressionNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to remove notification suppression")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to remove notification suppression")
+	}
+
+	api.recordAdminAction(c, service.AdminAction{
+		Actor:        adminActor(c),
+		Action:       service.AdminActionRemoveNotificationSuppression,
+		ResourceType: "notification_suppression",
+		ResourceID:   email,
+	})
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Address removed from the suppression list successfully",
+	})
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"errors"
+
+	"svc-balance/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// GetScalingHints handles GET /admin/scaling. The desired worker count is at data.desired_workers, where a KEDA
+// metrics-api scaler can read it.
+func (api *Api) GetScalingHints(c *fiber.Ctx) error {
+	const op = "api.Api.GetScalingHints"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	hints, err := api.service.GetScalingHints(c.Context())
+	if err != nil {
+		logger.WithError(err).Error("Failed to get scaling hints")
+
+		if errors.Is(err, service.ErrScalingUnavailable) {
+			return fiber.NewError(fiber.StatusServiceUnavailable, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Failed to read the task queue from Temporal")
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  "success",
+		"message": "Scaling hints retrieved successfully",
+		"data":    hints,
+	})
+}
@@ -3,8 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"svc-balance/util/config"
+	"svc-balance/util/notification"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sirupsen/logrus"
@@ -96,3 +98,32 @@ func createTemporalClient(
 
 	return temporalClient, nil
 }
+
+func createEmailSender(
+	logger *logrus.Logger,
+	emailConfig config.Email,
+) (notification.EmailSender, error) {
+	switch emailConfig.Sender {
+	case "", "log":
+		logger.Info("Email sender is log, transfer notifications are logged instead of sent")
+
+		return notification.NewLogSender(logger), nil
+	case "smtp":
+		sender, err := notification.NewSMTPSender(logger, notification.SMTPOptions{
+			Host:     emailConfig.SMTP.Host,
+			Port:     emailConfig.SMTP.Port,
+			Username: emailConfig.SMTP.Username,
+			Password: emailConfig.SMTP.Password,
+			From:     emailConfig.From,
+		}, time.Duration(emailConfig.TimeoutSeconds)*time.Second)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create smtp sender: %w", err)
+		}
+
+		logger.WithField("smtp_host", emailConfig.SMTP.Host).Info("SMTP email sender created successfully")
+
+		return sender, nil
+	default:
+		return nil, fmt.Errorf("unsupported email sender: %s", emailConfig.Sender)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"time"
+
+	"svc-balance/util/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// grpcServerOptions converts the connection settings of the gRPC server to server options. Zero values keep the
+// gRPC defaults, so a server without settings behaves as before.
+func grpcServerOptions(config config.Grpc) []grpc.ServerOption {
+	var opts []grpc.ServerOption
+
+	seconds := func(value int) time.Duration { return time.Duration(value) * time.Second }
+
+	params := keepalive.ServerParameters{
+		Time:                  seconds(config.Keepalive.TimeSeconds),
+		Timeout:               seconds(config.Keepalive.TimeoutSeconds),
+		MaxConnectionIdle:     seconds(config.Keepalive.MaxConnectionIdleSeconds),
+		MaxConnectionAge:      seconds(config.Keepalive.MaxConnectionAgeSeconds),
+		MaxConnectionAgeGrace: seconds(config.Keepalive.MaxConnectionAgeGraceSeconds),
+	}
+	if params != (keepalive.ServerParameters{}) {
+		opts = append(opts, grpc.KeepaliveParams(params))
+	}
+
+	if config.Keepalive.MinTimeSeconds > 0 || config.Keepalive.PermitWithoutStream {
+		policy := keepalive.EnforcementPolicy{
+			MinTime:             seconds(config.Keepalive.MinTimeSeconds),
+			PermitWithoutStream: config.Keepalive.PermitWithoutStream,
+		}
+		if policy.MinTime == 0 {
+			policy.MinTime = 5 * time.Minute
+		}
+
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(policy))
+	}
+
+	if config.MaxRecvMsgBytes > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(config.MaxRecvMsgBytes))
+	}
+	if config.MaxSendMsgBytes > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(config.MaxSendMsgBytes))
+	}
+	if config.ConnectionTimeoutSeconds > 0 {
+		opts = append(opts, grpc.ConnectionTimeout(seconds(config.ConnectionTimeoutSeconds)))
+	}
+
+	return opts
+}
@@ -12,8 +12,9 @@ func main() {
 	flag.Parse()
 
 	cmds := map[string]func(){
-		"help":  help,
-		"start": start,
+		"help":    help,
+		"start":   start,
+		"migrate": migrate,
 	}
 
 	if cmdFunc, ok := cmds[flag.Arg(0)]; ok {
@@ -35,6 +36,7 @@ func help() {
 			fmt.Sprintf(divider, strings.Repeat("-", 30), strings.Repeat("-", 50)) +
 			fmt.Sprintf(row, "help", "show this help message") +
 			fmt.Sprintf(row, "start", "start the server") +
+			fmt.Sprintf(row, "migrate", "apply pending schema migrations") +
 			fmt.Sprintf(divider, strings.Repeat("_", 30), strings.Repeat("_", 50))
 
 	fmt.Fprintln(os.Stderr, output)
@@ -5,23 +5,33 @@ import (
 	"fmt"
 	"net/http"
 	"runtime"
+	"strings"
 	"time"
 
+	"svc-balance/service"
+	"svc-balance/util/crash"
+	"svc-balance/util/workerinterceptor"
+
 	"github.com/sirupsen/logrus"
 )
 
+// scalingHintsTimeout bounds the Temporal call made for the scaling metrics on every scrape
+const scalingHintsTimeout = 2 * time.Second
+
 // MetricsServer provides a dedicated HTTP server for Prometheus metrics collection
 type MetricsServer struct {
-	logger *logrus.Logger
-	server *http.Server
-	port   int
+	logger  *logrus.Logger
+	server  *http.Server
+	port    int
+	service *service.Service
 }
 
 // NewMetricsServer creates a new metrics server instance
-func NewMetricsServer(logger *logrus.Logger, port int) *MetricsServer {
+func NewMetricsServer(logger *logrus.Logger, port int, service *service.Service) *MetricsServer {
 	return &MetricsServer{
-		logger: logger,
-		port:   port,
+		logger:  logger,
+		port:    port,
+		service: service,
 	}
 }
 
@@ -131,6 +141,10 @@ svc_balance_memory_sys_bytes %d
 # TYPE svc_balance_gc_cycles_total counter
 svc_balance_gc_cycles_total %d
 
+# HELP svc_balance_panics_recovered_total Total number of panics recovered by request and activity handlers
+# TYPE svc_balance_panics_recovered_total counter
+svc_balance_panics_recovered_total %d
+
 # HELP svc_balance_last_scrape_timestamp_seconds Timestamp of last metrics scrape
 # TYPE svc_balance_last_scrape_timestamp_seconds gauge
 svc_balance_last_scrape_timestamp_seconds %d
@@ -140,9 +154,13 @@ svc_balance_last_scrape_timestamp_seconds %d
 		memStats.TotalAlloc,
 		memStats.Sys,
 		memStats.NumGC,
+		crash.Total(),
 		time.Now().Unix(),
 	)
 
+	metrics += activityMetrics(workerinterceptor.Read())
+	metrics += ms.scalingMetrics(r.Context())
+
 	if _, err := w.Write([]byte(metrics)); err != nil {
 		logger.WithError(err).Error("Failed to write metrics response")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -152,6 +170,96 @@ svc_balance_last_scrape_timestamp_seconds %d
 	logger.Debug("Metrics served successfully")
 }
 
+// activityMetrics renders the activity execution counts recorded by the worker interceptor in Prometheus format
+func activityMetrics(stats []workerinterceptor.ActivityStats) string {
+	var builder strings.Builder
+
+	builder.WriteString(`
+# HELP svc_balance_activity_executions_total Temporal activity executions by activity type and outcome
+# TYPE svc_balance_activity_executions_total counter
+`)
+	for _, entry := range stats {
+		for _, outcome := range workerinterceptor.Outcomes {
+			fmt.Fprintf(&builder, "svc_balance_activity_executions_total{activity=%q,outcome=%q} %d\n", entry.ActivityType, outcome, entry.Executions[outcome])
+		}
+	}
+
+	builder.WriteString(`
+# HELP svc_balance_activity_duration_seconds Time spent executing Temporal activities
+# TYPE svc_balance_activity_duration_seconds summary
+`)
+	for _, entry := range stats {
+		fmt.Fprintf(&builder, "svc_balance_activity_duration_seconds_sum{activity=%q} %g\n", entry.ActivityType, entry.Duration.Seconds())
+		fmt.Fprintf(&builder, "svc_balance_activity_duration_seconds_count{activity=%q} %d\n", entry.ActivityType, entry.Count())
+	}
+
+	builder.WriteString(`
+# HELP svc_balance_activity_schedule_to_start_seconds Time first activity attempts waited on the task queue for a worker
+# TYPE svc_balance_activity_schedule_to_start_seconds summary
+`)
+	for _, entry := range stats {
+		fmt.Fprintf(&builder, "svc_balance_activity_schedule_to_start_seconds_sum{activity=%q} %g\n", entry.ActivityType, entry.ScheduleToStart.Seconds())
+		fmt.Fprintf(&builder, "svc_balance_activity_schedule_to_start_seconds_count{activity=%q} %d\n", entry.ActivityType, entry.ScheduleToStartCount)
+	}
+
+	return builder.String()
+}
+
+// scalingMetrics renders the load of the task queue of this service and the worker count it calls for, the
+// metrics an HPA or KEDA prometheus scaler targets. They are left out while Temporal cannot be queried.
+func (ms *MetricsServer) scalingMetrics(ctx context.Context) string {
+	ctx, cancel := context.WithTimeout(ctx, scalingHintsTimeout)
+	defer cancel()
+
+	hints, err := ms.service.GetScalingHints(ctx)
+	if err != nil {
+		ms.logger.WithError(err).Debug("Skipping scaling metrics")
+		return ""
+	}
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, `
+# HELP svc_balance_scaling_workers Worker processes polling the task queue
+# TYPE svc_balance_scaling_workers gauge
+svc_balance_scaling_workers{task_queue=%[1]q} %[2]d
+
+# HELP svc_balance_scaling_desired_workers Workers needed to drain the task queue at the configured targets
+# TYPE svc_balance_scaling_desired_workers gauge
+svc_balance_scaling_desired_workers{task_queue=%[1]q} %[3]d
+
+# HELP svc_balance_scaling_activities_in_flight Activities estimated to run across all workers
+# TYPE svc_balance_scaling_activities_in_flight gauge
+svc_balance_scaling_activities_in_flight{task_queue=%[1]q} %[4]g
+`, hints.TaskQueue, hints.Workers, hints.DesiredWorkers, hints.InFlight)
+
+	builder.WriteString(`
+# HELP svc_balance_task_queue_backlog Approximate tasks waiting on the task queue, by task type
+# TYPE svc_balance_task_queue_backlog gauge
+`)
+	for _, load := range hints.Load {
+		fmt.Fprintf(&builder, "svc_balance_task_queue_backlog{task_queue=%q,type=%q} %d\n", hints.TaskQueue, load.Type, load.Backlog)
+	}
+
+	builder.WriteString(`
+# HELP svc_balance_task_queue_backlog_age_seconds Age of the oldest task waiting on the task queue, by task type
+# TYPE svc_balance_task_queue_backlog_age_seconds gauge
+`)
+	for _, load := range hints.Load {
+		fmt.Fprintf(&builder, "svc_balance_task_queue_backlog_age_seconds{task_queue=%q,type=%q} %g\n", hints.TaskQueue, load.Type, load.BacklogAgeSeconds)
+	}
+
+	builder.WriteString(`
+# HELP svc_balance_task_queue_pollers Workers that polled the task queue recently, by task type
+# TYPE svc_balance_task_queue_pollers gauge
+`)
+	for _, load := range hints.Load {
+		fmt.Fprintf(&builder, "svc_balance_task_queue_pollers{task_queue=%q,type=%q} %d\n", hints.TaskQueue, load.Type, load.Pollers)
+	}
+
+	return builder.String()
+}
+
 // handleMetricsHealth provides health check for the metrics server
 func (ms *MetricsServer) handleMetricsHealth(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
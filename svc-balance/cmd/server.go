@@ -3,11 +3,17 @@ package main
 import (
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"svc-balance/api"
+	"svc-balance/ops"
+	"svc-balance/ops/pb"
+	"svc-balance/util/config"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func runRestServer(port int, api *api.Api) {
@@ -35,3 +41,35 @@ func runRestServer(port int, api *api.Api) {
 
 	log.Printf("rest server started successfully 🚀")
 }
+
+func runGrpcServer(port int, grpcConfig config.Grpc, server *ops.Ops) *grpc.Server {
+	// Create new gRPC server
+	grpcServer := grpc.NewServer(grpcServerOptions(grpcConfig)...)
+
+	// Register gRPC services
+	pb.RegisterBalanceOpsServer(grpcServer, server)
+
+	// Register reflection service on gRPC server.
+	reflection.Register(grpcServer)
+
+	// Listen at specified port
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		log.Printf("failed to listen at port: %v!", port)
+
+		os.Exit(1)
+	}
+
+	log.Printf("listening at port: %d", port)
+
+	// Serve the gRPC server
+	go func() {
+		log.Printf("gRPC server started successfully 🚀")
+
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Printf("failed to serve: %v", err)
+		}
+	}()
+
+	return grpcServer
+}
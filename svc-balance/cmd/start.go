@@ -6,15 +6,23 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	"svc-balance/activity"
 	"svc-balance/api"
+	"svc-balance/ops"
 	"svc-balance/service"
 	"svc-balance/store"
+	"svc-balance/store/memory"
+	"svc-balance/store/migrations"
+	"svc-balance/store/sqlite"
 	"svc-balance/util/config"
+	"svc-balance/util/failure"
 	"svc-balance/worker"
 
+	"numeric"
+
 	"github.com/sirupsen/logrus"
 )
 
@@ -42,27 +50,104 @@ func start() {
 		os.Exit(1)
 	}
 
+	// --- Apply profile (APP_PROFILE) ---
+	if !config.Profile.VerboseLogging() {
+		logger.Level = logrus.InfoLevel
+	}
+
 	logger.WithFields(logrus.Fields{
 		"[op]":   op,
 		"config": fmt.Sprintf("%+v", config),
 	}).Infof("Starting '%s' service ...", config.App.Name)
 
-	// --- Init postgres pool ---
-	postgresPool, err := createPostgresPool(logger, config.DB.Postgres)
+	// --- Init store layer (db.driver) ---
+	store, migrator, err := newStore(logger, config.DB, config.App.Name, config.Profile.DemoData())
 	if err != nil {
 		logger.WithFields(logrus.Fields{
 			"[op]":  op,
+			"scope": "newStore",
 			"error": err.Error(),
 		}).Error()
 
 		os.Exit(1)
 	}
 
-	// --- Init store layer ---
-	store := store.NewStore(logger, postgresPool)
+	// The in-memory store has no copy of the tables svc-transaction writes
+	if config.DB.InMemory() {
+		for _, feature := range memory.UnsupportedFeatures {
+			logger.WithFields(logrus.Fields{
+				"[op]":    op,
+				"feature": feature,
+			}).Error("Not available with the in-memory store: requests for it fail")
+		}
+	}
 
 	// --- Init service layer ---
-	balanceService := service.NewService(logger, store)
+	fxPricing, err := newFXPricing(config.FX)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"scope": "newFXPricing",
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
+	rounding, err := newRoundingPolicy(config.Rounding)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"scope": "newRoundingPolicy",
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
+	amountPrecision, err := newAmountPrecision(config.AmountPrecision)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"scope": "newAmountPrecision",
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
+	balanceService := service.NewService(logger, store, fxPricing, rounding, time.Duration(config.BusinessRules.RefreshSeconds)*time.Second)
+	balanceService.SetAmountPrecision(amountPrecision)
+	balanceService.SetScalingPolicy(newScalingPolicy(config.Scaling))
+	if err := balanceService.SetLatencyProfile(config.Latency.Profile); err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"scope": "SetLatencyProfile",
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
+	emailSender, err := createEmailSender(logger, config.Email)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"scope": "createEmailSender",
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+	balanceService.SetEmailSender(emailSender)
+	balanceService.SetRelaxedValidation(config.Profile.RelaxedValidation())
+	balanceService.SetFailureQuota(failure.Quota{
+		MaxFailurePercent: config.FailureSimulation.MaxFailurePercent,
+		MinOperations:     config.FailureSimulation.MinOperations,
+	})
+	if enabled, ok := config.Profile.FailureSimulation(); ok {
+		balanceService.SetFailureSimulationEnabled(enabled)
+	}
 
 	// --- Init activity ---
 	activity := activity.NewActivity(logger, balanceService)
@@ -72,7 +157,7 @@ func start() {
 	defer cancel()
 
 	// --- Init metrics server for Prometheus ---
-	metricsServer := NewMetricsServer(logger, 8080)
+	metricsServer := NewMetricsServer(logger, 8080, balanceService)
 	go func() {
 		if err := metricsServer.Start(ctx); err != nil {
 			logger.WithFields(logrus.Fields{
@@ -83,14 +168,30 @@ func start() {
 		}
 	}()
 
+	// --- Start balance alert evaluator ---
+	if config.Alerts.EvaluationIntervalSeconds > 0 {
+		go balanceService.RunBalanceAlertEvaluator(ctx, time.Duration(config.Alerts.EvaluationIntervalSeconds)*time.Second)
+	}
+
+	// --- Start FX rate recorder ---
+	if config.FX.RateSnapshotIntervalSeconds > 0 {
+		go balanceService.RunFXRateRecorder(ctx, time.Duration(config.FX.RateSnapshotIntervalSeconds)*time.Second)
+	}
+
 	// --- Init api layer ---
-	restApi := api.NewApi(logger, balanceService)
+	restApi := api.NewApi(logger, balanceService, migrator)
 
 	// --- Start REST server in a goroutine ---
 	go func() {
 		runRestServer(config.App.Port, restApi)
 	}()
 
+	// --- Start ops gRPC server ---
+	if config.App.GrpcPort > 0 {
+		grpcServer := runGrpcServer(config.App.GrpcPort, config.Grpc, ops.NewOps(logger, balanceService))
+		defer grpcServer.GracefulStop()
+	}
+
 	// --- Init temporal client and worker in a separate goroutine ---
 	go func() {
 		logger.Info("Attempting to connect to Temporal...")
@@ -116,9 +217,11 @@ func start() {
 			temporalWorker, err := worker.NewWorker(
 				logger,
 				temporalClient,
-				config.Temporal.TaskQueue,
+				taskQueueName(config.Temporal),
 				activity,
 				config.Temporal,
+				balanceService.SimulateFailure,
+				balanceService.InjectLatency,
 			)
 			if err != nil {
 				logger.WithFields(logrus.Fields{
@@ -137,6 +240,9 @@ func start() {
 
 			logger.Info("Temporal worker connected successfully")
 
+			// --- Let the service read the load of its task queue ---
+			balanceService.SetTemporalClient(temporalClient, taskQueueName(config.Temporal))
+
 			// --- Start Temporal worker ---
 			if err := temporalWorker.Run(ctx); err != nil {
 				logger.WithFields(logrus.Fields{
@@ -165,3 +271,134 @@ func start() {
 
 	log.Printf("Balance service stopped gracefully")
 }
+
+// newFXPricing converts the FX config to the pricing of the service, rejecting malformed pairs
+func newFXPricing(cfg config.FX) (service.FXPricing, error) {
+	if cfg.DefaultSpreadBps < 0 || cfg.MarginBps < 0 {
+		return service.FXPricing{}, fmt.Errorf("fx spread and margin cannot be negative")
+	}
+
+	pricing := service.FXPricing{
+		DefaultSpreadBps: cfg.DefaultSpreadBps,
+		MarginBps:        cfg.MarginBps,
+	}
+
+	for _, pair := range cfg.Pairs {
+		base, quote, ok := strings.Cut(strings.ToUpper(pair.Pair), "/")
+		if !ok || base == "" || quote == "" || base == quote {
+			return service.FXPricing{}, fmt.Errorf("invalid fx pair %q, want BASE/QUOTE", pair.Pair)
+		}
+
+		if pair.SpreadBps < 0 {
+			return service.FXPricing{}, fmt.Errorf("fx pair %s: spread cannot be negative", pair.Pair)
+		}
+
+		pricing.Pairs = append(pricing.Pairs, service.FXPair{Base: base, Quote: quote, SpreadBps: pair.SpreadBps})
+	}
+
+	return pricing, nil
+}
+
+// newAmountPrecision returns the precision amounts are checked against, the default one when none is configured
+func newAmountPrecision(cfg config.AmountPrecision) (numeric.Precision, error) {
+	if cfg.Precision == 0 {
+		return numeric.DefaultPrecision, nil
+	}
+
+	precision := numeric.Precision{Precision: cfg.Precision, Scale: cfg.Scale}
+	if err := precision.Validate(); err != nil {
+		return numeric.Precision{}, fmt.Errorf("invalid amount precision: %w", err)
+	}
+
+	return precision, nil
+}
+
+// newRoundingPolicy converts the rounding config to the policy of the service, rejecting unknown modes
+func newRoundingPolicy(cfg config.Rounding) (service.RoundingPolicy, error) {
+	policy := service.RoundingPolicy{Default: service.RoundingHalfUp}
+
+	if cfg.DefaultMode != "" {
+		mode, err := service.ParseRoundingMode(cfg.DefaultMode)
+		if err != nil {
+			return service.RoundingPolicy{}, err
+		}
+		policy.Default = mode
+	}
+
+	for _, rule := range cfg.Rules {
+		mode, err := service.ParseRoundingMode(rule.Mode)
+		if err != nil {
+			return service.RoundingPolicy{}, fmt.Errorf("rounding rule %+v: %w", rule, err)
+		}
+
+		policy.Rules = append(policy.Rules, service.RoundingRule{
+			Tenant:   rule.Tenant,
+			Currency: strings.ToUpper(rule.Currency),
+			Mode:     mode,
+		})
+	}
+
+	return policy, nil
+}
+
+// newScalingPolicy converts the scaling config to the policy the scaling hints are derived with
+func newScalingPolicy(cfg config.Scaling) service.ScalingPolicy {
+	return service.ScalingPolicy{
+		TargetBacklogPerWorker:  cfg.TargetBacklogPerWorker,
+		TargetInFlightPerWorker: cfg.TargetInFlightPerWorker,
+		MaxBacklogAge:           time.Duration(cfg.MaxBacklogAgeSeconds) * time.Second,
+		MinWorkers:              cfg.MinWorkers,
+		MaxWorkers:              cfg.MaxWorkers,
+		Window:                  time.Duration(cfg.WindowSeconds) * time.Second,
+	}
+}
+
+// taskQueueName returns the task queue of this worker fleet: the configured task queue with the fleet suffix appended
+func taskQueueName(cfg config.Temporal) string {
+	if cfg.TaskQueueSuffix == "" {
+		return cfg.TaskQueue
+	}
+
+	return cfg.TaskQueue + "-" + cfg.TaskQueueSuffix
+}
+
+// newStore returns the store selected by db.driver. The Postgres store applies the pending schema migrations first
+// when db.migrations.auto is on; the in-memory and SQLite stores return no migrator.
+func newStore(logger *logrus.Logger, cfg config.DB, appName string, demoData bool) (store.IStore, *migrations.Migrator, error) {
+	switch cfg.Driver {
+	case "", config.DriverPostgres:
+	case config.DriverMemory:
+		logger.Warn("Using the in-memory store: data is not shared with svc-transaction and is lost on restart")
+
+		return memory.NewStore(logger, demoData), nil, nil
+	case config.DriverSQLite:
+		logger.WithField("path", cfg.SQLite.Path).Warn("Using the SQLite store: data is shared with svc-transaction only through the same file")
+
+		sqliteStore, err := sqlite.NewStore(context.Background(), logger, cfg.SQLite.Path, demoData)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return sqliteStore, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown db driver %q, want postgres, memory or sqlite", cfg.Driver)
+	}
+
+	postgresPool, err := createPostgresPool(logger, cfg.Postgres)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	migrator, err := migrations.NewMigrator(logger, postgresPool, appName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+
+	if cfg.Migrations.Auto {
+		if _, err := migrator.Up(context.Background()); err != nil {
+			return nil, nil, fmt.Errorf("failed to apply migrations: %w", err)
+		}
+	}
+
+	return store.NewStore(logger, postgresPool), migrator, nil
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"svc-balance/util/crash"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// Recover turns a panic in a later handler into a 500 response instead of dropping the connection.
+// The panic is logged with its stack trace and counted in the panic metric.
+func Recover(logger *logrus.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				crash.Report(logger.WithFields(logrus.Fields{
+					"[op]":   "middleware.Recover",
+					"method": c.Method(),
+					"path":   c.Path(),
+				}), r)
+
+				err = c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": "Internal server error",
+				})
+			}
+		}()
+
+		return c.Next()
+	}
+}
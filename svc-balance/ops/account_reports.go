@@ -0,0 +1,119 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-balance/ops/pb"
+	"svc-balance/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (ops *Ops) ListLowBalanceAccounts(ctx context.Context, request *pb.ListLowBalanceAccountsRequest) (*pb.ListLowBalanceAccountsResponse, error) {
+	const op = "ops.Ops.ListLowBalanceAccounts"
+
+	logger := ops.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	threshold, err := decimal.NewFromString(request.GetThreshold())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "threshold must be a decimal number")
+	}
+
+	accounts, err := ops.service.ListLowBalanceAccounts(ctx, service.ListLowBalanceAccountsParams{
+		Threshold: threshold,
+		Limit:     request.GetLimit(),
+		Offset:    request.GetOffset(),
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAccountReport) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, status.Error(codes.Internal, "failed to list low balance accounts")
+	}
+
+	response := &pb.ListLowBalanceAccountsResponse{
+		Accounts: make([]*pb.LowBalanceAccount, 0, len(accounts)),
+	}
+	for _, account := range accounts {
+		response.Accounts = append(response.Accounts, &pb.LowBalanceAccount{
+			Id:            account.ID.String(),
+			AccountNumber: account.AccountNumber,
+			AccountName:   account.AccountName,
+			Balance:       account.Balance.String(),
+			Currency:      account.Currency,
+			Status:        account.Status,
+			CreatedAt:     reportTimestamp(account.CreatedAt),
+			UpdatedAt:     reportTimestamp(account.UpdatedAt),
+		})
+	}
+
+	return response, nil
+}
+
+func (ops *Ops) ListDormantAccounts(ctx context.Context, request *pb.ListDormantAccountsRequest) (*pb.ListDormantAccountsResponse, error) {
+	const op = "ops.Ops.ListDormantAccounts"
+
+	logger := ops.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	accounts, err := ops.service.ListDormantAccounts(ctx, service.ListDormantAccountsParams{
+		InactiveDays: int(request.GetInactiveDays()),
+		Limit:        request.GetLimit(),
+		Offset:       request.GetOffset(),
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAccountReport) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, status.Error(codes.Internal, "failed to list dormant accounts")
+	}
+
+	response := &pb.ListDormantAccountsResponse{
+		Accounts: make([]*pb.DormantAccount, 0, len(accounts)),
+	}
+	for _, account := range accounts {
+		response.Accounts = append(response.Accounts, &pb.DormantAccount{
+			Id:                account.ID.String(),
+			AccountNumber:     account.AccountNumber,
+			AccountName:       account.AccountName,
+			Balance:           account.Balance.String(),
+			Currency:          account.Currency,
+			CreatedAt:         reportTimestamp(account.CreatedAt),
+			LastTransactionAt: reportTimestamp(account.LastTransactionAt),
+		})
+	}
+
+	return response, nil
+}
+
+// reportTimestamp converts an RFC 3339 time of an account report; empty or unparsable times are left unset
+func reportTimestamp(value string) *timestamppb.Timestamp {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+
+	return timestamppb.New(parsed)
+}
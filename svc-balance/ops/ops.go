@@ -0,0 +1,31 @@
+package ops
+
+import (
+	"svc-balance/ops/pb"
+	"svc-balance/service"
+
+	"github.com/sirupsen/logrus"
+)
+
+// anonymousActor is recorded in the admin audit when a request does not name its actor
+const anonymousActor = "anonymous"
+
+// Ops serves the BalanceOps gRPC service used by the api-gateway admin API
+type Ops struct {
+	pb.UnimplementedBalanceOpsServer
+
+	logger *logrus.Logger
+
+	service *service.Service
+}
+
+func NewOps(
+	logger *logrus.Logger,
+	service *service.Service,
+) *Ops {
+	return &Ops{
+		logger: logger,
+
+		service: service,
+	}
+}
@@ -0,0 +1,827 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: balance_ops.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Failure simulation stats request message
+type GetFailureSimulationStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFailureSimulationStatsRequest) Reset() {
+	*x = GetFailureSimulationStatsRequest{}
+	mi := &file_balance_ops_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFailureSimulationStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFailureSimulationStatsRequest) ProtoMessage() {}
+
+func (x *GetFailureSimulationStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_ops_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFailureSimulationStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetFailureSimulationStatsRequest) Descriptor() ([]byte, []int) {
+	return file_balance_ops_proto_rawDescGZIP(), []int{0}
+}
+
+// Failure simulation stats response message
+type GetFailureSimulationStatsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Stats         *FailureSimulationStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFailureSimulationStatsResponse) Reset() {
+	*x = GetFailureSimulationStatsResponse{}
+	mi := &file_balance_ops_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFailureSimulationStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFailureSimulationStatsResponse) ProtoMessage() {}
+
+func (x *GetFailureSimulationStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_ops_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFailureSimulationStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetFailureSimulationStatsResponse) Descriptor() ([]byte, []int) {
+	return file_balance_ops_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetFailureSimulationStatsResponse) GetStats() *FailureSimulationStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// Failure simulation reset request message
+type ResetFailureSimulationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actor         string                 `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"` // Recorded in the admin audit; "anonymous" when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetFailureSimulationRequest) Reset() {
+	*x = ResetFailureSimulationRequest{}
+	mi := &file_balance_ops_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetFailureSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetFailureSimulationRequest) ProtoMessage() {}
+
+func (x *ResetFailureSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_ops_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetFailureSimulationRequest.ProtoReflect.Descriptor instead.
+func (*ResetFailureSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_balance_ops_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResetFailureSimulationRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+// Failure simulation reset response message
+type ResetFailureSimulationResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Before        *FailureSimulationStats `protobuf:"bytes,1,opt,name=before,proto3" json:"before,omitempty"`
+	After         *FailureSimulationStats `protobuf:"bytes,2,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetFailureSimulationResponse) Reset() {
+	*x = ResetFailureSimulationResponse{}
+	mi := &file_balance_ops_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetFailureSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetFailureSimulationResponse) ProtoMessage() {}
+
+func (x *ResetFailureSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_ops_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetFailureSimulationResponse.ProtoReflect.Descriptor instead.
+func (*ResetFailureSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_balance_ops_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ResetFailureSimulationResponse) GetBefore() *FailureSimulationStats {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *ResetFailureSimulationResponse) GetAfter() *FailureSimulationStats {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+// Counters of the failure simulator
+type FailureSimulationStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UptimeMs      int64                  `protobuf:"varint,1,opt,name=uptime_ms,json=uptimeMs,proto3" json:"uptime_ms,omitempty"`                                                                 // Time since the simulator started or was last reset
+	Occurrences   map[string]int64       `protobuf:"bytes,2,rep,name=occurrences,proto3" json:"occurrences,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Failures fired, by rule name
+	LearningMode  bool                   `protobuf:"varint,3,opt,name=learning_mode,json=learningMode,proto3" json:"learning_mode,omitempty"`
+	TotalRules    int32                  `protobuf:"varint,4,opt,name=total_rules,json=totalRules,proto3" json:"total_rules,omitempty"`
+	EnabledRules  int32                  `protobuf:"varint,5,opt,name=enabled_rules,json=enabledRules,proto3" json:"enabled_rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailureSimulationStats) Reset() {
+	*x = FailureSimulationStats{}
+	mi := &file_balance_ops_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailureSimulationStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailureSimulationStats) ProtoMessage() {}
+
+func (x *FailureSimulationStats) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_ops_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailureSimulationStats.ProtoReflect.Descriptor instead.
+func (*FailureSimulationStats) Descriptor() ([]byte, []int) {
+	return file_balance_ops_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FailureSimulationStats) GetUptimeMs() int64 {
+	if x != nil {
+		return x.UptimeMs
+	}
+	return 0
+}
+
+func (x *FailureSimulationStats) GetOccurrences() map[string]int64 {
+	if x != nil {
+		return x.Occurrences
+	}
+	return nil
+}
+
+func (x *FailureSimulationStats) GetLearningMode() bool {
+	if x != nil {
+		return x.LearningMode
+	}
+	return false
+}
+
+func (x *FailureSimulationStats) GetTotalRules() int32 {
+	if x != nil {
+		return x.TotalRules
+	}
+	return 0
+}
+
+func (x *FailureSimulationStats) GetEnabledRules() int32 {
+	if x != nil {
+		return x.EnabledRules
+	}
+	return 0
+}
+
+// Low balance accounts request message
+type ListLowBalanceAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Threshold     string                 `protobuf:"bytes,1,opt,name=threshold,proto3" json:"threshold,omitempty"` // Exact decimal compared with the balance in the currency of each account, e.g. "100.00"
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`        // 0 for the default page size
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLowBalanceAccountsRequest) Reset() {
+	*x = ListLowBalanceAccountsRequest{}
+	mi := &file_balance_ops_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLowBalanceAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLowBalanceAccountsRequest) ProtoMessage() {}
+
+func (x *ListLowBalanceAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_ops_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLowBalanceAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListLowBalanceAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_balance_ops_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListLowBalanceAccountsRequest) GetThreshold() string {
+	if x != nil {
+		return x.Threshold
+	}
+	return ""
+}
+
+func (x *ListLowBalanceAccountsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListLowBalanceAccountsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// Low balance accounts response message
+type ListLowBalanceAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      []*LowBalanceAccount   `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLowBalanceAccountsResponse) Reset() {
+	*x = ListLowBalanceAccountsResponse{}
+	mi := &file_balance_ops_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLowBalanceAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLowBalanceAccountsResponse) ProtoMessage() {}
+
+func (x *ListLowBalanceAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_ops_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLowBalanceAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListLowBalanceAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_balance_ops_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListLowBalanceAccountsResponse) GetAccounts() []*LowBalanceAccount {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+// An active account below the balance threshold
+type LowBalanceAccount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountNumber string                 `protobuf:"bytes,2,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	AccountName   string                 `protobuf:"bytes,3,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Balance       string                 `protobuf:"bytes,4,opt,name=balance,proto3" json:"balance,omitempty"` // Exact decimal
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LowBalanceAccount) Reset() {
+	*x = LowBalanceAccount{}
+	mi := &file_balance_ops_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LowBalanceAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LowBalanceAccount) ProtoMessage() {}
+
+func (x *LowBalanceAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_ops_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LowBalanceAccount.ProtoReflect.Descriptor instead.
+func (*LowBalanceAccount) Descriptor() ([]byte, []int) {
+	return file_balance_ops_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LowBalanceAccount) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LowBalanceAccount) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *LowBalanceAccount) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *LowBalanceAccount) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *LowBalanceAccount) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *LowBalanceAccount) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *LowBalanceAccount) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *LowBalanceAccount) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Dormant accounts request message
+type ListDormantAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InactiveDays  int32                  `protobuf:"varint,1,opt,name=inactive_days,json=inactiveDays,proto3" json:"inactive_days,omitempty"` // 0 for the default of 90 days
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                   // 0 for the default page size
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDormantAccountsRequest) Reset() {
+	*x = ListDormantAccountsRequest{}
+	mi := &file_balance_ops_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDormantAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDormantAccountsRequest) ProtoMessage() {}
+
+func (x *ListDormantAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_ops_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDormantAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListDormantAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_balance_ops_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListDormantAccountsRequest) GetInactiveDays() int32 {
+	if x != nil {
+		return x.InactiveDays
+	}
+	return 0
+}
+
+func (x *ListDormantAccountsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDormantAccountsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// Dormant accounts response message
+type ListDormantAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      []*DormantAccount      `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDormantAccountsResponse) Reset() {
+	*x = ListDormantAccountsResponse{}
+	mi := &file_balance_ops_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDormantAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDormantAccountsResponse) ProtoMessage() {}
+
+func (x *ListDormantAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_ops_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDormantAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListDormantAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_balance_ops_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListDormantAccountsResponse) GetAccounts() []*DormantAccount {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+// An active account without transactions for the idle period
+type DormantAccount struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountNumber     string                 `protobuf:"bytes,2,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"`
+	AccountName       string                 `protobuf:"bytes,3,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
+	Balance           string                 `protobuf:"bytes,4,opt,name=balance,proto3" json:"balance,omitempty"` // Exact decimal
+	Currency          string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastTransactionAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_transaction_at,json=lastTransactionAt,proto3" json:"last_transaction_at,omitempty"` // Unset for accounts that never transacted
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DormantAccount) Reset() {
+	*x = DormantAccount{}
+	mi := &file_balance_ops_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DormantAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DormantAccount) ProtoMessage() {}
+
+func (x *DormantAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_ops_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DormantAccount.ProtoReflect.Descriptor instead.
+func (*DormantAccount) Descriptor() ([]byte, []int) {
+	return file_balance_ops_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DormantAccount) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DormantAccount) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+func (x *DormantAccount) GetAccountName() string {
+	if x != nil {
+		return x.AccountName
+	}
+	return ""
+}
+
+func (x *DormantAccount) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *DormantAccount) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *DormantAccount) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DormantAccount) GetLastTransactionAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastTransactionAt
+	}
+	return nil
+}
+
+var File_balance_ops_proto protoreflect.FileDescriptor
+
+const file_balance_ops_proto_rawDesc = "" +
+	"\n" +
+	"\x11balance_ops.proto\x12\n" +
+	"balanceops\x1a\x1fgoogle/protobuf/timestamp.proto\"\"\n" +
+	" GetFailureSimulationStatsRequest\"]\n" +
+	"!GetFailureSimulationStatsResponse\x128\n" +
+	"\x05stats\x18\x01 \x01(\v2\".balanceops.FailureSimulationStatsR\x05stats\"5\n" +
+	"\x1dResetFailureSimulationRequest\x12\x14\n" +
+	"\x05actor\x18\x01 \x01(\tR\x05actor\"\x96\x01\n" +
+	"\x1eResetFailureSimulationResponse\x12:\n" +
+	"\x06before\x18\x01 \x01(\v2\".balanceops.FailureSimulationStatsR\x06before\x128\n" +
+	"\x05after\x18\x02 \x01(\v2\".balanceops.FailureSimulationStatsR\x05after\"\xb7\x02\n" +
+	"\x16FailureSimulationStats\x12\x1b\n" +
+	"\tuptime_ms\x18\x01 \x01(\x03R\buptimeMs\x12U\n" +
+	"\voccurrences\x18\x02 \x03(\v23.balanceops.FailureSimulationStats.OccurrencesEntryR\voccurrences\x12#\n" +
+	"\rlearning_mode\x18\x03 \x01(\bR\flearningMode\x12\x1f\n" +
+	"\vtotal_rules\x18\x04 \x01(\x05R\n" +
+	"totalRules\x12#\n" +
+	"\renabled_rules\x18\x05 \x01(\x05R\fenabledRules\x1a>\n" +
+	"\x10OccurrencesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"k\n" +
+	"\x1dListLowBalanceAccountsRequest\x12\x1c\n" +
+	"\tthreshold\x18\x01 \x01(\tR\tthreshold\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"[\n" +
+	"\x1eListLowBalanceAccountsResponse\x129\n" +
+	"\baccounts\x18\x01 \x03(\v2\x1d.balanceops.LowBalanceAccountR\baccounts\"\xb1\x02\n" +
+	"\x11LowBalanceAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0eaccount_number\x18\x02 \x01(\tR\raccountNumber\x12!\n" +
+	"\faccount_name\x18\x03 \x01(\tR\vaccountName\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\tR\abalance\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"o\n" +
+	"\x1aListDormantAccountsRequest\x12#\n" +
+	"\rinactive_days\x18\x01 \x01(\x05R\finactiveDays\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"U\n" +
+	"\x1bListDormantAccountsResponse\x126\n" +
+	"\baccounts\x18\x01 \x03(\v2\x1a.balanceops.DormantAccountR\baccounts\"\xa7\x02\n" +
+	"\x0eDormantAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x0eaccount_number\x18\x02 \x01(\tR\raccountNumber\x12!\n" +
+	"\faccount_name\x18\x03 \x01(\tR\vaccountName\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\tR\abalance\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12J\n" +
+	"\x13last_transaction_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x11lastTransactionAt2\xd0\x03\n" +
+	"\n" +
+	"BalanceOps\x12x\n" +
+	"\x19GetFailureSimulationStats\x12,.balanceops.GetFailureSimulationStatsRequest\x1a-.balanceops.GetFailureSimulationStatsResponse\x12o\n" +
+	"\x16ResetFailureSimulation\x12).balanceops.ResetFailureSimulationRequest\x1a*.balanceops.ResetFailureSimulationResponse\x12o\n" +
+	"\x16ListLowBalanceAccounts\x12).balanceops.ListLowBalanceAccountsRequest\x1a*.balanceops.ListLowBalanceAccountsResponse\x12f\n" +
+	"\x13ListDormantAccounts\x12&.balanceops.ListDormantAccountsRequest\x1a'.balanceops.ListDormantAccountsResponseB\x06Z\x04./pbb\x06proto3"
+
+var (
+	file_balance_ops_proto_rawDescOnce sync.Once
+	file_balance_ops_proto_rawDescData []byte
+)
+
+func file_balance_ops_proto_rawDescGZIP() []byte {
+	file_balance_ops_proto_rawDescOnce.Do(func() {
+		file_balance_ops_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_balance_ops_proto_rawDesc), len(file_balance_ops_proto_rawDesc)))
+	})
+	return file_balance_ops_proto_rawDescData
+}
+
+var file_balance_ops_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_balance_ops_proto_goTypes = []any{
+	(*GetFailureSimulationStatsRequest)(nil),  // 0: balanceops.GetFailureSimulationStatsRequest
+	(*GetFailureSimulationStatsResponse)(nil), // 1: balanceops.GetFailureSimulationStatsResponse
+	(*ResetFailureSimulationRequest)(nil),     // 2: balanceops.ResetFailureSimulationRequest
+	(*ResetFailureSimulationResponse)(nil),    // 3: balanceops.ResetFailureSimulationResponse
+	(*FailureSimulationStats)(nil),            // 4: balanceops.FailureSimulationStats
+	(*ListLowBalanceAccountsRequest)(nil),     // 5: balanceops.ListLowBalanceAccountsRequest
+	(*ListLowBalanceAccountsResponse)(nil),    // 6: balanceops.ListLowBalanceAccountsResponse
+	(*LowBalanceAccount)(nil),                 // 7: balanceops.LowBalanceAccount
+	(*ListDormantAccountsRequest)(nil),        // 8: balanceops.ListDormantAccountsRequest
+	(*ListDormantAccountsResponse)(nil),       // 9: balanceops.ListDormantAccountsResponse
+	(*DormantAccount)(nil),                    // 10: balanceops.DormantAccount
+	nil,                                       // 11: balanceops.FailureSimulationStats.OccurrencesEntry
+	(*timestamppb.Timestamp)(nil),             // 12: google.protobuf.Timestamp
+}
+var file_balance_ops_proto_depIdxs = []int32{
+	4,  // 0: balanceops.GetFailureSimulationStatsResponse.stats:type_name -> balanceops.FailureSimulationStats
+	4,  // 1: balanceops.ResetFailureSimulationResponse.before:type_name -> balanceops.FailureSimulationStats
+	4,  // 2: balanceops.ResetFailureSimulationResponse.after:type_name -> balanceops.FailureSimulationStats
+	11, // 3: balanceops.FailureSimulationStats.occurrences:type_name -> balanceops.FailureSimulationStats.OccurrencesEntry
+	7,  // 4: balanceops.ListLowBalanceAccountsResponse.accounts:type_name -> balanceops.LowBalanceAccount
+	12, // 5: balanceops.LowBalanceAccount.created_at:type_name -> google.protobuf.Timestamp
+	12, // 6: balanceops.LowBalanceAccount.updated_at:type_name -> google.protobuf.Timestamp
+	10, // 7: balanceops.ListDormantAccountsResponse.accounts:type_name -> balanceops.DormantAccount
+	12, // 8: balanceops.DormantAccount.created_at:type_name -> google.protobuf.Timestamp
+	12, // 9: balanceops.DormantAccount.last_transaction_at:type_name -> google.protobuf.Timestamp
+	0,  // 10: balanceops.BalanceOps.GetFailureSimulationStats:input_type -> balanceops.GetFailureSimulationStatsRequest
+	2,  // 11: balanceops.BalanceOps.ResetFailureSimulation:input_type -> balanceops.ResetFailureSimulationRequest
+	5,  // 12: balanceops.BalanceOps.ListLowBalanceAccounts:input_type -> balanceops.ListLowBalanceAccountsRequest
+	8,  // 13: balanceops.BalanceOps.ListDormantAccounts:input_type -> balanceops.ListDormantAccountsRequest
+	1,  // 14: balanceops.BalanceOps.GetFailureSimulationStats:output_type -> balanceops.GetFailureSimulationStatsResponse
+	3,  // 15: balanceops.BalanceOps.ResetFailureSimulation:output_type -> balanceops.ResetFailureSimulationResponse
+	6,  // 16: balanceops.BalanceOps.ListLowBalanceAccounts:output_type -> balanceops.ListLowBalanceAccountsResponse
+	9,  // 17: balanceops.BalanceOps.ListDormantAccounts:output_type -> balanceops.ListDormantAccountsResponse
+	14, // [14:18] is the sub-list for method output_type
+	10, // [10:14] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_balance_ops_proto_init() }
+func file_balance_ops_proto_init() {
+	if File_balance_ops_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_balance_ops_proto_rawDesc), len(file_balance_ops_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_balance_ops_proto_goTypes,
+		DependencyIndexes: file_balance_ops_proto_depIdxs,
+		MessageInfos:      file_balance_ops_proto_msgTypes,
+	}.Build()
+	File_balance_ops_proto = out.File
+	file_balance_ops_proto_goTypes = nil
+	file_balance_ops_proto_depIdxs = nil
+}
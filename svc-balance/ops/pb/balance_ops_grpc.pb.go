@@ -0,0 +1,247 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: balance_ops.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BalanceOps_GetFailureSimulationStats_FullMethodName = "/balanceops.BalanceOps/GetFailureSimulationStats"
+	BalanceOps_ResetFailureSimulation_FullMethodName    = "/balanceops.BalanceOps/ResetFailureSimulation"
+	BalanceOps_ListLowBalanceAccounts_FullMethodName    = "/balanceops.BalanceOps/ListLowBalanceAccounts"
+	BalanceOps_ListDormantAccounts_FullMethodName       = "/balanceops.BalanceOps/ListDormantAccounts"
+)
+
+// BalanceOpsClient is the client API for BalanceOps service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BalanceOps exposes the operational controls of svc-balance to the api-gateway admin API
+type BalanceOpsClient interface {
+	// GetFailureSimulationStats reports how often each simulated failure fired since the last reset
+	GetFailureSimulationStats(ctx context.Context, in *GetFailureSimulationStatsRequest, opts ...grpc.CallOption) (*GetFailureSimulationStatsResponse, error)
+	// ResetFailureSimulation clears the failure simulation counters and starts a new learning session
+	ResetFailureSimulation(ctx context.Context, in *ResetFailureSimulationRequest, opts ...grpc.CallOption) (*ResetFailureSimulationResponse, error)
+	// ListLowBalanceAccounts pages through the active accounts below a balance threshold, lowest balance first
+	ListLowBalanceAccounts(ctx context.Context, in *ListLowBalanceAccountsRequest, opts ...grpc.CallOption) (*ListLowBalanceAccountsResponse, error)
+	// ListDormantAccounts pages through the active accounts without transactions for a number of days, longest unused first
+	ListDormantAccounts(ctx context.Context, in *ListDormantAccountsRequest, opts ...grpc.CallOption) (*ListDormantAccountsResponse, error)
+}
+
+type balanceOpsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBalanceOpsClient(cc grpc.ClientConnInterface) BalanceOpsClient {
+	return &balanceOpsClient{cc}
+}
+
+func (c *balanceOpsClient) GetFailureSimulationStats(ctx context.Context, in *GetFailureSimulationStatsRequest, opts ...grpc.CallOption) (*GetFailureSimulationStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFailureSimulationStatsResponse)
+	err := c.cc.Invoke(ctx, BalanceOps_GetFailureSimulationStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *balanceOpsClient) ResetFailureSimulation(ctx context.Context, in *ResetFailureSimulationRequest, opts ...grpc.CallOption) (*ResetFailureSimulationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetFailureSimulationResponse)
+	err := c.cc.Invoke(ctx, BalanceOps_ResetFailureSimulation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *balanceOpsClient) ListLowBalanceAccounts(ctx context.Context, in *ListLowBalanceAccountsRequest, opts ...grpc.CallOption) (*ListLowBalanceAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLowBalanceAccountsResponse)
+	err := c.cc.Invoke(ctx, BalanceOps_ListLowBalanceAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *balanceOpsClient) ListDormantAccounts(ctx context.Context, in *ListDormantAccountsRequest, opts ...grpc.CallOption) (*ListDormantAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDormantAccountsResponse)
+	err := c.cc.Invoke(ctx, BalanceOps_ListDormantAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BalanceOpsServer is the server API for BalanceOps service.
+// All implementations must embed UnimplementedBalanceOpsServer
+// for forward compatibility.
+//
+// BalanceOps exposes the operational controls of svc-balance to the api-gateway admin API
+type BalanceOpsServer interface {
+	// GetFailureSimulationStats reports how often each simulated failure fired since the last reset
+	GetFailureSimulationStats(context.Context, *GetFailureSimulationStatsRequest) (*GetFailureSimulationStatsResponse, error)
+	// ResetFailureSimulation clears the failure simulation counters and starts a new learning session
+	ResetFailureSimulation(context.Context, *ResetFailureSimulationRequest) (*ResetFailureSimulationResponse, error)
+	// ListLowBalanceAccounts pages through the active accounts below a balance threshold, lowest balance first
+	ListLowBalanceAccounts(context.Context, *ListLowBalanceAccountsRequest) (*ListLowBalanceAccountsResponse, error)
+	// ListDormantAccounts pages through the active accounts without transactions for a number of days, longest unused first
+	ListDormantAccounts(context.Context, *ListDormantAccountsRequest) (*ListDormantAccountsResponse, error)
+	mustEmbedUnimplementedBalanceOpsServer()
+}
+
+// UnimplementedBalanceOpsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBalanceOpsServer struct{}
+
+func (UnimplementedBalanceOpsServer) GetFailureSimulationStats(context.Context, *GetFailureSimulationStatsRequest) (*GetFailureSimulationStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFailureSimulationStats not implemented")
+}
+func (UnimplementedBalanceOpsServer) ResetFailureSimulation(context.Context, *ResetFailureSimulationRequest) (*ResetFailureSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetFailureSimulation not implemented")
+}
+func (UnimplementedBalanceOpsServer) ListLowBalanceAccounts(context.Context, *ListLowBalanceAccountsRequest) (*ListLowBalanceAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLowBalanceAccounts not implemented")
+}
+func (UnimplementedBalanceOpsServer) ListDormantAccounts(context.Context, *ListDormantAccountsRequest) (*ListDormantAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDormantAccounts not implemented")
+}
+func (UnimplementedBalanceOpsServer) mustEmbedUnimplementedBalanceOpsServer() {}
+func (UnimplementedBalanceOpsServer) testEmbeddedByValue()                    {}
+
+// UnsafeBalanceOpsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BalanceOpsServer will
+// result in compilation errors.
+type UnsafeBalanceOpsServer interface {
+	mustEmbedUnimplementedBalanceOpsServer()
+}
+
+func RegisterBalanceOpsServer(s grpc.ServiceRegistrar, srv BalanceOpsServer) {
+	// If the following call pancis, it indicates UnimplementedBalanceOpsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BalanceOps_ServiceDesc, srv)
+}
+
+func _BalanceOps_GetFailureSimulationStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFailureSimulationStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BalanceOpsServer).GetFailureSimulationStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BalanceOps_GetFailureSimulationStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BalanceOpsServer).GetFailureSimulationStats(ctx, req.(*GetFailureSimulationStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BalanceOps_ResetFailureSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetFailureSimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BalanceOpsServer).ResetFailureSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BalanceOps_ResetFailureSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BalanceOpsServer).ResetFailureSimulation(ctx, req.(*ResetFailureSimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BalanceOps_ListLowBalanceAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLowBalanceAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BalanceOpsServer).ListLowBalanceAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BalanceOps_ListLowBalanceAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BalanceOpsServer).ListLowBalanceAccounts(ctx, req.(*ListLowBalanceAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BalanceOps_ListDormantAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDormantAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BalanceOpsServer).ListDormantAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BalanceOps_ListDormantAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BalanceOpsServer).ListDormantAccounts(ctx, req.(*ListDormantAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BalanceOps_ServiceDesc is the grpc.ServiceDesc for BalanceOps service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BalanceOps_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "balanceops.BalanceOps",
+	HandlerType: (*BalanceOpsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetFailureSimulationStats",
+			Handler:    _BalanceOps_GetFailureSimulationStats_Handler,
+		},
+		{
+			MethodName: "ResetFailureSimulation",
+			Handler:    _BalanceOps_ResetFailureSimulation_Handler,
+		},
+		{
+			MethodName: "ListLowBalanceAccounts",
+			Handler:    _BalanceOps_ListLowBalanceAccounts_Handler,
+		},
+		{
+			MethodName: "ListDormantAccounts",
+			Handler:    _BalanceOps_ListDormantAccounts_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "balance_ops.proto",
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-balance/store/sqlc"
+	"svc-balance/util/alias"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrInvalidAccountAlias is returned for aliases that are neither an international phone number nor an email address
+	ErrInvalidAccountAlias = errors.New("invalid account alias")
+	// ErrAccountAliasTaken is returned when registering an alias that already points to an account
+	ErrAccountAliasTaken = errors.New("account alias already registered")
+	// ErrAliasNotFound is returned when no account is registered under an alias
+	ErrAliasNotFound = errors.New("alias not found")
+)
+
+// AccountAlias is a phone number or email address a transfer can be sent to instead of an account
+type AccountAlias struct {
+	Alias     string    `json:"alias"`
+	AliasType string    `json:"alias_type"` // phone or email
+	AccountID uuid.UUID `json:"account_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ResolvedAccountAlias is the account an alias points to
+type ResolvedAccountAlias struct {
+	Alias         string    `json:"alias"`
+	AliasType     string    `json:"alias_type"`
+	AccountID     uuid.UUID `json:"account_id"`
+	AccountNumber string    `json:"account_number"`
+	AccountName   string    `json:"account_name"`
+	Currency      string    `json:"currency"`
+	Status        string    `json:"status"`
+}
+
+// RegisterAccountAlias points a phone number or email address to an account. An alias points to one account
+// only; moving it to another account takes deleting it first.
+func (service *Service) RegisterAccountAlias(ctx context.Context, accountID uuid.UUID, value string) (*AccountAlias, error) {
+	const op = "service.Service.RegisterAccountAlias"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": accountID.String(),
+	})
+
+	logger.Info()
+
+	normalized, aliasType, err := alias.Normalize(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAccountAlias, err)
+	}
+
+	// Make sure the account exists before pointing an alias to it
+	pgAccountID := pgtype.UUID{Bytes: accountID, Valid: true}
+	if _, err := service.store.GetAccountByID(ctx, pgAccountID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, accountID)
+		}
+
+		err = fmt.Errorf("failed to get account: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	record, err := service.store.CreateAccountAlias(ctx, sqlc.CreateAccountAliasParams{
+		Alias:     normalized,
+		AliasType: string(aliasType),
+		AccountID: pgAccountID,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, fmt.Errorf("%w: %s", ErrAccountAliasTaken, normalized)
+		}
+
+		err = fmt.Errorf("failed to register account alias: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return buildAccountAlias(record), nil
+}
+
+// ResolveAccountAlias returns the account a phone number or email address points to. The value is normalized
+// first, so it may be given in any format alias.Normalize accepts.
+func (service *Service) ResolveAccountAlias(ctx context.Context, value string) (*ResolvedAccountAlias, error) {
+	const op = "service.Service.ResolveAccountAlias"
+
+	normalized, _, err := alias.Normalize(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAccountAlias, err)
+	}
+
+	record, err := service.store.ResolveAccountAlias(ctx, normalized)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrAliasNotFound, normalized)
+		}
+
+		err = fmt.Errorf("failed to resolve account alias: %w", err)
+
+		service.logger.WithField("[op]", op).WithError(err).Error()
+
+		return nil, err
+	}
+
+	return &ResolvedAccountAlias{
+		Alias:         record.Alias,
+		AliasType:     record.AliasType,
+		AccountID:     uuid.UUID(record.AccountID.Bytes),
+		AccountNumber: record.AccountNumber,
+		AccountName:   record.AccountName,
+		Currency:      string(record.Currency),
+		Status:        string(record.Status),
+	}, nil
+}
+
+// ListAccountAliases lists the aliases of an account in the order they were registered
+func (service *Service) ListAccountAliases(ctx context.Context, accountID uuid.UUID) ([]AccountAlias, error) {
+	records, err := service.store.ListAccountAliases(ctx, pgtype.UUID{Bytes: accountID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account aliases: %w", err)
+	}
+
+	results := make([]AccountAlias, 0, len(records))
+	for _, record := range records {
+		results = append(results, *buildAccountAlias(record))
+	}
+
+	return results, nil
+}
+
+// DeleteAccountAlias stops an alias from resolving to its account
+func (service *Service) DeleteAccountAlias(ctx context.Context, value string) error {
+	const op = "service.Service.DeleteAccountAlias"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	logger.Info()
+
+	normalized, _, err := alias.Normalize(value)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidAccountAlias, err)
+	}
+
+	rows, err := service.store.DeleteAccountAlias(ctx, normalized)
+	if err != nil {
+		err = fmt.Errorf("failed to delete account alias: %w", err)
+
+		logger.WithError(err).Error()
+
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("%w: %s", ErrAliasNotFound, normalized)
+	}
+
+	return nil
+}
+
+// buildAccountAlias converts a database record to the service result format
+func buildAccountAlias(record sqlc.CoreAccountAlias) *AccountAlias {
+	return &AccountAlias{
+		Alias:     record.Alias,
+		AliasType: record.AliasType,
+		AccountID: uuid.UUID(record.AccountID.Bytes),
+		CreatedAt: record.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveAccountAlias(t *testing.T) {
+	t.Parallel()
+
+	accountID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+
+	service := createTestService()
+	service.store = &MockStore{
+		resolveAccountAliasFunc: func(ctx context.Context, alias string) (sqlc.ResolveAccountAliasRow, error) {
+			if alias != "+14155550101" {
+				return sqlc.ResolveAccountAliasRow{}, pgx.ErrNoRows
+			}
+			return sqlc.ResolveAccountAliasRow{
+				Alias:         alias,
+				AliasType:     "phone",
+				AccountID:     pgtype.UUID{Bytes: accountID, Valid: true},
+				AccountNumber: "ACC001",
+				AccountName:   "John Doe Primary Account",
+				Currency:      sqlc.CoreCurrencyCodeUSD,
+				Status:        sqlc.CoreAccountStatusActive,
+			}, nil
+		},
+	}
+
+	t.Run("formatted phone number resolves", func(t *testing.T) {
+		t.Parallel()
+
+		resolved, err := service.ResolveAccountAlias(context.Background(), "+1 (415) 555-0101")
+		require.NoError(t, err)
+		assert.Equal(t, accountID, resolved.AccountID)
+		assert.Equal(t, "ACC001", resolved.AccountNumber)
+		assert.Equal(t, "USD", resolved.Currency)
+	})
+
+	t.Run("unknown alias", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := service.ResolveAccountAlias(context.Background(), "jane@example.com")
+		assert.ErrorIs(t, err, ErrAliasNotFound)
+	})
+
+	t.Run("invalid alias", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := service.ResolveAccountAlias(context.Background(), "4155550101")
+		assert.ErrorIs(t, err, ErrInvalidAccountAlias)
+	})
+}
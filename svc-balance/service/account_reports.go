@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultDormantInactiveDays is the idle period used when ListDormantAccountsParams.InactiveDays is zero
+	DefaultDormantInactiveDays = 90
+
+	// MaxDormantInactiveDays caps the idle period of ListDormantAccounts at ten years
+	MaxDormantInactiveDays = 3650
+)
+
+// ErrInvalidAccountReport is returned for out-of-range thresholds, idle periods and pages
+var ErrInvalidAccountReport = errors.New("invalid account report")
+
+// ListLowBalanceAccountsParams selects one page of the active accounts below a balance threshold, lowest first
+type ListLowBalanceAccountsParams struct {
+	Threshold decimal.Decimal `json:"threshold"` // Compared with the balance in the currency of each account
+	Limit     int32           `json:"limit"`
+	Offset    int32           `json:"offset"`
+}
+
+// LowBalanceAccount is an account listed by ListLowBalanceAccounts
+type LowBalanceAccount struct {
+	ID            uuid.UUID       `json:"id"`
+	AccountNumber string          `json:"account_number"`
+	AccountName   string          `json:"account_name"`
+	Balance       decimal.Decimal `json:"balance"`
+	Currency      string          `json:"currency"`
+	Status        string          `json:"status"`
+	CreatedAt     string          `json:"created_at"`
+	UpdatedAt     string          `json:"updated_at"`
+}
+
+// ListDormantAccountsParams selects one page of the active accounts without transactions for InactiveDays
+type ListDormantAccountsParams struct {
+	InactiveDays int   `json:"inactive_days"`
+	Limit        int32 `json:"limit"`
+	Offset       int32 `json:"offset"`
+}
+
+// DormantAccount is an account listed by ListDormantAccounts
+type DormantAccount struct {
+	ID                uuid.UUID       `json:"id"`
+	AccountNumber     string          `json:"account_number"`
+	AccountName       string          `json:"account_name"`
+	Balance           decimal.Decimal `json:"balance"`
+	Currency          string          `json:"currency"`
+	CreatedAt         string          `json:"created_at"`
+	LastTransactionAt string          `json:"last_transaction_at,omitempty"` // Empty for accounts that never transacted
+}
+
+// validateReportPage applies the default page size and checks the page of an account report
+func validateReportPage(limit, offset *int32) error {
+	if *limit == 0 {
+		*limit = DefaultListAccountsLimit
+	}
+
+	if *limit < 1 || *limit > MaxListAccountsLimit {
+		return fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidAccountReport, MaxListAccountsLimit)
+	}
+	if *offset < 0 {
+		return fmt.Errorf("%w: offset must not be negative", ErrInvalidAccountReport)
+	}
+
+	return nil
+}
+
+// ListLowBalanceAccounts returns one page of the active accounts whose balance is below the threshold, lowest first
+func (service *Service) ListLowBalanceAccounts(ctx context.Context, params ListLowBalanceAccountsParams) ([]LowBalanceAccount, error) {
+	const op = "service.Service.ListLowBalanceAccounts"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	if params.Threshold.IsNegative() {
+		return nil, fmt.Errorf("%w: threshold must not be negative", ErrInvalidAccountReport)
+	}
+	if err := validateReportPage(&params.Limit, &params.Offset); err != nil {
+		return nil, err
+	}
+
+	rows, err := service.store.GetAccountsWithLowBalance(ctx, sqlc.GetAccountsWithLowBalanceParams{
+		Balance: service.decimalToPgNumeric(params.Threshold),
+		Limit:   params.Limit,
+		Offset:  params.Offset,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to list low balance accounts: %w", err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	accounts := make([]LowBalanceAccount, 0, len(rows))
+	for _, row := range rows {
+		balance, err := service.pgNumericToDecimal(row.Balance)
+		if err != nil {
+			err = fmt.Errorf("failed to convert balance of account %s: %w", row.AccountNumber, err)
+			logger.WithError(err).Error()
+			return nil, err
+		}
+
+		accounts = append(accounts, LowBalanceAccount{
+			ID:            row.ID.Bytes,
+			AccountNumber: row.AccountNumber,
+			AccountName:   row.AccountName,
+			Balance:       balance,
+			Currency:      string(row.Currency),
+			Status:        string(row.Status),
+			CreatedAt:     row.CreatedAt.Time.Format(time.RFC3339),
+			UpdatedAt:     row.UpdatedAt.Time.Format(time.RFC3339),
+		})
+	}
+
+	return accounts, nil
+}
+
+// ListDormantAccounts returns one page of the active accounts that have not transacted for the idle period, the
+// longest unused first. Accounts opened within the idle period are not dormant yet.
+func (service *Service) ListDormantAccounts(ctx context.Context, params ListDormantAccountsParams) ([]DormantAccount, error) {
+	const op = "service.Service.ListDormantAccounts"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	if params.InactiveDays == 0 {
+		params.InactiveDays = DefaultDormantInactiveDays
+	}
+
+	if params.InactiveDays < 1 || params.InactiveDays > MaxDormantInactiveDays {
+		return nil, fmt.Errorf("%w: inactive_days must be between 1 and %d", ErrInvalidAccountReport, MaxDormantInactiveDays)
+	}
+	if err := validateReportPage(&params.Limit, &params.Offset); err != nil {
+		return nil, err
+	}
+
+	inactiveSince := time.Now().AddDate(0, 0, -params.InactiveDays)
+
+	rows, err := service.store.GetDormantAccounts(ctx, sqlc.GetDormantAccountsParams{
+		InactiveSince: pgtype.Timestamptz{Time: inactiveSince, Valid: true},
+		RowLimit:      params.Limit,
+		RowOffset:     params.Offset,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to list dormant accounts: %w", err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	accounts := make([]DormantAccount, 0, len(rows))
+	for _, row := range rows {
+		balance, err := service.pgNumericToDecimal(row.Balance)
+		if err != nil {
+			err = fmt.Errorf("failed to convert balance of account %s: %w", row.AccountNumber, err)
+			logger.WithError(err).Error()
+			return nil, err
+		}
+
+		account := DormantAccount{
+			ID:            row.ID.Bytes,
+			AccountNumber: row.AccountNumber,
+			AccountName:   row.AccountName,
+			Balance:       balance,
+			Currency:      string(row.Currency),
+			CreatedAt:     row.CreatedAt.Time.Format(time.RFC3339),
+		}
+		if row.LastTransactionAt.Valid {
+			account.LastTransactionAt = row.LastTransactionAt.Time.Format(time.RFC3339)
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+func TestListLowBalanceAccounts(t *testing.T) {
+	t.Parallel()
+
+	var calls []sqlc.GetAccountsWithLowBalanceParams
+
+	service := createTestService()
+	service.store = &MockStore{
+		getAccountsWithLowBalanceFunc: func(ctx context.Context, arg sqlc.GetAccountsWithLowBalanceParams) ([]sqlc.GetAccountsWithLowBalanceRow, error) {
+			calls = append(calls, arg)
+
+			return []sqlc.GetAccountsWithLowBalanceRow{{
+				ID:            pgtype.UUID{Bytes: uuid.New(), Valid: true},
+				AccountNumber: "ACC001",
+				Balance:       createPgNumeric("12.50"),
+				Currency:      "USD",
+				Status:        sqlc.CoreAccountStatusActive,
+			}}, nil
+		},
+	}
+
+	accounts, err := service.ListLowBalanceAccounts(context.Background(), ListLowBalanceAccountsParams{Threshold: decimal.RequireFromString("100"), Offset: 50})
+	if err != nil {
+		t.Fatalf("ListLowBalanceAccounts() error = %v", err)
+	}
+	if len(accounts) != 1 || accounts[0].AccountNumber != "ACC001" || accounts[0].Balance.String() != "12.5" {
+		t.Errorf("ListLowBalanceAccounts() = %+v", accounts)
+	}
+	if threshold, _ := service.pgNumericToDecimal(calls[0].Balance); !threshold.Equal(decimal.NewFromInt(100)) || calls[0].Limit != DefaultListAccountsLimit || calls[0].Offset != 50 {
+		t.Errorf("GetAccountsWithLowBalance() called with %+v, want a threshold of 100, the default limit and offset 50", calls[0])
+	}
+
+	for _, params := range []ListLowBalanceAccountsParams{
+		{Threshold: decimal.NewFromInt(-1)},
+		{Limit: MaxListAccountsLimit + 1},
+		{Offset: -1},
+	} {
+		if _, err := service.ListLowBalanceAccounts(context.Background(), params); !errors.Is(err, ErrInvalidAccountReport) {
+			t.Errorf("ListLowBalanceAccounts(%+v) error = %v, want ErrInvalidAccountReport", params, err)
+		}
+	}
+}
+
+func TestListDormantAccounts(t *testing.T) {
+	t.Parallel()
+
+	lastTransactionAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var calls []sqlc.GetDormantAccountsParams
+
+	service := createTestService()
+	service.store = &MockStore{
+		getDormantAccountsFunc: func(ctx context.Context, arg sqlc.GetDormantAccountsParams) ([]sqlc.GetDormantAccountsRow, error) {
+			calls = append(calls, arg)
+
+			return []sqlc.GetDormantAccountsRow{
+				{AccountNumber: "ACC001", Balance: createPgNumeric("0"), Currency: "USD"},
+				{AccountNumber: "ACC002", Balance: createPgNumeric("10"), Currency: "EUR", LastTransactionAt: pgtype.Timestamptz{Time: lastTransactionAt, Valid: true}},
+			}, nil
+		},
+	}
+
+	accounts, err := service.ListDormantAccounts(context.Background(), ListDormantAccountsParams{})
+	if err != nil {
+		t.Fatalf("ListDormantAccounts() error = %v", err)
+	}
+	if len(accounts) != 2 || accounts[0].LastTransactionAt != "" || accounts[1].LastTransactionAt != "2026-01-02T03:04:05Z" {
+		t.Errorf("ListDormantAccounts() = %+v, want the never used account without a last transaction", accounts)
+	}
+
+	wantSince := time.Now().AddDate(0, 0, -DefaultDormantInactiveDays)
+	if since := calls[0].InactiveSince.Time; since.Sub(wantSince).Abs() > time.Minute || calls[0].RowLimit != DefaultListAccountsLimit {
+		t.Errorf("GetDormantAccounts() called with %+v, want %d days ago and the default limit", calls[0], DefaultDormantInactiveDays)
+	}
+
+	for _, params := range []ListDormantAccountsParams{
+		{InactiveDays: -1},
+		{InactiveDays: MaxDormantInactiveDays + 1},
+		{Limit: -1},
+	} {
+		if _, err := service.ListDormantAccounts(context.Background(), params); !errors.Is(err, ErrInvalidAccountReport) {
+			t.Errorf("ListDormantAccounts(%+v) error = %v, want ErrInvalidAccountReport", params, err)
+		}
+	}
+}
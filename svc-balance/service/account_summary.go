@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+)
+
+// GetAccountSummaryResults is an account with the version and time of its last change, which clients use to tell
+// whether a copy they hold is still current
+type GetAccountSummaryResults struct {
+	AccountSummary
+	UpdatedAt string `json:"updated_at"`
+	Version   int32  `json:"version"` // Incremented on every balance or status change
+}
+
+// GetAccountSummary returns an account by its ID or, when account is not a UUID, by its account number
+func (service *Service) GetAccountSummary(ctx context.Context, account string) (*GetAccountSummaryResults, error) {
+	const op = "service.Service.GetAccountSummary"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"account": account,
+	})
+
+	var record sqlc.CoreAccount
+	var err error
+	if accountID, parseErr := uuid.Parse(account); parseErr == nil {
+		record, err = service.store.GetAccountByID(ctx, pgtype.UUID{Bytes: accountID, Valid: true})
+	} else {
+		record, err = service.store.GetAccountByNumber(ctx, account)
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %s", ErrAccountNotFound, account)
+		} else {
+			err = fmt.Errorf("failed to get account: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	balance, err := service.pgNumericToDecimal(record.Balance)
+	if err != nil {
+		err = fmt.Errorf("failed to convert balance of account %s: %w", record.AccountNumber, err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	results := &GetAccountSummaryResults{
+		AccountSummary: AccountSummary{
+			ID:            record.ID.Bytes,
+			AccountNumber: record.AccountNumber,
+			AccountName:   record.AccountName,
+			Balance:       balance,
+			Currency:      string(record.Currency),
+			Status:        string(record.Status),
+			AccountType:   string(record.AccountType),
+			CreatedAt:     record.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+		},
+		UpdatedAt: record.UpdatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+		Version:   record.Version,
+	}
+
+	return results, nil
+}
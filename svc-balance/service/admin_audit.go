@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+)
+
+// adminAuditService identifies this service in core.admin_audit, which svc-transaction owns and serves
+const adminAuditService = "svc-balance"
+
+// Admin actions recorded by this service
+const (
+	AdminActionCreateBalanceAlert            = "balance_alert.create"
+	AdminActionSetBalanceAlertEnabled        = "balance_alert.set_enabled"
+	AdminActionDeleteBalanceAlert            = "balance_alert.delete"
+	AdminActionCreateBusinessRule            = "business_rule.create"
+	AdminActionUpdateBusinessRule            = "business_rule.update"
+	AdminActionDeleteBusinessRule            = "business_rule.delete"
+	AdminActionResetFailureSimulation        = "failure_simulation.reset"
+	AdminActionSetFailureSimulationEnabled   = "failure_simulation.set_enabled"
+	AdminActionSetFailureRuleEnabled         = "failure_simulation.set_rule_enabled"
+	AdminActionSetLatencyProfile             = "latency_profile.set"
+	AdminActionSetNotificationPreference     = "notification_preference.set"
+	AdminActionDeleteNotificationPreference  = "notification_preference.delete"
+	AdminActionAddNotificationSuppression    = "notification_suppression.add"
+	AdminActionRemoveNotificationSuppression = "notification_suppression.remove"
+	AdminActionRegisterAccountAlias          = "account_alias.register"
+	AdminActionDeleteAccountAlias            = "account_alias.delete"
+)
+
+// AdminAction describes one state-changing admin call. Before and After are stored as JSON; nil stores NULL.
+type AdminAction struct {
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Before       any
+	After        any
+}
+
+// RecordAdminAction stores action in core.admin_audit
+func (service *Service) RecordAdminAction(ctx context.Context, action AdminAction) error {
+	const op = "service.Service.RecordAdminAction"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":          op,
+		"actor":         action.Actor,
+		"action":        action.Action,
+		"resource_type": action.ResourceType,
+		"resource_id":   action.ResourceID,
+	})
+
+	if err := createAdminAudit(ctx, service.store, action); err != nil {
+		logger.WithError(err).Error()
+		return err
+	}
+
+	logger.Debug("Recorded admin action")
+
+	return nil
+}
+
+// createAdminAudit stores action through q, so changes made in a transaction are recorded in that same transaction
+// and are rolled back together with their record
+func createAdminAudit(ctx context.Context, q sqlc.Querier, action AdminAction) error {
+	params, err := newCreateAdminAuditParams(action)
+	if err != nil {
+		return err
+	}
+
+	if _, err := q.CreateAdminAudit(ctx, params); err != nil {
+		return fmt.Errorf("failed to record admin action: %w", err)
+	}
+
+	return nil
+}
+
+func newCreateAdminAuditParams(action AdminAction) (sqlc.CreateAdminAuditParams, error) {
+	before, err := adminAuditPayload(action.Before)
+	if err != nil {
+		return sqlc.CreateAdminAuditParams{}, fmt.Errorf("failed to encode before payload: %w", err)
+	}
+
+	after, err := adminAuditPayload(action.After)
+	if err != nil {
+		return sqlc.CreateAdminAuditParams{}, fmt.Errorf("failed to encode after payload: %w", err)
+	}
+
+	return sqlc.CreateAdminAuditParams{
+		Actor:         action.Actor,
+		Action:        action.Action,
+		Service:       adminAuditService,
+		ResourceType:  action.ResourceType,
+		ResourceID:    pgtype.Text{String: action.ResourceID, Valid: action.ResourceID != ""},
+		BeforePayload: before,
+		AfterPayload:  after,
+	}, nil
+}
+
+// adminAuditPayload encodes payload as JSON, returning nil (stored as NULL) for nil values, including nil pointers
+func adminAuditPayload(payload any) ([]byte, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil || string(encoded) == "null" {
+		return nil, err
+	}
+
+	return encoded, nil
+}
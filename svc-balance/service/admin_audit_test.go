@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestNewCreateAdminAuditParams(t *testing.T) {
+	t.Parallel()
+
+	var deleted *BalanceAlert
+
+	tests := []struct {
+		name       string
+		action     AdminAction
+		wantBefore string
+		wantAfter  string
+	}{
+		{
+			name: "Rule toggle",
+			action: AdminAction{
+				Actor:        "ops@example.com",
+				Action:       AdminActionSetBalanceAlertEnabled,
+				ResourceType: "balance_alert",
+				ResourceID:   "alert-1",
+				Before:       map[string]bool{"enabled": true},
+				After:        map[string]bool{"enabled": false},
+			},
+			wantBefore: `{"enabled":true}`,
+			wantAfter:  `{"enabled":false}`,
+		},
+		{
+			name: "Nil pointer payload is stored as NULL",
+			action: AdminAction{
+				Actor:        "ops@example.com",
+				Action:       AdminActionDeleteBalanceAlert,
+				ResourceType: "balance_alert",
+				ResourceID:   "alert-2",
+				Before:       map[string]bool{"enabled": true},
+				After:        deleted,
+			},
+			wantBefore: `{"enabled":true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params, err := newCreateAdminAuditParams(tt.action)
+			if err != nil {
+				t.Fatalf("newCreateAdminAuditParams() error = %v", err)
+			}
+
+			if params.Service != adminAuditService {
+				t.Errorf("service = %s, want %s", params.Service, adminAuditService)
+			}
+			if !params.ResourceID.Valid || params.ResourceID.String != tt.action.ResourceID {
+				t.Errorf("resource_id = %+v, want %q", params.ResourceID, tt.action.ResourceID)
+			}
+			if got := string(params.BeforePayload); got != tt.wantBefore {
+				t.Errorf("before_payload = %q, want %q", got, tt.wantBefore)
+			}
+			if got := string(params.AfterPayload); got != tt.wantAfter {
+				t.Errorf("after_payload = %q, want %q", got, tt.wantAfter)
+			}
+		})
+	}
+}
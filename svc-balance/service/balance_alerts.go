@@ -0,0 +1,432 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"svc-balance/store/sqlc"
+	"svc-balance/util/notification"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// BalanceAlertEventType is the event type delivered when a balance drops below an alert threshold
+const BalanceAlertEventType = "balance.below_threshold"
+
+// ErrBalanceAlertNotFound is returned when the requested balance alert does not exist
+var ErrBalanceAlertNotFound = errors.New("balance alert not found")
+
+// CreateBalanceAlertParams represents the input parameters for registering a balance alert
+type CreateBalanceAlertParams struct {
+	AccountID  uuid.UUID       `json:"account_id"`
+	Threshold  decimal.Decimal `json:"threshold"`
+	WebhookURL string          `json:"webhook_url"`
+}
+
+// ListBalanceAlertsParams represents the input parameters for listing balance alerts
+type ListBalanceAlertsParams struct {
+	AccountID *uuid.UUID `json:"account_id,omitempty"`
+	Limit     int32      `json:"limit"`
+	Offset    int32      `json:"offset"`
+}
+
+// BalanceAlert represents a registered standing balance alert
+type BalanceAlert struct {
+	ID              uuid.UUID       `json:"id"`
+	AccountID       uuid.UUID       `json:"account_id"`
+	Threshold       decimal.Decimal `json:"threshold"`
+	WebhookURL      string          `json:"webhook_url"`
+	Enabled         bool            `json:"enabled"`
+	Triggered       bool            `json:"triggered"`
+	LastTriggeredAt *time.Time      `json:"last_triggered_at,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// EvaluateBalanceAlertsResults summarizes a single evaluation pass over all enabled alerts
+type EvaluateBalanceAlertsResults struct {
+	Evaluated int `json:"evaluated"`
+	Triggered int `json:"triggered"`
+	Recovered int `json:"recovered"`
+	Failed    int `json:"failed"`
+}
+
+// CreateBalanceAlert registers a new standing balance alert for an account
+func (service *Service) CreateBalanceAlert(ctx context.Context, params CreateBalanceAlertParams) (*BalanceAlert, error) {
+	const op = "service.Service.CreateBalanceAlert"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	if err := service.validateCreateBalanceAlertParams(params); err != nil {
+		err = fmt.Errorf("invalid parameters: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Make sure the account exists before attaching an alert to it
+	accountID := pgtype.UUID{Bytes: params.AccountID, Valid: true}
+	if _, err := service.store.GetAccountByID(ctx, accountID); err != nil {
+		err = fmt.Errorf("failed to get account: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	alert, err := service.store.CreateBalanceAlert(ctx, sqlc.CreateBalanceAlertParams{
+		AccountID:  accountID,
+		Threshold:  service.decimalToPgNumeric(params.Threshold),
+		WebhookUrl: params.WebhookURL,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to create balance alert: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	result, err := service.buildBalanceAlert(alert)
+	if err != nil {
+		err = fmt.Errorf("failed to build result: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", result)).Info()
+
+	return result, nil
+}
+
+// GetBalanceAlert retrieves a single balance alert by ID
+func (service *Service) GetBalanceAlert(ctx context.Context, id uuid.UUID) (*BalanceAlert, error) {
+	const op = "service.Service.GetBalanceAlert"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"alert_id": id.String(),
+	})
+
+	logger.Info()
+
+	alert, err := service.store.GetBalanceAlertByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBalanceAlertNotFound
+		}
+
+		err = fmt.Errorf("failed to get balance alert: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return service.buildBalanceAlert(alert)
+}
+
+// ListBalanceAlerts lists balance alerts, optionally filtered by account
+func (service *Service) ListBalanceAlerts(ctx context.Context, params ListBalanceAlertsParams) ([]BalanceAlert, error) {
+	const op = "service.Service.ListBalanceAlerts"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	var (
+		alerts []sqlc.CoreBalanceAlert
+		err    error
+	)
+
+	if params.AccountID != nil {
+		alerts, err = service.store.ListBalanceAlertsByAccount(ctx, pgtype.UUID{Bytes: *params.AccountID, Valid: true})
+	} else {
+		alerts, err = service.store.ListBalanceAlerts(ctx, sqlc.ListBalanceAlertsParams{
+			Limit:  params.Limit,
+			Offset: params.Offset,
+		})
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to list balance alerts: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := make([]BalanceAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		result, err := service.buildBalanceAlert(alert)
+		if err != nil {
+			err = fmt.Errorf("failed to build result: %w", err)
+
+			logger.WithError(err).Error()
+
+			return nil, err
+		}
+
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+// SetBalanceAlertEnabled enables or disables a balance alert; disabling also re-arms it
+func (service *Service) SetBalanceAlertEnabled(ctx context.Context, id uuid.UUID, enabled bool) (*BalanceAlert, error) {
+	const op = "service.Service.SetBalanceAlertEnabled"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"alert_id": id.String(),
+		"enabled":  enabled,
+	})
+
+	logger.Info()
+
+	alert, err := service.store.SetBalanceAlertEnabled(ctx, sqlc.SetBalanceAlertEnabledParams{
+		ID:      pgtype.UUID{Bytes: id, Valid: true},
+		Enabled: enabled,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBalanceAlertNotFound
+		}
+
+		err = fmt.Errorf("failed to update balance alert: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return service.buildBalanceAlert(alert)
+}
+
+// DeleteBalanceAlert removes a balance alert
+func (service *Service) DeleteBalanceAlert(ctx context.Context, id uuid.UUID) error {
+	const op = "service.Service.DeleteBalanceAlert"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"alert_id": id.String(),
+	})
+
+	logger.Info()
+
+	rows, err := service.store.DeleteBalanceAlert(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		err = fmt.Errorf("failed to delete balance alert: %w", err)
+
+		logger.WithError(err).Error()
+
+		return err
+	}
+
+	if rows == 0 {
+		return ErrBalanceAlertNotFound
+	}
+
+	return nil
+}
+
+// EvaluateBalanceAlerts checks every enabled alert against the current account balance.
+// An alert fires once when the balance drops below its threshold and is re-armed when
+// the balance recovers, so a balance that stays low does not produce repeated deliveries.
+func (service *Service) EvaluateBalanceAlerts(ctx context.Context) (*EvaluateBalanceAlertsResults, error) {
+	const op = "service.Service.EvaluateBalanceAlerts"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	rows, err := service.store.ListEnabledBalanceAlertsWithBalance(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to list enabled balance alerts: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &EvaluateBalanceAlertsResults{}
+
+	for _, row := range rows {
+		results.Evaluated++
+
+		balance, err := service.pgNumericToDecimal(row.Balance)
+		if err != nil {
+			results.Failed++
+			logger.WithError(err).Warn("Failed to convert account balance")
+			continue
+		}
+
+		threshold, err := service.pgNumericToDecimal(row.Threshold)
+		if err != nil {
+			results.Failed++
+			logger.WithError(err).Warn("Failed to convert alert threshold")
+			continue
+		}
+
+		switch {
+		case shouldTriggerBalanceAlert(balance, threshold, row.Triggered):
+			if err := service.deliverBalanceAlert(ctx, row, balance, threshold); err != nil {
+				results.Failed++
+				logger.WithError(err).WithField("alert_id", uuid.UUID(row.ID.Bytes).String()).Warn("Failed to deliver balance alert")
+				continue
+			}
+
+			if err := service.store.MarkBalanceAlertTriggered(ctx, row.ID); err != nil {
+				results.Failed++
+				logger.WithError(err).Warn("Failed to mark balance alert as triggered")
+				continue
+			}
+
+			results.Triggered++
+
+		case shouldResetBalanceAlert(balance, threshold, row.Triggered):
+			if err := service.store.ResetBalanceAlert(ctx, row.ID); err != nil {
+				results.Failed++
+				logger.WithError(err).Warn("Failed to reset balance alert")
+				continue
+			}
+
+			results.Recovered++
+		}
+	}
+
+	if results.Triggered > 0 || results.Failed > 0 {
+		logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Balance alerts evaluated")
+	}
+
+	return results, nil
+}
+
+// RunBalanceAlertEvaluator evaluates balance alerts on a fixed interval until the context is cancelled
+func (service *Service) RunBalanceAlertEvaluator(ctx context.Context, interval time.Duration) {
+	const op = "service.Service.RunBalanceAlertEvaluator"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"interval": interval.String(),
+	})
+
+	logger.Info("Starting balance alert evaluator")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Balance alert evaluator stopped")
+			return
+		case <-ticker.C:
+			if _, err := service.EvaluateBalanceAlerts(ctx); err != nil {
+				logger.WithError(err).Warn("Balance alert evaluation failed")
+			}
+		}
+	}
+}
+
+// deliverBalanceAlert sends the alert event to the registered webhook
+func (service *Service) deliverBalanceAlert(
+	ctx context.Context,
+	row sqlc.ListEnabledBalanceAlertsWithBalanceRow,
+	balance decimal.Decimal,
+	threshold decimal.Decimal,
+) error {
+	event := notification.Event{
+		Type:       BalanceAlertEventType,
+		OccurredAt: time.Now().UTC(),
+		Data: map[string]any{
+			"alert_id":       uuid.UUID(row.ID.Bytes).String(),
+			"account_id":     uuid.UUID(row.AccountID.Bytes).String(),
+			"account_number": row.AccountNumber,
+			"currency":       string(row.Currency),
+			"balance":        balance.String(),
+			"threshold":      threshold.String(),
+		},
+	}
+
+	return service.notifier.Notify(ctx, row.WebhookUrl, event)
+}
+
+// validateCreateBalanceAlertParams validates the input parameters
+func (service *Service) validateCreateBalanceAlertParams(params CreateBalanceAlertParams) error {
+	if params.AccountID == uuid.Nil {
+		return fmt.Errorf("account_id is required")
+	}
+
+	if params.Threshold.IsNegative() {
+		return fmt.Errorf("threshold cannot be negative")
+	}
+
+	if err := service.checkAmountPrecision("threshold", params.Threshold); err != nil {
+		return err
+	}
+
+	if params.WebhookURL == "" {
+		return fmt.Errorf("webhook_url is required")
+	}
+
+	webhookURL, err := url.Parse(params.WebhookURL)
+	if err != nil || webhookURL.Host == "" || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") {
+		return fmt.Errorf("webhook_url must be an absolute http or https URL")
+	}
+
+	return nil
+}
+
+// buildBalanceAlert converts a database record to the service result format
+func (service *Service) buildBalanceAlert(alert sqlc.CoreBalanceAlert) (*BalanceAlert, error) {
+	threshold, err := service.pgNumericToDecimal(alert.Threshold)
+	if err != nil {
+		return nil, fmt.Errorf("invalid threshold: %w", err)
+	}
+
+	result := &BalanceAlert{
+		ID:         uuid.UUID(alert.ID.Bytes),
+		AccountID:  uuid.UUID(alert.AccountID.Bytes),
+		Threshold:  threshold,
+		WebhookURL: alert.WebhookUrl,
+		Enabled:    alert.Enabled,
+		Triggered:  alert.Triggered,
+		CreatedAt:  alert.CreatedAt.Time,
+		UpdatedAt:  alert.UpdatedAt.Time,
+	}
+
+	if alert.LastTriggeredAt.Valid {
+		result.LastTriggeredAt = &alert.LastTriggeredAt.Time
+	}
+
+	return result, nil
+}
+
+// shouldTriggerBalanceAlert reports whether an armed alert has crossed below its threshold
+func shouldTriggerBalanceAlert(balance, threshold decimal.Decimal, triggered bool) bool {
+	return !triggered && balance.LessThan(threshold)
+}
+
+// shouldResetBalanceAlert reports whether a fired alert should be re-armed after the balance recovered
+func shouldResetBalanceAlert(balance, threshold decimal.Decimal, triggered bool) bool {
+	return triggered && balance.GreaterThanOrEqual(threshold)
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestValidateCreateBalanceAlertParams(t *testing.T) {
+	t.Parallel()
+
+	service := createTestService()
+
+	testUUID := uuid.New()
+
+	tests := []struct {
+		name        string
+		params      CreateBalanceAlertParams
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name: "Valid params",
+			params: CreateBalanceAlertParams{
+				AccountID:  testUUID,
+				Threshold:  decimal.NewFromInt(100),
+				WebhookURL: "https://example.com/hooks/balance",
+			},
+			expectError: false,
+		},
+		{
+			name: "Zero threshold is allowed",
+			params: CreateBalanceAlertParams{
+				AccountID:  testUUID,
+				Threshold:  decimal.Zero,
+				WebhookURL: "http://alerts.local/webhook",
+			},
+			expectError: false,
+		},
+		{
+			name: "Missing account ID",
+			params: CreateBalanceAlertParams{
+				Threshold:  decimal.NewFromInt(100),
+				WebhookURL: "https://example.com/hooks/balance",
+			},
+			expectError: true,
+			errorMsg:    "account_id is required",
+		},
+		{
+			name: "Negative threshold",
+			params: CreateBalanceAlertParams{
+				AccountID:  testUUID,
+				Threshold:  decimal.NewFromInt(-1),
+				WebhookURL: "https://example.com/hooks/balance",
+			},
+			expectError: true,
+			errorMsg:    "threshold cannot be negative",
+		},
+		{
+			name: "Missing webhook URL",
+			params: CreateBalanceAlertParams{
+				AccountID: testUUID,
+				Threshold: decimal.NewFromInt(100),
+			},
+			expectError: true,
+			errorMsg:    "webhook_url is required",
+		},
+		{
+			name: "Relative webhook URL",
+			params: CreateBalanceAlertParams{
+				AccountID:  testUUID,
+				Threshold:  decimal.NewFromInt(100),
+				WebhookURL: "/hooks/balance",
+			},
+			expectError: true,
+			errorMsg:    "webhook_url must be an absolute http or https URL",
+		},
+		{
+			name: "Unsupported webhook scheme",
+			params: CreateBalanceAlertParams{
+				AccountID:  testUUID,
+				Threshold:  decimal.NewFromInt(100),
+				WebhookURL: "ftp://example.com/hooks",
+			},
+			expectError: true,
+			errorMsg:    "webhook_url must be an absolute http or https URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := service.validateCreateBalanceAlertParams(tt.params)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("validateCreateBalanceAlertParams() expected error but got none")
+					return
+				}
+				if tt.errorMsg != "" && err.Error() != tt.errorMsg {
+					t.Errorf("validateCreateBalanceAlertParams() error = %v, want %v", err.Error(), tt.errorMsg)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("validateCreateBalanceAlertParams() error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestBalanceAlertTransitions(t *testing.T) {
+	t.Parallel()
+
+	threshold := decimal.NewFromInt(100)
+
+	tests := []struct {
+		name          string
+		balance       decimal.Decimal
+		triggered     bool
+		expectTrigger bool
+		expectReset   bool
+	}{
+		{
+			name:          "Armed alert with balance below threshold fires",
+			balance:       decimal.NewFromFloat(99.99),
+			triggered:     false,
+			expectTrigger: true,
+		},
+		{
+			name:      "Armed alert with balance at threshold stays quiet",
+			balance:   decimal.NewFromInt(100),
+			triggered: false,
+		},
+		{
+			name:      "Fired alert with balance still below threshold does not fire again",
+			balance:   decimal.NewFromInt(50),
+			triggered: true,
+		},
+		{
+			name:        "Fired alert is re-armed once balance recovers",
+			balance:     decimal.NewFromInt(100),
+			triggered:   true,
+			expectReset: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := shouldTriggerBalanceAlert(tt.balance, threshold, tt.triggered); got != tt.expectTrigger {
+				t.Errorf("shouldTriggerBalanceAlert() = %v, want %v", got, tt.expectTrigger)
+			}
+			if got := shouldResetBalanceAlert(tt.balance, threshold, tt.triggered); got != tt.expectReset {
+				t.Errorf("shouldResetBalanceAlert() = %v, want %v", got, tt.expectReset)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"svc-balance/store/sqlc"
+	"svc-balance/util/pagination"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultBalanceHistoryLimit is the page size used when GetBalanceHistoryParams.Limit is zero
+	DefaultBalanceHistoryLimit = 50
+
+	// MaxBalanceHistoryLimit caps the page size of GetBalanceHistory
+	MaxBalanceHistoryLimit = 500
+)
+
+// ErrAccountNotFound is returned when the requested account does not exist
+var ErrAccountNotFound = errors.New("account not found")
+
+// ErrInvalidBalanceHistoryQuery is returned for unknown operations, malformed cursors and empty date ranges
+var ErrInvalidBalanceHistoryQuery = errors.New("invalid balance history query")
+
+// balanceHistoryOperations lists the operations svc-transaction records in the balance history
+var balanceHistoryOperations = []string{
+	"debit", "credit", "compensate", "freeze", "unfreeze", "adjustment", "transfer_in", "transfer_out",
+}
+
+// GetBalanceHistoryParams represents the input parameters for reading an account's balance history.
+// From is inclusive and To is exclusive; Cursor is the NextCursor of the previous page.
+type GetBalanceHistoryParams struct {
+	AccountID  uuid.UUID  `json:"account_id"`
+	Operations []string   `json:"operations,omitempty"`
+	From       *time.Time `json:"from,omitempty"`
+	To         *time.Time `json:"to,omitempty"`
+	Cursor     string     `json:"cursor,omitempty"`
+	Limit      int32      `json:"limit"`
+}
+
+// BalanceHistoryTotal sums the balance changes of one operation type
+type BalanceHistoryTotal struct {
+	Operation   string          `json:"operation"`
+	Count       int64           `json:"count"`
+	TotalChange decimal.Decimal `json:"total_change"`
+}
+
+// GetBalanceHistoryResults is one page of balance history, newest first.
+// Totals cover every entry matching the filters, not just this page.
+type GetBalanceHistoryResults struct {
+	AccountID  uuid.UUID             `json:"account_id"`
+	Currency   string                `json:"currency"`
+	Entries    []BalanceHistoryItem  `json:"entries"`
+	NextCursor string                `json:"next_cursor,omitempty"`
+	Totals     []BalanceHistoryTotal `json:"totals"`
+}
+
+// GetBalanceHistory returns a page of an account's balance history together with per-operation totals
+func (service *Service) GetBalanceHistory(ctx context.Context, params GetBalanceHistoryParams) (*GetBalanceHistoryResults, error) {
+	const op = "service.Service.GetBalanceHistory"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	if err := validateGetBalanceHistoryParams(params); err != nil {
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	page, err := pagination.NewPage(params.Cursor, params.Limit, DefaultBalanceHistoryLimit, MaxBalanceHistoryLimit)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrInvalidBalanceHistoryQuery, err)
+
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	// Entries are ordered by creation time and ID, so the cursor ID of a page is the UUID of its last entry
+	var cursorID uuid.UUID
+	if page.After != nil {
+		if cursorID, err = uuid.Parse(page.After.ID); err != nil {
+			err = fmt.Errorf("%w: %w", ErrInvalidBalanceHistoryQuery, pagination.ErrInvalidCursor)
+
+			logger.WithError(err).Warn()
+
+			return nil, err
+		}
+	}
+
+	accountID := pgtype.UUID{Bytes: params.AccountID, Valid: true}
+	account, err := service.store.GetAccountByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %s", ErrAccountNotFound, params.AccountID)
+		} else {
+			err = fmt.Errorf("failed to get account: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	createdFrom := optionalTimestamptz(params.From)
+	createdTo := optionalTimestamptz(params.To)
+
+	listParams := sqlc.ListBalanceHistoryParams{
+		AccountID:   accountID,
+		Operations:  params.Operations,
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+		RowLimit:    page.FetchLimit(),
+	}
+	if page.After != nil {
+		listParams.CursorCreatedAt = pgtype.Timestamptz{Time: page.After.Time, Valid: true}
+		listParams.CursorID = pgtype.UUID{Bytes: cursorID, Valid: true}
+	}
+
+	records, err := service.store.ListBalanceHistory(ctx, listParams)
+	if err != nil {
+		err = fmt.Errorf("failed to list balance history: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	totals, err := service.store.GetBalanceHistoryTotals(ctx, sqlc.GetBalanceHistoryTotalsParams{
+		AccountID:   accountID,
+		Operations:  params.Operations,
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to get balance history totals: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	records, nextCursor := pagination.Next(page, records, func(record sqlc.CoreAccountBalanceHistory) pagination.Cursor {
+		return pagination.Cursor{Time: record.CreatedAt.Time, ID: uuid.UUID(record.ID.Bytes).String()}
+	})
+
+	results := &GetBalanceHistoryResults{
+		AccountID:  params.AccountID,
+		Currency:   string(account.Currency),
+		Entries:    make([]BalanceHistoryItem, 0, len(records)),
+		NextCursor: nextCursor,
+		Totals:     make([]BalanceHistoryTotal, 0, len(totals)),
+	}
+
+	for _, record := range records {
+		results.Entries = append(results.Entries, service.buildBalanceHistoryItem(record))
+	}
+
+	for _, total := range totals {
+		totalChange, err := service.pgNumericToDecimal(total.TotalChange)
+		if err != nil {
+			err = fmt.Errorf("failed to convert total change: %w", err)
+
+			logger.WithError(err).Error()
+
+			return nil, err
+		}
+
+		results.Totals = append(results.Totals, BalanceHistoryTotal{
+			Operation:   total.Operation,
+			Count:       total.EntryCount,
+			TotalChange: totalChange,
+		})
+	}
+
+	logger.WithFields(logrus.Fields{
+		"entries":  len(results.Entries),
+		"has_more": results.NextCursor != "",
+		"totals":   len(results.Totals),
+	}).Info()
+
+	return results, nil
+}
+
+func validateGetBalanceHistoryParams(params GetBalanceHistoryParams) error {
+	if params.AccountID == uuid.Nil {
+		return fmt.Errorf("%w: account_id is required", ErrInvalidBalanceHistoryQuery)
+	}
+
+	for _, operation := range params.Operations {
+		if !slices.Contains(balanceHistoryOperations, operation) {
+			return fmt.Errorf("%w: unknown operation %q", ErrInvalidBalanceHistoryQuery, operation)
+		}
+	}
+
+	if params.From != nil && params.To != nil && !params.From.Before(*params.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidBalanceHistoryQuery)
+	}
+
+	return nil
+}
+
+// buildBalanceHistoryItem converts a stored balance history row; unconvertible values are left zero
+func (service *Service) buildBalanceHistoryItem(record sqlc.CoreAccountBalanceHistory) BalanceHistoryItem {
+	item := BalanceHistoryItem{
+		Operation: record.Operation,
+		CreatedAt: record.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	// Convert transaction ID if valid
+	if record.TransactionID.Valid {
+		txID, err := uuid.FromBytes(record.TransactionID.Bytes[:])
+		if err == nil {
+			item.TransactionID = &txID
+		}
+	}
+
+	// Convert balances
+	if oldBalance, err := service.pgNumericToDecimal(record.OldBalance); err == nil {
+		item.OldBalance = oldBalance
+	}
+	if newBalance, err := service.pgNumericToDecimal(record.NewBalance); err == nil {
+		item.NewBalance = newBalance
+	}
+	if balanceChange, err := service.pgNumericToDecimal(record.BalanceChange); err == nil {
+		item.BalanceChange = balanceChange
+	}
+
+	// Convert created by
+	if record.CreatedBy.Valid {
+		item.CreatedBy = record.CreatedBy.String
+	}
+
+	return item
+}
+
+func optionalTimestamptz(value *time.Time) pgtype.Timestamptz {
+	if value == nil {
+		return pgtype.Timestamptz{}
+	}
+
+	return pgtype.Timestamptz{Time: *value, Valid: true}
+}
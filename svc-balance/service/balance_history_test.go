@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestGetBalanceHistoryPagination(t *testing.T) {
+	t.Parallel()
+
+	accountID := uuid.New()
+	start := time.Date(2025, 6, 1, 12, 0, 0, 123456000, time.UTC)
+
+	// Five entries, newest first, one minute apart
+	var records []sqlc.CoreAccountBalanceHistory
+	for i := range 5 {
+		records = append(records, sqlc.CoreAccountBalanceHistory{
+			ID:            pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			AccountID:     pgtype.UUID{Bytes: accountID, Valid: true},
+			OldBalance:    createPgNumeric("100.00"),
+			NewBalance:    createPgNumeric("90.00"),
+			BalanceChange: createPgNumeric("-10.00"),
+			Operation:     "debit",
+			CreatedAt:     pgtype.Timestamptz{Time: start.Add(-time.Duration(i) * time.Minute), Valid: true},
+		})
+	}
+
+	var listCalls []sqlc.ListBalanceHistoryParams
+
+	service := createTestService()
+	service.store = &MockStore{
+		getAccountByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.CoreAccount, error) {
+			return sqlc.CoreAccount{ID: id, Currency: "USD"}, nil
+		},
+		listBalanceHistoryFunc: func(ctx context.Context, arg sqlc.ListBalanceHistoryParams) ([]sqlc.CoreAccountBalanceHistory, error) {
+			listCalls = append(listCalls, arg)
+
+			remaining := records
+			if arg.CursorCreatedAt.Valid {
+				for i, record := range records {
+					if record.ID == arg.CursorID {
+						remaining = records[i+1:]
+					}
+				}
+			}
+
+			return remaining[:min(len(remaining), int(arg.RowLimit))], nil
+		},
+		getBalanceHistoryTotalsFunc: func(ctx context.Context, arg sqlc.GetBalanceHistoryTotalsParams) ([]sqlc.GetBalanceHistoryTotalsRow, error) {
+			return []sqlc.GetBalanceHistoryTotalsRow{{Operation: "debit", EntryCount: 5, TotalChange: createPgNumeric("-50.00")}}, nil
+		},
+	}
+
+	first, err := service.GetBalanceHistory(context.Background(), GetBalanceHistoryParams{AccountID: accountID, Limit: 3})
+	if err != nil {
+		t.Fatalf("first page: unexpected error: %v", err)
+	}
+	if len(first.Entries) != 3 {
+		t.Fatalf("first page: got %d entries, want 3", len(first.Entries))
+	}
+	if first.NextCursor == "" {
+		t.Fatal("first page: expected a next cursor")
+	}
+	if listCalls[0].RowLimit != 4 {
+		t.Errorf("first page: row limit = %d, want 4", listCalls[0].RowLimit)
+	}
+	if len(first.Totals) != 1 || first.Totals[0].Count != 5 || first.Totals[0].TotalChange.String() != "-50" {
+		t.Errorf("first page: unexpected totals %+v", first.Totals)
+	}
+	if first.Currency != "USD" {
+		t.Errorf("first page: currency = %q, want USD", first.Currency)
+	}
+
+	second, err := service.GetBalanceHistory(context.Background(), GetBalanceHistoryParams{AccountID: accountID, Limit: 3, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("second page: unexpected error: %v", err)
+	}
+	if len(second.Entries) != 2 {
+		t.Fatalf("second page: got %d entries, want 2", len(second.Entries))
+	}
+	if second.NextCursor != "" {
+		t.Errorf("second page: unexpected next cursor %q", second.NextCursor)
+	}
+
+	// The cursor keeps the sub-second precision of the last entry of the first page
+	cursorCall := listCalls[1]
+	if cursorCall.CursorID != records[2].ID || !cursorCall.CursorCreatedAt.Time.Equal(records[2].CreatedAt.Time) {
+		t.Errorf("second page: cursor = (%v, %v), want (%v, %v)",
+			cursorCall.CursorCreatedAt.Time, cursorCall.CursorID, records[2].CreatedAt.Time, records[2].ID)
+	}
+}
+
+func TestGetBalanceHistoryInvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	accountID := uuid.New()
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		params GetBalanceHistoryParams
+	}{
+		{"Missing account ID", GetBalanceHistoryParams{}},
+		{"Unknown operation", GetBalanceHistoryParams{AccountID: accountID, Operations: []string{"debit", "refund"}}},
+		{"Malformed cursor", GetBalanceHistoryParams{AccountID: accountID, Cursor: "not-a-cursor"}},
+		{"Inverted range", GetBalanceHistoryParams{AccountID: accountID, From: &now, To: &earlier}},
+		{"Limit too large", GetBalanceHistoryParams{AccountID: accountID, Limit: MaxBalanceHistoryLimit + 1}},
+		{"Negative limit", GetBalanceHistoryParams{AccountID: accountID, Limit: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The query is rejected before the store is used, so no store is needed
+			_, err := createTestService().GetBalanceHistory(context.Background(), tt.params)
+			if !errors.Is(err, ErrInvalidBalanceHistoryQuery) {
+				t.Errorf("expected ErrInvalidBalanceHistoryQuery, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGetBalanceHistoryAccountNotFound(t *testing.T) {
+	t.Parallel()
+
+	service := createTestService()
+	service.store = &MockStore{
+		getAccountByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.CoreAccount, error) {
+			return sqlc.CoreAccount{}, pgx.ErrNoRows
+		},
+	}
+
+	_, err := service.GetBalanceHistory(context.Background(), GetBalanceHistoryParams{AccountID: uuid.New()})
+	if !errors.Is(err, ErrAccountNotFound) {
+		t.Errorf("expected ErrAccountNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,424 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"svc-balance/store/sqlc"
+	"svc-balance/util/rules"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrBusinessRuleNotFound is returned when the requested business rule does not exist
+var ErrBusinessRuleNotFound = errors.New("business rule not found")
+
+// ErrInvalidBusinessRule is returned for rules with an unknown kind, severity or currency, a negative threshold, or
+// the name and currency of an existing rule
+var ErrInvalidBusinessRule = errors.New("invalid business rule")
+
+// CreateBusinessRuleParams represents the input parameters for adding a business rule
+type CreateBusinessRuleParams struct {
+	Name      string          `json:"name"`
+	Kind      string          `json:"kind"`
+	Currency  string          `json:"currency,omitempty"` // Empty for any currency
+	Threshold decimal.Decimal `json:"threshold"`
+	Severity  string          `json:"severity"`
+	Enabled   bool            `json:"enabled"`
+	Actor     string          `json:"-"` // Recorded in core.admin_audit
+}
+
+// UpdateBusinessRuleParams represents the input parameters for changing a business rule; the name, kind and currency
+// of a rule are its identity and cannot change
+type UpdateBusinessRuleParams struct {
+	Threshold decimal.Decimal `json:"threshold"`
+	Severity  string          `json:"severity"`
+	Enabled   bool            `json:"enabled"`
+	Actor     string          `json:"-"` // Recorded in core.admin_audit
+}
+
+// BusinessRule represents a stored business validation rule
+type BusinessRule struct {
+	ID        uuid.UUID       `json:"id"`
+	Name      string          `json:"name"`
+	Kind      string          `json:"kind"`
+	Currency  string          `json:"currency,omitempty"`
+	Threshold decimal.Decimal `json:"threshold"`
+	Severity  string          `json:"severity"`
+	Enabled   bool            `json:"enabled"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// CreateBusinessRule adds a business rule; rules of an existing name and another currency set per-currency
+// thresholds of that rule. The rule and its admin audit record are stored in one transaction.
+func (service *Service) CreateBusinessRule(ctx context.Context, params CreateBusinessRuleParams) (*BusinessRule, error) {
+	const op = "service.Service.CreateBusinessRule"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	if err := service.validateCreateBusinessRuleParams(params); err != nil {
+		return nil, err
+	}
+
+	arg := sqlc.CreateBusinessRuleParams{
+		Name:      params.Name,
+		Kind:      params.Kind,
+		Threshold: service.decimalToPgNumeric(params.Threshold),
+		Severity:  params.Severity,
+		Enabled:   params.Enabled,
+	}
+	if params.Currency != "" {
+		arg.Currency = sqlc.NullCoreCurrencyCode{CoreCurrencyCode: sqlc.CoreCurrencyCode(strings.ToUpper(params.Currency)), Valid: true}
+	}
+
+	var results *BusinessRule
+
+	err := service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		rule, err := q.CreateBusinessRule(ctx, arg)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return fmt.Errorf("%w: rule %s already exists for this currency", ErrInvalidBusinessRule, params.Name)
+			}
+
+			return fmt.Errorf("failed to create business rule: %w", err)
+		}
+
+		results, err = service.buildBusinessRule(rule)
+		if err != nil {
+			return err
+		}
+
+		return createAdminAudit(ctx, q, AdminAction{
+			Actor:        params.Actor,
+			Action:       AdminActionCreateBusinessRule,
+			ResourceType: "business_rule",
+			ResourceID:   results.ID.String(),
+			After:        results,
+		})
+	})
+	if err != nil {
+		if !errors.Is(err, ErrInvalidBusinessRule) {
+			logger.WithError(err).Error()
+		}
+
+		return nil, err
+	}
+
+	service.invalidateBusinessRules()
+
+	return results, nil
+}
+
+// GetBusinessRule retrieves a single business rule by ID
+func (service *Service) GetBusinessRule(ctx context.Context, id uuid.UUID) (*BusinessRule, error) {
+	const op = "service.Service.GetBusinessRule"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"rule_id": id.String(),
+	})
+
+	logger.Info()
+
+	rule, err := service.getBusinessRule(ctx, service.store, id)
+	if err != nil {
+		if !errors.Is(err, ErrBusinessRuleNotFound) {
+			logger.WithError(err).Error()
+		}
+
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// getBusinessRule reads a business rule through q, which is the store or the queries of a transaction
+func (service *Service) getBusinessRule(ctx context.Context, q sqlc.Querier, id uuid.UUID) (*BusinessRule, error) {
+	rule, err := q.GetBusinessRuleByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBusinessRuleNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get business rule: %w", err)
+	}
+
+	return service.buildBusinessRule(rule)
+}
+
+// ListBusinessRules lists every business rule, by name and then currency
+func (service *Service) ListBusinessRules(ctx context.Context) ([]BusinessRule, error) {
+	const op = "service.Service.ListBusinessRules"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	logger.Info()
+
+	stored, err := service.store.ListBusinessRules(ctx)
+	if err != nil {
+		err = fmt.Errorf("failed to list business rules: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := make([]BusinessRule, 0, len(stored))
+	for _, rule := range stored {
+		result, err := service.buildBusinessRule(rule)
+		if err != nil {
+			err = fmt.Errorf("failed to build result: %w", err)
+
+			logger.WithError(err).Error()
+
+			return nil, err
+		}
+
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+// UpdateBusinessRule changes the threshold, severity and enabled flag of a business rule, recording the rule before
+// and after the change in core.admin_audit in the same transaction
+func (service *Service) UpdateBusinessRule(ctx context.Context, id uuid.UUID, params UpdateBusinessRuleParams) (*BusinessRule, error) {
+	const op = "service.Service.UpdateBusinessRule"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"rule_id": id.String(),
+		"params":  fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	if _, err := rules.ParseSeverity(params.Severity); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBusinessRule, err)
+	}
+	if params.Threshold.IsNegative() {
+		return nil, fmt.Errorf("%w: threshold cannot be negative", ErrInvalidBusinessRule)
+	}
+	if err := service.checkAmountPrecision("threshold", params.Threshold); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBusinessRule, err)
+	}
+
+	var results *BusinessRule
+
+	err := service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		before, err := service.getBusinessRule(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		rule, err := q.UpdateBusinessRule(ctx, sqlc.UpdateBusinessRuleParams{
+			ID:        pgtype.UUID{Bytes: id, Valid: true},
+			Threshold: service.decimalToPgNumeric(params.Threshold),
+			Severity:  params.Severity,
+			Enabled:   params.Enabled,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update business rule: %w", err)
+		}
+
+		results, err = service.buildBusinessRule(rule)
+		if err != nil {
+			return err
+		}
+
+		return createAdminAudit(ctx, q, AdminAction{
+			Actor:        params.Actor,
+			Action:       AdminActionUpdateBusinessRule,
+			ResourceType: "business_rule",
+			ResourceID:   id.String(),
+			Before:       before,
+			After:        results,
+		})
+	})
+	if err != nil {
+		if !errors.Is(err, ErrBusinessRuleNotFound) {
+			logger.WithError(err).Error()
+		}
+
+		return nil, err
+	}
+
+	service.invalidateBusinessRules()
+
+	return results, nil
+}
+
+// DeleteBusinessRule removes a business rule on behalf of actor; deleting a currency rule applies the rule of any
+// currency again
+func (service *Service) DeleteBusinessRule(ctx context.Context, id uuid.UUID, actor string) error {
+	const op = "service.Service.DeleteBusinessRule"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"rule_id": id.String(),
+	})
+
+	logger.Info()
+
+	err := service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		before, err := service.getBusinessRule(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		rows, err := q.DeleteBusinessRule(ctx, pgtype.UUID{Bytes: id, Valid: true})
+		if err != nil {
+			return fmt.Errorf("failed to delete business rule: %w", err)
+		}
+		if rows == 0 {
+			return ErrBusinessRuleNotFound
+		}
+
+		return createAdminAudit(ctx, q, AdminAction{
+			Actor:        actor,
+			Action:       AdminActionDeleteBusinessRule,
+			ResourceType: "business_rule",
+			ResourceID:   id.String(),
+			Before:       before,
+		})
+	})
+	if err != nil {
+		if !errors.Is(err, ErrBusinessRuleNotFound) {
+			logger.WithError(err).Error()
+		}
+
+		return err
+	}
+
+	service.invalidateBusinessRules()
+
+	return nil
+}
+
+// SetRelaxedValidation makes the business rules of severity error only warn, so operations failing them are flagged
+// rather than rejected. It is set at startup, before the rules are first loaded.
+func (service *Service) SetRelaxedValidation(relaxed bool) {
+	service.relaxedValidation = relaxed
+}
+
+// loadBusinessRules reads the rules of core.business_rules for the rules engine
+func (service *Service) loadBusinessRules(ctx context.Context) ([]rules.Rule, error) {
+	stored, err := service.store.ListBusinessRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := make([]rules.Rule, 0, len(stored))
+	for _, rule := range stored {
+		converted, err := service.buildBusinessRule(rule)
+		if err != nil {
+			return nil, err
+		}
+
+		loaded = append(loaded, rules.Rule{
+			Name:      converted.Name,
+			Kind:      rules.Kind(converted.Kind),
+			Currency:  converted.Currency,
+			Threshold: converted.Threshold,
+			Severity:  rules.Severity(converted.Severity),
+			Enabled:   converted.Enabled,
+		})
+	}
+
+	if service.relaxedValidation {
+		return rules.Relaxed(loaded), nil
+	}
+
+	return loaded, nil
+}
+
+// businessRuleEngine returns the engine of the current rules. When the rules cannot be read, validations go on with
+// the rules read last rather than failing.
+func (service *Service) businessRuleEngine(ctx context.Context, logger *logrus.Entry) *rules.Engine {
+	if service.businessRules == nil {
+		return rules.NewEngine(rules.Defaults())
+	}
+
+	engine, err := service.businessRules.Engine(ctx)
+	if err != nil {
+		logger.WithError(err).Warn("Validating with the business rules read last")
+	}
+
+	return engine
+}
+
+func (service *Service) invalidateBusinessRules() {
+	if service.businessRules != nil {
+		service.businessRules.Invalidate()
+	}
+}
+
+func (service *Service) validateCreateBusinessRuleParams(params CreateBusinessRuleParams) error {
+	if params.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidBusinessRule)
+	}
+
+	if _, err := rules.ParseKind(params.Kind); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBusinessRule, err)
+	}
+
+	if _, err := rules.ParseSeverity(params.Severity); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBusinessRule, err)
+	}
+
+	if params.Currency != "" {
+		if err := service.validateCurrency(strings.ToUpper(params.Currency)); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidBusinessRule, err)
+		}
+	}
+
+	if params.Threshold.IsNegative() {
+		return fmt.Errorf("%w: threshold cannot be negative", ErrInvalidBusinessRule)
+	}
+	if err := service.checkAmountPrecision("threshold", params.Threshold); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBusinessRule, err)
+	}
+
+	return nil
+}
+
+// buildBusinessRule converts a stored rule to its service representation
+func (service *Service) buildBusinessRule(rule sqlc.CoreBusinessRule) (*BusinessRule, error) {
+	threshold, err := service.pgNumericToDecimal(rule.Threshold)
+	if err != nil {
+		return nil, fmt.Errorf("invalid threshold of business rule %s: %w", rule.Name, err)
+	}
+
+	result := &BusinessRule{
+		ID:        uuid.UUID(rule.ID.Bytes),
+		Name:      rule.Name,
+		Kind:      rule.Kind,
+		Threshold: threshold,
+		Severity:  rule.Severity,
+		Enabled:   rule.Enabled,
+		CreatedAt: rule.CreatedAt.Time,
+		UpdatedAt: rule.UpdatedAt.Time,
+	}
+	if rule.Currency.Valid {
+		result.Currency = string(rule.Currency.CoreCurrencyCode)
+	}
+
+	return result, nil
+}
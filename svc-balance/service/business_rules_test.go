@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"svc-balance/store/sqlc"
+
+	"numeric"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+func TestValidateCreateBusinessRuleParams(t *testing.T) {
+	t.Parallel()
+
+	service := createTestService()
+
+	valid := CreateBusinessRuleParams{
+		Name:      "transaction_limits",
+		Kind:      "max_transaction_amount",
+		Currency:  "jpy",
+		Threshold: decimal.NewFromInt(15000000),
+		Severity:  "error",
+		Enabled:   true,
+	}
+	if err := service.validateCreateBusinessRuleParams(valid); err != nil {
+		t.Errorf("validateCreateBusinessRuleParams() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*CreateBusinessRuleParams)
+	}{
+		{name: "Missing name", modify: func(params *CreateBusinessRuleParams) { params.Name = "" }},
+		{name: "Unknown kind", modify: func(params *CreateBusinessRuleParams) { params.Kind = "max_daily_amount" }},
+		{name: "Unknown severity", modify: func(params *CreateBusinessRuleParams) { params.Severity = "info" }},
+		{name: "Unsupported currency", modify: func(params *CreateBusinessRuleParams) { params.Currency = "XYZ" }},
+		{name: "Negative threshold", modify: func(params *CreateBusinessRuleParams) { params.Threshold = decimal.NewFromInt(-1) }},
+		{name: "Threshold beyond scale", modify: func(params *CreateBusinessRuleParams) { params.Threshold = decimal.RequireFromString("100.00001") }},
+		{name: "Threshold beyond precision", modify: func(params *CreateBusinessRuleParams) { params.Threshold = decimal.New(1, 15) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params := valid
+			tt.modify(&params)
+
+			if err := service.validateCreateBusinessRuleParams(params); !errors.Is(err, ErrInvalidBusinessRule) {
+				t.Errorf("validateCreateBusinessRuleParams() error = %v, want ErrInvalidBusinessRule", err)
+			}
+		})
+	}
+}
+
+func TestCheckAmountPrecision(t *testing.T) {
+	t.Parallel()
+
+	service := createTestService()
+
+	if err := service.checkAmountPrecision("threshold", decimal.RequireFromString("999999999999999.9999")); err != nil {
+		t.Errorf("checkAmountPrecision() error = %v", err)
+	}
+
+	err := service.checkAmountPrecision("threshold", decimal.RequireFromString("0.12345"))
+	if !errors.Is(err, ErrPrecisionExceeded) {
+		t.Errorf("checkAmountPrecision() error = %v, want ErrPrecisionExceeded", err)
+	}
+
+	service.SetAmountPrecision(numeric.Precision{Precision: 10, Scale: 2})
+	err = service.checkAmountPrecision("threshold", decimal.RequireFromString("0.123"))
+	if !errors.Is(err, ErrPrecisionExceeded) {
+		t.Errorf("checkAmountPrecision() with NUMERIC(10,2) error = %v, want ErrPrecisionExceeded", err)
+	}
+}
+
+func TestUpdateBusinessRuleRecordsAudit(t *testing.T) {
+	t.Parallel()
+
+	ruleID := uuid.New()
+	stored := sqlc.CoreBusinessRule{
+		ID:        pgtype.UUID{Bytes: ruleID, Valid: true},
+		Name:      "transaction_limits",
+		Kind:      "max_transaction_amount",
+		Threshold: createPgNumeric("1000"),
+		Severity:  "error",
+		Enabled:   true,
+	}
+
+	var audits []sqlc.CreateAdminAuditParams
+	mockStore := &MockStore{
+		getBusinessRuleByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.CoreBusinessRule, error) {
+			if id.Bytes != ruleID {
+				return sqlc.CoreBusinessRule{}, pgx.ErrNoRows
+			}
+			return stored, nil
+		},
+		updateBusinessRuleFunc: func(ctx context.Context, arg sqlc.UpdateBusinessRuleParams) (sqlc.CoreBusinessRule, error) {
+			updated := stored
+			updated.Threshold = arg.Threshold
+			updated.Severity = arg.Severity
+			updated.Enabled = arg.Enabled
+			return updated, nil
+		},
+		createAdminAuditFunc: func(ctx context.Context, arg sqlc.CreateAdminAuditParams) (sqlc.CoreAdminAudit, error) {
+			audits = append(audits, arg)
+			return sqlc.CoreAdminAudit{}, nil
+		},
+	}
+
+	service := createTestService()
+	service.store = mockStore
+
+	rule, err := service.UpdateBusinessRule(context.Background(), ruleID, UpdateBusinessRuleParams{
+		Threshold: decimal.NewFromInt(500),
+		Severity:  "warning",
+		Enabled:   true,
+		Actor:     "ops@example.com",
+	})
+	if err != nil {
+		t.Fatalf("UpdateBusinessRule() error = %v", err)
+	}
+	if !rule.Threshold.Equal(decimal.NewFromInt(500)) || rule.Severity != "warning" {
+		t.Errorf("UpdateBusinessRule() = %+v, want threshold 500 and severity warning", rule)
+	}
+
+	if len(audits) != 1 {
+		t.Fatalf("recorded %d audits, want 1", len(audits))
+	}
+	audit := audits[0]
+	if audit.Actor != "ops@example.com" || audit.Action != AdminActionUpdateBusinessRule || audit.ResourceID.String != ruleID.String() {
+		t.Errorf("audit = %+v, want the update by ops@example.com of rule %s", audit, ruleID)
+	}
+
+	var before BusinessRule
+	if err := json.Unmarshal(audit.BeforePayload, &before); err != nil || !before.Threshold.Equal(decimal.NewFromInt(1000)) {
+		t.Errorf("audit before payload = %s (%v), want threshold 1000", audit.BeforePayload, err)
+	}
+
+	// The rule is not changed when it cannot be audited
+	mockStore.createAdminAuditFunc = func(ctx context.Context, arg sqlc.CreateAdminAuditParams) (sqlc.CoreAdminAudit, error) {
+		return sqlc.CoreAdminAudit{}, errors.New("connection reset")
+	}
+	if _, err := service.UpdateBusinessRule(context.Background(), ruleID, UpdateBusinessRuleParams{
+		Threshold: decimal.NewFromInt(500),
+		Severity:  "warning",
+	}); err == nil {
+		t.Error("UpdateBusinessRule() error = nil, want the audit failure")
+	}
+
+	if err := service.DeleteBusinessRule(context.Background(), uuid.New(), "ops@example.com"); !errors.Is(err, ErrBusinessRuleNotFound) {
+		t.Errorf("DeleteBusinessRule(unknown) error = %v, want ErrBusinessRuleNotFound", err)
+	}
+}
@@ -2,17 +2,27 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/big"
 
 	"svc-balance/store/sqlc"
 
+	"numeric"
+
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
 )
 
+// Errors for balance checks a transfer cannot go ahead after, see CheckBalanceResults.Failure
+var (
+	ErrAccountBlocked    = errors.New("account blocked")
+	ErrInvalidCurrency   = errors.New("invalid currency")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+)
+
 // CheckBalanceParams represents the input parameters for balance checking
 type CheckBalanceParams struct {
 	// Account identifier - either AccountID or AccountNumber must be provided
@@ -96,7 +106,11 @@ func (service *Service) CheckBalance(ctx context.Context, params CheckBalancePar
 	// Get account information
 	account, err := service.getAccountForBalance(ctx, params)
 	if err != nil {
-		err = fmt.Errorf("failed to get account: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %s", ErrAccountNotFound, accountReference(params))
+		} else {
+			err = fmt.Errorf("failed to get account: %w", err)
+		}
 
 		logger.WithError(err).Error()
 
@@ -198,6 +212,11 @@ func (service *Service) validateCheckBalanceParams(params CheckBalanceParams) er
 	if params.RequiredAmount != nil && params.RequiredAmount.IsNegative() {
 		return fmt.Errorf("required_amount cannot be negative")
 	}
+	if params.RequiredAmount != nil {
+		if err := service.checkAmountPrecision("required_amount", *params.RequiredAmount); err != nil {
+			return err
+		}
+	}
 
 	// Validate ExpectedCurrency if provided
 	if params.ExpectedCurrency != nil {
@@ -211,18 +230,8 @@ func (service *Service) validateCheckBalanceParams(params CheckBalanceParams) er
 
 // getAccountForBalance retrieves account information for balance checking
 func (service *Service) getAccountForBalance(ctx context.Context, params CheckBalanceParams) (sqlc.CheckAccountBalanceRow, error) {
-	var requiredAmount pgtype.Numeric
-
-	// Convert required amount to pgtype.Numeric if provided
-	if params.RequiredAmount != nil {
-		bigFloat, _ := params.RequiredAmount.Float64()
-		requiredAmount = pgtype.Numeric{
-			Int:   big.NewInt(0).SetBytes(params.RequiredAmount.BigInt().Bytes()),
-			Exp:   int32(-params.RequiredAmount.Exponent()),
-			Valid: true,
-		}
-		_ = bigFloat // Use the variable to avoid unused variable error
-	}
+	// A NULL required amount makes the query report sufficient funds
+	requiredAmount := numeric.FromDecimalPtr(params.RequiredAmount)
 
 	if params.AccountID != nil {
 		// Query by Account ID
@@ -249,6 +258,30 @@ func (service *Service) getAccountForBalance(ctx context.Context, params CheckBa
 	}
 }
 
+// accountReference names the account of a balance check in errors
+func accountReference(params CheckBalanceParams) string {
+	if params.AccountID != nil {
+		return params.AccountID.String()
+	}
+
+	return *params.AccountNumber
+}
+
+// Failure returns why the checked account cannot be debited the required amount in currency, wrapping
+// ErrAccountBlocked, ErrInvalidCurrency or ErrInsufficientFunds; nil when it can
+func (results *CheckBalanceResults) Failure(currency string) error {
+	switch {
+	case !results.IsActive:
+		return fmt.Errorf("%w: account %s is %s", ErrAccountBlocked, results.AccountID, results.Status)
+	case results.Currency != currency:
+		return fmt.Errorf("%w: account %s holds %s, not %s", ErrInvalidCurrency, results.AccountID, results.Currency, currency)
+	case !results.SufficientFunds:
+		return fmt.Errorf("%w: account %s has %s %s", ErrInsufficientFunds, results.AccountID, results.CurrentBalance, results.Currency)
+	default:
+		return nil
+	}
+}
+
 // buildCheckBalanceResult converts database result to service result format
 func (service *Service) buildCheckBalanceResult(account sqlc.CheckAccountBalanceRow) (*CheckBalanceResults, error) {
 	// Convert UUID
@@ -297,6 +330,13 @@ func (service *Service) performBalanceValidations(result *CheckBalanceResults, p
 			params.RequiredAmount.String(), result.CurrentBalance.String()))
 	}
 
+	// Check the required amount fits the account currency, e.g. no fractional yen
+	if params.RequiredAmount != nil {
+		if err := service.ValidateAmountScale(*params.RequiredAmount, result.Currency); errors.Is(err, ErrAmountScale) {
+			messages = append(messages, fmt.Sprintf("Invalid required amount: %v", err))
+		}
+	}
+
 	// Check for zero balance warning
 	if result.CurrentBalance.IsZero() {
 		messages = append(messages, "Account has zero balance")
@@ -328,36 +368,7 @@ func (service *Service) getBalanceDetails(ctx context.Context, accountID pgtype.
 	// Convert history records
 	var history []BalanceHistoryItem
 	for _, record := range historyRecords {
-		item := BalanceHistoryItem{
-			Operation: record.Operation,
-			CreatedAt: record.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
-		}
-
-		// Convert transaction ID if valid
-		if record.TransactionID.Valid {
-			txID, err := uuid.FromBytes(record.TransactionID.Bytes[:])
-			if err == nil {
-				item.TransactionID = &txID
-			}
-		}
-
-		// Convert balances
-		if oldBalance, err := service.pgNumericToDecimal(record.OldBalance); err == nil {
-			item.OldBalance = oldBalance
-		}
-		if newBalance, err := service.pgNumericToDecimal(record.NewBalance); err == nil {
-			item.NewBalance = newBalance
-		}
-		if balanceChange, err := service.pgNumericToDecimal(record.BalanceChange); err == nil {
-			item.BalanceChange = balanceChange
-		}
-
-		// Convert created by
-		if record.CreatedBy.Valid {
-			item.CreatedBy = record.CreatedBy.String
-		}
-
-		history = append(history, item)
+		history = append(history, service.buildBalanceHistoryItem(record))
 	}
 
 	// Convert total debits and credits
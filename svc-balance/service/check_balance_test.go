@@ -8,13 +8,17 @@ import (
 
 	"svc-balance/store/sqlc"
 
+	"numeric"
+
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
 
-// MockStore implements the store interface for testing
+// MockStore implements the store interface for testing; queries without a func panic
 type MockStore struct {
+	sqlc.Querier
+
 	checkAccountBalanceFunc           func(ctx context.Context, arg sqlc.CheckAccountBalanceParams) (sqlc.CheckAccountBalanceRow, error)
 	getAccountByIDFunc                func(ctx context.Context, id pgtype.UUID) (sqlc.CoreAccount, error)
 	getAccountByNumberFunc            func(ctx context.Context, accountNumber string) (sqlc.CoreAccount, error)
@@ -22,9 +26,28 @@ type MockStore struct {
 	getAccountSummaryFunc             func(ctx context.Context, id pgtype.UUID) (sqlc.GetAccountSummaryRow, error)
 	getAccountsByBalanceRangeFunc     func(ctx context.Context, arg sqlc.GetAccountsByBalanceRangeParams) ([]sqlc.GetAccountsByBalanceRangeRow, error)
 	getAccountsByCurrencyFunc         func(ctx context.Context, arg sqlc.GetAccountsByCurrencyParams) ([]sqlc.CoreAccount, error)
+	getAccountsByIDsOrNumbersFunc     func(ctx context.Context, arg sqlc.GetAccountsByIDsOrNumbersParams) ([]sqlc.CoreAccount, error)
 	getAccountsByStatusFunc           func(ctx context.Context, arg sqlc.GetAccountsByStatusParams) ([]sqlc.CoreAccount, error)
 	getAccountsWithLowBalanceFunc     func(ctx context.Context, arg sqlc.GetAccountsWithLowBalanceParams) ([]sqlc.GetAccountsWithLowBalanceRow, error)
+	getDormantAccountsFunc            func(ctx context.Context, arg sqlc.GetDormantAccountsParams) ([]sqlc.GetDormantAccountsRow, error)
+	getBalanceHistoryTotalsFunc       func(ctx context.Context, arg sqlc.GetBalanceHistoryTotalsParams) ([]sqlc.GetBalanceHistoryTotalsRow, error)
+	listBalanceHistoryFunc            func(ctx context.Context, arg sqlc.ListBalanceHistoryParams) ([]sqlc.CoreAccountBalanceHistory, error)
+	listEODBalancesFunc               func(ctx context.Context, arg sqlc.ListEODBalancesParams) ([]sqlc.CoreEodBalance, error)
+	searchAccountsFunc                func(ctx context.Context, arg sqlc.SearchAccountsParams) ([]sqlc.CoreAccount, error)
 	validateAccountForTransactionFunc func(ctx context.Context, arg sqlc.ValidateAccountForTransactionParams) (sqlc.ValidateAccountForTransactionRow, error)
+	createFxRateFunc                  func(ctx context.Context, arg sqlc.CreateFxRateParams) (sqlc.CoreFxRate, error)
+	getFxRateAsOfFunc                 func(ctx context.Context, arg sqlc.GetFxRateAsOfParams) (sqlc.CoreFxRate, error)
+	getNotificationRecipientFunc      func(ctx context.Context, accountNumber string) (sqlc.GetNotificationRecipientRow, error)
+	resolveAccountAliasFunc           func(ctx context.Context, alias string) (sqlc.ResolveAccountAliasRow, error)
+	createAdminAuditFunc              func(ctx context.Context, arg sqlc.CreateAdminAuditParams) (sqlc.CoreAdminAudit, error)
+	getBusinessRuleByIDFunc           func(ctx context.Context, id pgtype.UUID) (sqlc.CoreBusinessRule, error)
+	updateBusinessRuleFunc            func(ctx context.Context, arg sqlc.UpdateBusinessRuleParams) (sqlc.CoreBusinessRule, error)
+	deleteBusinessRuleFunc            func(ctx context.Context, id pgtype.UUID) (int64, error)
+}
+
+// WithTx runs fn on the mock itself; tests see a failed transaction as fn's error
+func (m *MockStore) WithTx(_ context.Context, fn func(sqlc.Querier) error) error {
+	return fn(m)
 }
 
 func (m *MockStore) CheckAccountBalance(ctx context.Context, arg sqlc.CheckAccountBalanceParams) (sqlc.CheckAccountBalanceRow, error) {
@@ -76,6 +99,13 @@ func (m *MockStore) GetAccountsByCurrency(ctx context.Context, arg sqlc.GetAccou
 	return nil, errors.New("not implemented")
 }
 
+func (m *MockStore) GetAccountsByIDsOrNumbers(ctx context.Context, arg sqlc.GetAccountsByIDsOrNumbersParams) ([]sqlc.CoreAccount, error) {
+	if m.getAccountsByIDsOrNumbersFunc != nil {
+		return m.getAccountsByIDsOrNumbersFunc(ctx, arg)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *MockStore) GetAccountsByStatus(ctx context.Context, arg sqlc.GetAccountsByStatusParams) ([]sqlc.CoreAccount, error) {
 	if m.getAccountsByStatusFunc != nil {
 		return m.getAccountsByStatusFunc(ctx, arg)
@@ -90,6 +120,41 @@ func (m *MockStore) GetAccountsWithLowBalance(ctx context.Context, arg sqlc.GetA
 	return nil, errors.New("not implemented")
 }
 
+func (m *MockStore) GetBalanceHistoryTotals(ctx context.Context, arg sqlc.GetBalanceHistoryTotalsParams) ([]sqlc.GetBalanceHistoryTotalsRow, error) {
+	if m.getBalanceHistoryTotalsFunc != nil {
+		return m.getBalanceHistoryTotalsFunc(ctx, arg)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockStore) GetDormantAccounts(ctx context.Context, arg sqlc.GetDormantAccountsParams) ([]sqlc.GetDormantAccountsRow, error) {
+	if m.getDormantAccountsFunc != nil {
+		return m.getDormantAccountsFunc(ctx, arg)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockStore) ListBalanceHistory(ctx context.Context, arg sqlc.ListBalanceHistoryParams) ([]sqlc.CoreAccountBalanceHistory, error) {
+	if m.listBalanceHistoryFunc != nil {
+		return m.listBalanceHistoryFunc(ctx, arg)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockStore) ListEODBalances(ctx context.Context, arg sqlc.ListEODBalancesParams) ([]sqlc.CoreEodBalance, error) {
+	if m.listEODBalancesFunc != nil {
+		return m.listEODBalancesFunc(ctx, arg)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockStore) SearchAccounts(ctx context.Context, arg sqlc.SearchAccountsParams) ([]sqlc.CoreAccount, error) {
+	if m.searchAccountsFunc != nil {
+		return m.searchAccountsFunc(ctx, arg)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *MockStore) ValidateAccountForTransaction(ctx context.Context, arg sqlc.ValidateAccountForTransactionParams) (sqlc.ValidateAccountForTransactionRow, error) {
 	if m.validateAccountForTransactionFunc != nil {
 		return m.validateAccountForTransactionFunc(ctx, arg)
@@ -97,6 +162,62 @@ func (m *MockStore) ValidateAccountForTransaction(ctx context.Context, arg sqlc.
 	return sqlc.ValidateAccountForTransactionRow{}, errors.New("not implemented")
 }
 
+func (m *MockStore) CreateFxRate(ctx context.Context, arg sqlc.CreateFxRateParams) (sqlc.CoreFxRate, error) {
+	if m.createFxRateFunc != nil {
+		return m.createFxRateFunc(ctx, arg)
+	}
+	return sqlc.CoreFxRate{}, errors.New("not implemented")
+}
+
+func (m *MockStore) GetFxRateAsOf(ctx context.Context, arg sqlc.GetFxRateAsOfParams) (sqlc.CoreFxRate, error) {
+	if m.getFxRateAsOfFunc != nil {
+		return m.getFxRateAsOfFunc(ctx, arg)
+	}
+	return sqlc.CoreFxRate{}, errors.New("not implemented")
+}
+
+func (m *MockStore) GetNotificationRecipient(ctx context.Context, accountNumber string) (sqlc.GetNotificationRecipientRow, error) {
+	if m.getNotificationRecipientFunc != nil {
+		return m.getNotificationRecipientFunc(ctx, accountNumber)
+	}
+	return sqlc.GetNotificationRecipientRow{}, errors.New("not implemented")
+}
+
+func (m *MockStore) ResolveAccountAlias(ctx context.Context, alias string) (sqlc.ResolveAccountAliasRow, error) {
+	if m.resolveAccountAliasFunc != nil {
+		return m.resolveAccountAliasFunc(ctx, alias)
+	}
+	return sqlc.ResolveAccountAliasRow{}, errors.New("not implemented")
+}
+
+func (m *MockStore) CreateAdminAudit(ctx context.Context, arg sqlc.CreateAdminAuditParams) (sqlc.CoreAdminAudit, error) {
+	if m.createAdminAuditFunc != nil {
+		return m.createAdminAuditFunc(ctx, arg)
+	}
+	return sqlc.CoreAdminAudit{}, errors.New("not implemented")
+}
+
+func (m *MockStore) GetBusinessRuleByID(ctx context.Context, id pgtype.UUID) (sqlc.CoreBusinessRule, error) {
+	if m.getBusinessRuleByIDFunc != nil {
+		return m.getBusinessRuleByIDFunc(ctx, id)
+	}
+	return sqlc.CoreBusinessRule{}, errors.New("not implemented")
+}
+
+func (m *MockStore) UpdateBusinessRule(ctx context.Context, arg sqlc.UpdateBusinessRuleParams) (sqlc.CoreBusinessRule, error) {
+	if m.updateBusinessRuleFunc != nil {
+		return m.updateBusinessRuleFunc(ctx, arg)
+	}
+	return sqlc.CoreBusinessRule{}, errors.New("not implemented")
+}
+
+func (m *MockStore) DeleteBusinessRule(ctx context.Context, id pgtype.UUID) (int64, error) {
+	if m.deleteBusinessRuleFunc != nil {
+		return m.deleteBusinessRuleFunc(ctx, id)
+	}
+	return 0, errors.New("not implemented")
+}
+
 func TestCheckBalanceValidateParams(t *testing.T) {
 	t.Parallel()
 
@@ -303,6 +424,111 @@ func TestPerformBalanceValidations(t *testing.T) {
 	}
 }
 
+func TestCheckBalanceResultsFailure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		result  CheckBalanceResults
+		wantErr error
+	}{
+		{
+			name:   "can_be_debited",
+			result: CheckBalanceResults{Currency: "USD", SufficientFunds: true, Status: "active", IsActive: true},
+		},
+		{
+			name:    "inactive_account",
+			result:  CheckBalanceResults{Currency: "USD", SufficientFunds: true, Status: "frozen"},
+			wantErr: ErrAccountBlocked,
+		},
+		{
+			name:    "currency_mismatch",
+			result:  CheckBalanceResults{Currency: "EUR", SufficientFunds: true, Status: "active", IsActive: true},
+			wantErr: ErrInvalidCurrency,
+		},
+		{
+			name:    "insufficient_funds",
+			result:  CheckBalanceResults{Currency: "USD", Status: "active", IsActive: true},
+			wantErr: ErrInsufficientFunds,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.result.Failure("USD")
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("Failure() error = %v, want nil", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Failure() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetAccountForBalanceRequiredAmount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		required string // empty when not provided
+	}{
+		{name: "not_provided"},
+		{name: "integer", required: "100"},
+		{name: "scaled", required: "100.50"},
+		{name: "sub_unit", required: "0.05"},
+		{name: "large", required: "123456789012345.6789"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got pgtype.Numeric
+
+			service := createTestService()
+			service.store = &MockStore{
+				checkAccountBalanceFunc: func(ctx context.Context, arg sqlc.CheckAccountBalanceParams) (sqlc.CheckAccountBalanceRow, error) {
+					got = arg.Column2
+					return sqlc.CheckAccountBalanceRow{}, nil
+				},
+			}
+
+			accountID := uuid.New()
+			params := CheckBalanceParams{AccountID: &accountID}
+			if tt.required != "" {
+				required := decimal.RequireFromString(tt.required)
+				params.RequiredAmount = &required
+			}
+
+			if _, err := service.getAccountForBalance(context.Background(), params); err != nil {
+				t.Fatalf("getAccountForBalance() error = %v", err)
+			}
+
+			if params.RequiredAmount == nil {
+				if got.Valid {
+					t.Errorf("getAccountForBalance() required amount = %+v, want NULL", got)
+				}
+				return
+			}
+
+			amount, err := numeric.ToDecimal(got)
+			if err != nil {
+				t.Fatalf("numeric.ToDecimal() error = %v", err)
+			}
+			if !amount.Equal(*params.RequiredAmount) {
+				t.Errorf("getAccountForBalance() required amount = %s, want %s", amount, params.RequiredAmount)
+			}
+		})
+	}
+}
+
 func TestBuildCheckBalanceResult(t *testing.T) {
 	t.Parallel()
 
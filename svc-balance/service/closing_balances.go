@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// BusinessDateFormat is how business dates are written in requests and responses
+	BusinessDateFormat = "2006-01-02"
+
+	// DefaultClosingBalanceDays is how many business dates GetClosingBalances returns when From is not set
+	DefaultClosingBalanceDays = 31
+
+	// MaxClosingBalanceDays caps the number of business dates of GetClosingBalances
+	MaxClosingBalanceDays = 366
+)
+
+// ErrInvalidClosingBalanceQuery is returned for reversed or too long date ranges
+var ErrInvalidClosingBalanceQuery = errors.New("invalid closing balance query")
+
+// GetClosingBalancesParams selects the business dates From to To, both inclusive. A zero To is yesterday (UTC) and
+// a zero From is DefaultClosingBalanceDays before To.
+type GetClosingBalancesParams struct {
+	AccountID uuid.UUID `json:"account_id"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+}
+
+// ClosingBalance is the balance of an account at the end of one business day, as stored by svc-transaction's
+// EODBalanceWorkflow
+type ClosingBalance struct {
+	BusinessDate       string          `json:"business_date"`
+	ClosingBalance     decimal.Decimal `json:"closing_balance"`
+	EntryCount         int32           `json:"entry_count"`          // Balance changes during the day
+	LastSequenceNumber int64           `json:"last_sequence_number"` // Balance history entry the balance was taken from
+	ComputedAt         string          `json:"computed_at"`
+}
+
+// GetClosingBalancesResults lists the closing balances of a date range, oldest first. Missing lists the business
+// dates since the account was opened that have not been computed yet.
+type GetClosingBalancesResults struct {
+	AccountID uuid.UUID        `json:"account_id"`
+	Currency  string           `json:"currency"`
+	From      string           `json:"from"`
+	To        string           `json:"to"`
+	Balances  []ClosingBalance `json:"balances"`
+	Missing   []string         `json:"missing,omitempty"`
+}
+
+// GetClosingBalances returns the stored end-of-day balances of an account, the source for statements, so they do
+// not have to replay the balance history
+func (service *Service) GetClosingBalances(ctx context.Context, params GetClosingBalancesParams) (*GetClosingBalancesResults, error) {
+	const op = "service.Service.GetClosingBalances"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	from, to, err := closingBalanceRange(params.From, params.To, time.Now())
+	if err != nil {
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	accountID := pgtype.UUID{Bytes: params.AccountID, Valid: true}
+	account, err := service.store.GetAccountByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %s", ErrAccountNotFound, params.AccountID)
+		} else {
+			err = fmt.Errorf("failed to get account: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	records, err := service.store.ListEODBalances(ctx, sqlc.ListEODBalancesParams{
+		AccountID: accountID,
+		FromDate:  pgtype.Date{Time: from, Valid: true},
+		ToDate:    pgtype.Date{Time: to, Valid: true},
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to list closing balances: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &GetClosingBalancesResults{
+		AccountID: params.AccountID,
+		Currency:  string(account.Currency),
+		From:      from.Format(BusinessDateFormat),
+		To:        to.Format(BusinessDateFormat),
+		Balances:  make([]ClosingBalance, 0, len(records)),
+	}
+
+	computed := make(map[string]bool, len(records))
+	for _, record := range records {
+		closingBalance, err := service.pgNumericToDecimal(record.ClosingBalance)
+		if err != nil {
+			err = fmt.Errorf("failed to convert closing balance: %w", err)
+
+			logger.WithError(err).Error()
+
+			return nil, err
+		}
+
+		businessDate := record.BusinessDate.Time.Format(BusinessDateFormat)
+		computed[businessDate] = true
+
+		results.Balances = append(results.Balances, ClosingBalance{
+			BusinessDate:       businessDate,
+			ClosingBalance:     closingBalance,
+			EntryCount:         record.EntryCount,
+			LastSequenceNumber: record.LastSequenceNumber,
+			ComputedAt:         record.ComputedAt.Time.Format(time.RFC3339),
+		})
+	}
+
+	opened := businessDate(account.CreatedAt.Time)
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		if !date.Before(opened) && !computed[date.Format(BusinessDateFormat)] {
+			results.Missing = append(results.Missing, date.Format(BusinessDateFormat))
+		}
+	}
+
+	logger.WithFields(logrus.Fields{
+		"balances": len(results.Balances),
+		"missing":  len(results.Missing),
+	}).Info()
+
+	return results, nil
+}
+
+// closingBalanceRange fills in the defaults of a business date range and checks it
+func closingBalanceRange(from, to, now time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = businessDate(now).AddDate(0, 0, -1)
+	}
+	to = businessDate(to)
+
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -(DefaultClosingBalanceDays - 1))
+	}
+	from = businessDate(from)
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: from must not be after to", ErrInvalidClosingBalanceQuery)
+	}
+	if days := int(to.Sub(from).Hours()/24) + 1; days > MaxClosingBalanceDays {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: at most %d days can be requested", ErrInvalidClosingBalanceQuery, MaxClosingBalanceDays)
+	}
+
+	return from, to, nil
+}
+
+// businessDate is the UTC date of t at midnight
+func businessDate(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestGetClosingBalances(t *testing.T) {
+	t.Parallel()
+
+	accountID := uuid.New()
+	date := func(day int) time.Time { return time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC) }
+
+	var listParams sqlc.ListEODBalancesParams
+
+	service := createTestService()
+	service.store = &MockStore{
+		getAccountByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.CoreAccount, error) {
+			// Opened during the 2nd, so the 1st has no closing balance
+			return sqlc.CoreAccount{ID: id, Currency: "EUR", CreatedAt: pgtype.Timestamptz{Time: date(2).Add(15 * time.Hour), Valid: true}}, nil
+		},
+		listEODBalancesFunc: func(ctx context.Context, arg sqlc.ListEODBalancesParams) ([]sqlc.CoreEodBalance, error) {
+			listParams = arg
+
+			return []sqlc.CoreEodBalance{
+				{BusinessDate: pgtype.Date{Time: date(2), Valid: true}, ClosingBalance: createPgNumeric("100.00"), EntryCount: 1, LastSequenceNumber: 1},
+				{BusinessDate: pgtype.Date{Time: date(4), Valid: true}, ClosingBalance: createPgNumeric("75.50"), EntryCount: 2, LastSequenceNumber: 3},
+			}, nil
+		},
+	}
+
+	results, err := service.GetClosingBalances(context.Background(), GetClosingBalancesParams{
+		AccountID: accountID,
+		From:      date(1),
+		To:        date(4).Add(18 * time.Hour), // The time of day is ignored
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !listParams.FromDate.Time.Equal(date(1)) || !listParams.ToDate.Time.Equal(date(4)) {
+		t.Errorf("listed %s to %s, want 2026-10-01 to 2026-10-04", listParams.FromDate.Time, listParams.ToDate.Time)
+	}
+	if results.Currency != "EUR" || results.From != "2026-10-01" || results.To != "2026-10-04" {
+		t.Errorf("unexpected results %+v", results)
+	}
+	if len(results.Balances) != 2 || results.Balances[1].ClosingBalance.String() != "75.5" || results.Balances[1].BusinessDate != "2026-10-04" {
+		t.Errorf("unexpected balances %+v", results.Balances)
+	}
+	if !slices.Equal(results.Missing, []string{"2026-10-03"}) {
+		t.Errorf("missing = %v, want [2026-10-03]", results.Missing)
+	}
+}
+
+func TestClosingBalanceRange(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+
+	from, to, err := closingBalanceRange(time.Time{}, time.Time{}, now)
+	if err != nil {
+		t.Fatalf("defaults: unexpected error: %v", err)
+	}
+	if want := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC); !to.Equal(want) {
+		t.Errorf("defaults: to = %s, want %s", to, want)
+	}
+	if want := time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC); !from.Equal(want) {
+		t.Errorf("defaults: from = %s, want %s", from, want)
+	}
+
+	if _, _, err := closingBalanceRange(now, now.AddDate(0, 0, -1), now); !errors.Is(err, ErrInvalidClosingBalanceQuery) {
+		t.Errorf("reversed range: err = %v, want ErrInvalidClosingBalanceQuery", err)
+	}
+	if _, _, err := closingBalanceRange(now.AddDate(-2, 0, 0), now, now); !errors.Is(err, ErrInvalidClosingBalanceQuery) {
+		t.Errorf("two years: err = %v, want ErrInvalidClosingBalanceQuery", err)
+	}
+	if _, _, err := closingBalanceRange(now.AddDate(0, 0, -(MaxClosingBalanceDays-1)), now, now); err != nil {
+		t.Errorf("longest range: unexpected error: %v", err)
+	}
+}
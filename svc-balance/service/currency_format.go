@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// ErrInvalidLocale is returned for locales that are not valid BCP 47 tags, e.g. "de_DE!"
+var ErrInvalidLocale = errors.New("invalid locale")
+
+// symbolAfterAmount lists the languages that write the currency symbol after the amount, e.g. "123,46 €" in German
+var symbolAfterAmount = map[string]bool{
+	"cs": true,
+	"da": true,
+	"de": true,
+	"es": true,
+	"fi": true,
+	"fr": true,
+	"it": true,
+	"nb": true,
+	"pl": true,
+	"ru": true,
+	"sk": true,
+	"sv": true,
+}
+
+// symbolBeforeWithSpace lists the languages that separate a leading currency symbol from the amount, e.g. "€ 123,46"
+var symbolBeforeWithSpace = map[string]bool{
+	"nl": true,
+}
+
+// parseLocale parses a BCP 47 locale such as "de-DE"
+func parseLocale(locale string) (language.Tag, error) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return language.Und, fmt.Errorf("%w: %q", ErrInvalidLocale, locale)
+	}
+
+	return tag, nil
+}
+
+// formatLocalized formats an amount already rounded to places with the digit grouping, decimal separator and
+// symbol placement of the locale, e.g. 1234.5 EUR is "€1,234.50" in en-US and "1.234,50 €" in de-DE
+func formatLocalized(amount decimal.Decimal, places int32, symbol string, tag language.Tag) string {
+	printer := message.NewPrinter(tag)
+
+	// Only the integer part goes through x/text, whose number formatting takes float64 and would lose precision
+	// on large amounts; the fraction is copied digit for digit
+	integer := amount.Abs().Truncate(0)
+	formatted := printer.Sprint(number.Decimal(integer.IntPart()))
+
+	if places > 0 {
+		digits := amount.Abs().StringFixed(places)
+		fraction := digits[strings.IndexByte(digits, '.')+1:]
+		formatted += decimalSeparator(printer) + fraction
+	}
+
+	if amount.IsNegative() {
+		formatted = "-" + formatted
+	}
+
+	base, _ := tag.Base()
+	switch {
+	case symbolAfterAmount[base.String()]:
+		return formatted + " " + symbol
+	case symbolBeforeWithSpace[base.String()]:
+		return symbol + " " + formatted
+	default:
+		return symbol + formatted
+	}
+}
+
+// decimalSeparator returns the decimal separator of the printer's locale, e.g. "," for German
+func decimalSeparator(printer *message.Printer) string {
+	return strings.Trim(printer.Sprint(number.Decimal(0.5, number.Scale(1))), "05")
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -9,6 +10,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// ErrAmountScale is returned when an amount has more decimal places than its currency allows, e.g. fractional yen
+var ErrAmountScale = errors.New("amount exceeds currency decimal places")
+
 // CurrencyInfo represents detailed information about a currency
 type CurrencyInfo struct {
 	Code         string           `json:"code"`
@@ -58,6 +62,7 @@ type ConvertCurrencyParams struct {
 	Amount       decimal.Decimal `json:"amount"`
 	FromCurrency string          `json:"from_currency"`
 	ToCurrency   string          `json:"to_currency"`
+	Tenant       string          `json:"tenant,omitempty"` // Selects the tenant's rounding rules
 }
 
 // ConvertCurrencyResults represents the result of currency conversion
@@ -66,8 +71,19 @@ type ConvertCurrencyResults struct {
 	ConvertedAmount   decimal.Decimal `json:"converted_amount"`
 	FromCurrency      string          `json:"from_currency"`
 	ToCurrency        string          `json:"to_currency"`
-	ExchangeRate      decimal.Decimal `json:"exchange_rate"`
+	ExchangeRate      decimal.Decimal `json:"exchange_rate"` // Applied rate, target per source currency
 	ConversionApplied bool            `json:"conversion_applied"`
+	RoundingMode      RoundingMode    `json:"rounding_mode"` // Used to round the converted amount to the target currency
+
+	// Pricing of the pair, set when a conversion was applied. Rates are quote currency per base currency.
+	Pair         string          `json:"pair,omitempty"` // e.g. EUR/USD
+	MidRate      decimal.Decimal `json:"mid_rate"`
+	BidRate      decimal.Decimal `json:"bid_rate"`
+	AskRate      decimal.Decimal `json:"ask_rate"`
+	Side         string          `json:"side,omitempty"` // BID when selling the base currency, ASK when buying it
+	SpreadBps    int64           `json:"spread_bps"`
+	MarginBps    int64           `json:"margin_bps"`
+	SpreadAmount decimal.Decimal `json:"spread_amount"` // Converted amount at the mid rate minus the converted amount, in the target currency
 }
 
 // ConvertCurrency converts an amount from one currency to another
@@ -110,17 +126,10 @@ func (service *Service) ConvertCurrency(ctx context.Context, params ConvertCurre
 	}
 
 	// Perform conversion
-	convertedAmount, exchangeRate, conversionApplied := service.performCurrencyConversion(
-		params.Amount, fromCurrencyInfo, toCurrencyInfo)
-
-	result := &ConvertCurrencyResults{
-		OriginalAmount:    params.Amount,
-		ConvertedAmount:   convertedAmount,
-		FromCurrency:      params.FromCurrency,
-		ToCurrency:        params.ToCurrency,
-		ExchangeRate:      exchangeRate,
-		ConversionApplied: conversionApplied,
-	}
+	rounding := service.rounding.Mode(params.Tenant, toCurrencyInfo.Code)
+	result := service.performCurrencyConversion(params.Amount, fromCurrencyInfo, toCurrencyInfo, rounding)
+	result.FromCurrency = params.FromCurrency
+	result.ToCurrency = params.ToCurrency
 
 	logger.WithField("results", fmt.Sprintf("%+v", result)).Info()
 
@@ -278,27 +287,42 @@ func (service *Service) validateConvertCurrencyParams(params ConvertCurrencyPara
 	return nil
 }
 
-// performCurrencyConversion performs the actual currency conversion calculation
-func (service *Service) performCurrencyConversion(amount decimal.Decimal, fromCurrency, toCurrency CurrencyInfo) (decimal.Decimal, decimal.Decimal, bool) {
+// performCurrencyConversion performs the actual currency conversion calculation, pricing the pair with the
+// configured spread and margin and rounding the result with the given mode
+func (service *Service) performCurrencyConversion(amount decimal.Decimal, fromCurrency, toCurrency CurrencyInfo, rounding RoundingMode) *ConvertCurrencyResults {
 	// If same currency, no conversion needed
 	if fromCurrency.Code == toCurrency.Code {
-		return amount, decimal.NewFromFloat(1.0), false
+		return &ConvertCurrencyResults{
+			OriginalAmount:  amount,
+			ConvertedAmount: amount,
+			ExchangeRate:    decimal.NewFromFloat(1.0),
+		}
 	}
 
-	// Convert via USD as base currency
-	// First convert from source currency to USD
-	amountInUSD := amount.Div(*fromCurrency.ExchangeRate)
-
-	// Then convert from USD to target currency
-	convertedAmount := amountInUSD.Mul(*toCurrency.ExchangeRate)
-
-	// Calculate the direct exchange rate
-	exchangeRate := (*toCurrency.ExchangeRate).Div(*fromCurrency.ExchangeRate)
+	quote := service.fxPricing.quote(fromCurrency, toCurrency)
 
 	// Round to appropriate decimal places for target currency
-	convertedAmount = convertedAmount.Round(int32(toCurrency.DecimalPlace))
+	places := int32(toCurrency.DecimalPlace)
+	convertedAmount := rounding.Round(amount.Mul(quote.Rate), places)
+
+	// Both rates are to USD, so the mid rate between them is taken via USD as base currency
+	midAmount := rounding.Round(amount.Div(*fromCurrency.ExchangeRate).Mul(*toCurrency.ExchangeRate), places)
 
-	return convertedAmount, exchangeRate, true
+	return &ConvertCurrencyResults{
+		OriginalAmount:    amount,
+		ConvertedAmount:   convertedAmount,
+		ExchangeRate:      quote.Rate,
+		ConversionApplied: true,
+		RoundingMode:      rounding,
+		Pair:              quote.Pair,
+		MidRate:           quote.Mid,
+		BidRate:           quote.Bid,
+		AskRate:           quote.Ask,
+		Side:              quote.Side,
+		SpreadBps:         quote.SpreadBps,
+		MarginBps:         quote.MarginBps,
+		SpreadAmount:      midAmount.Sub(convertedAmount),
+	}
 }
 
 // NormalizeCurrencyAmount normalizes an amount to the appropriate decimal places for a currency
@@ -311,6 +335,20 @@ func (service *Service) NormalizeCurrencyAmount(amount decimal.Decimal, currency
 	return amount.Round(int32(currencyInfo.DecimalPlace)), nil
 }
 
+// ValidateAmountScale checks that an amount is representable in the currency's minor units
+func (service *Service) ValidateAmountScale(amount decimal.Decimal, currencyCode string) error {
+	currencyInfo, err := service.getCurrencyInfo(currencyCode)
+	if err != nil {
+		return fmt.Errorf("failed to get currency info: %w", err)
+	}
+
+	if !amount.Equal(amount.Truncate(int32(currencyInfo.DecimalPlace))) {
+		return fmt.Errorf("%w: %s allows %d, got %s", ErrAmountScale, currencyInfo.Code, currencyInfo.DecimalPlace, amount.String())
+	}
+
+	return nil
+}
+
 // GetCurrencySymbol returns the symbol for a given currency code
 func (service *Service) GetCurrencySymbol(currencyCode string) (string, error) {
 	currencyInfo, err := service.getCurrencyInfo(currencyCode)
@@ -333,6 +371,55 @@ func (service *Service) FormatCurrencyAmount(amount decimal.Decimal, currencyCod
 	return fmt.Sprintf("%s%s", currencyInfo.Symbol, normalizedAmount.String()), nil
 }
 
+// FormatCurrencyParams represents input parameters for formatting an amount for display
+type FormatCurrencyParams struct {
+	Amount       decimal.Decimal `json:"amount"`
+	CurrencyCode string          `json:"currency_code"`
+	Locale       string          `json:"locale,omitempty"` // BCP 47, e.g. de-DE; empty for the plain "$100.50" form
+}
+
+// FormatCurrencyResults represents an amount formatted for display, e.g. on receipts
+type FormatCurrencyResults struct {
+	CurrencyCode string          `json:"currency_code"`
+	Symbol       string          `json:"symbol"`
+	DecimalPlace int             `json:"decimal_place"`
+	Amount       decimal.Decimal `json:"amount"`
+	Locale       string          `json:"locale,omitempty"`
+	Formatted    string          `json:"formatted"`
+}
+
+// FormatCurrency normalizes an amount to the currency's decimal places and prefixes its symbol.
+// Unlike FormatCurrencyAmount, trailing zeros are kept ("$100.50") as expected on printed documents.
+// With a locale, digits are grouped and the symbol placed as in that locale, e.g. "1.234,50 €" for de-DE.
+func (service *Service) FormatCurrency(ctx context.Context, params FormatCurrencyParams) (*FormatCurrencyResults, error) {
+	currencyInfo, err := service.getCurrencyInfo(params.CurrencyCode)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported currency: %w", err)
+	}
+
+	places := int32(currencyInfo.DecimalPlace)
+
+	results := &FormatCurrencyResults{
+		CurrencyCode: currencyInfo.Code,
+		Symbol:       currencyInfo.Symbol,
+		DecimalPlace: currencyInfo.DecimalPlace,
+		Amount:       params.Amount.Round(places),
+		Formatted:    fmt.Sprintf("%s%s", currencyInfo.Symbol, params.Amount.StringFixed(places)),
+	}
+
+	if params.Locale != "" {
+		tag, err := parseLocale(params.Locale)
+		if err != nil {
+			return nil, err
+		}
+
+		results.Locale = tag.String()
+		results.Formatted = formatLocalized(results.Amount, places, currencyInfo.Symbol, tag)
+	}
+
+	return results, nil
+}
+
 // decimalPtr is a helper function to create a pointer to a decimal value
 func decimalPtr(d decimal.Decimal) *decimal.Decimal {
 	return &d
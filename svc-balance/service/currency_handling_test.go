@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 
@@ -502,6 +503,44 @@ func TestNormalizeCurrencyAmount(t *testing.T) {
 	}
 }
 
+func TestValidateAmountScale(t *testing.T) {
+	t.Parallel() // Allow this test function to run parallel with others
+
+	service := createTestService()
+
+	tests := []struct {
+		name         string
+		amount       string
+		currencyCode string
+		wantScaleErr bool
+		expectError  bool
+	}{
+		{name: "USD cents", amount: "100.50", currencyCode: "USD"},
+		{name: "USD trailing zeros", amount: "100.500", currencyCode: "USD"},
+		{name: "USD fractional cents", amount: "100.505", currencyCode: "USD", wantScaleErr: true, expectError: true},
+		{name: "JPY whole yen", amount: "1050", currencyCode: "JPY"},
+		{name: "JPY fractional yen", amount: "10.50", currencyCode: "JPY", wantScaleErr: true, expectError: true},
+		{name: "Lowercase currency", amount: "10.5", currencyCode: "eur"},
+		{name: "Invalid currency", amount: "10", currencyCode: "INVALID", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel() // Allow subtests to run parallel with each other
+
+			err := service.ValidateAmountScale(decimal.RequireFromString(tt.amount), tt.currencyCode)
+
+			if (err != nil) != tt.expectError {
+				t.Fatalf("ValidateAmountScale() error = %v, expectError %v", err, tt.expectError)
+			}
+
+			if errors.Is(err, ErrAmountScale) != tt.wantScaleErr {
+				t.Errorf("ValidateAmountScale() error = %v, want ErrAmountScale %v", err, tt.wantScaleErr)
+			}
+		})
+	}
+}
+
 func TestGetCurrencySymbol(t *testing.T) {
 	t.Parallel() // Allow this test function to run parallel with others
 
@@ -704,3 +743,215 @@ func TestGetCurrencyInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatCurrency(t *testing.T) {
+	t.Parallel() // Allow this test function to run parallel with others
+
+	service := createTestService()
+
+	tests := []struct {
+		name           string
+		amount         decimal.Decimal
+		currencyCode   string
+		locale         string
+		expectedFormat string
+		expectError    bool
+	}{
+		{
+			name:           "Keeps trailing zeros",
+			amount:         decimal.RequireFromString("100.5"),
+			currencyCode:   "USD",
+			expectedFormat: "$100.50",
+		},
+		{
+			name:           "Rounds to currency decimals",
+			amount:         decimal.RequireFromString("99.999"),
+			currencyCode:   "eur",
+			expectedFormat: "€100.00",
+		},
+		{
+			name:           "Zero decimal currency",
+			amount:         decimal.RequireFromString("1500.4"),
+			currencyCode:   "JPY",
+			expectedFormat: "¥1500",
+		},
+		{
+			name:         "Invalid currency",
+			amount:       decimal.RequireFromString("1"),
+			currencyCode: "INVALID",
+			expectError:  true,
+		},
+		{
+			name:           "US English groups thousands",
+			amount:         decimal.RequireFromString("1234567.456"),
+			currencyCode:   "USD",
+			locale:         "en-US",
+			expectedFormat: "$1,234,567.46",
+		},
+		{
+			name:           "German places the symbol after the amount",
+			amount:         decimal.RequireFromString("123.456"),
+			currencyCode:   "EUR",
+			locale:         "de-DE",
+			expectedFormat: "123,46 €",
+		},
+		{
+			name:           "German groups with dots",
+			amount:         decimal.RequireFromString("1234.5"),
+			currencyCode:   "EUR",
+			locale:         "de-DE",
+			expectedFormat: "1.234,50 €",
+		},
+		{
+			name:           "Dutch separates a leading symbol",
+			amount:         decimal.RequireFromString("-1234.5"),
+			currencyCode:   "EUR",
+			locale:         "nl-NL",
+			expectedFormat: "€ -1.234,50",
+		},
+		{
+			name:           "Zero decimal currency in Japanese",
+			amount:         decimal.RequireFromString("1500000.4"),
+			currencyCode:   "JPY",
+			locale:         "ja-JP",
+			expectedFormat: "¥1,500,000",
+		},
+		{
+			name:           "Large amounts keep every digit",
+			amount:         decimal.RequireFromString("98765432109876.54"),
+			currencyCode:   "USD",
+			locale:         "en-US",
+			expectedFormat: "$98,765,432,109,876.54",
+		},
+		{
+			name:         "Invalid locale",
+			amount:       decimal.RequireFromString("1"),
+			currencyCode: "USD",
+			locale:       "not a locale",
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel() // Allow subtests to run parallel with each other
+
+			result, err := service.FormatCurrency(context.Background(), FormatCurrencyParams{
+				Amount:       tt.amount,
+				CurrencyCode: tt.currencyCode,
+				Locale:       tt.locale,
+			})
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("FormatCurrency() expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("FormatCurrency() error = %v", err)
+				return
+			}
+
+			if result.Formatted != tt.expectedFormat {
+				t.Errorf("FormatCurrency() = %s, want %s", result.Formatted, tt.expectedFormat)
+			}
+		})
+	}
+}
+
+// maxFuzzExponent bounds fuzzed exponents so String() does not allocate gigabytes of zeros
+const maxFuzzExponent = 1000
+
+func FuzzNormalizeCurrencyAmount(f *testing.F) {
+	for _, seed := range []struct {
+		amount       string
+		currencyCode string
+	}{
+		{"123.456", "USD"},
+		{"123.456", "jpy"},
+		{"99.995", " EUR "},
+		{"-0.005", "GBP"},
+		{"1E+3", "CHF"},
+		{"123.456", "INVALID"},
+	} {
+		f.Add(seed.amount, seed.currencyCode)
+	}
+
+	service := createTestService()
+
+	f.Fuzz(func(t *testing.T, value string, currencyCode string) {
+		amount, err := decimal.NewFromString(value)
+		if err != nil || amount.Exponent() > maxFuzzExponent || amount.Exponent() < -maxFuzzExponent {
+			t.Skip()
+		}
+
+		currencyInfo, infoErr := service.getCurrencyInfo(currencyCode)
+
+		result, err := service.NormalizeCurrencyAmount(amount, currencyCode)
+		if infoErr != nil {
+			if err == nil {
+				t.Errorf("NormalizeCurrencyAmount(%s, %q) expected error for unsupported currency", value, currencyCode)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("NormalizeCurrencyAmount(%s, %q) error = %v", value, currencyCode, err)
+		}
+
+		if currencyInfo.Code != strings.ToUpper(strings.TrimSpace(currencyCode)) {
+			t.Errorf("getCurrencyInfo(%q) = %s, want the normalized code", currencyCode, currencyInfo.Code)
+		}
+
+		places := int32(currencyInfo.DecimalPlace)
+
+		// The normalized amount must have no more than the currency's decimal places ...
+		if !result.Equal(result.Truncate(places)) {
+			t.Errorf("NormalizeCurrencyAmount(%s, %s) = %s, has more than %d decimal places", value, currencyCode, result.String(), places)
+		}
+
+		// ... and be within half a minor unit of the original amount
+		halfUnit := decimal.New(5, -places-1)
+		if result.Sub(amount).Abs().GreaterThan(halfUnit) {
+			t.Errorf("NormalizeCurrencyAmount(%s, %s) = %s, differs by more than %s", value, currencyCode, result.String(), halfUnit.String())
+		}
+
+		formatted, err := service.FormatCurrency(context.Background(), FormatCurrencyParams{Amount: amount, CurrencyCode: currencyCode})
+		if err != nil {
+			t.Fatalf("FormatCurrency(%s, %q) error = %v", value, currencyCode, err)
+		}
+
+		if formatted.Formatted != currencyInfo.Symbol+result.StringFixed(places) {
+			t.Errorf("FormatCurrency(%s, %s) = %s, want %s%s", value, currencyCode, formatted.Formatted, currencyInfo.Symbol, result.StringFixed(places))
+		}
+	})
+}
+
+func BenchmarkConvertCurrency(b *testing.B) {
+	service := createTestService()
+	params := ConvertCurrencyParams{
+		Amount:       decimal.RequireFromString("1234.56"),
+		FromCurrency: "EUR",
+		ToCurrency:   "JPY",
+	}
+
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := service.ConvertCurrency(context.Background(), params); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNormalizeCurrencyAmount(b *testing.B) {
+	service := createTestService()
+	amount := decimal.RequireFromString("1234.5678")
+
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := service.NormalizeCurrencyAmount(amount, "USD"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,498 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"svc-balance/store/sqlc"
+	"svc-balance/util/notification"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// Transfer outcomes account holders can be emailed about
+const (
+	TransferCompletedEventType   = "transfer.completed"
+	TransferCompensatedEventType = "transfer.compensated"
+)
+
+// Outcomes of a transfer notification for one account
+const (
+	NotificationSent         = "sent"
+	NotificationNoPreference = "no_preference" // The account holder never registered an email address
+	NotificationOptedOut     = "opted_out"     // The account holder turned this kind of email off
+	NotificationSuppressed   = "suppressed"    // The address is on the suppression list
+)
+
+var (
+	// ErrInvalidNotificationPreference is returned for preferences without a valid email address
+	ErrInvalidNotificationPreference = errors.New("invalid notification preference")
+	// ErrNotificationPreferenceNotFound is returned when an account has no notification preference
+	ErrNotificationPreferenceNotFound = errors.New("notification preference not found")
+	// ErrNotificationSuppressionNotFound is returned when an address is not on the suppression list
+	ErrNotificationSuppressionNotFound = errors.New("notification suppression not found")
+)
+
+// Emails sent for transfer outcomes. The payer hears about completions and compensations, the payee only about
+// completions, since a compensated transfer never reached them.
+var (
+	transferSentEmail = notification.MustEmailTemplate(
+		"transfer_sent",
+		`Your transfer of {{.Amount}} to {{.Counterparty}} is complete`,
+		`Hello {{.AccountName}},
+
+Your transfer of {{.Amount}} from account {{.AccountNumber}} to account {{.Counterparty}} has been completed.
+{{if .Description}}
+Description: {{.Description}}{{end}}
+Transfer ID: {{.TransferID}}
+Completed at: {{.OccurredAt}}
+
+You are receiving this email because you asked to be notified of completed transfers.
+`)
+
+	transferReceivedEmail = notification.MustEmailTemplate(
+		"transfer_received",
+		`You received {{.Amount}} from {{.Counterparty}}`,
+		`Hello {{.AccountName}},
+
+Account {{.AccountNumber}} received {{.Amount}} from account {{.Counterparty}}.
+{{if .Description}}
+Description: {{.Description}}{{end}}
+Transfer ID: {{.TransferID}}
+Completed at: {{.OccurredAt}}
+
+You are receiving this email because you asked to be notified of completed transfers.
+`)
+
+	transferCompensatedEmail = notification.MustEmailTemplate(
+		"transfer_compensated",
+		`Your transfer of {{.Amount}} to {{.Counterparty}} could not be completed`,
+		`Hello {{.AccountName}},
+
+Your transfer of {{.Amount}} from account {{.AccountNumber}} to account {{.Counterparty}} could not be completed.
+The amount has been returned to your account.
+{{if .Reason}}
+Reason: {{.Reason}}{{end}}
+Transfer ID: {{.TransferID}}
+Reversed at: {{.OccurredAt}}
+
+You are receiving this email because you asked to be notified of reversed transfers.
+`)
+)
+
+// NotificationPreference is where, and for which transfer outcomes, an account holder is emailed
+type NotificationPreference struct {
+	AccountID         uuid.UUID `json:"account_id"`
+	Email             string    `json:"email"`
+	NotifyCompleted   bool      `json:"notify_completed"`
+	NotifyCompensated bool      `json:"notify_compensated"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// SetNotificationPreferenceParams represents the input parameters for setting the preference of an account
+type SetNotificationPreferenceParams struct {
+	AccountID         uuid.UUID `json:"account_id"`
+	Email             string    `json:"email"`
+	NotifyCompleted   bool      `json:"notify_completed"`
+	NotifyCompensated bool      `json:"notify_compensated"`
+}
+
+// NotificationSuppression is an address no notification is sent to
+type NotificationSuppression struct {
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SendTransferNotificationParams describes a transfer outcome to email the account holders about
+type SendTransferNotificationParams struct {
+	EventType   string
+	TransferID  string
+	FromAccount string
+	ToAccount   string
+	Amount      decimal.Decimal
+	Currency    string
+	Description string
+	Reason      string
+	OccurredAt  time.Time
+}
+
+// SendTransferNotificationResults reports what happened for each account holder of the transfer
+type SendTransferNotificationResults struct {
+	Deliveries []TransferNotificationDelivery `json:"deliveries"`
+}
+
+// TransferNotificationDelivery is the outcome of a transfer notification for one account
+type TransferNotificationDelivery struct {
+	AccountNumber string `json:"account_number"`
+	Role          string `json:"role"`   // payer or payee
+	Status        string `json:"status"` // NotificationSent, NotificationNoPreference, NotificationOptedOut or NotificationSuppressed
+}
+
+// transferEmailData is what the transfer email templates are rendered with
+type transferEmailData struct {
+	AccountName   string
+	AccountNumber string
+	Counterparty  string
+	Amount        string
+	TransferID    string
+	Description   string
+	Reason        string
+	OccurredAt    string
+}
+
+// SetEmailSender replaces the stub sender, which only logs emails, with a real one
+func (service *Service) SetEmailSender(sender notification.EmailSender) {
+	service.emailSender = sender
+}
+
+// SetNotificationPreference creates or replaces the notification preference of an account
+func (service *Service) SetNotificationPreference(ctx context.Context, params SetNotificationPreferenceParams) (*NotificationPreference, error) {
+	const op = "service.Service.SetNotificationPreference"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": params.AccountID.String(),
+	})
+
+	logger.Info()
+
+	if params.AccountID == uuid.Nil {
+		return nil, fmt.Errorf("%w: account_id is required", ErrInvalidNotificationPreference)
+	}
+
+	email, err := normalizeEmail(params.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the account exists before attaching a preference to it
+	accountID := pgtype.UUID{Bytes: params.AccountID, Valid: true}
+	if _, err := service.store.GetAccountByID(ctx, accountID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, params.AccountID)
+		}
+
+		err = fmt.Errorf("failed to get account: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	preference, err := service.store.UpsertNotificationPreference(ctx, sqlc.UpsertNotificationPreferenceParams{
+		AccountID:         accountID,
+		Email:             email,
+		NotifyCompleted:   params.NotifyCompleted,
+		NotifyCompensated: params.NotifyCompensated,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to save notification preference: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return buildNotificationPreference(preference), nil
+}
+
+// GetNotificationPreference returns the notification preference of an account
+func (service *Service) GetNotificationPreference(ctx context.Context, accountID uuid.UUID) (*NotificationPreference, error) {
+	const op = "service.Service.GetNotificationPreference"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": accountID.String(),
+	})
+
+	preference, err := service.store.GetNotificationPreference(ctx, pgtype.UUID{Bytes: accountID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotificationPreferenceNotFound
+		}
+
+		err = fmt.Errorf("failed to get notification preference: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return buildNotificationPreference(preference), nil
+}
+
+// DeleteNotificationPreference stops all notifications to an account holder
+func (service *Service) DeleteNotificationPreference(ctx context.Context, accountID uuid.UUID) error {
+	const op = "service.Service.DeleteNotificationPreference"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": accountID.String(),
+	})
+
+	logger.Info()
+
+	rows, err := service.store.DeleteNotificationPreference(ctx, pgtype.UUID{Bytes: accountID, Valid: true})
+	if err != nil {
+		err = fmt.Errorf("failed to delete notification preference: %w", err)
+
+		logger.WithError(err).Error()
+
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotificationPreferenceNotFound
+	}
+
+	return nil
+}
+
+// AddNotificationSuppression puts an address on the suppression list, or updates the reason it is on it
+func (service *Service) AddNotificationSuppression(ctx context.Context, email, reason string) (*NotificationSuppression, error) {
+	const op = "service.Service.AddNotificationSuppression"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"reason": reason,
+	})
+
+	logger.Info()
+
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(reason) == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrInvalidNotificationPreference)
+	}
+
+	suppression, err := service.store.AddNotificationSuppression(ctx, sqlc.AddNotificationSuppressionParams{
+		Email:  email,
+		Reason: strings.TrimSpace(reason),
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to add notification suppression: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return buildNotificationSuppression(suppression), nil
+}
+
+// ListNotificationSuppressions lists the suppression list, newest first
+func (service *Service) ListNotificationSuppressions(ctx context.Context, limit, offset int32) ([]NotificationSuppression, error) {
+	const op = "service.Service.ListNotificationSuppressions"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"limit":  limit,
+		"offset": offset,
+	})
+
+	suppressions, err := service.store.ListNotificationSuppressions(ctx, sqlc.ListNotificationSuppressionsParams{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to list notification suppressions: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := make([]NotificationSuppression, 0, len(suppressions))
+	for _, suppression := range suppressions {
+		results = append(results, *buildNotificationSuppression(suppression))
+	}
+
+	return results, nil
+}
+
+// RemoveNotificationSuppression takes an address off the suppression list
+func (service *Service) RemoveNotificationSuppression(ctx context.Context, email string) error {
+	const op = "service.Service.RemoveNotificationSuppression"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	logger.Info()
+
+	rows, err := service.store.DeleteNotificationSuppression(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		err = fmt.Errorf("failed to delete notification suppression: %w", err)
+
+		logger.WithError(err).Error()
+
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotificationSuppressionNotFound
+	}
+
+	return nil
+}
+
+// SendTransferNotification emails the account holders of a completed or compensated transfer, as far as their
+// preferences and the suppression list allow. Accounts without a preference are skipped, not failed. A failed send
+// is returned so the activity retries the event; holders already emailed in that attempt may be emailed again.
+func (service *Service) SendTransferNotification(ctx context.Context, params SendTransferNotificationParams) (*SendTransferNotificationResults, error) {
+	const op = "service.Service.SendTransferNotification"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"event_type":  params.EventType,
+		"transfer_id": params.TransferID,
+	})
+
+	type recipient struct {
+		account      string
+		counterparty string
+		role         string
+		email        *notification.EmailTemplate
+	}
+
+	var recipients []recipient
+	switch params.EventType {
+	case TransferCompletedEventType:
+		recipients = []recipient{
+			{params.FromAccount, params.ToAccount, "payer", transferSentEmail},
+			{params.ToAccount, params.FromAccount, "payee", transferReceivedEmail},
+		}
+	case TransferCompensatedEventType:
+		recipients = []recipient{
+			{params.FromAccount, params.ToAccount, "payer", transferCompensatedEmail},
+		}
+	default:
+		err := fmt.Errorf("%w: unsupported event type %q", ErrInvalidTransferEvent, params.EventType)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	amount := service.formatNotificationAmount(ctx, params.Amount, params.Currency)
+
+	results := &SendTransferNotificationResults{}
+
+	for _, to := range recipients {
+		delivery := TransferNotificationDelivery{AccountNumber: to.account, Role: to.role}
+
+		row, err := service.store.GetNotificationRecipient(ctx, to.account)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			delivery.Status = NotificationNoPreference
+		case err != nil:
+			err = fmt.Errorf("failed to get notification recipient: %w", err)
+
+			logger.WithError(err).Error()
+
+			return nil, err
+		case row.Suppressed:
+			delivery.Status = NotificationSuppressed
+		case !wantsTransferNotification(row, params.EventType):
+			delivery.Status = NotificationOptedOut
+		default:
+			email, err := to.email.Render(row.Email, transferEmailData{
+				AccountName:   row.AccountName,
+				AccountNumber: to.account,
+				Counterparty:  to.counterparty,
+				Amount:        amount,
+				TransferID:    params.TransferID,
+				Description:   params.Description,
+				Reason:        params.Reason,
+				OccurredAt:    params.OccurredAt.UTC().Format(time.RFC1123),
+			})
+			if err != nil {
+				err = fmt.Errorf("%w: %v", ErrInvalidTransferEvent, err)
+
+				logger.WithError(err).Error()
+
+				return nil, err
+			}
+
+			if err := service.emailSender.Send(ctx, email); err != nil {
+				err = fmt.Errorf("failed to send transfer notification to %s: %w", to.role, err)
+
+				logger.WithError(err).Warn()
+
+				return nil, err
+			}
+
+			delivery.Status = NotificationSent
+		}
+
+		results.Deliveries = append(results.Deliveries, delivery)
+	}
+
+	logger.WithField("deliveries", fmt.Sprintf("%+v", results.Deliveries)).Info("Transfer notification processed")
+
+	return results, nil
+}
+
+// wantsTransferNotification reports whether the account holder asked for emails about this transfer outcome
+func wantsTransferNotification(row sqlc.GetNotificationRecipientRow, eventType string) bool {
+	if eventType == TransferCompensatedEventType {
+		return row.NotifyCompensated
+	}
+
+	return row.NotifyCompleted
+}
+
+// formatNotificationAmount formats the amount with its currency symbol, falling back to "100.5 XYZ" for currencies
+// that are not in the catalog
+func (service *Service) formatNotificationAmount(ctx context.Context, amount decimal.Decimal, currency string) string {
+	formatted, err := service.FormatCurrency(ctx, FormatCurrencyParams{Amount: amount, CurrencyCode: currency})
+	if err != nil {
+		return fmt.Sprintf("%s %s", amount.String(), currency)
+	}
+
+	return formatted.Formatted
+}
+
+// normalizeEmail validates a bare email address and lower-cases it, so suppression lookups are case-insensitive
+func normalizeEmail(email string) (string, error) {
+	address, err := mail.ParseAddress(strings.TrimSpace(email))
+	if err != nil || address.Name != "" || address.Address != strings.TrimSpace(email) {
+		return "", fmt.Errorf("%w: email must be a plain address such as jane@example.com", ErrInvalidNotificationPreference)
+	}
+
+	return strings.ToLower(address.Address), nil
+}
+
+// buildNotificationPreference converts a database record to the service result format
+func buildNotificationPreference(preference sqlc.CoreNotificationPreference) *NotificationPreference {
+	return &NotificationPreference{
+		AccountID:         uuid.UUID(preference.AccountID.Bytes),
+		Email:             preference.Email,
+		NotifyCompleted:   preference.NotifyCompleted,
+		NotifyCompensated: preference.NotifyCompensated,
+		CreatedAt:         preference.CreatedAt.Time,
+		UpdatedAt:         preference.UpdatedAt.Time,
+	}
+}
+
+// buildNotificationSuppression converts a database record to the service result format
+func buildNotificationSuppression(suppression sqlc.CoreNotificationSuppression) *NotificationSuppression {
+	return &NotificationSuppression{
+		Email:     suppression.Email,
+		Reason:    suppression.Reason,
+		CreatedAt: suppression.CreatedAt.Time,
+	}
+}
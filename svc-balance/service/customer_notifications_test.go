@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"svc-balance/store/sqlc"
+	"svc-balance/util/notification"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingEmailSender keeps the emails it is asked to send, failing them all when err is set
+type recordingEmailSender struct {
+	emails []notification.Email
+	err    error
+}
+
+func (s *recordingEmailSender) Send(ctx context.Context, email notification.Email) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.emails = append(s.emails, email)
+	return nil
+}
+
+func TestSendTransferNotification(t *testing.T) {
+	t.Parallel()
+
+	recipients := map[string]sqlc.GetNotificationRecipientRow{
+		"111111111111": {AccountNumber: "111111111111", AccountName: "Alice", Email: "alice@example.com", NotifyCompleted: true, NotifyCompensated: true},
+		"222222222222": {AccountNumber: "222222222222", AccountName: "Bob", Email: "bob@example.com", NotifyCompleted: true, NotifyCompensated: true},
+		"333333333333": {AccountNumber: "333333333333", AccountName: "Carol", Email: "carol@example.com", NotifyCompleted: false, NotifyCompensated: true},
+		"444444444444": {AccountNumber: "444444444444", AccountName: "Dave", Email: "dave@example.com", NotifyCompleted: true, NotifyCompensated: true, Suppressed: true},
+	}
+
+	newService := func(sender notification.EmailSender) *Service {
+		service := createTestService()
+		service.emailSender = sender
+		service.store = &MockStore{
+			getNotificationRecipientFunc: func(ctx context.Context, accountNumber string) (sqlc.GetNotificationRecipientRow, error) {
+				if row, ok := recipients[accountNumber]; ok {
+					return row, nil
+				}
+				return sqlc.GetNotificationRecipientRow{}, pgx.ErrNoRows
+			},
+		}
+		return service
+	}
+
+	transfer := func(eventType, from, to string) SendTransferNotificationParams {
+		return SendTransferNotificationParams{
+			EventType:   eventType,
+			TransferID:  "transfer-123",
+			FromAccount: from,
+			ToAccount:   to,
+			Amount:      decimal.RequireFromString("100.50"),
+			Currency:    "USD",
+			Description: "Rent",
+			Reason:      "credit account failed: account blocked",
+			OccurredAt:  time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		}
+	}
+
+	t.Run("completed transfer emails payer and payee", func(t *testing.T) {
+		t.Parallel()
+
+		sender := &recordingEmailSender{}
+		results, err := newService(sender).SendTransferNotification(context.Background(), transfer(TransferCompletedEventType, "111111111111", "222222222222"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []TransferNotificationDelivery{
+			{AccountNumber: "111111111111", Role: "payer", Status: NotificationSent},
+			{AccountNumber: "222222222222", Role: "payee", Status: NotificationSent},
+		}, results.Deliveries)
+
+		require.Len(t, sender.emails, 2)
+		assert.Equal(t, "alice@example.com", sender.emails[0].To)
+		assert.Equal(t, "Your transfer of $100.50 to 222222222222 is complete", sender.emails[0].Subject)
+		assert.Contains(t, sender.emails[0].Body, "Description: Rent")
+		assert.Equal(t, "bob@example.com", sender.emails[1].To)
+		assert.Equal(t, "You received $100.50 from 111111111111", sender.emails[1].Subject)
+	})
+
+	t.Run("compensated transfer emails the payer only", func(t *testing.T) {
+		t.Parallel()
+
+		sender := &recordingEmailSender{}
+		results, err := newService(sender).SendTransferNotification(context.Background(), transfer(TransferCompensatedEventType, "111111111111", "222222222222"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []TransferNotificationDelivery{
+			{AccountNumber: "111111111111", Role: "payer", Status: NotificationSent},
+		}, results.Deliveries)
+
+		require.Len(t, sender.emails, 1)
+		assert.Equal(t, "Your transfer of $100.50 to 222222222222 could not be completed", sender.emails[0].Subject)
+		assert.Contains(t, sender.emails[0].Body, "Reason: credit account failed: account blocked")
+	})
+
+	t.Run("preferences and suppression list are respected", func(t *testing.T) {
+		t.Parallel()
+
+		sender := &recordingEmailSender{}
+		service := newService(sender)
+
+		results, err := service.SendTransferNotification(context.Background(), transfer(TransferCompletedEventType, "333333333333", "444444444444"))
+		require.NoError(t, err)
+		assert.Equal(t, []TransferNotificationDelivery{
+			{AccountNumber: "333333333333", Role: "payer", Status: NotificationOptedOut},
+			{AccountNumber: "444444444444", Role: "payee", Status: NotificationSuppressed},
+		}, results.Deliveries)
+
+		results, err = service.SendTransferNotification(context.Background(), transfer(TransferCompletedEventType, "555555555555", "111111111111"))
+		require.NoError(t, err)
+		assert.Equal(t, NotificationNoPreference, results.Deliveries[0].Status)
+		assert.Equal(t, NotificationSent, results.Deliveries[1].Status)
+
+		require.Len(t, sender.emails, 1)
+		assert.Equal(t, "alice@example.com", sender.emails[0].To)
+	})
+
+	t.Run("send failures are returned for a retry", func(t *testing.T) {
+		t.Parallel()
+
+		sender := &recordingEmailSender{err: errors.New("connection refused")}
+		_, err := newService(sender).SendTransferNotification(context.Background(), transfer(TransferCompletedEventType, "111111111111", "222222222222"))
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrInvalidTransferEvent)
+	})
+
+	t.Run("rejects unknown event types", func(t *testing.T) {
+		t.Parallel()
+
+		sender := &recordingEmailSender{}
+		_, err := newService(sender).SendTransferNotification(context.Background(), transfer("transfer.expired", "111111111111", "222222222222"))
+		assert.ErrorIs(t, err, ErrInvalidTransferEvent)
+		assert.Empty(t, sender.emails)
+	})
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	t.Parallel()
+
+	email, err := normalizeEmail(" Jane.Doe@Example.com ")
+	require.NoError(t, err)
+	assert.Equal(t, "jane.doe@example.com", email)
+
+	for _, invalid := range []string{"", "jane", "Jane <jane@example.com>", "jane@example.com, bob@example.com"} {
+		_, err := normalizeEmail(invalid)
+		assert.ErrorIs(t, err, ErrInvalidNotificationPreference, invalid)
+	}
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"svc-balance/util/failure"
 )
@@ -85,22 +87,49 @@ func (service *Service) GetFailureSimulationStats() map[string]any {
 	// Add learning context to stats
 	stats["learning_mode"] = true
 	stats["total_rules"] = len(learningFailureRules)
-	stats["enabled_rules"] = countEnabledRules()
+	stats["enabled_rules"] = service.countEnabledRules()
 
 	return stats
 }
 
+// SetFailureQuota caps the share of operations the failure rules fail per minute
+func (service *Service) SetFailureQuota(quota failure.Quota) {
+	service.failureSimulator.SetQuota(quota)
+}
+
 // ResetFailureSimulation resets the failure simulation state
 // Useful for starting a new learning session with fresh statistics
 func (service *Service) ResetFailureSimulation() {
 	service.failureSimulator.Reset()
 }
 
+// SetFailureSimulationEnabled switches failure injection on or off for the whole service.
+// Disabling it keeps the rules and counters, so enabling it again resumes the learning session.
+func (service *Service) SetFailureSimulationEnabled(enabled bool) {
+	service.failureSimulator.SetEnabled(enabled)
+}
+
+// ErrFailureRuleNotFound is returned when a rule name matches none of the learning rules
+var ErrFailureRuleNotFound = errors.New("failure rule not found")
+
+// SetFailureRuleEnabled switches a single learning rule on or off, e.g. so a scripted scenario turns on
+// exactly the failures it demonstrates. ResetFailureSimulation restores the hardcoded defaults.
+func (service *Service) SetFailureRuleEnabled(name string, enabled bool) error {
+	for _, rule := range learningFailureRules {
+		if rule.Name == name {
+			service.failureSimulator.SetRuleEnabled(name, enabled)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrFailureRuleNotFound, name)
+}
+
 // countEnabledRules counts how many rules are currently enabled
-func countEnabledRules() int {
+func (service *Service) countEnabledRules() int {
 	count := 0
 	for _, rule := range learningFailureRules {
-		if rule.Enabled {
+		if service.failureSimulator.RuleEnabled(rule) {
 			count++
 		}
 	}
@@ -115,7 +144,7 @@ func (service *Service) GetLearningScenarios() []map[string]any {
 	for _, rule := range learningFailureRules {
 		scenario := map[string]any{
 			"name":        rule.Name,
-			"enabled":     rule.Enabled,
+			"enabled":     service.failureSimulator.RuleEnabled(rule),
 			"type":        rule.Type,
 			"probability": rule.Probability,
 			"operations":  rule.Operations,
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Sides of an FX quote, from the point of view of the desk quoting the pair
+const (
+	FXSideBid = "BID" // The customer sells the base currency
+	FXSideAsk = "ASK" // The customer buys the base currency
+)
+
+// basisPoint is 0.01%
+var basisPoint = decimal.New(1, -4)
+
+// FXPair is a currency pair quoted as BASE/QUOTE, e.g. EUR/USD, with its bid/ask spread
+type FXPair struct {
+	Base      string
+	Quote     string
+	SpreadBps int64 // Full width between bid and ask, in basis points of the mid rate
+}
+
+// FXPricing configures the spreads and margin applied to currency conversions.
+// The zero value converts at the mid rate.
+type FXPricing struct {
+	DefaultSpreadBps int64    // Spread of pairs that are not listed
+	MarginBps        int64    // Charged on top of the spread, on the side the customer takes
+	Pairs            []FXPair // Pairs with their own spread; a pair also prices its inverse
+}
+
+// fxQuote is the price of one pair for one conversion
+type fxQuote struct {
+	Pair      string
+	Mid       decimal.Decimal // Quote currency per base currency
+	Bid       decimal.Decimal
+	Ask       decimal.Decimal
+	Side      string
+	SpreadBps int64
+	MarginBps int64
+	Rate      decimal.Decimal // Target currency per source currency, after spread and margin
+}
+
+// pair returns the pair quoting the two currencies, or from/to with the default spread when none is listed
+func (pricing FXPricing) pair(from, to string) FXPair {
+	for _, pair := range pricing.Pairs {
+		base, quote := strings.ToUpper(pair.Base), strings.ToUpper(pair.Quote)
+		if (base == from && quote == to) || (base == to && quote == from) {
+			return FXPair{Base: base, Quote: quote, SpreadBps: pair.SpreadBps}
+		}
+	}
+
+	return FXPair{Base: from, Quote: to, SpreadBps: pricing.DefaultSpreadBps}
+}
+
+// quote prices a conversion between two currencies given their rates to USD. Converting out of the base
+// currency takes the bid and converting into it takes the ask, each widened by the margin.
+func (pricing FXPricing) quote(from, to CurrencyInfo) fxQuote {
+	pair := pricing.pair(from.Code, to.Code)
+
+	base, quote := from, to
+	if pair.Base != from.Code {
+		base, quote = to, from
+	}
+
+	mid := (*quote.ExchangeRate).Div(*base.ExchangeRate)
+	halfSpread := basisPoint.Mul(decimal.NewFromInt(pair.SpreadBps)).Div(decimal.NewFromInt(2))
+	margin := basisPoint.Mul(decimal.NewFromInt(pricing.MarginBps))
+
+	result := fxQuote{
+		Pair:      pair.Base + "/" + pair.Quote,
+		Mid:       mid,
+		Bid:       mid.Mul(decimal.NewFromInt(1).Sub(halfSpread)),
+		Ask:       mid.Mul(decimal.NewFromInt(1).Add(halfSpread)),
+		SpreadBps: pair.SpreadBps,
+		MarginBps: pricing.MarginBps,
+	}
+
+	if from.Code == pair.Base {
+		result.Side = FXSideBid
+		result.Rate = result.Bid.Mul(decimal.NewFromInt(1).Sub(margin))
+	} else {
+		result.Side = FXSideAsk
+		result.Rate = decimal.NewFromInt(1).Div(result.Ask.Mul(decimal.NewFromInt(1).Add(margin)))
+	}
+
+	return result
+}
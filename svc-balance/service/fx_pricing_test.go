@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFXPricingQuote(t *testing.T) {
+	base := CurrencyInfo{Code: "AAA", DecimalPlace: 2, ExchangeRate: decimalPtr(decimal.NewFromInt(1))}
+	quote := CurrencyInfo{Code: "BBB", DecimalPlace: 2, ExchangeRate: decimalPtr(decimal.NewFromInt(2))}
+
+	pricing := FXPricing{
+		DefaultSpreadBps: 500,
+		MarginBps:        50,
+		Pairs:            []FXPair{{Base: "AAA", Quote: "BBB", SpreadBps: 100}},
+	}
+
+	tests := []struct {
+		name     string
+		pricing  FXPricing
+		from, to CurrencyInfo
+		wantPair string
+		wantSide string
+		wantBid  string
+		wantAsk  string
+		wantRate decimal.Decimal
+	}{
+		{
+			name:     "selling the base takes the bid",
+			pricing:  pricing,
+			from:     base,
+			to:       quote,
+			wantPair: "AAA/BBB",
+			wantSide: FXSideBid,
+			wantBid:  "1.99",
+			wantAsk:  "2.01",
+			wantRate: decimal.RequireFromString("1.98005"),
+		},
+		{
+			name:     "buying the base takes the ask",
+			pricing:  pricing,
+			from:     quote,
+			to:       base,
+			wantPair: "AAA/BBB",
+			wantSide: FXSideAsk,
+			wantBid:  "1.99",
+			wantAsk:  "2.01",
+			wantRate: decimal.NewFromInt(1).Div(decimal.RequireFromString("2.02005")),
+		},
+		{
+			name:     "unlisted pair uses the default spread",
+			pricing:  FXPricing{DefaultSpreadBps: 200},
+			from:     base,
+			to:       quote,
+			wantPair: "AAA/BBB",
+			wantSide: FXSideBid,
+			wantBid:  "1.98",
+			wantAsk:  "2.02",
+			wantRate: decimal.RequireFromString("1.98"),
+		},
+		{
+			name:     "zero value converts at mid",
+			from:     quote,
+			to:       base,
+			wantPair: "BBB/AAA",
+			wantSide: FXSideBid,
+			wantBid:  "0.5",
+			wantAsk:  "0.5",
+			wantRate: decimal.RequireFromString("0.5"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.pricing.quote(tt.from, tt.to)
+
+			if got.Pair != tt.wantPair || got.Side != tt.wantSide {
+				t.Errorf("quote() pair = %s %s, want %s %s", got.Pair, got.Side, tt.wantPair, tt.wantSide)
+			}
+			if !got.Bid.Equal(decimal.RequireFromString(tt.wantBid)) || !got.Ask.Equal(decimal.RequireFromString(tt.wantAsk)) {
+				t.Errorf("quote() bid/ask = %s/%s, want %s/%s", got.Bid, got.Ask, tt.wantBid, tt.wantAsk)
+			}
+			if !got.Rate.Equal(tt.wantRate) {
+				t.Errorf("quote() rate = %s, want %s", got.Rate, tt.wantRate)
+			}
+		})
+	}
+}
+
+func TestConvertCurrencySpread(t *testing.T) {
+	service := createTestService()
+	service.fxPricing = FXPricing{
+		MarginBps: 25,
+		Pairs:     []FXPair{{Base: "EUR", Quote: "USD", SpreadBps: 10}},
+	}
+
+	result, err := service.ConvertCurrency(context.Background(), ConvertCurrencyParams{
+		Amount:       decimal.RequireFromString("1000.00"),
+		FromCurrency: "USD",
+		ToCurrency:   "EUR",
+	})
+	if err != nil {
+		t.Fatalf("ConvertCurrency() error = %v", err)
+	}
+
+	if result.Pair != "EUR/USD" || result.Side != FXSideAsk {
+		t.Errorf("ConvertCurrency() pair = %s %s, want EUR/USD ASK", result.Pair, result.Side)
+	}
+	if result.SpreadBps != 10 || result.MarginBps != 25 {
+		t.Errorf("ConvertCurrency() spread/margin = %d/%d bps, want 10/25", result.SpreadBps, result.MarginBps)
+	}
+	if !result.BidRate.LessThan(result.MidRate) || !result.AskRate.GreaterThan(result.MidRate) {
+		t.Errorf("ConvertCurrency() bid/mid/ask = %s/%s/%s, want bid < mid < ask", result.BidRate, result.MidRate, result.AskRate)
+	}
+
+	// 1000 USD is 850.00 EUR at mid; the ask and the margin take 0.30% of it
+	if !result.ConvertedAmount.Equal(decimal.RequireFromString("847.46")) {
+		t.Errorf("ConvertCurrency() ConvertedAmount = %s, want 847.46", result.ConvertedAmount)
+	}
+	if !result.SpreadAmount.Equal(decimal.RequireFromString("2.54")) {
+		t.Errorf("ConvertCurrency() SpreadAmount = %s, want 2.54", result.SpreadAmount)
+	}
+}
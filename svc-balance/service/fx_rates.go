@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-balance/store/sqlc"
+
+	"numeric"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// fxRateSourceCatalog is the source of rates taken from the currency catalog of the service
+const fxRateSourceCatalog = "currency_catalog"
+
+// ErrFXRateNotFound is returned when no rate of a currency was recorded at or before the requested time
+var ErrFXRateNotFound = errors.New("fx rate not found")
+
+// ErrInvalidConversionParams is returned for as-of conversions with a missing or unsupported amount, currency or time
+var ErrInvalidConversionParams = errors.New("invalid parameters")
+
+// RecordFXRatesResults summarizes one snapshot of the exchange rates
+type RecordFXRatesResults struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Recorded  int       `json:"recorded"`
+}
+
+// RecordFXRates stores the current rate of every active currency in fx_rates, all with the same fetch time
+func (service *Service) RecordFXRates(ctx context.Context) (*RecordFXRatesResults, error) {
+	const op = "service.Service.RecordFXRates"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]": op,
+	})
+
+	results := &RecordFXRatesResults{FetchedAt: time.Now().UTC()}
+
+	for _, currency := range service.getSupportedCurrencyList(false) {
+		_, err := service.store.CreateFxRate(ctx, sqlc.CreateFxRateParams{
+			Currency:  sqlc.CoreCurrencyCode(currency.Code),
+			RateToUsd: numeric.FromDecimalPtr(currency.ExchangeRate),
+			Source:    fxRateSourceCatalog,
+			FetchedAt: pgtype.Timestamptz{Time: results.FetchedAt, Valid: true},
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to record %s rate: %w", currency.Code, err)
+
+			logger.WithError(err).Error()
+
+			return nil, err
+		}
+
+		results.Recorded++
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Debug()
+
+	return results, nil
+}
+
+// RunFXRateRecorder records the exchange rates right away and then on a fixed interval until the context is cancelled
+func (service *Service) RunFXRateRecorder(ctx context.Context, interval time.Duration) {
+	const op = "service.Service.RunFXRateRecorder"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"interval": interval.String(),
+	})
+
+	logger.Info("Starting FX rate recorder")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := service.RecordFXRates(ctx); err != nil {
+			logger.WithError(err).Warn("FX rate recording failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("FX rate recorder stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ConvertCurrencyAsOfParams represents input parameters for a conversion at the rates of a past moment
+type ConvertCurrencyAsOfParams struct {
+	Amount       decimal.Decimal `json:"amount"`
+	FromCurrency string          `json:"from_currency"`
+	ToCurrency   string          `json:"to_currency"`
+	AsOf         time.Time       `json:"as_of"`
+	Tenant       string          `json:"tenant,omitempty"`
+}
+
+// ConvertCurrencyAsOfResults is a conversion priced with the rates recorded at or before AsOf
+type ConvertCurrencyAsOfResults struct {
+	ConvertCurrencyResults
+	AsOf              time.Time `json:"as_of"`
+	FromRateFetchedAt time.Time `json:"from_rate_fetched_at"`
+	ToRateFetchedAt   time.Time `json:"to_rate_fetched_at"`
+}
+
+// ConvertCurrencyAsOf converts an amount with the exchange rates in effect at a past moment, e.g. the time of the
+// original transfer when reconciling it or handling a dispute. The spread and margin are the current ones.
+func (service *Service) ConvertCurrencyAsOf(ctx context.Context, params ConvertCurrencyAsOfParams) (*ConvertCurrencyAsOfResults, error) {
+	const op = "service.Service.ConvertCurrencyAsOf"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	// Validate input parameters
+	err := service.validateConvertCurrencyParams(ConvertCurrencyParams{
+		Amount:       params.Amount,
+		FromCurrency: params.FromCurrency,
+		ToCurrency:   params.ToCurrency,
+	})
+	if err == nil && params.AsOf.IsZero() {
+		err = fmt.Errorf("as_of is required")
+	}
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrInvalidConversionParams, err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &ConvertCurrencyAsOfResults{AsOf: params.AsOf}
+
+	var fromCurrencyInfo, toCurrencyInfo CurrencyInfo
+	fromCurrencyInfo, results.FromRateFetchedAt, err = service.currencyInfoAsOf(ctx, params.FromCurrency, params.AsOf)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	toCurrencyInfo, results.ToRateFetchedAt, err = service.currencyInfoAsOf(ctx, params.ToCurrency, params.AsOf)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	rounding := service.rounding.Mode(params.Tenant, toCurrencyInfo.Code)
+	results.ConvertCurrencyResults = *service.performCurrencyConversion(params.Amount, fromCurrencyInfo, toCurrencyInfo, rounding)
+	results.FromCurrency = params.FromCurrency
+	results.ToCurrency = params.ToCurrency
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info()
+
+	return results, nil
+}
+
+// currencyInfoAsOf returns the currency with the last rate recorded at or before asOf, and when that rate was fetched
+func (service *Service) currencyInfoAsOf(ctx context.Context, currencyCode string, asOf time.Time) (CurrencyInfo, time.Time, error) {
+	currencyInfo, err := service.getCurrencyInfo(currencyCode)
+	if err != nil {
+		return CurrencyInfo{}, time.Time{}, fmt.Errorf("failed to get currency info: %w", err)
+	}
+
+	rate, err := service.store.GetFxRateAsOf(ctx, sqlc.GetFxRateAsOfParams{
+		Currency:  sqlc.CoreCurrencyCode(currencyInfo.Code),
+		FetchedAt: pgtype.Timestamptz{Time: asOf, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CurrencyInfo{}, time.Time{}, fmt.Errorf("%w: %s as of %s", ErrFXRateNotFound, currencyInfo.Code, asOf.Format(time.RFC3339))
+		}
+
+		return CurrencyInfo{}, time.Time{}, fmt.Errorf("failed to get %s rate: %w", currencyInfo.Code, err)
+	}
+
+	rateToUSD, err := numeric.ToDecimal(rate.RateToUsd)
+	if err != nil {
+		return CurrencyInfo{}, time.Time{}, fmt.Errorf("failed to convert %s rate: %w", currencyInfo.Code, err)
+	}
+
+	currencyInfo.ExchangeRate = &rateToUSD
+
+	return currencyInfo, rate.FetchedAt.Time, nil
+}
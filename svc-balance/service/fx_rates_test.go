@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"svc-balance/store/sqlc"
+
+	"numeric"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+func TestRecordFXRates(t *testing.T) {
+	service := createTestService()
+
+	var recorded []sqlc.CreateFxRateParams
+	service.store = &MockStore{
+		createFxRateFunc: func(ctx context.Context, arg sqlc.CreateFxRateParams) (sqlc.CoreFxRate, error) {
+			recorded = append(recorded, arg)
+			return sqlc.CoreFxRate{}, nil
+		},
+	}
+
+	results, err := service.RecordFXRates(context.Background())
+	if err != nil {
+		t.Fatalf("RecordFXRates() error = %v", err)
+	}
+
+	active := service.getSupportedCurrencyList(false)
+	if results.Recorded != len(active) || len(recorded) != len(active) {
+		t.Fatalf("RecordFXRates() recorded %d rates (%d stored), want %d", results.Recorded, len(recorded), len(active))
+	}
+
+	for _, rate := range recorded {
+		if !rate.FetchedAt.Time.Equal(results.FetchedAt) {
+			t.Errorf("RecordFXRates() %s fetched_at = %v, want %v", rate.Currency, rate.FetchedAt.Time, results.FetchedAt)
+		}
+		if rate.Source != fxRateSourceCatalog {
+			t.Errorf("RecordFXRates() %s source = %q, want %q", rate.Currency, rate.Source, fxRateSourceCatalog)
+		}
+	}
+}
+
+func TestConvertCurrencyAsOf(t *testing.T) {
+	asOf := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	fetchedAt := asOf.Add(-30 * time.Minute)
+
+	// EUR was at 0.80 per USD at the time, not the current 0.85
+	rates := map[sqlc.CoreCurrencyCode]string{"USD": "1", "EUR": "0.80"}
+
+	service := createTestService()
+	service.store = &MockStore{
+		getFxRateAsOfFunc: func(ctx context.Context, arg sqlc.GetFxRateAsOfParams) (sqlc.CoreFxRate, error) {
+			if !arg.FetchedAt.Time.Equal(asOf) {
+				t.Errorf("GetFxRateAsOf() as of %v, want %v", arg.FetchedAt.Time, asOf)
+			}
+			rate, ok := rates[arg.Currency]
+			if !ok {
+				return sqlc.CoreFxRate{}, pgx.ErrNoRows
+			}
+			return sqlc.CoreFxRate{
+				Currency:  arg.Currency,
+				RateToUsd: numeric.FromDecimal(decimal.RequireFromString(rate)),
+				FetchedAt: pgtype.Timestamptz{Time: fetchedAt, Valid: true},
+			}, nil
+		},
+	}
+
+	t.Run("rates of the time", func(t *testing.T) {
+		result, err := service.ConvertCurrencyAsOf(context.Background(), ConvertCurrencyAsOfParams{
+			Amount:       decimal.RequireFromString("100.00"),
+			FromCurrency: "USD",
+			ToCurrency:   "EUR",
+			AsOf:         asOf,
+		})
+		if err != nil {
+			t.Fatalf("ConvertCurrencyAsOf() error = %v", err)
+		}
+
+		if !result.ConvertedAmount.Equal(decimal.RequireFromString("80.00")) {
+			t.Errorf("ConvertCurrencyAsOf() ConvertedAmount = %s, want 80.00", result.ConvertedAmount)
+		}
+		if !result.FromRateFetchedAt.Equal(fetchedAt) || !result.ToRateFetchedAt.Equal(fetchedAt) {
+			t.Errorf("ConvertCurrencyAsOf() rates fetched at %v/%v, want %v", result.FromRateFetchedAt, result.ToRateFetchedAt, fetchedAt)
+		}
+	})
+
+	t.Run("no rate recorded", func(t *testing.T) {
+		_, err := service.ConvertCurrencyAsOf(context.Background(), ConvertCurrencyAsOfParams{
+			Amount:       decimal.RequireFromString("100.00"),
+			FromCurrency: "USD",
+			ToCurrency:   "GBP",
+			AsOf:         asOf,
+		})
+		if !errors.Is(err, ErrFXRateNotFound) {
+			t.Errorf("ConvertCurrencyAsOf() error = %v, want %v", err, ErrFXRateNotFound)
+		}
+	})
+
+	t.Run("missing as_of", func(t *testing.T) {
+		_, err := service.ConvertCurrencyAsOf(context.Background(), ConvertCurrencyAsOfParams{
+			Amount:       decimal.RequireFromString("100.00"),
+			FromCurrency: "USD",
+			ToCurrency:   "EUR",
+		})
+		if !errors.Is(err, ErrInvalidConversionParams) {
+			t.Errorf("ConvertCurrencyAsOf() error = %v, want %v", err, ErrInvalidConversionParams)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// MaxGetBalancesAccounts caps the accounts read by one GetBalances call
+const MaxGetBalancesAccounts = 200
+
+// ErrInvalidGetBalancesQuery is returned for empty or oversized account lists
+var ErrInvalidGetBalancesQuery = errors.New("invalid get balances query")
+
+// GetBalancesParams lists the accounts to read, each by its ID or its account number
+type GetBalancesParams struct {
+	Accounts []string `json:"accounts"`
+}
+
+// AccountBalance is the balance of one account read by GetBalances
+type AccountBalance struct {
+	Account       string          `json:"account"` // The ID or account number it was requested by
+	ID            uuid.UUID       `json:"id"`
+	AccountNumber string          `json:"account_number"`
+	Balance       decimal.Decimal `json:"balance"`
+	Currency      string          `json:"currency"`
+	Status        string          `json:"status"`
+	UpdatedAt     string          `json:"updated_at"`
+	Version       int32           `json:"version"` // Incremented on every balance or status change
+}
+
+// GetBalancesResults holds the balances in the order the accounts were requested, each account once
+type GetBalancesResults struct {
+	Balances []AccountBalance `json:"balances"`
+	NotFound []string         `json:"not_found"`
+}
+
+// GetBalances reads the balances of many accounts with a single query, for dashboards that would otherwise check
+// them one by one. Accounts that do not exist are listed in NotFound rather than failing the call.
+func (service *Service) GetBalances(ctx context.Context, params GetBalancesParams) (*GetBalancesResults, error) {
+	const op = "service.Service.GetBalances"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":     op,
+		"accounts": len(params.Accounts),
+	})
+
+	if len(params.Accounts) == 0 || len(params.Accounts) > MaxGetBalancesAccounts {
+		return nil, fmt.Errorf("%w: between 1 and %d accounts can be read at once", ErrInvalidGetBalancesQuery, MaxGetBalancesAccounts)
+	}
+
+	// Each account is looked up by ID when it parses as one, by account number otherwise
+	requested := make([]string, 0, len(params.Accounts))
+	seen := make(map[string]bool, len(params.Accounts))
+	query := sqlc.GetAccountsByIDsOrNumbersParams{Ids: []pgtype.UUID{}, AccountNumbers: []string{}}
+	for _, account := range params.Accounts {
+		if account == "" {
+			return nil, fmt.Errorf("%w: empty account", ErrInvalidGetBalancesQuery)
+		}
+		if seen[account] {
+			continue
+		}
+		seen[account] = true
+		requested = append(requested, account)
+
+		if accountID, err := uuid.Parse(account); err == nil {
+			query.Ids = append(query.Ids, pgtype.UUID{Bytes: accountID, Valid: true})
+		} else {
+			query.AccountNumbers = append(query.AccountNumbers, account)
+		}
+	}
+
+	accounts, err := service.store.GetAccountsByIDsOrNumbers(ctx, query)
+	if err != nil {
+		err = fmt.Errorf("failed to get accounts: %w", err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	found := make(map[string]sqlc.CoreAccount, 2*len(accounts))
+	for _, account := range accounts {
+		found[uuid.UUID(account.ID.Bytes).String()] = account
+		found[account.AccountNumber] = account
+	}
+
+	results := &GetBalancesResults{
+		Balances: make([]AccountBalance, 0, len(requested)),
+		NotFound: []string{},
+	}
+	for _, key := range requested {
+		lookup := key
+		if accountID, err := uuid.Parse(key); err == nil {
+			lookup = accountID.String() // IDs in upper case or braces are the same account
+		}
+
+		account, ok := found[lookup]
+		if !ok {
+			results.NotFound = append(results.NotFound, key)
+			continue
+		}
+
+		balance, err := service.pgNumericToDecimal(account.Balance)
+		if err != nil {
+			err = fmt.Errorf("failed to convert balance of account %s: %w", account.AccountNumber, err)
+			logger.WithError(err).Error()
+			return nil, err
+		}
+
+		results.Balances = append(results.Balances, AccountBalance{
+			Account:       key,
+			ID:            account.ID.Bytes,
+			AccountNumber: account.AccountNumber,
+			Balance:       balance,
+			Currency:      string(account.Currency),
+			Status:        string(account.Status),
+			UpdatedAt:     account.UpdatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+			Version:       account.Version,
+		})
+	}
+
+	logger.WithFields(logrus.Fields{
+		"found":     len(results.Balances),
+		"not_found": len(results.NotFound),
+	}).Debug("Balances read")
+
+	return results, nil
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestGetBalances(t *testing.T) {
+	t.Parallel()
+
+	savingsID := uuid.New()
+	accounts := []sqlc.CoreAccount{
+		{ID: pgtype.UUID{Bytes: uuid.New(), Valid: true}, AccountNumber: "ACC001", Balance: createPgNumeric("100.00"), Currency: "USD", Status: sqlc.CoreAccountStatusActive},
+		{ID: pgtype.UUID{Bytes: savingsID, Valid: true}, AccountNumber: "ACC002", Balance: createPgNumeric("2500.50"), Currency: "EUR", Status: sqlc.CoreAccountStatusActive},
+	}
+
+	var calls []sqlc.GetAccountsByIDsOrNumbersParams
+
+	service := createTestService()
+	service.store = &MockStore{
+		getAccountsByIDsOrNumbersFunc: func(ctx context.Context, arg sqlc.GetAccountsByIDsOrNumbersParams) ([]sqlc.CoreAccount, error) {
+			calls = append(calls, arg)
+			return accounts, nil
+		},
+	}
+
+	// Requested by number and by an upper-case ID, with a duplicate and an unknown account
+	savings := strings.ToUpper(savingsID.String())
+	results, err := service.GetBalances(context.Background(), GetBalancesParams{Accounts: []string{savings, "ACC001", "ACC404", "ACC001"}})
+	if err != nil {
+		t.Fatalf("GetBalances() error = %v", err)
+	}
+
+	if len(calls) != 1 || len(calls[0].Ids) != 1 || len(calls[0].AccountNumbers) != 2 {
+		t.Errorf("GetAccountsByIDsOrNumbers() called with %+v, want one query with 1 ID and 2 account numbers", calls)
+	}
+	if len(results.Balances) != 2 || results.Balances[0].Account != savings || results.Balances[0].AccountNumber != "ACC002" ||
+		results.Balances[0].Balance.String() != "2500.5" || results.Balances[1].AccountNumber != "ACC001" {
+		t.Errorf("GetBalances() balances = %+v, want ACC002 then ACC001", results.Balances)
+	}
+	if len(results.NotFound) != 1 || results.NotFound[0] != "ACC404" {
+		t.Errorf("GetBalances() not_found = %v, want [ACC404]", results.NotFound)
+	}
+
+	tooMany := make([]string, MaxGetBalancesAccounts+1)
+	for i := range tooMany {
+		tooMany[i] = "ACC001"
+	}
+	for _, params := range []GetBalancesParams{{}, {Accounts: []string{""}}, {Accounts: tooMany}} {
+		if _, err := service.GetBalances(context.Background(), params); !errors.Is(err, ErrInvalidGetBalancesQuery) {
+			t.Errorf("GetBalances(%d accounts) error = %v, want ErrInvalidGetBalancesQuery", len(params.Accounts), err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"svc-balance/util/latency"
+)
+
+// ErrUnknownLatencyProfile is returned when a latency profile name matches none of the profiles
+var ErrUnknownLatencyProfile = errors.New("unknown latency profile")
+
+// InjectLatency delays an activity by the active latency profile. It is the worker's latency hook and runs
+// before the failure simulation, so a slow attempt can still fail afterwards.
+func (service *Service) InjectLatency(ctx context.Context, operation string, heartbeat func()) error {
+	return service.latencyInjector.Wait(ctx, operation, heartbeat)
+}
+
+// SetLatencyProfile switches the simulated latency of this service's activities
+func (service *Service) SetLatencyProfile(name string) error {
+	if err := service.latencyInjector.SetProfile(name); err != nil {
+		return fmt.Errorf("%w: %s", ErrUnknownLatencyProfile, name)
+	}
+
+	return nil
+}
+
+// GetLatencyProfile returns the active latency profile and the available ones
+func (service *Service) GetLatencyProfile() map[string]any {
+	profiles := make([]map[string]any, 0, len(latency.Profiles))
+	for _, name := range latency.Names() {
+		profiles = append(profiles, describeLatencyProfile(latency.Profiles[name]))
+	}
+
+	return map[string]any{
+		"active":    describeLatencyProfile(service.latencyInjector.Profile()),
+		"available": profiles,
+	}
+}
+
+func describeLatencyProfile(profile latency.Profile) map[string]any {
+	return map[string]any{
+		"name":               profile.Name,
+		"description":        profile.Description,
+		"base_ms":            profile.Base.Milliseconds(),
+		"jitter_ms":          profile.Jitter.Milliseconds(),
+		"heartbeat_every_ms": profile.HeartbeatEvery.Milliseconds(),
+	}
+}
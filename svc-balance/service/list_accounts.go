@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultListAccountsLimit is the page size used when ListAccountsParams.Limit is zero
+	DefaultListAccountsLimit = 50
+
+	// MaxListAccountsLimit caps the page size of ListAccounts
+	MaxListAccountsLimit = 500
+)
+
+// ErrInvalidListAccountsQuery is returned for unknown statuses and out-of-range pages
+var ErrInvalidListAccountsQuery = errors.New("invalid list accounts query")
+
+// ListAccountsParams selects one page of accounts with a given status, newest first
+type ListAccountsParams struct {
+	Status string `json:"status"` // Defaults to "active"
+	Limit  int32  `json:"limit"`
+	Offset int32  `json:"offset"`
+}
+
+// AccountSummary is an account as listed by ListAccounts
+type AccountSummary struct {
+	ID            uuid.UUID       `json:"id"`
+	AccountNumber string          `json:"account_number"`
+	AccountName   string          `json:"account_name"`
+	Balance       decimal.Decimal `json:"balance"`
+	Currency      string          `json:"currency"`
+	Status        string          `json:"status"`
+	AccountType   string          `json:"account_type"`
+	CreatedAt     string          `json:"created_at"`
+}
+
+// ListAccounts returns one page of accounts with the requested status
+func (service *Service) ListAccounts(ctx context.Context, params ListAccountsParams) ([]AccountSummary, error) {
+	const op = "service.Service.ListAccounts"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	if params.Status == "" {
+		params.Status = string(sqlc.CoreAccountStatusActive)
+	}
+	if params.Limit == 0 {
+		params.Limit = DefaultListAccountsLimit
+	}
+
+	statuses := []sqlc.CoreAccountStatus{
+		sqlc.CoreAccountStatusActive, sqlc.CoreAccountStatusInactive, sqlc.CoreAccountStatusSuspended, sqlc.CoreAccountStatusClosed,
+	}
+	if !slices.Contains(statuses, sqlc.CoreAccountStatus(params.Status)) {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidListAccountsQuery, params.Status)
+	}
+	if params.Limit < 1 || params.Limit > MaxListAccountsLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidListAccountsQuery, MaxListAccountsLimit)
+	}
+	if params.Offset < 0 {
+		return nil, fmt.Errorf("%w: offset must not be negative", ErrInvalidListAccountsQuery)
+	}
+
+	accounts, err := service.store.GetAccountsByStatus(ctx, sqlc.GetAccountsByStatusParams{
+		Status: sqlc.CoreAccountStatus(params.Status),
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to list accounts: %w", err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	summaries := make([]AccountSummary, 0, len(accounts))
+	for _, account := range accounts {
+		balance, err := service.pgNumericToDecimal(account.Balance)
+		if err != nil {
+			err = fmt.Errorf("failed to convert balance of account %s: %w", account.AccountNumber, err)
+			logger.WithError(err).Error()
+			return nil, err
+		}
+
+		summaries = append(summaries, AccountSummary{
+			ID:            account.ID.Bytes,
+			AccountNumber: account.AccountNumber,
+			AccountName:   account.AccountName,
+			Balance:       balance,
+			Currency:      string(account.Currency),
+			Status:        string(account.Status),
+			AccountType:   string(account.AccountType),
+			CreatedAt:     account.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+
+	return summaries, nil
+}
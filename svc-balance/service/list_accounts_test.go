@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestListAccounts(t *testing.T) {
+	t.Parallel()
+
+	var calls []sqlc.GetAccountsByStatusParams
+
+	service := createTestService()
+	service.store = &MockStore{
+		getAccountsByStatusFunc: func(ctx context.Context, arg sqlc.GetAccountsByStatusParams) ([]sqlc.CoreAccount, error) {
+			calls = append(calls, arg)
+
+			return []sqlc.CoreAccount{{
+				ID:            pgtype.UUID{Bytes: uuid.New(), Valid: true},
+				AccountNumber: "ACC001",
+				AccountName:   "Demo Account",
+				Balance:       createPgNumeric("5000.00"),
+				Currency:      "USD",
+				Status:        arg.Status,
+			}}, nil
+		},
+	}
+
+	accounts, err := service.ListAccounts(context.Background(), ListAccountsParams{})
+	if err != nil {
+		t.Fatalf("ListAccounts() error = %v", err)
+	}
+	if len(accounts) != 1 || accounts[0].AccountNumber != "ACC001" || accounts[0].Balance.String() != "5000" {
+		t.Errorf("ListAccounts() = %+v", accounts)
+	}
+	if calls[0].Status != sqlc.CoreAccountStatusActive || calls[0].Limit != DefaultListAccountsLimit {
+		t.Errorf("GetAccountsByStatus() called with %+v, want active accounts and the default limit", calls[0])
+	}
+
+	for _, params := range []ListAccountsParams{
+		{Status: "frozen"},
+		{Limit: MaxListAccountsLimit + 1},
+		{Offset: -1},
+	} {
+		if _, err := service.ListAccounts(context.Background(), params); !errors.Is(err, ErrInvalidListAccountsQuery) {
+			t.Errorf("ListAccounts(%+v) error = %v, want ErrInvalidListAccountsQuery", params, err)
+		}
+	}
+}
+
+func TestGetAccountSummary(t *testing.T) {
+	t.Parallel()
+
+	accountID := uuid.New()
+	account := sqlc.CoreAccount{
+		ID:            pgtype.UUID{Bytes: accountID, Valid: true},
+		AccountNumber: "ACC001",
+		Balance:       createPgNumeric("250.50"),
+		Currency:      "USD",
+		Status:        sqlc.CoreAccountStatusActive,
+		Version:       7,
+	}
+
+	service := createTestService()
+	service.store = &MockStore{
+		getAccountByIDFunc: func(ctx context.Context, id pgtype.UUID) (sqlc.CoreAccount, error) {
+			if id.Bytes != accountID {
+				return sqlc.CoreAccount{}, pgx.ErrNoRows
+			}
+			return account, nil
+		},
+		getAccountByNumberFunc: func(ctx context.Context, accountNumber string) (sqlc.CoreAccount, error) {
+			if accountNumber != account.AccountNumber {
+				return sqlc.CoreAccount{}, pgx.ErrNoRows
+			}
+			return account, nil
+		},
+	}
+
+	for _, key := range []string{accountID.String(), "ACC001"} {
+		summary, err := service.GetAccountSummary(context.Background(), key)
+		if err != nil {
+			t.Fatalf("GetAccountSummary(%q) error = %v", key, err)
+		}
+		if summary.AccountNumber != "ACC001" || summary.Balance.String() != "250.5" || summary.Version != 7 {
+			t.Errorf("GetAccountSummary(%q) = %+v", key, summary)
+		}
+	}
+
+	if _, err := service.GetAccountSummary(context.Background(), "ACC404"); !errors.Is(err, ErrAccountNotFound) {
+		t.Errorf("GetAccountSummary() error = %v, want ErrAccountNotFound", err)
+	}
+}
+
+func TestSearchAccounts(t *testing.T) {
+	t.Parallel()
+
+	var calls []sqlc.SearchAccountsParams
+
+	service := createTestService()
+	service.store = &MockStore{
+		searchAccountsFunc: func(ctx context.Context, arg sqlc.SearchAccountsParams) ([]sqlc.CoreAccount, error) {
+			calls = append(calls, arg)
+
+			return []sqlc.CoreAccount{{
+				ID:            pgtype.UUID{Bytes: uuid.New(), Valid: true},
+				AccountNumber: "ACC100_01",
+				AccountName:   "Demo Account",
+				Balance:       createPgNumeric("12.50"),
+				Currency:      "EUR",
+				Status:        sqlc.CoreAccountStatusSuspended,
+			}}, nil
+		},
+	}
+
+	accounts, err := service.SearchAccounts(context.Background(), SearchAccountsParams{Query: " 100_ ", Currency: "eur"})
+	if err != nil {
+		t.Fatalf("SearchAccounts() error = %v", err)
+	}
+	if len(accounts) != 1 || accounts[0].AccountNumber != "ACC100_01" || accounts[0].Status != "suspended" {
+		t.Errorf("SearchAccounts() = %+v", accounts)
+	}
+
+	want := sqlc.SearchAccountsParams{
+		Query:    `100\_`,
+		Currency: sqlc.NullCoreCurrencyCode{CoreCurrencyCode: sqlc.CoreCurrencyCodeEUR, Valid: true},
+		RowLimit: DefaultListAccountsLimit,
+	}
+	if calls[0] != want {
+		t.Errorf("SearchAccounts() queried %+v, want %+v: an escaped query, any status and the default limit", calls[0], want)
+	}
+
+	for _, params := range []SearchAccountsParams{
+		{Query: "ab"},
+		{Query: "acc", Currency: "BTC"},
+		{Query: "acc", Status: "frozen"},
+		{Query: "acc", Limit: MaxListAccountsLimit + 1},
+	} {
+		if _, err := service.SearchAccounts(context.Background(), params); !errors.Is(err, ErrInvalidListAccountsQuery) {
+			t.Errorf("SearchAccounts(%+v) error = %v, want ErrInvalidListAccountsQuery", params, err)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// Rounding modes applied when an amount has more decimal places than its currency allows
+const (
+	RoundingHalfUp   RoundingMode = "half_up"   // Ties away from zero, the default
+	RoundingHalfEven RoundingMode = "half_even" // Banker's rounding: ties to the even digit
+	RoundingDown     RoundingMode = "down"      // Toward zero
+	RoundingUp       RoundingMode = "up"        // Away from zero
+)
+
+// ErrInvalidSplit is returned for splits without legs or with weights that do not add up to a positive total
+var ErrInvalidSplit = errors.New("invalid split")
+
+type RoundingMode string
+
+// ParseRoundingMode returns the rounding mode named s, e.g. "half_even"
+func ParseRoundingMode(s string) (RoundingMode, error) {
+	switch mode := RoundingMode(strings.ToLower(s)); mode {
+	case RoundingHalfUp, RoundingHalfEven, RoundingDown, RoundingUp:
+		return mode, nil
+	}
+
+	return "", fmt.Errorf("unknown rounding mode %q", s)
+}
+
+// Round rounds amount to places decimal places
+func (mode RoundingMode) Round(amount decimal.Decimal, places int32) decimal.Decimal {
+	switch mode {
+	case RoundingHalfEven:
+		return amount.RoundBank(places)
+	case RoundingDown:
+		return amount.RoundDown(places)
+	case RoundingUp:
+		return amount.RoundUp(places)
+	default:
+		return amount.Round(places)
+	}
+}
+
+// RoundingRule sets the rounding mode of a tenant, a currency, or a currency within a tenant.
+// An empty tenant or currency matches any.
+type RoundingRule struct {
+	Tenant   string
+	Currency string
+	Mode     RoundingMode
+}
+
+// RoundingPolicy picks the rounding mode of an operation. The zero value rounds half up.
+type RoundingPolicy struct {
+	Default RoundingMode
+	Rules   []RoundingRule
+}
+
+// Mode returns the mode of the most specific rule matching the tenant and currency: tenant and currency,
+// then tenant, then currency, then the default
+func (policy RoundingPolicy) Mode(tenant, currency string) RoundingMode {
+	best, bestScore := policy.Default, 0
+	for _, rule := range policy.Rules {
+		if rule.Tenant != "" && rule.Tenant != tenant {
+			continue
+		}
+		if rule.Currency != "" && !strings.EqualFold(rule.Currency, currency) {
+			continue
+		}
+
+		score := 1
+		if rule.Tenant != "" {
+			score += 2
+		}
+		if rule.Currency != "" {
+			score++
+		}
+
+		if score > bestScore {
+			best, bestScore = rule.Mode, score
+		}
+	}
+
+	if best == "" {
+		return RoundingHalfUp
+	}
+
+	return best
+}
+
+// SplitLeg is one part of a split amount
+type SplitLeg struct {
+	Index  int             `json:"index"`
+	Weight decimal.Decimal `json:"weight"`
+	Amount decimal.Decimal `json:"amount"`
+}
+
+// SplitAmountResults is an amount split by weight. The legs are rounded to the currency, and the remainder
+// line holds what rounding left over, so the legs and the remainder always sum exactly to the amount.
+type SplitAmountResults struct {
+	Amount       decimal.Decimal `json:"amount"`
+	Currency     string          `json:"currency"`
+	RoundingMode RoundingMode    `json:"rounding_mode"`
+	Legs         []SplitLeg      `json:"legs"`
+	Remainder    decimal.Decimal `json:"remainder"` // Negative when rounding gave the legs more than the amount
+}
+
+// splitAmount splits amount in proportion to weights, rounding each leg to places with mode
+func splitAmount(amount decimal.Decimal, weights []decimal.Decimal, places int32, mode RoundingMode) ([]SplitLeg, decimal.Decimal, error) {
+	if len(weights) == 0 {
+		return nil, decimal.Zero, fmt.Errorf("%w: at least one leg is required", ErrInvalidSplit)
+	}
+
+	total := decimal.Zero
+	for _, weight := range weights {
+		if weight.IsNegative() {
+			return nil, decimal.Zero, fmt.Errorf("%w: weights cannot be negative", ErrInvalidSplit)
+		}
+		total = total.Add(weight)
+	}
+	if !total.IsPositive() {
+		return nil, decimal.Zero, fmt.Errorf("%w: weights must add up to more than zero", ErrInvalidSplit)
+	}
+
+	legs := make([]SplitLeg, 0, len(weights))
+	remainder := amount
+	for i, weight := range weights {
+		legAmount := mode.Round(amount.Mul(weight).Div(total), places)
+		legs = append(legs, SplitLeg{Index: i, Weight: weight, Amount: legAmount})
+		remainder = remainder.Sub(legAmount)
+	}
+
+	return legs, remainder, nil
+}
+
+// SplitAmountParams represents input parameters for splitting an amount into legs, e.g. the parts of a multi-leg payment
+type SplitAmountParams struct {
+	Amount   decimal.Decimal   `json:"amount"`
+	Currency string            `json:"currency"`
+	Weights  []decimal.Decimal `json:"weights"` // One per leg, relative to their sum
+	Tenant   string            `json:"tenant,omitempty"`
+}
+
+// SplitAmount splits an amount by weight with the rounding mode of the tenant and currency
+func (service *Service) SplitAmount(ctx context.Context, params SplitAmountParams) (*SplitAmountResults, error) {
+	const op = "service.Service.SplitAmount"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	currencyInfo, err := service.getCurrencyInfo(params.Currency)
+	if err != nil {
+		err = fmt.Errorf("%w: %w", ErrInvalidSplit, err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	mode := service.rounding.Mode(params.Tenant, currencyInfo.Code)
+
+	legs, remainder, err := splitAmount(params.Amount, params.Weights, int32(currencyInfo.DecimalPlace), mode)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &SplitAmountResults{
+		Amount:       params.Amount,
+		Currency:     currencyInfo.Code,
+		RoundingMode: mode,
+		Legs:         legs,
+		Remainder:    remainder,
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info()
+
+	return results, nil
+}
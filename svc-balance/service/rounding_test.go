@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestRoundingModeRound(t *testing.T) {
+	tests := []struct {
+		amount string
+		mode   RoundingMode
+		want   string
+	}{
+		{"2.345", RoundingHalfUp, "2.35"},
+		{"2.345", RoundingHalfEven, "2.34"},
+		{"2.355", RoundingHalfEven, "2.36"},
+		{"2.349", RoundingDown, "2.34"},
+		{"2.341", RoundingUp, "2.35"},
+		{"-2.345", RoundingHalfUp, "-2.35"},
+		{"-2.349", RoundingDown, "-2.34"},
+		{"-2.341", RoundingUp, "-2.35"},
+		{"2.345", "", "2.35"}, // Unset modes round half up
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode)+" "+tt.amount, func(t *testing.T) {
+			got := tt.mode.Round(decimal.RequireFromString(tt.amount), 2)
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("Round(%s) = %s, want %s", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	if mode, err := ParseRoundingMode("HALF_EVEN"); err != nil || mode != RoundingHalfEven {
+		t.Errorf("ParseRoundingMode(HALF_EVEN) = %q, %v, want %q", mode, err, RoundingHalfEven)
+	}
+	if _, err := ParseRoundingMode("ceiling"); err == nil {
+		t.Error("ParseRoundingMode(ceiling) expected error but got none")
+	}
+}
+
+func TestRoundingPolicyMode(t *testing.T) {
+	policy := RoundingPolicy{
+		Default: RoundingHalfUp,
+		Rules: []RoundingRule{
+			{Currency: "JPY", Mode: RoundingDown},
+			{Tenant: "acme", Mode: RoundingHalfEven},
+			{Tenant: "acme", Currency: "JPY", Mode: RoundingUp},
+		},
+	}
+
+	tests := []struct {
+		tenant, currency string
+		want             RoundingMode
+	}{
+		{"", "USD", RoundingHalfUp},
+		{"", "JPY", RoundingDown},
+		{"other", "jpy", RoundingDown},
+		{"acme", "USD", RoundingHalfEven},
+		{"acme", "JPY", RoundingUp},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Mode(tt.tenant, tt.currency); got != tt.want {
+			t.Errorf("Mode(%q, %q) = %q, want %q", tt.tenant, tt.currency, got, tt.want)
+		}
+	}
+
+	if got := (RoundingPolicy{}).Mode("acme", "USD"); got != RoundingHalfUp {
+		t.Errorf("zero policy Mode() = %q, want %q", got, RoundingHalfUp)
+	}
+}
+
+func TestSplitAmount(t *testing.T) {
+	tests := []struct {
+		name          string
+		amount        string
+		currency      string
+		weights       []string
+		rounding      RoundingPolicy
+		wantLegs      []string
+		wantRemainder string
+		wantErr       error
+	}{
+		{
+			name:          "thirds round down into the remainder",
+			amount:        "100.00",
+			currency:      "USD",
+			weights:       []string{"1", "1", "1"},
+			wantLegs:      []string{"33.33", "33.33", "33.33"},
+			wantRemainder: "0.01",
+		},
+		{
+			name:          "rounding up leaves a negative remainder",
+			amount:        "100.00",
+			currency:      "USD",
+			weights:       []string{"1", "1", "1"},
+			rounding:      RoundingPolicy{Default: RoundingUp},
+			wantLegs:      []string{"33.34", "33.34", "33.34"},
+			wantRemainder: "-0.02",
+		},
+		{
+			name:          "yen have no minor units",
+			amount:        "1000",
+			currency:      "JPY",
+			weights:       []string{"0.5", "0.25", "0.25"},
+			rounding:      RoundingPolicy{Rules: []RoundingRule{{Currency: "JPY", Mode: RoundingHalfEven}}},
+			wantLegs:      []string{"500", "250", "250"},
+			wantRemainder: "0",
+		},
+		{
+			name:     "no legs",
+			amount:   "100.00",
+			currency: "USD",
+			wantErr:  ErrInvalidSplit,
+		},
+		{
+			name:     "zero weights",
+			amount:   "100.00",
+			currency: "USD",
+			weights:  []string{"0", "0"},
+			wantErr:  ErrInvalidSplit,
+		},
+		{
+			name:     "unknown currency",
+			amount:   "100.00",
+			currency: "XXX",
+			weights:  []string{"1"},
+			wantErr:  ErrInvalidSplit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := createTestService()
+			service.rounding = tt.rounding
+
+			params := SplitAmountParams{Amount: decimal.RequireFromString(tt.amount), Currency: tt.currency}
+			for _, weight := range tt.weights {
+				params.Weights = append(params.Weights, decimal.RequireFromString(weight))
+			}
+
+			result, err := service.SplitAmount(context.Background(), params)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("SplitAmount() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SplitAmount() error = %v", err)
+			}
+
+			if len(result.Legs) != len(tt.wantLegs) {
+				t.Fatalf("SplitAmount() returned %d legs, want %d", len(result.Legs), len(tt.wantLegs))
+			}
+
+			sum := result.Remainder
+			for i, leg := range result.Legs {
+				if !leg.Amount.Equal(decimal.RequireFromString(tt.wantLegs[i])) {
+					t.Errorf("SplitAmount() leg %d = %s, want %s", i, leg.Amount, tt.wantLegs[i])
+				}
+				sum = sum.Add(leg.Amount)
+			}
+
+			if !result.Remainder.Equal(decimal.RequireFromString(tt.wantRemainder)) {
+				t.Errorf("SplitAmount() remainder = %s, want %s", result.Remainder, tt.wantRemainder)
+			}
+			if !sum.Equal(params.Amount) {
+				t.Errorf("SplitAmount() legs and remainder sum to %s, want %s", sum, params.Amount)
+			}
+		})
+	}
+}
+
+func TestConvertCurrencyRounding(t *testing.T) {
+	service := createTestService()
+	service.rounding = RoundingPolicy{Rules: []RoundingRule{{Tenant: "acme", Currency: "JPY", Mode: RoundingDown}}}
+
+	// 10.015 USD is 1101.65 JPY at the catalog rate of 110
+	params := ConvertCurrencyParams{
+		Amount:       decimal.RequireFromString("10.015"),
+		FromCurrency: "USD",
+		ToCurrency:   "JPY",
+	}
+
+	for tenant, want := range map[string]string{"": "1102", "acme": "1101"} {
+		params.Tenant = tenant
+
+		result, err := service.ConvertCurrency(context.Background(), params)
+		if err != nil {
+			t.Fatalf("ConvertCurrency() error = %v", err)
+		}
+		if !result.ConvertedAmount.Equal(decimal.RequireFromString(want)) {
+			t.Errorf("ConvertCurrency() tenant %q ConvertedAmount = %s, want %s", tenant, result.ConvertedAmount, want)
+		}
+	}
+}
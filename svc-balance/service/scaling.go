@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"svc-balance/util/workerinterceptor"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/client"
+)
+
+// ErrScalingUnavailable is returned when the scaling hints cannot be read because Temporal is not connected yet
+var ErrScalingUnavailable = errors.New("temporal client not available - service is starting up")
+
+// Defaults of the scaling policy
+const (
+	defaultTargetBacklogPerWorker  = 100
+	defaultTargetInFlightPerWorker = 50 // Half the default activity slots of a worker of this service
+	defaultScalingMaxBacklogAge    = 5 * time.Second
+	defaultScalingWindow           = time.Minute
+)
+
+// scalingTaskQueueTypes are the task types the worker of this service polls: it runs activities only, the
+// workflows calling them are hosted elsewhere
+var scalingTaskQueueTypes = []struct {
+	name    string
+	sdkType client.TaskQueueType
+}{
+	{name: "activity", sdkType: client.TaskQueueTypeActivity},
+}
+
+// ScalingPolicy derives the desired worker count from the load of the task queue
+type ScalingPolicy struct {
+	TargetBacklogPerWorker  int           // Waiting tasks one worker is expected to drain
+	TargetInFlightPerWorker int           // Activities one worker is expected to run at once
+	MaxBacklogAge           time.Duration // One more worker is asked for while the oldest task waits longer
+	MinWorkers              int
+	MaxWorkers              int           // 0 is unbounded
+	Window                  time.Duration // Period activity latencies are averaged over
+}
+
+// TaskQueueLoad is the load of one task type of the task queue, summed over its build IDs
+type TaskQueueLoad struct {
+	Type              string  `json:"type"`
+	Pollers           int     `json:"pollers"`
+	Backlog           int64   `json:"backlog"`
+	BacklogAgeSeconds float64 `json:"backlog_age_seconds"`
+	TasksAddRate      float64 `json:"tasks_add_rate"`
+	TasksDispatchRate float64 `json:"tasks_dispatch_rate"`
+}
+
+// ScalingHints is the load of the task queue of this service and the worker count it calls for, meant as the input
+// of an HPA or KEDA scaler
+type ScalingHints struct {
+	TaskQueue string          `json:"task_queue"`
+	Workers   int             `json:"workers"` // Worker processes polling the task queue
+	Load      []TaskQueueLoad `json:"load"`
+
+	// Activity latencies seen by this instance over the window, a sample of those of every worker
+	WindowSeconds float64                             `json:"window_seconds"`
+	Activities    []workerinterceptor.ActivityLatency `json:"activities"`
+
+	Backlog           int64   `json:"backlog"`             // Over every task type
+	BacklogAgeSeconds float64 `json:"backlog_age_seconds"` // Of the oldest waiting task of any type
+	InFlight          float64 `json:"in_flight"`           // Activities running across all workers: dispatch rate times mean duration
+
+	DesiredWorkers int       `json:"desired_workers"`
+	Reason         string    `json:"reason"`
+	CheckedAt      time.Time `json:"checked_at"`
+}
+
+// SetScalingPolicy sets how the desired worker count of the scaling hints is derived
+func (service *Service) SetScalingPolicy(policy ScalingPolicy) {
+	service.scalingPolicy = policy
+}
+
+// GetScalingHints reads the backlog and pollers of the task queue of this service from Temporal and derives the
+// worker count that would drain it
+func (service *Service) GetScalingHints(ctx context.Context) (*ScalingHints, error) {
+	const op = "service.Service.GetScalingHints"
+
+	logger := service.logger.WithField("[op]", op)
+
+	if service.temporalClient == nil {
+		return nil, ErrScalingUnavailable
+	}
+
+	policy := service.resolvedScalingPolicy()
+
+	types := make([]client.TaskQueueType, 0, len(scalingTaskQueueTypes))
+	for _, taskQueueType := range scalingTaskQueueTypes {
+		types = append(types, taskQueueType.sdkType)
+	}
+
+	description, err := service.temporalClient.DescribeTaskQueueEnhanced(ctx, client.DescribeTaskQueueEnhancedOptions{
+		TaskQueue:      service.taskQueue,
+		TaskQueueTypes: types,
+		ReportPollers:  true,
+		ReportStats:    true,
+	})
+	if err != nil {
+		logger.WithError(err).Warn()
+
+		return nil, fmt.Errorf("failed to describe task queue %s: %w", service.taskQueue, err)
+	}
+
+	hints := &ScalingHints{
+		TaskQueue:     service.taskQueue,
+		WindowSeconds: policy.Window.Seconds(),
+		Activities:    workerinterceptor.Recent(policy.Window),
+		CheckedAt:     time.Now().UTC(),
+	}
+
+	workers := map[string]bool{}
+	var activityDispatchRate float64
+	for _, taskQueueType := range scalingTaskQueueTypes {
+		load := TaskQueueLoad{Type: taskQueueType.name}
+		pollers := map[string]bool{}
+		for _, version := range description.VersionsInfo {
+			info, ok := version.TypesInfo[taskQueueType.sdkType]
+			if !ok {
+				continue
+			}
+
+			for _, poller := range info.Pollers {
+				pollers[poller.Identity] = true
+				workers[poller.Identity] = true
+			}
+
+			if info.Stats != nil {
+				load.Backlog += info.Stats.ApproximateBacklogCount
+				load.BacklogAgeSeconds = max(load.BacklogAgeSeconds, info.Stats.ApproximateBacklogAge.Seconds())
+				load.TasksAddRate += float64(info.Stats.TasksAddRate)
+				load.TasksDispatchRate += float64(info.Stats.TasksDispatchRate)
+			}
+		}
+
+		load.Pollers = len(pollers)
+		hints.Load = append(hints.Load, load)
+		hints.Backlog += load.Backlog
+		hints.BacklogAgeSeconds = max(hints.BacklogAgeSeconds, load.BacklogAgeSeconds)
+		if taskQueueType.sdkType == client.TaskQueueTypeActivity {
+			activityDispatchRate = load.TasksDispatchRate
+		}
+	}
+
+	hints.Workers = len(workers)
+	hints.InFlight = activityDispatchRate * meanActivityDuration(hints.Activities)
+	hints.DesiredWorkers, hints.Reason = desiredWorkers(policy, hints.Workers, hints.Backlog, hints.BacklogAgeSeconds, hints.InFlight)
+
+	logger.WithFields(logrus.Fields{
+		"workers":         hints.Workers,
+		"backlog":         hints.Backlog,
+		"in_flight":       hints.InFlight,
+		"desired_workers": hints.DesiredWorkers,
+	}).Debug()
+
+	return hints, nil
+}
+
+// resolvedScalingPolicy returns the scaling policy with its unset fields defaulted
+func (service *Service) resolvedScalingPolicy() ScalingPolicy {
+	policy := service.scalingPolicy
+	if policy.TargetBacklogPerWorker <= 0 {
+		policy.TargetBacklogPerWorker = defaultTargetBacklogPerWorker
+	}
+	if policy.TargetInFlightPerWorker <= 0 {
+		policy.TargetInFlightPerWorker = defaultTargetInFlightPerWorker
+	}
+	if policy.MaxBacklogAge <= 0 {
+		policy.MaxBacklogAge = defaultScalingMaxBacklogAge
+	}
+	if policy.MinWorkers <= 0 {
+		policy.MinWorkers = 1
+	}
+	if policy.Window <= 0 {
+		policy.Window = defaultScalingWindow
+	}
+
+	return policy
+}
+
+// meanActivityDuration returns the mean duration of the activities, weighted by their executions
+func meanActivityDuration(activities []workerinterceptor.ActivityLatency) float64 {
+	var executions int
+	var total float64
+	for _, latency := range activities {
+		executions += latency.Executions
+		total += latency.AvgDurationSeconds * float64(latency.Executions)
+	}
+
+	if executions == 0 {
+		return 0
+	}
+
+	return total / float64(executions)
+}
+
+// desiredWorkers returns the workers needed to drain backlog and run inFlight activities at the targets of policy,
+// one more than workers while the oldest task waited longer than the policy allows, within its bounds. The reason
+// names what set the count.
+func desiredWorkers(policy ScalingPolicy, workers int, backlog int64, backlogAgeSeconds, inFlight float64) (int, string) {
+	desired, reason := 0, "idle"
+
+	if byBacklog := int(math.Ceil(float64(backlog) / float64(policy.TargetBacklogPerWorker))); byBacklog > desired {
+		desired = byBacklog
+		reason = fmt.Sprintf("%d tasks waiting at %d per worker", backlog, policy.TargetBacklogPerWorker)
+	}
+
+	if byInFlight := int(math.Ceil(inFlight / float64(policy.TargetInFlightPerWorker))); byInFlight > desired {
+		desired = byInFlight
+		reason = fmt.Sprintf("%.1f activities in flight at %d per worker", inFlight, policy.TargetInFlightPerWorker)
+	}
+
+	if backlogAgeSeconds > policy.MaxBacklogAge.Seconds() && desired <= workers {
+		desired = workers + 1
+		reason = fmt.Sprintf("tasks wait %.0fs for a worker, more than %.0fs", backlogAgeSeconds, policy.MaxBacklogAge.Seconds())
+	}
+
+	if desired < policy.MinWorkers {
+		desired = policy.MinWorkers
+		reason += ", raised to min_workers"
+	}
+
+	if policy.MaxWorkers > 0 && desired > policy.MaxWorkers {
+		desired = policy.MaxWorkers
+		reason += ", capped at max_workers"
+	}
+
+	return desired, reason
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MinSearchAccountsQueryLength is the shortest query the trigram indexes can serve
+	MinSearchAccountsQueryLength = 3
+
+	// MaxSearchAccountsQueryLength caps the length of a search query
+	MaxSearchAccountsQueryLength = 100
+)
+
+// likeEscaper escapes the pattern characters of ILIKE, so a query matches them literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchAccountsParams selects one page of the accounts matching a search query, by account number
+type SearchAccountsParams struct {
+	Query    string `json:"query"`    // Part of the account number or the start of the account name, case-insensitive
+	Currency string `json:"currency"` // Optional
+	Status   string `json:"status"`   // Optional; accounts of any status match when empty
+	Limit    int32  `json:"limit"`
+	Offset   int32  `json:"offset"`
+}
+
+// SearchAccounts returns the accounts whose account number contains the query or whose name starts with it, for
+// support staff looking up a customer's account from what they were told
+func (service *Service) SearchAccounts(ctx context.Context, params SearchAccountsParams) ([]AccountSummary, error) {
+	const op = "service.Service.SearchAccounts"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	params.Query = strings.TrimSpace(params.Query)
+	if params.Limit == 0 {
+		params.Limit = DefaultListAccountsLimit
+	}
+
+	if length := len([]rune(params.Query)); length < MinSearchAccountsQueryLength || length > MaxSearchAccountsQueryLength {
+		return nil, fmt.Errorf("%w: query must be between %d and %d characters", ErrInvalidListAccountsQuery, MinSearchAccountsQueryLength, MaxSearchAccountsQueryLength)
+	}
+	if params.Limit < 1 || params.Limit > MaxListAccountsLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidListAccountsQuery, MaxListAccountsLimit)
+	}
+	if params.Offset < 0 {
+		return nil, fmt.Errorf("%w: offset must not be negative", ErrInvalidListAccountsQuery)
+	}
+
+	arg := sqlc.SearchAccountsParams{
+		Query:     likeEscaper.Replace(params.Query),
+		RowLimit:  params.Limit,
+		RowOffset: params.Offset,
+	}
+
+	if params.Currency != "" {
+		currency := strings.ToUpper(params.Currency)
+		if !slices.ContainsFunc(service.getSupportedCurrencyList(false), func(info CurrencyInfo) bool { return info.Code == currency }) {
+			return nil, fmt.Errorf("%w: unsupported currency %q", ErrInvalidListAccountsQuery, params.Currency)
+		}
+
+		arg.Currency = sqlc.NullCoreCurrencyCode{CoreCurrencyCode: sqlc.CoreCurrencyCode(currency), Valid: true}
+	}
+
+	if params.Status != "" {
+		statuses := []sqlc.CoreAccountStatus{
+			sqlc.CoreAccountStatusActive, sqlc.CoreAccountStatusInactive, sqlc.CoreAccountStatusSuspended, sqlc.CoreAccountStatusClosed,
+		}
+		if !slices.Contains(statuses, sqlc.CoreAccountStatus(params.Status)) {
+			return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidListAccountsQuery, params.Status)
+		}
+
+		arg.Status = sqlc.NullCoreAccountStatus{CoreAccountStatus: sqlc.CoreAccountStatus(params.Status), Valid: true}
+	}
+
+	accounts, err := service.store.SearchAccounts(ctx, arg)
+	if err != nil {
+		err = fmt.Errorf("failed to search accounts: %w", err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	summaries := make([]AccountSummary, 0, len(accounts))
+	for _, account := range accounts {
+		balance, err := service.pgNumericToDecimal(account.Balance)
+		if err != nil {
+			err = fmt.Errorf("failed to convert balance of account %s: %w", account.AccountNumber, err)
+			logger.WithError(err).Error()
+			return nil, err
+		}
+
+		summaries = append(summaries, AccountSummary{
+			ID:            account.ID.Bytes,
+			AccountNumber: account.AccountNumber,
+			AccountName:   account.AccountName,
+			Balance:       balance,
+			Currency:      string(account.Currency),
+			Status:        string(account.Status),
+			AccountType:   string(account.AccountType),
+			CreatedAt:     account.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+
+	return summaries, nil
+}
@@ -1,10 +1,18 @@
 package service
 
 import (
+	"time"
+
 	"svc-balance/store"
 	"svc-balance/util/failure"
+	"svc-balance/util/latency"
+	"svc-balance/util/notification"
+	"svc-balance/util/rules"
+
+	"numeric"
 
 	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/client"
 )
 
 type Service struct {
@@ -13,17 +21,58 @@ type Service struct {
 	store store.IStore
 
 	failureSimulator *failure.Simulator
+	latencyInjector  *latency.Injector
+
+	notifier    notification.Notifier
+	emailSender notification.EmailSender
+
+	fxPricing FXPricing
+	rounding  RoundingPolicy
+
+	businessRules *rules.Cache
+	// Business rules of severity error only warn, for the learning profile
+	relaxedValidation bool
+
+	// Precision and scale every amount must fit; zero uses numeric.DefaultPrecision
+	amountPrecision numeric.Precision
+
+	// Set once Temporal is reachable; used to read the load of the task queue of this service's worker
+	temporalClient client.Client
+	taskQueue      string
+
+	// How the desired worker count of the scaling hints is derived; zero fields use the defaults
+	scalingPolicy ScalingPolicy
 }
 
 func NewService(
 	logger *logrus.Logger,
 	store store.IStore,
+	fxPricing FXPricing,
+	rounding RoundingPolicy,
+	businessRulesRefresh time.Duration,
 ) *Service {
-	return &Service{
+	service := &Service{
 		logger: logger,
 
 		store: store,
 
 		failureSimulator: failure.NewSimulator(logger),
+		latencyInjector:  latency.NewInjector(logger),
+
+		notifier:    notification.NewWebhookNotifier(logger, 10*time.Second),
+		emailSender: notification.NewLogSender(logger),
+
+		fxPricing: fxPricing,
+		rounding:  rounding,
 	}
+
+	service.businessRules = rules.NewCache(service.loadBusinessRules, businessRulesRefresh)
+
+	return service
+}
+
+// SetTemporalClient sets the Temporal client and the task queue of this service's worker (used for delayed connection)
+func (service *Service) SetTemporalClient(temporalClient client.Client, taskQueue string) {
+	service.temporalClient = temporalClient
+	service.taskQueue = taskQueue
 }
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-balance/util/notification"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrInvalidTransferEvent is returned for transfer events that cannot be delivered whatever the retries
+var ErrInvalidTransferEvent = errors.New("invalid transfer event")
+
+// NotifyTransferEventParams describes a transfer event to deliver to the callback URL given by the caller
+// of the transfer, e.g. when the transfer expired awaiting approval
+type NotifyTransferEventParams struct {
+	Destination string
+	EventType   string
+	TransferID  string
+	FromAccount string
+	ToAccount   string
+	Amount      decimal.Decimal
+	Currency    string
+	Reason      string
+	OccurredAt  time.Time
+}
+
+// NotifyTransferEvent delivers a transfer event to its destination webhook
+func (service *Service) NotifyTransferEvent(ctx context.Context, params NotifyTransferEventParams) error {
+	const op = "service.Service.NotifyTransferEvent"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"event_type":  params.EventType,
+		"transfer_id": params.TransferID,
+	})
+
+	if params.Destination == "" || params.EventType == "" {
+		err := fmt.Errorf("%w: destination and event type are required", ErrInvalidTransferEvent)
+
+		logger.WithError(err).Error()
+
+		return err
+	}
+
+	event := notification.Event{
+		Type:       params.EventType,
+		OccurredAt: params.OccurredAt.UTC(),
+		Data: map[string]any{
+			"transfer_id":  params.TransferID,
+			"from_account": params.FromAccount,
+			"to_account":   params.ToAccount,
+			"amount":       params.Amount.String(),
+			"currency":     params.Currency,
+			"reason":       params.Reason,
+		},
+	}
+
+	if err := service.notifier.Notify(ctx, params.Destination, event); err != nil {
+		err = fmt.Errorf("failed to notify transfer event: %w", err)
+
+		logger.WithError(err).Warn()
+
+		return err
+	}
+
+	return nil
+}
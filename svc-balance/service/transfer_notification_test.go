@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"svc-balance/util/notification"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotifier keeps the events it is asked to deliver
+type recordingNotifier struct {
+	destinations []string
+	events       []notification.Event
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, destination string, event notification.Event) error {
+	n.destinations = append(n.destinations, destination)
+	n.events = append(n.events, event)
+	return nil
+}
+
+func TestNotifyTransferEvent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("delivers the event to the destination", func(t *testing.T) {
+		t.Parallel()
+
+		notifier := &recordingNotifier{}
+		service := createTestService()
+		service.notifier = notifier
+
+		occurredAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+		err := service.NotifyTransferEvent(context.Background(), NotifyTransferEventParams{
+			Destination: "https://example.com/hooks",
+			EventType:   "transfer.expired",
+			TransferID:  "transfer-123",
+			FromAccount: "111111111111",
+			ToAccount:   "222222222222",
+			Amount:      decimal.RequireFromString("100.50"),
+			Currency:    "USD",
+			Reason:      "transfer was not approved within 3600s",
+			OccurredAt:  occurredAt,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, notifier.events, 1)
+		assert.Equal(t, "https://example.com/hooks", notifier.destinations[0])
+		assert.Equal(t, "transfer.expired", notifier.events[0].Type)
+		assert.Equal(t, occurredAt, notifier.events[0].OccurredAt)
+		assert.Equal(t, "100.5", notifier.events[0].Data["amount"])
+		assert.Equal(t, "transfer-123", notifier.events[0].Data["transfer_id"])
+	})
+
+	t.Run("rejects events without a destination", func(t *testing.T) {
+		t.Parallel()
+
+		notifier := &recordingNotifier{}
+		service := createTestService()
+		service.notifier = notifier
+
+		err := service.NotifyTransferEvent(context.Background(), NotifyTransferEventParams{EventType: "transfer.expired"})
+
+		assert.ErrorIs(t, err, ErrInvalidTransferEvent)
+		assert.Empty(t, notifier.events)
+	})
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// QuoteTransferParams represents input parameters for quoting a transfer
+type QuoteTransferParams struct {
+	ToAccount string          `json:"to_account"` // Account ID or account number of the destination
+	Amount    decimal.Decimal `json:"amount"`     // Debited from the source account, in Currency
+	Currency  string          `json:"currency"`
+}
+
+// QuoteTransfer prices the credit of a transfer in the currency of its destination account. Transfers between
+// accounts of the same currency are credited the amount as is and the result has ConversionApplied unset;
+// otherwise the amount is converted with the spread and margin of the pair, as ConvertCurrency does.
+func (service *Service) QuoteTransfer(ctx context.Context, params QuoteTransferParams) (*ConvertCurrencyResults, error) {
+	const op = "service.Service.QuoteTransfer"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	destination, err := service.GetAccountSummary(ctx, params.ToAccount)
+	if err != nil {
+		err = fmt.Errorf("failed to get destination account: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	result, err := service.ConvertCurrency(ctx, ConvertCurrencyParams{
+		Amount:       params.Amount,
+		FromCurrency: params.Currency,
+		ToCurrency:   destination.Currency,
+	})
+	if err != nil {
+		// ConvertCurrency only fails on its parameters, so the transfer cannot be quoted on a retry either
+		err = fmt.Errorf("%w: %v", ErrInvalidCurrency, err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	return result, nil
+}
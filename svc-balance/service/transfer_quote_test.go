@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteTransfer(t *testing.T) {
+	t.Parallel()
+
+	service := createTestService()
+	service.fxPricing = FXPricing{
+		MarginBps: 25,
+		Pairs:     []FXPair{{Base: "EUR", Quote: "USD", SpreadBps: 10}},
+	}
+	service.store = &MockStore{
+		getAccountByNumberFunc: func(ctx context.Context, accountNumber string) (sqlc.CoreAccount, error) {
+			switch accountNumber {
+			case "ACC001":
+				return sqlc.CoreAccount{AccountNumber: accountNumber, Currency: sqlc.CoreCurrencyCodeUSD}, nil
+			case "ACC002":
+				return sqlc.CoreAccount{AccountNumber: accountNumber, Currency: sqlc.CoreCurrencyCodeEUR}, nil
+			}
+			return sqlc.CoreAccount{}, pgx.ErrNoRows
+		},
+	}
+
+	t.Run("same currency is credited as is", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := service.QuoteTransfer(context.Background(), QuoteTransferParams{
+			ToAccount: "ACC001",
+			Amount:    decimal.RequireFromString("1000.00"),
+			Currency:  "USD",
+		})
+		require.NoError(t, err)
+		assert.False(t, result.ConversionApplied)
+		assert.True(t, result.ConvertedAmount.Equal(decimal.RequireFromString("1000.00")))
+		assert.Equal(t, "USD", result.ToCurrency)
+	})
+
+	t.Run("other currency applies the spread", func(t *testing.T) {
+		t.Parallel()
+
+		result, err := service.QuoteTransfer(context.Background(), QuoteTransferParams{
+			ToAccount: "ACC002",
+			Amount:    decimal.RequireFromString("1000.00"),
+			Currency:  "USD",
+		})
+		require.NoError(t, err)
+		assert.True(t, result.ConversionApplied)
+		assert.Equal(t, "EUR", result.ToCurrency)
+		assert.Equal(t, "EUR/USD", result.Pair)
+		assert.Equal(t, FXSideAsk, result.Side)
+		assert.True(t, result.ConvertedAmount.Equal(decimal.RequireFromString("847.46")), result.ConvertedAmount.String())
+		assert.True(t, result.SpreadAmount.Equal(decimal.RequireFromString("2.54")), result.SpreadAmount.String())
+	})
+
+	t.Run("unknown destination", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := service.QuoteTransfer(context.Background(), QuoteTransferParams{
+			ToAccount: "ACC999",
+			Amount:    decimal.RequireFromString("1000.00"),
+			Currency:  "USD",
+		})
+		assert.ErrorIs(t, err, ErrAccountNotFound)
+	})
+}
@@ -3,10 +3,35 @@ package service
 import (
 	"fmt"
 
+	"numeric"
+
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
 
+// ErrPrecisionExceeded is returned for amounts the numeric columns cannot store as they are
+var ErrPrecisionExceeded = numeric.ErrPrecisionExceeded
+
+// SetAmountPrecision sets the precision and scale amounts are checked against; zero keeps numeric.DefaultPrecision
+func (service *Service) SetAmountPrecision(precision numeric.Precision) {
+	service.amountPrecision = precision
+}
+
+// checkAmountPrecision returns ErrPrecisionExceeded for amounts the numeric columns would round or reject, so they
+// are refused instead of being stored or compared as a different value
+func (service *Service) checkAmountPrecision(field string, amount decimal.Decimal) error {
+	precision := service.amountPrecision
+	if precision == (numeric.Precision{}) {
+		precision = numeric.DefaultPrecision
+	}
+
+	if err := precision.Check(amount); err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+
+	return nil
+}
+
 // validateCurrency validates currency code using the comprehensive currency system
 func (service *Service) validateCurrency(currency string) error {
 	_, err := service.getCurrencyInfo(currency)
@@ -16,26 +41,16 @@ func (service *Service) validateCurrency(currency string) error {
 	return nil
 }
 
-// pgNumericToDecimal converts pgtype.Numeric to decimal.Decimal
+// decimalToPgNumeric converts a decimal.Decimal to pgtype.Numeric
+func (service *Service) decimalToPgNumeric(d decimal.Decimal) pgtype.Numeric {
+	return numeric.FromDecimal(d)
+}
+
+// pgNumericToDecimal converts pgtype.Numeric to decimal.Decimal, treating NULL as zero
 func (service *Service) pgNumericToDecimal(pgNum pgtype.Numeric) (decimal.Decimal, error) {
 	if !pgNum.Valid {
 		return decimal.Zero, nil
 	}
 
-	// Convert pgtype.Numeric to string then to decimal.Decimal
-	str := pgNum.Int.String()
-	if pgNum.Exp < 0 {
-		// Add decimal point
-		exp := int(-pgNum.Exp)
-		if len(str) <= exp {
-			// Pad with zeros
-			str = "0." + fmt.Sprintf("%0*s", exp, str)
-		} else {
-			// Insert decimal point
-			pos := len(str) - exp
-			str = str[:pos] + "." + str[pos:]
-		}
-	}
-
-	return decimal.NewFromString(str)
+	return numeric.ToDecimal(pgNum)
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
 )
 
 func TestValidateCurrency(t *testing.T) {
@@ -237,7 +238,7 @@ func TestPgNumericToDecimalEdgeCases(t *testing.T) {
 		}
 	})
 
-	// Test with positive exponent (should not happen in normal PostgreSQL usage but test for robustness)
+	// Test with positive exponent (pgx produces these for values with trailing zeros, e.g. 12300)
 	t.Run("Positive exponent", func(t *testing.T) {
 		t.Parallel()
 
@@ -253,9 +254,30 @@ func TestPgNumericToDecimalEdgeCases(t *testing.T) {
 			return
 		}
 
-		// With positive exponent, it should just return the integer as-is
-		if result.String() != "123" {
-			t.Errorf("pgNumericToDecimal() = %s, want %s", result.String(), "123")
+		if result.String() != "12300" {
+			t.Errorf("pgNumericToDecimal() = %s, want %s", result.String(), "12300")
 		}
 	})
 }
+
+func BenchmarkDecimalToPgNumeric(b *testing.B) {
+	service := createTestService()
+	amount := decimal.RequireFromString("12345.6789")
+
+	b.ReportAllocs()
+	for range b.N {
+		service.decimalToPgNumeric(amount)
+	}
+}
+
+func BenchmarkPgNumericToDecimal(b *testing.B) {
+	service := createTestService()
+	amount := createPgNumeric("12345.6789")
+
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := service.pgNumericToDecimal(amount); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -3,8 +3,11 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"svc-balance/store/sqlc"
+	"svc-balance/util/accounttype"
+	"svc-balance/util/rules"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -14,7 +17,7 @@ import (
 
 // ValidationResult represents a single validation check result
 type ValidationResult struct {
-	Type     string `json:"type"`     // "status", "balance", "currency", "business_rule"
+	Type     string `json:"type"`     // "status", "balance", "currency", "account_type", "business_rule"
 	Rule     string `json:"rule"`     // Specific rule name
 	Passed   bool   `json:"passed"`   // Whether validation passed
 	Message  string `json:"message"`  // Validation message
@@ -49,6 +52,7 @@ type ValidateAccountResults struct {
 	AccountName   string    `json:"account_name"`
 	Status        string    `json:"status"`
 	Currency      string    `json:"currency"`
+	AccountType   string    `json:"account_type"`
 
 	// Validation results
 	IsValid     bool               `json:"is_valid"`
@@ -89,8 +93,16 @@ func (service *Service) ValidateAccount(ctx context.Context, params ValidateAcco
 		return nil, err
 	}
 
+	// Count the withdrawals of this month for account types that limit them
+	withdrawals, err := service.countWithdrawalsForValidation(ctx, account, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
 	// Perform validations
-	result, err := service.performAccountValidations(account, params)
+	result, err := service.performAccountValidations(account, params, service.businessRuleEngine(ctx, logger), withdrawals)
 	if err != nil {
 		err = fmt.Errorf("failed to perform validations: %w", err)
 
@@ -138,6 +150,11 @@ func (service *Service) validateAccountValidationParams(params ValidateAccountPa
 	if params.TransactionAmount != nil && params.TransactionAmount.IsNegative() {
 		return fmt.Errorf("transaction_amount cannot be negative")
 	}
+	if params.TransactionAmount != nil {
+		if err := service.checkAmountPrecision("transaction_amount", *params.TransactionAmount); err != nil {
+			return err
+		}
+	}
 
 	// Validate expected currency if provided
 	if params.ExpectedCurrency != nil {
@@ -166,7 +183,7 @@ func (service *Service) getAccountForValidation(ctx context.Context, params Vali
 }
 
 // performAccountValidations performs all requested validation checks
-func (service *Service) performAccountValidations(account sqlc.CoreAccount, params ValidateAccountParams) (*ValidateAccountResults, error) {
+func (service *Service) performAccountValidations(account sqlc.CoreAccount, params ValidateAccountParams, engine *rules.Engine, withdrawals int64) (*ValidateAccountResults, error) {
 	// Convert UUID
 	accountID, err := uuid.FromBytes(account.ID.Bytes[:])
 	if err != nil {
@@ -180,6 +197,7 @@ func (service *Service) performAccountValidations(account sqlc.CoreAccount, para
 		AccountName:   account.AccountName,
 		Status:        string(account.Status),
 		Currency:      string(account.Currency),
+		AccountType:   string(account.AccountType),
 		IsValid:       true,
 		CanTransact:   true,
 		Validations:   []ValidationResult{},
@@ -202,9 +220,14 @@ func (service *Service) performAccountValidations(account sqlc.CoreAccount, para
 		service.validateAccountCurrency(result, account, params)
 	}
 
+	// Perform account type validation for debits
+	if isDebit(params) {
+		service.validateAccountType(result, account, withdrawals)
+	}
+
 	// Perform business rules validation (default: enabled)
 	if params.ValidateBusinessRules {
-		if err := service.validateBusinessRules(result, account, params); err != nil {
+		if err := service.validateBusinessRules(result, account, params, engine); err != nil {
 			return nil, err
 		}
 	}
@@ -251,7 +274,7 @@ func (service *Service) validateAccountBalance(result *ValidateAccountResults, a
 	}
 
 	// Only validate balance for debit transactions or when transaction amount is provided
-	if params.TransactionType != nil && *params.TransactionType == "debit" && params.TransactionAmount != nil {
+	if isDebit(params) && params.TransactionAmount != nil {
 		if balance.GreaterThanOrEqual(*params.TransactionAmount) {
 			validation.Passed = true
 			validation.Message = fmt.Sprintf("Sufficient funds available (Balance: %s, Required: %s)", balance.String(), params.TransactionAmount.String())
@@ -319,8 +342,47 @@ func (service *Service) validateAccountCurrency(result *ValidateAccountResults,
 	result.Validations = append(result.Validations, validation)
 }
 
-// validateBusinessRules validates business-specific rules
-func (service *Service) validateBusinessRules(result *ValidateAccountResults, account sqlc.CoreAccount, params ValidateAccountParams) error {
+// countWithdrawalsForValidation counts the withdrawals of this month of accounts whose type limits them, for debit
+// validations; 0 otherwise
+func (service *Service) countWithdrawalsForValidation(ctx context.Context, account sqlc.CoreAccount, params ValidateAccountParams) (int64, error) {
+	if !isDebit(params) || !accounttype.PolicyOf(accounttype.Type(account.AccountType)).Limited() {
+		return 0, nil
+	}
+
+	withdrawals, err := service.store.CountOutgoingDebits(ctx, sqlc.CountOutgoingDebitsParams{
+		AccountID:   account.ID,
+		CreatedFrom: pgtype.Timestamptz{Time: accounttype.MonthStart(time.Now()), Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count withdrawals of this month: %w", err)
+	}
+
+	return withdrawals, nil
+}
+
+// validateAccountType validates that the type of the account allows one more withdrawal this month
+func (service *Service) validateAccountType(result *ValidateAccountResults, account sqlc.CoreAccount, withdrawals int64) {
+	allowed, message := accounttype.PolicyOf(accounttype.Type(account.AccountType)).CheckWithdrawal(withdrawals)
+
+	validation := ValidationResult{
+		Type:     "account_type",
+		Rule:     "monthly_withdrawals",
+		Passed:   allowed,
+		Message:  fmt.Sprintf("%s account: %s", account.AccountType, message),
+		Severity: "info",
+	}
+
+	if !allowed {
+		validation.Severity = "error"
+		result.IsValid = false
+		result.CanTransact = false
+	}
+
+	result.Validations = append(result.Validations, validation)
+}
+
+// validateBusinessRules validates business-specific rules: the version check and the rules of engine
+func (service *Service) validateBusinessRules(result *ValidateAccountResults, account sqlc.CoreAccount, params ValidateAccountParams, engine *rules.Engine) error {
 	// Business Rule 1: Account version consistency
 	versionValidation := ValidationResult{
 		Type:     "business_rule",
@@ -338,52 +400,51 @@ func (service *Service) validateBusinessRules(result *ValidateAccountResults, ac
 
 	result.Validations = append(result.Validations, versionValidation)
 
-	// Business Rule 2: Account name consistency
-	nameValidation := ValidationResult{
-		Type: "business_rule",
-		Rule: "name_format",
+	// Configured rules: account name length, transaction limits and minimum balances, per currency
+	facts := rules.Facts{
+		Currency:    string(account.Currency),
+		AccountName: &account.AccountName,
+		Amount:      params.TransactionAmount,
 	}
 
-	if account.AccountName != "" && len(account.AccountName) >= 2 {
-		nameValidation.Passed = true
-		nameValidation.Message = "Account name format is valid"
-		nameValidation.Severity = "info"
-	} else {
-		nameValidation.Passed = false
-		nameValidation.Message = "Account name is too short or empty"
-		nameValidation.Severity = "warning"
-		// Don't fail validation for name format issues
+	balance, err := service.pgNumericToDecimal(account.Balance)
+	if err != nil {
+		return fmt.Errorf("invalid balance format: %w", err)
 	}
-
-	result.Validations = append(result.Validations, nameValidation)
-
-	// Business Rule 3: Transaction limits for large amounts
-	if params.TransactionAmount != nil {
-		limitValidation := ValidationResult{
-			Type: "business_rule",
-			Rule: "transaction_limits",
+	if isDebit(params) && params.TransactionAmount != nil {
+		balance = balance.Sub(*params.TransactionAmount)
+	}
+	facts.Balance = &balance
+
+	for _, outcome := range engine.Evaluate(facts) {
+		validation := ValidationResult{
+			Type:     "business_rule",
+			Rule:     outcome.Rule.Name,
+			Passed:   outcome.Passed,
+			Message:  outcome.Message,
+			Severity: "info",
 		}
 
-		// Define transaction limits (these could be configurable)
-		maxSingleTransaction := decimal.NewFromFloat(100000.00) // $100,000
-
-		if params.TransactionAmount.LessThanOrEqual(maxSingleTransaction) {
-			limitValidation.Passed = true
-			limitValidation.Message = fmt.Sprintf("Transaction amount within limits (%s)", params.TransactionAmount.String())
-			limitValidation.Severity = "info"
-		} else {
-			limitValidation.Passed = false
-			limitValidation.Message = fmt.Sprintf("Transaction amount exceeds limit (Amount: %s, Limit: %s)", params.TransactionAmount.String(), maxSingleTransaction.String())
-			limitValidation.Severity = "warning"
-			// Don't fail validation for limit checks, but flag for review
+		if !outcome.Passed {
+			// Warnings flag the account for review; only rules of severity error fail the validation
+			validation.Severity = string(outcome.Rule.Severity)
+			if outcome.Rule.Severity == rules.SeverityError {
+				result.IsValid = false
+				result.CanTransact = false
+			}
 		}
 
-		result.Validations = append(result.Validations, limitValidation)
+		result.Validations = append(result.Validations, validation)
 	}
 
 	return nil
 }
 
+// isDebit reports whether the account is validated for a debit
+func isDebit(params ValidateAccountParams) bool {
+	return params.TransactionType != nil && *params.TransactionType == "debit"
+}
+
 // updateValidationSummary updates the overall validation summary
 func (service *Service) updateValidationSummary(result *ValidateAccountResults) {
 	errorCount := 0
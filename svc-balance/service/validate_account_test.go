@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"svc-balance/store/sqlc"
+	"svc-balance/util/rules"
+
+	"numeric"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -223,6 +226,54 @@ func TestValidateAccountStatus(t *testing.T) {
 	}
 }
 
+func TestValidateAccountType(t *testing.T) {
+	t.Parallel()
+
+	service := createTestService()
+
+	tests := []struct {
+		name        string
+		accountType sqlc.CoreAccountType
+		withdrawals int64
+		expectValid bool
+	}{
+		{name: "Checking account", accountType: sqlc.CoreAccountTypeChecking, withdrawals: 30, expectValid: true},
+		{name: "Savings account below the limit", accountType: sqlc.CoreAccountTypeSavings, withdrawals: 4, expectValid: true},
+		{name: "Savings account at the limit", accountType: sqlc.CoreAccountTypeSavings, withdrawals: 5, expectValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := &ValidateAccountResults{
+				IsValid:     true,
+				CanTransact: true,
+				Validations: []ValidationResult{},
+			}
+
+			service.validateAccountType(result, sqlc.CoreAccount{AccountType: tt.accountType}, tt.withdrawals)
+
+			if result.IsValid != tt.expectValid || result.CanTransact != tt.expectValid {
+				t.Errorf("validateAccountType() IsValid = %v, CanTransact = %v, want %v", result.IsValid, result.CanTransact, tt.expectValid)
+			}
+
+			if len(result.Validations) != 1 {
+				t.Fatalf("validateAccountType() validations count = %d, want 1", len(result.Validations))
+			}
+
+			validation := result.Validations[0]
+			if validation.Type != "account_type" || validation.Rule != "monthly_withdrawals" {
+				t.Errorf("validateAccountType() validation = %s/%s, want account_type/monthly_withdrawals", validation.Type, validation.Rule)
+			}
+
+			if validation.Passed != tt.expectValid {
+				t.Errorf("validateAccountType() validation passed = %v, want %v", validation.Passed, tt.expectValid)
+			}
+		})
+	}
+}
+
 func TestValidateAccountBalance(t *testing.T) {
 	t.Parallel()
 
@@ -478,7 +529,7 @@ func TestValidateBusinessRules(t *testing.T) {
 				TransactionAmount: tt.transactionAmount,
 			}
 
-			err := service.validateBusinessRules(result, account, params)
+			err := service.validateBusinessRules(result, account, params, rules.NewEngine(rules.Defaults()))
 			if err != nil {
 				t.Errorf("validateBusinessRules() error = %v", err)
 				return
@@ -523,6 +574,55 @@ func TestValidateBusinessRules(t *testing.T) {
 	}
 }
 
+func TestValidateBusinessRulesPerCurrency(t *testing.T) {
+	t.Parallel()
+
+	service := createTestService()
+
+	engine := rules.NewEngine([]rules.Rule{
+		{Name: "transaction_limits", Kind: rules.KindMaxTransactionAmount, Threshold: decimal.NewFromInt(100000), Severity: rules.SeverityWarning, Enabled: true},
+		{Name: "transaction_limits", Kind: rules.KindMaxTransactionAmount, Currency: "GBP", Threshold: decimal.NewFromInt(50000), Severity: rules.SeverityError, Enabled: true},
+		{Name: "minimum_balance", Kind: rules.KindMinBalance, Threshold: decimal.NewFromInt(100), Severity: rules.SeverityError, Enabled: true},
+	})
+
+	debit := "debit"
+	tests := []struct {
+		name        string
+		currency    sqlc.CoreCurrencyCode
+		amount      decimal.Decimal
+		expectValid bool
+	}{
+		{name: "Above the GBP limit", currency: sqlc.CoreCurrencyCodeGBP, amount: decimal.NewFromInt(60000), expectValid: false},
+		{name: "Same amount in USD is only flagged", currency: sqlc.CoreCurrencyCodeUSD, amount: decimal.NewFromInt(60000), expectValid: true},
+		{name: "Debit leaving less than the minimum balance", currency: sqlc.CoreCurrencyCodeUSD, amount: decimal.NewFromInt(99950), expectValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := &ValidateAccountResults{IsValid: true, CanTransact: true}
+
+			account := sqlc.CoreAccount{
+				Version:     1,
+				AccountName: "John Doe",
+				Currency:    tt.currency,
+				Balance:     numeric.FromDecimal(decimal.NewFromInt(100000)),
+			}
+
+			params := ValidateAccountParams{TransactionType: &debit, TransactionAmount: &tt.amount}
+
+			if err := service.validateBusinessRules(result, account, params, engine); err != nil {
+				t.Fatalf("validateBusinessRules() error = %v", err)
+			}
+
+			if result.IsValid != tt.expectValid || result.CanTransact != tt.expectValid {
+				t.Errorf("validateBusinessRules() IsValid = %v, CanTransact = %v, want %v: %+v", result.IsValid, result.CanTransact, tt.expectValid, result.Validations)
+			}
+		})
+	}
+}
+
 func TestUpdateValidationSummary(t *testing.T) {
 	t.Parallel()
 
@@ -618,22 +718,7 @@ func stringPtr(s string) *string {
 }
 
 func createPgNumeric(value string) pgtype.Numeric {
-	d, err := decimal.NewFromString(value)
-	if err != nil {
-		panic(err)
-	}
-
-	// Convert decimal to pgtype.Numeric
-	// For PostgreSQL numeric, we need to store the unscaled value and the scale
-	// For example, 1000.00 should be stored as Int=100000, Exp=-2
-	exp := int32(d.Exponent())
-	coefficient := d.Coefficient()
-
-	return pgtype.Numeric{
-		Int:   coefficient,
-		Exp:   exp,
-		Valid: true,
-	}
+	return numeric.FromDecimal(decimal.RequireFromString(value))
 }
 
 func contains(s, substr string) bool {
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *Store) CreateAccountAlias(ctx context.Context, arg sqlc.CreateAccountAliasParams) (sqlc.CoreAccountAlias, error) {
+	defer s.write()()
+
+	if _, ok := s.db.tables.accountAliases[arg.Alias]; ok {
+		return sqlc.CoreAccountAlias{}, uniqueViolation("account_aliases_pkey")
+	}
+	if _, ok := s.db.tables.accounts[arg.AccountID.Bytes]; !ok {
+		return sqlc.CoreAccountAlias{}, foreignKeyViolation("account_aliases_account_id_fkey")
+	}
+
+	alias := sqlc.CoreAccountAlias{
+		Alias:     arg.Alias,
+		AliasType: arg.AliasType,
+		AccountID: arg.AccountID,
+		CreatedAt: now(),
+	}
+	put(s, s.db.tables.accountAliases, alias.Alias, alias)
+
+	return alias, nil
+}
+
+func (s *Store) ResolveAccountAlias(ctx context.Context, alias string) (sqlc.ResolveAccountAliasRow, error) {
+	defer s.read()()
+
+	registered, ok := s.db.tables.accountAliases[alias]
+	if !ok {
+		return sqlc.ResolveAccountAliasRow{}, pgx.ErrNoRows
+	}
+
+	account, ok := s.db.tables.accounts[registered.AccountID.Bytes]
+	if !ok {
+		return sqlc.ResolveAccountAliasRow{}, pgx.ErrNoRows
+	}
+
+	return sqlc.ResolveAccountAliasRow{
+		Alias:         registered.Alias,
+		AliasType:     registered.AliasType,
+		AccountID:     account.ID,
+		AccountNumber: account.AccountNumber,
+		AccountName:   account.AccountName,
+		Currency:      account.Currency,
+		Status:        account.Status,
+	}, nil
+}
+
+func (s *Store) ListAccountAliases(ctx context.Context, accountID pgtype.UUID) ([]sqlc.CoreAccountAlias, error) {
+	defer s.read()()
+
+	aliases := []sqlc.CoreAccountAlias{}
+	for _, alias := range s.db.tables.accountAliases {
+		if alias.AccountID == accountID {
+			aliases = append(aliases, alias)
+		}
+	}
+	slices.SortFunc(aliases, func(a, b sqlc.CoreAccountAlias) int {
+		if c := a.CreatedAt.Time.Compare(b.CreatedAt.Time); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Alias, b.Alias)
+	})
+
+	return aliases, nil
+}
+
+func (s *Store) DeleteAccountAlias(ctx context.Context, alias string) (int64, error) {
+	defer s.write()()
+
+	if !remove(s, s.db.tables.accountAliases, alias) {
+		return 0, nil
+	}
+
+	return 1, nil
+}
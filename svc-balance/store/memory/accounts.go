@@ -0,0 +1,242 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// sortedAccounts returns the accounts matching keep, ordered by compare
+func (s *Store) sortedAccounts(keep func(sqlc.CoreAccount) bool, compare func(a, b sqlc.CoreAccount) int) []sqlc.CoreAccount {
+	accounts := []sqlc.CoreAccount{}
+	for _, account := range s.db.tables.accounts {
+		if keep(account) {
+			accounts = append(accounts, account)
+		}
+	}
+	slices.SortFunc(accounts, compare)
+
+	return accounts
+}
+
+func byAccountNumber(a, b sqlc.CoreAccount) int {
+	return strings.Compare(a.AccountNumber, b.AccountNumber)
+}
+
+func byNewest(a, b sqlc.CoreAccount) int {
+	return b.CreatedAt.Time.Compare(a.CreatedAt.Time)
+}
+
+func (s *Store) GetAccountByID(ctx context.Context, id pgtype.UUID) (sqlc.CoreAccount, error) {
+	defer s.read()()
+
+	account, ok := s.db.tables.accounts[id.Bytes]
+	if !ok {
+		return sqlc.CoreAccount{}, pgx.ErrNoRows
+	}
+
+	return account, nil
+}
+
+func (s *Store) GetAccountByNumber(ctx context.Context, accountNumber string) (sqlc.CoreAccount, error) {
+	defer s.read()()
+
+	for _, account := range s.db.tables.accounts {
+		if account.AccountNumber == accountNumber {
+			return account, nil
+		}
+	}
+
+	return sqlc.CoreAccount{}, pgx.ErrNoRows
+}
+
+func (s *Store) CheckAccountBalance(ctx context.Context, arg sqlc.CheckAccountBalanceParams) (sqlc.CheckAccountBalanceRow, error) {
+	defer s.read()()
+
+	account, ok := s.db.tables.accounts[arg.ID.Bytes]
+	if !ok {
+		return sqlc.CheckAccountBalanceRow{}, pgx.ErrNoRows
+	}
+
+	return sqlc.CheckAccountBalanceRow{
+		ID:              account.ID,
+		AccountNumber:   account.AccountNumber,
+		AccountName:     account.AccountName,
+		Balance:         account.Balance,
+		Currency:        account.Currency,
+		Status:          account.Status,
+		SufficientFunds: !arg.Column2.Valid || toDecimal(account.Balance).GreaterThanOrEqual(toDecimal(arg.Column2)),
+	}, nil
+}
+
+// CountOutgoingDebits always counts zero: the debits are written by svc-transaction, whose transactions this store
+// has no copy of. svc-transaction still enforces the withdrawal limit of savings accounts when it debits them.
+func (s *Store) CountOutgoingDebits(ctx context.Context, arg sqlc.CountOutgoingDebitsParams) (int64, error) {
+	return 0, nil
+}
+
+func (s *Store) GetAccountsByIDsOrNumbers(ctx context.Context, arg sqlc.GetAccountsByIDsOrNumbersParams) ([]sqlc.CoreAccount, error) {
+	defer s.read()()
+
+	return s.sortedAccounts(func(account sqlc.CoreAccount) bool {
+		return slices.Contains(arg.Ids, account.ID) || slices.Contains(arg.AccountNumbers, account.AccountNumber)
+	}, byAccountNumber), nil
+}
+
+func (s *Store) GetAccountsByStatus(ctx context.Context, arg sqlc.GetAccountsByStatusParams) ([]sqlc.CoreAccount, error) {
+	defer s.read()()
+
+	accounts := s.sortedAccounts(func(account sqlc.CoreAccount) bool {
+		return account.Status == arg.Status
+	}, byNewest)
+
+	return page(accounts, arg.Limit, arg.Offset), nil
+}
+
+func (s *Store) GetAccountsByCurrency(ctx context.Context, arg sqlc.GetAccountsByCurrencyParams) ([]sqlc.CoreAccount, error) {
+	defer s.read()()
+
+	accounts := s.sortedAccounts(func(account sqlc.CoreAccount) bool {
+		return account.Currency == arg.Currency
+	}, func(a, b sqlc.CoreAccount) int {
+		return toDecimal(b.Balance).Cmp(toDecimal(a.Balance))
+	})
+
+	return page(accounts, arg.Limit, arg.Offset), nil
+}
+
+func (s *Store) GetAccountsWithLowBalance(ctx context.Context, arg sqlc.GetAccountsWithLowBalanceParams) ([]sqlc.GetAccountsWithLowBalanceRow, error) {
+	defer s.read()()
+
+	accounts := s.sortedAccounts(func(account sqlc.CoreAccount) bool {
+		return account.Status == sqlc.CoreAccountStatusActive && toDecimal(account.Balance).LessThan(toDecimal(arg.Balance))
+	}, func(a, b sqlc.CoreAccount) int {
+		return toDecimal(a.Balance).Cmp(toDecimal(b.Balance))
+	})
+
+	rows := []sqlc.GetAccountsWithLowBalanceRow{}
+	for _, account := range page(accounts, arg.Limit, arg.Offset) {
+		rows = append(rows, sqlc.GetAccountsWithLowBalanceRow{
+			ID:            account.ID,
+			AccountNumber: account.AccountNumber,
+			AccountName:   account.AccountName,
+			Balance:       account.Balance,
+			Currency:      account.Currency,
+			Status:        account.Status,
+			CreatedAt:     account.CreatedAt,
+			UpdatedAt:     account.UpdatedAt,
+		})
+	}
+
+	return rows, nil
+}
+
+func (s *Store) GetAccountsByBalanceRange(ctx context.Context, arg sqlc.GetAccountsByBalanceRangeParams) ([]sqlc.GetAccountsByBalanceRangeRow, error) {
+	defer s.read()()
+
+	low, high := toDecimal(arg.Balance), toDecimal(arg.Balance_2)
+	accounts := s.sortedAccounts(func(account sqlc.CoreAccount) bool {
+		balance := toDecimal(account.Balance)
+		return balance.GreaterThanOrEqual(low) && balance.LessThanOrEqual(high)
+	}, func(a, b sqlc.CoreAccount) int {
+		return toDecimal(b.Balance).Cmp(toDecimal(a.Balance))
+	})
+
+	rows := []sqlc.GetAccountsByBalanceRangeRow{}
+	for _, account := range page(accounts, arg.Limit, arg.Offset) {
+		rows = append(rows, sqlc.GetAccountsByBalanceRangeRow{
+			ID:            account.ID,
+			AccountNumber: account.AccountNumber,
+			AccountName:   account.AccountName,
+			Balance:       account.Balance,
+			Currency:      account.Currency,
+			Status:        account.Status,
+			CreatedAt:     account.CreatedAt,
+			UpdatedAt:     account.UpdatedAt,
+		})
+	}
+
+	return rows, nil
+}
+
+func (s *Store) SearchAccounts(ctx context.Context, arg sqlc.SearchAccountsParams) ([]sqlc.CoreAccount, error) {
+	defer s.read()()
+
+	query := strings.ToLower(arg.Query)
+	accounts := s.sortedAccounts(func(account sqlc.CoreAccount) bool {
+		if !strings.Contains(strings.ToLower(account.AccountNumber), query) && !strings.HasPrefix(strings.ToLower(account.AccountName), query) {
+			return false
+		}
+		if arg.Currency.Valid && account.Currency != arg.Currency.CoreCurrencyCode {
+			return false
+		}
+
+		return !arg.Status.Valid || account.Status == arg.Status.CoreAccountStatus
+	}, byAccountNumber)
+
+	return page(accounts, arg.RowLimit, arg.RowOffset), nil
+}
+
+func (s *Store) ValidateAccountForTransaction(ctx context.Context, arg sqlc.ValidateAccountForTransactionParams) (sqlc.ValidateAccountForTransactionRow, error) {
+	defer s.read()()
+
+	account, ok := s.db.tables.accounts[arg.ID.Bytes]
+	if !ok {
+		return sqlc.ValidateAccountForTransactionRow{}, pgx.ErrNoRows
+	}
+
+	row := sqlc.ValidateAccountForTransactionRow{
+		ID:                account.ID,
+		AccountNumber:     account.AccountNumber,
+		AccountName:       account.AccountName,
+		Balance:           account.Balance,
+		Currency:          account.Currency,
+		Status:            account.Status,
+		CanTransact:       true,
+		ValidationMessage: "OK",
+	}
+
+	switch {
+	case account.Status != sqlc.CoreAccountStatusActive:
+		row.CanTransact, row.ValidationMessage = false, "Account is not active"
+	case arg.Column2 == "debit" && toDecimal(account.Balance).LessThan(toDecimal(arg.Column3)):
+		row.CanTransact, row.ValidationMessage = false, "Insufficient funds"
+	}
+
+	return row, nil
+}
+
+// GetAccountSummary is not supported: the summary counts the transactions of svc-transaction
+func (s *Store) GetAccountSummary(ctx context.Context, id pgtype.UUID) (sqlc.GetAccountSummaryRow, error) {
+	return sqlc.GetAccountSummaryRow{}, ErrNotSupported
+}
+
+// GetDormantAccounts is not supported: dormancy is judged by the transactions of svc-transaction
+func (s *Store) GetDormantAccounts(ctx context.Context, arg sqlc.GetDormantAccountsParams) ([]sqlc.GetDormantAccountsRow, error) {
+	return nil, ErrNotSupported
+}
+
+// GetAccountBalanceHistory is not supported: the balance history is written by svc-transaction
+func (s *Store) GetAccountBalanceHistory(ctx context.Context, arg sqlc.GetAccountBalanceHistoryParams) ([]sqlc.CoreAccountBalanceHistory, error) {
+	return nil, ErrNotSupported
+}
+
+// ListBalanceHistory is not supported: the balance history is written by svc-transaction
+func (s *Store) ListBalanceHistory(ctx context.Context, arg sqlc.ListBalanceHistoryParams) ([]sqlc.CoreAccountBalanceHistory, error) {
+	return nil, ErrNotSupported
+}
+
+// GetBalanceHistoryTotals is not supported: the balance history is written by svc-transaction
+func (s *Store) GetBalanceHistoryTotals(ctx context.Context, arg sqlc.GetBalanceHistoryTotalsParams) ([]sqlc.GetBalanceHistoryTotalsRow, error) {
+	return nil, ErrNotSupported
+}
+
+// ListEODBalances is not supported: the closing balances are written by svc-transaction
+func (s *Store) ListEODBalances(ctx context.Context, arg sqlc.ListEODBalancesParams) ([]sqlc.CoreEodBalance, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,26 @@
+package memory
+
+import (
+	"context"
+
+	"svc-balance/store/sqlc"
+)
+
+func (s *Store) CreateAdminAudit(ctx context.Context, arg sqlc.CreateAdminAuditParams) (sqlc.CoreAdminAudit, error) {
+	defer s.write()()
+
+	audit := sqlc.CoreAdminAudit{
+		ID:            newID(),
+		Actor:         arg.Actor,
+		Action:        arg.Action,
+		Service:       arg.Service,
+		ResourceType:  arg.ResourceType,
+		ResourceID:    arg.ResourceID,
+		BeforePayload: arg.BeforePayload,
+		AfterPayload:  arg.AfterPayload,
+		CreatedAt:     now(),
+	}
+	insert(s, &s.db.tables.adminAudits, audit)
+
+	return audit, nil
+}
@@ -0,0 +1,161 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// sortedBalanceAlerts returns the balance alerts matching keep, the oldest first
+func (s *Store) sortedBalanceAlerts(keep func(sqlc.CoreBalanceAlert) bool) []sqlc.CoreBalanceAlert {
+	alerts := []sqlc.CoreBalanceAlert{}
+	for _, alert := range s.db.tables.balanceAlerts {
+		if keep(alert) {
+			alerts = append(alerts, alert)
+		}
+	}
+	slices.SortFunc(alerts, func(a, b sqlc.CoreBalanceAlert) int {
+		return a.CreatedAt.Time.Compare(b.CreatedAt.Time)
+	})
+
+	return alerts
+}
+
+func (s *Store) CreateBalanceAlert(ctx context.Context, arg sqlc.CreateBalanceAlertParams) (sqlc.CoreBalanceAlert, error) {
+	defer s.write()()
+
+	if _, ok := s.db.tables.accounts[arg.AccountID.Bytes]; !ok {
+		return sqlc.CoreBalanceAlert{}, foreignKeyViolation("balance_alerts_account_id_fkey")
+	}
+
+	createdAt := now()
+	alert := sqlc.CoreBalanceAlert{
+		ID:         newID(),
+		AccountID:  arg.AccountID,
+		Threshold:  toNumeric(toDecimal(arg.Threshold), 4),
+		WebhookUrl: arg.WebhookUrl,
+		Enabled:    true,
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+	}
+	put(s, s.db.tables.balanceAlerts, alert.ID.Bytes, alert)
+
+	return alert, nil
+}
+
+func (s *Store) GetBalanceAlertByID(ctx context.Context, id pgtype.UUID) (sqlc.CoreBalanceAlert, error) {
+	defer s.read()()
+
+	alert, ok := s.db.tables.balanceAlerts[id.Bytes]
+	if !ok {
+		return sqlc.CoreBalanceAlert{}, pgx.ErrNoRows
+	}
+
+	return alert, nil
+}
+
+func (s *Store) ListBalanceAlerts(ctx context.Context, arg sqlc.ListBalanceAlertsParams) ([]sqlc.CoreBalanceAlert, error) {
+	defer s.read()()
+
+	alerts := s.sortedBalanceAlerts(func(sqlc.CoreBalanceAlert) bool { return true })
+	slices.Reverse(alerts)
+
+	return page(alerts, arg.Limit, arg.Offset), nil
+}
+
+func (s *Store) ListBalanceAlertsByAccount(ctx context.Context, accountID pgtype.UUID) ([]sqlc.CoreBalanceAlert, error) {
+	defer s.read()()
+
+	alerts := s.sortedBalanceAlerts(func(alert sqlc.CoreBalanceAlert) bool {
+		return alert.AccountID == accountID
+	})
+	slices.Reverse(alerts)
+
+	return alerts, nil
+}
+
+func (s *Store) ListEnabledBalanceAlertsWithBalance(ctx context.Context) ([]sqlc.ListEnabledBalanceAlertsWithBalanceRow, error) {
+	defer s.read()()
+
+	rows := []sqlc.ListEnabledBalanceAlertsWithBalanceRow{}
+	for _, alert := range s.sortedBalanceAlerts(func(alert sqlc.CoreBalanceAlert) bool { return alert.Enabled }) {
+		account, ok := s.db.tables.accounts[alert.AccountID.Bytes]
+		if !ok {
+			continue
+		}
+
+		rows = append(rows, sqlc.ListEnabledBalanceAlertsWithBalanceRow{
+			ID:            alert.ID,
+			AccountID:     alert.AccountID,
+			Threshold:     alert.Threshold,
+			WebhookUrl:    alert.WebhookUrl,
+			Triggered:     alert.Triggered,
+			AccountNumber: account.AccountNumber,
+			Balance:       account.Balance,
+			Currency:      account.Currency,
+		})
+	}
+
+	return rows, nil
+}
+
+func (s *Store) SetBalanceAlertEnabled(ctx context.Context, arg sqlc.SetBalanceAlertEnabledParams) (sqlc.CoreBalanceAlert, error) {
+	defer s.write()()
+
+	alert, ok := s.db.tables.balanceAlerts[arg.ID.Bytes]
+	if !ok {
+		return sqlc.CoreBalanceAlert{}, pgx.ErrNoRows
+	}
+
+	alert.Enabled = arg.Enabled
+	alert.Triggered = arg.Enabled && alert.Triggered
+	alert.UpdatedAt = now()
+	put(s, s.db.tables.balanceAlerts, alert.ID.Bytes, alert)
+
+	return alert, nil
+}
+
+func (s *Store) DeleteBalanceAlert(ctx context.Context, id pgtype.UUID) (int64, error) {
+	defer s.write()()
+
+	if !remove(s, s.db.tables.balanceAlerts, id.Bytes) {
+		return 0, nil
+	}
+
+	return 1, nil
+}
+
+func (s *Store) MarkBalanceAlertTriggered(ctx context.Context, id pgtype.UUID) error {
+	defer s.write()()
+
+	alert, ok := s.db.tables.balanceAlerts[id.Bytes]
+	if !ok {
+		return nil
+	}
+
+	alert.Triggered = true
+	alert.LastTriggeredAt = now()
+	alert.UpdatedAt = alert.LastTriggeredAt
+	put(s, s.db.tables.balanceAlerts, alert.ID.Bytes, alert)
+
+	return nil
+}
+
+func (s *Store) ResetBalanceAlert(ctx context.Context, id pgtype.UUID) error {
+	defer s.write()()
+
+	alert, ok := s.db.tables.balanceAlerts[id.Bytes]
+	if !ok {
+		return nil
+	}
+
+	alert.Triggered = false
+	alert.UpdatedAt = now()
+	put(s, s.db.tables.balanceAlerts, alert.ID.Bytes, alert)
+
+	return nil
+}
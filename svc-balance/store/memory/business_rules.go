@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *Store) CreateBusinessRule(ctx context.Context, arg sqlc.CreateBusinessRuleParams) (sqlc.CoreBusinessRule, error) {
+	defer s.write()()
+
+	for _, rule := range s.db.tables.businessRules {
+		if rule.Name == arg.Name && rule.Currency == arg.Currency {
+			return sqlc.CoreBusinessRule{}, uniqueViolation("idx_business_rules_name_currency")
+		}
+	}
+
+	createdAt := now()
+	rule := sqlc.CoreBusinessRule{
+		ID:        newID(),
+		Name:      arg.Name,
+		Kind:      arg.Kind,
+		Currency:  arg.Currency,
+		Threshold: toNumeric(toDecimal(arg.Threshold), 4),
+		Severity:  arg.Severity,
+		Enabled:   arg.Enabled,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	put(s, s.db.tables.businessRules, rule.ID.Bytes, rule)
+
+	return rule, nil
+}
+
+func (s *Store) GetBusinessRuleByID(ctx context.Context, id pgtype.UUID) (sqlc.CoreBusinessRule, error) {
+	defer s.read()()
+
+	rule, ok := s.db.tables.businessRules[id.Bytes]
+	if !ok {
+		return sqlc.CoreBusinessRule{}, pgx.ErrNoRows
+	}
+
+	return rule, nil
+}
+
+func (s *Store) ListBusinessRules(ctx context.Context) ([]sqlc.CoreBusinessRule, error) {
+	defer s.read()()
+
+	rules := make([]sqlc.CoreBusinessRule, 0, len(s.db.tables.businessRules))
+	for _, rule := range s.db.tables.businessRules {
+		rules = append(rules, rule)
+	}
+
+	// ORDER BY name ASC, currency ASC NULLS FIRST
+	slices.SortFunc(rules, func(a, b sqlc.CoreBusinessRule) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		if a.Currency.Valid != b.Currency.Valid {
+			if a.Currency.Valid {
+				return 1
+			}
+			return -1
+		}
+
+		return strings.Compare(string(a.Currency.CoreCurrencyCode), string(b.Currency.CoreCurrencyCode))
+	})
+
+	return rules, nil
+}
+
+func (s *Store) UpdateBusinessRule(ctx context.Context, arg sqlc.UpdateBusinessRuleParams) (sqlc.CoreBusinessRule, error) {
+	defer s.write()()
+
+	rule, ok := s.db.tables.businessRules[arg.ID.Bytes]
+	if !ok {
+		return sqlc.CoreBusinessRule{}, pgx.ErrNoRows
+	}
+
+	rule.Threshold = toNumeric(toDecimal(arg.Threshold), 4)
+	rule.Severity = arg.Severity
+	rule.Enabled = arg.Enabled
+	rule.UpdatedAt = now()
+	put(s, s.db.tables.businessRules, rule.ID.Bytes, rule)
+
+	return rule, nil
+}
+
+func (s *Store) DeleteBusinessRule(ctx context.Context, id pgtype.UUID) (int64, error) {
+	defer s.write()()
+
+	if !remove(s, s.db.tables.businessRules, id.Bytes) {
+		return 0, nil
+	}
+
+	return 1, nil
+}
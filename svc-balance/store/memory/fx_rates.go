@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *Store) CreateFxRate(ctx context.Context, arg sqlc.CreateFxRateParams) (sqlc.CoreFxRate, error) {
+	defer s.write()()
+
+	rate := sqlc.CoreFxRate{
+		ID:        newID(),
+		Currency:  arg.Currency,
+		RateToUsd: toNumeric(toDecimal(arg.RateToUsd), 10),
+		Source:    arg.Source,
+		FetchedAt: arg.FetchedAt,
+	}
+	insert(s, &s.db.tables.fxRates, rate)
+
+	return rate, nil
+}
+
+func (s *Store) GetFxRateAsOf(ctx context.Context, arg sqlc.GetFxRateAsOfParams) (sqlc.CoreFxRate, error) {
+	defer s.read()()
+
+	var latest *sqlc.CoreFxRate
+	for i, rate := range s.db.tables.fxRates {
+		if rate.Currency != arg.Currency || rate.FetchedAt.Time.After(arg.FetchedAt.Time) {
+			continue
+		}
+		if latest == nil || rate.FetchedAt.Time.After(latest.FetchedAt.Time) {
+			latest = &s.db.tables.fxRates[i]
+		}
+	}
+
+	if latest == nil {
+		return sqlc.CoreFxRate{}, pgx.ErrNoRows
+	}
+
+	return *latest, nil
+}
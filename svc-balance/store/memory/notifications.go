@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"svc-balance/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *Store) UpsertNotificationPreference(ctx context.Context, arg sqlc.UpsertNotificationPreferenceParams) (sqlc.CoreNotificationPreference, error) {
+	defer s.write()()
+
+	if _, ok := s.db.tables.accounts[arg.AccountID.Bytes]; !ok {
+		return sqlc.CoreNotificationPreference{}, foreignKeyViolation("notification_preferences_account_id_fkey")
+	}
+
+	preference, ok := s.db.tables.notificationPreferences[arg.AccountID.Bytes]
+	if !ok {
+		preference = sqlc.CoreNotificationPreference{AccountID: arg.AccountID, CreatedAt: now()}
+	}
+	preference.Email = arg.Email
+	preference.NotifyCompleted = arg.NotifyCompleted
+	preference.NotifyCompensated = arg.NotifyCompensated
+	preference.UpdatedAt = now()
+	put(s, s.db.tables.notificationPreferences, arg.AccountID.Bytes, preference)
+
+	return preference, nil
+}
+
+func (s *Store) GetNotificationPreference(ctx context.Context, accountID pgtype.UUID) (sqlc.CoreNotificationPreference, error) {
+	defer s.read()()
+
+	preference, ok := s.db.tables.notificationPreferences[accountID.Bytes]
+	if !ok {
+		return sqlc.CoreNotificationPreference{}, pgx.ErrNoRows
+	}
+
+	return preference, nil
+}
+
+func (s *Store) DeleteNotificationPreference(ctx context.Context, accountID pgtype.UUID) (int64, error) {
+	defer s.write()()
+
+	if !remove(s, s.db.tables.notificationPreferences, accountID.Bytes) {
+		return 0, nil
+	}
+
+	return 1, nil
+}
+
+func (s *Store) GetNotificationRecipient(ctx context.Context, accountNumber string) (sqlc.GetNotificationRecipientRow, error) {
+	defer s.read()()
+
+	for _, account := range s.db.tables.accounts {
+		if account.AccountNumber != accountNumber {
+			continue
+		}
+
+		preference, ok := s.db.tables.notificationPreferences[account.ID.Bytes]
+		if !ok {
+			break
+		}
+		_, suppressed := s.db.tables.notificationSuppressions[strings.ToLower(preference.Email)]
+
+		return sqlc.GetNotificationRecipientRow{
+			AccountID:         account.ID,
+			AccountNumber:     account.AccountNumber,
+			AccountName:       account.AccountName,
+			Email:             preference.Email,
+			NotifyCompleted:   preference.NotifyCompleted,
+			NotifyCompensated: preference.NotifyCompensated,
+			Suppressed:        suppressed,
+		}, nil
+	}
+
+	return sqlc.GetNotificationRecipientRow{}, pgx.ErrNoRows
+}
+
+func (s *Store) AddNotificationSuppression(ctx context.Context, arg sqlc.AddNotificationSuppressionParams) (sqlc.CoreNotificationSuppression, error) {
+	defer s.write()()
+
+	suppression, ok := s.db.tables.notificationSuppressions[arg.Email]
+	if !ok {
+		suppression = sqlc.CoreNotificationSuppression{Email: arg.Email, CreatedAt: now()}
+	}
+	suppression.Reason = arg.Reason
+	put(s, s.db.tables.notificationSuppressions, arg.Email, suppression)
+
+	return suppression, nil
+}
+
+func (s *Store) ListNotificationSuppressions(ctx context.Context, arg sqlc.ListNotificationSuppressionsParams) ([]sqlc.CoreNotificationSuppression, error) {
+	defer s.read()()
+
+	suppressions := make([]sqlc.CoreNotificationSuppression, 0, len(s.db.tables.notificationSuppressions))
+	for _, suppression := range s.db.tables.notificationSuppressions {
+		suppressions = append(suppressions, suppression)
+	}
+	slices.SortFunc(suppressions, func(a, b sqlc.CoreNotificationSuppression) int {
+		return b.CreatedAt.Time.Compare(a.CreatedAt.Time)
+	})
+
+	return page(suppressions, arg.Limit, arg.Offset), nil
+}
+
+func (s *Store) DeleteNotificationSuppression(ctx context.Context, email string) (int64, error) {
+	defer s.write()()
+
+	if !remove(s, s.db.tables.notificationSuppressions, email) {
+		return 0, nil
+	}
+
+	return 1, nil
+}
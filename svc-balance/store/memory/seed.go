@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"svc-balance/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// seedTables returns the tables with the reference data of _init/postgres/04-data.sql and, with demoData set, the
+// demo data of _init/postgres/seed/demo-data.sql, like the database init scripts outside the realistic profile.
+// svc-transaction seeds the same accounts, so both services start from the same balances.
+func seedTables(demoData bool) tables {
+	t := tables{
+		accounts:                 map[[16]byte]sqlc.CoreAccount{},
+		accountAliases:           map[string]sqlc.CoreAccountAlias{},
+		balanceAlerts:            map[[16]byte]sqlc.CoreBalanceAlert{},
+		businessRules:            map[[16]byte]sqlc.CoreBusinessRule{},
+		notificationPreferences:  map[[16]byte]sqlc.CoreNotificationPreference{},
+		notificationSuppressions: map[string]sqlc.CoreNotificationSuppression{},
+	}
+
+	createdAt := now()
+
+	for _, rule := range []struct {
+		name, kind, threshold string
+	}{
+		{"name_format", "min_account_name_length", "2"},
+		{"transaction_limits", "max_transaction_amount", "100000.0000"},
+	} {
+		id := newID()
+		t.businessRules[id.Bytes] = sqlc.CoreBusinessRule{
+			ID:        id,
+			Name:      rule.name,
+			Kind:      rule.kind,
+			Threshold: toNumeric(decimal.RequireFromString(rule.threshold), 4),
+			Severity:  "warning",
+			Enabled:   true,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
+	}
+
+	if !demoData {
+		return t
+	}
+
+	for _, account := range []struct {
+		id, number, name, balance string
+		currency                  sqlc.CoreCurrencyCode
+		status                    sqlc.CoreAccountStatus
+		accountType               sqlc.CoreAccountType
+	}{
+		{"550e8400-e29b-41d4-a716-446655440001", "ACC001", "John Doe Primary Account", "5000", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440002", "ACC002", "Jane Smith Savings Account", "10000", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeSavings},
+		{"550e8400-e29b-41d4-a716-446655440003", "ACC003", "Business Account - Tech Corp", "25000", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440004", "ACC004", "Alice Johnson EUR Account", "7500", sqlc.CoreCurrencyCodeEUR, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440005", "ACC005", "Bob Wilson GBP Account", "3000", sqlc.CoreCurrencyCodeGBP, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440006", "ACC006", "Test Account - Low Balance", "100", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440007", "ACC007", "Suspended Account", "1000", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusSuspended, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440008", "ACC008", "Corporate Account - BigCorp", "50000", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440009", "ACC009", "Zero Balance Account", "0", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440010", "ACC010", "High Balance Account", "100000", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+	} {
+		id := pgtype.UUID{Bytes: uuid.MustParse(account.id), Valid: true}
+		t.accounts[id.Bytes] = sqlc.CoreAccount{
+			ID:            id,
+			AccountNumber: account.number,
+			AccountName:   account.name,
+			Balance:       toNumeric(decimal.RequireFromString(account.balance), 4),
+			Currency:      account.currency,
+			Status:        account.status,
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt,
+			Version:       1,
+			AccountType:   account.accountType,
+		}
+	}
+
+	for _, alias := range []struct {
+		alias, aliasType, accountID string
+	}{
+		{"+14155550101", "phone", "550e8400-e29b-41d4-a716-446655440001"},
+		{"john.doe@example.com", "email", "550e8400-e29b-41d4-a716-446655440001"},
+		{"+14155550102", "phone", "550e8400-e29b-41d4-a716-446655440002"},
+	} {
+		t.accountAliases[alias.alias] = sqlc.CoreAccountAlias{
+			Alias:     alias.alias,
+			AliasType: alias.aliasType,
+			AccountID: pgtype.UUID{Bytes: uuid.MustParse(alias.accountID), Valid: true},
+			CreatedAt: createdAt,
+		}
+	}
+
+	return t
+}
@@ -0,0 +1,239 @@
+// Package memory is an in-memory implementation of store.IStore, for running the service without Postgres in quick
+// demos and end-to-end tests. The tables start with the demo data of _init/postgres and are lost on restart.
+//
+// The tables are private to the process: the balances svc-transaction moves in its own in-memory store are not seen
+// here, so balances read from svc-balance stay at their demo values. The SQLite store of store/sqlite shares its file
+// with svc-transaction instead.
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"svc-balance/store"
+	"svc-balance/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrNotSupported is returned by the queries the in-memory store does not implement: those reading the transactions
+// and balance history written by svc-transaction, which this store has no copy of
+var ErrNotSupported = errors.New("not supported by the in-memory store")
+
+// UnsupportedFeatures names the features whose queries return ErrNotSupported, for cmd/start.go to report at startup
+var UnsupportedFeatures = []string{"account summaries", "dormant accounts", "balance history", "end-of-day balances"}
+
+// tables holds the rows of every table the store implements
+type tables struct {
+	accounts                 map[[16]byte]sqlc.CoreAccount
+	accountAliases           map[string]sqlc.CoreAccountAlias
+	adminAudits              []sqlc.CoreAdminAudit
+	balanceAlerts            map[[16]byte]sqlc.CoreBalanceAlert
+	businessRules            map[[16]byte]sqlc.CoreBusinessRule
+	fxRates                  []sqlc.CoreFxRate
+	notificationPreferences  map[[16]byte]sqlc.CoreNotificationPreference
+	notificationSuppressions map[string]sqlc.CoreNotificationSuppression
+}
+
+// database is shared by the store and the transactions it runs
+type database struct {
+	// writeMutex serializes transactions and the writes made outside them, like the row locks of Postgres would
+	writeMutex sync.Mutex
+	// dataMutex guards the tables for the duration of a single query
+	dataMutex sync.RWMutex
+
+	tables tables
+}
+
+type Store struct {
+	logger *logrus.Logger
+
+	db *database
+
+	// inTx is set on the store passed to the function of WithTx, whose writes are undone by rollback when it fails
+	inTx     bool
+	rollback []func()
+}
+
+// NewStore returns a store holding the reference data and, with demoData set, the demo accounts
+func NewStore(logger *logrus.Logger, demoData bool) store.IStore {
+	return &Store{
+		logger: logger,
+
+		db: &database{tables: seedTables(demoData)},
+	}
+}
+
+// WithTx runs fn in a transaction: transactions run one at a time and the writes of fn are undone when it returns an
+// error. Reads outside a transaction may see the writes of a transaction that has not finished yet.
+func (s *Store) WithTx(ctx context.Context, fn func(sqlc.Querier) error) error {
+	if s.inTx {
+		return fn(s)
+	}
+
+	s.db.writeMutex.Lock()
+	defer s.db.writeMutex.Unlock()
+
+	tx := &Store{logger: s.logger, db: s.db, inTx: true}
+
+	// A panic in fn must not leave its writes behind
+	defer func() {
+		if r := recover(); r != nil {
+			tx.undo()
+			panic(r)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		tx.undo()
+
+		return err
+	}
+
+	return nil
+}
+
+// undo reverts the writes of the transaction, the latest first
+func (s *Store) undo() {
+	s.db.dataMutex.Lock()
+	defer s.db.dataMutex.Unlock()
+
+	for i := len(s.rollback) - 1; i >= 0; i-- {
+		s.rollback[i]()
+	}
+	s.rollback = nil
+}
+
+// read locks the tables for a query that only reads them and returns the unlock function
+func (s *Store) read() func() {
+	s.db.dataMutex.RLock()
+
+	return s.db.dataMutex.RUnlock
+}
+
+// write locks the tables for a query that changes them and returns the unlock function. Outside a transaction the
+// query also waits for the running transaction to finish.
+func (s *Store) write() func() {
+	if !s.inTx {
+		s.db.writeMutex.Lock()
+	}
+	s.db.dataMutex.Lock()
+
+	return func() {
+		s.db.dataMutex.Unlock()
+		if !s.inTx {
+			s.db.writeMutex.Unlock()
+		}
+	}
+}
+
+// onRollback registers how to revert a write when the transaction running it fails
+func (s *Store) onRollback(revert func()) {
+	if s.inTx {
+		s.rollback = append(s.rollback, revert)
+	}
+}
+
+// put sets m[key] to value, restoring the previous value on rollback
+func put[K comparable, V any](s *Store, m map[K]V, key K, value V) {
+	previous, existed := m[key]
+	m[key] = value
+
+	s.onRollback(func() {
+		if existed {
+			m[key] = previous
+		} else {
+			delete(m, key)
+		}
+	})
+}
+
+// remove deletes m[key], restoring it on rollback
+func remove[K comparable, V any](s *Store, m map[K]V, key K) bool {
+	previous, existed := m[key]
+	if !existed {
+		return false
+	}
+	delete(m, key)
+
+	s.onRollback(func() {
+		m[key] = previous
+	})
+
+	return true
+}
+
+// insert appends row to rows, dropping it again on rollback
+func insert[V any](s *Store, rows *[]V, row V) {
+	n := len(*rows)
+	*rows = append(*rows, row)
+
+	s.onRollback(func() {
+		*rows = (*rows)[:n]
+	})
+}
+
+// uniqueViolation returns the error Postgres reports when an insert breaks the unique constraint
+func uniqueViolation(constraint string) error {
+	return &pgconn.PgError{
+		Code:           "23505",
+		Message:        "duplicate key value violates unique constraint \"" + constraint + "\"",
+		ConstraintName: constraint,
+	}
+}
+
+// foreignKeyViolation returns the error Postgres reports when an insert refers to a row that does not exist
+func foreignKeyViolation(constraint string) error {
+	return &pgconn.PgError{
+		Code:           "23503",
+		Message:        "insert violates foreign key constraint \"" + constraint + "\"",
+		ConstraintName: constraint,
+	}
+}
+
+func newID() pgtype.UUID {
+	return pgtype.UUID{Bytes: uuid.New(), Valid: true}
+}
+
+func now() pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: time.Now(), Valid: true}
+}
+
+// toDecimal converts a numeric column; NULL converts to zero
+func toDecimal(n pgtype.Numeric) decimal.Decimal {
+	if !n.Valid || n.Int == nil {
+		return decimal.Zero
+	}
+
+	return decimal.NewFromBigInt(n.Int, n.Exp)
+}
+
+// toNumeric converts d to a numeric column with the given number of decimal places
+func toNumeric(d decimal.Decimal, places int32) pgtype.Numeric {
+	d = d.Round(places)
+
+	return pgtype.Numeric{Int: d.Coefficient(), Exp: d.Exponent(), Valid: true}
+}
+
+// page applies LIMIT and OFFSET to rows
+func page[V any](rows []V, limit, offset int32) []V {
+	if offset < 0 {
+		offset = 0
+	}
+	if int(offset) >= len(rows) {
+		return []V{}
+	}
+	rows = rows[offset:]
+
+	if limit >= 0 && int(limit) < len(rows) {
+		rows = rows[:limit]
+	}
+
+	return rows
+}
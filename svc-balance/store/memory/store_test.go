@@ -0,0 +1,119 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"svc-balance/store/memory"
+	"svc-balance/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var johnDoe = pgtype.UUID{Bytes: uuid.MustParse("550e8400-e29b-41d4-a716-446655440001"), Valid: true}
+
+func newTestStore() *memory.Store {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	return memory.NewStore(logger, true).(*memory.Store)
+}
+
+func TestSeededAccounts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := newTestStore()
+
+	account, err := store.GetAccountByNumber(ctx, "ACC001")
+	require.NoError(t, err)
+	assert.Equal(t, johnDoe, account.ID)
+	assert.True(t, decimal.NewFromInt(5000).Equal(decimal.NewFromBigInt(account.Balance.Int, account.Balance.Exp)))
+
+	suspended, err := store.GetAccountsByStatus(ctx, sqlc.GetAccountsByStatusParams{Status: sqlc.CoreAccountStatusSuspended, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, suspended, 1)
+	assert.Equal(t, "ACC007", suspended[0].AccountNumber)
+
+	resolved, err := store.ResolveAccountAlias(ctx, "john.doe@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "ACC001", resolved.AccountNumber)
+
+	_, err = store.GetAccountByNumber(ctx, "ACC999")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestWithoutDemoData(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	ctx := context.Background()
+	store := memory.NewStore(logger, false)
+
+	_, err := store.GetAccountByNumber(ctx, "ACC001")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	_, err = store.ResolveAccountAlias(ctx, "john.doe@example.com")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// The reference data is loaded whatever the profile
+	rules, err := store.ListBusinessRules(ctx)
+	require.NoError(t, err)
+	assert.Len(t, rules, 2)
+}
+
+func TestWithTxRollback(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := newTestStore()
+	errAbort := errors.New("abort")
+
+	err := store.WithTx(ctx, func(q sqlc.Querier) error {
+		_, err := q.CreateAccountAlias(ctx, sqlc.CreateAccountAliasParams{Alias: "+14155550199", AliasType: "phone", AccountID: johnDoe})
+		require.NoError(t, err)
+
+		deleted, err := q.DeleteAccountAlias(ctx, "+14155550101")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	_, err = store.ResolveAccountAlias(ctx, "+14155550199")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	_, err = store.ResolveAccountAlias(ctx, "+14155550101")
+	assert.NoError(t, err)
+}
+
+func TestConstraints(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := newTestStore()
+
+	var pgErr *pgconn.PgError
+
+	_, err := store.CreateAccountAlias(ctx, sqlc.CreateAccountAliasParams{Alias: "+14155550101", AliasType: "phone", AccountID: johnDoe})
+	require.ErrorAs(t, err, &pgErr)
+	assert.Equal(t, "23505", pgErr.Code)
+
+	_, err = store.CreateAccountAlias(ctx, sqlc.CreateAccountAliasParams{Alias: "+14155550198", AliasType: "phone", AccountID: pgtype.UUID{Bytes: uuid.New(), Valid: true}})
+	require.ErrorAs(t, err, &pgErr)
+	assert.Equal(t, "23503", pgErr.Code)
+
+	_, err = store.CreateBusinessRule(ctx, sqlc.CreateBusinessRuleParams{Name: "name_format", Kind: "min_account_name_length", Severity: "warning"})
+	require.ErrorAs(t, err, &pgErr)
+	assert.Equal(t, "idx_business_rules_name_currency", pgErr.ConstraintName)
+}
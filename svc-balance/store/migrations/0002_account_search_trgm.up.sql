@@ -0,0 +1,5 @@
+-- Trigram indexes behind the partial account searches of GET /accounts?query=, for databases created before them
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_accounts_account_number_trgm ON core.accounts USING GIN (account_number gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_accounts_account_name_trgm ON core.accounts USING GIN (account_name gin_trgm_ops);
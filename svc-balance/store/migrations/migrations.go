@@ -0,0 +1,259 @@
+// Package migrations applies the embedded schema migrations of svc-balance.
+//
+// Migrations are the files NNNN_name.up.sql of this directory, applied in version order, each in its own
+// transaction together with its row in core.schema_migrations, so a failed migration leaves nothing behind.
+// They cannot use statements that refuse to run in a transaction, such as CREATE INDEX CONCURRENTLY.
+//
+// Every service sharing the database keeps its own versions in core.schema_migrations, and all of them take the same
+// advisory lock while migrating, so replicas and services starting together apply each migration once.
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// baselineVersion is the schema the database init scripts create. Databases that already have it, because the scripts
+// ran before migrations existed, record it as applied instead of running it.
+const baselineVersion = 1
+
+// lockKey is the advisory lock held while migrating; it is the same for every service sharing the database
+const lockKey = 7_146_335_271
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.up\.sql$`)
+
+// Migration is one embedded migration
+type Migration struct {
+	Version int64
+	Name    string
+	SQL     string
+}
+
+// Status is the schema version of a service, as reported by its health check
+type Status struct {
+	Service string  `json:"service"`
+	Version int64   `json:"version"` // Latest applied migration; 0 when none is
+	Latest  int64   `json:"latest"`  // Latest embedded migration
+	Pending []int64 `json:"pending"` // Embedded migrations not applied yet
+}
+
+// Migrator applies the embedded migrations of a service
+type Migrator struct {
+	logger *logrus.Logger
+
+	pool    *pgxpool.Pool
+	service string
+
+	migrations []Migration
+}
+
+// NewMigrator returns a migrator recording its versions under service
+func NewMigrator(logger *logrus.Logger, pool *pgxpool.Pool, service string) (*Migrator, error) {
+	migrations, err := Load(files)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		logger: logger,
+
+		pool:    pool,
+		service: service,
+
+		migrations: migrations,
+	}, nil
+}
+
+// Load reads the migrations of a directory, in version order
+func Load(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	migrations := make([]Migration, 0, len(names))
+	for _, name := range names {
+		match := fileName.FindStringSubmatch(path.Base(name))
+		if match == nil {
+			return nil, fmt.Errorf("migration %s is not named NNNN_name.up.sql", name)
+		}
+
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+
+		sql, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", name, err)
+		}
+
+		migrations = append(migrations, Migration{Version: version, Name: match[2], SQL: string(sql)})
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int { return int(a.Version - b.Version) })
+
+	for i, migration := range migrations {
+		if migration.Version != int64(i+1) {
+			return nil, fmt.Errorf("migration versions must run from 1 without gaps, found %d at position %d", migration.Version, i+1)
+		}
+	}
+
+	return migrations, nil
+}
+
+// Up applies the pending migrations and returns how many it applied
+func (migrator *Migrator) Up(ctx context.Context) (int, error) {
+	const op = "migrations.Migrator.Up"
+
+	logger := migrator.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"service": migrator.service,
+	})
+
+	conn, err := migrator.pool.Acquire(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to acquire a connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return 0, fmt.Errorf("failed to take the migration lock: %w", err)
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+
+	if _, err := conn.Exec(ctx, createTable); err != nil {
+		return 0, fmt.Errorf("failed to create core.schema_migrations: %w", err)
+	}
+
+	applied, err := appliedVersions(ctx, conn, migrator.service)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range migrator.migrations {
+		if applied[migration.Version] {
+			continue
+		}
+
+		baselined := false
+		if migration.Version == baselineVersion {
+			err := conn.QueryRow(ctx, "SELECT to_regclass('core.accounts') IS NOT NULL").Scan(&baselined)
+			if err != nil {
+				return count, fmt.Errorf("failed to look for an existing schema: %w", err)
+			}
+		}
+
+		start := time.Now()
+
+		err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if !baselined {
+				if _, err := tx.Exec(ctx, migration.SQL); err != nil {
+					return err
+				}
+			}
+
+			_, err := tx.Exec(ctx,
+				"INSERT INTO core.schema_migrations (service, version, name, baselined) VALUES ($1, $2, $3, $4)",
+				migrator.service, migration.Version, migration.Name, baselined,
+			)
+			return err
+		})
+		if err != nil {
+			return count, fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		count++
+
+		logger.WithFields(logrus.Fields{
+			"version":     migration.Version,
+			"name":        migration.Name,
+			"baselined":   baselined,
+			"duration_ms": time.Since(start).Milliseconds(),
+		}).Info("Migration applied")
+	}
+
+	return count, nil
+}
+
+// Status reports the applied and pending migrations
+func (migrator *Migrator) Status(ctx context.Context) (*Status, error) {
+	status := &Status{
+		Service: migrator.service,
+		Pending: []int64{},
+	}
+	if len(migrator.migrations) > 0 {
+		status.Latest = migrator.migrations[len(migrator.migrations)-1].Version
+	}
+
+	var exists bool
+	if err := migrator.pool.QueryRow(ctx, "SELECT to_regclass('core.schema_migrations') IS NOT NULL").Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to look for core.schema_migrations: %w", err)
+	}
+
+	applied := map[int64]bool{}
+	if exists {
+		var err error
+		if applied, err = appliedVersions(ctx, migrator.pool, migrator.service); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, migration := range migrator.migrations {
+		if applied[migration.Version] {
+			status.Version = max(status.Version, migration.Version)
+		} else {
+			status.Pending = append(status.Pending, migration.Version)
+		}
+	}
+
+	return status, nil
+}
+
+const createTable = `
+CREATE SCHEMA IF NOT EXISTS core;
+
+CREATE TABLE IF NOT EXISTS core.schema_migrations (
+    service VARCHAR(100) NOT NULL,
+    version BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    baselined BOOLEAN NOT NULL DEFAULT FALSE, -- Found already applied by the database init scripts
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (service, version)
+);`
+
+// querier is a connection or a pool
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func appliedVersions(ctx context.Context, conn querier, service string) (map[int64]bool, error) {
+	rows, err := conn.Query(ctx, "SELECT version FROM core.schema_migrations WHERE service = $1", service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	versions, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	applied := make(map[int64]bool, len(versions))
+	for _, version := range versions {
+		applied[version] = true
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,30 @@
+-- name: CreateAccountAlias :one
+INSERT INTO core.account_aliases (
+    alias,
+    alias_type,
+    account_id
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: ResolveAccountAlias :one
+SELECT
+    al.alias,
+    al.alias_type,
+    a.id AS account_id,
+    a.account_number,
+    a.account_name,
+    a.currency,
+    a.status
+FROM core.account_aliases al
+JOIN core.accounts a ON a.id = al.account_id
+WHERE al.alias = $1;
+
+-- name: ListAccountAliases :many
+SELECT * FROM core.account_aliases
+WHERE account_id = $1
+ORDER BY created_at, alias;
+
+-- name: DeleteAccountAlias :execrows
+DELETE FROM core.account_aliases
+WHERE alias = $1;
@@ -8,7 +8,8 @@ SELECT
     status,
     created_at,
     updated_at,
-    version
+    version,
+    account_type
 FROM core.accounts
 WHERE id = $1;
 
@@ -22,7 +23,8 @@ SELECT
     status,
     created_at,
     updated_at,
-    version
+    version,
+    account_type
 FROM core.accounts
 WHERE account_number = $1;
 
@@ -42,6 +44,31 @@ SELECT
 FROM core.accounts
 WHERE id = $1;
 
+-- name: CountOutgoingDebits :one
+-- Debits of an account created since created_from that were not cancelled or failed
+SELECT COUNT(*) FROM core.transactions
+WHERE account_id = sqlc.arg(account_id)
+AND transaction_type = 'debit'
+AND status IN ('pending', 'completed')
+AND created_at >= sqlc.arg(created_from)::TIMESTAMPTZ;
+
+-- name: GetAccountsByIDsOrNumbers :many
+-- Accounts whose ID is in ids or whose account number is in account_numbers, read in one round trip
+SELECT 
+    id,
+    account_number,
+    account_name,
+    balance,
+    currency,
+    status,
+    created_at,
+    updated_at,
+    version,
+    account_type
+FROM core.accounts
+WHERE id = ANY(sqlc.arg(ids)::UUID[]) OR account_number = ANY(sqlc.arg(account_numbers)::TEXT[])
+ORDER BY account_number;
+
 -- name: GetAccountsByStatus :many
 SELECT 
     id,
@@ -52,7 +79,8 @@ SELECT
     status,
     created_at,
     updated_at,
-    version
+    version,
+    account_type
 FROM core.accounts
 WHERE status = $1
 ORDER BY created_at DESC
@@ -68,7 +96,8 @@ SELECT
     status,
     created_at,
     updated_at,
-    version
+    version,
+    account_type
 FROM core.accounts
 WHERE currency = $1
 ORDER BY balance DESC
@@ -84,12 +113,49 @@ SELECT
     h.balance_change,
     h.operation,
     h.created_at,
-    h.created_by
+    h.created_by,
+    h.sequence_number,
+    h.chain_hash
 FROM core.account_balance_history h
 WHERE h.account_id = $1
 ORDER BY h.created_at DESC
 LIMIT $2 OFFSET $3;
 
+-- name: ListBalanceHistory :many
+SELECT 
+    h.id,
+    h.account_id,
+    h.transaction_id,
+    h.old_balance,
+    h.new_balance,
+    h.balance_change,
+    h.operation,
+    h.created_at,
+    h.created_by,
+    h.sequence_number,
+    h.chain_hash
+FROM core.account_balance_history h
+WHERE h.account_id = sqlc.arg(account_id)
+    AND (COALESCE(cardinality(sqlc.arg(operations)::TEXT[]), 0) = 0 OR h.operation = ANY(sqlc.arg(operations)::TEXT[]))
+    AND (sqlc.narg(created_from)::TIMESTAMPTZ IS NULL OR h.created_at >= sqlc.narg(created_from))
+    AND (sqlc.narg(created_to)::TIMESTAMPTZ IS NULL OR h.created_at < sqlc.narg(created_to))
+    AND (sqlc.narg(cursor_created_at)::TIMESTAMPTZ IS NULL OR (h.created_at, h.id) < (sqlc.narg(cursor_created_at), sqlc.narg(cursor_id)::UUID))
+ORDER BY h.created_at DESC, h.id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetBalanceHistoryTotals :many
+SELECT 
+    h.operation,
+    COUNT(*) AS entry_count,
+    COALESCE(SUM(h.balance_change), 0)::DECIMAL AS total_change
+FROM core.account_balance_history h
+WHERE h.account_id = sqlc.arg(account_id)
+    AND (COALESCE(cardinality(sqlc.arg(operations)::TEXT[]), 0) = 0 OR h.operation = ANY(sqlc.arg(operations)::TEXT[]))
+    AND (sqlc.narg(created_from)::TIMESTAMPTZ IS NULL OR h.created_at >= sqlc.narg(created_from))
+    AND (sqlc.narg(created_to)::TIMESTAMPTZ IS NULL OR h.created_at < sqlc.narg(created_to))
+GROUP BY h.operation
+ORDER BY h.operation;
+
 -- name: GetAccountSummary :one
 SELECT 
     a.id,
@@ -144,6 +210,26 @@ WHERE balance < $1 AND status = 'active'
 ORDER BY balance ASC
 LIMIT $2 OFFSET $3;
 
+-- name: GetDormantAccounts :many
+-- Active accounts opened before inactive_since without transactions since, the longest unused first
+SELECT 
+    a.id,
+    a.account_number,
+    a.account_name,
+    a.balance,
+    a.currency,
+    a.status,
+    a.created_at,
+    (SELECT MAX(t.created_at) FROM core.transactions t WHERE t.account_id = a.id)::TIMESTAMPTZ AS last_transaction_at
+FROM core.accounts a
+WHERE a.status = 'active'
+    AND a.created_at < sqlc.arg(inactive_since)
+    AND NOT EXISTS (
+        SELECT 1 FROM core.transactions t WHERE t.account_id = a.id AND t.created_at >= sqlc.arg(inactive_since)
+    )
+ORDER BY last_transaction_at ASC NULLS FIRST, a.account_number
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
 -- name: GetAccountsByBalanceRange :many
 SELECT 
     id,
@@ -158,3 +244,23 @@ FROM core.accounts
 WHERE balance BETWEEN $1 AND $2
 ORDER BY balance DESC
 LIMIT $3 OFFSET $4;
+
+-- name: SearchAccounts :many
+-- Accounts whose account number contains query or whose name starts with it, served by the trigram indexes
+SELECT 
+    id,
+    account_number,
+    account_name,
+    balance,
+    currency,
+    status,
+    created_at,
+    updated_at,
+    version,
+    account_type
+FROM core.accounts
+WHERE (account_number ILIKE '%' || sqlc.arg(query)::TEXT || '%' OR account_name ILIKE sqlc.arg(query)::TEXT || '%')
+    AND (sqlc.narg(currency)::core.currency_code IS NULL OR currency = sqlc.narg(currency))
+    AND (sqlc.narg(status)::core.account_status IS NULL OR status = sqlc.narg(status))
+ORDER BY account_number
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
@@ -0,0 +1,12 @@
+-- name: CreateAdminAudit :one
+INSERT INTO core.admin_audit (
+    actor,
+    action,
+    service,
+    resource_type,
+    resource_id,
+    before_payload,
+    after_payload
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
@@ -0,0 +1,61 @@
+-- name: CreateBalanceAlert :one
+INSERT INTO core.balance_alerts (
+    account_id,
+    threshold,
+    webhook_url
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: GetBalanceAlertByID :one
+SELECT * FROM core.balance_alerts
+WHERE id = $1;
+
+-- name: ListBalanceAlerts :many
+SELECT * FROM core.balance_alerts
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: ListBalanceAlertsByAccount :many
+SELECT * FROM core.balance_alerts
+WHERE account_id = $1
+ORDER BY created_at DESC;
+
+-- name: SetBalanceAlertEnabled :one
+UPDATE core.balance_alerts
+SET
+    enabled = $2,
+    triggered = CASE WHEN $2 THEN triggered ELSE FALSE END
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteBalanceAlert :execrows
+DELETE FROM core.balance_alerts
+WHERE id = $1;
+
+-- name: ListEnabledBalanceAlertsWithBalance :many
+SELECT
+    ba.id,
+    ba.account_id,
+    ba.threshold,
+    ba.webhook_url,
+    ba.triggered,
+    a.account_number,
+    a.balance,
+    a.currency
+FROM core.balance_alerts ba
+JOIN core.accounts a ON a.id = ba.account_id
+WHERE ba.enabled = TRUE
+ORDER BY ba.created_at ASC;
+
+-- name: MarkBalanceAlertTriggered :exec
+UPDATE core.balance_alerts
+SET
+    triggered = TRUE,
+    last_triggered_at = NOW()
+WHERE id = $1;
+
+-- name: ResetBalanceAlert :exec
+UPDATE core.balance_alerts
+SET triggered = FALSE
+WHERE id = $1;
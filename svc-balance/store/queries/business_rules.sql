@@ -0,0 +1,32 @@
+-- name: CreateBusinessRule :one
+INSERT INTO core.business_rules (
+    name,
+    kind,
+    currency,
+    threshold,
+    severity,
+    enabled
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetBusinessRuleByID :one
+SELECT * FROM core.business_rules
+WHERE id = $1;
+
+-- name: ListBusinessRules :many
+SELECT * FROM core.business_rules
+ORDER BY name ASC, currency ASC NULLS FIRST;
+
+-- name: UpdateBusinessRule :one
+UPDATE core.business_rules
+SET
+    threshold = $2,
+    severity = $3,
+    enabled = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteBusinessRule :execrows
+DELETE FROM core.business_rules
+WHERE id = $1;
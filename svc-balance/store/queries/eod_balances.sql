@@ -0,0 +1,7 @@
+-- name: ListEODBalances :many
+-- Closing balances of an account for the business dates from from_date to to_date, both inclusive
+SELECT * FROM core.eod_balances
+WHERE account_id = sqlc.arg(account_id)
+  AND business_date >= sqlc.arg(from_date)::date
+  AND business_date <= sqlc.arg(to_date)::date
+ORDER BY business_date;
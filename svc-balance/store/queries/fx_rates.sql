@@ -0,0 +1,16 @@
+-- name: CreateFxRate :one
+INSERT INTO core.fx_rates (
+    currency,
+    rate_to_usd,
+    source,
+    fetched_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetFxRateAsOf :one
+SELECT * FROM core.fx_rates
+WHERE currency = $1
+  AND fetched_at <= $2
+ORDER BY fetched_at DESC
+LIMIT 1;
@@ -0,0 +1,58 @@
+-- name: UpsertNotificationPreference :one
+INSERT INTO core.notification_preferences (
+    account_id,
+    email,
+    notify_completed,
+    notify_compensated
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (account_id) DO UPDATE SET
+    email = EXCLUDED.email,
+    notify_completed = EXCLUDED.notify_completed,
+    notify_compensated = EXCLUDED.notify_compensated
+RETURNING *;
+
+-- name: GetNotificationPreference :one
+SELECT * FROM core.notification_preferences
+WHERE account_id = $1;
+
+-- name: DeleteNotificationPreference :execrows
+DELETE FROM core.notification_preferences
+WHERE account_id = $1;
+
+-- name: GetNotificationRecipient :one
+SELECT
+    a.id AS account_id,
+    a.account_number,
+    a.account_name,
+    p.email,
+    p.notify_completed,
+    p.notify_compensated,
+    EXISTS (
+        SELECT 1 FROM core.notification_suppressions s
+        WHERE s.email = lower(p.email)
+    ) AS suppressed
+FROM core.notification_preferences p
+JOIN core.accounts a ON a.id = p.account_id
+WHERE a.account_number = $1;
+
+-- name: AddNotificationSuppression :one
+INSERT INTO core.notification_suppressions (
+    email,
+    reason
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (email) DO UPDATE SET
+    reason = EXCLUDED.reason
+RETURNING *;
+
+-- name: ListNotificationSuppressions :many
+SELECT * FROM core.notification_suppressions
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: DeleteNotificationSuppression :execrows
+DELETE FROM core.notification_suppressions
+WHERE email = $1;
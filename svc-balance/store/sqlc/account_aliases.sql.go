@@ -0,0 +1,123 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: account_aliases.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createAccountAlias = `-- name: CreateAccountAlias :one
+INSERT INTO core.account_aliases (
+    alias,
+    alias_type,
+    account_id
+) VALUES (
+    $1, $2, $3
+) RETURNING alias, alias_type, account_id, created_at
+`
+
+type CreateAccountAliasParams struct {
+	Alias     string      `json:"alias"`
+	AliasType string      `json:"alias_type"`
+	AccountID pgtype.UUID `json:"account_id"`
+}
+
+func (q *Queries) CreateAccountAlias(ctx context.Context, arg CreateAccountAliasParams) (CoreAccountAlias, error) {
+	row := q.db.QueryRow(ctx, createAccountAlias, arg.Alias, arg.AliasType, arg.AccountID)
+	var i CoreAccountAlias
+	err := row.Scan(
+		&i.Alias,
+		&i.AliasType,
+		&i.AccountID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteAccountAlias = `-- name: DeleteAccountAlias :execrows
+DELETE FROM core.account_aliases
+WHERE alias = $1
+`
+
+func (q *Queries) DeleteAccountAlias(ctx context.Context, alias string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAccountAlias, alias)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listAccountAliases = `-- name: ListAccountAliases :many
+SELECT alias, alias_type, account_id, created_at FROM core.account_aliases
+WHERE account_id = $1
+ORDER BY created_at, alias
+`
+
+func (q *Queries) ListAccountAliases(ctx context.Context, accountID pgtype.UUID) ([]CoreAccountAlias, error) {
+	rows, err := q.db.Query(ctx, listAccountAliases, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreAccountAlias{}
+	for rows.Next() {
+		var i CoreAccountAlias
+		if err := rows.Scan(
+			&i.Alias,
+			&i.AliasType,
+			&i.AccountID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const resolveAccountAlias = `-- name: ResolveAccountAlias :one
+SELECT
+    al.alias,
+    al.alias_type,
+    a.id AS account_id,
+    a.account_number,
+    a.account_name,
+    a.currency,
+    a.status
+FROM core.account_aliases al
+JOIN core.accounts a ON a.id = al.account_id
+WHERE al.alias = $1
+`
+
+type ResolveAccountAliasRow struct {
+	Alias         string            `json:"alias"`
+	AliasType     string            `json:"alias_type"`
+	AccountID     pgtype.UUID       `json:"account_id"`
+	AccountNumber string            `json:"account_number"`
+	AccountName   string            `json:"account_name"`
+	Currency      CoreCurrencyCode  `json:"currency"`
+	Status        CoreAccountStatus `json:"status"`
+}
+
+func (q *Queries) ResolveAccountAlias(ctx context.Context, alias string) (ResolveAccountAliasRow, error) {
+	row := q.db.QueryRow(ctx, resolveAccountAlias, alias)
+	var i ResolveAccountAliasRow
+	err := row.Scan(
+		&i.Alias,
+		&i.AliasType,
+		&i.AccountID,
+		&i.AccountNumber,
+		&i.AccountName,
+		&i.Currency,
+		&i.Status,
+	)
+	return i, err
+}
@@ -58,6 +58,27 @@ func (q *Queries) CheckAccountBalance(ctx context.Context, arg CheckAccountBalan
 	return i, err
 }
 
+const countOutgoingDebits = `-- name: CountOutgoingDebits :one
+SELECT COUNT(*) FROM core.transactions
+WHERE account_id = $1
+AND transaction_type = 'debit'
+AND status IN ('pending', 'completed')
+AND created_at >= $2::TIMESTAMPTZ
+`
+
+type CountOutgoingDebitsParams struct {
+	AccountID   pgtype.UUID        `json:"account_id"`
+	CreatedFrom pgtype.Timestamptz `json:"created_from"`
+}
+
+// Debits of an account created since created_from that were not cancelled or failed
+func (q *Queries) CountOutgoingDebits(ctx context.Context, arg CountOutgoingDebitsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countOutgoingDebits, arg.AccountID, arg.CreatedFrom)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const getAccountBalanceHistory = `-- name: GetAccountBalanceHistory :many
 SELECT 
     h.id,
@@ -68,7 +89,9 @@ SELECT
     h.balance_change,
     h.operation,
     h.created_at,
-    h.created_by
+    h.created_by,
+    h.sequence_number,
+    h.chain_hash
 FROM core.account_balance_history h
 WHERE h.account_id = $1
 ORDER BY h.created_at DESC
@@ -100,6 +123,8 @@ func (q *Queries) GetAccountBalanceHistory(ctx context.Context, arg GetAccountBa
 			&i.Operation,
 			&i.CreatedAt,
 			&i.CreatedBy,
+			&i.SequenceNumber,
+			&i.ChainHash,
 		); err != nil {
 			return nil, err
 		}
@@ -121,7 +146,8 @@ SELECT
     status,
     created_at,
     updated_at,
-    version
+    version,
+    account_type
 FROM core.accounts
 WHERE id = $1
 `
@@ -139,6 +165,7 @@ func (q *Queries) GetAccountByID(ctx context.Context, id pgtype.UUID) (CoreAccou
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Version,
+		&i.AccountType,
 	)
 	return i, err
 }
@@ -153,7 +180,8 @@ SELECT
     status,
     created_at,
     updated_at,
-    version
+    version,
+    account_type
 FROM core.accounts
 WHERE account_number = $1
 `
@@ -171,6 +199,7 @@ func (q *Queries) GetAccountByNumber(ctx context.Context, accountNumber string)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Version,
+		&i.AccountType,
 	)
 	return i, err
 }
@@ -305,7 +334,8 @@ SELECT
     status,
     created_at,
     updated_at,
-    version
+    version,
+    account_type
 FROM core.accounts
 WHERE currency = $1
 ORDER BY balance DESC
@@ -337,6 +367,61 @@ func (q *Queries) GetAccountsByCurrency(ctx context.Context, arg GetAccountsByCu
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.Version,
+			&i.AccountType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAccountsByIDsOrNumbers = `-- name: GetAccountsByIDsOrNumbers :many
+SELECT 
+    id,
+    account_number,
+    account_name,
+    balance,
+    currency,
+    status,
+    created_at,
+    updated_at,
+    version,
+    account_type
+FROM core.accounts
+WHERE id = ANY($1::UUID[]) OR account_number = ANY($2::TEXT[])
+ORDER BY account_number
+`
+
+type GetAccountsByIDsOrNumbersParams struct {
+	Ids            []pgtype.UUID `json:"ids"`
+	AccountNumbers []string      `json:"account_numbers"`
+}
+
+// Accounts whose ID is in ids or whose account number is in account_numbers, read in one round trip
+func (q *Queries) GetAccountsByIDsOrNumbers(ctx context.Context, arg GetAccountsByIDsOrNumbersParams) ([]CoreAccount, error) {
+	rows, err := q.db.Query(ctx, getAccountsByIDsOrNumbers, arg.Ids, arg.AccountNumbers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreAccount{}
+	for rows.Next() {
+		var i CoreAccount
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountNumber,
+			&i.AccountName,
+			&i.Balance,
+			&i.Currency,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.AccountType,
 		); err != nil {
 			return nil, err
 		}
@@ -358,7 +443,8 @@ SELECT
     status,
     created_at,
     updated_at,
-    version
+    version,
+    account_type
 FROM core.accounts
 WHERE status = $1
 ORDER BY created_at DESC
@@ -390,6 +476,7 @@ func (q *Queries) GetAccountsByStatus(ctx context.Context, arg GetAccountsByStat
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.Version,
+			&i.AccountType,
 		); err != nil {
 			return nil, err
 		}
@@ -463,6 +550,264 @@ func (q *Queries) GetAccountsWithLowBalance(ctx context.Context, arg GetAccounts
 	return items, nil
 }
 
+const getBalanceHistoryTotals = `-- name: GetBalanceHistoryTotals :many
+SELECT 
+    h.operation,
+    COUNT(*) AS entry_count,
+    COALESCE(SUM(h.balance_change), 0)::DECIMAL AS total_change
+FROM core.account_balance_history h
+WHERE h.account_id = $1
+    AND (COALESCE(cardinality($2::TEXT[]), 0) = 0 OR h.operation = ANY($2::TEXT[]))
+    AND ($3::TIMESTAMPTZ IS NULL OR h.created_at >= $3)
+    AND ($4::TIMESTAMPTZ IS NULL OR h.created_at < $4)
+GROUP BY h.operation
+ORDER BY h.operation
+`
+
+type GetBalanceHistoryTotalsParams struct {
+	AccountID   pgtype.UUID        `json:"account_id"`
+	Operations  []string           `json:"operations"`
+	CreatedFrom pgtype.Timestamptz `json:"created_from"`
+	CreatedTo   pgtype.Timestamptz `json:"created_to"`
+}
+
+type GetBalanceHistoryTotalsRow struct {
+	Operation   string         `json:"operation"`
+	EntryCount  int64          `json:"entry_count"`
+	TotalChange pgtype.Numeric `json:"total_change"`
+}
+
+func (q *Queries) GetBalanceHistoryTotals(ctx context.Context, arg GetBalanceHistoryTotalsParams) ([]GetBalanceHistoryTotalsRow, error) {
+	rows, err := q.db.Query(ctx, getBalanceHistoryTotals,
+		arg.AccountID,
+		arg.Operations,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetBalanceHistoryTotalsRow{}
+	for rows.Next() {
+		var i GetBalanceHistoryTotalsRow
+		if err := rows.Scan(&i.Operation, &i.EntryCount, &i.TotalChange); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDormantAccounts = `-- name: GetDormantAccounts :many
+SELECT 
+    a.id,
+    a.account_number,
+    a.account_name,
+    a.balance,
+    a.currency,
+    a.status,
+    a.created_at,
+    (SELECT MAX(t.created_at) FROM core.transactions t WHERE t.account_id = a.id)::TIMESTAMPTZ AS last_transaction_at
+FROM core.accounts a
+WHERE a.status = 'active'
+    AND a.created_at < $1
+    AND NOT EXISTS (
+        SELECT 1 FROM core.transactions t WHERE t.account_id = a.id AND t.created_at >= $1
+    )
+ORDER BY last_transaction_at ASC NULLS FIRST, a.account_number
+LIMIT $2 OFFSET $3
+`
+
+type GetDormantAccountsParams struct {
+	InactiveSince pgtype.Timestamptz `json:"inactive_since"`
+	RowLimit      int32              `json:"row_limit"`
+	RowOffset     int32              `json:"row_offset"`
+}
+
+type GetDormantAccountsRow struct {
+	ID                pgtype.UUID        `json:"id"`
+	AccountNumber     string             `json:"account_number"`
+	AccountName       string             `json:"account_name"`
+	Balance           pgtype.Numeric     `json:"balance"`
+	Currency          CoreCurrencyCode   `json:"currency"`
+	Status            CoreAccountStatus  `json:"status"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	LastTransactionAt pgtype.Timestamptz `json:"last_transaction_at"`
+}
+
+// Active accounts opened before inactive_since without transactions since, the longest unused first
+func (q *Queries) GetDormantAccounts(ctx context.Context, arg GetDormantAccountsParams) ([]GetDormantAccountsRow, error) {
+	rows, err := q.db.Query(ctx, getDormantAccounts, arg.InactiveSince, arg.RowLimit, arg.RowOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDormantAccountsRow{}
+	for rows.Next() {
+		var i GetDormantAccountsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountNumber,
+			&i.AccountName,
+			&i.Balance,
+			&i.Currency,
+			&i.Status,
+			&i.CreatedAt,
+			&i.LastTransactionAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBalanceHistory = `-- name: ListBalanceHistory :many
+SELECT 
+    h.id,
+    h.account_id,
+    h.transaction_id,
+    h.old_balance,
+    h.new_balance,
+    h.balance_change,
+    h.operation,
+    h.created_at,
+    h.created_by,
+    h.sequence_number,
+    h.chain_hash
+FROM core.account_balance_history h
+WHERE h.account_id = $1
+    AND (COALESCE(cardinality($2::TEXT[]), 0) = 0 OR h.operation = ANY($2::TEXT[]))
+    AND ($3::TIMESTAMPTZ IS NULL OR h.created_at >= $3)
+    AND ($4::TIMESTAMPTZ IS NULL OR h.created_at < $4)
+    AND ($5::TIMESTAMPTZ IS NULL OR (h.created_at, h.id) < ($5, $6::UUID))
+ORDER BY h.created_at DESC, h.id DESC
+LIMIT $7
+`
+
+type ListBalanceHistoryParams struct {
+	AccountID       pgtype.UUID        `json:"account_id"`
+	Operations      []string           `json:"operations"`
+	CreatedFrom     pgtype.Timestamptz `json:"created_from"`
+	CreatedTo       pgtype.Timestamptz `json:"created_to"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.UUID        `json:"cursor_id"`
+	RowLimit        int32              `json:"row_limit"`
+}
+
+func (q *Queries) ListBalanceHistory(ctx context.Context, arg ListBalanceHistoryParams) ([]CoreAccountBalanceHistory, error) {
+	rows, err := q.db.Query(ctx, listBalanceHistory,
+		arg.AccountID,
+		arg.Operations,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreAccountBalanceHistory{}
+	for rows.Next() {
+		var i CoreAccountBalanceHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.TransactionID,
+			&i.OldBalance,
+			&i.NewBalance,
+			&i.BalanceChange,
+			&i.Operation,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.SequenceNumber,
+			&i.ChainHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchAccounts = `-- name: SearchAccounts :many
+SELECT 
+    id,
+    account_number,
+    account_name,
+    balance,
+    currency,
+    status,
+    created_at,
+    updated_at,
+    version,
+    account_type
+FROM core.accounts
+WHERE (account_number ILIKE '%' || $1::TEXT || '%' OR account_name ILIKE $1::TEXT || '%')
+    AND ($2::core.currency_code IS NULL OR currency = $2)
+    AND ($3::core.account_status IS NULL OR status = $3)
+ORDER BY account_number
+LIMIT $4 OFFSET $5
+`
+
+type SearchAccountsParams struct {
+	Query     string                `json:"query"`
+	Currency  NullCoreCurrencyCode  `json:"currency"`
+	Status    NullCoreAccountStatus `json:"status"`
+	RowLimit  int32                 `json:"row_limit"`
+	RowOffset int32                 `json:"row_offset"`
+}
+
+// Accounts whose account number contains query or whose name starts with it, served by the trigram indexes
+func (q *Queries) SearchAccounts(ctx context.Context, arg SearchAccountsParams) ([]CoreAccount, error) {
+	rows, err := q.db.Query(ctx, searchAccounts,
+		arg.Query,
+		arg.Currency,
+		arg.Status,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreAccount{}
+	for rows.Next() {
+		var i CoreAccount
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountNumber,
+			&i.AccountName,
+			&i.Balance,
+			&i.Currency,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.AccountType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const validateAccountForTransaction = `-- name: ValidateAccountForTransaction :one
 SELECT 
     id,
@@ -0,0 +1,61 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: admin_audit.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createAdminAudit = `-- name: CreateAdminAudit :one
+INSERT INTO core.admin_audit (
+    actor,
+    action,
+    service,
+    resource_type,
+    resource_id,
+    before_payload,
+    after_payload
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, actor, action, service, resource_type, resource_id, before_payload, after_payload, created_at
+`
+
+type CreateAdminAuditParams struct {
+	Actor         string      `json:"actor"`
+	Action        string      `json:"action"`
+	Service       string      `json:"service"`
+	ResourceType  string      `json:"resource_type"`
+	ResourceID    pgtype.Text `json:"resource_id"`
+	BeforePayload []byte      `json:"before_payload"`
+	AfterPayload  []byte      `json:"after_payload"`
+}
+
+func (q *Queries) CreateAdminAudit(ctx context.Context, arg CreateAdminAuditParams) (CoreAdminAudit, error) {
+	row := q.db.QueryRow(ctx, createAdminAudit,
+		arg.Actor,
+		arg.Action,
+		arg.Service,
+		arg.ResourceType,
+		arg.ResourceID,
+		arg.BeforePayload,
+		arg.AfterPayload,
+	)
+	var i CoreAdminAudit
+	err := row.Scan(
+		&i.ID,
+		&i.Actor,
+		&i.Action,
+		&i.Service,
+		&i.ResourceType,
+		&i.ResourceID,
+		&i.BeforePayload,
+		&i.AfterPayload,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,268 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: balance_alerts.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createBalanceAlert = `-- name: CreateBalanceAlert :one
+INSERT INTO core.balance_alerts (
+    account_id,
+    threshold,
+    webhook_url
+) VALUES (
+    $1, $2, $3
+) RETURNING id, account_id, threshold, webhook_url, enabled, triggered, last_triggered_at, created_at, updated_at
+`
+
+type CreateBalanceAlertParams struct {
+	AccountID  pgtype.UUID    `json:"account_id"`
+	Threshold  pgtype.Numeric `json:"threshold"`
+	WebhookUrl string         `json:"webhook_url"`
+}
+
+func (q *Queries) CreateBalanceAlert(ctx context.Context, arg CreateBalanceAlertParams) (CoreBalanceAlert, error) {
+	row := q.db.QueryRow(ctx, createBalanceAlert, arg.AccountID, arg.Threshold, arg.WebhookUrl)
+	var i CoreBalanceAlert
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Threshold,
+		&i.WebhookUrl,
+		&i.Enabled,
+		&i.Triggered,
+		&i.LastTriggeredAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteBalanceAlert = `-- name: DeleteBalanceAlert :execrows
+DELETE FROM core.balance_alerts
+WHERE id = $1
+`
+
+func (q *Queries) DeleteBalanceAlert(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteBalanceAlert, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getBalanceAlertByID = `-- name: GetBalanceAlertByID :one
+SELECT id, account_id, threshold, webhook_url, enabled, triggered, last_triggered_at, created_at, updated_at FROM core.balance_alerts
+WHERE id = $1
+`
+
+func (q *Queries) GetBalanceAlertByID(ctx context.Context, id pgtype.UUID) (CoreBalanceAlert, error) {
+	row := q.db.QueryRow(ctx, getBalanceAlertByID, id)
+	var i CoreBalanceAlert
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Threshold,
+		&i.WebhookUrl,
+		&i.Enabled,
+		&i.Triggered,
+		&i.LastTriggeredAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listBalanceAlerts = `-- name: ListBalanceAlerts :many
+SELECT id, account_id, threshold, webhook_url, enabled, triggered, last_triggered_at, created_at, updated_at FROM core.balance_alerts
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type ListBalanceAlertsParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListBalanceAlerts(ctx context.Context, arg ListBalanceAlertsParams) ([]CoreBalanceAlert, error) {
+	rows, err := q.db.Query(ctx, listBalanceAlerts, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreBalanceAlert{}
+	for rows.Next() {
+		var i CoreBalanceAlert
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Threshold,
+			&i.WebhookUrl,
+			&i.Enabled,
+			&i.Triggered,
+			&i.LastTriggeredAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBalanceAlertsByAccount = `-- name: ListBalanceAlertsByAccount :many
+SELECT id, account_id, threshold, webhook_url, enabled, triggered, last_triggered_at, created_at, updated_at FROM core.balance_alerts
+WHERE account_id = $1
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListBalanceAlertsByAccount(ctx context.Context, accountID pgtype.UUID) ([]CoreBalanceAlert, error) {
+	rows, err := q.db.Query(ctx, listBalanceAlertsByAccount, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreBalanceAlert{}
+	for rows.Next() {
+		var i CoreBalanceAlert
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Threshold,
+			&i.WebhookUrl,
+			&i.Enabled,
+			&i.Triggered,
+			&i.LastTriggeredAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEnabledBalanceAlertsWithBalance = `-- name: ListEnabledBalanceAlertsWithBalance :many
+SELECT
+    ba.id,
+    ba.account_id,
+    ba.threshold,
+    ba.webhook_url,
+    ba.triggered,
+    a.account_number,
+    a.balance,
+    a.currency
+FROM core.balance_alerts ba
+JOIN core.accounts a ON a.id = ba.account_id
+WHERE ba.enabled = TRUE
+ORDER BY ba.created_at ASC
+`
+
+type ListEnabledBalanceAlertsWithBalanceRow struct {
+	ID            pgtype.UUID      `json:"id"`
+	AccountID     pgtype.UUID      `json:"account_id"`
+	Threshold     pgtype.Numeric   `json:"threshold"`
+	WebhookUrl    string           `json:"webhook_url"`
+	Triggered     bool             `json:"triggered"`
+	AccountNumber string           `json:"account_number"`
+	Balance       pgtype.Numeric   `json:"balance"`
+	Currency      CoreCurrencyCode `json:"currency"`
+}
+
+func (q *Queries) ListEnabledBalanceAlertsWithBalance(ctx context.Context) ([]ListEnabledBalanceAlertsWithBalanceRow, error) {
+	rows, err := q.db.Query(ctx, listEnabledBalanceAlertsWithBalance)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListEnabledBalanceAlertsWithBalanceRow{}
+	for rows.Next() {
+		var i ListEnabledBalanceAlertsWithBalanceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Threshold,
+			&i.WebhookUrl,
+			&i.Triggered,
+			&i.AccountNumber,
+			&i.Balance,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markBalanceAlertTriggered = `-- name: MarkBalanceAlertTriggered :exec
+UPDATE core.balance_alerts
+SET
+    triggered = TRUE,
+    last_triggered_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkBalanceAlertTriggered(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, markBalanceAlertTriggered, id)
+	return err
+}
+
+const resetBalanceAlert = `-- name: ResetBalanceAlert :exec
+UPDATE core.balance_alerts
+SET triggered = FALSE
+WHERE id = $1
+`
+
+func (q *Queries) ResetBalanceAlert(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, resetBalanceAlert, id)
+	return err
+}
+
+const setBalanceAlertEnabled = `-- name: SetBalanceAlertEnabled :one
+UPDATE core.balance_alerts
+SET
+    enabled = $2,
+    triggered = CASE WHEN $2 THEN triggered ELSE FALSE END
+WHERE id = $1
+RETURNING id, account_id, threshold, webhook_url, enabled, triggered, last_triggered_at, created_at, updated_at
+`
+
+type SetBalanceAlertEnabledParams struct {
+	ID      pgtype.UUID `json:"id"`
+	Enabled bool        `json:"enabled"`
+}
+
+func (q *Queries) SetBalanceAlertEnabled(ctx context.Context, arg SetBalanceAlertEnabledParams) (CoreBalanceAlert, error) {
+	row := q.db.QueryRow(ctx, setBalanceAlertEnabled, arg.ID, arg.Enabled)
+	var i CoreBalanceAlert
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Threshold,
+		&i.WebhookUrl,
+		&i.Enabled,
+		&i.Triggered,
+		&i.LastTriggeredAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
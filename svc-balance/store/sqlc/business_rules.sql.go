@@ -0,0 +1,167 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: business_rules.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createBusinessRule = `-- name: CreateBusinessRule :one
+INSERT INTO core.business_rules (
+    name,
+    kind,
+    currency,
+    threshold,
+    severity,
+    enabled
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, name, kind, currency, threshold, severity, enabled, created_at, updated_at
+`
+
+type CreateBusinessRuleParams struct {
+	Name      string               `json:"name"`
+	Kind      string               `json:"kind"`
+	Currency  NullCoreCurrencyCode `json:"currency"`
+	Threshold pgtype.Numeric       `json:"threshold"`
+	Severity  string               `json:"severity"`
+	Enabled   bool                 `json:"enabled"`
+}
+
+func (q *Queries) CreateBusinessRule(ctx context.Context, arg CreateBusinessRuleParams) (CoreBusinessRule, error) {
+	row := q.db.QueryRow(ctx, createBusinessRule,
+		arg.Name,
+		arg.Kind,
+		arg.Currency,
+		arg.Threshold,
+		arg.Severity,
+		arg.Enabled,
+	)
+	var i CoreBusinessRule
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.Currency,
+		&i.Threshold,
+		&i.Severity,
+		&i.Enabled,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteBusinessRule = `-- name: DeleteBusinessRule :execrows
+DELETE FROM core.business_rules
+WHERE id = $1
+`
+
+func (q *Queries) DeleteBusinessRule(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteBusinessRule, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getBusinessRuleByID = `-- name: GetBusinessRuleByID :one
+SELECT id, name, kind, currency, threshold, severity, enabled, created_at, updated_at FROM core.business_rules
+WHERE id = $1
+`
+
+func (q *Queries) GetBusinessRuleByID(ctx context.Context, id pgtype.UUID) (CoreBusinessRule, error) {
+	row := q.db.QueryRow(ctx, getBusinessRuleByID, id)
+	var i CoreBusinessRule
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.Currency,
+		&i.Threshold,
+		&i.Severity,
+		&i.Enabled,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listBusinessRules = `-- name: ListBusinessRules :many
+SELECT id, name, kind, currency, threshold, severity, enabled, created_at, updated_at FROM core.business_rules
+ORDER BY name ASC, currency ASC NULLS FIRST
+`
+
+func (q *Queries) ListBusinessRules(ctx context.Context) ([]CoreBusinessRule, error) {
+	rows, err := q.db.Query(ctx, listBusinessRules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreBusinessRule{}
+	for rows.Next() {
+		var i CoreBusinessRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Kind,
+			&i.Currency,
+			&i.Threshold,
+			&i.Severity,
+			&i.Enabled,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateBusinessRule = `-- name: UpdateBusinessRule :one
+UPDATE core.business_rules
+SET
+    threshold = $2,
+    severity = $3,
+    enabled = $4
+WHERE id = $1
+RETURNING id, name, kind, currency, threshold, severity, enabled, created_at, updated_at
+`
+
+type UpdateBusinessRuleParams struct {
+	ID        pgtype.UUID    `json:"id"`
+	Threshold pgtype.Numeric `json:"threshold"`
+	Severity  string         `json:"severity"`
+	Enabled   bool           `json:"enabled"`
+}
+
+func (q *Queries) UpdateBusinessRule(ctx context.Context, arg UpdateBusinessRuleParams) (CoreBusinessRule, error) {
+	row := q.db.QueryRow(ctx, updateBusinessRule,
+		arg.ID,
+		arg.Threshold,
+		arg.Severity,
+		arg.Enabled,
+	)
+	var i CoreBusinessRule
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.Currency,
+		&i.Threshold,
+		&i.Severity,
+		&i.Enabled,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,55 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: eod_balances.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const listEODBalances = `-- name: ListEODBalances :many
+SELECT account_id, business_date, currency, closing_balance, entry_count, last_sequence_number, computed_at FROM core.eod_balances
+WHERE account_id = $1
+  AND business_date >= $2::date
+  AND business_date <= $3::date
+ORDER BY business_date
+`
+
+type ListEODBalancesParams struct {
+	AccountID pgtype.UUID `json:"account_id"`
+	FromDate  pgtype.Date `json:"from_date"`
+	ToDate    pgtype.Date `json:"to_date"`
+}
+
+// Closing balances of an account for the business dates from from_date to to_date, both inclusive
+func (q *Queries) ListEODBalances(ctx context.Context, arg ListEODBalancesParams) ([]CoreEodBalance, error) {
+	rows, err := q.db.Query(ctx, listEODBalances, arg.AccountID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreEodBalance{}
+	for rows.Next() {
+		var i CoreEodBalance
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.BusinessDate,
+			&i.Currency,
+			&i.ClosingBalance,
+			&i.EntryCount,
+			&i.LastSequenceNumber,
+			&i.ComputedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
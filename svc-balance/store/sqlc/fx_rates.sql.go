@@ -0,0 +1,74 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: fx_rates.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createFxRate = `-- name: CreateFxRate :one
+INSERT INTO core.fx_rates (
+    currency,
+    rate_to_usd,
+    source,
+    fetched_at
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, currency, rate_to_usd, source, fetched_at
+`
+
+type CreateFxRateParams struct {
+	Currency  CoreCurrencyCode   `json:"currency"`
+	RateToUsd pgtype.Numeric     `json:"rate_to_usd"`
+	Source    string             `json:"source"`
+	FetchedAt pgtype.Timestamptz `json:"fetched_at"`
+}
+
+func (q *Queries) CreateFxRate(ctx context.Context, arg CreateFxRateParams) (CoreFxRate, error) {
+	row := q.db.QueryRow(ctx, createFxRate,
+		arg.Currency,
+		arg.RateToUsd,
+		arg.Source,
+		arg.FetchedAt,
+	)
+	var i CoreFxRate
+	err := row.Scan(
+		&i.ID,
+		&i.Currency,
+		&i.RateToUsd,
+		&i.Source,
+		&i.FetchedAt,
+	)
+	return i, err
+}
+
+const getFxRateAsOf = `-- name: GetFxRateAsOf :one
+SELECT id, currency, rate_to_usd, source, fetched_at FROM core.fx_rates
+WHERE currency = $1
+  AND fetched_at <= $2
+ORDER BY fetched_at DESC
+LIMIT 1
+`
+
+type GetFxRateAsOfParams struct {
+	Currency  CoreCurrencyCode   `json:"currency"`
+	FetchedAt pgtype.Timestamptz `json:"fetched_at"`
+}
+
+func (q *Queries) GetFxRateAsOf(ctx context.Context, arg GetFxRateAsOfParams) (CoreFxRate, error) {
+	row := q.db.QueryRow(ctx, getFxRateAsOf, arg.Currency, arg.FetchedAt)
+	var i CoreFxRate
+	err := row.Scan(
+		&i.ID,
+		&i.Currency,
+		&i.RateToUsd,
+		&i.Source,
+		&i.FetchedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,204 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: notifications.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addNotificationSuppression = `-- name: AddNotificationSuppression :one
+INSERT INTO core.notification_suppressions (
+    email,
+    reason
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (email) DO UPDATE SET
+    reason = EXCLUDED.reason
+RETURNING email, reason, created_at
+`
+
+type AddNotificationSuppressionParams struct {
+	Email  string `json:"email"`
+	Reason string `json:"reason"`
+}
+
+func (q *Queries) AddNotificationSuppression(ctx context.Context, arg AddNotificationSuppressionParams) (CoreNotificationSuppression, error) {
+	row := q.db.QueryRow(ctx, addNotificationSuppression, arg.Email, arg.Reason)
+	var i CoreNotificationSuppression
+	err := row.Scan(
+		&i.Email,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteNotificationPreference = `-- name: DeleteNotificationPreference :execrows
+DELETE FROM core.notification_preferences
+WHERE account_id = $1
+`
+
+func (q *Queries) DeleteNotificationPreference(ctx context.Context, accountID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteNotificationPreference, accountID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteNotificationSuppression = `-- name: DeleteNotificationSuppression :execrows
+DELETE FROM core.notification_suppressions
+WHERE email = $1
+`
+
+func (q *Queries) DeleteNotificationSuppression(ctx context.Context, email string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteNotificationSuppression, email)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getNotificationPreference = `-- name: GetNotificationPreference :one
+SELECT account_id, email, notify_completed, notify_compensated, created_at, updated_at FROM core.notification_preferences
+WHERE account_id = $1
+`
+
+func (q *Queries) GetNotificationPreference(ctx context.Context, accountID pgtype.UUID) (CoreNotificationPreference, error) {
+	row := q.db.QueryRow(ctx, getNotificationPreference, accountID)
+	var i CoreNotificationPreference
+	err := row.Scan(
+		&i.AccountID,
+		&i.Email,
+		&i.NotifyCompleted,
+		&i.NotifyCompensated,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getNotificationRecipient = `-- name: GetNotificationRecipient :one
+SELECT
+    a.id AS account_id,
+    a.account_number,
+    a.account_name,
+    p.email,
+    p.notify_completed,
+    p.notify_compensated,
+    EXISTS (
+        SELECT 1 FROM core.notification_suppressions s
+        WHERE s.email = lower(p.email)
+    ) AS suppressed
+FROM core.notification_preferences p
+JOIN core.accounts a ON a.id = p.account_id
+WHERE a.account_number = $1
+`
+
+type GetNotificationRecipientRow struct {
+	AccountID         pgtype.UUID `json:"account_id"`
+	AccountNumber     string      `json:"account_number"`
+	AccountName       string      `json:"account_name"`
+	Email             string      `json:"email"`
+	NotifyCompleted   bool        `json:"notify_completed"`
+	NotifyCompensated bool        `json:"notify_compensated"`
+	Suppressed        bool        `json:"suppressed"`
+}
+
+func (q *Queries) GetNotificationRecipient(ctx context.Context, accountNumber string) (GetNotificationRecipientRow, error) {
+	row := q.db.QueryRow(ctx, getNotificationRecipient, accountNumber)
+	var i GetNotificationRecipientRow
+	err := row.Scan(
+		&i.AccountID,
+		&i.AccountNumber,
+		&i.AccountName,
+		&i.Email,
+		&i.NotifyCompleted,
+		&i.NotifyCompensated,
+		&i.Suppressed,
+	)
+	return i, err
+}
+
+const listNotificationSuppressions = `-- name: ListNotificationSuppressions :many
+SELECT email, reason, created_at FROM core.notification_suppressions
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type ListNotificationSuppressionsParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListNotificationSuppressions(ctx context.Context, arg ListNotificationSuppressionsParams) ([]CoreNotificationSuppression, error) {
+	rows, err := q.db.Query(ctx, listNotificationSuppressions, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreNotificationSuppression{}
+	for rows.Next() {
+		var i CoreNotificationSuppression
+		if err := rows.Scan(
+			&i.Email,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertNotificationPreference = `-- name: UpsertNotificationPreference :one
+INSERT INTO core.notification_preferences (
+    account_id,
+    email,
+    notify_completed,
+    notify_compensated
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (account_id) DO UPDATE SET
+    email = EXCLUDED.email,
+    notify_completed = EXCLUDED.notify_completed,
+    notify_compensated = EXCLUDED.notify_compensated
+RETURNING account_id, email, notify_completed, notify_compensated, created_at, updated_at
+`
+
+type UpsertNotificationPreferenceParams struct {
+	AccountID         pgtype.UUID `json:"account_id"`
+	Email             string      `json:"email"`
+	NotifyCompleted   bool        `json:"notify_completed"`
+	NotifyCompensated bool        `json:"notify_compensated"`
+}
+
+func (q *Queries) UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (CoreNotificationPreference, error) {
+	row := q.db.QueryRow(ctx, upsertNotificationPreference,
+		arg.AccountID,
+		arg.Email,
+		arg.NotifyCompleted,
+		arg.NotifyCompensated,
+	)
+	var i CoreNotificationPreference
+	err := row.Scan(
+		&i.AccountID,
+		&i.Email,
+		&i.NotifyCompleted,
+		&i.NotifyCompensated,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -11,15 +11,55 @@ import (
 )
 
 type Querier interface {
+	AddNotificationSuppression(ctx context.Context, arg AddNotificationSuppressionParams) (CoreNotificationSuppression, error)
 	CheckAccountBalance(ctx context.Context, arg CheckAccountBalanceParams) (CheckAccountBalanceRow, error)
+	// Debits of an account created since created_from that were not cancelled or failed
+	CountOutgoingDebits(ctx context.Context, arg CountOutgoingDebitsParams) (int64, error)
+	CreateAccountAlias(ctx context.Context, arg CreateAccountAliasParams) (CoreAccountAlias, error)
+	CreateAdminAudit(ctx context.Context, arg CreateAdminAuditParams) (CoreAdminAudit, error)
+	CreateBalanceAlert(ctx context.Context, arg CreateBalanceAlertParams) (CoreBalanceAlert, error)
+	CreateBusinessRule(ctx context.Context, arg CreateBusinessRuleParams) (CoreBusinessRule, error)
+	CreateFxRate(ctx context.Context, arg CreateFxRateParams) (CoreFxRate, error)
+	DeleteAccountAlias(ctx context.Context, alias string) (int64, error)
+	DeleteBalanceAlert(ctx context.Context, id pgtype.UUID) (int64, error)
+	DeleteBusinessRule(ctx context.Context, id pgtype.UUID) (int64, error)
+	DeleteNotificationPreference(ctx context.Context, accountID pgtype.UUID) (int64, error)
+	DeleteNotificationSuppression(ctx context.Context, email string) (int64, error)
 	GetAccountBalanceHistory(ctx context.Context, arg GetAccountBalanceHistoryParams) ([]CoreAccountBalanceHistory, error)
 	GetAccountByID(ctx context.Context, id pgtype.UUID) (CoreAccount, error)
 	GetAccountByNumber(ctx context.Context, accountNumber string) (CoreAccount, error)
 	GetAccountSummary(ctx context.Context, id pgtype.UUID) (GetAccountSummaryRow, error)
 	GetAccountsByBalanceRange(ctx context.Context, arg GetAccountsByBalanceRangeParams) ([]GetAccountsByBalanceRangeRow, error)
 	GetAccountsByCurrency(ctx context.Context, arg GetAccountsByCurrencyParams) ([]CoreAccount, error)
+	// Accounts whose ID is in ids or whose account number is in account_numbers, read in one round trip
+	GetAccountsByIDsOrNumbers(ctx context.Context, arg GetAccountsByIDsOrNumbersParams) ([]CoreAccount, error)
 	GetAccountsByStatus(ctx context.Context, arg GetAccountsByStatusParams) ([]CoreAccount, error)
 	GetAccountsWithLowBalance(ctx context.Context, arg GetAccountsWithLowBalanceParams) ([]GetAccountsWithLowBalanceRow, error)
+	GetBalanceAlertByID(ctx context.Context, id pgtype.UUID) (CoreBalanceAlert, error)
+	GetBalanceHistoryTotals(ctx context.Context, arg GetBalanceHistoryTotalsParams) ([]GetBalanceHistoryTotalsRow, error)
+	GetBusinessRuleByID(ctx context.Context, id pgtype.UUID) (CoreBusinessRule, error)
+	// Active accounts opened before inactive_since without transactions since, the longest unused first
+	GetDormantAccounts(ctx context.Context, arg GetDormantAccountsParams) ([]GetDormantAccountsRow, error)
+	GetFxRateAsOf(ctx context.Context, arg GetFxRateAsOfParams) (CoreFxRate, error)
+	GetNotificationPreference(ctx context.Context, accountID pgtype.UUID) (CoreNotificationPreference, error)
+	GetNotificationRecipient(ctx context.Context, accountNumber string) (GetNotificationRecipientRow, error)
+	ListAccountAliases(ctx context.Context, accountID pgtype.UUID) ([]CoreAccountAlias, error)
+	ListBalanceAlerts(ctx context.Context, arg ListBalanceAlertsParams) ([]CoreBalanceAlert, error)
+	ListBalanceAlertsByAccount(ctx context.Context, accountID pgtype.UUID) ([]CoreBalanceAlert, error)
+	ListBalanceHistory(ctx context.Context, arg ListBalanceHistoryParams) ([]CoreAccountBalanceHistory, error)
+	ListBusinessRules(ctx context.Context) ([]CoreBusinessRule, error)
+	// Closing balances of an account for the business dates from from_date to to_date, both inclusive
+	ListEODBalances(ctx context.Context, arg ListEODBalancesParams) ([]CoreEodBalance, error)
+	ListEnabledBalanceAlertsWithBalance(ctx context.Context) ([]ListEnabledBalanceAlertsWithBalanceRow, error)
+	ListNotificationSuppressions(ctx context.Context, arg ListNotificationSuppressionsParams) ([]CoreNotificationSuppression, error)
+	MarkBalanceAlertTriggered(ctx context.Context, id pgtype.UUID) error
+	ResetBalanceAlert(ctx context.Context, id pgtype.UUID) error
+	ResolveAccountAlias(ctx context.Context, alias string) (ResolveAccountAliasRow, error)
+	// Accounts whose account number contains query or whose name starts with it, served by the trigram indexes
+	SearchAccounts(ctx context.Context, arg SearchAccountsParams) ([]CoreAccount, error)
+	SetBalanceAlertEnabled(ctx context.Context, arg SetBalanceAlertEnabledParams) (CoreBalanceAlert, error)
+	UpdateBusinessRule(ctx context.Context, arg UpdateBusinessRuleParams) (CoreBusinessRule, error)
+	UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) (CoreNotificationPreference, error)
 	ValidateAccountForTransaction(ctx context.Context, arg ValidateAccountForTransactionParams) (ValidateAccountForTransactionRow, error)
 }
 
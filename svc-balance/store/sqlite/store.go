@@ -0,0 +1,49 @@
+// Package sqlite implements store.IStore on a SQLite file, for running the service without Postgres. It runs the
+// sqlc-generated queries through pgsqlite, which rewrites them to SQLite.
+//
+// svc-transaction opens the same file with its own queries, so the balances it moves are read here, as they are on
+// Postgres.
+package sqlite
+
+import (
+	"context"
+
+	"svc-balance/store"
+	"svc-balance/store/sqlc"
+
+	"pgsqlite"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Store struct {
+	*sqlc.Queries
+
+	logger *logrus.Logger
+
+	db *pgsqlite.DB
+}
+
+// NewStore opens the SQLite database at path, creating it when it does not exist; demoData loads the demo accounts
+// into a new database
+func NewStore(ctx context.Context, logger *logrus.Logger, path string, demoData bool) (store.IStore, error) {
+	db, err := pgsqlite.Open(ctx, path, pgsqlite.Options{DemoData: demoData})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		Queries: sqlc.New(db),
+
+		logger: logger,
+
+		db: db,
+	}, nil
+}
+
+// WithTx runs fn in a transaction; a panic in fn rolls it back and is re-raised
+func (store *Store) WithTx(ctx context.Context, fn func(sqlc.Querier) error) error {
+	return store.db.WithTx(ctx, func(tx *pgsqlite.Tx) error {
+		return fn(sqlc.New(tx))
+	})
+}
@@ -0,0 +1,224 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"svc-balance/service"
+	"svc-balance/store/sqlc"
+
+	"pgsqlite"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var johnDoe = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+
+func newTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	return logger
+}
+
+// newTestStore opens a store on a new file with the demo data
+func newTestStore(t *testing.T) (*Store, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "temporal-flow-demo.db")
+
+	store, err := NewStore(context.Background(), newTestLogger(), path, true)
+	require.NoError(t, err)
+
+	return store.(*Store), path
+}
+
+func newTestService(store *Store) *service.Service {
+	return service.NewService(store.logger, store, service.FXPricing{}, service.RoundingPolicy{}, 0)
+}
+
+// TestStatements prepares every statement of the sqlc-generated code on SQLite, the counterpart of TestSchemaDrift:
+// a query the dialect rewrite turns into invalid SQLite, or one referring to a column schema.sql lacks, fails here
+func TestStatements(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestStore(t)
+	statements := generatedStatements(t)
+
+	names := make([]string, 0, len(statements))
+	for name := range statements {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if err := store.db.Prepare(context.Background(), statements[name]); err != nil {
+			t.Errorf("prepare %s: %v", name, err)
+		}
+	}
+}
+
+// TestQueries runs every query with zero arguments on the demo data. The query may find no row or violate a
+// constraint, but it must bind its arguments and scan its columns.
+func TestQueries(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestStore(t)
+
+	querier := reflect.TypeOf((*sqlc.Querier)(nil)).Elem()
+	value := reflect.ValueOf(store)
+	for i := range querier.NumMethod() {
+		method := querier.Method(i)
+
+		args := []reflect.Value{reflect.ValueOf(context.Background())}
+		for j := 1; j < method.Type.NumIn(); j++ {
+			args = append(args, reflect.Zero(method.Type.In(j)))
+		}
+
+		results := value.MethodByName(method.Name).Call(args)
+		err, _ := results[len(results)-1].Interface().(error)
+
+		var pgErr *pgconn.PgError
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) && !errors.As(err, &pgErr) {
+			t.Errorf("%s: %v", method.Name, err)
+		}
+	}
+}
+
+// generatedStatements reads the queries of the sqlc-generated files by their name
+func generatedStatements(t *testing.T) map[string]string {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join("..", "sqlc", "*.sql.go"))
+	require.NoError(t, err)
+
+	statements := map[string]string{}
+	fileSet := token.NewFileSet()
+	for _, path := range paths {
+		file, err := parser.ParseFile(fileSet, path, nil, 0)
+		require.NoError(t, err)
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			literal, ok := node.(*ast.BasicLit)
+			if !ok || literal.Kind != token.STRING {
+				return true
+			}
+
+			sql, err := strconv.Unquote(literal.Value)
+			if err != nil || !strings.HasPrefix(sql, "-- name: ") {
+				return true
+			}
+
+			statements[strings.Fields(sql)[2]] = sql
+
+			return true
+		})
+	}
+
+	require.NotEmpty(t, statements)
+
+	return statements
+}
+
+func TestCheckBalance(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, _ := newTestStore(t)
+	balanceService := newTestService(store)
+
+	accountID := johnDoe
+	required := decimal.NewFromInt(6000)
+	result, err := balanceService.CheckBalance(ctx, service.CheckBalanceParams{
+		AccountID:      &accountID,
+		RequiredAmount: &required,
+		IncludeDetails: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "ACC001", result.AccountNumber)
+	assert.True(t, decimal.NewFromInt(5000).Equal(result.CurrentBalance))
+	assert.False(t, result.SufficientFunds)
+
+	// The details read the transactions and balance history svc-transaction writes, which the in-memory store lacks
+	require.NotNil(t, result.Details)
+	assert.NotZero(t, result.Details.TransactionCount)
+	assert.NotEmpty(t, result.Details.BalanceHistory)
+}
+
+func TestGetBalanceHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, _ := newTestStore(t)
+
+	result, err := newTestService(store).GetBalanceHistory(ctx, service.GetBalanceHistoryParams{AccountID: johnDoe})
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 3)
+
+	// The seeded entries share their created_at, so only the totals have a set order: the initial credit of 5000 and
+	// the credit of 200 undoing the debit
+	require.Len(t, result.Totals, 2)
+	assert.Equal(t, "credit", result.Totals[0].Operation)
+	assert.Equal(t, int64(2), result.Totals[0].Count)
+	assert.True(t, decimal.NewFromInt(5200).Equal(result.Totals[0].TotalChange))
+	assert.Equal(t, "debit", result.Totals[1].Operation)
+	assert.True(t, decimal.NewFromInt(-200).Equal(result.Totals[1].TotalChange))
+}
+
+// TestSharedFile writes to the file the way svc-transaction does and reads it back through the store
+func TestSharedFile(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, path := newTestStore(t)
+
+	db, err := pgsqlite.Open(ctx, path, pgsqlite.Options{DemoData: true})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(ctx, `UPDATE core.accounts SET balance = balance - $1::DECIMAL WHERE id = $2`, 100.25, johnDoe.String())
+	require.NoError(t, err)
+
+	account, err := store.GetAccountByID(ctx, pgtype.UUID{Bytes: johnDoe, Valid: true})
+	require.NoError(t, err)
+	assert.True(t, decimal.RequireFromString("4899.75").Equal(decimal.NewFromBigInt(account.Balance.Int, account.Balance.Exp)))
+}
+
+func TestWithTxRollback(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, _ := newTestStore(t)
+	errAbort := errors.New("abort")
+
+	err := store.WithTx(ctx, func(q sqlc.Querier) error {
+		_, err := q.CreateAccountAlias(ctx, sqlc.CreateAccountAliasParams{
+			Alias:     "+14155550198",
+			AliasType: "phone",
+			AccountID: pgtype.UUID{Bytes: johnDoe, Valid: true},
+		})
+		require.NoError(t, err)
+
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	_, err = store.ResolveAccountAlias(ctx, "+14155550198")
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
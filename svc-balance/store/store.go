@@ -1,6 +1,7 @@
 package store
 
 import (
+	"context"
 	"sync"
 
 	"svc-balance/store/sqlc"
@@ -11,6 +12,8 @@ import (
 
 type IStore interface {
 	sqlc.Querier
+
+	WithTx(ctx context.Context, fn func(sqlc.Querier) error) error
 }
 
 type Store struct {
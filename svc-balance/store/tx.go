@@ -39,8 +39,9 @@ func ReadOnlyTxOptions() TxOptions {
 	}
 }
 
-// // WithTxOptions executes a function within a database transaction with custom options
-func (store *Store) WithTxOptions(ctx context.Context, opts TxOptions, fn func(*sqlc.Queries) error) error {
+// WithTxOptions runs fn in a database transaction with custom options. fn receives the queries bound to the
+// transaction, so service helpers taking a sqlc.Querier run on the transaction there and on the pool elsewhere.
+func (store *Store) WithTxOptions(ctx context.Context, opts TxOptions, fn func(sqlc.Querier) error) error {
 	store.mutex.Lock()
 	defer store.mutex.Unlock()
 
@@ -60,8 +61,7 @@ func (store *Store) WithTxOptions(ctx context.Context, opts TxOptions, fn func(*
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
-	q := sqlc.New(tx)
-	err = fn(q)
+	err = fn(store.Queries.WithTx(tx))
 	if err != nil {
 		if rbErr := tx.Rollback(ctx); rbErr != nil {
 			if errors.Is(rbErr, pgx.ErrTxClosed) {
@@ -82,7 +82,7 @@ func (store *Store) WithTxOptions(ctx context.Context, opts TxOptions, fn func(*
 	return tx.Commit(ctx)
 }
 
-// // WithTx executes a function within a database transaction with default options
-func (store *Store) WithTx(ctx context.Context, fn func(*sqlc.Queries) error) error {
+// WithTx executes a function within a database transaction with default options
+func (store *Store) WithTx(ctx context.Context, fn func(sqlc.Querier) error) error {
 	return store.WithTxOptions(ctx, DefaultTxOptions(), fn)
 }
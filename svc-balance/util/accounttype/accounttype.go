@@ -0,0 +1,69 @@
+// Package accounttype holds the behavior that differs between account types. Checking accounts are unrestricted;
+// savings accounts allow a limited number of outgoing transfers per calendar month. No account type can be
+// overdrawn, which core.accounts enforces for every account with its non-negative balance check.
+package accounttype
+
+import (
+	"fmt"
+	"time"
+)
+
+// Type is the type of an account, as stored in core.accounts.account_type
+type Type string
+
+const (
+	Checking Type = "checking"
+	Savings  Type = "savings"
+)
+
+// Parse returns the type named s; an empty s is a checking account
+func Parse(s string) (Type, error) {
+	switch accountType := Type(s); accountType {
+	case "":
+		return Checking, nil
+	case Checking, Savings:
+		return accountType, nil
+	}
+
+	return "", fmt.Errorf("unknown account type %q", s)
+}
+
+// Policy is what an account type allows
+type Policy struct {
+	MaxMonthlyWithdrawals int // Outgoing transfers per calendar month (UTC); 0 for no limit
+}
+
+// PolicyOf returns the policy of an account type; unknown types are treated as checking accounts
+func PolicyOf(accountType Type) Policy {
+	if accountType == Savings {
+		return Policy{MaxMonthlyWithdrawals: 5}
+	}
+
+	return Policy{}
+}
+
+// Limited reports whether the policy limits the number of withdrawals, so callers can skip counting them otherwise
+func (policy Policy) Limited() bool {
+	return policy.MaxMonthlyWithdrawals > 0
+}
+
+// CheckWithdrawal reports whether one more withdrawal is allowed after made withdrawals this month, with a message
+// for the validation result
+func (policy Policy) CheckWithdrawal(made int64) (bool, string) {
+	if !policy.Limited() {
+		return true, "Account type allows unlimited withdrawals"
+	}
+
+	if made >= int64(policy.MaxMonthlyWithdrawals) {
+		return false, fmt.Sprintf("Monthly withdrawal limit reached (Withdrawals: %d, Limit: %d)", made, policy.MaxMonthlyWithdrawals)
+	}
+
+	return true, fmt.Sprintf("Monthly withdrawal limit not reached (Withdrawals: %d, Limit: %d)", made, policy.MaxMonthlyWithdrawals)
+}
+
+// MonthStart returns the start of the calendar month of t in UTC, from which withdrawals are counted
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
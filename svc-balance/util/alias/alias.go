@@ -0,0 +1,82 @@
+// Package alias normalizes the phone numbers and email addresses a transfer can be addressed to instead of an
+// account. Aliases are stored and looked up in their normalized form, so "+1 (415) 555-0101" and "+14155550101",
+// or "Jane@Example.com" and "jane@example.com", resolve to the same account.
+package alias
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// Type is the kind of an alias, as stored in core.account_aliases.alias_type
+type Type string
+
+const (
+	Phone Type = "phone"
+	Email Type = "email"
+)
+
+// maxLength matches core.account_aliases.alias
+const maxLength = 320
+
+// E.164 allows at most 15 digits; shorter numbers than 8 digits are not in use internationally
+const (
+	minPhoneDigits = 8
+	maxPhoneDigits = 15
+)
+
+// phoneSeparators are dropped from phone numbers before validating them
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+// ErrInvalid is returned for values that are neither an international phone number nor an email address
+var ErrInvalid = errors.New("invalid alias")
+
+// Normalize returns the normalized form of value and its type. Values containing "@" are email addresses;
+// anything else must be a phone number in international format, starting with "+" and the country code.
+func Normalize(value string) (string, Type, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", "", fmt.Errorf("%w: alias is required", ErrInvalid)
+	}
+
+	if strings.Contains(value, "@") {
+		email, err := normalizeEmail(value)
+		return email, Email, err
+	}
+
+	phone, err := normalizePhone(value)
+	return phone, Phone, err
+}
+
+// normalizeEmail lower-cases a bare email address
+func normalizeEmail(value string) (string, error) {
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Name != "" || address.Address != value {
+		return "", fmt.Errorf("%w: email must be a plain address such as jane@example.com", ErrInvalid)
+	}
+	if len(address.Address) > maxLength {
+		return "", fmt.Errorf("%w: email must be at most %d characters", ErrInvalid, maxLength)
+	}
+
+	return strings.ToLower(address.Address), nil
+}
+
+// normalizePhone drops the separators of a phone number and checks it is in E.164 format
+func normalizePhone(value string) (string, error) {
+	phone := phoneSeparators.Replace(value)
+
+	digits, ok := strings.CutPrefix(phone, "+")
+	if !ok {
+		return "", fmt.Errorf("%w: phone number must start with + and the country code", ErrInvalid)
+	}
+	if len(digits) < minPhoneDigits || len(digits) > maxPhoneDigits {
+		return "", fmt.Errorf("%w: phone number must have %d to %d digits", ErrInvalid, minPhoneDigits, maxPhoneDigits)
+	}
+	if digits[0] == '0' || strings.Trim(digits, "0123456789") != "" {
+		return "", fmt.Errorf("%w: phone number must be + followed by digits, without a leading 0", ErrInvalid)
+	}
+
+	return phone, nil
+}
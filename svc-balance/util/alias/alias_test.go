@@ -0,0 +1,42 @@
+package alias
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input     string
+		want      string
+		wantType  Type
+		wantError bool
+	}{
+		{input: "+14155550101", want: "+14155550101", wantType: Phone},
+		{input: " +1 (415) 555-0101 ", want: "+14155550101", wantType: Phone},
+		{input: "+62.812.3456.7890", want: "+6281234567890", wantType: Phone},
+		{input: "Jane.Doe@Example.com", want: "jane.doe@example.com", wantType: Email},
+		{input: "", wantError: true},
+		{input: "4155550101", wantError: true},              // No country code
+		{input: "+0415555010", wantError: true},             // Leading 0
+		{input: "+1415555", wantError: true},                // Too short
+		{input: "+1415555010199999", wantError: true},       // Too long
+		{input: "+1415555O101", wantError: true},            // Letter O
+		{input: "Jane <jane@example.com>", wantError: true}, // Not a bare address
+		{input: "jane@", wantError: true},
+	}
+
+	for _, tt := range tests {
+		got, gotType, err := Normalize(tt.input)
+		if tt.wantError {
+			if !errors.Is(err, ErrInvalid) {
+				t.Errorf("Normalize(%q) error = %v; want ErrInvalid", tt.input, err)
+			}
+			continue
+		}
+
+		if err != nil || got != tt.want || gotType != tt.wantType {
+			t.Errorf("Normalize(%q) = %q, %q, %v; want %q, %q", tt.input, got, gotType, err, tt.want, tt.wantType)
+		}
+	}
+}
@@ -3,14 +3,29 @@ package config
 import (
 	"fmt"
 
+	"profile"
+
 	"github.com/spf13/viper"
 )
 
 // Config holds all configuration for the application
 type Config struct {
 	App      App      `mapstructure:"app"`
+	Grpc     Grpc     `mapstructure:"grpc"`
 	DB       DB       `mapstructure:"db"`
 	Temporal Temporal `mapstructure:"temporal"`
+	Alerts   Alerts   `mapstructure:"alerts"`
+	FX       FX       `mapstructure:"fx"`
+	Rounding Rounding `mapstructure:"rounding"`
+	Latency  Latency  `mapstructure:"latency"`
+	Email    Email    `mapstructure:"email"`
+
+	FailureSimulation FailureSimulation `mapstructure:"failure_simulation"`
+	BusinessRules     BusinessRules     `mapstructure:"business_rules"`
+	AmountPrecision   AmountPrecision   `mapstructure:"amount_precision"`
+	Scaling           Scaling           `mapstructure:"scaling"`
+
+	Profile profile.Profile `mapstructure:"-"` // From APP_PROFILE rather than config.json
 }
 
 // LoadConfig reads configuration from file or environment variables.
@@ -32,5 +47,10 @@ func LoadConfig(path string) (config Config, err error) {
 		return config, fmt.Errorf("failed to unmarshal configuration: %s", err)
 	}
 
+	config.Profile, err = profile.Load()
+	if err != nil {
+		return config, err
+	}
+
 	return
 }
@@ -3,9 +3,10 @@ package config
 // App config
 
 type App struct {
-	Name string `mapstructure:"name"`
-	Host string `mapstructure:"host"`
-	Port int    `mapstructure:"port"`
+	Name     string `mapstructure:"name"`
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	GrpcPort int    `mapstructure:"grpc_port"` // Ops gRPC service used by the api-gateway admin API; 0 disables it
 }
 
 // DB config
@@ -20,8 +21,39 @@ type PostgresConfig struct {
 	Pool             PostgresPool `mapstructure:"pool"`
 }
 
+// Migrations controls the embedded schema migrations
+type Migrations struct {
+	Auto bool `mapstructure:"auto"` // Apply pending migrations at startup; otherwise run the migrate command
+}
+
+// Store drivers selectable with db.driver
+const (
+	DriverPostgres = "postgres"
+	DriverMemory   = "memory" // In-memory tables seeded with the demo data, lost on restart; for demos and tests
+	DriverSQLite   = "sqlite" // The Postgres schema in a SQLite file both services can open; for laptop demos and CI
+)
+
+// SQLiteConfig locates the file of the sqlite driver
+type SQLiteConfig struct {
+	Path string `mapstructure:"path"` // Created with the demo data when missing
+}
+
 type DB struct {
-	Postgres PostgresConfig `mapstructure:"postgres"`
+	Driver     string         `mapstructure:"driver"` // postgres (default), memory or sqlite
+	Postgres   PostgresConfig `mapstructure:"postgres"`
+	SQLite     SQLiteConfig   `mapstructure:"sqlite"`
+	Migrations Migrations     `mapstructure:"migrations"`
+}
+
+// InMemory reports whether db.driver selects the in-memory store
+func (db DB) InMemory() bool {
+	return db.Driver == DriverMemory
+}
+
+// Migrated reports whether db.driver selects Postgres, whose schema the embedded migrations keep; the in-memory
+// store has no schema and the SQLite store creates its own
+func (db DB) Migrated() bool {
+	return db.Driver == "" || db.Driver == DriverPostgres
 }
 
 // Temporal config with performance optimization settings
@@ -36,8 +68,117 @@ type TemporalWorkerOptions struct {
 }
 
 type Temporal struct {
-	HostPort      string                `mapstructure:"host_port"`
-	Namespace     string                `mapstructure:"namespace"`
-	TaskQueue     string                `mapstructure:"task_queue"`
-	WorkerOptions TemporalWorkerOptions `mapstructure:"worker_options"`
+	HostPort        string                `mapstructure:"host_port"`
+	Namespace       string                `mapstructure:"namespace"`
+	TaskQueue       string                `mapstructure:"task_queue"`
+	TaskQueueSuffix string                `mapstructure:"task_queue_suffix"` // Worker fleet, e.g. v2 polls balance-task-queue-v2; empty polls task_queue as is
+	WorkerOptions   TemporalWorkerOptions `mapstructure:"worker_options"`
+}
+
+// Alerts config
+
+type Alerts struct {
+	EvaluationIntervalSeconds int `mapstructure:"evaluation_interval_seconds"`
+}
+
+// FX config
+
+type FXPair struct {
+	Pair      string `mapstructure:"pair"` // BASE/QUOTE, e.g. EUR/USD
+	SpreadBps int64  `mapstructure:"spread_bps"`
+}
+
+type FX struct {
+	DefaultSpreadBps int64    `mapstructure:"default_spread_bps"`
+	MarginBps        int64    `mapstructure:"margin_bps"`
+	Pairs            []FXPair `mapstructure:"pairs"`
+
+	RateSnapshotIntervalSeconds int `mapstructure:"rate_snapshot_interval_seconds"` // 0 disables recording rates for as-of conversions
+}
+
+// Rounding config
+
+type RoundingRule struct {
+	Tenant   string `mapstructure:"tenant"`   // Empty for any tenant
+	Currency string `mapstructure:"currency"` // Empty for any currency
+	Mode     string `mapstructure:"mode"`     // half_up, half_even, down or up
+}
+
+type Rounding struct {
+	DefaultMode string         `mapstructure:"default_mode"`
+	Rules       []RoundingRule `mapstructure:"rules"`
+}
+
+// AmountPrecision config
+
+// AmountPrecision is the NUMERIC(precision, scale) every amount must fit before it is stored or compared
+type AmountPrecision struct {
+	Precision int32 `mapstructure:"precision"` // 0 uses NUMERIC(19,4), the type of the amount and balance columns
+	Scale     int32 `mapstructure:"scale"`
+}
+
+// BusinessRules config
+
+type BusinessRules struct {
+	RefreshSeconds int `mapstructure:"refresh_seconds"` // How long rules read from core.business_rules are used before they are read again; 0 reads them on every validation
+}
+
+// Latency config
+
+type Latency struct {
+	Profile string `mapstructure:"profile"` // none, fast, realistic, slow_bank or unresponsive_bank; empty is none
+}
+
+// FailureSimulation config
+
+type FailureSimulation struct {
+	MaxFailurePercent float64 `mapstructure:"max_failure_percent"` // Operations failed per minute as a percentage of all operations; 0 for no cap
+	MinOperations     int     `mapstructure:"min_operations"`      // Operations of the minute before the cap applies
+}
+
+// Email config
+
+type SMTP struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"` // Empty to submit without authentication
+	Password string `mapstructure:"password"`
+}
+
+type Email struct {
+	Sender         string `mapstructure:"sender"` // smtp, or log to only log the emails; empty is log
+	From           string `mapstructure:"from"`
+	TimeoutSeconds int    `mapstructure:"timeout_seconds"`
+	SMTP           SMTP   `mapstructure:"smtp"`
+}
+
+// Scaling config
+
+type Scaling struct {
+	TargetBacklogPerWorker  int `mapstructure:"target_backlog_per_worker"`   // Tasks waiting on the task queue one worker is expected to drain; 0 is 100
+	TargetInFlightPerWorker int `mapstructure:"target_in_flight_per_worker"` // Activities one worker is expected to run at once; 0 is 50, half the default activity slots of a worker
+	MaxBacklogAgeSeconds    int `mapstructure:"max_backlog_age_seconds"`     // Ask for one more worker while the oldest task waits longer; 0 is 5
+	MinWorkers              int `mapstructure:"min_workers"`                 // 0 is 1
+	MaxWorkers              int `mapstructure:"max_workers"`                 // 0 is unbounded
+	WindowSeconds           int `mapstructure:"window_seconds"`              // Period activity latencies are averaged over; 0 is 60, at most 900
+}
+
+// Grpc config
+
+// GrpcServerKeepalive configures the HTTP/2 pings of the gRPC server and how long connections are kept
+type GrpcServerKeepalive struct {
+	TimeSeconds                  int  `mapstructure:"time_seconds"`                     // Ping a client after this long without activity; 0 is 2 hours
+	TimeoutSeconds               int  `mapstructure:"timeout_seconds"`                  // Close the connection when a ping is not answered in time; 0 is 20 seconds
+	MinTimeSeconds               int  `mapstructure:"min_time_seconds"`                 // Clients pinging more often are disconnected (too_many_pings); 0 is 5 minutes
+	PermitWithoutStream          bool `mapstructure:"permit_without_stream"`            // Accept client pings while no call is in flight
+	MaxConnectionIdleSeconds     int  `mapstructure:"max_connection_idle_seconds"`      // Close connections idle this long; 0 keeps them
+	MaxConnectionAgeSeconds      int  `mapstructure:"max_connection_age_seconds"`       // Close connections this old so clients rebalance; 0 keeps them
+	MaxConnectionAgeGraceSeconds int  `mapstructure:"max_connection_age_grace_seconds"` // Time given to calls in flight on an aged connection; 0 is unlimited
+}
+
+type Grpc struct {
+	Keepalive                GrpcServerKeepalive `mapstructure:"keepalive"`
+	MaxRecvMsgBytes          int                 `mapstructure:"max_recv_msg_bytes"`         // 0 is 4 MiB
+	MaxSendMsgBytes          int                 `mapstructure:"max_send_msg_bytes"`         // 0 is unlimited
+	ConnectionTimeoutSeconds int                 `mapstructure:"connection_timeout_seconds"` // Deadline of the connection handshake; 0 is 120 seconds
 }
@@ -25,12 +25,31 @@ type Rule struct {
 	MaxCount    int      // maximum occurrences (0 = unlimited)
 }
 
+// Quota caps the share of operations the rules fail per minute, so that chaos rules cannot take over a busy
+// environment. Slow rules delay operations without failing them and are not limited.
+type Quota struct {
+	MaxFailurePercent float64 // Failed operations per minute as a percentage of all operations; 0 for no cap
+	MinOperations     int     // Operations of the minute before the cap applies, so a quiet demo still sees its failures
+}
+
+// quotaWindow is the period the operations and failures of a Quota are counted over
+const quotaWindow = time.Minute
+
 // Simulator manages failure injection for learning and testing purposes
 type Simulator struct {
-	logger      *logrus.Logger
-	startTime   time.Time
-	occurrences map[string]int // track occurrences per rule
-	mutex       sync.RWMutex
+	logger         *logrus.Logger
+	startTime      time.Time
+	occurrences    map[string]int  // track occurrences per rule
+	disabled       bool            // switched off at runtime, e.g. by flowctl chaos disable
+	disabledReason string          // why the simulator switched itself off; empty when switched off by hand
+	overrides      map[string]bool // rule name -> enabled, set at runtime over the rule's own Enabled
+	mutex          sync.RWMutex
+
+	quota            Quota
+	windowStart      time.Time
+	windowOperations int
+	windowFailures   int
+	suppressed       int // Failures skipped because of the quota since the last reset
 }
 
 // NewSimulator creates a new failure simulator
@@ -39,6 +58,7 @@ func NewSimulator(logger *logrus.Logger) *Simulator {
 		logger:      logger,
 		startTime:   time.Now(),
 		occurrences: make(map[string]int),
+		overrides:   make(map[string]bool),
 	}
 }
 
@@ -47,8 +67,30 @@ func (s *Simulator) SimulateFailure(ctx context.Context, operation string, accou
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	if s.disabled {
+		return nil
+	}
+
+	s.countOperation(time.Now())
+
 	for _, rule := range rules {
 		if s.shouldApplyRule(rule, operation, accountID) {
+			if isFailure(rule) {
+				if s.overQuota() {
+					s.suppressed++
+
+					s.logger.WithFields(logrus.Fields{
+						"rule":      rule.Name,
+						"operation": operation,
+						"failures":  s.windowFailures,
+						"of":        s.windowOperations,
+					}).Debug("Failure skipped, failure quota of the minute used up")
+
+					return nil
+				}
+				s.windowFailures++
+			}
+
 			// Track occurrence
 			s.occurrences[rule.Name]++
 
@@ -67,10 +109,35 @@ func (s *Simulator) SimulateFailure(ctx context.Context, operation string, accou
 	return nil
 }
 
+// countOperation counts one operation in the current quota window, starting a new window once a minute has passed
+func (s *Simulator) countOperation(now time.Time) {
+	if now.Sub(s.windowStart) >= quotaWindow {
+		s.windowStart = now
+		s.windowOperations = 0
+		s.windowFailures = 0
+	}
+
+	s.windowOperations++
+}
+
+// overQuota reports whether failing the current operation would exceed the quota of the window
+func (s *Simulator) overQuota() bool {
+	if s.quota.MaxFailurePercent <= 0 || s.windowOperations < s.quota.MinOperations {
+		return false
+	}
+
+	return float64(s.windowFailures+1)*100 > s.quota.MaxFailurePercent*float64(s.windowOperations)
+}
+
+// isFailure reports whether a rule fails the operation rather than only delaying it
+func isFailure(rule Rule) bool {
+	return rule.Type != "slow"
+}
+
 // shouldApplyRule determines if a failure rule should be applied
 func (s *Simulator) shouldApplyRule(rule Rule, operation string, accountID string) bool {
 	// Check if rule is enabled
-	if !rule.Enabled {
+	if !s.ruleEnabled(rule) {
 		return false
 	}
 
@@ -183,8 +250,17 @@ func (s *Simulator) GetStats() map[string]any {
 	defer s.mutex.RUnlock()
 
 	stats := map[string]any{
-		"uptime_ms":   time.Since(s.startTime).Milliseconds(),
-		"occurrences": make(map[string]int),
+		"uptime_ms":           time.Since(s.startTime).Milliseconds(),
+		"occurrences":         make(map[string]int),
+		"enabled":             !s.disabled,
+		"max_failure_percent": s.quota.MaxFailurePercent,
+		"min_operations":      s.quota.MinOperations,
+		"window_operations":   s.windowOperations,
+		"window_failures":     s.windowFailures,
+		"suppressed_failures": s.suppressed,
+	}
+	if s.disabledReason != "" {
+		stats["disabled_reason"] = s.disabledReason
 	}
 
 	// Copy occurrences to avoid race conditions
@@ -202,6 +278,84 @@ func (s *Simulator) Reset() {
 
 	s.startTime = time.Now()
 	s.occurrences = make(map[string]int)
+	s.overrides = make(map[string]bool)
+	s.windowStart = time.Time{}
+	s.windowOperations = 0
+	s.windowFailures = 0
+	s.suppressed = 0
 
 	s.logger.Info("🔄 Failure simulator state reset for new learning session")
 }
+
+// SetEnabled switches failure injection on or off without touching the rules or the counters
+func (s *Simulator) SetEnabled(enabled bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.disabled = !enabled
+	s.disabledReason = ""
+
+	s.logger.WithField("enabled", enabled).Info("🎛️ Failure simulator switched")
+}
+
+// Enabled reports whether failure injection is switched on
+func (s *Simulator) Enabled() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return !s.disabled
+}
+
+// Trip switches failure injection off because the environment needs to recover; the reason is reported in the
+// stats until failure injection is switched on again
+func (s *Simulator) Trip(reason string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.disabled {
+		return
+	}
+
+	s.disabled = true
+	s.disabledReason = reason
+
+	s.logger.WithField("reason", reason).Warn("🛑 Failure simulator switched off automatically")
+}
+
+// SetQuota sets the cap on the share of operations failed per minute
+func (s *Simulator) SetQuota(quota Quota) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.quota = quota
+}
+
+// SetRuleEnabled switches a single rule on or off, overriding its Enabled field until the next Reset
+func (s *Simulator) SetRuleEnabled(name string, enabled bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.overrides[name] = enabled
+
+	s.logger.WithFields(logrus.Fields{
+		"rule":    name,
+		"enabled": enabled,
+	}).Info("🎛️ Failure rule switched")
+}
+
+// RuleEnabled reports whether a rule is enabled, taking runtime overrides into account
+func (s *Simulator) RuleEnabled(rule Rule) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.ruleEnabled(rule)
+}
+
+// ruleEnabled is RuleEnabled for callers already holding the mutex
+func (s *Simulator) ruleEnabled(rule Rule) bool {
+	if enabled, ok := s.overrides[rule.Name]; ok {
+		return enabled
+	}
+
+	return rule.Enabled
+}
@@ -229,3 +229,150 @@ func TestSimulator_Reset(t *testing.T) {
 	occurrences = stats["occurrences"].(map[string]int)
 	assert.Equal(t, 0, occurrences["test_rule"])
 }
+
+func TestSimulator_SetEnabled(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	rules := []Rule{
+		{
+			Name:        "test_rule",
+			Enabled:     true,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"CheckBalance"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+
+	// Disabled simulator injects nothing and counts nothing
+	simulator.SetEnabled(false)
+	assert.NoError(t, simulator.SimulateFailure(ctx, "CheckBalance", "123456789012", rules))
+	assert.Equal(t, false, simulator.GetStats()["enabled"])
+	assert.Equal(t, 0, simulator.GetStats()["occurrences"].(map[string]int)["test_rule"])
+
+	// Enabling it again resumes injection
+	simulator.SetEnabled(true)
+	assert.Error(t, simulator.SimulateFailure(ctx, "CheckBalance", "123456789012", rules))
+	assert.Equal(t, true, simulator.GetStats()["enabled"])
+}
+
+func TestSimulator_SetRuleEnabled(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	rules := []Rule{
+		{
+			Name:        "off_by_default",
+			Enabled:     false,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"CheckBalance"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+
+	assert.NoError(t, simulator.SimulateFailure(ctx, "CheckBalance", "123456789012", rules))
+
+	// An override turns the rule on without changing the rule itself
+	simulator.SetRuleEnabled("off_by_default", true)
+	assert.True(t, simulator.RuleEnabled(rules[0]))
+	assert.Error(t, simulator.SimulateFailure(ctx, "CheckBalance", "123456789012", rules))
+
+	// Reset restores the rule's own Enabled field
+	simulator.Reset()
+	assert.False(t, simulator.RuleEnabled(rules[0]))
+	assert.NoError(t, simulator.SimulateFailure(ctx, "CheckBalance", "123456789012", rules))
+}
+
+func TestSimulator_Quota(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+	simulator.SetQuota(Quota{MaxFailurePercent: 25, MinOperations: 4})
+
+	rules := []Rule{
+		{
+			Name:        "always_fail",
+			Enabled:     true,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"Fail"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+
+	// Below MinOperations every failure fires
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+
+	simulator.Reset()
+	simulator.SetQuota(Quota{MaxFailurePercent: 25, MinOperations: 4})
+
+	for range 3 {
+		assert.NoError(t, simulator.SimulateFailure(ctx, "Other", "123456789012", rules))
+	}
+
+	// 1 failure in 4 operations is within 25%, a second one in 5 is not
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+	assert.NoError(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+
+	// 2 in 9 is within 25% again
+	for range 3 {
+		assert.NoError(t, simulator.SimulateFailure(ctx, "Other", "123456789012", rules))
+	}
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+
+	stats := simulator.GetStats()
+	assert.Equal(t, 9, stats["window_operations"])
+	assert.Equal(t, 2, stats["window_failures"])
+	assert.Equal(t, 1, stats["suppressed_failures"])
+	assert.Equal(t, 2, stats["occurrences"].(map[string]int)["always_fail"])
+
+	// A new minute starts a new window
+	simulator.countOperation(simulator.windowStart.Add(quotaWindow))
+	assert.Equal(t, 1, simulator.windowOperations)
+	assert.Equal(t, 0, simulator.windowFailures)
+}
+
+func TestSimulator_Trip(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	rules := []Rule{
+		{
+			Name:        "always_fail",
+			Enabled:     true,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"*"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+
+	simulator.Trip("backlog too large")
+	assert.False(t, simulator.Enabled())
+	assert.NoError(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+	assert.Equal(t, "backlog too large", simulator.GetStats()["disabled_reason"])
+
+	// Switching it on again clears the reason
+	simulator.SetEnabled(true)
+	assert.True(t, simulator.Enabled())
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+	assert.NotContains(t, simulator.GetStats(), "disabled_reason")
+}
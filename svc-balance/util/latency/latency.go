@@ -0,0 +1,179 @@
+// Package latency injects simulated processing time into activities according to a named profile,
+// so learners can watch activity timeouts and heartbeats under conditions closer to a real bank.
+// Unlike the failure rules, a profile applies to every activity and never fails on its own.
+package latency
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Profile is a named set of simulated latencies
+type Profile struct {
+	Name        string
+	Description string
+	Base        time.Duration // Added to every activity
+	Jitter      time.Duration // Up to this much more, picked at random per activity attempt
+	// HeartbeatEvery is how often the activity heartbeats while it waits; zero sends no heartbeats,
+	// so a wait longer than the activity's heartbeat timeout makes Temporal time the attempt out
+	HeartbeatEvery time.Duration
+}
+
+// ProfileNone injects nothing; it is the default
+const ProfileNone = "none"
+
+// Profiles are the available latency profiles by name
+var Profiles = map[string]Profile{
+	ProfileNone: {
+		Name:        ProfileNone,
+		Description: "No simulated latency",
+	},
+	"fast": {
+		Name:           "fast",
+		Description:    "An in-memory ledger: a few tens of milliseconds per activity",
+		Base:           20 * time.Millisecond,
+		Jitter:         30 * time.Millisecond,
+		HeartbeatEvery: time.Second,
+	},
+	"realistic": {
+		Name:           "realistic",
+		Description:    "A core banking system under normal load: a few hundred milliseconds to a second",
+		Base:           300 * time.Millisecond,
+		Jitter:         700 * time.Millisecond,
+		HeartbeatEvery: time.Second,
+	},
+	"slow_bank": {
+		Name:           "slow_bank",
+		Description:    "An overloaded bank: 5 to 10 seconds per activity, heartbeating so attempts stay alive",
+		Base:           5 * time.Second,
+		Jitter:         5 * time.Second,
+		HeartbeatEvery: 2 * time.Second,
+	},
+	"unresponsive_bank": {
+		Name:        "unresponsive_bank",
+		Description: "A bank that hangs for 40 seconds without heartbeats, past the 30 second heartbeat timeout",
+		Base:        40 * time.Second,
+	},
+}
+
+// Names returns the profile names in alphabetical order
+func Names() []string {
+	names := make([]string, 0, len(Profiles))
+	for name := range Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Lookup returns the profile with the given name; an empty name is the none profile
+func Lookup(name string) (Profile, error) {
+	if name == "" {
+		name = ProfileNone
+	}
+
+	profile, ok := Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown latency profile %q, expected one of %v", name, Names())
+	}
+
+	return profile, nil
+}
+
+// Injector holds the active profile of a service
+type Injector struct {
+	logger  *logrus.Logger
+	profile Profile
+	random  *rand.Rand
+	mutex   sync.Mutex
+}
+
+// NewInjector creates an injector with the none profile
+func NewInjector(logger *logrus.Logger) *Injector {
+	return &Injector{
+		logger:  logger,
+		profile: Profiles[ProfileNone],
+		random:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Profile returns the active profile
+func (injector *Injector) Profile() Profile {
+	injector.mutex.Lock()
+	defer injector.mutex.Unlock()
+
+	return injector.profile
+}
+
+// SetProfile switches the active profile; activities already waiting keep their delay
+func (injector *Injector) SetProfile(name string) error {
+	profile, err := Lookup(name)
+	if err != nil {
+		return err
+	}
+
+	injector.mutex.Lock()
+	injector.profile = profile
+	injector.mutex.Unlock()
+
+	injector.logger.WithField("profile", profile.Name).Info("🐢 Latency profile switched")
+
+	return nil
+}
+
+// Wait sleeps for the active profile's latency, calling heartbeat at the profile's interval while it waits.
+// It returns early with the context error when ctx is done.
+func (injector *Injector) Wait(ctx context.Context, operation string, heartbeat func()) error {
+	profile, delay := injector.next()
+	if delay <= 0 {
+		return nil
+	}
+
+	injector.logger.WithFields(logrus.Fields{
+		"operation": operation,
+		"profile":   profile.Name,
+		"delay":     delay,
+	}).Debug("🐢 Injecting simulated latency")
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var ticks <-chan time.Time
+	if profile.HeartbeatEvery > 0 && heartbeat != nil {
+		ticker := time.NewTicker(profile.HeartbeatEvery)
+		defer ticker.Stop()
+
+		ticks = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		case <-ticks:
+			heartbeat()
+		}
+	}
+}
+
+// next returns the active profile and a delay drawn from it
+func (injector *Injector) next() (Profile, time.Duration) {
+	injector.mutex.Lock()
+	defer injector.mutex.Unlock()
+
+	delay := injector.profile.Base
+	if injector.profile.Jitter > 0 {
+		delay += time.Duration(injector.random.Int63n(int64(injector.profile.Jitter)))
+	}
+
+	return injector.profile, delay
+}
@@ -0,0 +1,51 @@
+package latency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjector_SetProfile(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	injector := NewInjector(logger)
+	assert.Equal(t, ProfileNone, injector.Profile().Name)
+
+	require.NoError(t, injector.SetProfile("realistic"))
+	assert.Equal(t, "realistic", injector.Profile().Name)
+
+	// An unknown profile keeps the active one
+	assert.Error(t, injector.SetProfile("warp_speed"))
+	assert.Equal(t, "realistic", injector.Profile().Name)
+}
+
+func TestInjector_Wait(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	injector := NewInjector(logger)
+
+	// The none profile returns at once
+	assert.NoError(t, injector.Wait(context.Background(), "CheckBalance", nil))
+
+	Profiles["test_heartbeat"] = Profile{Name: "test_heartbeat", Base: 50 * time.Millisecond, HeartbeatEvery: 10 * time.Millisecond}
+	defer delete(Profiles, "test_heartbeat")
+	require.NoError(t, injector.SetProfile("test_heartbeat"))
+
+	heartbeats := 0
+	started := time.Now()
+	assert.NoError(t, injector.Wait(context.Background(), "CheckBalance", func() { heartbeats++ }))
+	assert.GreaterOrEqual(t, time.Since(started), 50*time.Millisecond)
+	assert.Greater(t, heartbeats, 0)
+
+	// A cancelled context stops the wait
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, injector.Wait(ctx, "CheckBalance", nil), context.Canceled)
+}
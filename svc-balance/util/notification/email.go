@@ -0,0 +1,178 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Email is a plain-text message to a single recipient
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// EmailSender delivers emails
+type EmailSender interface {
+	Send(ctx context.Context, email Email) error
+}
+
+// SMTPOptions configures the relay an SMTPSender submits mail to
+type SMTPOptions struct {
+	Host     string
+	Port     int
+	Username string // Empty to submit without authentication
+	Password string
+	From     string // Address, optionally with a display name: "Bank <notifications@example.com>"
+}
+
+// SMTPSender submits emails to an SMTP relay, using STARTTLS when the relay offers it
+type SMTPSender struct {
+	logger   *logrus.Logger
+	options  SMTPOptions
+	envelope string // Bare address of From, used as the SMTP sender
+	timeout  time.Duration
+}
+
+// NewSMTPSender creates a sender that submits to the relay described by options
+func NewSMTPSender(logger *logrus.Logger, options SMTPOptions, timeout time.Duration) (*SMTPSender, error) {
+	if options.Host == "" || options.Port == 0 {
+		return nil, fmt.Errorf("smtp host and port are required")
+	}
+
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	from, err := mail.ParseAddress(options.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid smtp from address %q: %w", options.From, err)
+	}
+
+	return &SMTPSender{
+		logger:   logger,
+		options:  options,
+		envelope: from.Address,
+		timeout:  timeout,
+	}, nil
+}
+
+// Send submits the email; the context deadline, or the sender timeout when earlier, bounds the whole exchange
+func (s *SMTPSender) Send(ctx context.Context, email Email) error {
+	const op = "notification.SMTPSender.Send"
+
+	address := net.JoinHostPort(s.options.Host, strconv.Itoa(s.options.Port))
+
+	deadline := time.Now().Add(s.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	conn, err := (&net.Dialer{Deadline: deadline}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to smtp relay: %w", err)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to set smtp deadline: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, s.options.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start smtp session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(nil); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	if s.options.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.options.Username, s.options.Password, s.options.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate to smtp relay: %w", err)
+		}
+	}
+
+	if err := client.Mail(s.envelope); err != nil {
+		return fmt.Errorf("smtp relay rejected sender: %w", err)
+	}
+	if err := client.Rcpt(email.To); err != nil {
+		return fmt.Errorf("smtp relay rejected recipient: %w", err)
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to start smtp data: %w", err)
+	}
+	if _, err := writer.Write(formatMessage(s.options.From, email, time.Now())); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write smtp data: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("smtp relay rejected message: %w", err)
+	}
+
+	if err := client.Quit(); err != nil {
+		s.logger.WithError(err).WithField("[op]", op).Debug("SMTP QUIT failed after the message was accepted")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"to":      email.To,
+		"subject": email.Subject,
+	}).Info("📧 Email sent")
+
+	return nil
+}
+
+// formatMessage renders the email as an RFC 5322 message with a UTF-8 plain-text body
+func formatMessage(from string, email Email, date time.Time) []byte {
+	var message bytes.Buffer
+
+	fmt.Fprintf(&message, "From: %s\r\n", from)
+	fmt.Fprintf(&message, "To: %s\r\n", email.To)
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	message.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(strings.ReplaceAll(strings.ReplaceAll(email.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return message.Bytes()
+}
+
+// LogSender is a stub sender that only logs the emails it is asked to send, for environments without a relay
+type LogSender struct {
+	logger *logrus.Logger
+}
+
+// NewLogSender creates a stub sender
+func NewLogSender(logger *logrus.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+// Send logs the email instead of delivering it
+func (s *LogSender) Send(ctx context.Context, email Email) error {
+	s.logger.WithFields(logrus.Fields{
+		"[op]":    "notification.LogSender.Send",
+		"to":      email.To,
+		"subject": email.Subject,
+		"body":    email.Body,
+	}).Info("📧 Email not sent (stub sender)")
+
+	return nil
+}
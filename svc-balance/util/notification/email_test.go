@@ -0,0 +1,46 @@
+package notification
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailTemplateRender(t *testing.T) {
+	t.Parallel()
+
+	emailTemplate := MustEmailTemplate("test", "Hello {{.Name}}", "Dear {{.Name}},\nbye\n")
+
+	email, err := emailTemplate.Render("jane@example.com", map[string]string{"Name": "Jane\r\nBcc: x@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", email.To)
+	assert.Equal(t, "Hello Jane Bcc: x@example.com", email.Subject)
+	assert.Equal(t, "Dear Jane\r\nBcc: x@example.com,\nbye\n", email.Body)
+
+	_, err = emailTemplate.Render("jane@example.com", map[string]string{})
+	assert.Error(t, err)
+
+	_, err = NewEmailTemplate("broken", "{{.Name", "")
+	assert.Error(t, err)
+}
+
+func TestFormatMessage(t *testing.T) {
+	t.Parallel()
+
+	message := string(formatMessage("Bank <notifications@example.com>", Email{
+		To:      "jane@example.com",
+		Subject: "Transfer of 10,00 € complete",
+		Body:    "line 1\nline 2\n",
+	}, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)))
+
+	headers, body, ok := strings.Cut(message, "\r\n\r\n")
+	require.True(t, ok)
+	assert.Contains(t, headers, "From: Bank <notifications@example.com>\r\n")
+	assert.Contains(t, headers, "To: jane@example.com\r\n")
+	assert.Contains(t, headers, "Subject: =?utf-8?q?Transfer_of_10,00_=E2=82=AC_complete?=\r\n")
+	assert.Contains(t, headers, "Date: Sun, 01 Mar 2026 12:00:00 +0000\r\n")
+	assert.Equal(t, "line 1\r\nline 2\r\n", body)
+}
@@ -0,0 +1,58 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// EmailTemplate renders the subject and plain-text body of one kind of email
+type EmailTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// NewEmailTemplate parses the subject and body templates; both use text/template syntax and fail on missing keys
+func NewEmailTemplate(name, subject, body string) (*EmailTemplate, error) {
+	subjectTemplate, err := template.New(name + ".subject").Option("missingkey=error").Parse(subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s subject template: %w", name, err)
+	}
+
+	bodyTemplate, err := template.New(name + ".body").Option("missingkey=error").Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s body template: %w", name, err)
+	}
+
+	return &EmailTemplate{subject: subjectTemplate, body: bodyTemplate}, nil
+}
+
+// MustEmailTemplate is like NewEmailTemplate but panics on invalid templates; meant for package-level templates
+func MustEmailTemplate(name, subject, body string) *EmailTemplate {
+	emailTemplate, err := NewEmailTemplate(name, subject, body)
+	if err != nil {
+		panic(err)
+	}
+
+	return emailTemplate
+}
+
+// Render executes the templates with data and addresses the result to to. Line breaks are removed from the subject
+// so data cannot inject headers.
+func (t *EmailTemplate) Render(to string, data any) (Email, error) {
+	var subject, body strings.Builder
+
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return Email{}, fmt.Errorf("failed to render subject: %w", err)
+	}
+
+	if err := t.body.Execute(&body, data); err != nil {
+		return Email{}, fmt.Errorf("failed to render body: %w", err)
+	}
+
+	return Email{
+		To:      to,
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Body:    body.String(),
+	}, nil
+}
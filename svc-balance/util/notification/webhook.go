@@ -0,0 +1,74 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Event represents a notification payload delivered to subscribers
+type Event struct {
+	Type       string         `json:"type"`
+	OccurredAt time.Time      `json:"occurred_at"`
+	Data       map[string]any `json:"data"`
+}
+
+// Notifier delivers events to an external destination
+type Notifier interface {
+	Notify(ctx context.Context, destination string, event Event) error
+}
+
+// WebhookNotifier delivers events as JSON POST requests
+type WebhookNotifier struct {
+	logger *logrus.Logger
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a new webhook notifier with the given request timeout
+func NewWebhookNotifier(logger *logrus.Logger, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		logger: logger,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Notify posts the event to the destination URL and treats any non-2xx response as a failure
+func (n *WebhookNotifier) Notify(ctx context.Context, destination string, event Event) error {
+	const op = "notification.WebhookNotifier.Notify"
+
+	logger := n.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"destination": destination,
+		"event_type":  event.Type,
+	})
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, destination, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	logger.WithField("status_code", resp.StatusCode).Info("📬 Webhook delivered")
+
+	return nil
+}
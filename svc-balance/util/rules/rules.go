@@ -0,0 +1,259 @@
+// Package rules evaluates the soft business validation rules of svc-balance and svc-transaction, e.g. the largest
+// amount of a single transaction. Rules are stored in core.business_rules so operators can change thresholds per
+// currency without a deploy; Defaults holds the rules that applied before they were configurable.
+package rules
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Kind is what a rule checks; the threshold of the rule is read in the unit of its kind
+type Kind string
+
+const (
+	KindMaxTransactionAmount Kind = "max_transaction_amount"  // The transaction amount is at most the threshold
+	KindMinAccountNameLength Kind = "min_account_name_length" // The account name has at least threshold characters
+	KindMinBalance           Kind = "min_balance"             // The balance, after the transaction for debits, is at least the threshold
+)
+
+// Severity is how a failed rule is reported: errors reject the operation, warnings only flag it for review
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// ParseKind returns the kind named s
+func ParseKind(s string) (Kind, error) {
+	switch kind := Kind(s); kind {
+	case KindMaxTransactionAmount, KindMinAccountNameLength, KindMinBalance:
+		return kind, nil
+	}
+
+	return "", fmt.Errorf("unknown rule kind %q", s)
+}
+
+// ParseSeverity returns the severity named s
+func ParseSeverity(s string) (Severity, error) {
+	switch severity := Severity(s); severity {
+	case SeverityError, SeverityWarning:
+		return severity, nil
+	}
+
+	return "", fmt.Errorf("unknown rule severity %q", s)
+}
+
+// Rule is one configured check. Rules sharing a name are the same check with per-currency thresholds: an empty
+// currency matches any, and a rule of the currency of the operation replaces it.
+type Rule struct {
+	Name      string
+	Kind      Kind
+	Currency  string
+	Threshold decimal.Decimal
+	Severity  Severity
+	Enabled   bool // A disabled currency rule turns the check off for that currency only
+}
+
+// Defaults are the rules that were hardcoded before they moved to core.business_rules. They apply until the rules
+// are first loaded, so an unreachable database does not turn every check off.
+func Defaults() []Rule {
+	return []Rule{
+		{Name: "name_format", Kind: KindMinAccountNameLength, Threshold: decimal.NewFromInt(2), Severity: SeverityWarning, Enabled: true},
+		{Name: "transaction_limits", Kind: KindMaxTransactionAmount, Threshold: decimal.NewFromInt(100000), Severity: SeverityWarning, Enabled: true},
+	}
+}
+
+// Relaxed returns rules with severity error downgraded to warning, so failing them flags an operation for review
+// instead of rejecting it
+func Relaxed(rules []Rule) []Rule {
+	relaxed := make([]Rule, len(rules))
+	for i, rule := range rules {
+		if rule.Severity == SeverityError {
+			rule.Severity = SeverityWarning
+		}
+		relaxed[i] = rule
+	}
+
+	return relaxed
+}
+
+// Facts are what the rules are evaluated against. Rules whose facts are not set are skipped, e.g. transaction
+// limits when no amount is given.
+type Facts struct {
+	Currency    string
+	AccountName *string
+	Amount      *decimal.Decimal
+	Balance     *decimal.Decimal // The balance the operation leaves behind
+}
+
+// Outcome is the result of one rule
+type Outcome struct {
+	Rule    Rule
+	Passed  bool
+	Message string
+}
+
+// Engine evaluates a fixed set of rules
+type Engine struct {
+	rules []Rule
+}
+
+func NewEngine(rules []Rule) *Engine {
+	return &Engine{rules: rules}
+}
+
+// Evaluate applies, for each rule name, the rule of the currency of facts or else the rule of any currency, in the
+// order the names first appear
+func (engine *Engine) Evaluate(facts Facts) []Outcome {
+	var names []string
+	selected := map[string]Rule{}
+	for _, rule := range engine.rules {
+		current, seen := selected[rule.Name]
+		if !seen {
+			names = append(names, rule.Name)
+		}
+
+		switch {
+		case rule.Currency != "" && strings.EqualFold(rule.Currency, facts.Currency):
+			selected[rule.Name] = rule
+		case rule.Currency == "" && (!seen || current.Currency == ""):
+			selected[rule.Name] = rule
+		case !seen:
+			// A rule of another currency never applies, but keeps the position of its name
+			selected[rule.Name] = Rule{Name: rule.Name}
+		}
+	}
+
+	var outcomes []Outcome
+	for _, name := range names {
+		rule := selected[name]
+		if !rule.Enabled {
+			continue
+		}
+
+		if outcome, ok := evaluate(rule, facts); ok {
+			outcomes = append(outcomes, outcome)
+		}
+	}
+
+	return outcomes
+}
+
+func evaluate(rule Rule, facts Facts) (Outcome, bool) {
+	outcome := Outcome{Rule: rule}
+
+	switch rule.Kind {
+	case KindMaxTransactionAmount:
+		if facts.Amount == nil {
+			return Outcome{}, false
+		}
+
+		outcome.Passed = facts.Amount.LessThanOrEqual(rule.Threshold)
+		if outcome.Passed {
+			outcome.Message = fmt.Sprintf("Transaction amount within limits (%s)", facts.Amount.String())
+		} else {
+			outcome.Message = fmt.Sprintf("Transaction amount exceeds limit (Amount: %s, Limit: %s)", facts.Amount.String(), rule.Threshold.String())
+		}
+
+	case KindMinAccountNameLength:
+		if facts.AccountName == nil {
+			return Outcome{}, false
+		}
+
+		outcome.Passed = decimal.NewFromInt(int64(len(strings.TrimSpace(*facts.AccountName)))).GreaterThanOrEqual(rule.Threshold)
+		if outcome.Passed {
+			outcome.Message = "Account name format is valid"
+		} else {
+			outcome.Message = fmt.Sprintf("Account name is shorter than %s characters", rule.Threshold.String())
+		}
+
+	case KindMinBalance:
+		if facts.Balance == nil {
+			return Outcome{}, false
+		}
+
+		outcome.Passed = facts.Balance.GreaterThanOrEqual(rule.Threshold)
+		if outcome.Passed {
+			outcome.Message = fmt.Sprintf("Balance meets the minimum (Balance: %s, Minimum: %s)", facts.Balance.String(), rule.Threshold.String())
+		} else {
+			outcome.Message = fmt.Sprintf("Balance below the minimum (Balance: %s, Minimum: %s)", facts.Balance.String(), rule.Threshold.String())
+		}
+
+	default:
+		return Outcome{}, false
+	}
+
+	return outcome, true
+}
+
+// Failed returns the first failed outcome of severity error
+func Failed(outcomes []Outcome) (Outcome, bool) {
+	for _, outcome := range outcomes {
+		if !outcome.Passed && outcome.Rule.Severity == SeverityError {
+			return outcome, true
+		}
+	}
+
+	return Outcome{}, false
+}
+
+// Loader reads the current rules, e.g. from core.business_rules
+type Loader func(ctx context.Context) ([]Rule, error)
+
+// Cache keeps the rules of a Loader for a refresh interval, so validations do not read the rules table every time
+type Cache struct {
+	load    Loader
+	refresh time.Duration
+	now     func() time.Time
+
+	mutex    sync.Mutex
+	engine   *Engine
+	loadedAt time.Time
+}
+
+// NewCache returns a cache starting with Defaults; a refresh of 0 reloads the rules on every call
+func NewCache(load Loader, refresh time.Duration) *Cache {
+	return &Cache{
+		load:    load,
+		refresh: refresh,
+		now:     time.Now,
+
+		engine: NewEngine(Defaults()),
+	}
+}
+
+// Engine returns an engine of the current rules. When the rules cannot be reloaded it returns the rules loaded last,
+// or Defaults, together with the error.
+func (cache *Cache) Engine(ctx context.Context) (*Engine, error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if !cache.loadedAt.IsZero() && cache.now().Sub(cache.loadedAt) < cache.refresh {
+		return cache.engine, nil
+	}
+
+	loaded, err := cache.load(ctx)
+	if err != nil {
+		return cache.engine, fmt.Errorf("failed to load business rules: %w", err)
+	}
+
+	cache.engine = NewEngine(loaded)
+	cache.loadedAt = cache.now()
+
+	return cache.engine, nil
+}
+
+// Invalidate makes the next Engine call reload the rules, e.g. after they were changed
+func (cache *Cache) Invalidate() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.loadedAt = time.Time{}
+}
@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestEvaluatePicksTheRuleOfTheCurrency(t *testing.T) {
+	engine := NewEngine([]Rule{
+		{Name: "transaction_limits", Kind: KindMaxTransactionAmount, Threshold: decimal.NewFromInt(100000), Severity: SeverityWarning, Enabled: true},
+		{Name: "transaction_limits", Kind: KindMaxTransactionAmount, Currency: "JPY", Threshold: decimal.NewFromInt(15000000), Severity: SeverityError, Enabled: true},
+		{Name: "gbp_minimum", Kind: KindMinBalance, Currency: "GBP", Threshold: decimal.NewFromInt(10), Severity: SeverityError, Enabled: true},
+		{Name: "transaction_limits", Kind: KindMaxTransactionAmount, Currency: "EUR", Severity: SeverityError, Enabled: false},
+	})
+
+	amount := decimal.NewFromInt(200000)
+	balance := decimal.NewFromInt(5)
+
+	tests := []struct {
+		currency string
+		want     []Outcome
+	}{
+		{currency: "USD", want: []Outcome{{Rule: engine.rules[0], Passed: false}}},
+		{currency: "jpy", want: []Outcome{{Rule: engine.rules[1], Passed: true}}},
+		{currency: "GBP", want: []Outcome{{Rule: engine.rules[0], Passed: false}, {Rule: engine.rules[2], Passed: false}}},
+		{currency: "EUR", want: nil}, // The disabled EUR rule turns the limit off for EUR only
+	}
+
+	for _, tt := range tests {
+		outcomes := engine.Evaluate(Facts{Currency: tt.currency, Amount: &amount, Balance: &balance})
+		if len(outcomes) != len(tt.want) {
+			t.Fatalf("Evaluate(%s) = %+v, want %d outcomes", tt.currency, outcomes, len(tt.want))
+		}
+		for i, outcome := range outcomes {
+			if outcome.Rule != tt.want[i].Rule || outcome.Passed != tt.want[i].Passed {
+				t.Errorf("Evaluate(%s)[%d] = %+v, want %+v", tt.currency, i, outcome, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestEvaluateSkipsRulesWithoutFacts(t *testing.T) {
+	name := "A"
+
+	outcomes := NewEngine(Defaults()).Evaluate(Facts{Currency: "USD", AccountName: &name})
+	if len(outcomes) != 1 || outcomes[0].Rule.Kind != KindMinAccountNameLength || outcomes[0].Passed {
+		t.Fatalf("Evaluate() = %+v, want only the failed name rule", outcomes)
+	}
+
+	if _, failed := Failed(outcomes); failed {
+		t.Error("Failed() reported a warning as an error")
+	}
+}
+
+func TestRelaxed(t *testing.T) {
+	amount := decimal.NewFromInt(500)
+	limit := []Rule{{Name: "limit", Kind: KindMaxTransactionAmount, Threshold: decimal.NewFromInt(100), Severity: SeverityError, Enabled: true}}
+
+	if _, failed := Failed(NewEngine(limit).Evaluate(Facts{Currency: "USD", Amount: &amount})); !failed {
+		t.Fatal("Failed() = false for an amount over an error limit")
+	}
+
+	outcomes := NewEngine(Relaxed(limit)).Evaluate(Facts{Currency: "USD", Amount: &amount})
+	if len(outcomes) != 1 || outcomes[0].Passed || outcomes[0].Rule.Severity != SeverityWarning {
+		t.Fatalf("Evaluate() = %+v, want the limit failed as a warning", outcomes)
+	}
+	if _, failed := Failed(outcomes); failed {
+		t.Error("Failed() rejected an operation under relaxed rules")
+	}
+	if limit[0].Severity != SeverityError {
+		t.Error("Relaxed() changed the rules it was given")
+	}
+}
+
+func TestCache(t *testing.T) {
+	loads := 0
+	var loadErr error
+	cache := NewCache(func(context.Context) ([]Rule, error) {
+		loads++
+		return nil, loadErr
+	}, time.Minute)
+
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	loadErr = errors.New("database unavailable")
+	engine, err := cache.Engine(context.Background())
+	if err == nil || len(engine.rules) != len(Defaults()) {
+		t.Fatalf("Engine() = %+v, %v; want the defaults and the load error", engine, err)
+	}
+
+	loadErr = nil
+	if engine, err := cache.Engine(context.Background()); err != nil || len(engine.rules) != 0 {
+		t.Fatalf("Engine() = %+v, %v; want the loaded rules", engine, err)
+	}
+
+	now = now.Add(30 * time.Second)
+	cache.Engine(context.Background())
+	if loads != 2 {
+		t.Errorf("loads = %d within the refresh interval, want 2", loads)
+	}
+
+	cache.Invalidate()
+	cache.Engine(context.Background())
+	if loads != 3 {
+		t.Errorf("loads = %d after Invalidate, want 3", loads)
+	}
+}
@@ -0,0 +1,196 @@
+package workerinterceptor
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Outcomes of an activity execution
+const (
+	OutcomeCompleted = "completed"
+	OutcomeFailed    = "failed"
+	OutcomePanicked  = "panicked"
+)
+
+// Outcomes lists every outcome, in the order metrics are rendered
+var Outcomes = []string{OutcomeCompleted, OutcomeFailed, OutcomePanicked}
+
+const (
+	// maxRecentWindow is the longest window Recent can report; older samples are dropped
+	maxRecentWindow = 15 * time.Minute
+
+	// maxRecentSamples bounds the samples kept per activity type and latency
+	maxRecentSamples = 1000
+)
+
+// ActivityStats counts the executions of one activity type since the process started
+type ActivityStats struct {
+	ActivityType string
+	Executions   map[string]int64 // By outcome
+	Duration     time.Duration    // Total over all executions
+
+	ScheduleToStart      time.Duration // Total time first attempts waited on the task queue
+	ScheduleToStartCount int64         // First attempts ScheduleToStart covers
+}
+
+// Count returns the number of executions of every outcome
+func (stats ActivityStats) Count() int64 {
+	var count int64
+	for _, executions := range stats.Executions {
+		count += executions
+	}
+
+	return count
+}
+
+// ActivityLatency is the latencies of one activity type over a recent window
+type ActivityLatency struct {
+	ActivityType string `json:"activity_type"`
+
+	Executions         int     `json:"executions"`
+	AvgDurationSeconds float64 `json:"avg_duration_seconds"`
+	MaxDurationSeconds float64 `json:"max_duration_seconds"`
+
+	// Time first attempts waited on the task queue for a worker; retries are left out as they include the backoff
+	ScheduleToStarts          int     `json:"schedule_to_starts"`
+	AvgScheduleToStartSeconds float64 `json:"avg_schedule_to_start_seconds"`
+	MaxScheduleToStartSeconds float64 `json:"max_schedule_to_start_seconds"`
+}
+
+// sample is one latency observed at a point in time
+type sample struct {
+	at      time.Time
+	latency time.Duration
+}
+
+type entry struct {
+	stats ActivityStats
+
+	recentDurations        []sample
+	recentScheduleToStarts []sample
+}
+
+var (
+	mutex   sync.Mutex
+	entries = map[string]*entry{}
+)
+
+// lookup returns the entry of activityType, creating it; the caller holds mutex
+func lookup(activityType string) *entry {
+	current, ok := entries[activityType]
+	if !ok {
+		current = &entry{stats: ActivityStats{ActivityType: activityType, Executions: map[string]int64{}}}
+		entries[activityType] = current
+	}
+
+	return current
+}
+
+func observe(activityType, outcome string, duration time.Duration) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	current := lookup(activityType)
+	current.stats.Executions[outcome]++
+	current.stats.Duration += duration
+	current.recentDurations = appendSample(current.recentDurations, sample{at: time.Now(), latency: duration})
+}
+
+func observeScheduleToStart(activityType string, latency time.Duration) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	current := lookup(activityType)
+	current.stats.ScheduleToStart += latency
+	current.stats.ScheduleToStartCount++
+	current.recentScheduleToStarts = appendSample(current.recentScheduleToStarts, sample{at: time.Now(), latency: latency})
+}
+
+// appendSample adds next to samples, dropping those older than maxRecentWindow or beyond maxRecentSamples
+func appendSample(samples []sample, next sample) []sample {
+	samples = append(samples, next)
+
+	drop := max(0, len(samples)-maxRecentSamples)
+	for drop < len(samples) && next.at.Sub(samples[drop].at) > maxRecentWindow {
+		drop++
+	}
+
+	return samples[drop:]
+}
+
+// Read returns a copy of the execution counts of every activity type that has run, sorted by activity type
+func Read() []ActivityStats {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	snapshot := make([]ActivityStats, 0, len(entries))
+	for _, current := range entries {
+		stats := current.stats
+
+		executions := make(map[string]int64, len(stats.Executions))
+		for outcome, count := range stats.Executions {
+			executions[outcome] = count
+		}
+		stats.Executions = executions
+
+		snapshot = append(snapshot, stats)
+	}
+
+	sort.Slice(snapshot, func(i, j int) bool { return snapshot[i].ActivityType < snapshot[j].ActivityType })
+
+	return snapshot
+}
+
+// Recent returns the latencies of every activity type that ran during the last window (at most 15 minutes), sorted
+// by activity type
+func Recent(window time.Duration) []ActivityLatency {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	since := time.Now().Add(-min(window, maxRecentWindow))
+
+	latencies := []ActivityLatency{}
+	for activityType, current := range entries {
+		executions, avgDuration, maxDuration := summarize(current.recentDurations, since)
+		scheduleToStarts, avgScheduleToStart, maxScheduleToStart := summarize(current.recentScheduleToStarts, since)
+		if executions == 0 && scheduleToStarts == 0 {
+			continue
+		}
+
+		latencies = append(latencies, ActivityLatency{
+			ActivityType:              activityType,
+			Executions:                executions,
+			AvgDurationSeconds:        avgDuration.Seconds(),
+			MaxDurationSeconds:        maxDuration.Seconds(),
+			ScheduleToStarts:          scheduleToStarts,
+			AvgScheduleToStartSeconds: avgScheduleToStart.Seconds(),
+			MaxScheduleToStartSeconds: maxScheduleToStart.Seconds(),
+		})
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i].ActivityType < latencies[j].ActivityType })
+
+	return latencies
+}
+
+// summarize returns the count, mean and maximum of the samples taken after since
+func summarize(samples []sample, since time.Time) (int, time.Duration, time.Duration) {
+	var count int
+	var total, longest time.Duration
+	for _, observed := range samples {
+		if observed.at.Before(since) {
+			continue
+		}
+
+		count++
+		total += observed.latency
+		longest = max(longest, observed.latency)
+	}
+
+	if count == 0 {
+		return 0, 0, 0
+	}
+
+	return count, total / time.Duration(count), longest
+}
@@ -3,10 +3,12 @@ package worker
 import (
 	"svc-balance/activity"
 	"svc-balance/util/config"
+	"svc-balance/util/workerinterceptor"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
 	"go.temporal.io/sdk/worker"
 )
 
@@ -28,6 +30,8 @@ func NewWorker(
 	taskQueue string,
 	activity *activity.Activity,
 	temporalConfig config.Temporal,
+	failureHook workerinterceptor.FailureHook,
+	latencyHook workerinterceptor.LatencyHook,
 ) (*Worker, error) {
 	// Create worker with performance-optimized options
 	workerOptions := worker.Options{
@@ -44,6 +48,11 @@ func NewWorker(
 		// Task timeout optimizations
 		MaxHeartbeatThrottleInterval:     60 * time.Second,
 		DefaultHeartbeatThrottleInterval: 30 * time.Second,
+
+		// Activity logging fields, request IDs, latency and failure simulation, metrics and panic recovery
+		Interceptors: []interceptor.WorkerInterceptor{
+			workerinterceptor.New(workerinterceptor.Options{Logger: logger, FailureHook: failureHook, LatencyHook: latencyHook}),
+		},
 	}
 
 	temporalWorker := worker.New(client, taskQueue, workerOptions)
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"svc-external-bank/api/pb"
+	"svc-external-bank/service"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Api struct {
+	pb.UnimplementedExternalBankServer
+
+	logger *logrus.Logger
+
+	service *service.Service
+}
+
+func NewApi(
+	logger *logrus.Logger,
+	service *service.Service,
+) *Api {
+	return &Api{
+		logger: logger,
+
+		service: service,
+	}
+}
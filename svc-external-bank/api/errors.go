@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"svc-external-bank/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serviceErrors maps service errors to the status codes returned to clients. Simulated outages are UNAVAILABLE,
+// which callers treat as retryable.
+var serviceErrors = []struct {
+	err  error
+	code codes.Code
+}{
+	{service.ErrInvalidParameters, codes.InvalidArgument},
+	{service.ErrSettlementNotFound, codes.NotFound},
+	{service.ErrSettlementConflict, codes.FailedPrecondition},
+	{service.ErrPartnerUnavailable, codes.Unavailable},
+	{context.DeadlineExceeded, codes.DeadlineExceeded},
+}
+
+// ErrorStatusUnary is a gRPC unary interceptor that turns service errors into statuses. Errors that are already
+// statuses, or that have no mapping, are returned unchanged.
+func (api *Api) ErrorStatusUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		err = statusError(err)
+	}
+
+	return resp, err
+}
+
+// statusError returns err as a status when it matches one of the serviceErrors
+func statusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	for _, mapping := range serviceErrors {
+		if errors.Is(err, mapping.err) {
+			return status.Error(mapping.code, err.Error())
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"svc-external-bank/api/pb"
+	"svc-external-bank/service"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// settlementStatuses maps the service statuses of a settlement to their proto values
+var settlementStatuses = map[string]pb.SettlementStatus{
+	service.SettlementStatusPending:       pb.SettlementStatus_SETTLEMENT_STATUS_PENDING,
+	service.SettlementStatusSettled:       pb.SettlementStatus_SETTLEMENT_STATUS_SETTLED,
+	service.SettlementStatusRejected:      pb.SettlementStatus_SETTLEMENT_STATUS_REJECTED,
+	service.SettlementStatusRecalled:      pb.SettlementStatus_SETTLEMENT_STATUS_RECALLED,
+	service.SettlementStatusRecallRefused: pb.SettlementStatus_SETTLEMENT_STATUS_RECALL_REFUSED,
+}
+
+func (api *Api) SubmitSettlement(ctx context.Context, request *pb.SubmitSettlementRequest) (*pb.SubmitSettlementResponse, error) {
+	const op = "api.Api.SubmitSettlement"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := service.SubmitSettlementParams{
+		SettlementID:       request.SettlementId,
+		TransferID:         request.TransferId,
+		BeneficiaryAccount: request.BeneficiaryAccount,
+		Amount:             request.Amount,
+		Currency:           request.Currency,
+		CallbackURL:        request.CallbackUrl,
+		CallbackToken:      request.CallbackToken,
+	}
+
+	settlement, err := api.service.SubmitSettlement(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.SubmitSettlementResponse{
+		Settlement: settlementToProto(settlement),
+	}
+
+	logger.WithField("response", fmt.Sprintf("%+v", response)).Info()
+
+	return response, nil
+}
+
+func (api *Api) GetSettlement(ctx context.Context, request *pb.GetSettlementRequest) (*pb.GetSettlementResponse, error) {
+	const op = "api.Api.GetSettlement"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	settlement, err := api.service.GetSettlement(ctx, request.SettlementId)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.GetSettlementResponse{
+		Settlement: settlementToProto(settlement),
+	}
+
+	logger.WithField("response", fmt.Sprintf("%+v", response)).Info()
+
+	return response, nil
+}
+
+func (api *Api) RecallSettlement(ctx context.Context, request *pb.RecallSettlementRequest) (*pb.RecallSettlementResponse, error) {
+	const op = "api.Api.RecallSettlement"
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	params := service.RecallSettlementParams{
+		SettlementID: request.SettlementId,
+		Reason:       request.Reason,
+	}
+
+	settlement, err := api.service.RecallSettlement(ctx, params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Set response
+	response := &pb.RecallSettlementResponse{
+		Settlement: settlementToProto(settlement),
+	}
+
+	logger.WithField("response", fmt.Sprintf("%+v", response)).Info()
+
+	return response, nil
+}
+
+// settlementToProto converts a service settlement to its proto message
+func settlementToProto(settlement *service.Settlement) *pb.Settlement {
+	return &pb.Settlement{
+		SettlementId:       settlement.SettlementID,
+		TransferId:         settlement.TransferID,
+		BeneficiaryAccount: settlement.BeneficiaryAccount,
+		Amount:             settlement.Amount.String(),
+		Currency:           settlement.Currency,
+		Status:             settlementStatuses[settlement.Status],
+		Reason:             settlement.Reason,
+		SubmittedAt:        timestamppb.New(settlement.SubmittedAt),
+		SettledAt:          optionalTimestamp(settlement.SettledAt),
+		RecalledAt:         optionalTimestamp(settlement.RecalledAt),
+	}
+}
+
+// optionalTimestamp converts an optional time, leaving the field unset when it is nil
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"os"
+
+	"svc-external-bank/api"
+	"svc-external-bank/api/pb"
+	"svc-external-bank/util/config"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+func runGrpcServer(port int, grpcConfig config.Grpc, server *api.Api) *grpc.Server {
+	// Create new gRPC server
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler(
+			otelgrpc.WithPropagators(propagation.TraceContext{}),
+		)),
+		// Return the errors of the service with status codes callers can retry on
+		grpc.ChainUnaryInterceptor(server.ErrorStatusUnary),
+	}
+	opts = append(opts, grpcServerOptions(grpcConfig)...)
+	grpcServer := grpc.NewServer(opts...)
+
+	// Register gRPC services
+	pb.RegisterExternalBankServer(grpcServer, server)
+
+	// Register reflection service on gRPC server.
+	reflection.Register(grpcServer)
+
+	// Listen at specified port
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		log.Printf("failed to listen at port: %v!", port)
+
+		os.Exit(1)
+	}
+
+	log.Printf("listening at port: %d", port)
+
+	// Serve the gRPC server
+	go func() {
+		log.Printf("gRPC server started successfully 🚀")
+
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Printf("failed to serve: %v", err)
+		}
+	}()
+
+	return grpcServer
+}
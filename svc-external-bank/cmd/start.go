@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"svc-external-bank/api"
+	"svc-external-bank/service"
+	"svc-external-bank/util/config"
+	"svc-external-bank/util/failure"
+
+	"github.com/sirupsen/logrus"
+)
+
+func start() {
+	const op = "main.start"
+
+	// --- Init logger ---
+	var logger = logrus.New()
+	logger.Formatter = new(logrus.TextFormatter)
+	logger.Formatter.(*logrus.TextFormatter).DisableColors = true
+	logger.Formatter.(*logrus.TextFormatter).DisableTimestamp = true
+	logger.Level = logrus.DebugLevel
+	logger.Out = os.Stdout
+
+	// --- Load config ---
+	config, err := config.LoadConfig(".")
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"scope": "LoadConfig",
+			"err":   err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
+	// --- Apply profile (APP_PROFILE) ---
+	if !config.Profile.VerboseLogging() {
+		logger.Level = logrus.InfoLevel
+	}
+
+	logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"config": fmt.Sprintf("%+v", config),
+	}).Infof("Starting '%s' service ...", config.App.Name)
+
+	// --- Init service layer ---
+	service := service.NewService(logger, config.Settlement)
+	failureSimulation := config.FailureSimulation.Enabled
+	if enabled, ok := config.Profile.FailureSimulation(); ok {
+		failureSimulation = enabled
+	}
+	service.SetFailureSimulationEnabled(failureSimulation)
+	service.SetFailureQuota(failure.Quota{
+		MaxFailurePercent: config.FailureSimulation.MaxFailurePercent,
+		MinOperations:     config.FailureSimulation.MinOperations,
+	})
+
+	// --- Init api layer ---
+	server := api.NewApi(logger, service)
+
+	// --- Start gRPC server ---
+	grpcServer := runGrpcServer(config.App.Port, config.Grpc, server)
+
+	// --- Wait for signal ---
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt)
+
+	// --- Block until signal is received ---
+	<-ch
+
+	logger.Info("Shutdown signal received, stopping server...")
+	grpcServer.GracefulStop()
+
+	log.Printf("end of program...")
+}
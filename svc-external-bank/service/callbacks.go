@@ -0,0 +1,165 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// callbackTimeout bounds each delivery of a settlement callback
+const callbackTimeout = 10 * time.Second
+
+// errCallbackRefused is returned when the caller answers a callback with a client error. It no longer waits for
+// the settlement, e.g. because its request timed out and was resubmitted, so the callback is not retried.
+var errCallbackRefused = errors.New("settlement callback refused")
+
+// SettlementCallback is posted to the callback URL of a settlement once it is no longer pending
+type SettlementCallback struct {
+	CallbackToken      []byte     `json:"callback_token"` // As submitted, base64 encoded
+	SettlementID       string     `json:"settlement_id"`
+	TransferID         string     `json:"transfer_id"`
+	BeneficiaryAccount string     `json:"beneficiary_account"`
+	Amount             string     `json:"amount"` // Decimal string, e.g. "100.50"
+	Currency           string     `json:"currency"`
+	Status             string     `json:"status"` // settled, or recalled when the settlement was recalled first
+	Reason             string     `json:"reason,omitempty"`
+	SettledAt          *time.Time `json:"settled_at,omitempty"`
+	RecalledAt         *time.Time `json:"recalled_at,omitempty"`
+}
+
+// settlementCallback is where a pending settlement is posted once it settles
+type settlementCallback struct {
+	url   string
+	token []byte
+}
+
+// registerCallback records where to post a pending settlement and schedules the delivery for when it settles.
+// A resubmission only replaces the token, so the single delivery answers the latest submission. svc.mutex must
+// be held.
+func (svc *Service) registerCallback(settlement *Settlement, url string, token []byte) {
+	_, scheduled := svc.callbacks[settlement.SettlementID]
+
+	svc.callbacks[settlement.SettlementID] = &settlementCallback{url: url, token: token}
+
+	if !scheduled {
+		svc.scheduleCallback(settlement.SettlementID, svc.settleDelay(settlement))
+	}
+}
+
+// scheduleCallback delivers the callback of a settlement after delay
+func (svc *Service) scheduleCallback(settlementID string, delay time.Duration) {
+	time.AfterFunc(delay, func() {
+		svc.deliverCallback(settlementID)
+	})
+}
+
+// settleDelay returns how long a pending settlement has left before it settles
+func (svc *Service) settleDelay(settlement *Settlement) time.Duration {
+	settledAt := settlement.SubmittedAt.Add(time.Duration(svc.config.SettleAfterSeconds) * time.Second)
+
+	return max(settledAt.Sub(svc.now()), 0)
+}
+
+// deliverCallback posts a settlement to its callback URL once it is no longer pending, retrying while the caller
+// cannot be reached. A callback lost to the failure simulation or never accepted is left to the caller to notice.
+func (svc *Service) deliverCallback(settlementID string) {
+	const op = "service.Service.deliverCallback"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":          op,
+		"settlement_id": settlementID,
+	})
+
+	svc.mutex.Lock()
+	settlement := svc.settlements[settlementID]
+	svc.settle(settlement, svc.now())
+	if settlement.Status == SettlementStatusPending {
+		// Not settled yet by the service clock, check again once it should be
+		svc.scheduleCallback(settlementID, max(svc.settleDelay(settlement), time.Second))
+		svc.mutex.Unlock()
+		return
+	}
+	callback := svc.callbacks[settlementID]
+	delete(svc.callbacks, settlementID)
+	settlement = copySettlement(settlement)
+	svc.mutex.Unlock()
+
+	logger = logger.WithFields(logrus.Fields{
+		"callback_url": callback.url,
+		"status":       settlement.Status,
+	})
+
+	if err := svc.failureSimulator.SimulateFailure(context.Background(), failureOperationDeliverCallback, settlement.BeneficiaryAccount, learningFailureRules); err != nil {
+		logger.WithError(err).Warn("Settlement callback lost")
+
+		return
+	}
+
+	body := SettlementCallback{
+		CallbackToken:      callback.token,
+		SettlementID:       settlement.SettlementID,
+		TransferID:         settlement.TransferID,
+		BeneficiaryAccount: settlement.BeneficiaryAccount,
+		Amount:             settlement.Amount.String(),
+		Currency:           settlement.Currency,
+		Status:             settlement.Status,
+		Reason:             settlement.Reason,
+		SettledAt:          settlement.SettledAt,
+		RecalledAt:         settlement.RecalledAt,
+	}
+
+	attempts := max(svc.config.CallbackAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		err := svc.postCallback(callback.url, body)
+		if err == nil {
+			logger.WithField("attempt", attempt).Info("Settlement callback delivered")
+
+			return
+		}
+
+		if errors.Is(err, errCallbackRefused) || attempt >= attempts {
+			logger.WithError(err).WithField("attempt", attempt).Error("Settlement callback not delivered")
+
+			return
+		}
+
+		logger.WithError(err).WithField("attempt", attempt).Warn("Settlement callback failed, retrying")
+
+		time.Sleep(time.Duration(svc.config.CallbackRetrySeconds) * time.Second)
+	}
+}
+
+// postCallback posts a settlement callback and treats any non-2xx response as a failure
+func (svc *Service) postCallback(url string, body SettlementCallback) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal settlement callback: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create settlement callback request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := svc.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver settlement callback: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return fmt.Errorf("%w: status %d", errCallbackRefused, resp.StatusCode)
+	default:
+		return fmt.Errorf("settlement callback returned status %d", resp.StatusCode)
+	}
+}
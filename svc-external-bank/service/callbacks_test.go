@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"svc-external-bank/util/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitSettlement_Callback(t *testing.T) {
+	t.Parallel()
+
+	callbacks := make(chan SettlementCallback, 2)
+	statuses := []int{http.StatusServiceUnavailable, http.StatusOK}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var callback SettlementCallback
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&callback))
+		callbacks <- callback
+
+		w.WriteHeader(statuses[0])
+		statuses = statuses[1:]
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	svc, advance := newSettlementTestService(config.Settlement{SettleAfterSeconds: 1, CallbackAttempts: 3})
+
+	params := submitSettlementParams("ACC001000002")
+	params.CallbackURL = server.URL
+	params.CallbackToken = []byte("token-1")
+
+	settlement, err := svc.SubmitSettlement(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, SettlementStatusPending, settlement.Status)
+
+	// A resubmission replaces the token without scheduling a second callback
+	params.CallbackToken = []byte("token-2")
+	_, err = svc.SubmitSettlement(ctx, params)
+	require.NoError(t, err)
+
+	advance(time.Second)
+
+	// The first delivery fails and is retried
+	for _, attempt := range []string{"failed", "delivered"} {
+		select {
+		case callback := <-callbacks:
+			assert.Equal(t, []byte("token-2"), callback.CallbackToken, attempt)
+			assert.Equal(t, "transfer-1-settlement", callback.SettlementID, attempt)
+			assert.Equal(t, SettlementStatusSettled, callback.Status, attempt)
+			assert.Equal(t, "100.5", callback.Amount, attempt)
+			assert.NotNil(t, callback.SettledAt, attempt)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("callback not %s", attempt)
+		}
+	}
+
+	select {
+	case callback := <-callbacks:
+		t.Fatalf("unexpected callback %+v", callback)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSubmitSettlement_CallbackRefused(t *testing.T) {
+	t.Parallel()
+
+	deliveries := make(chan struct{}, 3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deliveries <- struct{}{}
+
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	svc, _ := newSettlementTestService(config.Settlement{CallbackAttempts: 3})
+
+	settlement := &Settlement{SettlementID: "transfer-1-settlement", Status: SettlementStatusSettled}
+	svc.settlements[settlement.SettlementID] = settlement
+	svc.callbacks[settlement.SettlementID] = &settlementCallback{url: server.URL, token: []byte("token-1")}
+
+	// The caller no longer waits for the settlement, so the callback is not retried
+	svc.deliverCallback(settlement.SettlementID)
+	assert.Len(t, deliveries, 1)
+	assert.Empty(t, svc.callbacks)
+}
+
+func TestSubmitSettlement_NoCallbackWhenDecided(t *testing.T) {
+	t.Parallel()
+
+	svc, _ := newSettlementTestService(config.Settlement{RejectedAccounts: []string{"888888888888"}})
+
+	params := submitSettlementParams("888888888888")
+	params.CallbackURL = "http://127.0.0.1:0/callbacks"
+
+	// A settlement decided at submission is answered in the reply
+	settlement, err := svc.SubmitSettlement(context.Background(), params)
+	require.NoError(t, err)
+	assert.Equal(t, SettlementStatusRejected, settlement.Status)
+	assert.Empty(t, svc.callbacks)
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidParameters is wrapped by errors of requests rejected by their validation
+var ErrInvalidParameters = errors.New("invalid parameters")
+
+// ErrSettlementNotFound is returned for a settlement ID the partner never accepted
+var ErrSettlementNotFound = errors.New("settlement not found")
+
+// ErrSettlementConflict is returned when a settlement ID is resubmitted with different details
+var ErrSettlementConflict = errors.New("settlement ID already used for a different settlement")
+
+// ErrPartnerUnavailable is wrapped by the simulated outages of the partner bank. A submission that fails with it
+// may still have been accepted, so callers must resubmit with the same settlement ID rather than a new one.
+var ErrPartnerUnavailable = errors.New("partner bank unavailable")
+
+// invalidParameters returns a validation error described by a printf-style message that matches ErrInvalidParameters
+func invalidParameters(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidParameters, fmt.Sprintf(format, args...))
+}
+
+// partnerUnavailable wraps a simulated failure so that it matches ErrPartnerUnavailable
+func partnerUnavailable(err error) error {
+	return fmt.Errorf("%w: %w", ErrPartnerUnavailable, err)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"svc-external-bank/util/failure"
+)
+
+// Operations of the partner bank that failure rules can target
+const (
+	failureOperationSubmitSettlement      = "SubmitSettlement"      // Before a submission is processed
+	failureOperationSubmitSettlementReply = "SubmitSettlementReply" // After a submission is stored, losing its reply
+	failureOperationGetSettlement         = "GetSettlement"
+	failureOperationRecallSettlement      = "RecallSettlement"
+	failureOperationDeliverCallback       = "DeliverCallback" // Before a settlement is posted to its callback URL, losing it
+)
+
+// Learning-focused failure simulation rules
+// These are hardcoded scenarios of a partner bank that is unreliable in the ways real ones are: it goes down, it
+// answers slowly, it accepts a settlement and loses the reply, it forgets to call back, and it sits on recalls. Rules target the beneficiary
+// account of the settlement.
+var learningFailureRules = []failure.Rule{
+	// Scenario 1: Partner outages, retried by the settlement activity
+	{
+		Name:        "partner_unavailable",
+		Enabled:     true,
+		Type:        "error",
+		Probability: 0.1, // 10% chance
+		Operations:  []string{failureOperationSubmitSettlement, failureOperationGetSettlement},
+		Accounts:    []string{"*"},
+		Message:     "Partner bank unavailable demonstrating retries across a service boundary",
+		MaxCount:    10,
+	},
+
+	// Scenario 2: Slow partner responses
+	{
+		Name:        "slow_partner",
+		Enabled:     true,
+		Type:        "slow",
+		Probability: 0.2, // 20% chance
+		Operations:  []string{failureOperationSubmitSettlement},
+		Accounts:    []string{"*"},
+		DelayMs:     3000, // 3 second delay
+		MaxCount:    10,
+	},
+
+	// Scenario 3: Lost replies, the settlement is accepted but the caller only sees an outage
+	{
+		Name:        "lost_submit_response",
+		Enabled:     true,
+		Type:        "error",
+		Probability: 1.0, // Always lose the reply for this account
+		Operations:  []string{failureOperationSubmitSettlementReply},
+		Accounts:    []string{"555555555555"}, // Specific test account (beneficiary)
+		Message:     "Settlement accepted but its reply was lost, demonstrating idempotent resubmission",
+		MaxCount:    2,
+	},
+
+	// Scenario 4: Lost callbacks, the caller waits until its activity times out and resubmits
+	{
+		Name:        "lost_callback",
+		Enabled:     true,
+		Type:        "error",
+		Probability: 0.2, // 20% chance
+		Operations:  []string{failureOperationDeliverCallback},
+		Accounts:    []string{"*"},
+		Message:     "Settlement callback lost demonstrating timeouts of asynchronously completed activities",
+		MaxCount:    3,
+	},
+
+	// Scenario 5: Recalls hanging until the caller gives up
+	{
+		Name:        "recall_timeout",
+		Enabled:     true,
+		Type:        "timeout",
+		Probability: 0.3, // 30% chance
+		Operations:  []string{failureOperationRecallSettlement},
+		Accounts:    []string{"*"},
+		TimeoutMs:   5000, // 5 second timeout
+		Message:     "Recall timeout demonstrating compensation retries against a partner",
+		MaxCount:    2,
+	},
+}
+
+// SetFailureQuota caps the share of calls the failure rules fail per minute
+func (svc *Service) SetFailureQuota(quota failure.Quota) {
+	svc.failureSimulator.SetQuota(quota)
+}
+
+// SetFailureSimulationEnabled switches failure injection on or off for the whole service
+func (svc *Service) SetFailureSimulationEnabled(enabled bool) {
+	svc.failureSimulator.SetEnabled(enabled)
+}
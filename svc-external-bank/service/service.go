@@ -0,0 +1,46 @@
+package service
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"svc-external-bank/util/config"
+	"svc-external-bank/util/failure"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Service simulates a partner bank. Settlements live in memory only: the partner is outside the saga's control,
+// and a restart that forgets them is one more thing the saga has to survive.
+type Service struct {
+	logger *logrus.Logger
+	config config.Settlement
+
+	now func() time.Time // Replaced in tests to move settlements past their settle delay and recall window
+
+	mutex       sync.Mutex
+	settlements map[string]*Settlement         // Settlement ID -> settlement
+	callbacks   map[string]*settlementCallback // Settlement ID -> callback awaiting delivery
+	httpClient  *http.Client                   // Delivers callbacks
+
+	failureSimulator *failure.Simulator
+}
+
+func NewService(
+	logger *logrus.Logger,
+	config config.Settlement,
+) *Service {
+	return &Service{
+		logger: logger,
+		config: config,
+
+		now: time.Now,
+
+		settlements: make(map[string]*Settlement),
+		callbacks:   make(map[string]*settlementCallback),
+		httpClient:  &http.Client{Timeout: callbackTimeout},
+
+		failureSimulator: failure.NewSimulator(logger),
+	}
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// Statuses of a settlement at the partner bank
+const (
+	SettlementStatusPending       = "pending"
+	SettlementStatusSettled       = "settled"
+	SettlementStatusRejected      = "rejected"
+	SettlementStatusRecalled      = "recalled"
+	SettlementStatusRecallRefused = "recall_refused"
+)
+
+// Settlement is a transfer the partner bank was asked to settle
+type Settlement struct {
+	SettlementID       string          `json:"settlement_id"`
+	TransferID         string          `json:"transfer_id"`
+	BeneficiaryAccount string          `json:"beneficiary_account"`
+	Amount             decimal.Decimal `json:"amount"`
+	Currency           string          `json:"currency"`
+	Status             string          `json:"status"`
+	Reason             string          `json:"reason,omitempty"` // Why the settlement was rejected or its recall refused
+	SubmittedAt        time.Time       `json:"submitted_at"`
+	SettledAt          *time.Time      `json:"settled_at,omitempty"`
+	RecalledAt         *time.Time      `json:"recalled_at,omitempty"`
+}
+
+type SubmitSettlementParams struct {
+	SettlementID       string `json:"settlement_id"`
+	TransferID         string `json:"transfer_id"`
+	BeneficiaryAccount string `json:"beneficiary_account"`
+	Amount             string `json:"amount"` // Decimal string, e.g. "100.50"
+	Currency           string `json:"currency"`
+	CallbackURL        string `json:"callback_url,omitempty"`   // Receives the settlement once it is no longer pending
+	CallbackToken      []byte `json:"callback_token,omitempty"` // Echoed back in the callback
+}
+
+type RecallSettlementParams struct {
+	SettlementID string `json:"settlement_id"`
+	Reason       string `json:"reason"`
+}
+
+// SubmitSettlement accepts a settlement, or returns the existing one when the settlement ID was submitted before.
+// Beneficiaries on the rejected list and amounts over the limit are rejected rather than failed, so the caller
+// knows nothing was settled. The reply to an accepted submission may be lost on the way back, which the caller
+// sees as an outage.
+func (svc *Service) SubmitSettlement(ctx context.Context, params SubmitSettlementParams) (*Settlement, error) {
+	const op = "service.Service.SubmitSettlement"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	amount, err := validateSubmitSettlementParams(params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	if err := svc.failureSimulator.SimulateFailure(ctx, failureOperationSubmitSettlement, params.BeneficiaryAccount, learningFailureRules); err != nil {
+		err = partnerUnavailable(err)
+
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	svc.mutex.Lock()
+	settlement, err := svc.submitSettlement(params, amount)
+	if err == nil && settlement.Status == SettlementStatusPending && params.CallbackURL != "" {
+		svc.registerCallback(settlement, params.CallbackURL, params.CallbackToken)
+	}
+	svc.mutex.Unlock()
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// The settlement is stored at this point, so a lost reply is only lost to the caller
+	if err := svc.failureSimulator.SimulateFailure(ctx, failureOperationSubmitSettlementReply, params.BeneficiaryAccount, learningFailureRules); err != nil {
+		err = partnerUnavailable(err)
+
+		logger.WithError(err).Warn("Settlement accepted, reply lost")
+
+		return nil, err
+	}
+
+	logger.WithField("settlement", fmt.Sprintf("%+v", settlement)).Info()
+
+	return settlement, nil
+}
+
+// submitSettlement stores a new settlement or returns the existing one. svc.mutex must be held.
+func (svc *Service) submitSettlement(params SubmitSettlementParams, amount decimal.Decimal) (*Settlement, error) {
+	now := svc.now()
+
+	if existing, ok := svc.settlements[params.SettlementID]; ok {
+		if existing.TransferID != params.TransferID ||
+			existing.BeneficiaryAccount != params.BeneficiaryAccount ||
+			!existing.Amount.Equal(amount) ||
+			existing.Currency != params.Currency {
+			return nil, fmt.Errorf("%w: %s", ErrSettlementConflict, params.SettlementID)
+		}
+
+		svc.settle(existing, now)
+
+		return copySettlement(existing), nil
+	}
+
+	settlement := &Settlement{
+		SettlementID:       params.SettlementID,
+		TransferID:         params.TransferID,
+		BeneficiaryAccount: params.BeneficiaryAccount,
+		Amount:             amount,
+		Currency:           params.Currency,
+		Status:             SettlementStatusPending,
+		SubmittedAt:        now,
+	}
+
+	switch {
+	case slices.Contains(svc.config.RejectedAccounts, params.BeneficiaryAccount):
+		settlement.Status = SettlementStatusRejected
+		settlement.Reason = "beneficiary account is not accepted by the partner bank"
+	case svc.config.MaxAmount > 0 && amount.GreaterThan(decimal.NewFromFloat(svc.config.MaxAmount)):
+		settlement.Status = SettlementStatusRejected
+		settlement.Reason = fmt.Sprintf("amount exceeds the partner bank limit of %s", decimal.NewFromFloat(svc.config.MaxAmount))
+	default:
+		svc.settle(settlement, now)
+	}
+
+	svc.settlements[settlement.SettlementID] = settlement
+
+	return copySettlement(settlement), nil
+}
+
+// GetSettlement returns the current status of a settlement
+func (svc *Service) GetSettlement(ctx context.Context, settlementID string) (*Settlement, error) {
+	const op = "service.Service.GetSettlement"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":          op,
+		"settlement_id": settlementID,
+	})
+
+	settlement, err := svc.findSettlement(settlementID)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	if err := svc.failureSimulator.SimulateFailure(ctx, failureOperationGetSettlement, settlement.BeneficiaryAccount, learningFailureRules); err != nil {
+		err = partnerUnavailable(err)
+
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	logger.WithField("settlement", fmt.Sprintf("%+v", settlement)).Debug()
+
+	return settlement, nil
+}
+
+// RecallSettlement asks the partner bank to return the funds of a settlement. A pending settlement is cancelled
+// before it settles; a settled one is returned unless its beneficiary is on the recall refused list or the recall
+// window has passed. A refused recall is final: the funds are with a partner the saga does not control, and
+// reconciling them is left to people. Recalling a rejected, recalled or refused settlement changes nothing.
+func (svc *Service) RecallSettlement(ctx context.Context, params RecallSettlementParams) (*Settlement, error) {
+	const op = "service.Service.RecallSettlement"
+
+	logger := svc.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	settlement, err := svc.findSettlement(params.SettlementID)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	if err := svc.failureSimulator.SimulateFailure(ctx, failureOperationRecallSettlement, settlement.BeneficiaryAccount, learningFailureRules); err != nil {
+		err = partnerUnavailable(err)
+
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	svc.mutex.Lock()
+	settlement = svc.recallSettlement(svc.settlements[params.SettlementID])
+	svc.mutex.Unlock()
+
+	logger.WithField("settlement", fmt.Sprintf("%+v", settlement)).Info()
+
+	return settlement, nil
+}
+
+// recallSettlement applies a recall to a stored settlement. svc.mutex must be held.
+func (svc *Service) recallSettlement(settlement *Settlement) *Settlement {
+	now := svc.now()
+	svc.settle(settlement, now)
+
+	switch settlement.Status {
+	case SettlementStatusPending:
+		settlement.Status = SettlementStatusRecalled
+		settlement.RecalledAt = &now
+
+	case SettlementStatusSettled:
+		window := time.Duration(svc.config.RecallWindowSeconds) * time.Second
+
+		switch {
+		case slices.Contains(svc.config.RecallRefusedAccounts, settlement.BeneficiaryAccount):
+			settlement.Status = SettlementStatusRecallRefused
+			settlement.Reason = "beneficiary bank refused to return the funds"
+		case window > 0 && now.Sub(*settlement.SettledAt) > window:
+			settlement.Status = SettlementStatusRecallRefused
+			settlement.Reason = fmt.Sprintf("recall window of %s has passed", window)
+		default:
+			settlement.Status = SettlementStatusRecalled
+			settlement.RecalledAt = &now
+		}
+	}
+
+	return copySettlement(settlement)
+}
+
+// findSettlement returns a copy of a stored settlement, brought up to date
+func (svc *Service) findSettlement(settlementID string) (*Settlement, error) {
+	if settlementID == "" {
+		return nil, invalidParameters("settlement_id is required")
+	}
+
+	svc.mutex.Lock()
+	defer svc.mutex.Unlock()
+
+	settlement, ok := svc.settlements[settlementID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSettlementNotFound, settlementID)
+	}
+
+	svc.settle(settlement, svc.now())
+
+	return copySettlement(settlement), nil
+}
+
+// settle marks a pending settlement settled once its settle delay has passed. Settling is lazy, so the partner
+// needs no background work and tests control it through svc.now.
+func (svc *Service) settle(settlement *Settlement, now time.Time) {
+	if settlement.Status != SettlementStatusPending {
+		return
+	}
+
+	settledAt := settlement.SubmittedAt.Add(time.Duration(svc.config.SettleAfterSeconds) * time.Second)
+	if now.Before(settledAt) {
+		return
+	}
+
+	settlement.Status = SettlementStatusSettled
+	settlement.SettledAt = &settledAt
+}
+
+// copySettlement returns a copy that callers can read without holding svc.mutex
+func copySettlement(settlement *Settlement) *Settlement {
+	copied := *settlement
+
+	return &copied
+}
+
+// validateSubmitSettlementParams validates a submission and returns its amount
+func validateSubmitSettlementParams(params SubmitSettlementParams) (decimal.Decimal, error) {
+	if params.SettlementID == "" {
+		return decimal.Zero, invalidParameters("settlement_id is required")
+	}
+
+	if params.TransferID == "" {
+		return decimal.Zero, invalidParameters("transfer_id is required")
+	}
+
+	if params.BeneficiaryAccount == "" {
+		return decimal.Zero, invalidParameters("beneficiary_account is required")
+	}
+
+	if len(params.Currency) != 3 {
+		return decimal.Zero, invalidParameters("currency must be a 3-letter ISO 4217 code")
+	}
+
+	amount, err := decimal.NewFromString(params.Amount)
+	if err != nil {
+		return decimal.Zero, invalidParameters("invalid amount %q", params.Amount)
+	}
+
+	if !amount.IsPositive() {
+		return decimal.Zero, invalidParameters("amount must be positive")
+	}
+
+	return amount, nil
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"svc-external-bank/util/config"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSettlementTestService returns a partner bank without simulated failures whose clock is moved by advance
+func newSettlementTestService(config config.Settlement) (svc *Service, advance func(time.Duration)) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	now := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+
+	svc = NewService(logger, config)
+	svc.now = func() time.Time { return now }
+	svc.SetFailureSimulationEnabled(false)
+
+	return svc, func(d time.Duration) { now = now.Add(d) }
+}
+
+func submitSettlementParams(beneficiary string) SubmitSettlementParams {
+	return SubmitSettlementParams{
+		SettlementID:       "transfer-1-settlement",
+		TransferID:         "transfer-1",
+		BeneficiaryAccount: beneficiary,
+		Amount:             "100.50",
+		Currency:           "USD",
+	}
+}
+
+func TestSubmitSettlement(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc, advance := newSettlementTestService(config.Settlement{SettleAfterSeconds: 5})
+
+	settlement, err := svc.SubmitSettlement(ctx, submitSettlementParams("ACC001000002"))
+	require.NoError(t, err)
+	assert.Equal(t, SettlementStatusPending, settlement.Status)
+	assert.Equal(t, "100.5", settlement.Amount.String())
+
+	// Settles lazily once the delay has passed
+	advance(5 * time.Second)
+	settlement, err = svc.GetSettlement(ctx, "transfer-1-settlement")
+	require.NoError(t, err)
+	assert.Equal(t, SettlementStatusSettled, settlement.Status)
+	require.NotNil(t, settlement.SettledAt)
+
+	// Resubmitting is idempotent
+	resubmitted, err := svc.SubmitSettlement(ctx, submitSettlementParams("ACC001000002"))
+	require.NoError(t, err)
+	assert.Equal(t, settlement, resubmitted)
+
+	// Reusing the ID for another settlement is a conflict
+	other := submitSettlementParams("ACC001000002")
+	other.Amount = "99"
+	_, err = svc.SubmitSettlement(ctx, other)
+	assert.ErrorIs(t, err, ErrSettlementConflict)
+}
+
+func TestSubmitSettlement_Rejected(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc, _ := newSettlementTestService(config.Settlement{
+		MaxAmount:        100,
+		RejectedAccounts: []string{"888888888888"},
+	})
+
+	settlement, err := svc.SubmitSettlement(ctx, submitSettlementParams("888888888888"))
+	require.NoError(t, err)
+	assert.Equal(t, SettlementStatusRejected, settlement.Status)
+	assert.Contains(t, settlement.Reason, "not accepted")
+
+	params := submitSettlementParams("ACC001000002")
+	params.SettlementID = "transfer-2-settlement"
+	settlement, err = svc.SubmitSettlement(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, SettlementStatusRejected, settlement.Status)
+	assert.Equal(t, "amount exceeds the partner bank limit of 100", settlement.Reason)
+}
+
+func TestSubmitSettlement_InvalidParameters(t *testing.T) {
+	t.Parallel()
+
+	svc, _ := newSettlementTestService(config.Settlement{})
+
+	for name, mutate := range map[string]func(*SubmitSettlementParams){
+		"missing_settlement_id": func(p *SubmitSettlementParams) { p.SettlementID = "" },
+		"missing_beneficiary":   func(p *SubmitSettlementParams) { p.BeneficiaryAccount = "" },
+		"invalid_amount":        func(p *SubmitSettlementParams) { p.Amount = "abc" },
+		"zero_amount":           func(p *SubmitSettlementParams) { p.Amount = "0" },
+		"invalid_currency":      func(p *SubmitSettlementParams) { p.Currency = "DOLLAR" },
+	} {
+		params := submitSettlementParams("ACC001000002")
+		mutate(&params)
+
+		_, err := svc.SubmitSettlement(context.Background(), params)
+		assert.ErrorIs(t, err, ErrInvalidParameters, name)
+	}
+}
+
+func TestSubmitSettlement_LostReply(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc, _ := newSettlementTestService(config.Settlement{})
+	svc.SetFailureSimulationEnabled(true)
+
+	// Only lost_submit_response targets this account; the random rules may still fire, so retry past them
+	var err error
+	for range 10 {
+		if _, err = svc.SubmitSettlement(ctx, submitSettlementParams("555555555555")); err == nil {
+			break
+		}
+		assert.ErrorIs(t, err, ErrPartnerUnavailable)
+	}
+	require.NoError(t, err)
+
+	stats := svc.failureSimulator.GetStats()
+	assert.Equal(t, 2, stats["occurrences"].(map[string]int)["lost_submit_response"])
+}
+
+func TestRecallSettlement(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("pending_is_cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _ := newSettlementTestService(config.Settlement{SettleAfterSeconds: 5})
+		_, err := svc.SubmitSettlement(ctx, submitSettlementParams("ACC001000002"))
+		require.NoError(t, err)
+
+		settlement, err := svc.RecallSettlement(ctx, RecallSettlementParams{SettlementID: "transfer-1-settlement"})
+		require.NoError(t, err)
+		assert.Equal(t, SettlementStatusRecalled, settlement.Status)
+		assert.Nil(t, settlement.SettledAt)
+	})
+
+	t.Run("settled_is_returned", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _ := newSettlementTestService(config.Settlement{})
+		_, err := svc.SubmitSettlement(ctx, submitSettlementParams("ACC001000002"))
+		require.NoError(t, err)
+
+		settlement, err := svc.RecallSettlement(ctx, RecallSettlementParams{SettlementID: "transfer-1-settlement"})
+		require.NoError(t, err)
+		assert.Equal(t, SettlementStatusRecalled, settlement.Status)
+		assert.NotNil(t, settlement.RecalledAt)
+	})
+
+	t.Run("refused_for_account", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _ := newSettlementTestService(config.Settlement{RecallRefusedAccounts: []string{"666666666666"}})
+		_, err := svc.SubmitSettlement(ctx, submitSettlementParams("666666666666"))
+		require.NoError(t, err)
+
+		settlement, err := svc.RecallSettlement(ctx, RecallSettlementParams{SettlementID: "transfer-1-settlement"})
+		require.NoError(t, err)
+		assert.Equal(t, SettlementStatusRecallRefused, settlement.Status)
+
+		// A refused recall is final
+		settlement, err = svc.RecallSettlement(ctx, RecallSettlementParams{SettlementID: "transfer-1-settlement"})
+		require.NoError(t, err)
+		assert.Equal(t, SettlementStatusRecallRefused, settlement.Status)
+	})
+
+	t.Run("refused_after_window", func(t *testing.T) {
+		t.Parallel()
+
+		svc, advance := newSettlementTestService(config.Settlement{RecallWindowSeconds: 60})
+		_, err := svc.SubmitSettlement(ctx, submitSettlementParams("ACC001000002"))
+		require.NoError(t, err)
+
+		advance(61 * time.Second)
+		settlement, err := svc.RecallSettlement(ctx, RecallSettlementParams{SettlementID: "transfer-1-settlement"})
+		require.NoError(t, err)
+		assert.Equal(t, SettlementStatusRecallRefused, settlement.Status)
+		assert.Equal(t, "recall window of 1m0s has passed", settlement.Reason)
+	})
+
+	t.Run("unknown_settlement", func(t *testing.T) {
+		t.Parallel()
+
+		svc, _ := newSettlementTestService(config.Settlement{})
+		_, err := svc.RecallSettlement(ctx, RecallSettlementParams{SettlementID: "unknown"})
+		assert.ErrorIs(t, err, ErrSettlementNotFound)
+	})
+}
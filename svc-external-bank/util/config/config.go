@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+
+	"profile"
+
+	"github.com/spf13/viper"
+)
+
+// Config holds all configuration for the application
+type Config struct {
+	App               App               `mapstructure:"app"`
+	Grpc              Grpc              `mapstructure:"grpc"`
+	Settlement        Settlement        `mapstructure:"settlement"`
+	FailureSimulation FailureSimulation `mapstructure:"failure_simulation"`
+
+	Profile profile.Profile `mapstructure:"-"` // From APP_PROFILE rather than config.json
+}
+
+// LoadConfig reads configuration from file or environment variables.
+func LoadConfig(path string) (config Config, err error) {
+	viper.AddConfigPath(path)
+	viper.SetConfigName("config")
+	viper.SetConfigType("json")
+
+	// Enable automatic environment variable reading
+	viper.AutomaticEnv()
+
+	err = viper.ReadInConfig()
+	if err != nil {
+		return config, fmt.Errorf("failed to read configuration file: %s", err)
+	}
+
+	err = viper.Unmarshal(&config)
+	if err != nil {
+		return config, fmt.Errorf("failed to unmarshal configuration: %s", err)
+	}
+
+	config.Profile, err = profile.Load()
+	if err != nil {
+		return config, err
+	}
+
+	return
+}
@@ -0,0 +1,53 @@
+package config
+
+// App config
+
+type App struct {
+	Name string `mapstructure:"name"`
+	Host string `mapstructure:"host"` // Bind address (0.0.0.0 for listening)
+	Port int    `mapstructure:"port"`
+}
+
+// Settlement config
+
+// Settlement controls how the simulated partner bank treats the settlements it is sent
+type Settlement struct {
+	SettleAfterSeconds    int      `mapstructure:"settle_after_seconds"`    // How long a submitted settlement stays pending
+	MaxAmount             float64  `mapstructure:"max_amount"`              // Larger settlements are rejected; 0 for no limit
+	RejectedAccounts      []string `mapstructure:"rejected_accounts"`       // Beneficiaries whose settlements are always rejected
+	RecallRefusedAccounts []string `mapstructure:"recall_refused_accounts"` // Beneficiaries whose settled funds are never returned
+	RecallWindowSeconds   int      `mapstructure:"recall_window_seconds"`   // Recalls of older settlements are refused; 0 for no limit
+
+	// Settlements submitted with a callback URL are posted to it once settled, retrying while the caller is down
+	CallbackAttempts     int `mapstructure:"callback_attempts"`      // Deliveries tried per settlement; at least one
+	CallbackRetrySeconds int `mapstructure:"callback_retry_seconds"` // Wait between deliveries
+}
+
+// FailureSimulation config
+
+// FailureSimulation switches and caps the outages, slow responses and lost replies of the partner bank
+type FailureSimulation struct {
+	Enabled           bool    `mapstructure:"enabled"`
+	MaxFailurePercent float64 `mapstructure:"max_failure_percent"` // Calls failed per minute as a percentage of all calls; 0 for no cap
+	MinOperations     int     `mapstructure:"min_operations"`      // Calls of the minute before the cap applies
+}
+
+// Grpc config
+
+// GrpcServerKeepalive configures the HTTP/2 pings of the gRPC server and how long connections are kept
+type GrpcServerKeepalive struct {
+	TimeSeconds                  int  `mapstructure:"time_seconds"`                     // Ping a client after this long without activity; 0 is 2 hours
+	TimeoutSeconds               int  `mapstructure:"timeout_seconds"`                  // Close the connection when a ping is not answered in time; 0 is 20 seconds
+	MinTimeSeconds               int  `mapstructure:"min_time_seconds"`                 // Clients pinging more often are disconnected (too_many_pings); 0 is 5 minutes
+	PermitWithoutStream          bool `mapstructure:"permit_without_stream"`            // Accept client pings while no call is in flight
+	MaxConnectionIdleSeconds     int  `mapstructure:"max_connection_idle_seconds"`      // Close connections idle this long; 0 keeps them
+	MaxConnectionAgeSeconds      int  `mapstructure:"max_connection_age_seconds"`       // Close connections this old so clients rebalance; 0 keeps them
+	MaxConnectionAgeGraceSeconds int  `mapstructure:"max_connection_age_grace_seconds"` // Time given to calls in flight on an aged connection; 0 is unlimited
+}
+
+type Grpc struct {
+	Keepalive                GrpcServerKeepalive `mapstructure:"keepalive"`
+	MaxRecvMsgBytes          int                 `mapstructure:"max_recv_msg_bytes"`         // 0 is 4 MiB
+	MaxSendMsgBytes          int                 `mapstructure:"max_send_msg_bytes"`         // 0 is unlimited
+	ConnectionTimeoutSeconds int                 `mapstructure:"connection_timeout_seconds"` // Deadline of the connection handshake; 0 is 120 seconds
+}
@@ -0,0 +1,361 @@
+package failure
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Rule represents a failure simulation rule
+type Rule struct {
+	Name        string
+	Enabled     bool
+	Type        string   // "error", "timeout", "slow", "panic"
+	Probability float64  // 0.0 to 1.0
+	Operations  []string // operations to target, ["*"] for all
+	Accounts    []string // account IDs to target, ["*"] for all
+	Message     string   // custom error message
+	DelayMs     int      // delay for "slow" type
+	TimeoutMs   int      // timeout duration
+	MaxCount    int      // maximum occurrences (0 = unlimited)
+}
+
+// Quota caps the share of operations the rules fail per minute, so that chaos rules cannot take over a busy
+// environment. Slow rules delay operations without failing them and are not limited.
+type Quota struct {
+	MaxFailurePercent float64 // Failed operations per minute as a percentage of all operations; 0 for no cap
+	MinOperations     int     // Operations of the minute before the cap applies, so a quiet demo still sees its failures
+}
+
+// quotaWindow is the period the operations and failures of a Quota are counted over
+const quotaWindow = time.Minute
+
+// Simulator manages failure injection for learning and testing purposes
+type Simulator struct {
+	logger         *logrus.Logger
+	startTime      time.Time
+	occurrences    map[string]int  // track occurrences per rule
+	disabled       bool            // switched off at runtime, e.g. by flowctl chaos disable
+	disabledReason string          // why the simulator switched itself off; empty when switched off by hand
+	overrides      map[string]bool // rule name -> enabled, set at runtime over the rule's own Enabled
+	mutex          sync.RWMutex
+
+	quota            Quota
+	windowStart      time.Time
+	windowOperations int
+	windowFailures   int
+	suppressed       int // Failures skipped because of the quota since the last reset
+}
+
+// NewSimulator creates a new failure simulator
+func NewSimulator(logger *logrus.Logger) *Simulator {
+	return &Simulator{
+		logger:      logger,
+		startTime:   time.Now(),
+		occurrences: make(map[string]int),
+		overrides:   make(map[string]bool),
+	}
+}
+
+// SimulateFailure checks if a failure should be injected based on the provided rules
+func (s *Simulator) SimulateFailure(ctx context.Context, operation string, accountID string, rules []Rule) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.disabled {
+		return nil
+	}
+
+	s.countOperation(time.Now())
+
+	for _, rule := range rules {
+		if s.shouldApplyRule(rule, operation, accountID) {
+			if isFailure(rule) {
+				if s.overQuota() {
+					s.suppressed++
+
+					s.logger.WithFields(logrus.Fields{
+						"rule":      rule.Name,
+						"operation": operation,
+						"failures":  s.windowFailures,
+						"of":        s.windowOperations,
+					}).Debug("Failure skipped, failure quota of the minute used up")
+
+					return nil
+				}
+				s.windowFailures++
+			}
+
+			// Track occurrence
+			s.occurrences[rule.Name]++
+
+			s.logger.WithFields(logrus.Fields{
+				"rule":       rule.Name,
+				"operation":  operation,
+				"account_id": accountID,
+				"type":       rule.Type,
+				"occurrence": s.occurrences[rule.Name],
+			}).Warn("🚨 Injecting simulated partner bank failure for Temporal testing")
+
+			return s.executeFailure(ctx, rule)
+		}
+	}
+
+	return nil
+}
+
+// countOperation counts one operation in the current quota window, starting a new window once a minute has passed
+func (s *Simulator) countOperation(now time.Time) {
+	if now.Sub(s.windowStart) >= quotaWindow {
+		s.windowStart = now
+		s.windowOperations = 0
+		s.windowFailures = 0
+	}
+
+	s.windowOperations++
+}
+
+// overQuota reports whether failing the current operation would exceed the quota of the window
+func (s *Simulator) overQuota() bool {
+	if s.quota.MaxFailurePercent <= 0 || s.windowOperations < s.quota.MinOperations {
+		return false
+	}
+
+	return float64(s.windowFailures+1)*100 > s.quota.MaxFailurePercent*float64(s.windowOperations)
+}
+
+// isFailure reports whether a rule fails the operation rather than only delaying it
+func isFailure(rule Rule) bool {
+	return rule.Type != "slow"
+}
+
+// shouldApplyRule determines if a failure rule should be applied
+func (s *Simulator) shouldApplyRule(rule Rule, operation string, accountID string) bool {
+	// Check if rule is enabled
+	if !s.ruleEnabled(rule) {
+		return false
+	}
+
+	// Check max occurrences
+	if rule.MaxCount > 0 && s.occurrences[rule.Name] >= rule.MaxCount {
+		return false
+	}
+
+	// Check operation match
+	if !s.matchesOperation(rule.Operations, operation) {
+		return false
+	}
+
+	// Check account match
+	if !s.matchesAccount(rule.Accounts, accountID) {
+		return false
+	}
+
+	// Check probability
+	if rule.Probability > 0 && rand.Float64() > rule.Probability {
+		return false
+	}
+
+	return true
+}
+
+// matchesOperation checks if the operation matches the rule's operation filters
+func (s *Simulator) matchesOperation(operations []string, operation string) bool {
+	if len(operations) == 0 {
+		return true // no filter means match all
+	}
+
+	for _, op := range operations {
+		if op == "*" || strings.EqualFold(op, operation) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAccount checks if the account matches the rule's account filters
+func (s *Simulator) matchesAccount(accounts []string, accountID string) bool {
+	if len(accounts) == 0 {
+		return true // no filter means match all
+	}
+
+	for _, acc := range accounts {
+		if acc == "*" || acc == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+// executeFailure executes the specified failure type
+func (s *Simulator) executeFailure(ctx context.Context, rule Rule) error {
+	switch rule.Type {
+	case "error":
+		message := rule.Message
+		if message == "" {
+			message = fmt.Sprintf("Learning demo partner bank failure: %s", rule.Name)
+		}
+		return fmt.Errorf("DEMO_PARTNER_FAILURE: %s", message)
+
+	case "timeout":
+		timeoutDuration := time.Duration(rule.TimeoutMs) * time.Millisecond
+		if timeoutDuration == 0 {
+			timeoutDuration = 5 * time.Second
+		}
+
+		s.logger.WithField("timeout", timeoutDuration).Debug("⏱️ Simulating partner bank timeout for Temporal testing")
+
+		select {
+		case <-time.After(timeoutDuration):
+			return fmt.Errorf("DEMO_PARTNER_TIMEOUT: operation timed out after %v", timeoutDuration)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+	case "slow":
+		delay := time.Duration(rule.DelayMs) * time.Millisecond
+		if delay == 0 {
+			delay = 2 * time.Second
+		}
+
+		s.logger.WithField("delay", delay).Debug("🐌 Simulating slow partner bank call for Temporal testing")
+
+		select {
+		case <-time.After(delay):
+			return nil // Continue normally after delay
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+	case "panic":
+		message := rule.Message
+		if message == "" {
+			message = fmt.Sprintf("Learning demo partner bank panic: %s", rule.Name)
+		}
+		panic(fmt.Sprintf("DEMO_PARTNER_PANIC: %s", message))
+
+	default:
+		return fmt.Errorf("DEMO_PARTNER_FAILURE: unknown failure type: %s", rule.Type)
+	}
+}
+
+// GetStats returns statistics about failure simulation
+func (s *Simulator) GetStats() map[string]any {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	stats := map[string]any{
+		"uptime_ms":           time.Since(s.startTime).Milliseconds(),
+		"occurrences":         make(map[string]int),
+		"enabled":             !s.disabled,
+		"max_failure_percent": s.quota.MaxFailurePercent,
+		"min_operations":      s.quota.MinOperations,
+		"window_operations":   s.windowOperations,
+		"window_failures":     s.windowFailures,
+		"suppressed_failures": s.suppressed,
+	}
+	if s.disabledReason != "" {
+		stats["disabled_reason"] = s.disabledReason
+	}
+
+	// Copy occurrences to avoid race conditions
+	for rule, count := range s.occurrences {
+		stats["occurrences"].(map[string]int)[rule] = count
+	}
+
+	return stats
+}
+
+// Reset resets the failure simulator state
+func (s *Simulator) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.startTime = time.Now()
+	s.occurrences = make(map[string]int)
+	s.overrides = make(map[string]bool)
+	s.windowStart = time.Time{}
+	s.windowOperations = 0
+	s.windowFailures = 0
+	s.suppressed = 0
+
+	s.logger.Info("🔄 Partner bank failure simulator state reset for new learning session")
+}
+
+// SetEnabled switches failure injection on or off without touching the rules or the counters
+func (s *Simulator) SetEnabled(enabled bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.disabled = !enabled
+	s.disabledReason = ""
+
+	s.logger.WithField("enabled", enabled).Info("🎛️ Failure simulator switched")
+}
+
+// Enabled reports whether failure injection is switched on
+func (s *Simulator) Enabled() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return !s.disabled
+}
+
+// Trip switches failure injection off because the environment needs to recover; the reason is reported in the
+// stats until failure injection is switched on again
+func (s *Simulator) Trip(reason string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.disabled {
+		return
+	}
+
+	s.disabled = true
+	s.disabledReason = reason
+
+	s.logger.WithField("reason", reason).Warn("🛑 Failure simulator switched off automatically")
+}
+
+// SetQuota sets the cap on the share of operations failed per minute
+func (s *Simulator) SetQuota(quota Quota) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.quota = quota
+}
+
+// SetRuleEnabled switches a single rule on or off, overriding its Enabled field until the next Reset
+func (s *Simulator) SetRuleEnabled(name string, enabled bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.overrides[name] = enabled
+
+	s.logger.WithFields(logrus.Fields{
+		"rule":    name,
+		"enabled": enabled,
+	}).Info("🎛️ Failure rule switched")
+}
+
+// RuleEnabled reports whether a rule is enabled, taking runtime overrides into account
+func (s *Simulator) RuleEnabled(rule Rule) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.ruleEnabled(rule)
+}
+
+// ruleEnabled is RuleEnabled for callers already holding the mutex
+func (s *Simulator) ruleEnabled(rule Rule) bool {
+	if enabled, ok := s.overrides[rule.Name]; ok {
+		return enabled
+	}
+
+	return rule.Enabled
+}
@@ -0,0 +1,353 @@
+package failure
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulator_PartnerBankFailures(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel) // Reduce log noise in tests
+
+	simulator := NewSimulator(logger)
+
+	tests := []struct {
+		name        string
+		rules       []Rule
+		operation   string
+		accountID   string
+		expectError bool
+	}{
+		{
+			name: "should_fail_submit_operation",
+			rules: []Rule{
+				{
+					Name:        "submit_failure",
+					Enabled:     true,
+					Type:        "error",
+					Probability: 1.0, // Always fail
+					Operations:  []string{"SubmitSettlement"},
+					Accounts:    []string{"*"},
+					Message:     "Test submit failure",
+				},
+			},
+			operation:   "SubmitSettlement",
+			accountID:   "123456789012",
+			expectError: true,
+		},
+		{
+			name: "should_fail_get_operation",
+			rules: []Rule{
+				{
+					Name:        "get_failure",
+					Enabled:     true,
+					Type:        "error",
+					Probability: 1.0, // Always fail
+					Operations:  []string{"GetSettlement"},
+					Accounts:    []string{"*"},
+					Message:     "Test get failure",
+				},
+			},
+			operation:   "GetSettlement",
+			accountID:   "123456789012",
+			expectError: true,
+		},
+		{
+			name: "should_fail_recall_operation",
+			rules: []Rule{
+				{
+					Name:        "recall_failure",
+					Enabled:     true,
+					Type:        "error",
+					Probability: 1.0, // Always fail
+					Operations:  []string{"RecallSettlement"},
+					Accounts:    []string{"*"},
+					Message:     "Test recall failure",
+				},
+			},
+			operation:   "RecallSettlement",
+			accountID:   "123456789012",
+			expectError: true,
+		},
+		{
+			name: "should_not_fail_when_operation_does_not_match",
+			rules: []Rule{
+				{
+					Name:        "different_operation",
+					Enabled:     true,
+					Type:        "error",
+					Probability: 1.0,
+					Operations:  []string{"SubmitSettlement"},
+					Accounts:    []string{"*"},
+				},
+			},
+			operation:   "GetSettlement", // Different operation
+			accountID:   "123456789012",
+			expectError: false,
+		},
+		{
+			name: "should_handle_timeout_simulation",
+			rules: []Rule{
+				{
+					Name:        "partner_timeout",
+					Enabled:     true,
+					Type:        "timeout",
+					Probability: 1.0,
+					Operations:  []string{"SubmitSettlement"},
+					Accounts:    []string{"*"},
+					TimeoutMs:   50, // Very short timeout for test
+				},
+			},
+			operation:   "SubmitSettlement",
+			accountID:   "123456789012",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset simulator for each test
+			simulator.Reset()
+
+			ctx := context.Background()
+			err := simulator.SimulateFailure(ctx, tt.operation, tt.accountID, tt.rules)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "DEMO_PARTNER")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSimulator_PartnerBankSlowOperation(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	rules := []Rule{
+		{
+			Name:        "slow_get",
+			Enabled:     true,
+			Type:        "slow",
+			Probability: 1.0,
+			Operations:  []string{"GetSettlement"},
+			Accounts:    []string{"*"},
+			DelayMs:     100, // 100ms delay
+		},
+	}
+
+	ctx := context.Background()
+	start := time.Now()
+
+	err := simulator.SimulateFailure(ctx, "GetSettlement", "123456789012", rules)
+
+	duration := time.Since(start)
+
+	// Should not return an error for slow type
+	assert.NoError(t, err)
+
+	// Should take at least 100ms
+	assert.GreaterOrEqual(t, duration.Milliseconds(), int64(100))
+}
+
+func TestSimulator_PartnerBankStatsTracking(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	// Initial stats
+	stats := simulator.GetStats()
+	assert.NotNil(t, stats)
+	assert.Contains(t, stats, "occurrences")
+	assert.Contains(t, stats, "uptime_ms")
+
+	// Trigger multiple partner bank failures
+	rules := []Rule{
+		{
+			Name:        "submit_test_rule",
+			Enabled:     true,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"SubmitSettlement"},
+			Accounts:    []string{"*"},
+		},
+		{
+			Name:        "get_test_rule",
+			Enabled:     true,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"GetSettlement"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+
+	// Trigger submit failure
+	simulator.SimulateFailure(ctx, "SubmitSettlement", "123456789012", rules)
+
+	// Trigger get failure
+	simulator.SimulateFailure(ctx, "GetSettlement", "987654321098", rules)
+
+	// Check stats updated
+	stats = simulator.GetStats()
+	occurrences := stats["occurrences"].(map[string]int)
+	assert.Equal(t, 1, occurrences["submit_test_rule"])
+	assert.Equal(t, 1, occurrences["get_test_rule"])
+}
+
+func TestSimulator_SetEnabled(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	rules := []Rule{
+		{
+			Name:        "test_rule",
+			Enabled:     true,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"GetSettlement"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+
+	// Disabled simulator injects nothing and counts nothing
+	simulator.SetEnabled(false)
+	assert.NoError(t, simulator.SimulateFailure(ctx, "GetSettlement", "123456789012", rules))
+	assert.Equal(t, false, simulator.GetStats()["enabled"])
+	assert.Equal(t, 0, simulator.GetStats()["occurrences"].(map[string]int)["test_rule"])
+
+	// Enabling it again resumes injection
+	simulator.SetEnabled(true)
+	assert.Error(t, simulator.SimulateFailure(ctx, "GetSettlement", "123456789012", rules))
+	assert.Equal(t, true, simulator.GetStats()["enabled"])
+}
+
+func TestSimulator_SetRuleEnabled(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	rules := []Rule{
+		{
+			Name:        "off_by_default",
+			Enabled:     false,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"SubmitSettlement"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+
+	assert.NoError(t, simulator.SimulateFailure(ctx, "SubmitSettlement", "123456789012", rules))
+
+	// An override turns the rule on without changing the rule itself
+	simulator.SetRuleEnabled("off_by_default", true)
+	assert.True(t, simulator.RuleEnabled(rules[0]))
+	assert.Error(t, simulator.SimulateFailure(ctx, "SubmitSettlement", "123456789012", rules))
+
+	// Reset restores the rule's own Enabled field
+	simulator.Reset()
+	assert.False(t, simulator.RuleEnabled(rules[0]))
+	assert.NoError(t, simulator.SimulateFailure(ctx, "SubmitSettlement", "123456789012", rules))
+}
+
+func TestSimulator_Quota(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+	simulator.SetQuota(Quota{MaxFailurePercent: 25, MinOperations: 4})
+
+	rules := []Rule{
+		{
+			Name:        "always_fail",
+			Enabled:     true,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"Fail"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+
+	// Below MinOperations every failure fires
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+
+	simulator.Reset()
+	simulator.SetQuota(Quota{MaxFailurePercent: 25, MinOperations: 4})
+
+	for range 3 {
+		assert.NoError(t, simulator.SimulateFailure(ctx, "Other", "123456789012", rules))
+	}
+
+	// 1 failure in 4 operations is within 25%, a second one in 5 is not
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+	assert.NoError(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+
+	// 2 in 9 is within 25% again
+	for range 3 {
+		assert.NoError(t, simulator.SimulateFailure(ctx, "Other", "123456789012", rules))
+	}
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+
+	stats := simulator.GetStats()
+	assert.Equal(t, 9, stats["window_operations"])
+	assert.Equal(t, 2, stats["window_failures"])
+	assert.Equal(t, 1, stats["suppressed_failures"])
+	assert.Equal(t, 2, stats["occurrences"].(map[string]int)["always_fail"])
+
+	// A new minute starts a new window
+	simulator.countOperation(simulator.windowStart.Add(quotaWindow))
+	assert.Equal(t, 1, simulator.windowOperations)
+	assert.Equal(t, 0, simulator.windowFailures)
+}
+
+func TestSimulator_Trip(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	simulator := NewSimulator(logger)
+
+	rules := []Rule{
+		{
+			Name:        "always_fail",
+			Enabled:     true,
+			Type:        "error",
+			Probability: 1.0,
+			Operations:  []string{"*"},
+			Accounts:    []string{"*"},
+		},
+	}
+
+	ctx := context.Background()
+
+	simulator.Trip("backlog too large")
+	assert.False(t, simulator.Enabled())
+	assert.NoError(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+	assert.Equal(t, "backlog too large", simulator.GetStats()["disabled_reason"])
+
+	// Switching it on again clears the reason
+	simulator.SetEnabled(true)
+	assert.True(t, simulator.Enabled())
+	assert.Error(t, simulator.SimulateFailure(ctx, "Fail", "123456789012", rules))
+	assert.NotContains(t, simulator.GetStats(), "disabled_reason")
+}
@@ -0,0 +1,192 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"svc-transaction/service"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/temporal"
+)
+
+// AccountClosureActivityParams defines parameters shared by the account closure activities
+type AccountClosureActivityParams struct {
+	AccountID      string `json:"account_id"`
+	SweepAccountID string `json:"sweep_account_id"`
+	WorkflowID     string `json:"workflow_id"`
+	RunID          string `json:"run_id"`
+}
+
+// BlockAccountForClosureActivityResults defines results from the BlockAccountForClosure activity
+type BlockAccountForClosureActivityResults struct {
+	PreviousStatus string `json:"previous_status"`
+}
+
+// CountInFlightTransfersActivityResults defines results from the CountInFlightTransfers activity
+type CountInFlightTransfersActivityResults struct {
+	Count int64 `json:"count"`
+}
+
+// RecordAccountClosureStepActivityParams defines parameters for the RecordAccountClosureStep activity
+type RecordAccountClosureStepActivityParams struct {
+	AccountClosureActivityParams
+	Step    string         `json:"step"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// SweepAccountBalanceActivityResults defines results from the SweepAccountBalance activity.
+// TransferID is empty when there was no balance left to sweep.
+type SweepAccountBalanceActivityResults struct {
+	TransferID string          `json:"transfer_id,omitempty"`
+	Amount     decimal.Decimal `json:"amount"`
+	Currency   string          `json:"currency"`
+}
+
+// ReopenAccountActivityParams defines parameters for the ReopenAccount activity
+type ReopenAccountActivityParams struct {
+	AccountClosureActivityParams
+	PreviousStatus string `json:"previous_status"`
+	Reason         string `json:"reason"`
+}
+
+// BlockAccountForClosure is the Temporal activity that suspends an account at the start of its closure
+func (api *Activity) BlockAccountForClosure(ctx context.Context, params AccountClosureActivityParams) (*BlockAccountForClosureActivityResults, error) {
+	logger := api.activityLogger(ctx)
+
+	stepParams, sweepAccountID, err := params.parse()
+	if err != nil {
+		return nil, closureActivityError(logger, err)
+	}
+
+	result, err := api.service.BlockAccountForClosure(ctx, stepParams, sweepAccountID)
+	if err != nil {
+		return nil, closureActivityError(logger, err)
+	}
+
+	return &BlockAccountForClosureActivityResults{PreviousStatus: result.PreviousStatus}, nil
+}
+
+// CountInFlightTransfers is the Temporal activity that counts the running transfer workflows of an account
+func (api *Activity) CountInFlightTransfers(ctx context.Context, params AccountClosureActivityParams) (*CountInFlightTransfersActivityResults, error) {
+	logger := api.activityLogger(ctx)
+
+	stepParams, _, err := params.parse()
+	if err != nil {
+		return nil, closureActivityError(logger, err)
+	}
+
+	count, err := api.service.CountInFlightTransfers(ctx, stepParams.AccountID)
+	if err != nil {
+		return nil, closureActivityError(logger, err)
+	}
+
+	logger.WithField("in_flight", count).Info()
+
+	return &CountInFlightTransfersActivityResults{Count: count}, nil
+}
+
+// RecordAccountClosureStep is the Temporal activity that records a closure step which changes no data
+func (api *Activity) RecordAccountClosureStep(ctx context.Context, params RecordAccountClosureStepActivityParams) error {
+	logger := api.activityLogger(ctx).WithField("step", params.Step)
+
+	stepParams, _, err := params.parse()
+	if err != nil {
+		return closureActivityError(logger, err)
+	}
+
+	if err := api.service.RecordAccountClosureStep(ctx, stepParams, params.Step, params.Details); err != nil {
+		return closureActivityError(logger, err)
+	}
+
+	return nil
+}
+
+// SweepAccountBalance is the Temporal activity that moves the remaining balance of a blocked account to the sweep account
+func (api *Activity) SweepAccountBalance(ctx context.Context, params AccountClosureActivityParams) (*SweepAccountBalanceActivityResults, error) {
+	logger := api.activityLogger(ctx)
+
+	stepParams, sweepAccountID, err := params.parse()
+	if err != nil {
+		return nil, closureActivityError(logger, err)
+	}
+
+	result, err := api.service.SweepAccountBalance(ctx, stepParams, sweepAccountID)
+	if err != nil {
+		return nil, closureActivityError(logger, err)
+	}
+
+	return &SweepAccountBalanceActivityResults{
+		TransferID: result.TransferID,
+		Amount:     result.Amount,
+		Currency:   result.Currency,
+	}, nil
+}
+
+// CloseAccount is the Temporal activity that marks a swept account as closed
+func (api *Activity) CloseAccount(ctx context.Context, params AccountClosureActivityParams) error {
+	logger := api.activityLogger(ctx)
+
+	stepParams, _, err := params.parse()
+	if err != nil {
+		return closureActivityError(logger, err)
+	}
+
+	if err := api.service.CloseAccount(ctx, stepParams); err != nil {
+		return closureActivityError(logger, err)
+	}
+
+	return nil
+}
+
+// ReopenAccount is the Temporal activity that restores the status of an account whose closure cannot finish
+func (api *Activity) ReopenAccount(ctx context.Context, params ReopenAccountActivityParams) error {
+	logger := api.activityLogger(ctx).WithField("reason", params.Reason)
+
+	stepParams, _, err := params.parse()
+	if err != nil {
+		return closureActivityError(logger, err)
+	}
+
+	if err := api.service.ReopenAccount(ctx, stepParams, params.PreviousStatus, params.Reason); err != nil {
+		return closureActivityError(logger, err)
+	}
+
+	return nil
+}
+
+// parse converts the activity parameters to the service parameters; the sweep account is optional
+func (params AccountClosureActivityParams) parse() (service.AccountClosureStepParams, uuid.UUID, error) {
+	accountID, err := uuid.Parse(params.AccountID)
+	if err != nil {
+		return service.AccountClosureStepParams{}, uuid.Nil, fmt.Errorf("%w: invalid account_id format: %w", service.ErrAccountClosureRejected, err)
+	}
+
+	var sweepAccountID uuid.UUID
+	if params.SweepAccountID != "" {
+		sweepAccountID, err = uuid.Parse(params.SweepAccountID)
+		if err != nil {
+			return service.AccountClosureStepParams{}, uuid.Nil, fmt.Errorf("%w: invalid sweep_account_id format: %w", service.ErrAccountClosureRejected, err)
+		}
+	}
+
+	return service.AccountClosureStepParams{
+		AccountID:  accountID,
+		WorkflowID: params.WorkflowID,
+		RunID:      params.RunID,
+	}, sweepAccountID, nil
+}
+
+// closureActivityError logs the error and makes closure rule violations non-retryable, since retrying cannot fix them
+func closureActivityError(logger *logrus.Entry, err error) error {
+	logger.WithError(err).Error()
+
+	if errors.Is(err, service.ErrAccountClosureRejected) || errors.Is(err, service.ErrAccountNotFound) {
+		return temporal.NewNonRetryableApplicationError(err.Error(), "ACCOUNT_CLOSURE_REJECTED", err)
+	}
+
+	return err
+}
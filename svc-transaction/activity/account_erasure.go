@@ -0,0 +1,137 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-transaction/service"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/temporal"
+)
+
+// AccountErasureActivityParams defines parameters shared by the account erasure activities
+type AccountErasureActivityParams struct {
+	AccountID  string `json:"account_id"`
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+}
+
+// AnonymizeAccountDataActivityResults defines results from the AnonymizeAccountData activity
+type AnonymizeAccountDataActivityResults struct {
+	ClosedAt time.Time          `json:"closed_at"`
+	Scrubbed service.ErasedRows `json:"scrubbed"`
+}
+
+// VerifyAccountErasureActivityResults defines results from the VerifyAccountErasure activity
+type VerifyAccountErasureActivityResults struct {
+	Remaining service.ErasedRows `json:"remaining"`
+}
+
+// IssueAccountErasureCertificateActivityParams defines parameters for the IssueAccountErasureCertificate activity
+type IssueAccountErasureCertificateActivityParams struct {
+	AccountErasureActivityParams
+	RequestedBy string             `json:"requested_by,omitempty"`
+	ClosedAt    time.Time          `json:"closed_at"`
+	Scrubbed    service.ErasedRows `json:"scrubbed"`
+}
+
+// IssueAccountErasureCertificateActivityResults defines results from the IssueAccountErasureCertificate activity
+type IssueAccountErasureCertificateActivityResults struct {
+	CertificateID string    `json:"certificate_id"`
+	Digest        string    `json:"digest"`
+	ErasedAt      time.Time `json:"erased_at"`
+}
+
+// AnonymizeAccountData is the Temporal activity that scrubs the personal data of a closed account
+func (api *Activity) AnonymizeAccountData(ctx context.Context, params AccountErasureActivityParams) (*AnonymizeAccountDataActivityResults, error) {
+	logger := api.activityLogger(ctx)
+
+	stepParams, err := params.parse()
+	if err != nil {
+		return nil, erasureActivityError(logger, err)
+	}
+
+	result, err := api.service.AnonymizeAccountData(ctx, stepParams)
+	if err != nil {
+		return nil, erasureActivityError(logger, err)
+	}
+
+	return &AnonymizeAccountDataActivityResults{
+		ClosedAt: result.ClosedAt,
+		Scrubbed: result.Scrubbed,
+	}, nil
+}
+
+// VerifyAccountErasure is the Temporal activity that counts the rows of an account still holding personal data
+func (api *Activity) VerifyAccountErasure(ctx context.Context, params AccountErasureActivityParams) (*VerifyAccountErasureActivityResults, error) {
+	logger := api.activityLogger(ctx)
+
+	stepParams, err := params.parse()
+	if err != nil {
+		return nil, erasureActivityError(logger, err)
+	}
+
+	remaining, err := api.service.VerifyAccountErasure(ctx, stepParams.AccountID)
+	if err != nil {
+		return nil, erasureActivityError(logger, err)
+	}
+
+	logger.WithField("remaining", fmt.Sprintf("%+v", remaining)).Info()
+
+	return &VerifyAccountErasureActivityResults{Remaining: remaining}, nil
+}
+
+// IssueAccountErasureCertificate is the Temporal activity that records a verified erasure
+func (api *Activity) IssueAccountErasureCertificate(ctx context.Context, params IssueAccountErasureCertificateActivityParams) (*IssueAccountErasureCertificateActivityResults, error) {
+	logger := api.activityLogger(ctx)
+
+	stepParams, err := params.parse()
+	if err != nil {
+		return nil, erasureActivityError(logger, err)
+	}
+
+	certificate, err := api.service.IssueAccountErasureCertificate(ctx, service.IssueAccountErasureCertificateParams{
+		AccountErasureStepParams: stepParams,
+		RequestedBy:              params.RequestedBy,
+		ClosedAt:                 params.ClosedAt,
+		Scrubbed:                 params.Scrubbed,
+	})
+	if err != nil {
+		return nil, erasureActivityError(logger, err)
+	}
+
+	return &IssueAccountErasureCertificateActivityResults{
+		CertificateID: certificate.ID.String(),
+		Digest:        certificate.Digest,
+		ErasedAt:      certificate.ErasedAt,
+	}, nil
+}
+
+// parse converts the activity parameters to the service parameters
+func (params AccountErasureActivityParams) parse() (service.AccountErasureStepParams, error) {
+	accountID, err := uuid.Parse(params.AccountID)
+	if err != nil {
+		return service.AccountErasureStepParams{}, fmt.Errorf("%w: invalid account_id format: %w", service.ErrAccountErasureRejected, err)
+	}
+
+	return service.AccountErasureStepParams{
+		AccountID:  accountID,
+		WorkflowID: params.WorkflowID,
+		RunID:      params.RunID,
+	}, nil
+}
+
+// erasureActivityError logs the error and makes erasure rule violations non-retryable, since retrying cannot fix them
+func erasureActivityError(logger *logrus.Entry, err error) error {
+	logger.WithError(err).Error()
+
+	if errors.Is(err, service.ErrAccountErasureRejected) || errors.Is(err, service.ErrAccountNotFound) {
+		return temporal.NewNonRetryableApplicationError(err.Error(), "ACCOUNT_ERASURE_REJECTED", err)
+	}
+
+	return err
+}
@@ -1,11 +1,58 @@
 package activity
 
 import (
+	"context"
+	"errors"
+
 	"svc-transaction/service"
+	"svc-transaction/util/workerinterceptor"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/temporal"
+)
+
+// Application error types of business failures. The first four match the non-retryable error types the
+// transfer workflow is configured with in flowngine.
+const (
+	ErrorTypeInsufficientFunds           = "INSUFFICIENT_FUNDS"
+	ErrorTypeAccountNotFound             = "ACCOUNT_NOT_FOUND"
+	ErrorTypeInvalidCurrency             = "INVALID_CURRENCY"
+	ErrorTypeAccountBlocked              = "ACCOUNT_BLOCKED"
+	ErrorTypeCompensationExceedsOriginal = "COMPENSATION_EXCEEDS_ORIGINAL"
+	ErrorTypeIdempotencyConflict         = "IDEMPOTENCY_CONFLICT"
+	ErrorTypeLedgerExportRejected        = "LEDGER_EXPORT_REJECTED"
+	ErrorTypeEODBalanceRejected          = "EOD_BALANCE_REJECTED"
+	ErrorTypeExternalSettlementRejected  = "EXTERNAL_SETTLEMENT_REJECTED"
+	ErrorTypeInvalidTransferEvent        = "INVALID_TRANSFER_EVENT"
+	ErrorTypeBusinessRuleViolation       = "BUSINESS_RULE_VIOLATION"
+	ErrorTypeAccountTypeRestriction      = "ACCOUNT_TYPE_RESTRICTION"
+	ErrorTypeInvalidTransferApproval     = "INVALID_TRANSFER_APPROVAL"
+	ErrorTypePrecisionExceeded           = "PRECISION_EXCEEDED"
 )
 
+// businessErrorTypes maps the service errors that retrying cannot fix to their application error types
+var businessErrorTypes = []struct {
+	err       error
+	errorType string
+}{
+	{service.ErrInsufficientFunds, ErrorTypeInsufficientFunds},
+	{service.ErrAccountNotFound, ErrorTypeAccountNotFound},
+	{service.ErrInvalidCurrency, ErrorTypeInvalidCurrency},
+	{service.ErrAccountBlocked, ErrorTypeAccountBlocked},
+	{service.ErrCompensationExceedsOriginal, ErrorTypeCompensationExceedsOriginal},
+	{service.ErrIdempotencyConflict, ErrorTypeIdempotencyConflict},
+	{service.ErrLedgerExportRejected, ErrorTypeLedgerExportRejected},
+	{service.ErrLedgerExportUnavailable, ErrorTypeLedgerExportRejected},
+	{service.ErrEODBalanceRejected, ErrorTypeEODBalanceRejected},
+	{service.ErrExternalSettlementRejected, ErrorTypeExternalSettlementRejected},
+	{service.ErrInvalidTransferEvent, ErrorTypeInvalidTransferEvent},
+	{service.ErrBusinessRuleViolation, ErrorTypeBusinessRuleViolation},
+	{service.ErrAccountTypeRestriction, ErrorTypeAccountTypeRestriction},
+	{service.ErrInvalidTransferApproval, ErrorTypeInvalidTransferApproval},
+	{service.ErrPrecisionExceeded, ErrorTypePrecisionExceeded},
+}
+
 type Activity struct {
 	logger *logrus.Logger
 
@@ -29,5 +76,61 @@ func (api *Activity) GetActivities() []any {
 		api.DebitAccount,
 		api.CreditAccount,
 		api.CompensateDebit,
+		api.GenerateSettlementFile,
+		api.BlockAccountForClosure,
+		api.CountInFlightTransfers,
+		api.RecordAccountClosureStep,
+		api.SweepAccountBalance,
+		api.CloseAccount,
+		api.ReopenAccount,
+		api.AnonymizeAccountData,
+		api.VerifyAccountErasure,
+		api.IssueAccountErasureCertificate,
+		api.PurgeExpiredIdempotencyKeys,
+		api.SweepPendingTransactions,
+		api.ExportLedgerSnapshot,
+		api.ComputeEODBalances,
+		api.ListCompensationsForReview,
+		api.ReviewCompensation,
+		api.RecordCompensationReviewReport,
+		api.SettleExternalTransfer,
+		api.RecallExternalSettlement,
+		api.RecordTransferEvent,
+		api.RecordTransferApproval,
 	}
 }
+
+// activityError wraps business failures in non-retryable application errors typed by businessErrorTypes, so the
+// workflow fails the step at once. Any other error is returned as is and retried as a transient fault.
+// Idempotency conflicts carry the differing fields as error details.
+func activityError(err error) error {
+	var details []any
+	var conflict *service.IdempotencyConflictError
+	if errors.As(err, &conflict) {
+		details = append(details, conflict.Diffs)
+	}
+
+	for _, business := range businessErrorTypes {
+		if errors.Is(err, business.err) {
+			return temporal.NewNonRetryableApplicationError(err.Error(), business.errorType, err, details...)
+		}
+	}
+
+	return err
+}
+
+// activityLogger returns the logger the worker interceptor prepared for the running activity, carrying its
+// activity, workflow, account and transfer fields
+func (api *Activity) activityLogger(ctx context.Context) *logrus.Entry {
+	return workerinterceptor.Logger(ctx, api.logger)
+}
+
+// parseAccount splits the account of a transfer activity into an account ID or an account number. Transfers are
+// started with either, e.g. the gateway and the demo scenarios pass account numbers.
+func parseAccount(account string) (*uuid.UUID, *string) {
+	if accountID, err := uuid.Parse(account); err == nil {
+		return &accountID, nil
+	}
+
+	return nil, &account
+}
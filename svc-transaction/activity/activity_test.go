@@ -1,20 +1,69 @@
 package activity
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
+	"svc-transaction/service"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
 )
 
 func TestGetActivities(t *testing.T) {
 	activity := &Activity{}
 	activities := activity.GetActivities()
 
-	// Should have exactly 3 activities
-	assert.Equal(t, 3, len(activities))
+	assert.Equal(t, 24, len(activities))
 
 	// All activities should be non-nil
 	for _, act := range activities {
 		assert.NotNil(t, act)
 	}
 }
+
+func TestActivityError(t *testing.T) {
+	for err, errorType := range map[error]string{
+		service.ErrInsufficientFunds:           ErrorTypeInsufficientFunds,
+		service.ErrAccountNotFound:             ErrorTypeAccountNotFound,
+		service.ErrInvalidCurrency:             ErrorTypeInvalidCurrency,
+		service.ErrAccountBlocked:              ErrorTypeAccountBlocked,
+		service.ErrCompensationExceedsOriginal: ErrorTypeCompensationExceedsOriginal,
+		service.ErrIdempotencyConflict:         ErrorTypeIdempotencyConflict,
+		service.ErrLedgerExportRejected:        ErrorTypeLedgerExportRejected,
+		service.ErrLedgerExportUnavailable:     ErrorTypeLedgerExportRejected,
+		service.ErrEODBalanceRejected:          ErrorTypeEODBalanceRejected,
+		service.ErrExternalSettlementRejected:  ErrorTypeExternalSettlementRejected,
+		service.ErrInvalidTransferEvent:        ErrorTypeInvalidTransferEvent,
+		service.ErrBusinessRuleViolation:       ErrorTypeBusinessRuleViolation,
+		service.ErrAccountTypeRestriction:      ErrorTypeAccountTypeRestriction,
+		service.ErrInvalidTransferApproval:     ErrorTypeInvalidTransferApproval,
+		service.ErrPrecisionExceeded:           ErrorTypePrecisionExceeded,
+	} {
+		wrapped := activityError(fmt.Errorf("debit account failed: %w", err))
+
+		var applicationErr *temporal.ApplicationError
+		require.ErrorAs(t, wrapped, &applicationErr)
+		assert.Equal(t, errorType, applicationErr.Type())
+		assert.True(t, applicationErr.NonRetryable())
+		assert.ErrorIs(t, wrapped, err)
+	}
+
+	// Idempotency conflicts carry the differing fields
+	diffs := []service.IdempotencyDiff{{Field: "amount", Existing: "100", Requested: "50"}}
+	wrapped := activityError(fmt.Errorf("debit account failed: %w", &service.IdempotencyConflictError{IdempotencyKey: "transfer-1-debit", Diffs: diffs}))
+
+	var applicationErr *temporal.ApplicationError
+	require.ErrorAs(t, wrapped, &applicationErr)
+	assert.Equal(t, ErrorTypeIdempotencyConflict, applicationErr.Type())
+
+	var details []service.IdempotencyDiff
+	require.NoError(t, applicationErr.Details(&details))
+	assert.Equal(t, diffs, details)
+
+	// Transient faults stay plain errors, so the retry policy applies
+	transient := errors.New("connection refused")
+	assert.Same(t, transient, activityError(transient))
+}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
-	"github.com/sirupsen/logrus"
 	"go.temporal.io/sdk/activity"
 )
 
@@ -50,45 +49,13 @@ type CompensateDebitActivityResults struct {
 
 // CompensateDebit is the Temporal activity that handles CompensateDebit requests
 func (api *Activity) CompensateDebit(ctx context.Context, params CompensateDebitActivityParams) (*CompensateDebitActivityResults, error) {
-	const op = "activity.Activity.CompensateDebit"
-
-	// Get activity info for logging
-	activityInfo := activity.GetInfo(ctx)
-
-	logger := api.logger.WithFields(logrus.Fields{
-		"[op]":                    op,
-		"activity_id":             activityInfo.ActivityID,
-		"activity_type":           activityInfo.ActivityType.Name,
-		"workflow_id":             params.WorkflowID,
-		"run_id":                  params.RunID,
-		"transfer_id":             params.TransferID,
-		"account_id":              params.AccountID,
-		"original_transaction_id": params.OriginalTransactionID,
-	})
-
-	logger.WithField("message", "Starting CompensateDebit activity").Info()
-
-	// PERFORMANCE OPTIMIZATION: Record heartbeat for long-running activity monitoring
-	activity.RecordHeartbeat(ctx, "CompensateDebit_started")
-
-	// FAILURE SIMULATION: Check if we should inject a failure
-	if err := api.service.SimulateFailure(ctx, "CompensateDebit", params.AccountID); err != nil {
-		logger.WithError(err).Warn("🚨 Transaction failure simulation triggered")
-		return nil, err
-	}
+	logger := api.activityLogger(ctx).WithField("original_transaction_id", params.OriginalTransactionID)
 
 	// PERFORMANCE OPTIMIZATION: Record heartbeat before parsing
 	activity.RecordHeartbeat(ctx, "CompensateDebit_parsing")
 
-	// Parse account ID
-	accountID, err := uuid.Parse(params.AccountID)
-	if err != nil {
-		err = fmt.Errorf("invalid account_id format: %w", err)
-
-		logger.WithError(err).Error()
-
-		return nil, err
-	}
+	// The account is an account ID or an account number
+	accountID, accountNumber := parseAccount(params.AccountID)
 
 	// Parse original transaction ID
 	originalTransactionID, err := uuid.Parse(params.OriginalTransactionID)
@@ -103,7 +70,8 @@ func (api *Activity) CompensateDebit(ctx context.Context, params CompensateDebit
 	// Convert to service parameters
 	serviceParams := service.CompensateDebitParams{
 		OriginalTransactionID: &originalTransactionID,
-		AccountID:             &accountID,
+		AccountID:             accountID,
+		AccountNumber:         accountNumber,
 		Amount:                params.Amount,
 		Currency:              params.Currency,
 		Description:           &params.CompensationReason,
@@ -116,7 +84,7 @@ func (api *Activity) CompensateDebit(ctx context.Context, params CompensateDebit
 			"transfer_id": params.TransferID,
 			"workflow_id": params.WorkflowID,
 			"run_id":      params.RunID,
-			"activity_id": activityInfo.ActivityID,
+			"activity_id": activity.GetInfo(ctx).ActivityID,
 		},
 	}
 
@@ -130,7 +98,7 @@ func (api *Activity) CompensateDebit(ctx context.Context, params CompensateDebit
 
 		logger.WithError(err).Error()
 
-		return nil, err
+		return nil, activityError(err)
 	}
 
 	// PERFORMANCE OPTIMIZATION: Record heartbeat after service completion
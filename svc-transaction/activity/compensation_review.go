@@ -0,0 +1,72 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+
+	"svc-transaction/service"
+)
+
+// ListCompensationsForReviewActivityParams defines parameters for the ListCompensationsForReview activity
+type ListCompensationsForReviewActivityParams struct {
+	BatchSize int32 `json:"batch_size"`
+}
+
+// ListCompensationsForReviewActivityResults defines results from the ListCompensationsForReview activity
+type ListCompensationsForReviewActivityResults struct {
+	WorkflowIDs []string `json:"workflow_ids"`
+}
+
+// ReviewCompensationActivityParams defines parameters for the ReviewCompensation activity
+type ReviewCompensationActivityParams struct {
+	WorkflowID string `json:"workflow_id"`
+}
+
+// ListCompensationsForReview is the Temporal activity that lists the compensations left pending by their workflow
+func (api *Activity) ListCompensationsForReview(ctx context.Context, params ListCompensationsForReviewActivityParams) (*ListCompensationsForReviewActivityResults, error) {
+	logger := api.activityLogger(ctx).WithField("batch_size", params.BatchSize)
+
+	workflowIDs, err := api.service.ListCompensationsForReview(ctx, params.BatchSize)
+	if err != nil {
+		err = fmt.Errorf("list compensations for review failed: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("pending", len(workflowIDs)).Info()
+
+	return &ListCompensationsForReviewActivityResults{WorkflowIDs: workflowIDs}, nil
+}
+
+// ReviewCompensation is the Temporal activity that retries or escalates one pending compensation
+func (api *Activity) ReviewCompensation(ctx context.Context, params ReviewCompensationActivityParams) (*service.CompensationReviewResult, error) {
+	logger := api.activityLogger(ctx).WithField("workflow_id", params.WorkflowID)
+
+	result, err := api.service.ReviewCompensation(ctx, params.WorkflowID)
+	if err != nil {
+		err = fmt.Errorf("review compensation failed: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("outcome", result.Outcome).Info()
+
+	return result, nil
+}
+
+// RecordCompensationReviewReport is the Temporal activity that emits the summary of a compensation review
+func (api *Activity) RecordCompensationReviewReport(ctx context.Context, report service.CompensationReviewReport) error {
+	if err := api.service.RecordCompensationReviewReport(ctx, report); err != nil {
+		err = fmt.Errorf("record compensation review report failed: %w", err)
+
+		api.activityLogger(ctx).WithField("business_date", report.BusinessDate).WithError(err).Error()
+
+		return err
+	}
+
+	return nil
+}
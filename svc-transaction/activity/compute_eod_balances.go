@@ -0,0 +1,45 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"svc-transaction/service"
+)
+
+// ComputeEODBalancesActivityParams defines parameters for the ComputeEODBalances activity
+type ComputeEODBalancesActivityParams struct {
+	BusinessDate time.Time `json:"business_date"`
+}
+
+// ComputeEODBalancesActivityResults defines results from the ComputeEODBalances activity
+type ComputeEODBalancesActivityResults struct {
+	BusinessDate string `json:"business_date"`
+	Accounts     int64  `json:"accounts"`
+}
+
+// ComputeEODBalances is the Temporal activity that stores the closing balances of one business day
+func (api *Activity) ComputeEODBalances(ctx context.Context, params ComputeEODBalancesActivityParams) (*ComputeEODBalancesActivityResults, error) {
+	logger := api.activityLogger(ctx).WithField("business_date", params.BusinessDate)
+
+	result, err := api.service.ComputeEODBalances(ctx, service.ComputeEODBalancesParams{
+		BusinessDate: params.BusinessDate,
+	})
+	if err != nil {
+		err = fmt.Errorf("compute end-of-day balances failed: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, activityError(err)
+	}
+
+	activityResult := &ComputeEODBalancesActivityResults{
+		BusinessDate: result.BusinessDate,
+		Accounts:     result.Accounts,
+	}
+
+	logger.WithField("result", fmt.Sprintf("%+v", activityResult)).Info()
+
+	return activityResult, nil
+}
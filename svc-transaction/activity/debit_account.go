@@ -6,9 +6,7 @@ import (
 
 	"svc-transaction/service"
 
-	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
-	"github.com/sirupsen/logrus"
 	"go.temporal.io/sdk/activity"
 )
 
@@ -47,48 +45,18 @@ type DebitAccountActivityResults struct {
 
 // DebitAccount is the Temporal activity that handles DebitAccount requests
 func (api *Activity) DebitAccount(ctx context.Context, params DebitAccountActivityParams) (*DebitAccountActivityResults, error) {
-	const op = "activity.Activity.DebitAccount"
-
-	// Get activity info for logging
-	activityInfo := activity.GetInfo(ctx)
-
-	logger := api.logger.WithFields(logrus.Fields{
-		"[op]":          op,
-		"activity_id":   activityInfo.ActivityID,
-		"activity_type": activityInfo.ActivityType.Name,
-		"workflow_id":   params.WorkflowID,
-		"run_id":        params.RunID,
-		"transfer_id":   params.TransferID,
-		"account_id":    params.AccountID,
-	})
-
-	logger.WithField("message", "Starting DebitAccount activity").Info()
-
-	// PERFORMANCE OPTIMIZATION: Record heartbeat for long-running activity monitoring
-	activity.RecordHeartbeat(ctx, "DebitAccount_started")
-
-	// FAILURE SIMULATION: Check if we should inject a failure
-	if err := api.service.SimulateFailure(ctx, "DebitAccount", params.AccountID); err != nil {
-		logger.WithError(err).Warn("🚨 Transaction failure simulation triggered")
-		return nil, err
-	}
+	logger := api.activityLogger(ctx)
 
 	// PERFORMANCE OPTIMIZATION: Record heartbeat before parsing
 	activity.RecordHeartbeat(ctx, "DebitAccount_parsing")
 
-	// Parse account ID
-	accountID, err := uuid.Parse(params.AccountID)
-	if err != nil {
-		err = fmt.Errorf("invalid account_id format: %w", err)
-
-		logger.WithError(err).Error()
-
-		return nil, err
-	}
+	// The account is an account ID or an account number
+	accountID, accountNumber := parseAccount(params.AccountID)
 
 	// Convert to service parameters
 	serviceParams := service.DebitAccountParams{
-		AccountID:      &accountID,
+		AccountID:      accountID,
+		AccountNumber:  accountNumber,
 		Amount:         params.Amount,
 		Currency:       params.Currency,
 		Description:    &params.Description,
@@ -98,7 +66,7 @@ func (api *Activity) DebitAccount(ctx context.Context, params DebitAccountActivi
 			"transfer_id": params.TransferID,
 			"workflow_id": params.WorkflowID,
 			"run_id":      params.RunID,
-			"activity_id": activityInfo.ActivityID,
+			"activity_id": activity.GetInfo(ctx).ActivityID,
 		},
 	}
 
@@ -112,7 +80,7 @@ func (api *Activity) DebitAccount(ctx context.Context, params DebitAccountActivi
 
 		logger.WithError(err).Error()
 
-		return nil, err
+		return nil, activityError(err)
 	}
 
 	// PERFORMANCE OPTIMIZATION: Record heartbeat after service completion
@@ -1,10 +1,18 @@
 package activity
 
 import (
+	"io"
 	"testing"
 
+	"svc-transaction/service"
+	"svc-transaction/store/memory"
+
 	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
 )
 
 func TestDebitAccountActivityParams(t *testing.T) {
@@ -64,3 +72,68 @@ func TestDebitAccountActivityResults(t *testing.T) {
 	assert.Equal(t, "2023-01-01T00:00:00Z", results.CreatedAt)
 	assert.Equal(t, "2023-01-01T00:00:01Z", results.CompletedAt)
 }
+
+func TestTransferActivitiesAcceptAccountNumbers(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	api := NewActivity(logger, service.NewService(logger, memory.NewStore(logger, true)))
+
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestActivityEnvironment()
+	env.RegisterActivity(api.DebitAccount)
+	env.RegisterActivity(api.CreditAccount)
+	env.RegisterActivity(api.CompensateDebit)
+
+	// The gateway and the demo scenarios start transfers with account numbers rather than account IDs
+	value, err := env.ExecuteActivity(api.DebitAccount, DebitAccountActivityParams{
+		AccountID:      "ACC001",
+		Amount:         decimal.RequireFromString("100"),
+		Currency:       "USD",
+		IdempotencyKey: "transfer-1-debit",
+		TransferID:     "transfer-1",
+	})
+	require.NoError(t, err)
+	var debit DebitAccountActivityResults
+	require.NoError(t, value.Get(&debit))
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440001", debit.AccountID)
+	assert.Equal(t, "4900", debit.NewBalance.String())
+
+	value, err = env.ExecuteActivity(api.CreditAccount, CreditAccountActivityParams{
+		AccountID:      "ACC002",
+		Amount:         decimal.RequireFromString("100"),
+		Currency:       "USD",
+		IdempotencyKey: "transfer-1-credit",
+		TransferID:     "transfer-1",
+	})
+	require.NoError(t, err)
+	var credit CreditAccountActivityResults
+	require.NoError(t, value.Get(&credit))
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440002", credit.AccountID)
+	assert.Equal(t, "10100", credit.NewBalance.String())
+
+	value, err = env.ExecuteActivity(api.CompensateDebit, CompensateDebitActivityParams{
+		OriginalTransactionID: debit.TransactionID,
+		AccountID:             "ACC001",
+		Amount:                decimal.RequireFromString("100"),
+		Currency:              "USD",
+		CompensationReason:    "credit failed",
+		IdempotencyKey:        "transfer-1-compensate",
+		TransferID:            "transfer-1",
+	})
+	require.NoError(t, err)
+	var compensation CompensateDebitActivityResults
+	require.NoError(t, value.Get(&compensation))
+	assert.Equal(t, "5000", compensation.NewBalance.String())
+
+	// An unknown account number is a business failure the workflow does not retry
+	_, err = env.ExecuteActivity(api.DebitAccount, DebitAccountActivityParams{
+		AccountID:      "ACC404",
+		Amount:         decimal.RequireFromString("100"),
+		Currency:       "USD",
+		IdempotencyKey: "transfer-2-debit",
+	})
+	var applicationErr *temporal.ApplicationError
+	require.ErrorAs(t, err, &applicationErr)
+	assert.Equal(t, ErrorTypeAccountNotFound, applicationErr.Type())
+}
@@ -0,0 +1,58 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"svc-transaction/service"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ExportLedgerSnapshotActivityParams defines parameters for the ExportLedgerSnapshot activity
+type ExportLedgerSnapshotActivityParams struct {
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Format    string    `json:"format"`
+	BatchSize int32     `json:"batch_size"`
+}
+
+// ExportLedgerSnapshotActivityResults defines results from the ExportLedgerSnapshot activity
+type ExportLedgerSnapshotActivityResults struct {
+	Format string                     `json:"format"`
+	Files  []service.LedgerExportFile `json:"files"`
+}
+
+// ExportLedgerSnapshot is the Temporal activity that writes the transactions and balance history of a date range
+// to the export storage
+func (api *Activity) ExportLedgerSnapshot(ctx context.Context, params ExportLedgerSnapshotActivityParams) (*ExportLedgerSnapshotActivityResults, error) {
+	logger := api.activityLogger(ctx).WithFields(logrus.Fields{
+		"from":   params.From,
+		"to":     params.To,
+		"format": params.Format,
+	})
+
+	result, err := api.service.ExportLedgerSnapshot(ctx, service.ExportLedgerSnapshotParams{
+		From:      params.From,
+		To:        params.To,
+		Format:    params.Format,
+		BatchSize: params.BatchSize,
+	})
+	if err != nil {
+		err = fmt.Errorf("export ledger snapshot failed: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, activityError(err)
+	}
+
+	activityResult := &ExportLedgerSnapshotActivityResults{
+		Format: result.Format,
+		Files:  result.Files,
+	}
+
+	logger.WithField("result", fmt.Sprintf("%+v", activityResult)).Info()
+
+	return activityResult, nil
+}
@@ -0,0 +1,135 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"svc-transaction/service"
+
+	"github.com/shopspring/decimal"
+	"go.temporal.io/sdk/activity"
+)
+
+// SettleExternalTransferActivityParams defines parameters for the SettleExternalTransfer activity
+// This matches the structure expected by the workflow
+type SettleExternalTransferActivityParams struct {
+	SettlementID       string          `json:"settlement_id"`
+	TransferID         string          `json:"transfer_id"`
+	BeneficiaryAccount string          `json:"beneficiary_account"`
+	Amount             decimal.Decimal `json:"amount"`
+	Currency           string          `json:"currency"`
+	CallbackURL        string          `json:"callback_url,omitempty"` // Completes the activity asynchronously through this URL
+	WorkflowID         string          `json:"workflow_id"`
+	RunID              string          `json:"run_id"`
+}
+
+// RecallExternalSettlementActivityParams defines parameters for the RecallExternalSettlement activity
+// This matches the structure expected by the workflow
+type RecallExternalSettlementActivityParams struct {
+	SettlementID string `json:"settlement_id"`
+	TransferID   string `json:"transfer_id"`
+	Reason       string `json:"reason"`
+	WorkflowID   string `json:"workflow_id"`
+	RunID        string `json:"run_id"`
+}
+
+// ExternalSettlementActivityResults defines results from the external settlement activities
+// This matches the structure expected by the workflow
+type ExternalSettlementActivityResults struct {
+	SettlementID string `json:"settlement_id"`
+	Status       string `json:"status"` // settled, recalled, recall_refused or not_found
+	Reason       string `json:"reason,omitempty"`
+	SettledAt    string `json:"settled_at,omitempty"`
+	RecalledAt   string `json:"recalled_at,omitempty"`
+}
+
+// SettleExternalTransfer is the Temporal activity that settles a transfer with the partner bank. It completes once
+// the partner has settled; pending settlements and outages are retried, rejections fail the step at once.
+//
+// With a callback URL the activity is completed asynchronously instead: its task token goes to the partner with
+// the settlement, and a settlement left pending returns activity.ErrResultPending. The partner posts the token
+// back to the callback URL once it settles, and FlowEngine completes the activity with the Temporal client.
+func (api *Activity) SettleExternalTransfer(ctx context.Context, params SettleExternalTransferActivityParams) (*ExternalSettlementActivityResults, error) {
+	logger := api.activityLogger(ctx).WithField("settlement_id", params.SettlementID)
+
+	activity.RecordHeartbeat(ctx, "SettleExternalTransfer_service_call")
+
+	var callbackToken []byte
+	if params.CallbackURL != "" {
+		callbackToken = activity.GetInfo(ctx).TaskToken
+	}
+
+	result, err := api.service.SettleExternalTransfer(ctx, service.SettleExternalTransferParams{
+		SettlementID:       params.SettlementID,
+		TransferID:         params.TransferID,
+		BeneficiaryAccount: params.BeneficiaryAccount,
+		Amount:             params.Amount,
+		Currency:           params.Currency,
+		CallbackURL:        params.CallbackURL,
+		CallbackToken:      callbackToken,
+	})
+	if err != nil {
+		err = fmt.Errorf("settle external transfer failed: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, activityError(err)
+	}
+
+	if result.Status == service.ExternalSettlementStatusPending {
+		logger.WithField("callback_url", params.CallbackURL).Info("Awaiting partner callback to complete the activity")
+
+		return nil, activity.ErrResultPending
+	}
+
+	activityResult := externalSettlementActivityResults(result)
+
+	logger.WithField("result", fmt.Sprintf("%+v", activityResult)).Info()
+
+	return activityResult, nil
+}
+
+// RecallExternalSettlement is the Temporal activity that asks the partner bank to return a settlement. The
+// partner's answer, including a refusal, is returned for the workflow to act on.
+func (api *Activity) RecallExternalSettlement(ctx context.Context, params RecallExternalSettlementActivityParams) (*ExternalSettlementActivityResults, error) {
+	logger := api.activityLogger(ctx).WithField("settlement_id", params.SettlementID)
+
+	activity.RecordHeartbeat(ctx, "RecallExternalSettlement_service_call")
+
+	result, err := api.service.RecallExternalSettlement(ctx, service.RecallExternalSettlementParams{
+		SettlementID: params.SettlementID,
+		Reason:       params.Reason,
+	})
+	if err != nil {
+		err = fmt.Errorf("recall external settlement failed: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, activityError(err)
+	}
+
+	activityResult := externalSettlementActivityResults(result)
+
+	logger.WithField("result", fmt.Sprintf("%+v", activityResult)).Info()
+
+	return activityResult, nil
+}
+
+// externalSettlementActivityResults converts the service results to the activity result format
+func externalSettlementActivityResults(result *service.ExternalSettlementResults) *ExternalSettlementActivityResults {
+	activityResult := &ExternalSettlementActivityResults{
+		SettlementID: result.SettlementID,
+		Status:       result.Status,
+		Reason:       result.Reason,
+	}
+
+	if result.SettledAt != nil {
+		activityResult.SettledAt = result.SettledAt.Format(time.RFC3339)
+	}
+	if result.RecalledAt != nil {
+		activityResult.RecalledAt = result.RecalledAt.Format(time.RFC3339)
+	}
+
+	return activityResult
+}
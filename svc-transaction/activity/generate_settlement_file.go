@@ -0,0 +1,68 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"svc-transaction/service"
+
+	"go.temporal.io/sdk/activity"
+)
+
+// GenerateSettlementFileActivityParams defines parameters for the GenerateSettlementFile activity
+type GenerateSettlementFileActivityParams struct {
+	Format       string `json:"format"`
+	MaxTransfers int    `json:"max_transfers"`
+}
+
+// GenerateSettlementFileActivityResults defines results from the GenerateSettlementFile activity.
+// Generated is false when there were no completed transfers waiting for settlement.
+type GenerateSettlementFileActivityResults struct {
+	Generated        bool   `json:"generated"`
+	SettlementFileID string `json:"settlement_file_id,omitempty"`
+	FileReference    string `json:"file_reference,omitempty"`
+	Format           string `json:"format,omitempty"`
+	TransferCount    int32  `json:"transfer_count"`
+	ControlSum       string `json:"control_sum,omitempty"`
+	Checksum         string `json:"checksum,omitempty"`
+}
+
+// GenerateSettlementFile is the Temporal activity that batches completed transfers into a settlement file
+func (api *Activity) GenerateSettlementFile(ctx context.Context, params GenerateSettlementFileActivityParams) (*GenerateSettlementFileActivityResults, error) {
+	logger := api.activityLogger(ctx).WithField("format", params.Format)
+
+	result, err := api.service.GenerateSettlementFile(ctx, service.GenerateSettlementFileParams{
+		Format:       params.Format,
+		MaxTransfers: params.MaxTransfers,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrNoTransfersToSettle) {
+			logger.Info("No transfers to settle")
+
+			return &GenerateSettlementFileActivityResults{Generated: false}, nil
+		}
+
+		err = fmt.Errorf("generate settlement file failed: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	activity.RecordHeartbeat(ctx, "GenerateSettlementFile_completed")
+
+	activityResult := &GenerateSettlementFileActivityResults{
+		Generated:        true,
+		SettlementFileID: result.ID.String(),
+		FileReference:    result.FileReference,
+		Format:           result.Format,
+		TransferCount:    result.TransferCount,
+		ControlSum:       result.ControlSum.String(),
+		Checksum:         result.Checksum,
+	}
+
+	logger.WithField("result", fmt.Sprintf("%+v", activityResult)).Info()
+
+	return activityResult, nil
+}
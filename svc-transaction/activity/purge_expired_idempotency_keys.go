@@ -0,0 +1,34 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+)
+
+// PurgeExpiredIdempotencyKeysActivityParams defines parameters for the PurgeExpiredIdempotencyKeys activity
+type PurgeExpiredIdempotencyKeysActivityParams struct {
+	BatchSize int32 `json:"batch_size"`
+}
+
+// PurgeExpiredIdempotencyKeysActivityResults defines results from the PurgeExpiredIdempotencyKeys activity
+type PurgeExpiredIdempotencyKeysActivityResults struct {
+	Purged int64 `json:"purged"`
+}
+
+// PurgeExpiredIdempotencyKeys is the Temporal activity that deletes expired keys from the idempotency key registry
+func (api *Activity) PurgeExpiredIdempotencyKeys(ctx context.Context, params PurgeExpiredIdempotencyKeysActivityParams) (*PurgeExpiredIdempotencyKeysActivityResults, error) {
+	logger := api.activityLogger(ctx).WithField("batch_size", params.BatchSize)
+
+	purged, err := api.service.PurgeExpiredIdempotencyKeys(ctx, params.BatchSize)
+	if err != nil {
+		err = fmt.Errorf("purge expired idempotency keys failed: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("purged", purged).Info()
+
+	return &PurgeExpiredIdempotencyKeysActivityResults{Purged: purged}, nil
+}
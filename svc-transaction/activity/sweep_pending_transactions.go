@@ -0,0 +1,47 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+)
+
+// SweepPendingTransactionsActivityParams defines parameters for the SweepPendingTransactions activity
+type SweepPendingTransactionsActivityParams struct {
+	BatchSize int32 `json:"batch_size"`
+}
+
+// SweepPendingTransactionsActivityResults defines results from the SweepPendingTransactions activity.
+// Skipped counts transactions resolved by their own flow while the sweep was running.
+type SweepPendingTransactionsActivityResults struct {
+	Examined  int `json:"examined"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+	Errors    int `json:"errors"`
+}
+
+// SweepPendingTransactions is the Temporal activity that completes or fails transactions stuck in pending
+func (api *Activity) SweepPendingTransactions(ctx context.Context, params SweepPendingTransactionsActivityParams) (*SweepPendingTransactionsActivityResults, error) {
+	logger := api.activityLogger(ctx).WithField("batch_size", params.BatchSize)
+
+	result, err := api.service.SweepPendingTransactions(ctx, params.BatchSize)
+	if err != nil {
+		err = fmt.Errorf("sweep pending transactions failed: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	activityResult := &SweepPendingTransactionsActivityResults{
+		Examined:  result.Examined,
+		Completed: result.Completed,
+		Failed:    result.Failed,
+		Skipped:   result.Skipped,
+		Errors:    result.Errors,
+	}
+
+	logger.WithField("result", fmt.Sprintf("%+v", activityResult)).Info()
+
+	return activityResult, nil
+}
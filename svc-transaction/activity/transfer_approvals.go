@@ -0,0 +1,30 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+
+	"svc-transaction/service"
+)
+
+// RecordTransferApprovalActivityResults defines results from the RecordTransferApproval activity
+type RecordTransferApprovalActivityResults struct {
+	Recorded bool `json:"recorded"` // False when the approval had been recorded by an earlier attempt
+}
+
+// RecordTransferApproval is the Temporal activity that stores an approval accepted by a transfer workflow held for
+// approval in core.transfer_approvals, so who approved a transfer and when can be audited after the workflow is gone.
+func (api *Activity) RecordTransferApproval(ctx context.Context, params service.RecordTransferApprovalParams) (*RecordTransferApprovalActivityResults, error) {
+	logger := api.activityLogger(ctx).WithField("approved_by", params.ApprovedBy)
+
+	recorded, err := api.service.RecordTransferApproval(ctx, params)
+	if err != nil {
+		err = fmt.Errorf("record transfer approval failed: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, activityError(err)
+	}
+
+	return &RecordTransferApprovalActivityResults{Recorded: recorded}, nil
+}
@@ -0,0 +1,31 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+
+	"svc-transaction/service"
+)
+
+// RecordTransferEventActivityResults defines results from the RecordTransferEvent activity
+type RecordTransferEventActivityResults struct {
+	Recorded bool `json:"recorded"` // False when the event had been recorded by an earlier attempt
+}
+
+// RecordTransferEvent is the Temporal activity that stores a state change of a saga transfer in core.transfer_events.
+// The transfer workflow records its start, each saga step and its outcome, so the status survives Temporal outages
+// and history retention and can be reported on in SQL.
+func (api *Activity) RecordTransferEvent(ctx context.Context, params service.RecordTransferEventParams) (*RecordTransferEventActivityResults, error) {
+	logger := api.activityLogger(ctx).WithField("sequence_number", params.SequenceNumber)
+
+	recorded, err := api.service.RecordTransferEvent(ctx, params)
+	if err != nil {
+		err = fmt.Errorf("record transfer event failed: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, activityError(err)
+	}
+
+	return &RecordTransferEventActivityResults{Recorded: recorded}, nil
+}
@@ -0,0 +1,34 @@
+package external_bank_adapter
+
+import (
+	"svc-transaction/adapter/external_bank_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+)
+
+// Adapter is a wrapper around the grpc client of the partner bank (svc-external-bank)
+type Adapter struct {
+	serviceName string
+
+	logger *logrus.Logger
+
+	externalBankClient pb.ExternalBankClient
+}
+
+// NewAdapter creates a new grpc adapter
+func NewAdapter(
+	serviceName string,
+	logger *logrus.Logger,
+	cc *grpc.ClientConn,
+) *Adapter {
+	externalBankClient := pb.NewExternalBankClient(cc)
+
+	return &Adapter{
+		serviceName: serviceName,
+
+		logger: logger,
+
+		externalBankClient: externalBankClient,
+	}
+}
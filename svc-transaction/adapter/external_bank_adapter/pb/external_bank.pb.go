@@ -0,0 +1,655 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: external_bank.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Status of a settlement at the partner bank
+type SettlementStatus int32
+
+const (
+	SettlementStatus_SETTLEMENT_STATUS_UNSPECIFIED    SettlementStatus = 0
+	SettlementStatus_SETTLEMENT_STATUS_PENDING        SettlementStatus = 1 // Accepted, not settled yet
+	SettlementStatus_SETTLEMENT_STATUS_SETTLED        SettlementStatus = 2
+	SettlementStatus_SETTLEMENT_STATUS_REJECTED       SettlementStatus = 3 // Refused by the partner, nothing was settled
+	SettlementStatus_SETTLEMENT_STATUS_RECALLED       SettlementStatus = 4 // Reversed by the partner after a recall
+	SettlementStatus_SETTLEMENT_STATUS_RECALL_REFUSED SettlementStatus = 5 // Settled, and the partner refused to reverse it
+)
+
+// Enum value maps for SettlementStatus.
+var (
+	SettlementStatus_name = map[int32]string{
+		0: "SETTLEMENT_STATUS_UNSPECIFIED",
+		1: "SETTLEMENT_STATUS_PENDING",
+		2: "SETTLEMENT_STATUS_SETTLED",
+		3: "SETTLEMENT_STATUS_REJECTED",
+		4: "SETTLEMENT_STATUS_RECALLED",
+		5: "SETTLEMENT_STATUS_RECALL_REFUSED",
+	}
+	SettlementStatus_value = map[string]int32{
+		"SETTLEMENT_STATUS_UNSPECIFIED":    0,
+		"SETTLEMENT_STATUS_PENDING":        1,
+		"SETTLEMENT_STATUS_SETTLED":        2,
+		"SETTLEMENT_STATUS_REJECTED":       3,
+		"SETTLEMENT_STATUS_RECALLED":       4,
+		"SETTLEMENT_STATUS_RECALL_REFUSED": 5,
+	}
+)
+
+func (x SettlementStatus) Enum() *SettlementStatus {
+	p := new(SettlementStatus)
+	*p = x
+	return p
+}
+
+func (x SettlementStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SettlementStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_external_bank_proto_enumTypes[0].Descriptor()
+}
+
+func (SettlementStatus) Type() protoreflect.EnumType {
+	return &file_external_bank_proto_enumTypes[0]
+}
+
+func (x SettlementStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SettlementStatus.Descriptor instead.
+func (SettlementStatus) EnumDescriptor() ([]byte, []int) {
+	return file_external_bank_proto_rawDescGZIP(), []int{0}
+}
+
+// Settlement message
+type Settlement struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	SettlementId       string                 `protobuf:"bytes,1,opt,name=settlement_id,json=settlementId,proto3" json:"settlement_id,omitempty"`
+	TransferId         string                 `protobuf:"bytes,2,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	BeneficiaryAccount string                 `protobuf:"bytes,3,opt,name=beneficiary_account,json=beneficiaryAccount,proto3" json:"beneficiary_account,omitempty"`
+	Amount             string                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"` // Decimal string, e.g. "100.50"
+	Currency           string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status             SettlementStatus       `protobuf:"varint,6,opt,name=status,proto3,enum=externalbank.SettlementStatus" json:"status,omitempty"`
+	Reason             string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"` // Why the partner rejected the settlement or refused its recall
+	SubmittedAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	SettledAt          *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=settled_at,json=settledAt,proto3" json:"settled_at,omitempty"`
+	RecalledAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=recalled_at,json=recalledAt,proto3" json:"recalled_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Settlement) Reset() {
+	*x = Settlement{}
+	mi := &file_external_bank_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Settlement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Settlement) ProtoMessage() {}
+
+func (x *Settlement) ProtoReflect() protoreflect.Message {
+	mi := &file_external_bank_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Settlement.ProtoReflect.Descriptor instead.
+func (*Settlement) Descriptor() ([]byte, []int) {
+	return file_external_bank_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Settlement) GetSettlementId() string {
+	if x != nil {
+		return x.SettlementId
+	}
+	return ""
+}
+
+func (x *Settlement) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *Settlement) GetBeneficiaryAccount() string {
+	if x != nil {
+		return x.BeneficiaryAccount
+	}
+	return ""
+}
+
+func (x *Settlement) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Settlement) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Settlement) GetStatus() SettlementStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SettlementStatus_SETTLEMENT_STATUS_UNSPECIFIED
+}
+
+func (x *Settlement) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Settlement) GetSubmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return nil
+}
+
+func (x *Settlement) GetSettledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SettledAt
+	}
+	return nil
+}
+
+func (x *Settlement) GetRecalledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecalledAt
+	}
+	return nil
+}
+
+// Submit settlement request message
+type SubmitSettlementRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	SettlementId       string                 `protobuf:"bytes,1,opt,name=settlement_id,json=settlementId,proto3" json:"settlement_id,omitempty"` // Chosen by the caller; resubmitting it returns the existing settlement
+	TransferId         string                 `protobuf:"bytes,2,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	BeneficiaryAccount string                 `protobuf:"bytes,3,opt,name=beneficiary_account,json=beneficiaryAccount,proto3" json:"beneficiary_account,omitempty"`
+	Amount             string                 `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"` // Decimal string, e.g. "100.50"
+	Currency           string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	CallbackUrl        string                 `protobuf:"bytes,6,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`       // Receives the settlement once it is no longer pending; empty to poll instead
+	CallbackToken      []byte                 `protobuf:"bytes,7,opt,name=callback_token,json=callbackToken,proto3" json:"callback_token,omitempty"` // Echoed back in the callback so the caller can match it to its waiting request
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *SubmitSettlementRequest) Reset() {
+	*x = SubmitSettlementRequest{}
+	mi := &file_external_bank_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitSettlementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitSettlementRequest) ProtoMessage() {}
+
+func (x *SubmitSettlementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_external_bank_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitSettlementRequest.ProtoReflect.Descriptor instead.
+func (*SubmitSettlementRequest) Descriptor() ([]byte, []int) {
+	return file_external_bank_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubmitSettlementRequest) GetSettlementId() string {
+	if x != nil {
+		return x.SettlementId
+	}
+	return ""
+}
+
+func (x *SubmitSettlementRequest) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *SubmitSettlementRequest) GetBeneficiaryAccount() string {
+	if x != nil {
+		return x.BeneficiaryAccount
+	}
+	return ""
+}
+
+func (x *SubmitSettlementRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *SubmitSettlementRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SubmitSettlementRequest) GetCallbackUrl() string {
+	if x != nil {
+		return x.CallbackUrl
+	}
+	return ""
+}
+
+func (x *SubmitSettlementRequest) GetCallbackToken() []byte {
+	if x != nil {
+		return x.CallbackToken
+	}
+	return nil
+}
+
+// Submit settlement response message
+type SubmitSettlementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settlement    *Settlement            `protobuf:"bytes,1,opt,name=settlement,proto3" json:"settlement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitSettlementResponse) Reset() {
+	*x = SubmitSettlementResponse{}
+	mi := &file_external_bank_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitSettlementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitSettlementResponse) ProtoMessage() {}
+
+func (x *SubmitSettlementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_external_bank_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitSettlementResponse.ProtoReflect.Descriptor instead.
+func (*SubmitSettlementResponse) Descriptor() ([]byte, []int) {
+	return file_external_bank_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubmitSettlementResponse) GetSettlement() *Settlement {
+	if x != nil {
+		return x.Settlement
+	}
+	return nil
+}
+
+// Get settlement request message
+type GetSettlementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SettlementId  string                 `protobuf:"bytes,1,opt,name=settlement_id,json=settlementId,proto3" json:"settlement_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSettlementRequest) Reset() {
+	*x = GetSettlementRequest{}
+	mi := &file_external_bank_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSettlementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSettlementRequest) ProtoMessage() {}
+
+func (x *GetSettlementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_external_bank_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSettlementRequest.ProtoReflect.Descriptor instead.
+func (*GetSettlementRequest) Descriptor() ([]byte, []int) {
+	return file_external_bank_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetSettlementRequest) GetSettlementId() string {
+	if x != nil {
+		return x.SettlementId
+	}
+	return ""
+}
+
+// Get settlement response message
+type GetSettlementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settlement    *Settlement            `protobuf:"bytes,1,opt,name=settlement,proto3" json:"settlement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSettlementResponse) Reset() {
+	*x = GetSettlementResponse{}
+	mi := &file_external_bank_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSettlementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSettlementResponse) ProtoMessage() {}
+
+func (x *GetSettlementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_external_bank_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSettlementResponse.ProtoReflect.Descriptor instead.
+func (*GetSettlementResponse) Descriptor() ([]byte, []int) {
+	return file_external_bank_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetSettlementResponse) GetSettlement() *Settlement {
+	if x != nil {
+		return x.Settlement
+	}
+	return nil
+}
+
+// Recall settlement request message
+type RecallSettlementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SettlementId  string                 `protobuf:"bytes,1,opt,name=settlement_id,json=settlementId,proto3" json:"settlement_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecallSettlementRequest) Reset() {
+	*x = RecallSettlementRequest{}
+	mi := &file_external_bank_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecallSettlementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecallSettlementRequest) ProtoMessage() {}
+
+func (x *RecallSettlementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_external_bank_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecallSettlementRequest.ProtoReflect.Descriptor instead.
+func (*RecallSettlementRequest) Descriptor() ([]byte, []int) {
+	return file_external_bank_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RecallSettlementRequest) GetSettlementId() string {
+	if x != nil {
+		return x.SettlementId
+	}
+	return ""
+}
+
+func (x *RecallSettlementRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Recall settlement response message
+type RecallSettlementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settlement    *Settlement            `protobuf:"bytes,1,opt,name=settlement,proto3" json:"settlement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecallSettlementResponse) Reset() {
+	*x = RecallSettlementResponse{}
+	mi := &file_external_bank_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecallSettlementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecallSettlementResponse) ProtoMessage() {}
+
+func (x *RecallSettlementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_external_bank_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecallSettlementResponse.ProtoReflect.Descriptor instead.
+func (*RecallSettlementResponse) Descriptor() ([]byte, []int) {
+	return file_external_bank_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RecallSettlementResponse) GetSettlement() *Settlement {
+	if x != nil {
+		return x.Settlement
+	}
+	return nil
+}
+
+var File_external_bank_proto protoreflect.FileDescriptor
+
+const file_external_bank_proto_rawDesc = "" +
+	"\n" +
+	"\x13external_bank.proto\x12\fexternalbank\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbe\x03\n" +
+	"\n" +
+	"Settlement\x12#\n" +
+	"\rsettlement_id\x18\x01 \x01(\tR\fsettlementId\x12\x1f\n" +
+	"\vtransfer_id\x18\x02 \x01(\tR\n" +
+	"transferId\x12/\n" +
+	"\x13beneficiary_account\x18\x03 \x01(\tR\x12beneficiaryAccount\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x126\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x1e.externalbank.SettlementStatusR\x06status\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12=\n" +
+	"\fsubmitted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vsubmittedAt\x129\n" +
+	"\n" +
+	"settled_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tsettledAt\x12;\n" +
+	"\vrecalled_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"recalledAt\"\x8e\x02\n" +
+	"\x17SubmitSettlementRequest\x12#\n" +
+	"\rsettlement_id\x18\x01 \x01(\tR\fsettlementId\x12\x1f\n" +
+	"\vtransfer_id\x18\x02 \x01(\tR\n" +
+	"transferId\x12/\n" +
+	"\x13beneficiary_account\x18\x03 \x01(\tR\x12beneficiaryAccount\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12!\n" +
+	"\fcallback_url\x18\x06 \x01(\tR\vcallbackUrl\x12%\n" +
+	"\x0ecallback_token\x18\a \x01(\fR\rcallbackToken\"T\n" +
+	"\x18SubmitSettlementResponse\x128\n" +
+	"\n" +
+	"settlement\x18\x01 \x01(\v2\x18.externalbank.SettlementR\n" +
+	"settlement\";\n" +
+	"\x14GetSettlementRequest\x12#\n" +
+	"\rsettlement_id\x18\x01 \x01(\tR\fsettlementId\"Q\n" +
+	"\x15GetSettlementResponse\x128\n" +
+	"\n" +
+	"settlement\x18\x01 \x01(\v2\x18.externalbank.SettlementR\n" +
+	"settlement\"V\n" +
+	"\x17RecallSettlementRequest\x12#\n" +
+	"\rsettlement_id\x18\x01 \x01(\tR\fsettlementId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"T\n" +
+	"\x18RecallSettlementResponse\x128\n" +
+	"\n" +
+	"settlement\x18\x01 \x01(\v2\x18.externalbank.SettlementR\n" +
+	"settlement*\xd9\x01\n" +
+	"\x10SettlementStatus\x12!\n" +
+	"\x1dSETTLEMENT_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19SETTLEMENT_STATUS_PENDING\x10\x01\x12\x1d\n" +
+	"\x19SETTLEMENT_STATUS_SETTLED\x10\x02\x12\x1e\n" +
+	"\x1aSETTLEMENT_STATUS_REJECTED\x10\x03\x12\x1e\n" +
+	"\x1aSETTLEMENT_STATUS_RECALLED\x10\x04\x12$\n" +
+	" SETTLEMENT_STATUS_RECALL_REFUSED\x10\x052\xae\x02\n" +
+	"\fExternalBank\x12a\n" +
+	"\x10SubmitSettlement\x12%.externalbank.SubmitSettlementRequest\x1a&.externalbank.SubmitSettlementResponse\x12X\n" +
+	"\rGetSettlement\x12\".externalbank.GetSettlementRequest\x1a#.externalbank.GetSettlementResponse\x12a\n" +
+	"\x10RecallSettlement\x12%.externalbank.RecallSettlementRequest\x1a&.externalbank.RecallSettlementResponseB\x06Z\x04./pbb\x06proto3"
+
+var (
+	file_external_bank_proto_rawDescOnce sync.Once
+	file_external_bank_proto_rawDescData []byte
+)
+
+func file_external_bank_proto_rawDescGZIP() []byte {
+	file_external_bank_proto_rawDescOnce.Do(func() {
+		file_external_bank_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_external_bank_proto_rawDesc), len(file_external_bank_proto_rawDesc)))
+	})
+	return file_external_bank_proto_rawDescData
+}
+
+var file_external_bank_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_external_bank_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_external_bank_proto_goTypes = []any{
+	(SettlementStatus)(0),            // 0: externalbank.SettlementStatus
+	(*Settlement)(nil),               // 1: externalbank.Settlement
+	(*SubmitSettlementRequest)(nil),  // 2: externalbank.SubmitSettlementRequest
+	(*SubmitSettlementResponse)(nil), // 3: externalbank.SubmitSettlementResponse
+	(*GetSettlementRequest)(nil),     // 4: externalbank.GetSettlementRequest
+	(*GetSettlementResponse)(nil),    // 5: externalbank.GetSettlementResponse
+	(*RecallSettlementRequest)(nil),  // 6: externalbank.RecallSettlementRequest
+	(*RecallSettlementResponse)(nil), // 7: externalbank.RecallSettlementResponse
+	(*timestamppb.Timestamp)(nil),    // 8: google.protobuf.Timestamp
+}
+var file_external_bank_proto_depIdxs = []int32{
+	0,  // 0: externalbank.Settlement.status:type_name -> externalbank.SettlementStatus
+	8,  // 1: externalbank.Settlement.submitted_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: externalbank.Settlement.settled_at:type_name -> google.protobuf.Timestamp
+	8,  // 3: externalbank.Settlement.recalled_at:type_name -> google.protobuf.Timestamp
+	1,  // 4: externalbank.SubmitSettlementResponse.settlement:type_name -> externalbank.Settlement
+	1,  // 5: externalbank.GetSettlementResponse.settlement:type_name -> externalbank.Settlement
+	1,  // 6: externalbank.RecallSettlementResponse.settlement:type_name -> externalbank.Settlement
+	2,  // 7: externalbank.ExternalBank.SubmitSettlement:input_type -> externalbank.SubmitSettlementRequest
+	4,  // 8: externalbank.ExternalBank.GetSettlement:input_type -> externalbank.GetSettlementRequest
+	6,  // 9: externalbank.ExternalBank.RecallSettlement:input_type -> externalbank.RecallSettlementRequest
+	3,  // 10: externalbank.ExternalBank.SubmitSettlement:output_type -> externalbank.SubmitSettlementResponse
+	5,  // 11: externalbank.ExternalBank.GetSettlement:output_type -> externalbank.GetSettlementResponse
+	7,  // 12: externalbank.ExternalBank.RecallSettlement:output_type -> externalbank.RecallSettlementResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_external_bank_proto_init() }
+func file_external_bank_proto_init() {
+	if File_external_bank_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_external_bank_proto_rawDesc), len(file_external_bank_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_external_bank_proto_goTypes,
+		DependencyIndexes: file_external_bank_proto_depIdxs,
+		EnumInfos:         file_external_bank_proto_enumTypes,
+		MessageInfos:      file_external_bank_proto_msgTypes,
+	}.Build()
+	File_external_bank_proto = out.File
+	file_external_bank_proto_goTypes = nil
+	file_external_bank_proto_depIdxs = nil
+}
@@ -0,0 +1,211 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: external_bank.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ExternalBank_SubmitSettlement_FullMethodName = "/externalbank.ExternalBank/SubmitSettlement"
+	ExternalBank_GetSettlement_FullMethodName    = "/externalbank.ExternalBank/GetSettlement"
+	ExternalBank_RecallSettlement_FullMethodName = "/externalbank.ExternalBank/RecallSettlement"
+)
+
+// ExternalBankClient is the client API for ExternalBank service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ExternalBank simulates a partner bank that settles outgoing transfers. It is deliberately slow and flaky,
+// and may refuse to recall a settlement, so the transfer saga must cope with a partner it does not control.
+type ExternalBankClient interface {
+	// SubmitSettlement asks the partner to settle a transfer; resubmitting the same settlement_id is idempotent.
+	// A pending settlement submitted with a callback_url is posted to it once it is settled.
+	SubmitSettlement(ctx context.Context, in *SubmitSettlementRequest, opts ...grpc.CallOption) (*SubmitSettlementResponse, error)
+	// GetSettlement reports the current status of a submitted settlement
+	GetSettlement(ctx context.Context, in *GetSettlementRequest, opts ...grpc.CallOption) (*GetSettlementResponse, error)
+	// RecallSettlement asks the partner to reverse a settlement; the partner may refuse
+	RecallSettlement(ctx context.Context, in *RecallSettlementRequest, opts ...grpc.CallOption) (*RecallSettlementResponse, error)
+}
+
+type externalBankClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExternalBankClient(cc grpc.ClientConnInterface) ExternalBankClient {
+	return &externalBankClient{cc}
+}
+
+func (c *externalBankClient) SubmitSettlement(ctx context.Context, in *SubmitSettlementRequest, opts ...grpc.CallOption) (*SubmitSettlementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitSettlementResponse)
+	err := c.cc.Invoke(ctx, ExternalBank_SubmitSettlement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *externalBankClient) GetSettlement(ctx context.Context, in *GetSettlementRequest, opts ...grpc.CallOption) (*GetSettlementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSettlementResponse)
+	err := c.cc.Invoke(ctx, ExternalBank_GetSettlement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *externalBankClient) RecallSettlement(ctx context.Context, in *RecallSettlementRequest, opts ...grpc.CallOption) (*RecallSettlementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecallSettlementResponse)
+	err := c.cc.Invoke(ctx, ExternalBank_RecallSettlement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExternalBankServer is the server API for ExternalBank service.
+// All implementations must embed UnimplementedExternalBankServer
+// for forward compatibility.
+//
+// ExternalBank simulates a partner bank that settles outgoing transfers. It is deliberately slow and flaky,
+// and may refuse to recall a settlement, so the transfer saga must cope with a partner it does not control.
+type ExternalBankServer interface {
+	// SubmitSettlement asks the partner to settle a transfer; resubmitting the same settlement_id is idempotent.
+	// A pending settlement submitted with a callback_url is posted to it once it is settled.
+	SubmitSettlement(context.Context, *SubmitSettlementRequest) (*SubmitSettlementResponse, error)
+	// GetSettlement reports the current status of a submitted settlement
+	GetSettlement(context.Context, *GetSettlementRequest) (*GetSettlementResponse, error)
+	// RecallSettlement asks the partner to reverse a settlement; the partner may refuse
+	RecallSettlement(context.Context, *RecallSettlementRequest) (*RecallSettlementResponse, error)
+	mustEmbedUnimplementedExternalBankServer()
+}
+
+// UnimplementedExternalBankServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExternalBankServer struct{}
+
+func (UnimplementedExternalBankServer) SubmitSettlement(context.Context, *SubmitSettlementRequest) (*SubmitSettlementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitSettlement not implemented")
+}
+func (UnimplementedExternalBankServer) GetSettlement(context.Context, *GetSettlementRequest) (*GetSettlementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSettlement not implemented")
+}
+func (UnimplementedExternalBankServer) RecallSettlement(context.Context, *RecallSettlementRequest) (*RecallSettlementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecallSettlement not implemented")
+}
+func (UnimplementedExternalBankServer) mustEmbedUnimplementedExternalBankServer() {}
+func (UnimplementedExternalBankServer) testEmbeddedByValue()                      {}
+
+// UnsafeExternalBankServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExternalBankServer will
+// result in compilation errors.
+type UnsafeExternalBankServer interface {
+	mustEmbedUnimplementedExternalBankServer()
+}
+
+func RegisterExternalBankServer(s grpc.ServiceRegistrar, srv ExternalBankServer) {
+	// If the following call pancis, it indicates UnimplementedExternalBankServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ExternalBank_ServiceDesc, srv)
+}
+
+func _ExternalBank_SubmitSettlement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitSettlementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalBankServer).SubmitSettlement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExternalBank_SubmitSettlement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalBankServer).SubmitSettlement(ctx, req.(*SubmitSettlementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExternalBank_GetSettlement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSettlementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalBankServer).GetSettlement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExternalBank_GetSettlement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalBankServer).GetSettlement(ctx, req.(*GetSettlementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExternalBank_RecallSettlement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecallSettlementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalBankServer).RecallSettlement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExternalBank_RecallSettlement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalBankServer).RecallSettlement(ctx, req.(*RecallSettlementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExternalBank_ServiceDesc is the grpc.ServiceDesc for ExternalBank service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExternalBank_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "externalbank.ExternalBank",
+	HandlerType: (*ExternalBankServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitSettlement",
+			Handler:    _ExternalBank_SubmitSettlement_Handler,
+		},
+		{
+			MethodName: "GetSettlement",
+			Handler:    _ExternalBank_GetSettlement_Handler,
+		},
+		{
+			MethodName: "RecallSettlement",
+			Handler:    _ExternalBank_RecallSettlement_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "external_bank.proto",
+}
@@ -0,0 +1,34 @@
+package external_bank_adapter
+
+import (
+	"context"
+	"fmt"
+
+	"svc-transaction/adapter/external_bank_adapter/pb"
+
+	"github.com/sirupsen/logrus"
+)
+
+func (adapter *Adapter) SubmitSettlement(ctx context.Context, request *pb.SubmitSettlementRequest) (response *pb.SubmitSettlementResponse, err error) {
+	const op = "external_bank_adapter.Adapter.SubmitSettlement"
+
+	logger := adapter.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+		"type":    fmt.Sprintf("%T", request),
+	})
+
+	logger.Info()
+
+	// Call service
+	response, err = adapter.externalBankClient.SubmitSettlement(ctx, request)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("response", fmt.Sprintf("%+v", response)).Info()
+
+	return response, nil
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"svc-transaction/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// StartAccountClosureRequest represents the request body for closing an account
+type StartAccountClosureRequest struct {
+	SweepAccountID      string `json:"sweep_account_id"`
+	DrainTimeoutSeconds int    `json:"drain_timeout_seconds,omitempty"` // Defaults to service.DefaultAccountClosureDrainTimeout
+	RequestedBy         string `json:"requested_by,omitempty"`
+}
+
+// StartAccountClosure handles POST /accounts/:id/closure
+func (api *Api) StartAccountClosure(ctx *fiber.Ctx) error {
+	const op = "api.Api.StartAccountClosure"
+
+	accountID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	var request StartAccountClosureRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	sweepAccountID, err := uuid.Parse(request.SweepAccountID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid sweep_account_id")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": accountID,
+	})
+
+	result, err := api.service.StartAccountClosure(ctx.Context(), service.StartAccountClosureParams{
+		AccountID:      accountID,
+		SweepAccountID: sweepAccountID,
+		DrainTimeout:   time.Duration(request.DrainTimeoutSeconds) * time.Second,
+		RequestedBy:    request.RequestedBy,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to start account closure")
+
+		switch {
+		case errors.Is(err, service.ErrAccountNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrAccountClosureRejected):
+			return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, service.ErrAccountClosureInProgress):
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to start account closure")
+	}
+
+	api.recordAdminAction(ctx, service.AdminAction{
+		Actor:        adminActor(ctx, request.RequestedBy),
+		Action:       service.AdminActionStartAccountClosure,
+		ResourceType: "account",
+		ResourceID:   accountID.String(),
+		After:        fiber.Map{"request": request, "workflow": result},
+	})
+
+	return ctx.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "Account closure started",
+		"data":    result,
+	})
+}
+
+// GetAccountClosureSteps handles GET /accounts/:id/closure
+func (api *Api) GetAccountClosureSteps(ctx *fiber.Ctx) error {
+	const op = "api.Api.GetAccountClosureSteps"
+
+	accountID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	steps, err := api.service.GetAccountClosureSteps(ctx.Context(), accountID)
+	if err != nil {
+		api.logger.WithFields(logrus.Fields{
+			"[op]":       op,
+			"account_id": accountID,
+		}).WithError(err).Error("Failed to get account closure steps")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve account closure steps")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Account closure steps retrieved successfully",
+		"data":    steps,
+	})
+}
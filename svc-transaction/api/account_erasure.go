@@ -0,0 +1,97 @@
+package api
+
+import (
+	"errors"
+
+	"svc-transaction/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// StartAccountErasureRequest represents the request body for erasing the personal data of an account
+type StartAccountErasureRequest struct {
+	RequestedBy string `json:"requested_by,omitempty"`
+}
+
+// StartAccountErasure handles POST /accounts/:id/erasure
+func (api *Api) StartAccountErasure(ctx *fiber.Ctx) error {
+	const op = "api.Api.StartAccountErasure"
+
+	accountID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	var request StartAccountErasureRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&request); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	result, err := api.service.StartAccountErasure(ctx.Context(), service.StartAccountErasureParams{
+		AccountID:   accountID,
+		RequestedBy: request.RequestedBy,
+	})
+	if err != nil {
+		api.logger.WithFields(logrus.Fields{
+			"[op]":       op,
+			"account_id": accountID,
+		}).WithError(err).Error("Failed to start account erasure")
+
+		switch {
+		case errors.Is(err, service.ErrAccountNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrAccountErasureRejected):
+			return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, service.ErrAccountErasureInProgress):
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to start account erasure")
+	}
+
+	api.recordAdminAction(ctx, service.AdminAction{
+		Actor:        adminActor(ctx, request.RequestedBy),
+		Action:       service.AdminActionStartAccountErasure,
+		ResourceType: "account",
+		ResourceID:   accountID.String(),
+		After:        fiber.Map{"request": request, "workflow": result},
+	})
+
+	return ctx.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "Account erasure started",
+		"data":    result,
+	})
+}
+
+// GetAccountErasureCertificate handles GET /accounts/:id/erasure
+func (api *Api) GetAccountErasureCertificate(ctx *fiber.Ctx) error {
+	const op = "api.Api.GetAccountErasureCertificate"
+
+	accountID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	certificate, err := api.service.GetAccountErasureCertificate(ctx.Context(), accountID)
+	if err != nil {
+		if errors.Is(err, service.ErrAccountErasureCertificateNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		api.logger.WithFields(logrus.Fields{
+			"[op]":       op,
+			"account_id": accountID,
+		}).WithError(err).Error("Failed to get account erasure certificate")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve account erasure certificate")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Account erasure certificate retrieved successfully",
+		"data":    certificate,
+	})
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+
+	"svc-transaction/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// AddAccountOwnerRequest represents the request body for adding an owner to an account
+type AddAccountOwnerRequest struct {
+	OwnerID     string `json:"owner_id"`
+	RequestedBy string `json:"requested_by,omitempty"`
+}
+
+// AddAccountOwner handles POST /accounts/:id/owners
+func (api *Api) AddAccountOwner(ctx *fiber.Ctx) error {
+	const op = "api.Api.AddAccountOwner"
+
+	accountID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	var request AddAccountOwnerRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": accountID,
+		"owner_id":   request.OwnerID,
+	})
+
+	result, err := api.service.AddAccountOwner(ctx.Context(), accountID, request.OwnerID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidAccountOwner):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrAccountNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrAccountOwnerExists):
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to add account owner")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to add account owner")
+	}
+
+	api.recordAdminAction(ctx, service.AdminAction{
+		Actor:        adminActor(ctx, request.RequestedBy),
+		Action:       service.AdminActionAddAccountOwner,
+		ResourceType: "account",
+		ResourceID:   accountID.String(),
+		After:        result,
+	})
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Account owner added successfully",
+		"data":    result,
+	})
+}
+
+// ListAccountOwners handles GET /accounts/:id/owners
+func (api *Api) ListAccountOwners(ctx *fiber.Ctx) error {
+	const op = "api.Api.ListAccountOwners"
+
+	accountID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	owners, err := api.service.ListAccountOwners(ctx.Context(), accountID)
+	if err != nil {
+		api.logger.WithFields(logrus.Fields{
+			"[op]":       op,
+			"account_id": accountID,
+		}).WithError(err).Error("Failed to list account owners")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve account owners")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Account owners retrieved successfully",
+		"data":    owners,
+	})
+}
+
+// RemoveAccountOwner handles DELETE /accounts/:id/owners/:owner_id
+func (api *Api) RemoveAccountOwner(ctx *fiber.Ctx) error {
+	const op = "api.Api.RemoveAccountOwner"
+
+	accountID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	ownerID := ctx.Params("owner_id")
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": accountID,
+		"owner_id":   ownerID,
+	})
+
+	if err := api.service.RemoveAccountOwner(ctx.Context(), accountID, ownerID); err != nil {
+		if errors.Is(err, service.ErrAccountOwnerNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to remove account owner")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to remove account owner")
+	}
+
+	api.recordAdminAction(ctx, service.AdminAction{
+		Actor:        adminActor(ctx, ""),
+		Action:       service.AdminActionRemoveAccountOwner,
+		ResourceType: "account",
+		ResourceID:   accountID.String(),
+		Before:       fiber.Map{"owner_id": ownerID},
+	})
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Account owner removed successfully",
+	})
+}
@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"svc-transaction/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// PostAdjustmentRequest represents the request body for requesting an adjustment of an account balance
+type PostAdjustmentRequest struct {
+	Amount      decimal.Decimal `json:"amount"` // Signed: negative takes from the balance
+	Currency    string          `json:"currency"`
+	Reason      string          `json:"reason"`
+	RequestedBy string          `json:"requested_by,omitempty"`
+}
+
+// ReviewAdjustmentRequest represents the request body for approving or rejecting an adjustment
+type ReviewAdjustmentRequest struct {
+	Note       string `json:"note,omitempty"` // Required to reject
+	ReviewedBy string `json:"reviewed_by,omitempty"`
+}
+
+// PostAdjustment handles POST /accounts/:id/adjustments. The adjustment is only applied once another operator
+// approves it, so it answers 202 with the pending adjustment.
+func (api *Api) PostAdjustment(ctx *fiber.Ctx) error {
+	const op = "api.Api.PostAdjustment"
+
+	accountID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid account ID")
+	}
+
+	var request PostAdjustmentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	actor := adminActor(ctx, request.RequestedBy)
+	if actor == anonymousActor {
+		return fiber.NewError(fiber.StatusBadRequest, "Adjustments need a named requester: set the X-Actor header or requested_by")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": accountID,
+		"actor":      actor,
+	})
+
+	result, err := api.service.PostAdjustment(ctx.Context(), service.PostAdjustmentParams{
+		AccountID: accountID,
+		Amount:    request.Amount,
+		Currency:  request.Currency,
+		Reason:    request.Reason,
+		Actor:     actor,
+	})
+	if err != nil {
+		return adjustmentError(ctx, logger, err, "Failed to post adjustment")
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "Adjustment awaiting approval",
+		"data":    result,
+	})
+}
+
+// ApproveAdjustment handles POST /adjustments/:id/approve
+func (api *Api) ApproveAdjustment(ctx *fiber.Ctx) error {
+	return api.reviewAdjustment(ctx, "api.Api.ApproveAdjustment", api.service.ApproveAdjustment, "approved")
+}
+
+// RejectAdjustment handles POST /adjustments/:id/reject
+func (api *Api) RejectAdjustment(ctx *fiber.Ctx) error {
+	return api.reviewAdjustment(ctx, "api.Api.RejectAdjustment", api.service.RejectAdjustment, "rejected")
+}
+
+// reviewAdjustment approves or rejects the adjustment of the request with review
+func (api *Api) reviewAdjustment(
+	ctx *fiber.Ctx,
+	op string,
+	review func(context.Context, service.ReviewAdjustmentParams) (*service.Adjustment, error),
+	outcome string,
+) error {
+	adjustmentID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid adjustment ID")
+	}
+
+	var request ReviewAdjustmentRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&request); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	actor := adminActor(ctx, request.ReviewedBy)
+	if actor == anonymousActor {
+		return fiber.NewError(fiber.StatusBadRequest, "Adjustments need a named reviewer: set the X-Actor header or reviewed_by")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":          op,
+		"adjustment_id": adjustmentID,
+		"actor":         actor,
+	})
+
+	result, err := review(ctx.Context(), service.ReviewAdjustmentParams{
+		ID:    adjustmentID,
+		Actor: actor,
+		Note:  request.Note,
+	})
+	if err != nil {
+		return adjustmentError(ctx, logger, err, "Failed to review adjustment")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Adjustment " + outcome,
+		"data":    result,
+	})
+}
+
+// GetAdjustment handles GET /adjustments/:id
+func (api *Api) GetAdjustment(ctx *fiber.Ctx) error {
+	const op = "api.Api.GetAdjustment"
+
+	adjustmentID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid adjustment ID")
+	}
+
+	result, err := api.service.GetAdjustment(ctx.Context(), adjustmentID)
+	if err != nil {
+		if errors.Is(err, service.ErrAdjustmentNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		api.logger.WithFields(logrus.Fields{
+			"[op]":          op,
+			"adjustment_id": adjustmentID,
+		}).WithError(err).Error("Failed to get adjustment")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve adjustment")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Adjustment retrieved successfully",
+		"data":    result,
+	})
+}
+
+// ListAdjustments handles GET /adjustments?status=&account_id=&limit=
+func (api *Api) ListAdjustments(ctx *fiber.Ctx) error {
+	const op = "api.Api.ListAdjustments"
+
+	filter := service.AdjustmentFilter{
+		Status: ctx.Query("status"),
+		Limit:  int32(ctx.QueryInt("limit", 0)),
+	}
+	if accountID := ctx.Query("account_id"); accountID != "" {
+		parsed, err := uuid.Parse(accountID)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid account_id")
+		}
+		filter.AccountID = parsed
+	}
+
+	results, err := api.service.ListAdjustments(ctx.Context(), filter)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAdjustment) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		api.logger.WithField("[op]", op).WithError(err).Error("Failed to list adjustments")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve adjustments")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Adjustments retrieved successfully",
+		"data":    results,
+	})
+}
+
+// adjustmentError answers the client errors of the adjustment calls, and logs the others before answering them
+// with 500 and message
+func adjustmentError(ctx *fiber.Ctx, logger *logrus.Entry, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrPrecisionExceeded):
+		return precisionExceeded(ctx, err)
+	case errors.Is(err, service.ErrInvalidAdjustment), errors.Is(err, service.ErrInvalidCurrency):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrSelfApproval):
+		return fiber.NewError(fiber.StatusForbidden, err.Error())
+	case errors.Is(err, service.ErrAccountNotFound), errors.Is(err, service.ErrAdjustmentNotFound):
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	case errors.Is(err, service.ErrAdjustmentReviewed), errors.Is(err, service.ErrAccountBlocked), errors.Is(err, service.ErrInsufficientFunds):
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	}
+
+	logger.WithError(err).Error(message)
+
+	return fiber.NewError(fiber.StatusInternalServerError, message)
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"svc-transaction/service"
+	"svc-transaction/store/sqlc"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// HeaderActor carries the identity of the operator making an admin call
+const HeaderActor = "X-Actor"
+
+// anonymousActor is recorded when an admin call names no actor
+const anonymousActor = "anonymous"
+
+// AdminAuditRecord represents an admin audit record
+type AdminAuditRecord struct {
+	ID           string          `json:"id"`
+	Actor        string          `json:"actor"`
+	Action       string          `json:"action"`
+	Service      string          `json:"service"`
+	ResourceType string          `json:"resource_type"`
+	ResourceID   *string         `json:"resource_id,omitempty"`
+	Before       json.RawMessage `json:"before,omitempty"`
+	After        json.RawMessage `json:"after,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// adminActor returns the actor of an admin call: the X-Actor header, else fallback (such as a requested_by
+// field), else "anonymous"
+func adminActor(ctx *fiber.Ctx, fallback string) string {
+	if actor := ctx.Get(HeaderActor); actor != "" {
+		return actor
+	}
+	if fallback != "" {
+		return fallback
+	}
+
+	return anonymousActor
+}
+
+// recordAdminAction records a state-changing admin call once it has succeeded. The call has already taken
+// effect, so a failure to record it is logged rather than returned.
+func (api *Api) recordAdminAction(ctx *fiber.Ctx, action service.AdminAction) {
+	if err := api.service.RecordAdminAction(ctx.Context(), action); err != nil {
+		api.logger.WithFields(logrus.Fields{
+			"[op]":        "api.Api.recordAdminAction",
+			"action":      action.Action,
+			"resource_id": action.ResourceID,
+		}).WithError(err).Error("Failed to record admin action")
+	}
+}
+
+// parseAdminAuditFilter reads the actor, action, resource_type, resource_id, from, to and limit query parameters.
+// from and to are RFC 3339 timestamps.
+func parseAdminAuditFilter(ctx *fiber.Ctx) (service.AdminAuditFilter, error) {
+	filter := service.AdminAuditFilter{
+		Actor:        ctx.Query("actor"),
+		Action:       ctx.Query("action"),
+		ResourceType: ctx.Query("resource_type"),
+		ResourceID:   ctx.Query("resource_id"),
+		Limit:        50, // Default limit
+	}
+
+	for name, target := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		value := ctx.Query(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fiber.NewError(fiber.StatusBadRequest, "Invalid "+name+" timestamp, expected RFC 3339")
+		}
+		*target = parsed
+	}
+
+	if limitParam := ctx.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.ParseInt(limitParam, 10, 32); err == nil && parsedLimit > 0 && parsedLimit <= 1000 {
+			filter.Limit = int32(parsedLimit)
+		}
+	}
+
+	return filter, nil
+}
+
+// newAdminAuditRecord converts a stored audit row into its response format
+func newAdminAuditRecord(record sqlc.CoreAdminAudit) AdminAuditRecord {
+	response := AdminAuditRecord{
+		ID:           record.ID.String(),
+		Actor:        record.Actor,
+		Action:       record.Action,
+		Service:      record.Service,
+		ResourceType: record.ResourceType,
+		Before:       record.BeforePayload,
+		After:        record.AfterPayload,
+		CreatedAt:    record.CreatedAt.Time,
+	}
+
+	if record.ResourceID.Valid {
+		response.ResourceID = &record.ResourceID.String
+	}
+
+	return response
+}
+
+// ListAdminAudits handles GET /admin-audit
+func (api *Api) ListAdminAudits(ctx *fiber.Ctx) error {
+	const op = "api.Api.ListAdminAudits"
+
+	filter, err := parseAdminAuditFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"filter": fmt.Sprintf("%+v", filter),
+	})
+	logger.Info("Listing admin audit records")
+
+	records, err := api.service.ListAdminAudits(ctx.Context(), filter)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAdminAuditFilter) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to list admin audit records")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve admin audit records")
+	}
+
+	response := make([]AdminAuditRecord, len(records))
+	for i, record := range records {
+		response[i] = newAdminAuditRecord(record)
+	}
+
+	logger.WithField("record_count", len(response)).Info("Listed admin audit records")
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Admin audit records retrieved successfully",
+		"data":    response,
+		"count":   len(response),
+	})
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"svc-transaction/middleware"
 	"svc-transaction/service"
+	"svc-transaction/store/migrations"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
@@ -11,39 +12,133 @@ import (
 type Api struct {
 	logger *logrus.Logger
 
-	service *service.Service
+	service  *service.Service
+	migrator *migrations.Migrator
 }
 
 func NewApi(
 	logger *logrus.Logger,
 	service *service.Service,
+	migrator *migrations.Migrator,
 ) *Api {
 	return &Api{
 		logger: logger,
 
-		service: service,
+		service:  service,
+		migrator: migrator,
 	}
 }
 
 func (api *Api) SetupRoutes(app *fiber.App) *fiber.App {
+	// Panic recovery middleware
+	app.Use(middleware.Recover(api.logger))
+
 	// Error handler middleware
 	app.Use(middleware.ErrorHandler())
 
 	// Health Routes
 	health := app.Group("/health")
 	health.Get("/", api.Health)
+	app.Get("/healthz", api.Healthz)
 
 	// Failure Simulation Routes (for testing and monitoring)
 	failureSimulation := app.Group("/failure-simulation")
 	failureSimulation.Get("/stats", api.GetFailureSimulationStats)
 	failureSimulation.Post("/reset", api.ResetFailureSimulation)
+	failureSimulation.Post("/enable", api.EnableFailureSimulation)
+	failureSimulation.Post("/disable", api.DisableFailureSimulation)
 	failureSimulation.Get("/scenarios", api.GetLearningScenarios)
+	failureSimulation.Post("/rules/:name/enable", api.EnableFailureRule)
+	failureSimulation.Post("/rules/:name/disable", api.DisableFailureRule)
+
+	// Simulated Latency Routes (base processing time added to every activity)
+	latencyProfile := app.Group("/latency-profile")
+	latencyProfile.Get("/", api.GetLatencyProfile)
+	latencyProfile.Post("/:name", api.SetLatencyProfile)
 
 	// Enhanced Compensation Audit Routes
 	compensationAudit := app.Group("/compensation-audit")
+	compensationAudit.Get("/", api.ListCompensationAudits)
 	compensationAudit.Get("/stats", api.GetCompensationStats)
 	compensationAudit.Get("/workflow/:workflow_id", api.GetCompensationAuditByWorkflow)
+	compensationAudit.Post("/workflow/:workflow_id/retry", api.RetryCompensation)
 	compensationAudit.Get("/pending", api.GetPendingCompensations)
 
+	// Fast-path Internal Transfer Routes (single DB transaction, no Temporal)
+	internalTransfers := app.Group("/internal-transfers")
+	internalTransfers.Post("/", api.CreateInternalTransfer)
+	internalTransfers.Get("/:transfer_id", api.GetInternalTransfer)
+
+	// Settlement File Routes (outgoing settlement files and their acknowledgements)
+	settlementFiles := app.Group("/settlement-files")
+	settlementFiles.Post("/", api.GenerateSettlementFile)
+	settlementFiles.Get("/", api.ListSettlementFiles)
+	settlementFiles.Get("/transfers/:transfer_id", api.GetTransferSettlement)
+	settlementFiles.Get("/:id", api.GetSettlementFile)
+	settlementFiles.Get("/:id/download", api.DownloadSettlementFile)
+	settlementFiles.Post("/:id/acknowledgements", api.AcknowledgeSettlementFile)
+
+	// Transfer Reference Routes (human-friendly references printed on receipts)
+	transferReferences := app.Group("/transfer-references")
+	transferReferences.Post("/", api.AssignTransferReference)
+	transferReferences.Get("/transfers/:transfer_id", api.GetTransferReferenceByTransferID)
+	transferReferences.Get("/:reference", api.GetTransferReference)
+
+	// Transfer Event Routes (state changes recorded by transfer workflows in core.transfer_events)
+	transferEvents := app.Group("/transfer-events")
+	transferEvents.Get("/:transfer_id", api.ListTransferEvents)
+
+	// Transfer Routes (latest state of saga transfers, projected from their events into core.transfer_read_model,
+	// and the approvals given to transfers held for approval)
+	transfers := app.Group("/transfers")
+	transfers.Get("/", api.ListTransferReadModels)
+	transfers.Get("/:transfer_id", api.GetTransferReadModel)
+	transfers.Get("/:transfer_id/approvals", api.ListTransferApprovals)
+
+	// Account Routes (opening, owners of joint accounts, adjustments, plus AccountClosureWorkflow, AccountErasureWorkflow and
+	// their records)
+	accounts := app.Group("/accounts")
+	accounts.Post("/", api.OpenAccount)
+	accounts.Post("/:id/closure", api.StartAccountClosure)
+	accounts.Get("/:id/closure", api.GetAccountClosureSteps)
+	accounts.Post("/:id/erasure", api.StartAccountErasure)
+	accounts.Get("/:id/erasure", api.GetAccountErasureCertificate)
+	accounts.Get("/:id/owners", api.ListAccountOwners)
+	accounts.Post("/:id/owners", api.AddAccountOwner)
+	accounts.Delete("/:id/owners/:owner_id", api.RemoveAccountOwner)
+	accounts.Post("/:id/adjustments", api.PostAdjustment)
+
+	// Adjustment Routes (balance corrections requested with POST /accounts/:id/adjustments, applied once a second
+	// operator approves them)
+	adjustments := app.Group("/adjustments")
+	adjustments.Get("/", api.ListAdjustments)
+	adjustments.Get("/:id", api.GetAdjustment)
+	adjustments.Post("/:id/approve", api.ApproveAdjustment)
+	adjustments.Post("/:id/reject", api.RejectAdjustment)
+
+	// Admin Audit Routes (state-changing admin calls recorded in core.admin_audit)
+	adminAudit := app.Group("/admin-audit")
+	adminAudit.Get("/", api.ListAdminAudits)
+
+	// Admin Routes (worker scaling hints for HPA or KEDA)
+	admin := app.Group("/admin")
+	admin.Get("/scaling", api.GetScalingHints)
+
+	// Reconciliation Routes (balance history chain verification)
+	reconciliation := app.Group("/reconciliation")
+	reconciliation.Get("/report", api.GetReconciliationReport)
+
+	// Pending Transaction Routes (transactions stuck in pending, resolved by PendingTransactionSweepWorkflow)
+	pendingTransactions := app.Group("/pending-transactions")
+	pendingTransactions.Get("/stats", api.GetPendingTransactionStats)
+
+	// Ledger Export Routes (on-demand LedgerExportWorkflow runs; scheduled runs export the last interval)
+	ledgerExports := app.Group("/ledger-exports")
+	ledgerExports.Post("/", api.StartLedgerExport)
+
+	// End-of-Day Balance Routes (on-demand EODBalanceWorkflow runs; the nightly schedule computes the previous day)
+	eodBalances := app.Group("/eod-balances")
+	eodBalances.Post("/", api.StartEODBalances)
+
 	return app
 }
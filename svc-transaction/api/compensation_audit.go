@@ -1,9 +1,14 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
 	"time"
 
+	"svc-transaction/service"
+	"svc-transaction/store/sqlc"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -11,14 +16,16 @@ import (
 
 // CompensationStatsResponse represents the response for compensation statistics
 type CompensationStatsResponse struct {
-	TotalCompensations     int64   `json:"total_compensations"`
-	CompletedCompensations int64   `json:"completed_compensations"`
-	FailedCompensations    int64   `json:"failed_compensations"`
-	TimeoutCompensations   int64   `json:"timeout_compensations"`
-	ManualCompensations    int64   `json:"manual_compensations"`
-	PendingCompensations   int64   `json:"pending_compensations"`
-	AverageAttempts        float64 `json:"average_attempts"`
-	Period                 string  `json:"period"`
+	TotalCompensations     int64     `json:"total_compensations"`
+	CompletedCompensations int64     `json:"completed_compensations"`
+	FailedCompensations    int64     `json:"failed_compensations"`
+	TimeoutCompensations   int64     `json:"timeout_compensations"`
+	ManualCompensations    int64     `json:"manual_compensations"`
+	PendingCompensations   int64     `json:"pending_compensations"`
+	AverageAttempts        float64   `json:"average_attempts"`
+	Period                 string    `json:"period"`
+	From                   time.Time `json:"from"`
+	To                     time.Time `json:"to"`
 }
 
 // CompensationAuditRecord represents a compensation audit record
@@ -40,16 +47,135 @@ type CompensationAuditRecord struct {
 	TimeoutDurationMs         *int32     `json:"timeout_duration_ms,omitempty"`
 }
 
+// parseCompensationAuditFilter reads the status, type, workflow_id, from, to and limit query parameters.
+// from and to are RFC 3339 timestamps.
+func parseCompensationAuditFilter(ctx *fiber.Ctx) (service.CompensationAuditFilter, error) {
+	filter := service.CompensationAuditFilter{
+		Status:     ctx.Query("status"),
+		Type:       ctx.Query("type"),
+		WorkflowID: ctx.Query("workflow_id"),
+		Limit:      50, // Default limit
+	}
+
+	for name, target := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		value := ctx.Query(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fiber.NewError(fiber.StatusBadRequest, "Invalid "+name+" timestamp, expected RFC 3339")
+		}
+		*target = parsed
+	}
+
+	if limitParam := ctx.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.ParseInt(limitParam, 10, 32); err == nil && parsedLimit > 0 && parsedLimit <= 1000 {
+			filter.Limit = int32(parsedLimit)
+		}
+	}
+
+	return filter, nil
+}
+
+// newCompensationAuditRecord converts a stored audit row into its response format
+func newCompensationAuditRecord(record sqlc.CoreCompensationAuditTrail) CompensationAuditRecord {
+	response := CompensationAuditRecord{
+		ID:                   record.ID.String(),
+		WorkflowID:           record.WorkflowID,
+		RunID:                record.RunID,
+		CompensationReason:   record.CompensationReason,
+		CompensationType:     string(record.CompensationType),
+		CompensationStatus:   string(record.CompensationStatus),
+		CompensationAttempts: record.CompensationAttempts,
+		CreatedAt:            record.CreatedAt.Time,
+		UpdatedAt:            record.UpdatedAt.Time,
+	}
+
+	// Handle nullable fields
+	if record.TransferID.Valid {
+		response.TransferID = &record.TransferID.String
+	}
+	if record.OriginalTransactionID.Valid {
+		uuidStr := uuid.UUID(record.OriginalTransactionID.Bytes).String()
+		response.OriginalTransactionID = &uuidStr
+	}
+	if record.CompensationTransactionID.Valid {
+		uuidStr := uuid.UUID(record.CompensationTransactionID.Bytes).String()
+		response.CompensationTransactionID = &uuidStr
+	}
+	if record.CompletedAt.Valid {
+		response.CompletedAt = &record.CompletedAt.Time
+	}
+	if record.FailureReason.Valid {
+		response.FailureReason = &record.FailureReason.String
+	}
+	if record.TimeoutDurationMs.Valid {
+		response.TimeoutDurationMs = &record.TimeoutDurationMs.Int32
+	}
+
+	return response
+}
+
+// ListCompensationAudits handles GET /compensation-audit
+func (api *Api) ListCompensationAudits(ctx *fiber.Ctx) error {
+	const op = "api.Api.ListCompensationAudits"
+
+	filter, err := parseCompensationAuditFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"filter": fmt.Sprintf("%+v", filter),
+	})
+	logger.Info("Listing compensation audit records")
+
+	records, err := api.service.ListCompensationAudits(ctx.Context(), filter)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCompensationFilter) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to list compensation audit records")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve compensation audit records")
+	}
+
+	response := make([]CompensationAuditRecord, len(records))
+	for i, record := range records {
+		response[i] = newCompensationAuditRecord(record)
+	}
+
+	logger.WithField("record_count", len(response)).Info("Listed compensation audit records")
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Compensation audit records retrieved successfully",
+		"data":    response,
+		"count":   len(response),
+	})
+}
+
 // GetCompensationStats handles GET /compensation-audit/stats
 func (api *Api) GetCompensationStats(ctx *fiber.Ctx) error {
 	const op = "api.Api.GetCompensationStats"
 
+	filter, err := parseCompensationAuditFilter(ctx)
+	if err != nil {
+		return err
+	}
+
 	logger := api.logger.WithField("[op]", op)
 	logger.Info("Getting compensation statistics")
 
 	// Get compensation statistics
-	stats, err := api.service.GetCompensationStats(ctx.Context())
+	stats, covered, err := api.service.GetCompensationStats(ctx.Context(), filter)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidCompensationFilter) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
 		logger.WithError(err).Error("Failed to get compensation stats")
 		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve compensation statistics")
 	}
@@ -62,7 +188,9 @@ func (api *Api) GetCompensationStats(ctx *fiber.Ctx) error {
 		ManualCompensations:    stats.ManualCompensations,
 		PendingCompensations:   stats.PendingCompensations,
 		AverageAttempts:        stats.AvgAttempts,
-		Period:                 "24h",
+		Period:                 covered.To.Sub(covered.From).String(),
+		From:                   covered.From,
+		To:                     covered.To,
 	}
 
 	logger.WithFields(logrus.Fields{
@@ -102,41 +230,7 @@ func (api *Api) GetCompensationAuditByWorkflow(ctx *fiber.Ctx) error {
 	// Convert records to response format
 	response := make([]CompensationAuditRecord, len(records))
 	for i, record := range records {
-		response[i] = CompensationAuditRecord{
-			ID:                   record.ID.String(),
-			WorkflowID:           record.WorkflowID,
-			RunID:                record.RunID,
-			CompensationReason:   record.CompensationReason,
-			CompensationType:     string(record.CompensationType),
-			CompensationStatus:   string(record.CompensationStatus),
-			CompensationAttempts: record.CompensationAttempts,
-			CreatedAt:            record.CreatedAt.Time,
-			UpdatedAt:            record.UpdatedAt.Time,
-		}
-
-		// Handle nullable fields
-		if record.TransferID.Valid {
-			response[i].TransferID = &record.TransferID.String
-		}
-		if record.OriginalTransactionID.Valid {
-			uuidObj := uuid.UUID(record.OriginalTransactionID.Bytes)
-			uuidStr := uuidObj.String()
-			response[i].OriginalTransactionID = &uuidStr
-		}
-		if record.CompensationTransactionID.Valid {
-			uuidObj := uuid.UUID(record.CompensationTransactionID.Bytes)
-			uuidStr := uuidObj.String()
-			response[i].CompensationTransactionID = &uuidStr
-		}
-		if record.CompletedAt.Valid {
-			response[i].CompletedAt = &record.CompletedAt.Time
-		}
-		if record.FailureReason.Valid {
-			response[i].FailureReason = &record.FailureReason.String
-		}
-		if record.TimeoutDurationMs.Valid {
-			response[i].TimeoutDurationMs = &record.TimeoutDurationMs.Int32
-		}
+		response[i] = newCompensationAuditRecord(record)
 	}
 
 	logger.WithField("record_count", len(response)).Info("Retrieved compensation audit records")
@@ -173,44 +267,10 @@ func (api *Api) GetPendingCompensations(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve pending compensations")
 	}
 
-	// Convert records to response format (same as above)
+	// Convert records to response format
 	response := make([]CompensationAuditRecord, len(records))
 	for i, record := range records {
-		response[i] = CompensationAuditRecord{
-			ID:                   record.ID.String(),
-			WorkflowID:           record.WorkflowID,
-			RunID:                record.RunID,
-			CompensationReason:   record.CompensationReason,
-			CompensationType:     string(record.CompensationType),
-			CompensationStatus:   string(record.CompensationStatus),
-			CompensationAttempts: record.CompensationAttempts,
-			CreatedAt:            record.CreatedAt.Time,
-			UpdatedAt:            record.UpdatedAt.Time,
-		}
-
-		// Handle nullable fields (same as above)
-		if record.TransferID.Valid {
-			response[i].TransferID = &record.TransferID.String
-		}
-		if record.OriginalTransactionID.Valid {
-			uuidObj := uuid.UUID(record.OriginalTransactionID.Bytes)
-			uuidStr := uuidObj.String()
-			response[i].OriginalTransactionID = &uuidStr
-		}
-		if record.CompensationTransactionID.Valid {
-			uuidObj := uuid.UUID(record.CompensationTransactionID.Bytes)
-			uuidStr := uuidObj.String()
-			response[i].CompensationTransactionID = &uuidStr
-		}
-		if record.CompletedAt.Valid {
-			response[i].CompletedAt = &record.CompletedAt.Time
-		}
-		if record.FailureReason.Valid {
-			response[i].FailureReason = &record.FailureReason.String
-		}
-		if record.TimeoutDurationMs.Valid {
-			response[i].TimeoutDurationMs = &record.TimeoutDurationMs.Int32
-		}
+		response[i] = newCompensationAuditRecord(record)
 	}
 
 	logger.WithField("pending_count", len(response)).Info("Retrieved pending compensations")
@@ -222,3 +282,102 @@ func (api *Api) GetPendingCompensations(ctx *fiber.Ctx) error {
 		"note":    "These compensations may require manual intervention",
 	})
 }
+
+// RetryCompensationRequest represents the optional request body for retrying a compensation
+type RetryCompensationRequest struct {
+	RequestedBy string `json:"requested_by,omitempty"`
+}
+
+// RetryCompensation handles POST /compensation-audit/workflow/:workflow_id/retry
+func (api *Api) RetryCompensation(ctx *fiber.Ctx) error {
+	const op = "api.Api.RetryCompensation"
+
+	workflowID := ctx.Params("workflow_id")
+	if workflowID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Workflow ID is required")
+	}
+
+	var request RetryCompensationRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&request); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"workflow_id": workflowID,
+	})
+	logger.Info("Retrying compensation")
+
+	before, err := api.service.GetCompensationAuditByWorkflowID(ctx.Context(), workflowID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get compensation audit records")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve compensation audit records")
+	}
+
+	record, result, err := api.service.RetryCompensation(ctx.Context(), service.RetryCompensationParams{
+		WorkflowID:  workflowID,
+		RequestedBy: adminActor(ctx, request.RequestedBy),
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to retry compensation")
+
+		var conflict *service.IdempotencyConflictError
+		if errors.As(err, &conflict) {
+			return idempotencyConflict(ctx, conflict)
+		}
+
+		switch {
+		case errors.Is(err, service.ErrCompensationNotRetryable):
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrAccountNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retry compensation")
+	}
+
+	response := newCompensationAuditRecord(*record)
+
+	var beforeRecord any
+	if len(before) > 0 {
+		beforeRecord = newCompensationAuditRecord(before[0])
+	}
+
+	api.recordAdminAction(ctx, service.AdminAction{
+		Actor:        adminActor(ctx, request.RequestedBy),
+		Action:       service.AdminActionRetryCompensation,
+		ResourceType: "compensation",
+		ResourceID:   response.ID,
+		Before:       beforeRecord,
+		After:        fiber.Map{"compensation": response, "transaction": result},
+	})
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Compensation retried successfully",
+		"data": fiber.Map{
+			"compensation": response,
+			"transaction":  result,
+		},
+	})
+}
+
+// idempotencyConflict answers 409 IDEMPOTENCY_CONFLICT with the fields that differ from the transaction the key created
+func idempotencyConflict(ctx *fiber.Ctx, conflict *service.IdempotencyConflictError) error {
+	return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+		"error":           conflict.Error(),
+		"code":            "IDEMPOTENCY_CONFLICT",
+		"idempotency_key": conflict.IdempotencyKey,
+		"transaction_id":  conflict.TransactionID,
+		"diffs":           conflict.Diffs,
+	})
+}
+
+// precisionExceeded answers 400 PRECISION_EXCEEDED for amounts the numeric columns would have rounded or rejected
+func precisionExceeded(ctx *fiber.Ctx, err error) error {
+	return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": err.Error(),
+		"code":  "PRECISION_EXCEEDED",
+	})
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"svc-transaction/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// StartEODBalancesRequest represents the request body for computing the closing balances of a business date
+type StartEODBalancesRequest struct {
+	BusinessDate string `json:"business_date"` // YYYY-MM-DD (UTC); the day must have ended
+	RequestedBy  string `json:"requested_by,omitempty"`
+}
+
+// StartEODBalances handles POST /eod-balances
+func (api *Api) StartEODBalances(ctx *fiber.Ctx) error {
+	const op = "api.Api.StartEODBalances"
+
+	var request StartEODBalancesRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	businessDate, err := time.Parse(service.BusinessDateFormat, request.BusinessDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid business_date, expected a date as YYYY-MM-DD")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":          op,
+		"business_date": request.BusinessDate,
+	})
+
+	result, err := api.service.StartEODBalances(ctx.Context(), service.StartEODBalancesParams{
+		BusinessDate: businessDate,
+		RequestedBy:  request.RequestedBy,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to start end-of-day balance run")
+
+		switch {
+		case errors.Is(err, service.ErrEODBalanceRejected):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrEODBalanceInProgress):
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to start end-of-day balance run")
+	}
+
+	api.recordAdminAction(ctx, service.AdminAction{
+		Actor:        adminActor(ctx, request.RequestedBy),
+		Action:       service.AdminActionStartEODBalances,
+		ResourceType: "eod_balances",
+		ResourceID:   request.BusinessDate,
+		After:        fiber.Map{"request": request, "workflow": result},
+	})
+
+	return ctx.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "End-of-day balance run started",
+		"data":    result,
+	})
+}
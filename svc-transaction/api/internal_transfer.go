@@ -0,0 +1,118 @@
+package api
+
+import (
+	"errors"
+
+	"svc-transaction/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// InternalTransferRequest represents the request body for a fast-path transfer
+type InternalTransferRequest struct {
+	TransferID    string  `json:"transfer_id"`
+	FromAccountID string  `json:"from_account_id"`
+	ToAccountID   string  `json:"to_account_id"`
+	Amount        string  `json:"amount"`
+	Currency      string  `json:"currency"`
+	Description   *string `json:"description,omitempty"`
+	RequestedBy   string  `json:"requested_by,omitempty"`
+}
+
+// CreateInternalTransfer handles POST /internal-transfers
+func (api *Api) CreateInternalTransfer(ctx *fiber.Ctx) error {
+	const op = "api.Api.CreateInternalTransfer"
+
+	var request InternalTransferRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"transfer_id": request.TransferID,
+	})
+
+	fromAccountID, err := uuid.Parse(request.FromAccountID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid from_account_id")
+	}
+
+	toAccountID, err := uuid.Parse(request.ToAccountID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid to_account_id")
+	}
+
+	amount, err := decimal.NewFromString(request.Amount)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid amount")
+	}
+
+	result, err := api.service.InternalTransfer(ctx.Context(), service.InternalTransferParams{
+		TransferID:    request.TransferID,
+		FromAccountID: fromAccountID,
+		ToAccountID:   toAccountID,
+		Amount:        amount,
+		Currency:      request.Currency,
+		Description:   request.Description,
+		RequestedBy:   request.RequestedBy,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Internal transfer failed")
+
+		var conflict *service.IdempotencyConflictError
+		if errors.As(err, &conflict) {
+			return idempotencyConflict(ctx, conflict)
+		}
+
+		switch {
+		case errors.Is(err, service.ErrPrecisionExceeded):
+			return precisionExceeded(ctx, err)
+		case errors.Is(err, service.ErrInternalTransferRejected):
+			return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, service.ErrIdempotencyConflict):
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to execute internal transfer")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Internal transfer completed successfully",
+		"data":    result,
+	})
+}
+
+// GetInternalTransfer handles GET /internal-transfers/:transfer_id
+func (api *Api) GetInternalTransfer(ctx *fiber.Ctx) error {
+	const op = "api.Api.GetInternalTransfer"
+
+	transferID := ctx.Params("transfer_id")
+	if transferID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Transfer ID is required")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"transfer_id": transferID,
+	})
+
+	result, err := api.service.GetInternalTransfer(ctx.Context(), transferID)
+	if err != nil {
+		if errors.Is(err, service.ErrInternalTransferNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to get internal transfer")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve internal transfer")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Internal transfer retrieved successfully",
+		"data":    result,
+	})
+}
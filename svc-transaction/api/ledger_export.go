@@ -0,0 +1,70 @@
+package api
+
+import (
+	"errors"
+	"time"
+
+	"svc-transaction/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// StartLedgerExportRequest represents the request body for exporting the ledger of a date range
+type StartLedgerExportRequest struct {
+	From        time.Time `json:"from"`             // RFC 3339, inclusive
+	To          time.Time `json:"to"`               // RFC 3339, exclusive
+	Format      string    `json:"format,omitempty"` // csv or parquet; defaults to csv
+	RequestedBy string    `json:"requested_by,omitempty"`
+}
+
+// StartLedgerExport handles POST /ledger-exports
+func (api *Api) StartLedgerExport(ctx *fiber.Ctx) error {
+	const op = "api.Api.StartLedgerExport"
+
+	var request StartLedgerExportRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body, from and to must be RFC 3339 timestamps")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"from":   request.From,
+		"to":     request.To,
+		"format": request.Format,
+	})
+
+	result, err := api.service.StartLedgerExport(ctx.Context(), service.StartLedgerExportParams{
+		From:        request.From,
+		To:          request.To,
+		Format:      request.Format,
+		RequestedBy: request.RequestedBy,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to start ledger export")
+
+		switch {
+		case errors.Is(err, service.ErrLedgerExportRejected):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrLedgerExportInProgress):
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrLedgerExportUnavailable):
+			return fiber.NewError(fiber.StatusServiceUnavailable, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to start ledger export")
+	}
+
+	api.recordAdminAction(ctx, service.AdminAction{
+		Actor:        adminActor(ctx, request.RequestedBy),
+		Action:       service.AdminActionStartLedgerExport,
+		ResourceType: "ledger_export",
+		ResourceID:   result.WorkflowID,
+		After:        fiber.Map{"request": request, "workflow": result},
+	})
+
+	return ctx.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "Ledger export started",
+		"data":    result,
+	})
+}
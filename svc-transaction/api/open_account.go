@@ -0,0 +1,70 @@
+package api
+
+import (
+	"errors"
+
+	"svc-transaction/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// OpenAccountRequest represents the request body for opening an account
+type OpenAccountRequest struct {
+	AccountNumber  string          `json:"account_number"`
+	AccountName    string          `json:"account_name"`
+	Currency       string          `json:"currency"`
+	AccountType    string          `json:"account_type,omitempty"` // checking or savings; empty for checking
+	OpeningBalance decimal.Decimal `json:"opening_balance"`
+	RequestedBy    string          `json:"requested_by,omitempty"`
+	Tenant         string          `json:"tenant,omitempty"` // Selects the account number format; empty for the default
+}
+
+// OpenAccount handles POST /accounts. Opening an existing account number returns it with 200 instead of 201.
+func (api *Api) OpenAccount(ctx *fiber.Ctx) error {
+	const op = "api.Api.OpenAccount"
+
+	var request OpenAccountRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":           op,
+		"account_number": request.AccountNumber,
+	})
+
+	result, err := api.service.OpenAccount(ctx.Context(), service.OpenAccountParams{
+		AccountNumber:  request.AccountNumber,
+		AccountName:    request.AccountName,
+		Currency:       request.Currency,
+		AccountType:    request.AccountType,
+		OpeningBalance: request.OpeningBalance,
+		Tenant:         request.Tenant,
+		Actor:          adminActor(ctx, request.RequestedBy),
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrPrecisionExceeded) {
+			return precisionExceeded(ctx, err)
+		}
+		if errors.Is(err, service.ErrInvalidAccountOpening) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to open account")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to open account")
+	}
+
+	if !result.Created {
+		return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message": "Account already exists",
+			"data":    result,
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Account opened successfully",
+		"data":    result,
+	})
+}
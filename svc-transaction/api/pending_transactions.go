@@ -0,0 +1,25 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// GetPendingTransactionStats handles GET /pending-transactions/stats
+func (api *Api) GetPendingTransactionStats(ctx *fiber.Ctx) error {
+	const op = "api.Api.GetPendingTransactionStats"
+
+	stats, err := api.service.GetPendingTransactionStats(ctx.Context())
+	if err != nil {
+		api.logger.WithFields(logrus.Fields{
+			"[op]": op,
+		}).WithError(err).Error("Failed to get pending transaction statistics")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get pending transaction statistics")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Pending transaction statistics retrieved successfully",
+		"data":    stats,
+	})
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"errors"
+
+	"svc-transaction/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// GetReconciliationReport handles GET /reconciliation/report, optionally limited to one account with ?account_id=
+func (api *Api) GetReconciliationReport(ctx *fiber.Ctx) error {
+	const op = "api.Api.GetReconciliationReport"
+
+	var accountID uuid.UUID
+	if value := ctx.Query("account_id"); value != "" {
+		var err error
+		if accountID, err = uuid.Parse(value); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid account_id")
+		}
+	}
+
+	report, err := api.service.GetReconciliationReport(ctx.Context(), accountID)
+	if err != nil {
+		api.logger.WithFields(logrus.Fields{
+			"[op]":       op,
+			"account_id": accountID,
+		}).WithError(err).Error("Failed to build reconciliation report")
+
+		if errors.Is(err, service.ErrAccountNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to build reconciliation report")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Reconciliation report generated successfully",
+		"data":    report,
+	})
+}
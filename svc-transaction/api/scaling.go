@@ -0,0 +1,34 @@
+package api
+
+import (
+	"errors"
+
+	"svc-transaction/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// GetScalingHints handles GET /admin/scaling. The desired worker count is at data.desired_workers, where a KEDA
+// metrics-api scaler can read it.
+func (api *Api) GetScalingHints(ctx *fiber.Ctx) error {
+	const op = "api.Api.GetScalingHints"
+
+	hints, err := api.service.GetScalingHints(ctx.Context())
+	if err != nil {
+		api.logger.WithFields(logrus.Fields{
+			"[op]": op,
+		}).WithError(err).Error("Failed to get scaling hints")
+
+		if errors.Is(err, service.ErrScalingUnavailable) {
+			return fiber.NewError(fiber.StatusServiceUnavailable, err.Error())
+		}
+
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Failed to read the task queue from Temporal")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Scaling hints retrieved successfully",
+		"data":    hints,
+	})
+}
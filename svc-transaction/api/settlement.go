@@ -0,0 +1,344 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"svc-transaction/service"
+	"svc-transaction/util/settlement"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// GenerateSettlementFileRequest represents the request body for generating a settlement file on demand
+type GenerateSettlementFileRequest struct {
+	Format       string `json:"format"`
+	MaxTransfers int    `json:"max_transfers,omitempty"`
+}
+
+// SettlementAcknowledgementRequest represents a JSON acknowledgement for a settlement file.
+// Status is "accepted" or "rejected" and applies to every transfer not listed in Transfers.
+type SettlementAcknowledgementRequest struct {
+	AcknowledgementReference string                              `json:"acknowledgement_reference"`
+	Status                   string                              `json:"status"`
+	Reason                   string                              `json:"reason,omitempty"`
+	Transfers                []SettlementTransferAcknowledgement `json:"transfers,omitempty"`
+}
+
+// SettlementTransferAcknowledgement represents the acknowledged status of a single transfer
+type SettlementTransferAcknowledgement struct {
+	TransferID string `json:"transfer_id"`
+	Status     string `json:"status"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// GenerateSettlementFile handles POST /settlement-files
+func (api *Api) GenerateSettlementFile(ctx *fiber.Ctx) error {
+	const op = "api.Api.GenerateSettlementFile"
+
+	var request GenerateSettlementFileRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if _, err := settlement.ParseFormat(request.Format); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"format": request.Format,
+	})
+
+	result, err := api.service.GenerateSettlementFile(ctx.Context(), service.GenerateSettlementFileParams{
+		Format:       request.Format,
+		MaxTransfers: request.MaxTransfers,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrNoTransfersToSettle) {
+			return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+				"message": "No completed transfers waiting for settlement",
+			})
+		}
+
+		logger.WithError(err).Error("Failed to generate settlement file")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to generate settlement file")
+	}
+
+	api.recordAdminAction(ctx, service.AdminAction{
+		Actor:        adminActor(ctx, ""),
+		Action:       service.AdminActionGenerateSettlementFile,
+		ResourceType: "settlement_file",
+		ResourceID:   result.ID.String(),
+		After:        result,
+	})
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Settlement file generated successfully",
+		"data":    result,
+	})
+}
+
+// ListSettlementFiles handles GET /settlement-files
+func (api *Api) ListSettlementFiles(ctx *fiber.Ctx) error {
+	const op = "api.Api.ListSettlementFiles"
+
+	// Parse limit parameter
+	limit := int32(50) // Default limit
+	if limitParam := ctx.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.ParseInt(limitParam, 10, 32); err == nil && parsedLimit > 0 && parsedLimit <= 1000 {
+			limit = int32(parsedLimit)
+		}
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":  op,
+		"limit": limit,
+	})
+
+	results, err := api.service.ListSettlementFiles(ctx.Context(), limit)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list settlement files")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve settlement files")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Settlement files retrieved successfully",
+		"data":    results,
+	})
+}
+
+// GetSettlementFile handles GET /settlement-files/:id
+func (api *Api) GetSettlementFile(ctx *fiber.Ctx) error {
+	const op = "api.Api.GetSettlementFile"
+
+	fileID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid settlement file ID")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":               op,
+		"settlement_file_id": fileID,
+	})
+
+	result, err := api.service.GetSettlementFile(ctx.Context(), fileID)
+	if err != nil {
+		if errors.Is(err, service.ErrSettlementFileNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to get settlement file")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve settlement file")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Settlement file retrieved successfully",
+		"data":    result,
+	})
+}
+
+// DownloadSettlementFile handles GET /settlement-files/:id/download
+func (api *Api) DownloadSettlementFile(ctx *fiber.Ctx) error {
+	const op = "api.Api.DownloadSettlementFile"
+
+	fileID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid settlement file ID")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":               op,
+		"settlement_file_id": fileID,
+	})
+
+	file, err := api.service.DownloadSettlementFile(ctx.Context(), fileID)
+	if err != nil {
+		if errors.Is(err, service.ErrSettlementFileNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to download settlement file")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to download settlement file")
+	}
+
+	ctx.Set(fiber.HeaderContentType, file.ContentType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", file.FileName))
+	ctx.Set("X-Checksum-SHA256", file.Checksum)
+
+	return ctx.Status(fiber.StatusOK).Send(file.Content)
+}
+
+// AcknowledgeSettlementFile handles POST /settlement-files/:id/acknowledgements.
+// The body is either a JSON acknowledgement or an ISO 20022 pacs.002 status report (Content-Type: application/xml).
+func (api *Api) AcknowledgeSettlementFile(ctx *fiber.Ctx) error {
+	const op = "api.Api.AcknowledgeSettlementFile"
+
+	fileID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid settlement file ID")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":               op,
+		"settlement_file_id": fileID,
+	})
+
+	var params service.AcknowledgeSettlementFileParams
+	if strings.Contains(ctx.Get(fiber.HeaderContentType), "xml") {
+		params, err = acknowledgementFromPacs002(ctx.Body())
+	} else {
+		params, err = acknowledgementFromJSON(ctx)
+	}
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	params.SettlementFileID = fileID
+
+	// Recorded as the before payload of the audit entry; a missing file fails the acknowledgement below
+	before, _ := api.service.GetSettlementFile(ctx.Context(), fileID)
+
+	result, err := api.service.AcknowledgeSettlementFile(ctx.Context(), params)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSettlementFileNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		case errors.Is(err, service.ErrSettlementFileAlreadyAcknowledged):
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrInvalidAcknowledgement):
+			return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to acknowledge settlement file")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to acknowledge settlement file")
+	}
+
+	api.recordAdminAction(ctx, service.AdminAction{
+		Actor:        adminActor(ctx, ""),
+		Action:       service.AdminActionAcknowledgeSettlementFile,
+		ResourceType: "settlement_file",
+		ResourceID:   fileID.String(),
+		Before:       before,
+		After:        result,
+	})
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Settlement file acknowledged successfully",
+		"data":    result,
+	})
+}
+
+// GetTransferSettlement handles GET /settlement-files/transfers/:transfer_id
+func (api *Api) GetTransferSettlement(ctx *fiber.Ctx) error {
+	const op = "api.Api.GetTransferSettlement"
+
+	transferID := ctx.Params("transfer_id")
+	if transferID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Transfer ID is required")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"transfer_id": transferID,
+	})
+
+	result, err := api.service.GetTransferSettlement(ctx.Context(), transferID)
+	if err != nil {
+		if errors.Is(err, service.ErrSettlementEntryNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to get transfer settlement")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve transfer settlement")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Transfer settlement retrieved successfully",
+		"data":    result,
+	})
+}
+
+// acknowledgementFromJSON maps a JSON acknowledgement body to service parameters
+func acknowledgementFromJSON(ctx *fiber.Ctx) (service.AcknowledgeSettlementFileParams, error) {
+	var request SettlementAcknowledgementRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return service.AcknowledgeSettlementFileParams{}, fmt.Errorf("invalid request body")
+	}
+
+	rejected, err := parseAcknowledgementStatus(request.Status)
+	if err != nil {
+		return service.AcknowledgeSettlementFileParams{}, err
+	}
+
+	params := service.AcknowledgeSettlementFileParams{
+		AcknowledgementReference: request.AcknowledgementReference,
+		Rejected:                 rejected,
+		Reason:                   request.Reason,
+	}
+
+	for _, transfer := range request.Transfers {
+		if transfer.TransferID == "" {
+			return service.AcknowledgeSettlementFileParams{}, fmt.Errorf("transfer_id is required for every acknowledged transfer")
+		}
+
+		transferRejected, err := parseAcknowledgementStatus(transfer.Status)
+		if err != nil {
+			return service.AcknowledgeSettlementFileParams{}, err
+		}
+
+		params.Transfers = append(params.Transfers, service.TransferAcknowledgement{
+			Reference: transfer.TransferID,
+			Rejected:  transferRejected,
+			Reason:    transfer.Reason,
+		})
+	}
+
+	return params, nil
+}
+
+// acknowledgementFromPacs002 maps a pacs.002 status report to service parameters
+func acknowledgementFromPacs002(body []byte) (service.AcknowledgeSettlementFileParams, error) {
+	acknowledgement, err := settlement.ParsePacs002(body)
+	if err != nil {
+		return service.AcknowledgeSettlementFileParams{}, err
+	}
+
+	params := service.AcknowledgeSettlementFileParams{
+		AcknowledgementReference: acknowledgement.MessageID,
+		OriginalFileReference:    acknowledgement.OriginalFileReference,
+		Rejected:                 settlement.Rejected(acknowledgement.GroupStatus),
+		Reason:                   acknowledgement.GroupReason,
+	}
+
+	for _, tx := range acknowledgement.Transactions {
+		params.Transfers = append(params.Transfers, service.TransferAcknowledgement{
+			Reference: tx.Reference,
+			Rejected:  settlement.Rejected(tx.Status),
+			Reason:    tx.Reason,
+		})
+	}
+
+	return params, nil
+}
+
+// parseAcknowledgementStatus maps a JSON acknowledgement status to whether it rejects settlement
+func parseAcknowledgementStatus(status string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "accepted", "settled":
+		return false, nil
+	case "rejected":
+		return true, nil
+	default:
+		return false, fmt.Errorf("status must be accepted or rejected, got %q", status)
+	}
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// ListTransferApprovals handles GET /transfers/:transfer_id/approvals
+func (api *Api) ListTransferApprovals(ctx *fiber.Ctx) error {
+	const op = "api.Api.ListTransferApprovals"
+
+	transferID := ctx.Params("transfer_id")
+	if transferID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Transfer ID is required")
+	}
+
+	approvals, err := api.service.ListTransferApprovals(ctx.Context(), transferID)
+	if err != nil {
+		api.logger.WithFields(logrus.Fields{
+			"[op]":        op,
+			"transfer_id": transferID,
+		}).WithError(err).Error("Failed to list transfer approvals")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve transfer approvals")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Transfer approvals retrieved successfully",
+		"data":    approvals,
+	})
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"errors"
+
+	"svc-transaction/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// ListTransferEvents handles GET /transfer-events/:transfer_id
+func (api *Api) ListTransferEvents(ctx *fiber.Ctx) error {
+	const op = "api.Api.ListTransferEvents"
+
+	transferID := ctx.Params("transfer_id")
+	if transferID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Transfer ID is required")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"transfer_id": transferID,
+	})
+
+	results, err := api.service.ListTransferEvents(ctx.Context(), transferID)
+	if err != nil {
+		if errors.Is(err, service.ErrTransferEventsNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to list transfer events")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve transfer events")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Transfer events retrieved successfully",
+		"data":    results,
+	})
+}
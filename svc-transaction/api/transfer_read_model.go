@@ -0,0 +1,111 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"svc-transaction/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// GetTransferReadModel handles GET /transfers/:transfer_id
+func (api *Api) GetTransferReadModel(ctx *fiber.Ctx) error {
+	const op = "api.Api.GetTransferReadModel"
+
+	transferID := ctx.Params("transfer_id")
+	if transferID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Transfer ID is required")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"transfer_id": transferID,
+	})
+
+	result, err := api.service.GetTransferReadModel(ctx.Context(), transferID)
+	if err != nil {
+		if errors.Is(err, service.ErrTransferNotProjected) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to get transfer read model")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve transfer")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Transfer retrieved successfully",
+		"data":    result,
+	})
+}
+
+// ListTransferReadModels handles GET /transfers?account=&status=&from=&to=&limit=&cursor=. The next page is requested
+// with the next_cursor of the response, which is empty on the last page.
+func (api *Api) ListTransferReadModels(ctx *fiber.Ctx) error {
+	const op = "api.Api.ListTransferReadModels"
+
+	params, err := parseListTransferReadModelsParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	results, err := api.service.ListTransferReadModels(ctx.Context(), params)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTransferReadModelQuery) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to list transfer read models")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve transfers")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":     "Transfers retrieved successfully",
+		"data":        results.Transfers,
+		"count":       len(results.Transfers),
+		"next_cursor": results.NextCursor,
+	})
+}
+
+// parseListTransferReadModelsParams reads the filters of GET /transfers; from and to bound the start of the
+// transfers and are RFC 3339 timestamps
+func parseListTransferReadModelsParams(ctx *fiber.Ctx) (service.ListTransferReadModelsParams, error) {
+	params := service.ListTransferReadModelsParams{
+		Account: ctx.Query("account"),
+		Status:  ctx.Query("status"),
+		Cursor:  ctx.Query("cursor"),
+	}
+
+	for name, target := range map[string]**time.Time{"from": &params.StartedFrom, "to": &params.StartedTo} {
+		value := ctx.Query(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return params, fiber.NewError(fiber.StatusBadRequest, "Invalid "+name+" timestamp, expected RFC 3339")
+		}
+		*target = &parsed
+	}
+
+	if limitParam := ctx.Query("limit"); limitParam != "" {
+		limit, err := strconv.ParseInt(limitParam, 10, 32)
+		if err != nil {
+			return params, fiber.NewError(fiber.StatusBadRequest, "Invalid limit")
+		}
+		params.Limit = int32(limit)
+	}
+
+	return params, nil
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"errors"
+
+	"svc-transaction/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// AssignTransferReferenceRequest represents the request body for issuing a transfer reference
+type AssignTransferReferenceRequest struct {
+	TransferID string `json:"transfer_id"`
+	BranchCode string `json:"branch_code"`
+}
+
+// AssignTransferReference handles POST /transfer-references
+func (api *Api) AssignTransferReference(ctx *fiber.Ctx) error {
+	const op = "api.Api.AssignTransferReference"
+
+	var request AssignTransferReferenceRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"transfer_id": request.TransferID,
+	})
+
+	result, err := api.service.AssignTransferReference(ctx.Context(), service.AssignTransferReferenceParams{
+		TransferID: request.TransferID,
+		BranchCode: request.BranchCode,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTransferReference) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to assign transfer reference")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to assign transfer reference")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Transfer reference assigned successfully",
+		"data":    result,
+	})
+}
+
+// GetTransferReference handles GET /transfer-references/:reference
+func (api *Api) GetTransferReference(ctx *fiber.Ctx) error {
+	const op = "api.Api.GetTransferReference"
+
+	reference := ctx.Params("reference")
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":      op,
+		"reference": reference,
+	})
+
+	result, err := api.service.GetTransferReference(ctx.Context(), reference)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTransferReference):
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, service.ErrTransferReferenceNotFound):
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to get transfer reference")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve transfer reference")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Transfer reference retrieved successfully",
+		"data":    result,
+	})
+}
+
+// GetTransferReferenceByTransferID handles GET /transfer-references/transfers/:transfer_id
+func (api *Api) GetTransferReferenceByTransferID(ctx *fiber.Ctx) error {
+	const op = "api.Api.GetTransferReferenceByTransferID"
+
+	transferID := ctx.Params("transfer_id")
+	if transferID == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Transfer ID is required")
+	}
+
+	logger := api.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"transfer_id": transferID,
+	})
+
+	result, err := api.service.GetTransferReferenceByTransferID(ctx.Context(), transferID)
+	if err != nil {
+		if errors.Is(err, service.ErrTransferReferenceNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, err.Error())
+		}
+
+		logger.WithError(err).Error("Failed to get transfer reference")
+
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to retrieve transfer reference")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Transfer reference retrieved successfully",
+		"data":    result,
+	})
+}
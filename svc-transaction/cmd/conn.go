@@ -3,12 +3,22 @@ package main
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"svc-transaction/adapter/external_bank_adapter"
+	"svc-transaction/service"
+	"svc-transaction/util/accountnumber"
+	"svc-transaction/util/alerting"
 	"svc-transaction/util/config"
+	"svc-transaction/util/objectstore"
+
+	"numeric"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/sirupsen/logrus"
 	"go.temporal.io/sdk/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 func createPostgresPool(
@@ -86,3 +96,154 @@ func createTemporalClient(
 
 	return temporalClient, nil
 }
+
+// createObjectStore returns the store exported and archived files are written to, or nil when no storage backend
+// is configured
+func createObjectStore(
+	logger *logrus.Logger,
+	storageConfig config.Storage,
+) (objectstore.Store, error) {
+	const op = "main.createObjectStore"
+
+	store, err := objectstore.New(objectstore.Options{
+		Backend:  storageConfig.Backend,
+		LocalDir: storageConfig.LocalDir,
+		S3: objectstore.S3Options{
+			Endpoint:        storageConfig.S3.Endpoint,
+			Region:          storageConfig.S3.Region,
+			Bucket:          storageConfig.S3.Bucket,
+			AccessKeyID:     storageConfig.S3.AccessKeyID,
+			SecretAccessKey: storageConfig.S3.SecretAccessKey,
+		},
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to create object store: %w", err)
+
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"error": err.Error(),
+		}).Error()
+
+		return nil, err
+	}
+
+	if store == nil {
+		logger.Info("No storage backend configured, ledger exports and settlement file archiving are disabled")
+
+		return nil, nil
+	}
+
+	logger.WithField("backend", storageConfig.Backend).Info("Object store created successfully")
+
+	return store, nil
+}
+
+// createAccountNumberValidator returns the validator of the account numbers of opened accounts; relaxed returns none,
+// accepting any account number
+func createAccountNumberValidator(config config.AccountNumbers, relaxed bool) (*accountnumber.Validator, error) {
+	if relaxed {
+		return nil, nil
+	}
+
+	formats := make([]accountnumber.Format, 0, len(config.Formats))
+	for _, format := range config.Formats {
+		formats = append(formats, accountnumber.Format{
+			Tenant:    format.Tenant,
+			Prefix:    format.Prefix,
+			MinLength: format.MinLength,
+			MaxLength: format.MaxLength,
+			Checksum:  format.Checksum,
+		})
+	}
+
+	validator, err := accountnumber.NewValidator(formats)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account number validator: %w", err)
+	}
+
+	return validator, nil
+}
+
+// createAmountPrecision returns the precision amounts are checked against, the default one when none is configured
+func createAmountPrecision(config config.AmountPrecision) (numeric.Precision, error) {
+	if config.Precision == 0 {
+		return numeric.DefaultPrecision, nil
+	}
+
+	precision := numeric.Precision{Precision: config.Precision, Scale: config.Scale}
+	if err := precision.Validate(); err != nil {
+		return numeric.Precision{}, fmt.Errorf("invalid amount precision: %w", err)
+	}
+
+	return precision, nil
+}
+
+// createExternalBankAdapter returns the adapter of the partner bank, or nil when no partner is configured
+func createExternalBankAdapter(config config.ExternalBank, logger *logrus.Logger) (*external_bank_adapter.Adapter, error) {
+	if config.Host == "" {
+		logger.Info("No partner bank configured, external settlements are rejected")
+
+		return nil, nil
+	}
+
+	address := fmt.Sprintf("%s:%d", config.Host, config.Port)
+
+	connOpts, err := grpcDialOptions(config.Grpc)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(
+		address,
+		append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, connOpts...)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s grpc server: %w", config.Name, err)
+	}
+
+	return external_bank_adapter.NewAdapter(config.Name, logger, conn), nil
+}
+
+// createAlertEvaluator returns the evaluator of the configured alerting rules, or nil when alerting is disabled
+func createAlertEvaluator(config config.Alerting, sources map[string]alerting.Source, logger *logrus.Logger) (*alerting.Evaluator, error) {
+	if config.RulesFile == "" {
+		return nil, nil
+	}
+
+	rules, err := alerting.LoadRules(config.RulesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := 5 * time.Second
+	if config.TimeoutSeconds > 0 {
+		timeout = time.Duration(config.TimeoutSeconds) * time.Second
+	}
+
+	evaluator, err := alerting.NewEvaluator(logger, rules, sources, alerting.NewHTTPNotifier(logger, timeout))
+	if err != nil {
+		return nil, fmt.Errorf("invalid alerting rules %s: %w", config.RulesFile, err)
+	}
+
+	return evaluator, nil
+}
+
+func createScalingPolicy(config config.Scaling) service.ScalingPolicy {
+	return service.ScalingPolicy{
+		TargetBacklogPerWorker:  config.TargetBacklogPerWorker,
+		TargetInFlightPerWorker: config.TargetInFlightPerWorker,
+		MaxBacklogAge:           time.Duration(config.MaxBacklogAgeSeconds) * time.Second,
+		MinWorkers:              config.MinWorkers,
+		MaxWorkers:              config.MaxWorkers,
+		Window:                  time.Duration(config.WindowSeconds) * time.Second,
+	}
+}
+
+// taskQueueName returns the task queue of this worker fleet: the configured task queue with the fleet suffix appended
+func taskQueueName(config config.Temporal) string {
+	if config.TaskQueueSuffix == "" {
+		return config.TaskQueue
+	}
+
+	return config.TaskQueue + "-" + config.TaskQueueSuffix
+}
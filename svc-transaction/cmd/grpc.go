@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"svc-transaction/util/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/keepalive"
+)
+
+// grpcServerOptions converts the connection settings of the gRPC server to server options. Zero values keep the
+// gRPC defaults, so a server without settings behaves as before.
+func grpcServerOptions(config config.Grpc) []grpc.ServerOption {
+	var opts []grpc.ServerOption
+
+	seconds := func(value int) time.Duration { return time.Duration(value) * time.Second }
+
+	params := keepalive.ServerParameters{
+		Time:                  seconds(config.Keepalive.TimeSeconds),
+		Timeout:               seconds(config.Keepalive.TimeoutSeconds),
+		MaxConnectionIdle:     seconds(config.Keepalive.MaxConnectionIdleSeconds),
+		MaxConnectionAge:      seconds(config.Keepalive.MaxConnectionAgeSeconds),
+		MaxConnectionAgeGrace: seconds(config.Keepalive.MaxConnectionAgeGraceSeconds),
+	}
+	if params != (keepalive.ServerParameters{}) {
+		opts = append(opts, grpc.KeepaliveParams(params))
+	}
+
+	if config.Keepalive.MinTimeSeconds > 0 || config.Keepalive.PermitWithoutStream {
+		policy := keepalive.EnforcementPolicy{
+			MinTime:             seconds(config.Keepalive.MinTimeSeconds),
+			PermitWithoutStream: config.Keepalive.PermitWithoutStream,
+		}
+		if policy.MinTime == 0 {
+			policy.MinTime = 5 * time.Minute
+		}
+
+		opts = append(opts, grpc.KeepaliveEnforcementPolicy(policy))
+	}
+
+	if config.MaxRecvMsgBytes > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(config.MaxRecvMsgBytes))
+	}
+	if config.MaxSendMsgBytes > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(config.MaxSendMsgBytes))
+	}
+	if config.ConnectionTimeoutSeconds > 0 {
+		opts = append(opts, grpc.ConnectionTimeout(seconds(config.ConnectionTimeoutSeconds)))
+	}
+
+	return opts
+}
+
+// grpcDialOptions converts the connection settings of a gRPC client to dial options. Zero values keep the gRPC
+// defaults, so a client without settings behaves as before.
+func grpcDialOptions(config config.GrpcClient) ([]grpc.DialOption, error) {
+	var opts []grpc.DialOption
+
+	if config.Keepalive.TimeSeconds > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                time.Duration(config.Keepalive.TimeSeconds) * time.Second,
+			Timeout:             time.Duration(config.Keepalive.TimeoutSeconds) * time.Second,
+			PermitWithoutStream: config.Keepalive.PermitWithoutStream,
+		}))
+	}
+
+	var callOpts []grpc.CallOption
+	if config.MaxRecvMsgBytes > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(config.MaxRecvMsgBytes))
+	}
+	if config.MaxSendMsgBytes > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(config.MaxSendMsgBytes))
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+
+	if config.ConnectTimeoutSeconds > 0 {
+		opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: time.Duration(config.ConnectTimeoutSeconds) * time.Second,
+		}))
+	}
+
+	if config.Retry.MaxAttempts > 1 {
+		serviceConfig, err := grpcRetryServiceConfig(config.Retry)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, grpc.WithDefaultServiceConfig(serviceConfig))
+	}
+
+	return opts, nil
+}
+
+// grpcRetryServiceConfig returns a service config retrying every method on UNAVAILABLE, the code of calls lost with
+// their connection ("transport is closing") and of services that are briefly unavailable. The retry throttling
+// budget stops retries while most calls fail, so an outage is not multiplied by max_attempts.
+func grpcRetryServiceConfig(retry config.GrpcRetry) (string, error) {
+	initialBackoff := retry.InitialBackoffMs
+	if initialBackoff <= 0 {
+		initialBackoff = 100
+	}
+	maxBackoff := retry.MaxBackoffMs
+	if maxBackoff <= 0 {
+		maxBackoff = 2000
+	}
+	multiplier := retry.BackoffMultiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	budgetTokens := retry.BudgetTokens
+	if budgetTokens <= 0 {
+		budgetTokens = 10
+	}
+	tokenRatio := retry.BudgetTokenRatio
+	if tokenRatio <= 0 {
+		tokenRatio = 0.1
+	}
+
+	serviceConfig := map[string]interface{}{
+		"methodConfig": []map[string]interface{}{{
+			"name": []map[string]interface{}{{}}, // Every method of every service
+			"retryPolicy": map[string]interface{}{
+				"maxAttempts":          retry.MaxAttempts,
+				"initialBackoff":       fmt.Sprintf("%.3fs", float64(initialBackoff)/1000),
+				"maxBackoff":           fmt.Sprintf("%.3fs", float64(maxBackoff)/1000),
+				"backoffMultiplier":    multiplier,
+				"retryableStatusCodes": []string{"UNAVAILABLE"},
+			},
+		}},
+		"retryThrottling": map[string]interface{}{
+			"maxTokens":  budgetTokens,
+			"tokenRatio": tokenRatio,
+		},
+	}
+
+	data, err := json.Marshal(serviceConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode grpc retry policy: %w", err)
+	}
+
+	return string(data), nil
+}
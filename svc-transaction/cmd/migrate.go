@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"svc-transaction/store/migrations"
+	"svc-transaction/util/config"
+
+	"github.com/sirupsen/logrus"
+)
+
+// migrate applies the pending schema migrations and exits, for deployments that keep db.migrations.auto off
+func migrate() {
+	const op = "main.migrate"
+
+	logger := logrus.New()
+	logger.Formatter = &logrus.TextFormatter{DisableColors: true, DisableTimestamp: true}
+	logger.Out = os.Stdout
+
+	config, err := config.LoadConfig(".")
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"scope": "LoadConfig",
+			"err":   err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
+	if !config.DB.Migrated() {
+		fmt.Printf("db.driver is %s, there is no schema to migrate\n", config.DB.Driver)
+		return
+	}
+
+	postgresPool, err := createPostgresPool(logger, config.DB.Postgres)
+	if err != nil {
+		os.Exit(1)
+	}
+	defer postgresPool.Close()
+
+	migrator, err := migrations.NewMigrator(logger, postgresPool, config.App.Name)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"scope": "NewMigrator",
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":    op,
+			"applied": applied,
+			"error":   err.Error(),
+		}).Error()
+
+		postgresPool.Close()
+		os.Exit(1)
+	}
+
+	status, err := migrator.Status(context.Background())
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"error": err.Error(),
+		}).Error()
+
+		postgresPool.Close()
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s schema at version %d, %d migrations applied\n", status.Service, status.Version, applied)
+}
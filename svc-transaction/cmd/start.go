@@ -6,13 +6,19 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	"svc-transaction/activity"
 	"svc-transaction/api"
+	"svc-transaction/ops"
 	"svc-transaction/service"
 	"svc-transaction/store"
+	"svc-transaction/store/memory"
+	"svc-transaction/store/migrations"
+	"svc-transaction/store/sqlite"
 	"svc-transaction/util/config"
+	"svc-transaction/util/failure"
 	"svc-transaction/worker"
 
 	"github.com/sirupsen/logrus"
@@ -42,13 +48,43 @@ func start() {
 		os.Exit(1)
 	}
 
+	// --- Apply profile (APP_PROFILE) ---
+	if !config.Profile.VerboseLogging() {
+		logger.Level = logrus.InfoLevel
+	}
+
 	logger.WithFields(logrus.Fields{
 		"[op]":   op,
 		"config": fmt.Sprintf("%+v", config),
 	}).Infof("Starting '%s' service ...", config.App.Name)
 
-	// --- Init postgres pool ---
-	postgresPool, err := createPostgresPool(logger, config.DB.Postgres)
+	// --- Init store layer (db.driver) ---
+	store, migrator, err := newStore(logger, config.DB, config.App.Name, config.Profile.DemoData())
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"scope": "newStore",
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
+	// The in-memory store has no settlement, ledger export or end-of-day balance tables
+	if config.DB.InMemory() {
+		if err := checkInMemoryFeatures(logger, config); err != nil {
+			logger.WithFields(logrus.Fields{
+				"[op]":  op,
+				"scope": "checkInMemoryFeatures",
+				"error": err.Error(),
+			}).Error()
+
+			os.Exit(1)
+		}
+	}
+
+	// --- Init object store for exported and archived files ---
+	objectStore, err := createObjectStore(logger, config.Storage)
 	if err != nil {
 		logger.WithFields(logrus.Fields{
 			"[op]":  op,
@@ -58,11 +94,83 @@ func start() {
 		os.Exit(1)
 	}
 
-	// --- Init store layer ---
-	store := store.NewStore(logger, postgresPool)
+	// --- Init account number validator ---
+	accountNumbers, err := createAccountNumberValidator(config.AccountNumbers, config.Profile.RelaxedValidation())
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
+	// --- Init amount precision guard ---
+	amountPrecision, err := createAmountPrecision(config.AmountPrecision)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
+	// --- Init partner bank adapter (used by the external settlement step of transfers) ---
+	externalBank, err := createExternalBankAdapter(config.ExternalBank, logger)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
 
 	// --- Init service layer ---
 	transactionService := service.NewService(logger, store)
+	transactionService.SetErasureRetention(time.Duration(config.Erasure.RetentionDays) * 24 * time.Hour)
+	transactionService.SetIdempotencyRetention(time.Duration(config.Idempotency.RetentionHours) * time.Hour)
+	transactionService.SetPendingStaleAfter(time.Duration(config.PendingSweep.StaleAfterMinutes) * time.Minute)
+	transactionService.SetObjectStore(objectStore)
+	transactionService.SetAccountNumberValidator(accountNumbers)
+	transactionService.SetAmountPrecision(amountPrecision)
+	transactionService.SetBusinessRulesRefresh(time.Duration(config.BusinessRules.RefreshSeconds) * time.Second)
+	transactionService.SetRelaxedValidation(config.Profile.RelaxedValidation())
+	transactionService.SetExternalBank(externalBank, time.Duration(config.ExternalBank.TimeoutSeconds)*time.Second)
+	transactionService.SetLedgerExportPrefix(config.LedgerExport.Prefix)
+	transactionService.SetScalingPolicy(createScalingPolicy(config.Scaling))
+	transactionService.SetFailureQuota(failure.Quota{
+		MaxFailurePercent: config.FailureSimulation.MaxFailurePercent,
+		MinOperations:     config.FailureSimulation.MinOperations,
+	})
+	if enabled, ok := config.Profile.FailureSimulation(); ok {
+		transactionService.SetFailureSimulationEnabled(enabled)
+	}
+	transactionService.SetCompensationBacklogLimit(
+		config.FailureSimulation.CompensationBacklogLimit,
+		time.Duration(config.FailureSimulation.BacklogCheckSeconds)*time.Second,
+	)
+	if err := transactionService.SetLatencyProfile(config.Latency.Profile); err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"scope": "SetLatencyProfile",
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
+
+	// --- Init alerting evaluator ---
+	alertEvaluator, err := createAlertEvaluator(config.Alerting, transactionService.AlertSources(), logger)
+	if err != nil {
+		logger.WithFields(logrus.Fields{
+			"[op]":  op,
+			"error": err.Error(),
+		}).Error()
+
+		os.Exit(1)
+	}
 
 	// --- Init activity ---
 	activity := activity.NewActivity(logger, transactionService)
@@ -72,7 +180,7 @@ func start() {
 	defer cancel()
 
 	// --- Init metrics server for Prometheus ---
-	metricsServer := NewMetricsServer(logger, 8080)
+	metricsServer := NewMetricsServer(logger, 8080, transactionService)
 	go func() {
 		if err := metricsServer.Start(ctx); err != nil {
 			logger.WithFields(logrus.Fields{
@@ -84,13 +192,38 @@ func start() {
 	}()
 
 	// --- Init api layer ---
-	restApi := api.NewApi(logger, transactionService)
+	restApi := api.NewApi(logger, transactionService, migrator)
 
 	// --- Start REST server in a goroutine ---
 	go func() {
 		runRestServer(config.App.Port, restApi)
 	}()
 
+	// --- Start transfer read model projection ---
+	if config.TransferReadModel.Enabled {
+		go transactionService.RunTransferProjection(
+			ctx,
+			time.Duration(config.TransferReadModel.IntervalSeconds)*time.Second,
+			config.TransferReadModel.BatchSize,
+		)
+	}
+
+	// --- Start alerting evaluator ---
+	if alertEvaluator != nil {
+		interval := 30 * time.Second
+		if config.Alerting.IntervalSeconds > 0 {
+			interval = time.Duration(config.Alerting.IntervalSeconds) * time.Second
+		}
+
+		go alertEvaluator.Run(ctx, interval)
+	}
+
+	// --- Start ops gRPC server ---
+	if config.App.GrpcPort > 0 {
+		grpcServer := runGrpcServer(config.App.GrpcPort, config.Grpc, ops.NewOps(logger, transactionService))
+		defer grpcServer.GracefulStop()
+	}
+
 	// --- Init temporal client and worker in a separate goroutine ---
 	go func() {
 		logger.Info("Attempting to connect to Temporal...")
@@ -116,9 +249,11 @@ func start() {
 			temporalWorker, err := worker.NewWorker(
 				logger,
 				temporalClient,
-				config.Temporal.TaskQueue,
+				taskQueueName(config.Temporal),
 				activity,
 				config.Temporal,
+				transactionService.SimulateFailure,
+				transactionService.InjectLatency,
 			)
 			if err != nil {
 				logger.WithFields(logrus.Fields{
@@ -137,6 +272,71 @@ func start() {
 
 			logger.Info("Temporal worker connected successfully")
 
+			// --- Let the service start and observe workflows ---
+			transactionService.SetTemporalClient(temporalClient, taskQueueName(config.Temporal))
+
+			// --- Schedule settlement file generation ---
+			if config.Settlement.IntervalMinutes > 0 {
+				if err := temporalWorker.EnsureSettlementSchedule(ctx, config.Settlement); err != nil {
+					logger.WithFields(logrus.Fields{
+						"[op]":  op,
+						"error": err.Error(),
+					}).Error("Failed to schedule settlement file generation")
+				}
+			}
+
+			// --- Schedule idempotency key cleanup ---
+			if config.Idempotency.CleanupIntervalMinutes > 0 {
+				if err := temporalWorker.EnsureIdempotencyCleanupSchedule(ctx, config.Idempotency); err != nil {
+					logger.WithFields(logrus.Fields{
+						"[op]":  op,
+						"error": err.Error(),
+					}).Error("Failed to schedule idempotency key cleanup")
+				}
+			}
+
+			// --- Schedule pending transaction sweep ---
+			if config.PendingSweep.IntervalMinutes > 0 {
+				if err := temporalWorker.EnsurePendingSweepSchedule(ctx, config.PendingSweep); err != nil {
+					logger.WithFields(logrus.Fields{
+						"[op]":  op,
+						"error": err.Error(),
+					}).Error("Failed to schedule pending transaction sweep")
+				}
+			}
+
+			// --- Schedule ledger snapshot export ---
+			if config.LedgerExport.IntervalMinutes > 0 {
+				if objectStore == nil {
+					logger.WithField("[op]", op).Warn("Ledger export schedule skipped: no storage backend configured")
+				} else if err := temporalWorker.EnsureLedgerExportSchedule(ctx, config.LedgerExport); err != nil {
+					logger.WithFields(logrus.Fields{
+						"[op]":  op,
+						"error": err.Error(),
+					}).Error("Failed to schedule ledger snapshot export")
+				}
+			}
+
+			// --- Schedule end-of-day closing balances ---
+			if config.EODBalances.Enabled {
+				if err := temporalWorker.EnsureEODBalanceSchedule(ctx, config.EODBalances); err != nil {
+					logger.WithFields(logrus.Fields{
+						"[op]":  op,
+						"error": err.Error(),
+					}).Error("Failed to schedule end-of-day closing balances")
+				}
+			}
+
+			// --- Schedule end-of-day compensation review ---
+			if config.CompensationReview.Enabled {
+				if err := temporalWorker.EnsureCompensationReviewSchedule(ctx, config.CompensationReview); err != nil {
+					logger.WithFields(logrus.Fields{
+						"[op]":  op,
+						"error": err.Error(),
+					}).Error("Failed to schedule end-of-day compensation review")
+				}
+			}
+
 			// --- Start Temporal worker ---
 			if err := temporalWorker.Run(ctx); err != nil {
 				logger.WithFields(logrus.Fields{
@@ -165,3 +365,74 @@ func start() {
 
 	log.Printf("Transaction service stopped gracefully")
 }
+
+// checkInMemoryFeatures refuses to start the schedules config.json switches on that the in-memory store cannot run,
+// and logs the features whose requests will fail with it
+func checkInMemoryFeatures(logger *logrus.Logger, cfg config.Config) error {
+	const op = "main.checkInMemoryFeatures"
+
+	var enabled []string
+	if cfg.Settlement.IntervalMinutes > 0 {
+		enabled = append(enabled, "settlement.interval_minutes")
+	}
+	if cfg.LedgerExport.IntervalMinutes > 0 {
+		enabled = append(enabled, "ledger_export.interval_minutes")
+	}
+	if cfg.EODBalances.Enabled {
+		enabled = append(enabled, "eod_balances.enabled")
+	}
+
+	if len(enabled) > 0 {
+		return fmt.Errorf("%s need the postgres or sqlite db driver: switch them off to use db.driver %s", strings.Join(enabled, ", "), cfg.DB.Driver)
+	}
+
+	for _, feature := range memory.UnsupportedFeatures {
+		logger.WithFields(logrus.Fields{
+			"[op]":    op,
+			"feature": feature,
+		}).Error("Not available with the in-memory store: requests for it fail")
+	}
+
+	return nil
+}
+
+// newStore returns the store selected by db.driver. The Postgres store applies the pending schema migrations first
+// when db.migrations.auto is on; the in-memory and SQLite stores return no migrator.
+func newStore(logger *logrus.Logger, cfg config.DB, appName string, demoData bool) (store.IStore, *migrations.Migrator, error) {
+	switch cfg.Driver {
+	case "", config.DriverPostgres:
+	case config.DriverMemory:
+		logger.Warn("Using the in-memory store: data is not shared with svc-balance and is lost on restart")
+
+		return memory.NewStore(logger, demoData), nil, nil
+	case config.DriverSQLite:
+		logger.WithField("path", cfg.SQLite.Path).Warn("Using the SQLite store: data is shared with svc-balance only through the same file")
+
+		sqliteStore, err := sqlite.NewStore(context.Background(), logger, cfg.SQLite.Path, demoData)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return sqliteStore, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown db driver %q, want postgres, memory or sqlite", cfg.Driver)
+	}
+
+	postgresPool, err := createPostgresPool(logger, cfg.Postgres)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	migrator, err := migrations.NewMigrator(logger, postgresPool, appName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+
+	if cfg.Migrations.Auto {
+		if _, err := migrator.Up(context.Background()); err != nil {
+			return nil, nil, fmt.Errorf("failed to apply migrations: %w", err)
+		}
+	}
+
+	return store.NewStore(logger, postgresPool), migrator, nil
+}
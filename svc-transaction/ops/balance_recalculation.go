@@ -0,0 +1,69 @@
+package ops
+
+import (
+	"context"
+	"errors"
+
+	"svc-transaction/ops/pb"
+	"svc-transaction/service"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (ops *Ops) RecalculateBalance(ctx context.Context, request *pb.RecalculateBalanceRequest) (*pb.RecalculateBalanceResponse, error) {
+	const op = "ops.Ops.RecalculateBalance"
+
+	actor := request.GetActor()
+	if actor == "" {
+		actor = anonymousActor
+	}
+
+	logger := ops.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": request.GetAccountId(),
+		"fix":        request.GetFix(),
+		"actor":      actor,
+	})
+
+	logger.Info()
+
+	accountID, err := uuid.Parse(request.GetAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid account_id")
+	}
+
+	results, err := ops.service.RecalculateBalance(ctx, service.RecalculateBalanceParams{
+		AccountID: accountID,
+		Fix:       request.GetFix(),
+		Actor:     actor,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrAccountNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, service.ErrBalanceRecalculationRejected):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, status.Error(codes.Internal, "failed to recalculate balance")
+	}
+
+	response := &pb.RecalculateBalanceResponse{
+		AccountId:        results.AccountID.String(),
+		StoredBalance:    results.StoredBalance.String(),
+		LedgerBalance:    results.LedgerBalance.String(),
+		Drift:            results.Drift.String(),
+		TransactionCount: results.TransactionCount,
+		Fixed:            results.Fixed,
+	}
+	if results.AdjustmentID != nil {
+		response.AdjustmentId = results.AdjustmentID.String()
+	}
+
+	return response, nil
+}
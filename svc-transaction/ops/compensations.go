@@ -0,0 +1,130 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"svc-transaction/ops/pb"
+	"svc-transaction/service"
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultPendingCompensationsLimit = 50
+	maxPendingCompensationsLimit     = 1000
+)
+
+func (ops *Ops) GetCompensationStats(ctx context.Context, request *pb.GetCompensationStatsRequest) (*pb.GetCompensationStatsResponse, error) {
+	const op = "ops.Ops.GetCompensationStats"
+
+	logger := ops.logger.WithFields(logrus.Fields{
+		"[op]":    op,
+		"request": fmt.Sprintf("%+v", request),
+	})
+
+	logger.Info()
+
+	filter := service.CompensationAuditFilter{}
+	if request.GetFrom() != nil {
+		filter.From = request.GetFrom().AsTime()
+	}
+	if request.GetTo() != nil {
+		filter.To = request.GetTo().AsTime()
+	}
+
+	stats, covered, err := ops.service.GetCompensationStats(ctx, filter)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCompensationFilter) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, status.Error(codes.Internal, "failed to retrieve compensation statistics")
+	}
+
+	return &pb.GetCompensationStatsResponse{
+		Total:           stats.TotalCompensations,
+		Completed:       stats.CompletedCompensations,
+		Failed:          stats.FailedCompensations,
+		Timeout:         stats.TimeoutCompensations,
+		ManualRequired:  stats.ManualCompensations,
+		Pending:         stats.PendingCompensations,
+		AverageAttempts: stats.AvgAttempts,
+		From:            timestamppb.New(covered.From),
+		To:              timestamppb.New(covered.To),
+	}, nil
+}
+
+func (ops *Ops) GetPendingCompensations(ctx context.Context, request *pb.GetPendingCompensationsRequest) (*pb.GetPendingCompensationsResponse, error) {
+	const op = "ops.Ops.GetPendingCompensations"
+
+	limit := request.GetLimit()
+	if limit == 0 {
+		limit = defaultPendingCompensationsLimit
+	}
+
+	logger := ops.logger.WithFields(logrus.Fields{
+		"[op]":  op,
+		"limit": limit,
+	})
+
+	logger.Info()
+
+	if limit < 0 || limit > maxPendingCompensationsLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxPendingCompensationsLimit)
+	}
+
+	records, err := ops.service.GetPendingCompensations(ctx, limit)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, status.Error(codes.Internal, "failed to retrieve pending compensations")
+	}
+
+	response := &pb.GetPendingCompensationsResponse{
+		Compensations: make([]*pb.Compensation, 0, len(records)),
+	}
+	for _, record := range records {
+		response.Compensations = append(response.Compensations, newCompensation(record))
+	}
+
+	return response, nil
+}
+
+func newCompensation(record sqlc.CoreCompensationAuditTrail) *pb.Compensation {
+	compensation := &pb.Compensation{
+		Id:                record.ID.String(),
+		WorkflowId:        record.WorkflowID,
+		RunId:             record.RunID,
+		TransferId:        record.TransferID.String,
+		Reason:            record.CompensationReason,
+		Type:              string(record.CompensationType),
+		Status:            string(record.CompensationStatus),
+		Attempts:          record.CompensationAttempts,
+		CreatedAt:         timestamppb.New(record.CreatedAt.Time),
+		UpdatedAt:         timestamppb.New(record.UpdatedAt.Time),
+		FailureReason:     record.FailureReason.String,
+		TimeoutDurationMs: record.TimeoutDurationMs.Int32,
+	}
+
+	// Handle nullable fields
+	if record.OriginalTransactionID.Valid {
+		compensation.OriginalTransactionId = uuid.UUID(record.OriginalTransactionID.Bytes).String()
+	}
+	if record.CompensationTransactionID.Valid {
+		compensation.CompensationTransactionId = uuid.UUID(record.CompensationTransactionID.Bytes).String()
+	}
+	if record.CompletedAt.Valid {
+		compensation.CompletedAt = timestamppb.New(record.CompletedAt.Time)
+	}
+
+	return compensation
+}
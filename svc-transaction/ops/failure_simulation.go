@@ -0,0 +1,84 @@
+package ops
+
+import (
+	"context"
+
+	"svc-transaction/ops/pb"
+	"svc-transaction/service"
+
+	"github.com/sirupsen/logrus"
+)
+
+func (ops *Ops) GetFailureSimulationStats(ctx context.Context, request *pb.GetFailureSimulationStatsRequest) (*pb.GetFailureSimulationStatsResponse, error) {
+	const op = "ops.Ops.GetFailureSimulationStats"
+
+	ops.logger.WithField("[op]", op).Info()
+
+	return &pb.GetFailureSimulationStatsResponse{
+		Stats: newFailureSimulationStats(ops.service.GetFailureSimulationStats()),
+	}, nil
+}
+
+func (ops *Ops) ResetFailureSimulation(ctx context.Context, request *pb.ResetFailureSimulationRequest) (*pb.ResetFailureSimulationResponse, error) {
+	const op = "ops.Ops.ResetFailureSimulation"
+
+	actor := request.GetActor()
+	if actor == "" {
+		actor = anonymousActor
+	}
+
+	logger := ops.logger.WithFields(logrus.Fields{
+		"[op]":  op,
+		"actor": actor,
+	})
+
+	logger.Info()
+
+	before := ops.service.GetFailureSimulationStats()
+	ops.service.ResetFailureSimulation()
+	after := ops.service.GetFailureSimulationStats()
+
+	// The reset has already taken effect, so a failure to record it is logged rather than returned
+	err := ops.service.RecordAdminAction(ctx, service.AdminAction{
+		Actor:        actor,
+		Action:       service.AdminActionResetFailureSimulation,
+		ResourceType: "failure_simulation",
+		Before:       before,
+		After:        after,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to record admin action")
+	}
+
+	return &pb.ResetFailureSimulationResponse{
+		Before: newFailureSimulationStats(before),
+		After:  newFailureSimulationStats(after),
+	}, nil
+}
+
+// newFailureSimulationStats converts the stats map of service.Service.GetFailureSimulationStats
+func newFailureSimulationStats(stats map[string]any) *pb.FailureSimulationStats {
+	response := &pb.FailureSimulationStats{
+		Occurrences: make(map[string]int64),
+	}
+
+	if uptime, ok := stats["uptime_ms"].(int64); ok {
+		response.UptimeMs = uptime
+	}
+	if occurrences, ok := stats["occurrences"].(map[string]int); ok {
+		for rule, count := range occurrences {
+			response.Occurrences[rule] = int64(count)
+		}
+	}
+	if learningMode, ok := stats["learning_mode"].(bool); ok {
+		response.LearningMode = learningMode
+	}
+	if totalRules, ok := stats["total_rules"].(int); ok {
+		response.TotalRules = int32(totalRules)
+	}
+	if enabledRules, ok := stats["enabled_rules"].(int); ok {
+		response.EnabledRules = int32(enabledRules)
+	}
+
+	return response
+}
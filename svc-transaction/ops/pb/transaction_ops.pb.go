@@ -0,0 +1,1008 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: transaction_ops.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Failure simulation stats request message
+type GetFailureSimulationStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFailureSimulationStatsRequest) Reset() {
+	*x = GetFailureSimulationStatsRequest{}
+	mi := &file_transaction_ops_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFailureSimulationStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFailureSimulationStatsRequest) ProtoMessage() {}
+
+func (x *GetFailureSimulationStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_ops_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFailureSimulationStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetFailureSimulationStatsRequest) Descriptor() ([]byte, []int) {
+	return file_transaction_ops_proto_rawDescGZIP(), []int{0}
+}
+
+// Failure simulation stats response message
+type GetFailureSimulationStatsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Stats         *FailureSimulationStats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFailureSimulationStatsResponse) Reset() {
+	*x = GetFailureSimulationStatsResponse{}
+	mi := &file_transaction_ops_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFailureSimulationStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFailureSimulationStatsResponse) ProtoMessage() {}
+
+func (x *GetFailureSimulationStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_ops_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFailureSimulationStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetFailureSimulationStatsResponse) Descriptor() ([]byte, []int) {
+	return file_transaction_ops_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetFailureSimulationStatsResponse) GetStats() *FailureSimulationStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// Failure simulation reset request message
+type ResetFailureSimulationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Actor         string                 `protobuf:"bytes,1,opt,name=actor,proto3" json:"actor,omitempty"` // Recorded in the admin audit; "anonymous" when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetFailureSimulationRequest) Reset() {
+	*x = ResetFailureSimulationRequest{}
+	mi := &file_transaction_ops_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetFailureSimulationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetFailureSimulationRequest) ProtoMessage() {}
+
+func (x *ResetFailureSimulationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_ops_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetFailureSimulationRequest.ProtoReflect.Descriptor instead.
+func (*ResetFailureSimulationRequest) Descriptor() ([]byte, []int) {
+	return file_transaction_ops_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResetFailureSimulationRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+// Failure simulation reset response message
+type ResetFailureSimulationResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Before        *FailureSimulationStats `protobuf:"bytes,1,opt,name=before,proto3" json:"before,omitempty"`
+	After         *FailureSimulationStats `protobuf:"bytes,2,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetFailureSimulationResponse) Reset() {
+	*x = ResetFailureSimulationResponse{}
+	mi := &file_transaction_ops_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetFailureSimulationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetFailureSimulationResponse) ProtoMessage() {}
+
+func (x *ResetFailureSimulationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_ops_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetFailureSimulationResponse.ProtoReflect.Descriptor instead.
+func (*ResetFailureSimulationResponse) Descriptor() ([]byte, []int) {
+	return file_transaction_ops_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ResetFailureSimulationResponse) GetBefore() *FailureSimulationStats {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *ResetFailureSimulationResponse) GetAfter() *FailureSimulationStats {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+// Counters of the failure simulator
+type FailureSimulationStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UptimeMs      int64                  `protobuf:"varint,1,opt,name=uptime_ms,json=uptimeMs,proto3" json:"uptime_ms,omitempty"`                                                                 // Time since the simulator started or was last reset
+	Occurrences   map[string]int64       `protobuf:"bytes,2,rep,name=occurrences,proto3" json:"occurrences,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Failures fired, by rule name
+	LearningMode  bool                   `protobuf:"varint,3,opt,name=learning_mode,json=learningMode,proto3" json:"learning_mode,omitempty"`
+	TotalRules    int32                  `protobuf:"varint,4,opt,name=total_rules,json=totalRules,proto3" json:"total_rules,omitempty"`
+	EnabledRules  int32                  `protobuf:"varint,5,opt,name=enabled_rules,json=enabledRules,proto3" json:"enabled_rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FailureSimulationStats) Reset() {
+	*x = FailureSimulationStats{}
+	mi := &file_transaction_ops_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailureSimulationStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailureSimulationStats) ProtoMessage() {}
+
+func (x *FailureSimulationStats) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_ops_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailureSimulationStats.ProtoReflect.Descriptor instead.
+func (*FailureSimulationStats) Descriptor() ([]byte, []int) {
+	return file_transaction_ops_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FailureSimulationStats) GetUptimeMs() int64 {
+	if x != nil {
+		return x.UptimeMs
+	}
+	return 0
+}
+
+func (x *FailureSimulationStats) GetOccurrences() map[string]int64 {
+	if x != nil {
+		return x.Occurrences
+	}
+	return nil
+}
+
+func (x *FailureSimulationStats) GetLearningMode() bool {
+	if x != nil {
+		return x.LearningMode
+	}
+	return false
+}
+
+func (x *FailureSimulationStats) GetTotalRules() int32 {
+	if x != nil {
+		return x.TotalRules
+	}
+	return 0
+}
+
+func (x *FailureSimulationStats) GetEnabledRules() int32 {
+	if x != nil {
+		return x.EnabledRules
+	}
+	return 0
+}
+
+// Compensation stats request message
+type GetCompensationStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"` // Defaults to 24 hours before to
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`     // Defaults to now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCompensationStatsRequest) Reset() {
+	*x = GetCompensationStatsRequest{}
+	mi := &file_transaction_ops_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCompensationStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCompensationStatsRequest) ProtoMessage() {}
+
+func (x *GetCompensationStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_ops_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCompensationStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetCompensationStatsRequest) Descriptor() ([]byte, []int) {
+	return file_transaction_ops_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCompensationStatsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetCompensationStatsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// Compensation stats response message
+type GetCompensationStatsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Total           int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Completed       int64                  `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
+	Failed          int64                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	Timeout         int64                  `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	ManualRequired  int64                  `protobuf:"varint,5,opt,name=manual_required,json=manualRequired,proto3" json:"manual_required,omitempty"`
+	Pending         int64                  `protobuf:"varint,6,opt,name=pending,proto3" json:"pending,omitempty"`
+	AverageAttempts float64                `protobuf:"fixed64,7,opt,name=average_attempts,json=averageAttempts,proto3" json:"average_attempts,omitempty"`
+	From            *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=from,proto3" json:"from,omitempty"` // Range actually covered
+	To              *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetCompensationStatsResponse) Reset() {
+	*x = GetCompensationStatsResponse{}
+	mi := &file_transaction_ops_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCompensationStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCompensationStatsResponse) ProtoMessage() {}
+
+func (x *GetCompensationStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_ops_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCompensationStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetCompensationStatsResponse) Descriptor() ([]byte, []int) {
+	return file_transaction_ops_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCompensationStatsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetCompensationStatsResponse) GetCompleted() int64 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *GetCompensationStatsResponse) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *GetCompensationStatsResponse) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *GetCompensationStatsResponse) GetManualRequired() int64 {
+	if x != nil {
+		return x.ManualRequired
+	}
+	return 0
+}
+
+func (x *GetCompensationStatsResponse) GetPending() int64 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *GetCompensationStatsResponse) GetAverageAttempts() float64 {
+	if x != nil {
+		return x.AverageAttempts
+	}
+	return 0
+}
+
+func (x *GetCompensationStatsResponse) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetCompensationStatsResponse) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// Pending compensations request message
+type GetPendingCompensationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"` // 1 to 1000, defaults to 50
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPendingCompensationsRequest) Reset() {
+	*x = GetPendingCompensationsRequest{}
+	mi := &file_transaction_ops_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPendingCompensationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPendingCompensationsRequest) ProtoMessage() {}
+
+func (x *GetPendingCompensationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_ops_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPendingCompensationsRequest.ProtoReflect.Descriptor instead.
+func (*GetPendingCompensationsRequest) Descriptor() ([]byte, []int) {
+	return file_transaction_ops_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetPendingCompensationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Pending compensations response message
+type GetPendingCompensationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Compensations []*Compensation        `protobuf:"bytes,1,rep,name=compensations,proto3" json:"compensations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPendingCompensationsResponse) Reset() {
+	*x = GetPendingCompensationsResponse{}
+	mi := &file_transaction_ops_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPendingCompensationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPendingCompensationsResponse) ProtoMessage() {}
+
+func (x *GetPendingCompensationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_ops_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPendingCompensationsResponse.ProtoReflect.Descriptor instead.
+func (*GetPendingCompensationsResponse) Descriptor() ([]byte, []int) {
+	return file_transaction_ops_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetPendingCompensationsResponse) GetCompensations() []*Compensation {
+	if x != nil {
+		return x.Compensations
+	}
+	return nil
+}
+
+// A compensation audit record
+type Compensation struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	Id                        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	WorkflowId                string                 `protobuf:"bytes,2,opt,name=workflow_id,json=workflowId,proto3" json:"workflow_id,omitempty"`
+	RunId                     string                 `protobuf:"bytes,3,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	TransferId                string                 `protobuf:"bytes,4,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	OriginalTransactionId     string                 `protobuf:"bytes,5,opt,name=original_transaction_id,json=originalTransactionId,proto3" json:"original_transaction_id,omitempty"`
+	CompensationTransactionId string                 `protobuf:"bytes,6,opt,name=compensation_transaction_id,json=compensationTransactionId,proto3" json:"compensation_transaction_id,omitempty"`
+	Reason                    string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	Type                      string                 `protobuf:"bytes,8,opt,name=type,proto3" json:"type,omitempty"`
+	Status                    string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts                  int32                  `protobuf:"varint,10,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	CreatedAt                 *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt                 *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CompletedAt               *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	FailureReason             string                 `protobuf:"bytes,14,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	TimeoutDurationMs         int32                  `protobuf:"varint,15,opt,name=timeout_duration_ms,json=timeoutDurationMs,proto3" json:"timeout_duration_ms,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *Compensation) Reset() {
+	*x = Compensation{}
+	mi := &file_transaction_ops_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Compensation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Compensation) ProtoMessage() {}
+
+func (x *Compensation) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_ops_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Compensation.ProtoReflect.Descriptor instead.
+func (*Compensation) Descriptor() ([]byte, []int) {
+	return file_transaction_ops_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Compensation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Compensation) GetWorkflowId() string {
+	if x != nil {
+		return x.WorkflowId
+	}
+	return ""
+}
+
+func (x *Compensation) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *Compensation) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *Compensation) GetOriginalTransactionId() string {
+	if x != nil {
+		return x.OriginalTransactionId
+	}
+	return ""
+}
+
+func (x *Compensation) GetCompensationTransactionId() string {
+	if x != nil {
+		return x.CompensationTransactionId
+	}
+	return ""
+}
+
+func (x *Compensation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Compensation) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Compensation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Compensation) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Compensation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Compensation) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Compensation) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Compensation) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *Compensation) GetTimeoutDurationMs() int32 {
+	if x != nil {
+		return x.TimeoutDurationMs
+	}
+	return 0
+}
+
+// Balance recalculation request message
+type RecalculateBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Fix           bool                   `protobuf:"varint,2,opt,name=fix,proto3" json:"fix,omitempty"`    // Correct any drift; otherwise the drift is only reported
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"` // Recorded in the admin audit; "anonymous" when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecalculateBalanceRequest) Reset() {
+	*x = RecalculateBalanceRequest{}
+	mi := &file_transaction_ops_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecalculateBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecalculateBalanceRequest) ProtoMessage() {}
+
+func (x *RecalculateBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_ops_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecalculateBalanceRequest.ProtoReflect.Descriptor instead.
+func (*RecalculateBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_transaction_ops_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RecalculateBalanceRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *RecalculateBalanceRequest) GetFix() bool {
+	if x != nil {
+		return x.Fix
+	}
+	return false
+}
+
+func (x *RecalculateBalanceRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+// Balance recalculation response message; balances are decimal strings in major units
+type RecalculateBalanceResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AccountId        string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	StoredBalance    string                 `protobuf:"bytes,2,opt,name=stored_balance,json=storedBalance,proto3" json:"stored_balance,omitempty"` // Balance of the account before any correction
+	LedgerBalance    string                 `protobuf:"bytes,3,opt,name=ledger_balance,json=ledgerBalance,proto3" json:"ledger_balance,omitempty"` // Balance implied by the completed transactions
+	Drift            string                 `protobuf:"bytes,4,opt,name=drift,proto3" json:"drift,omitempty"`                                      // ledger_balance - stored_balance
+	TransactionCount int64                  `protobuf:"varint,5,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	Fixed            bool                   `protobuf:"varint,6,opt,name=fixed,proto3" json:"fixed,omitempty"`
+	AdjustmentId     string                 `protobuf:"bytes,7,opt,name=adjustment_id,json=adjustmentId,proto3" json:"adjustment_id,omitempty"` // Balance history entry of the correction, empty when nothing was fixed
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RecalculateBalanceResponse) Reset() {
+	*x = RecalculateBalanceResponse{}
+	mi := &file_transaction_ops_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecalculateBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecalculateBalanceResponse) ProtoMessage() {}
+
+func (x *RecalculateBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_ops_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecalculateBalanceResponse.ProtoReflect.Descriptor instead.
+func (*RecalculateBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_transaction_ops_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RecalculateBalanceResponse) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *RecalculateBalanceResponse) GetStoredBalance() string {
+	if x != nil {
+		return x.StoredBalance
+	}
+	return ""
+}
+
+func (x *RecalculateBalanceResponse) GetLedgerBalance() string {
+	if x != nil {
+		return x.LedgerBalance
+	}
+	return ""
+}
+
+func (x *RecalculateBalanceResponse) GetDrift() string {
+	if x != nil {
+		return x.Drift
+	}
+	return ""
+}
+
+func (x *RecalculateBalanceResponse) GetTransactionCount() int64 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+func (x *RecalculateBalanceResponse) GetFixed() bool {
+	if x != nil {
+		return x.Fixed
+	}
+	return false
+}
+
+func (x *RecalculateBalanceResponse) GetAdjustmentId() string {
+	if x != nil {
+		return x.AdjustmentId
+	}
+	return ""
+}
+
+var File_transaction_ops_proto protoreflect.FileDescriptor
+
+const file_transaction_ops_proto_rawDesc = "" +
+	"\n" +
+	"\x15transaction_ops.proto\x12\x0etransactionops\x1a\x1fgoogle/protobuf/timestamp.proto\"\"\n" +
+	" GetFailureSimulationStatsRequest\"a\n" +
+	"!GetFailureSimulationStatsResponse\x12<\n" +
+	"\x05stats\x18\x01 \x01(\v2&.transactionops.FailureSimulationStatsR\x05stats\"5\n" +
+	"\x1dResetFailureSimulationRequest\x12\x14\n" +
+	"\x05actor\x18\x01 \x01(\tR\x05actor\"\x9e\x01\n" +
+	"\x1eResetFailureSimulationResponse\x12>\n" +
+	"\x06before\x18\x01 \x01(\v2&.transactionops.FailureSimulationStatsR\x06before\x12<\n" +
+	"\x05after\x18\x02 \x01(\v2&.transactionops.FailureSimulationStatsR\x05after\"\xbb\x02\n" +
+	"\x16FailureSimulationStats\x12\x1b\n" +
+	"\tuptime_ms\x18\x01 \x01(\x03R\buptimeMs\x12Y\n" +
+	"\voccurrences\x18\x02 \x03(\v27.transactionops.FailureSimulationStats.OccurrencesEntryR\voccurrences\x12#\n" +
+	"\rlearning_mode\x18\x03 \x01(\bR\flearningMode\x12\x1f\n" +
+	"\vtotal_rules\x18\x04 \x01(\x05R\n" +
+	"totalRules\x12#\n" +
+	"\renabled_rules\x18\x05 \x01(\x05R\fenabledRules\x1a>\n" +
+	"\x10OccurrencesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"y\n" +
+	"\x1bGetCompensationStatsRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\xce\x02\n" +
+	"\x1cGetCompensationStatsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x1c\n" +
+	"\tcompleted\x18\x02 \x01(\x03R\tcompleted\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x03R\x06failed\x12\x18\n" +
+	"\atimeout\x18\x04 \x01(\x03R\atimeout\x12'\n" +
+	"\x0fmanual_required\x18\x05 \x01(\x03R\x0emanualRequired\x12\x18\n" +
+	"\apending\x18\x06 \x01(\x03R\apending\x12)\n" +
+	"\x10average_attempts\x18\a \x01(\x01R\x0faverageAttempts\x12.\n" +
+	"\x04from\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"6\n" +
+	"\x1eGetPendingCompensationsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\"e\n" +
+	"\x1fGetPendingCompensationsResponse\x12B\n" +
+	"\rcompensations\x18\x01 \x03(\v2\x1c.transactionops.CompensationR\rcompensations\"\xdb\x04\n" +
+	"\fCompensation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vworkflow_id\x18\x02 \x01(\tR\n" +
+	"workflowId\x12\x15\n" +
+	"\x06run_id\x18\x03 \x01(\tR\x05runId\x12\x1f\n" +
+	"\vtransfer_id\x18\x04 \x01(\tR\n" +
+	"transferId\x126\n" +
+	"\x17original_transaction_id\x18\x05 \x01(\tR\x15originalTransactionId\x12>\n" +
+	"\x1bcompensation_transaction_id\x18\x06 \x01(\tR\x19compensationTransactionId\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x12\n" +
+	"\x04type\x18\b \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x1a\n" +
+	"\battempts\x18\n" +
+	" \x01(\x05R\battempts\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12=\n" +
+	"\fcompleted_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12%\n" +
+	"\x0efailure_reason\x18\x0e \x01(\tR\rfailureReason\x12.\n" +
+	"\x13timeout_duration_ms\x18\x0f \x01(\x05R\x11timeoutDurationMs\"b\n" +
+	"\x19RecalculateBalanceRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x10\n" +
+	"\x03fix\x18\x02 \x01(\bR\x03fix\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\"\x87\x02\n" +
+	"\x1aRecalculateBalanceResponse\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12%\n" +
+	"\x0estored_balance\x18\x02 \x01(\tR\rstoredBalance\x12%\n" +
+	"\x0eledger_balance\x18\x03 \x01(\tR\rledgerBalance\x12\x14\n" +
+	"\x05drift\x18\x04 \x01(\tR\x05drift\x12+\n" +
+	"\x11transaction_count\x18\x05 \x01(\x03R\x10transactionCount\x12\x14\n" +
+	"\x05fixed\x18\x06 \x01(\bR\x05fixed\x12#\n" +
+	"\radjustment_id\x18\a \x01(\tR\fadjustmentId2\xe8\x04\n" +
+	"\x0eTransactionOps\x12\x80\x01\n" +
+	"\x19GetFailureSimulationStats\x120.transactionops.GetFailureSimulationStatsRequest\x1a1.transactionops.GetFailureSimulationStatsResponse\x12w\n" +
+	"\x16ResetFailureSimulation\x12-.transactionops.ResetFailureSimulationRequest\x1a..transactionops.ResetFailureSimulationResponse\x12q\n" +
+	"\x14GetCompensationStats\x12+.transactionops.GetCompensationStatsRequest\x1a,.transactionops.GetCompensationStatsResponse\x12z\n" +
+	"\x17GetPendingCompensations\x12..transactionops.GetPendingCompensationsRequest\x1a/.transactionops.GetPendingCompensationsResponse\x12k\n" +
+	"\x12RecalculateBalance\x12).transactionops.RecalculateBalanceRequest\x1a*.transactionops.RecalculateBalanceResponseB\x06Z\x04./pbb\x06proto3"
+
+var (
+	file_transaction_ops_proto_rawDescOnce sync.Once
+	file_transaction_ops_proto_rawDescData []byte
+)
+
+func file_transaction_ops_proto_rawDescGZIP() []byte {
+	file_transaction_ops_proto_rawDescOnce.Do(func() {
+		file_transaction_ops_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_transaction_ops_proto_rawDesc), len(file_transaction_ops_proto_rawDesc)))
+	})
+	return file_transaction_ops_proto_rawDescData
+}
+
+var file_transaction_ops_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_transaction_ops_proto_goTypes = []any{
+	(*GetFailureSimulationStatsRequest)(nil),  // 0: transactionops.GetFailureSimulationStatsRequest
+	(*GetFailureSimulationStatsResponse)(nil), // 1: transactionops.GetFailureSimulationStatsResponse
+	(*ResetFailureSimulationRequest)(nil),     // 2: transactionops.ResetFailureSimulationRequest
+	(*ResetFailureSimulationResponse)(nil),    // 3: transactionops.ResetFailureSimulationResponse
+	(*FailureSimulationStats)(nil),            // 4: transactionops.FailureSimulationStats
+	(*GetCompensationStatsRequest)(nil),       // 5: transactionops.GetCompensationStatsRequest
+	(*GetCompensationStatsResponse)(nil),      // 6: transactionops.GetCompensationStatsResponse
+	(*GetPendingCompensationsRequest)(nil),    // 7: transactionops.GetPendingCompensationsRequest
+	(*GetPendingCompensationsResponse)(nil),   // 8: transactionops.GetPendingCompensationsResponse
+	(*Compensation)(nil),                      // 9: transactionops.Compensation
+	(*RecalculateBalanceRequest)(nil),         // 10: transactionops.RecalculateBalanceRequest
+	(*RecalculateBalanceResponse)(nil),        // 11: transactionops.RecalculateBalanceResponse
+	nil,                                       // 12: transactionops.FailureSimulationStats.OccurrencesEntry
+	(*timestamppb.Timestamp)(nil),             // 13: google.protobuf.Timestamp
+}
+var file_transaction_ops_proto_depIdxs = []int32{
+	4,  // 0: transactionops.GetFailureSimulationStatsResponse.stats:type_name -> transactionops.FailureSimulationStats
+	4,  // 1: transactionops.ResetFailureSimulationResponse.before:type_name -> transactionops.FailureSimulationStats
+	4,  // 2: transactionops.ResetFailureSimulationResponse.after:type_name -> transactionops.FailureSimulationStats
+	12, // 3: transactionops.FailureSimulationStats.occurrences:type_name -> transactionops.FailureSimulationStats.OccurrencesEntry
+	13, // 4: transactionops.GetCompensationStatsRequest.from:type_name -> google.protobuf.Timestamp
+	13, // 5: transactionops.GetCompensationStatsRequest.to:type_name -> google.protobuf.Timestamp
+	13, // 6: transactionops.GetCompensationStatsResponse.from:type_name -> google.protobuf.Timestamp
+	13, // 7: transactionops.GetCompensationStatsResponse.to:type_name -> google.protobuf.Timestamp
+	9,  // 8: transactionops.GetPendingCompensationsResponse.compensations:type_name -> transactionops.Compensation
+	13, // 9: transactionops.Compensation.created_at:type_name -> google.protobuf.Timestamp
+	13, // 10: transactionops.Compensation.updated_at:type_name -> google.protobuf.Timestamp
+	13, // 11: transactionops.Compensation.completed_at:type_name -> google.protobuf.Timestamp
+	0,  // 12: transactionops.TransactionOps.GetFailureSimulationStats:input_type -> transactionops.GetFailureSimulationStatsRequest
+	2,  // 13: transactionops.TransactionOps.ResetFailureSimulation:input_type -> transactionops.ResetFailureSimulationRequest
+	5,  // 14: transactionops.TransactionOps.GetCompensationStats:input_type -> transactionops.GetCompensationStatsRequest
+	7,  // 15: transactionops.TransactionOps.GetPendingCompensations:input_type -> transactionops.GetPendingCompensationsRequest
+	10, // 16: transactionops.TransactionOps.RecalculateBalance:input_type -> transactionops.RecalculateBalanceRequest
+	1,  // 17: transactionops.TransactionOps.GetFailureSimulationStats:output_type -> transactionops.GetFailureSimulationStatsResponse
+	3,  // 18: transactionops.TransactionOps.ResetFailureSimulation:output_type -> transactionops.ResetFailureSimulationResponse
+	6,  // 19: transactionops.TransactionOps.GetCompensationStats:output_type -> transactionops.GetCompensationStatsResponse
+	8,  // 20: transactionops.TransactionOps.GetPendingCompensations:output_type -> transactionops.GetPendingCompensationsResponse
+	11, // 21: transactionops.TransactionOps.RecalculateBalance:output_type -> transactionops.RecalculateBalanceResponse
+	17, // [17:22] is the sub-list for method output_type
+	12, // [12:17] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_transaction_ops_proto_init() }
+func file_transaction_ops_proto_init() {
+	if File_transaction_ops_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_transaction_ops_proto_rawDesc), len(file_transaction_ops_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_transaction_ops_proto_goTypes,
+		DependencyIndexes: file_transaction_ops_proto_depIdxs,
+		MessageInfos:      file_transaction_ops_proto_msgTypes,
+	}.Build()
+	File_transaction_ops_proto = out.File
+	file_transaction_ops_proto_goTypes = nil
+	file_transaction_ops_proto_depIdxs = nil
+}
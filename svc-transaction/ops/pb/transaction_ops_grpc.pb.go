@@ -0,0 +1,287 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.31.0
+// source: transaction_ops.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TransactionOps_GetFailureSimulationStats_FullMethodName = "/transactionops.TransactionOps/GetFailureSimulationStats"
+	TransactionOps_ResetFailureSimulation_FullMethodName    = "/transactionops.TransactionOps/ResetFailureSimulation"
+	TransactionOps_GetCompensationStats_FullMethodName      = "/transactionops.TransactionOps/GetCompensationStats"
+	TransactionOps_GetPendingCompensations_FullMethodName   = "/transactionops.TransactionOps/GetPendingCompensations"
+	TransactionOps_RecalculateBalance_FullMethodName        = "/transactionops.TransactionOps/RecalculateBalance"
+)
+
+// TransactionOpsClient is the client API for TransactionOps service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TransactionOps exposes the operational controls of svc-transaction to the api-gateway admin API
+type TransactionOpsClient interface {
+	// GetFailureSimulationStats reports how often each simulated failure fired since the last reset
+	GetFailureSimulationStats(ctx context.Context, in *GetFailureSimulationStatsRequest, opts ...grpc.CallOption) (*GetFailureSimulationStatsResponse, error)
+	// ResetFailureSimulation clears the failure simulation counters and starts a new learning session
+	ResetFailureSimulation(ctx context.Context, in *ResetFailureSimulationRequest, opts ...grpc.CallOption) (*ResetFailureSimulationResponse, error)
+	// GetCompensationStats counts compensation audit records by outcome
+	GetCompensationStats(ctx context.Context, in *GetCompensationStatsRequest, opts ...grpc.CallOption) (*GetCompensationStatsResponse, error)
+	// GetPendingCompensations lists the compensations still waiting to complete, oldest first
+	GetPendingCompensations(ctx context.Context, in *GetPendingCompensationsRequest, opts ...grpc.CallOption) (*GetPendingCompensationsResponse, error)
+	// RecalculateBalance recomputes an account balance from its transactions and optionally corrects the drift
+	RecalculateBalance(ctx context.Context, in *RecalculateBalanceRequest, opts ...grpc.CallOption) (*RecalculateBalanceResponse, error)
+}
+
+type transactionOpsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTransactionOpsClient(cc grpc.ClientConnInterface) TransactionOpsClient {
+	return &transactionOpsClient{cc}
+}
+
+func (c *transactionOpsClient) GetFailureSimulationStats(ctx context.Context, in *GetFailureSimulationStatsRequest, opts ...grpc.CallOption) (*GetFailureSimulationStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFailureSimulationStatsResponse)
+	err := c.cc.Invoke(ctx, TransactionOps_GetFailureSimulationStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionOpsClient) ResetFailureSimulation(ctx context.Context, in *ResetFailureSimulationRequest, opts ...grpc.CallOption) (*ResetFailureSimulationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetFailureSimulationResponse)
+	err := c.cc.Invoke(ctx, TransactionOps_ResetFailureSimulation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionOpsClient) GetCompensationStats(ctx context.Context, in *GetCompensationStatsRequest, opts ...grpc.CallOption) (*GetCompensationStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCompensationStatsResponse)
+	err := c.cc.Invoke(ctx, TransactionOps_GetCompensationStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionOpsClient) GetPendingCompensations(ctx context.Context, in *GetPendingCompensationsRequest, opts ...grpc.CallOption) (*GetPendingCompensationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPendingCompensationsResponse)
+	err := c.cc.Invoke(ctx, TransactionOps_GetPendingCompensations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionOpsClient) RecalculateBalance(ctx context.Context, in *RecalculateBalanceRequest, opts ...grpc.CallOption) (*RecalculateBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecalculateBalanceResponse)
+	err := c.cc.Invoke(ctx, TransactionOps_RecalculateBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TransactionOpsServer is the server API for TransactionOps service.
+// All implementations must embed UnimplementedTransactionOpsServer
+// for forward compatibility.
+//
+// TransactionOps exposes the operational controls of svc-transaction to the api-gateway admin API
+type TransactionOpsServer interface {
+	// GetFailureSimulationStats reports how often each simulated failure fired since the last reset
+	GetFailureSimulationStats(context.Context, *GetFailureSimulationStatsRequest) (*GetFailureSimulationStatsResponse, error)
+	// ResetFailureSimulation clears the failure simulation counters and starts a new learning session
+	ResetFailureSimulation(context.Context, *ResetFailureSimulationRequest) (*ResetFailureSimulationResponse, error)
+	// GetCompensationStats counts compensation audit records by outcome
+	GetCompensationStats(context.Context, *GetCompensationStatsRequest) (*GetCompensationStatsResponse, error)
+	// GetPendingCompensations lists the compensations still waiting to complete, oldest first
+	GetPendingCompensations(context.Context, *GetPendingCompensationsRequest) (*GetPendingCompensationsResponse, error)
+	// RecalculateBalance recomputes an account balance from its transactions and optionally corrects the drift
+	RecalculateBalance(context.Context, *RecalculateBalanceRequest) (*RecalculateBalanceResponse, error)
+	mustEmbedUnimplementedTransactionOpsServer()
+}
+
+// UnimplementedTransactionOpsServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTransactionOpsServer struct{}
+
+func (UnimplementedTransactionOpsServer) GetFailureSimulationStats(context.Context, *GetFailureSimulationStatsRequest) (*GetFailureSimulationStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFailureSimulationStats not implemented")
+}
+func (UnimplementedTransactionOpsServer) ResetFailureSimulation(context.Context, *ResetFailureSimulationRequest) (*ResetFailureSimulationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetFailureSimulation not implemented")
+}
+func (UnimplementedTransactionOpsServer) GetCompensationStats(context.Context, *GetCompensationStatsRequest) (*GetCompensationStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCompensationStats not implemented")
+}
+func (UnimplementedTransactionOpsServer) GetPendingCompensations(context.Context, *GetPendingCompensationsRequest) (*GetPendingCompensationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPendingCompensations not implemented")
+}
+func (UnimplementedTransactionOpsServer) RecalculateBalance(context.Context, *RecalculateBalanceRequest) (*RecalculateBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecalculateBalance not implemented")
+}
+func (UnimplementedTransactionOpsServer) mustEmbedUnimplementedTransactionOpsServer() {}
+func (UnimplementedTransactionOpsServer) testEmbeddedByValue()                        {}
+
+// UnsafeTransactionOpsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TransactionOpsServer will
+// result in compilation errors.
+type UnsafeTransactionOpsServer interface {
+	mustEmbedUnimplementedTransactionOpsServer()
+}
+
+func RegisterTransactionOpsServer(s grpc.ServiceRegistrar, srv TransactionOpsServer) {
+	// If the following call pancis, it indicates UnimplementedTransactionOpsServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TransactionOps_ServiceDesc, srv)
+}
+
+func _TransactionOps_GetFailureSimulationStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFailureSimulationStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionOpsServer).GetFailureSimulationStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionOps_GetFailureSimulationStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionOpsServer).GetFailureSimulationStats(ctx, req.(*GetFailureSimulationStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionOps_ResetFailureSimulation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetFailureSimulationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionOpsServer).ResetFailureSimulation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionOps_ResetFailureSimulation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionOpsServer).ResetFailureSimulation(ctx, req.(*ResetFailureSimulationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionOps_GetCompensationStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCompensationStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionOpsServer).GetCompensationStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionOps_GetCompensationStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionOpsServer).GetCompensationStats(ctx, req.(*GetCompensationStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionOps_GetPendingCompensations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPendingCompensationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionOpsServer).GetPendingCompensations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionOps_GetPendingCompensations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionOpsServer).GetPendingCompensations(ctx, req.(*GetPendingCompensationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionOps_RecalculateBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecalculateBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionOpsServer).RecalculateBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionOps_RecalculateBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionOpsServer).RecalculateBalance(ctx, req.(*RecalculateBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TransactionOps_ServiceDesc is the grpc.ServiceDesc for TransactionOps service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TransactionOps_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "transactionops.TransactionOps",
+	HandlerType: (*TransactionOpsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetFailureSimulationStats",
+			Handler:    _TransactionOps_GetFailureSimulationStats_Handler,
+		},
+		{
+			MethodName: "ResetFailureSimulation",
+			Handler:    _TransactionOps_ResetFailureSimulation_Handler,
+		},
+		{
+			MethodName: "GetCompensationStats",
+			Handler:    _TransactionOps_GetCompensationStats_Handler,
+		},
+		{
+			MethodName: "GetPendingCompensations",
+			Handler:    _TransactionOps_GetPendingCompensations_Handler,
+		},
+		{
+			MethodName: "RecalculateBalance",
+			Handler:    _TransactionOps_RecalculateBalance_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "transaction_ops.proto",
+}
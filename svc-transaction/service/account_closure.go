@@ -0,0 +1,576 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+)
+
+// AccountClosureWorkflowName is the name AccountClosureWorkflow is registered under by the worker
+const AccountClosureWorkflowName = "AccountClosureWorkflow"
+
+// DefaultAccountClosureDrainTimeout is how long a closure waits for in-flight transfers when no timeout is given
+const DefaultAccountClosureDrainTimeout = 30 * time.Minute
+
+// Steps recorded in core.account_closure_audit_trail, in the order a successful closure records them.
+// A closure that cannot finish records reopened instead of the remaining steps.
+const (
+	AccountClosureStepBlocked  = "blocked"
+	AccountClosureStepDrained  = "drained"
+	AccountClosureStepSwept    = "swept"
+	AccountClosureStepClosed   = "closed"
+	AccountClosureStepReopened = "reopened"
+)
+
+// ErrAccountClosureRejected is returned when an account cannot be closed, e.g. it is already closed
+// or the sweep account is not an active account of the same currency
+var ErrAccountClosureRejected = errors.New("account closure rejected")
+
+// ErrAccountClosureInProgress is returned when a closure workflow is already running for the account
+var ErrAccountClosureInProgress = errors.New("account closure already in progress")
+
+// ErrAccountNotFound is returned when the account to close or the sweep account does not exist
+var ErrAccountNotFound = errors.New("account not found")
+
+// inFlightTransfersQuery finds running transfer workflows of an account through the search attributes
+// flowngine sets on every transfer workflow. They hold the accounts as the client gave them, usually account
+// numbers, and the account ID for a destination resolved from an alias, so both are matched.
+const inFlightTransfersQuery = "WorkflowType IN ('transferWorkflow', 'conditionalTransferWorkflow') AND ExecutionStatus = 'Running' AND " +
+	"(TransferSourceAccount IN ('%[1]s', '%[2]s') OR TransferDestinationAccount IN ('%[1]s', '%[2]s'))"
+
+// StartAccountClosureParams represents the input parameters for closing an account
+type StartAccountClosureParams struct {
+	AccountID      uuid.UUID     `json:"account_id"`
+	SweepAccountID uuid.UUID     `json:"sweep_account_id"`
+	DrainTimeout   time.Duration `json:"drain_timeout"`
+	RequestedBy    string        `json:"requested_by,omitempty"`
+}
+
+// StartAccountClosureResults identifies the started closure workflow
+type StartAccountClosureResults struct {
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+}
+
+// AccountClosureWorkflowParams is the input of AccountClosureWorkflow
+type AccountClosureWorkflowParams struct {
+	AccountID      string        `json:"account_id"`
+	SweepAccountID string        `json:"sweep_account_id"`
+	DrainTimeout   time.Duration `json:"drain_timeout"`
+	RequestedBy    string        `json:"requested_by,omitempty"`
+}
+
+// AccountClosureStepParams identifies the account and the closure workflow run a step belongs to
+type AccountClosureStepParams struct {
+	AccountID  uuid.UUID `json:"account_id"`
+	WorkflowID string    `json:"workflow_id"`
+	RunID      string    `json:"run_id"`
+}
+
+// BlockAccountForClosureResults is recorded as the details of the blocked step
+type BlockAccountForClosureResults struct {
+	PreviousStatus string `json:"previous_status"`
+	SweepAccountID string `json:"sweep_account_id"`
+}
+
+// SweepAccountBalanceResults is recorded as the details of the swept step.
+// TransferID is empty when the account had no balance left to sweep.
+type SweepAccountBalanceResults struct {
+	TransferID     string          `json:"transfer_id,omitempty"`
+	SweepAccountID string          `json:"sweep_account_id"`
+	Amount         decimal.Decimal `json:"amount"`
+	Currency       string          `json:"currency"`
+}
+
+// ReopenAccountResults is recorded as the details of the reopened step
+type ReopenAccountResults struct {
+	RestoredStatus string `json:"restored_status"`
+	Reason         string `json:"reason"`
+}
+
+// AccountClosureStep is one entry of an account's closure audit trail
+type AccountClosureStep struct {
+	ID         uuid.UUID       `json:"id"`
+	WorkflowID string          `json:"workflow_id"`
+	RunID      string          `json:"run_id"`
+	Step       string          `json:"step"`
+	Details    json.RawMessage `json:"details,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// accountClosureWorkflowID allows a single running closure per account
+func accountClosureWorkflowID(accountID uuid.UUID) string {
+	return fmt.Sprintf("account_closure_%s", accountID)
+}
+
+// StartAccountClosure checks that the account can be closed and starts AccountClosureWorkflow for it.
+// The workflow itself re-checks everything once the account is locked.
+func (service *Service) StartAccountClosure(ctx context.Context, params StartAccountClosureParams) (*StartAccountClosureResults, error) {
+	const op = "service.Service.StartAccountClosure"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	if params.AccountID == uuid.Nil || params.SweepAccountID == uuid.Nil {
+		return nil, fmt.Errorf("%w: account_id and sweep_account_id are required", ErrAccountClosureRejected)
+	}
+	if params.DrainTimeout < 0 {
+		return nil, fmt.Errorf("%w: drain timeout cannot be negative", ErrAccountClosureRejected)
+	}
+	if params.DrainTimeout == 0 {
+		params.DrainTimeout = DefaultAccountClosureDrainTimeout
+	}
+
+	account, err := service.getAccount(ctx, service.store.GetAccountByID, params.AccountID)
+	if err != nil {
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+	sweepAccount, err := service.getAccount(ctx, service.store.GetAccountByID, params.SweepAccountID)
+	if err != nil {
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+	if err := checkAccountClosureRules(account, sweepAccount); err != nil {
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	if service.temporalClient == nil {
+		err := fmt.Errorf("temporal client not available - service is starting up")
+
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	run, err := service.temporalClient.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:                                       accountClosureWorkflowID(params.AccountID),
+		TaskQueue:                                service.taskQueue,
+		WorkflowExecutionErrorWhenAlreadyStarted: true,
+	}, AccountClosureWorkflowName, AccountClosureWorkflowParams{
+		AccountID:      params.AccountID.String(),
+		SweepAccountID: params.SweepAccountID.String(),
+		DrainTimeout:   params.DrainTimeout,
+		RequestedBy:    params.RequestedBy,
+	})
+	if err != nil {
+		var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+		if errors.As(err, &alreadyStarted) {
+			err = fmt.Errorf("%w: %s", ErrAccountClosureInProgress, accountClosureWorkflowID(params.AccountID))
+		} else {
+			err = fmt.Errorf("failed to start account closure workflow: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &StartAccountClosureResults{
+		WorkflowID: run.GetID(),
+		RunID:      run.GetRunID(),
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Account closure started")
+
+	return results, nil
+}
+
+// GetAccountClosureSteps returns the closure audit trail of an account, oldest step first
+func (service *Service) GetAccountClosureSteps(ctx context.Context, accountID uuid.UUID) ([]AccountClosureStep, error) {
+	records, err := service.store.ListAccountClosureSteps(ctx, pgtype.UUID{Bytes: accountID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account closure steps: %w", err)
+	}
+
+	steps := make([]AccountClosureStep, 0, len(records))
+	for _, record := range records {
+		steps = append(steps, AccountClosureStep{
+			ID:         uuid.UUID(record.ID.Bytes),
+			WorkflowID: record.WorkflowID,
+			RunID:      record.RunID,
+			Step:       record.Step,
+			Details:    record.Details,
+			CreatedAt:  record.CreatedAt.Time,
+		})
+	}
+
+	return steps, nil
+}
+
+// BlockAccountForClosure suspends the account so no new transfer can debit or credit it.
+// The status it had before is recorded so a closure that cannot finish can restore it.
+func (service *Service) BlockAccountForClosure(ctx context.Context, params AccountClosureStepParams, sweepAccountID uuid.UUID) (*BlockAccountForClosureResults, error) {
+	const op = "service.Service.BlockAccountForClosure"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	var results BlockAccountForClosureResults
+	err := service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		if recorded, err := getAccountClosureStep(ctx, q, params, AccountClosureStepBlocked, &results); err != nil || recorded {
+			return err
+		}
+
+		account, _, err := service.lockAccount(ctx, q, pgtype.UUID{Bytes: params.AccountID, Valid: true})
+		if err != nil {
+			return err
+		}
+		sweepAccount, err := service.getAccount(ctx, q.GetAccountByID, sweepAccountID)
+		if err != nil {
+			return err
+		}
+		if err := checkAccountClosureRules(account, sweepAccount); err != nil {
+			return err
+		}
+
+		if _, err := q.UpdateAccountStatus(ctx, sqlc.UpdateAccountStatusParams{
+			Status: sqlc.CoreAccountStatusSuspended,
+			ID:     account.ID,
+		}); err != nil {
+			return fmt.Errorf("failed to suspend account: %w", err)
+		}
+
+		results = BlockAccountForClosureResults{
+			PreviousStatus: string(account.Status),
+			SweepAccountID: sweepAccountID.String(),
+		}
+
+		return createAccountClosureStep(ctx, q, params, AccountClosureStepBlocked, results)
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to block account: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("previous_status", results.PreviousStatus).Info("Account blocked for closure")
+
+	return &results, nil
+}
+
+// CountInFlightTransfers counts the running transfer workflows that debit or credit the account, by its ID or its
+// account number
+func (service *Service) CountInFlightTransfers(ctx context.Context, accountID uuid.UUID) (int64, error) {
+	if service.temporalClient == nil {
+		return 0, fmt.Errorf("temporal client not available - service is starting up")
+	}
+
+	account, err := service.getAccount(ctx, service.store.GetAccountByID, accountID)
+	if err != nil {
+		return 0, err
+	}
+
+	// The account number is interpolated into the query, so one that could end the string literal is refused
+	if strings.ContainsAny(account.AccountNumber, `'"\`) {
+		return 0, fmt.Errorf("account number %q cannot be used in a visibility query", account.AccountNumber)
+	}
+
+	response, err := service.temporalClient.CountWorkflow(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+		Query: fmt.Sprintf(inFlightTransfersQuery, accountID, account.AccountNumber),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count in-flight transfers: %w", err)
+	}
+
+	return response.GetCount(), nil
+}
+
+// RecordAccountClosureStep records a step that changes no data, such as the end of draining
+func (service *Service) RecordAccountClosureStep(ctx context.Context, params AccountClosureStepParams, step string, details any) error {
+	return service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		if recorded, err := getAccountClosureStep(ctx, q, params, step, nil); err != nil || recorded {
+			return err
+		}
+
+		return createAccountClosureStep(ctx, q, params, step, details)
+	})
+}
+
+// SweepAccountBalance moves the whole remaining balance of a blocked account to the sweep account
+// in a single database transaction, the same way a fast-path internal transfer does
+func (service *Service) SweepAccountBalance(ctx context.Context, params AccountClosureStepParams, sweepAccountID uuid.UUID) (*SweepAccountBalanceResults, error) {
+	const op = "service.Service.SweepAccountBalance"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	var results SweepAccountBalanceResults
+	err := service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		if recorded, err := getAccountClosureStep(ctx, q, params, AccountClosureStepSwept, &results); err != nil || recorded {
+			return err
+		}
+
+		// Lock both accounts in the same order as internal transfers so a concurrent transfer cannot deadlock with the sweep
+		accounts := make(map[uuid.UUID]sqlc.CoreAccount, 2)
+		balances := make(map[uuid.UUID]decimal.Decimal, 2)
+		for _, accountID := range orderAccountLocks(params.AccountID, sweepAccountID) {
+			account, balance, err := service.lockAccount(ctx, q, pgtype.UUID{Bytes: accountID, Valid: true})
+			if err != nil {
+				return err
+			}
+			accounts[accountID] = account
+			balances[accountID] = balance
+		}
+
+		account := accounts[params.AccountID]
+		if account.Status != sqlc.CoreAccountStatusSuspended {
+			return fmt.Errorf("%w: account must be blocked before its balance is swept (status: %s)", ErrAccountClosureRejected, account.Status)
+		}
+		if err := checkAccountClosureRules(account, accounts[sweepAccountID]); err != nil {
+			return err
+		}
+
+		results = SweepAccountBalanceResults{
+			SweepAccountID: sweepAccountID.String(),
+			Amount:         balances[params.AccountID],
+			Currency:       string(account.Currency),
+		}
+
+		if results.Amount.IsPositive() {
+			description := fmt.Sprintf("Balance sweep for closure of account %s", account.AccountNumber)
+
+			transfer, err := service.postTransfer(ctx, q, InternalTransferParams{
+				TransferID:    fmt.Sprintf("closure-sweep-%s", params.RunID),
+				FromAccountID: params.AccountID,
+				ToAccountID:   sweepAccountID,
+				Amount:        results.Amount,
+				Currency:      results.Currency,
+				Description:   &description,
+				RequestedBy:   params.WorkflowID,
+			}, balances[params.AccountID], balances[sweepAccountID], "account_closure_sweep", accountClosureCreatedBy)
+			if err != nil {
+				return err
+			}
+
+			results.TransferID = transfer.TransferID
+		}
+
+		return createAccountClosureStep(ctx, q, params, AccountClosureStepSwept, results)
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to sweep account balance: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Account balance swept")
+
+	return &results, nil
+}
+
+// CloseAccount marks a blocked account with no balance left as closed
+func (service *Service) CloseAccount(ctx context.Context, params AccountClosureStepParams) error {
+	const op = "service.Service.CloseAccount"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	err := service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		if recorded, err := getAccountClosureStep(ctx, q, params, AccountClosureStepClosed, nil); err != nil || recorded {
+			return err
+		}
+
+		account, balance, err := service.lockAccount(ctx, q, pgtype.UUID{Bytes: params.AccountID, Valid: true})
+		if err != nil {
+			return err
+		}
+		if account.Status != sqlc.CoreAccountStatusSuspended {
+			return fmt.Errorf("%w: account must be blocked before it is closed (status: %s)", ErrAccountClosureRejected, account.Status)
+		}
+		if !balance.IsZero() {
+			return fmt.Errorf("%w: account still holds a balance of %s", ErrAccountClosureRejected, balance.String())
+		}
+
+		if _, err := q.UpdateAccountStatus(ctx, sqlc.UpdateAccountStatusParams{
+			Status: sqlc.CoreAccountStatusClosed,
+			ID:     account.ID,
+		}); err != nil {
+			return fmt.Errorf("failed to close account: %w", err)
+		}
+
+		return createAccountClosureStep(ctx, q, params, AccountClosureStepClosed, nil)
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to close account: %w", err)
+
+		logger.WithError(err).Error()
+
+		return err
+	}
+
+	logger.Info("Account closed")
+
+	return nil
+}
+
+// ReopenAccount restores the status a blocked account had before its closure started.
+// It is used when a closure cannot finish, e.g. because in-flight transfers did not drain in time.
+func (service *Service) ReopenAccount(ctx context.Context, params AccountClosureStepParams, previousStatus string, reason string) error {
+	const op = "service.Service.ReopenAccount"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":            op,
+		"params":          fmt.Sprintf("%+v", params),
+		"previous_status": previousStatus,
+	})
+
+	restored := sqlc.CoreAccountStatus(previousStatus)
+	switch restored {
+	case sqlc.CoreAccountStatusActive, sqlc.CoreAccountStatusInactive, sqlc.CoreAccountStatusSuspended:
+	default:
+		return fmt.Errorf("%w: cannot restore status %q", ErrAccountClosureRejected, previousStatus)
+	}
+
+	err := service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		if recorded, err := getAccountClosureStep(ctx, q, params, AccountClosureStepReopened, nil); err != nil || recorded {
+			return err
+		}
+
+		account, _, err := service.lockAccount(ctx, q, pgtype.UUID{Bytes: params.AccountID, Valid: true})
+		if err != nil {
+			return err
+		}
+		if account.Status != sqlc.CoreAccountStatusSuspended {
+			return fmt.Errorf("%w: only a blocked account can be reopened (status: %s)", ErrAccountClosureRejected, account.Status)
+		}
+
+		if _, err := q.UpdateAccountStatus(ctx, sqlc.UpdateAccountStatusParams{
+			Status: restored,
+			ID:     account.ID,
+		}); err != nil {
+			return fmt.Errorf("failed to restore account status: %w", err)
+		}
+
+		return createAccountClosureStep(ctx, q, params, AccountClosureStepReopened, ReopenAccountResults{
+			RestoredStatus: previousStatus,
+			Reason:         reason,
+		})
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to reopen account: %w", err)
+
+		logger.WithError(err).Error()
+
+		return err
+	}
+
+	logger.WithField("reason", reason).Warn("Account closure abandoned, account reopened")
+
+	return nil
+}
+
+// getAccount loads an account with the given query, mapping a missing row to ErrAccountNotFound
+func (service *Service) getAccount(ctx context.Context, get func(context.Context, pgtype.UUID) (sqlc.CoreAccount, error), accountID uuid.UUID) (sqlc.CoreAccount, error) {
+	account, err := get(ctx, pgtype.UUID{Bytes: accountID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.CoreAccount{}, fmt.Errorf("%w: %s", ErrAccountNotFound, accountID)
+		}
+
+		return sqlc.CoreAccount{}, fmt.Errorf("failed to get account %s: %w", accountID, err)
+	}
+
+	return account, nil
+}
+
+// checkAccountClosureRules checks that the account is not closed yet and that the sweep account can receive its balance
+func checkAccountClosureRules(account, sweepAccount sqlc.CoreAccount) error {
+	if account.ID == sweepAccount.ID {
+		return fmt.Errorf("%w: sweep account must differ from the account being closed", ErrAccountClosureRejected)
+	}
+
+	if account.Status == sqlc.CoreAccountStatusClosed {
+		return fmt.Errorf("%w: account is already closed", ErrAccountClosureRejected)
+	}
+
+	if sweepAccount.Status != sqlc.CoreAccountStatusActive {
+		return fmt.Errorf("%w: sweep account is not active (status: %s)", ErrAccountClosureRejected, sweepAccount.Status)
+	}
+
+	if account.Currency != sweepAccount.Currency {
+		return fmt.Errorf("%w: sweep account must be in %s (sweep account: %s)", ErrAccountClosureRejected, account.Currency, sweepAccount.Currency)
+	}
+
+	return nil
+}
+
+// getAccountClosureStep reports whether the step was already recorded for this run, decoding its details into out.
+// Steps check this first so that a retried activity returns what the first attempt committed.
+func getAccountClosureStep(ctx context.Context, q sqlc.Querier, params AccountClosureStepParams, step string, out any) (bool, error) {
+	record, err := q.GetAccountClosureStep(ctx, sqlc.GetAccountClosureStepParams{
+		RunID: params.RunID,
+		Step:  step,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to get %s closure step: %w", step, err)
+	}
+
+	if out != nil && len(record.Details) > 0 {
+		if err := json.Unmarshal(record.Details, out); err != nil {
+			return false, fmt.Errorf("failed to decode %s closure step: %w", step, err)
+		}
+	}
+
+	return true, nil
+}
+
+// createAccountClosureStep writes a step to the closure audit trail
+func createAccountClosureStep(ctx context.Context, q sqlc.Querier, params AccountClosureStepParams, step string, details any) error {
+	var encoded []byte
+	if details != nil {
+		var err error
+		encoded, err = json.Marshal(details)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s closure step details: %w", step, err)
+		}
+	}
+
+	if _, err := q.CreateAccountClosureStep(ctx, sqlc.CreateAccountClosureStepParams{
+		AccountID:  pgtype.UUID{Bytes: params.AccountID, Valid: true},
+		WorkflowID: params.WorkflowID,
+		RunID:      params.RunID,
+		Step:       step,
+		Details:    encoded,
+	}); err != nil {
+		return fmt.Errorf("failed to record %s closure step: %w", step, err)
+	}
+
+	return nil
+}
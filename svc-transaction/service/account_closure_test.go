@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"svc-transaction/store/memory"
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/mocks"
+)
+
+func TestCheckAccountClosureRules(t *testing.T) {
+	t.Parallel()
+
+	account := func(id byte, status sqlc.CoreAccountStatus, currency sqlc.CoreCurrencyCode) sqlc.CoreAccount {
+		return sqlc.CoreAccount{ID: pgtype.UUID{Bytes: [16]byte{id}, Valid: true}, Status: status, Currency: currency}
+	}
+
+	tests := []struct {
+		name        string
+		account     sqlc.CoreAccount
+		sweep       sqlc.CoreAccount
+		expectError bool
+	}{
+		{
+			name:    "Active account swept to active account",
+			account: account(1, sqlc.CoreAccountStatusActive, sqlc.CoreCurrencyCodeUSD),
+			sweep:   account(2, sqlc.CoreAccountStatusActive, sqlc.CoreCurrencyCodeUSD),
+		},
+		{
+			name:    "Account already blocked by a closure",
+			account: account(1, sqlc.CoreAccountStatusSuspended, sqlc.CoreCurrencyCodeUSD),
+			sweep:   account(2, sqlc.CoreAccountStatusActive, sqlc.CoreCurrencyCodeUSD),
+		},
+		{
+			name:        "Account already closed",
+			account:     account(1, sqlc.CoreAccountStatusClosed, sqlc.CoreCurrencyCodeUSD),
+			sweep:       account(2, sqlc.CoreAccountStatusActive, sqlc.CoreCurrencyCodeUSD),
+			expectError: true,
+		},
+		{
+			name:        "Sweep account is the account being closed",
+			account:     account(1, sqlc.CoreAccountStatusActive, sqlc.CoreCurrencyCodeUSD),
+			sweep:       account(1, sqlc.CoreAccountStatusActive, sqlc.CoreCurrencyCodeUSD),
+			expectError: true,
+		},
+		{
+			name:        "Inactive sweep account",
+			account:     account(1, sqlc.CoreAccountStatusActive, sqlc.CoreCurrencyCodeUSD),
+			sweep:       account(2, sqlc.CoreAccountStatusInactive, sqlc.CoreCurrencyCodeUSD),
+			expectError: true,
+		},
+		{
+			name:        "Sweep account in another currency",
+			account:     account(1, sqlc.CoreAccountStatusActive, sqlc.CoreCurrencyCodeUSD),
+			sweep:       account(2, sqlc.CoreAccountStatusActive, sqlc.CoreCurrencyCodeEUR),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkAccountClosureRules(tt.account, tt.sweep)
+
+			if tt.expectError {
+				if !errors.Is(err, ErrAccountClosureRejected) {
+					t.Errorf("checkAccountClosureRules() error = %v, want ErrAccountClosureRejected", err)
+				}
+			} else if err != nil {
+				t.Errorf("checkAccountClosureRules() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCountInFlightTransfers(t *testing.T) {
+	t.Parallel()
+
+	accountID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	want := "WorkflowType IN ('transferWorkflow', 'conditionalTransferWorkflow') AND ExecutionStatus = 'Running' AND " +
+		"(TransferSourceAccount IN ('550e8400-e29b-41d4-a716-446655440001', 'ACC001') OR " +
+		"TransferDestinationAccount IN ('550e8400-e29b-41d4-a716-446655440001', 'ACC001'))"
+
+	temporalClient := &mocks.Client{}
+	temporalClient.On("CountWorkflow", mock.Anything, mock.MatchedBy(func(request *workflowservice.CountWorkflowExecutionsRequest) bool {
+		return request.Query == want
+	})).Return(&workflowservice.CountWorkflowExecutionsResponse{Count: 3}, nil)
+
+	service := &Service{logger: logrus.New(), store: memory.NewStore(logrus.New(), true), temporalClient: temporalClient}
+
+	count, err := service.CountInFlightTransfers(context.Background(), accountID)
+	if err != nil {
+		t.Fatalf("CountInFlightTransfers() error = %v", err)
+	}
+	if count != 3 {
+		t.Errorf("CountInFlightTransfers() = %d, want 3", count)
+	}
+
+	temporalClient.AssertExpectations(t)
+}
+
+func TestCountInFlightTransfersByAccountNumber(t *testing.T) {
+	t.Parallel()
+
+	// Search attributes of running transfers as flowngine sets them: from_account and to_account as the client sent
+	// them, or the account ID a to_alias resolved to
+	running := []struct{ source, destination string }{
+		{"ACC001", "ACC002"},
+		{"ACC003", "550e8400-e29b-41d4-a716-446655440002"},
+	}
+
+	temporalClient := &mocks.Client{}
+	temporalClient.On("CountWorkflow", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, request *workflowservice.CountWorkflowExecutionsRequest) *workflowservice.CountWorkflowExecutionsResponse {
+			var count int64
+			for _, transfer := range running {
+				if strings.Contains(request.Query, "'"+transfer.source+"'") || strings.Contains(request.Query, "'"+transfer.destination+"'") {
+					count++
+				}
+			}
+			return &workflowservice.CountWorkflowExecutionsResponse{Count: count}
+		}, nil)
+
+	service := &Service{logger: logrus.New(), store: memory.NewStore(logrus.New(), true), temporalClient: temporalClient}
+
+	for accountID, want := range map[string]int64{
+		"550e8400-e29b-41d4-a716-446655440001": 1, // ACC001, source of a transfer started by account number
+		"550e8400-e29b-41d4-a716-446655440002": 2, // ACC002, destination by account number and by alias
+		"550e8400-e29b-41d4-a716-446655440004": 0,
+	} {
+		count, err := service.CountInFlightTransfers(context.Background(), uuid.MustParse(accountID))
+		if err != nil {
+			t.Fatalf("CountInFlightTransfers(%s) error = %v", accountID, err)
+		}
+		if count != want {
+			t.Errorf("CountInFlightTransfers(%s) = %d, want %d", accountID, count, want)
+		}
+	}
+
+	_, err := service.CountInFlightTransfers(context.Background(), uuid.New())
+	if !errors.Is(err, ErrAccountNotFound) {
+		t.Errorf("CountInFlightTransfers() of unknown account error = %v, want ErrAccountNotFound", err)
+	}
+}
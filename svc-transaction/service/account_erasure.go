@@ -0,0 +1,466 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+// AccountErasureWorkflowName is the name AccountErasureWorkflow is registered under by the worker
+const AccountErasureWorkflowName = "AccountErasureWorkflow"
+
+// DefaultAccountErasureRetention is how long a closed account is kept before its personal data can be erased
+// when no retention is configured
+const DefaultAccountErasureRetention = 5 * 365 * 24 * time.Hour
+
+// ErasedValue replaces personal data in columns that cannot be NULL, such as the account name
+const ErasedValue = "[erased]"
+
+// erasureKeptMetadataKeys are the metadata keys the services write themselves and rely on, e.g. to find the
+// compensation of a transaction. Every other key may hold caller-supplied personal data and is erased.
+var erasureKeptMetadataKeys = []string{
+	"audit_record_id",
+	"backoff_multiplier",
+	"compensation",
+	"compensation_type",
+	"enhanced_compensation",
+	"execution_path",
+	"max_retries",
+	"original_transaction_id",
+	"run_id",
+	"timeout_ms",
+	"transfer_id",
+	"workflow_id",
+}
+
+// ErrAccountErasureRejected is returned when an account cannot be erased, e.g. it is not closed,
+// its retention period has not elapsed or it was already erased
+var ErrAccountErasureRejected = errors.New("account erasure rejected")
+
+// ErrAccountErasureInProgress is returned when an erasure workflow is already running for the account
+var ErrAccountErasureInProgress = errors.New("account erasure already in progress")
+
+// ErrAccountErasureCertificateNotFound is returned when the account has not been erased
+var ErrAccountErasureCertificateNotFound = errors.New("account erasure certificate not found")
+
+// StartAccountErasureParams represents the input parameters for erasing the personal data of an account
+type StartAccountErasureParams struct {
+	AccountID   uuid.UUID `json:"account_id"`
+	RequestedBy string    `json:"requested_by,omitempty"`
+}
+
+// StartAccountErasureResults identifies the started erasure workflow
+type StartAccountErasureResults struct {
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+}
+
+// AccountErasureWorkflowParams is the input of AccountErasureWorkflow
+type AccountErasureWorkflowParams struct {
+	AccountID   string `json:"account_id"`
+	RequestedBy string `json:"requested_by,omitempty"`
+}
+
+// AccountErasureStepParams identifies the account and the erasure workflow run a step belongs to
+type AccountErasureStepParams struct {
+	AccountID  uuid.UUID `json:"account_id"`
+	WorkflowID string    `json:"workflow_id"`
+	RunID      string    `json:"run_id"`
+}
+
+// ErasedRows counts rows holding personal data of an account, per table
+type ErasedRows struct {
+	Accounts           int64 `json:"accounts"`
+	Transactions       int64 `json:"transactions"`
+	Transfers          int64 `json:"transfers"`
+	CompensationAudits int64 `json:"compensation_audits"`
+}
+
+// Total is the number of rows over all tables
+func (rows ErasedRows) Total() int64 {
+	return rows.Accounts + rows.Transactions + rows.Transfers + rows.CompensationAudits
+}
+
+// AnonymizeAccountDataResults reports what an anonymization changed.
+// A retry after a committed attempt finds nothing left to scrub and reports zero rows.
+type AnonymizeAccountDataResults struct {
+	ClosedAt time.Time  `json:"closed_at"`
+	Scrubbed ErasedRows `json:"scrubbed"`
+}
+
+// IssueAccountErasureCertificateParams represents the input parameters for recording a verified erasure
+type IssueAccountErasureCertificateParams struct {
+	AccountErasureStepParams
+	RequestedBy string     `json:"requested_by,omitempty"`
+	ClosedAt    time.Time  `json:"closed_at"`
+	Scrubbed    ErasedRows `json:"scrubbed"`
+}
+
+// AccountErasureCertificate records that the personal data of an account was erased and verified.
+// DigestValid reports whether the stored digest still matches the certificate fields.
+type AccountErasureCertificate struct {
+	ID          uuid.UUID  `json:"id"`
+	AccountID   uuid.UUID  `json:"account_id"`
+	WorkflowID  string     `json:"workflow_id"`
+	RunID       string     `json:"run_id"`
+	RequestedBy string     `json:"requested_by,omitempty"`
+	ClosedAt    time.Time  `json:"closed_at"`
+	Scrubbed    ErasedRows `json:"scrubbed_rows"`
+	Digest      string     `json:"digest"`
+	DigestValid bool       `json:"digest_valid"`
+	ErasedAt    time.Time  `json:"erased_at"`
+}
+
+// accountErasureWorkflowID allows a single running erasure per account
+func accountErasureWorkflowID(accountID uuid.UUID) string {
+	return fmt.Sprintf("account_erasure_%s", accountID)
+}
+
+// SetErasureRetention sets how long a closed account is kept before it can be erased; zero keeps the default
+func (service *Service) SetErasureRetention(retention time.Duration) {
+	service.erasureRetention = retention
+}
+
+func (service *Service) accountErasureRetention() time.Duration {
+	if service.erasureRetention <= 0 {
+		return DefaultAccountErasureRetention
+	}
+
+	return service.erasureRetention
+}
+
+// StartAccountErasure checks that the account can be erased and starts AccountErasureWorkflow for it.
+// The workflow itself re-checks everything once the account is locked.
+func (service *Service) StartAccountErasure(ctx context.Context, params StartAccountErasureParams) (*StartAccountErasureResults, error) {
+	const op = "service.Service.StartAccountErasure"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	if params.AccountID == uuid.Nil {
+		return nil, fmt.Errorf("%w: account_id is required", ErrAccountErasureRejected)
+	}
+
+	account, err := service.getAccount(ctx, service.store.GetAccountByID, params.AccountID)
+	if err != nil {
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+	if err := service.checkAccountErasure(ctx, service.store, account, time.Now()); err != nil {
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	if service.temporalClient == nil {
+		err := fmt.Errorf("temporal client not available - service is starting up")
+
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	run, err := service.temporalClient.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:                                       accountErasureWorkflowID(params.AccountID),
+		TaskQueue:                                service.taskQueue,
+		WorkflowExecutionErrorWhenAlreadyStarted: true,
+	}, AccountErasureWorkflowName, AccountErasureWorkflowParams{
+		AccountID:   params.AccountID.String(),
+		RequestedBy: params.RequestedBy,
+	})
+	if err != nil {
+		var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+		if errors.As(err, &alreadyStarted) {
+			err = fmt.Errorf("%w: %s", ErrAccountErasureInProgress, accountErasureWorkflowID(params.AccountID))
+		} else {
+			err = fmt.Errorf("failed to start account erasure workflow: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &StartAccountErasureResults{
+		WorkflowID: run.GetID(),
+		RunID:      run.GetRunID(),
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Account erasure started")
+
+	return results, nil
+}
+
+// GetAccountErasureCertificate returns the erasure certificate of an account and checks its digest
+func (service *Service) GetAccountErasureCertificate(ctx context.Context, accountID uuid.UUID) (*AccountErasureCertificate, error) {
+	record, err := service.store.GetAccountErasureCertificate(ctx, pgtype.UUID{Bytes: accountID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrAccountErasureCertificateNotFound, accountID)
+		}
+
+		return nil, fmt.Errorf("failed to get account erasure certificate: %w", err)
+	}
+
+	return newAccountErasureCertificate(record)
+}
+
+// AnonymizeAccountData scrubs the personal data of a closed account whose retention period has elapsed:
+// its name, the descriptions and caller-supplied metadata of its transactions and transfers, and the
+// free-text reasons of its compensation audit records. Amounts, statuses and balance history are kept.
+func (service *Service) AnonymizeAccountData(ctx context.Context, params AccountErasureStepParams) (*AnonymizeAccountDataResults, error) {
+	const op = "service.Service.AnonymizeAccountData"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	var results AnonymizeAccountDataResults
+	err := service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		accountID := pgtype.UUID{Bytes: params.AccountID, Valid: true}
+
+		if err := service.acquireAccountLock(ctx, q, accountID); err != nil {
+			return err
+		}
+		account, err := service.getAccount(ctx, q.GetAccountForUpdate, params.AccountID)
+		if err != nil {
+			return err
+		}
+		if err := service.checkAccountErasure(ctx, q, account, time.Now()); err != nil {
+			return err
+		}
+
+		if results.ClosedAt, err = accountClosedAt(ctx, q, account); err != nil {
+			return err
+		}
+
+		if results.Scrubbed.Accounts, err = q.AnonymizeAccount(ctx, sqlc.AnonymizeAccountParams{
+			ErasedName: ErasedValue,
+			ID:         accountID,
+		}); err != nil {
+			return fmt.Errorf("failed to anonymize account: %w", err)
+		}
+
+		if results.Scrubbed.Transactions, err = q.AnonymizeAccountTransactions(ctx, sqlc.AnonymizeAccountTransactionsParams{
+			KeptMetadataKeys: erasureKeptMetadataKeys,
+			AccountID:        accountID,
+		}); err != nil {
+			return fmt.Errorf("failed to anonymize transactions: %w", err)
+		}
+
+		if results.Scrubbed.Transfers, err = q.AnonymizeAccountTransfers(ctx, sqlc.AnonymizeAccountTransfersParams{
+			KeptMetadataKeys: erasureKeptMetadataKeys,
+			AccountID:        accountID,
+		}); err != nil {
+			return fmt.Errorf("failed to anonymize transfers: %w", err)
+		}
+
+		if results.Scrubbed.CompensationAudits, err = q.AnonymizeAccountCompensationAudits(ctx, sqlc.AnonymizeAccountCompensationAuditsParams{
+			ErasedName:       ErasedValue,
+			KeptMetadataKeys: erasureKeptMetadataKeys,
+			AccountID:        accountID,
+		}); err != nil {
+			return fmt.Errorf("failed to anonymize compensation audit records: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to anonymize account data: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Account data anonymized")
+
+	return &results, nil
+}
+
+// VerifyAccountErasure counts the rows of an account that still hold personal data; all counts are zero
+// after a complete erasure
+func (service *Service) VerifyAccountErasure(ctx context.Context, accountID uuid.UUID) (ErasedRows, error) {
+	remaining, err := service.store.CountAccountPersonalData(ctx, sqlc.CountAccountPersonalDataParams{
+		AccountID:        pgtype.UUID{Bytes: accountID, Valid: true},
+		ErasedName:       ErasedValue,
+		KeptMetadataKeys: erasureKeptMetadataKeys,
+	})
+	if err != nil {
+		return ErasedRows{}, fmt.Errorf("failed to count remaining personal data: %w", err)
+	}
+
+	return ErasedRows(remaining), nil
+}
+
+// IssueAccountErasureCertificate records a verified erasure. A retry of the same run returns the certificate
+// the first attempt issued.
+func (service *Service) IssueAccountErasureCertificate(ctx context.Context, params IssueAccountErasureCertificateParams) (*AccountErasureCertificate, error) {
+	const op = "service.Service.IssueAccountErasureCertificate"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	existing, err := service.GetAccountErasureCertificate(ctx, params.AccountID)
+	switch {
+	case err == nil:
+		if existing.RunID != params.RunID {
+			return nil, fmt.Errorf("%w: account was already erased by %s", ErrAccountErasureRejected, existing.WorkflowID)
+		}
+
+		return existing, nil
+	case !errors.Is(err, ErrAccountErasureCertificateNotFound):
+		return nil, err
+	}
+
+	certificate := AccountErasureCertificate{
+		AccountID:   params.AccountID,
+		WorkflowID:  params.WorkflowID,
+		RunID:       params.RunID,
+		RequestedBy: params.RequestedBy,
+		// PostgreSQL keeps microseconds, so the digest is computed over what will be read back
+		ClosedAt: params.ClosedAt.UTC().Truncate(time.Microsecond),
+		Scrubbed: params.Scrubbed,
+		ErasedAt: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	certificate.Digest = accountErasureDigest(certificate)
+
+	scrubbed, err := json.Marshal(certificate.Scrubbed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal scrubbed rows: %w", err)
+	}
+
+	record, err := service.store.CreateAccountErasureCertificate(ctx, sqlc.CreateAccountErasureCertificateParams{
+		AccountID:    pgtype.UUID{Bytes: certificate.AccountID, Valid: true},
+		WorkflowID:   certificate.WorkflowID,
+		RunID:        certificate.RunID,
+		RequestedBy:  pgtype.Text{String: certificate.RequestedBy, Valid: certificate.RequestedBy != ""},
+		ClosedAt:     pgtype.Timestamptz{Time: certificate.ClosedAt, Valid: true},
+		ScrubbedRows: scrubbed,
+		Digest:       certificate.Digest,
+		ErasedAt:     pgtype.Timestamptz{Time: certificate.ErasedAt, Valid: true},
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to create account erasure certificate: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	issued, err := newAccountErasureCertificate(record)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.WithField("digest", issued.Digest).Info("Account erasure certificate issued")
+
+	return issued, nil
+}
+
+// checkAccountErasure checks that the account is closed, has been closed for the retention period and was not
+// erased before
+func (service *Service) checkAccountErasure(ctx context.Context, q sqlc.Querier, account sqlc.CoreAccount, now time.Time) error {
+	if _, err := q.GetAccountErasureCertificate(ctx, account.ID); err == nil {
+		return fmt.Errorf("%w: account was already erased", ErrAccountErasureRejected)
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to get account erasure certificate: %w", err)
+	}
+
+	closedAt, err := accountClosedAt(ctx, q, account)
+	if err != nil {
+		return err
+	}
+
+	return checkAccountErasureRules(account, closedAt, now, service.accountErasureRetention())
+}
+
+// checkAccountErasureRules checks that the account is closed and its retention period has elapsed
+func checkAccountErasureRules(account sqlc.CoreAccount, closedAt, now time.Time, retention time.Duration) error {
+	if account.Status != sqlc.CoreAccountStatusClosed {
+		return fmt.Errorf("%w: only a closed account can be erased (status: %s)", ErrAccountErasureRejected, account.Status)
+	}
+
+	if erasableAt := closedAt.Add(retention); now.Before(erasableAt) {
+		return fmt.Errorf("%w: account is retained until %s", ErrAccountErasureRejected, erasableAt.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// accountClosedAt returns when the account was closed: the closed step of its closure workflow, or the last
+// update of an account closed some other way. Closed accounts cannot change, so the two agree.
+func accountClosedAt(ctx context.Context, q sqlc.Querier, account sqlc.CoreAccount) (time.Time, error) {
+	steps, err := q.ListAccountClosureSteps(ctx, account.ID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to list account closure steps: %w", err)
+	}
+
+	for i := len(steps) - 1; i >= 0; i-- {
+		if steps[i].Step == AccountClosureStepClosed {
+			return steps[i].CreatedAt.Time, nil
+		}
+	}
+
+	return account.UpdatedAt.Time, nil
+}
+
+// accountErasureDigest hashes the certificate fields, so a later edit to the stored certificate can be detected
+func accountErasureDigest(certificate AccountErasureCertificate) string {
+	input := strings.Join([]string{
+		certificate.AccountID.String(),
+		certificate.WorkflowID,
+		certificate.RunID,
+		certificate.RequestedBy,
+		certificate.ClosedAt.UTC().Format(time.RFC3339Nano),
+		fmt.Sprintf("%d,%d,%d,%d", certificate.Scrubbed.Accounts, certificate.Scrubbed.Transactions, certificate.Scrubbed.Transfers, certificate.Scrubbed.CompensationAudits),
+		certificate.ErasedAt.UTC().Format(time.RFC3339Nano),
+	}, "|")
+
+	sum := sha256.Sum256([]byte(input))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func newAccountErasureCertificate(record sqlc.CoreAccountErasureCertificate) (*AccountErasureCertificate, error) {
+	certificate := &AccountErasureCertificate{
+		ID:          uuid.UUID(record.ID.Bytes),
+		AccountID:   uuid.UUID(record.AccountID.Bytes),
+		WorkflowID:  record.WorkflowID,
+		RunID:       record.RunID,
+		RequestedBy: record.RequestedBy.String,
+		ClosedAt:    record.ClosedAt.Time,
+		Digest:      record.Digest,
+		ErasedAt:    record.ErasedAt.Time,
+	}
+
+	if err := json.Unmarshal(record.ScrubbedRows, &certificate.Scrubbed); err != nil {
+		return nil, fmt.Errorf("failed to decode scrubbed rows: %w", err)
+	}
+
+	certificate.DigestValid = accountErasureDigest(*certificate) == record.Digest
+
+	return certificate, nil
+}
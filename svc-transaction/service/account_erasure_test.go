@@ -0,0 +1,118 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestCheckAccountErasureRules(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name        string
+		status      sqlc.CoreAccountStatus
+		closedAt    time.Time
+		expectError bool
+	}{
+		{
+			name:     "Closed past retention",
+			status:   sqlc.CoreAccountStatusClosed,
+			closedAt: now.Add(-retention),
+		},
+		{
+			name:        "Closed within retention",
+			status:      sqlc.CoreAccountStatusClosed,
+			closedAt:    now.Add(-retention + time.Hour),
+			expectError: true,
+		},
+		{
+			name:        "Active account",
+			status:      sqlc.CoreAccountStatusActive,
+			closedAt:    now.Add(-2 * retention),
+			expectError: true,
+		},
+		{
+			name:        "Account blocked by a closure",
+			status:      sqlc.CoreAccountStatusSuspended,
+			closedAt:    now.Add(-2 * retention),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkAccountErasureRules(sqlc.CoreAccount{Status: tt.status}, tt.closedAt, now, retention)
+
+			if tt.expectError {
+				if !errors.Is(err, ErrAccountErasureRejected) {
+					t.Errorf("checkAccountErasureRules() error = %v, want ErrAccountErasureRejected", err)
+				}
+			} else if err != nil {
+				t.Errorf("checkAccountErasureRules() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestAccountErasureCertificateDigest(t *testing.T) {
+	t.Parallel()
+
+	certificate := AccountErasureCertificate{
+		AccountID:   uuid.MustParse("550e8400-e29b-41d4-a716-446655440001"),
+		WorkflowID:  "account_erasure_550e8400-e29b-41d4-a716-446655440001",
+		RunID:       "run",
+		RequestedBy: "dpo@example.com",
+		ClosedAt:    time.Date(2020, 1, 2, 3, 4, 5, 123456000, time.UTC),
+		Scrubbed:    ErasedRows{Accounts: 1, Transactions: 4, Transfers: 2},
+		ErasedAt:    time.Date(2026, 1, 2, 3, 4, 5, 654321000, time.UTC),
+	}
+	digest := accountErasureDigest(certificate)
+
+	scrubbed, err := json.Marshal(certificate.Scrubbed)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	record := sqlc.CoreAccountErasureCertificate{
+		ID:           pgtype.UUID{Bytes: uuid.New(), Valid: true},
+		AccountID:    pgtype.UUID{Bytes: certificate.AccountID, Valid: true},
+		WorkflowID:   certificate.WorkflowID,
+		RunID:        certificate.RunID,
+		RequestedBy:  pgtype.Text{String: certificate.RequestedBy, Valid: true},
+		ClosedAt:     pgtype.Timestamptz{Time: certificate.ClosedAt.In(time.FixedZone("WIB", 7*60*60)), Valid: true},
+		ScrubbedRows: scrubbed,
+		Digest:       digest,
+		ErasedAt:     pgtype.Timestamptz{Time: certificate.ErasedAt, Valid: true},
+	}
+
+	loaded, err := newAccountErasureCertificate(record)
+	if err != nil {
+		t.Fatalf("newAccountErasureCertificate() error = %v", err)
+	}
+	if !loaded.DigestValid {
+		t.Errorf("DigestValid = false for an unchanged certificate")
+	}
+
+	// Editing a field after the certificate was issued breaks the digest
+	record.ScrubbedRows = []byte(`{"accounts":1,"transactions":0,"transfers":2,"compensation_audits":0}`)
+
+	tampered, err := newAccountErasureCertificate(record)
+	if err != nil {
+		t.Fatalf("newAccountErasureCertificate() error = %v", err)
+	}
+	if tampered.DigestValid {
+		t.Errorf("DigestValid = true for an edited certificate")
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+)
+
+// maxOwnerIDLength matches core.account_owners.owner_id
+const maxOwnerIDLength = 100
+
+// ErrInvalidAccountOwner is returned when an owner ID is empty or too long
+var ErrInvalidAccountOwner = errors.New("invalid account owner")
+
+// ErrAccountOwnerExists is returned when adding an owner the account already has
+var ErrAccountOwnerExists = errors.New("account owner already exists")
+
+// ErrAccountOwnerNotFound is returned when removing an owner the account does not have
+var ErrAccountOwnerNotFound = errors.New("account owner not found")
+
+// AccountOwnerResults represents an owner of an account. Accounts with two or more owners are joint accounts,
+// whose debits above the joint threshold need the approval of two of them.
+type AccountOwnerResults struct {
+	AccountID string `json:"account_id"`
+	OwnerID   string `json:"owner_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// AddAccountOwner adds an owner to an account
+func (service *Service) AddAccountOwner(ctx context.Context, accountID uuid.UUID, ownerID string) (*AccountOwnerResults, error) {
+	const op = "service.Service.AddAccountOwner"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": accountID,
+		"owner_id":   ownerID,
+	})
+
+	if err := validateAccountOwnerID(ownerID); err != nil {
+		return nil, err
+	}
+
+	if _, err := service.getAccount(ctx, service.store.GetAccountByID, accountID); err != nil {
+		return nil, err
+	}
+
+	record, err := service.store.AddAccountOwner(ctx, sqlc.AddAccountOwnerParams{
+		AccountID: pgtype.UUID{Bytes: accountID, Valid: true},
+		OwnerID:   ownerID,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, fmt.Errorf("%w: %s", ErrAccountOwnerExists, ownerID)
+		}
+
+		err = fmt.Errorf("failed to add account owner: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.Info("Account owner added")
+
+	result := buildAccountOwnerResult(record)
+
+	return &result, nil
+}
+
+// ListAccountOwners returns the owners of an account in the order they were added. Accounts without
+// owners on record return an empty list.
+func (service *Service) ListAccountOwners(ctx context.Context, accountID uuid.UUID) ([]AccountOwnerResults, error) {
+	records, err := service.store.ListAccountOwners(ctx, pgtype.UUID{Bytes: accountID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account owners: %w", err)
+	}
+
+	results := make([]AccountOwnerResults, 0, len(records))
+	for _, record := range records {
+		results = append(results, buildAccountOwnerResult(record))
+	}
+
+	return results, nil
+}
+
+// RemoveAccountOwner removes an owner from an account
+func (service *Service) RemoveAccountOwner(ctx context.Context, accountID uuid.UUID, ownerID string) error {
+	const op = "service.Service.RemoveAccountOwner"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": accountID,
+		"owner_id":   ownerID,
+	})
+
+	rows, err := service.store.RemoveAccountOwner(ctx, sqlc.RemoveAccountOwnerParams{
+		AccountID: pgtype.UUID{Bytes: accountID, Valid: true},
+		OwnerID:   ownerID,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to remove account owner: %w", err)
+
+		logger.WithError(err).Error()
+
+		return err
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("%w: %s", ErrAccountOwnerNotFound, ownerID)
+	}
+
+	logger.Info("Account owner removed")
+
+	return nil
+}
+
+// validateAccountOwnerID checks an owner ID against core.account_owners.owner_id
+func validateAccountOwnerID(ownerID string) error {
+	if strings.TrimSpace(ownerID) == "" {
+		return fmt.Errorf("%w: owner_id is required", ErrInvalidAccountOwner)
+	}
+	if len(ownerID) > maxOwnerIDLength {
+		return fmt.Errorf("%w: owner_id must be at most %d characters", ErrInvalidAccountOwner, maxOwnerIDLength)
+	}
+
+	return nil
+}
+
+// buildAccountOwnerResult converts a stored account owner to the API representation
+func buildAccountOwnerResult(record sqlc.CoreAccountOwner) AccountOwnerResults {
+	return AccountOwnerResults{
+		AccountID: uuid.UUID(record.AccountID.Bytes).String(),
+		OwnerID:   record.OwnerID,
+		CreatedAt: record.CreatedAt.Time.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-transaction/store/sqlc"
+	"svc-transaction/util/accounttype"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrAccountTypeRestriction is returned when an operation is not allowed for the type of the account, e.g. a
+// withdrawal from a savings account past its monthly limit
+var ErrAccountTypeRestriction = errors.New("account type restriction")
+
+// accountTypeValidation checks that the type of the account allows one more withdrawal this month. q is the store or
+// the queries of a transaction that locked the account, which makes the count exact.
+func (service *Service) accountTypeValidation(ctx context.Context, q sqlc.Querier, accountID pgtype.UUID, accountType sqlc.CoreAccountType) (ValidationResult, error) {
+	policy := accounttype.PolicyOf(accounttype.Type(accountType))
+
+	var made int64
+	if policy.Limited() {
+		var err error
+		made, err = q.CountOutgoingDebits(ctx, sqlc.CountOutgoingDebitsParams{
+			AccountID:   accountID,
+			CreatedFrom: pgtype.Timestamptz{Time: accounttype.MonthStart(time.Now()), Valid: true},
+		})
+		if err != nil {
+			return ValidationResult{}, fmt.Errorf("failed to count withdrawals of this month: %w", err)
+		}
+	}
+
+	allowed, message := policy.CheckWithdrawal(made)
+	result := ValidationResult{
+		Field:   "account_type",
+		Message: fmt.Sprintf("%s account: %s", accountType, message),
+		Level:   "info",
+		Passed:  allowed,
+	}
+	if !allowed {
+		result.Level = "error"
+	}
+
+	return result, nil
+}
+
+// accountTypeFailure returns an error wrapping ErrAccountTypeRestriction when the type of a locked account does not
+// allow one more withdrawal this month
+func (service *Service) accountTypeFailure(ctx context.Context, q sqlc.Querier, account sqlc.CoreAccount) error {
+	result, err := service.accountTypeValidation(ctx, q, account.ID, account.AccountType)
+	if err != nil {
+		return err
+	}
+
+	if !result.Passed {
+		return fmt.Errorf("%w: %s", ErrAccountTypeRestriction, result.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// A savings account allows five withdrawals per month; the sixth fails validation and leaves the balance unchanged
+func TestSavingsWithdrawalLimit(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	ledger := newMemoryLedger()
+	accountID := ledger.addAccount("ACC000000001", decimal.NewFromInt(1000))
+	account := ledger.accounts[accountID]
+	account.AccountType = sqlc.CoreAccountTypeSavings
+	ledger.accounts[accountID] = account
+
+	service := &Service{logger: logger, store: newMemoryStore(ledger)}
+
+	debit := func() (*DebitAccountResults, error) {
+		return service.DebitAccount(context.Background(), DebitAccountParams{
+			AccountID: &accountID,
+			Amount:    decimal.NewFromInt(10),
+			Currency:  "USD",
+		})
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := debit(); err != nil {
+			t.Fatalf("withdrawal %d failed: %v", i+1, err)
+		}
+	}
+
+	result, err := debit()
+	if !errors.Is(err, ErrAccountTypeRestriction) {
+		t.Fatalf("sixth withdrawal error = %v, want %v", err, ErrAccountTypeRestriction)
+	}
+	if result == nil || result.Status != "validation_failed" {
+		t.Fatalf("sixth withdrawal result = %+v, want status validation_failed", result)
+	}
+	if got := ledger.balances[accountID]; !got.Equal(decimal.NewFromInt(950)) {
+		t.Errorf("balance = %s, want 950", got)
+	}
+
+	// Checking accounts have no limit
+	account.AccountType = sqlc.CoreAccountTypeChecking
+	ledger.accounts[accountID] = account
+	if _, err := debit(); err != nil {
+		t.Errorf("withdrawal from a checking account failed: %v", err)
+	}
+}
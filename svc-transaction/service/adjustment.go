@@ -0,0 +1,559 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"numeric"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// adjustmentCreatedBy is recorded in core.account_balance_history.created_by for approved adjustments
+const adjustmentCreatedBy = "transaction_service_adjustment"
+
+// Limits of the text columns of core.pending_adjustments
+const (
+	maxAdjustmentReasonLength = 500
+	maxAdjustmentActorLength  = 100
+)
+
+// Statuses of core.pending_adjustments
+const (
+	AdjustmentStatusPending  = "pending"
+	AdjustmentStatusApproved = "approved"
+	AdjustmentStatusRejected = "rejected"
+)
+
+// defaultAdjustmentListLimit and maxAdjustmentListLimit bound ListAdjustments
+const (
+	defaultAdjustmentListLimit = 50
+	maxAdjustmentListLimit     = 500
+)
+
+// ErrInvalidAdjustment is returned when an adjustment cannot be requested or reviewed with the given parameters
+var ErrInvalidAdjustment = errors.New("invalid adjustment")
+
+// ErrAdjustmentNotFound is returned when no adjustment has the given ID
+var ErrAdjustmentNotFound = errors.New("adjustment not found")
+
+// ErrAdjustmentReviewed is returned when an adjustment was already approved or rejected
+var ErrAdjustmentReviewed = errors.New("adjustment already reviewed")
+
+// ErrSelfApproval is returned when the operator who requested an adjustment tries to approve it
+var ErrSelfApproval = errors.New("adjustment cannot be approved by its requester")
+
+// PostAdjustmentParams describes an admin correction of an account balance
+type PostAdjustmentParams struct {
+	AccountID uuid.UUID
+	Amount    decimal.Decimal // Signed: positive adds to the balance, negative takes from it
+	Currency  string
+	Reason    string
+	Actor     string // Operator requesting the adjustment, who cannot approve it
+}
+
+// ReviewAdjustmentParams approves or rejects a pending adjustment
+type ReviewAdjustmentParams struct {
+	ID    uuid.UUID
+	Actor string // Operator reviewing the adjustment
+	Note  string // Optional for approvals, required for rejections
+}
+
+// AdjustmentFilter narrows ListAdjustments. Zero-valued fields leave that dimension unfiltered.
+type AdjustmentFilter struct {
+	Status    string
+	AccountID uuid.UUID
+	Limit     int32
+}
+
+// Adjustment is a balance correction and its review
+type Adjustment struct {
+	ID            uuid.UUID       `json:"id"`
+	AccountID     uuid.UUID       `json:"account_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	Currency      string          `json:"currency"`
+	Reason        string          `json:"reason"`
+	Status        string          `json:"status"`
+	RequestedBy   string          `json:"requested_by"`
+	RequestedAt   time.Time       `json:"requested_at"`
+	ReviewedBy    *string         `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time      `json:"reviewed_at,omitempty"`
+	ReviewNote    *string         `json:"review_note,omitempty"`
+	TransactionID *uuid.UUID      `json:"transaction_id,omitempty"` // Set once approved
+
+	// Set by ApproveAdjustment
+	PreviousBalance *decimal.Decimal `json:"previous_balance,omitempty"`
+	NewBalance      *decimal.Decimal `json:"new_balance,omitempty"`
+}
+
+// PostAdjustment requests a signed correction of an account balance. The balance does not change until a second
+// operator approves the adjustment with ApproveAdjustment; the request is recorded in core.admin_audit in the same
+// database transaction as the pending adjustment.
+func (service *Service) PostAdjustment(ctx context.Context, params PostAdjustmentParams) (*Adjustment, error) {
+	const op = "service.Service.PostAdjustment"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": params.AccountID,
+		"amount":     params.Amount.String(),
+		"currency":   params.Currency,
+		"actor":      params.Actor,
+	})
+
+	logger.Info()
+
+	if err := service.validatePostAdjustmentParams(params); err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	pgAmount, err := service.decimalToPgNumeric(params.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert amount: %w", err)
+	}
+
+	var adjustment *Adjustment
+
+	err = service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		account, err := service.getAccount(ctx, q.GetAccountByID, params.AccountID)
+		if err != nil {
+			return err
+		}
+
+		// Fail fast on what the approval would reject anyway; the balance itself is checked on approval
+		if err := checkAdjustableAccount(account, params.Currency); err != nil {
+			return err
+		}
+
+		pending, err := q.CreatePendingAdjustment(ctx, sqlc.CreatePendingAdjustmentParams{
+			AccountID:   account.ID,
+			Amount:      pgAmount,
+			Currency:    account.Currency,
+			Reason:      params.Reason,
+			RequestedBy: params.Actor,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create pending adjustment: %w", err)
+		}
+
+		adjustment, err = toAdjustment(pending)
+		if err != nil {
+			return err
+		}
+
+		return createAdminAudit(ctx, q, AdminAction{
+			Actor:        params.Actor,
+			Action:       AdminActionRequestAdjustment,
+			ResourceType: "adjustment",
+			ResourceID:   adjustment.ID.String(),
+			After:        adjustment,
+		})
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to post adjustment: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithField("adjustment_id", adjustment.ID).Info("Adjustment awaiting approval")
+
+	return adjustment, nil
+}
+
+// ApproveAdjustment applies a pending adjustment on behalf of an operator other than its requester. The ledger only
+// holds positive amounts, so the adjustment is recorded as a completed credit for a positive amount and a completed
+// debit for a negative one, which keeps RecalculateBalance agreeing with it, and its balance history entry has the
+// "adjustment" operation. An adjustment cannot take the balance below zero or touch a closed account; it then stays
+// pending. The approval is recorded in core.admin_audit in the same database transaction as the balance change.
+func (service *Service) ApproveAdjustment(ctx context.Context, params ReviewAdjustmentParams) (*Adjustment, error) {
+	const op = "service.Service.ApproveAdjustment"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":          op,
+		"adjustment_id": params.ID,
+		"actor":         params.Actor,
+	})
+
+	logger.Info()
+
+	if err := validateReviewAdjustmentParams(params, false); err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	var adjustment *Adjustment
+
+	err := service.store.WithRetryTx(ctx, func(q sqlc.Querier) error {
+		pending, err := getPendingAdjustment(ctx, q, params.ID)
+		if err != nil {
+			return err
+		}
+		if pending.RequestedBy == params.Actor {
+			return fmt.Errorf("%w: %s requested it", ErrSelfApproval, params.Actor)
+		}
+
+		before, err := toAdjustment(pending)
+		if err != nil {
+			return err
+		}
+
+		transactionID, previousBalance, err := service.applyAdjustment(ctx, q, before, params.Actor)
+		if err != nil {
+			return err
+		}
+
+		reviewed, err := q.ReviewPendingAdjustment(ctx, sqlc.ReviewPendingAdjustmentParams{
+			Status:        AdjustmentStatusApproved,
+			ReviewedBy:    pgtype.Text{String: params.Actor, Valid: true},
+			ReviewNote:    pgtype.Text{String: params.Note, Valid: params.Note != ""},
+			TransactionID: transactionID,
+			ID:            pending.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to approve adjustment: %w", err)
+		}
+
+		adjustment, err = toAdjustment(reviewed)
+		if err != nil {
+			return err
+		}
+
+		newBalance := previousBalance.Add(adjustment.Amount)
+		adjustment.PreviousBalance = &previousBalance
+		adjustment.NewBalance = &newBalance
+
+		return createAdminAudit(ctx, q, AdminAction{
+			Actor:        params.Actor,
+			Action:       AdminActionApproveAdjustment,
+			ResourceType: "adjustment",
+			ResourceID:   adjustment.ID.String(),
+			Before:       before,
+			After:        adjustment,
+		})
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to approve adjustment: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"transaction_id": adjustment.TransactionID,
+		"new_balance":    adjustment.NewBalance.String(),
+	}).Info("Adjustment approved")
+
+	return adjustment, nil
+}
+
+// RejectAdjustment closes a pending adjustment without changing the balance. Any operator may reject it, including
+// its requester withdrawing it, and the rejection is recorded in core.admin_audit in the same database transaction.
+func (service *Service) RejectAdjustment(ctx context.Context, params ReviewAdjustmentParams) (*Adjustment, error) {
+	const op = "service.Service.RejectAdjustment"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":          op,
+		"adjustment_id": params.ID,
+		"actor":         params.Actor,
+	})
+
+	logger.Info()
+
+	if err := validateReviewAdjustmentParams(params, true); err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	var adjustment *Adjustment
+
+	err := service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		pending, err := getPendingAdjustment(ctx, q, params.ID)
+		if err != nil {
+			return err
+		}
+
+		before, err := toAdjustment(pending)
+		if err != nil {
+			return err
+		}
+
+		reviewed, err := q.ReviewPendingAdjustment(ctx, sqlc.ReviewPendingAdjustmentParams{
+			Status:     AdjustmentStatusRejected,
+			ReviewedBy: pgtype.Text{String: params.Actor, Valid: true},
+			ReviewNote: pgtype.Text{String: params.Note, Valid: true},
+			ID:         pending.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reject adjustment: %w", err)
+		}
+
+		adjustment, err = toAdjustment(reviewed)
+		if err != nil {
+			return err
+		}
+
+		return createAdminAudit(ctx, q, AdminAction{
+			Actor:        params.Actor,
+			Action:       AdminActionRejectAdjustment,
+			ResourceType: "adjustment",
+			ResourceID:   adjustment.ID.String(),
+			Before:       before,
+			After:        adjustment,
+		})
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to reject adjustment: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.Info("Adjustment rejected")
+
+	return adjustment, nil
+}
+
+// GetAdjustment returns an adjustment whatever its status
+func (service *Service) GetAdjustment(ctx context.Context, id uuid.UUID) (*Adjustment, error) {
+	pending, err := service.store.GetPendingAdjustment(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrAdjustmentNotFound, id)
+		}
+
+		return nil, fmt.Errorf("failed to get adjustment %s: %w", id, err)
+	}
+
+	return toAdjustment(pending)
+}
+
+// ListAdjustments returns the most recently requested adjustments first
+func (service *Service) ListAdjustments(ctx context.Context, filter AdjustmentFilter) ([]Adjustment, error) {
+	switch filter.Status {
+	case "", AdjustmentStatusPending, AdjustmentStatusApproved, AdjustmentStatusRejected:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidAdjustment, filter.Status)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAdjustmentListLimit
+	}
+	if filter.Limit > maxAdjustmentListLimit {
+		filter.Limit = maxAdjustmentListLimit
+	}
+
+	rows, err := service.store.ListPendingAdjustments(ctx, sqlc.ListPendingAdjustmentsParams{
+		Status:    pgtype.Text{String: filter.Status, Valid: filter.Status != ""},
+		AccountID: pgtype.UUID{Bytes: filter.AccountID, Valid: filter.AccountID != uuid.Nil},
+		RowLimit:  filter.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list adjustments: %w", err)
+	}
+
+	adjustments := make([]Adjustment, 0, len(rows))
+	for _, row := range rows {
+		adjustment, err := toAdjustment(row)
+		if err != nil {
+			return nil, err
+		}
+		adjustments = append(adjustments, *adjustment)
+	}
+
+	return adjustments, nil
+}
+
+// applyAdjustment posts an approved adjustment to the ledger and the account balance and returns the transaction
+// and the balance before it. It must run inside a database transaction.
+func (service *Service) applyAdjustment(ctx context.Context, q sqlc.Querier, adjustment *Adjustment, approvedBy string) (pgtype.UUID, decimal.Decimal, error) {
+	account, err := service.getAccount(ctx, q.GetAccountByID, adjustment.AccountID)
+	if err != nil {
+		return pgtype.UUID{}, decimal.Zero, err
+	}
+
+	if err := checkAdjustableAccount(account, adjustment.Currency); err != nil {
+		return pgtype.UUID{}, decimal.Zero, err
+	}
+
+	_, previousBalance, err := service.lockAccount(ctx, q, account.ID)
+	if err != nil {
+		return pgtype.UUID{}, decimal.Zero, err
+	}
+
+	if previousBalance.Add(adjustment.Amount).IsNegative() {
+		return pgtype.UUID{}, decimal.Zero, fmt.Errorf("%w: balance %s cannot cover an adjustment of %s", ErrInsufficientFunds, previousBalance, adjustment.Amount)
+	}
+
+	transactionType := sqlc.CoreTransactionTypeCredit
+	if adjustment.Amount.IsNegative() {
+		transactionType = sqlc.CoreTransactionTypeDebit
+	}
+
+	pgAmount, err := service.decimalToPgNumeric(adjustment.Amount.Abs())
+	if err != nil {
+		return pgtype.UUID{}, decimal.Zero, fmt.Errorf("failed to convert amount: %w", err)
+	}
+
+	metadata, err := json.Marshal(map[string]any{
+		"adjustment_id": adjustment.ID,
+		"requested_by":  adjustment.RequestedBy,
+		"approved_by":   approvedBy,
+	})
+	if err != nil {
+		return pgtype.UUID{}, decimal.Zero, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	transaction, err := q.CreateTransaction(ctx, sqlc.CreateTransactionParams{
+		AccountID:       account.ID,
+		TransactionType: transactionType,
+		Amount:          pgAmount,
+		Currency:        account.Currency,
+		Description:     pgtype.Text{String: adjustment.Reason, Valid: true},
+		ReferenceID:     pgtype.Text{String: adjustment.ID.String(), Valid: true},
+		Metadata:        metadata,
+	})
+	if err != nil {
+		return pgtype.UUID{}, decimal.Zero, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	if _, err := service.applyBalanceChange(ctx, q, account.ID, transaction.ID, previousBalance, adjustment.Amount, balanceAdjustmentOperation, adjustmentCreatedBy); err != nil {
+		return pgtype.UUID{}, decimal.Zero, err
+	}
+
+	return transaction.ID, previousBalance, nil
+}
+
+// checkAdjustableAccount rejects adjustments of closed accounts and in another currency than the account's
+func checkAdjustableAccount(account sqlc.CoreAccount, currency string) error {
+	if account.Status == sqlc.CoreAccountStatusClosed {
+		return fmt.Errorf("%w: account %s is closed", ErrAccountBlocked, account.AccountNumber)
+	}
+	if string(account.Currency) != currency {
+		return fmt.Errorf("%w: account has %s, adjustment has %s", ErrInvalidCurrency, account.Currency, currency)
+	}
+
+	return nil
+}
+
+// getPendingAdjustment locks an adjustment that has not been reviewed yet for the rest of the database transaction
+func getPendingAdjustment(ctx context.Context, q sqlc.Querier, id uuid.UUID) (sqlc.CorePendingAdjustment, error) {
+	pending, err := q.GetPendingAdjustmentForUpdate(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.CorePendingAdjustment{}, fmt.Errorf("%w: %s", ErrAdjustmentNotFound, id)
+		}
+
+		return sqlc.CorePendingAdjustment{}, fmt.Errorf("failed to get adjustment %s: %w", id, err)
+	}
+
+	if pending.Status != AdjustmentStatusPending {
+		return sqlc.CorePendingAdjustment{}, fmt.Errorf("%w: %s is %s", ErrAdjustmentReviewed, id, pending.Status)
+	}
+
+	return pending, nil
+}
+
+// toAdjustment converts a core.pending_adjustments row
+func toAdjustment(row sqlc.CorePendingAdjustment) (*Adjustment, error) {
+	amount, err := numeric.ToDecimal(row.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert adjustment amount: %w", err)
+	}
+
+	adjustment := &Adjustment{
+		ID:          uuid.UUID(row.ID.Bytes),
+		AccountID:   uuid.UUID(row.AccountID.Bytes),
+		Amount:      amount,
+		Currency:    string(row.Currency),
+		Reason:      row.Reason,
+		Status:      row.Status,
+		RequestedBy: row.RequestedBy,
+		RequestedAt: row.RequestedAt.Time,
+	}
+	if row.ReviewedBy.Valid {
+		adjustment.ReviewedBy = &row.ReviewedBy.String
+	}
+	if row.ReviewedAt.Valid {
+		adjustment.ReviewedAt = &row.ReviewedAt.Time
+	}
+	if row.ReviewNote.Valid {
+		adjustment.ReviewNote = &row.ReviewNote.String
+	}
+	if row.TransactionID.Valid {
+		transactionID := uuid.UUID(row.TransactionID.Bytes)
+		adjustment.TransactionID = &transactionID
+	}
+
+	return adjustment, nil
+}
+
+// validatePostAdjustmentParams checks an adjustment request before any account is read
+func (service *Service) validatePostAdjustmentParams(params PostAdjustmentParams) error {
+	if params.AccountID == uuid.Nil {
+		return fmt.Errorf("%w: account_id is required", ErrInvalidAdjustment)
+	}
+	if err := validateAmountSign(AmountOperationAdjustment, "amount", params.Amount); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAdjustment, err)
+	}
+	if _, ok := currencyDecimalPlaces[params.Currency]; !ok {
+		return fmt.Errorf("%w: unsupported currency %q", ErrInvalidAdjustment, params.Currency)
+	}
+	if err := validateAmountScale(params.Amount, params.Currency); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAdjustment, err)
+	}
+	if err := service.checkAmountPrecision("amount", params.Amount); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidAdjustment, err)
+	}
+	if strings.TrimSpace(params.Reason) == "" {
+		return fmt.Errorf("%w: reason is required", ErrInvalidAdjustment)
+	}
+	if len(params.Reason) > maxAdjustmentReasonLength {
+		return fmt.Errorf("%w: reason must be at most %d characters", ErrInvalidAdjustment, maxAdjustmentReasonLength)
+	}
+
+	return validateAdjustmentActor(params.Actor)
+}
+
+// validateReviewAdjustmentParams checks an approval or, with noteRequired, a rejection
+func validateReviewAdjustmentParams(params ReviewAdjustmentParams, noteRequired bool) error {
+	if params.ID == uuid.Nil {
+		return fmt.Errorf("%w: id is required", ErrInvalidAdjustment)
+	}
+	if noteRequired && strings.TrimSpace(params.Note) == "" {
+		return fmt.Errorf("%w: note is required", ErrInvalidAdjustment)
+	}
+	if len(params.Note) > maxAdjustmentReasonLength {
+		return fmt.Errorf("%w: note must be at most %d characters", ErrInvalidAdjustment, maxAdjustmentReasonLength)
+	}
+
+	return validateAdjustmentActor(params.Actor)
+}
+
+// validateAdjustmentActor requires a named operator, without which requester and approver cannot be told apart
+func validateAdjustmentActor(actor string) error {
+	if strings.TrimSpace(actor) == "" {
+		return fmt.Errorf("%w: actor is required", ErrInvalidAdjustment)
+	}
+	if len(actor) > maxAdjustmentActorLength {
+		return fmt.Errorf("%w: actor must be at most %d characters", ErrInvalidAdjustment, maxAdjustmentActorLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdjustmentMakerChecker(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	ledger := newMemoryLedger()
+	accountID := ledger.addAccount("ACC000000001", decimal.NewFromInt(100))
+	service := &Service{logger: logger, store: newMemoryStore(ledger)}
+
+	post := func(t *testing.T, amount string) *Adjustment {
+		t.Helper()
+
+		adjustment, err := service.PostAdjustment(context.Background(), PostAdjustmentParams{
+			AccountID: accountID,
+			Amount:    decimal.RequireFromString(amount),
+			Currency:  "USD",
+			Reason:    "Refund of a duplicated fee",
+			Actor:     "maker@example.com",
+		})
+		require.NoError(t, err)
+
+		return adjustment
+	}
+
+	t.Run("pending_until_approved", func(t *testing.T) {
+		adjustment := post(t, "25.50")
+
+		assert.Equal(t, AdjustmentStatusPending, adjustment.Status)
+		assert.True(t, ledger.balances[accountID].Equal(decimal.NewFromInt(100)), "balance changed before approval")
+
+		_, err := service.ApproveAdjustment(context.Background(), ReviewAdjustmentParams{ID: adjustment.ID, Actor: "maker@example.com"})
+		assert.ErrorIs(t, err, ErrSelfApproval)
+		assert.True(t, ledger.balances[accountID].Equal(decimal.NewFromInt(100)))
+
+		approved, err := service.ApproveAdjustment(context.Background(), ReviewAdjustmentParams{ID: adjustment.ID, Actor: "checker@example.com"})
+		require.NoError(t, err)
+
+		assert.Equal(t, AdjustmentStatusApproved, approved.Status)
+		require.NotNil(t, approved.ReviewedBy)
+		assert.Equal(t, "checker@example.com", *approved.ReviewedBy)
+		require.NotNil(t, approved.TransactionID)
+		assert.True(t, approved.NewBalance.Equal(decimal.RequireFromString("125.50")))
+		assert.True(t, ledger.balances[accountID].Equal(decimal.RequireFromString("125.50")))
+
+		_, err = service.ApproveAdjustment(context.Background(), ReviewAdjustmentParams{ID: adjustment.ID, Actor: "other@example.com"})
+		assert.ErrorIs(t, err, ErrAdjustmentReviewed)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		adjustment := post(t, "-0.50")
+
+		_, err := service.ApproveAdjustment(context.Background(), ReviewAdjustmentParams{ID: adjustment.ID, Actor: "checker@example.com"})
+		require.NoError(t, err)
+		assert.True(t, ledger.balances[accountID].Equal(decimal.NewFromInt(125)))
+	})
+
+	t.Run("overdraw_stays_pending", func(t *testing.T) {
+		adjustment := post(t, "-126")
+
+		_, err := service.ApproveAdjustment(context.Background(), ReviewAdjustmentParams{ID: adjustment.ID, Actor: "checker@example.com"})
+		assert.ErrorIs(t, err, ErrInsufficientFunds)
+
+		stored, err := service.GetAdjustment(context.Background(), adjustment.ID)
+		require.NoError(t, err)
+		assert.Equal(t, AdjustmentStatusPending, stored.Status)
+
+		_, err = service.RejectAdjustment(context.Background(), ReviewAdjustmentParams{ID: adjustment.ID, Actor: "checker@example.com"})
+		assert.ErrorIs(t, err, ErrInvalidAdjustment, "a rejection needs a note")
+
+		rejected, err := service.RejectAdjustment(context.Background(), ReviewAdjustmentParams{ID: adjustment.ID, Actor: "checker@example.com", Note: "Exceeds the balance"})
+		require.NoError(t, err)
+		assert.Equal(t, AdjustmentStatusRejected, rejected.Status)
+		assert.Nil(t, rejected.TransactionID)
+		assert.True(t, ledger.balances[accountID].Equal(decimal.NewFromInt(125)))
+	})
+
+	t.Run("currency_mismatch", func(t *testing.T) {
+		_, err := service.PostAdjustment(context.Background(), PostAdjustmentParams{
+			AccountID: accountID,
+			Amount:    decimal.NewFromInt(1),
+			Currency:  "EUR",
+			Reason:    "Chargeback",
+			Actor:     "maker@example.com",
+		})
+		assert.ErrorIs(t, err, ErrInvalidCurrency)
+	})
+
+	actions := make([]string, 0, len(ledger.adminAudits))
+	for _, audit := range ledger.adminAudits {
+		actions = append(actions, audit.Action)
+	}
+	assert.Equal(t, []string{
+		AdminActionRequestAdjustment, AdminActionApproveAdjustment,
+		AdminActionRequestAdjustment, AdminActionApproveAdjustment,
+		AdminActionRequestAdjustment, AdminActionRejectAdjustment,
+	}, actions)
+
+	// Approved adjustments are in the ledger, so recalculating finds no drift
+	results, err := service.RecalculateBalance(context.Background(), RecalculateBalanceParams{AccountID: accountID})
+	require.NoError(t, err)
+	assert.True(t, results.Drift.IsZero(), "drift = %s", results.Drift)
+}
+
+func TestValidatePostAdjustmentParams(t *testing.T) {
+	t.Parallel()
+
+	service := &Service{}
+
+	valid := PostAdjustmentParams{
+		AccountID: uuid.New(),
+		Amount:    decimal.RequireFromString("-10.25"),
+		Currency:  "USD",
+		Reason:    "Fee reversal",
+		Actor:     "maker@example.com",
+	}
+	assert.NoError(t, service.validatePostAdjustmentParams(valid))
+
+	tests := []struct {
+		name   string
+		modify func(*PostAdjustmentParams)
+	}{
+		{name: "Missing account", modify: func(params *PostAdjustmentParams) { params.AccountID = uuid.Nil }},
+		{name: "Zero amount", modify: func(params *PostAdjustmentParams) { params.Amount = decimal.Zero }},
+		{name: "Unsupported currency", modify: func(params *PostAdjustmentParams) { params.Currency = "XYZ" }},
+		{name: "Fractional yen", modify: func(params *PostAdjustmentParams) { params.Currency = "JPY" }},
+		{name: "Missing reason", modify: func(params *PostAdjustmentParams) { params.Reason = " " }},
+		{name: "Missing actor", modify: func(params *PostAdjustmentParams) { params.Actor = "" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params := valid
+			tt.modify(&params)
+
+			assert.ErrorIs(t, service.validatePostAdjustmentParams(params), ErrInvalidAdjustment)
+		})
+	}
+}
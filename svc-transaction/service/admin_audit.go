@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+)
+
+// adminAuditService identifies this service in core.admin_audit, which svc-balance writes to as well
+const adminAuditService = "svc-transaction"
+
+// Admin actions recorded by this service
+const (
+	AdminActionResetFailureSimulation      = "failure_simulation.reset"
+	AdminActionSetFailureSimulationEnabled = "failure_simulation.set_enabled"
+	AdminActionSetFailureRuleEnabled       = "failure_simulation.set_rule_enabled"
+	AdminActionSetLatencyProfile           = "latency_profile.set"
+	AdminActionOpenAccount                 = "account.open"
+	AdminActionRetryCompensation           = "compensation.retry"
+	AdminActionStartAccountClosure         = "account.closure.start"
+	AdminActionStartAccountErasure         = "account.erasure.start"
+	AdminActionGenerateSettlementFile      = "settlement_file.generate"
+	AdminActionAcknowledgeSettlementFile   = "settlement_file.acknowledge"
+	AdminActionSweepPendingTransaction     = "transaction.sweep"
+	AdminActionRecalculateBalance          = "account.balance.recalculate"
+	AdminActionStartLedgerExport           = "ledger_export.start"
+	AdminActionStartEODBalances            = "eod_balances.start"
+	AdminActionAddAccountOwner             = "account.owner.add"
+	AdminActionRemoveAccountOwner          = "account.owner.remove"
+	AdminActionRequestAdjustment           = "adjustment.request"
+	AdminActionApproveAdjustment           = "adjustment.approve"
+	AdminActionRejectAdjustment            = "adjustment.reject"
+	AdminActionReportCompensationReview    = "compensation.review_report"
+)
+
+// ErrInvalidAdminAuditFilter is returned when an admin audit filter cannot be applied
+var ErrInvalidAdminAuditFilter = errors.New("invalid admin audit filter")
+
+// AdminAction describes one state-changing admin call. Before and After are stored as JSON; nil stores NULL.
+type AdminAction struct {
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Before       any
+	After        any
+}
+
+// AdminAuditFilter narrows admin audit queries. Zero-valued fields leave that dimension unfiltered.
+// From is inclusive and To is exclusive.
+type AdminAuditFilter struct {
+	Actor        string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	From         time.Time
+	To           time.Time
+	Limit        int32
+}
+
+func (filter AdminAuditFilter) validate() error {
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidAdminAuditFilter)
+	}
+
+	return nil
+}
+
+// RecordAdminAction stores action in core.admin_audit
+func (service *Service) RecordAdminAction(ctx context.Context, action AdminAction) error {
+	const op = "service.Service.RecordAdminAction"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":          op,
+		"actor":         action.Actor,
+		"action":        action.Action,
+		"resource_type": action.ResourceType,
+		"resource_id":   action.ResourceID,
+	})
+
+	if err := createAdminAudit(ctx, service.store, action); err != nil {
+		logger.WithError(err).Error()
+		return err
+	}
+
+	logger.Debug("Recorded admin action")
+
+	return nil
+}
+
+// ListAdminAudits returns the most recent admin audit records matching filter
+func (service *Service) ListAdminAudits(ctx context.Context, filter AdminAuditFilter) ([]sqlc.CoreAdminAudit, error) {
+	const op = "service.Service.ListAdminAudits"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"filter": fmt.Sprintf("%+v", filter),
+	})
+
+	logger.Debug("Listing admin audit records")
+
+	if err := filter.validate(); err != nil {
+		logger.WithError(err).Debug()
+		return nil, err
+	}
+
+	records, err := service.store.ListAdminAudits(ctx, sqlc.ListAdminAuditsParams{
+		Actor:        pgtype.Text{String: filter.Actor, Valid: filter.Actor != ""},
+		Action:       pgtype.Text{String: filter.Action, Valid: filter.Action != ""},
+		ResourceType: pgtype.Text{String: filter.ResourceType, Valid: filter.ResourceType != ""},
+		ResourceID:   pgtype.Text{String: filter.ResourceID, Valid: filter.ResourceID != ""},
+		CreatedFrom:  pgtype.Timestamptz{Time: filter.From, Valid: !filter.From.IsZero()},
+		CreatedTo:    pgtype.Timestamptz{Time: filter.To, Valid: !filter.To.IsZero()},
+		RowLimit:     filter.Limit,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to list admin audit records: %w", err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	logger.WithField("record_count", len(records)).Debug("Listed admin audit records")
+
+	return records, nil
+}
+
+// createAdminAudit stores action through q, so changes made in a transaction are recorded in that same transaction
+// and are rolled back together with their record
+func createAdminAudit(ctx context.Context, q sqlc.Querier, action AdminAction) error {
+	params, err := newCreateAdminAuditParams(action)
+	if err != nil {
+		return err
+	}
+
+	if _, err := q.CreateAdminAudit(ctx, params); err != nil {
+		return fmt.Errorf("failed to record admin action: %w", err)
+	}
+
+	return nil
+}
+
+func newCreateAdminAuditParams(action AdminAction) (sqlc.CreateAdminAuditParams, error) {
+	before, err := adminAuditPayload(action.Before)
+	if err != nil {
+		return sqlc.CreateAdminAuditParams{}, fmt.Errorf("failed to encode before payload: %w", err)
+	}
+
+	after, err := adminAuditPayload(action.After)
+	if err != nil {
+		return sqlc.CreateAdminAuditParams{}, fmt.Errorf("failed to encode after payload: %w", err)
+	}
+
+	return sqlc.CreateAdminAuditParams{
+		Actor:         action.Actor,
+		Action:        action.Action,
+		Service:       adminAuditService,
+		ResourceType:  action.ResourceType,
+		ResourceID:    pgtype.Text{String: action.ResourceID, Valid: action.ResourceID != ""},
+		BeforePayload: before,
+		AfterPayload:  after,
+	}, nil
+}
+
+// adminAuditPayload encodes payload as JSON, returning nil (stored as NULL) for nil values, including nil pointers
+func adminAuditPayload(payload any) ([]byte, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil || string(encoded) == "null" {
+		return nil, err
+	}
+
+	return encoded, nil
+}
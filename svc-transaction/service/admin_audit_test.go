@@ -0,0 +1,109 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewCreateAdminAuditParams(t *testing.T) {
+	t.Parallel()
+
+	var missing *SettlementFileResults
+
+	tests := []struct {
+		name       string
+		action     AdminAction
+		wantBefore string
+		wantAfter  string
+		wantID     bool
+	}{
+		{
+			name: "Before and after payloads",
+			action: AdminAction{
+				Actor:        "ops@example.com",
+				Action:       AdminActionAcknowledgeSettlementFile,
+				ResourceType: "settlement_file",
+				ResourceID:   "file-1",
+				Before:       map[string]string{"status": "sent"},
+				After:        map[string]string{"status": "accepted"},
+			},
+			wantBefore: `{"status":"sent"}`,
+			wantAfter:  `{"status":"accepted"}`,
+			wantID:     true,
+		},
+		{
+			name: "No resource and no before payload",
+			action: AdminAction{
+				Actor:        "ops@example.com",
+				Action:       AdminActionResetFailureSimulation,
+				ResourceType: "failure_simulation",
+				After:        map[string]int{"total": 0},
+			},
+			wantAfter: `{"total":0}`,
+		},
+		{
+			name: "Nil pointer payload is stored as NULL",
+			action: AdminAction{
+				Actor:        "ops@example.com",
+				Action:       AdminActionAcknowledgeSettlementFile,
+				ResourceType: "settlement_file",
+				ResourceID:   "file-2",
+				Before:       missing,
+			},
+			wantID: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params, err := newCreateAdminAuditParams(tt.action)
+			if err != nil {
+				t.Fatalf("newCreateAdminAuditParams() error = %v", err)
+			}
+
+			if params.Service != adminAuditService {
+				t.Errorf("service = %s, want %s", params.Service, adminAuditService)
+			}
+			if params.Actor != tt.action.Actor || params.Action != tt.action.Action {
+				t.Errorf("actor, action = %s, %s, want %s, %s", params.Actor, params.Action, tt.action.Actor, tt.action.Action)
+			}
+			if params.ResourceID.Valid != tt.wantID || params.ResourceID.String != tt.action.ResourceID {
+				t.Errorf("resource_id = %+v, want %q", params.ResourceID, tt.action.ResourceID)
+			}
+			if got := string(params.BeforePayload); got != tt.wantBefore {
+				t.Errorf("before_payload = %q, want %q", got, tt.wantBefore)
+			}
+			if got := string(params.AfterPayload); got != tt.wantAfter {
+				t.Errorf("after_payload = %q, want %q", got, tt.wantAfter)
+			}
+		})
+	}
+}
+
+func TestNewCreateAdminAuditParamsRejectsUnencodablePayload(t *testing.T) {
+	t.Parallel()
+
+	_, err := newCreateAdminAuditParams(AdminAction{Action: AdminActionStartAccountClosure, After: make(chan int)})
+	if err == nil {
+		t.Fatal("newCreateAdminAuditParams() error = nil, want an encoding error")
+	}
+}
+
+func TestAdminAuditFilterValidate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if err := (AdminAuditFilter{From: now, To: now.Add(time.Hour)}).validate(); err != nil {
+		t.Errorf("validate() error = %v, want nil", err)
+	}
+	if err := (AdminAuditFilter{From: now}).validate(); err != nil {
+		t.Errorf("validate() with open range error = %v, want nil", err)
+	}
+	if err := (AdminAuditFilter{From: now, To: now}).validate(); !errors.Is(err, ErrInvalidAdminAuditFilter) {
+		t.Errorf("validate() error = %v, want %v", err, ErrInvalidAdminAuditFilter)
+	}
+}
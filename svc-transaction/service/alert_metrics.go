@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"svc-transaction/util/alerting"
+
+	"go.temporal.io/sdk/client"
+)
+
+// Metrics the alerting rules of this service can watch
+const (
+	AlertMetricCompensationRate          = "compensation_rate"           // Compensations recorded over the last hour
+	AlertMetricCompensationFailureRatio  = "compensation_failure_ratio"  // Share of those that failed, timed out or need manual work, 0 to 1
+	AlertMetricFailureSimulationTriggers = "failure_simulation_triggers" // Failures injected during the last minute
+	AlertMetricFailureSimulationTripped  = "failure_simulation_tripped"  // 1 when the simulator switched itself off, else 0
+	AlertMetricWorkflowTaskBacklog       = "workflow_task_backlog"       // Approximate workflow tasks waiting on the task queue of the rule
+)
+
+// alertCompensationWindow is the period compensation metrics cover
+const alertCompensationWindow = time.Hour
+
+// AlertSources returns the metrics alerting rules can watch, by name
+func (service *Service) AlertSources() map[string]alerting.Source {
+	return map[string]alerting.Source{
+		AlertMetricCompensationRate: func(ctx context.Context, _ alerting.Rule) (float64, error) {
+			stats, _, err := service.GetCompensationStats(ctx, CompensationAuditFilter{From: time.Now().Add(-alertCompensationWindow)})
+			if err != nil {
+				return 0, err
+			}
+
+			return float64(stats.TotalCompensations), nil
+		},
+		AlertMetricCompensationFailureRatio: func(ctx context.Context, _ alerting.Rule) (float64, error) {
+			stats, _, err := service.GetCompensationStats(ctx, CompensationAuditFilter{From: time.Now().Add(-alertCompensationWindow)})
+			if err != nil {
+				return 0, err
+			}
+			if stats.TotalCompensations == 0 {
+				return 0, nil
+			}
+
+			unsuccessful := stats.FailedCompensations + stats.TimeoutCompensations + stats.ManualCompensations
+
+			return float64(unsuccessful) / float64(stats.TotalCompensations), nil
+		},
+		AlertMetricFailureSimulationTriggers: func(context.Context, alerting.Rule) (float64, error) {
+			return float64(service.failureSimulator.RecentFailures()), nil
+		},
+		AlertMetricFailureSimulationTripped: func(context.Context, alerting.Rule) (float64, error) {
+			if _, tripped := service.failureSimulator.GetStats()["disabled_reason"]; tripped {
+				return 1, nil
+			}
+
+			return 0, nil
+		},
+		AlertMetricWorkflowTaskBacklog: service.workflowTaskBacklog,
+	}
+}
+
+// workflowTaskBacklog reads the approximate workflow task backlog of the task queue of rule from Temporal, summed
+// over its build IDs
+func (service *Service) workflowTaskBacklog(ctx context.Context, rule alerting.Rule) (float64, error) {
+	if service.temporalClient == nil {
+		return 0, errors.New("temporal client not connected")
+	}
+
+	taskQueue := rule.TaskQueue
+	if taskQueue == "" {
+		taskQueue = service.taskQueue
+	}
+
+	description, err := service.temporalClient.DescribeTaskQueueEnhanced(ctx, client.DescribeTaskQueueEnhancedOptions{
+		TaskQueue:      taskQueue,
+		TaskQueueTypes: []client.TaskQueueType{client.TaskQueueTypeWorkflow},
+		ReportStats:    true,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var backlog int64
+	for _, version := range description.VersionsInfo {
+		if info, ok := version.TypesInfo[client.TaskQueueTypeWorkflow]; ok && info.Stats != nil {
+			backlog += info.Stats.ApproximateBacklogCount
+		}
+	}
+
+	return float64(backlog), nil
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// AmountOperation is the kind of operation an amount enters the service with
+type AmountOperation string
+
+const (
+	AmountOperationDebit            AmountOperation = "debit"
+	AmountOperationCredit           AmountOperation = "credit"
+	AmountOperationCompensation     AmountOperation = "compensation"
+	AmountOperationInternalTransfer AmountOperation = "internal_transfer"
+	AmountOperationOpeningBalance   AmountOperation = "opening_balance"
+	AmountOperationAdjustment       AmountOperation = "adjustment"
+)
+
+// AmountPolicy is the sign an amount of an operation may have. Amounts are positive unless the policy allows more.
+type AmountPolicy struct {
+	AllowZero     bool
+	AllowNegative bool
+}
+
+// amountPolicies holds the policy of every operation. Debits, credits, compensations and transfers carry their
+// direction in the operation, so their amounts are strictly positive; an account may open without funds; an
+// adjustment carries its direction in its sign, so it can be negative but never zero.
+var amountPolicies = map[AmountOperation]AmountPolicy{
+	AmountOperationDebit:            {},
+	AmountOperationCredit:           {},
+	AmountOperationCompensation:     {},
+	AmountOperationInternalTransfer: {},
+	AmountOperationOpeningBalance:   {AllowZero: true},
+	AmountOperationAdjustment:       {AllowNegative: true},
+}
+
+// validateAmountSign rejects an amount whose sign the policy of operation does not allow, naming field in the error
+func validateAmountSign(operation AmountOperation, field string, amount decimal.Decimal) error {
+	policy, ok := amountPolicies[operation]
+	if !ok {
+		return fmt.Errorf("no amount policy for operation %q", operation)
+	}
+
+	if amount.IsZero() && !policy.AllowZero {
+		return fmt.Errorf("%s cannot be zero", field)
+	}
+
+	if amount.IsNegative() && !policy.AllowNegative {
+		return fmt.Errorf("%s cannot be negative", field)
+	}
+
+	return nil
+}
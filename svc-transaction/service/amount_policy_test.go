@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAmountSign(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		operation AmountOperation
+		amount    string
+		wantErr   string
+	}{
+		{operation: AmountOperationDebit, amount: "10"},
+		{operation: AmountOperationDebit, amount: "0", wantErr: "amount cannot be zero"},
+		{operation: AmountOperationCredit, amount: "-10", wantErr: "amount cannot be negative"},
+		{operation: AmountOperationCompensation, amount: "-10", wantErr: "amount cannot be negative"},
+		{operation: AmountOperationInternalTransfer, amount: "0", wantErr: "amount cannot be zero"},
+		{operation: AmountOperationOpeningBalance, amount: "0"},
+		{operation: AmountOperationOpeningBalance, amount: "-10", wantErr: "amount cannot be negative"},
+		{operation: AmountOperationAdjustment, amount: "-10"},
+		{operation: AmountOperationAdjustment, amount: "10"},
+		{operation: AmountOperationAdjustment, amount: "0", wantErr: "amount cannot be zero"},
+		{operation: "refund", amount: "10", wantErr: `no amount policy for operation "refund"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.operation)+"_"+tt.amount, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateAmountSign(tt.operation, "amount", decimal.RequireFromString(tt.amount))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
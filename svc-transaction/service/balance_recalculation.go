@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// balanceRecalculationCreatedBy is recorded in core.account_balance_history.created_by for drift corrections
+const balanceRecalculationCreatedBy = "transaction_service_balance_recalculation"
+
+// balanceAdjustmentOperation is the balance history operation of a correction that has no transaction of its own
+const balanceAdjustmentOperation = "adjustment"
+
+// ErrBalanceRecalculationRejected is returned when the drift of an account cannot be corrected
+var ErrBalanceRecalculationRejected = errors.New("balance recalculation rejected")
+
+// RecalculateBalanceParams selects the account to recalculate. Without Fix the drift is only reported.
+type RecalculateBalanceParams struct {
+	AccountID uuid.UUID
+	Fix       bool
+	Actor     string // Recorded in the admin audit when the drift is corrected
+}
+
+// RecalculateBalanceResults compares the stored balance of an account with the balance its ledger implies
+type RecalculateBalanceResults struct {
+	AccountID        uuid.UUID       `json:"account_id"`
+	StoredBalance    decimal.Decimal `json:"stored_balance"`
+	LedgerBalance    decimal.Decimal `json:"ledger_balance"`
+	Drift            decimal.Decimal `json:"drift"` // LedgerBalance - StoredBalance
+	TransactionCount int64           `json:"transaction_count"`
+	Fixed            bool            `json:"fixed"`
+	AdjustmentID     *uuid.UUID      `json:"adjustment_id,omitempty"` // Balance history entry of the correction
+}
+
+// RecalculateBalance recomputes the balance of an account from its completed transactions, inside a serializable
+// database transaction holding the account lock, and compares it with the stored balance. With Fix, any drift is
+// corrected by moving the stored balance to the ledger balance and recording the correction as an "adjustment"
+// balance history entry without a transaction, which keeps the balance history chain continuous. The correction is
+// recorded in core.admin_audit in the same database transaction.
+func (service *Service) RecalculateBalance(ctx context.Context, params RecalculateBalanceParams) (*RecalculateBalanceResults, error) {
+	const op = "service.Service.RecalculateBalance"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"account_id": params.AccountID,
+		"fix":        params.Fix,
+		"actor":      params.Actor,
+	})
+
+	results := &RecalculateBalanceResults{AccountID: params.AccountID}
+
+	err := service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		account, err := service.getAccount(ctx, q.GetAccountByID, params.AccountID)
+		if err != nil {
+			return err
+		}
+
+		_, storedBalance, err := service.lockAccount(ctx, q, account.ID)
+		if err != nil {
+			return err
+		}
+
+		ledger, err := q.GetAccountLedgerBalance(ctx, account.ID)
+		if err != nil {
+			return fmt.Errorf("failed to compute ledger balance: %w", err)
+		}
+
+		ledgerBalance, err := service.pgNumericToDecimal(ledger.LedgerBalance)
+		if err != nil {
+			return fmt.Errorf("failed to convert ledger balance: %w", err)
+		}
+
+		results.StoredBalance = storedBalance
+		results.LedgerBalance = ledgerBalance
+		results.Drift = ledgerBalance.Sub(storedBalance)
+		results.TransactionCount = ledger.TransactionCount
+
+		if !params.Fix || results.Drift.IsZero() {
+			return nil
+		}
+
+		if ledgerBalance.IsNegative() {
+			return fmt.Errorf("%w: ledger balance %s is negative", ErrBalanceRecalculationRejected, ledgerBalance)
+		}
+
+		adjustmentID, err := service.applyBalanceAdjustment(ctx, q, account.ID, storedBalance, results.Drift)
+		if err != nil {
+			return err
+		}
+
+		results.Fixed = true
+		results.AdjustmentID = &adjustmentID
+
+		return createAdminAudit(ctx, q, AdminAction{
+			Actor:        params.Actor,
+			Action:       AdminActionRecalculateBalance,
+			ResourceType: "account",
+			ResourceID:   params.AccountID.String(),
+			Before:       map[string]any{"balance": storedBalance},
+			After: map[string]any{
+				"balance":       ledgerBalance,
+				"drift":         results.Drift,
+				"adjustment_id": adjustmentID,
+			},
+		})
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to recalculate balance: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"stored_balance": results.StoredBalance.String(),
+		"ledger_balance": results.LedgerBalance.String(),
+		"drift":          results.Drift.String(),
+		"fixed":          results.Fixed,
+	}).Info("Balance recalculated")
+
+	return results, nil
+}
+
+// applyBalanceAdjustment changes the account balance by change and records it as an adjustment balance history entry.
+// It must run inside a database transaction after the account has been locked.
+func (service *Service) applyBalanceAdjustment(ctx context.Context, q sqlc.Querier, accountID pgtype.UUID, oldBalance, change decimal.Decimal) (uuid.UUID, error) {
+	pgChange, err := service.decimalToPgNumeric(change)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to convert balance change: %w", err)
+	}
+
+	updated, err := q.AdjustAccountBalance(ctx, sqlc.AdjustAccountBalanceParams{
+		ID:            accountID,
+		BalanceChange: pgChange,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to apply adjustment: %w", err)
+	}
+
+	pgOldBalance, err := service.decimalToPgNumeric(oldBalance)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to convert old balance: %w", err)
+	}
+
+	history, err := q.CreateBalanceHistoryRecord(ctx, sqlc.CreateBalanceHistoryRecordParams{
+		AccountID:     accountID,
+		OldBalance:    pgOldBalance,
+		NewBalance:    updated.Balance,
+		BalanceChange: pgChange,
+		Operation:     balanceAdjustmentOperation,
+		CreatedBy:     pgtype.Text{String: balanceRecalculationCreatedBy, Valid: true},
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to record adjustment balance history: %w", err)
+	}
+
+	return uuid.UUID(history.ID.Bytes), nil
+}
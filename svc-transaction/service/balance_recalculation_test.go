@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecalculateBalance(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	ledger := newMemoryLedger()
+	accountID := ledger.addAccount("ACC000000001", decimal.NewFromInt(1000))
+	service := &Service{logger: logger, store: newMemoryStore(ledger)}
+
+	_, err := service.DebitAccount(context.Background(), DebitAccountParams{
+		AccountID: &accountID,
+		Amount:    decimal.RequireFromString("250.25"),
+		Currency:  "USD",
+	})
+	require.NoError(t, err)
+
+	t.Run("no_drift", func(t *testing.T) {
+		results, err := service.RecalculateBalance(context.Background(), RecalculateBalanceParams{AccountID: accountID, Fix: true, Actor: "ops@example.com"})
+		require.NoError(t, err)
+
+		assert.True(t, results.StoredBalance.Equal(decimal.RequireFromString("749.75")))
+		assert.True(t, results.LedgerBalance.Equal(results.StoredBalance))
+		assert.True(t, results.Drift.IsZero())
+		assert.Equal(t, int64(2), results.TransactionCount)
+		assert.False(t, results.Fixed)
+		assert.Empty(t, ledger.adminAudits)
+	})
+
+	// The stored balance drifts away from the ledger, e.g. after a manual update
+	ledger.balances[accountID] = decimal.RequireFromString("800")
+
+	t.Run("report_only", func(t *testing.T) {
+		results, err := service.RecalculateBalance(context.Background(), RecalculateBalanceParams{AccountID: accountID})
+		require.NoError(t, err)
+
+		assert.True(t, results.Drift.Equal(decimal.RequireFromString("-50.25")))
+		assert.False(t, results.Fixed)
+		assert.Nil(t, results.AdjustmentID)
+		assert.True(t, ledger.balances[accountID].Equal(decimal.RequireFromString("800")))
+	})
+
+	t.Run("fix", func(t *testing.T) {
+		history := ledger.history
+
+		results, err := service.RecalculateBalance(context.Background(), RecalculateBalanceParams{AccountID: accountID, Fix: true, Actor: "ops@example.com"})
+		require.NoError(t, err)
+
+		assert.True(t, results.Fixed)
+		require.NotNil(t, results.AdjustmentID)
+		assert.True(t, ledger.balances[accountID].Equal(decimal.RequireFromString("749.75")))
+		assert.Equal(t, history+1, ledger.history)
+
+		require.Len(t, ledger.adminAudits, 1)
+		assert.Equal(t, "ops@example.com", ledger.adminAudits[0].Actor)
+		assert.Equal(t, AdminActionRecalculateBalance, ledger.adminAudits[0].Action)
+		assert.Equal(t, accountID.String(), ledger.adminAudits[0].ResourceID.String)
+	})
+
+	t.Run("fixed_drift_stays_fixed", func(t *testing.T) {
+		results, err := service.RecalculateBalance(context.Background(), RecalculateBalanceParams{AccountID: accountID})
+		require.NoError(t, err)
+
+		assert.True(t, results.Drift.IsZero())
+		assert.True(t, results.LedgerBalance.Equal(decimal.RequireFromString("749.75")))
+	})
+
+	t.Run("account_not_found", func(t *testing.T) {
+		_, err := service.RecalculateBalance(context.Background(), RecalculateBalanceParams{AccountID: uuid.New()})
+		assert.ErrorIs(t, err, ErrAccountNotFound)
+	})
+}
+
+func TestRecalculateBalanceRejectsNegativeLedgerBalance(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	ledger := newMemoryLedger()
+	accountID := ledger.addAccount("ACC000000001", decimal.NewFromInt(100))
+	// A completed debit the balance never reflected leaves the ledger below zero
+	ledger.transactions = append(ledger.transactions, ledgerTransaction{
+		id:              uuid.New(),
+		accountID:       accountID,
+		transactionType: sqlc.CoreTransactionTypeDebit,
+		amount:          decimal.NewFromInt(150),
+		currency:        sqlc.CoreCurrencyCodeUSD,
+		status:          sqlc.CoreTransactionStatusCompleted,
+		createdAt:       time.Now(),
+	})
+	service := &Service{logger: logger, store: newMemoryStore(ledger)}
+
+	_, err := service.RecalculateBalance(context.Background(), RecalculateBalanceParams{AccountID: accountID, Fix: true})
+	assert.ErrorIs(t, err, ErrBalanceRecalculationRejected)
+	assert.True(t, ledger.balances[accountID].Equal(decimal.NewFromInt(100)))
+	assert.Empty(t, ledger.adminAudits)
+}
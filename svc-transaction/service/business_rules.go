@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-transaction/util/rules"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrBusinessRuleViolation is returned when an operation fails a business rule of severity error
+var ErrBusinessRuleViolation = errors.New("business rule violation")
+
+// SetBusinessRulesRefresh makes the service validate with the rules of core.business_rules, reread every refresh.
+// Without it the service validates with rules.Defaults.
+func (service *Service) SetBusinessRulesRefresh(refresh time.Duration) {
+	service.businessRules = rules.NewCache(service.loadBusinessRules, refresh)
+}
+
+// SetRelaxedValidation makes the business rules of severity error only warn, so operations failing them are flagged
+// rather than rejected. It is set at startup, before the rules are first loaded.
+func (service *Service) SetRelaxedValidation(relaxed bool) {
+	service.relaxedValidation = relaxed
+}
+
+// loadBusinessRules reads the rules of core.business_rules for the rules engine
+func (service *Service) loadBusinessRules(ctx context.Context) ([]rules.Rule, error) {
+	stored, err := service.store.ListBusinessRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := make([]rules.Rule, 0, len(stored))
+	for _, rule := range stored {
+		threshold, err := service.pgNumericToDecimal(rule.Threshold)
+		if err != nil {
+			return nil, fmt.Errorf("invalid threshold of business rule %s: %w", rule.Name, err)
+		}
+
+		converted := rules.Rule{
+			Name:      rule.Name,
+			Kind:      rules.Kind(rule.Kind),
+			Threshold: threshold,
+			Severity:  rules.Severity(rule.Severity),
+			Enabled:   rule.Enabled,
+		}
+		if rule.Currency.Valid {
+			converted.Currency = string(rule.Currency.CoreCurrencyCode)
+		}
+
+		loaded = append(loaded, converted)
+	}
+
+	if service.relaxedValidation {
+		return rules.Relaxed(loaded), nil
+	}
+
+	return loaded, nil
+}
+
+// evaluateBusinessRules evaluates the current rules against facts. When the rules cannot be read, operations are
+// validated with the rules read last rather than failing.
+func (service *Service) evaluateBusinessRules(ctx context.Context, facts rules.Facts) []rules.Outcome {
+	engine := rules.NewEngine(rules.Defaults())
+	if service.businessRules != nil {
+		var err error
+		if engine, err = service.businessRules.Engine(ctx); err != nil {
+			service.logger.WithFields(logrus.Fields{
+				"[op]":  "service.Service.evaluateBusinessRules",
+				"error": err.Error(),
+			}).Warn("Validating with the business rules read last")
+		}
+	}
+
+	return engine.Evaluate(facts)
+}
+
+// businessRuleValidations converts rule outcomes to validation results; failed rules keep their severity as level
+func businessRuleValidations(outcomes []rules.Outcome) []ValidationResult {
+	results := make([]ValidationResult, 0, len(outcomes))
+	for _, outcome := range outcomes {
+		result := ValidationResult{
+			Field:   "business_rule." + outcome.Rule.Name,
+			Message: outcome.Message,
+			Level:   "info",
+			Passed:  outcome.Passed,
+		}
+		if !outcome.Passed {
+			result.Level = string(outcome.Rule.Severity)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// businessRuleFailure returns an error wrapping ErrBusinessRuleViolation for the first failed rule of severity error
+func businessRuleFailure(outcomes []rules.Outcome) error {
+	if failed, ok := rules.Failed(outcomes); ok {
+		return fmt.Errorf("%w: %s: %s", ErrBusinessRuleViolation, failed.Rule.Name, failed.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"svc-transaction/store"
+	"svc-transaction/store/sqlc"
+	"svc-transaction/util/rules"
+
+	"numeric"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// businessRuleStore serves the rows of core.business_rules
+type businessRuleStore struct {
+	store.IStore
+
+	rules []sqlc.CoreBusinessRule
+	err   error
+}
+
+func (store *businessRuleStore) ListBusinessRules(context.Context) ([]sqlc.CoreBusinessRule, error) {
+	return store.rules, store.err
+}
+
+func TestEvaluateBusinessRules(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	ruleStore := &businessRuleStore{rules: []sqlc.CoreBusinessRule{
+		{Name: "transaction_limits", Kind: "max_transaction_amount", Threshold: numeric.FromDecimal(decimal.NewFromInt(100000)), Severity: "warning", Enabled: true},
+		{
+			Name:      "transaction_limits",
+			Kind:      "max_transaction_amount",
+			Currency:  sqlc.NullCoreCurrencyCode{CoreCurrencyCode: sqlc.CoreCurrencyCodeGBP, Valid: true},
+			Threshold: numeric.FromDecimal(decimal.NewFromInt(50000)),
+			Severity:  "error",
+			Enabled:   true,
+		},
+	}}
+
+	service := &Service{logger: logger, store: ruleStore}
+	service.SetBusinessRulesRefresh(0)
+
+	amount := decimal.NewFromInt(60000)
+
+	outcomes := service.evaluateBusinessRules(context.Background(), rules.Facts{Currency: "GBP", Amount: &amount})
+	err := businessRuleFailure(outcomes)
+	assert.ErrorIs(t, err, ErrBusinessRuleViolation)
+	assert.ErrorContains(t, err, "transaction_limits")
+
+	validations := businessRuleValidations(outcomes)
+	require.Len(t, validations, 1)
+	assert.Equal(t, ValidationResult{Field: "business_rule.transaction_limits", Message: outcomes[0].Message, Level: "error"}, validations[0])
+	assert.ErrorIs(t, validationFailure(validations), ErrBusinessRuleViolation)
+
+	// USD falls back to the rule of any currency, which only flags the amount
+	outcomes = service.evaluateBusinessRules(context.Background(), rules.Facts{Currency: "USD", Amount: &amount})
+	assert.NoError(t, businessRuleFailure(outcomes))
+	assert.Len(t, outcomes, 1)
+
+	// Rules that cannot be read leave the rules read last in place
+	ruleStore.err = errors.New("database unavailable")
+	outcomes = service.evaluateBusinessRules(context.Background(), rules.Facts{Currency: "GBP", Amount: &amount})
+	assert.ErrorIs(t, businessRuleFailure(outcomes), ErrBusinessRuleViolation)
+}
@@ -90,7 +90,7 @@ func (service *Service) CompensateDebit(ctx context.Context, params CompensateDe
 
 	// Step 2: Check for existing compensation transaction with same idempotency key
 	if params.IdempotencyKey != nil {
-		existingResult, err := service.checkExistingCompensationTransaction(ctx, *params.IdempotencyKey)
+		existingResult, err := service.checkExistingCompensationTransaction(ctx, *params.IdempotencyKey, compensationIdempotencyPayload(params))
 		if err != nil {
 			err = fmt.Errorf("failed to check existing compensation transaction: %w", err)
 
@@ -139,17 +139,7 @@ func (service *Service) CompensateDebit(ctx context.Context, params CompensateDe
 	}
 
 	// Step 6: Check if validation passed (no errors)
-	hasErrors := false
-	for _, result := range validationResults {
-		if !result.Passed && result.Level == "error" {
-			hasErrors = true
-			break
-		}
-	}
-
-	if hasErrors {
-		err = fmt.Errorf("account validation failed")
-
+	if err := validationFailure(validationResults); err != nil {
 		logger.WithError(err).Error()
 
 		return &CompensateDebitResults{
@@ -163,6 +153,9 @@ func (service *Service) CompensateDebit(ctx context.Context, params CompensateDe
 
 	// Step 7: Execute the compensation transaction
 	result, err := service.executeCompensationTransaction(ctx, accountID, params, originalTransaction, validationResults)
+	if err != nil && params.IdempotencyKey != nil && isIdempotencyKeyViolation(err) {
+		result, err = service.findCompensationByIdempotencyKey(ctx, *params.IdempotencyKey, compensationIdempotencyPayload(params))
+	}
 	if err != nil {
 		err = fmt.Errorf("failed to execute compensation transaction: %w", err)
 
@@ -211,13 +204,9 @@ func (service *Service) validateCompensateDebitParams(params CompensateDebitPara
 		return fmt.Errorf("only one of account_id or account_number should be provided")
 	}
 
-	// Validate amount
-	if params.Amount.IsZero() {
-		return fmt.Errorf("amount cannot be zero")
-	}
-
-	if params.Amount.IsNegative() {
-		return fmt.Errorf("amount cannot be negative")
+	// Validate amount sign
+	if err := validateAmountSign(AmountOperationCompensation, "amount", params.Amount); err != nil {
+		return err
 	}
 
 	// Validate currency
@@ -230,6 +219,16 @@ func (service *Service) validateCompensateDebitParams(params CompensateDebitPara
 		return fmt.Errorf("currency must be a 3-letter code")
 	}
 
+	// Validate amount fits the currency's minor units
+	if err := validateAmountScale(params.Amount, params.Currency); err != nil {
+		return err
+	}
+
+	// Validate amount fits the amount columns without rounding
+	if err := service.checkAmountPrecision("amount", params.Amount); err != nil {
+		return err
+	}
+
 	// Validate idempotency key if provided
 	if params.IdempotencyKey != nil && *params.IdempotencyKey == "" {
 		return fmt.Errorf("idempotency_key cannot be empty when provided")
@@ -238,20 +237,48 @@ func (service *Service) validateCompensateDebitParams(params CompensateDebitPara
 	return nil
 }
 
-// checkExistingCompensationTransaction checks if a compensation transaction already exists with the given idempotency key
-func (service *Service) checkExistingCompensationTransaction(ctx context.Context, idempotencyKey string) (*CompensateDebitResults, error) {
+// checkExistingCompensationTransaction returns the compensation registered for the idempotency key, or nil when the key
+// is not registered. It returns an IdempotencyConflictError when that compensation was made for a different payload.
+func (service *Service) checkExistingCompensationTransaction(ctx context.Context, idempotencyKey string, payload idempotencyPayload) (*CompensateDebitResults, error) {
+	var result CompensateDebitResults
+
+	found, err := service.lookupIdempotencyKey(ctx, idempotencyKey, payload, &result)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// findCompensationByIdempotencyKey returns the compensation transaction created with the idempotency key, for keys that
+// are taken without being registered. It returns an IdempotencyConflictError when that transaction was created for a
+// different payload.
+func (service *Service) findCompensationByIdempotencyKey(ctx context.Context, idempotencyKey string, payload idempotencyPayload) (*CompensateDebitResults, error) {
 	pgIdempotencyKey := pgtype.Text{String: idempotencyKey, Valid: true}
 
 	transaction, err := service.store.GetTransactionByIdempotencyKey(ctx, pgIdempotencyKey)
 	if err != nil {
-		// If not found, that's okay - we can proceed with new transaction
-		return nil, nil
+		return nil, fmt.Errorf("failed to get transaction by idempotency key: %w", err)
+	}
+
+	if err := service.checkIdempotencyPayload(ctx, transaction, payload); err != nil {
+		return nil, err
 	}
 
 	// Convert the existing transaction to compensation result
 	return service.convertTransactionToCompensationResult(ctx, transaction)
 }
 
+// compensationIdempotencyPayload returns what a compensation reusing an idempotency key has to repeat
+func compensationIdempotencyPayload(params CompensateDebitParams) idempotencyPayload {
+	payload := newIdempotencyPayload(idempotencyTypeCompensation, params.AccountID, params.AccountNumber, params.Amount, params.Currency, params.ReferenceID)
+	if params.OriginalTransactionID != nil {
+		payload.OriginalTransactionID = params.OriginalTransactionID.String()
+	}
+
+	return payload
+}
+
 // resolveOriginalTransaction resolves the original transaction details if provided
 func (service *Service) resolveOriginalTransaction(ctx context.Context, params CompensateDebitParams) (*sqlc.GetTransactionByIDRow, error) {
 	if params.OriginalTransactionID != nil {
@@ -308,7 +335,7 @@ func (service *Service) resolveCompensationAccountID(ctx context.Context, params
 	if params.AccountNumber != nil {
 		account, err := service.store.GetAccountByAccountNumber(ctx, *params.AccountNumber)
 		if err != nil {
-			return uuid.Nil, fmt.Errorf("account not found: %w", err)
+			return uuid.Nil, accountLookupError(err, *params.AccountNumber)
 		}
 		return account.ID.Bytes, nil
 	}
@@ -329,21 +356,22 @@ func (service *Service) validateAccountForCompensation(ctx context.Context, acco
 	pgAccountID := pgtype.UUID{Bytes: accountID, Valid: true}
 	account, err := service.store.GetAccountByID(ctx, pgAccountID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get account details: %w", err)
+		return nil, accountLookupError(err, accountID.String())
 	}
 
-	// Validate account status
-	if account.Status != sqlc.CoreAccountStatusActive {
+	// Validate account status. Suspended accounts still take reversals of their own debits,
+	// so transfers that were in flight when an account closure blocked the account can be compensated.
+	if account.Status != sqlc.CoreAccountStatusActive && account.Status != sqlc.CoreAccountStatusSuspended {
 		results = append(results, ValidationResult{
 			Field:   "account_status",
-			Message: fmt.Sprintf("Account status is %s, expected active", account.Status),
+			Message: fmt.Sprintf("Account status is %s, expected active or suspended", account.Status),
 			Level:   "error",
 			Passed:  false,
 		})
 	} else {
 		results = append(results, ValidationResult{
 			Field:   "account_status",
-			Message: "Account is active",
+			Message: fmt.Sprintf("Account is %s", account.Status),
 			Level:   "info",
 			Passed:  true,
 		})
@@ -396,17 +424,7 @@ func (service *Service) validateAccountForCompensation(ctx context.Context, acco
 
 // executeCompensationTransaction executes the compensation transaction within a database transaction
 func (service *Service) executeCompensationTransaction(ctx context.Context, accountID uuid.UUID, params CompensateDebitParams, originalTransaction *sqlc.GetTransactionByIDRow, validationResults []ValidationResult) (*CompensateDebitResults, error) {
-	// Get account details for balance information
 	pgAccountID := pgtype.UUID{Bytes: accountID, Valid: true}
-	account, err := service.store.GetAccountByID(ctx, pgAccountID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get account details: %w", err)
-	}
-
-	previousBalance, err := service.pgNumericToDecimal(account.Balance)
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert previous balance: %w", err)
-	}
 
 	// Prepare transaction metadata with compensation information
 	metadata := make(map[string]any)
@@ -432,6 +450,9 @@ func (service *Service) executeCompensationTransaction(ctx context.Context, acco
 	if params.RunID != nil {
 		metadata["run_id"] = *params.RunID
 	}
+	if params.IdempotencyKey != nil {
+		metadata[payloadHashMetadataKey] = compensationIdempotencyPayload(params).hash()
+	}
 
 	metadataJSON, err := json.Marshal(metadata)
 	if err != nil {
@@ -470,70 +491,107 @@ func (service *Service) executeCompensationTransaction(ctx context.Context, acco
 		Metadata:        metadataJSON,
 	}
 
-	// Create the transaction
-	createResult, err := service.store.CreateTransaction(ctx, createParams)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create compensation transaction: %w", err)
-	}
+	var result *CompensateDebitResults
+
+	// Lock the account, record the compensation and apply the balance change atomically; the transaction runs again
+	// when it loses a serialization conflict to a concurrent one
+	err = service.store.WithRetryTx(ctx, func(q sqlc.Querier) error {
+		account, previousBalance, err := service.lockAccount(ctx, q, pgAccountID)
+		if err != nil {
+			return err
+		}
+
+		// The account lock also serializes compensations of the same debit, so the sum below is stable
+		if originalTransaction != nil {
+			if err := service.checkCompensationLimit(ctx, q, originalTransaction, params.Amount); err != nil {
+				return err
+			}
+		}
+
+		createResult, err := q.CreateTransaction(ctx, createParams)
+		if err != nil {
+			return fmt.Errorf("failed to create compensation transaction: %w", err)
+		}
+
+		completeResult, err := service.applyBalanceChange(ctx, q, pgAccountID, createResult.ID, previousBalance, params.Amount, "compensation", sagaCreatedBy)
+		if err != nil {
+			return err
+		}
+
+		// Build the result
+		result = &CompensateDebitResults{
+			TransactionID:      createResult.ID.Bytes,
+			AccountID:          accountID,
+			AccountNumber:      account.AccountNumber,
+			AccountName:        account.AccountName,
+			Amount:             params.Amount,
+			Currency:           params.Currency,
+			Description:        params.Description,
+			ReferenceID:        params.ReferenceID,
+			IdempotencyKey:     params.IdempotencyKey,
+			Status:             string(completeResult.Status),
+			PreviousBalance:    previousBalance,
+			NewBalance:         previousBalance.Add(params.Amount),
+			CreatedAt:          createResult.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+			ValidationResults:  validationResults,
+			Metadata:           metadata,
+			CompensationReason: params.CompensationReason,
+			WorkflowID:         params.WorkflowID,
+			RunID:              params.RunID,
+		}
+
+		// Add completion time if available
+		if completeResult.CompletedAt.Valid {
+			completedAt := completeResult.CompletedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+			result.CompletedAt = &completedAt
+		}
 
-	// Complete the transaction immediately (compensation is typically atomic)
-	pgTransactionID := pgtype.UUID{Bytes: createResult.ID.Bytes, Valid: true}
-	completeResult, err := service.store.CompleteTransaction(ctx, pgTransactionID)
+		// Add original transaction info if available
+		if originalTransaction != nil {
+			originalID := uuid.UUID(originalTransaction.ID.Bytes)
+			result.OriginalTransactionID = &originalID
+			if originalTransaction.ReferenceID.Valid {
+				result.OriginalReferenceID = &originalTransaction.ReferenceID.String
+			}
+		} else if params.OriginalReferenceID != nil {
+			result.OriginalReferenceID = params.OriginalReferenceID
+		}
+
+		// Register the key with the transaction, so a replay is answered with this result
+		return service.registerIdempotencyKey(ctx, q, params.IdempotencyKey, idempotencyTypeCompensation, compensationIdempotencyPayload(params), result)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to complete compensation transaction: %w", err)
+		return nil, err
 	}
 
-	// Get updated account balance
-	updatedAccount, err := service.store.GetAccountByID(ctx, pgAccountID)
+	return result, nil
+}
+
+// checkCompensationLimit ensures that all compensations of a debit together never credit back more than was debited
+func (service *Service) checkCompensationLimit(ctx context.Context, q sqlc.Querier, originalTransaction *sqlc.GetTransactionByIDRow, amount decimal.Decimal) error {
+	originalAmount, err := service.pgNumericToDecimal(originalTransaction.Amount)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get updated account details: %w", err)
+		return fmt.Errorf("failed to convert original transaction amount: %w", err)
 	}
 
-	newBalance, err := service.pgNumericToDecimal(updatedAccount.Balance)
+	originalID := uuid.UUID(originalTransaction.ID.Bytes)
+
+	pgCompensated, err := q.GetCompensatedAmount(ctx, originalID.String())
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert new balance: %w", err)
+		return fmt.Errorf("failed to get compensated amount: %w", err)
 	}
 
-	// Build the result
-	result := &CompensateDebitResults{
-		TransactionID:      createResult.ID.Bytes,
-		AccountID:          accountID,
-		AccountNumber:      account.AccountNumber,
-		AccountName:        account.AccountName,
-		Amount:             params.Amount,
-		Currency:           params.Currency,
-		Description:        params.Description,
-		ReferenceID:        params.ReferenceID,
-		IdempotencyKey:     params.IdempotencyKey,
-		Status:             string(completeResult.Status),
-		PreviousBalance:    previousBalance,
-		NewBalance:         newBalance,
-		CreatedAt:          createResult.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
-		ValidationResults:  validationResults,
-		Metadata:           metadata,
-		CompensationReason: params.CompensationReason,
-		WorkflowID:         params.WorkflowID,
-		RunID:              params.RunID,
-	}
-
-	// Add completion time if available
-	if completeResult.CompletedAt.Valid {
-		completedAt := completeResult.CompletedAt.Time.Format("2006-01-02T15:04:05Z07:00")
-		result.CompletedAt = &completedAt
+	compensated, err := service.pgNumericToDecimal(pgCompensated)
+	if err != nil {
+		return fmt.Errorf("failed to convert compensated amount: %w", err)
 	}
 
-	// Add original transaction info if available
-	if originalTransaction != nil {
-		originalID := uuid.UUID(originalTransaction.ID.Bytes)
-		result.OriginalTransactionID = &originalID
-		if originalTransaction.ReferenceID.Valid {
-			result.OriginalReferenceID = &originalTransaction.ReferenceID.String
-		}
-	} else if params.OriginalReferenceID != nil {
-		result.OriginalReferenceID = params.OriginalReferenceID
+	if compensated.Add(amount).GreaterThan(originalAmount) {
+		return fmt.Errorf("%w: original %s, already compensated %s, requested %s",
+			ErrCompensationExceedsOriginal, originalAmount.String(), compensated.String(), amount.String())
 	}
 
-	return result, nil
+	return nil
 }
 
 // convertTransactionToCompensationResult converts an existing transaction to compensation result
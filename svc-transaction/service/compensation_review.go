@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultCompensationReviewBatchSize = 100
+
+	// compensationReviewActor identifies the end-of-day compensation review in core.admin_audit and in the
+	// compensation transactions it creates
+	compensationReviewActor = "compensation-reviewer"
+)
+
+// Outcomes of reviewing one pending compensation
+const (
+	CompensationReviewRetried   = "retried"   // What was still owed was credited back
+	CompensationReviewResolved  = "resolved"  // Nothing was owed any more; the record is closed as completed
+	CompensationReviewEscalated = "escalated" // Marked manual_required for an operator
+	CompensationReviewSkipped   = "skipped"   // Settled by its own workflow in the meantime
+)
+
+// CompensationReviewResult is the outcome of reviewing the compensation of one workflow
+type CompensationReviewResult struct {
+	WorkflowID    string     `json:"workflow_id"`
+	Outcome       string     `json:"outcome"`
+	Reason        string     `json:"reason,omitempty"` // Why the compensation was escalated
+	TransactionID *uuid.UUID `json:"transaction_id,omitempty"`
+}
+
+// CompensationReviewReport summarises one end-of-day compensation review
+type CompensationReviewReport struct {
+	BusinessDate         string   `json:"business_date"`
+	Examined             int      `json:"examined"`
+	Retried              int      `json:"retried"`
+	Resolved             int      `json:"resolved"`
+	Escalated            int      `json:"escalated"`
+	Skipped              int      `json:"skipped"`
+	Errors               int      `json:"errors"`
+	EscalatedWorkflowIDs []string `json:"escalated_workflow_ids,omitempty"`
+}
+
+// Add counts result in the report
+func (report *CompensationReviewReport) Add(result CompensationReviewResult) {
+	report.Examined++
+
+	switch result.Outcome {
+	case CompensationReviewRetried:
+		report.Retried++
+	case CompensationReviewResolved:
+		report.Resolved++
+	case CompensationReviewEscalated:
+		report.Escalated++
+		report.EscalatedWorkflowIDs = append(report.EscalatedWorkflowIDs, result.WorkflowID)
+	default:
+		report.Skipped++
+	}
+}
+
+// ListCompensationsForReview returns the workflows of up to batchSize compensations still pending after their
+// workflow should have settled them, oldest first
+func (service *Service) ListCompensationsForReview(ctx context.Context, batchSize int32) ([]string, error) {
+	if batchSize <= 0 {
+		batchSize = defaultCompensationReviewBatchSize
+	}
+
+	records, err := service.GetPendingCompensations(ctx, batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	workflowIDs := make([]string, 0, len(records))
+	for _, record := range records {
+		workflowIDs = append(workflowIDs, record.WorkflowID)
+	}
+
+	return workflowIDs, nil
+}
+
+// ReviewCompensation closes the pending compensation of a workflow where it can:
+//   - something is still owed on the original debit: it is credited back and the record completed
+//   - nothing is owed any more: the record is completed
+//
+// A compensation without an original debit, or whose credit fails, is escalated to manual_required with the
+// reason, where operators pick it up with RetryCompensation.
+func (service *Service) ReviewCompensation(ctx context.Context, workflowID string) (*CompensationReviewResult, error) {
+	const op = "service.Service.ReviewCompensation"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"workflow_id": workflowID,
+	})
+
+	records, err := service.GetCompensationAuditByWorkflowID(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Records are newest first; only the latest outcome matters
+	if len(records) == 0 || records[0].CompensationStatus != sqlc.CoreCompensationStatusPending {
+		return &CompensationReviewResult{WorkflowID: workflowID, Outcome: CompensationReviewSkipped}, nil
+	}
+	record := records[0]
+
+	if !record.OriginalTransactionID.Valid {
+		return service.escalateCompensation(ctx, workflowID, "no original transaction to compensate")
+	}
+
+	originalTransaction, remaining, err := service.owedCompensation(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	if !remaining.IsPositive() {
+		if _, err := service.UpdateCompensationAudit(ctx, workflowID, CompensationAuditParams{
+			CompensationStatus: string(sqlc.CoreCompensationStatusCompleted),
+		}); err != nil {
+			return nil, err
+		}
+
+		logger.Info("Pending compensation already fully compensated")
+
+		return &CompensationReviewResult{WorkflowID: workflowID, Outcome: CompensationReviewResolved}, nil
+	}
+
+	reason := fmt.Sprintf("End-of-day review of compensation %s", uuid.UUID(record.ID.Bytes))
+
+	result, err := service.creditOwedCompensation(ctx, record, originalTransaction, remaining, reason, compensationReviewActor)
+	if err != nil {
+		logger.WithError(err).Warn("Retry of pending compensation failed")
+
+		return service.escalateCompensation(ctx, workflowID, fmt.Sprintf("retry failed: %s", err))
+	}
+
+	if _, err := service.UpdateCompensationAudit(ctx, workflowID, CompensationAuditParams{
+		CompensationStatus:        string(sqlc.CoreCompensationStatusCompleted),
+		CompensationTransactionID: &result.TransactionID,
+	}); err != nil {
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"transaction_id": result.TransactionID,
+		"amount":         remaining.String(),
+	}).Info("Pending compensation retried successfully")
+
+	return &CompensationReviewResult{WorkflowID: workflowID, Outcome: CompensationReviewRetried, TransactionID: &result.TransactionID}, nil
+}
+
+// escalateCompensation marks the compensation of a workflow manual_required for reason
+func (service *Service) escalateCompensation(ctx context.Context, workflowID, reason string) (*CompensationReviewResult, error) {
+	failureReason := "Escalated by the end-of-day compensation review: " + reason
+	if _, err := service.UpdateCompensationAudit(ctx, workflowID, CompensationAuditParams{
+		CompensationStatus: string(sqlc.CoreCompensationStatusManualRequired),
+		FailureReason:      &failureReason,
+	}); err != nil {
+		return nil, err
+	}
+
+	service.logger.WithFields(logrus.Fields{
+		"workflow_id": workflowID,
+		"reason":      reason,
+	}).Warn("Pending compensation escalated for manual intervention")
+
+	return &CompensationReviewResult{WorkflowID: workflowID, Outcome: CompensationReviewEscalated, Reason: reason}, nil
+}
+
+// RecordCompensationReviewReport emits the summary of an end-of-day compensation review as an entry of
+// core.admin_audit, keyed on its business date
+func (service *Service) RecordCompensationReviewReport(ctx context.Context, report CompensationReviewReport) error {
+	const op = "service.Service.RecordCompensationReviewReport"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":          op,
+		"business_date": report.BusinessDate,
+	})
+
+	if err := createAdminAudit(ctx, service.store, AdminAction{
+		Actor:        compensationReviewActor,
+		Action:       AdminActionReportCompensationReview,
+		ResourceType: "compensation_review",
+		ResourceID:   report.BusinessDate,
+		After:        report,
+	}); err != nil {
+		logger.WithError(err).Error()
+
+		return err
+	}
+
+	logger.WithField("report", fmt.Sprintf("%+v", report)).Info("Compensation review report recorded")
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"svc-transaction/store"
+	"svc-transaction/store/sqlc"
+
+	"numeric"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// compensationReviewStore holds the audit record of one workflow and the amounts of its original debit
+type compensationReviewStore struct {
+	store.IStore
+
+	record      *sqlc.CoreCompensationAuditTrail
+	amount      decimal.Decimal
+	compensated decimal.Decimal
+	updates     []sqlc.UpdateCompensationAuditParams
+}
+
+func (store *compensationReviewStore) GetCompensationAuditByWorkflowID(_ context.Context, _ string) ([]sqlc.CoreCompensationAuditTrail, error) {
+	if store.record == nil {
+		return nil, nil
+	}
+
+	return []sqlc.CoreCompensationAuditTrail{*store.record}, nil
+}
+
+func (store *compensationReviewStore) GetTransactionByID(_ context.Context, id pgtype.UUID) (sqlc.GetTransactionByIDRow, error) {
+	return sqlc.GetTransactionByIDRow{ID: id, Amount: numeric.FromDecimal(store.amount)}, nil
+}
+
+func (store *compensationReviewStore) GetCompensatedAmount(_ context.Context, _ string) (pgtype.Numeric, error) {
+	return numeric.FromDecimal(store.compensated), nil
+}
+
+func (store *compensationReviewStore) UpdateCompensationAudit(_ context.Context, arg sqlc.UpdateCompensationAuditParams) (sqlc.CoreCompensationAuditTrail, error) {
+	store.updates = append(store.updates, arg)
+	store.record.CompensationStatus = arg.CompensationStatus
+
+	return *store.record, nil
+}
+
+func TestReviewCompensation(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	original := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+
+	tests := []struct {
+		name        string
+		record      *sqlc.CoreCompensationAuditTrail
+		compensated decimal.Decimal
+		outcome     string
+		status      sqlc.CoreCompensationStatus // Status the record is updated to; empty for no update
+	}{
+		{
+			name:    "no_record",
+			outcome: CompensationReviewSkipped,
+		},
+		{
+			name:    "settled_meanwhile",
+			record:  &sqlc.CoreCompensationAuditTrail{CompensationStatus: sqlc.CoreCompensationStatusFailed, OriginalTransactionID: original},
+			outcome: CompensationReviewSkipped,
+		},
+		{
+			name:    "no_original_transaction",
+			record:  &sqlc.CoreCompensationAuditTrail{CompensationStatus: sqlc.CoreCompensationStatusPending},
+			outcome: CompensationReviewEscalated,
+			status:  sqlc.CoreCompensationStatusManualRequired,
+		},
+		{
+			name:        "fully_compensated",
+			record:      &sqlc.CoreCompensationAuditTrail{CompensationStatus: sqlc.CoreCompensationStatusPending, OriginalTransactionID: original},
+			compensated: decimal.NewFromInt(50),
+			outcome:     CompensationReviewResolved,
+			status:      sqlc.CoreCompensationStatusCompleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewStore := &compensationReviewStore{record: tt.record, amount: decimal.NewFromInt(50), compensated: tt.compensated}
+			service := &Service{logger: logger, store: reviewStore}
+
+			result, err := service.ReviewCompensation(context.Background(), "transfer-workflow")
+			require.NoError(t, err)
+			assert.Equal(t, tt.outcome, result.Outcome)
+
+			if tt.status == "" {
+				assert.Empty(t, reviewStore.updates)
+				return
+			}
+
+			require.Len(t, reviewStore.updates, 1)
+			assert.Equal(t, tt.status, reviewStore.updates[0].CompensationStatus)
+			if tt.outcome == CompensationReviewEscalated {
+				assert.Contains(t, reviewStore.updates[0].FailureReason.String, "no original transaction")
+			}
+		})
+	}
+}
+
+func TestCompensationReviewReportAdd(t *testing.T) {
+	t.Parallel()
+
+	var report CompensationReviewReport
+	for _, result := range []CompensationReviewResult{
+		{WorkflowID: "a", Outcome: CompensationReviewRetried},
+		{WorkflowID: "b", Outcome: CompensationReviewEscalated},
+		{WorkflowID: "c", Outcome: CompensationReviewResolved},
+		{WorkflowID: "d", Outcome: CompensationReviewSkipped},
+		{WorkflowID: "e", Outcome: CompensationReviewEscalated},
+	} {
+		report.Add(result)
+	}
+
+	assert.Equal(t, CompensationReviewReport{
+		Examined:             5,
+		Retried:              1,
+		Resolved:             1,
+		Escalated:            2,
+		Skipped:              1,
+		EscalatedWorkflowIDs: []string{"b", "e"},
+	}, report)
+}
@@ -68,7 +68,7 @@ func (service *Service) CreditAccount(ctx context.Context, params CreditAccountP
 
 	// Step 2: Check for existing transaction with same idempotency key
 	if params.IdempotencyKey != nil {
-		existingResult, err := service.checkExistingCreditTransaction(ctx, *params.IdempotencyKey)
+		existingResult, err := service.checkExistingCreditTransaction(ctx, *params.IdempotencyKey, creditIdempotencyPayload(params))
 		if err != nil {
 			err = fmt.Errorf("failed to check existing transaction: %w", err)
 
@@ -107,17 +107,7 @@ func (service *Service) CreditAccount(ctx context.Context, params CreditAccountP
 	}
 
 	// Step 5: Check if validation passed (no errors)
-	hasErrors := false
-	for _, result := range validationResults {
-		if !result.Passed && result.Level == "error" {
-			hasErrors = true
-			break
-		}
-	}
-
-	if hasErrors {
-		err = fmt.Errorf("account validation failed")
-
+	if err := validationFailure(validationResults); err != nil {
 		logger.WithError(err).Error()
 
 		return &CreditAccountResults{
@@ -131,6 +121,10 @@ func (service *Service) CreditAccount(ctx context.Context, params CreditAccountP
 
 	// Step 6: Execute the credit transaction
 	result, err := service.executeCreditTransaction(ctx, accountID, params, validationResults)
+	if err != nil && params.IdempotencyKey != nil && isIdempotencyKeyViolation(err) {
+		// The request that took the key already made the credit and published its event
+		return service.findCreditByIdempotencyKey(ctx, *params.IdempotencyKey, creditIdempotencyPayload(params))
+	}
 	if err != nil {
 		err = fmt.Errorf("failed to execute credit transaction: %w", err)
 
@@ -141,6 +135,9 @@ func (service *Service) CreditAccount(ctx context.Context, params CreditAccountP
 
 	logger.WithField("results", fmt.Sprintf("%+v", result)).Info()
 
+	// Step 7: Let conditional transfers watching the account know about the credit
+	service.publishCreditEvent(ctx, result.TransactionID, result.AccountID, result.AccountNumber, result.Amount, result.Currency)
+
 	return result, nil
 }
 
@@ -165,13 +162,9 @@ func (service *Service) validateCreditAccountParams(params CreditAccountParams)
 		return fmt.Errorf("account_number cannot be empty")
 	}
 
-	// Validate amount
-	if params.Amount.IsZero() {
-		return fmt.Errorf("amount cannot be zero")
-	}
-
-	if params.Amount.IsNegative() {
-		return fmt.Errorf("amount cannot be negative")
+	// Validate amount sign
+	if err := validateAmountSign(AmountOperationCredit, "amount", params.Amount); err != nil {
+		return err
 	}
 
 	// Validate currency
@@ -184,6 +177,16 @@ func (service *Service) validateCreditAccountParams(params CreditAccountParams)
 		return fmt.Errorf("currency must be a 3-letter code")
 	}
 
+	// Validate amount fits the currency's minor units
+	if err := validateAmountScale(params.Amount, params.Currency); err != nil {
+		return err
+	}
+
+	// Validate amount fits the amount columns without rounding
+	if err := service.checkAmountPrecision("amount", params.Amount); err != nil {
+		return err
+	}
+
 	// Validate idempotency key if provided
 	if params.IdempotencyKey != nil && *params.IdempotencyKey == "" {
 		return fmt.Errorf("idempotency_key cannot be empty when provided")
@@ -192,14 +195,31 @@ func (service *Service) validateCreditAccountParams(params CreditAccountParams)
 	return nil
 }
 
-// checkExistingCreditTransaction checks if a transaction already exists with the given idempotency key
-func (service *Service) checkExistingCreditTransaction(ctx context.Context, idempotencyKey string) (*CreditAccountResults, error) {
+// checkExistingCreditTransaction returns the credit registered for the idempotency key, or nil when the key is not
+// registered. It returns an IdempotencyConflictError when that credit was made for a different payload.
+func (service *Service) checkExistingCreditTransaction(ctx context.Context, idempotencyKey string, payload idempotencyPayload) (*CreditAccountResults, error) {
+	var result CreditAccountResults
+
+	found, err := service.lookupIdempotencyKey(ctx, idempotencyKey, payload, &result)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// findCreditByIdempotencyKey returns the credit transaction created with the idempotency key, for keys that are taken
+// without being registered. It returns an IdempotencyConflictError when that transaction was created for a different payload.
+func (service *Service) findCreditByIdempotencyKey(ctx context.Context, idempotencyKey string, payload idempotencyPayload) (*CreditAccountResults, error) {
 	pgIdempotencyKey := pgtype.Text{String: idempotencyKey, Valid: true}
 
 	transaction, err := service.store.GetTransactionByIdempotencyKey(ctx, pgIdempotencyKey)
 	if err != nil {
-		// If not found, that's okay - we can proceed with new transaction
-		return nil, nil
+		return nil, fmt.Errorf("failed to get transaction by idempotency key: %w", err)
+	}
+
+	if err := service.checkIdempotencyPayload(ctx, transaction, payload); err != nil {
+		return nil, err
 	}
 
 	// Convert existing transaction to result format
@@ -211,6 +231,11 @@ func (service *Service) checkExistingCreditTransaction(ctx context.Context, idem
 	return result, nil
 }
 
+// creditIdempotencyPayload returns what a credit reusing an idempotency key has to repeat
+func creditIdempotencyPayload(params CreditAccountParams) idempotencyPayload {
+	return newIdempotencyPayload(idempotencyTypeCredit, params.AccountID, params.AccountNumber, params.Amount, params.Currency, params.ReferenceID)
+}
+
 // resolveCreditAccountID resolves account ID from account number if needed
 func (service *Service) resolveCreditAccountID(ctx context.Context, params CreditAccountParams) (uuid.UUID, error) {
 	if params.AccountID != nil {
@@ -220,7 +245,7 @@ func (service *Service) resolveCreditAccountID(ctx context.Context, params Credi
 	// Get account by account number
 	account, err := service.store.GetAccountByAccountNumber(ctx, *params.AccountNumber)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("account not found with account number %s: %w", *params.AccountNumber, err)
+		return uuid.Nil, accountLookupError(err, *params.AccountNumber)
 	}
 
 	return uuid.UUID(account.ID.Bytes), nil
@@ -236,7 +261,7 @@ func (service *Service) validateAccountForCredit(ctx context.Context, accountID
 	// Get account details
 	account, err := service.store.GetAccountByID(ctx, pgAccountID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get account: %w", err)
+		return nil, accountLookupError(err, accountID.String())
 	}
 
 	// Validate account status - only active accounts can receive credits
@@ -286,17 +311,7 @@ func (service *Service) validateAccountForCredit(ctx context.Context, accountID
 
 // executeCreditTransaction executes the credit transaction
 func (service *Service) executeCreditTransaction(ctx context.Context, accountID uuid.UUID, params CreditAccountParams, validationResults []ValidationResult) (*CreditAccountResults, error) {
-	// Get account details before transaction
 	pgAccountID := pgtype.UUID{Bytes: accountID, Valid: true}
-	account, err := service.store.GetAccountByID(ctx, pgAccountID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get account details: %w", err)
-	}
-
-	previousBalance, err := service.pgNumericToDecimal(account.Balance)
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert previous balance: %w", err)
-	}
 
 	// Convert parameters to database types
 	pgAmount, err := service.decimalToPgNumeric(params.Amount)
@@ -321,10 +336,16 @@ func (service *Service) executeCreditTransaction(ctx context.Context, accountID
 		pgIdempotencyKey = pgtype.Text{String: *params.IdempotencyKey, Valid: true}
 	}
 
+	// Keep the hash of the request so a reused idempotency key can be checked against it
+	metadata := params.Metadata
+	if params.IdempotencyKey != nil {
+		metadata = withPayloadHash(metadata, creditIdempotencyPayload(params))
+	}
+
 	var pgMetadata []byte
-	if params.Metadata != nil {
+	if metadata != nil {
 		// Convert metadata map to JSON bytes
-		metadataBytes, err := json.Marshal(params.Metadata)
+		metadataBytes, err := json.Marshal(metadata)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
 		}
@@ -343,42 +364,55 @@ func (service *Service) executeCreditTransaction(ctx context.Context, accountID
 		Metadata:        pgMetadata,
 	}
 
-	transaction, err := service.store.CreateTransaction(ctx, createParams)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create transaction: %w", err)
-	}
+	var result *CreditAccountResults
 
-	// Complete the transaction (this will update the account balance)
-	completedTransaction, err := service.store.CompleteTransaction(ctx, transaction.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to complete transaction: %w", err)
-	}
+	// Lock the account, record the transaction and apply the balance change atomically; the transaction runs again
+	// when it loses a serialization conflict to a concurrent one
+	err = service.store.WithRetryTx(ctx, func(q sqlc.Querier) error {
+		account, previousBalance, err := service.lockAccount(ctx, q, pgAccountID)
+		if err != nil {
+			return err
+		}
 
-	// Calculate new balance (previous balance plus credit amount)
-	newBalance := previousBalance.Add(params.Amount)
+		transaction, err := q.CreateTransaction(ctx, createParams)
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
 
-	// Build the result
-	result := &CreditAccountResults{
-		TransactionID:     uuid.UUID(transaction.ID.Bytes),
-		AccountID:         accountID,
-		AccountNumber:     account.AccountNumber,
-		AccountName:       account.AccountName,
-		Amount:            params.Amount,
-		Currency:          params.Currency,
-		Description:       params.Description,
-		ReferenceID:       params.ReferenceID,
-		IdempotencyKey:    params.IdempotencyKey,
-		Status:            string(completedTransaction.Status),
-		PreviousBalance:   previousBalance,
-		NewBalance:        newBalance,
-		CreatedAt:         transaction.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
-		ValidationResults: validationResults,
-		Metadata:          params.Metadata,
-	}
-
-	if completedTransaction.CompletedAt.Valid {
-		completedAtStr := completedTransaction.CompletedAt.Time.Format("2006-01-02T15:04:05Z07:00")
-		result.CompletedAt = &completedAtStr
+		completedTransaction, err := service.applyBalanceChange(ctx, q, pgAccountID, transaction.ID, previousBalance, params.Amount, "credit", sagaCreatedBy)
+		if err != nil {
+			return err
+		}
+
+		// Build the result
+		result = &CreditAccountResults{
+			TransactionID:     uuid.UUID(transaction.ID.Bytes),
+			AccountID:         accountID,
+			AccountNumber:     account.AccountNumber,
+			AccountName:       account.AccountName,
+			Amount:            params.Amount,
+			Currency:          params.Currency,
+			Description:       params.Description,
+			ReferenceID:       params.ReferenceID,
+			IdempotencyKey:    params.IdempotencyKey,
+			Status:            string(completedTransaction.Status),
+			PreviousBalance:   previousBalance,
+			NewBalance:        previousBalance.Add(params.Amount),
+			CreatedAt:         transaction.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+			ValidationResults: validationResults,
+			Metadata:          params.Metadata,
+		}
+
+		if completedTransaction.CompletedAt.Valid {
+			completedAtStr := completedTransaction.CompletedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+			result.CompletedAt = &completedAtStr
+		}
+
+		// Register the key with the transaction, so a replay is answered with this result
+		return service.registerIdempotencyKey(ctx, q, params.IdempotencyKey, idempotencyTypeCredit, creditIdempotencyPayload(params), result)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return result, nil
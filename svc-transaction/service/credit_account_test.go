@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"numeric"
+
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
@@ -336,14 +338,5 @@ func creditDecimalPtr(d decimal.Decimal) *decimal.Decimal {
 }
 
 func createCreditPgNumeric(value string) pgtype.Numeric {
-	d, err := decimal.NewFromString(value)
-	if err != nil {
-		panic(err)
-	}
-
-	return pgtype.Numeric{
-		Int:   d.Coefficient(),
-		Exp:   int32(d.Exponent()),
-		Valid: true,
-	}
+	return numeric.FromDecimal(decimal.RequireFromString(value))
 }
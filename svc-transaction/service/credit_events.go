@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+// incomingCreditSignalName is the signal the conditional transfers of flowngine wait for, see
+// IncomingCreditSignalName in flowngine/service/conditional_transfer.go
+const incomingCreditSignalName = "incoming_credit"
+
+// conditionalTransfersQuery finds the conditional transfers waiting for an account to be credited, through the
+// search attribute flowngine sets on them while they wait. A trigger may name the account by number or by ID.
+const conditionalTransfersQuery = "WorkflowType = 'conditionalTransferWorkflow' AND ExecutionStatus = 'Running' AND TransferTriggerAccount IN ('%s', '%s')"
+
+// creditEventTimeout bounds how long publishing a credit event may delay the credit that caused it
+const creditEventTimeout = 5 * time.Second
+
+// IncomingCreditEvent is published for every committed credit, as the payload of incomingCreditSignalName
+type IncomingCreditEvent struct {
+	TransactionID string          `json:"transaction_id"`
+	AccountID     string          `json:"account_id"`
+	AccountNumber string          `json:"account_number"`
+	Amount        decimal.Decimal `json:"amount"`
+	Currency      string          `json:"currency"`
+}
+
+// publishCreditEvent signals the conditional transfers watching a credited account. The credit is committed
+// already, so failures are logged only: a lost event delays a trigger, never the credit itself. An empty account
+// number is looked up, since triggers may name the account by number.
+func (service *Service) publishCreditEvent(ctx context.Context, transactionID, accountID uuid.UUID, accountNumber string, amount decimal.Decimal, currency string) {
+	const op = "service.Service.publishCreditEvent"
+
+	event := IncomingCreditEvent{
+		TransactionID: transactionID.String(),
+		AccountID:     accountID.String(),
+		AccountNumber: accountNumber,
+		Amount:        amount,
+		Currency:      currency,
+	}
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":  op,
+		"event": fmt.Sprintf("%+v", event),
+	})
+
+	if service.temporalClient == nil {
+		logger.Debug("Temporal client not ready, credit event not published")
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), creditEventTimeout)
+	defer cancel()
+
+	if event.AccountNumber == "" {
+		account, err := service.store.GetAccountByID(ctx, pgtype.UUID{Bytes: accountID, Valid: true})
+		if err != nil {
+			logger.WithError(err).Warn("Failed to get credited account, credit event published by account ID only")
+		}
+		event.AccountNumber = account.AccountNumber
+	}
+
+	// Account numbers are interpolated into the query, so numbers with quotes or backslashes are skipped
+	if strings.ContainsAny(event.AccountNumber, `'"\`) {
+		logger.Warn("Account number cannot be queried, credit event not published")
+
+		return
+	}
+
+	query := fmt.Sprintf(conditionalTransfersQuery, event.AccountNumber, accountID)
+
+	var nextPageToken []byte
+	for {
+		response, err := service.temporalClient.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:         query,
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			logger.WithError(err).Warn("Failed to find conditional transfers watching the account")
+
+			return
+		}
+
+		for _, execution := range response.GetExecutions() {
+			workflowID := execution.GetExecution().GetWorkflowId()
+
+			err := service.temporalClient.SignalWorkflow(ctx, workflowID, execution.GetExecution().GetRunId(), incomingCreditSignalName, event)
+			if err != nil {
+				logger.WithError(err).WithField("workflow_id", workflowID).Warn("Failed to signal conditional transfer")
+
+				continue
+			}
+
+			logger.WithField("workflow_id", workflowID).Info("Credit event published to conditional transfer")
+		}
+
+		nextPageToken = response.GetNextPageToken()
+		if len(nextPageToken) == 0 {
+			return
+		}
+	}
+}
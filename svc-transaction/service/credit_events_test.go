@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"go.temporal.io/api/common/v1"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/mocks"
+)
+
+func TestPublishCreditEvent(t *testing.T) {
+	t.Parallel()
+
+	transactionID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440009")
+	accountID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	want := "WorkflowType = 'conditionalTransferWorkflow' AND ExecutionStatus = 'Running' AND " +
+		"TransferTriggerAccount IN ('ACC001', '550e8400-e29b-41d4-a716-446655440001')"
+
+	execution := func(workflowID string) *workflow.WorkflowExecutionInfo {
+		return &workflow.WorkflowExecutionInfo{Execution: &common.WorkflowExecution{WorkflowId: workflowID, RunId: "run-" + workflowID}}
+	}
+
+	temporalClient := &mocks.Client{}
+	temporalClient.On("ListWorkflow", mock.Anything, mock.MatchedBy(func(request *workflowservice.ListWorkflowExecutionsRequest) bool {
+		return request.Query == want && len(request.NextPageToken) == 0
+	})).Return(&workflowservice.ListWorkflowExecutionsResponse{
+		Executions:    []*workflow.WorkflowExecutionInfo{execution("transfer_workflow_1"), execution("transfer_workflow_2")},
+		NextPageToken: []byte("page-2"),
+	}, nil).Once()
+	temporalClient.On("ListWorkflow", mock.Anything, mock.MatchedBy(func(request *workflowservice.ListWorkflowExecutionsRequest) bool {
+		return request.Query == want && string(request.NextPageToken) == "page-2"
+	})).Return(&workflowservice.ListWorkflowExecutionsResponse{
+		Executions: []*workflow.WorkflowExecutionInfo{execution("transfer_workflow_3")},
+	}, nil).Once()
+
+	wantEvent := IncomingCreditEvent{
+		TransactionID: transactionID.String(),
+		AccountID:     accountID.String(),
+		AccountNumber: "ACC001",
+		Amount:        decimal.NewFromInt(250),
+		Currency:      "USD",
+	}
+	// A failed signal must not stop the remaining transfers from being signalled
+	temporalClient.On("SignalWorkflow", mock.Anything, "transfer_workflow_1", "run-transfer_workflow_1", incomingCreditSignalName, wantEvent).Return(nil).Once()
+	temporalClient.On("SignalWorkflow", mock.Anything, "transfer_workflow_2", "run-transfer_workflow_2", incomingCreditSignalName, wantEvent).Return(errors.New("workflow completed")).Once()
+	temporalClient.On("SignalWorkflow", mock.Anything, "transfer_workflow_3", "run-transfer_workflow_3", incomingCreditSignalName, wantEvent).Return(nil).Once()
+
+	service := &Service{logger: logrus.New(), temporalClient: temporalClient}
+	service.publishCreditEvent(context.Background(), transactionID, accountID, "ACC001", decimal.NewFromInt(250), "USD")
+
+	temporalClient.AssertExpectations(t)
+}
+
+func TestPublishCreditEvent_UnqueryableAccountNumber(t *testing.T) {
+	t.Parallel()
+
+	temporalClient := &mocks.Client{}
+
+	service := &Service{logger: logrus.New(), temporalClient: temporalClient}
+	service.publishCreditEvent(context.Background(), uuid.New(), uuid.New(), "ACC' OR 'x' = 'x", decimal.NewFromInt(1), "USD")
+
+	temporalClient.AssertNotCalled(t, "ListWorkflow", mock.Anything, mock.Anything)
+}
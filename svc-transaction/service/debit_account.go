@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"svc-transaction/store/sqlc"
+	"svc-transaction/util/rules"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -21,6 +23,34 @@ type ValidationResult struct {
 	Passed  bool   `json:"passed"`
 }
 
+// validationFailure returns an error for the first failed validation, wrapping ErrAccountBlocked,
+// ErrInsufficientFunds, ErrInvalidCurrency, ErrAccountTypeRestriction or ErrBusinessRuleViolation by the field that
+// failed; nil when every validation passed
+func validationFailure(results []ValidationResult) error {
+	for _, result := range results {
+		if result.Passed || result.Level != "error" {
+			continue
+		}
+
+		switch {
+		case result.Field == "account_status":
+			return fmt.Errorf("account validation failed: %w: %s", ErrAccountBlocked, result.Message)
+		case result.Field == "account_balance":
+			return fmt.Errorf("account validation failed: %w: %s", ErrInsufficientFunds, result.Message)
+		case result.Field == "currency":
+			return fmt.Errorf("account validation failed: %w: %s", ErrInvalidCurrency, result.Message)
+		case result.Field == "account_type":
+			return fmt.Errorf("account validation failed: %w: %s", ErrAccountTypeRestriction, result.Message)
+		case strings.HasPrefix(result.Field, "business_rule."):
+			return fmt.Errorf("account validation failed: %w: %s", ErrBusinessRuleViolation, result.Message)
+		default:
+			return fmt.Errorf("account validation failed: %s", result.Message)
+		}
+	}
+
+	return nil
+}
+
 // DebitAccountParams represents the input parameters for debiting an account
 type DebitAccountParams struct {
 	AccountID      *uuid.UUID      `json:"account_id,omitempty"`
@@ -76,7 +106,7 @@ func (service *Service) DebitAccount(ctx context.Context, params DebitAccountPar
 
 	// Step 2: Check for existing transaction with same idempotency key
 	if params.IdempotencyKey != nil {
-		existingResult, err := service.checkExistingDebitTransaction(ctx, *params.IdempotencyKey)
+		existingResult, err := service.checkExistingDebitTransaction(ctx, *params.IdempotencyKey, debitIdempotencyPayload(params))
 		if err != nil {
 			err = fmt.Errorf("failed to check existing transaction: %w", err)
 
@@ -115,17 +145,7 @@ func (service *Service) DebitAccount(ctx context.Context, params DebitAccountPar
 	}
 
 	// Step 5: Check if validation passed (no errors)
-	hasErrors := false
-	for _, result := range validationResults {
-		if !result.Passed && result.Level == "error" {
-			hasErrors = true
-			break
-		}
-	}
-
-	if hasErrors {
-		err = fmt.Errorf("account validation failed")
-
+	if err := validationFailure(validationResults); err != nil {
 		logger.WithError(err).Error()
 
 		return &DebitAccountResults{
@@ -139,6 +159,9 @@ func (service *Service) DebitAccount(ctx context.Context, params DebitAccountPar
 
 	// Step 6: Execute the debit transaction within a database transaction
 	result, err := service.executeDebitTransaction(ctx, accountID, params, validationResults)
+	if err != nil && params.IdempotencyKey != nil && isIdempotencyKeyViolation(err) {
+		result, err = service.findDebitByIdempotencyKey(ctx, *params.IdempotencyKey, debitIdempotencyPayload(params))
+	}
 	if err != nil {
 		err = fmt.Errorf("failed to execute debit transaction: %w", err)
 
@@ -173,13 +196,9 @@ func (service *Service) validateDebitAccountParams(params DebitAccountParams) er
 		return fmt.Errorf("account_number cannot be empty")
 	}
 
-	// Validate amount
-	if params.Amount.IsZero() {
-		return fmt.Errorf("amount cannot be zero")
-	}
-
-	if params.Amount.IsNegative() {
-		return fmt.Errorf("amount cannot be negative")
+	// Validate amount sign
+	if err := validateAmountSign(AmountOperationDebit, "amount", params.Amount); err != nil {
+		return err
 	}
 
 	// Validate currency
@@ -192,6 +211,16 @@ func (service *Service) validateDebitAccountParams(params DebitAccountParams) er
 		return fmt.Errorf("currency must be a 3-letter code")
 	}
 
+	// Validate amount fits the currency's minor units
+	if err := validateAmountScale(params.Amount, params.Currency); err != nil {
+		return err
+	}
+
+	// Validate amount fits the amount columns without rounding
+	if err := service.checkAmountPrecision("amount", params.Amount); err != nil {
+		return err
+	}
+
 	// Validate idempotency key if provided
 	if params.IdempotencyKey != nil && *params.IdempotencyKey == "" {
 		return fmt.Errorf("idempotency_key cannot be empty when provided")
@@ -200,14 +229,31 @@ func (service *Service) validateDebitAccountParams(params DebitAccountParams) er
 	return nil
 }
 
-// checkExistingDebitTransaction checks if a transaction with the same idempotency key already exists
-func (service *Service) checkExistingDebitTransaction(ctx context.Context, idempotencyKey string) (*DebitAccountResults, error) {
+// checkExistingDebitTransaction returns the debit registered for the idempotency key, or nil when the key is not
+// registered. It returns an IdempotencyConflictError when that debit was made for a different payload.
+func (service *Service) checkExistingDebitTransaction(ctx context.Context, idempotencyKey string, payload idempotencyPayload) (*DebitAccountResults, error) {
+	var result DebitAccountResults
+
+	found, err := service.lookupIdempotencyKey(ctx, idempotencyKey, payload, &result)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// findDebitByIdempotencyKey returns the debit transaction created with the idempotency key, for keys that are taken
+// without being registered. It returns an IdempotencyConflictError when that transaction was created for a different payload.
+func (service *Service) findDebitByIdempotencyKey(ctx context.Context, idempotencyKey string, payload idempotencyPayload) (*DebitAccountResults, error) {
 	pgIdempotencyKey := pgtype.Text{String: idempotencyKey, Valid: true}
 
 	transaction, err := service.store.GetTransactionByIdempotencyKey(ctx, pgIdempotencyKey)
 	if err != nil {
-		// If not found, that's okay - we can proceed with new transaction
-		return nil, nil
+		return nil, fmt.Errorf("failed to get transaction by idempotency key: %w", err)
+	}
+
+	if err := service.checkIdempotencyPayload(ctx, transaction, payload); err != nil {
+		return nil, err
 	}
 
 	// Convert existing transaction to result format
@@ -219,6 +265,11 @@ func (service *Service) checkExistingDebitTransaction(ctx context.Context, idemp
 	return result, nil
 }
 
+// debitIdempotencyPayload returns what a debit reusing an idempotency key has to repeat
+func debitIdempotencyPayload(params DebitAccountParams) idempotencyPayload {
+	return newIdempotencyPayload(idempotencyTypeDebit, params.AccountID, params.AccountNumber, params.Amount, params.Currency, params.ReferenceID)
+}
+
 // resolveAccountID resolves the account ID from either AccountID or AccountNumber
 func (service *Service) resolveAccountID(ctx context.Context, params DebitAccountParams) (uuid.UUID, error) {
 	if params.AccountID != nil {
@@ -228,7 +279,7 @@ func (service *Service) resolveAccountID(ctx context.Context, params DebitAccoun
 	// Get account by account number
 	account, err := service.store.GetAccountByAccountNumber(ctx, *params.AccountNumber)
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("account not found with account number %s: %w", *params.AccountNumber, err)
+		return uuid.Nil, accountLookupError(err, *params.AccountNumber)
 	}
 
 	return uuid.UUID(account.ID.Bytes), nil
@@ -253,7 +304,7 @@ func (service *Service) validateAccountForDebit(ctx context.Context, accountID u
 		Column2: pgAmount,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to check account balance: %w", err)
+		return nil, accountLookupError(err, accountID.String())
 	}
 
 	// Validate account status
@@ -310,22 +361,32 @@ func (service *Service) validateAccountForDebit(ctx context.Context, accountID u
 		})
 	}
 
+	// Validate the withdrawal limit of the account type
+	accountTypeResult, err := service.accountTypeValidation(ctx, service.store, pgAccountID, balanceCheck.AccountType)
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, accountTypeResult)
+
+	// Validate configured business rules: transaction limits and the minimum balance left behind
+	balance, err := service.pgNumericToDecimal(balanceCheck.Balance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert balance for validation: %w", err)
+	}
+	remaining := balance.Sub(params.Amount)
+
+	results = append(results, businessRuleValidations(service.evaluateBusinessRules(ctx, rules.Facts{
+		Currency: params.Currency,
+		Amount:   &params.Amount,
+		Balance:  &remaining,
+	}))...)
+
 	return results, nil
 }
 
 // executeDebitTransaction executes the debit transaction within a database transaction
 func (service *Service) executeDebitTransaction(ctx context.Context, accountID uuid.UUID, params DebitAccountParams, validationResults []ValidationResult) (*DebitAccountResults, error) {
-	// Get account details before transaction
 	pgAccountID := pgtype.UUID{Bytes: accountID, Valid: true}
-	account, err := service.store.GetAccountByID(ctx, pgAccountID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get account details: %w", err)
-	}
-
-	previousBalance, err := service.pgNumericToDecimal(account.Balance)
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert previous balance: %w", err)
-	}
 
 	// Convert parameters to database types
 	pgAmount, err := service.decimalToPgNumeric(params.Amount)
@@ -350,10 +411,16 @@ func (service *Service) executeDebitTransaction(ctx context.Context, accountID u
 		pgIdempotencyKey = pgtype.Text{String: *params.IdempotencyKey, Valid: true}
 	}
 
+	// Keep the hash of the request so a reused idempotency key can be checked against it
+	metadata := params.Metadata
+	if params.IdempotencyKey != nil {
+		metadata = withPayloadHash(metadata, debitIdempotencyPayload(params))
+	}
+
 	var pgMetadata []byte
-	if params.Metadata != nil {
+	if metadata != nil {
 		// Convert metadata map to JSON bytes
-		metadataBytes, err := json.Marshal(params.Metadata)
+		metadataBytes, err := json.Marshal(metadata)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
 		}
@@ -372,42 +439,63 @@ func (service *Service) executeDebitTransaction(ctx context.Context, accountID u
 		Metadata:        pgMetadata,
 	}
 
-	transaction, err := service.store.CreateTransaction(ctx, createParams)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create transaction: %w", err)
-	}
+	var result *DebitAccountResults
 
-	// Complete the transaction (this will update the account balance)
-	completedTransaction, err := service.store.CompleteTransaction(ctx, transaction.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to complete transaction: %w", err)
-	}
+	// Lock the account, record the transaction and apply the balance change atomically; the transaction runs again
+	// when it loses a serialization conflict to a concurrent one
+	err = service.store.WithRetryTx(ctx, func(q sqlc.Querier) error {
+		account, previousBalance, err := service.lockAccount(ctx, q, pgAccountID)
+		if err != nil {
+			return err
+		}
 
-	// Calculate new balance (previous balance minus debit amount)
-	newBalance := previousBalance.Sub(params.Amount)
+		// Validation ran before the account was locked, so check the balance again
+		if previousBalance.LessThan(params.Amount) {
+			return fmt.Errorf("%w: current balance %s, required %s", ErrInsufficientFunds, previousBalance.String(), params.Amount.String())
+		}
+		if err := service.accountTypeFailure(ctx, q, account); err != nil {
+			return err
+		}
 
-	// Build the result
-	result := &DebitAccountResults{
-		TransactionID:     uuid.UUID(transaction.ID.Bytes),
-		AccountID:         accountID,
-		AccountNumber:     account.AccountNumber,
-		AccountName:       account.AccountName,
-		Amount:            params.Amount,
-		Currency:          params.Currency,
-		Description:       params.Description,
-		ReferenceID:       params.ReferenceID,
-		IdempotencyKey:    params.IdempotencyKey,
-		Status:            string(completedTransaction.Status),
-		PreviousBalance:   previousBalance,
-		NewBalance:        newBalance,
-		CreatedAt:         transaction.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
-		ValidationResults: validationResults,
-		Metadata:          params.Metadata,
-	}
-
-	if completedTransaction.CompletedAt.Valid {
-		completedAtStr := completedTransaction.CompletedAt.Time.Format("2006-01-02T15:04:05Z07:00")
-		result.CompletedAt = &completedAtStr
+		transaction, err := q.CreateTransaction(ctx, createParams)
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+
+		completedTransaction, err := service.applyBalanceChange(ctx, q, pgAccountID, transaction.ID, previousBalance, params.Amount.Neg(), "debit", sagaCreatedBy)
+		if err != nil {
+			return err
+		}
+
+		// Build the result
+		result = &DebitAccountResults{
+			TransactionID:     uuid.UUID(transaction.ID.Bytes),
+			AccountID:         accountID,
+			AccountNumber:     account.AccountNumber,
+			AccountName:       account.AccountName,
+			Amount:            params.Amount,
+			Currency:          params.Currency,
+			Description:       params.Description,
+			ReferenceID:       params.ReferenceID,
+			IdempotencyKey:    params.IdempotencyKey,
+			Status:            string(completedTransaction.Status),
+			PreviousBalance:   previousBalance,
+			NewBalance:        previousBalance.Sub(params.Amount),
+			CreatedAt:         transaction.CreatedAt.Time.Format("2006-01-02T15:04:05Z07:00"),
+			ValidationResults: validationResults,
+			Metadata:          params.Metadata,
+		}
+
+		if completedTransaction.CompletedAt.Valid {
+			completedAtStr := completedTransaction.CompletedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+			result.CompletedAt = &completedAtStr
+		}
+
+		// Register the key with the transaction, so a replay is answered with this result
+		return service.registerIdempotencyKey(ctx, q, params.IdempotencyKey, idempotencyTypeDebit, debitIdempotencyPayload(params), result)
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return result, nil
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -181,18 +182,140 @@ func (service *Service) GetCompensationAuditByWorkflowID(
 	return records, nil
 }
 
-// GetCompensationStats returns compensation statistics for monitoring
-func (service *Service) GetCompensationStats(ctx context.Context) (*sqlc.GetCompensationStatsRow, error) {
+// defaultCompensationStatsWindow is the period GetCompensationStats covers when the filter has no start
+const defaultCompensationStatsWindow = 24 * time.Hour
+
+// ErrInvalidCompensationFilter is returned when a compensation audit filter names an unknown status or type,
+// or when its date range is empty
+var ErrInvalidCompensationFilter = errors.New("invalid compensation audit filter")
+
+// CompensationAuditFilter narrows compensation audit queries. Zero-valued fields leave that dimension unfiltered.
+// From is inclusive and To is exclusive.
+type CompensationAuditFilter struct {
+	Status     string
+	Type       string
+	WorkflowID string
+	From       time.Time
+	To         time.Time
+	Limit      int32
+}
+
+func (filter CompensationAuditFilter) validate() error {
+	switch sqlc.CoreCompensationStatus(filter.Status) {
+	case "",
+		sqlc.CoreCompensationStatusPending,
+		sqlc.CoreCompensationStatusCompleted,
+		sqlc.CoreCompensationStatusFailed,
+		sqlc.CoreCompensationStatusTimeout,
+		sqlc.CoreCompensationStatusManualRequired:
+	default:
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidCompensationFilter, filter.Status)
+	}
+
+	switch sqlc.CoreCompensationType(filter.Type) {
+	case "",
+		sqlc.CoreCompensationTypeDebitReversal,
+		sqlc.CoreCompensationTypeCreditReversal,
+		sqlc.CoreCompensationTypeManualAdjustment:
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidCompensationFilter, filter.Type)
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidCompensationFilter)
+	}
+
+	return nil
+}
+
+func (filter CompensationAuditFilter) status() sqlc.NullCoreCompensationStatus {
+	return sqlc.NullCoreCompensationStatus{
+		CoreCompensationStatus: sqlc.CoreCompensationStatus(filter.Status),
+		Valid:                  filter.Status != "",
+	}
+}
+
+func (filter CompensationAuditFilter) compensationType() sqlc.NullCoreCompensationType {
+	return sqlc.NullCoreCompensationType{
+		CoreCompensationType: sqlc.CoreCompensationType(filter.Type),
+		Valid:                filter.Type != "",
+	}
+}
+
+// ListCompensationAudits returns the most recent compensation audit records matching filter
+func (service *Service) ListCompensationAudits(
+	ctx context.Context,
+	filter CompensationAuditFilter,
+) ([]sqlc.CoreCompensationAuditTrail, error) {
+	const op = "service.Service.ListCompensationAudits"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"filter": fmt.Sprintf("%+v", filter),
+	})
+
+	logger.Debug("Listing compensation audit records")
+
+	if err := filter.validate(); err != nil {
+		logger.WithError(err).Debug()
+		return nil, err
+	}
+
+	records, err := service.store.ListCompensationAudits(ctx, sqlc.ListCompensationAuditsParams{
+		CompensationStatus: filter.status(),
+		CompensationType:   filter.compensationType(),
+		WorkflowID:         pgtype.Text{String: filter.WorkflowID, Valid: filter.WorkflowID != ""},
+		CreatedFrom:        pgtype.Timestamptz{Time: filter.From, Valid: !filter.From.IsZero()},
+		CreatedTo:          pgtype.Timestamptz{Time: filter.To, Valid: !filter.To.IsZero()},
+		RowLimit:           filter.Limit,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to list compensation audit records: %w", err)
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	logger.WithField("record_count", len(records)).Debug("Listed compensation audit records")
+
+	return records, nil
+}
+
+// GetCompensationStats returns compensation statistics for monitoring. When filter has no range it covers
+// the last 24 hours; the range it actually covered is written back into the returned filter.
+func (service *Service) GetCompensationStats(
+	ctx context.Context,
+	filter CompensationAuditFilter,
+) (*sqlc.GetCompensationStatsFilteredRow, CompensationAuditFilter, error) {
 	const op = "service.Service.GetCompensationStats"
 
-	logger := service.logger.WithField("[op]", op)
+	if filter.To.IsZero() {
+		filter.To = time.Now()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-defaultCompensationStatsWindow)
+	}
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"filter": fmt.Sprintf("%+v", filter),
+	})
 	logger.Debug("Getting compensation statistics")
 
-	stats, err := service.store.GetCompensationStats(ctx)
+	if err := filter.validate(); err != nil {
+		logger.WithError(err).Debug()
+		return nil, filter, err
+	}
+
+	stats, err := service.store.GetCompensationStatsFiltered(ctx, sqlc.GetCompensationStatsFilteredParams{
+		CompensationStatus: filter.status(),
+		CompensationType:   filter.compensationType(),
+		CreatedFrom:        pgtype.Timestamptz{Time: filter.From, Valid: true},
+		CreatedTo:          pgtype.Timestamptz{Time: filter.To, Valid: true},
+	})
 	if err != nil {
 		err = fmt.Errorf("failed to get compensation stats: %w", err)
 		logger.WithError(err).Error()
-		return nil, err
+		return nil, filter, err
 	}
 
 	logger.WithFields(logrus.Fields{
@@ -201,7 +324,7 @@ func (service *Service) GetCompensationStats(ctx context.Context) (*sqlc.GetComp
 		"failed_compensations":    stats.FailedCompensations,
 	}).Debug("Retrieved compensation statistics")
 
-	return &stats, nil
+	return &stats, filter, nil
 }
 
 // Enhanced compensation activity params with timeout and retry configuration
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompensationAuditFilterValidate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		filter  CompensationAuditFilter
+		wantErr bool
+	}{
+		{name: "empty", filter: CompensationAuditFilter{}},
+		{name: "known_status_and_type", filter: CompensationAuditFilter{Status: "manual_required", Type: "debit_reversal"}},
+		{name: "open_ended_range", filter: CompensationAuditFilter{From: now}},
+		{name: "closed_range", filter: CompensationAuditFilter{From: now.Add(-time.Hour), To: now}},
+		{name: "unknown_status", filter: CompensationAuditFilter{Status: "done"}, wantErr: true},
+		{name: "unknown_type", filter: CompensationAuditFilter{Type: "refund"}, wantErr: true},
+		{name: "empty_range", filter: CompensationAuditFilter{From: now, To: now}, wantErr: true},
+		{name: "inverted_range", filter: CompensationAuditFilter{From: now, To: now.Add(-time.Hour)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.filter.validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidCompensationFilter)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+// EODBalanceWorkflowName is the name EODBalanceWorkflow is registered under by the worker
+const EODBalanceWorkflowName = "EODBalanceWorkflow"
+
+// BusinessDateFormat is how business dates are written in requests, responses and workflow IDs
+const BusinessDateFormat = "2006-01-02"
+
+// ErrEODBalanceRejected is returned for a business date that is missing or has not ended yet
+var ErrEODBalanceRejected = errors.New("end-of-day balance run rejected")
+
+// ErrEODBalanceInProgress is returned when the closing balances of the same business date are already being computed
+var ErrEODBalanceInProgress = errors.New("end-of-day balance run already in progress")
+
+// ComputeEODBalancesParams selects the business day (UTC) whose closing balances are computed
+type ComputeEODBalancesParams struct {
+	BusinessDate time.Time `json:"business_date"`
+}
+
+// ComputeEODBalancesResults reports one computed business day
+type ComputeEODBalancesResults struct {
+	BusinessDate string `json:"business_date"`
+	Accounts     int64  `json:"accounts"` // Closing balances written, one per account opened by the end of the day
+}
+
+// StartEODBalancesParams represents an on-demand run, e.g. after a late correction to a closed day
+type StartEODBalancesParams struct {
+	BusinessDate time.Time `json:"business_date"`
+	RequestedBy  string    `json:"requested_by,omitempty"`
+}
+
+// StartEODBalancesResults identifies the started EODBalanceWorkflow
+type StartEODBalancesResults struct {
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+}
+
+// EODBalanceWorkflowParams is the input of EODBalanceWorkflow. Without a BusinessDate the workflow computes the day
+// before it started, which is how the nightly schedule runs it.
+type EODBalanceWorkflowParams struct {
+	BusinessDate time.Time `json:"business_date"`
+}
+
+// BusinessDay returns the UTC day of date as [start, end)
+func BusinessDay(date time.Time) (start, end time.Time) {
+	year, month, day := date.UTC().Date()
+	start = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	return start, start.AddDate(0, 0, 1)
+}
+
+// eodBalanceWorkflowID allows a single running computation per business date
+func eodBalanceWorkflowID(date time.Time) string {
+	return "eod_balances_" + date.UTC().Format(BusinessDateFormat)
+}
+
+// validateBusinessDate accepts the business days that ended by now
+func validateBusinessDate(date, now time.Time) error {
+	if date.IsZero() {
+		return fmt.Errorf("%w: business_date is required", ErrEODBalanceRejected)
+	}
+	if _, end := BusinessDay(date); end.After(now) {
+		return fmt.Errorf("%w: business day %s has not ended yet", ErrEODBalanceRejected, date.UTC().Format(BusinessDateFormat))
+	}
+
+	return nil
+}
+
+// StartEODBalances starts EODBalanceWorkflow for a business date that has already been computed or was missed
+func (service *Service) StartEODBalances(ctx context.Context, params StartEODBalancesParams) (*StartEODBalancesResults, error) {
+	const op = "service.Service.StartEODBalances"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	if err := validateBusinessDate(params.BusinessDate, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if service.temporalClient == nil {
+		err := fmt.Errorf("temporal client not available - service is starting up")
+
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	businessDate, _ := BusinessDay(params.BusinessDate)
+	workflowID := eodBalanceWorkflowID(businessDate)
+
+	run, err := service.temporalClient.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:                                       workflowID,
+		TaskQueue:                                service.taskQueue,
+		WorkflowExecutionErrorWhenAlreadyStarted: true,
+	}, EODBalanceWorkflowName, EODBalanceWorkflowParams{
+		BusinessDate: businessDate,
+	})
+	if err != nil {
+		var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+		if errors.As(err, &alreadyStarted) {
+			err = fmt.Errorf("%w: %s", ErrEODBalanceInProgress, workflowID)
+		} else {
+			err = fmt.Errorf("failed to start end-of-day balance workflow: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &StartEODBalancesResults{
+		WorkflowID: run.GetID(),
+		RunID:      run.GetRunID(),
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info("End-of-day balance run started")
+
+	return results, nil
+}
+
+// ComputeEODBalances stores the closing balance of every account opened by the end of the business day: the new
+// balance of its last balance history entry of that day or earlier, or zero without one. All accounts are written
+// in one statement, and computing the same day again overwrites its rows, so re-runs are safe and pick up
+// corrections made since.
+func (service *Service) ComputeEODBalances(ctx context.Context, params ComputeEODBalancesParams) (*ComputeEODBalancesResults, error) {
+	const op = "service.Service.ComputeEODBalances"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":          op,
+		"business_date": params.BusinessDate,
+	})
+
+	if err := validateBusinessDate(params.BusinessDate, time.Now()); err != nil {
+		return nil, err
+	}
+
+	start, end := BusinessDay(params.BusinessDate)
+
+	accounts, err := service.store.UpsertEODBalances(ctx, sqlc.UpsertEODBalancesParams{
+		BusinessDate: pgtype.Date{Time: start, Valid: true},
+		DayEnd:       pgtype.Timestamptz{Time: end, Valid: true},
+		DayStart:     pgtype.Timestamptz{Time: start, Valid: true},
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to store closing balances: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &ComputeEODBalancesResults{
+		BusinessDate: start.Format(BusinessDateFormat),
+		Accounts:     accounts,
+	}
+
+	logger.WithField("accounts", accounts).Info("Closing balances stored")
+
+	return results, nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"svc-transaction/store"
+	"svc-transaction/store/sqlc"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// eodBalanceStore answers UpsertEODBalances and remembers the day it was asked for
+type eodBalanceStore struct {
+	store.IStore
+
+	accounts int64
+	params   sqlc.UpsertEODBalancesParams
+}
+
+func (store *eodBalanceStore) UpsertEODBalances(_ context.Context, arg sqlc.UpsertEODBalancesParams) (int64, error) {
+	store.params = arg
+
+	return store.accounts, nil
+}
+
+func TestBusinessDay(t *testing.T) {
+	t.Parallel()
+
+	// 01:30 in Jakarta is still the previous day in UTC
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	start, end := BusinessDay(time.Date(2026, 10, 16, 1, 30, 0, 0, jakarta))
+
+	assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), end)
+	assert.Equal(t, "eod_balances_2026-10-15", eodBalanceWorkflowID(start))
+}
+
+func TestValidateBusinessDate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 0, 15, 0, 0, time.UTC)
+
+	assert.NoError(t, validateBusinessDate(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), now))
+	assert.ErrorIs(t, validateBusinessDate(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), now), ErrEODBalanceRejected)
+	assert.ErrorIs(t, validateBusinessDate(time.Time{}, now), ErrEODBalanceRejected)
+}
+
+func TestComputeEODBalances(t *testing.T) {
+	t.Parallel()
+
+	balances := &eodBalanceStore{accounts: 42}
+	service := &Service{logger: logrus.New(), store: balances}
+
+	results, err := service.ComputeEODBalances(context.Background(), ComputeEODBalancesParams{
+		BusinessDate: time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, &ComputeEODBalancesResults{BusinessDate: "2026-10-15", Accounts: 42}, results)
+	assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), balances.params.BusinessDate.Time)
+	assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC), balances.params.DayStart.Time)
+	assert.Equal(t, time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), balances.params.DayEnd.Time)
+
+	_, err = service.ComputeEODBalances(context.Background(), ComputeEODBalancesParams{BusinessDate: time.Now()})
+	assert.ErrorIs(t, err, ErrEODBalanceRejected)
+}
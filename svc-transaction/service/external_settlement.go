@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"svc-transaction/adapter/external_bank_adapter"
+	"svc-transaction/adapter/external_bank_adapter/pb"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Statuses of a settlement at the partner bank, as reported to the transfer saga
+const (
+	ExternalSettlementStatusPending       = "pending"
+	ExternalSettlementStatusSettled       = "settled"
+	ExternalSettlementStatusRejected      = "rejected"
+	ExternalSettlementStatusRecalled      = "recalled"
+	ExternalSettlementStatusRecallRefused = "recall_refused"
+	ExternalSettlementStatusNotFound      = "not_found" // The partner never accepted the settlement, so nothing has to be recalled
+)
+
+// DefaultExternalBankTimeout is the deadline of each call to the partner bank when no timeout is configured
+const DefaultExternalBankTimeout = 10 * time.Second
+
+// ErrExternalSettlementRejected is returned when the partner bank refuses a settlement or no partner is configured.
+// Nothing was settled, so the saga compensates the debit.
+var ErrExternalSettlementRejected = errors.New("external settlement rejected")
+
+// ErrExternalSettlementPending is returned while the partner bank has accepted a settlement without settling it.
+// It is retried like an outage until the partner settles or the saga gives up.
+var ErrExternalSettlementPending = errors.New("external settlement pending")
+
+type SettleExternalTransferParams struct {
+	SettlementID       string          `json:"settlement_id"`
+	TransferID         string          `json:"transfer_id"`
+	BeneficiaryAccount string          `json:"beneficiary_account"`
+	Amount             decimal.Decimal `json:"amount"`
+	Currency           string          `json:"currency"`
+	CallbackURL        string          `json:"callback_url,omitempty"`   // Receives the settlement from the partner once it settles
+	CallbackToken      []byte          `json:"callback_token,omitempty"` // Echoed back by the partner in the callback
+}
+
+type RecallExternalSettlementParams struct {
+	SettlementID string `json:"settlement_id"`
+	Reason       string `json:"reason"`
+}
+
+// ExternalSettlementResults is the state of a settlement at the partner bank
+type ExternalSettlementResults struct {
+	SettlementID string     `json:"settlement_id"`
+	Status       string     `json:"status"`
+	Reason       string     `json:"reason,omitempty"`
+	SettledAt    *time.Time `json:"settled_at,omitempty"`
+	RecalledAt   *time.Time `json:"recalled_at,omitempty"`
+}
+
+// SetExternalBank sets the partner bank that settles transfers leaving the bank, and the deadline of each call to it
+func (service *Service) SetExternalBank(externalBank *external_bank_adapter.Adapter, timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultExternalBankTimeout
+	}
+
+	service.externalBank = externalBank
+	service.externalBankTimeout = timeout
+}
+
+// SettleExternalTransfer submits a settlement to the partner bank and returns once it is settled. The partner
+// treats a resubmitted settlement ID as the same settlement, so retrying after an outage or a lost reply never
+// settles twice. A settlement that is still pending fails with ErrExternalSettlementPending to be retried, unless
+// it was submitted with a callback URL: the pending status is then returned and the partner calls back later.
+func (service *Service) SettleExternalTransfer(ctx context.Context, params SettleExternalTransferParams) (*ExternalSettlementResults, error) {
+	const op = "service.Service.SettleExternalTransfer"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	if service.externalBank == nil {
+		err := fmt.Errorf("%w: no partner bank configured", ErrExternalSettlementRejected)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, service.externalBankTimeout)
+	defer cancel()
+
+	response, err := service.externalBank.SubmitSettlement(ctx, &pb.SubmitSettlementRequest{
+		SettlementId:       params.SettlementID,
+		TransferId:         params.TransferID,
+		BeneficiaryAccount: params.BeneficiaryAccount,
+		Amount:             params.Amount.String(),
+		Currency:           params.Currency,
+		CallbackUrl:        params.CallbackURL,
+		CallbackToken:      params.CallbackToken,
+	})
+	if err != nil {
+		err = externalBankError(err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := externalSettlementResults(response.Settlement)
+
+	switch results.Status {
+	case ExternalSettlementStatusSettled:
+		logger.WithField("results", fmt.Sprintf("%+v", results)).Info()
+
+		return results, nil
+
+	case ExternalSettlementStatusPending:
+		if params.CallbackURL != "" {
+			logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Settlement pending, awaiting partner callback")
+
+			return results, nil
+		}
+
+		err = fmt.Errorf("%w: %s", ErrExternalSettlementPending, params.SettlementID)
+
+		logger.WithError(err).Info()
+
+		return nil, err
+
+	default:
+		// Rejected, or already recalled by an earlier run of the saga
+		err = fmt.Errorf("%w: settlement %s is %s: %s", ErrExternalSettlementRejected, params.SettlementID, results.Status, results.Reason)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+}
+
+// RecallExternalSettlement asks the partner bank to return the funds of a settlement. The partner's answer is
+// returned rather than failed: a recall_refused status means the funds are gone and the transfer needs manual
+// reconciliation. A settlement the partner never accepted is reported as not_found.
+func (service *Service) RecallExternalSettlement(ctx context.Context, params RecallExternalSettlementParams) (*ExternalSettlementResults, error) {
+	const op = "service.Service.RecallExternalSettlement"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	if service.externalBank == nil {
+		results := &ExternalSettlementResults{SettlementID: params.SettlementID, Status: ExternalSettlementStatusNotFound}
+
+		logger.WithField("results", fmt.Sprintf("%+v", results)).Warn("No partner bank configured, nothing to recall")
+
+		return results, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, service.externalBankTimeout)
+	defer cancel()
+
+	response, err := service.externalBank.RecallSettlement(ctx, &pb.RecallSettlementRequest{
+		SettlementId: params.SettlementID,
+		Reason:       params.Reason,
+	})
+	if status.Code(err) == codes.NotFound {
+		results := &ExternalSettlementResults{SettlementID: params.SettlementID, Status: ExternalSettlementStatusNotFound}
+
+		logger.WithField("results", fmt.Sprintf("%+v", results)).Info()
+
+		return results, nil
+	}
+	if err != nil {
+		err = externalBankError(err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := externalSettlementResults(response.Settlement)
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info()
+
+	return results, nil
+}
+
+// externalBankError maps the answers of the partner bank that retrying cannot change to service errors. Outages
+// and timeouts are returned as they are and retried.
+func externalBankError(err error) error {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %w", ErrExternalSettlementRejected, err)
+	case codes.FailedPrecondition:
+		return fmt.Errorf("%w: %w", ErrIdempotencyConflict, err)
+	default:
+		return fmt.Errorf("partner bank call failed: %w", err)
+	}
+}
+
+// externalSettlementResults converts a settlement of the partner bank
+func externalSettlementResults(settlement *pb.Settlement) *ExternalSettlementResults {
+	results := &ExternalSettlementResults{
+		SettlementID: settlement.GetSettlementId(),
+		Status:       strings.ToLower(strings.TrimPrefix(settlement.GetStatus().String(), "SETTLEMENT_STATUS_")),
+		Reason:       settlement.GetReason(),
+	}
+
+	if settlement.GetSettledAt() != nil {
+		settledAt := settlement.GetSettledAt().AsTime()
+		results.SettledAt = &settledAt
+	}
+	if settlement.GetRecalledAt() != nil {
+		recalledAt := settlement.GetRecalledAt().AsTime()
+		results.RecalledAt = &recalledAt
+	}
+
+	return results
+}
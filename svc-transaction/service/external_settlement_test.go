@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"svc-transaction/adapter/external_bank_adapter"
+	"svc-transaction/adapter/external_bank_adapter/pb"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// partnerBank answers every call with a fixed settlement or error
+type partnerBank struct {
+	pb.UnimplementedExternalBankServer
+
+	settlement *pb.Settlement
+	err        error
+
+	submitted *pb.SubmitSettlementRequest // Last submission received
+}
+
+func (bank *partnerBank) SubmitSettlement(ctx context.Context, request *pb.SubmitSettlementRequest) (*pb.SubmitSettlementResponse, error) {
+	bank.submitted = request
+
+	if bank.err != nil {
+		return nil, bank.err
+	}
+
+	return &pb.SubmitSettlementResponse{Settlement: bank.settlement}, nil
+}
+
+func (bank *partnerBank) RecallSettlement(ctx context.Context, request *pb.RecallSettlementRequest) (*pb.RecallSettlementResponse, error) {
+	if bank.err != nil {
+		return nil, bank.err
+	}
+
+	return &pb.RecallSettlementResponse{Settlement: bank.settlement}, nil
+}
+
+// newExternalSettlementTestService returns a service whose partner bank is served in memory by bank
+func newExternalSettlementTestService(t *testing.T, bank *partnerBank) *Service {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pb.RegisterExternalBankServer(server, bank)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///partner",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	svc := NewService(logger, nil)
+	svc.SetExternalBank(external_bank_adapter.NewAdapter("svc-external-bank", logger, conn), time.Second)
+
+	return svc
+}
+
+func settleExternalTransferParams() SettleExternalTransferParams {
+	return SettleExternalTransferParams{
+		SettlementID:       "transfer-1-settle",
+		TransferID:         "transfer-1",
+		BeneficiaryAccount: "ACC001000002",
+		Amount:             decimal.RequireFromString("100.50"),
+		Currency:           "USD",
+	}
+}
+
+func TestSettleExternalTransfer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	settledAt := time.Date(2025, 6, 2, 10, 0, 5, 0, time.UTC)
+
+	t.Run("settled", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newExternalSettlementTestService(t, &partnerBank{settlement: &pb.Settlement{
+			SettlementId: "transfer-1-settle",
+			Status:       pb.SettlementStatus_SETTLEMENT_STATUS_SETTLED,
+			SettledAt:    timestamppb.New(settledAt),
+		}})
+
+		results, err := svc.SettleExternalTransfer(ctx, settleExternalTransferParams())
+		require.NoError(t, err)
+		assert.Equal(t, ExternalSettlementStatusSettled, results.Status)
+		require.NotNil(t, results.SettledAt)
+		assert.True(t, settledAt.Equal(*results.SettledAt))
+	})
+
+	t.Run("pending_is_retried", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newExternalSettlementTestService(t, &partnerBank{settlement: &pb.Settlement{
+			SettlementId: "transfer-1-settle",
+			Status:       pb.SettlementStatus_SETTLEMENT_STATUS_PENDING,
+		}})
+
+		_, err := svc.SettleExternalTransfer(ctx, settleExternalTransferParams())
+		assert.ErrorIs(t, err, ErrExternalSettlementPending)
+	})
+
+	t.Run("pending_awaits_callback", func(t *testing.T) {
+		t.Parallel()
+
+		bank := &partnerBank{settlement: &pb.Settlement{
+			SettlementId: "transfer-1-settle",
+			Status:       pb.SettlementStatus_SETTLEMENT_STATUS_PENDING,
+		}}
+		svc := newExternalSettlementTestService(t, bank)
+
+		params := settleExternalTransferParams()
+		params.CallbackURL = "http://flowngine:8083/callbacks/external-settlements"
+		params.CallbackToken = []byte("task-token")
+
+		results, err := svc.SettleExternalTransfer(ctx, params)
+		require.NoError(t, err)
+		assert.Equal(t, ExternalSettlementStatusPending, results.Status)
+		assert.Equal(t, params.CallbackURL, bank.submitted.GetCallbackUrl())
+		assert.Equal(t, []byte("task-token"), bank.submitted.GetCallbackToken())
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newExternalSettlementTestService(t, &partnerBank{settlement: &pb.Settlement{
+			SettlementId: "transfer-1-settle",
+			Status:       pb.SettlementStatus_SETTLEMENT_STATUS_REJECTED,
+			Reason:       "beneficiary account is not accepted by the partner bank",
+		}})
+
+		_, err := svc.SettleExternalTransfer(ctx, settleExternalTransferParams())
+		assert.ErrorIs(t, err, ErrExternalSettlementRejected)
+		assert.Contains(t, err.Error(), "not accepted")
+	})
+
+	t.Run("idempotency_conflict", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newExternalSettlementTestService(t, &partnerBank{err: status.Error(codes.FailedPrecondition, "settlement ID already used")})
+
+		_, err := svc.SettleExternalTransfer(ctx, settleExternalTransferParams())
+		assert.ErrorIs(t, err, ErrIdempotencyConflict)
+	})
+
+	t.Run("outage_is_retried", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newExternalSettlementTestService(t, &partnerBank{err: status.Error(codes.Unavailable, "partner bank unavailable")})
+
+		_, err := svc.SettleExternalTransfer(ctx, settleExternalTransferParams())
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrExternalSettlementRejected)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("no_partner_configured", func(t *testing.T) {
+		t.Parallel()
+
+		svc := NewService(logrus.New(), nil)
+
+		_, err := svc.SettleExternalTransfer(ctx, settleExternalTransferParams())
+		assert.ErrorIs(t, err, ErrExternalSettlementRejected)
+	})
+}
+
+func TestRecallExternalSettlement(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	params := RecallExternalSettlementParams{SettlementID: "transfer-1-settle", Reason: "credit failed"}
+
+	t.Run("recall_refused", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newExternalSettlementTestService(t, &partnerBank{settlement: &pb.Settlement{
+			SettlementId: "transfer-1-settle",
+			Status:       pb.SettlementStatus_SETTLEMENT_STATUS_RECALL_REFUSED,
+			Reason:       "beneficiary bank refused to return the funds",
+		}})
+
+		results, err := svc.RecallExternalSettlement(ctx, params)
+		require.NoError(t, err)
+		assert.Equal(t, ExternalSettlementStatusRecallRefused, results.Status)
+		assert.Equal(t, "beneficiary bank refused to return the funds", results.Reason)
+	})
+
+	t.Run("never_accepted", func(t *testing.T) {
+		t.Parallel()
+
+		svc := newExternalSettlementTestService(t, &partnerBank{err: status.Error(codes.NotFound, "settlement not found")})
+
+		results, err := svc.RecallExternalSettlement(ctx, params)
+		require.NoError(t, err)
+		assert.Equal(t, ExternalSettlementStatusNotFound, results.Status)
+	})
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"svc-transaction/util/failure"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultCompensationBacklogCheckInterval is how often the compensation backlog is counted when no interval is set
+	DefaultCompensationBacklogCheckInterval = 30 * time.Second
+
+	// compensationBacklogWindow is how far back unfinished compensations count towards the backlog
+	compensationBacklogWindow = time.Hour
+)
+
+// compensationBacklogGuard switches the failure simulator off when compensations pile up faster than they complete
+type compensationBacklogGuard struct {
+	limit         int64 // 0 disables the guard
+	checkInterval time.Duration
+
+	mutex     sync.Mutex
+	lastCheck time.Time
+}
+
+// SetFailureQuota caps the share of operations the failure rules fail per minute
+func (service *Service) SetFailureQuota(quota failure.Quota) {
+	service.failureSimulator.SetQuota(quota)
+}
+
+// SetCompensationBacklogLimit switches the failure simulator off once more than limit compensations of the last
+// hour have not completed; 0 disables the check. The backlog is counted at most once per checkInterval.
+func (service *Service) SetCompensationBacklogLimit(limit int64, checkInterval time.Duration) {
+	if checkInterval <= 0 {
+		checkInterval = DefaultCompensationBacklogCheckInterval
+	}
+
+	service.backlogGuard.mutex.Lock()
+	defer service.backlogGuard.mutex.Unlock()
+
+	service.backlogGuard.limit = limit
+	service.backlogGuard.checkInterval = checkInterval
+}
+
+// checkCompensationBacklog trips the failure simulator when the compensation backlog exceeds its limit. It runs on
+// the activity path, so the backlog is counted at most once per check interval and a failed count is only logged.
+// The simulator stays off until it is switched on again, e.g. with flowctl chaos enable.
+func (service *Service) checkCompensationBacklog(ctx context.Context) {
+	const op = "service.Service.checkCompensationBacklog"
+
+	guard := &service.backlogGuard
+
+	guard.mutex.Lock()
+	now := time.Now()
+	due := guard.limit > 0 && now.Sub(guard.lastCheck) >= guard.checkInterval
+	if due {
+		guard.lastCheck = now
+	}
+	limit := guard.limit
+	guard.mutex.Unlock()
+
+	if !due || !service.failureSimulator.Enabled() {
+		return
+	}
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":  op,
+		"limit": limit,
+	})
+
+	backlog, err := service.store.CountCompensationBacklog(ctx, pgtype.Timestamptz{Time: now.Add(-compensationBacklogWindow), Valid: true})
+	if err != nil {
+		logger.WithError(err).Warn("Failed to count compensation backlog")
+
+		return
+	}
+
+	if backlog > limit {
+		service.failureSimulator.Trip(fmt.Sprintf("compensation backlog of %d exceeds %d", backlog, limit))
+
+		logger.WithField("backlog", backlog).Warn("Failure simulation disabled, compensations are piling up")
+	}
+}
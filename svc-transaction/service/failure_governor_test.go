@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"svc-transaction/store"
+	"svc-transaction/util/failure"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// compensationBacklogStore answers CountCompensationBacklog and counts how often it was asked
+type compensationBacklogStore struct {
+	store.IStore
+
+	backlog int64
+	calls   int
+}
+
+func (store *compensationBacklogStore) CountCompensationBacklog(_ context.Context, _ pgtype.Timestamptz) (int64, error) {
+	store.calls++
+
+	return store.backlog, nil
+}
+
+func TestCheckCompensationBacklog(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	backlog := &compensationBacklogStore{backlog: 10}
+	service := &Service{logger: logger, store: backlog, failureSimulator: failure.NewSimulator(logger)}
+
+	// Without a limit the backlog is never counted
+	service.checkCompensationBacklog(context.Background())
+	assert.Equal(t, 0, backlog.calls)
+
+	service.SetCompensationBacklogLimit(10, time.Hour)
+	service.checkCompensationBacklog(context.Background())
+	assert.Equal(t, 1, backlog.calls)
+	assert.True(t, service.failureSimulator.Enabled(), "a backlog at the limit keeps failure simulation on")
+
+	// The next count is due after the check interval
+	backlog.backlog = 11
+	service.checkCompensationBacklog(context.Background())
+	assert.Equal(t, 1, backlog.calls)
+
+	service.backlogGuard.lastCheck = time.Time{}
+	service.checkCompensationBacklog(context.Background())
+	assert.Equal(t, 2, backlog.calls)
+	assert.False(t, service.failureSimulator.Enabled())
+	assert.Equal(t, "compensation backlog of 11 exceeds 10", service.GetFailureSimulationStats()["disabled_reason"])
+}
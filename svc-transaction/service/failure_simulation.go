@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"svc-transaction/util/failure"
@@ -113,9 +114,10 @@ var learningFailureRules = []failure.Rule{
 // SimulateFailure executes failure simulation based on hardcoded learning rules
 // This method is called from activities to inject controlled failures for demonstration
 func (service *Service) SimulateFailure(ctx context.Context, operation string, accountID string) error {
+	service.checkCompensationBacklog(ctx)
+
 	// Combine regular transaction rules with enhanced compensation rules
-	allRules := append(learningFailureRules, enhancedCompensationRules...)
-	return service.failureSimulator.SimulateFailure(ctx, operation, accountID, allRules)
+	return service.failureSimulator.SimulateFailure(ctx, operation, accountID, allFailureRules())
 }
 
 // GetFailureSimulationStats returns statistics about failure simulation
@@ -127,7 +129,11 @@ func (service *Service) GetFailureSimulationStats() map[string]any {
 	stats["learning_mode"] = true
 	stats["service"] = "transaction"
 	stats["total_rules"] = len(learningFailureRules)
-	stats["enabled_rules"] = countEnabledRules()
+	stats["enabled_rules"] = service.countEnabledRules()
+
+	service.backlogGuard.mutex.Lock()
+	stats["compensation_backlog_limit"] = service.backlogGuard.limit
+	service.backlogGuard.mutex.Unlock()
 
 	return stats
 }
@@ -138,11 +144,41 @@ func (service *Service) ResetFailureSimulation() {
 	service.failureSimulator.Reset()
 }
 
+// SetFailureSimulationEnabled switches failure injection on or off for the whole service.
+// Disabling it keeps the rules and counters, so enabling it again resumes the learning session.
+func (service *Service) SetFailureSimulationEnabled(enabled bool) {
+	service.failureSimulator.SetEnabled(enabled)
+}
+
+// ErrFailureRuleNotFound is returned when a rule name matches none of the learning rules
+var ErrFailureRuleNotFound = errors.New("failure rule not found")
+
+// SetFailureRuleEnabled switches a single learning rule on or off, e.g. so a scripted scenario turns on
+// exactly the failures it demonstrates. ResetFailureSimulation restores the hardcoded defaults.
+func (service *Service) SetFailureRuleEnabled(name string, enabled bool) error {
+	for _, rule := range allFailureRules() {
+		if rule.Name == name {
+			service.failureSimulator.SetRuleEnabled(name, enabled)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrFailureRuleNotFound, name)
+}
+
+// allFailureRules returns the regular transaction rules followed by the enhanced compensation rules
+func allFailureRules() []failure.Rule {
+	rules := make([]failure.Rule, 0, len(learningFailureRules)+len(enhancedCompensationRules))
+	rules = append(rules, learningFailureRules...)
+
+	return append(rules, enhancedCompensationRules...)
+}
+
 // countEnabledRules counts how many rules are currently enabled
-func countEnabledRules() int {
+func (service *Service) countEnabledRules() int {
 	count := 0
 	for _, rule := range learningFailureRules {
-		if rule.Enabled {
+		if service.failureSimulator.RuleEnabled(rule) {
 			count++
 		}
 	}
@@ -157,7 +193,7 @@ func (service *Service) GetLearningScenarios() []map[string]any {
 	for _, rule := range learningFailureRules {
 		scenario := map[string]any{
 			"name":        rule.Name,
-			"enabled":     rule.Enabled,
+			"enabled":     service.failureSimulator.RuleEnabled(rule),
 			"type":        rule.Type,
 			"probability": rule.Probability,
 			"operations":  rule.Operations,
@@ -324,7 +360,7 @@ func (service *Service) GetEnhancedCompensationScenarios() []map[string]any {
 	for _, rule := range enhancedCompensationRules {
 		scenario := map[string]any{
 			"name":        rule.Name,
-			"enabled":     rule.Enabled,
+			"enabled":     service.failureSimulator.RuleEnabled(rule),
 			"type":        rule.Type,
 			"probability": rule.Probability,
 			"operations":  rule.Operations,
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// ErrIdempotencyConflict is returned when an idempotency key is reused for a request that differs from the one that
+// first used it, e.g. with another amount or account. The existing transaction is left untouched.
+var ErrIdempotencyConflict = errors.New("idempotency conflict")
+
+// DefaultIdempotencyRetention is how long an idempotency key stays registered when no retention is configured
+const DefaultIdempotencyRetention = 7 * 24 * time.Hour
+
+// defaultIdempotencyCleanupBatchSize is how many expired keys PurgeExpiredIdempotencyKeys deletes per statement
+const defaultIdempotencyCleanupBatchSize = 1000
+
+// payloadHashMetadataKey is the metadata key under which a transaction keeps the hash of the request that created it
+const payloadHashMetadataKey = "payload_hash"
+
+// Transaction types of an idempotency payload. Compensations are credits marked as such in their metadata.
+const (
+	idempotencyTypeDebit        = "debit"
+	idempotencyTypeCredit       = "credit"
+	idempotencyTypeCompensation = "compensation"
+)
+
+// idempotencyScopeInternalTransfer is the registry scope of fast-path transfers, keyed by their transfer ID.
+// The other scopes are the transaction types above.
+const idempotencyScopeInternalTransfer = "internal_transfer"
+
+// IdempotencyDiff is a field that differs between the transaction an idempotency key created and a new request
+type IdempotencyDiff struct {
+	Field     string `json:"field"`
+	Existing  string `json:"existing"`
+	Requested string `json:"requested"`
+}
+
+// IdempotencyConflictError reports which fields differ when an idempotency key is reused. It wraps ErrIdempotencyConflict.
+type IdempotencyConflictError struct {
+	IdempotencyKey string            `json:"idempotency_key"`
+	TransactionID  uuid.UUID         `json:"transaction_id"`
+	Diffs          []IdempotencyDiff `json:"diffs"`
+}
+
+func (e *IdempotencyConflictError) Error() string {
+	summary := make([]string, 0, len(e.Diffs))
+	for _, diff := range e.Diffs {
+		summary = append(summary, fmt.Sprintf("%s %q, requested %q", diff.Field, diff.Existing, diff.Requested))
+	}
+
+	return fmt.Sprintf("%s: key %q was used by transaction %s with %s", ErrIdempotencyConflict, e.IdempotencyKey, e.TransactionID, strings.Join(summary, "; "))
+}
+
+func (e *IdempotencyConflictError) Unwrap() error {
+	return ErrIdempotencyConflict
+}
+
+// idempotencyPayload is the part of a debit, credit or compensation request that has to be repeated when its
+// idempotency key is reused. Fields are kept as the request gives them, so the account is either an ID or a number.
+type idempotencyPayload struct {
+	TransactionType       string          `json:"transaction_type"`
+	AccountID             string          `json:"account_id,omitempty"`
+	AccountNumber         string          `json:"account_number,omitempty"`
+	Amount                decimal.Decimal `json:"amount"`
+	Currency              string          `json:"currency"`
+	ReferenceID           string          `json:"reference_id,omitempty"`
+	OriginalTransactionID string          `json:"original_transaction_id,omitempty"` // Compensations only
+	ToAccountID           string          `json:"to_account_id,omitempty"`           // Internal transfers only
+}
+
+func newIdempotencyPayload(transactionType string, accountID *uuid.UUID, accountNumber *string, amount decimal.Decimal, currency string, referenceID *string) idempotencyPayload {
+	payload := idempotencyPayload{
+		TransactionType: transactionType,
+		Amount:          amount,
+		Currency:        currency,
+	}
+
+	if accountID != nil {
+		payload.AccountID = accountID.String()
+	}
+	if accountNumber != nil {
+		payload.AccountNumber = *accountNumber
+	}
+	if referenceID != nil {
+		payload.ReferenceID = *referenceID
+	}
+
+	return payload
+}
+
+// hash returns a hex SHA-256 of the payload. Amounts are hashed without trailing zeros, so "100.50" and "100.5" match.
+func (payload idempotencyPayload) hash() string {
+	data, _ := json.Marshal(payload)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// withPayloadHash returns a copy of metadata that records the hash of the request creating the transaction
+func withPayloadHash(metadata map[string]any, payload idempotencyPayload) map[string]any {
+	metadata = maps.Clone(metadata)
+	if metadata == nil {
+		metadata = make(map[string]any)
+	}
+	metadata[payloadHashMetadataKey] = payload.hash()
+
+	return metadata
+}
+
+// checkIdempotencyPayload returns an IdempotencyConflictError when the transaction an idempotency key created does not
+// match the requested payload. Matching hashes are accepted at once; otherwise the fields the transaction keeps are
+// compared, which also covers transactions recorded before payload hashes were stored.
+func (service *Service) checkIdempotencyPayload(ctx context.Context, transaction sqlc.GetTransactionByIdempotencyKeyRow, requested idempotencyPayload) error {
+	var metadata map[string]any
+	if len(transaction.Metadata) > 0 {
+		_ = json.Unmarshal(transaction.Metadata, &metadata)
+	}
+
+	if hash, ok := metadata[payloadHashMetadataKey].(string); ok && hash == requested.hash() {
+		return nil
+	}
+
+	amount, err := service.pgNumericToDecimal(transaction.Amount)
+	if err != nil {
+		return fmt.Errorf("failed to convert amount: %w", err)
+	}
+
+	existing := idempotencyPayload{
+		TransactionType: string(transaction.TransactionType),
+		AccountID:       uuid.UUID(transaction.AccountID.Bytes).String(),
+		Amount:          amount,
+		Currency:        string(transaction.Currency),
+		ReferenceID:     transaction.ReferenceID.String,
+	}
+	if compensation, _ := metadata["compensation"].(bool); compensation {
+		existing.TransactionType = idempotencyTypeCompensation
+	}
+	if originalID, ok := metadata["original_transaction_id"].(string); ok {
+		existing.OriginalTransactionID = originalID
+	}
+
+	// Requests naming the account by number are compared against the number of the existing account
+	if requested.AccountNumber != "" {
+		account, err := service.store.GetAccountByID(ctx, transaction.AccountID)
+		if err != nil {
+			return fmt.Errorf("failed to get account of existing transaction: %w", err)
+		}
+		existing.AccountNumber = account.AccountNumber
+	}
+
+	diffs := idempotencyDiffs(existing, requested)
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	return &IdempotencyConflictError{
+		IdempotencyKey: transaction.IdempotencyKey.String,
+		TransactionID:  uuid.UUID(transaction.ID.Bytes),
+		Diffs:          diffs,
+	}
+}
+
+// idempotencyDiffs lists the fields of requested that differ from existing. The account, reference and original
+// transaction are only compared when the request sets them.
+func idempotencyDiffs(existing, requested idempotencyPayload) []IdempotencyDiff {
+	var diffs []IdempotencyDiff
+
+	compare := func(field, existing, requested string) {
+		if existing != requested {
+			diffs = append(diffs, IdempotencyDiff{Field: field, Existing: existing, Requested: requested})
+		}
+	}
+
+	compare("transaction_type", existing.TransactionType, requested.TransactionType)
+	if requested.AccountID != "" {
+		compare("account_id", existing.AccountID, requested.AccountID)
+	}
+	if requested.AccountNumber != "" {
+		compare("account_number", existing.AccountNumber, requested.AccountNumber)
+	}
+	if !existing.Amount.Equal(requested.Amount) {
+		diffs = append(diffs, IdempotencyDiff{Field: "amount", Existing: existing.Amount.String(), Requested: requested.Amount.String()})
+	}
+	compare("currency", existing.Currency, requested.Currency)
+	if requested.ReferenceID != "" {
+		compare("reference_id", existing.ReferenceID, requested.ReferenceID)
+	}
+	if requested.OriginalTransactionID != "" {
+		compare("original_transaction_id", existing.OriginalTransactionID, requested.OriginalTransactionID)
+	}
+	if requested.ToAccountID != "" {
+		compare("to_account_id", existing.ToAccountID, requested.ToAccountID)
+	}
+
+	return diffs
+}
+
+// idempotencySnapshot holds the fields of a registered response that a reused key is compared against. It decodes
+// the results of debits, credits, compensations and internal transfers alike.
+type idempotencySnapshot struct {
+	TransactionID         uuid.UUID       `json:"transaction_id"`
+	DebitTransactionID    uuid.UUID       `json:"debit_transaction_id"`
+	AccountID             string          `json:"account_id"`
+	AccountNumber         string          `json:"account_number"`
+	FromAccountID         string          `json:"from_account_id"`
+	ToAccountID           string          `json:"to_account_id"`
+	Amount                decimal.Decimal `json:"amount"`
+	Currency              string          `json:"currency"`
+	ReferenceID           *string         `json:"reference_id"`
+	OriginalTransactionID *uuid.UUID      `json:"original_transaction_id"`
+}
+
+// payload returns the payload of the request that produced the snapshot
+func (snapshot idempotencySnapshot) payload(scope string) idempotencyPayload {
+	payload := idempotencyPayload{
+		TransactionType: scope,
+		AccountID:       snapshot.AccountID,
+		AccountNumber:   snapshot.AccountNumber,
+		Amount:          snapshot.Amount,
+		Currency:        snapshot.Currency,
+	}
+	if scope == idempotencyScopeInternalTransfer {
+		payload.AccountID = snapshot.FromAccountID
+		payload.ToAccountID = snapshot.ToAccountID
+	}
+	if snapshot.ReferenceID != nil {
+		payload.ReferenceID = *snapshot.ReferenceID
+	}
+	if snapshot.OriginalTransactionID != nil {
+		payload.OriginalTransactionID = snapshot.OriginalTransactionID.String()
+	}
+
+	return payload
+}
+
+// transactionID returns the transaction the snapshot reports, the debit leg for internal transfers
+func (snapshot idempotencySnapshot) transactionID() uuid.UUID {
+	if snapshot.TransactionID != uuid.Nil {
+		return snapshot.TransactionID
+	}
+
+	return snapshot.DebitTransactionID
+}
+
+// SetIdempotencyRetention sets how long an idempotency key stays registered; zero keeps the default
+func (service *Service) SetIdempotencyRetention(retention time.Duration) {
+	service.idempotencyRetention = retention
+}
+
+func (service *Service) idempotencyKeyRetention() time.Duration {
+	if service.idempotencyRetention <= 0 {
+		return DefaultIdempotencyRetention
+	}
+
+	return service.idempotencyRetention
+}
+
+// lookupIdempotencyKey decodes the response registered for key into result and reports whether there was one.
+// Registrations are compared by hash first and by the fields of their snapshot otherwise, so a request naming the
+// account by number still matches one that named it by ID. A key registered for a different payload, or by another
+// operation, returns an IdempotencyConflictError.
+func (service *Service) lookupIdempotencyKey(ctx context.Context, key string, requested idempotencyPayload, result any) (bool, error) {
+	registered, err := service.store.GetIdempotencyKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	if registered.RequestHash != requested.hash() {
+		var snapshot idempotencySnapshot
+		if err := json.Unmarshal(registered.ResponseSnapshot, &snapshot); err != nil {
+			return false, fmt.Errorf("failed to decode response snapshot: %w", err)
+		}
+
+		if diffs := idempotencyDiffs(snapshot.payload(registered.Scope), requested); len(diffs) > 0 {
+			return false, &IdempotencyConflictError{
+				IdempotencyKey: key,
+				TransactionID:  snapshot.transactionID(),
+				Diffs:          diffs,
+			}
+		}
+	}
+
+	if err := json.Unmarshal(registered.ResponseSnapshot, result); err != nil {
+		return false, fmt.Errorf("failed to decode response snapshot: %w", err)
+	}
+
+	return true, nil
+}
+
+// registerIdempotencyKey records result as the response of the request that first used key, using the queries of
+// the transaction that produced it so both are committed together. Nothing is recorded without a key.
+func (service *Service) registerIdempotencyKey(ctx context.Context, q sqlc.Querier, key *string, scope string, payload idempotencyPayload, result any) error {
+	if key == nil {
+		return nil
+	}
+
+	snapshot, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal response snapshot: %w", err)
+	}
+
+	_, err = q.CreateIdempotencyKey(ctx, sqlc.CreateIdempotencyKeyParams{
+		IdempotencyKey:   *key,
+		Scope:            scope,
+		RequestHash:      payload.hash(),
+		ResponseSnapshot: snapshot,
+		ExpiresAt:        pgtype.Timestamptz{Time: time.Now().Add(service.idempotencyKeyRetention()), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: key %q is already registered", ErrIdempotencyConflict, *key)
+		}
+
+		return fmt.Errorf("failed to register idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// isIdempotencyKeyViolation reports whether err comes from creating a transaction with an idempotency key that is
+// already taken. It happens when a concurrent request registered the key first, or when the registration of a key
+// expired while the transaction it created is kept.
+func isIdempotencyKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "transactions_idempotency_key_key"
+}
+
+// PurgeExpiredIdempotencyKeys deletes expired idempotency keys in batches and returns how many were deleted.
+// Expired keys are already ignored by lookups; purging only keeps the registry small.
+func (service *Service) PurgeExpiredIdempotencyKeys(ctx context.Context, batchSize int32) (int64, error) {
+	if batchSize <= 0 {
+		batchSize = defaultIdempotencyCleanupBatchSize
+	}
+
+	var purged int64
+	for {
+		deleted, err := service.store.DeleteExpiredIdempotencyKeys(ctx, batchSize)
+		if err != nil {
+			return purged, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+		}
+
+		purged += deleted
+		if deleted < int64(batchSize) {
+			return purged, nil
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"numeric"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyPayloadHash(t *testing.T) {
+	t.Parallel()
+
+	accountID := uuid.New()
+	payload := newIdempotencyPayload(idempotencyTypeDebit, &accountID, nil, decimal.RequireFromString("100.50"), "USD", stringPtr("REF123"))
+
+	same := payload
+	same.Amount = decimal.RequireFromString("100.5")
+	assert.Equal(t, payload.hash(), same.hash())
+
+	other := payload
+	other.Amount = decimal.RequireFromString("100.51")
+	assert.NotEqual(t, payload.hash(), other.hash())
+}
+
+func TestCheckIdempotencyPayload(t *testing.T) {
+	t.Parallel()
+
+	service := createTestService()
+
+	accountID := uuid.New()
+	originalID := uuid.New()
+	requested := newIdempotencyPayload(idempotencyTypeDebit, &accountID, nil, decimal.RequireFromString("100.00"), "USD", stringPtr("REF123"))
+
+	transaction := func(amount string, metadata map[string]any) sqlc.GetTransactionByIdempotencyKeyRow {
+		data, err := json.Marshal(metadata)
+		require.NoError(t, err)
+
+		return sqlc.GetTransactionByIdempotencyKeyRow{
+			ID:              pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			AccountID:       pgtype.UUID{Bytes: accountID, Valid: true},
+			TransactionType: sqlc.CoreTransactionTypeDebit,
+			Amount:          numeric.FromDecimal(decimal.RequireFromString(amount)),
+			Currency:        sqlc.CoreCurrencyCodeUSD,
+			ReferenceID:     pgtype.Text{String: "REF123", Valid: true},
+			IdempotencyKey:  pgtype.Text{String: "transfer-1-debit", Valid: true},
+			Metadata:        data,
+		}
+	}
+
+	t.Run("matching_hash", func(t *testing.T) {
+		assert.NoError(t, service.checkIdempotencyPayload(context.Background(), transaction("100", withPayloadHash(nil, requested)), requested))
+	})
+
+	t.Run("matching_fields_without_hash", func(t *testing.T) {
+		assert.NoError(t, service.checkIdempotencyPayload(context.Background(), transaction("100", nil), requested))
+	})
+
+	t.Run("different_amount", func(t *testing.T) {
+		existing := requested
+		existing.Amount = decimal.RequireFromString("250")
+
+		err := service.checkIdempotencyPayload(context.Background(), transaction("250", withPayloadHash(nil, existing)), requested)
+
+		var conflict *IdempotencyConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.ErrorIs(t, err, ErrIdempotencyConflict)
+		assert.Equal(t, "transfer-1-debit", conflict.IdempotencyKey)
+		assert.Equal(t, []IdempotencyDiff{{Field: "amount", Existing: "250", Requested: "100"}}, conflict.Diffs)
+		assert.Contains(t, err.Error(), `amount "250", requested "100"`)
+	})
+
+	t.Run("different_account_and_type", func(t *testing.T) {
+		otherAccount := uuid.New()
+		credit := newIdempotencyPayload(idempotencyTypeCredit, &otherAccount, nil, decimal.RequireFromString("100"), "USD", nil)
+
+		err := service.checkIdempotencyPayload(context.Background(), transaction("100", nil), credit)
+
+		var conflict *IdempotencyConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, []IdempotencyDiff{
+			{Field: "transaction_type", Existing: "debit", Requested: "credit"},
+			{Field: "account_id", Existing: accountID.String(), Requested: otherAccount.String()},
+		}, conflict.Diffs)
+	})
+
+	t.Run("compensation_of_another_debit", func(t *testing.T) {
+		row := transaction("100", map[string]any{"compensation": true, "original_transaction_id": originalID.String()})
+		row.TransactionType = sqlc.CoreTransactionTypeCredit
+
+		compensation := compensationIdempotencyPayload(CompensateDebitParams{
+			AccountID:             &accountID,
+			OriginalTransactionID: &originalID,
+			Amount:                decimal.RequireFromString("100"),
+			Currency:              "USD",
+		})
+		assert.NoError(t, service.checkIdempotencyPayload(context.Background(), row, compensation))
+
+		otherOriginal := uuid.New()
+		compensation.OriginalTransactionID = otherOriginal.String()
+
+		var conflict *IdempotencyConflictError
+		require.ErrorAs(t, service.checkIdempotencyPayload(context.Background(), row, compensation), &conflict)
+		assert.Equal(t, []IdempotencyDiff{
+			{Field: "original_transaction_id", Existing: originalID.String(), Requested: otherOriginal.String()},
+		}, conflict.Diffs)
+	})
+}
+
+func TestIdempotencyKeyRegistry(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	ledger := newMemoryLedger()
+	accountID := ledger.addAccount("ACC000000001", decimal.NewFromInt(1000))
+	service := &Service{logger: logger, store: newMemoryStore(ledger)}
+
+	key := "transfer-1-debit"
+	params := DebitAccountParams{
+		AccountID:      &accountID,
+		Amount:         decimal.RequireFromString("100.50"),
+		Currency:       "USD",
+		ReferenceID:    stringPtr("REF123"),
+		IdempotencyKey: &key,
+	}
+
+	first, err := service.DebitAccount(context.Background(), params)
+	require.NoError(t, err)
+
+	registered, ok := ledger.idempotencyKeys[key]
+	require.True(t, ok)
+	assert.Equal(t, idempotencyTypeDebit, registered.Scope)
+	assert.Equal(t, debitIdempotencyPayload(params).hash(), registered.RequestHash)
+	assert.WithinDuration(t, time.Now().Add(DefaultIdempotencyRetention), registered.ExpiresAt.Time, time.Minute)
+
+	transactions := len(ledger.transactions)
+
+	t.Run("replay", func(t *testing.T) {
+		replayed, err := service.DebitAccount(context.Background(), params)
+		require.NoError(t, err)
+
+		assert.Equal(t, first.TransactionID, replayed.TransactionID)
+		assert.True(t, first.NewBalance.Equal(replayed.NewBalance))
+		assert.Len(t, ledger.transactions, transactions)
+	})
+
+	t.Run("replay_by_account_number", func(t *testing.T) {
+		byNumber := params
+		byNumber.AccountID = nil
+		byNumber.AccountNumber = stringPtr("ACC000000001")
+
+		replayed, err := service.DebitAccount(context.Background(), byNumber)
+		require.NoError(t, err)
+		assert.Equal(t, first.TransactionID, replayed.TransactionID)
+	})
+
+	t.Run("different_amount", func(t *testing.T) {
+		different := params
+		different.Amount = decimal.NewFromInt(250)
+
+		_, err := service.DebitAccount(context.Background(), different)
+
+		var conflict *IdempotencyConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, first.TransactionID, conflict.TransactionID)
+		assert.Equal(t, []IdempotencyDiff{{Field: "amount", Existing: "100.5", Requested: "250"}}, conflict.Diffs)
+	})
+
+	t.Run("credit_with_debit_key", func(t *testing.T) {
+		_, err := service.CreditAccount(context.Background(), CreditAccountParams{
+			AccountID:      &accountID,
+			Amount:         params.Amount,
+			Currency:       "USD",
+			ReferenceID:    params.ReferenceID,
+			IdempotencyKey: &key,
+		})
+
+		var conflict *IdempotencyConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, []IdempotencyDiff{{Field: "transaction_type", Existing: "debit", Requested: "credit"}}, conflict.Diffs)
+	})
+
+	t.Run("expired_registration", func(t *testing.T) {
+		// The transaction outlives its registration, so a late replay is answered from the transaction itself
+		expired := ledger.idempotencyKeys[key]
+		expired.ExpiresAt = pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}
+		ledger.idempotencyKeys[key] = expired
+
+		replayed, err := service.DebitAccount(context.Background(), params)
+		require.NoError(t, err)
+		assert.Equal(t, first.TransactionID, replayed.TransactionID)
+		assert.Len(t, ledger.transactions, transactions)
+	})
+}
+
+func TestPurgeExpiredIdempotencyKeys(t *testing.T) {
+	t.Parallel()
+
+	ledger := newMemoryLedger()
+	service := &Service{logger: logrus.New(), store: newMemoryStore(ledger)}
+
+	for key, expiresAt := range map[string]time.Time{
+		"expired-1": time.Now().Add(-time.Hour),
+		"expired-2": time.Now().Add(-time.Minute),
+		"expired-3": time.Now().Add(-time.Second),
+		"live":      time.Now().Add(time.Hour),
+	} {
+		ledger.idempotencyKeys[key] = sqlc.CoreIdempotencyKey{
+			IdempotencyKey: key,
+			ExpiresAt:      pgtype.Timestamptz{Time: expiresAt, Valid: true},
+		}
+	}
+
+	purged, err := service.PurgeExpiredIdempotencyKeys(context.Background(), 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), purged)
+	assert.Len(t, ledger.idempotencyKeys, 1)
+	assert.Contains(t, ledger.idempotencyKeys, "live")
+}
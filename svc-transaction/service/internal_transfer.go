@@ -0,0 +1,407 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-transaction/store/sqlc"
+	"svc-transaction/util/rules"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrInternalTransferRejected is returned when a fast-path transfer has invalid parameters or fails
+// a business rule (inactive account, currency mismatch, insufficient funds, the withdrawal limit of a savings account).
+// Nothing is persisted in that case.
+var ErrInternalTransferRejected = errors.New("internal transfer rejected")
+
+// ErrInternalTransferNotFound is returned when no fast-path transfer exists for the given transfer ID
+var ErrInternalTransferNotFound = errors.New("internal transfer not found")
+
+// InternalTransferParams represents the input parameters for a fast-path account-to-account transfer
+type InternalTransferParams struct {
+	TransferID    string          `json:"transfer_id"`
+	FromAccountID uuid.UUID       `json:"from_account_id"`
+	ToAccountID   uuid.UUID       `json:"to_account_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	Currency      string          `json:"currency"`
+	Description   *string         `json:"description,omitempty"`
+	RequestedBy   string          `json:"requested_by,omitempty"`
+}
+
+// InternalTransferResults represents the output of a fast-path transfer
+type InternalTransferResults struct {
+	TransferID          string          `json:"transfer_id"`
+	Status              string          `json:"status"`
+	FromAccountID       uuid.UUID       `json:"from_account_id"`
+	ToAccountID         uuid.UUID       `json:"to_account_id"`
+	Amount              decimal.Decimal `json:"amount"`
+	Currency            string          `json:"currency"`
+	Description         *string         `json:"description,omitempty"`
+	DebitTransactionID  uuid.UUID       `json:"debit_transaction_id"`
+	CreditTransactionID uuid.UUID       `json:"credit_transaction_id"`
+	CreatedAt           string          `json:"created_at"`
+	CompletedAt         *string         `json:"completed_at,omitempty"`
+	DurationMs          int64           `json:"duration_ms"`
+}
+
+// InternalTransfer moves funds between two accounts of the same currency inside a single
+// database transaction. Unlike the saga executed by flowngine there is no intermediate state:
+// either both legs are committed or neither is, so no compensation is ever required.
+func (service *Service) InternalTransfer(ctx context.Context, params InternalTransferParams) (*InternalTransferResults, error) {
+	const op = "service.Service.InternalTransfer"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	startedAt := time.Now()
+
+	// Step 1: Validate input parameters
+	if err := service.validateInternalTransferParams(params); err != nil {
+		err = fmt.Errorf("%w: invalid parameters: %w", ErrInternalTransferRejected, err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	// Step 2: Return the existing transfer if this transfer ID was already processed
+	payload := internalTransferIdempotencyPayload(params)
+
+	var existing InternalTransferResults
+	found, err := service.lookupIdempotencyKey(ctx, params.TransferID, payload, &existing)
+	if err != nil {
+		err = fmt.Errorf("failed to check existing transfer: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+	if found {
+		logger.WithField("transfer_id", existing.TransferID).Info("Returning existing internal transfer")
+
+		return &existing, nil
+	}
+
+	// Step 3: Debit and credit within a single database transaction
+	var result *InternalTransferResults
+	err = service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		transfer, err := service.executeInternalTransfer(ctx, q, params)
+		if err != nil {
+			return err
+		}
+
+		result, err = service.buildInternalTransferResult(transfer)
+		if err != nil {
+			return fmt.Errorf("failed to build result: %w", err)
+		}
+
+		return service.registerIdempotencyKey(ctx, q, &params.TransferID, idempotencyScopeInternalTransfer, payload, result)
+	})
+	if err != nil && isIdempotencyKeyViolation(err) {
+		// The transfer was made by a concurrent request, or its registration expired
+		logger.WithField("transfer_id", params.TransferID).Info("Returning existing internal transfer")
+
+		return service.GetInternalTransfer(ctx, params.TransferID)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to execute internal transfer: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	result.DurationMs = time.Since(startedAt).Milliseconds()
+
+	logger.WithField("results", fmt.Sprintf("%+v", result)).Info("⚡ Internal transfer completed on fast path")
+
+	// Step 4: Let conditional transfers watching the destination know about the credit
+	service.publishCreditEvent(ctx, result.CreditTransactionID, result.ToAccountID, "", result.Amount, result.Currency)
+
+	return result, nil
+}
+
+// internalTransferIdempotencyPayload returns what a transfer reusing a transfer ID has to repeat
+func internalTransferIdempotencyPayload(params InternalTransferParams) idempotencyPayload {
+	payload := newIdempotencyPayload(idempotencyScopeInternalTransfer, &params.FromAccountID, nil, params.Amount, params.Currency, nil)
+	payload.ToAccountID = params.ToAccountID.String()
+
+	return payload
+}
+
+// GetInternalTransfer retrieves a fast-path transfer by its transfer ID or transfer reference
+func (service *Service) GetInternalTransfer(ctx context.Context, transferID string) (*InternalTransferResults, error) {
+	transferID, err := service.resolveTransferID(ctx, transferID)
+	if err != nil {
+		if errors.Is(err, ErrTransferReferenceNotFound) {
+			return nil, ErrInternalTransferNotFound
+		}
+
+		return nil, err
+	}
+
+	transfer, err := service.store.GetTransferByTransferID(ctx, transferID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrInternalTransferNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get transfer: %w", err)
+	}
+
+	return service.buildInternalTransferResult(transfer)
+}
+
+// executeInternalTransfer performs both legs of the transfer using the transaction-scoped queries
+func (service *Service) executeInternalTransfer(ctx context.Context, q sqlc.Querier, params InternalTransferParams) (sqlc.CoreTransfer, error) {
+	// Lock both accounts in a stable order so concurrent opposite-direction transfers cannot deadlock
+	accounts := make(map[uuid.UUID]sqlc.CoreAccount, 2)
+	for _, accountID := range orderAccountLocks(params.FromAccountID, params.ToAccountID) {
+		if err := service.acquireAccountLock(ctx, q, pgtype.UUID{Bytes: accountID, Valid: true}); err != nil {
+			return sqlc.CoreTransfer{}, err
+		}
+
+		account, err := q.GetAccountForUpdate(ctx, pgtype.UUID{Bytes: accountID, Valid: true})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return sqlc.CoreTransfer{}, fmt.Errorf("%w: account %s not found", ErrInternalTransferRejected, accountID)
+			}
+			return sqlc.CoreTransfer{}, fmt.Errorf("failed to lock account %s: %w", accountID, err)
+		}
+		accounts[accountID] = account
+	}
+
+	fromAccount := accounts[params.FromAccountID]
+	toAccount := accounts[params.ToAccountID]
+
+	fromBalance, err := service.pgNumericToDecimal(fromAccount.Balance)
+	if err != nil {
+		return sqlc.CoreTransfer{}, fmt.Errorf("failed to convert source balance: %w", err)
+	}
+	toBalance, err := service.pgNumericToDecimal(toAccount.Balance)
+	if err != nil {
+		return sqlc.CoreTransfer{}, fmt.Errorf("failed to convert destination balance: %w", err)
+	}
+
+	if err := checkInternalTransferRules(fromAccount, toAccount, fromBalance, params); err != nil {
+		return sqlc.CoreTransfer{}, err
+	}
+
+	if err := service.accountTypeFailure(ctx, q, fromAccount); err != nil {
+		return sqlc.CoreTransfer{}, fmt.Errorf("%w: %w", ErrInternalTransferRejected, err)
+	}
+
+	remaining := fromBalance.Sub(params.Amount)
+	outcomes := service.evaluateBusinessRules(ctx, rules.Facts{Currency: params.Currency, Amount: &params.Amount, Balance: &remaining})
+	if err := businessRuleFailure(outcomes); err != nil {
+		return sqlc.CoreTransfer{}, fmt.Errorf("%w: %w", ErrInternalTransferRejected, err)
+	}
+
+	return service.postTransfer(ctx, q, params, fromBalance, toBalance, "fast_path", fastPathCreatedBy)
+}
+
+// postTransfer writes the debit and credit legs of a transfer between two locked accounts and records it as completed.
+// The caller is responsible for locking both accounts and checking the business rules first.
+func (service *Service) postTransfer(
+	ctx context.Context,
+	q sqlc.Querier,
+	params InternalTransferParams,
+	fromBalance decimal.Decimal,
+	toBalance decimal.Decimal,
+	executionPath string,
+	createdBy string,
+) (sqlc.CoreTransfer, error) {
+	fromID := pgtype.UUID{Bytes: params.FromAccountID, Valid: true}
+	toID := pgtype.UUID{Bytes: params.ToAccountID, Valid: true}
+
+	pgAmount, err := service.decimalToPgNumeric(params.Amount)
+	if err != nil {
+		return sqlc.CoreTransfer{}, fmt.Errorf("failed to convert amount: %w", err)
+	}
+
+	currency := service.mapCurrencyToEnum(params.Currency)
+	reference := pgtype.Text{String: params.TransferID, Valid: true}
+
+	var description pgtype.Text
+	if params.Description != nil {
+		description = pgtype.Text{String: *params.Description, Valid: true}
+	}
+
+	// Debit leg
+	debit, err := q.CreateTransaction(ctx, sqlc.CreateTransactionParams{
+		AccountID:       fromID,
+		TransactionType: sqlc.CoreTransactionTypeDebit,
+		Amount:          pgAmount,
+		Currency:        currency,
+		Description:     description,
+		ReferenceID:     reference,
+		IdempotencyKey:  pgtype.Text{String: fmt.Sprintf("%s-debit", params.TransferID), Valid: true},
+	})
+	if err != nil {
+		return sqlc.CoreTransfer{}, fmt.Errorf("failed to create debit transaction: %w", err)
+	}
+
+	if _, err := service.applyBalanceChange(ctx, q, fromID, debit.ID, fromBalance, params.Amount.Neg(), "debit", createdBy); err != nil {
+		return sqlc.CoreTransfer{}, err
+	}
+
+	// Credit leg
+	credit, err := q.CreateTransaction(ctx, sqlc.CreateTransactionParams{
+		AccountID:       toID,
+		TransactionType: sqlc.CoreTransactionTypeCredit,
+		Amount:          pgAmount,
+		Currency:        currency,
+		Description:     description,
+		ReferenceID:     reference,
+		IdempotencyKey:  pgtype.Text{String: fmt.Sprintf("%s-credit", params.TransferID), Valid: true},
+	})
+	if err != nil {
+		return sqlc.CoreTransfer{}, fmt.Errorf("failed to create credit transaction: %w", err)
+	}
+
+	if _, err := service.applyBalanceChange(ctx, q, toID, credit.ID, toBalance, params.Amount, "credit", createdBy); err != nil {
+		return sqlc.CoreTransfer{}, err
+	}
+
+	metadata, err := json.Marshal(map[string]any{
+		"execution_path": executionPath,
+		"requested_by":   params.RequestedBy,
+	})
+	if err != nil {
+		return sqlc.CoreTransfer{}, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	transfer, err := q.CreateTransfer(ctx, sqlc.CreateTransferParams{
+		TransferID:          params.TransferID,
+		FromAccountID:       fromID,
+		ToAccountID:         toID,
+		Amount:              pgAmount,
+		Currency:            currency,
+		Description:         description,
+		Status:              sqlc.CoreTransferStatusCompleted,
+		DebitTransactionID:  debit.ID,
+		CreditTransactionID: credit.ID,
+		Metadata:            metadata,
+	})
+	if err != nil {
+		return sqlc.CoreTransfer{}, fmt.Errorf("failed to create transfer record: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// buildInternalTransferResult converts a transfer record to the service result format
+func (service *Service) buildInternalTransferResult(transfer sqlc.CoreTransfer) (*InternalTransferResults, error) {
+	amount, err := service.pgNumericToDecimal(transfer.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert amount: %w", err)
+	}
+
+	result := &InternalTransferResults{
+		TransferID:          transfer.TransferID,
+		Status:              string(transfer.Status),
+		FromAccountID:       uuid.UUID(transfer.FromAccountID.Bytes),
+		ToAccountID:         uuid.UUID(transfer.ToAccountID.Bytes),
+		Amount:              amount,
+		Currency:            string(transfer.Currency),
+		DebitTransactionID:  uuid.UUID(transfer.DebitTransactionID.Bytes),
+		CreditTransactionID: uuid.UUID(transfer.CreditTransactionID.Bytes),
+		CreatedAt:           transfer.CreatedAt.Time.Format(time.RFC3339),
+	}
+
+	if transfer.Description.Valid {
+		result.Description = &transfer.Description.String
+	}
+
+	if transfer.CompletedAt.Valid {
+		completedAt := transfer.CompletedAt.Time.Format(time.RFC3339)
+		result.CompletedAt = &completedAt
+	} else if transfer.Status == sqlc.CoreTransferStatusCompleted {
+		// The completion trigger only fires on UPDATE, so inserted-as-completed rows use created_at
+		result.CompletedAt = &result.CreatedAt
+	}
+
+	return result, nil
+}
+
+// validateInternalTransferParams validates the input parameters for a fast-path transfer
+func (service *Service) validateInternalTransferParams(params InternalTransferParams) error {
+	if params.TransferID == "" {
+		return fmt.Errorf("transfer_id is required")
+	}
+
+	if params.FromAccountID == uuid.Nil {
+		return fmt.Errorf("from_account_id is required")
+	}
+
+	if params.ToAccountID == uuid.Nil {
+		return fmt.Errorf("to_account_id is required")
+	}
+
+	if params.FromAccountID == params.ToAccountID {
+		return fmt.Errorf("from_account_id and to_account_id cannot be the same")
+	}
+
+	if err := validateAmountSign(AmountOperationInternalTransfer, "amount", params.Amount); err != nil {
+		return err
+	}
+
+	if len(params.Currency) != 3 {
+		return fmt.Errorf("currency must be a 3-letter code")
+	}
+
+	if err := validateAmountScale(params.Amount, params.Currency); err != nil {
+		return err
+	}
+
+	if err := service.checkAmountPrecision("amount", params.Amount); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// checkInternalTransferRules applies the business rules that would otherwise be enforced by the saga activities
+func checkInternalTransferRules(from, to sqlc.CoreAccount, fromBalance decimal.Decimal, params InternalTransferParams) error {
+	if from.Status != sqlc.CoreAccountStatusActive {
+		return fmt.Errorf("%w: source account is not active (status: %s)", ErrInternalTransferRejected, from.Status)
+	}
+
+	if to.Status != sqlc.CoreAccountStatusActive {
+		return fmt.Errorf("%w: destination account is not active (status: %s)", ErrInternalTransferRejected, to.Status)
+	}
+
+	if string(from.Currency) != params.Currency || string(to.Currency) != params.Currency {
+		return fmt.Errorf("%w: fast path requires both accounts in %s (source: %s, destination: %s)",
+			ErrInternalTransferRejected, params.Currency, from.Currency, to.Currency)
+	}
+
+	if fromBalance.LessThan(params.Amount) {
+		return fmt.Errorf("%w: insufficient funds (balance: %s, required: %s)",
+			ErrInternalTransferRejected, fromBalance.String(), params.Amount.String())
+	}
+
+	return nil
+}
+
+// orderAccountLocks returns the two account IDs in a deterministic lock order
+func orderAccountLocks(a, b uuid.UUID) []uuid.UUID {
+	if bytes.Compare(a[:], b[:]) <= 0 {
+		return []uuid.UUID{a, b}
+	}
+
+	return []uuid.UUID{b, a}
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestValidateInternalTransferParams(t *testing.T) {
+	t.Parallel()
+
+	service := createTestService()
+
+	fromID := uuid.New()
+	toID := uuid.New()
+
+	validParams := func() InternalTransferParams {
+		return InternalTransferParams{
+			TransferID:    uuid.New().String(),
+			FromAccountID: fromID,
+			ToAccountID:   toID,
+			Amount:        decimal.NewFromFloat(25.50),
+			Currency:      "USD",
+		}
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(p *InternalTransferParams)
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:        "Valid params",
+			modify:      func(p *InternalTransferParams) {},
+			expectError: false,
+		},
+		{
+			name:        "Missing transfer ID",
+			modify:      func(p *InternalTransferParams) { p.TransferID = "" },
+			expectError: true,
+			errorMsg:    "transfer_id is required",
+		},
+		{
+			name:        "Missing source account",
+			modify:      func(p *InternalTransferParams) { p.FromAccountID = uuid.Nil },
+			expectError: true,
+			errorMsg:    "from_account_id is required",
+		},
+		{
+			name:        "Missing destination account",
+			modify:      func(p *InternalTransferParams) { p.ToAccountID = uuid.Nil },
+			expectError: true,
+			errorMsg:    "to_account_id is required",
+		},
+		{
+			name:        "Same source and destination",
+			modify:      func(p *InternalTransferParams) { p.ToAccountID = p.FromAccountID },
+			expectError: true,
+			errorMsg:    "from_account_id and to_account_id cannot be the same",
+		},
+		{
+			name:        "Zero amount",
+			modify:      func(p *InternalTransferParams) { p.Amount = decimal.Zero },
+			expectError: true,
+			errorMsg:    "amount cannot be zero",
+		},
+		{
+			name:        "Negative amount",
+			modify:      func(p *InternalTransferParams) { p.Amount = decimal.NewFromInt(-5) },
+			expectError: true,
+			errorMsg:    "amount cannot be negative",
+		},
+		{
+			name:        "Invalid currency",
+			modify:      func(p *InternalTransferParams) { p.Currency = "US" },
+			expectError: true,
+			errorMsg:    "currency must be a 3-letter code",
+		},
+		{
+			name: "Fractional yen",
+			modify: func(p *InternalTransferParams) {
+				p.Amount = decimal.RequireFromString("10.50")
+				p.Currency = "JPY"
+			},
+			expectError: true,
+			errorMsg:    "amount 10.5 exceeds 0 decimal places allowed for JPY",
+		},
+		{
+			name: "Whole yen",
+			modify: func(p *InternalTransferParams) {
+				p.Amount = decimal.NewFromInt(1050)
+				p.Currency = "JPY"
+			},
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params := validParams()
+			tt.modify(&params)
+
+			err := service.validateInternalTransferParams(params)
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("validateInternalTransferParams() expected error but got none")
+					return
+				}
+				if tt.errorMsg != "" && err.Error() != tt.errorMsg {
+					t.Errorf("validateInternalTransferParams() error = %v, want %v", err.Error(), tt.errorMsg)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("validateInternalTransferParams() error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckInternalTransferRules(t *testing.T) {
+	t.Parallel()
+
+	activeUSD := sqlc.CoreAccount{Status: sqlc.CoreAccountStatusActive, Currency: sqlc.CoreCurrencyCodeUSD}
+	activeEUR := sqlc.CoreAccount{Status: sqlc.CoreAccountStatusActive, Currency: sqlc.CoreCurrencyCodeEUR}
+	suspendedUSD := sqlc.CoreAccount{Status: sqlc.CoreAccountStatusSuspended, Currency: sqlc.CoreCurrencyCodeUSD}
+
+	params := InternalTransferParams{
+		Amount:   decimal.NewFromInt(100),
+		Currency: "USD",
+	}
+
+	tests := []struct {
+		name        string
+		from        sqlc.CoreAccount
+		to          sqlc.CoreAccount
+		balance     decimal.Decimal
+		expectError bool
+	}{
+		{
+			name:    "Same currency with sufficient funds",
+			from:    activeUSD,
+			to:      activeUSD,
+			balance: decimal.NewFromInt(100),
+		},
+		{
+			name:        "Insufficient funds",
+			from:        activeUSD,
+			to:          activeUSD,
+			balance:     decimal.NewFromFloat(99.99),
+			expectError: true,
+		},
+		{
+			name:        "Cross-currency destination",
+			from:        activeUSD,
+			to:          activeEUR,
+			balance:     decimal.NewFromInt(1000),
+			expectError: true,
+		},
+		{
+			name:        "Suspended source account",
+			from:        suspendedUSD,
+			to:          activeUSD,
+			balance:     decimal.NewFromInt(1000),
+			expectError: true,
+		},
+		{
+			name:        "Suspended destination account",
+			from:        activeUSD,
+			to:          suspendedUSD,
+			balance:     decimal.NewFromInt(1000),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkInternalTransferRules(tt.from, tt.to, tt.balance, params)
+
+			if tt.expectError {
+				if !errors.Is(err, ErrInternalTransferRejected) {
+					t.Errorf("checkInternalTransferRules() error = %v, want ErrInternalTransferRejected", err)
+				}
+			} else if err != nil {
+				t.Errorf("checkInternalTransferRules() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestOrderAccountLocks(t *testing.T) {
+	t.Parallel()
+
+	a := uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	b := uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+
+	first := orderAccountLocks(a, b)
+	second := orderAccountLocks(b, a)
+
+	if first[0] != a || first[1] != b {
+		t.Errorf("orderAccountLocks(a, b) = %v, want [%v %v]", first, a, b)
+	}
+	if second[0] != first[0] || second[1] != first[1] {
+		t.Errorf("orderAccountLocks() is not symmetric: %v vs %v", first, second)
+	}
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-transaction/store/sqlc"
+	"svc-transaction/util/lockwait"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// Values recorded in core.account_balance_history.created_by for each execution path
+const (
+	sagaCreatedBy           = "transaction_service"
+	fastPathCreatedBy       = "transaction_service_fast_path"
+	accountClosureCreatedBy = "transaction_service_account_closure"
+)
+
+// ErrInsufficientFunds is returned when the locked account balance no longer covers a debit,
+// e.g. because a concurrent debit committed after validation
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrAccountBlocked is returned when the account status does not allow the operation, e.g. a frozen or closed account
+var ErrAccountBlocked = errors.New("account blocked")
+
+// ErrInvalidCurrency is returned when the currency of an operation does not match the account currency
+var ErrInvalidCurrency = errors.New("invalid currency")
+
+// ErrCompensationExceedsOriginal is returned when a compensation would credit back more than was debited
+var ErrCompensationExceedsOriginal = errors.New("compensation exceeds original debit")
+
+// accountLookupError maps a missing account row to ErrAccountNotFound, so it can be told apart from a database failure
+func accountLookupError(err error, account string) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ErrAccountNotFound, account)
+	}
+
+	return fmt.Errorf("failed to get account %s: %w", account, err)
+}
+
+// slowAccountLockWait is the account lock wait above which the wait is logged
+const slowAccountLockWait = 500 * time.Millisecond
+
+// acquireAccountLock takes the transaction-scoped advisory lock of the account, so every mutation of the account
+// serializes on the same key no matter which service instance or workflow runs it. The wait is recorded in lockwait.
+// It must run inside a database transaction; the lock is released when the transaction ends.
+func (service *Service) acquireAccountLock(ctx context.Context, q sqlc.Querier, accountID pgtype.UUID) error {
+	startedAt := time.Now()
+
+	if err := q.AcquireAccountLock(ctx, uuid.UUID(accountID.Bytes).String()); err != nil {
+		lockwait.Fail()
+
+		return fmt.Errorf("failed to acquire account lock: %w", err)
+	}
+
+	wait := time.Since(startedAt)
+	lockwait.Observe(wait)
+
+	if wait >= slowAccountLockWait {
+		service.logger.WithFields(logrus.Fields{
+			"account_id": uuid.UUID(accountID.Bytes).String(),
+			"wait":       wait.String(),
+		}).Warn("Slow account lock acquisition")
+	}
+
+	return nil
+}
+
+// lockAccount takes the account's advisory lock, then locks the account row for the rest of the database
+// transaction and returns its balance
+func (service *Service) lockAccount(ctx context.Context, q sqlc.Querier, accountID pgtype.UUID) (sqlc.CoreAccount, decimal.Decimal, error) {
+	if err := service.acquireAccountLock(ctx, q, accountID); err != nil {
+		return sqlc.CoreAccount{}, decimal.Zero, err
+	}
+
+	account, err := q.GetAccountForUpdate(ctx, accountID)
+	if err != nil {
+		return sqlc.CoreAccount{}, decimal.Zero, fmt.Errorf("failed to lock account: %w", err)
+	}
+
+	balance, err := service.pgNumericToDecimal(account.Balance)
+	if err != nil {
+		return sqlc.CoreAccount{}, decimal.Zero, fmt.Errorf("failed to convert account balance: %w", err)
+	}
+
+	return account, balance, nil
+}
+
+// applyBalanceChange updates the account balance, writes the balance history and completes the transaction.
+// It must run inside a database transaction after the account has been locked.
+func (service *Service) applyBalanceChange(
+	ctx context.Context,
+	q sqlc.Querier,
+	accountID pgtype.UUID,
+	transactionID pgtype.UUID,
+	oldBalance decimal.Decimal,
+	change decimal.Decimal,
+	operation string,
+	createdBy string,
+) (sqlc.CompleteTransactionRow, error) {
+	pgChange, err := service.decimalToPgNumeric(change)
+	if err != nil {
+		return sqlc.CompleteTransactionRow{}, fmt.Errorf("failed to convert balance change: %w", err)
+	}
+
+	updated, err := q.AdjustAccountBalance(ctx, sqlc.AdjustAccountBalanceParams{
+		ID:            accountID,
+		BalanceChange: pgChange,
+	})
+	if err != nil {
+		return sqlc.CompleteTransactionRow{}, fmt.Errorf("failed to apply %s: %w", operation, err)
+	}
+
+	pgOldBalance, err := service.decimalToPgNumeric(oldBalance)
+	if err != nil {
+		return sqlc.CompleteTransactionRow{}, fmt.Errorf("failed to convert old balance: %w", err)
+	}
+
+	if _, err := q.CreateBalanceHistoryRecord(ctx, sqlc.CreateBalanceHistoryRecordParams{
+		AccountID:     accountID,
+		TransactionID: transactionID,
+		OldBalance:    pgOldBalance,
+		NewBalance:    updated.Balance,
+		BalanceChange: pgChange,
+		Operation:     operation,
+		CreatedBy:     pgtype.Text{String: createdBy, Valid: true},
+	}); err != nil {
+		return sqlc.CompleteTransactionRow{}, fmt.Errorf("failed to record %s balance history: %w", operation, err)
+	}
+
+	completed, err := q.CompleteTransaction(ctx, transactionID)
+	if err != nil {
+		return sqlc.CompleteTransactionRow{}, fmt.Errorf("failed to complete %s transaction: %w", operation, err)
+	}
+
+	service.logger.WithFields(logrus.Fields{
+		"account_id": uuid.UUID(accountID.Bytes).String(),
+		"operation":  operation,
+		"change":     change.String(),
+		"created_by": createdBy,
+	}).Debug("Balance change applied")
+
+	return completed, nil
+}
@@ -0,0 +1,478 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"svc-transaction/store/sqlc"
+	"svc-transaction/util/ledgerexport"
+	"svc-transaction/util/objectstore"
+	"svc-transaction/util/parquet"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+// LedgerExportWorkflowName is the name LedgerExportWorkflow is registered under by the worker
+const LedgerExportWorkflowName = "LedgerExportWorkflow"
+
+const (
+	// DefaultLedgerExportPrefix is the key prefix of exported files when none is configured
+	DefaultLedgerExportPrefix = "ledger-exports"
+
+	defaultLedgerExportBatchSize = 1000
+	maxLedgerExportBatchSize     = 10000
+
+	ledgerExportTimeFormat = "20060102T150405Z"
+)
+
+// Tables written by a ledger export
+const (
+	LedgerExportTransactions   = "transactions"
+	LedgerExportBalanceHistory = "balance_history"
+)
+
+// ErrLedgerExportRejected is returned when the requested export is invalid, e.g. an empty date range
+var ErrLedgerExportRejected = errors.New("ledger export rejected")
+
+// ErrLedgerExportInProgress is returned when the same export is already running
+var ErrLedgerExportInProgress = errors.New("ledger export already in progress")
+
+// ErrLedgerExportUnavailable is returned when no object store is configured
+var ErrLedgerExportUnavailable = errors.New("ledger export storage not configured")
+
+// ledgerExportTransactionColumns are the columns of the transactions file. Amounts are exact decimal strings.
+var ledgerExportTransactionColumns = []parquet.Column{
+	{Name: "id", Type: parquet.String},
+	{Name: "account_id", Type: parquet.String},
+	{Name: "account_number", Type: parquet.String},
+	{Name: "transaction_type", Type: parquet.String},
+	{Name: "amount", Type: parquet.String},
+	{Name: "currency", Type: parquet.String},
+	{Name: "description", Type: parquet.String, Optional: true},
+	{Name: "reference_id", Type: parquet.String, Optional: true},
+	{Name: "status", Type: parquet.String},
+	{Name: "created_at", Type: parquet.Timestamp},
+	{Name: "updated_at", Type: parquet.Timestamp},
+	{Name: "completed_at", Type: parquet.Timestamp, Optional: true},
+}
+
+// ledgerExportBalanceHistoryColumns are the columns of the balance history file. Balances are exact decimal strings.
+var ledgerExportBalanceHistoryColumns = []parquet.Column{
+	{Name: "id", Type: parquet.String},
+	{Name: "account_id", Type: parquet.String},
+	{Name: "transaction_id", Type: parquet.String, Optional: true},
+	{Name: "old_balance", Type: parquet.String},
+	{Name: "new_balance", Type: parquet.String},
+	{Name: "balance_change", Type: parquet.String},
+	{Name: "operation", Type: parquet.String},
+	{Name: "created_at", Type: parquet.Timestamp},
+	{Name: "created_by", Type: parquet.String, Optional: true},
+	{Name: "sequence_number", Type: parquet.Int64},
+}
+
+// ExportLedgerSnapshotParams selects the rows created in [From, To) and the format of the files
+type ExportLedgerSnapshotParams struct {
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	Format    string    `json:"format"`     // csv or parquet; empty is csv
+	BatchSize int32     `json:"batch_size"` // Rows read per query; 0 uses the default
+}
+
+// ExportLedgerSnapshotResults lists the files written by an export
+type ExportLedgerSnapshotResults struct {
+	From   time.Time          `json:"from"`
+	To     time.Time          `json:"to"`
+	Format string             `json:"format"`
+	Files  []LedgerExportFile `json:"files"`
+}
+
+// LedgerExportFile is one exported table
+type LedgerExportFile struct {
+	Table    string `json:"table"`
+	Key      string `json:"key"`
+	Location string `json:"location"`
+	Rows     int64  `json:"rows"`
+	Bytes    int64  `json:"bytes"`
+	SHA256   string `json:"sha256"`
+}
+
+// StartLedgerExportParams represents an on-demand export request
+type StartLedgerExportParams struct {
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	Format      string    `json:"format"`
+	RequestedBy string    `json:"requested_by,omitempty"`
+}
+
+// StartLedgerExportResults identifies the started export workflow
+type StartLedgerExportResults struct {
+	WorkflowID string `json:"workflow_id"`
+	RunID      string `json:"run_id"`
+}
+
+// LedgerExportWorkflowParams is the input of LedgerExportWorkflow. Without From and To the workflow exports the
+// last complete Interval before it started, which is how the export schedule runs it.
+type LedgerExportWorkflowParams struct {
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	Interval  time.Duration `json:"interval,omitempty"`
+	Format    string        `json:"format"`
+	BatchSize int32         `json:"batch_size"`
+}
+
+// SetLedgerExportPrefix sets the key prefix of exported files; empty keeps the default
+func (service *Service) SetLedgerExportPrefix(prefix string) {
+	service.exportPrefix = prefix
+}
+
+func (service *Service) ledgerExportPrefix() string {
+	if service.exportPrefix == "" {
+		return DefaultLedgerExportPrefix
+	}
+
+	return service.exportPrefix
+}
+
+// ledgerExportKey names the file of a table so that exporting the same range again replaces it
+func (service *Service) ledgerExportKey(from, to time.Time, table string, format ledgerexport.Format) string {
+	return path.Join(
+		service.ledgerExportPrefix(),
+		fmt.Sprintf("%s_%s", from.UTC().Format(ledgerExportTimeFormat), to.UTC().Format(ledgerExportTimeFormat)),
+		table+"."+format.Extension(),
+	)
+}
+
+// ledgerExportWorkflowID allows a single running export per range and format
+func ledgerExportWorkflowID(from, to time.Time, format ledgerexport.Format) string {
+	return fmt.Sprintf("ledger_export_%s_%s_%s", from.UTC().Format(ledgerExportTimeFormat), to.UTC().Format(ledgerExportTimeFormat), format)
+}
+
+func validateLedgerExportRange(from, to time.Time) error {
+	if from.IsZero() || to.IsZero() {
+		return fmt.Errorf("%w: from and to are required", ErrLedgerExportRejected)
+	}
+	if !from.Before(to) {
+		return fmt.Errorf("%w: from must be before to", ErrLedgerExportRejected)
+	}
+
+	return nil
+}
+
+// StartLedgerExport starts LedgerExportWorkflow for the given range, so that a large export does not hold the
+// request open
+func (service *Service) StartLedgerExport(ctx context.Context, params StartLedgerExportParams) (*StartLedgerExportResults, error) {
+	const op = "service.Service.StartLedgerExport"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	format, err := ledgerexport.ParseFormat(params.Format)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLedgerExportRejected, err)
+	}
+	if err := validateLedgerExportRange(params.From, params.To); err != nil {
+		return nil, err
+	}
+	if service.objectStore == nil {
+		return nil, ErrLedgerExportUnavailable
+	}
+
+	if service.temporalClient == nil {
+		err := fmt.Errorf("temporal client not available - service is starting up")
+
+		logger.WithError(err).Warn()
+
+		return nil, err
+	}
+
+	workflowID := ledgerExportWorkflowID(params.From, params.To, format)
+
+	run, err := service.temporalClient.ExecuteWorkflow(ctx, client.StartWorkflowOptions{
+		ID:                                       workflowID,
+		TaskQueue:                                service.taskQueue,
+		WorkflowExecutionErrorWhenAlreadyStarted: true,
+	}, LedgerExportWorkflowName, LedgerExportWorkflowParams{
+		From:   params.From.UTC(),
+		To:     params.To.UTC(),
+		Format: string(format),
+	})
+	if err != nil {
+		var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+		if errors.As(err, &alreadyStarted) {
+			err = fmt.Errorf("%w: %s", ErrLedgerExportInProgress, workflowID)
+		} else {
+			err = fmt.Errorf("failed to start ledger export workflow: %w", err)
+		}
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &StartLedgerExportResults{
+		WorkflowID: run.GetID(),
+		RunID:      run.GetRunID(),
+	}
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info("Ledger export started")
+
+	return results, nil
+}
+
+// ExportLedgerSnapshot writes the transactions and balance history created in [From, To) to one file per table in
+// the object store. Rows are read page by page in (created_at, id) order, so the export does not hold a long
+// database transaction and memory use does not grow with the range. Exporting the same range again replaces the files.
+func (service *Service) ExportLedgerSnapshot(ctx context.Context, params ExportLedgerSnapshotParams) (*ExportLedgerSnapshotResults, error) {
+	const op = "service.Service.ExportLedgerSnapshot"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"from":   params.From,
+		"to":     params.To,
+		"format": params.Format,
+	})
+
+	format, err := ledgerexport.ParseFormat(params.Format)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLedgerExportRejected, err)
+	}
+	if err := validateLedgerExportRange(params.From, params.To); err != nil {
+		return nil, err
+	}
+	if service.objectStore == nil {
+		return nil, ErrLedgerExportUnavailable
+	}
+
+	batchSize := params.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultLedgerExportBatchSize
+	}
+	if batchSize > maxLedgerExportBatchSize {
+		batchSize = maxLedgerExportBatchSize
+	}
+
+	results := &ExportLedgerSnapshotResults{
+		From:   params.From.UTC(),
+		To:     params.To.UTC(),
+		Format: string(format),
+	}
+
+	tables := []struct {
+		name    string
+		columns []parquet.Column
+		write   func(ctx context.Context, writer ledgerexport.Writer, from, to time.Time, batchSize int32) (int64, error)
+	}{
+		{LedgerExportTransactions, ledgerExportTransactionColumns, service.exportTransactions},
+		{LedgerExportBalanceHistory, ledgerExportBalanceHistoryColumns, service.exportBalanceHistory},
+	}
+
+	for _, table := range tables {
+		key := service.ledgerExportKey(results.From, results.To, table.name, format)
+
+		file, err := service.exportLedgerTable(ctx, key, format, table.columns, func(writer ledgerexport.Writer) (int64, error) {
+			return table.write(ctx, writer, results.From, results.To, batchSize)
+		})
+		if err != nil {
+			err = fmt.Errorf("failed to export %s: %w", table.name, err)
+
+			logger.WithError(err).Error()
+
+			return nil, err
+		}
+
+		file.Table = table.name
+		results.Files = append(results.Files, *file)
+	}
+
+	logger.WithField("files", fmt.Sprintf("%+v", results.Files)).Info("Ledger snapshot exported")
+
+	return results, nil
+}
+
+// exportLedgerTable streams one table produced by write to the object store under key
+func (service *Service) exportLedgerTable(
+	ctx context.Context,
+	key string,
+	format ledgerexport.Format,
+	columns []parquet.Column,
+	write func(ledgerexport.Writer) (int64, error),
+) (*LedgerExportFile, error) {
+	var rows int64
+
+	object, err := objectstore.Write(ctx, service.objectStore, key, format.ContentType(), func(w io.Writer) error {
+		writer, err := ledgerexport.NewWriter(w, format, columns)
+		if err != nil {
+			return err
+		}
+
+		if rows, err = write(writer); err != nil {
+			return err
+		}
+
+		if err := writer.Close(); err != nil {
+			return fmt.Errorf("failed to finish file: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LedgerExportFile{
+		Key:      object.Key,
+		Location: object.Location,
+		Rows:     rows,
+		Bytes:    object.Size,
+		SHA256:   object.SHA256,
+	}, nil
+}
+
+func (service *Service) exportTransactions(ctx context.Context, writer ledgerexport.Writer, from, to time.Time, batchSize int32) (int64, error) {
+	var rows int64
+
+	cursor := ledgerExportCursor{createdAt: from}
+	for {
+		page, err := service.store.ListTransactionsForExport(ctx, sqlc.ListTransactionsForExportParams{
+			CreatedFrom:    pgtype.Timestamptz{Time: from, Valid: true},
+			CreatedTo:      pgtype.Timestamptz{Time: to, Valid: true},
+			AfterCreatedAt: pgtype.Timestamptz{Time: cursor.createdAt, Valid: true},
+			AfterID:        pgtype.UUID{Bytes: cursor.id, Valid: true},
+			RowLimit:       batchSize,
+		})
+		if err != nil {
+			return rows, fmt.Errorf("failed to list transactions: %w", err)
+		}
+
+		for _, transaction := range page {
+			amount, err := service.pgNumericToDecimal(transaction.Amount)
+			if err != nil {
+				return rows, fmt.Errorf("failed to convert amount: %w", err)
+			}
+
+			err = writer.Write(
+				uuid.UUID(transaction.ID.Bytes).String(),
+				uuid.UUID(transaction.AccountID.Bytes).String(),
+				transaction.AccountNumber,
+				string(transaction.TransactionType),
+				amount.String(),
+				string(transaction.Currency),
+				exportText(transaction.Description),
+				exportText(transaction.ReferenceID),
+				string(transaction.Status),
+				transaction.CreatedAt.Time,
+				transaction.UpdatedAt.Time,
+				exportTime(transaction.CompletedAt),
+			)
+			if err != nil {
+				return rows, fmt.Errorf("failed to write transaction: %w", err)
+			}
+
+			rows++
+		}
+
+		if len(page) < int(batchSize) {
+			return rows, nil
+		}
+
+		last := page[len(page)-1]
+		cursor = ledgerExportCursor{createdAt: last.CreatedAt.Time, id: last.ID.Bytes}
+	}
+}
+
+func (service *Service) exportBalanceHistory(ctx context.Context, writer ledgerexport.Writer, from, to time.Time, batchSize int32) (int64, error) {
+	var rows int64
+
+	cursor := ledgerExportCursor{createdAt: from}
+	for {
+		page, err := service.store.ListBalanceHistoryForExport(ctx, sqlc.ListBalanceHistoryForExportParams{
+			CreatedFrom:    pgtype.Timestamptz{Time: from, Valid: true},
+			CreatedTo:      pgtype.Timestamptz{Time: to, Valid: true},
+			AfterCreatedAt: pgtype.Timestamptz{Time: cursor.createdAt, Valid: true},
+			AfterID:        pgtype.UUID{Bytes: cursor.id, Valid: true},
+			RowLimit:       batchSize,
+		})
+		if err != nil {
+			return rows, fmt.Errorf("failed to list balance history: %w", err)
+		}
+
+		for _, entry := range page {
+			balances := make([]string, 0, 3)
+			for _, n := range []pgtype.Numeric{entry.OldBalance, entry.NewBalance, entry.BalanceChange} {
+				balance, err := service.pgNumericToDecimal(n)
+				if err != nil {
+					return rows, fmt.Errorf("failed to convert balance: %w", err)
+				}
+
+				balances = append(balances, balance.String())
+			}
+
+			var transactionID any
+			if entry.TransactionID.Valid {
+				transactionID = uuid.UUID(entry.TransactionID.Bytes).String()
+			}
+
+			err = writer.Write(
+				uuid.UUID(entry.ID.Bytes).String(),
+				uuid.UUID(entry.AccountID.Bytes).String(),
+				transactionID,
+				balances[0],
+				balances[1],
+				balances[2],
+				entry.Operation,
+				entry.CreatedAt.Time,
+				exportText(entry.CreatedBy),
+				entry.SequenceNumber,
+			)
+			if err != nil {
+				return rows, fmt.Errorf("failed to write balance history entry: %w", err)
+			}
+
+			rows++
+		}
+
+		if len(page) < int(batchSize) {
+			return rows, nil
+		}
+
+		last := page[len(page)-1]
+		cursor = ledgerExportCursor{createdAt: last.CreatedAt.Time, id: last.ID.Bytes}
+	}
+}
+
+// ledgerExportCursor is the (created_at, id) of the last exported row; the first page starts at the beginning of the
+// range with the nil UUID, which sorts before every row created at that instant
+type ledgerExportCursor struct {
+	createdAt time.Time
+	id        uuid.UUID
+}
+
+// exportText returns nil for a NULL text column
+func exportText(text pgtype.Text) any {
+	if !text.Valid {
+		return nil
+	}
+
+	return text.String
+}
+
+// exportTime returns nil for a NULL timestamp column
+func exportTime(timestamp pgtype.Timestamptz) any {
+	if !timestamp.Valid {
+		return nil
+	}
+
+	return timestamp.Time
+}
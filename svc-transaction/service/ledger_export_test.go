@@ -0,0 +1,209 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"svc-transaction/store"
+	"svc-transaction/store/sqlc"
+	"svc-transaction/util/objectstore"
+
+	"numeric"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportStore pages through fixed rows the way the export queries do and counts the queries it answers
+type exportStore struct {
+	store.IStore
+
+	transactions []sqlc.ListTransactionsForExportRow
+	history      []sqlc.ListBalanceHistoryForExportRow
+	queries      int
+}
+
+// afterCursor reports whether a row sorts after the keyset cursor and falls within the range
+func afterCursor(createdAt time.Time, id pgtype.UUID, from, to, afterCreatedAt pgtype.Timestamptz, afterID pgtype.UUID) bool {
+	if createdAt.Before(from.Time) || !createdAt.Before(to.Time) {
+		return false
+	}
+	if !createdAt.Equal(afterCreatedAt.Time) {
+		return createdAt.After(afterCreatedAt.Time)
+	}
+
+	return bytes.Compare(id.Bytes[:], afterID.Bytes[:]) > 0
+}
+
+func (store *exportStore) ListTransactionsForExport(_ context.Context, arg sqlc.ListTransactionsForExportParams) ([]sqlc.ListTransactionsForExportRow, error) {
+	store.queries++
+
+	page := []sqlc.ListTransactionsForExportRow{}
+	for _, row := range store.transactions {
+		if len(page) < int(arg.RowLimit) && afterCursor(row.CreatedAt.Time, row.ID, arg.CreatedFrom, arg.CreatedTo, arg.AfterCreatedAt, arg.AfterID) {
+			page = append(page, row)
+		}
+	}
+
+	return page, nil
+}
+
+func (store *exportStore) ListBalanceHistoryForExport(_ context.Context, arg sqlc.ListBalanceHistoryForExportParams) ([]sqlc.ListBalanceHistoryForExportRow, error) {
+	store.queries++
+
+	page := []sqlc.ListBalanceHistoryForExportRow{}
+	for _, row := range store.history {
+		if len(page) < int(arg.RowLimit) && afterCursor(row.CreatedAt.Time, row.ID, arg.CreatedFrom, arg.CreatedTo, arg.AfterCreatedAt, arg.AfterID) {
+			page = append(page, row)
+		}
+	}
+
+	return page, nil
+}
+
+// sortedUUIDs returns n UUIDs in ascending byte order
+func sortedUUIDs(n int) []pgtype.UUID {
+	ids := make([]pgtype.UUID, n)
+	for i := range ids {
+		id := uuid.New()
+		id[0] = byte(i)
+		ids[i] = pgtype.UUID{Bytes: id, Valid: true}
+	}
+
+	return ids
+}
+
+func TestExportLedgerSnapshot(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	at := func(offset time.Duration) pgtype.Timestamptz {
+		return pgtype.Timestamptz{Time: from.Add(offset), Valid: true}
+	}
+
+	accountID := pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	ids := sortedUUIDs(5)
+
+	transaction := func(id pgtype.UUID, createdAt pgtype.Timestamptz, amount string) sqlc.ListTransactionsForExportRow {
+		return sqlc.ListTransactionsForExportRow{
+			ID:              id,
+			AccountID:       accountID,
+			AccountNumber:   "ACC000000001",
+			TransactionType: sqlc.CoreTransactionTypeDebit,
+			Amount:          numeric.FromDecimal(decimal.RequireFromString(amount)),
+			Currency:        sqlc.CoreCurrencyCodeUSD,
+			Status:          sqlc.CoreTransactionStatusCompleted,
+			CreatedAt:       createdAt,
+			UpdatedAt:       createdAt,
+			CompletedAt:     createdAt,
+		}
+	}
+
+	stub := &exportStore{
+		transactions: []sqlc.ListTransactionsForExportRow{
+			transaction(ids[0], at(-time.Second), "1"), // Before the range
+			// Three rows at the same instant straddle a page boundary
+			transaction(ids[1], at(time.Hour), "10.50"),
+			transaction(ids[2], at(time.Hour), "20"),
+			transaction(ids[3], at(time.Hour), "30.1234"),
+			transaction(ids[4], at(24*time.Hour), "1"), // At the end of the range, which is exclusive
+		},
+		history: []sqlc.ListBalanceHistoryForExportRow{{
+			ID:             ids[0],
+			AccountID:      accountID,
+			OldBalance:     numeric.FromDecimal(decimal.RequireFromString("100")),
+			NewBalance:     numeric.FromDecimal(decimal.RequireFromString("89.5")),
+			BalanceChange:  numeric.FromDecimal(decimal.RequireFromString("-10.5")),
+			Operation:      "adjustment",
+			CreatedAt:      at(time.Hour),
+			SequenceNumber: 7,
+		}},
+	}
+	stub.transactions[1].Description = pgtype.Text{String: "coffee, large", Valid: true}
+
+	dir := t.TempDir()
+	local, err := objectstore.NewLocal(dir)
+	require.NoError(t, err)
+
+	service := &Service{logger: logrus.New(), store: stub}
+	service.SetObjectStore(local)
+
+	results, err := service.ExportLedgerSnapshot(context.Background(), ExportLedgerSnapshotParams{From: from, To: to, BatchSize: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, "csv", results.Format)
+	require.Len(t, results.Files, 2)
+	// A full and a short page for transactions, a single short page for balance history
+	assert.Equal(t, 3, stub.queries)
+
+	transactions := results.Files[0]
+	assert.Equal(t, LedgerExportTransactions, transactions.Table)
+	assert.Equal(t, "ledger-exports/20260301T000000Z_20260302T000000Z/transactions.csv", transactions.Key)
+	assert.Equal(t, filepath.Join(dir, "ledger-exports", "20260301T000000Z_20260302T000000Z", "transactions.csv"), transactions.Location)
+	assert.Equal(t, int64(3), transactions.Rows)
+
+	data, err := os.ReadFile(transactions.Location)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), transactions.Bytes)
+	assert.Len(t, transactions.SHA256, 64)
+
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, "id", records[0][0])
+	assert.Equal(t, []string{uuid.UUID(ids[1].Bytes).String(), "10.5", "coffee, large", "", "2026-03-01T01:00:00Z"},
+		[]string{records[1][0], records[1][4], records[1][6], records[1][7], records[1][9]})
+	assert.Equal(t, "30.1234", records[3][4])
+
+	history := results.Files[1]
+	assert.Equal(t, LedgerExportBalanceHistory, history.Table)
+	assert.Equal(t, int64(1), history.Rows)
+
+	data, err = os.ReadFile(history.Location)
+	require.NoError(t, err)
+
+	records, err = csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{"", "100", "89.5", "-10.5", "adjustment"}, records[1][2:7])
+	assert.Equal(t, "7", records[1][9])
+
+	t.Run("parquet", func(t *testing.T) {
+		results, err := service.ExportLedgerSnapshot(context.Background(), ExportLedgerSnapshotParams{From: from, To: to, Format: "parquet"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "ledger-exports/20260301T000000Z_20260302T000000Z/transactions.parquet", results.Files[0].Key)
+		assert.Equal(t, int64(3), results.Files[0].Rows)
+
+		data, err := os.ReadFile(results.Files[0].Location)
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data, []byte("PAR1")))
+		assert.True(t, bytes.HasSuffix(data, []byte("PAR1")))
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		for _, params := range []ExportLedgerSnapshotParams{
+			{From: to, To: from},
+			{From: from},
+			{From: from, To: to, Format: "xlsx"},
+		} {
+			_, err := service.ExportLedgerSnapshot(context.Background(), params)
+			assert.ErrorIs(t, err, ErrLedgerExportRejected)
+		}
+	})
+
+	t.Run("without_storage", func(t *testing.T) {
+		_, err := (&Service{logger: logrus.New(), store: stub}).ExportLedgerSnapshot(context.Background(), ExportLedgerSnapshotParams{From: from, To: to})
+		assert.ErrorIs(t, err, ErrLedgerExportUnavailable)
+	})
+}
@@ -0,0 +1,796 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"svc-transaction/store/sqlc"
+	"svc-transaction/util/lockwait"
+
+	"numeric"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"pgregory.net/rapid"
+)
+
+// ledgerTransaction is a row of core.transactions in the in-memory ledger
+type ledgerTransaction struct {
+	id              uuid.UUID
+	accountID       uuid.UUID
+	transactionType sqlc.CoreTransactionType
+	amount          decimal.Decimal
+	currency        sqlc.CoreCurrencyCode
+	description     pgtype.Text
+	referenceID     pgtype.Text
+	idempotencyKey  pgtype.Text
+	status          sqlc.CoreTransactionStatus
+	createdAt       time.Time
+	completedAt     pgtype.Timestamptz
+	metadata        []byte
+}
+
+// ledgerState is the table data of the in-memory ledger
+type ledgerState struct {
+	accounts     map[uuid.UUID]sqlc.CoreAccount
+	balances     map[uuid.UUID]decimal.Decimal
+	transactions []ledgerTransaction
+	history      int
+	adminAudits  []sqlc.CoreAdminAudit
+
+	idempotencyKeys    map[string]sqlc.CoreIdempotencyKey
+	pendingAdjustments map[uuid.UUID]sqlc.CorePendingAdjustment
+}
+
+func (state ledgerState) clone() ledgerState {
+	cloned := ledgerState{
+		accounts:     make(map[uuid.UUID]sqlc.CoreAccount, len(state.accounts)),
+		balances:     make(map[uuid.UUID]decimal.Decimal, len(state.balances)),
+		transactions: append([]ledgerTransaction(nil), state.transactions...),
+		history:      state.history,
+		adminAudits:  append([]sqlc.CoreAdminAudit(nil), state.adminAudits...),
+
+		idempotencyKeys:    maps.Clone(state.idempotencyKeys),
+		pendingAdjustments: maps.Clone(state.pendingAdjustments),
+	}
+	for id, account := range state.accounts {
+		cloned.accounts[id] = account
+	}
+	for id, balance := range state.balances {
+		cloned.balances[id] = balance
+	}
+
+	return cloned
+}
+
+// memoryLedger implements sqlc.DBTX for the queries used by the debit, credit and compensation paths,
+// enforcing the same constraints as the schema (non-negative balances, unique idempotency keys).
+// Queries are safe for concurrent use.
+type memoryLedger struct {
+	mutex sync.Mutex
+
+	// afterQuery, when set, is called with the name of every query once it has run
+	afterQuery func(name string)
+
+	ledgerState
+}
+
+func newMemoryLedger() *memoryLedger {
+	return &memoryLedger{ledgerState: ledgerState{
+		accounts: make(map[uuid.UUID]sqlc.CoreAccount),
+		balances: make(map[uuid.UUID]decimal.Decimal),
+
+		idempotencyKeys:    make(map[string]sqlc.CoreIdempotencyKey),
+		pendingAdjustments: make(map[uuid.UUID]sqlc.CorePendingAdjustment),
+	}}
+}
+
+// addAccount opens an active USD account funded by a completed opening credit
+func (ledger *memoryLedger) addAccount(number string, opening decimal.Decimal) uuid.UUID {
+	id := uuid.New()
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	ledger.accounts[id] = sqlc.CoreAccount{
+		ID:            pgtype.UUID{Bytes: id, Valid: true},
+		AccountNumber: number,
+		AccountName:   "Account " + number,
+		Currency:      sqlc.CoreCurrencyCodeUSD,
+		Status:        sqlc.CoreAccountStatusActive,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Version:       1,
+		AccountType:   sqlc.CoreAccountTypeChecking,
+	}
+	ledger.balances[id] = opening
+
+	if opening.IsPositive() {
+		ledger.transactions = append(ledger.transactions, ledgerTransaction{
+			id:              uuid.New(),
+			accountID:       id,
+			transactionType: sqlc.CoreTransactionTypeCredit,
+			amount:          opening,
+			currency:        sqlc.CoreCurrencyCodeUSD,
+			status:          sqlc.CoreTransactionStatusCompleted,
+			createdAt:       now.Time,
+			completedAt:     now,
+		})
+	}
+
+	return id
+}
+
+func (ledger *memoryLedger) Exec(_ context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	name := queryName(sql)
+
+	if name == "DeleteExpiredIdempotencyKeys" {
+		ledger.mutex.Lock()
+		defer ledger.mutex.Unlock()
+
+		var deleted int32
+		for key, registered := range ledger.idempotencyKeys {
+			if deleted < args[0].(int32) && !registered.ExpiresAt.Time.After(time.Now()) {
+				delete(ledger.idempotencyKeys, key)
+				deleted++
+			}
+		}
+
+		return pgconn.NewCommandTag(fmt.Sprintf("DELETE %d", deleted)), nil
+	}
+
+	// memoryStore already serializes transactions, which is what the advisory lock provides
+	if name == "AcquireAccountLock" {
+		if ledger.afterQuery != nil {
+			ledger.afterQuery(name)
+		}
+
+		return pgconn.NewCommandTag("SELECT 1"), nil
+	}
+
+	return pgconn.CommandTag{}, fmt.Errorf("memory ledger: unsupported exec %q", name)
+}
+
+func (ledger *memoryLedger) Query(_ context.Context, sql string, _ ...interface{}) (pgx.Rows, error) {
+	return nil, fmt.Errorf("memory ledger: unsupported query %q", queryName(sql))
+}
+
+func (ledger *memoryLedger) QueryRow(_ context.Context, sql string, args ...interface{}) pgx.Row {
+	name := queryName(sql)
+
+	ledger.mutex.Lock()
+	values, err := ledger.queryRow(name, args)
+	ledger.mutex.Unlock()
+
+	if ledger.afterQuery != nil {
+		ledger.afterQuery(name)
+	}
+
+	return memoryRow{values: values, err: err}
+}
+
+func (ledger *memoryLedger) queryRow(name string, args []interface{}) ([]any, error) {
+	now := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+
+	switch name {
+	case "GetAccountByID", "GetAccountForUpdate":
+		account, ok := ledger.accounts[args[0].(pgtype.UUID).Bytes]
+		if !ok {
+			return nil, pgx.ErrNoRows
+		}
+		account.Balance = numeric.FromDecimal(ledger.balances[account.ID.Bytes])
+
+		return []any{account.ID, account.AccountNumber, account.AccountName, account.Balance, account.Currency,
+			account.Status, account.CreatedAt, account.UpdatedAt, account.Version, account.AccountType}, nil
+
+	case "CheckAccountBalance":
+		account, ok := ledger.accounts[args[0].(pgtype.UUID).Bytes]
+		if !ok {
+			return nil, pgx.ErrNoRows
+		}
+		balance := ledger.balances[account.ID.Bytes]
+
+		return []any{account.ID, account.AccountNumber, account.AccountName, numeric.FromDecimal(balance), account.Currency,
+			account.Status, account.AccountType, balance.GreaterThanOrEqual(fromNumeric(args[1].(pgtype.Numeric)))}, nil
+
+	case "CountOutgoingDebits":
+		var count int64
+		for _, transaction := range ledger.transactions {
+			if transaction.accountID == args[0].(pgtype.UUID).Bytes && transaction.transactionType == sqlc.CoreTransactionTypeDebit &&
+				(transaction.status == sqlc.CoreTransactionStatusPending || transaction.status == sqlc.CoreTransactionStatusCompleted) &&
+				!transaction.createdAt.Before(args[1].(pgtype.Timestamptz).Time) {
+				count++
+			}
+		}
+
+		return []any{count}, nil
+
+	case "AdjustAccountBalance":
+		id := args[1].(pgtype.UUID).Bytes
+		account, ok := ledger.accounts[id]
+		if !ok {
+			return nil, pgx.ErrNoRows
+		}
+		balance := ledger.balances[id].Add(fromNumeric(args[0].(pgtype.Numeric)))
+		if balance.IsNegative() {
+			return nil, &pgconn.PgError{Code: "23514", ConstraintName: "accounts_balance_check", Message: `new row for relation "accounts" violates check constraint "accounts_balance_check"`}
+		}
+		account.Version++
+		ledger.accounts[id] = account
+		ledger.balances[id] = balance
+
+		return []any{account.ID, numeric.FromDecimal(balance), account.Version}, nil
+
+	case "CreateBalanceHistoryRecord":
+		ledger.history++
+
+		return []any{pgtype.UUID{Bytes: uuid.New(), Valid: true}, now}, nil
+
+	case "CreateTransaction":
+		key := args[6].(pgtype.Text)
+		if key.Valid {
+			for _, transaction := range ledger.transactions {
+				if transaction.idempotencyKey == key {
+					return nil, &pgconn.PgError{Code: "23505", ConstraintName: "transactions_idempotency_key_key", Message: `duplicate key value violates unique constraint "transactions_idempotency_key_key"`}
+				}
+			}
+		}
+		transaction := ledgerTransaction{
+			id:              uuid.New(),
+			accountID:       args[0].(pgtype.UUID).Bytes,
+			transactionType: args[1].(sqlc.CoreTransactionType),
+			amount:          fromNumeric(args[2].(pgtype.Numeric)),
+			currency:        args[3].(sqlc.CoreCurrencyCode),
+			description:     args[4].(pgtype.Text),
+			referenceID:     args[5].(pgtype.Text),
+			idempotencyKey:  key,
+			status:          sqlc.CoreTransactionStatusPending,
+			createdAt:       now.Time,
+			metadata:        args[7].([]byte),
+		}
+		ledger.transactions = append(ledger.transactions, transaction)
+
+		return []any{pgtype.UUID{Bytes: transaction.id, Valid: true}, now}, nil
+
+	case "CompleteTransaction":
+		id := args[0].(pgtype.UUID).Bytes
+		for i := range ledger.transactions {
+			transaction := &ledger.transactions[i]
+			if transaction.id == id && transaction.status == sqlc.CoreTransactionStatusPending {
+				transaction.status = sqlc.CoreTransactionStatusCompleted
+				transaction.completedAt = now
+
+				return []any{pgtype.UUID{Bytes: id, Valid: true}, transaction.status, now}, nil
+			}
+		}
+
+		return nil, pgx.ErrNoRows
+
+	case "GetTransactionByID", "GetTransactionByIdempotencyKey":
+		for _, transaction := range ledger.transactions {
+			if (name == "GetTransactionByID" && transaction.id == args[0].(pgtype.UUID).Bytes) ||
+				(name == "GetTransactionByIdempotencyKey" && transaction.idempotencyKey.Valid && transaction.idempotencyKey == args[0].(pgtype.Text)) {
+				return []any{
+					pgtype.UUID{Bytes: transaction.id, Valid: true},
+					pgtype.UUID{Bytes: transaction.accountID, Valid: true},
+					transaction.transactionType,
+					numeric.FromDecimal(transaction.amount),
+					transaction.currency,
+					transaction.description,
+					transaction.referenceID,
+					transaction.idempotencyKey,
+					transaction.status,
+					pgtype.Timestamptz{Time: transaction.createdAt, Valid: true},
+					pgtype.Timestamptz{Time: transaction.createdAt, Valid: true},
+					transaction.completedAt,
+					transaction.metadata,
+				}, nil
+			}
+		}
+
+		return nil, pgx.ErrNoRows
+
+	case "GetIdempotencyKey":
+		registered, ok := ledger.idempotencyKeys[args[0].(string)]
+		if !ok || !registered.ExpiresAt.Time.After(now.Time) {
+			return nil, pgx.ErrNoRows
+		}
+
+		return idempotencyKeyValues(registered), nil
+
+	case "CreateIdempotencyKey":
+		key := args[0].(string)
+		if registered, ok := ledger.idempotencyKeys[key]; ok && registered.ExpiresAt.Time.After(now.Time) {
+			return nil, pgx.ErrNoRows
+		}
+		registered := sqlc.CoreIdempotencyKey{
+			IdempotencyKey:   key,
+			Scope:            args[1].(string),
+			RequestHash:      args[2].(string),
+			ResponseSnapshot: args[3].([]byte),
+			CreatedAt:        now,
+			ExpiresAt:        args[4].(pgtype.Timestamptz),
+		}
+		ledger.idempotencyKeys[key] = registered
+
+		return idempotencyKeyValues(registered), nil
+
+	case "GetAccountLedgerBalance":
+		id := args[0].(pgtype.UUID).Bytes
+		balance := decimal.Zero
+		var count int64
+		for _, transaction := range ledger.transactions {
+			if transaction.accountID != id || transaction.status != sqlc.CoreTransactionStatusCompleted {
+				continue
+			}
+			count++
+			if transaction.transactionType == sqlc.CoreTransactionTypeCredit {
+				balance = balance.Add(transaction.amount)
+			} else {
+				balance = balance.Sub(transaction.amount)
+			}
+		}
+
+		return []any{numeric.FromDecimal(balance), count}, nil
+
+	case "CreateAdminAudit":
+		audit := sqlc.CoreAdminAudit{
+			ID:            pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			Actor:         args[0].(string),
+			Action:        args[1].(string),
+			Service:       args[2].(string),
+			ResourceType:  args[3].(string),
+			ResourceID:    args[4].(pgtype.Text),
+			BeforePayload: args[5].([]byte),
+			AfterPayload:  args[6].([]byte),
+			CreatedAt:     now,
+		}
+		ledger.adminAudits = append(ledger.adminAudits, audit)
+
+		return []any{audit.ID, audit.Actor, audit.Action, audit.Service, audit.ResourceType, audit.ResourceID,
+			audit.BeforePayload, audit.AfterPayload, audit.CreatedAt}, nil
+
+	case "CreatePendingAdjustment":
+		adjustment := sqlc.CorePendingAdjustment{
+			ID:          pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			AccountID:   args[0].(pgtype.UUID),
+			Amount:      args[1].(pgtype.Numeric),
+			Currency:    args[2].(sqlc.CoreCurrencyCode),
+			Reason:      args[3].(string),
+			Status:      "pending",
+			RequestedBy: args[4].(string),
+			RequestedAt: now,
+		}
+		ledger.pendingAdjustments[adjustment.ID.Bytes] = adjustment
+
+		return pendingAdjustmentValues(adjustment), nil
+
+	case "GetPendingAdjustment", "GetPendingAdjustmentForUpdate":
+		adjustment, ok := ledger.pendingAdjustments[args[0].(pgtype.UUID).Bytes]
+		if !ok {
+			return nil, pgx.ErrNoRows
+		}
+
+		return pendingAdjustmentValues(adjustment), nil
+
+	case "ReviewPendingAdjustment":
+		adjustment, ok := ledger.pendingAdjustments[args[4].(pgtype.UUID).Bytes]
+		if !ok || adjustment.Status != "pending" {
+			return nil, pgx.ErrNoRows
+		}
+		adjustment.Status = args[0].(string)
+		adjustment.ReviewedBy = args[1].(pgtype.Text)
+		adjustment.ReviewedAt = now
+		adjustment.ReviewNote = args[2].(pgtype.Text)
+		adjustment.TransactionID = args[3].(pgtype.UUID)
+		ledger.pendingAdjustments[adjustment.ID.Bytes] = adjustment
+
+		return pendingAdjustmentValues(adjustment), nil
+
+	case "GetCompensatedAmount":
+		total := decimal.Zero
+		for _, transaction := range ledger.transactions {
+			if transaction.transactionType == sqlc.CoreTransactionTypeCredit &&
+				transaction.status == sqlc.CoreTransactionStatusCompleted &&
+				originalTransactionID(transaction.metadata) == args[0].(string) {
+				total = total.Add(transaction.amount)
+			}
+		}
+
+		return []any{numeric.FromDecimal(total)}, nil
+	}
+
+	return nil, fmt.Errorf("memory ledger: unsupported query %q", name)
+}
+
+func idempotencyKeyValues(registered sqlc.CoreIdempotencyKey) []any {
+	return []any{registered.IdempotencyKey, registered.Scope, registered.RequestHash, registered.ResponseSnapshot,
+		registered.CreatedAt, registered.ExpiresAt}
+}
+
+func pendingAdjustmentValues(adjustment sqlc.CorePendingAdjustment) []any {
+	return []any{adjustment.ID, adjustment.AccountID, adjustment.Amount, adjustment.Currency, adjustment.Reason,
+		adjustment.Status, adjustment.RequestedBy, adjustment.RequestedAt, adjustment.ReviewedBy, adjustment.ReviewedAt,
+		adjustment.ReviewNote, adjustment.TransactionID}
+}
+
+// memoryRow implements pgx.Row over values of exactly the scanned types
+type memoryRow struct {
+	values []any
+	err    error
+}
+
+func (row memoryRow) Scan(dest ...any) error {
+	if row.err != nil {
+		return row.err
+	}
+	if len(dest) != len(row.values) {
+		return fmt.Errorf("memory ledger: scanning %d values into %d destinations", len(row.values), len(dest))
+	}
+
+	for i, value := range row.values {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(value))
+	}
+
+	return nil
+}
+
+// memoryStore implements store.IStore on top of the in-memory ledger.
+// WithTx rolls the ledger back when fn fails. Transactions are serialized, standing in for the
+// account row lock taken by GetAccountForUpdate; queries outside a transaction are not.
+type memoryStore struct {
+	*sqlc.Queries
+
+	ledger  *memoryLedger
+	txMutex sync.Mutex
+}
+
+func newMemoryStore(ledger *memoryLedger) *memoryStore {
+	return &memoryStore{Queries: sqlc.New(ledger), ledger: ledger}
+}
+
+func (store *memoryStore) WithTx(_ context.Context, fn func(sqlc.Querier) error) error {
+	store.txMutex.Lock()
+	defer store.txMutex.Unlock()
+
+	store.ledger.mutex.Lock()
+	snapshot := store.ledger.ledgerState.clone()
+	store.ledger.mutex.Unlock()
+
+	if err := fn(store.Queries); err != nil {
+		store.ledger.mutex.Lock()
+		store.ledger.ledgerState = snapshot
+		store.ledger.mutex.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// WithRetryTx runs fn once: serialized transactions cannot conflict
+func (store *memoryStore) WithRetryTx(ctx context.Context, fn func(sqlc.Querier) error) error {
+	return store.WithTx(ctx, fn)
+}
+
+func queryName(sql string) string {
+	name, _, _ := strings.Cut(strings.TrimPrefix(sql, "-- name: "), " ")
+
+	return name
+}
+
+func originalTransactionID(metadata []byte) string {
+	var fields struct {
+		OriginalTransactionID string `json:"original_transaction_id"`
+	}
+	_ = json.Unmarshal(metadata, &fields)
+
+	return fields.OriginalTransactionID
+}
+
+// fromNumeric converts query arguments, which the service always builds with numeric.FromDecimal
+func fromNumeric(n pgtype.Numeric) decimal.Decimal {
+	d, err := numeric.ToDecimal(n)
+	if err != nil {
+		panic(fmt.Sprintf("memory ledger: %v", err))
+	}
+
+	return d
+}
+
+// ledgerMachine drives random debits, credits, compensations and idempotent replays against the service
+type ledgerMachine struct {
+	service *Service
+	ledger  *memoryLedger
+
+	accounts []uuid.UUID
+	debits   []uuid.UUID
+	replays  []func() (uuid.UUID, error)
+	results  []uuid.UUID
+	keys     int
+}
+
+func newLedgerMachine(t *rapid.T) *ledgerMachine {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	ledger := newMemoryLedger()
+	machine := &ledgerMachine{
+		service: &Service{
+			logger: logger,
+			store:  newMemoryStore(ledger),
+		},
+		ledger: ledger,
+	}
+
+	for i := range rapid.IntRange(1, 3).Draw(t, "accounts") {
+		opening := rapid.Int64Range(0, 100000).Draw(t, fmt.Sprintf("opening_%d", i))
+		machine.accounts = append(machine.accounts, ledger.addAccount(fmt.Sprintf("ACC%09d", i), decimal.New(opening, -2)))
+	}
+
+	return machine
+}
+
+func (machine *ledgerMachine) nextKey(operation string) *string {
+	machine.keys++
+	key := fmt.Sprintf("%s-%d", operation, machine.keys)
+
+	return &key
+}
+
+// remember records a successful operation so it can be replayed with the same idempotency key
+func (machine *ledgerMachine) remember(id uuid.UUID, replay func() (uuid.UUID, error)) {
+	machine.results = append(machine.results, id)
+	machine.replays = append(machine.replays, replay)
+}
+
+func drawAmount(t *rapid.T, label string, maxCents int64) decimal.Decimal {
+	return decimal.New(rapid.Int64Range(1, maxCents).Draw(t, label), -2)
+}
+
+func (machine *ledgerMachine) Debit(t *rapid.T) {
+	accountID := rapid.SampledFrom(machine.accounts).Draw(t, "account")
+	params := DebitAccountParams{
+		AccountID:      &accountID,
+		Amount:         drawAmount(t, "amount", 50000),
+		Currency:       "USD",
+		IdempotencyKey: machine.nextKey("debit"),
+	}
+	sufficient := machine.ledger.balances[accountID].GreaterThanOrEqual(params.Amount)
+
+	results, err := machine.service.DebitAccount(context.Background(), params)
+	if !sufficient {
+		if err == nil {
+			t.Fatalf("debit of %s exceeding balance %s succeeded", params.Amount, machine.ledger.balances[accountID])
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("debit failed: %v", err)
+	}
+
+	machine.debits = append(machine.debits, results.TransactionID)
+	machine.remember(results.TransactionID, func() (uuid.UUID, error) {
+		replayed, err := machine.service.DebitAccount(context.Background(), params)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return replayed.TransactionID, nil
+	})
+}
+
+func (machine *ledgerMachine) Credit(t *rapid.T) {
+	accountID := rapid.SampledFrom(machine.accounts).Draw(t, "account")
+	params := CreditAccountParams{
+		AccountID:      &accountID,
+		Amount:         drawAmount(t, "amount", 50000),
+		Currency:       "USD",
+		IdempotencyKey: machine.nextKey("credit"),
+	}
+
+	results, err := machine.service.CreditAccount(context.Background(), params)
+	if err != nil {
+		t.Fatalf("credit failed: %v", err)
+	}
+
+	machine.remember(results.TransactionID, func() (uuid.UUID, error) {
+		replayed, err := machine.service.CreditAccount(context.Background(), params)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return replayed.TransactionID, nil
+	})
+}
+
+func (machine *ledgerMachine) Compensate(t *rapid.T) {
+	if len(machine.debits) == 0 {
+		t.Skip("no debits to compensate")
+	}
+
+	originalID := rapid.SampledFrom(machine.debits).Draw(t, "original")
+	original := machine.ledger.transaction(originalID)
+	compensated := machine.ledger.compensated(originalID)
+
+	// Draw up to 1.5x the original amount so over-compensation is exercised
+	params := CompensateDebitParams{
+		OriginalTransactionID: &originalID,
+		Amount:                drawAmount(t, "amount", original.amount.Shift(2).IntPart()*3/2+1),
+		Currency:              "USD",
+		IdempotencyKey:        machine.nextKey("compensation"),
+	}
+	allowed := compensated.Add(params.Amount).LessThanOrEqual(original.amount)
+
+	results, err := machine.service.CompensateDebit(context.Background(), params)
+	if !allowed {
+		if !errors.Is(err, ErrCompensationExceedsOriginal) {
+			t.Fatalf("compensation of %s on top of %s for debit of %s: got error %v, want %v",
+				params.Amount, compensated, original.amount, err, ErrCompensationExceedsOriginal)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("compensation failed: %v", err)
+	}
+
+	machine.remember(results.TransactionID, func() (uuid.UUID, error) {
+		replayed, err := machine.service.CompensateDebit(context.Background(), params)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return replayed.TransactionID, nil
+	})
+}
+
+func (machine *ledgerMachine) Replay(t *rapid.T) {
+	if len(machine.replays) == 0 {
+		t.Skip("no operations to replay")
+	}
+
+	i := rapid.IntRange(0, len(machine.replays)-1).Draw(t, "operation")
+	before := machine.ledger.ledgerState.clone()
+
+	id, err := machine.replays[i]()
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+	if id != machine.results[i] {
+		t.Fatalf("replay returned transaction %s, want %s", id, machine.results[i])
+	}
+
+	if len(machine.ledger.transactions) != len(before.transactions) || machine.ledger.history != before.history {
+		t.Fatalf("replay created records: %d transactions and %d history records, want %d and %d",
+			len(machine.ledger.transactions), machine.ledger.history, len(before.transactions), before.history)
+	}
+	for accountID, balance := range before.balances {
+		if !machine.ledger.balances[accountID].Equal(balance) {
+			t.Fatalf("replay changed balance of %s from %s to %s", accountID, balance, machine.ledger.balances[accountID])
+		}
+	}
+}
+
+// Check verifies the ledger invariants after every step
+func (machine *ledgerMachine) Check(t *rapid.T) {
+	for _, accountID := range machine.accounts {
+		balance := machine.ledger.balances[accountID]
+		if balance.IsNegative() {
+			t.Fatalf("account %s has negative balance %s", accountID, balance)
+		}
+
+		expected := decimal.Zero
+		for _, transaction := range machine.ledger.transactions {
+			if transaction.accountID != accountID || transaction.status != sqlc.CoreTransactionStatusCompleted {
+				continue
+			}
+			switch transaction.transactionType {
+			case sqlc.CoreTransactionTypeCredit:
+				expected = expected.Add(transaction.amount)
+			case sqlc.CoreTransactionTypeDebit:
+				expected = expected.Sub(transaction.amount)
+			}
+		}
+		if !balance.Equal(expected) {
+			t.Fatalf("account %s has balance %s, completed transactions sum to %s", accountID, balance, expected)
+		}
+	}
+
+	for _, debitID := range machine.debits {
+		original := machine.ledger.transaction(debitID)
+		if compensated := machine.ledger.compensated(debitID); compensated.GreaterThan(original.amount) {
+			t.Fatalf("debit %s of %s compensated by %s", debitID, original.amount, compensated)
+		}
+	}
+
+	for _, transaction := range machine.ledger.transactions {
+		if transaction.status != sqlc.CoreTransactionStatusCompleted {
+			t.Fatalf("transaction %s left in status %s", transaction.id, transaction.status)
+		}
+	}
+}
+
+func (ledger *memoryLedger) transaction(id uuid.UUID) ledgerTransaction {
+	for _, transaction := range ledger.transactions {
+		if transaction.id == id {
+			return transaction
+		}
+	}
+
+	return ledgerTransaction{}
+}
+
+func (ledger *memoryLedger) compensated(id uuid.UUID) decimal.Decimal {
+	total := decimal.Zero
+	for _, transaction := range ledger.transactions {
+		if transaction.status == sqlc.CoreTransactionStatusCompleted && originalTransactionID(transaction.metadata) == id.String() {
+			total = total.Add(transaction.amount)
+		}
+	}
+
+	return total
+}
+
+func TestLedgerInvariants(t *testing.T) {
+	t.Parallel()
+
+	rapid.Check(t, func(t *rapid.T) {
+		machine := newLedgerMachine(t)
+
+		t.Repeat(rapid.StateMachineActions(machine))
+	})
+}
+
+// TestAccountLockPrecedesRowLock checks that mutations take the account's advisory lock before the row lock,
+// so every service instance serializes on the same key in the same order
+func TestAccountLockPrecedesRowLock(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	ledger := newMemoryLedger()
+	accountID := ledger.addAccount("ACC000000001", decimal.NewFromInt(100))
+	service := &Service{logger: logger, store: newMemoryStore(ledger)}
+
+	var queries []string
+	ledger.afterQuery = func(name string) {
+		queries = append(queries, name)
+	}
+
+	before := lockwait.Read()
+
+	key := "lock-order"
+	if _, err := service.DebitAccount(context.Background(), DebitAccountParams{
+		AccountID:      &accountID,
+		Amount:         decimal.NewFromInt(10),
+		Currency:       "USD",
+		IdempotencyKey: &key,
+	}); err != nil {
+		t.Fatalf("debit failed: %v", err)
+	}
+
+	lockAt, rowLockAt := -1, -1
+	for i, name := range queries {
+		if name == "AcquireAccountLock" && lockAt < 0 {
+			lockAt = i
+		}
+		if name == "GetAccountForUpdate" && rowLockAt < 0 {
+			rowLockAt = i
+		}
+	}
+	if lockAt < 0 || rowLockAt < 0 || lockAt > rowLockAt {
+		t.Errorf("queries = %v, want AcquireAccountLock before GetAccountForUpdate", queries)
+	}
+
+	if got := lockwait.Read().Count - before.Count; got < 1 {
+		t.Errorf("lock wait observations increased by %d, want at least 1", got)
+	}
+}
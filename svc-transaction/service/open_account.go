@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"svc-transaction/store/sqlc"
+	"svc-transaction/util/accountnumber"
+	"svc-transaction/util/accounttype"
+	"svc-transaction/util/rules"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// accountOpeningCreatedBy is recorded in core.account_balance_history.created_by for opening balances
+const accountOpeningCreatedBy = "transaction_service_account_opening"
+
+// maxAccountNumberLength matches core.accounts.account_number
+const maxAccountNumberLength = 20
+
+// ErrInvalidAccountOpening is returned when an account cannot be opened with the given parameters
+var ErrInvalidAccountOpening = errors.New("invalid account opening")
+
+// OpenAccountParams represents the input parameters for opening an account, e.g. when seeding demo accounts
+type OpenAccountParams struct {
+	AccountNumber  string          `json:"account_number"`
+	AccountName    string          `json:"account_name"`
+	Currency       string          `json:"currency"`
+	AccountType    string          `json:"account_type"`    // checking or savings; empty for checking
+	OpeningBalance decimal.Decimal `json:"opening_balance"` // Credited to the new account; zero opens it empty
+	Tenant         string          `json:"tenant"`          // Selects the account number format; empty for the default
+	Actor          string          `json:"actor"`           // Recorded in core.admin_audit when the account is created
+}
+
+// OpenAccountResults represents the account after opening. Created is false when an account with the
+// same number already existed, in which case it is returned unchanged.
+type OpenAccountResults struct {
+	AccountID     uuid.UUID       `json:"account_id"`
+	AccountNumber string          `json:"account_number"`
+	AccountName   string          `json:"account_name"`
+	Currency      string          `json:"currency"`
+	AccountType   string          `json:"account_type"`
+	Balance       decimal.Decimal `json:"balance"`
+	Status        string          `json:"status"`
+	Created       bool            `json:"created"`
+}
+
+// OpenAccount opens an account, credits its opening balance and records the opening in core.admin_audit in one
+// database transaction. Opening an account number that already exists is a no-op, so seeding can be repeated safely.
+func (service *Service) OpenAccount(ctx context.Context, params OpenAccountParams) (*OpenAccountResults, error) {
+	const op = "service.Service.OpenAccount"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":           op,
+		"account_number": params.AccountNumber,
+	})
+
+	if err := validateOpenAccountParams(params); err != nil {
+		return nil, err
+	}
+	if err := service.checkAmountPrecision("opening_balance", params.OpeningBalance); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAccountOpening, err)
+	}
+
+	// Numbers the gateway would reject could never receive a transfer
+	var formatErr *accountnumber.FormatError
+	if err := service.accountNumbers.Validate(params.Tenant, params.AccountNumber); errors.As(err, &formatErr) {
+		return nil, fmt.Errorf("%w: account_number %s", ErrInvalidAccountOpening, formatErr.Reason)
+	}
+
+	// Rules of severity error, e.g. a longer minimum account name, keep the account from being opened
+	outcomes := service.evaluateBusinessRules(ctx, rules.Facts{Currency: params.Currency, AccountName: &params.AccountName})
+	if err := businessRuleFailure(outcomes); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAccountOpening, err)
+	}
+
+	// Validated above, so only an empty type needs resolving
+	accountType, _ := accounttype.Parse(params.AccountType)
+
+	var results *OpenAccountResults
+
+	err := service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		existing, err := q.GetAccountByAccountNumber(ctx, params.AccountNumber)
+		if err == nil {
+			results, err = service.buildOpenAccountResults(existing, false)
+			return err
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("failed to get account %s: %w", params.AccountNumber, err)
+		}
+
+		account, err := q.CreateAccount(ctx, sqlc.CreateAccountParams{
+			AccountNumber: params.AccountNumber,
+			AccountName:   params.AccountName,
+			Currency:      service.mapCurrencyToEnum(params.Currency),
+			AccountType:   sqlc.CoreAccountType(accountType),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create account: %w", err)
+		}
+
+		if !params.OpeningBalance.IsZero() {
+			if err := service.creditOpeningBalance(ctx, q, &account, params); err != nil {
+				return err
+			}
+		}
+
+		results, err = service.buildOpenAccountResults(account, true)
+		if err != nil {
+			return err
+		}
+
+		return createAdminAudit(ctx, q, AdminAction{
+			Actor:        params.Actor,
+			Action:       AdminActionOpenAccount,
+			ResourceType: "account",
+			ResourceID:   results.AccountID.String(),
+			After:        results,
+		})
+	})
+	if err != nil {
+		logger.WithError(err).Error()
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"account_id": results.AccountID.String(),
+		"created":    results.Created,
+	}).Info("Account opened")
+
+	return results, nil
+}
+
+func (service *Service) buildOpenAccountResults(account sqlc.CoreAccount, created bool) (*OpenAccountResults, error) {
+	balance, err := service.pgNumericToDecimal(account.Balance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert account balance: %w", err)
+	}
+
+	return &OpenAccountResults{
+		AccountID:     account.ID.Bytes,
+		AccountNumber: account.AccountNumber,
+		AccountName:   account.AccountName,
+		Currency:      string(account.Currency),
+		AccountType:   string(account.AccountType),
+		Balance:       balance,
+		Status:        string(account.Status),
+		Created:       created,
+	}, nil
+}
+
+// creditOpeningBalance credits the opening balance of a freshly created account and refreshes account with the result
+func (service *Service) creditOpeningBalance(ctx context.Context, q sqlc.Querier, account *sqlc.CoreAccount, params OpenAccountParams) error {
+	if err := service.acquireAccountLock(ctx, q, account.ID); err != nil {
+		return err
+	}
+
+	pgAmount, err := service.decimalToPgNumeric(params.OpeningBalance)
+	if err != nil {
+		return fmt.Errorf("failed to convert opening balance: %w", err)
+	}
+
+	credit, err := q.CreateTransaction(ctx, sqlc.CreateTransactionParams{
+		AccountID:       account.ID,
+		TransactionType: sqlc.CoreTransactionTypeCredit,
+		Amount:          pgAmount,
+		Currency:        account.Currency,
+		Description:     pgtype.Text{String: "Opening balance", Valid: true},
+		IdempotencyKey:  pgtype.Text{String: fmt.Sprintf("account-opening-%s", params.AccountNumber), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create opening balance transaction: %w", err)
+	}
+
+	if _, err := service.applyBalanceChange(ctx, q, account.ID, credit.ID, decimal.Zero, params.OpeningBalance, "credit", accountOpeningCreatedBy); err != nil {
+		return err
+	}
+
+	// The account was created empty in this transaction, so its balance is now exactly the opening balance
+	account.Balance = pgAmount
+
+	return nil
+}
+
+// validateOpenAccountParams checks the parameters of an account opening
+func validateOpenAccountParams(params OpenAccountParams) error {
+	if strings.TrimSpace(params.AccountNumber) == "" {
+		return fmt.Errorf("%w: account_number is required", ErrInvalidAccountOpening)
+	}
+	if len(params.AccountNumber) > maxAccountNumberLength {
+		return fmt.Errorf("%w: account_number must be at most %d characters", ErrInvalidAccountOpening, maxAccountNumberLength)
+	}
+	if strings.TrimSpace(params.AccountName) == "" {
+		return fmt.Errorf("%w: account_name is required", ErrInvalidAccountOpening)
+	}
+	if _, ok := currencyDecimalPlaces[params.Currency]; !ok {
+		return fmt.Errorf("%w: unsupported currency %q", ErrInvalidAccountOpening, params.Currency)
+	}
+	if _, err := accounttype.Parse(params.AccountType); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAccountOpening, err)
+	}
+	if err := validateAmountSign(AmountOperationOpeningBalance, "opening_balance", params.OpeningBalance); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAccountOpening, err)
+	}
+	if err := validateAmountScale(params.OpeningBalance, params.Currency); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAccountOpening, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"svc-transaction/util/accountnumber"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestValidateOpenAccountParams(t *testing.T) {
+	t.Parallel()
+
+	validParams := func() OpenAccountParams {
+		return OpenAccountParams{
+			AccountNumber:  "DEMO001",
+			AccountName:    "Demo Account",
+			Currency:       "USD",
+			OpeningBalance: decimal.RequireFromString("1000.50"),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		modify      func(p *OpenAccountParams)
+		expectError bool
+	}{
+		{name: "Valid params", modify: func(p *OpenAccountParams) {}},
+		{name: "Empty opening balance", modify: func(p *OpenAccountParams) { p.OpeningBalance = decimal.Zero }},
+		{name: "Savings account", modify: func(p *OpenAccountParams) { p.AccountType = "savings" }},
+		{name: "Unknown account type", modify: func(p *OpenAccountParams) { p.AccountType = "brokerage" }, expectError: true},
+		{name: "Missing account number", modify: func(p *OpenAccountParams) { p.AccountNumber = " " }, expectError: true},
+		{name: "Account number too long", modify: func(p *OpenAccountParams) { p.AccountNumber = "DEMO-0000000000000001" }, expectError: true},
+		{name: "Missing account name", modify: func(p *OpenAccountParams) { p.AccountName = "" }, expectError: true},
+		{name: "Unsupported currency", modify: func(p *OpenAccountParams) { p.Currency = "XYZ" }, expectError: true},
+		{name: "Negative opening balance", modify: func(p *OpenAccountParams) { p.OpeningBalance = decimal.NewFromInt(-1) }, expectError: true},
+		{name: "Fractional yen", modify: func(p *OpenAccountParams) { p.Currency = "JPY" }, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params := validParams()
+			tt.modify(&params)
+
+			err := validateOpenAccountParams(params)
+			if tt.expectError && !errors.Is(err, ErrInvalidAccountOpening) {
+				t.Errorf("validateOpenAccountParams() error = %v, want ErrInvalidAccountOpening", err)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("validateOpenAccountParams() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestOpenAccountRejectsAccountNumberFormat(t *testing.T) {
+	t.Parallel()
+
+	accountNumbers, err := accountnumber.NewValidator([]accountnumber.Format{
+		{MinLength: 12, MaxLength: 12},
+		{Tenant: "acme", Prefix: "ACM", MinLength: 14, MaxLength: 14, Checksum: accountnumber.ChecksumLuhn},
+	})
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+
+	service := createTestService()
+	service.SetAccountNumberValidator(accountNumbers)
+
+	tests := []struct {
+		name          string
+		tenant        string
+		accountNumber string
+		wantErr       string
+	}{
+		{name: "Default format length", accountNumber: "ACC001", wantErr: "invalid account opening: account_number must be 12 characters"},
+		{name: "Tenant prefix", tenant: "acme", accountNumber: "ACC79927398713", wantErr: "invalid account opening: account_number must start with ACM"},
+		{name: "Tenant check digit", tenant: "acme", accountNumber: "ACM79927398714", wantErr: "invalid account opening: account_number has an invalid check digit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Rejected before the store is used, so the test service needs none
+			_, err := service.OpenAccount(context.Background(), OpenAccountParams{
+				AccountNumber: tt.accountNumber,
+				AccountName:   "Alice",
+				Currency:      "USD",
+				Tenant:        tt.tenant,
+			})
+			if !errors.Is(err, ErrInvalidAccountOpening) || err.Error() != tt.wantErr {
+				t.Errorf("OpenAccount() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
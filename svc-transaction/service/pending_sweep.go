@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-transaction/store/sqlc"
+	"svc-transaction/util/pendingsweep"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultPendingStaleAfter is how old a pending transaction has to be before the sweeper resolves it
+	DefaultPendingStaleAfter = 15 * time.Minute
+
+	defaultPendingSweepBatchSize = 100
+
+	// pendingSweepActor identifies the sweeper in core.admin_audit
+	pendingSweepActor = "pending-transaction-sweeper"
+)
+
+// Outcomes of sweeping one pending transaction
+const (
+	PendingSweepCompleted = "completed"
+	PendingSweepFailed    = "failed"
+	PendingSweepSkipped   = "skipped" // Resolved by someone else in the meantime
+)
+
+// SweepPendingTransactionsResults counts how the stale pending transactions of one sweep were resolved
+type SweepPendingTransactionsResults struct {
+	Examined  int `json:"examined"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+	Errors    int `json:"errors"`
+}
+
+// PendingTransactionStats reports the pending transactions and what the sweeper did since the service started
+type PendingTransactionStats struct {
+	Pending           int64      `json:"pending"`
+	Stale             int64      `json:"stale"` // Pending for longer than StaleAfterSeconds
+	StaleAfterSeconds int64      `json:"stale_after_seconds"`
+	SweepRuns         int64      `json:"sweep_runs"`
+	SweptCompleted    int64      `json:"swept_completed"`
+	SweptFailed       int64      `json:"swept_failed"`
+	SweepErrors       int64      `json:"sweep_errors"`
+	LastSweepAt       *time.Time `json:"last_sweep_at,omitempty"`
+}
+
+// SetPendingStaleAfter sets how old a pending transaction has to be before the sweeper resolves it; zero keeps the default
+func (service *Service) SetPendingStaleAfter(staleAfter time.Duration) {
+	service.pendingStaleAfter = staleAfter
+}
+
+func (service *Service) pendingTransactionStaleAfter() time.Duration {
+	if service.pendingStaleAfter <= 0 {
+		return DefaultPendingStaleAfter
+	}
+
+	return service.pendingStaleAfter
+}
+
+// SweepPendingTransactions resolves up to batchSize transactions that have been pending for too long. Debits and
+// credits create and complete their transaction atomically, so a stale pending row is left over from a crash or an
+// older release:
+//   - its balance change was applied (it has balance history): the transaction is completed
+//   - otherwise: the transaction is marked failed, leaving the balance untouched
+//
+// Every resolution is recorded in core.admin_audit. A transaction that cannot be resolved is left for the next sweep.
+func (service *Service) SweepPendingTransactions(ctx context.Context, batchSize int32) (*SweepPendingTransactionsResults, error) {
+	const op = "service.Service.SweepPendingTransactions"
+
+	if batchSize <= 0 {
+		batchSize = defaultPendingSweepBatchSize
+	}
+	staleAfter := service.pendingTransactionStaleAfter()
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"batch_size":  batchSize,
+		"stale_after": staleAfter.String(),
+	})
+
+	transactions, err := service.store.ListStalePendingTransactions(ctx, sqlc.ListStalePendingTransactionsParams{
+		StaleBefore: pgtype.Timestamptz{Time: time.Now().Add(-staleAfter), Valid: true},
+		RowLimit:    batchSize,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to list stale pending transactions: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	results := &SweepPendingTransactionsResults{Examined: len(transactions)}
+	for _, transaction := range transactions {
+		outcome, err := service.sweepPendingTransaction(ctx, transaction, staleAfter)
+		if err != nil {
+			logger.WithError(err).WithField("transaction_id", uuid.UUID(transaction.ID.Bytes)).Error("Failed to sweep pending transaction")
+
+			results.Errors++
+			continue
+		}
+
+		switch outcome {
+		case PendingSweepCompleted:
+			results.Completed++
+		case PendingSweepFailed:
+			results.Failed++
+		default:
+			results.Skipped++
+		}
+	}
+
+	pendingsweep.Record(results.Completed, results.Failed, results.Errors)
+
+	logger.WithField("results", fmt.Sprintf("%+v", results)).Info()
+
+	return results, nil
+}
+
+// sweepPendingTransaction resolves one stale pending transaction under its account lock and audits the outcome
+func (service *Service) sweepPendingTransaction(ctx context.Context, transaction sqlc.ListStalePendingTransactionsRow, staleAfter time.Duration) (string, error) {
+	var outcome string
+
+	err := service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		if _, _, err := service.lockAccount(ctx, q, transaction.AccountID); err != nil {
+			return err
+		}
+
+		history, err := q.GetBalanceHistoryByTransaction(ctx, transaction.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get balance history: %w", err)
+		}
+
+		outcome = pendingSweepOutcome(len(history))
+
+		// Completing and failing only change rows that are still pending; ErrNoRows means someone else resolved it
+		var (
+			status sqlc.CoreTransactionStatus
+			reason string
+		)
+		if outcome == PendingSweepCompleted {
+			completed, err := q.CompleteTransaction(ctx, transaction.ID)
+			if err != nil {
+				return fmt.Errorf("failed to complete transaction: %w", err)
+			}
+			status = completed.Status
+		} else {
+			reason = fmt.Sprintf("Pending for more than %s without a balance change", staleAfter)
+
+			failed, err := q.FailTransaction(ctx, sqlc.FailTransactionParams{ID: transaction.ID, JsonbBuildObject: reason})
+			if err != nil {
+				return fmt.Errorf("failed to mark transaction failed: %w", err)
+			}
+			status = failed.Status
+		}
+
+		return createAdminAudit(ctx, q, AdminAction{
+			Actor:        pendingSweepActor,
+			Action:       AdminActionSweepPendingTransaction,
+			ResourceType: "transaction",
+			ResourceID:   uuid.UUID(transaction.ID.Bytes).String(),
+			Before: map[string]any{
+				"status":     sqlc.CoreTransactionStatusPending,
+				"created_at": transaction.CreatedAt.Time,
+			},
+			After: map[string]any{
+				"status":         status,
+				"outcome":        outcome,
+				"failure_reason": reason,
+			},
+		})
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return PendingSweepSkipped, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return outcome, nil
+}
+
+// pendingSweepOutcome decides how a stale pending transaction is resolved from its balance history records:
+// a recorded balance change means only the completion was lost
+func pendingSweepOutcome(historyRecords int) string {
+	if historyRecords > 0 {
+		return PendingSweepCompleted
+	}
+
+	return PendingSweepFailed
+}
+
+// GetPendingTransactionStats counts the pending transactions and reports what the sweeper did since the service started
+func (service *Service) GetPendingTransactionStats(ctx context.Context) (*PendingTransactionStats, error) {
+	const op = "service.Service.GetPendingTransactionStats"
+
+	logger := service.logger.WithField("[op]", op)
+
+	staleAfter := service.pendingTransactionStaleAfter()
+
+	counts, err := service.store.CountPendingTransactions(ctx, pgtype.Timestamptz{Time: time.Now().Add(-staleAfter), Valid: true})
+	if err != nil {
+		err = fmt.Errorf("failed to count pending transactions: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	sweeps := pendingsweep.Read()
+
+	stats := &PendingTransactionStats{
+		Pending:           counts.Pending,
+		Stale:             counts.Stale,
+		StaleAfterSeconds: int64(staleAfter.Seconds()),
+		SweepRuns:         sweeps.Runs,
+		SweptCompleted:    sweeps.Completed,
+		SweptFailed:       sweeps.Failed,
+		SweepErrors:       sweeps.Errors,
+	}
+	if !sweeps.LastRunAt.IsZero() {
+		stats.LastSweepAt = &sweeps.LastRunAt
+	}
+
+	return stats, nil
+}
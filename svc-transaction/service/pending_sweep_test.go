@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"svc-transaction/store"
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pendingCountStore answers CountPendingTransactions and remembers the cut-off it was asked for
+type pendingCountStore struct {
+	store.IStore
+
+	counts      sqlc.CountPendingTransactionsRow
+	staleBefore pgtype.Timestamptz
+}
+
+func (store *pendingCountStore) CountPendingTransactions(_ context.Context, staleBefore pgtype.Timestamptz) (sqlc.CountPendingTransactionsRow, error) {
+	store.staleBefore = staleBefore
+
+	return store.counts, nil
+}
+
+func TestPendingSweepOutcome(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, PendingSweepCompleted, pendingSweepOutcome(1))
+	assert.Equal(t, PendingSweepFailed, pendingSweepOutcome(0))
+}
+
+func TestGetPendingTransactionStats(t *testing.T) {
+	t.Parallel()
+
+	counts := &pendingCountStore{counts: sqlc.CountPendingTransactionsRow{Pending: 5, Stale: 2}}
+	service := &Service{logger: logrus.New(), store: counts}
+	service.SetPendingStaleAfter(30 * time.Minute)
+
+	stats, err := service.GetPendingTransactionStats(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(5), stats.Pending)
+	assert.Equal(t, int64(2), stats.Stale)
+	assert.Equal(t, int64(1800), stats.StaleAfterSeconds)
+	assert.WithinDuration(t, time.Now().Add(-30*time.Minute), counts.staleBefore.Time, time.Minute)
+}
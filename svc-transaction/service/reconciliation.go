@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"numeric"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// Discrepancy types reported by the balance history verification
+const (
+	// DiscrepancySequenceGap means entries are missing between two sequence numbers
+	DiscrepancySequenceGap = "sequence_gap"
+	// DiscrepancyChainHashMismatch means an entry was altered, or the entry before it was removed
+	DiscrepancyChainHashMismatch = "chain_hash_mismatch"
+	// DiscrepancyBalanceDiscontinuity means an entry did not start from the balance the previous entry left
+	DiscrepancyBalanceDiscontinuity = "balance_discontinuity"
+	// DiscrepancyInvalidChange means the recorded change is not the difference between the old and new balance
+	DiscrepancyInvalidChange = "invalid_change"
+	// DiscrepancyDuplicateApplication means one transaction changed the account balance more than once
+	DiscrepancyDuplicateApplication = "duplicate_application"
+	// DiscrepancyBalanceMismatch means the account balance is not the balance the history ends at
+	DiscrepancyBalanceMismatch = "balance_mismatch"
+)
+
+// balanceScale is the scale of the DECIMAL(19,4) balance columns, which PostgreSQL uses when hashing them as text
+const balanceScale = 4
+
+// BalanceHistoryDiscrepancy describes one problem found in the balance history of an account
+type BalanceHistoryDiscrepancy struct {
+	AccountID      uuid.UUID  `json:"account_id"`
+	Type           string     `json:"type"`
+	SequenceNumber int64      `json:"sequence_number,omitempty"`
+	HistoryID      *uuid.UUID `json:"history_id,omitempty"`
+	TransactionID  *uuid.UUID `json:"transaction_id,omitempty"`
+	Detail         string     `json:"detail"`
+}
+
+// ReconciliationReport is the result of verifying the balance history of one or all accounts
+type ReconciliationReport struct {
+	GeneratedAt     time.Time                   `json:"generated_at"`
+	AccountsChecked int                         `json:"accounts_checked"`
+	EntriesChecked  int                         `json:"entries_checked"`
+	Discrepancies   []BalanceHistoryDiscrepancy `json:"discrepancies"`
+}
+
+// GetReconciliationReport verifies the balance history chain of an account, or of every account when accountID
+// is uuid.Nil. Every balance change should appear exactly once: a missing, altered or replayed entry, or a
+// transaction applied twice despite idempotency, is reported as a discrepancy.
+func (service *Service) GetReconciliationReport(ctx context.Context, accountID uuid.UUID) (*ReconciliationReport, error) {
+	const op = "service.Service.GetReconciliationReport"
+
+	accounts, err := service.store.ListAccountsForReconciliation(ctx, pgtype.UUID{Bytes: accountID, Valid: accountID != uuid.Nil})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	if accountID != uuid.Nil && len(accounts) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, accountID)
+	}
+
+	report := &ReconciliationReport{
+		GeneratedAt:   time.Now().UTC(),
+		Discrepancies: []BalanceHistoryDiscrepancy{},
+	}
+
+	for _, account := range accounts {
+		history, err := service.store.ListBalanceHistoryChain(ctx, account.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list balance history of account %s: %w", uuid.UUID(account.ID.Bytes), err)
+		}
+
+		discrepancies, err := verifyBalanceHistoryChain(account, history)
+		if err != nil {
+			return nil, err
+		}
+
+		report.AccountsChecked++
+		report.EntriesChecked += len(history)
+		report.Discrepancies = append(report.Discrepancies, discrepancies...)
+	}
+
+	if len(report.Discrepancies) > 0 {
+		service.logger.WithFields(logrus.Fields{
+			"[op]":            op,
+			"accounts":        report.AccountsChecked,
+			"discrepancies":   len(report.Discrepancies),
+			"entries_checked": report.EntriesChecked,
+		}).Warn("Balance history verification found discrepancies")
+	}
+
+	return report, nil
+}
+
+// verifyBalanceHistoryChain checks the history of one account, given in sequence order, against itself and
+// against the current balance of the account
+func verifyBalanceHistoryChain(account sqlc.CoreAccount, history []sqlc.CoreAccountBalanceHistory) ([]BalanceHistoryDiscrepancy, error) {
+	accountID := uuid.UUID(account.ID.Bytes)
+
+	balance, err := numeric.ToDecimal(account.Balance)
+	if err != nil {
+		return nil, fmt.Errorf("invalid balance of account %s: %w", accountID, err)
+	}
+
+	discrepancies := []BalanceHistoryDiscrepancy{}
+	report := func(entry sqlc.CoreAccountBalanceHistory, kind, detail string, args ...any) {
+		historyID := uuid.UUID(entry.ID.Bytes)
+		discrepancy := BalanceHistoryDiscrepancy{
+			AccountID:      accountID,
+			Type:           kind,
+			SequenceNumber: entry.SequenceNumber,
+			HistoryID:      &historyID,
+			Detail:         fmt.Sprintf(detail, args...),
+		}
+		if entry.TransactionID.Valid {
+			transactionID := uuid.UUID(entry.TransactionID.Bytes)
+			discrepancy.TransactionID = &transactionID
+		}
+		discrepancies = append(discrepancies, discrepancy)
+	}
+
+	var (
+		previousSequence int64
+		previousHash     string
+		previousBalance  = decimal.Zero
+		applied          = map[uuid.UUID]int64{}
+	)
+
+	for _, entry := range history {
+		oldBalance, newBalance, change, err := balanceHistoryAmounts(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		if entry.SequenceNumber != previousSequence+1 {
+			report(entry, DiscrepancySequenceGap, "expected sequence number %d, found %d", previousSequence+1, entry.SequenceNumber)
+		}
+
+		expectedHash, err := BalanceHistoryChainHash(previousHash, entry)
+		if err != nil {
+			return nil, err
+		}
+		if entry.ChainHash != expectedHash {
+			report(entry, DiscrepancyChainHashMismatch, "expected chain hash %s, found %s", expectedHash, entry.ChainHash)
+		}
+
+		if !oldBalance.Equal(previousBalance) {
+			report(entry, DiscrepancyBalanceDiscontinuity, "old balance %s does not match the previous new balance %s", oldBalance, previousBalance)
+		}
+
+		if !newBalance.Sub(oldBalance).Equal(change) {
+			report(entry, DiscrepancyInvalidChange, "change %s does not take the balance from %s to %s", change, oldBalance, newBalance)
+		}
+
+		if entry.TransactionID.Valid {
+			transactionID := uuid.UUID(entry.TransactionID.Bytes)
+			if first, seen := applied[transactionID]; seen {
+				report(entry, DiscrepancyDuplicateApplication, "transaction already applied at sequence number %d", first)
+			} else {
+				applied[transactionID] = entry.SequenceNumber
+			}
+		}
+
+		// Continue from the recorded values so one bad entry is reported once, not for every entry after it
+		previousSequence = entry.SequenceNumber
+		previousHash = entry.ChainHash
+		previousBalance = newBalance
+	}
+
+	if !balance.Equal(previousBalance) {
+		discrepancies = append(discrepancies, BalanceHistoryDiscrepancy{
+			AccountID:      accountID,
+			Type:           DiscrepancyBalanceMismatch,
+			SequenceNumber: previousSequence,
+			Detail:         fmt.Sprintf("account balance %s does not match the balance history total %s", balance, previousBalance),
+		})
+	}
+
+	return discrepancies, nil
+}
+
+// BalanceHistoryChainHash computes the chain hash of a balance history entry from the chain hash of the entry
+// before it (empty for the first entry). It must match core.chain_balance_history in _init/postgres/03-trigger.sql.
+func BalanceHistoryChainHash(previousHash string, entry sqlc.CoreAccountBalanceHistory) (string, error) {
+	oldBalance, newBalance, change, err := balanceHistoryAmounts(entry)
+	if err != nil {
+		return "", err
+	}
+
+	var transactionID string
+	if entry.TransactionID.Valid {
+		transactionID = uuid.UUID(entry.TransactionID.Bytes).String()
+	}
+
+	input := strings.Join([]string{
+		previousHash,
+		uuid.UUID(entry.AccountID.Bytes).String(),
+		strconv.FormatInt(entry.SequenceNumber, 10),
+		transactionID,
+		oldBalance.StringFixed(balanceScale),
+		newBalance.StringFixed(balanceScale),
+		change.StringFixed(balanceScale),
+		entry.Operation,
+	}, "|")
+
+	sum := sha256.Sum256([]byte(input))
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func balanceHistoryAmounts(entry sqlc.CoreAccountBalanceHistory) (oldBalance, newBalance, change decimal.Decimal, err error) {
+	if oldBalance, err = numeric.ToDecimal(entry.OldBalance); err != nil {
+		return oldBalance, newBalance, change, fmt.Errorf("invalid old balance in balance history %s: %w", uuid.UUID(entry.ID.Bytes), err)
+	}
+	if newBalance, err = numeric.ToDecimal(entry.NewBalance); err != nil {
+		return oldBalance, newBalance, change, fmt.Errorf("invalid new balance in balance history %s: %w", uuid.UUID(entry.ID.Bytes), err)
+	}
+	if change, err = numeric.ToDecimal(entry.BalanceChange); err != nil {
+		return oldBalance, newBalance, change, fmt.Errorf("invalid balance change in balance history %s: %w", uuid.UUID(entry.ID.Bytes), err)
+	}
+
+	return oldBalance, newBalance, change, nil
+}
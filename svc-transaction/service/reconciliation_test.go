@@ -0,0 +1,179 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"svc-transaction/store/sqlc"
+
+	"numeric"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+var reconciliationAccountID = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+
+// balanceHistoryChain builds a correctly chained history from (transaction, change) pairs, starting at zero
+func balanceHistoryChain(t *testing.T, changes ...string) []sqlc.CoreAccountBalanceHistory {
+	t.Helper()
+
+	var (
+		history []sqlc.CoreAccountBalanceHistory
+		balance = decimal.Zero
+		hash    string
+	)
+
+	for i, value := range changes {
+		change := decimal.RequireFromString(value)
+		operation := "credit"
+		if change.IsNegative() {
+			operation = "debit"
+		}
+
+		entry := sqlc.CoreAccountBalanceHistory{
+			ID:             pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			AccountID:      pgtype.UUID{Bytes: reconciliationAccountID, Valid: true},
+			TransactionID:  pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			OldBalance:     numeric.FromDecimal(balance),
+			NewBalance:     numeric.FromDecimal(balance.Add(change)),
+			BalanceChange:  numeric.FromDecimal(change),
+			Operation:      operation,
+			SequenceNumber: int64(i + 1),
+		}
+
+		var err error
+		if entry.ChainHash, err = BalanceHistoryChainHash(hash, entry); err != nil {
+			t.Fatalf("BalanceHistoryChainHash() error = %v", err)
+		}
+
+		history = append(history, entry)
+		balance = balance.Add(change)
+		hash = entry.ChainHash
+	}
+
+	return history
+}
+
+func reconciliationAccount(balance string) sqlc.CoreAccount {
+	return sqlc.CoreAccount{
+		ID:      pgtype.UUID{Bytes: reconciliationAccountID, Valid: true},
+		Balance: numeric.FromDecimal(decimal.RequireFromString(balance)),
+	}
+}
+
+func TestBalanceHistoryChainHashMatchesTriggerInput(t *testing.T) {
+	t.Parallel()
+
+	// Same input core.chain_balance_history hashes for the first seeded entry, with balances at DECIMAL(19,4) scale
+	entry := sqlc.CoreAccountBalanceHistory{
+		AccountID:      pgtype.UUID{Bytes: reconciliationAccountID, Valid: true},
+		TransactionID:  pgtype.UUID{Bytes: uuid.MustParse("660e8400-e29b-41d4-a716-446655440001"), Valid: true},
+		OldBalance:     numeric.FromDecimal(decimal.Zero),
+		NewBalance:     numeric.FromDecimal(decimal.RequireFromString("5000")),
+		BalanceChange:  numeric.FromDecimal(decimal.RequireFromString("5000")),
+		Operation:      "credit",
+		SequenceNumber: 1,
+	}
+	sum := sha256.Sum256([]byte("|550e8400-e29b-41d4-a716-446655440001|1|660e8400-e29b-41d4-a716-446655440001|0.0000|5000.0000|5000.0000|credit"))
+
+	got, err := BalanceHistoryChainHash("", entry)
+	if err != nil {
+		t.Fatalf("BalanceHistoryChainHash() error = %v", err)
+	}
+	if want := hex.EncodeToString(sum[:]); got != want {
+		t.Errorf("BalanceHistoryChainHash() = %s, want %s", got, want)
+	}
+}
+
+func TestVerifyBalanceHistoryChain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		balance string
+		history func(t *testing.T) []sqlc.CoreAccountBalanceHistory
+		want    []string
+	}{
+		{
+			name:    "Intact chain",
+			balance: "300",
+			history: func(t *testing.T) []sqlc.CoreAccountBalanceHistory {
+				return balanceHistoryChain(t, "500", "-200")
+			},
+		},
+		{
+			name:    "No history and no balance",
+			balance: "0",
+			history: func(*testing.T) []sqlc.CoreAccountBalanceHistory { return nil },
+		},
+		{
+			name:    "Entry removed from the middle",
+			balance: "350",
+			history: func(t *testing.T) []sqlc.CoreAccountBalanceHistory {
+				history := balanceHistoryChain(t, "500", "-200", "50")
+				return append(history[:1], history[2])
+			},
+			want: []string{DiscrepancySequenceGap, DiscrepancyChainHashMismatch, DiscrepancyBalanceDiscontinuity},
+		},
+		{
+			name:    "Amount altered after the fact",
+			balance: "300",
+			history: func(t *testing.T) []sqlc.CoreAccountBalanceHistory {
+				history := balanceHistoryChain(t, "500", "-200")
+				history[1].BalanceChange = numeric.FromDecimal(decimal.RequireFromString("-100"))
+				return history
+			},
+			want: []string{DiscrepancyChainHashMismatch, DiscrepancyInvalidChange},
+		},
+		{
+			name:    "Transaction applied twice",
+			balance: "300",
+			history: func(t *testing.T) []sqlc.CoreAccountBalanceHistory {
+				history := balanceHistoryChain(t, "500", "-100", "-100")
+				// The replay is chained like any other entry, only its transaction gives it away
+				history[2].TransactionID = history[1].TransactionID
+				hash, err := BalanceHistoryChainHash(history[1].ChainHash, history[2])
+				if err != nil {
+					t.Fatalf("BalanceHistoryChainHash() error = %v", err)
+				}
+				history[2].ChainHash = hash
+				return history
+			},
+			want: []string{DiscrepancyDuplicateApplication},
+		},
+		{
+			name:    "Balance changed without history",
+			balance: "400",
+			history: func(t *testing.T) []sqlc.CoreAccountBalanceHistory {
+				return balanceHistoryChain(t, "500", "-200")
+			},
+			want: []string{DiscrepancyBalanceMismatch},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			discrepancies, err := verifyBalanceHistoryChain(reconciliationAccount(tt.balance), tt.history(t))
+			if err != nil {
+				t.Fatalf("verifyBalanceHistoryChain() error = %v", err)
+			}
+
+			if len(discrepancies) != len(tt.want) {
+				t.Fatalf("verifyBalanceHistoryChain() = %+v, want types %v", discrepancies, tt.want)
+			}
+			for i, discrepancy := range discrepancies {
+				if discrepancy.Type != tt.want[i] {
+					t.Errorf("discrepancy %d type = %s, want %s", i, discrepancy.Type, tt.want[i])
+				}
+				if discrepancy.AccountID != reconciliationAccountID {
+					t.Errorf("discrepancy %d account = %s, want %s", i, discrepancy.AccountID, reconciliationAccountID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrCompensationNotRetryable is returned when a workflow has no compensation left to retry,
+// e.g. because its compensation completed or is still pending
+var ErrCompensationNotRetryable = errors.New("compensation not retryable")
+
+// retryableCompensationStatuses are the outcomes an operator may retry
+var retryableCompensationStatuses = []sqlc.CoreCompensationStatus{
+	sqlc.CoreCompensationStatusFailed,
+	sqlc.CoreCompensationStatusTimeout,
+	sqlc.CoreCompensationStatusManualRequired,
+}
+
+// RetryCompensationParams identifies the compensation to retry by the workflow that recorded it
+type RetryCompensationParams struct {
+	WorkflowID  string `json:"workflow_id"`
+	RequestedBy string `json:"requested_by,omitempty"`
+}
+
+// RetryCompensation credits back what is still owed on the original debit of a failed, timed out or
+// manual_required compensation and marks its audit record completed. The compensation idempotency key is
+// derived from the audit record, so repeating a retry that already went through credits nothing twice.
+func (service *Service) RetryCompensation(ctx context.Context, params RetryCompensationParams) (*sqlc.CoreCompensationAuditTrail, *CompensateDebitResults, error) {
+	const op = "service.Service.RetryCompensation"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":         op,
+		"workflow_id":  params.WorkflowID,
+		"requested_by": params.RequestedBy,
+	})
+
+	records, err := service.GetCompensationAuditByWorkflowID(ctx, params.WorkflowID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("%w: no compensation recorded for workflow %s", ErrCompensationNotRetryable, params.WorkflowID)
+	}
+
+	// Records are newest first; only the latest outcome matters
+	record := records[0]
+	if !slices.Contains(retryableCompensationStatuses, record.CompensationStatus) {
+		return nil, nil, fmt.Errorf("%w: compensation of workflow %s is %s", ErrCompensationNotRetryable, params.WorkflowID, record.CompensationStatus)
+	}
+	if !record.OriginalTransactionID.Valid {
+		return nil, nil, fmt.Errorf("%w: compensation of workflow %s has no original transaction", ErrCompensationNotRetryable, params.WorkflowID)
+	}
+
+	originalTransaction, remaining, err := service.owedCompensation(ctx, record)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !remaining.IsPositive() {
+		return nil, nil, fmt.Errorf("%w: original transaction %s is already fully compensated", ErrCompensationNotRetryable, uuid.UUID(record.OriginalTransactionID.Bytes))
+	}
+
+	reason := fmt.Sprintf("Operator retry of compensation %s", uuid.UUID(record.ID.Bytes))
+
+	result, err := service.creditOwedCompensation(ctx, record, originalTransaction, remaining, reason, params.RequestedBy)
+	if err != nil {
+		failureReason := err.Error()
+		if _, updateErr := service.UpdateCompensationAudit(ctx, params.WorkflowID, CompensationAuditParams{
+			CompensationStatus: string(record.CompensationStatus),
+			FailureReason:      &failureReason,
+		}); updateErr != nil {
+			logger.WithError(updateErr).Warn("Failed to update compensation audit record")
+		}
+
+		err = fmt.Errorf("compensation retry failed: %w", err)
+		logger.WithError(err).Error()
+		return nil, nil, err
+	}
+
+	updated, err := service.UpdateCompensationAudit(ctx, params.WorkflowID, CompensationAuditParams{
+		CompensationStatus:        string(sqlc.CoreCompensationStatusCompleted),
+		CompensationTransactionID: &result.TransactionID,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"transaction_id": result.TransactionID,
+		"amount":         remaining.String(),
+	}).Info("Compensation retried successfully")
+
+	return updated, result, nil
+}
+
+// owedCompensation returns the original debit of record and what is still owed on it: its amount less everything
+// already credited back against it
+func (service *Service) owedCompensation(ctx context.Context, record sqlc.CoreCompensationAuditTrail) (*sqlc.GetTransactionByIDRow, decimal.Decimal, error) {
+	originalTransaction, err := service.store.GetTransactionByID(ctx, record.OriginalTransactionID)
+	if err != nil {
+		return nil, decimal.Zero, fmt.Errorf("failed to get original transaction: %w", err)
+	}
+
+	originalAmount, err := service.pgNumericToDecimal(originalTransaction.Amount)
+	if err != nil {
+		return nil, decimal.Zero, fmt.Errorf("failed to convert original transaction amount: %w", err)
+	}
+
+	pgCompensated, err := service.store.GetCompensatedAmount(ctx, uuid.UUID(record.OriginalTransactionID.Bytes).String())
+	if err != nil {
+		return nil, decimal.Zero, fmt.Errorf("failed to get compensated amount: %w", err)
+	}
+
+	compensated, err := service.pgNumericToDecimal(pgCompensated)
+	if err != nil {
+		return nil, decimal.Zero, fmt.Errorf("failed to convert compensated amount: %w", err)
+	}
+
+	return &originalTransaction, originalAmount.Sub(compensated), nil
+}
+
+// creditOwedCompensation credits remaining back to the account of the original debit of record. The idempotency key
+// is derived from the audit record, so crediting it again after a success credits nothing twice.
+func (service *Service) creditOwedCompensation(
+	ctx context.Context,
+	record sqlc.CoreCompensationAuditTrail,
+	originalTransaction *sqlc.GetTransactionByIDRow,
+	remaining decimal.Decimal,
+	reason string,
+	requestedBy string,
+) (*CompensateDebitResults, error) {
+	originalID := uuid.UUID(record.OriginalTransactionID.Bytes)
+	idempotencyKey := fmt.Sprintf("compensation-retry-%s", uuid.UUID(record.ID.Bytes))
+	accountID := uuid.UUID(originalTransaction.AccountID.Bytes)
+
+	return service.CompensateDebit(ctx, CompensateDebitParams{
+		OriginalTransactionID: &originalID,
+		AccountID:             &accountID,
+		Amount:                remaining,
+		Currency:              string(originalTransaction.Currency),
+		Description:           &reason,
+		ReferenceID:           &record.TransferID.String,
+		IdempotencyKey:        &idempotencyKey,
+		CompensationReason:    &reason,
+		WorkflowID:            &record.WorkflowID,
+		RunID:                 &record.RunID,
+		Metadata: map[string]any{
+			"transfer_id":     record.TransferID.String,
+			"audit_record_id": uuid.UUID(record.ID.Bytes).String(),
+			"retried_by":      requestedBy,
+		},
+	})
+}
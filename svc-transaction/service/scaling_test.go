@@ -0,0 +1,72 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"svc-transaction/util/workerinterceptor"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDesiredWorkers(t *testing.T) {
+	t.Parallel()
+
+	policy := ScalingPolicy{
+		TargetBacklogPerWorker:  100,
+		TargetInFlightPerWorker: 40,
+		MaxBacklogAge:           5 * time.Second,
+		MinWorkers:              1,
+		MaxWorkers:              10,
+	}
+
+	tests := []struct {
+		name       string
+		workers    int
+		backlog    int64
+		backlogAge float64
+		inFlight   float64
+		want       int
+		wantReason string
+	}{
+		{name: "idle", workers: 3, want: 1, wantReason: "idle, raised to min_workers"},
+		{name: "backlog", workers: 1, backlog: 250, want: 3, wantReason: "250 tasks waiting at 100 per worker"},
+		{name: "in_flight", workers: 2, backlog: 50, inFlight: 90, want: 3, wantReason: "90.0 activities in flight at 40 per worker"},
+		{name: "old_backlog", workers: 2, backlog: 10, backlogAge: 12, want: 3, wantReason: "tasks wait 12s for a worker, more than 5s"},
+		{name: "old_backlog_already_scaling", workers: 2, backlog: 400, backlogAge: 12, want: 4, wantReason: "400 tasks waiting at 100 per worker"},
+		{name: "capped", workers: 8, backlog: 5000, want: 10, wantReason: "5000 tasks waiting at 100 per worker, capped at max_workers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, reason := desiredWorkers(policy, tt.workers, tt.backlog, tt.backlogAge, tt.inFlight)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
+
+func TestMeanActivityDuration(t *testing.T) {
+	t.Parallel()
+
+	assert.Zero(t, meanActivityDuration(nil))
+	assert.InDelta(t, 0.25, meanActivityDuration([]workerinterceptor.ActivityLatency{
+		{ActivityType: "DebitAccount", Executions: 3, AvgDurationSeconds: 0.1},
+		{ActivityType: "CreditAccount", Executions: 1, AvgDurationSeconds: 0.7},
+	}), 1e-9)
+}
+
+func TestResolvedScalingPolicy(t *testing.T) {
+	t.Parallel()
+
+	service := &Service{}
+	assert.Equal(t, ScalingPolicy{
+		TargetBacklogPerWorker:  100,
+		TargetInFlightPerWorker: 40,
+		MaxBacklogAge:           5 * time.Second,
+		MinWorkers:              1,
+		Window:                  time.Minute,
+	}, service.resolvedScalingPolicy())
+}
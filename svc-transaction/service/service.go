@@ -1,10 +1,20 @@
 package service
 
 import (
+	"time"
+
+	"svc-transaction/adapter/external_bank_adapter"
 	"svc-transaction/store"
+	"svc-transaction/util/accountnumber"
 	"svc-transaction/util/failure"
+	"svc-transaction/util/latency"
+	"svc-transaction/util/objectstore"
+	"svc-transaction/util/rules"
+
+	"numeric"
 
 	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/client"
 )
 
 type Service struct {
@@ -13,6 +23,47 @@ type Service struct {
 	store store.IStore
 
 	failureSimulator *failure.Simulator
+	latencyInjector  *latency.Injector
+
+	// Switches the failure simulator off when compensations pile up
+	backlogGuard compensationBacklogGuard
+
+	// Set once Temporal is reachable; used to start and observe workflows
+	temporalClient client.Client
+	taskQueue      string
+
+	// How long a closed account is kept before its personal data can be erased
+	erasureRetention time.Duration
+
+	// How long an idempotency key stays registered before the same key can be used for another request
+	idempotencyRetention time.Duration
+
+	// How old a pending transaction has to be before the sweeper resolves it
+	pendingStaleAfter time.Duration
+
+	// Where exported and archived files are written; nil disables ledger exports and settlement file archiving
+	objectStore objectstore.Store
+
+	// Key prefix of ledger export files
+	exportPrefix string
+
+	// Precision and scale every amount must fit before it is stored; zero uses numeric.DefaultPrecision
+	amountPrecision numeric.Precision
+
+	// Per-tenant formats of the account numbers of opened accounts; nil accepts any account number
+	accountNumbers *accountnumber.Validator
+
+	// Soft business rules of core.business_rules; nil validates with rules.Defaults
+	businessRules *rules.Cache
+	// Business rules of severity error only warn, for the learning profile
+	relaxedValidation bool
+
+	// Partner bank that settles transfers leaving the bank; nil rejects every external settlement
+	externalBank        *external_bank_adapter.Adapter
+	externalBankTimeout time.Duration
+
+	// How the desired worker count of the scaling hints is derived; zero fields use the defaults
+	scalingPolicy ScalingPolicy
 }
 
 func NewService(
@@ -25,5 +76,22 @@ func NewService(
 		store: store,
 
 		failureSimulator: failure.NewSimulator(logger),
+		latencyInjector:  latency.NewInjector(logger),
 	}
 }
+
+// SetTemporalClient sets the Temporal client and the task queue of this service's worker (used for delayed connection)
+func (service *Service) SetTemporalClient(temporalClient client.Client, taskQueue string) {
+	service.temporalClient = temporalClient
+	service.taskQueue = taskQueue
+}
+
+// SetObjectStore sets the store exported and archived files are written to
+func (service *Service) SetObjectStore(objectStore objectstore.Store) {
+	service.objectStore = objectStore
+}
+
+// SetAccountNumberValidator sets the per-tenant formats account numbers are checked against when accounts are opened
+func (service *Service) SetAccountNumberValidator(accountNumbers *accountnumber.Validator) {
+	service.accountNumbers = accountNumbers
+}
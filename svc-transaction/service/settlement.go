@@ -0,0 +1,576 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"svc-transaction/store/sqlc"
+	"svc-transaction/util/settlement"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultSettlementBatchSize = 500
+	maxSettlementBatchSize     = 10000
+
+	// settlementArchivePrefix is the object store key prefix of archived settlement files
+	settlementArchivePrefix = "settlement-files"
+)
+
+// ErrNoTransfersToSettle is returned when there are no completed transfers waiting for settlement
+var ErrNoTransfersToSettle = errors.New("no transfers to settle")
+
+// ErrSettlementFileNotFound is returned when no settlement file exists for the given ID
+var ErrSettlementFileNotFound = errors.New("settlement file not found")
+
+// ErrSettlementEntryNotFound is returned when a transfer has not been included in any settlement file
+var ErrSettlementEntryNotFound = errors.New("settlement entry not found")
+
+// ErrSettlementFileAlreadyAcknowledged is returned when an acknowledgement arrives for a file that was already acknowledged
+var ErrSettlementFileAlreadyAcknowledged = errors.New("settlement file already acknowledged")
+
+// ErrInvalidAcknowledgement is returned when an acknowledgement does not match the settlement file it targets
+var ErrInvalidAcknowledgement = errors.New("invalid settlement acknowledgement")
+
+// GenerateSettlementFileParams represents the input parameters for generating a settlement file
+type GenerateSettlementFileParams struct {
+	Format       string `json:"format"`
+	MaxTransfers int    `json:"max_transfers"`
+}
+
+// SettlementFileResults represents a stored settlement file without its content
+type SettlementFileResults struct {
+	ID                       uuid.UUID                `json:"id"`
+	FileReference            string                   `json:"file_reference"`
+	Format                   string                   `json:"format"`
+	Status                   string                   `json:"status"`
+	TransferCount            int32                    `json:"transfer_count"`
+	ControlSum               decimal.Decimal          `json:"control_sum"`
+	Checksum                 string                   `json:"checksum"`
+	AcknowledgementReference *string                  `json:"acknowledgement_reference,omitempty"`
+	AcknowledgedAt           *string                  `json:"acknowledged_at,omitempty"`
+	CreatedAt                string                   `json:"created_at"`
+	Entries                  []SettlementEntryResults `json:"entries,omitempty"`
+}
+
+// SettlementEntryResults represents the settlement status of a single transfer
+type SettlementEntryResults struct {
+	TransferID       string    `json:"transfer_id"`
+	SettlementFileID uuid.UUID `json:"settlement_file_id"`
+	SettlementStatus string    `json:"settlement_status"`
+	RejectionReason  *string   `json:"rejection_reason,omitempty"`
+	SettledAt        *string   `json:"settled_at,omitempty"`
+}
+
+// SettlementFileContent is a settlement file ready to be downloaded
+type SettlementFileContent struct {
+	FileName    string
+	ContentType string
+	Checksum    string
+	Content     []byte
+}
+
+// AcknowledgeSettlementFileParams represents an acknowledgement received for a settlement file.
+// Transfers listed in Transfers take their own status; every other transfer in the file takes the group status.
+type AcknowledgeSettlementFileParams struct {
+	SettlementFileID         uuid.UUID                 `json:"settlement_file_id"`
+	AcknowledgementReference string                    `json:"acknowledgement_reference"`
+	OriginalFileReference    string                    `json:"original_file_reference,omitempty"`
+	Rejected                 bool                      `json:"rejected"`
+	Reason                   string                    `json:"reason,omitempty"`
+	Transfers                []TransferAcknowledgement `json:"transfers,omitempty"`
+}
+
+// TransferAcknowledgement is the acknowledged status of a single transfer, identified by
+// its transfer ID or by the transaction reference written to the file
+type TransferAcknowledgement struct {
+	Reference string `json:"reference"`
+	Rejected  bool   `json:"rejected"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// GenerateSettlementFile batches completed transfers that have not been settled yet into a new settlement file.
+// The file and its entries are written in the same database transaction, so a transfer is never part of two files.
+func (service *Service) GenerateSettlementFile(ctx context.Context, params GenerateSettlementFileParams) (*SettlementFileResults, error) {
+	const op = "service.Service.GenerateSettlementFile"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	format, err := settlement.ParseFormat(params.Format)
+	if err != nil {
+		err = fmt.Errorf("invalid parameters: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	batchSize := params.MaxTransfers
+	if batchSize <= 0 {
+		batchSize = defaultSettlementBatchSize
+	}
+	if batchSize > maxSettlementBatchSize {
+		batchSize = maxSettlementBatchSize
+	}
+
+	var file sqlc.CoreSettlementFile
+	var entries []sqlc.CoreSettlementEntry
+	err = service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		rows, txErr := q.ListUnsettledTransfers(ctx, int32(batchSize))
+		if txErr != nil {
+			return fmt.Errorf("failed to list unsettled transfers: %w", txErr)
+		}
+
+		if len(rows) == 0 {
+			return ErrNoTransfersToSettle
+		}
+
+		batch, txErr := service.buildSettlementBatch(rows)
+		if txErr != nil {
+			return txErr
+		}
+
+		content, txErr := settlement.Render(format, batch)
+		if txErr != nil {
+			return fmt.Errorf("failed to render settlement file: %w", txErr)
+		}
+
+		controlSum, txErr := service.decimalToPgNumeric(batch.ControlSum())
+		if txErr != nil {
+			return fmt.Errorf("failed to convert control sum: %w", txErr)
+		}
+
+		checksum := sha256.Sum256(content)
+
+		file, txErr = q.CreateSettlementFile(ctx, sqlc.CreateSettlementFileParams{
+			FileReference: batch.Reference,
+			Format:        sqlc.CoreSettlementFileFormat(format),
+			TransferCount: int32(len(batch.Entries)),
+			ControlSum:    controlSum,
+			Content:       string(content),
+			Checksum:      hex.EncodeToString(checksum[:]),
+		})
+		if txErr != nil {
+			return fmt.Errorf("failed to store settlement file: %w", txErr)
+		}
+
+		for _, entry := range batch.Entries {
+			created, txErr := q.CreateSettlementEntry(ctx, sqlc.CreateSettlementEntryParams{
+				SettlementFileID: file.ID,
+				TransferID:       entry.TransferID,
+			})
+			if txErr != nil {
+				return fmt.Errorf("failed to create settlement entry for transfer %s: %w", entry.TransferID, txErr)
+			}
+			entries = append(entries, created)
+		}
+
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrNoTransfersToSettle) {
+			logger.Info("No completed transfers waiting for settlement")
+
+			return nil, ErrNoTransfersToSettle
+		}
+
+		err = fmt.Errorf("failed to generate settlement file: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	result, err := service.buildSettlementFileResult(file.ID, file.FileReference, file.Format, file.Status, file.TransferCount, file.ControlSum,
+		file.Checksum, file.AcknowledgementReference, file.AcknowledgedAt, file.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build result: %w", err)
+	}
+	result.Entries = buildSettlementEntryResults(entries)
+
+	service.archiveSettlementFile(ctx, logger, file)
+
+	logger.WithFields(logrus.Fields{
+		"file_reference": result.FileReference,
+		"transfer_count": result.TransferCount,
+		"control_sum":    result.ControlSum.String(),
+	}).Info("📄 Settlement file generated")
+
+	return result, nil
+}
+
+// archiveSettlementFile copies a generated settlement file to the object store, when one is configured, for the
+// bank-facing transfer jobs that pick files up from there. The database copy stays authoritative, so a failed copy
+// is logged and does not fail the generation.
+func (service *Service) archiveSettlementFile(ctx context.Context, logger *logrus.Entry, file sqlc.CoreSettlementFile) {
+	if service.objectStore == nil {
+		return
+	}
+
+	format := settlement.Format(file.Format)
+	key := path.Join(settlementArchivePrefix, fmt.Sprintf("%s.%s", file.FileReference, format.Extension()))
+
+	location, err := service.objectStore.Put(ctx, key, strings.NewReader(file.Content), int64(len(file.Content)), format.ContentType())
+	if err != nil {
+		logger.WithError(err).WithField("key", key).Warn("Failed to archive settlement file")
+
+		return
+	}
+
+	logger.WithField("location", location).Info("Settlement file archived")
+}
+
+// ListSettlementFiles returns the most recent settlement files
+func (service *Service) ListSettlementFiles(ctx context.Context, limit int32) ([]SettlementFileResults, error) {
+	rows, err := service.store.ListSettlementFiles(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list settlement files: %w", err)
+	}
+
+	results := make([]SettlementFileResults, 0, len(rows))
+	for _, row := range rows {
+		result, err := service.buildSettlementFileResult(row.ID, row.FileReference, row.Format, row.Status, row.TransferCount, row.ControlSum,
+			row.Checksum, row.AcknowledgementReference, row.AcknowledgedAt, row.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build result: %w", err)
+		}
+		results = append(results, *result)
+	}
+
+	return results, nil
+}
+
+// GetSettlementFile returns a settlement file together with the settlement status of its transfers
+func (service *Service) GetSettlementFile(ctx context.Context, fileID uuid.UUID) (*SettlementFileResults, error) {
+	file, err := service.getSettlementFile(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := service.store.GetSettlementEntriesByFile(ctx, file.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settlement entries: %w", err)
+	}
+
+	result, err := service.buildSettlementFileResult(file.ID, file.FileReference, file.Format, file.Status, file.TransferCount, file.ControlSum,
+		file.Checksum, file.AcknowledgementReference, file.AcknowledgedAt, file.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build result: %w", err)
+	}
+	result.Entries = buildSettlementEntryResults(entries)
+
+	return result, nil
+}
+
+// DownloadSettlementFile returns the stored content of a settlement file
+func (service *Service) DownloadSettlementFile(ctx context.Context, fileID uuid.UUID) (*SettlementFileContent, error) {
+	file, err := service.getSettlementFile(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	format := settlement.Format(file.Format)
+
+	return &SettlementFileContent{
+		FileName:    fmt.Sprintf("%s.%s", file.FileReference, format.Extension()),
+		ContentType: format.ContentType(),
+		Checksum:    file.Checksum,
+		Content:     []byte(file.Content),
+	}, nil
+}
+
+// GetTransferSettlement returns the settlement status of a single transfer, identified by transfer ID or reference
+func (service *Service) GetTransferSettlement(ctx context.Context, transferID string) (*SettlementEntryResults, error) {
+	transferID, err := service.resolveTransferID(ctx, transferID)
+	if err != nil {
+		if errors.Is(err, ErrTransferReferenceNotFound) {
+			return nil, ErrSettlementEntryNotFound
+		}
+
+		return nil, err
+	}
+
+	entry, err := service.store.GetSettlementEntryByTransferID(ctx, transferID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSettlementEntryNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get settlement entry: %w", err)
+	}
+
+	result := buildSettlementEntryResults([]sqlc.CoreSettlementEntry{entry})[0]
+
+	return &result, nil
+}
+
+// AcknowledgeSettlementFile applies an acknowledgement from the clearing counterparty, marking every transfer
+// in the file as settled or rejected and deriving the file status from the outcome
+func (service *Service) AcknowledgeSettlementFile(ctx context.Context, params AcknowledgeSettlementFileParams) (*SettlementFileResults, error) {
+	const op = "service.Service.AcknowledgeSettlementFile"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	fileID := pgtype.UUID{Bytes: params.SettlementFileID, Valid: true}
+
+	err := service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		file, txErr := q.GetSettlementFileForUpdate(ctx, fileID)
+		if txErr != nil {
+			if errors.Is(txErr, pgx.ErrNoRows) {
+				return ErrSettlementFileNotFound
+			}
+			return fmt.Errorf("failed to lock settlement file: %w", txErr)
+		}
+
+		if file.Status != sqlc.CoreSettlementFileStatusGenerated {
+			return fmt.Errorf("%w: %s is %s", ErrSettlementFileAlreadyAcknowledged, file.FileReference, file.Status)
+		}
+
+		if params.OriginalFileReference != "" && params.OriginalFileReference != file.FileReference {
+			return fmt.Errorf("%w: acknowledgement refers to %s but file is %s", ErrInvalidAcknowledgement, params.OriginalFileReference, file.FileReference)
+		}
+
+		entries, txErr := q.GetSettlementEntriesByFile(ctx, file.ID)
+		if txErr != nil {
+			return fmt.Errorf("failed to get settlement entries: %w", txErr)
+		}
+
+		outcomes, txErr := resolveSettlementOutcomes(entries, params)
+		if txErr != nil {
+			return txErr
+		}
+
+		settledAt := pgtype.Timestamptz{Time: time.Now(), Valid: true}
+		for i, entry := range entries {
+			update := sqlc.UpdateSettlementEntryStatusParams{
+				ID:               entry.ID,
+				SettlementStatus: sqlc.CoreSettlementStatusSettled,
+				SettledAt:        settledAt,
+			}
+			if outcomes[i].Rejected {
+				update.SettlementStatus = sqlc.CoreSettlementStatusRejected
+				update.RejectionReason = pgtype.Text{String: outcomes[i].Reason, Valid: outcomes[i].Reason != ""}
+				update.SettledAt = pgtype.Timestamptz{}
+			}
+
+			if _, txErr := q.UpdateSettlementEntryStatus(ctx, update); txErr != nil {
+				return fmt.Errorf("failed to update settlement entry for transfer %s: %w", entry.TransferID, txErr)
+			}
+		}
+
+		if _, txErr := q.AcknowledgeSettlementFile(ctx, sqlc.AcknowledgeSettlementFileParams{
+			ID:                       file.ID,
+			Status:                   settlementFileStatus(outcomes),
+			AcknowledgementReference: pgtype.Text{String: params.AcknowledgementReference, Valid: params.AcknowledgementReference != ""},
+		}); txErr != nil {
+			return fmt.Errorf("failed to acknowledge settlement file: %w", txErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	result, err := service.GetSettlementFile(ctx, params.SettlementFileID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"file_reference": result.FileReference,
+		"status":         result.Status,
+	}).Info("Settlement file acknowledged")
+
+	return result, nil
+}
+
+// getSettlementFile loads a settlement file, mapping a missing row to ErrSettlementFileNotFound
+func (service *Service) getSettlementFile(ctx context.Context, fileID uuid.UUID) (sqlc.CoreSettlementFile, error) {
+	file, err := service.store.GetSettlementFile(ctx, pgtype.UUID{Bytes: fileID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.CoreSettlementFile{}, ErrSettlementFileNotFound
+		}
+
+		return sqlc.CoreSettlementFile{}, fmt.Errorf("failed to get settlement file: %w", err)
+	}
+
+	return file, nil
+}
+
+// buildSettlementBatch converts unsettled transfer rows into a settlement batch with a fresh file reference
+func (service *Service) buildSettlementBatch(rows []sqlc.ListUnsettledTransfersRow) (settlement.Batch, error) {
+	createdAt := time.Now().UTC()
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return settlement.Batch{}, fmt.Errorf("failed to generate file reference: %w", err)
+	}
+
+	batch := settlement.Batch{
+		Reference: fmt.Sprintf("STL%s%s", createdAt.Format("20060102150405"), hex.EncodeToString(suffix)),
+		CreatedAt: createdAt,
+	}
+
+	for _, row := range rows {
+		amount, err := service.pgNumericToDecimal(row.Amount)
+		if err != nil {
+			return settlement.Batch{}, fmt.Errorf("failed to convert amount of transfer %s: %w", row.TransferID, err)
+		}
+
+		batch.Entries = append(batch.Entries, settlement.Entry{
+			TransferID:        row.TransferID,
+			FromAccountNumber: row.FromAccountNumber,
+			FromAccountName:   row.FromAccountName,
+			ToAccountNumber:   row.ToAccountNumber,
+			ToAccountName:     row.ToAccountName,
+			Amount:            amount,
+			Currency:          string(row.Currency),
+			Description:       row.Description.String,
+			CompletedAt:       row.CompletedAt.Time,
+		})
+	}
+
+	return batch, nil
+}
+
+// buildSettlementFileResult converts settlement file columns to the service result format
+func (service *Service) buildSettlementFileResult(
+	id pgtype.UUID,
+	fileReference string,
+	format sqlc.CoreSettlementFileFormat,
+	status sqlc.CoreSettlementFileStatus,
+	transferCount int32,
+	controlSum pgtype.Numeric,
+	checksum string,
+	acknowledgementReference pgtype.Text,
+	acknowledgedAt pgtype.Timestamptz,
+	createdAt pgtype.Timestamptz,
+) (*SettlementFileResults, error) {
+	sum, err := service.pgNumericToDecimal(controlSum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert control sum: %w", err)
+	}
+
+	result := &SettlementFileResults{
+		ID:            uuid.UUID(id.Bytes),
+		FileReference: fileReference,
+		Format:        string(format),
+		Status:        string(status),
+		TransferCount: transferCount,
+		ControlSum:    sum,
+		Checksum:      checksum,
+		CreatedAt:     createdAt.Time.Format(time.RFC3339),
+	}
+
+	if acknowledgementReference.Valid {
+		result.AcknowledgementReference = &acknowledgementReference.String
+	}
+
+	if acknowledgedAt.Valid {
+		formatted := acknowledgedAt.Time.Format(time.RFC3339)
+		result.AcknowledgedAt = &formatted
+	}
+
+	return result, nil
+}
+
+// buildSettlementEntryResults converts settlement entries to the service result format
+func buildSettlementEntryResults(entries []sqlc.CoreSettlementEntry) []SettlementEntryResults {
+	results := make([]SettlementEntryResults, 0, len(entries))
+	for _, entry := range entries {
+		result := SettlementEntryResults{
+			TransferID:       entry.TransferID,
+			SettlementFileID: uuid.UUID(entry.SettlementFileID.Bytes),
+			SettlementStatus: string(entry.SettlementStatus),
+		}
+
+		if entry.RejectionReason.Valid {
+			result.RejectionReason = &entry.RejectionReason.String
+		}
+
+		if entry.SettledAt.Valid {
+			settledAt := entry.SettledAt.Time.Format(time.RFC3339)
+			result.SettledAt = &settledAt
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// resolveSettlementOutcomes determines the acknowledged outcome of each entry, in the same order as entries.
+// Every transfer referenced by the acknowledgement must belong to the file.
+func resolveSettlementOutcomes(entries []sqlc.CoreSettlementEntry, params AcknowledgeSettlementFileParams) ([]TransferAcknowledgement, error) {
+	outcomes := make([]TransferAcknowledgement, len(entries))
+	for i, entry := range entries {
+		outcomes[i] = TransferAcknowledgement{
+			Reference: entry.TransferID,
+			Rejected:  params.Rejected,
+			Reason:    params.Reason,
+		}
+	}
+
+	for _, transfer := range params.Transfers {
+		matched := false
+		for i, entry := range entries {
+			if settlement.MatchesTransfer(transfer.Reference, entry.TransferID) {
+				outcomes[i].Rejected = transfer.Rejected
+				outcomes[i].Reason = transfer.Reason
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			return nil, fmt.Errorf("%w: transfer %s is not part of the settlement file", ErrInvalidAcknowledgement, transfer.Reference)
+		}
+	}
+
+	return outcomes, nil
+}
+
+// settlementFileStatus derives the file status from the outcome of its transfers
+func settlementFileStatus(outcomes []TransferAcknowledgement) sqlc.CoreSettlementFileStatus {
+	var rejected int
+	for _, outcome := range outcomes {
+		if outcome.Rejected {
+			rejected++
+		}
+	}
+
+	switch rejected {
+	case 0:
+		return sqlc.CoreSettlementFileStatusAcknowledged
+	case len(outcomes):
+		return sqlc.CoreSettlementFileStatusRejected
+	default:
+		return sqlc.CoreSettlementFileStatusPartiallyAcknowledged
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"testing"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSettlementOutcomes(t *testing.T) {
+	t.Parallel()
+
+	entries := []sqlc.CoreSettlementEntry{
+		{TransferID: "0f8fad5b-d9cb-469f-a165-70867728950e"},
+		{TransferID: "7c9e6679-7425-40de-944b-e07fc1f90ae7"},
+	}
+
+	t.Run("group_status_applies_to_unlisted_transfers", func(t *testing.T) {
+		t.Parallel()
+
+		outcomes, err := resolveSettlementOutcomes(entries, AcknowledgeSettlementFileParams{
+			Transfers: []TransferAcknowledgement{
+				{Reference: "7c9e6679742540de944be07fc1f90ae7", Rejected: true, Reason: "Account closed"},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, outcomes, 2)
+
+		assert.False(t, outcomes[0].Rejected)
+		assert.True(t, outcomes[1].Rejected)
+		assert.Equal(t, "Account closed", outcomes[1].Reason)
+		assert.Equal(t, sqlc.CoreSettlementFileStatusPartiallyAcknowledged, settlementFileStatus(outcomes))
+	})
+
+	t.Run("whole_file_rejected", func(t *testing.T) {
+		t.Parallel()
+
+		outcomes, err := resolveSettlementOutcomes(entries, AcknowledgeSettlementFileParams{
+			Rejected: true,
+			Reason:   "Invalid control sum",
+		})
+		require.NoError(t, err)
+
+		for _, outcome := range outcomes {
+			assert.True(t, outcome.Rejected)
+			assert.Equal(t, "Invalid control sum", outcome.Reason)
+		}
+		assert.Equal(t, sqlc.CoreSettlementFileStatusRejected, settlementFileStatus(outcomes))
+	})
+
+	t.Run("all_accepted", func(t *testing.T) {
+		t.Parallel()
+
+		outcomes, err := resolveSettlementOutcomes(entries, AcknowledgeSettlementFileParams{})
+		require.NoError(t, err)
+
+		assert.Equal(t, sqlc.CoreSettlementFileStatusAcknowledged, settlementFileStatus(outcomes))
+	})
+
+	t.Run("unknown_transfer", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := resolveSettlementOutcomes(entries, AcknowledgeSettlementFileParams{
+			Transfers: []TransferAcknowledgement{{Reference: "not-in-file", Rejected: true}},
+		})
+		assert.ErrorIs(t, err, ErrInvalidAcknowledgement)
+	})
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrInvalidTransferApproval is returned when a transfer approval misses a field
+var ErrInvalidTransferApproval = errors.New("invalid transfer approval")
+
+// RecordTransferApprovalParams is an approval accepted by a transfer workflow held for approval
+type RecordTransferApprovalParams struct {
+	TransferID string    `json:"transfer_id"`
+	WorkflowID string    `json:"workflow_id"`
+	ApprovedBy string    `json:"approved_by"`
+	ApprovedAt time.Time `json:"approved_at"` // Workflow time the approval was accepted
+}
+
+// TransferApprovalResults is a recorded approval of a transfer
+type TransferApprovalResults struct {
+	TransferID string `json:"transfer_id"`
+	WorkflowID string `json:"workflow_id"`
+	ApprovedBy string `json:"approved_by"`
+	ApprovedAt string `json:"approved_at"`
+	RecordedAt string `json:"recorded_at"`
+}
+
+// RecordTransferApproval stores who approved a transfer and when. It is idempotent: a second approval of the
+// same transfer by the same approver is ignored, and recorded is false.
+func (service *Service) RecordTransferApproval(ctx context.Context, params RecordTransferApprovalParams) (recorded bool, err error) {
+	const op = "service.Service.RecordTransferApproval"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"transfer_id": params.TransferID,
+		"approved_by": params.ApprovedBy,
+	})
+
+	arg, err := newCreateTransferApprovalParams(params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return false, err
+	}
+
+	rows, err := service.store.CreateTransferApproval(ctx, arg)
+	if err != nil {
+		err = fmt.Errorf("failed to create transfer approval: %w", err)
+
+		logger.WithError(err).Error()
+
+		return false, err
+	}
+
+	if rows == 0 {
+		logger.Info("Transfer approval already recorded")
+
+		return false, nil
+	}
+
+	logger.Info("Transfer approval recorded")
+
+	return true, nil
+}
+
+// ListTransferApprovals returns the recorded approvals of a transfer in the order they were given
+func (service *Service) ListTransferApprovals(ctx context.Context, transferID string) ([]TransferApprovalResults, error) {
+	records, err := service.store.ListTransferApprovals(ctx, transferID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transfer approvals: %w", err)
+	}
+
+	results := make([]TransferApprovalResults, 0, len(records))
+	for _, record := range records {
+		results = append(results, TransferApprovalResults{
+			TransferID: record.TransferID,
+			WorkflowID: record.WorkflowID,
+			ApprovedBy: record.ApprovedBy,
+			ApprovedAt: record.ApprovedAt.Time.Format(time.RFC3339Nano),
+			RecordedAt: record.RecordedAt.Time.Format(time.RFC3339),
+		})
+	}
+
+	return results, nil
+}
+
+// newCreateTransferApprovalParams validates a transfer approval and converts it to its row
+func newCreateTransferApprovalParams(params RecordTransferApprovalParams) (sqlc.CreateTransferApprovalParams, error) {
+	switch {
+	case params.TransferID == "":
+		return sqlc.CreateTransferApprovalParams{}, fmt.Errorf("%w: transfer_id is required", ErrInvalidTransferApproval)
+	case params.WorkflowID == "":
+		return sqlc.CreateTransferApprovalParams{}, fmt.Errorf("%w: workflow_id is required", ErrInvalidTransferApproval)
+	case params.ApprovedBy == "":
+		return sqlc.CreateTransferApprovalParams{}, fmt.Errorf("%w: approved_by is required", ErrInvalidTransferApproval)
+	case len(params.ApprovedBy) > maxOwnerIDLength:
+		return sqlc.CreateTransferApprovalParams{}, fmt.Errorf("%w: approved_by must be at most %d characters", ErrInvalidTransferApproval, maxOwnerIDLength)
+	case params.ApprovedAt.IsZero():
+		return sqlc.CreateTransferApprovalParams{}, fmt.Errorf("%w: approved_at is required", ErrInvalidTransferApproval)
+	}
+
+	return sqlc.CreateTransferApprovalParams{
+		TransferID: params.TransferID,
+		WorkflowID: params.WorkflowID,
+		ApprovedBy: params.ApprovedBy,
+		ApprovedAt: pgtype.Timestamptz{Time: params.ApprovedAt, Valid: true},
+	}, nil
+}
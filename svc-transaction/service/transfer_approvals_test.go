@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"svc-transaction/store"
+	"svc-transaction/store/sqlc"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// transferApprovalStore keeps transfer approvals in memory, ignoring a second approval by the same approver like
+// the unique constraint of core.transfer_approvals
+type transferApprovalStore struct {
+	store.IStore
+
+	approvals []sqlc.CoreTransferApproval
+}
+
+func (store *transferApprovalStore) CreateTransferApproval(_ context.Context, arg sqlc.CreateTransferApprovalParams) (int64, error) {
+	for _, approval := range store.approvals {
+		if approval.TransferID == arg.TransferID && approval.ApprovedBy == arg.ApprovedBy {
+			return 0, nil
+		}
+	}
+
+	store.approvals = append(store.approvals, sqlc.CoreTransferApproval{
+		TransferID: arg.TransferID,
+		WorkflowID: arg.WorkflowID,
+		ApprovedBy: arg.ApprovedBy,
+		ApprovedAt: arg.ApprovedAt,
+		RecordedAt: arg.ApprovedAt,
+	})
+
+	return 1, nil
+}
+
+func (store *transferApprovalStore) ListTransferApprovals(_ context.Context, transferID string) ([]sqlc.CoreTransferApproval, error) {
+	approvals := []sqlc.CoreTransferApproval{}
+	for _, approval := range store.approvals {
+		if approval.TransferID == transferID {
+			approvals = append(approvals, approval)
+		}
+	}
+
+	return approvals, nil
+}
+
+func TestRecordTransferApproval(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	approvals := &transferApprovalStore{}
+	service := &Service{logger: logger, store: approvals}
+
+	approvedAt := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	for _, approver := range []string{"tech-corp-cfo", "tech-corp-ceo", "tech-corp-cfo"} {
+		_, err := service.RecordTransferApproval(context.Background(), RecordTransferApprovalParams{
+			TransferID: "transfer-1",
+			WorkflowID: "transfer_workflow_transfer-1",
+			ApprovedBy: approver,
+			ApprovedAt: approvedAt,
+		})
+		require.NoError(t, err)
+
+		approvedAt = approvedAt.Add(time.Minute)
+	}
+
+	// The repeated approval of tech-corp-cfo is not recorded again
+	results, err := service.ListTransferApprovals(context.Background(), "transfer-1")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "tech-corp-cfo", results[0].ApprovedBy)
+	assert.Equal(t, "tech-corp-ceo", results[1].ApprovedBy)
+	assert.Equal(t, "2026-03-01T09:31:00Z", results[1].ApprovedAt)
+
+	results, err = service.ListTransferApprovals(context.Background(), "transfer-2")
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestNewCreateTransferApprovalParams(t *testing.T) {
+	t.Parallel()
+
+	valid := func() RecordTransferApprovalParams {
+		return RecordTransferApprovalParams{
+			TransferID: "transfer-1",
+			WorkflowID: "transfer_workflow_transfer-1",
+			ApprovedBy: "tech-corp-cfo",
+			ApprovedAt: time.Now(),
+		}
+	}
+
+	_, err := newCreateTransferApprovalParams(valid())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(*RecordTransferApprovalParams)
+	}{
+		{name: "missing transfer_id", modify: func(p *RecordTransferApprovalParams) { p.TransferID = "" }},
+		{name: "missing workflow_id", modify: func(p *RecordTransferApprovalParams) { p.WorkflowID = "" }},
+		{name: "missing approved_by", modify: func(p *RecordTransferApprovalParams) { p.ApprovedBy = "" }},
+		{name: "approved_by too long", modify: func(p *RecordTransferApprovalParams) { p.ApprovedBy = strings.Repeat("a", 101) }},
+		{name: "missing approved_at", modify: func(p *RecordTransferApprovalParams) { p.ApprovedAt = time.Time{} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params := valid()
+			tt.modify(&params)
+
+			_, err := newCreateTransferApprovalParams(params)
+			assert.ErrorIs(t, err, ErrInvalidTransferApproval)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"numeric"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrInvalidTransferEvent is returned when a transfer event misses a field or carries an unknown type or status
+var ErrInvalidTransferEvent = errors.New("invalid transfer event")
+
+// ErrTransferEventsNotFound is returned when no event has been recorded for a transfer
+var ErrTransferEventsNotFound = errors.New("no transfer events recorded")
+
+// Types of the state changes recorded by transfer workflows
+const (
+	TransferEventStarted       = "transfer_started"
+	TransferEventStepCompleted = "step_completed"
+	TransferEventStepFailed    = "step_failed"
+	TransferEventFinished      = "transfer_finished"
+)
+
+// transferEventTypes lists the accepted event types
+var transferEventTypes = map[string]bool{
+	TransferEventStarted:       true,
+	TransferEventStepCompleted: true,
+	TransferEventStepFailed:    true,
+	TransferEventFinished:      true,
+}
+
+// transferEventStatuses lists the transfer statuses an event may leave a transfer in
+var transferEventStatuses = map[string]bool{
+	"processing":  true,
+	"completed":   true,
+	"failed":      true,
+	"compensated": true,
+	"expired":     true,
+}
+
+// RecordTransferEventParams is a state change of a saga transfer as recorded by its workflow
+type RecordTransferEventParams struct {
+	TransferID     string          `json:"transfer_id"`
+	WorkflowID     string          `json:"workflow_id"`
+	RunID          string          `json:"run_id"`
+	SequenceNumber int32           `json:"sequence_number"` // Position within the workflow run, starting at 1
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"` // Status of the transfer after the event
+	Step           string          `json:"step,omitempty"`
+	FromAccount    string          `json:"from_account"`
+	ToAccount      string          `json:"to_account"`
+	Amount         decimal.Decimal `json:"amount"`
+	Currency       string          `json:"currency"`
+	Description    string          `json:"description"`
+	ErrorMessage   string          `json:"error_message,omitempty"`
+	OccurredAt     time.Time       `json:"occurred_at"`
+}
+
+// TransferEventResults is a recorded state change of a saga transfer
+type TransferEventResults struct {
+	TransferID     string `json:"transfer_id"`
+	WorkflowID     string `json:"workflow_id"`
+	RunID          string `json:"run_id"`
+	SequenceNumber int32  `json:"sequence_number"`
+	EventType      string `json:"event_type"`
+	Status         string `json:"status"`
+	Step           string `json:"step,omitempty"`
+	FromAccount    string `json:"from_account"`
+	ToAccount      string `json:"to_account"`
+	Amount         string `json:"amount"` // Decimal string, e.g. "100.50"
+	Currency       string `json:"currency"`
+	Description    string `json:"description"`
+	ErrorMessage   string `json:"error_message,omitempty"`
+	OccurredAt     string `json:"occurred_at"`
+	RecordedAt     string `json:"recorded_at"`
+}
+
+// RecordTransferEvent stores a state change of a saga transfer. It is idempotent: an event already recorded for
+// the same workflow run and sequence number is ignored, and recorded is false.
+func (service *Service) RecordTransferEvent(ctx context.Context, params RecordTransferEventParams) (recorded bool, err error) {
+	const op = "service.Service.RecordTransferEvent"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":            op,
+		"transfer_id":     params.TransferID,
+		"sequence_number": params.SequenceNumber,
+		"event_type":      params.EventType,
+		"status":          params.Status,
+	})
+
+	arg, err := newCreateTransferEventParams(params)
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return false, err
+	}
+
+	rows, err := service.store.CreateTransferEvent(ctx, arg)
+	if err != nil {
+		err = fmt.Errorf("failed to create transfer event: %w", err)
+
+		logger.WithError(err).Error()
+
+		return false, err
+	}
+
+	if rows == 0 {
+		logger.Info("Transfer event already recorded")
+
+		return false, nil
+	}
+
+	logger.Info("Transfer event recorded")
+
+	return true, nil
+}
+
+// ListTransferEvents returns the recorded state changes of a transfer in the order they happened
+func (service *Service) ListTransferEvents(ctx context.Context, transferID string) ([]TransferEventResults, error) {
+	records, err := service.store.ListTransferEvents(ctx, transferID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transfer events: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTransferEventsNotFound, transferID)
+	}
+
+	results := make([]TransferEventResults, 0, len(records))
+	for _, record := range records {
+		result, err := buildTransferEventResult(record)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// newCreateTransferEventParams validates a transfer event and converts it to its row
+func newCreateTransferEventParams(params RecordTransferEventParams) (sqlc.CreateTransferEventParams, error) {
+	switch {
+	case params.TransferID == "":
+		return sqlc.CreateTransferEventParams{}, fmt.Errorf("%w: transfer_id is required", ErrInvalidTransferEvent)
+	case params.WorkflowID == "" || params.RunID == "":
+		return sqlc.CreateTransferEventParams{}, fmt.Errorf("%w: workflow_id and run_id are required", ErrInvalidTransferEvent)
+	case params.SequenceNumber <= 0:
+		return sqlc.CreateTransferEventParams{}, fmt.Errorf("%w: sequence_number must be positive", ErrInvalidTransferEvent)
+	case !transferEventTypes[params.EventType]:
+		return sqlc.CreateTransferEventParams{}, fmt.Errorf("%w: unknown event_type %q", ErrInvalidTransferEvent, params.EventType)
+	case !transferEventStatuses[params.Status]:
+		return sqlc.CreateTransferEventParams{}, fmt.Errorf("%w: unknown status %q", ErrInvalidTransferEvent, params.Status)
+	case params.OccurredAt.IsZero():
+		return sqlc.CreateTransferEventParams{}, fmt.Errorf("%w: occurred_at is required", ErrInvalidTransferEvent)
+	}
+
+	return sqlc.CreateTransferEventParams{
+		TransferID:     params.TransferID,
+		WorkflowID:     params.WorkflowID,
+		RunID:          params.RunID,
+		SequenceNumber: params.SequenceNumber,
+		EventType:      params.EventType,
+		Status:         params.Status,
+		Step:           pgtype.Text{String: params.Step, Valid: params.Step != ""},
+		FromAccount:    params.FromAccount,
+		ToAccount:      params.ToAccount,
+		Amount:         numeric.FromDecimal(params.Amount),
+		Currency:       params.Currency,
+		Description:    params.Description,
+		ErrorMessage:   pgtype.Text{String: params.ErrorMessage, Valid: params.ErrorMessage != ""},
+		OccurredAt:     pgtype.Timestamptz{Time: params.OccurredAt, Valid: true},
+	}, nil
+}
+
+// buildTransferEventResult converts a stored transfer event to the API representation
+func buildTransferEventResult(record sqlc.CoreTransferEvent) (TransferEventResults, error) {
+	amount, err := numeric.ToDecimal(record.Amount)
+	if err != nil {
+		return TransferEventResults{}, fmt.Errorf("invalid transfer event amount: %w", err)
+	}
+
+	return TransferEventResults{
+		TransferID:     record.TransferID,
+		WorkflowID:     record.WorkflowID,
+		RunID:          record.RunID,
+		SequenceNumber: record.SequenceNumber,
+		EventType:      record.EventType,
+		Status:         record.Status,
+		Step:           record.Step.String,
+		FromAccount:    record.FromAccount,
+		ToAccount:      record.ToAccount,
+		Amount:         amount.String(),
+		Currency:       record.Currency,
+		Description:    record.Description,
+		ErrorMessage:   record.ErrorMessage.String,
+		OccurredAt:     record.OccurredAt.Time.Format(time.RFC3339Nano),
+		RecordedAt:     record.RecordedAt.Time.Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"svc-transaction/store"
+	"svc-transaction/store/sqlc"
+
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// transferEventStore keeps transfer events in memory, ignoring repeats of a workflow run's sequence number like
+// the unique constraint of core.transfer_events
+type transferEventStore struct {
+	store.IStore
+
+	events []sqlc.CoreTransferEvent
+}
+
+func (store *transferEventStore) CreateTransferEvent(_ context.Context, arg sqlc.CreateTransferEventParams) (int64, error) {
+	for _, event := range store.events {
+		if event.WorkflowID == arg.WorkflowID && event.RunID == arg.RunID && event.SequenceNumber == arg.SequenceNumber {
+			return 0, nil
+		}
+	}
+
+	store.events = append(store.events, sqlc.CoreTransferEvent{
+		TransferID:     arg.TransferID,
+		WorkflowID:     arg.WorkflowID,
+		RunID:          arg.RunID,
+		SequenceNumber: arg.SequenceNumber,
+		EventType:      arg.EventType,
+		Status:         arg.Status,
+		Step:           arg.Step,
+		FromAccount:    arg.FromAccount,
+		ToAccount:      arg.ToAccount,
+		Amount:         arg.Amount,
+		Currency:       arg.Currency,
+		Description:    arg.Description,
+		ErrorMessage:   arg.ErrorMessage,
+		OccurredAt:     arg.OccurredAt,
+		RecordedAt:     arg.OccurredAt,
+	})
+
+	return 1, nil
+}
+
+func (store *transferEventStore) ListTransferEvents(_ context.Context, transferID string) ([]sqlc.CoreTransferEvent, error) {
+	events := []sqlc.CoreTransferEvent{}
+	for _, event := range store.events {
+		if event.TransferID == transferID {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+func TestRecordTransferEvent(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	events := &transferEventStore{}
+	service := &Service{logger: logger, store: events}
+
+	occurredAt := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	params := RecordTransferEventParams{
+		TransferID:     "transfer-1",
+		WorkflowID:     "transfer_workflow_transfer-1",
+		RunID:          "run-1",
+		SequenceNumber: 2,
+		EventType:      TransferEventStepFailed,
+		Status:         "processing",
+		Step:           "CreditAccount",
+		FromAccount:    "ACC001000001",
+		ToAccount:      "ACC001000002",
+		Amount:         decimal.RequireFromString("100.50"),
+		Currency:       "USD",
+		ErrorMessage:   "account blocked",
+		OccurredAt:     occurredAt,
+	}
+
+	recorded, err := service.RecordTransferEvent(context.Background(), params)
+	require.NoError(t, err)
+	assert.True(t, recorded)
+
+	// A retried activity records the same event again
+	recorded, err = service.RecordTransferEvent(context.Background(), params)
+	require.NoError(t, err)
+	assert.False(t, recorded)
+
+	results, err := service.ListTransferEvents(context.Background(), "transfer-1")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "CreditAccount", results[0].Step)
+	assert.Equal(t, "100.5", results[0].Amount)
+	assert.Equal(t, "account blocked", results[0].ErrorMessage)
+	assert.Equal(t, occurredAt.Format(time.RFC3339Nano), results[0].OccurredAt)
+
+	_, err = service.ListTransferEvents(context.Background(), "transfer-2")
+	assert.ErrorIs(t, err, ErrTransferEventsNotFound)
+}
+
+func TestNewCreateTransferEventParams(t *testing.T) {
+	t.Parallel()
+
+	valid := func() RecordTransferEventParams {
+		return RecordTransferEventParams{
+			TransferID:     "transfer-1",
+			WorkflowID:     "transfer_workflow_transfer-1",
+			RunID:          "run-1",
+			SequenceNumber: 1,
+			EventType:      TransferEventStarted,
+			Status:         "processing",
+			Amount:         decimal.NewFromInt(10),
+			Currency:       "USD",
+			OccurredAt:     time.Now(),
+		}
+	}
+
+	arg, err := newCreateTransferEventParams(valid())
+	require.NoError(t, err)
+	assert.False(t, arg.Step.Valid, "events without a step store NULL")
+	assert.False(t, arg.ErrorMessage.Valid)
+
+	tests := []struct {
+		name   string
+		modify func(*RecordTransferEventParams)
+	}{
+		{name: "missing transfer_id", modify: func(p *RecordTransferEventParams) { p.TransferID = "" }},
+		{name: "missing run_id", modify: func(p *RecordTransferEventParams) { p.RunID = "" }},
+		{name: "zero sequence_number", modify: func(p *RecordTransferEventParams) { p.SequenceNumber = 0 }},
+		{name: "unknown event_type", modify: func(p *RecordTransferEventParams) { p.EventType = "debited" }},
+		{name: "unknown status", modify: func(p *RecordTransferEventParams) { p.Status = "TRANSFER_STATUS_COMPLETED" }},
+		{name: "missing occurred_at", modify: func(p *RecordTransferEventParams) { p.OccurredAt = time.Time{} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			params := valid()
+			tt.modify(&params)
+
+			_, err := newCreateTransferEventParams(params)
+			assert.ErrorIs(t, err, ErrInvalidTransferEvent)
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-transaction/store/sqlc"
+	"svc-transaction/util/pagination"
+
+	"numeric"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// transferReadModelProjection names the checkpoint of the transfer read model in core.projection_checkpoints
+	transferReadModelProjection = "transfer_read_model"
+
+	defaultTransferProjectionBatchSize = 500
+	defaultTransferProjectionInterval  = time.Second
+
+	// transferProjectionSettleDelay keeps the projection behind the newest events. Positions are assigned when an
+	// event is inserted, not when it commits, so a fresh event may still be followed by one with a lower position.
+	transferProjectionSettleDelay = 2 * time.Second
+
+	defaultTransferReadModelLimit = 50
+	maxTransferReadModelLimit     = 500
+)
+
+// ErrTransferNotProjected is returned when the read model has no row for a transfer, because the transfer did not
+// record its events or the projection has not reached them yet
+var ErrTransferNotProjected = errors.New("transfer not found in read model")
+
+// ErrInvalidTransferReadModelQuery is returned for list requests with an unknown status or an invalid range
+var ErrInvalidTransferReadModelQuery = errors.New("invalid transfer read model query")
+
+// ProjectTransferEventsResults reports one pass of the transfer read model projection
+type ProjectTransferEventsResults struct {
+	Events   int   `json:"events"`   // Events read after the checkpoint
+	Applied  int   `json:"applied"`  // Events that changed the read model; older events of a transfer are skipped
+	Position int64 `json:"position"` // Checkpoint after the pass
+}
+
+// TransferReadModelResults is the latest state of a saga transfer, as projected from its events
+type TransferReadModelResults struct {
+	TransferID    string `json:"transfer_id"`
+	WorkflowID    string `json:"workflow_id"`
+	RunID         string `json:"run_id"`
+	Status        string `json:"status"` // processing, completed, failed, compensated or expired
+	LastEventType string `json:"last_event_type"`
+	LastStep      string `json:"last_step,omitempty"`
+	FromAccount   string `json:"from_account"`
+	ToAccount     string `json:"to_account"`
+	Amount        string `json:"amount"` // Decimal string, e.g. "100.50"
+	Currency      string `json:"currency"`
+	Description   string `json:"description"`
+	ErrorMessage  string `json:"error_message,omitempty"`
+	StartedAt     string `json:"started_at"`
+	FinishedAt    string `json:"finished_at,omitempty"`
+	UpdatedAt     string `json:"updated_at"`
+	ProjectedAt   string `json:"projected_at"`
+}
+
+// ListTransferReadModelsParams filters the transfers of the read model. Empty fields do not filter.
+type ListTransferReadModelsParams struct {
+	Account     string     // Source or destination account
+	Status      string     // processing, completed, failed, compensated or expired
+	StartedFrom *time.Time // Inclusive
+	StartedTo   *time.Time // Exclusive
+	Limit       int32      // 0 uses defaultTransferReadModelLimit; capped at maxTransferReadModelLimit
+	Cursor      string     // NextCursor of the previous page; empty for the first page
+}
+
+// ListTransferReadModelsResults is one page of projected transfers, most recently started first
+type ListTransferReadModelsResults struct {
+	Transfers  []TransferReadModelResults `json:"transfers"`
+	NextCursor string                     `json:"next_cursor,omitempty"` // Empty on the last page
+}
+
+// RunTransferProjection keeps core.transfer_read_model up to date with core.transfer_events until ctx is done.
+// A full batch is followed by the next one straight away; otherwise the projection waits interval before polling
+// again. Failed passes are logged and retried, since the checkpoint only moves with the rows it covers.
+func (service *Service) RunTransferProjection(ctx context.Context, interval time.Duration, batchSize int32) {
+	const op = "service.Service.RunTransferProjection"
+
+	if interval <= 0 {
+		interval = defaultTransferProjectionInterval
+	}
+	if batchSize <= 0 {
+		batchSize = defaultTransferProjectionBatchSize
+	}
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"interval":   interval.String(),
+		"batch_size": batchSize,
+	})
+
+	logger.Info("Transfer read model projection started")
+
+	for {
+		wait := interval
+
+		results, err := service.ProjectTransferEvents(ctx, batchSize)
+		if err != nil {
+			logger.WithError(err).Warn("Transfer read model projection failed, retrying")
+		} else if results.Events == int(batchSize) {
+			wait = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Transfer read model projection stopped")
+
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// ProjectTransferEvents applies up to batchSize transfer events recorded after the checkpoint of the read model, in
+// the order they were recorded. The rows and the checkpoint are written in one transaction, so an event is applied
+// exactly once; service instances projecting at the same time fail their transaction rather than skip events.
+func (service *Service) ProjectTransferEvents(ctx context.Context, batchSize int32) (*ProjectTransferEventsResults, error) {
+	const op = "service.Service.ProjectTransferEvents"
+
+	if batchSize <= 0 {
+		batchSize = defaultTransferProjectionBatchSize
+	}
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":       op,
+		"batch_size": batchSize,
+	})
+
+	var results *ProjectTransferEventsResults
+	err := service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		var err error
+		results, err = projectTransferEvents(ctx, q, batchSize, time.Now().Add(-transferProjectionSettleDelay))
+
+		return err
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to project transfer events: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	if results.Events > 0 {
+		logger.WithField("results", fmt.Sprintf("%+v", results)).Debug("Transfer events projected")
+	}
+
+	return results, nil
+}
+
+// projectTransferEvents applies the events recorded before recordedBefore after the checkpoint and moves the
+// checkpoint past them
+func projectTransferEvents(ctx context.Context, q sqlc.Querier, batchSize int32, recordedBefore time.Time) (*ProjectTransferEventsResults, error) {
+	position, err := q.GetProjectionCheckpoint(ctx, transferReadModelProjection)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get projection checkpoint: %w", err)
+	}
+
+	events, err := q.ListTransferEventsAfter(ctx, sqlc.ListTransferEventsAfterParams{
+		AfterPosition:  position,
+		RecordedBefore: pgtype.Timestamptz{Time: recordedBefore, Valid: true},
+		RowLimit:       batchSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transfer events: %w", err)
+	}
+
+	results := &ProjectTransferEventsResults{Events: len(events), Position: position}
+	if len(events) == 0 {
+		return results, nil
+	}
+
+	for _, event := range events {
+		rows, err := q.UpsertTransferReadModel(ctx, newUpsertTransferReadModelParams(event))
+		if err != nil {
+			return nil, fmt.Errorf("failed to project transfer event %d: %w", event.Position, err)
+		}
+
+		results.Applied += int(rows)
+		results.Position = event.Position
+	}
+
+	err = q.UpsertProjectionCheckpoint(ctx, sqlc.UpsertProjectionCheckpointParams{
+		Projection: transferReadModelProjection,
+		Position:   results.Position,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save projection checkpoint: %w", err)
+	}
+
+	return results, nil
+}
+
+// newUpsertTransferReadModelParams converts a transfer event to the read model row it leaves the transfer in
+func newUpsertTransferReadModelParams(event sqlc.CoreTransferEvent) sqlc.UpsertTransferReadModelParams {
+	params := sqlc.UpsertTransferReadModelParams{
+		TransferID:    event.TransferID,
+		WorkflowID:    event.WorkflowID,
+		RunID:         event.RunID,
+		Status:        event.Status,
+		LastEventType: event.EventType,
+		LastStep:      event.Step,
+		FromAccount:   event.FromAccount,
+		ToAccount:     event.ToAccount,
+		Amount:        event.Amount,
+		Currency:      event.Currency,
+		Description:   event.Description,
+		ErrorMessage:  event.ErrorMessage,
+		StartedAt:     event.OccurredAt, // The upsert keeps the earliest
+		UpdatedAt:     event.OccurredAt,
+		EventPosition: event.Position,
+	}
+
+	if event.EventType == TransferEventFinished {
+		params.FinishedAt = event.OccurredAt
+	}
+
+	return params
+}
+
+// GetTransferReadModel returns the projected state of a saga transfer
+func (service *Service) GetTransferReadModel(ctx context.Context, transferID string) (*TransferReadModelResults, error) {
+	record, err := service.store.GetTransferReadModel(ctx, transferID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrTransferNotProjected, transferID)
+		}
+
+		return nil, fmt.Errorf("failed to get transfer read model: %w", err)
+	}
+
+	result, err := buildTransferReadModelResult(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListTransferReadModels returns a page of the projected transfers matching params, most recently started first
+func (service *Service) ListTransferReadModels(ctx context.Context, params ListTransferReadModelsParams) (*ListTransferReadModelsResults, error) {
+	arg, page, err := newListTransferReadModelsParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := service.store.ListTransferReadModels(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transfer read models: %w", err)
+	}
+
+	records, nextCursor := pagination.Next(page, records, func(record sqlc.CoreTransferReadModel) pagination.Cursor {
+		return pagination.Cursor{Time: record.StartedAt.Time, ID: record.TransferID}
+	})
+
+	results := &ListTransferReadModelsResults{
+		Transfers:  make([]TransferReadModelResults, 0, len(records)),
+		NextCursor: nextCursor,
+	}
+	for _, record := range records {
+		result, err := buildTransferReadModelResult(record)
+		if err != nil {
+			return nil, err
+		}
+
+		results.Transfers = append(results.Transfers, result)
+	}
+
+	return results, nil
+}
+
+// newListTransferReadModelsParams validates a list request and converts it to the query arguments and the page
+func newListTransferReadModelsParams(params ListTransferReadModelsParams) (sqlc.ListTransferReadModelsParams, pagination.Page, error) {
+	if params.Status != "" && !transferEventStatuses[params.Status] {
+		return sqlc.ListTransferReadModelsParams{}, pagination.Page{}, fmt.Errorf("%w: unknown status %q", ErrInvalidTransferReadModelQuery, params.Status)
+	}
+
+	if params.StartedFrom != nil && params.StartedTo != nil && !params.StartedFrom.Before(*params.StartedTo) {
+		return sqlc.ListTransferReadModelsParams{}, pagination.Page{}, fmt.Errorf("%w: started_from must be before started_to", ErrInvalidTransferReadModelQuery)
+	}
+
+	if params.Limit < 0 {
+		return sqlc.ListTransferReadModelsParams{}, pagination.Page{}, fmt.Errorf("%w: limit cannot be negative", ErrInvalidTransferReadModelQuery)
+	}
+
+	page, err := pagination.NewPage(params.Cursor, min(params.Limit, maxTransferReadModelLimit), defaultTransferReadModelLimit, maxTransferReadModelLimit)
+	if err != nil {
+		return sqlc.ListTransferReadModelsParams{}, pagination.Page{}, fmt.Errorf("%w: %w", ErrInvalidTransferReadModelQuery, err)
+	}
+
+	arg := sqlc.ListTransferReadModelsParams{
+		Account:  pgtype.Text{String: params.Account, Valid: params.Account != ""},
+		Status:   pgtype.Text{String: params.Status, Valid: params.Status != ""},
+		RowLimit: page.FetchLimit(),
+	}
+	if params.StartedFrom != nil {
+		arg.StartedFrom = pgtype.Timestamptz{Time: *params.StartedFrom, Valid: true}
+	}
+	if params.StartedTo != nil {
+		arg.StartedTo = pgtype.Timestamptz{Time: *params.StartedTo, Valid: true}
+	}
+	if page.After != nil {
+		arg.CursorStartedAt = pgtype.Timestamptz{Time: page.After.Time, Valid: true}
+		arg.CursorTransferID = pgtype.Text{String: page.After.ID, Valid: true}
+	}
+
+	return arg, page, nil
+}
+
+// buildTransferReadModelResult converts a read model row to the API representation
+func buildTransferReadModelResult(record sqlc.CoreTransferReadModel) (TransferReadModelResults, error) {
+	amount, err := numeric.ToDecimal(record.Amount)
+	if err != nil {
+		return TransferReadModelResults{}, fmt.Errorf("invalid transfer read model amount: %w", err)
+	}
+
+	result := TransferReadModelResults{
+		TransferID:    record.TransferID,
+		WorkflowID:    record.WorkflowID,
+		RunID:         record.RunID,
+		Status:        record.Status,
+		LastEventType: record.LastEventType,
+		LastStep:      record.LastStep.String,
+		FromAccount:   record.FromAccount,
+		ToAccount:     record.ToAccount,
+		Amount:        amount.String(),
+		Currency:      record.Currency,
+		Description:   record.Description,
+		ErrorMessage:  record.ErrorMessage.String,
+		StartedAt:     record.StartedAt.Time.Format(time.RFC3339Nano),
+		UpdatedAt:     record.UpdatedAt.Time.Format(time.RFC3339Nano),
+		ProjectedAt:   record.ProjectedAt.Time.Format(time.RFC3339),
+	}
+	if record.FinishedAt.Valid {
+		result.FinishedAt = record.FinishedAt.Time.Format(time.RFC3339Nano)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"svc-transaction/store"
+	"svc-transaction/store/sqlc"
+
+	"numeric"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readModelQuerier keeps the transfer events, the read model and the projection checkpoints in memory, applying
+// the upsert only for newer events like UpsertTransferReadModel
+type readModelQuerier struct {
+	sqlc.Querier
+
+	events      []sqlc.CoreTransferEvent
+	readModel   map[string]sqlc.CoreTransferReadModel
+	checkpoints map[string]int64
+}
+
+func newReadModelQuerier(events ...sqlc.CoreTransferEvent) *readModelQuerier {
+	return &readModelQuerier{
+		events:      events,
+		readModel:   map[string]sqlc.CoreTransferReadModel{},
+		checkpoints: map[string]int64{},
+	}
+}
+
+func (q *readModelQuerier) GetProjectionCheckpoint(_ context.Context, projection string) (int64, error) {
+	position, ok := q.checkpoints[projection]
+	if !ok {
+		return 0, pgx.ErrNoRows
+	}
+
+	return position, nil
+}
+
+func (q *readModelQuerier) UpsertProjectionCheckpoint(_ context.Context, arg sqlc.UpsertProjectionCheckpointParams) error {
+	q.checkpoints[arg.Projection] = arg.Position
+
+	return nil
+}
+
+func (q *readModelQuerier) ListTransferEventsAfter(_ context.Context, arg sqlc.ListTransferEventsAfterParams) ([]sqlc.CoreTransferEvent, error) {
+	events := []sqlc.CoreTransferEvent{}
+	for _, event := range q.events {
+		if event.Position > arg.AfterPosition && event.RecordedAt.Time.Before(arg.RecordedBefore.Time) && len(events) < int(arg.RowLimit) {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+func (q *readModelQuerier) UpsertTransferReadModel(_ context.Context, arg sqlc.UpsertTransferReadModelParams) (int64, error) {
+	row := sqlc.CoreTransferReadModel{
+		TransferID:    arg.TransferID,
+		WorkflowID:    arg.WorkflowID,
+		RunID:         arg.RunID,
+		Status:        arg.Status,
+		LastEventType: arg.LastEventType,
+		LastStep:      arg.LastStep,
+		FromAccount:   arg.FromAccount,
+		ToAccount:     arg.ToAccount,
+		Amount:        arg.Amount,
+		Currency:      arg.Currency,
+		Description:   arg.Description,
+		ErrorMessage:  arg.ErrorMessage,
+		StartedAt:     arg.StartedAt,
+		FinishedAt:    arg.FinishedAt,
+		UpdatedAt:     arg.UpdatedAt,
+		EventPosition: arg.EventPosition,
+	}
+
+	if existing, ok := q.readModel[arg.TransferID]; ok {
+		if existing.EventPosition >= arg.EventPosition {
+			return 0, nil
+		}
+		if existing.StartedAt.Time.Before(row.StartedAt.Time) {
+			row.StartedAt = existing.StartedAt
+		}
+	}
+
+	q.readModel[arg.TransferID] = row
+
+	return 1, nil
+}
+
+func TestProjectTransferEvents(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	event := func(position int64, transferID, eventType, status, step string, offset time.Duration) sqlc.CoreTransferEvent {
+		return sqlc.CoreTransferEvent{
+			Position:       position,
+			TransferID:     transferID,
+			WorkflowID:     "transfer_workflow_" + transferID,
+			RunID:          "run-1",
+			SequenceNumber: int32(position),
+			EventType:      eventType,
+			Status:         status,
+			Step:           pgtype.Text{String: step, Valid: step != ""},
+			FromAccount:    "ACC001000001",
+			ToAccount:      "ACC001000002",
+			Amount:         numeric.FromDecimal(decimal.RequireFromString("100.50")),
+			Currency:       "USD",
+			OccurredAt:     pgtype.Timestamptz{Time: startedAt.Add(offset), Valid: true},
+			RecordedAt:     pgtype.Timestamptz{Time: startedAt.Add(offset), Valid: true},
+		}
+	}
+
+	q := newReadModelQuerier(
+		event(1, "transfer-1", TransferEventStarted, "processing", "", 0),
+		event(2, "transfer-2", TransferEventStarted, "processing", "", time.Second),
+		event(3, "transfer-1", TransferEventStepCompleted, "processing", "DebitAccount", 2*time.Second),
+		event(4, "transfer-1", TransferEventFinished, "completed", "", 3*time.Second),
+		event(5, "transfer-2", TransferEventStepFailed, "processing", "DebitAccount", time.Hour), // Not settled yet
+	)
+	ctx := context.Background()
+	recordedBefore := startedAt.Add(time.Minute)
+
+	results, err := projectTransferEvents(ctx, q, 3, recordedBefore)
+	require.NoError(t, err)
+	assert.Equal(t, &ProjectTransferEventsResults{Events: 3, Applied: 3, Position: 3}, results)
+	assert.Equal(t, "DebitAccount", q.readModel["transfer-1"].LastStep.String)
+	assert.False(t, q.readModel["transfer-1"].FinishedAt.Valid)
+
+	results, err = projectTransferEvents(ctx, q, 3, recordedBefore)
+	require.NoError(t, err)
+	assert.Equal(t, &ProjectTransferEventsResults{Events: 1, Applied: 1, Position: 4}, results)
+
+	transfer := q.readModel["transfer-1"]
+	assert.Equal(t, "completed", transfer.Status)
+	assert.Equal(t, TransferEventFinished, transfer.LastEventType)
+	assert.False(t, transfer.LastStep.Valid)
+	assert.Equal(t, startedAt, transfer.StartedAt.Time, "the start is kept from the first event")
+	assert.Equal(t, startedAt.Add(3*time.Second), transfer.FinishedAt.Time)
+	assert.Equal(t, int64(4), transfer.EventPosition)
+
+	// Nothing left before recordedBefore: the checkpoint stays where it is
+	results, err = projectTransferEvents(ctx, q, 3, recordedBefore)
+	require.NoError(t, err)
+	assert.Equal(t, &ProjectTransferEventsResults{Position: 4}, results)
+	assert.Equal(t, int64(4), q.checkpoints[transferReadModelProjection])
+	assert.Equal(t, "processing", q.readModel["transfer-2"].Status)
+}
+
+func TestNewListTransferReadModelsParams(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	arg, _, err := newListTransferReadModelsParams(ListTransferReadModelsParams{Account: "ACC001000001", StartedFrom: &from})
+	require.NoError(t, err)
+	assert.Equal(t, pgtype.Text{String: "ACC001000001", Valid: true}, arg.Account)
+	assert.False(t, arg.Status.Valid)
+	assert.True(t, arg.StartedFrom.Valid)
+	assert.False(t, arg.StartedTo.Valid)
+	assert.False(t, arg.CursorStartedAt.Valid)
+	assert.Equal(t, int32(defaultTransferReadModelLimit+1), arg.RowLimit, "one extra row tells whether another page follows")
+
+	arg, page, err := newListTransferReadModelsParams(ListTransferReadModelsParams{Status: "compensated", Limit: 10000})
+	require.NoError(t, err)
+	assert.Equal(t, int32(maxTransferReadModelLimit), page.Limit)
+
+	tests := []struct {
+		name   string
+		params ListTransferReadModelsParams
+	}{
+		{name: "unknown status", params: ListTransferReadModelsParams{Status: "TRANSFER_STATUS_COMPLETED"}},
+		{name: "empty range", params: ListTransferReadModelsParams{StartedFrom: &to, StartedTo: &from}},
+		{name: "negative limit", params: ListTransferReadModelsParams{Limit: -1}},
+		{name: "malformed cursor", params: ListTransferReadModelsParams{Cursor: "not-a-cursor"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, err := newListTransferReadModelsParams(tt.params)
+			assert.ErrorIs(t, err, ErrInvalidTransferReadModelQuery)
+		})
+	}
+}
+
+// readModelStore lists the read model rows it holds the way ListTransferReadModels orders and pages them
+type readModelStore struct {
+	store.IStore
+
+	rows []sqlc.CoreTransferReadModel // Most recently started first
+}
+
+func (store *readModelStore) ListTransferReadModels(_ context.Context, arg sqlc.ListTransferReadModelsParams) ([]sqlc.CoreTransferReadModel, error) {
+	rows := []sqlc.CoreTransferReadModel{}
+	for _, row := range store.rows {
+		if arg.CursorStartedAt.Valid {
+			after := row.StartedAt.Time.Before(arg.CursorStartedAt.Time) ||
+				(row.StartedAt.Time.Equal(arg.CursorStartedAt.Time) && row.TransferID < arg.CursorTransferID.String)
+			if !after {
+				continue
+			}
+		}
+		if len(rows) < int(arg.RowLimit) {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+func TestListTransferReadModelsPages(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	row := func(transferID string, offset time.Duration) sqlc.CoreTransferReadModel {
+		return sqlc.CoreTransferReadModel{
+			TransferID: transferID,
+			Status:     "completed",
+			Amount:     numeric.FromDecimal(decimal.RequireFromString("10")),
+			StartedAt:  pgtype.Timestamptz{Time: startedAt.Add(offset), Valid: true},
+		}
+	}
+
+	// transfer-2 and transfer-1 started at the same time, so the transfer ID breaks the tie
+	service := &Service{store: &readModelStore{rows: []sqlc.CoreTransferReadModel{
+		row("transfer-3", time.Minute),
+		row("transfer-2", 0),
+		row("transfer-1", 0),
+	}}}
+
+	var transferIDs []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+
+		results, err := service.ListTransferReadModels(context.Background(), ListTransferReadModelsParams{Limit: 2, Cursor: cursor})
+		require.NoError(t, err)
+
+		for _, transfer := range results.Transfers {
+			transferIDs = append(transferIDs, transfer.TransferID)
+		}
+
+		if results.NextCursor == "" {
+			break
+		}
+		cursor = results.NextCursor
+	}
+
+	assert.Equal(t, []string{"transfer-3", "transfer-2", "transfer-1"}, transferIDs)
+}
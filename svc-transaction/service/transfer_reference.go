@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-transaction/store/sqlc"
+	"svc-transaction/util/reference"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrInvalidTransferReference is returned when a reference is malformed or fails its check digit
+var ErrInvalidTransferReference = errors.New("invalid transfer reference")
+
+// ErrTransferReferenceNotFound is returned when no transfer carries the given reference
+var ErrTransferReferenceNotFound = errors.New("transfer reference not found")
+
+// AssignTransferReferenceParams represents the input parameters for issuing a transfer reference
+type AssignTransferReferenceParams struct {
+	TransferID string `json:"transfer_id"`
+	BranchCode string `json:"branch_code"`
+}
+
+// TransferReferenceResults represents a transfer reference and the transfer it identifies
+type TransferReferenceResults struct {
+	Reference      string `json:"reference"`
+	TransferID     string `json:"transfer_id"`
+	BranchCode     string `json:"branch_code"`
+	BusinessDate   string `json:"business_date"`
+	SequenceNumber int32  `json:"sequence_number"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// AssignTransferReference issues the next reference of the branch for today's business date.
+// It is idempotent: a transfer that already has a reference gets the same one back.
+func (service *Service) AssignTransferReference(ctx context.Context, params AssignTransferReferenceParams) (*TransferReferenceResults, error) {
+	const op = "service.Service.AssignTransferReference"
+
+	logger := service.logger.WithFields(logrus.Fields{
+		"[op]":   op,
+		"params": fmt.Sprintf("%+v", params),
+	})
+
+	logger.Info()
+
+	if params.TransferID == "" {
+		return nil, fmt.Errorf("%w: transfer_id is required", ErrInvalidTransferReference)
+	}
+
+	if err := reference.ValidateBranchCode(params.BranchCode); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTransferReference, err)
+	}
+
+	// Step 1: Return the existing reference if this transfer already has one
+	existing, err := service.GetTransferReferenceByTransferID(ctx, params.TransferID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, ErrTransferReferenceNotFound) {
+		err = fmt.Errorf("failed to check existing reference: %w", err)
+
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	businessDate := time.Now().UTC()
+	pgBusinessDate := pgtype.Date{Time: businessDate, Valid: true}
+
+	// Step 2: Take the next sequence number and store the reference in the same transaction,
+	// so a failed insert does not burn a sequence number
+	var record sqlc.CoreTransferReference
+	err = service.store.WithTx(ctx, func(q sqlc.Querier) error {
+		sequence, err := q.NextTransferReferenceSequence(ctx, sqlc.NextTransferReferenceSequenceParams{
+			BranchCode:   params.BranchCode,
+			BusinessDate: pgBusinessDate,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to take next sequence number: %w", err)
+		}
+
+		ref, err := reference.New(businessDate, params.BranchCode, int(sequence))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidTransferReference, err)
+		}
+
+		record, err = q.CreateTransferReference(ctx, sqlc.CreateTransferReferenceParams{
+			Reference:      ref.String(),
+			TransferID:     params.TransferID,
+			BranchCode:     params.BranchCode,
+			BusinessDate:   pgBusinessDate,
+			SequenceNumber: sequence,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create transfer reference: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.WithError(err).Error()
+
+		return nil, err
+	}
+
+	result := buildTransferReferenceResult(record)
+
+	logger.WithField("reference", result.Reference).Info("Transfer reference assigned")
+
+	return result, nil
+}
+
+// GetTransferReference looks up a transfer by the reference printed on its receipt
+func (service *Service) GetTransferReference(ctx context.Context, value string) (*TransferReferenceResults, error) {
+	ref, err := reference.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTransferReference, err)
+	}
+
+	record, err := service.store.GetTransferReference(ctx, ref.String())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTransferReferenceNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get transfer reference: %w", err)
+	}
+
+	return buildTransferReferenceResult(record), nil
+}
+
+// GetTransferReferenceByTransferID retrieves the reference issued for a transfer
+func (service *Service) GetTransferReferenceByTransferID(ctx context.Context, transferID string) (*TransferReferenceResults, error) {
+	record, err := service.store.GetTransferReferenceByTransferID(ctx, transferID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTransferReferenceNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get transfer reference: %w", err)
+	}
+
+	return buildTransferReferenceResult(record), nil
+}
+
+// resolveTransferID maps a transfer reference to its transfer ID so lookups accept either identifier.
+// Values that are not well-formed references are returned unchanged.
+func (service *Service) resolveTransferID(ctx context.Context, value string) (string, error) {
+	if _, err := reference.Parse(value); err != nil {
+		return value, nil
+	}
+
+	result, err := service.GetTransferReference(ctx, value)
+	if err != nil {
+		return "", err
+	}
+
+	return result.TransferID, nil
+}
+
+// buildTransferReferenceResult converts a stored reference to the API representation
+func buildTransferReferenceResult(record sqlc.CoreTransferReference) *TransferReferenceResults {
+	return &TransferReferenceResults{
+		Reference:      record.Reference,
+		TransferID:     record.TransferID,
+		BranchCode:     record.BranchCode,
+		BusinessDate:   record.BusinessDate.Time.Format(time.DateOnly),
+		SequenceNumber: record.SequenceNumber,
+		CreatedAt:      record.CreatedAt.Time.Format(time.RFC3339),
+	}
+}
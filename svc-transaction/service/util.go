@@ -5,26 +5,72 @@ import (
 
 	"svc-transaction/store/sqlc"
 
+	"numeric"
+
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
 
+// ErrPrecisionExceeded is returned for amounts the numeric columns cannot store as they are
+var ErrPrecisionExceeded = numeric.ErrPrecisionExceeded
+
+// currencyDecimalPlaces mirrors the decimal places of the svc-balance currency catalog
+var currencyDecimalPlaces = map[string]int32{
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"JPY": 0,
+	"CAD": 2,
+	"AUD": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"SGD": 2,
+	"HKD": 2,
+}
+
+// validateAmountScale rejects amounts with more decimal places than the currency allows, e.g. fractional yen.
+// Unknown currencies are left to the account currency checks.
+func validateAmountScale(amount decimal.Decimal, currency string) error {
+	places, ok := currencyDecimalPlaces[currency]
+	if !ok {
+		return nil
+	}
+
+	if !amount.Equal(amount.Truncate(places)) {
+		return fmt.Errorf("amount %s exceeds %d decimal places allowed for %s", amount.String(), places, currency)
+	}
+
+	return nil
+}
+
+// SetAmountPrecision sets the precision and scale amounts are checked against; zero keeps numeric.DefaultPrecision
+func (service *Service) SetAmountPrecision(precision numeric.Precision) {
+	service.amountPrecision = precision
+}
+
+// checkAmountPrecision returns ErrPrecisionExceeded for amounts the numeric columns would round or reject, so they
+// are refused instead of being stored as a different value
+func (service *Service) checkAmountPrecision(field string, amount decimal.Decimal) error {
+	precision := service.amountPrecision
+	if precision == (numeric.Precision{}) {
+		precision = numeric.DefaultPrecision
+	}
+
+	if err := precision.Check(amount); err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+
+	return nil
+}
+
 // decimalToPgNumeric converts a decimal.Decimal to pgtype.Numeric
 func (service *Service) decimalToPgNumeric(d decimal.Decimal) (pgtype.Numeric, error) {
-	return pgtype.Numeric{
-		Int:   d.Coefficient(),
-		Exp:   int32(d.Exponent()),
-		Valid: true,
-	}, nil
+	return numeric.FromDecimal(d), nil
 }
 
 // pgNumericToDecimal converts a pgtype.Numeric to decimal.Decimal
 func (service *Service) pgNumericToDecimal(n pgtype.Numeric) (decimal.Decimal, error) {
-	if !n.Valid {
-		return decimal.Zero, fmt.Errorf("numeric value is not valid")
-	}
-
-	return decimal.NewFromBigInt(n.Int, n.Exp), nil
+	return numeric.ToDecimal(n)
 }
 
 // mapCurrencyToEnum maps a currency string to the corresponding enum value
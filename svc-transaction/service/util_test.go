@@ -0,0 +1,31 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func BenchmarkDecimalToPgNumeric(b *testing.B) {
+	service := createTestService()
+	amount := decimal.RequireFromString("12345.6789")
+
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := service.decimalToPgNumeric(amount); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPgNumericToDecimal(b *testing.B) {
+	service := createTestService()
+	amount := createPgNumeric("12345.6789")
+
+	b.ReportAllocs()
+	for range b.N {
+		if _, err := service.pgNumericToDecimal(amount); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *Store) UpdateAccountStatus(ctx context.Context, arg sqlc.UpdateAccountStatusParams) (sqlc.UpdateAccountStatusRow, error) {
+	defer s.write()()
+
+	account, err := s.updateAccount(arg.ID, func(account *sqlc.CoreAccount) {
+		account.Status = arg.Status
+	})
+	if err != nil {
+		return sqlc.UpdateAccountStatusRow{}, err
+	}
+
+	return sqlc.UpdateAccountStatusRow{ID: account.ID, Status: account.Status, Version: account.Version}, nil
+}
+
+func (s *Store) CreateAccountClosureStep(ctx context.Context, arg sqlc.CreateAccountClosureStepParams) (sqlc.CoreAccountClosureAuditTrail, error) {
+	defer s.write()()
+
+	if _, ok := s.db.tables.accounts[arg.AccountID.Bytes]; !ok {
+		return sqlc.CoreAccountClosureAuditTrail{}, foreignKeyViolation("account_closure_audit_trail_account_id_fkey")
+	}
+	for _, step := range s.db.tables.accountClosureSteps {
+		if step.RunID == arg.RunID && step.Step == arg.Step {
+			return sqlc.CoreAccountClosureAuditTrail{}, uniqueViolation("account_closure_audit_trail_run_id_step_key")
+		}
+	}
+
+	step := sqlc.CoreAccountClosureAuditTrail{
+		ID:         newID(),
+		AccountID:  arg.AccountID,
+		WorkflowID: arg.WorkflowID,
+		RunID:      arg.RunID,
+		Step:       arg.Step,
+		Details:    arg.Details,
+		CreatedAt:  now(),
+	}
+	insert(s, &s.db.tables.accountClosureSteps, step)
+
+	return step, nil
+}
+
+func (s *Store) GetAccountClosureStep(ctx context.Context, arg sqlc.GetAccountClosureStepParams) (sqlc.CoreAccountClosureAuditTrail, error) {
+	defer s.read()()
+
+	for _, step := range s.db.tables.accountClosureSteps {
+		if step.RunID == arg.RunID && step.Step == arg.Step {
+			return step, nil
+		}
+	}
+
+	return sqlc.CoreAccountClosureAuditTrail{}, pgx.ErrNoRows
+}
+
+func (s *Store) ListAccountClosureSteps(ctx context.Context, accountID pgtype.UUID) ([]sqlc.CoreAccountClosureAuditTrail, error) {
+	defer s.read()()
+
+	return filter(s.db.tables.accountClosureSteps, func(step sqlc.CoreAccountClosureAuditTrail) bool {
+		return step.AccountID == accountID
+	}), nil
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *Store) AddAccountOwner(ctx context.Context, arg sqlc.AddAccountOwnerParams) (sqlc.CoreAccountOwner, error) {
+	defer s.write()()
+
+	if _, ok := s.db.tables.accounts[arg.AccountID.Bytes]; !ok {
+		return sqlc.CoreAccountOwner{}, foreignKeyViolation("account_owners_account_id_fkey")
+	}
+
+	key := accountOwnerKey{arg.AccountID.Bytes, arg.OwnerID}
+	if _, ok := s.db.tables.accountOwners[key]; ok {
+		return sqlc.CoreAccountOwner{}, uniqueViolation("account_owners_pkey")
+	}
+
+	owner := sqlc.CoreAccountOwner{
+		AccountID: arg.AccountID,
+		OwnerID:   arg.OwnerID,
+		CreatedAt: now(),
+	}
+	put(s, s.db.tables.accountOwners, key, owner)
+
+	return owner, nil
+}
+
+func (s *Store) ListAccountOwners(ctx context.Context, accountID pgtype.UUID) ([]sqlc.CoreAccountOwner, error) {
+	defer s.read()()
+
+	owners := []sqlc.CoreAccountOwner{}
+	for key, owner := range s.db.tables.accountOwners {
+		if key.accountID == accountID.Bytes {
+			owners = append(owners, owner)
+		}
+	}
+	slices.SortFunc(owners, func(a, b sqlc.CoreAccountOwner) int {
+		if c := a.CreatedAt.Time.Compare(b.CreatedAt.Time); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.OwnerID, b.OwnerID)
+	})
+
+	return owners, nil
+}
+
+func (s *Store) RemoveAccountOwner(ctx context.Context, arg sqlc.RemoveAccountOwnerParams) (int64, error) {
+	defer s.write()()
+
+	if remove(s, s.db.tables.accountOwners, accountOwnerKey{arg.AccountID.Bytes, arg.OwnerID}) {
+		return 1, nil
+	}
+
+	return 0, nil
+}
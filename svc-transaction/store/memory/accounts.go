@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// updateAccount applies change to an account, enforcing the balance check and the trigger_accounts_validation
+// trigger of _init/postgres/03-trigger.sql, and bumps its version
+func (s *Store) updateAccount(id pgtype.UUID, change func(*sqlc.CoreAccount)) (sqlc.CoreAccount, error) {
+	account, ok := s.db.tables.accounts[id.Bytes]
+	if !ok {
+		return sqlc.CoreAccount{}, pgx.ErrNoRows
+	}
+
+	updated := account
+	change(&updated)
+
+	if toDecimal(updated.Balance).IsNegative() {
+		return sqlc.CoreAccount{}, checkViolation("accounts_balance_check")
+	}
+	if !toDecimal(updated.Balance).Equal(toDecimal(account.Balance)) && updated.Status != sqlc.CoreAccountStatusActive {
+		return sqlc.CoreAccount{}, raiseException("Cannot modify balance for account with status: %s", updated.Status)
+	}
+
+	updated.Version++
+	updated.UpdatedAt = now()
+	put(s, s.db.tables.accounts, id.Bytes, updated)
+
+	return updated, nil
+}
+
+func (s *Store) CreateAccount(ctx context.Context, arg sqlc.CreateAccountParams) (sqlc.CoreAccount, error) {
+	defer s.write()()
+
+	for _, account := range s.db.tables.accounts {
+		if account.AccountNumber == arg.AccountNumber {
+			return sqlc.CoreAccount{}, uniqueViolation("accounts_account_number_key")
+		}
+	}
+
+	createdAt := now()
+	account := sqlc.CoreAccount{
+		ID:            newID(),
+		AccountNumber: arg.AccountNumber,
+		AccountName:   arg.AccountName,
+		Balance:       toNumeric(decimal.Zero, 4),
+		Currency:      arg.Currency,
+		Status:        sqlc.CoreAccountStatusActive,
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+		Version:       1,
+		AccountType:   arg.AccountType,
+	}
+	put(s, s.db.tables.accounts, account.ID.Bytes, account)
+
+	return account, nil
+}
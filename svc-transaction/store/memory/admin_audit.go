@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"svc-transaction/store/sqlc"
+)
+
+func (s *Store) CreateAdminAudit(ctx context.Context, arg sqlc.CreateAdminAuditParams) (sqlc.CoreAdminAudit, error) {
+	defer s.write()()
+
+	audit := sqlc.CoreAdminAudit{
+		ID:            newID(),
+		Actor:         arg.Actor,
+		Action:        arg.Action,
+		Service:       arg.Service,
+		ResourceType:  arg.ResourceType,
+		ResourceID:    arg.ResourceID,
+		BeforePayload: arg.BeforePayload,
+		AfterPayload:  arg.AfterPayload,
+		CreatedAt:     now(),
+	}
+	insert(s, &s.db.tables.adminAudits, audit)
+
+	return audit, nil
+}
+
+func (s *Store) ListAdminAudits(ctx context.Context, arg sqlc.ListAdminAuditsParams) ([]sqlc.CoreAdminAudit, error) {
+	defer s.read()()
+
+	audits := filter(s.db.tables.adminAudits, func(audit sqlc.CoreAdminAudit) bool {
+		switch {
+		case arg.Actor.Valid && audit.Actor != arg.Actor.String:
+			return false
+		case arg.Action.Valid && audit.Action != arg.Action.String:
+			return false
+		case arg.ResourceType.Valid && audit.ResourceType != arg.ResourceType.String:
+			return false
+		case arg.ResourceID.Valid && audit.ResourceID != arg.ResourceID:
+			return false
+		case arg.CreatedFrom.Valid && audit.CreatedAt.Time.Before(arg.CreatedFrom.Time):
+			return false
+		case arg.CreatedTo.Valid && !audit.CreatedAt.Time.Before(arg.CreatedTo.Time):
+			return false
+		}
+
+		return true
+	})
+	slices.Reverse(audits)
+
+	return limit(audits, arg.RowLimit), nil
+}
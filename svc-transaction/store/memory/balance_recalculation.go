@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"context"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+func (s *Store) GetAccountLedgerBalance(ctx context.Context, accountID pgtype.UUID) (sqlc.GetAccountLedgerBalanceRow, error) {
+	defer s.read()()
+
+	balance := decimal.Zero
+	var count int64
+	for _, transaction := range s.db.tables.transactions {
+		if transaction.AccountID != accountID || transaction.Status != sqlc.CoreTransactionStatusCompleted {
+			continue
+		}
+		count++
+		if transaction.TransactionType == sqlc.CoreTransactionTypeCredit {
+			balance = balance.Add(toDecimal(transaction.Amount))
+		} else {
+			balance = balance.Sub(toDecimal(transaction.Amount))
+		}
+	}
+
+	return sqlc.GetAccountLedgerBalanceRow{LedgerBalance: toNumeric(balance, 4), TransactionCount: count}, nil
+}
@@ -0,0 +1,16 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"svc-transaction/store/sqlc"
+)
+
+// ListBusinessRules returns the rules seeded by seedTables, already ordered by name and currency: svc-balance
+// manages the rules, and its changes are not seen here
+func (s *Store) ListBusinessRules(ctx context.Context) ([]sqlc.CoreBusinessRule, error) {
+	defer s.read()()
+
+	return slices.Clone(s.db.tables.businessRules), nil
+}
@@ -0,0 +1,218 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// newestCompensationAudits returns the compensation audits matching keep, the latest first
+func (s *Store) newestCompensationAudits(keep func(sqlc.CoreCompensationAuditTrail) bool) []sqlc.CoreCompensationAuditTrail {
+	audits := filter(s.db.tables.compensationAudits, keep)
+	slices.Reverse(audits)
+
+	return audits
+}
+
+// compensationStats counts the compensation audits matching keep by status
+func (s *Store) compensationStats(keep func(sqlc.CoreCompensationAuditTrail) bool) sqlc.GetCompensationStatsRow {
+	row := sqlc.GetCompensationStatsRow{}
+	var attempts int64
+	for _, audit := range s.db.tables.compensationAudits {
+		if !keep(audit) {
+			continue
+		}
+
+		row.TotalCompensations++
+		attempts += int64(audit.CompensationAttempts)
+		switch audit.CompensationStatus {
+		case sqlc.CoreCompensationStatusCompleted:
+			row.CompletedCompensations++
+		case sqlc.CoreCompensationStatusFailed:
+			row.FailedCompensations++
+		case sqlc.CoreCompensationStatusTimeout:
+			row.TimeoutCompensations++
+		case sqlc.CoreCompensationStatusManualRequired:
+			row.ManualCompensations++
+		case sqlc.CoreCompensationStatusPending:
+			row.PendingCompensations++
+		}
+	}
+	if row.TotalCompensations > 0 {
+		row.AvgAttempts = float64(attempts) / float64(row.TotalCompensations)
+	}
+
+	return row
+}
+
+func (s *Store) CreateCompensationAudit(ctx context.Context, arg sqlc.CreateCompensationAuditParams) (sqlc.CoreCompensationAuditTrail, error) {
+	defer s.write()()
+
+	createdAt := now()
+	audit := sqlc.CoreCompensationAuditTrail{
+		ID:                    newID(),
+		WorkflowID:            arg.WorkflowID,
+		RunID:                 arg.RunID,
+		TransferID:            arg.TransferID,
+		OriginalTransactionID: arg.OriginalTransactionID,
+		CompensationReason:    arg.CompensationReason,
+		CompensationType:      arg.CompensationType,
+		CompensationStatus:    arg.CompensationStatus,
+		CreatedAt:             createdAt,
+		UpdatedAt:             createdAt,
+		Metadata:              arg.Metadata,
+	}
+	insert(s, &s.db.tables.compensationAudits, audit)
+
+	return audit, nil
+}
+
+// UpdateCompensationAudit updates every audit of the workflow and returns the first one, like the UPDATE it mirrors
+func (s *Store) UpdateCompensationAudit(ctx context.Context, arg sqlc.UpdateCompensationAuditParams) (sqlc.CoreCompensationAuditTrail, error) {
+	defer s.write()()
+
+	var updated []sqlc.CoreCompensationAuditTrail
+	for i, audit := range s.db.tables.compensationAudits {
+		if audit.WorkflowID != arg.WorkflowID {
+			continue
+		}
+
+		audit.CompensationStatus = arg.CompensationStatus
+		audit.CompensationTransactionID = arg.CompensationTransactionID
+		audit.CompensationAttempts++
+		audit.FailureReason = arg.FailureReason
+		audit.TimeoutDurationMs = arg.TimeoutDurationMs
+		audit.UpdatedAt = now()
+		switch arg.CompensationStatus {
+		case sqlc.CoreCompensationStatusCompleted, sqlc.CoreCompensationStatusFailed, sqlc.CoreCompensationStatusTimeout, sqlc.CoreCompensationStatusManualRequired:
+			audit.CompletedAt = audit.UpdatedAt
+		}
+		update(s, &s.db.tables.compensationAudits, i, audit)
+		updated = append(updated, audit)
+	}
+
+	if len(updated) == 0 {
+		return sqlc.CoreCompensationAuditTrail{}, pgx.ErrNoRows
+	}
+
+	return updated[0], nil
+}
+
+func (s *Store) GetCompensationAuditByWorkflowID(ctx context.Context, workflowID string) ([]sqlc.CoreCompensationAuditTrail, error) {
+	defer s.read()()
+
+	return s.newestCompensationAudits(func(audit sqlc.CoreCompensationAuditTrail) bool {
+		return audit.WorkflowID == workflowID
+	}), nil
+}
+
+func (s *Store) GetCompensationAuditByTransferID(ctx context.Context, transferID pgtype.Text) ([]sqlc.CoreCompensationAuditTrail, error) {
+	defer s.read()()
+
+	return s.newestCompensationAudits(func(audit sqlc.CoreCompensationAuditTrail) bool {
+		return transferID.Valid && audit.TransferID == transferID
+	}), nil
+}
+
+func (s *Store) GetPendingCompensations(ctx context.Context, n int32) ([]sqlc.CoreCompensationAuditTrail, error) {
+	defer s.read()()
+
+	createdBefore := time.Now().Add(-5 * time.Minute)
+
+	return limit(filter(s.db.tables.compensationAudits, func(audit sqlc.CoreCompensationAuditTrail) bool {
+		return audit.CompensationStatus == sqlc.CoreCompensationStatusPending && audit.CreatedAt.Time.Before(createdBefore)
+	}), n), nil
+}
+
+func (s *Store) GetCompensationStats(ctx context.Context) (sqlc.GetCompensationStatsRow, error) {
+	defer s.read()()
+
+	createdFrom := time.Now().Add(-24 * time.Hour)
+
+	return s.compensationStats(func(audit sqlc.CoreCompensationAuditTrail) bool {
+		return !audit.CreatedAt.Time.Before(createdFrom)
+	}), nil
+}
+
+func (s *Store) GetCompensationStatsFiltered(ctx context.Context, arg sqlc.GetCompensationStatsFilteredParams) (sqlc.GetCompensationStatsFilteredRow, error) {
+	defer s.read()()
+
+	return sqlc.GetCompensationStatsFilteredRow(s.compensationStats(func(audit sqlc.CoreCompensationAuditTrail) bool {
+		switch {
+		case arg.CompensationStatus.Valid && audit.CompensationStatus != arg.CompensationStatus.CoreCompensationStatus:
+			return false
+		case arg.CompensationType.Valid && audit.CompensationType != arg.CompensationType.CoreCompensationType:
+			return false
+		}
+
+		return !audit.CreatedAt.Time.Before(arg.CreatedFrom.Time) && audit.CreatedAt.Time.Before(arg.CreatedTo.Time)
+	})), nil
+}
+
+func (s *Store) GetFailedCompensationsByTimeoutDuration(ctx context.Context, arg sqlc.GetFailedCompensationsByTimeoutDurationParams) ([]sqlc.GetFailedCompensationsByTimeoutDurationRow, error) {
+	defer s.read()()
+
+	audits := s.newestCompensationAudits(func(audit sqlc.CoreCompensationAuditTrail) bool {
+		return audit.CompensationStatus == sqlc.CoreCompensationStatusTimeout &&
+			audit.TimeoutDurationMs.Valid && arg.TimeoutDurationMs.Valid &&
+			audit.TimeoutDurationMs.Int32 > arg.TimeoutDurationMs.Int32
+	})
+	// ORDER BY timeout_duration_ms DESC, created_at DESC
+	slices.SortStableFunc(audits, func(a, b sqlc.CoreCompensationAuditTrail) int {
+		return int(b.TimeoutDurationMs.Int32) - int(a.TimeoutDurationMs.Int32)
+	})
+
+	rows := []sqlc.GetFailedCompensationsByTimeoutDurationRow{}
+	for _, audit := range limit(audits, arg.Limit) {
+		rows = append(rows, sqlc.GetFailedCompensationsByTimeoutDurationRow{
+			WorkflowID:           audit.WorkflowID,
+			TransferID:           audit.TransferID,
+			CompensationReason:   audit.CompensationReason,
+			TimeoutDurationMs:    audit.TimeoutDurationMs,
+			CompensationAttempts: audit.CompensationAttempts,
+			CreatedAt:            audit.CreatedAt,
+			FailureReason:        audit.FailureReason,
+		})
+	}
+
+	return rows, nil
+}
+
+func (s *Store) ListCompensationAudits(ctx context.Context, arg sqlc.ListCompensationAuditsParams) ([]sqlc.CoreCompensationAuditTrail, error) {
+	defer s.read()()
+
+	return limit(s.newestCompensationAudits(func(audit sqlc.CoreCompensationAuditTrail) bool {
+		switch {
+		case arg.CompensationStatus.Valid && audit.CompensationStatus != arg.CompensationStatus.CoreCompensationStatus:
+			return false
+		case arg.CompensationType.Valid && audit.CompensationType != arg.CompensationType.CoreCompensationType:
+			return false
+		case arg.WorkflowID.Valid && audit.WorkflowID != arg.WorkflowID.String:
+			return false
+		case arg.CreatedFrom.Valid && audit.CreatedAt.Time.Before(arg.CreatedFrom.Time):
+			return false
+		case arg.CreatedTo.Valid && !audit.CreatedAt.Time.Before(arg.CreatedTo.Time):
+			return false
+		}
+
+		return true
+	}), arg.RowLimit), nil
+}
+
+func (s *Store) CountCompensationBacklog(ctx context.Context, createdFrom pgtype.Timestamptz) (int64, error) {
+	defer s.read()()
+
+	var count int64
+	for _, audit := range s.db.tables.compensationAudits {
+		if audit.CompensationStatus != sqlc.CoreCompensationStatusCompleted && !audit.CreatedAt.Time.Before(createdFrom.Time) {
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *Store) GetIdempotencyKey(ctx context.Context, idempotencyKey string) (sqlc.CoreIdempotencyKey, error) {
+	defer s.read()()
+
+	key, ok := s.db.tables.idempotencyKeys[idempotencyKey]
+	if !ok || !key.ExpiresAt.Time.After(time.Now()) {
+		return sqlc.CoreIdempotencyKey{}, pgx.ErrNoRows
+	}
+
+	return key, nil
+}
+
+// CreateIdempotencyKey registers a key, taking over an expired registration. It returns pgx.ErrNoRows while the key
+// is still registered.
+func (s *Store) CreateIdempotencyKey(ctx context.Context, arg sqlc.CreateIdempotencyKeyParams) (sqlc.CoreIdempotencyKey, error) {
+	defer s.write()()
+
+	if existing, ok := s.db.tables.idempotencyKeys[arg.IdempotencyKey]; ok && existing.ExpiresAt.Time.After(time.Now()) {
+		return sqlc.CoreIdempotencyKey{}, pgx.ErrNoRows
+	}
+
+	key := sqlc.CoreIdempotencyKey{
+		IdempotencyKey:   arg.IdempotencyKey,
+		Scope:            arg.Scope,
+		RequestHash:      arg.RequestHash,
+		ResponseSnapshot: arg.ResponseSnapshot,
+		CreatedAt:        now(),
+		ExpiresAt:        arg.ExpiresAt,
+	}
+	put(s, s.db.tables.idempotencyKeys, key.IdempotencyKey, key)
+
+	return key, nil
+}
+
+func (s *Store) DeleteExpiredIdempotencyKeys(ctx context.Context, n int32) (int64, error) {
+	defer s.write()()
+
+	expired := []sqlc.CoreIdempotencyKey{}
+	for _, key := range s.db.tables.idempotencyKeys {
+		if !key.ExpiresAt.Time.After(time.Now()) {
+			expired = append(expired, key)
+		}
+	}
+	slices.SortFunc(expired, func(a, b sqlc.CoreIdempotencyKey) int {
+		return a.ExpiresAt.Time.Compare(b.ExpiresAt.Time)
+	})
+
+	var deleted int64
+	for _, key := range limit(expired, n) {
+		if remove(s, s.db.tables.idempotencyKeys, key.IdempotencyKey) {
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AcquireAccountLock does nothing: transactions already run one at a time
+func (s *Store) AcquireAccountLock(ctx context.Context, accountID string) error {
+	return nil
+}
+
+// GetAccountForUpdate is GetAccountByID: transactions already run one at a time
+func (s *Store) GetAccountForUpdate(ctx context.Context, id pgtype.UUID) (sqlc.CoreAccount, error) {
+	return s.GetAccountByID(ctx, id)
+}
+
+func (s *Store) AdjustAccountBalance(ctx context.Context, arg sqlc.AdjustAccountBalanceParams) (sqlc.AdjustAccountBalanceRow, error) {
+	defer s.write()()
+
+	account, err := s.updateAccount(arg.ID, func(account *sqlc.CoreAccount) {
+		account.Balance = toNumeric(toDecimal(account.Balance).Add(toDecimal(arg.BalanceChange)), 4)
+	})
+	if err != nil {
+		return sqlc.AdjustAccountBalanceRow{}, err
+	}
+
+	return sqlc.AdjustAccountBalanceRow{ID: account.ID, Balance: account.Balance, Version: account.Version}, nil
+}
+
+func (s *Store) CreateTransfer(ctx context.Context, arg sqlc.CreateTransferParams) (sqlc.CoreTransfer, error) {
+	defer s.write()()
+
+	if _, ok := s.db.tables.transfers[arg.TransferID]; ok {
+		return sqlc.CoreTransfer{}, uniqueViolation("transfers_transfer_id_key")
+	}
+	if _, ok := s.db.tables.accounts[arg.FromAccountID.Bytes]; !ok {
+		return sqlc.CoreTransfer{}, foreignKeyViolation("fk_transfers_from_account")
+	}
+	if _, ok := s.db.tables.accounts[arg.ToAccountID.Bytes]; !ok {
+		return sqlc.CoreTransfer{}, foreignKeyViolation("fk_transfers_to_account")
+	}
+	if arg.FromAccountID == arg.ToAccountID {
+		return sqlc.CoreTransfer{}, checkViolation("chk_transfers_different_accounts")
+	}
+	if !toDecimal(arg.Amount).IsPositive() {
+		return sqlc.CoreTransfer{}, checkViolation("transfers_amount_check")
+	}
+
+	createdAt := now()
+	transfer := sqlc.CoreTransfer{
+		ID:                  newID(),
+		TransferID:          arg.TransferID,
+		FromAccountID:       arg.FromAccountID,
+		ToAccountID:         arg.ToAccountID,
+		Amount:              toNumeric(toDecimal(arg.Amount), 4),
+		Currency:            arg.Currency,
+		Description:         arg.Description,
+		Status:              arg.Status,
+		DebitTransactionID:  arg.DebitTransactionID,
+		CreditTransactionID: arg.CreditTransactionID,
+		CreatedAt:           createdAt,
+		UpdatedAt:           createdAt,
+		Metadata:            arg.Metadata,
+	}
+	put(s, s.db.tables.transfers, transfer.TransferID, transfer)
+
+	return transfer, nil
+}
+
+func (s *Store) GetTransferByTransferID(ctx context.Context, transferID string) (sqlc.CoreTransfer, error) {
+	defer s.read()()
+
+	transfer, ok := s.db.tables.transfers[transferID]
+	if !ok {
+		return sqlc.CoreTransfer{}, pgx.ErrNoRows
+	}
+
+	return transfer, nil
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"context"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *Store) ListStalePendingTransactions(ctx context.Context, arg sqlc.ListStalePendingTransactionsParams) ([]sqlc.ListStalePendingTransactionsRow, error) {
+	defer s.read()()
+
+	rows := []sqlc.ListStalePendingTransactionsRow{}
+	for _, transaction := range limit(filter(s.db.tables.transactions, func(transaction sqlc.CoreTransaction) bool {
+		return transaction.Status == sqlc.CoreTransactionStatusPending && transaction.CreatedAt.Time.Before(arg.StaleBefore.Time)
+	}), arg.RowLimit) {
+		rows = append(rows, sqlc.ListStalePendingTransactionsRow{
+			ID:              transaction.ID,
+			AccountID:       transaction.AccountID,
+			TransactionType: transaction.TransactionType,
+			Amount:          transaction.Amount,
+			Currency:        transaction.Currency,
+			IdempotencyKey:  transaction.IdempotencyKey,
+			CreatedAt:       transaction.CreatedAt,
+		})
+	}
+
+	return rows, nil
+}
+
+func (s *Store) CountPendingTransactions(ctx context.Context, staleBefore pgtype.Timestamptz) (sqlc.CountPendingTransactionsRow, error) {
+	defer s.read()()
+
+	row := sqlc.CountPendingTransactionsRow{}
+	for _, transaction := range s.db.tables.transactions {
+		if transaction.Status != sqlc.CoreTransactionStatusPending {
+			continue
+		}
+		row.Pending++
+		if transaction.CreatedAt.Time.Before(staleBefore.Time) {
+			row.Stale++
+		}
+	}
+
+	return row, nil
+}
@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *Store) ListAccountsForReconciliation(ctx context.Context, accountID pgtype.UUID) ([]sqlc.CoreAccount, error) {
+	defer s.read()()
+
+	accounts := []sqlc.CoreAccount{}
+	for _, account := range s.db.tables.accounts {
+		if !accountID.Valid || account.ID == accountID {
+			accounts = append(accounts, account)
+		}
+	}
+	slices.SortFunc(accounts, func(a, b sqlc.CoreAccount) int {
+		return strings.Compare(a.AccountNumber, b.AccountNumber)
+	})
+
+	return accounts, nil
+}
+
+// ListBalanceHistoryChain returns the history of the account in insertion order, which is chain order
+func (s *Store) ListBalanceHistoryChain(ctx context.Context, accountID pgtype.UUID) ([]sqlc.CoreAccountBalanceHistory, error) {
+	defer s.read()()
+
+	return filter(s.db.tables.balanceHistory, func(entry sqlc.CoreAccountBalanceHistory) bool {
+		return entry.AccountID == accountID
+	}), nil
+}
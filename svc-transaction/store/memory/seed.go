@@ -0,0 +1,182 @@
+package memory
+
+import (
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// seedTables returns the tables with the reference data of _init/postgres/04-data.sql and, with demoData set, the
+// demo data of _init/postgres/seed/demo-data.sql, like the database init scripts outside the realistic profile.
+// svc-balance seeds the same accounts, so both services start from the same balances.
+func seedTables(demoData bool) tables {
+	t := tables{
+		accounts:                   map[[16]byte]sqlc.CoreAccount{},
+		accountOwners:              map[accountOwnerKey]sqlc.CoreAccountOwner{},
+		idempotencyKeys:            map[string]sqlc.CoreIdempotencyKey{},
+		projectionCheckpoints:      map[string]int64{},
+		transfers:                  map[string]sqlc.CoreTransfer{},
+		transferApprovals:          map[transferApprovalKey]sqlc.CoreTransferApproval{},
+		transferEventKeys:          map[transferEventKey]struct{}{},
+		transferReadModels:         map[string]sqlc.CoreTransferReadModel{},
+		transferReferenceSequences: map[transferReferenceSequenceKey]int32{},
+	}
+
+	createdAt := now()
+
+	for _, rule := range []struct {
+		name, kind, threshold string
+	}{
+		{"name_format", "min_account_name_length", "2"},
+		{"transaction_limits", "max_transaction_amount", "100000.0000"},
+	} {
+		t.businessRules = append(t.businessRules, sqlc.CoreBusinessRule{
+			ID:        newID(),
+			Name:      rule.name,
+			Kind:      rule.kind,
+			Threshold: toNumeric(decimal.RequireFromString(rule.threshold), 4),
+			Severity:  "warning",
+			Enabled:   true,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		})
+	}
+
+	if !demoData {
+		return t
+	}
+
+	for _, account := range []struct {
+		id, number, name, balance string
+		currency                  sqlc.CoreCurrencyCode
+		status                    sqlc.CoreAccountStatus
+		accountType               sqlc.CoreAccountType
+	}{
+		{"550e8400-e29b-41d4-a716-446655440001", "ACC001", "John Doe Primary Account", "5000", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440002", "ACC002", "Jane Smith Savings Account", "10000", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeSavings},
+		{"550e8400-e29b-41d4-a716-446655440003", "ACC003", "Business Account - Tech Corp", "25000", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440004", "ACC004", "Alice Johnson EUR Account", "7500", sqlc.CoreCurrencyCodeEUR, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440005", "ACC005", "Bob Wilson GBP Account", "3000", sqlc.CoreCurrencyCodeGBP, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440006", "ACC006", "Test Account - Low Balance", "100", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440007", "ACC007", "Suspended Account", "1000", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusSuspended, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440008", "ACC008", "Corporate Account - BigCorp", "50000", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440009", "ACC009", "Zero Balance Account", "0", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+		{"550e8400-e29b-41d4-a716-446655440010", "ACC010", "High Balance Account", "100000", sqlc.CoreCurrencyCodeUSD, sqlc.CoreAccountStatusActive, sqlc.CoreAccountTypeChecking},
+	} {
+		id := pgtype.UUID{Bytes: uuid.MustParse(account.id), Valid: true}
+		t.accounts[id.Bytes] = sqlc.CoreAccount{
+			ID:            id,
+			AccountNumber: account.number,
+			AccountName:   account.name,
+			Balance:       toNumeric(decimal.RequireFromString(account.balance), 4),
+			Currency:      account.currency,
+			Status:        account.status,
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt,
+			Version:       1,
+			AccountType:   account.accountType,
+		}
+	}
+
+	// The owners of the Tech Corp account, who must both approve its large transfers
+	techCorp := uuid.MustParse("550e8400-e29b-41d4-a716-446655440003")
+	for i, ownerID := range []string{"tech-corp-cfo", "tech-corp-ceo"} {
+		t.accountOwners[accountOwnerKey{techCorp, ownerID}] = sqlc.CoreAccountOwner{
+			AccountID: pgtype.UUID{Bytes: techCorp, Valid: true},
+			OwnerID:   ownerID,
+			// Listed in the order they were added
+			CreatedAt: pgtype.Timestamptz{Time: createdAt.Time.Add(time.Duration(i) * time.Microsecond), Valid: true},
+		}
+	}
+
+	// Days ago each transaction completed; like the demo data, all of them were created at startup
+	for _, transaction := range []struct {
+		id, accountID, amount, description string
+		transactionType                    sqlc.CoreTransactionType
+		completedDaysAgo                   int
+	}{
+		{"660e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440001", "5000", "Initial deposit", sqlc.CoreTransactionTypeCredit, 30},
+		{"660e8400-e29b-41d4-a716-446655440002", "550e8400-e29b-41d4-a716-446655440002", "10000", "Initial deposit", sqlc.CoreTransactionTypeCredit, 25},
+		{"660e8400-e29b-41d4-a716-446655440003", "550e8400-e29b-41d4-a716-446655440003", "25000", "Business initial funding", sqlc.CoreTransactionTypeCredit, 20},
+		{"660e8400-e29b-41d4-a716-446655440004", "550e8400-e29b-41d4-a716-446655440001", "200", "ATM withdrawal", sqlc.CoreTransactionTypeDebit, 15},
+		{"660e8400-e29b-41d4-a716-446655440005", "550e8400-e29b-41d4-a716-446655440001", "200", "Salary deposit", sqlc.CoreTransactionTypeCredit, 10},
+		{"660e8400-e29b-41d4-a716-446655440006", "550e8400-e29b-41d4-a716-446655440002", "500", "Online purchase", sqlc.CoreTransactionTypeDebit, 8},
+		{"660e8400-e29b-41d4-a716-446655440007", "550e8400-e29b-41d4-a716-446655440002", "500", "Refund", sqlc.CoreTransactionTypeCredit, 5},
+		{"660e8400-e29b-41d4-a716-446655440008", "550e8400-e29b-41d4-a716-446655440008", "50000", "Corporate funding", sqlc.CoreTransactionTypeCredit, 3},
+		{"660e8400-e29b-41d4-a716-446655440009", "550e8400-e29b-41d4-a716-446655440010", "100000", "Large deposit", sqlc.CoreTransactionTypeCredit, 1},
+	} {
+		t.transactions = append(t.transactions, sqlc.CoreTransaction{
+			ID:              pgtype.UUID{Bytes: uuid.MustParse(transaction.id), Valid: true},
+			AccountID:       pgtype.UUID{Bytes: uuid.MustParse(transaction.accountID), Valid: true},
+			TransactionType: transaction.transactionType,
+			Amount:          toNumeric(decimal.RequireFromString(transaction.amount), 4),
+			Currency:        sqlc.CoreCurrencyCodeUSD,
+			Description:     pgtype.Text{String: transaction.description, Valid: true},
+			Status:          sqlc.CoreTransactionStatusCompleted,
+			CreatedAt:       createdAt,
+			UpdatedAt:       createdAt,
+			CompletedAt:     pgtype.Timestamptz{Time: createdAt.Time.AddDate(0, 0, -transaction.completedDaysAgo), Valid: true},
+		})
+	}
+
+	for _, entry := range []struct {
+		accountID, transactionID, oldBalance, newBalance, change, operation, createdBy string
+	}{
+		{"550e8400-e29b-41d4-a716-446655440001", "660e8400-e29b-41d4-a716-446655440001", "0", "5000", "5000", "credit", "initial_setup"},
+		{"550e8400-e29b-41d4-a716-446655440002", "660e8400-e29b-41d4-a716-446655440002", "0", "10000", "10000", "credit", "initial_setup"},
+		{"550e8400-e29b-41d4-a716-446655440003", "660e8400-e29b-41d4-a716-446655440003", "0", "25000", "25000", "credit", "initial_setup"},
+		{"550e8400-e29b-41d4-a716-446655440001", "660e8400-e29b-41d4-a716-446655440004", "5000", "4800", "-200", "debit", "transaction_service"},
+		{"550e8400-e29b-41d4-a716-446655440001", "660e8400-e29b-41d4-a716-446655440005", "4800", "5000", "200", "credit", "transaction_service"},
+		{"550e8400-e29b-41d4-a716-446655440002", "660e8400-e29b-41d4-a716-446655440006", "10000", "9500", "-500", "debit", "transaction_service"},
+		{"550e8400-e29b-41d4-a716-446655440002", "660e8400-e29b-41d4-a716-446655440007", "9500", "10000", "500", "credit", "transaction_service"},
+		{"550e8400-e29b-41d4-a716-446655440008", "660e8400-e29b-41d4-a716-446655440008", "0", "50000", "50000", "credit", "initial_setup"},
+		{"550e8400-e29b-41d4-a716-446655440010", "660e8400-e29b-41d4-a716-446655440009", "0", "100000", "100000", "credit", "initial_setup"},
+	} {
+		t.balanceHistory = append(t.balanceHistory, t.chainBalanceHistory(sqlc.CoreAccountBalanceHistory{
+			ID:            newID(),
+			AccountID:     pgtype.UUID{Bytes: uuid.MustParse(entry.accountID), Valid: true},
+			TransactionID: pgtype.UUID{Bytes: uuid.MustParse(entry.transactionID), Valid: true},
+			OldBalance:    toNumeric(decimal.RequireFromString(entry.oldBalance), 4),
+			NewBalance:    toNumeric(decimal.RequireFromString(entry.newBalance), 4),
+			BalanceChange: toNumeric(decimal.RequireFromString(entry.change), 4),
+			Operation:     entry.operation,
+			CreatedAt:     createdAt,
+			CreatedBy:     pgtype.Text{String: entry.createdBy, Valid: true},
+		}))
+	}
+
+	for _, transfer := range []struct {
+		id, transferID, fromAccountID, toAccountID, amount, description string
+		status                                                          sqlc.CoreTransferStatus
+		debitTransactionID, creditTransactionID                         string
+	}{
+		{"770e8400-e29b-41d4-a716-446655440001", "TXF-2024-001", "550e8400-e29b-41d4-a716-446655440001", "550e8400-e29b-41d4-a716-446655440002", "500", "Test transfer - completed", sqlc.CoreTransferStatusCompleted, "660e8400-e29b-41d4-a716-446655440004", "660e8400-e29b-41d4-a716-446655440007"},
+		{"770e8400-e29b-41d4-a716-446655440002", "TXF-2024-002", "550e8400-e29b-41d4-a716-446655440003", "550e8400-e29b-41d4-a716-446655440008", "1000", "Business to business transfer", sqlc.CoreTransferStatusPending, "", ""},
+		{"770e8400-e29b-41d4-a716-446655440003", "TXF-2024-003", "550e8400-e29b-41d4-a716-446655440010", "550e8400-e29b-41d4-a716-446655440001", "2000", "Large account to personal", sqlc.CoreTransferStatusProcessing, "", ""},
+	} {
+		record := sqlc.CoreTransfer{
+			ID:            pgtype.UUID{Bytes: uuid.MustParse(transfer.id), Valid: true},
+			TransferID:    transfer.transferID,
+			FromAccountID: pgtype.UUID{Bytes: uuid.MustParse(transfer.fromAccountID), Valid: true},
+			ToAccountID:   pgtype.UUID{Bytes: uuid.MustParse(transfer.toAccountID), Valid: true},
+			Amount:        toNumeric(decimal.RequireFromString(transfer.amount), 4),
+			Currency:      sqlc.CoreCurrencyCodeUSD,
+			Description:   pgtype.Text{String: transfer.description, Valid: true},
+			Status:        transfer.status,
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt,
+		}
+		if transfer.status == sqlc.CoreTransferStatusCompleted {
+			record.DebitTransactionID = pgtype.UUID{Bytes: uuid.MustParse(transfer.debitTransactionID), Valid: true}
+			record.CreditTransactionID = pgtype.UUID{Bytes: uuid.MustParse(transfer.creditTransactionID), Valid: true}
+			record.CompletedAt = pgtype.Timestamptz{Time: createdAt.Time.AddDate(0, 0, -5), Valid: true}
+		}
+		t.transfers[record.TransferID] = record
+	}
+
+	return t
+}
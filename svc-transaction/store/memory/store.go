@@ -0,0 +1,323 @@
+// Package memory is an in-memory implementation of store.IStore, for running the service without Postgres in quick
+// demos and end-to-end tests. The tables start with the demo data of _init/postgres and are lost on restart.
+//
+// The tables are private to the process: the balances moved here are not seen by svc-balance, whose own in-memory
+// store keeps the demo balances. The SQLite store of store/sqlite shares its file with svc-balance instead.
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"svc-transaction/store"
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrNotSupported is returned by the queries the in-memory store does not implement: those of settlement, ledger
+// export, end-of-day balances, account erasure and pending adjustments, which need the Postgres deployment
+var ErrNotSupported = errors.New("not supported by the in-memory store")
+
+// UnsupportedFeatures names the features whose queries return ErrNotSupported, for cmd/start.go to report at startup
+var UnsupportedFeatures = []string{"settlement", "ledger export", "end-of-day balances", "account erasure", "pending adjustments"}
+
+// transferApprovalKey is the unique key of core.transfer_approvals
+type transferApprovalKey struct {
+	transferID, approvedBy string
+}
+
+// transferEventKey is the unique key of core.transfer_events
+type transferEventKey struct {
+	workflowID, runID string
+	sequenceNumber    int32
+}
+
+// transferReferenceSequenceKey is the primary key of core.transfer_reference_sequences
+type transferReferenceSequenceKey struct {
+	branchCode   string
+	businessDate time.Time
+}
+
+// accountOwnerKey is the primary key of core.account_owners
+type accountOwnerKey struct {
+	accountID [16]byte
+	ownerID   string
+}
+
+// tables holds the rows of every table the store implements
+type tables struct {
+	accounts                   map[[16]byte]sqlc.CoreAccount
+	accountClosureSteps        []sqlc.CoreAccountClosureAuditTrail
+	accountOwners              map[accountOwnerKey]sqlc.CoreAccountOwner
+	adminAudits                []sqlc.CoreAdminAudit
+	balanceHistory             []sqlc.CoreAccountBalanceHistory
+	businessRules              []sqlc.CoreBusinessRule
+	compensationAudits         []sqlc.CoreCompensationAuditTrail
+	idempotencyKeys            map[string]sqlc.CoreIdempotencyKey
+	projectionCheckpoints      map[string]int64
+	transactions               []sqlc.CoreTransaction
+	transfers                  map[string]sqlc.CoreTransfer
+	transferApprovals          map[transferApprovalKey]sqlc.CoreTransferApproval
+	transferEvents             []sqlc.CoreTransferEvent
+	transferEventKeys          map[transferEventKey]struct{}
+	transferReadModels         map[string]sqlc.CoreTransferReadModel
+	transferReferences         []sqlc.CoreTransferReference
+	transferReferenceSequences map[transferReferenceSequenceKey]int32
+
+	// transferEventPosition is the last position given to a transfer event; like a Postgres identity column it is
+	// not rolled back
+	transferEventPosition int64
+}
+
+// database is shared by the store and the transactions it runs
+type database struct {
+	// writeMutex serializes transactions and the writes made outside them, like the row locks of Postgres would
+	writeMutex sync.Mutex
+	// dataMutex guards the tables for the duration of a single query
+	dataMutex sync.RWMutex
+
+	tables tables
+}
+
+type Store struct {
+	logger *logrus.Logger
+
+	db *database
+
+	// inTx is set on the store passed to the function of WithTx, whose writes are undone by rollback when it fails
+	inTx     bool
+	rollback []func()
+}
+
+// NewStore returns a store holding the reference data and, with demoData set, the demo accounts
+func NewStore(logger *logrus.Logger, demoData bool) store.IStore {
+	return &Store{
+		logger: logger,
+
+		db: &database{tables: seedTables(demoData)},
+	}
+}
+
+// WithTx runs fn in a transaction: transactions run one at a time and the writes of fn are undone when it returns an
+// error. Reads outside a transaction may see the writes of a transaction that has not finished yet.
+func (s *Store) WithTx(ctx context.Context, fn func(sqlc.Querier) error) error {
+	if s.inTx {
+		return fn(s)
+	}
+
+	s.db.writeMutex.Lock()
+	defer s.db.writeMutex.Unlock()
+
+	tx := &Store{logger: s.logger, db: s.db, inTx: true}
+
+	// A panic in fn must not leave its writes behind
+	defer func() {
+		if r := recover(); r != nil {
+			tx.undo()
+			panic(r)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		tx.undo()
+
+		return err
+	}
+
+	return nil
+}
+
+// WithRetryTx is WithTx: transactions run one at a time, so they never fail on a serialization conflict
+func (s *Store) WithRetryTx(ctx context.Context, fn func(sqlc.Querier) error) error {
+	return s.WithTx(ctx, fn)
+}
+
+// undo reverts the writes of the transaction, the latest first
+func (s *Store) undo() {
+	s.db.dataMutex.Lock()
+	defer s.db.dataMutex.Unlock()
+
+	for i := len(s.rollback) - 1; i >= 0; i-- {
+		s.rollback[i]()
+	}
+	s.rollback = nil
+}
+
+// read locks the tables for a query that only reads them and returns the unlock function
+func (s *Store) read() func() {
+	s.db.dataMutex.RLock()
+
+	return s.db.dataMutex.RUnlock
+}
+
+// write locks the tables for a query that changes them and returns the unlock function. Outside a transaction the
+// query also waits for the running transaction to finish.
+func (s *Store) write() func() {
+	if !s.inTx {
+		s.db.writeMutex.Lock()
+	}
+	s.db.dataMutex.Lock()
+
+	return func() {
+		s.db.dataMutex.Unlock()
+		if !s.inTx {
+			s.db.writeMutex.Unlock()
+		}
+	}
+}
+
+// onRollback registers how to revert a write when the transaction running it fails
+func (s *Store) onRollback(revert func()) {
+	if s.inTx {
+		s.rollback = append(s.rollback, revert)
+	}
+}
+
+// put sets m[key] to value, restoring the previous value on rollback
+func put[K comparable, V any](s *Store, m map[K]V, key K, value V) {
+	previous, existed := m[key]
+	m[key] = value
+
+	s.onRollback(func() {
+		if existed {
+			m[key] = previous
+		} else {
+			delete(m, key)
+		}
+	})
+}
+
+// remove deletes m[key], restoring it on rollback
+func remove[K comparable, V any](s *Store, m map[K]V, key K) bool {
+	previous, existed := m[key]
+	if !existed {
+		return false
+	}
+	delete(m, key)
+
+	s.onRollback(func() {
+		m[key] = previous
+	})
+
+	return true
+}
+
+// insert appends row to rows, dropping it again on rollback
+func insert[V any](s *Store, rows *[]V, row V) {
+	n := len(*rows)
+	*rows = append(*rows, row)
+
+	s.onRollback(func() {
+		*rows = (*rows)[:n]
+	})
+}
+
+// update replaces (*rows)[i] with row, restoring the previous row on rollback
+func update[V any](s *Store, rows *[]V, i int, row V) {
+	previous := (*rows)[i]
+	(*rows)[i] = row
+
+	s.onRollback(func() {
+		(*rows)[i] = previous
+	})
+}
+
+// uniqueViolation returns the error Postgres reports when an insert breaks the unique constraint
+func uniqueViolation(constraint string) error {
+	return &pgconn.PgError{
+		Code:           "23505",
+		Message:        "duplicate key value violates unique constraint \"" + constraint + "\"",
+		ConstraintName: constraint,
+	}
+}
+
+// foreignKeyViolation returns the error Postgres reports when an insert refers to a row that does not exist
+func foreignKeyViolation(constraint string) error {
+	return &pgconn.PgError{
+		Code:           "23503",
+		Message:        "insert violates foreign key constraint \"" + constraint + "\"",
+		ConstraintName: constraint,
+	}
+}
+
+// checkViolation returns the error Postgres reports when a row breaks the check constraint
+func checkViolation(constraint string) error {
+	return &pgconn.PgError{
+		Code:           "23514",
+		Message:        "new row violates check constraint \"" + constraint + "\"",
+		ConstraintName: constraint,
+	}
+}
+
+// raiseException returns the error a trigger of _init/postgres/03-trigger.sql raises
+func raiseException(format string, args ...any) error {
+	return &pgconn.PgError{
+		Code:    "P0001",
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func newID() pgtype.UUID {
+	return pgtype.UUID{Bytes: uuid.New(), Valid: true}
+}
+
+func now() pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: time.Now(), Valid: true}
+}
+
+// toDecimal converts a numeric column; NULL converts to zero
+func toDecimal(n pgtype.Numeric) decimal.Decimal {
+	if !n.Valid || n.Int == nil {
+		return decimal.Zero
+	}
+
+	return decimal.NewFromBigInt(n.Int, n.Exp)
+}
+
+// toNumeric converts d to a numeric column with the given number of decimal places
+func toNumeric(d decimal.Decimal, places int32) pgtype.Numeric {
+	d = d.Round(places)
+
+	return pgtype.Numeric{Int: d.Coefficient(), Exp: d.Exponent(), Valid: true}
+}
+
+// page applies LIMIT and OFFSET to rows
+func page[V any](rows []V, n, offset int32) []V {
+	if offset < 0 {
+		offset = 0
+	}
+	if int(offset) >= len(rows) {
+		return []V{}
+	}
+
+	return limit(rows[offset:], n)
+}
+
+// limit applies LIMIT to rows
+func limit[V any](rows []V, n int32) []V {
+	if n >= 0 && int(n) < len(rows) {
+		return rows[:n]
+	}
+
+	return rows
+}
+
+// filter returns the rows matching keep, in their original order
+func filter[V any](rows []V, keep func(V) bool) []V {
+	kept := []V{}
+	for _, row := range rows {
+		if keep(row) {
+			kept = append(kept, row)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,188 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"svc-transaction/service"
+	"svc-transaction/store/memory"
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	johnDoe    = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	janeSmith  = uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+	lowBalance = uuid.MustParse("550e8400-e29b-41d4-a716-446655440006")
+	suspended  = uuid.MustParse("550e8400-e29b-41d4-a716-446655440007")
+)
+
+func newTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	return logger
+}
+
+func balance(t *testing.T, q sqlc.Querier, accountID uuid.UUID) decimal.Decimal {
+	t.Helper()
+
+	account, err := q.GetAccountByID(context.Background(), pgtype.UUID{Bytes: accountID, Valid: true})
+	require.NoError(t, err)
+
+	return decimal.NewFromBigInt(account.Balance.Int, account.Balance.Exp)
+}
+
+func TestInternalTransfer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := newTestLogger()
+	store := memory.NewStore(logger, true)
+	transactionService := service.NewService(logger, store)
+
+	params := service.InternalTransferParams{
+		TransferID:    uuid.NewString(),
+		FromAccountID: johnDoe,
+		ToAccountID:   janeSmith,
+		Amount:        decimal.NewFromInt(100),
+		Currency:      "USD",
+	}
+
+	result, err := transactionService.InternalTransfer(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", result.Status)
+	assert.True(t, decimal.NewFromInt(4900).Equal(balance(t, store, johnDoe)))
+	assert.True(t, decimal.NewFromInt(10100).Equal(balance(t, store, janeSmith)))
+
+	// Retrying the transfer returns the same result without moving funds again
+	retried, err := transactionService.InternalTransfer(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, result.DebitTransactionID, retried.DebitTransactionID)
+	assert.True(t, decimal.NewFromInt(4900).Equal(balance(t, store, johnDoe)))
+
+	// The seeded history and the entries of the transfer form a valid chain
+	for _, accountID := range []uuid.UUID{johnDoe, janeSmith} {
+		report, err := transactionService.GetReconciliationReport(ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, 4, report.EntriesChecked)
+		assert.Empty(t, report.Discrepancies)
+	}
+}
+
+func TestInternalTransferInsufficientFunds(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := newTestLogger()
+	store := memory.NewStore(logger, true)
+	transactionService := service.NewService(logger, store)
+
+	_, err := transactionService.InternalTransfer(ctx, service.InternalTransferParams{
+		TransferID:    uuid.NewString(),
+		FromAccountID: lowBalance,
+		ToAccountID:   johnDoe,
+		Amount:        decimal.NewFromInt(500),
+		Currency:      "USD",
+	})
+	require.Error(t, err)
+
+	assert.True(t, decimal.NewFromInt(100).Equal(balance(t, store, lowBalance)))
+	assert.True(t, decimal.NewFromInt(5000).Equal(balance(t, store, johnDoe)))
+}
+
+func TestWithoutDemoData(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := memory.NewStore(newTestLogger(), false)
+
+	_, err := store.GetAccountByID(ctx, pgtype.UUID{Bytes: johnDoe, Valid: true})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// The reference data is loaded whatever the profile
+	rules, err := store.ListBusinessRules(ctx)
+	require.NoError(t, err)
+	assert.Len(t, rules, 2)
+}
+
+func TestWithTxRollback(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := memory.NewStore(newTestLogger(), true)
+	errAbort := errors.New("abort")
+
+	var transactionID pgtype.UUID
+	err := store.WithTx(ctx, func(q sqlc.Querier) error {
+		row, err := q.CreateTransaction(ctx, sqlc.CreateTransactionParams{
+			AccountID:       pgtype.UUID{Bytes: johnDoe, Valid: true},
+			TransactionType: sqlc.CoreTransactionTypeDebit,
+			Amount:          pgtype.Numeric{Int: decimal.NewFromInt(50).Coefficient(), Valid: true},
+			Currency:        sqlc.CoreCurrencyCodeUSD,
+		})
+		require.NoError(t, err)
+		transactionID = row.ID
+
+		_, err = q.AdjustAccountBalance(ctx, sqlc.AdjustAccountBalanceParams{
+			ID:            pgtype.UUID{Bytes: johnDoe, Valid: true},
+			BalanceChange: pgtype.Numeric{Int: decimal.NewFromInt(-50).Coefficient(), Valid: true},
+		})
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(4950).Equal(balance(t, q, johnDoe)))
+
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	assert.True(t, decimal.NewFromInt(5000).Equal(balance(t, store, johnDoe)))
+	_, err = store.GetTransactionByID(ctx, transactionID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestConstraints(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := memory.NewStore(newTestLogger(), true)
+
+	create := func(accountID uuid.UUID, idempotencyKey string) error {
+		_, err := store.CreateTransaction(ctx, sqlc.CreateTransactionParams{
+			AccountID:       pgtype.UUID{Bytes: accountID, Valid: true},
+			TransactionType: sqlc.CoreTransactionTypeCredit,
+			Amount:          pgtype.Numeric{Int: decimal.NewFromInt(10).Coefficient(), Valid: true},
+			Currency:        sqlc.CoreCurrencyCodeUSD,
+			IdempotencyKey:  pgtype.Text{String: idempotencyKey, Valid: idempotencyKey != ""},
+		})
+
+		return err
+	}
+
+	var pgErr *pgconn.PgError
+
+	require.NoError(t, create(johnDoe, "key-1"))
+	require.ErrorAs(t, create(johnDoe, "key-1"), &pgErr)
+	assert.Equal(t, "23505", pgErr.Code)
+	assert.Equal(t, "transactions_idempotency_key_key", pgErr.ConstraintName)
+
+	// Like trigger_transactions_validation, transactions of inactive accounts are refused
+	require.ErrorAs(t, create(suspended, ""), &pgErr)
+	assert.Equal(t, "P0001", pgErr.Code)
+
+	// Like the balance check of core.accounts, balances cannot go negative
+	_, err := store.AdjustAccountBalance(ctx, sqlc.AdjustAccountBalanceParams{
+		ID:            pgtype.UUID{Bytes: lowBalance, Valid: true},
+		BalanceChange: pgtype.Numeric{Int: decimal.NewFromInt(-101).Coefficient(), Valid: true},
+	})
+	require.ErrorAs(t, err, &pgErr)
+	assert.Equal(t, "23514", pgErr.Code)
+}
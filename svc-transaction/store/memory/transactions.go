@@ -0,0 +1,589 @@
+package memory
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// transactionIndex returns the position of the transaction in the table, or -1
+func (s *Store) transactionIndex(id pgtype.UUID) int {
+	return slices.IndexFunc(s.db.tables.transactions, func(transaction sqlc.CoreTransaction) bool {
+		return transaction.ID == id
+	})
+}
+
+// newestTransactions returns the transactions matching keep, the latest first
+func (s *Store) newestTransactions(keep func(sqlc.CoreTransaction) bool) []sqlc.CoreTransaction {
+	transactions := filter(s.db.tables.transactions, keep)
+	slices.Reverse(transactions)
+
+	return transactions
+}
+
+// withMetadata returns metadata with key set to value, like metadata || jsonb_build_object(key, value)
+func withMetadata(metadata []byte, key string, value any) ([]byte, error) {
+	fields := map[string]any{}
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &fields); err != nil {
+			return nil, fmt.Errorf("invalid transaction metadata: %w", err)
+		}
+	}
+	fields[key] = value
+
+	return json.Marshal(fields)
+}
+
+// metadataText returns metadata->>key
+func metadataText(metadata []byte, key string) (string, bool) {
+	fields := map[string]any{}
+	if err := json.Unmarshal(metadata, &fields); err != nil {
+		return "", false
+	}
+
+	switch value := fields[key].(type) {
+	case nil:
+		return "", false
+	case string:
+		return value, true
+	default:
+		text, err := json.Marshal(value)
+		return string(text), err == nil
+	}
+}
+
+func transactionSummaryRow(transaction sqlc.CoreTransaction) sqlc.GetTransactionsByAccountRow {
+	return sqlc.GetTransactionsByAccountRow{
+		ID:              transaction.ID,
+		AccountID:       transaction.AccountID,
+		TransactionType: transaction.TransactionType,
+		Amount:          transaction.Amount,
+		Currency:        transaction.Currency,
+		Description:     transaction.Description,
+		ReferenceID:     transaction.ReferenceID,
+		Status:          transaction.Status,
+		CreatedAt:       transaction.CreatedAt,
+		CompletedAt:     transaction.CompletedAt,
+	}
+}
+
+func transactionRow(transaction sqlc.CoreTransaction) sqlc.GetTransactionByIDRow {
+	return sqlc.GetTransactionByIDRow{
+		ID:              transaction.ID,
+		AccountID:       transaction.AccountID,
+		TransactionType: transaction.TransactionType,
+		Amount:          transaction.Amount,
+		Currency:        transaction.Currency,
+		Description:     transaction.Description,
+		ReferenceID:     transaction.ReferenceID,
+		IdempotencyKey:  transaction.IdempotencyKey,
+		Status:          transaction.Status,
+		CreatedAt:       transaction.CreatedAt,
+		UpdatedAt:       transaction.UpdatedAt,
+		CompletedAt:     transaction.CompletedAt,
+		Metadata:        transaction.Metadata,
+	}
+}
+
+// chainBalanceHistory sets the sequence number and chain hash of a new balance history entry, like the
+// trigger_balance_history_chain trigger of _init/postgres/03-trigger.sql
+func (t *tables) chainBalanceHistory(entry sqlc.CoreAccountBalanceHistory) sqlc.CoreAccountBalanceHistory {
+	var previousSequence int64
+	var previousHash string
+	for _, previous := range t.balanceHistory {
+		if previous.AccountID == entry.AccountID && previous.SequenceNumber > previousSequence {
+			previousSequence, previousHash = previous.SequenceNumber, previous.ChainHash
+		}
+	}
+
+	var transactionID string
+	if entry.TransactionID.Valid {
+		transactionID = uuid.UUID(entry.TransactionID.Bytes).String()
+	}
+
+	entry.SequenceNumber = previousSequence + 1
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		previousHash,
+		uuid.UUID(entry.AccountID.Bytes).String(),
+		strconv.FormatInt(entry.SequenceNumber, 10),
+		transactionID,
+		toDecimal(entry.OldBalance).StringFixed(4),
+		toDecimal(entry.NewBalance).StringFixed(4),
+		toDecimal(entry.BalanceChange).StringFixed(4),
+		entry.Operation,
+	}, "|")))
+	entry.ChainHash = hex.EncodeToString(sum[:])
+
+	return entry
+}
+
+func (s *Store) CreateTransaction(ctx context.Context, arg sqlc.CreateTransactionParams) (sqlc.CreateTransactionRow, error) {
+	defer s.write()()
+
+	// trigger_transactions_validation
+	account, ok := s.db.tables.accounts[arg.AccountID.Bytes]
+	if !ok {
+		return sqlc.CreateTransactionRow{}, raiseException("Account not found: %s", uuid.UUID(arg.AccountID.Bytes))
+	}
+	if account.Status != sqlc.CoreAccountStatusActive {
+		return sqlc.CreateTransactionRow{}, raiseException("Cannot create transaction for account with status: %s", account.Status)
+	}
+
+	if !toDecimal(arg.Amount).IsPositive() {
+		return sqlc.CreateTransactionRow{}, checkViolation("transactions_amount_check")
+	}
+	if arg.IdempotencyKey.Valid {
+		for _, transaction := range s.db.tables.transactions {
+			if transaction.IdempotencyKey == arg.IdempotencyKey {
+				return sqlc.CreateTransactionRow{}, uniqueViolation("transactions_idempotency_key_key")
+			}
+		}
+	}
+
+	createdAt := now()
+	transaction := sqlc.CoreTransaction{
+		ID:              newID(),
+		AccountID:       arg.AccountID,
+		TransactionType: arg.TransactionType,
+		Amount:          toNumeric(toDecimal(arg.Amount), 4),
+		Currency:        arg.Currency,
+		Description:     arg.Description,
+		ReferenceID:     arg.ReferenceID,
+		Status:          sqlc.CoreTransactionStatusPending,
+		IdempotencyKey:  arg.IdempotencyKey,
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
+		Metadata:        arg.Metadata,
+	}
+	insert(s, &s.db.tables.transactions, transaction)
+
+	return sqlc.CreateTransactionRow{ID: transaction.ID, CreatedAt: transaction.CreatedAt}, nil
+}
+
+func (s *Store) GetTransactionByID(ctx context.Context, id pgtype.UUID) (sqlc.GetTransactionByIDRow, error) {
+	defer s.read()()
+
+	i := s.transactionIndex(id)
+	if i < 0 {
+		return sqlc.GetTransactionByIDRow{}, pgx.ErrNoRows
+	}
+
+	return transactionRow(s.db.tables.transactions[i]), nil
+}
+
+func (s *Store) GetTransactionByIdempotencyKey(ctx context.Context, idempotencyKey pgtype.Text) (sqlc.GetTransactionByIdempotencyKeyRow, error) {
+	defer s.read()()
+
+	if !idempotencyKey.Valid {
+		return sqlc.GetTransactionByIdempotencyKeyRow{}, pgx.ErrNoRows
+	}
+
+	for _, transaction := range s.db.tables.transactions {
+		if transaction.IdempotencyKey == idempotencyKey {
+			return sqlc.GetTransactionByIdempotencyKeyRow(transactionRow(transaction)), nil
+		}
+	}
+
+	return sqlc.GetTransactionByIdempotencyKeyRow{}, pgx.ErrNoRows
+}
+
+func (s *Store) UpdateTransactionStatus(ctx context.Context, arg sqlc.UpdateTransactionStatusParams) (sqlc.UpdateTransactionStatusRow, error) {
+	defer s.write()()
+
+	i := s.transactionIndex(arg.ID)
+	if i < 0 {
+		return sqlc.UpdateTransactionStatusRow{}, pgx.ErrNoRows
+	}
+
+	transaction := s.db.tables.transactions[i]
+	transaction.Status = arg.Status
+	transaction.UpdatedAt = now()
+	if arg.Status == sqlc.CoreTransactionStatusCompleted {
+		transaction.CompletedAt = transaction.UpdatedAt
+	}
+	update(s, &s.db.tables.transactions, i, transaction)
+
+	return sqlc.UpdateTransactionStatusRow{
+		ID:          transaction.ID,
+		Status:      transaction.Status,
+		UpdatedAt:   transaction.UpdatedAt,
+		CompletedAt: transaction.CompletedAt,
+	}, nil
+}
+
+// pendingTransaction returns the position of the transaction when it is still pending, or -1
+func (s *Store) pendingTransaction(id pgtype.UUID) int {
+	i := s.transactionIndex(id)
+	if i < 0 || s.db.tables.transactions[i].Status != sqlc.CoreTransactionStatusPending {
+		return -1
+	}
+
+	return i
+}
+
+func (s *Store) CompleteTransaction(ctx context.Context, id pgtype.UUID) (sqlc.CompleteTransactionRow, error) {
+	defer s.write()()
+
+	i := s.pendingTransaction(id)
+	if i < 0 {
+		return sqlc.CompleteTransactionRow{}, pgx.ErrNoRows
+	}
+
+	transaction := s.db.tables.transactions[i]
+	transaction.Status = sqlc.CoreTransactionStatusCompleted
+	transaction.CompletedAt = now()
+	transaction.UpdatedAt = transaction.CompletedAt
+	update(s, &s.db.tables.transactions, i, transaction)
+
+	return sqlc.CompleteTransactionRow{
+		ID:          transaction.ID,
+		Status:      transaction.Status,
+		CompletedAt: transaction.CompletedAt,
+	}, nil
+}
+
+// endTransaction moves a pending transaction to status, recording reason in its metadata under reasonKey
+func (s *Store) endTransaction(id pgtype.UUID, status sqlc.CoreTransactionStatus, reasonKey string, reason any) (sqlc.CoreTransaction, error) {
+	defer s.write()()
+
+	i := s.pendingTransaction(id)
+	if i < 0 {
+		return sqlc.CoreTransaction{}, pgx.ErrNoRows
+	}
+
+	transaction := s.db.tables.transactions[i]
+	metadata, err := withMetadata(transaction.Metadata, reasonKey, reason)
+	if err != nil {
+		return sqlc.CoreTransaction{}, err
+	}
+	transaction.Status = status
+	transaction.UpdatedAt = now()
+	transaction.Metadata = metadata
+	update(s, &s.db.tables.transactions, i, transaction)
+
+	return transaction, nil
+}
+
+func (s *Store) FailTransaction(ctx context.Context, arg sqlc.FailTransactionParams) (sqlc.FailTransactionRow, error) {
+	transaction, err := s.endTransaction(arg.ID, sqlc.CoreTransactionStatusFailed, "failure_reason", arg.JsonbBuildObject)
+	if err != nil {
+		return sqlc.FailTransactionRow{}, err
+	}
+
+	return sqlc.FailTransactionRow{ID: transaction.ID, Status: transaction.Status, UpdatedAt: transaction.UpdatedAt}, nil
+}
+
+func (s *Store) CancelTransaction(ctx context.Context, arg sqlc.CancelTransactionParams) (sqlc.CancelTransactionRow, error) {
+	transaction, err := s.endTransaction(arg.ID, sqlc.CoreTransactionStatusCancelled, "cancellation_reason", arg.JsonbBuildObject)
+	if err != nil {
+		return sqlc.CancelTransactionRow{}, err
+	}
+
+	return sqlc.CancelTransactionRow{ID: transaction.ID, Status: transaction.Status, UpdatedAt: transaction.UpdatedAt}, nil
+}
+
+func (s *Store) UpdateTransactionMetadata(ctx context.Context, arg sqlc.UpdateTransactionMetadataParams) (sqlc.UpdateTransactionMetadataRow, error) {
+	defer s.write()()
+
+	i := s.transactionIndex(arg.ID)
+	if i < 0 {
+		return sqlc.UpdateTransactionMetadataRow{}, pgx.ErrNoRows
+	}
+
+	transaction := s.db.tables.transactions[i]
+	transaction.Metadata = arg.Metadata
+	transaction.UpdatedAt = now()
+	update(s, &s.db.tables.transactions, i, transaction)
+
+	return sqlc.UpdateTransactionMetadataRow{ID: transaction.ID, Metadata: transaction.Metadata, UpdatedAt: transaction.UpdatedAt}, nil
+}
+
+func (s *Store) GetCompensatedAmount(ctx context.Context, originalTransactionID string) (pgtype.Numeric, error) {
+	defer s.read()()
+
+	compensated := decimal.Zero
+	for _, transaction := range s.db.tables.transactions {
+		if transaction.TransactionType != sqlc.CoreTransactionTypeCredit || transaction.Status != sqlc.CoreTransactionStatusCompleted {
+			continue
+		}
+		if id, ok := metadataText(transaction.Metadata, "original_transaction_id"); ok && id == originalTransactionID {
+			compensated = compensated.Add(toDecimal(transaction.Amount))
+		}
+	}
+
+	return toNumeric(compensated, 4), nil
+}
+
+func (s *Store) GetTransactionsByAccount(ctx context.Context, arg sqlc.GetTransactionsByAccountParams) ([]sqlc.GetTransactionsByAccountRow, error) {
+	defer s.read()()
+
+	rows := []sqlc.GetTransactionsByAccountRow{}
+	for _, transaction := range page(s.newestTransactions(func(transaction sqlc.CoreTransaction) bool {
+		return transaction.AccountID == arg.AccountID
+	}), arg.Limit, arg.Offset) {
+		rows = append(rows, transactionSummaryRow(transaction))
+	}
+
+	return rows, nil
+}
+
+func (s *Store) GetTransactionsByReference(ctx context.Context, referenceID pgtype.Text) ([]sqlc.GetTransactionsByReferenceRow, error) {
+	defer s.read()()
+
+	rows := []sqlc.GetTransactionsByReferenceRow{}
+	for _, transaction := range s.db.tables.transactions {
+		if referenceID.Valid && transaction.ReferenceID == referenceID {
+			rows = append(rows, sqlc.GetTransactionsByReferenceRow(transactionSummaryRow(transaction)))
+		}
+	}
+
+	return rows, nil
+}
+
+func (s *Store) GetTransactionsByStatus(ctx context.Context, arg sqlc.GetTransactionsByStatusParams) ([]sqlc.GetTransactionsByStatusRow, error) {
+	defer s.read()()
+
+	rows := []sqlc.GetTransactionsByStatusRow{}
+	for _, transaction := range page(s.newestTransactions(func(transaction sqlc.CoreTransaction) bool {
+		return transaction.Status == arg.Status
+	}), arg.Limit, arg.Offset) {
+		rows = append(rows, sqlc.GetTransactionsByStatusRow(transactionSummaryRow(transaction)))
+	}
+
+	return rows, nil
+}
+
+func (s *Store) GetPendingTransactions(ctx context.Context, n int32) ([]sqlc.GetPendingTransactionsRow, error) {
+	defer s.read()()
+
+	staleBefore := time.Now().Add(-5 * time.Minute)
+
+	rows := []sqlc.GetPendingTransactionsRow{}
+	for _, transaction := range limit(filter(s.db.tables.transactions, func(transaction sqlc.CoreTransaction) bool {
+		return transaction.Status == sqlc.CoreTransactionStatusPending && transaction.CreatedAt.Time.Before(staleBefore)
+	}), n) {
+		rows = append(rows, sqlc.GetPendingTransactionsRow{
+			ID:              transaction.ID,
+			AccountID:       transaction.AccountID,
+			TransactionType: transaction.TransactionType,
+			Amount:          transaction.Amount,
+			Currency:        transaction.Currency,
+			Description:     transaction.Description,
+			ReferenceID:     transaction.ReferenceID,
+			IdempotencyKey:  transaction.IdempotencyKey,
+			Status:          transaction.Status,
+			CreatedAt:       transaction.CreatedAt,
+			Metadata:        transaction.Metadata,
+		})
+	}
+
+	return rows, nil
+}
+
+func (s *Store) GetTransactionsByAccountAndType(ctx context.Context, arg sqlc.GetTransactionsByAccountAndTypeParams) ([]sqlc.GetTransactionsByAccountAndTypeRow, error) {
+	defer s.read()()
+
+	rows := []sqlc.GetTransactionsByAccountAndTypeRow{}
+	for _, transaction := range page(s.newestTransactions(func(transaction sqlc.CoreTransaction) bool {
+		return transaction.AccountID == arg.AccountID && transaction.TransactionType == arg.TransactionType
+	}), arg.Limit, arg.Offset) {
+		rows = append(rows, sqlc.GetTransactionsByAccountAndTypeRow(transactionSummaryRow(transaction)))
+	}
+
+	return rows, nil
+}
+
+func (s *Store) GetTransactionsByDateRange(ctx context.Context, arg sqlc.GetTransactionsByDateRangeParams) ([]sqlc.GetTransactionsByDateRangeRow, error) {
+	defer s.read()()
+
+	rows := []sqlc.GetTransactionsByDateRangeRow{}
+	for _, transaction := range page(s.newestTransactions(func(transaction sqlc.CoreTransaction) bool {
+		return between(transaction.CreatedAt, arg.CreatedAt, arg.CreatedAt_2)
+	}), arg.Limit, arg.Offset) {
+		rows = append(rows, sqlc.GetTransactionsByDateRangeRow(transactionSummaryRow(transaction)))
+	}
+
+	return rows, nil
+}
+
+func (s *Store) GetTransactionSummaryByAccount(ctx context.Context, accountID pgtype.UUID) (sqlc.GetTransactionSummaryByAccountRow, error) {
+	defer s.read()()
+
+	row := sqlc.GetTransactionSummaryByAccountRow{}
+	debits, credits := decimal.Zero, decimal.Zero
+	for _, transaction := range s.db.tables.transactions {
+		if transaction.AccountID != accountID {
+			continue
+		}
+
+		// Like the aggregate, the row carries the account only when it has transactions
+		row.AccountID = accountID
+		row.TotalTransactions++
+		switch transaction.Status {
+		case sqlc.CoreTransactionStatusCompleted:
+			row.CompletedTransactions++
+			if transaction.TransactionType == sqlc.CoreTransactionTypeDebit {
+				debits = debits.Add(toDecimal(transaction.Amount))
+			} else {
+				credits = credits.Add(toDecimal(transaction.Amount))
+			}
+		case sqlc.CoreTransactionStatusPending:
+			row.PendingTransactions++
+		case sqlc.CoreTransactionStatusFailed:
+			row.FailedTransactions++
+		}
+	}
+	row.TotalDebits = toNumeric(debits, 4)
+	row.TotalCredits = toNumeric(credits, 4)
+
+	return row, nil
+}
+
+func (s *Store) GetRecentTransactionsByAccount(ctx context.Context, arg sqlc.GetRecentTransactionsByAccountParams) ([]sqlc.GetRecentTransactionsByAccountRow, error) {
+	defer s.read()()
+
+	createdFrom := time.Now().AddDate(0, 0, -30)
+
+	rows := []sqlc.GetRecentTransactionsByAccountRow{}
+	for _, transaction := range limit(s.newestTransactions(func(transaction sqlc.CoreTransaction) bool {
+		return transaction.AccountID == arg.AccountID && !transaction.CreatedAt.Time.Before(createdFrom)
+	}), arg.Limit) {
+		rows = append(rows, sqlc.GetRecentTransactionsByAccountRow(transactionSummaryRow(transaction)))
+	}
+
+	return rows, nil
+}
+
+func (s *Store) GetAccountByID(ctx context.Context, id pgtype.UUID) (sqlc.CoreAccount, error) {
+	defer s.read()()
+
+	account, ok := s.db.tables.accounts[id.Bytes]
+	if !ok {
+		return sqlc.CoreAccount{}, pgx.ErrNoRows
+	}
+
+	return account, nil
+}
+
+func (s *Store) GetAccountByAccountNumber(ctx context.Context, accountNumber string) (sqlc.CoreAccount, error) {
+	defer s.read()()
+
+	for _, account := range s.db.tables.accounts {
+		if account.AccountNumber == accountNumber {
+			return account, nil
+		}
+	}
+
+	return sqlc.CoreAccount{}, pgx.ErrNoRows
+}
+
+func (s *Store) CheckAccountBalance(ctx context.Context, arg sqlc.CheckAccountBalanceParams) (sqlc.CheckAccountBalanceRow, error) {
+	defer s.read()()
+
+	account, ok := s.db.tables.accounts[arg.ID.Bytes]
+	if !ok {
+		return sqlc.CheckAccountBalanceRow{}, pgx.ErrNoRows
+	}
+
+	return sqlc.CheckAccountBalanceRow{
+		ID:              account.ID,
+		AccountNumber:   account.AccountNumber,
+		AccountName:     account.AccountName,
+		Balance:         account.Balance,
+		Currency:        account.Currency,
+		Status:          account.Status,
+		AccountType:     account.AccountType,
+		SufficientFunds: !arg.Column2.Valid || toDecimal(account.Balance).GreaterThanOrEqual(toDecimal(arg.Column2)),
+	}, nil
+}
+
+func (s *Store) CountOutgoingDebits(ctx context.Context, arg sqlc.CountOutgoingDebitsParams) (int64, error) {
+	defer s.read()()
+
+	var count int64
+	for _, transaction := range s.db.tables.transactions {
+		if transaction.AccountID != arg.AccountID || transaction.TransactionType != sqlc.CoreTransactionTypeDebit {
+			continue
+		}
+		if transaction.Status != sqlc.CoreTransactionStatusPending && transaction.Status != sqlc.CoreTransactionStatusCompleted {
+			continue
+		}
+		if !transaction.CreatedAt.Time.Before(arg.CreatedFrom.Time) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (s *Store) CreateBalanceHistoryRecord(ctx context.Context, arg sqlc.CreateBalanceHistoryRecordParams) (sqlc.CreateBalanceHistoryRecordRow, error) {
+	defer s.write()()
+
+	if _, ok := s.db.tables.accounts[arg.AccountID.Bytes]; !ok {
+		return sqlc.CreateBalanceHistoryRecordRow{}, foreignKeyViolation("account_balance_history_account_id_fkey")
+	}
+
+	entry := s.db.tables.chainBalanceHistory(sqlc.CoreAccountBalanceHistory{
+		ID:            newID(),
+		AccountID:     arg.AccountID,
+		TransactionID: arg.TransactionID,
+		OldBalance:    toNumeric(toDecimal(arg.OldBalance), 4),
+		NewBalance:    toNumeric(toDecimal(arg.NewBalance), 4),
+		BalanceChange: toNumeric(toDecimal(arg.BalanceChange), 4),
+		Operation:     arg.Operation,
+		CreatedAt:     now(),
+		CreatedBy:     arg.CreatedBy,
+	})
+	insert(s, &s.db.tables.balanceHistory, entry)
+
+	return sqlc.CreateBalanceHistoryRecordRow{ID: entry.ID, CreatedAt: entry.CreatedAt}, nil
+}
+
+func (s *Store) GetAccountBalanceHistory(ctx context.Context, arg sqlc.GetAccountBalanceHistoryParams) ([]sqlc.CoreAccountBalanceHistory, error) {
+	defer s.read()()
+
+	history := filter(s.db.tables.balanceHistory, func(entry sqlc.CoreAccountBalanceHistory) bool {
+		return entry.AccountID == arg.AccountID
+	})
+	slices.Reverse(history)
+
+	return page(history, arg.Limit, arg.Offset), nil
+}
+
+func (s *Store) GetBalanceHistoryByTransaction(ctx context.Context, transactionID pgtype.UUID) ([]sqlc.CoreAccountBalanceHistory, error) {
+	defer s.read()()
+
+	return filter(s.db.tables.balanceHistory, func(entry sqlc.CoreAccountBalanceHistory) bool {
+		return transactionID.Valid && entry.TransactionID == transactionID
+	}), nil
+}
+
+func (s *Store) GetBalanceHistoryByDateRange(ctx context.Context, arg sqlc.GetBalanceHistoryByDateRangeParams) ([]sqlc.CoreAccountBalanceHistory, error) {
+	defer s.read()()
+
+	history := filter(s.db.tables.balanceHistory, func(entry sqlc.CoreAccountBalanceHistory) bool {
+		return entry.AccountID == arg.AccountID && between(entry.CreatedAt, arg.CreatedAt, arg.CreatedAt_2)
+	})
+	slices.Reverse(history)
+
+	return page(history, arg.Limit, arg.Offset), nil
+}
+
+// between reports whether t is BETWEEN from AND to
+func between(t, from, to pgtype.Timestamptz) bool {
+	return !t.Time.Before(from.Time) && !t.Time.After(to.Time)
+}
@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"svc-transaction/store/sqlc"
+)
+
+// CreateTransferApproval records an approval, ignoring a repeated approval by the same approver
+func (s *Store) CreateTransferApproval(ctx context.Context, arg sqlc.CreateTransferApprovalParams) (int64, error) {
+	defer s.write()()
+
+	key := transferApprovalKey{arg.TransferID, arg.ApprovedBy}
+	if _, ok := s.db.tables.transferApprovals[key]; ok {
+		return 0, nil
+	}
+
+	put(s, s.db.tables.transferApprovals, key, sqlc.CoreTransferApproval{
+		ID:         newID(),
+		TransferID: arg.TransferID,
+		WorkflowID: arg.WorkflowID,
+		ApprovedBy: arg.ApprovedBy,
+		ApprovedAt: arg.ApprovedAt,
+		RecordedAt: now(),
+	})
+
+	return 1, nil
+}
+
+func (s *Store) ListTransferApprovals(ctx context.Context, transferID string) ([]sqlc.CoreTransferApproval, error) {
+	defer s.read()()
+
+	approvals := []sqlc.CoreTransferApproval{}
+	for key, approval := range s.db.tables.transferApprovals {
+		if key.transferID == transferID {
+			approvals = append(approvals, approval)
+		}
+	}
+	slices.SortFunc(approvals, func(a, b sqlc.CoreTransferApproval) int {
+		if c := a.ApprovedAt.Time.Compare(b.ApprovedAt.Time); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.ApprovedBy, b.ApprovedBy)
+	})
+
+	return approvals, nil
+}
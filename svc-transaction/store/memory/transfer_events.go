@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"svc-transaction/store/sqlc"
+)
+
+// CreateTransferEvent records an event, ignoring a retried recording of the same event of a workflow run
+func (s *Store) CreateTransferEvent(ctx context.Context, arg sqlc.CreateTransferEventParams) (int64, error) {
+	defer s.write()()
+
+	key := transferEventKey{arg.WorkflowID, arg.RunID, arg.SequenceNumber}
+	if _, ok := s.db.tables.transferEventKeys[key]; ok {
+		return 0, nil
+	}
+
+	s.db.tables.transferEventPosition++
+	insert(s, &s.db.tables.transferEvents, sqlc.CoreTransferEvent{
+		ID:             newID(),
+		Position:       s.db.tables.transferEventPosition,
+		TransferID:     arg.TransferID,
+		WorkflowID:     arg.WorkflowID,
+		RunID:          arg.RunID,
+		SequenceNumber: arg.SequenceNumber,
+		EventType:      arg.EventType,
+		Status:         arg.Status,
+		Step:           arg.Step,
+		FromAccount:    arg.FromAccount,
+		ToAccount:      arg.ToAccount,
+		Amount:         toNumeric(toDecimal(arg.Amount), 4),
+		Currency:       arg.Currency,
+		Description:    arg.Description,
+		ErrorMessage:   arg.ErrorMessage,
+		OccurredAt:     arg.OccurredAt,
+		RecordedAt:     now(),
+	})
+	put(s, s.db.tables.transferEventKeys, key, struct{}{})
+
+	return 1, nil
+}
+
+func (s *Store) ListTransferEvents(ctx context.Context, transferID string) ([]sqlc.CoreTransferEvent, error) {
+	defer s.read()()
+
+	events := filter(s.db.tables.transferEvents, func(event sqlc.CoreTransferEvent) bool {
+		return event.TransferID == transferID
+	})
+	// ORDER BY occurred_at, sequence_number
+	slices.SortStableFunc(events, func(a, b sqlc.CoreTransferEvent) int {
+		if c := a.OccurredAt.Time.Compare(b.OccurredAt.Time); c != 0 {
+			return c
+		}
+
+		return int(a.SequenceNumber) - int(b.SequenceNumber)
+	})
+
+	return events, nil
+}
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// compareReadModels orders read models by started_at and transfer_id
+func compareReadModels(a, b sqlc.CoreTransferReadModel) int {
+	if c := a.StartedAt.Time.Compare(b.StartedAt.Time); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a.TransferID, b.TransferID)
+}
+
+func (s *Store) ListTransferEventsAfter(ctx context.Context, arg sqlc.ListTransferEventsAfterParams) ([]sqlc.CoreTransferEvent, error) {
+	defer s.read()()
+
+	// The events are stored in position order
+	return limit(filter(s.db.tables.transferEvents, func(event sqlc.CoreTransferEvent) bool {
+		return event.Position > arg.AfterPosition && event.RecordedAt.Time.Before(arg.RecordedBefore.Time)
+	}), arg.RowLimit), nil
+}
+
+// UpsertTransferReadModel projects an event onto the read model of its transfer, unless a later event was projected
+// already
+func (s *Store) UpsertTransferReadModel(ctx context.Context, arg sqlc.UpsertTransferReadModelParams) (int64, error) {
+	defer s.write()()
+
+	readModel := sqlc.CoreTransferReadModel{
+		TransferID:    arg.TransferID,
+		WorkflowID:    arg.WorkflowID,
+		RunID:         arg.RunID,
+		Status:        arg.Status,
+		LastEventType: arg.LastEventType,
+		LastStep:      arg.LastStep,
+		FromAccount:   arg.FromAccount,
+		ToAccount:     arg.ToAccount,
+		Amount:        toNumeric(toDecimal(arg.Amount), 4),
+		Currency:      arg.Currency,
+		Description:   arg.Description,
+		ErrorMessage:  arg.ErrorMessage,
+		StartedAt:     arg.StartedAt,
+		FinishedAt:    arg.FinishedAt,
+		UpdatedAt:     arg.UpdatedAt,
+		EventPosition: arg.EventPosition,
+		ProjectedAt:   now(),
+	}
+
+	if existing, ok := s.db.tables.transferReadModels[arg.TransferID]; ok {
+		if existing.EventPosition >= arg.EventPosition {
+			return 0, nil
+		}
+		if existing.StartedAt.Time.Before(readModel.StartedAt.Time) {
+			readModel.StartedAt = existing.StartedAt
+		}
+	}
+	put(s, s.db.tables.transferReadModels, readModel.TransferID, readModel)
+
+	return 1, nil
+}
+
+func (s *Store) GetProjectionCheckpoint(ctx context.Context, projection string) (int64, error) {
+	defer s.read()()
+
+	position, ok := s.db.tables.projectionCheckpoints[projection]
+	if !ok {
+		return 0, pgx.ErrNoRows
+	}
+
+	return position, nil
+}
+
+func (s *Store) UpsertProjectionCheckpoint(ctx context.Context, arg sqlc.UpsertProjectionCheckpointParams) error {
+	defer s.write()()
+
+	put(s, s.db.tables.projectionCheckpoints, arg.Projection, arg.Position)
+
+	return nil
+}
+
+func (s *Store) GetTransferReadModel(ctx context.Context, transferID string) (sqlc.CoreTransferReadModel, error) {
+	defer s.read()()
+
+	readModel, ok := s.db.tables.transferReadModels[transferID]
+	if !ok {
+		return sqlc.CoreTransferReadModel{}, pgx.ErrNoRows
+	}
+
+	return readModel, nil
+}
+
+func (s *Store) ListTransferReadModels(ctx context.Context, arg sqlc.ListTransferReadModelsParams) ([]sqlc.CoreTransferReadModel, error) {
+	defer s.read()()
+
+	cursor := sqlc.CoreTransferReadModel{StartedAt: arg.CursorStartedAt, TransferID: arg.CursorTransferID.String}
+
+	readModels := []sqlc.CoreTransferReadModel{}
+	for _, readModel := range s.db.tables.transferReadModels {
+		switch {
+		case arg.Account.Valid && readModel.FromAccount != arg.Account.String && readModel.ToAccount != arg.Account.String:
+			continue
+		case arg.Status.Valid && readModel.Status != arg.Status.String:
+			continue
+		case arg.StartedFrom.Valid && readModel.StartedAt.Time.Before(arg.StartedFrom.Time):
+			continue
+		case arg.StartedTo.Valid && !readModel.StartedAt.Time.Before(arg.StartedTo.Time):
+			continue
+		case arg.CursorStartedAt.Valid && compareReadModels(readModel, cursor) >= 0:
+			continue
+		}
+		readModels = append(readModels, readModel)
+	}
+
+	// ORDER BY started_at DESC, transfer_id DESC
+	slices.SortFunc(readModels, func(a, b sqlc.CoreTransferReadModel) int {
+		return compareReadModels(b, a)
+	})
+
+	return limit(readModels, arg.RowLimit), nil
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *Store) NextTransferReferenceSequence(ctx context.Context, arg sqlc.NextTransferReferenceSequenceParams) (int32, error) {
+	defer s.write()()
+
+	key := transferReferenceSequenceKey{arg.BranchCode, arg.BusinessDate.Time}
+	sequence := s.db.tables.transferReferenceSequences[key] + 1
+	put(s, s.db.tables.transferReferenceSequences, key, sequence)
+
+	return sequence, nil
+}
+
+func (s *Store) CreateTransferReference(ctx context.Context, arg sqlc.CreateTransferReferenceParams) (sqlc.CoreTransferReference, error) {
+	defer s.write()()
+
+	for _, reference := range s.db.tables.transferReferences {
+		switch {
+		case reference.Reference == arg.Reference:
+			return sqlc.CoreTransferReference{}, uniqueViolation("transfer_references_reference_key")
+		case reference.TransferID == arg.TransferID:
+			return sqlc.CoreTransferReference{}, uniqueViolation("transfer_references_transfer_id_key")
+		case reference.BranchCode == arg.BranchCode && reference.BusinessDate.Time.Equal(arg.BusinessDate.Time) && reference.SequenceNumber == arg.SequenceNumber:
+			return sqlc.CoreTransferReference{}, uniqueViolation("transfer_references_branch_code_business_date_sequence_number_key")
+		}
+	}
+
+	reference := sqlc.CoreTransferReference{
+		ID:             newID(),
+		Reference:      arg.Reference,
+		TransferID:     arg.TransferID,
+		BranchCode:     arg.BranchCode,
+		BusinessDate:   arg.BusinessDate,
+		SequenceNumber: arg.SequenceNumber,
+		CreatedAt:      now(),
+	}
+	insert(s, &s.db.tables.transferReferences, reference)
+
+	return reference, nil
+}
+
+func (s *Store) GetTransferReference(ctx context.Context, value string) (sqlc.CoreTransferReference, error) {
+	defer s.read()()
+
+	for _, reference := range s.db.tables.transferReferences {
+		if reference.Reference == value {
+			return reference, nil
+		}
+	}
+
+	return sqlc.CoreTransferReference{}, pgx.ErrNoRows
+}
+
+func (s *Store) GetTransferReferenceByTransferID(ctx context.Context, transferID string) (sqlc.CoreTransferReference, error) {
+	defer s.read()()
+
+	for _, reference := range s.db.tables.transferReferences {
+		if reference.TransferID == transferID {
+			return reference, nil
+		}
+	}
+
+	return sqlc.CoreTransferReference{}, pgx.ErrNoRows
+}
@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// The queries below return ErrNotSupported. cmd/start.go turns off the settlement, ledger export and end-of-day
+// balance schedules when the in-memory store is used; account erasure and pending adjustments fail when requested.
+
+// Settlement
+
+func (s *Store) AcknowledgeSettlementFile(ctx context.Context, arg sqlc.AcknowledgeSettlementFileParams) (sqlc.CoreSettlementFile, error) {
+	return sqlc.CoreSettlementFile{}, ErrNotSupported
+}
+
+func (s *Store) CreateSettlementEntry(ctx context.Context, arg sqlc.CreateSettlementEntryParams) (sqlc.CoreSettlementEntry, error) {
+	return sqlc.CoreSettlementEntry{}, ErrNotSupported
+}
+
+func (s *Store) CreateSettlementFile(ctx context.Context, arg sqlc.CreateSettlementFileParams) (sqlc.CoreSettlementFile, error) {
+	return sqlc.CoreSettlementFile{}, ErrNotSupported
+}
+
+func (s *Store) GetSettlementEntriesByFile(ctx context.Context, settlementFileID pgtype.UUID) ([]sqlc.CoreSettlementEntry, error) {
+	return nil, ErrNotSupported
+}
+
+func (s *Store) GetSettlementEntryByTransferID(ctx context.Context, transferID string) (sqlc.CoreSettlementEntry, error) {
+	return sqlc.CoreSettlementEntry{}, ErrNotSupported
+}
+
+func (s *Store) GetSettlementFile(ctx context.Context, id pgtype.UUID) (sqlc.CoreSettlementFile, error) {
+	return sqlc.CoreSettlementFile{}, ErrNotSupported
+}
+
+func (s *Store) GetSettlementFileForUpdate(ctx context.Context, id pgtype.UUID) (sqlc.CoreSettlementFile, error) {
+	return sqlc.CoreSettlementFile{}, ErrNotSupported
+}
+
+func (s *Store) ListSettlementFiles(ctx context.Context, limit int32) ([]sqlc.ListSettlementFilesRow, error) {
+	return nil, ErrNotSupported
+}
+
+func (s *Store) ListUnsettledTransfers(ctx context.Context, limit int32) ([]sqlc.ListUnsettledTransfersRow, error) {
+	return nil, ErrNotSupported
+}
+
+func (s *Store) UpdateSettlementEntryStatus(ctx context.Context, arg sqlc.UpdateSettlementEntryStatusParams) (sqlc.CoreSettlementEntry, error) {
+	return sqlc.CoreSettlementEntry{}, ErrNotSupported
+}
+
+// Ledger export
+
+func (s *Store) ListBalanceHistoryForExport(ctx context.Context, arg sqlc.ListBalanceHistoryForExportParams) ([]sqlc.ListBalanceHistoryForExportRow, error) {
+	return nil, ErrNotSupported
+}
+
+func (s *Store) ListTransactionsForExport(ctx context.Context, arg sqlc.ListTransactionsForExportParams) ([]sqlc.ListTransactionsForExportRow, error) {
+	return nil, ErrNotSupported
+}
+
+// End-of-day balances
+
+func (s *Store) UpsertEODBalances(ctx context.Context, arg sqlc.UpsertEODBalancesParams) (int64, error) {
+	return 0, ErrNotSupported
+}
+
+// Account erasure
+
+func (s *Store) AnonymizeAccount(ctx context.Context, arg sqlc.AnonymizeAccountParams) (int64, error) {
+	return 0, ErrNotSupported
+}
+
+func (s *Store) AnonymizeAccountCompensationAudits(ctx context.Context, arg sqlc.AnonymizeAccountCompensationAuditsParams) (int64, error) {
+	return 0, ErrNotSupported
+}
+
+func (s *Store) AnonymizeAccountTransactions(ctx context.Context, arg sqlc.AnonymizeAccountTransactionsParams) (int64, error) {
+	return 0, ErrNotSupported
+}
+
+func (s *Store) AnonymizeAccountTransfers(ctx context.Context, arg sqlc.AnonymizeAccountTransfersParams) (int64, error) {
+	return 0, ErrNotSupported
+}
+
+func (s *Store) CountAccountPersonalData(ctx context.Context, arg sqlc.CountAccountPersonalDataParams) (sqlc.CountAccountPersonalDataRow, error) {
+	return sqlc.CountAccountPersonalDataRow{}, ErrNotSupported
+}
+
+func (s *Store) CreateAccountErasureCertificate(ctx context.Context, arg sqlc.CreateAccountErasureCertificateParams) (sqlc.CoreAccountErasureCertificate, error) {
+	return sqlc.CoreAccountErasureCertificate{}, ErrNotSupported
+}
+
+func (s *Store) GetAccountErasureCertificate(ctx context.Context, accountID pgtype.UUID) (sqlc.CoreAccountErasureCertificate, error) {
+	return sqlc.CoreAccountErasureCertificate{}, ErrNotSupported
+}
+
+// Pending adjustments
+
+func (s *Store) CreatePendingAdjustment(ctx context.Context, arg sqlc.CreatePendingAdjustmentParams) (sqlc.CorePendingAdjustment, error) {
+	return sqlc.CorePendingAdjustment{}, ErrNotSupported
+}
+
+func (s *Store) GetPendingAdjustment(ctx context.Context, id pgtype.UUID) (sqlc.CorePendingAdjustment, error) {
+	return sqlc.CorePendingAdjustment{}, ErrNotSupported
+}
+
+func (s *Store) GetPendingAdjustmentForUpdate(ctx context.Context, id pgtype.UUID) (sqlc.CorePendingAdjustment, error) {
+	return sqlc.CorePendingAdjustment{}, ErrNotSupported
+}
+
+func (s *Store) ListPendingAdjustments(ctx context.Context, arg sqlc.ListPendingAdjustmentsParams) ([]sqlc.CorePendingAdjustment, error) {
+	return nil, ErrNotSupported
+}
+
+func (s *Store) ReviewPendingAdjustment(ctx context.Context, arg sqlc.ReviewPendingAdjustmentParams) (sqlc.CorePendingAdjustment, error) {
+	return sqlc.CorePendingAdjustment{}, ErrNotSupported
+}
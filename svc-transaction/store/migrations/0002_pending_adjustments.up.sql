@@ -0,0 +1,25 @@
+-- Balance corrections held for a second operator's approval, for databases created before them
+CREATE TABLE IF NOT EXISTS core.pending_adjustments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES core.accounts(id),
+    amount DECIMAL(19,4) NOT NULL CHECK (amount <> 0),
+    currency core.currency_code NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    requested_by VARCHAR(100) NOT NULL,
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    reviewed_by VARCHAR(100),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT,
+    transaction_id UUID REFERENCES core.transactions(id),
+    CHECK (status <> 'approved' OR reviewed_by <> requested_by) -- Maker and checker are different operators
+);
+
+-- Pending adjustments indexes
+CREATE INDEX IF NOT EXISTS idx_pending_adjustments_status_requested ON core.pending_adjustments(status, requested_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pending_adjustments_account_id ON core.pending_adjustments(account_id, requested_at DESC);
+
+COMMENT ON TABLE core.pending_adjustments IS 'Balance corrections requested by one operator and applied only once a second operator approves them';
+COMMENT ON COLUMN core.pending_adjustments.amount IS 'Signed change of the balance; negative takes from it';
+COMMENT ON COLUMN core.pending_adjustments.status IS 'pending, approved or rejected';
+COMMENT ON COLUMN core.pending_adjustments.transaction_id IS 'Transaction that applied the adjustment once approved';
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	migrations, err := Load(files)
+	if err != nil {
+		t.Fatalf("Load(embedded) error = %v", err)
+	}
+	if len(migrations) < 1 || migrations[0].Name != "baseline" || !strings.Contains(migrations[0].SQL, "CREATE TABLE core.accounts") {
+		t.Errorf("Load(embedded) = %d migrations starting with %q, want the baseline first", len(migrations), migrations[0].Name)
+	}
+
+	sql := &fstest.MapFile{Data: []byte("SELECT 1;")}
+
+	migrations, err = Load(fstest.MapFS{"0002_second.up.sql": sql, "0001_first.up.sql": sql, "0010_tenth.up.sql": sql})
+	if err == nil || !strings.Contains(err.Error(), "without gaps") {
+		t.Errorf("Load(gap) = %v, %v, want a gap error", migrations, err)
+	}
+
+	migrations, err = Load(fstest.MapFS{"0002_second.up.sql": sql, "0001_first.up.sql": sql})
+	if err != nil || migrations[0].Name != "first" || migrations[1].Version != 2 {
+		t.Errorf("Load() = %+v, %v, want first then second", migrations, err)
+	}
+
+	for _, name := range []string{"first.sql", "0001_first.down.sql", "0001_First.up.sql"} {
+		if _, err := Load(fstest.MapFS{name: sql}); err == nil {
+			t.Errorf("Load(%s) error = nil, want a naming error", name)
+		}
+	}
+}
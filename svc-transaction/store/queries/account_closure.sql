@@ -0,0 +1,27 @@
+-- name: UpdateAccountStatus :one
+UPDATE core.accounts
+SET 
+    status = sqlc.arg(status),
+    version = version + 1
+WHERE id = sqlc.arg(id)
+RETURNING id, status, version;
+
+-- name: CreateAccountClosureStep :one
+INSERT INTO core.account_closure_audit_trail (
+    account_id,
+    workflow_id,
+    run_id,
+    step,
+    details
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetAccountClosureStep :one
+SELECT * FROM core.account_closure_audit_trail
+WHERE run_id = $1 AND step = $2;
+
+-- name: ListAccountClosureSteps :many
+SELECT * FROM core.account_closure_audit_trail
+WHERE account_id = $1
+ORDER BY created_at ASC, id ASC;
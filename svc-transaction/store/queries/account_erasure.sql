@@ -0,0 +1,68 @@
+-- name: AnonymizeAccount :execrows
+UPDATE core.accounts
+SET 
+    account_name = sqlc.arg(erased_name)::TEXT,
+    version = version + 1
+WHERE id = sqlc.arg(id) AND account_name <> sqlc.arg(erased_name)::TEXT;
+
+-- name: AnonymizeAccountTransactions :execrows
+-- Drops descriptions and every metadata key outside kept_metadata_keys
+UPDATE core.transactions
+SET 
+    description = NULL,
+    metadata = metadata - ARRAY(SELECT k FROM jsonb_object_keys(metadata) AS k WHERE k <> ALL(sqlc.arg(kept_metadata_keys)::TEXT[]))
+WHERE account_id = sqlc.arg(account_id)
+    AND (description IS NOT NULL OR EXISTS (SELECT 1 FROM jsonb_object_keys(metadata) AS k WHERE k <> ALL(sqlc.arg(kept_metadata_keys)::TEXT[])));
+
+-- name: AnonymizeAccountTransfers :execrows
+-- Drops descriptions and every metadata key outside kept_metadata_keys from transfers on either side of the account
+UPDATE core.transfers
+SET 
+    description = NULL,
+    metadata = metadata - ARRAY(SELECT k FROM jsonb_object_keys(metadata) AS k WHERE k <> ALL(sqlc.arg(kept_metadata_keys)::TEXT[]))
+WHERE (from_account_id = sqlc.arg(account_id) OR to_account_id = sqlc.arg(account_id))
+    AND (description IS NOT NULL OR EXISTS (SELECT 1 FROM jsonb_object_keys(metadata) AS k WHERE k <> ALL(sqlc.arg(kept_metadata_keys)::TEXT[])));
+
+-- name: AnonymizeAccountCompensationAudits :execrows
+-- Replaces the free-text reasons and drops every metadata key outside kept_metadata_keys
+UPDATE core.compensation_audit_trail
+SET 
+    compensation_reason = sqlc.arg(erased_name)::TEXT,
+    metadata = metadata - ARRAY(SELECT k FROM jsonb_object_keys(metadata) AS k WHERE k <> ALL(sqlc.arg(kept_metadata_keys)::TEXT[]))
+WHERE (original_transaction_id IN (SELECT id FROM core.transactions WHERE account_id = sqlc.arg(account_id))
+        OR compensation_transaction_id IN (SELECT id FROM core.transactions WHERE account_id = sqlc.arg(account_id)))
+    AND (compensation_reason <> sqlc.arg(erased_name)::TEXT OR EXISTS (SELECT 1 FROM jsonb_object_keys(metadata) AS k WHERE k <> ALL(sqlc.arg(kept_metadata_keys)::TEXT[])));
+
+-- name: CountAccountPersonalData :one
+-- Rows the anonymize queries would still change, used to verify an erasure
+SELECT 
+    (SELECT COUNT(*) FROM core.accounts a
+        WHERE a.id = sqlc.arg(account_id) AND a.account_name <> sqlc.arg(erased_name)::TEXT) AS accounts,
+    (SELECT COUNT(*) FROM core.transactions t
+        WHERE t.account_id = sqlc.arg(account_id)
+            AND (t.description IS NOT NULL OR EXISTS (SELECT 1 FROM jsonb_object_keys(t.metadata) AS k WHERE k <> ALL(sqlc.arg(kept_metadata_keys)::TEXT[])))) AS transactions,
+    (SELECT COUNT(*) FROM core.transfers tr
+        WHERE (tr.from_account_id = sqlc.arg(account_id) OR tr.to_account_id = sqlc.arg(account_id))
+            AND (tr.description IS NOT NULL OR EXISTS (SELECT 1 FROM jsonb_object_keys(tr.metadata) AS k WHERE k <> ALL(sqlc.arg(kept_metadata_keys)::TEXT[])))) AS transfers,
+    (SELECT COUNT(*) FROM core.compensation_audit_trail c
+        WHERE (c.original_transaction_id IN (SELECT id FROM core.transactions WHERE account_id = sqlc.arg(account_id))
+                OR c.compensation_transaction_id IN (SELECT id FROM core.transactions WHERE account_id = sqlc.arg(account_id)))
+            AND (c.compensation_reason <> sqlc.arg(erased_name)::TEXT OR EXISTS (SELECT 1 FROM jsonb_object_keys(c.metadata) AS k WHERE k <> ALL(sqlc.arg(kept_metadata_keys)::TEXT[])))) AS compensation_audits;
+
+-- name: CreateAccountErasureCertificate :one
+INSERT INTO core.account_erasure_certificates (
+    account_id,
+    workflow_id,
+    run_id,
+    requested_by,
+    closed_at,
+    scrubbed_rows,
+    digest,
+    erased_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetAccountErasureCertificate :one
+SELECT * FROM core.account_erasure_certificates
+WHERE account_id = $1;
@@ -0,0 +1,16 @@
+-- name: AddAccountOwner :one
+INSERT INTO core.account_owners (
+    account_id,
+    owner_id
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: ListAccountOwners :many
+SELECT * FROM core.account_owners
+WHERE account_id = $1
+ORDER BY created_at, owner_id;
+
+-- name: RemoveAccountOwner :execrows
+DELETE FROM core.account_owners
+WHERE account_id = $1 AND owner_id = $2;
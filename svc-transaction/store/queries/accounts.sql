@@ -0,0 +1,10 @@
+-- name: CreateAccount :one
+-- Opens an empty account; the opening balance is credited separately so it shows up in the balance history
+INSERT INTO core.accounts (
+    account_number,
+    account_name,
+    currency,
+    account_type
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
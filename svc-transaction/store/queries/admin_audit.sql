@@ -0,0 +1,23 @@
+-- name: CreateAdminAudit :one
+INSERT INTO core.admin_audit (
+    actor,
+    action,
+    service,
+    resource_type,
+    resource_id,
+    before_payload,
+    after_payload
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: ListAdminAudits :many
+SELECT * FROM core.admin_audit
+WHERE (sqlc.narg(actor)::TEXT IS NULL OR actor = sqlc.narg(actor))
+AND (sqlc.narg(action)::TEXT IS NULL OR action = sqlc.narg(action))
+AND (sqlc.narg(resource_type)::TEXT IS NULL OR resource_type = sqlc.narg(resource_type))
+AND (sqlc.narg(resource_id)::TEXT IS NULL OR resource_id = sqlc.narg(resource_id))
+AND (sqlc.narg(created_from)::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg(created_from))
+AND (sqlc.narg(created_to)::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg(created_to))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,8 @@
+-- name: GetAccountLedgerBalance :one
+-- Balance implied by the completed transactions of an account
+SELECT
+    COALESCE(SUM(CASE WHEN transaction_type = 'credit' THEN amount ELSE -amount END), 0)::DECIMAL AS ledger_balance,
+    COUNT(*) AS transaction_count
+FROM core.transactions
+WHERE account_id = sqlc.arg(account_id)
+    AND status = 'completed';
@@ -0,0 +1,3 @@
+-- name: ListBusinessRules :many
+SELECT * FROM core.business_rules
+ORDER BY name ASC, currency ASC NULLS FIRST;
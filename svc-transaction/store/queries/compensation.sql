@@ -67,4 +67,34 @@ FROM core.compensation_audit_trail
 WHERE compensation_status = 'timeout' 
 AND timeout_duration_ms > $1
 ORDER BY timeout_duration_ms DESC, created_at DESC
-LIMIT $2; 
\ No newline at end of file
+LIMIT $2; 
+-- name: ListCompensationAudits :many
+SELECT * FROM core.compensation_audit_trail
+WHERE (sqlc.narg(compensation_status)::core.compensation_status IS NULL OR compensation_status = sqlc.narg(compensation_status))
+AND (sqlc.narg(compensation_type)::core.compensation_type IS NULL OR compensation_type = sqlc.narg(compensation_type))
+AND (sqlc.narg(workflow_id)::TEXT IS NULL OR workflow_id = sqlc.narg(workflow_id))
+AND (sqlc.narg(created_from)::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg(created_from))
+AND (sqlc.narg(created_to)::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg(created_to))
+ORDER BY created_at DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetCompensationStatsFiltered :one
+SELECT 
+    COUNT(*) as total_compensations,
+    COUNT(*) FILTER (WHERE compensation_status = 'completed') as completed_compensations,
+    COUNT(*) FILTER (WHERE compensation_status = 'failed') as failed_compensations,
+    COUNT(*) FILTER (WHERE compensation_status = 'timeout') as timeout_compensations,
+    COUNT(*) FILTER (WHERE compensation_status = 'manual_required') as manual_compensations,
+    COUNT(*) FILTER (WHERE compensation_status = 'pending') as pending_compensations,
+    COALESCE(AVG(compensation_attempts), 0)::FLOAT8 as avg_attempts
+FROM core.compensation_audit_trail
+WHERE (sqlc.narg(compensation_status)::core.compensation_status IS NULL OR compensation_status = sqlc.narg(compensation_status))
+AND (sqlc.narg(compensation_type)::core.compensation_type IS NULL OR compensation_type = sqlc.narg(compensation_type))
+AND created_at >= sqlc.arg(created_from)::TIMESTAMPTZ
+AND created_at < sqlc.arg(created_to)::TIMESTAMPTZ;
+
+-- name: CountCompensationBacklog :one
+-- Compensations created since created_from that have not completed
+SELECT COUNT(*) FROM core.compensation_audit_trail
+WHERE compensation_status <> 'completed'
+AND created_at >= sqlc.arg(created_from)::TIMESTAMPTZ;
@@ -0,0 +1,43 @@
+-- name: UpsertEODBalances :execrows
+-- Closing balances of the accounts opened before day_end, taken from their last balance history entry before
+-- day_end; running the same business date again overwrites its rows
+INSERT INTO core.eod_balances (
+    account_id,
+    business_date,
+    currency,
+    closing_balance,
+    entry_count,
+    last_sequence_number,
+    computed_at
+)
+SELECT
+    a.id,
+    sqlc.arg(business_date)::date,
+    a.currency,
+    COALESCE(last_entry.new_balance, 0),
+    day_entries.entry_count::integer,
+    COALESCE(last_entry.sequence_number, 0),
+    NOW()
+FROM core.accounts a
+LEFT JOIN LATERAL (
+    SELECT h.new_balance, h.sequence_number
+    FROM core.account_balance_history h
+    WHERE h.account_id = a.id
+        AND h.created_at < sqlc.arg(day_end)::timestamptz
+    ORDER BY h.sequence_number DESC
+    LIMIT 1
+) last_entry ON TRUE
+CROSS JOIN LATERAL (
+    SELECT COUNT(*) AS entry_count
+    FROM core.account_balance_history h
+    WHERE h.account_id = a.id
+        AND h.created_at >= sqlc.arg(day_start)::timestamptz
+        AND h.created_at < sqlc.arg(day_end)::timestamptz
+) day_entries
+WHERE a.created_at < sqlc.arg(day_end)::timestamptz
+ON CONFLICT (account_id, business_date) DO UPDATE SET
+    currency = EXCLUDED.currency,
+    closing_balance = EXCLUDED.closing_balance,
+    entry_count = EXCLUDED.entry_count,
+    last_sequence_number = EXCLUDED.last_sequence_number,
+    computed_at = EXCLUDED.computed_at;
@@ -0,0 +1,33 @@
+-- name: GetIdempotencyKey :one
+SELECT * FROM core.idempotency_keys
+WHERE idempotency_key = $1 AND expires_at > NOW();
+
+-- name: CreateIdempotencyKey :one
+-- Registers a key, taking over an expired registration the cleanup job has not deleted yet.
+-- Returns no row while the key is still registered.
+INSERT INTO core.idempotency_keys (
+    idempotency_key,
+    scope,
+    request_hash,
+    response_snapshot,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (idempotency_key) DO UPDATE SET
+    scope = EXCLUDED.scope,
+    request_hash = EXCLUDED.request_hash,
+    response_snapshot = EXCLUDED.response_snapshot,
+    created_at = NOW(),
+    expires_at = EXCLUDED.expires_at
+WHERE core.idempotency_keys.expires_at <= NOW()
+RETURNING *;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM core.idempotency_keys
+WHERE idempotency_key IN (
+    SELECT idempotency_key FROM core.idempotency_keys
+    WHERE expires_at <= NOW()
+    ORDER BY expires_at
+    LIMIT $1
+);
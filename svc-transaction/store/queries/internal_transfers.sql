@@ -0,0 +1,47 @@
+-- name: AcquireAccountLock :exec
+-- Transaction-scoped advisory lock serializing mutations of one account across service instances
+SELECT pg_advisory_xact_lock(hashtextextended('core.accounts:' || sqlc.arg(account_id)::TEXT, 0));
+
+-- name: GetAccountForUpdate :one
+SELECT 
+    id,
+    account_number,
+    account_name,
+    balance,
+    currency,
+    status,
+    created_at,
+    updated_at,
+    version,
+    account_type
+FROM core.accounts
+WHERE id = $1
+FOR UPDATE;
+
+-- name: AdjustAccountBalance :one
+UPDATE core.accounts
+SET 
+    balance = balance + sqlc.arg(balance_change)::DECIMAL,
+    version = version + 1
+WHERE id = sqlc.arg(id)
+RETURNING id, balance, version;
+
+-- name: CreateTransfer :one
+INSERT INTO core.transfers (
+    transfer_id,
+    from_account_id,
+    to_account_id,
+    amount,
+    currency,
+    description,
+    status,
+    debit_transaction_id,
+    credit_transaction_id,
+    metadata
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: GetTransferByTransferID :one
+SELECT * FROM core.transfers
+WHERE transfer_id = $1;
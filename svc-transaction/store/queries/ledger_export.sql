@@ -0,0 +1,42 @@
+-- name: ListTransactionsForExport :many
+-- Transactions created in [created_from, created_to) after the (after_created_at, after_id) keyset cursor
+SELECT
+    t.id,
+    t.account_id,
+    a.account_number,
+    t.transaction_type,
+    t.amount,
+    t.currency,
+    t.description,
+    t.reference_id,
+    t.status,
+    t.created_at,
+    t.updated_at,
+    t.completed_at
+FROM core.transactions t
+JOIN core.accounts a ON a.id = t.account_id
+WHERE t.created_at >= sqlc.arg(created_from)
+    AND t.created_at < sqlc.arg(created_to)
+    AND (t.created_at, t.id) > (sqlc.arg(after_created_at)::timestamptz, sqlc.arg(after_id)::uuid)
+ORDER BY t.created_at ASC, t.id ASC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListBalanceHistoryForExport :many
+-- Balance history entries created in [created_from, created_to) after the (after_created_at, after_id) keyset cursor
+SELECT
+    id,
+    account_id,
+    transaction_id,
+    old_balance,
+    new_balance,
+    balance_change,
+    operation,
+    created_at,
+    created_by,
+    sequence_number
+FROM core.account_balance_history
+WHERE created_at >= sqlc.arg(created_from)
+    AND created_at < sqlc.arg(created_to)
+    AND (created_at, id) > (sqlc.arg(after_created_at)::timestamptz, sqlc.arg(after_id)::uuid)
+ORDER BY created_at ASC, id ASC
+LIMIT sqlc.arg(row_limit);
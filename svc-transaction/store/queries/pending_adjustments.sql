@@ -0,0 +1,38 @@
+-- name: CreatePendingAdjustment :one
+INSERT INTO core.pending_adjustments (
+    account_id,
+    amount,
+    currency,
+    reason,
+    requested_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetPendingAdjustment :one
+SELECT * FROM core.pending_adjustments
+WHERE id = $1;
+
+-- name: GetPendingAdjustmentForUpdate :one
+SELECT * FROM core.pending_adjustments
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListPendingAdjustments :many
+SELECT * FROM core.pending_adjustments
+WHERE (sqlc.narg(status)::TEXT IS NULL OR status = sqlc.narg(status))
+AND (sqlc.narg(account_id)::UUID IS NULL OR account_id = sqlc.narg(account_id))
+ORDER BY requested_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ReviewPendingAdjustment :one
+-- Moves a pending adjustment to approved or rejected; returns no row when it was reviewed already
+UPDATE core.pending_adjustments
+SET status = sqlc.arg(status),
+    reviewed_by = sqlc.arg(reviewed_by),
+    reviewed_at = NOW(),
+    review_note = sqlc.narg(review_note),
+    transaction_id = sqlc.narg(transaction_id)
+WHERE id = sqlc.arg(id)
+AND status = 'pending'
+RETURNING *;
@@ -0,0 +1,22 @@
+-- name: ListStalePendingTransactions :many
+-- Pending transactions created before stale_before, oldest first
+SELECT
+    id,
+    account_id,
+    transaction_type,
+    amount,
+    currency,
+    idempotency_key,
+    created_at
+FROM core.transactions
+WHERE status = 'pending'
+    AND created_at < sqlc.arg(stale_before)
+ORDER BY created_at ASC
+LIMIT sqlc.arg(row_limit);
+
+-- name: CountPendingTransactions :one
+SELECT
+    COUNT(*) AS pending,
+    COUNT(*) FILTER (WHERE created_at < sqlc.arg(stale_before)) AS stale
+FROM core.transactions
+WHERE status = 'pending';
@@ -0,0 +1,10 @@
+-- name: ListAccountsForReconciliation :many
+SELECT * FROM core.accounts
+WHERE sqlc.narg(account_id)::UUID IS NULL OR id = sqlc.narg(account_id)
+ORDER BY account_number ASC;
+
+-- name: ListBalanceHistoryChain :many
+-- Full history of one account in chain order, for verification
+SELECT * FROM core.account_balance_history
+WHERE account_id = $1
+ORDER BY sequence_number ASC;
@@ -0,0 +1,93 @@
+-- name: ListUnsettledTransfers :many
+SELECT
+    t.transfer_id,
+    t.amount,
+    t.currency,
+    t.description,
+    COALESCE(t.completed_at, t.created_at)::TIMESTAMPTZ AS completed_at,
+    fa.account_number AS from_account_number,
+    fa.account_name AS from_account_name,
+    ta.account_number AS to_account_number,
+    ta.account_name AS to_account_name
+FROM core.transfers t
+JOIN core.accounts fa ON fa.id = t.from_account_id
+JOIN core.accounts ta ON ta.id = t.to_account_id
+LEFT JOIN core.settlement_entries se ON se.transfer_id = t.transfer_id
+WHERE t.status = 'completed'
+    AND se.id IS NULL
+ORDER BY COALESCE(t.completed_at, t.created_at), t.transfer_id
+LIMIT $1
+FOR UPDATE OF t SKIP LOCKED;
+
+-- name: CreateSettlementFile :one
+INSERT INTO core.settlement_files (
+    file_reference,
+    format,
+    transfer_count,
+    control_sum,
+    content,
+    checksum
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: CreateSettlementEntry :one
+INSERT INTO core.settlement_entries (
+    settlement_file_id,
+    transfer_id
+) VALUES (
+    $1, $2
+) RETURNING *;
+
+-- name: GetSettlementFile :one
+SELECT * FROM core.settlement_files
+WHERE id = $1;
+
+-- name: GetSettlementFileForUpdate :one
+SELECT * FROM core.settlement_files
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListSettlementFiles :many
+SELECT
+    id,
+    file_reference,
+    format,
+    status,
+    transfer_count,
+    control_sum,
+    checksum,
+    acknowledgement_reference,
+    acknowledged_at,
+    created_at,
+    updated_at
+FROM core.settlement_files
+ORDER BY created_at DESC
+LIMIT $1;
+
+-- name: GetSettlementEntriesByFile :many
+SELECT * FROM core.settlement_entries
+WHERE settlement_file_id = $1
+ORDER BY transfer_id;
+
+-- name: GetSettlementEntryByTransferID :one
+SELECT * FROM core.settlement_entries
+WHERE transfer_id = $1;
+
+-- name: UpdateSettlementEntryStatus :one
+UPDATE core.settlement_entries
+SET
+    settlement_status = $2,
+    rejection_reason = $3,
+    settled_at = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: AcknowledgeSettlementFile :one
+UPDATE core.settlement_files
+SET
+    status = $2,
+    acknowledgement_reference = $3,
+    acknowledged_at = NOW()
+WHERE id = $1
+RETURNING *;
@@ -85,6 +85,13 @@ SET
 WHERE id = $1 AND status = 'pending'
 RETURNING id, status, updated_at;
 
+-- name: GetCompensatedAmount :one
+SELECT COALESCE(SUM(amount), 0)::DECIMAL AS compensated_amount
+FROM core.transactions
+WHERE transaction_type = 'credit'
+    AND status = 'completed'
+    AND metadata->>'original_transaction_id' = sqlc.arg(original_transaction_id)::TEXT;
+
 -- name: GetTransactionsByAccount :many
 SELECT 
     id,
@@ -238,7 +245,8 @@ SELECT
     status,
     created_at,
     updated_at,
-    version
+    version,
+    account_type
 FROM core.accounts
 WHERE id = $1;
 
@@ -252,7 +260,8 @@ SELECT
     status,
     created_at,
     updated_at,
-    version
+    version,
+    account_type
 FROM core.accounts
 WHERE account_number = $1;
 
@@ -264,6 +273,7 @@ SELECT
     balance,
     currency,
     status,
+    account_type,
     CASE 
         WHEN $2::DECIMAL IS NULL THEN TRUE
         WHEN balance >= $2::DECIMAL THEN TRUE
@@ -272,6 +282,15 @@ SELECT
 FROM core.accounts
 WHERE id = $1;
 
+-- name: CountOutgoingDebits :one
+-- Debits of an account created since created_from that were not cancelled or failed; debits later compensated
+-- still count
+SELECT COUNT(*) FROM core.transactions
+WHERE account_id = sqlc.arg(account_id)
+AND transaction_type = 'debit'
+AND status IN ('pending', 'completed')
+AND created_at >= sqlc.arg(created_from)::TIMESTAMPTZ;
+
 -- name: CreateBalanceHistoryRecord :one
 INSERT INTO core.account_balance_history (
     account_id,
@@ -295,7 +314,9 @@ SELECT
     balance_change,
     operation,
     created_at,
-    created_by
+    created_by,
+    sequence_number,
+    chain_hash
 FROM core.account_balance_history
 WHERE account_id = $1
 ORDER BY created_at DESC
@@ -311,7 +332,9 @@ SELECT
     balance_change,
     operation,
     created_at,
-    created_by
+    created_by,
+    sequence_number,
+    chain_hash
 FROM core.account_balance_history
 WHERE transaction_id = $1
 ORDER BY created_at ASC;
@@ -326,7 +349,9 @@ SELECT
     balance_change,
     operation,
     created_at,
-    created_by
+    created_by,
+    sequence_number,
+    chain_hash
 FROM core.account_balance_history
 WHERE account_id = $1
     AND created_at BETWEEN $2 AND $3
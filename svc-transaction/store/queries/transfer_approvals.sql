@@ -0,0 +1,15 @@
+-- name: CreateTransferApproval :execrows
+INSERT INTO core.transfer_approvals (
+    transfer_id,
+    workflow_id,
+    approved_by,
+    approved_at
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (transfer_id, approved_by) DO NOTHING;
+
+-- name: ListTransferApprovals :many
+SELECT * FROM core.transfer_approvals
+WHERE transfer_id = $1
+ORDER BY approved_at, approved_by;
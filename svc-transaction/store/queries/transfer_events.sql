@@ -0,0 +1,25 @@
+-- name: CreateTransferEvent :execrows
+INSERT INTO core.transfer_events (
+    transfer_id,
+    workflow_id,
+    run_id,
+    sequence_number,
+    event_type,
+    status,
+    step,
+    from_account,
+    to_account,
+    amount,
+    currency,
+    description,
+    error_message,
+    occurred_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+)
+ON CONFLICT (workflow_id, run_id, sequence_number) DO NOTHING;
+
+-- name: ListTransferEvents :many
+SELECT * FROM core.transfer_events
+WHERE transfer_id = $1
+ORDER BY occurred_at, sequence_number;
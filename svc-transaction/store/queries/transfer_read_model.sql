@@ -0,0 +1,75 @@
+-- name: ListTransferEventsAfter :many
+SELECT * FROM core.transfer_events
+WHERE position > sqlc.arg(after_position)
+AND recorded_at < sqlc.arg(recorded_before)
+ORDER BY position
+LIMIT sqlc.arg(row_limit);
+
+-- name: UpsertTransferReadModel :execrows
+INSERT INTO core.transfer_read_model (
+    transfer_id,
+    workflow_id,
+    run_id,
+    status,
+    last_event_type,
+    last_step,
+    from_account,
+    to_account,
+    amount,
+    currency,
+    description,
+    error_message,
+    started_at,
+    finished_at,
+    updated_at,
+    event_position
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+)
+ON CONFLICT (transfer_id) DO UPDATE SET
+    workflow_id = EXCLUDED.workflow_id,
+    run_id = EXCLUDED.run_id,
+    status = EXCLUDED.status,
+    last_event_type = EXCLUDED.last_event_type,
+    last_step = EXCLUDED.last_step,
+    from_account = EXCLUDED.from_account,
+    to_account = EXCLUDED.to_account,
+    amount = EXCLUDED.amount,
+    currency = EXCLUDED.currency,
+    description = EXCLUDED.description,
+    error_message = EXCLUDED.error_message,
+    started_at = LEAST(core.transfer_read_model.started_at, EXCLUDED.started_at),
+    finished_at = EXCLUDED.finished_at,
+    updated_at = EXCLUDED.updated_at,
+    event_position = EXCLUDED.event_position,
+    projected_at = NOW()
+WHERE core.transfer_read_model.event_position < EXCLUDED.event_position;
+
+-- name: GetProjectionCheckpoint :one
+SELECT position FROM core.projection_checkpoints
+WHERE projection = $1;
+
+-- name: UpsertProjectionCheckpoint :exec
+INSERT INTO core.projection_checkpoints (
+    projection,
+    position
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (projection) DO UPDATE SET
+    position = EXCLUDED.position,
+    updated_at = NOW();
+
+-- name: GetTransferReadModel :one
+SELECT * FROM core.transfer_read_model
+WHERE transfer_id = $1;
+
+-- name: ListTransferReadModels :many
+SELECT * FROM core.transfer_read_model
+WHERE (sqlc.narg(account)::TEXT IS NULL OR from_account = sqlc.narg(account) OR to_account = sqlc.narg(account))
+AND (sqlc.narg(status)::TEXT IS NULL OR status = sqlc.narg(status))
+AND (sqlc.narg(started_from)::TIMESTAMPTZ IS NULL OR started_at >= sqlc.narg(started_from))
+AND (sqlc.narg(started_to)::TIMESTAMPTZ IS NULL OR started_at < sqlc.narg(started_to))
+AND (sqlc.narg(cursor_started_at)::TIMESTAMPTZ IS NULL OR (started_at, transfer_id) < (sqlc.narg(cursor_started_at), sqlc.narg(cursor_transfer_id)::TEXT))
+ORDER BY started_at DESC, transfer_id DESC
+LIMIT sqlc.arg(row_limit);
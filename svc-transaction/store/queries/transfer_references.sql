@@ -0,0 +1,30 @@
+-- name: NextTransferReferenceSequence :one
+INSERT INTO core.transfer_reference_sequences (
+    branch_code,
+    business_date,
+    last_sequence
+) VALUES (
+    $1, $2, 1
+)
+ON CONFLICT (branch_code, business_date)
+DO UPDATE SET last_sequence = core.transfer_reference_sequences.last_sequence + 1
+RETURNING last_sequence;
+
+-- name: CreateTransferReference :one
+INSERT INTO core.transfer_references (
+    reference,
+    transfer_id,
+    branch_code,
+    business_date,
+    sequence_number
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetTransferReference :one
+SELECT * FROM core.transfer_references
+WHERE reference = $1;
+
+-- name: GetTransferReferenceByTransferID :one
+SELECT * FROM core.transfer_references
+WHERE transfer_id = $1;
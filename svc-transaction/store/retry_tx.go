@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+)
+
+// Postgres error codes of transactions that failed only because of concurrent transactions
+const (
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
+)
+
+// RetryOptions bounds the attempts of WithRetryTx
+type RetryOptions struct {
+	MaxAttempts    int           // Including the first one
+	InitialBackoff time.Duration // Doubled after every failed attempt
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryOptions returns the retry options of money movements: 5 attempts, backing off from 20ms up to 500ms
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		MaxAttempts:    5,
+		InitialBackoff: 20 * time.Millisecond,
+		MaxBackoff:     500 * time.Millisecond,
+	}
+}
+
+// IsRetryableTxError reports whether err is a serialization failure or a deadlock, after which the transaction can
+// succeed when it is run again
+func IsRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == serializationFailureCode || pgErr.Code == deadlockDetectedCode
+}
+
+// WithRetryTx runs fn in a SERIALIZABLE transaction and runs it again in a new one, after a jittered backoff, when
+// the transaction fails with a serialization failure or a deadlock. fn must be safe to run more than once: every
+// attempt starts from a rolled back database, but values fn assigns outside of it are only those of the last attempt.
+func (store *Store) WithRetryTx(ctx context.Context, fn func(sqlc.Querier) error) error {
+	return retryTx(ctx, store.logger, DefaultRetryOptions(), func() error {
+		return store.WithTxOptions(ctx, DefaultTxOptions(), fn)
+	})
+}
+
+// retryTx calls attempt until it succeeds, fails with an error that is not retryable, or runs out of attempts
+func retryTx(ctx context.Context, logger *logrus.Logger, opts RetryOptions, attempt func() error) error {
+	backoff := opts.InitialBackoff
+
+	for n := 1; ; n++ {
+		err := attempt()
+		if err == nil || !IsRetryableTxError(err) {
+			return err
+		}
+		if n >= opts.MaxAttempts {
+			return fmt.Errorf("transaction failed after %d attempts: %w", n, err)
+		}
+
+		// Full jitter keeps transactions that conflicted once from meeting again on the next attempt
+		var wait time.Duration
+		if backoff > 0 {
+			wait = rand.N(backoff) + 1
+		}
+
+		logger.WithFields(logrus.Fields{
+			"[op]":    "store.retryTx",
+			"attempt": n,
+			"backoff": wait.String(),
+		}).WithError(err).Warn("Retrying transaction after a serialization failure")
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("transaction retry canceled: %w", errors.Join(ctx.Err(), err))
+		case <-time.After(wait):
+		}
+
+		backoff = min(2*backoff, opts.MaxBackoff)
+	}
+}
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/sirupsen/logrus"
+)
+
+func TestIsRetryableTxError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "Serialization failure", err: &pgconn.PgError{Code: "40001"}, want: true},
+		{name: "Wrapped deadlock", err: fmt.Errorf("failed to commit transaction: %w", &pgconn.PgError{Code: "40P01"}), want: true},
+		{name: "Unique violation", err: &pgconn.PgError{Code: "23505"}},
+		{name: "Other error", err: errors.New("insufficient funds")},
+		{name: "Nil", err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsRetryableTxError(tt.err); got != tt.want {
+				t.Errorf("IsRetryableTxError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryTx(t *testing.T) {
+	t.Parallel()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	opts := RetryOptions{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+	conflict := &pgconn.PgError{Code: "40001"}
+	insufficientFunds := errors.New("insufficient funds")
+
+	tests := []struct {
+		name         string
+		failures     []error // Returned by the attempts in order; later attempts succeed
+		wantAttempts int
+		wantErr      error
+	}{
+		{name: "First attempt succeeds", wantAttempts: 1},
+		{name: "Succeeds after conflicts", failures: []error{conflict, conflict}, wantAttempts: 3},
+		{name: "Gives up after max attempts", failures: []error{conflict, conflict, conflict, conflict}, wantAttempts: 3, wantErr: conflict},
+		{name: "Does not retry other errors", failures: []error{insufficientFunds}, wantAttempts: 1, wantErr: insufficientFunds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			attempts := 0
+			err := retryTx(context.Background(), logger, opts, func() error {
+				attempts++
+				if attempts <= len(tt.failures) {
+					return tt.failures[attempts-1]
+				}
+				return nil
+			})
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("retryTx() made %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("retryTx() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("retryTx() error = %v, want nil", err)
+			}
+		})
+	}
+
+	t.Run("Canceled context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := retryTx(ctx, logger, RetryOptions{MaxAttempts: 3, InitialBackoff: time.Hour, MaxBackoff: time.Hour}, func() error {
+			return conflict
+		})
+		if !errors.Is(err, context.Canceled) || !errors.Is(err, conflict) {
+			t.Errorf("retryTx() error = %v, want the cancellation and the conflict", err)
+		}
+	})
+}
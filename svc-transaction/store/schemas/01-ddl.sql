@@ -3,12 +3,16 @@ CREATE SCHEMA IF NOT EXISTS "core";
 
 -- Type definitions
 CREATE TYPE core.account_status AS ENUM ('active', 'inactive', 'suspended', 'closed');
+CREATE TYPE core.account_type AS ENUM ('checking', 'savings');
 CREATE TYPE core.currency_code AS ENUM ('USD', 'EUR', 'GBP', 'JPY', 'CAD', 'AUD', 'CHF', 'CNY', 'SGD', 'HKD');
 CREATE TYPE core.transaction_type AS ENUM ('debit', 'credit');
 CREATE TYPE core.transaction_status AS ENUM ('pending', 'completed', 'failed', 'cancelled');
 CREATE TYPE core.transfer_status AS ENUM ('pending', 'processing', 'completed', 'failed', 'cancelled');
 CREATE TYPE core.compensation_type AS ENUM ('debit_reversal', 'credit_reversal', 'manual_adjustment');
 CREATE TYPE core.compensation_status AS ENUM ('pending', 'completed', 'failed', 'timeout', 'manual_required');
+CREATE TYPE core.settlement_file_format AS ENUM ('csv', 'pacs008');
+CREATE TYPE core.settlement_file_status AS ENUM ('generated', 'acknowledged', 'partially_acknowledged', 'rejected');
+CREATE TYPE core.settlement_status AS ENUM ('pending', 'settled', 'rejected');
 
 -- Table definitions
 
@@ -22,7 +26,8 @@ CREATE TABLE core.accounts (
     status core.account_status NOT NULL DEFAULT 'active',
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
-    version INTEGER NOT NULL DEFAULT 1 -- For optimistic locking
+    version INTEGER NOT NULL DEFAULT 1, -- For optimistic locking
+    account_type core.account_type NOT NULL DEFAULT 'checking'
 );
 
 -- Transactions table for transaction service
@@ -74,7 +79,10 @@ CREATE TABLE core.account_balance_history (
     balance_change DECIMAL(19,4) NOT NULL,
     operation VARCHAR(50) NOT NULL, -- 'debit', 'credit', 'adjustment'
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
-    created_by VARCHAR(255) -- Service or user that made the change
+    created_by VARCHAR(255), -- Service or user that made the change
+    sequence_number BIGINT NOT NULL, -- Set by trigger_balance_history_chain
+    chain_hash VARCHAR(64) NOT NULL, -- Set by trigger_balance_history_chain
+    UNIQUE (account_id, sequence_number) -- Two writers cannot claim the same position in the chain
 );
 
 -- Compensation audit trail for tracking compensation operations
@@ -97,6 +105,257 @@ CREATE TABLE core.compensation_audit_trail (
     metadata JSONB -- Additional compensation context
 );
 
+-- Steps taken by account closure workflows, one row per step
+CREATE TABLE core.account_closure_audit_trail (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES core.accounts(id),
+    workflow_id VARCHAR(255) NOT NULL,
+    run_id VARCHAR(255) NOT NULL,
+    step VARCHAR(50) NOT NULL, -- 'blocked', 'drained', 'swept', 'closed', 'reopened'
+    details JSONB, -- Step-specific context such as the swept amount
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (run_id, step) -- Each step is recorded once per workflow run
+);
+
+-- Proof that the personal data of a closed account was erased, one per account
+CREATE TABLE core.account_erasure_certificates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL UNIQUE REFERENCES core.accounts(id), -- An account is erased at most once
+    workflow_id VARCHAR(255) NOT NULL,
+    run_id VARCHAR(255) NOT NULL,
+    requested_by VARCHAR(255),
+    closed_at TIMESTAMP WITH TIME ZONE NOT NULL, -- Start of the retention period that had to elapse
+    scrubbed_rows JSONB NOT NULL, -- Rows scrubbed per table
+    digest VARCHAR(64) NOT NULL, -- SHA-256 over the certificate fields
+    erased_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- State-changing admin calls, one row per call
+CREATE TABLE core.admin_audit (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor VARCHAR(255) NOT NULL, -- From the X-Actor header
+    action VARCHAR(100) NOT NULL, -- e.g. 'balance_alert.update', 'account_closure.start'
+    service VARCHAR(50) NOT NULL, -- Service that handled the call
+    resource_type VARCHAR(50) NOT NULL,
+    resource_id VARCHAR(255),
+    before_payload JSONB, -- NULL when the resource did not exist before the call
+    after_payload JSONB, -- NULL when the call removed the resource
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Standing balance alerts registered against accounts
+CREATE TABLE core.balance_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES core.accounts(id),
+    threshold DECIMAL(19,4) NOT NULL CHECK (threshold >= 0),
+    webhook_url TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    triggered BOOLEAN NOT NULL DEFAULT FALSE, -- Set while the balance stays below the threshold
+    last_triggered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Soft business validation rules shared by svc-balance and svc-transaction; rows of the same name are one check
+-- with per-currency thresholds
+CREATE TABLE core.business_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(50) NOT NULL CHECK (kind IN ('max_transaction_amount', 'min_account_name_length', 'min_balance')),
+    currency core.currency_code, -- NULL for any currency
+    threshold DECIMAL(19,4) NOT NULL,
+    severity VARCHAR(10) NOT NULL CHECK (severity IN ('error', 'warning')),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Exchange rates to USD as fetched over time, so conversions can be recomputed as of a past moment
+CREATE TABLE core.fx_rates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    currency core.currency_code NOT NULL,
+    rate_to_usd DECIMAL(24,10) NOT NULL CHECK (rate_to_usd > 0), -- Units of the currency per USD
+    source VARCHAR(50) NOT NULL,
+    fetched_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Outgoing settlement files batching completed transfers
+CREATE TABLE core.settlement_files (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    file_reference VARCHAR(35) NOT NULL UNIQUE, -- Also used as the ISO 20022 message ID
+    format core.settlement_file_format NOT NULL,
+    status core.settlement_file_status NOT NULL DEFAULT 'generated',
+    transfer_count INTEGER NOT NULL CHECK (transfer_count > 0),
+    control_sum DECIMAL(19,4) NOT NULL,
+    content TEXT NOT NULL,
+    checksum VARCHAR(64) NOT NULL, -- SHA-256 of content
+    acknowledgement_reference VARCHAR(255),
+    acknowledged_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Settlement state of each transfer included in a settlement file
+CREATE TABLE core.settlement_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    settlement_file_id UUID NOT NULL REFERENCES core.settlement_files(id),
+    transfer_id VARCHAR(255) NOT NULL UNIQUE REFERENCES core.transfers(transfer_id), -- A transfer is settled at most once
+    settlement_status core.settlement_status NOT NULL DEFAULT 'pending',
+    rejection_reason TEXT,
+    settled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Daily per-branch counters backing transfer reference sequence numbers
+CREATE TABLE core.transfer_reference_sequences (
+    branch_code VARCHAR(3) NOT NULL,
+    business_date DATE NOT NULL,
+    last_sequence INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (branch_code, business_date)
+);
+
+-- Human-friendly reference numbers printed on receipts, one per transfer
+CREATE TABLE core.transfer_references (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reference VARCHAR(32) NOT NULL UNIQUE, -- YYMMDD-BBB-NNNNNN-C
+    transfer_id VARCHAR(255) NOT NULL UNIQUE, -- Saga transfers have no core.transfers row, so no foreign key
+    branch_code VARCHAR(3) NOT NULL,
+    business_date DATE NOT NULL,
+    sequence_number INTEGER NOT NULL CHECK (sequence_number > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (branch_code, business_date, sequence_number)
+);
+
+-- Idempotency key registry shared by all svc-transaction operations, looked up before any other query
+CREATE TABLE core.idempotency_keys (
+    idempotency_key VARCHAR(255) PRIMARY KEY,
+    scope VARCHAR(32) NOT NULL, -- Operation that used the key: debit, credit, compensation or internal_transfer
+    request_hash CHAR(64) NOT NULL,
+    response_snapshot JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Email notification settings of account holders, one row per account
+CREATE TABLE core.notification_preferences (
+    account_id UUID PRIMARY KEY REFERENCES core.accounts(id),
+    email VARCHAR(320) NOT NULL,
+    notify_completed BOOLEAN NOT NULL DEFAULT TRUE,
+    notify_compensated BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Email addresses no notification is ever sent to, whatever the preferences say
+CREATE TABLE core.notification_suppressions (
+    email VARCHAR(320) PRIMARY KEY, -- Lower case
+    reason VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Closing balance of every account at the end of each business day (UTC), written by EODBalanceWorkflow
+CREATE TABLE core.eod_balances (
+    account_id UUID NOT NULL REFERENCES core.accounts(id),
+    business_date DATE NOT NULL,
+    currency core.currency_code NOT NULL,
+    closing_balance DECIMAL(19,4) NOT NULL,
+    entry_count INTEGER NOT NULL, -- Balance history entries of the day
+    last_sequence_number BIGINT NOT NULL, -- Last balance history entry included; 0 before the first one
+    computed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (account_id, business_date)
+);
+
+-- Phone numbers and email addresses a transfer can be sent to instead of an account
+CREATE TABLE core.account_aliases (
+    alias VARCHAR(320) PRIMARY KEY, -- Normalized: E.164 phone number or lower case email address
+    alias_type VARCHAR(10) NOT NULL CHECK (alias_type IN ('phone', 'email')),
+    account_id UUID NOT NULL REFERENCES core.accounts(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- State changes of saga transfers, recorded by their workflows as they happen
+CREATE TABLE core.transfer_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    position BIGINT GENERATED ALWAYS AS IDENTITY UNIQUE, -- Order in which the events were recorded, read by projections
+    transfer_id VARCHAR(255) NOT NULL, -- Saga transfers have no core.transfers row, so no foreign key
+    workflow_id VARCHAR(255) NOT NULL,
+    run_id VARCHAR(255) NOT NULL,
+    sequence_number INTEGER NOT NULL CHECK (sequence_number > 0),
+    event_type VARCHAR(32) NOT NULL, -- transfer_started, step_completed, step_failed or transfer_finished
+    status VARCHAR(32) NOT NULL, -- processing, completed, failed, compensated or expired
+    step VARCHAR(100), -- Saga activity of step events, e.g. DebitAccount
+    from_account VARCHAR(255) NOT NULL,
+    to_account VARCHAR(255) NOT NULL,
+    amount DECIMAL(19,4) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    error_message TEXT,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL, -- Workflow time of the state change
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (workflow_id, run_id, sequence_number)
+);
+
+CREATE TABLE core.transfer_read_model (
+    transfer_id VARCHAR(255) PRIMARY KEY,
+    workflow_id VARCHAR(255) NOT NULL,
+    run_id VARCHAR(255) NOT NULL,
+    status VARCHAR(32) NOT NULL, -- processing, completed, failed, compensated or expired
+    last_event_type VARCHAR(32) NOT NULL,
+    last_step VARCHAR(100),
+    from_account VARCHAR(255) NOT NULL,
+    to_account VARCHAR(255) NOT NULL,
+    amount DECIMAL(19,4) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    error_message TEXT,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL, -- Workflow time of the latest event
+    event_position BIGINT NOT NULL, -- core.transfer_events.position of the latest event
+    projected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE core.projection_checkpoints (
+    projection VARCHAR(100) PRIMARY KEY,
+    position BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE core.account_owners (
+    account_id UUID NOT NULL REFERENCES core.accounts(id),
+    owner_id VARCHAR(100) NOT NULL, -- Matches the approved_by of the transfer approvals given by the owner
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (account_id, owner_id)
+);
+
+CREATE TABLE core.transfer_approvals (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    transfer_id VARCHAR(255) NOT NULL, -- Saga transfers have no core.transfers row, so no foreign key
+    workflow_id VARCHAR(255) NOT NULL,
+    approved_by VARCHAR(100) NOT NULL,
+    approved_at TIMESTAMP WITH TIME ZONE NOT NULL, -- Workflow time the approval was accepted
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (transfer_id, approved_by)
+);
+
+CREATE TABLE core.pending_adjustments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    account_id UUID NOT NULL REFERENCES core.accounts(id),
+    amount DECIMAL(19,4) NOT NULL CHECK (amount <> 0),
+    currency core.currency_code NOT NULL,
+    reason TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    requested_by VARCHAR(100) NOT NULL,
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    reviewed_by VARCHAR(100),
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    review_note TEXT,
+    transaction_id UUID REFERENCES core.transactions(id),
+    CHECK (status <> 'approved' OR reviewed_by <> requested_by) -- Maker and checker are different operators
+);
+
 -- Index definitions
 
 -- Accounts indexes
@@ -113,6 +372,7 @@ CREATE INDEX idx_transactions_reference_id ON core.transactions(reference_id);
 CREATE INDEX idx_transactions_idempotency_key ON core.transactions(idempotency_key);
 CREATE INDEX idx_transactions_created_at ON core.transactions(created_at);
 CREATE INDEX idx_transactions_account_created ON core.transactions(account_id, created_at);
+CREATE INDEX idx_transactions_original_transaction_id ON core.transactions((metadata->>'original_transaction_id')) WHERE transaction_type = 'credit';
 
 -- Transfers indexes
 CREATE INDEX idx_transfers_transfer_id ON core.transfers(transfer_id);
@@ -138,12 +398,63 @@ CREATE INDEX idx_compensation_type ON core.compensation_audit_trail(compensation
 CREATE INDEX idx_compensation_created_at ON core.compensation_audit_trail(created_at);
 CREATE INDEX idx_compensation_workflow_status ON core.compensation_audit_trail(workflow_id, compensation_status);
 
+-- Admin audit indexes
+CREATE INDEX idx_admin_audit_created_at ON core.admin_audit(created_at);
+CREATE INDEX idx_admin_audit_actor_created ON core.admin_audit(actor, created_at);
+CREATE INDEX idx_admin_audit_resource ON core.admin_audit(resource_type, resource_id);
+
+-- Account closure audit trail indexes
+CREATE INDEX idx_account_closure_account_created ON core.account_closure_audit_trail(account_id, created_at);
+
+-- Balance alerts indexes
+CREATE INDEX idx_balance_alerts_account_id ON core.balance_alerts(account_id);
+CREATE INDEX idx_balance_alerts_enabled ON core.balance_alerts(enabled);
+
+-- Business rules indexes
+CREATE UNIQUE INDEX idx_business_rules_name_currency ON core.business_rules(name, COALESCE(currency::TEXT, ''));
+
+-- FX rates indexes
+CREATE INDEX idx_fx_rates_currency_fetched_at ON core.fx_rates(currency, fetched_at DESC);
+
+-- Settlement indexes
+CREATE INDEX idx_settlement_files_status ON core.settlement_files(status);
+CREATE INDEX idx_settlement_files_created_at ON core.settlement_files(created_at);
+CREATE INDEX idx_settlement_entries_file_id ON core.settlement_entries(settlement_file_id);
+CREATE INDEX idx_settlement_entries_status ON core.settlement_entries(settlement_status);
+
+-- Transfer references indexes
+CREATE INDEX idx_transfer_references_created_at ON core.transfer_references(created_at);
+
+-- Idempotency keys indexes
+CREATE INDEX idx_idempotency_keys_expires_at ON core.idempotency_keys(expires_at);
+
+-- EOD balances indexes
+CREATE INDEX idx_eod_balances_business_date ON core.eod_balances(business_date);
+
+-- Account aliases indexes
+CREATE INDEX idx_account_aliases_account_id ON core.account_aliases(account_id);
+
+-- Transfer events indexes
+CREATE INDEX idx_transfer_events_transfer_id ON core.transfer_events(transfer_id, occurred_at);
+CREATE INDEX idx_transfer_events_status_occurred ON core.transfer_events(status, occurred_at);
+
+-- Transfer read model indexes
+CREATE INDEX idx_transfer_read_model_from_account ON core.transfer_read_model(from_account, started_at DESC);
+CREATE INDEX idx_transfer_read_model_to_account ON core.transfer_read_model(to_account, started_at DESC);
+CREATE INDEX idx_transfer_read_model_status_updated ON core.transfer_read_model(status, updated_at DESC);
+CREATE INDEX idx_transfer_read_model_started ON core.transfer_read_model(started_at DESC, transfer_id DESC);
+
+-- Pending adjustments indexes
+CREATE INDEX idx_pending_adjustments_status_requested ON core.pending_adjustments(status, requested_at DESC);
+CREATE INDEX idx_pending_adjustments_account_id ON core.pending_adjustments(account_id, requested_at DESC);
+
 -- Comment definitions
 COMMENT ON SCHEMA core IS 'Core banking schema for temporal-flow-demo';
 
 COMMENT ON TABLE core.accounts IS 'Account information and balances';
 COMMENT ON COLUMN core.accounts.version IS 'Version for optimistic locking';
 COMMENT ON COLUMN core.accounts.balance IS 'Current account balance with 4 decimal precision';
+COMMENT ON COLUMN core.accounts.account_type IS 'Savings accounts allow a limited number of outgoing transfers per calendar month';
 
 COMMENT ON TABLE core.transactions IS 'Individual debit/credit transactions';
 COMMENT ON COLUMN core.transactions.idempotency_key IS 'Ensures idempotent transaction processing';
@@ -154,11 +465,69 @@ COMMENT ON COLUMN core.transfers.workflow_id IS 'Temporal workflow ID for tracki
 COMMENT ON COLUMN core.transfers.run_id IS 'Temporal run ID for tracking';
 
 COMMENT ON TABLE core.account_balance_history IS 'Audit trail for all balance changes';
+COMMENT ON COLUMN core.account_balance_history.sequence_number IS 'Position of the change in the account history, starting at 1 with no gaps';
+COMMENT ON COLUMN core.account_balance_history.chain_hash IS 'SHA-256 of the previous chain_hash and this change, used to detect altered or removed history';
 COMMENT ON TABLE core.compensation_audit_trail IS 'Audit trail for compensation operations in Temporal workflows';
 COMMENT ON COLUMN core.compensation_audit_trail.workflow_id IS 'Temporal workflow ID for compensation tracking';
 COMMENT ON COLUMN core.compensation_audit_trail.compensation_attempts IS 'Number of attempts made for this compensation';
 COMMENT ON COLUMN core.compensation_audit_trail.timeout_duration_ms IS 'Timeout duration if compensation timed out';
 
+COMMENT ON TABLE core.account_closure_audit_trail IS 'Audit trail for account closure workflows';
+COMMENT ON COLUMN core.account_closure_audit_trail.step IS 'Closure step: blocked, drained, swept, closed or reopened';
+
+COMMENT ON TABLE core.account_erasure_certificates IS 'Erasure certificates issued by AccountErasureWorkflow after verifying no personal data is left';
+COMMENT ON COLUMN core.account_erasure_certificates.digest IS 'SHA-256 over account, workflow, dates and scrubbed rows, so later edits to the certificate can be detected';
+
+COMMENT ON TABLE core.admin_audit IS 'State-changing admin calls with the state before and after each call';
+COMMENT ON COLUMN core.admin_audit.action IS 'What the admin did, as resource_type.verb';
+
+COMMENT ON TABLE core.balance_alerts IS 'Standing low-balance alerts delivered via webhook';
+COMMENT ON COLUMN core.balance_alerts.triggered IS 'Whether the alert has fired and is waiting for the balance to recover';
+
+COMMENT ON TABLE core.business_rules IS 'Soft business validation rules with per-currency thresholds';
+COMMENT ON COLUMN core.business_rules.severity IS 'error rejects the operation, warning only flags it for review';
+
+COMMENT ON TABLE core.fx_rates IS 'Exchange rates to USD with the time they were fetched, used for as-of conversions';
+COMMENT ON COLUMN core.fx_rates.fetched_at IS 'When the rate was fetched; a rate applies until the next one of the same currency';
+
+COMMENT ON TABLE core.settlement_files IS 'Outgoing settlement files (CSV or ISO 20022 pacs.008) batching completed transfers';
+COMMENT ON COLUMN core.settlement_files.file_reference IS 'File reference, also used as the ISO 20022 message ID';
+COMMENT ON COLUMN core.settlement_files.checksum IS 'SHA-256 checksum of the stored file content';
+COMMENT ON TABLE core.settlement_entries IS 'Settlement status of each transfer included in a settlement file';
+
+COMMENT ON TABLE core.transfer_references IS 'Human-friendly transfer reference numbers used by support agents to look up transfers';
+COMMENT ON COLUMN core.transfer_references.reference IS 'Business date, branch code, daily sequence number and Luhn check digit';
+
+COMMENT ON TABLE core.idempotency_keys IS 'Idempotency keys of debits, credits, compensations and internal transfers, kept until they expire';
+COMMENT ON COLUMN core.idempotency_keys.request_hash IS 'Hex SHA-256 of the request payload the key was first used with';
+COMMENT ON COLUMN core.idempotency_keys.response_snapshot IS 'Result returned when the key is reused with the same payload';
+COMMENT ON TABLE core.transfer_reference_sequences IS 'Last sequence number issued per branch and business date';
+
+COMMENT ON TABLE core.notification_preferences IS 'Where and for which transfer outcomes account holders are emailed';
+COMMENT ON TABLE core.notification_suppressions IS 'Addresses that bounced, complained or opted out of all notifications';
+
+COMMENT ON TABLE core.eod_balances IS 'End-of-day closing balances, read by statements instead of replaying the balance history';
+COMMENT ON COLUMN core.eod_balances.last_sequence_number IS 'Balance history position the closing balance was taken from, so re-runs can be checked against the chain';
+
+COMMENT ON TABLE core.account_aliases IS 'Phone numbers and email addresses resolved to the account a transfer is sent to';
+
+COMMENT ON TABLE core.account_owners IS 'Owners of joint accounts; debits from accounts with two or more owners above the joint threshold need two approvals';
+
+COMMENT ON TABLE core.transfer_approvals IS 'Approvals given to transfers held for approval, one row per approver';
+
+COMMENT ON TABLE core.transfer_events IS 'State changes of saga transfers, so their status and reporting do not depend on Temporal history';
+COMMENT ON COLUMN core.transfer_events.sequence_number IS 'Position of the event within its workflow run; a retried recording of the same event is ignored';
+COMMENT ON COLUMN core.transfer_events.status IS 'Status of the transfer after the event';
+
+COMMENT ON TABLE core.transfer_read_model IS 'Latest state of every saga transfer, projected from core.transfer_events for the status and list endpoints';
+COMMENT ON COLUMN core.transfer_read_model.event_position IS 'Position of the latest projected event; older events never overwrite newer ones';
+COMMENT ON TABLE core.projection_checkpoints IS 'Position in core.transfer_events up to which each projection has applied the events';
+
+COMMENT ON TABLE core.pending_adjustments IS 'Balance corrections requested by one operator and applied only once a second operator approves them';
+COMMENT ON COLUMN core.pending_adjustments.amount IS 'Signed change of the balance; negative takes from it';
+COMMENT ON COLUMN core.pending_adjustments.status IS 'pending, approved or rejected';
+COMMENT ON COLUMN core.pending_adjustments.transaction_id IS 'Transaction that applied the adjustment once approved';
+
 -- Reference definitions
 ALTER TABLE core.transfers ADD CONSTRAINT fk_transfers_from_account 
     FOREIGN KEY (from_account_id) REFERENCES core.accounts(id) ON DELETE RESTRICT;
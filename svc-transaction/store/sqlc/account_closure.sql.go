@@ -0,0 +1,139 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: account_closure.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createAccountClosureStep = `-- name: CreateAccountClosureStep :one
+INSERT INTO core.account_closure_audit_trail (
+    account_id,
+    workflow_id,
+    run_id,
+    step,
+    details
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, account_id, workflow_id, run_id, step, details, created_at
+`
+
+type CreateAccountClosureStepParams struct {
+	AccountID  pgtype.UUID `json:"account_id"`
+	WorkflowID string      `json:"workflow_id"`
+	RunID      string      `json:"run_id"`
+	Step       string      `json:"step"`
+	Details    []byte      `json:"details"`
+}
+
+func (q *Queries) CreateAccountClosureStep(ctx context.Context, arg CreateAccountClosureStepParams) (CoreAccountClosureAuditTrail, error) {
+	row := q.db.QueryRow(ctx, createAccountClosureStep,
+		arg.AccountID,
+		arg.WorkflowID,
+		arg.RunID,
+		arg.Step,
+		arg.Details,
+	)
+	var i CoreAccountClosureAuditTrail
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.WorkflowID,
+		&i.RunID,
+		&i.Step,
+		&i.Details,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getAccountClosureStep = `-- name: GetAccountClosureStep :one
+SELECT id, account_id, workflow_id, run_id, step, details, created_at FROM core.account_closure_audit_trail
+WHERE run_id = $1 AND step = $2
+`
+
+type GetAccountClosureStepParams struct {
+	RunID string `json:"run_id"`
+	Step  string `json:"step"`
+}
+
+func (q *Queries) GetAccountClosureStep(ctx context.Context, arg GetAccountClosureStepParams) (CoreAccountClosureAuditTrail, error) {
+	row := q.db.QueryRow(ctx, getAccountClosureStep, arg.RunID, arg.Step)
+	var i CoreAccountClosureAuditTrail
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.WorkflowID,
+		&i.RunID,
+		&i.Step,
+		&i.Details,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAccountClosureSteps = `-- name: ListAccountClosureSteps :many
+SELECT id, account_id, workflow_id, run_id, step, details, created_at FROM core.account_closure_audit_trail
+WHERE account_id = $1
+ORDER BY created_at ASC, id ASC
+`
+
+func (q *Queries) ListAccountClosureSteps(ctx context.Context, accountID pgtype.UUID) ([]CoreAccountClosureAuditTrail, error) {
+	rows, err := q.db.Query(ctx, listAccountClosureSteps, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreAccountClosureAuditTrail{}
+	for rows.Next() {
+		var i CoreAccountClosureAuditTrail
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.WorkflowID,
+			&i.RunID,
+			&i.Step,
+			&i.Details,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
+UPDATE core.accounts
+SET 
+    status = $1,
+    version = version + 1
+WHERE id = $2
+RETURNING id, status, version
+`
+
+type UpdateAccountStatusParams struct {
+	Status CoreAccountStatus `json:"status"`
+	ID     pgtype.UUID       `json:"id"`
+}
+
+type UpdateAccountStatusRow struct {
+	ID      pgtype.UUID       `json:"id"`
+	Status  CoreAccountStatus `json:"status"`
+	Version int32             `json:"version"`
+}
+
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (UpdateAccountStatusRow, error) {
+	row := q.db.QueryRow(ctx, updateAccountStatus, arg.Status, arg.ID)
+	var i UpdateAccountStatusRow
+	err := row.Scan(&i.ID, &i.Status, &i.Version)
+	return i, err
+}
@@ -0,0 +1,220 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: account_erasure.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const anonymizeAccount = `-- name: AnonymizeAccount :execrows
+UPDATE core.accounts
+SET 
+    account_name = $1::TEXT,
+    version = version + 1
+WHERE id = $2 AND account_name <> $1::TEXT
+`
+
+type AnonymizeAccountParams struct {
+	ErasedName string      `json:"erased_name"`
+	ID         pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) AnonymizeAccount(ctx context.Context, arg AnonymizeAccountParams) (int64, error) {
+	result, err := q.db.Exec(ctx, anonymizeAccount, arg.ErasedName, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const anonymizeAccountCompensationAudits = `-- name: AnonymizeAccountCompensationAudits :execrows
+UPDATE core.compensation_audit_trail
+SET 
+    compensation_reason = $1::TEXT,
+    metadata = metadata - ARRAY(SELECT k FROM jsonb_object_keys(metadata) AS k WHERE k <> ALL($2::TEXT[]))
+WHERE (original_transaction_id IN (SELECT id FROM core.transactions WHERE account_id = $3)
+        OR compensation_transaction_id IN (SELECT id FROM core.transactions WHERE account_id = $3))
+    AND (compensation_reason <> $1::TEXT OR EXISTS (SELECT 1 FROM jsonb_object_keys(metadata) AS k WHERE k <> ALL($2::TEXT[])))
+`
+
+type AnonymizeAccountCompensationAuditsParams struct {
+	ErasedName       string      `json:"erased_name"`
+	KeptMetadataKeys []string    `json:"kept_metadata_keys"`
+	AccountID        pgtype.UUID `json:"account_id"`
+}
+
+// Replaces the free-text reasons and drops every metadata key outside kept_metadata_keys
+func (q *Queries) AnonymizeAccountCompensationAudits(ctx context.Context, arg AnonymizeAccountCompensationAuditsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, anonymizeAccountCompensationAudits, arg.ErasedName, arg.KeptMetadataKeys, arg.AccountID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const anonymizeAccountTransactions = `-- name: AnonymizeAccountTransactions :execrows
+UPDATE core.transactions
+SET 
+    description = NULL,
+    metadata = metadata - ARRAY(SELECT k FROM jsonb_object_keys(metadata) AS k WHERE k <> ALL($1::TEXT[]))
+WHERE account_id = $2
+    AND (description IS NOT NULL OR EXISTS (SELECT 1 FROM jsonb_object_keys(metadata) AS k WHERE k <> ALL($1::TEXT[])))
+`
+
+type AnonymizeAccountTransactionsParams struct {
+	KeptMetadataKeys []string    `json:"kept_metadata_keys"`
+	AccountID        pgtype.UUID `json:"account_id"`
+}
+
+// Drops descriptions and every metadata key outside kept_metadata_keys
+func (q *Queries) AnonymizeAccountTransactions(ctx context.Context, arg AnonymizeAccountTransactionsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, anonymizeAccountTransactions, arg.KeptMetadataKeys, arg.AccountID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const anonymizeAccountTransfers = `-- name: AnonymizeAccountTransfers :execrows
+UPDATE core.transfers
+SET 
+    description = NULL,
+    metadata = metadata - ARRAY(SELECT k FROM jsonb_object_keys(metadata) AS k WHERE k <> ALL($1::TEXT[]))
+WHERE (from_account_id = $2 OR to_account_id = $2)
+    AND (description IS NOT NULL OR EXISTS (SELECT 1 FROM jsonb_object_keys(metadata) AS k WHERE k <> ALL($1::TEXT[])))
+`
+
+type AnonymizeAccountTransfersParams struct {
+	KeptMetadataKeys []string    `json:"kept_metadata_keys"`
+	AccountID        pgtype.UUID `json:"account_id"`
+}
+
+// Drops descriptions and every metadata key outside kept_metadata_keys from transfers on either side of the account
+func (q *Queries) AnonymizeAccountTransfers(ctx context.Context, arg AnonymizeAccountTransfersParams) (int64, error) {
+	result, err := q.db.Exec(ctx, anonymizeAccountTransfers, arg.KeptMetadataKeys, arg.AccountID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const countAccountPersonalData = `-- name: CountAccountPersonalData :one
+SELECT 
+    (SELECT COUNT(*) FROM core.accounts a
+        WHERE a.id = $1 AND a.account_name <> $2::TEXT) AS accounts,
+    (SELECT COUNT(*) FROM core.transactions t
+        WHERE t.account_id = $1
+            AND (t.description IS NOT NULL OR EXISTS (SELECT 1 FROM jsonb_object_keys(t.metadata) AS k WHERE k <> ALL($3::TEXT[])))) AS transactions,
+    (SELECT COUNT(*) FROM core.transfers tr
+        WHERE (tr.from_account_id = $1 OR tr.to_account_id = $1)
+            AND (tr.description IS NOT NULL OR EXISTS (SELECT 1 FROM jsonb_object_keys(tr.metadata) AS k WHERE k <> ALL($3::TEXT[])))) AS transfers,
+    (SELECT COUNT(*) FROM core.compensation_audit_trail c
+        WHERE (c.original_transaction_id IN (SELECT id FROM core.transactions WHERE account_id = $1)
+                OR c.compensation_transaction_id IN (SELECT id FROM core.transactions WHERE account_id = $1))
+            AND (c.compensation_reason <> $2::TEXT OR EXISTS (SELECT 1 FROM jsonb_object_keys(c.metadata) AS k WHERE k <> ALL($3::TEXT[])))) AS compensation_audits
+`
+
+type CountAccountPersonalDataParams struct {
+	AccountID        pgtype.UUID `json:"account_id"`
+	ErasedName       string      `json:"erased_name"`
+	KeptMetadataKeys []string    `json:"kept_metadata_keys"`
+}
+
+type CountAccountPersonalDataRow struct {
+	Accounts           int64 `json:"accounts"`
+	Transactions       int64 `json:"transactions"`
+	Transfers          int64 `json:"transfers"`
+	CompensationAudits int64 `json:"compensation_audits"`
+}
+
+// Rows the anonymize queries would still change, used to verify an erasure
+func (q *Queries) CountAccountPersonalData(ctx context.Context, arg CountAccountPersonalDataParams) (CountAccountPersonalDataRow, error) {
+	row := q.db.QueryRow(ctx, countAccountPersonalData, arg.AccountID, arg.ErasedName, arg.KeptMetadataKeys)
+	var i CountAccountPersonalDataRow
+	err := row.Scan(
+		&i.Accounts,
+		&i.Transactions,
+		&i.Transfers,
+		&i.CompensationAudits,
+	)
+	return i, err
+}
+
+const createAccountErasureCertificate = `-- name: CreateAccountErasureCertificate :one
+INSERT INTO core.account_erasure_certificates (
+    account_id,
+    workflow_id,
+    run_id,
+    requested_by,
+    closed_at,
+    scrubbed_rows,
+    digest,
+    erased_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING id, account_id, workflow_id, run_id, requested_by, closed_at, scrubbed_rows, digest, erased_at
+`
+
+type CreateAccountErasureCertificateParams struct {
+	AccountID    pgtype.UUID        `json:"account_id"`
+	WorkflowID   string             `json:"workflow_id"`
+	RunID        string             `json:"run_id"`
+	RequestedBy  pgtype.Text        `json:"requested_by"`
+	ClosedAt     pgtype.Timestamptz `json:"closed_at"`
+	ScrubbedRows []byte             `json:"scrubbed_rows"`
+	Digest       string             `json:"digest"`
+	ErasedAt     pgtype.Timestamptz `json:"erased_at"`
+}
+
+func (q *Queries) CreateAccountErasureCertificate(ctx context.Context, arg CreateAccountErasureCertificateParams) (CoreAccountErasureCertificate, error) {
+	row := q.db.QueryRow(ctx, createAccountErasureCertificate,
+		arg.AccountID,
+		arg.WorkflowID,
+		arg.RunID,
+		arg.RequestedBy,
+		arg.ClosedAt,
+		arg.ScrubbedRows,
+		arg.Digest,
+		arg.ErasedAt,
+	)
+	var i CoreAccountErasureCertificate
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.WorkflowID,
+		&i.RunID,
+		&i.RequestedBy,
+		&i.ClosedAt,
+		&i.ScrubbedRows,
+		&i.Digest,
+		&i.ErasedAt,
+	)
+	return i, err
+}
+
+const getAccountErasureCertificate = `-- name: GetAccountErasureCertificate :one
+SELECT id, account_id, workflow_id, run_id, requested_by, closed_at, scrubbed_rows, digest, erased_at FROM core.account_erasure_certificates
+WHERE account_id = $1
+`
+
+func (q *Queries) GetAccountErasureCertificate(ctx context.Context, accountID pgtype.UUID) (CoreAccountErasureCertificate, error) {
+	row := q.db.QueryRow(ctx, getAccountErasureCertificate, accountID)
+	var i CoreAccountErasureCertificate
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.WorkflowID,
+		&i.RunID,
+		&i.RequestedBy,
+		&i.ClosedAt,
+		&i.ScrubbedRows,
+		&i.Digest,
+		&i.ErasedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,85 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: account_owners.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addAccountOwner = `-- name: AddAccountOwner :one
+INSERT INTO core.account_owners (
+    account_id,
+    owner_id
+) VALUES (
+    $1, $2
+) RETURNING account_id, owner_id, created_at
+`
+
+type AddAccountOwnerParams struct {
+	AccountID pgtype.UUID `json:"account_id"`
+	OwnerID   string      `json:"owner_id"`
+}
+
+func (q *Queries) AddAccountOwner(ctx context.Context, arg AddAccountOwnerParams) (CoreAccountOwner, error) {
+	row := q.db.QueryRow(ctx, addAccountOwner, arg.AccountID, arg.OwnerID)
+	var i CoreAccountOwner
+	err := row.Scan(
+		&i.AccountID,
+		&i.OwnerID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAccountOwners = `-- name: ListAccountOwners :many
+SELECT account_id, owner_id, created_at FROM core.account_owners
+WHERE account_id = $1
+ORDER BY created_at, owner_id
+`
+
+func (q *Queries) ListAccountOwners(ctx context.Context, accountID pgtype.UUID) ([]CoreAccountOwner, error) {
+	rows, err := q.db.Query(ctx, listAccountOwners, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreAccountOwner{}
+	for rows.Next() {
+		var i CoreAccountOwner
+		if err := rows.Scan(
+			&i.AccountID,
+			&i.OwnerID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const removeAccountOwner = `-- name: RemoveAccountOwner :execrows
+DELETE FROM core.account_owners
+WHERE account_id = $1 AND owner_id = $2
+`
+
+type RemoveAccountOwnerParams struct {
+	AccountID pgtype.UUID `json:"account_id"`
+	OwnerID   string      `json:"owner_id"`
+}
+
+func (q *Queries) RemoveAccountOwner(ctx context.Context, arg RemoveAccountOwnerParams) (int64, error) {
+	result, err := q.db.Exec(ctx, removeAccountOwner, arg.AccountID, arg.OwnerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,52 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: accounts.sql
+
+package sqlc
+
+import (
+	"context"
+)
+
+const createAccount = `-- name: CreateAccount :one
+INSERT INTO core.accounts (
+    account_number,
+    account_name,
+    currency,
+    account_type
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING id, account_number, account_name, balance, currency, status, created_at, updated_at, version, account_type
+`
+
+type CreateAccountParams struct {
+	AccountNumber string           `json:"account_number"`
+	AccountName   string           `json:"account_name"`
+	Currency      CoreCurrencyCode `json:"currency"`
+	AccountType   CoreAccountType  `json:"account_type"`
+}
+
+// Opens an empty account; the opening balance is credited separately so it shows up in the balance history
+func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (CoreAccount, error) {
+	row := q.db.QueryRow(ctx, createAccount,
+		arg.AccountNumber,
+		arg.AccountName,
+		arg.Currency,
+		arg.AccountType,
+	)
+	var i CoreAccount
+	err := row.Scan(
+		&i.ID,
+		&i.AccountNumber,
+		&i.AccountName,
+		&i.Balance,
+		&i.Currency,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.AccountType,
+	)
+	return i, err
+}
@@ -0,0 +1,121 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: admin_audit.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createAdminAudit = `-- name: CreateAdminAudit :one
+INSERT INTO core.admin_audit (
+    actor,
+    action,
+    service,
+    resource_type,
+    resource_id,
+    before_payload,
+    after_payload
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, actor, action, service, resource_type, resource_id, before_payload, after_payload, created_at
+`
+
+type CreateAdminAuditParams struct {
+	Actor         string      `json:"actor"`
+	Action        string      `json:"action"`
+	Service       string      `json:"service"`
+	ResourceType  string      `json:"resource_type"`
+	ResourceID    pgtype.Text `json:"resource_id"`
+	BeforePayload []byte      `json:"before_payload"`
+	AfterPayload  []byte      `json:"after_payload"`
+}
+
+func (q *Queries) CreateAdminAudit(ctx context.Context, arg CreateAdminAuditParams) (CoreAdminAudit, error) {
+	row := q.db.QueryRow(ctx, createAdminAudit,
+		arg.Actor,
+		arg.Action,
+		arg.Service,
+		arg.ResourceType,
+		arg.ResourceID,
+		arg.BeforePayload,
+		arg.AfterPayload,
+	)
+	var i CoreAdminAudit
+	err := row.Scan(
+		&i.ID,
+		&i.Actor,
+		&i.Action,
+		&i.Service,
+		&i.ResourceType,
+		&i.ResourceID,
+		&i.BeforePayload,
+		&i.AfterPayload,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAdminAudits = `-- name: ListAdminAudits :many
+SELECT id, actor, action, service, resource_type, resource_id, before_payload, after_payload, created_at FROM core.admin_audit
+WHERE ($1::TEXT IS NULL OR actor = $1)
+AND ($2::TEXT IS NULL OR action = $2)
+AND ($3::TEXT IS NULL OR resource_type = $3)
+AND ($4::TEXT IS NULL OR resource_id = $4)
+AND ($5::TIMESTAMPTZ IS NULL OR created_at >= $5)
+AND ($6::TIMESTAMPTZ IS NULL OR created_at < $6)
+ORDER BY created_at DESC, id DESC
+LIMIT $7
+`
+
+type ListAdminAuditsParams struct {
+	Actor        pgtype.Text        `json:"actor"`
+	Action       pgtype.Text        `json:"action"`
+	ResourceType pgtype.Text        `json:"resource_type"`
+	ResourceID   pgtype.Text        `json:"resource_id"`
+	CreatedFrom  pgtype.Timestamptz `json:"created_from"`
+	CreatedTo    pgtype.Timestamptz `json:"created_to"`
+	RowLimit     int32              `json:"row_limit"`
+}
+
+func (q *Queries) ListAdminAudits(ctx context.Context, arg ListAdminAuditsParams) ([]CoreAdminAudit, error) {
+	rows, err := q.db.Query(ctx, listAdminAudits,
+		arg.Actor,
+		arg.Action,
+		arg.ResourceType,
+		arg.ResourceID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreAdminAudit{}
+	for rows.Next() {
+		var i CoreAdminAudit
+		if err := rows.Scan(
+			&i.ID,
+			&i.Actor,
+			&i.Action,
+			&i.Service,
+			&i.ResourceType,
+			&i.ResourceID,
+			&i.BeforePayload,
+			&i.AfterPayload,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
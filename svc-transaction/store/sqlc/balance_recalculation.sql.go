@@ -0,0 +1,34 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: balance_recalculation.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getAccountLedgerBalance = `-- name: GetAccountLedgerBalance :one
+SELECT
+    COALESCE(SUM(CASE WHEN transaction_type = 'credit' THEN amount ELSE -amount END), 0)::DECIMAL AS ledger_balance,
+    COUNT(*) AS transaction_count
+FROM core.transactions
+WHERE account_id = $1
+    AND status = 'completed'
+`
+
+type GetAccountLedgerBalanceRow struct {
+	LedgerBalance    pgtype.Numeric `json:"ledger_balance"`
+	TransactionCount int64          `json:"transaction_count"`
+}
+
+// Balance implied by the completed transactions of an account
+func (q *Queries) GetAccountLedgerBalance(ctx context.Context, accountID pgtype.UUID) (GetAccountLedgerBalanceRow, error) {
+	row := q.db.QueryRow(ctx, getAccountLedgerBalance, accountID)
+	var i GetAccountLedgerBalanceRow
+	err := row.Scan(&i.LedgerBalance, &i.TransactionCount)
+	return i, err
+}
@@ -0,0 +1,45 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: business_rules.sql
+
+package sqlc
+
+import (
+	"context"
+)
+
+const listBusinessRules = `-- name: ListBusinessRules :many
+SELECT id, name, kind, currency, threshold, severity, enabled, created_at, updated_at FROM core.business_rules
+ORDER BY name ASC, currency ASC NULLS FIRST
+`
+
+func (q *Queries) ListBusinessRules(ctx context.Context) ([]CoreBusinessRule, error) {
+	rows, err := q.db.Query(ctx, listBusinessRules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreBusinessRule{}
+	for rows.Next() {
+		var i CoreBusinessRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Kind,
+			&i.Currency,
+			&i.Threshold,
+			&i.Severity,
+			&i.Enabled,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
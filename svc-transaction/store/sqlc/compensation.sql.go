@@ -11,6 +11,20 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countCompensationBacklog = `-- name: CountCompensationBacklog :one
+SELECT COUNT(*) FROM core.compensation_audit_trail
+WHERE compensation_status <> 'completed'
+AND created_at >= $1::TIMESTAMPTZ
+`
+
+// Compensations created since created_from that have not completed
+func (q *Queries) CountCompensationBacklog(ctx context.Context, createdFrom pgtype.Timestamptz) (int64, error) {
+	row := q.db.QueryRow(ctx, countCompensationBacklog, createdFrom)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCompensationAudit = `-- name: CreateCompensationAudit :one
 INSERT INTO core.compensation_audit_trail (
     workflow_id,
@@ -194,6 +208,59 @@ func (q *Queries) GetCompensationStats(ctx context.Context) (GetCompensationStat
 	return i, err
 }
 
+const getCompensationStatsFiltered = `-- name: GetCompensationStatsFiltered :one
+SELECT 
+    COUNT(*) as total_compensations,
+    COUNT(*) FILTER (WHERE compensation_status = 'completed') as completed_compensations,
+    COUNT(*) FILTER (WHERE compensation_status = 'failed') as failed_compensations,
+    COUNT(*) FILTER (WHERE compensation_status = 'timeout') as timeout_compensations,
+    COUNT(*) FILTER (WHERE compensation_status = 'manual_required') as manual_compensations,
+    COUNT(*) FILTER (WHERE compensation_status = 'pending') as pending_compensations,
+    COALESCE(AVG(compensation_attempts), 0)::FLOAT8 as avg_attempts
+FROM core.compensation_audit_trail
+WHERE ($1::core.compensation_status IS NULL OR compensation_status = $1)
+AND ($2::core.compensation_type IS NULL OR compensation_type = $2)
+AND created_at >= $3::TIMESTAMPTZ
+AND created_at < $4::TIMESTAMPTZ
+`
+
+type GetCompensationStatsFilteredParams struct {
+	CompensationStatus NullCoreCompensationStatus `json:"compensation_status"`
+	CompensationType   NullCoreCompensationType   `json:"compensation_type"`
+	CreatedFrom        pgtype.Timestamptz         `json:"created_from"`
+	CreatedTo          pgtype.Timestamptz         `json:"created_to"`
+}
+
+type GetCompensationStatsFilteredRow struct {
+	TotalCompensations     int64   `json:"total_compensations"`
+	CompletedCompensations int64   `json:"completed_compensations"`
+	FailedCompensations    int64   `json:"failed_compensations"`
+	TimeoutCompensations   int64   `json:"timeout_compensations"`
+	ManualCompensations    int64   `json:"manual_compensations"`
+	PendingCompensations   int64   `json:"pending_compensations"`
+	AvgAttempts            float64 `json:"avg_attempts"`
+}
+
+func (q *Queries) GetCompensationStatsFiltered(ctx context.Context, arg GetCompensationStatsFilteredParams) (GetCompensationStatsFilteredRow, error) {
+	row := q.db.QueryRow(ctx, getCompensationStatsFiltered,
+		arg.CompensationStatus,
+		arg.CompensationType,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+	)
+	var i GetCompensationStatsFilteredRow
+	err := row.Scan(
+		&i.TotalCompensations,
+		&i.CompletedCompensations,
+		&i.FailedCompensations,
+		&i.TimeoutCompensations,
+		&i.ManualCompensations,
+		&i.PendingCompensations,
+		&i.AvgAttempts,
+	)
+	return i, err
+}
+
 const getFailedCompensationsByTimeoutDuration = `-- name: GetFailedCompensationsByTimeoutDuration :many
 SELECT 
     workflow_id,
@@ -298,6 +365,70 @@ func (q *Queries) GetPendingCompensations(ctx context.Context, limit int32) ([]C
 	return items, nil
 }
 
+const listCompensationAudits = `-- name: ListCompensationAudits :many
+SELECT id, workflow_id, run_id, transfer_id, original_transaction_id, compensation_transaction_id, compensation_reason, compensation_type, compensation_status, compensation_attempts, created_at, updated_at, completed_at, failure_reason, timeout_duration_ms, metadata FROM core.compensation_audit_trail
+WHERE ($1::core.compensation_status IS NULL OR compensation_status = $1)
+AND ($2::core.compensation_type IS NULL OR compensation_type = $2)
+AND ($3::TEXT IS NULL OR workflow_id = $3)
+AND ($4::TIMESTAMPTZ IS NULL OR created_at >= $4)
+AND ($5::TIMESTAMPTZ IS NULL OR created_at < $5)
+ORDER BY created_at DESC
+LIMIT $6
+`
+
+type ListCompensationAuditsParams struct {
+	CompensationStatus NullCoreCompensationStatus `json:"compensation_status"`
+	CompensationType   NullCoreCompensationType   `json:"compensation_type"`
+	WorkflowID         pgtype.Text                `json:"workflow_id"`
+	CreatedFrom        pgtype.Timestamptz         `json:"created_from"`
+	CreatedTo          pgtype.Timestamptz         `json:"created_to"`
+	RowLimit           int32                      `json:"row_limit"`
+}
+
+func (q *Queries) ListCompensationAudits(ctx context.Context, arg ListCompensationAuditsParams) ([]CoreCompensationAuditTrail, error) {
+	rows, err := q.db.Query(ctx, listCompensationAudits,
+		arg.CompensationStatus,
+		arg.CompensationType,
+		arg.WorkflowID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreCompensationAuditTrail{}
+	for rows.Next() {
+		var i CoreCompensationAuditTrail
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkflowID,
+			&i.RunID,
+			&i.TransferID,
+			&i.OriginalTransactionID,
+			&i.CompensationTransactionID,
+			&i.CompensationReason,
+			&i.CompensationType,
+			&i.CompensationStatus,
+			&i.CompensationAttempts,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+			&i.FailureReason,
+			&i.TimeoutDurationMs,
+			&i.Metadata,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateCompensationAudit = `-- name: UpdateCompensationAudit :one
 UPDATE core.compensation_audit_trail 
 SET 
@@ -0,0 +1,71 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: eod_balances.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const upsertEODBalances = `-- name: UpsertEODBalances :execrows
+INSERT INTO core.eod_balances (
+    account_id,
+    business_date,
+    currency,
+    closing_balance,
+    entry_count,
+    last_sequence_number,
+    computed_at
+)
+SELECT
+    a.id,
+    $1::date,
+    a.currency,
+    COALESCE(last_entry.new_balance, 0),
+    day_entries.entry_count::integer,
+    COALESCE(last_entry.sequence_number, 0),
+    NOW()
+FROM core.accounts a
+LEFT JOIN LATERAL (
+    SELECT h.new_balance, h.sequence_number
+    FROM core.account_balance_history h
+    WHERE h.account_id = a.id
+        AND h.created_at < $2::timestamptz
+    ORDER BY h.sequence_number DESC
+    LIMIT 1
+) last_entry ON TRUE
+CROSS JOIN LATERAL (
+    SELECT COUNT(*) AS entry_count
+    FROM core.account_balance_history h
+    WHERE h.account_id = a.id
+        AND h.created_at >= $3::timestamptz
+        AND h.created_at < $2::timestamptz
+) day_entries
+WHERE a.created_at < $2::timestamptz
+ON CONFLICT (account_id, business_date) DO UPDATE SET
+    currency = EXCLUDED.currency,
+    closing_balance = EXCLUDED.closing_balance,
+    entry_count = EXCLUDED.entry_count,
+    last_sequence_number = EXCLUDED.last_sequence_number,
+    computed_at = EXCLUDED.computed_at
+`
+
+type UpsertEODBalancesParams struct {
+	BusinessDate pgtype.Date        `json:"business_date"`
+	DayEnd       pgtype.Timestamptz `json:"day_end"`
+	DayStart     pgtype.Timestamptz `json:"day_start"`
+}
+
+// Closing balances of the accounts opened before day_end, taken from their last balance history entry before
+// day_end; running the same business date again overwrites its rows
+func (q *Queries) UpsertEODBalances(ctx context.Context, arg UpsertEODBalancesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, upsertEODBalances, arg.BusinessDate, arg.DayEnd, arg.DayStart)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
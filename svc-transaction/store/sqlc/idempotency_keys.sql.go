@@ -0,0 +1,99 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: idempotency_keys.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createIdempotencyKey = `-- name: CreateIdempotencyKey :one
+INSERT INTO core.idempotency_keys (
+    idempotency_key,
+    scope,
+    request_hash,
+    response_snapshot,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (idempotency_key) DO UPDATE SET
+    scope = EXCLUDED.scope,
+    request_hash = EXCLUDED.request_hash,
+    response_snapshot = EXCLUDED.response_snapshot,
+    created_at = NOW(),
+    expires_at = EXCLUDED.expires_at
+WHERE core.idempotency_keys.expires_at <= NOW()
+RETURNING idempotency_key, scope, request_hash, response_snapshot, created_at, expires_at
+`
+
+type CreateIdempotencyKeyParams struct {
+	IdempotencyKey   string             `json:"idempotency_key"`
+	Scope            string             `json:"scope"`
+	RequestHash      string             `json:"request_hash"`
+	ResponseSnapshot []byte             `json:"response_snapshot"`
+	ExpiresAt        pgtype.Timestamptz `json:"expires_at"`
+}
+
+// Registers a key, taking over an expired registration the cleanup job has not deleted yet.
+// Returns no row while the key is still registered.
+func (q *Queries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (CoreIdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, createIdempotencyKey,
+		arg.IdempotencyKey,
+		arg.Scope,
+		arg.RequestHash,
+		arg.ResponseSnapshot,
+		arg.ExpiresAt,
+	)
+	var i CoreIdempotencyKey
+	err := row.Scan(
+		&i.IdempotencyKey,
+		&i.Scope,
+		&i.RequestHash,
+		&i.ResponseSnapshot,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const deleteExpiredIdempotencyKeys = `-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM core.idempotency_keys
+WHERE idempotency_key IN (
+    SELECT idempotency_key FROM core.idempotency_keys
+    WHERE expires_at <= NOW()
+    ORDER BY expires_at
+    LIMIT $1
+)
+`
+
+func (q *Queries) DeleteExpiredIdempotencyKeys(ctx context.Context, limit int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredIdempotencyKeys, limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getIdempotencyKey = `-- name: GetIdempotencyKey :one
+SELECT idempotency_key, scope, request_hash, response_snapshot, created_at, expires_at FROM core.idempotency_keys
+WHERE idempotency_key = $1 AND expires_at > NOW()
+`
+
+func (q *Queries) GetIdempotencyKey(ctx context.Context, idempotencyKey string) (CoreIdempotencyKey, error) {
+	row := q.db.QueryRow(ctx, getIdempotencyKey, idempotencyKey)
+	var i CoreIdempotencyKey
+	err := row.Scan(
+		&i.IdempotencyKey,
+		&i.Scope,
+		&i.RequestHash,
+		&i.ResponseSnapshot,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
@@ -0,0 +1,182 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: internal_transfers.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const acquireAccountLock = `-- name: AcquireAccountLock :exec
+SELECT pg_advisory_xact_lock(hashtextextended('core.accounts:' || $1::TEXT, 0))
+`
+
+// Transaction-scoped advisory lock serializing mutations of one account across service instances
+func (q *Queries) AcquireAccountLock(ctx context.Context, accountID string) error {
+	_, err := q.db.Exec(ctx, acquireAccountLock, accountID)
+	return err
+}
+
+const adjustAccountBalance = `-- name: AdjustAccountBalance :one
+UPDATE core.accounts
+SET 
+    balance = balance + $1::DECIMAL,
+    version = version + 1
+WHERE id = $2
+RETURNING id, balance, version
+`
+
+type AdjustAccountBalanceParams struct {
+	BalanceChange pgtype.Numeric `json:"balance_change"`
+	ID            pgtype.UUID    `json:"id"`
+}
+
+type AdjustAccountBalanceRow struct {
+	ID      pgtype.UUID    `json:"id"`
+	Balance pgtype.Numeric `json:"balance"`
+	Version int32          `json:"version"`
+}
+
+func (q *Queries) AdjustAccountBalance(ctx context.Context, arg AdjustAccountBalanceParams) (AdjustAccountBalanceRow, error) {
+	row := q.db.QueryRow(ctx, adjustAccountBalance, arg.BalanceChange, arg.ID)
+	var i AdjustAccountBalanceRow
+	err := row.Scan(&i.ID, &i.Balance, &i.Version)
+	return i, err
+}
+
+const createTransfer = `-- name: CreateTransfer :one
+INSERT INTO core.transfers (
+    transfer_id,
+    from_account_id,
+    to_account_id,
+    amount,
+    currency,
+    description,
+    status,
+    debit_transaction_id,
+    credit_transaction_id,
+    metadata
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id, transfer_id, from_account_id, to_account_id, amount, currency, description, status, debit_transaction_id, credit_transaction_id, workflow_id, run_id, created_at, updated_at, completed_at, failed_at, failure_reason, metadata
+`
+
+type CreateTransferParams struct {
+	TransferID          string             `json:"transfer_id"`
+	FromAccountID       pgtype.UUID        `json:"from_account_id"`
+	ToAccountID         pgtype.UUID        `json:"to_account_id"`
+	Amount              pgtype.Numeric     `json:"amount"`
+	Currency            CoreCurrencyCode   `json:"currency"`
+	Description         pgtype.Text        `json:"description"`
+	Status              CoreTransferStatus `json:"status"`
+	DebitTransactionID  pgtype.UUID        `json:"debit_transaction_id"`
+	CreditTransactionID pgtype.UUID        `json:"credit_transaction_id"`
+	Metadata            []byte             `json:"metadata"`
+}
+
+func (q *Queries) CreateTransfer(ctx context.Context, arg CreateTransferParams) (CoreTransfer, error) {
+	row := q.db.QueryRow(ctx, createTransfer,
+		arg.TransferID,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Currency,
+		arg.Description,
+		arg.Status,
+		arg.DebitTransactionID,
+		arg.CreditTransactionID,
+		arg.Metadata,
+	)
+	var i CoreTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.TransferID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Description,
+		&i.Status,
+		&i.DebitTransactionID,
+		&i.CreditTransactionID,
+		&i.WorkflowID,
+		&i.RunID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+		&i.FailedAt,
+		&i.FailureReason,
+		&i.Metadata,
+	)
+	return i, err
+}
+
+const getAccountForUpdate = `-- name: GetAccountForUpdate :one
+SELECT 
+    id,
+    account_number,
+    account_name,
+    balance,
+    currency,
+    status,
+    created_at,
+    updated_at,
+    version,
+    account_type
+FROM core.accounts
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetAccountForUpdate(ctx context.Context, id pgtype.UUID) (CoreAccount, error) {
+	row := q.db.QueryRow(ctx, getAccountForUpdate, id)
+	var i CoreAccount
+	err := row.Scan(
+		&i.ID,
+		&i.AccountNumber,
+		&i.AccountName,
+		&i.Balance,
+		&i.Currency,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.AccountType,
+	)
+	return i, err
+}
+
+const getTransferByTransferID = `-- name: GetTransferByTransferID :one
+SELECT id, transfer_id, from_account_id, to_account_id, amount, currency, description, status, debit_transaction_id, credit_transaction_id, workflow_id, run_id, created_at, updated_at, completed_at, failed_at, failure_reason, metadata FROM core.transfers
+WHERE transfer_id = $1
+`
+
+func (q *Queries) GetTransferByTransferID(ctx context.Context, transferID string) (CoreTransfer, error) {
+	row := q.db.QueryRow(ctx, getTransferByTransferID, transferID)
+	var i CoreTransfer
+	err := row.Scan(
+		&i.ID,
+		&i.TransferID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Description,
+		&i.Status,
+		&i.DebitTransactionID,
+		&i.CreditTransactionID,
+		&i.WorkflowID,
+		&i.RunID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+		&i.FailedAt,
+		&i.FailureReason,
+		&i.Metadata,
+	)
+	return i, err
+}
@@ -0,0 +1,177 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: ledger_export.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const listBalanceHistoryForExport = `-- name: ListBalanceHistoryForExport :many
+SELECT
+    id,
+    account_id,
+    transaction_id,
+    old_balance,
+    new_balance,
+    balance_change,
+    operation,
+    created_at,
+    created_by,
+    sequence_number
+FROM core.account_balance_history
+WHERE created_at >= $1
+    AND created_at < $2
+    AND (created_at, id) > ($3::timestamptz, $4::uuid)
+ORDER BY created_at ASC, id ASC
+LIMIT $5
+`
+
+type ListBalanceHistoryForExportParams struct {
+	CreatedFrom    pgtype.Timestamptz `json:"created_from"`
+	CreatedTo      pgtype.Timestamptz `json:"created_to"`
+	AfterCreatedAt pgtype.Timestamptz `json:"after_created_at"`
+	AfterID        pgtype.UUID        `json:"after_id"`
+	RowLimit       int32              `json:"row_limit"`
+}
+
+type ListBalanceHistoryForExportRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	AccountID      pgtype.UUID        `json:"account_id"`
+	TransactionID  pgtype.UUID        `json:"transaction_id"`
+	OldBalance     pgtype.Numeric     `json:"old_balance"`
+	NewBalance     pgtype.Numeric     `json:"new_balance"`
+	BalanceChange  pgtype.Numeric     `json:"balance_change"`
+	Operation      string             `json:"operation"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	CreatedBy      pgtype.Text        `json:"created_by"`
+	SequenceNumber int64              `json:"sequence_number"`
+}
+
+// Balance history entries created in [created_from, created_to) after the (after_created_at, after_id) keyset cursor
+func (q *Queries) ListBalanceHistoryForExport(ctx context.Context, arg ListBalanceHistoryForExportParams) ([]ListBalanceHistoryForExportRow, error) {
+	rows, err := q.db.Query(ctx, listBalanceHistoryForExport,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListBalanceHistoryForExportRow{}
+	for rows.Next() {
+		var i ListBalanceHistoryForExportRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.TransactionID,
+			&i.OldBalance,
+			&i.NewBalance,
+			&i.BalanceChange,
+			&i.Operation,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.SequenceNumber,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionsForExport = `-- name: ListTransactionsForExport :many
+SELECT
+    t.id,
+    t.account_id,
+    a.account_number,
+    t.transaction_type,
+    t.amount,
+    t.currency,
+    t.description,
+    t.reference_id,
+    t.status,
+    t.created_at,
+    t.updated_at,
+    t.completed_at
+FROM core.transactions t
+JOIN core.accounts a ON a.id = t.account_id
+WHERE t.created_at >= $1
+    AND t.created_at < $2
+    AND (t.created_at, t.id) > ($3::timestamptz, $4::uuid)
+ORDER BY t.created_at ASC, t.id ASC
+LIMIT $5
+`
+
+type ListTransactionsForExportParams struct {
+	CreatedFrom    pgtype.Timestamptz `json:"created_from"`
+	CreatedTo      pgtype.Timestamptz `json:"created_to"`
+	AfterCreatedAt pgtype.Timestamptz `json:"after_created_at"`
+	AfterID        pgtype.UUID        `json:"after_id"`
+	RowLimit       int32              `json:"row_limit"`
+}
+
+type ListTransactionsForExportRow struct {
+	ID              pgtype.UUID           `json:"id"`
+	AccountID       pgtype.UUID           `json:"account_id"`
+	AccountNumber   string                `json:"account_number"`
+	TransactionType CoreTransactionType   `json:"transaction_type"`
+	Amount          pgtype.Numeric        `json:"amount"`
+	Currency        CoreCurrencyCode      `json:"currency"`
+	Description     pgtype.Text           `json:"description"`
+	ReferenceID     pgtype.Text           `json:"reference_id"`
+	Status          CoreTransactionStatus `json:"status"`
+	CreatedAt       pgtype.Timestamptz    `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz    `json:"updated_at"`
+	CompletedAt     pgtype.Timestamptz    `json:"completed_at"`
+}
+
+// Transactions created in [created_from, created_to) after the (after_created_at, after_id) keyset cursor
+func (q *Queries) ListTransactionsForExport(ctx context.Context, arg ListTransactionsForExportParams) ([]ListTransactionsForExportRow, error) {
+	rows, err := q.db.Query(ctx, listTransactionsForExport,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTransactionsForExportRow{}
+	for rows.Next() {
+		var i ListTransactionsForExportRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.AccountNumber,
+			&i.TransactionType,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.ReferenceID,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -55,6 +55,48 @@ func (ns NullCoreAccountStatus) Value() (driver.Value, error) {
 	return string(ns.CoreAccountStatus), nil
 }
 
+type CoreAccountType string
+
+const (
+	CoreAccountTypeChecking CoreAccountType = "checking"
+	CoreAccountTypeSavings  CoreAccountType = "savings"
+)
+
+func (e *CoreAccountType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CoreAccountType(s)
+	case string:
+		*e = CoreAccountType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CoreAccountType: %T", src)
+	}
+	return nil
+}
+
+type NullCoreAccountType struct {
+	CoreAccountType CoreAccountType `json:"core_account_type"`
+	Valid           bool            `json:"valid"` // Valid is true if CoreAccountType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCoreAccountType) Scan(value interface{}) error {
+	if value == nil {
+		ns.CoreAccountType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CoreAccountType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCoreAccountType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CoreAccountType), nil
+}
+
 type CoreCompensationStatus string
 
 const (
@@ -193,6 +235,135 @@ func (ns NullCoreCurrencyCode) Value() (driver.Value, error) {
 	return string(ns.CoreCurrencyCode), nil
 }
 
+type CoreSettlementFileFormat string
+
+const (
+	CoreSettlementFileFormatCsv     CoreSettlementFileFormat = "csv"
+	CoreSettlementFileFormatPacs008 CoreSettlementFileFormat = "pacs008"
+)
+
+func (e *CoreSettlementFileFormat) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CoreSettlementFileFormat(s)
+	case string:
+		*e = CoreSettlementFileFormat(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CoreSettlementFileFormat: %T", src)
+	}
+	return nil
+}
+
+type NullCoreSettlementFileFormat struct {
+	CoreSettlementFileFormat CoreSettlementFileFormat `json:"core_settlement_file_format"`
+	Valid                    bool                     `json:"valid"` // Valid is true if CoreSettlementFileFormat is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCoreSettlementFileFormat) Scan(value interface{}) error {
+	if value == nil {
+		ns.CoreSettlementFileFormat, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CoreSettlementFileFormat.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCoreSettlementFileFormat) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CoreSettlementFileFormat), nil
+}
+
+type CoreSettlementFileStatus string
+
+const (
+	CoreSettlementFileStatusGenerated             CoreSettlementFileStatus = "generated"
+	CoreSettlementFileStatusAcknowledged          CoreSettlementFileStatus = "acknowledged"
+	CoreSettlementFileStatusPartiallyAcknowledged CoreSettlementFileStatus = "partially_acknowledged"
+	CoreSettlementFileStatusRejected              CoreSettlementFileStatus = "rejected"
+)
+
+func (e *CoreSettlementFileStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CoreSettlementFileStatus(s)
+	case string:
+		*e = CoreSettlementFileStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CoreSettlementFileStatus: %T", src)
+	}
+	return nil
+}
+
+type NullCoreSettlementFileStatus struct {
+	CoreSettlementFileStatus CoreSettlementFileStatus `json:"core_settlement_file_status"`
+	Valid                    bool                     `json:"valid"` // Valid is true if CoreSettlementFileStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCoreSettlementFileStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.CoreSettlementFileStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CoreSettlementFileStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCoreSettlementFileStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CoreSettlementFileStatus), nil
+}
+
+type CoreSettlementStatus string
+
+const (
+	CoreSettlementStatusPending  CoreSettlementStatus = "pending"
+	CoreSettlementStatusSettled  CoreSettlementStatus = "settled"
+	CoreSettlementStatusRejected CoreSettlementStatus = "rejected"
+)
+
+func (e *CoreSettlementStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CoreSettlementStatus(s)
+	case string:
+		*e = CoreSettlementStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CoreSettlementStatus: %T", src)
+	}
+	return nil
+}
+
+type NullCoreSettlementStatus struct {
+	CoreSettlementStatus CoreSettlementStatus `json:"core_settlement_status"`
+	Valid                bool                 `json:"valid"` // Valid is true if CoreSettlementStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCoreSettlementStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.CoreSettlementStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CoreSettlementStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCoreSettlementStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CoreSettlementStatus), nil
+}
+
 type CoreTransactionStatus string
 
 const (
@@ -337,19 +508,98 @@ type CoreAccount struct {
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 	// Version for optimistic locking
 	Version int32 `json:"version"`
+	// Savings accounts allow a limited number of outgoing transfers per calendar month
+	AccountType CoreAccountType `json:"account_type"`
 }
 
 // Audit trail for all balance changes
 type CoreAccountBalanceHistory struct {
-	ID            pgtype.UUID        `json:"id"`
-	AccountID     pgtype.UUID        `json:"account_id"`
-	TransactionID pgtype.UUID        `json:"transaction_id"`
-	OldBalance    pgtype.Numeric     `json:"old_balance"`
-	NewBalance    pgtype.Numeric     `json:"new_balance"`
-	BalanceChange pgtype.Numeric     `json:"balance_change"`
-	Operation     string             `json:"operation"`
+	ID             pgtype.UUID        `json:"id"`
+	AccountID      pgtype.UUID        `json:"account_id"`
+	TransactionID  pgtype.UUID        `json:"transaction_id"`
+	OldBalance     pgtype.Numeric     `json:"old_balance"`
+	NewBalance     pgtype.Numeric     `json:"new_balance"`
+	BalanceChange  pgtype.Numeric     `json:"balance_change"`
+	Operation      string             `json:"operation"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	CreatedBy      pgtype.Text        `json:"created_by"`
+	SequenceNumber int64              `json:"sequence_number"`
+	ChainHash      string             `json:"chain_hash"`
+}
+
+// Audit trail for account closure workflows
+type CoreAccountClosureAuditTrail struct {
+	ID         pgtype.UUID `json:"id"`
+	AccountID  pgtype.UUID `json:"account_id"`
+	WorkflowID string      `json:"workflow_id"`
+	RunID      string      `json:"run_id"`
+	// Closure step: blocked, drained, swept, closed or reopened
+	Step      string             `json:"step"`
+	Details   []byte             `json:"details"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// Erasure certificates issued by AccountErasureWorkflow after verifying no personal data is left
+type CoreAccountErasureCertificate struct {
+	ID           pgtype.UUID        `json:"id"`
+	AccountID    pgtype.UUID        `json:"account_id"`
+	WorkflowID   string             `json:"workflow_id"`
+	RunID        string             `json:"run_id"`
+	RequestedBy  pgtype.Text        `json:"requested_by"`
+	ClosedAt     pgtype.Timestamptz `json:"closed_at"`
+	ScrubbedRows []byte             `json:"scrubbed_rows"`
+	// SHA-256 over account, workflow, dates and scrubbed rows, so later edits to the certificate can be detected
+	Digest   string             `json:"digest"`
+	ErasedAt pgtype.Timestamptz `json:"erased_at"`
+}
+
+// Owners of joint accounts; debits from accounts with two or more owners above the joint threshold need two approvals
+type CoreAccountOwner struct {
+	AccountID pgtype.UUID        `json:"account_id"`
+	OwnerID   string             `json:"owner_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// State-changing admin calls with the state before and after each call
+type CoreAdminAudit struct {
+	ID    pgtype.UUID `json:"id"`
+	Actor string      `json:"actor"`
+	// What the admin did, as resource_type.verb
+	Action        string             `json:"action"`
+	Service       string             `json:"service"`
+	ResourceType  string             `json:"resource_type"`
+	ResourceID    pgtype.Text        `json:"resource_id"`
+	BeforePayload []byte             `json:"before_payload"`
+	AfterPayload  []byte             `json:"after_payload"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
-	CreatedBy     pgtype.Text        `json:"created_by"`
+}
+
+// Standing low-balance alerts delivered via webhook
+type CoreBalanceAlert struct {
+	ID         pgtype.UUID    `json:"id"`
+	AccountID  pgtype.UUID    `json:"account_id"`
+	Threshold  pgtype.Numeric `json:"threshold"`
+	WebhookUrl string         `json:"webhook_url"`
+	Enabled    bool           `json:"enabled"`
+	// Whether the alert has fired and is waiting for the balance to recover
+	Triggered       bool               `json:"triggered"`
+	LastTriggeredAt pgtype.Timestamptz `json:"last_triggered_at"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Soft business validation rules with per-currency thresholds
+type CoreBusinessRule struct {
+	ID        pgtype.UUID          `json:"id"`
+	Name      string               `json:"name"`
+	Kind      string               `json:"kind"`
+	Currency  NullCoreCurrencyCode `json:"currency"`
+	Threshold pgtype.Numeric       `json:"threshold"`
+	// error rejects the operation, warning only flags it for review
+	Severity  string             `json:"severity"`
+	Enabled   bool               `json:"enabled"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 }
 
 // Audit trail for compensation operations in Temporal workflows
@@ -375,6 +625,113 @@ type CoreCompensationAuditTrail struct {
 	Metadata          []byte      `json:"metadata"`
 }
 
+// End-of-day closing balances, read by statements instead of replaying the balance history
+type CoreEodBalance struct {
+	AccountID      pgtype.UUID      `json:"account_id"`
+	BusinessDate   pgtype.Date      `json:"business_date"`
+	Currency       CoreCurrencyCode `json:"currency"`
+	ClosingBalance pgtype.Numeric   `json:"closing_balance"`
+	EntryCount     int32            `json:"entry_count"`
+	// Balance history position the closing balance was taken from, so re-runs can be checked against the chain
+	LastSequenceNumber int64              `json:"last_sequence_number"`
+	ComputedAt         pgtype.Timestamptz `json:"computed_at"`
+}
+
+// Exchange rates to USD with the time they were fetched, used for as-of conversions
+type CoreFxRate struct {
+	ID        pgtype.UUID      `json:"id"`
+	Currency  CoreCurrencyCode `json:"currency"`
+	RateToUsd pgtype.Numeric   `json:"rate_to_usd"`
+	Source    string           `json:"source"`
+	// When the rate was fetched; a rate applies until the next one of the same currency
+	FetchedAt pgtype.Timestamptz `json:"fetched_at"`
+}
+
+// Idempotency keys of debits, credits, compensations and internal transfers, kept until they expire
+type CoreIdempotencyKey struct {
+	IdempotencyKey string `json:"idempotency_key"`
+	Scope          string `json:"scope"`
+	// Hex SHA-256 of the request payload the key was first used with
+	RequestHash string `json:"request_hash"`
+	// Result returned when the key is reused with the same payload
+	ResponseSnapshot []byte             `json:"response_snapshot"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt        pgtype.Timestamptz `json:"expires_at"`
+}
+
+// Where and for which transfer outcomes account holders are emailed
+type CoreNotificationPreference struct {
+	AccountID         pgtype.UUID        `json:"account_id"`
+	Email             string             `json:"email"`
+	NotifyCompleted   bool               `json:"notify_completed"`
+	NotifyCompensated bool               `json:"notify_compensated"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Addresses that bounced, complained or opted out of all notifications
+type CoreNotificationSuppression struct {
+	Email     string             `json:"email"`
+	Reason    string             `json:"reason"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+// Balance corrections requested by one operator and applied only once a second operator approves them
+type CorePendingAdjustment struct {
+	ID        pgtype.UUID `json:"id"`
+	AccountID pgtype.UUID `json:"account_id"`
+	// Signed change of the balance; negative takes from it
+	Amount   pgtype.Numeric   `json:"amount"`
+	Currency CoreCurrencyCode `json:"currency"`
+	Reason   string           `json:"reason"`
+	// pending, approved or rejected
+	Status      string             `json:"status"`
+	RequestedBy string             `json:"requested_by"`
+	RequestedAt pgtype.Timestamptz `json:"requested_at"`
+	ReviewedBy  pgtype.Text        `json:"reviewed_by"`
+	ReviewedAt  pgtype.Timestamptz `json:"reviewed_at"`
+	ReviewNote  pgtype.Text        `json:"review_note"`
+	// Transaction that applied the adjustment once approved
+	TransactionID pgtype.UUID `json:"transaction_id"`
+}
+
+// Position in core.transfer_events up to which each projection has applied the events
+type CoreProjectionCheckpoint struct {
+	Projection string             `json:"projection"`
+	Position   int64              `json:"position"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+// Settlement status of each transfer included in a settlement file
+type CoreSettlementEntry struct {
+	ID               pgtype.UUID          `json:"id"`
+	SettlementFileID pgtype.UUID          `json:"settlement_file_id"`
+	TransferID       string               `json:"transfer_id"`
+	SettlementStatus CoreSettlementStatus `json:"settlement_status"`
+	RejectionReason  pgtype.Text          `json:"rejection_reason"`
+	SettledAt        pgtype.Timestamptz   `json:"settled_at"`
+	CreatedAt        pgtype.Timestamptz   `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz   `json:"updated_at"`
+}
+
+// Outgoing settlement files (CSV or ISO 20022 pacs.008) batching completed transfers
+type CoreSettlementFile struct {
+	ID pgtype.UUID `json:"id"`
+	// File reference, also used as the ISO 20022 message ID
+	FileReference string                   `json:"file_reference"`
+	Format        CoreSettlementFileFormat `json:"format"`
+	Status        CoreSettlementFileStatus `json:"status"`
+	TransferCount int32                    `json:"transfer_count"`
+	ControlSum    pgtype.Numeric           `json:"control_sum"`
+	Content       string                   `json:"content"`
+	// SHA-256 checksum of the stored file content
+	Checksum                 string             `json:"checksum"`
+	AcknowledgementReference pgtype.Text        `json:"acknowledgement_reference"`
+	AcknowledgedAt           pgtype.Timestamptz `json:"acknowledged_at"`
+	CreatedAt                pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                pgtype.Timestamptz `json:"updated_at"`
+}
+
 // Individual debit/credit transactions
 type CoreTransaction struct {
 	ID              pgtype.UUID           `json:"id"`
@@ -417,3 +774,78 @@ type CoreTransfer struct {
 	FailureReason pgtype.Text        `json:"failure_reason"`
 	Metadata      []byte             `json:"metadata"`
 }
+
+// Approvals given to transfers held for approval, one row per approver
+type CoreTransferApproval struct {
+	ID         pgtype.UUID        `json:"id"`
+	TransferID string             `json:"transfer_id"`
+	WorkflowID string             `json:"workflow_id"`
+	ApprovedBy string             `json:"approved_by"`
+	ApprovedAt pgtype.Timestamptz `json:"approved_at"`
+	RecordedAt pgtype.Timestamptz `json:"recorded_at"`
+}
+
+// State changes of saga transfers, so their status and reporting do not depend on Temporal history
+type CoreTransferEvent struct {
+	ID         pgtype.UUID `json:"id"`
+	Position   int64       `json:"position"`
+	TransferID string      `json:"transfer_id"`
+	WorkflowID string      `json:"workflow_id"`
+	RunID      string      `json:"run_id"`
+	// Position of the event within its workflow run; a retried recording of the same event is ignored
+	SequenceNumber int32  `json:"sequence_number"`
+	EventType      string `json:"event_type"`
+	// Status of the transfer after the event
+	Status       string             `json:"status"`
+	Step         pgtype.Text        `json:"step"`
+	FromAccount  string             `json:"from_account"`
+	ToAccount    string             `json:"to_account"`
+	Amount       pgtype.Numeric     `json:"amount"`
+	Currency     string             `json:"currency"`
+	Description  string             `json:"description"`
+	ErrorMessage pgtype.Text        `json:"error_message"`
+	OccurredAt   pgtype.Timestamptz `json:"occurred_at"`
+	RecordedAt   pgtype.Timestamptz `json:"recorded_at"`
+}
+
+// Latest state of every saga transfer, projected from core.transfer_events for the status and list endpoints
+type CoreTransferReadModel struct {
+	TransferID    string             `json:"transfer_id"`
+	WorkflowID    string             `json:"workflow_id"`
+	RunID         string             `json:"run_id"`
+	Status        string             `json:"status"`
+	LastEventType string             `json:"last_event_type"`
+	LastStep      pgtype.Text        `json:"last_step"`
+	FromAccount   string             `json:"from_account"`
+	ToAccount     string             `json:"to_account"`
+	Amount        pgtype.Numeric     `json:"amount"`
+	Currency      string             `json:"currency"`
+	Description   string             `json:"description"`
+	ErrorMessage  pgtype.Text        `json:"error_message"`
+	StartedAt     pgtype.Timestamptz `json:"started_at"`
+	FinishedAt    pgtype.Timestamptz `json:"finished_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	// Position of the latest projected event; older events never overwrite newer ones
+	EventPosition int64              `json:"event_position"`
+	ProjectedAt   pgtype.Timestamptz `json:"projected_at"`
+}
+
+// Human-friendly transfer reference numbers used by support agents to look up transfers
+type CoreTransferReference struct {
+	ID pgtype.UUID `json:"id"`
+	// Business date, branch code, daily sequence number and Luhn check digit
+	Reference      string             `json:"reference"`
+	TransferID     string             `json:"transfer_id"`
+	BranchCode     string             `json:"branch_code"`
+	BusinessDate   pgtype.Date        `json:"business_date"`
+	SequenceNumber int32              `json:"sequence_number"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+// Last sequence number issued per branch and business date
+type CoreTransferReferenceSequence struct {
+	BranchCode   string             `json:"branch_code"`
+	BusinessDate pgtype.Date        `json:"business_date"`
+	LastSequence int32              `json:"last_sequence"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
@@ -0,0 +1,203 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: pending_adjustments.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createPendingAdjustment = `-- name: CreatePendingAdjustment :one
+INSERT INTO core.pending_adjustments (
+    account_id,
+    amount,
+    currency,
+    reason,
+    requested_by
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, account_id, amount, currency, reason, status, requested_by, requested_at, reviewed_by, reviewed_at, review_note, transaction_id
+`
+
+type CreatePendingAdjustmentParams struct {
+	AccountID   pgtype.UUID      `json:"account_id"`
+	Amount      pgtype.Numeric   `json:"amount"`
+	Currency    CoreCurrencyCode `json:"currency"`
+	Reason      string           `json:"reason"`
+	RequestedBy string           `json:"requested_by"`
+}
+
+func (q *Queries) CreatePendingAdjustment(ctx context.Context, arg CreatePendingAdjustmentParams) (CorePendingAdjustment, error) {
+	row := q.db.QueryRow(ctx, createPendingAdjustment,
+		arg.AccountID,
+		arg.Amount,
+		arg.Currency,
+		arg.Reason,
+		arg.RequestedBy,
+	)
+	var i CorePendingAdjustment
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Reason,
+		&i.Status,
+		&i.RequestedBy,
+		&i.RequestedAt,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.ReviewNote,
+		&i.TransactionID,
+	)
+	return i, err
+}
+
+const getPendingAdjustment = `-- name: GetPendingAdjustment :one
+SELECT id, account_id, amount, currency, reason, status, requested_by, requested_at, reviewed_by, reviewed_at, review_note, transaction_id FROM core.pending_adjustments
+WHERE id = $1
+`
+
+func (q *Queries) GetPendingAdjustment(ctx context.Context, id pgtype.UUID) (CorePendingAdjustment, error) {
+	row := q.db.QueryRow(ctx, getPendingAdjustment, id)
+	var i CorePendingAdjustment
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Reason,
+		&i.Status,
+		&i.RequestedBy,
+		&i.RequestedAt,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.ReviewNote,
+		&i.TransactionID,
+	)
+	return i, err
+}
+
+const getPendingAdjustmentForUpdate = `-- name: GetPendingAdjustmentForUpdate :one
+SELECT id, account_id, amount, currency, reason, status, requested_by, requested_at, reviewed_by, reviewed_at, review_note, transaction_id FROM core.pending_adjustments
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetPendingAdjustmentForUpdate(ctx context.Context, id pgtype.UUID) (CorePendingAdjustment, error) {
+	row := q.db.QueryRow(ctx, getPendingAdjustmentForUpdate, id)
+	var i CorePendingAdjustment
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Reason,
+		&i.Status,
+		&i.RequestedBy,
+		&i.RequestedAt,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.ReviewNote,
+		&i.TransactionID,
+	)
+	return i, err
+}
+
+const listPendingAdjustments = `-- name: ListPendingAdjustments :many
+SELECT id, account_id, amount, currency, reason, status, requested_by, requested_at, reviewed_by, reviewed_at, review_note, transaction_id FROM core.pending_adjustments
+WHERE ($1::TEXT IS NULL OR status = $1)
+AND ($2::UUID IS NULL OR account_id = $2)
+ORDER BY requested_at DESC, id DESC
+LIMIT $3
+`
+
+type ListPendingAdjustmentsParams struct {
+	Status    pgtype.Text `json:"status"`
+	AccountID pgtype.UUID `json:"account_id"`
+	RowLimit  int32       `json:"row_limit"`
+}
+
+func (q *Queries) ListPendingAdjustments(ctx context.Context, arg ListPendingAdjustmentsParams) ([]CorePendingAdjustment, error) {
+	rows, err := q.db.Query(ctx, listPendingAdjustments, arg.Status, arg.AccountID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CorePendingAdjustment{}
+	for rows.Next() {
+		var i CorePendingAdjustment
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.Amount,
+			&i.Currency,
+			&i.Reason,
+			&i.Status,
+			&i.RequestedBy,
+			&i.RequestedAt,
+			&i.ReviewedBy,
+			&i.ReviewedAt,
+			&i.ReviewNote,
+			&i.TransactionID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reviewPendingAdjustment = `-- name: ReviewPendingAdjustment :one
+UPDATE core.pending_adjustments
+SET status = $1,
+    reviewed_by = $2,
+    reviewed_at = NOW(),
+    review_note = $3,
+    transaction_id = $4
+WHERE id = $5
+AND status = 'pending'
+RETURNING id, account_id, amount, currency, reason, status, requested_by, requested_at, reviewed_by, reviewed_at, review_note, transaction_id
+`
+
+type ReviewPendingAdjustmentParams struct {
+	Status        string      `json:"status"`
+	ReviewedBy    pgtype.Text `json:"reviewed_by"`
+	ReviewNote    pgtype.Text `json:"review_note"`
+	TransactionID pgtype.UUID `json:"transaction_id"`
+	ID            pgtype.UUID `json:"id"`
+}
+
+// Moves a pending adjustment to approved or rejected; returns no row when it was reviewed already
+func (q *Queries) ReviewPendingAdjustment(ctx context.Context, arg ReviewPendingAdjustmentParams) (CorePendingAdjustment, error) {
+	row := q.db.QueryRow(ctx, reviewPendingAdjustment,
+		arg.Status,
+		arg.ReviewedBy,
+		arg.ReviewNote,
+		arg.TransactionID,
+		arg.ID,
+	)
+	var i CorePendingAdjustment
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.Currency,
+		&i.Reason,
+		&i.Status,
+		&i.RequestedBy,
+		&i.RequestedAt,
+		&i.ReviewedBy,
+		&i.ReviewedAt,
+		&i.ReviewNote,
+		&i.TransactionID,
+	)
+	return i, err
+}
@@ -0,0 +1,92 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: pending_transactions.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countPendingTransactions = `-- name: CountPendingTransactions :one
+SELECT
+    COUNT(*) AS pending,
+    COUNT(*) FILTER (WHERE created_at < $1) AS stale
+FROM core.transactions
+WHERE status = 'pending'
+`
+
+type CountPendingTransactionsRow struct {
+	Pending int64 `json:"pending"`
+	Stale   int64 `json:"stale"`
+}
+
+func (q *Queries) CountPendingTransactions(ctx context.Context, staleBefore pgtype.Timestamptz) (CountPendingTransactionsRow, error) {
+	row := q.db.QueryRow(ctx, countPendingTransactions, staleBefore)
+	var i CountPendingTransactionsRow
+	err := row.Scan(&i.Pending, &i.Stale)
+	return i, err
+}
+
+const listStalePendingTransactions = `-- name: ListStalePendingTransactions :many
+SELECT
+    id,
+    account_id,
+    transaction_type,
+    amount,
+    currency,
+    idempotency_key,
+    created_at
+FROM core.transactions
+WHERE status = 'pending'
+    AND created_at < $1
+ORDER BY created_at ASC
+LIMIT $2
+`
+
+type ListStalePendingTransactionsParams struct {
+	StaleBefore pgtype.Timestamptz `json:"stale_before"`
+	RowLimit    int32              `json:"row_limit"`
+}
+
+type ListStalePendingTransactionsRow struct {
+	ID              pgtype.UUID         `json:"id"`
+	AccountID       pgtype.UUID         `json:"account_id"`
+	TransactionType CoreTransactionType `json:"transaction_type"`
+	Amount          pgtype.Numeric      `json:"amount"`
+	Currency        CoreCurrencyCode    `json:"currency"`
+	IdempotencyKey  pgtype.Text         `json:"idempotency_key"`
+	CreatedAt       pgtype.Timestamptz  `json:"created_at"`
+}
+
+// Pending transactions created before stale_before, oldest first
+func (q *Queries) ListStalePendingTransactions(ctx context.Context, arg ListStalePendingTransactionsParams) ([]ListStalePendingTransactionsRow, error) {
+	rows, err := q.db.Query(ctx, listStalePendingTransactions, arg.StaleBefore, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStalePendingTransactionsRow{}
+	for rows.Next() {
+		var i ListStalePendingTransactionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.TransactionType,
+			&i.Amount,
+			&i.Currency,
+			&i.IdempotencyKey,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
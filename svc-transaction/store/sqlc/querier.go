@@ -11,26 +11,77 @@ import (
 )
 
 type Querier interface {
+	AcknowledgeSettlementFile(ctx context.Context, arg AcknowledgeSettlementFileParams) (CoreSettlementFile, error)
+	// Transaction-scoped advisory lock serializing mutations of one account across service instances
+	AcquireAccountLock(ctx context.Context, accountID string) error
+	AddAccountOwner(ctx context.Context, arg AddAccountOwnerParams) (CoreAccountOwner, error)
+	AdjustAccountBalance(ctx context.Context, arg AdjustAccountBalanceParams) (AdjustAccountBalanceRow, error)
+	AnonymizeAccount(ctx context.Context, arg AnonymizeAccountParams) (int64, error)
+	// Replaces the free-text reasons and drops every metadata key outside kept_metadata_keys
+	AnonymizeAccountCompensationAudits(ctx context.Context, arg AnonymizeAccountCompensationAuditsParams) (int64, error)
+	// Drops descriptions and every metadata key outside kept_metadata_keys
+	AnonymizeAccountTransactions(ctx context.Context, arg AnonymizeAccountTransactionsParams) (int64, error)
+	// Drops descriptions and every metadata key outside kept_metadata_keys from transfers on either side of the account
+	AnonymizeAccountTransfers(ctx context.Context, arg AnonymizeAccountTransfersParams) (int64, error)
 	CancelTransaction(ctx context.Context, arg CancelTransactionParams) (CancelTransactionRow, error)
 	CheckAccountBalance(ctx context.Context, arg CheckAccountBalanceParams) (CheckAccountBalanceRow, error)
 	CompleteTransaction(ctx context.Context, id pgtype.UUID) (CompleteTransactionRow, error)
+	// Rows the anonymize queries would still change, used to verify an erasure
+	CountAccountPersonalData(ctx context.Context, arg CountAccountPersonalDataParams) (CountAccountPersonalDataRow, error)
+	// Compensations created since created_from that have not completed
+	CountCompensationBacklog(ctx context.Context, createdFrom pgtype.Timestamptz) (int64, error)
+	// Debits of an account created since created_from that were not cancelled or failed; debits later compensated
+	// still count
+	CountOutgoingDebits(ctx context.Context, arg CountOutgoingDebitsParams) (int64, error)
+	CountPendingTransactions(ctx context.Context, staleBefore pgtype.Timestamptz) (CountPendingTransactionsRow, error)
+	// Opens an empty account; the opening balance is credited separately so it shows up in the balance history
+	CreateAccount(ctx context.Context, arg CreateAccountParams) (CoreAccount, error)
+	CreateAccountClosureStep(ctx context.Context, arg CreateAccountClosureStepParams) (CoreAccountClosureAuditTrail, error)
+	CreateAccountErasureCertificate(ctx context.Context, arg CreateAccountErasureCertificateParams) (CoreAccountErasureCertificate, error)
+	CreateAdminAudit(ctx context.Context, arg CreateAdminAuditParams) (CoreAdminAudit, error)
 	CreateBalanceHistoryRecord(ctx context.Context, arg CreateBalanceHistoryRecordParams) (CreateBalanceHistoryRecordRow, error)
 	CreateCompensationAudit(ctx context.Context, arg CreateCompensationAuditParams) (CoreCompensationAuditTrail, error)
+	// Registers a key, taking over an expired registration the cleanup job has not deleted yet.
+	// Returns no row while the key is still registered.
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) (CoreIdempotencyKey, error)
+	CreatePendingAdjustment(ctx context.Context, arg CreatePendingAdjustmentParams) (CorePendingAdjustment, error)
+	CreateSettlementEntry(ctx context.Context, arg CreateSettlementEntryParams) (CoreSettlementEntry, error)
+	CreateSettlementFile(ctx context.Context, arg CreateSettlementFileParams) (CoreSettlementFile, error)
 	CreateTransaction(ctx context.Context, arg CreateTransactionParams) (CreateTransactionRow, error)
+	CreateTransfer(ctx context.Context, arg CreateTransferParams) (CoreTransfer, error)
+	CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (int64, error)
+	CreateTransferEvent(ctx context.Context, arg CreateTransferEventParams) (int64, error)
+	CreateTransferReference(ctx context.Context, arg CreateTransferReferenceParams) (CoreTransferReference, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context, limit int32) (int64, error)
 	FailTransaction(ctx context.Context, arg FailTransactionParams) (FailTransactionRow, error)
 	GetAccountBalanceHistory(ctx context.Context, arg GetAccountBalanceHistoryParams) ([]CoreAccountBalanceHistory, error)
 	GetAccountByAccountNumber(ctx context.Context, accountNumber string) (CoreAccount, error)
 	// Account-related queries for transaction service
 	GetAccountByID(ctx context.Context, id pgtype.UUID) (CoreAccount, error)
+	GetAccountClosureStep(ctx context.Context, arg GetAccountClosureStepParams) (CoreAccountClosureAuditTrail, error)
+	GetAccountErasureCertificate(ctx context.Context, accountID pgtype.UUID) (CoreAccountErasureCertificate, error)
+	GetAccountForUpdate(ctx context.Context, id pgtype.UUID) (CoreAccount, error)
+	// Balance implied by the completed transactions of an account
+	GetAccountLedgerBalance(ctx context.Context, accountID pgtype.UUID) (GetAccountLedgerBalanceRow, error)
 	GetBalanceHistoryByDateRange(ctx context.Context, arg GetBalanceHistoryByDateRangeParams) ([]CoreAccountBalanceHistory, error)
 	GetBalanceHistoryByTransaction(ctx context.Context, transactionID pgtype.UUID) ([]CoreAccountBalanceHistory, error)
+	GetCompensatedAmount(ctx context.Context, originalTransactionID string) (pgtype.Numeric, error)
 	GetCompensationAuditByTransferID(ctx context.Context, transferID pgtype.Text) ([]CoreCompensationAuditTrail, error)
 	GetCompensationAuditByWorkflowID(ctx context.Context, workflowID string) ([]CoreCompensationAuditTrail, error)
 	GetCompensationStats(ctx context.Context) (GetCompensationStatsRow, error)
+	GetCompensationStatsFiltered(ctx context.Context, arg GetCompensationStatsFilteredParams) (GetCompensationStatsFilteredRow, error)
 	GetFailedCompensationsByTimeoutDuration(ctx context.Context, arg GetFailedCompensationsByTimeoutDurationParams) ([]GetFailedCompensationsByTimeoutDurationRow, error)
+	GetIdempotencyKey(ctx context.Context, idempotencyKey string) (CoreIdempotencyKey, error)
+	GetPendingAdjustment(ctx context.Context, id pgtype.UUID) (CorePendingAdjustment, error)
+	GetPendingAdjustmentForUpdate(ctx context.Context, id pgtype.UUID) (CorePendingAdjustment, error)
 	GetPendingCompensations(ctx context.Context, limit int32) ([]CoreCompensationAuditTrail, error)
 	GetPendingTransactions(ctx context.Context, limit int32) ([]GetPendingTransactionsRow, error)
+	GetProjectionCheckpoint(ctx context.Context, projection string) (int64, error)
 	GetRecentTransactionsByAccount(ctx context.Context, arg GetRecentTransactionsByAccountParams) ([]GetRecentTransactionsByAccountRow, error)
+	GetSettlementEntriesByFile(ctx context.Context, settlementFileID pgtype.UUID) ([]CoreSettlementEntry, error)
+	GetSettlementEntryByTransferID(ctx context.Context, transferID string) (CoreSettlementEntry, error)
+	GetSettlementFile(ctx context.Context, id pgtype.UUID) (CoreSettlementFile, error)
+	GetSettlementFileForUpdate(ctx context.Context, id pgtype.UUID) (CoreSettlementFile, error)
 	GetTransactionByID(ctx context.Context, id pgtype.UUID) (GetTransactionByIDRow, error)
 	GetTransactionByIdempotencyKey(ctx context.Context, idempotencyKey pgtype.Text) (GetTransactionByIdempotencyKeyRow, error)
 	GetTransactionSummaryByAccount(ctx context.Context, accountID pgtype.UUID) (GetTransactionSummaryByAccountRow, error)
@@ -39,9 +90,45 @@ type Querier interface {
 	GetTransactionsByDateRange(ctx context.Context, arg GetTransactionsByDateRangeParams) ([]GetTransactionsByDateRangeRow, error)
 	GetTransactionsByReference(ctx context.Context, referenceID pgtype.Text) ([]GetTransactionsByReferenceRow, error)
 	GetTransactionsByStatus(ctx context.Context, arg GetTransactionsByStatusParams) ([]GetTransactionsByStatusRow, error)
+	GetTransferByTransferID(ctx context.Context, transferID string) (CoreTransfer, error)
+	GetTransferReadModel(ctx context.Context, transferID string) (CoreTransferReadModel, error)
+	GetTransferReference(ctx context.Context, reference string) (CoreTransferReference, error)
+	GetTransferReferenceByTransferID(ctx context.Context, transferID string) (CoreTransferReference, error)
+	ListAccountClosureSteps(ctx context.Context, accountID pgtype.UUID) ([]CoreAccountClosureAuditTrail, error)
+	ListAccountOwners(ctx context.Context, accountID pgtype.UUID) ([]CoreAccountOwner, error)
+	ListAccountsForReconciliation(ctx context.Context, accountID pgtype.UUID) ([]CoreAccount, error)
+	ListAdminAudits(ctx context.Context, arg ListAdminAuditsParams) ([]CoreAdminAudit, error)
+	// Full history of one account in chain order, for verification
+	ListBalanceHistoryChain(ctx context.Context, accountID pgtype.UUID) ([]CoreAccountBalanceHistory, error)
+	// Balance history entries created in [created_from, created_to) after the (after_created_at, after_id) keyset cursor
+	ListBalanceHistoryForExport(ctx context.Context, arg ListBalanceHistoryForExportParams) ([]ListBalanceHistoryForExportRow, error)
+	ListBusinessRules(ctx context.Context) ([]CoreBusinessRule, error)
+	ListCompensationAudits(ctx context.Context, arg ListCompensationAuditsParams) ([]CoreCompensationAuditTrail, error)
+	ListPendingAdjustments(ctx context.Context, arg ListPendingAdjustmentsParams) ([]CorePendingAdjustment, error)
+	ListSettlementFiles(ctx context.Context, limit int32) ([]ListSettlementFilesRow, error)
+	// Pending transactions created before stale_before, oldest first
+	ListStalePendingTransactions(ctx context.Context, arg ListStalePendingTransactionsParams) ([]ListStalePendingTransactionsRow, error)
+	// Transactions created in [created_from, created_to) after the (after_created_at, after_id) keyset cursor
+	ListTransactionsForExport(ctx context.Context, arg ListTransactionsForExportParams) ([]ListTransactionsForExportRow, error)
+	ListTransferApprovals(ctx context.Context, transferID string) ([]CoreTransferApproval, error)
+	ListTransferEvents(ctx context.Context, transferID string) ([]CoreTransferEvent, error)
+	ListTransferEventsAfter(ctx context.Context, arg ListTransferEventsAfterParams) ([]CoreTransferEvent, error)
+	ListTransferReadModels(ctx context.Context, arg ListTransferReadModelsParams) ([]CoreTransferReadModel, error)
+	ListUnsettledTransfers(ctx context.Context, limit int32) ([]ListUnsettledTransfersRow, error)
+	NextTransferReferenceSequence(ctx context.Context, arg NextTransferReferenceSequenceParams) (int32, error)
+	RemoveAccountOwner(ctx context.Context, arg RemoveAccountOwnerParams) (int64, error)
+	// Moves a pending adjustment to approved or rejected; returns no row when it was reviewed already
+	ReviewPendingAdjustment(ctx context.Context, arg ReviewPendingAdjustmentParams) (CorePendingAdjustment, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (UpdateAccountStatusRow, error)
 	UpdateCompensationAudit(ctx context.Context, arg UpdateCompensationAuditParams) (CoreCompensationAuditTrail, error)
+	UpdateSettlementEntryStatus(ctx context.Context, arg UpdateSettlementEntryStatusParams) (CoreSettlementEntry, error)
 	UpdateTransactionMetadata(ctx context.Context, arg UpdateTransactionMetadataParams) (UpdateTransactionMetadataRow, error)
 	UpdateTransactionStatus(ctx context.Context, arg UpdateTransactionStatusParams) (UpdateTransactionStatusRow, error)
+	// Closing balances of the accounts opened before day_end, taken from their last balance history entry before
+	// day_end; running the same business date again overwrites its rows
+	UpsertEODBalances(ctx context.Context, arg UpsertEODBalancesParams) (int64, error)
+	UpsertProjectionCheckpoint(ctx context.Context, arg UpsertProjectionCheckpointParams) error
+	UpsertTransferReadModel(ctx context.Context, arg UpsertTransferReadModelParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
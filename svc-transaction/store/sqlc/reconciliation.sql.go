@@ -0,0 +1,88 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: reconciliation.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const listAccountsForReconciliation = `-- name: ListAccountsForReconciliation :many
+SELECT id, account_number, account_name, balance, currency, status, created_at, updated_at, version, account_type FROM core.accounts
+WHERE $1::UUID IS NULL OR id = $1
+ORDER BY account_number ASC
+`
+
+func (q *Queries) ListAccountsForReconciliation(ctx context.Context, accountID pgtype.UUID) ([]CoreAccount, error) {
+	rows, err := q.db.Query(ctx, listAccountsForReconciliation, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreAccount{}
+	for rows.Next() {
+		var i CoreAccount
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountNumber,
+			&i.AccountName,
+			&i.Balance,
+			&i.Currency,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.AccountType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBalanceHistoryChain = `-- name: ListBalanceHistoryChain :many
+SELECT id, account_id, transaction_id, old_balance, new_balance, balance_change, operation, created_at, created_by, sequence_number, chain_hash FROM core.account_balance_history
+WHERE account_id = $1
+ORDER BY sequence_number ASC
+`
+
+// Full history of one account in chain order, for verification
+func (q *Queries) ListBalanceHistoryChain(ctx context.Context, accountID pgtype.UUID) ([]CoreAccountBalanceHistory, error) {
+	rows, err := q.db.Query(ctx, listBalanceHistoryChain, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreAccountBalanceHistory{}
+	for rows.Next() {
+		var i CoreAccountBalanceHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountID,
+			&i.TransactionID,
+			&i.OldBalance,
+			&i.NewBalance,
+			&i.BalanceChange,
+			&i.Operation,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.SequenceNumber,
+			&i.ChainHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,400 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: settlement.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const acknowledgeSettlementFile = `-- name: AcknowledgeSettlementFile :one
+UPDATE core.settlement_files
+SET
+    status = $2,
+    acknowledgement_reference = $3,
+    acknowledged_at = NOW()
+WHERE id = $1
+RETURNING id, file_reference, format, status, transfer_count, control_sum, content, checksum, acknowledgement_reference, acknowledged_at, created_at, updated_at
+`
+
+type AcknowledgeSettlementFileParams struct {
+	ID                       pgtype.UUID              `json:"id"`
+	Status                   CoreSettlementFileStatus `json:"status"`
+	AcknowledgementReference pgtype.Text              `json:"acknowledgement_reference"`
+}
+
+func (q *Queries) AcknowledgeSettlementFile(ctx context.Context, arg AcknowledgeSettlementFileParams) (CoreSettlementFile, error) {
+	row := q.db.QueryRow(ctx, acknowledgeSettlementFile, arg.ID, arg.Status, arg.AcknowledgementReference)
+	var i CoreSettlementFile
+	err := row.Scan(
+		&i.ID,
+		&i.FileReference,
+		&i.Format,
+		&i.Status,
+		&i.TransferCount,
+		&i.ControlSum,
+		&i.Content,
+		&i.Checksum,
+		&i.AcknowledgementReference,
+		&i.AcknowledgedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createSettlementEntry = `-- name: CreateSettlementEntry :one
+INSERT INTO core.settlement_entries (
+    settlement_file_id,
+    transfer_id
+) VALUES (
+    $1, $2
+) RETURNING id, settlement_file_id, transfer_id, settlement_status, rejection_reason, settled_at, created_at, updated_at
+`
+
+type CreateSettlementEntryParams struct {
+	SettlementFileID pgtype.UUID `json:"settlement_file_id"`
+	TransferID       string      `json:"transfer_id"`
+}
+
+func (q *Queries) CreateSettlementEntry(ctx context.Context, arg CreateSettlementEntryParams) (CoreSettlementEntry, error) {
+	row := q.db.QueryRow(ctx, createSettlementEntry, arg.SettlementFileID, arg.TransferID)
+	var i CoreSettlementEntry
+	err := row.Scan(
+		&i.ID,
+		&i.SettlementFileID,
+		&i.TransferID,
+		&i.SettlementStatus,
+		&i.RejectionReason,
+		&i.SettledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createSettlementFile = `-- name: CreateSettlementFile :one
+INSERT INTO core.settlement_files (
+    file_reference,
+    format,
+    transfer_count,
+    control_sum,
+    content,
+    checksum
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, file_reference, format, status, transfer_count, control_sum, content, checksum, acknowledgement_reference, acknowledged_at, created_at, updated_at
+`
+
+type CreateSettlementFileParams struct {
+	FileReference string                   `json:"file_reference"`
+	Format        CoreSettlementFileFormat `json:"format"`
+	TransferCount int32                    `json:"transfer_count"`
+	ControlSum    pgtype.Numeric           `json:"control_sum"`
+	Content       string                   `json:"content"`
+	Checksum      string                   `json:"checksum"`
+}
+
+func (q *Queries) CreateSettlementFile(ctx context.Context, arg CreateSettlementFileParams) (CoreSettlementFile, error) {
+	row := q.db.QueryRow(ctx, createSettlementFile,
+		arg.FileReference,
+		arg.Format,
+		arg.TransferCount,
+		arg.ControlSum,
+		arg.Content,
+		arg.Checksum,
+	)
+	var i CoreSettlementFile
+	err := row.Scan(
+		&i.ID,
+		&i.FileReference,
+		&i.Format,
+		&i.Status,
+		&i.TransferCount,
+		&i.ControlSum,
+		&i.Content,
+		&i.Checksum,
+		&i.AcknowledgementReference,
+		&i.AcknowledgedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSettlementEntriesByFile = `-- name: GetSettlementEntriesByFile :many
+SELECT id, settlement_file_id, transfer_id, settlement_status, rejection_reason, settled_at, created_at, updated_at FROM core.settlement_entries
+WHERE settlement_file_id = $1
+ORDER BY transfer_id
+`
+
+func (q *Queries) GetSettlementEntriesByFile(ctx context.Context, settlementFileID pgtype.UUID) ([]CoreSettlementEntry, error) {
+	rows, err := q.db.Query(ctx, getSettlementEntriesByFile, settlementFileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreSettlementEntry{}
+	for rows.Next() {
+		var i CoreSettlementEntry
+		if err := rows.Scan(
+			&i.ID,
+			&i.SettlementFileID,
+			&i.TransferID,
+			&i.SettlementStatus,
+			&i.RejectionReason,
+			&i.SettledAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSettlementEntryByTransferID = `-- name: GetSettlementEntryByTransferID :one
+SELECT id, settlement_file_id, transfer_id, settlement_status, rejection_reason, settled_at, created_at, updated_at FROM core.settlement_entries
+WHERE transfer_id = $1
+`
+
+func (q *Queries) GetSettlementEntryByTransferID(ctx context.Context, transferID string) (CoreSettlementEntry, error) {
+	row := q.db.QueryRow(ctx, getSettlementEntryByTransferID, transferID)
+	var i CoreSettlementEntry
+	err := row.Scan(
+		&i.ID,
+		&i.SettlementFileID,
+		&i.TransferID,
+		&i.SettlementStatus,
+		&i.RejectionReason,
+		&i.SettledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSettlementFile = `-- name: GetSettlementFile :one
+SELECT id, file_reference, format, status, transfer_count, control_sum, content, checksum, acknowledgement_reference, acknowledged_at, created_at, updated_at FROM core.settlement_files
+WHERE id = $1
+`
+
+func (q *Queries) GetSettlementFile(ctx context.Context, id pgtype.UUID) (CoreSettlementFile, error) {
+	row := q.db.QueryRow(ctx, getSettlementFile, id)
+	var i CoreSettlementFile
+	err := row.Scan(
+		&i.ID,
+		&i.FileReference,
+		&i.Format,
+		&i.Status,
+		&i.TransferCount,
+		&i.ControlSum,
+		&i.Content,
+		&i.Checksum,
+		&i.AcknowledgementReference,
+		&i.AcknowledgedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSettlementFileForUpdate = `-- name: GetSettlementFileForUpdate :one
+SELECT id, file_reference, format, status, transfer_count, control_sum, content, checksum, acknowledgement_reference, acknowledged_at, created_at, updated_at FROM core.settlement_files
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetSettlementFileForUpdate(ctx context.Context, id pgtype.UUID) (CoreSettlementFile, error) {
+	row := q.db.QueryRow(ctx, getSettlementFileForUpdate, id)
+	var i CoreSettlementFile
+	err := row.Scan(
+		&i.ID,
+		&i.FileReference,
+		&i.Format,
+		&i.Status,
+		&i.TransferCount,
+		&i.ControlSum,
+		&i.Content,
+		&i.Checksum,
+		&i.AcknowledgementReference,
+		&i.AcknowledgedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listSettlementFiles = `-- name: ListSettlementFiles :many
+SELECT
+    id,
+    file_reference,
+    format,
+    status,
+    transfer_count,
+    control_sum,
+    checksum,
+    acknowledgement_reference,
+    acknowledged_at,
+    created_at,
+    updated_at
+FROM core.settlement_files
+ORDER BY created_at DESC
+LIMIT $1
+`
+
+type ListSettlementFilesRow struct {
+	ID                       pgtype.UUID              `json:"id"`
+	FileReference            string                   `json:"file_reference"`
+	Format                   CoreSettlementFileFormat `json:"format"`
+	Status                   CoreSettlementFileStatus `json:"status"`
+	TransferCount            int32                    `json:"transfer_count"`
+	ControlSum               pgtype.Numeric           `json:"control_sum"`
+	Checksum                 string                   `json:"checksum"`
+	AcknowledgementReference pgtype.Text              `json:"acknowledgement_reference"`
+	AcknowledgedAt           pgtype.Timestamptz       `json:"acknowledged_at"`
+	CreatedAt                pgtype.Timestamptz       `json:"created_at"`
+	UpdatedAt                pgtype.Timestamptz       `json:"updated_at"`
+}
+
+func (q *Queries) ListSettlementFiles(ctx context.Context, limit int32) ([]ListSettlementFilesRow, error) {
+	rows, err := q.db.Query(ctx, listSettlementFiles, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSettlementFilesRow{}
+	for rows.Next() {
+		var i ListSettlementFilesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FileReference,
+			&i.Format,
+			&i.Status,
+			&i.TransferCount,
+			&i.ControlSum,
+			&i.Checksum,
+			&i.AcknowledgementReference,
+			&i.AcknowledgedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnsettledTransfers = `-- name: ListUnsettledTransfers :many
+SELECT
+    t.transfer_id,
+    t.amount,
+    t.currency,
+    t.description,
+    COALESCE(t.completed_at, t.created_at)::TIMESTAMPTZ AS completed_at,
+    fa.account_number AS from_account_number,
+    fa.account_name AS from_account_name,
+    ta.account_number AS to_account_number,
+    ta.account_name AS to_account_name
+FROM core.transfers t
+JOIN core.accounts fa ON fa.id = t.from_account_id
+JOIN core.accounts ta ON ta.id = t.to_account_id
+LEFT JOIN core.settlement_entries se ON se.transfer_id = t.transfer_id
+WHERE t.status = 'completed'
+    AND se.id IS NULL
+ORDER BY COALESCE(t.completed_at, t.created_at), t.transfer_id
+LIMIT $1
+FOR UPDATE OF t SKIP LOCKED
+`
+
+type ListUnsettledTransfersRow struct {
+	TransferID        string             `json:"transfer_id"`
+	Amount            pgtype.Numeric     `json:"amount"`
+	Currency          CoreCurrencyCode   `json:"currency"`
+	Description       pgtype.Text        `json:"description"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+	FromAccountNumber string             `json:"from_account_number"`
+	FromAccountName   string             `json:"from_account_name"`
+	ToAccountNumber   string             `json:"to_account_number"`
+	ToAccountName     string             `json:"to_account_name"`
+}
+
+func (q *Queries) ListUnsettledTransfers(ctx context.Context, limit int32) ([]ListUnsettledTransfersRow, error) {
+	rows, err := q.db.Query(ctx, listUnsettledTransfers, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUnsettledTransfersRow{}
+	for rows.Next() {
+		var i ListUnsettledTransfersRow
+		if err := rows.Scan(
+			&i.TransferID,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.CompletedAt,
+			&i.FromAccountNumber,
+			&i.FromAccountName,
+			&i.ToAccountNumber,
+			&i.ToAccountName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateSettlementEntryStatus = `-- name: UpdateSettlementEntryStatus :one
+UPDATE core.settlement_entries
+SET
+    settlement_status = $2,
+    rejection_reason = $3,
+    settled_at = $4
+WHERE id = $1
+RETURNING id, settlement_file_id, transfer_id, settlement_status, rejection_reason, settled_at, created_at, updated_at
+`
+
+type UpdateSettlementEntryStatusParams struct {
+	ID               pgtype.UUID          `json:"id"`
+	SettlementStatus CoreSettlementStatus `json:"settlement_status"`
+	RejectionReason  pgtype.Text          `json:"rejection_reason"`
+	SettledAt        pgtype.Timestamptz   `json:"settled_at"`
+}
+
+func (q *Queries) UpdateSettlementEntryStatus(ctx context.Context, arg UpdateSettlementEntryStatusParams) (CoreSettlementEntry, error) {
+	row := q.db.QueryRow(ctx, updateSettlementEntryStatus,
+		arg.ID,
+		arg.SettlementStatus,
+		arg.RejectionReason,
+		arg.SettledAt,
+	)
+	var i CoreSettlementEntry
+	err := row.Scan(
+		&i.ID,
+		&i.SettlementFileID,
+		&i.TransferID,
+		&i.SettlementStatus,
+		&i.RejectionReason,
+		&i.SettledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
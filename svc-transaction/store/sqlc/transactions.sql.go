@@ -47,6 +47,7 @@ SELECT
     balance,
     currency,
     status,
+    account_type,
     CASE 
         WHEN $2::DECIMAL IS NULL THEN TRUE
         WHEN balance >= $2::DECIMAL THEN TRUE
@@ -68,6 +69,7 @@ type CheckAccountBalanceRow struct {
 	Balance         pgtype.Numeric    `json:"balance"`
 	Currency        CoreCurrencyCode  `json:"currency"`
 	Status          CoreAccountStatus `json:"status"`
+	AccountType     CoreAccountType   `json:"account_type"`
 	SufficientFunds bool              `json:"sufficient_funds"`
 }
 
@@ -81,6 +83,7 @@ func (q *Queries) CheckAccountBalance(ctx context.Context, arg CheckAccountBalan
 		&i.Balance,
 		&i.Currency,
 		&i.Status,
+		&i.AccountType,
 		&i.SufficientFunds,
 	)
 	return i, err
@@ -109,6 +112,28 @@ func (q *Queries) CompleteTransaction(ctx context.Context, id pgtype.UUID) (Comp
 	return i, err
 }
 
+const countOutgoingDebits = `-- name: CountOutgoingDebits :one
+SELECT COUNT(*) FROM core.transactions
+WHERE account_id = $1
+AND transaction_type = 'debit'
+AND status IN ('pending', 'completed')
+AND created_at >= $2::TIMESTAMPTZ
+`
+
+type CountOutgoingDebitsParams struct {
+	AccountID   pgtype.UUID        `json:"account_id"`
+	CreatedFrom pgtype.Timestamptz `json:"created_from"`
+}
+
+// Debits of an account created since created_from that were not cancelled or failed; debits later compensated
+// still count
+func (q *Queries) CountOutgoingDebits(ctx context.Context, arg CountOutgoingDebitsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countOutgoingDebits, arg.AccountID, arg.CreatedFrom)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createBalanceHistoryRecord = `-- name: CreateBalanceHistoryRecord :one
 INSERT INTO core.account_balance_history (
     account_id,
@@ -239,7 +264,9 @@ SELECT
     balance_change,
     operation,
     created_at,
-    created_by
+    created_by,
+    sequence_number,
+    chain_hash
 FROM core.account_balance_history
 WHERE account_id = $1
 ORDER BY created_at DESC
@@ -271,6 +298,8 @@ func (q *Queries) GetAccountBalanceHistory(ctx context.Context, arg GetAccountBa
 			&i.Operation,
 			&i.CreatedAt,
 			&i.CreatedBy,
+			&i.SequenceNumber,
+			&i.ChainHash,
 		); err != nil {
 			return nil, err
 		}
@@ -292,7 +321,8 @@ SELECT
     status,
     created_at,
     updated_at,
-    version
+    version,
+    account_type
 FROM core.accounts
 WHERE account_number = $1
 `
@@ -310,6 +340,7 @@ func (q *Queries) GetAccountByAccountNumber(ctx context.Context, accountNumber s
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Version,
+		&i.AccountType,
 	)
 	return i, err
 }
@@ -325,7 +356,8 @@ SELECT
     status,
     created_at,
     updated_at,
-    version
+    version,
+    account_type
 FROM core.accounts
 WHERE id = $1
 `
@@ -344,6 +376,7 @@ func (q *Queries) GetAccountByID(ctx context.Context, id pgtype.UUID) (CoreAccou
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Version,
+		&i.AccountType,
 	)
 	return i, err
 }
@@ -358,7 +391,9 @@ SELECT
     balance_change,
     operation,
     created_at,
-    created_by
+    created_by,
+    sequence_number,
+    chain_hash
 FROM core.account_balance_history
 WHERE account_id = $1
     AND created_at BETWEEN $2 AND $3
@@ -399,6 +434,8 @@ func (q *Queries) GetBalanceHistoryByDateRange(ctx context.Context, arg GetBalan
 			&i.Operation,
 			&i.CreatedAt,
 			&i.CreatedBy,
+			&i.SequenceNumber,
+			&i.ChainHash,
 		); err != nil {
 			return nil, err
 		}
@@ -420,7 +457,9 @@ SELECT
     balance_change,
     operation,
     created_at,
-    created_by
+    created_by,
+    sequence_number,
+    chain_hash
 FROM core.account_balance_history
 WHERE transaction_id = $1
 ORDER BY created_at ASC
@@ -445,6 +484,8 @@ func (q *Queries) GetBalanceHistoryByTransaction(ctx context.Context, transactio
 			&i.Operation,
 			&i.CreatedAt,
 			&i.CreatedBy,
+			&i.SequenceNumber,
+			&i.ChainHash,
 		); err != nil {
 			return nil, err
 		}
@@ -456,6 +497,21 @@ func (q *Queries) GetBalanceHistoryByTransaction(ctx context.Context, transactio
 	return items, nil
 }
 
+const getCompensatedAmount = `-- name: GetCompensatedAmount :one
+SELECT COALESCE(SUM(amount), 0)::DECIMAL AS compensated_amount
+FROM core.transactions
+WHERE transaction_type = 'credit'
+    AND status = 'completed'
+    AND metadata->>'original_transaction_id' = $1::TEXT
+`
+
+func (q *Queries) GetCompensatedAmount(ctx context.Context, originalTransactionID string) (pgtype.Numeric, error) {
+	row := q.db.QueryRow(ctx, getCompensatedAmount, originalTransactionID)
+	var compensated_amount pgtype.Numeric
+	err := row.Scan(&compensated_amount)
+	return compensated_amount, err
+}
+
 const getPendingTransactions = `-- name: GetPendingTransactions :many
 SELECT 
     id,
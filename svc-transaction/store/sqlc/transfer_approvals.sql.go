@@ -0,0 +1,77 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: transfer_approvals.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createTransferApproval = `-- name: CreateTransferApproval :execrows
+INSERT INTO core.transfer_approvals (
+    transfer_id,
+    workflow_id,
+    approved_by,
+    approved_at
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (transfer_id, approved_by) DO NOTHING
+`
+
+type CreateTransferApprovalParams struct {
+	TransferID string             `json:"transfer_id"`
+	WorkflowID string             `json:"workflow_id"`
+	ApprovedBy string             `json:"approved_by"`
+	ApprovedAt pgtype.Timestamptz `json:"approved_at"`
+}
+
+func (q *Queries) CreateTransferApproval(ctx context.Context, arg CreateTransferApprovalParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createTransferApproval,
+		arg.TransferID,
+		arg.WorkflowID,
+		arg.ApprovedBy,
+		arg.ApprovedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listTransferApprovals = `-- name: ListTransferApprovals :many
+SELECT id, transfer_id, workflow_id, approved_by, approved_at, recorded_at FROM core.transfer_approvals
+WHERE transfer_id = $1
+ORDER BY approved_at, approved_by
+`
+
+func (q *Queries) ListTransferApprovals(ctx context.Context, transferID string) ([]CoreTransferApproval, error) {
+	rows, err := q.db.Query(ctx, listTransferApprovals, transferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreTransferApproval{}
+	for rows.Next() {
+		var i CoreTransferApproval
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransferID,
+			&i.WorkflowID,
+			&i.ApprovedBy,
+			&i.ApprovedAt,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,118 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: transfer_events.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createTransferEvent = `-- name: CreateTransferEvent :execrows
+INSERT INTO core.transfer_events (
+    transfer_id,
+    workflow_id,
+    run_id,
+    sequence_number,
+    event_type,
+    status,
+    step,
+    from_account,
+    to_account,
+    amount,
+    currency,
+    description,
+    error_message,
+    occurred_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
+)
+ON CONFLICT (workflow_id, run_id, sequence_number) DO NOTHING
+`
+
+type CreateTransferEventParams struct {
+	TransferID     string             `json:"transfer_id"`
+	WorkflowID     string             `json:"workflow_id"`
+	RunID          string             `json:"run_id"`
+	SequenceNumber int32              `json:"sequence_number"`
+	EventType      string             `json:"event_type"`
+	Status         string             `json:"status"`
+	Step           pgtype.Text        `json:"step"`
+	FromAccount    string             `json:"from_account"`
+	ToAccount      string             `json:"to_account"`
+	Amount         pgtype.Numeric     `json:"amount"`
+	Currency       string             `json:"currency"`
+	Description    string             `json:"description"`
+	ErrorMessage   pgtype.Text        `json:"error_message"`
+	OccurredAt     pgtype.Timestamptz `json:"occurred_at"`
+}
+
+func (q *Queries) CreateTransferEvent(ctx context.Context, arg CreateTransferEventParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createTransferEvent,
+		arg.TransferID,
+		arg.WorkflowID,
+		arg.RunID,
+		arg.SequenceNumber,
+		arg.EventType,
+		arg.Status,
+		arg.Step,
+		arg.FromAccount,
+		arg.ToAccount,
+		arg.Amount,
+		arg.Currency,
+		arg.Description,
+		arg.ErrorMessage,
+		arg.OccurredAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listTransferEvents = `-- name: ListTransferEvents :many
+SELECT id, position, transfer_id, workflow_id, run_id, sequence_number, event_type, status, step, from_account, to_account, amount, currency, description, error_message, occurred_at, recorded_at FROM core.transfer_events
+WHERE transfer_id = $1
+ORDER BY occurred_at, sequence_number
+`
+
+func (q *Queries) ListTransferEvents(ctx context.Context, transferID string) ([]CoreTransferEvent, error) {
+	rows, err := q.db.Query(ctx, listTransferEvents, transferID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreTransferEvent{}
+	for rows.Next() {
+		var i CoreTransferEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Position,
+			&i.TransferID,
+			&i.WorkflowID,
+			&i.RunID,
+			&i.SequenceNumber,
+			&i.EventType,
+			&i.Status,
+			&i.Step,
+			&i.FromAccount,
+			&i.ToAccount,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.ErrorMessage,
+			&i.OccurredAt,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,280 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: transfer_read_model.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getProjectionCheckpoint = `-- name: GetProjectionCheckpoint :one
+SELECT position FROM core.projection_checkpoints
+WHERE projection = $1
+`
+
+func (q *Queries) GetProjectionCheckpoint(ctx context.Context, projection string) (int64, error) {
+	row := q.db.QueryRow(ctx, getProjectionCheckpoint, projection)
+	var position int64
+	err := row.Scan(&position)
+	return position, err
+}
+
+const getTransferReadModel = `-- name: GetTransferReadModel :one
+SELECT transfer_id, workflow_id, run_id, status, last_event_type, last_step, from_account, to_account, amount, currency, description, error_message, started_at, finished_at, updated_at, event_position, projected_at FROM core.transfer_read_model
+WHERE transfer_id = $1
+`
+
+func (q *Queries) GetTransferReadModel(ctx context.Context, transferID string) (CoreTransferReadModel, error) {
+	row := q.db.QueryRow(ctx, getTransferReadModel, transferID)
+	var i CoreTransferReadModel
+	err := row.Scan(
+		&i.TransferID,
+		&i.WorkflowID,
+		&i.RunID,
+		&i.Status,
+		&i.LastEventType,
+		&i.LastStep,
+		&i.FromAccount,
+		&i.ToAccount,
+		&i.Amount,
+		&i.Currency,
+		&i.Description,
+		&i.ErrorMessage,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.UpdatedAt,
+		&i.EventPosition,
+		&i.ProjectedAt,
+	)
+	return i, err
+}
+
+const listTransferEventsAfter = `-- name: ListTransferEventsAfter :many
+SELECT id, position, transfer_id, workflow_id, run_id, sequence_number, event_type, status, step, from_account, to_account, amount, currency, description, error_message, occurred_at, recorded_at FROM core.transfer_events
+WHERE position > $1
+AND recorded_at < $2
+ORDER BY position
+LIMIT $3
+`
+
+type ListTransferEventsAfterParams struct {
+	AfterPosition  int64              `json:"after_position"`
+	RecordedBefore pgtype.Timestamptz `json:"recorded_before"`
+	RowLimit       int32              `json:"row_limit"`
+}
+
+func (q *Queries) ListTransferEventsAfter(ctx context.Context, arg ListTransferEventsAfterParams) ([]CoreTransferEvent, error) {
+	rows, err := q.db.Query(ctx, listTransferEventsAfter, arg.AfterPosition, arg.RecordedBefore, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreTransferEvent{}
+	for rows.Next() {
+		var i CoreTransferEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Position,
+			&i.TransferID,
+			&i.WorkflowID,
+			&i.RunID,
+			&i.SequenceNumber,
+			&i.EventType,
+			&i.Status,
+			&i.Step,
+			&i.FromAccount,
+			&i.ToAccount,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.ErrorMessage,
+			&i.OccurredAt,
+			&i.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransferReadModels = `-- name: ListTransferReadModels :many
+SELECT transfer_id, workflow_id, run_id, status, last_event_type, last_step, from_account, to_account, amount, currency, description, error_message, started_at, finished_at, updated_at, event_position, projected_at FROM core.transfer_read_model
+WHERE ($1::TEXT IS NULL OR from_account = $1 OR to_account = $1)
+AND ($2::TEXT IS NULL OR status = $2)
+AND ($3::TIMESTAMPTZ IS NULL OR started_at >= $3)
+AND ($4::TIMESTAMPTZ IS NULL OR started_at < $4)
+AND ($5::TIMESTAMPTZ IS NULL OR (started_at, transfer_id) < ($5, $6::TEXT))
+ORDER BY started_at DESC, transfer_id DESC
+LIMIT $7
+`
+
+type ListTransferReadModelsParams struct {
+	Account          pgtype.Text        `json:"account"`
+	Status           pgtype.Text        `json:"status"`
+	StartedFrom      pgtype.Timestamptz `json:"started_from"`
+	StartedTo        pgtype.Timestamptz `json:"started_to"`
+	CursorStartedAt  pgtype.Timestamptz `json:"cursor_started_at"`
+	CursorTransferID pgtype.Text        `json:"cursor_transfer_id"`
+	RowLimit         int32              `json:"row_limit"`
+}
+
+func (q *Queries) ListTransferReadModels(ctx context.Context, arg ListTransferReadModelsParams) ([]CoreTransferReadModel, error) {
+	rows, err := q.db.Query(ctx, listTransferReadModels,
+		arg.Account,
+		arg.Status,
+		arg.StartedFrom,
+		arg.StartedTo,
+		arg.CursorStartedAt,
+		arg.CursorTransferID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CoreTransferReadModel{}
+	for rows.Next() {
+		var i CoreTransferReadModel
+		if err := rows.Scan(
+			&i.TransferID,
+			&i.WorkflowID,
+			&i.RunID,
+			&i.Status,
+			&i.LastEventType,
+			&i.LastStep,
+			&i.FromAccount,
+			&i.ToAccount,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.ErrorMessage,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.UpdatedAt,
+			&i.EventPosition,
+			&i.ProjectedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertProjectionCheckpoint = `-- name: UpsertProjectionCheckpoint :exec
+INSERT INTO core.projection_checkpoints (
+    projection,
+    position
+) VALUES (
+    $1, $2
+)
+ON CONFLICT (projection) DO UPDATE SET
+    position = EXCLUDED.position,
+    updated_at = NOW()
+`
+
+type UpsertProjectionCheckpointParams struct {
+	Projection string `json:"projection"`
+	Position   int64  `json:"position"`
+}
+
+func (q *Queries) UpsertProjectionCheckpoint(ctx context.Context, arg UpsertProjectionCheckpointParams) error {
+	_, err := q.db.Exec(ctx, upsertProjectionCheckpoint, arg.Projection, arg.Position)
+	return err
+}
+
+const upsertTransferReadModel = `-- name: UpsertTransferReadModel :execrows
+INSERT INTO core.transfer_read_model (
+    transfer_id,
+    workflow_id,
+    run_id,
+    status,
+    last_event_type,
+    last_step,
+    from_account,
+    to_account,
+    amount,
+    currency,
+    description,
+    error_message,
+    started_at,
+    finished_at,
+    updated_at,
+    event_position
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16
+)
+ON CONFLICT (transfer_id) DO UPDATE SET
+    workflow_id = EXCLUDED.workflow_id,
+    run_id = EXCLUDED.run_id,
+    status = EXCLUDED.status,
+    last_event_type = EXCLUDED.last_event_type,
+    last_step = EXCLUDED.last_step,
+    from_account = EXCLUDED.from_account,
+    to_account = EXCLUDED.to_account,
+    amount = EXCLUDED.amount,
+    currency = EXCLUDED.currency,
+    description = EXCLUDED.description,
+    error_message = EXCLUDED.error_message,
+    started_at = LEAST(core.transfer_read_model.started_at, EXCLUDED.started_at),
+    finished_at = EXCLUDED.finished_at,
+    updated_at = EXCLUDED.updated_at,
+    event_position = EXCLUDED.event_position,
+    projected_at = NOW()
+WHERE core.transfer_read_model.event_position < EXCLUDED.event_position
+`
+
+type UpsertTransferReadModelParams struct {
+	TransferID    string             `json:"transfer_id"`
+	WorkflowID    string             `json:"workflow_id"`
+	RunID         string             `json:"run_id"`
+	Status        string             `json:"status"`
+	LastEventType string             `json:"last_event_type"`
+	LastStep      pgtype.Text        `json:"last_step"`
+	FromAccount   string             `json:"from_account"`
+	ToAccount     string             `json:"to_account"`
+	Amount        pgtype.Numeric     `json:"amount"`
+	Currency      string             `json:"currency"`
+	Description   string             `json:"description"`
+	ErrorMessage  pgtype.Text        `json:"error_message"`
+	StartedAt     pgtype.Timestamptz `json:"started_at"`
+	FinishedAt    pgtype.Timestamptz `json:"finished_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	EventPosition int64              `json:"event_position"`
+}
+
+func (q *Queries) UpsertTransferReadModel(ctx context.Context, arg UpsertTransferReadModelParams) (int64, error) {
+	result, err := q.db.Exec(ctx, upsertTransferReadModel,
+		arg.TransferID,
+		arg.WorkflowID,
+		arg.RunID,
+		arg.Status,
+		arg.LastEventType,
+		arg.LastStep,
+		arg.FromAccount,
+		arg.ToAccount,
+		arg.Amount,
+		arg.Currency,
+		arg.Description,
+		arg.ErrorMessage,
+		arg.StartedAt,
+		arg.FinishedAt,
+		arg.UpdatedAt,
+		arg.EventPosition,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
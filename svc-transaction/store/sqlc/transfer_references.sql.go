@@ -0,0 +1,118 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: transfer_references.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createTransferReference = `-- name: CreateTransferReference :one
+INSERT INTO core.transfer_references (
+    reference,
+    transfer_id,
+    branch_code,
+    business_date,
+    sequence_number
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING id, reference, transfer_id, branch_code, business_date, sequence_number, created_at
+`
+
+type CreateTransferReferenceParams struct {
+	Reference      string      `json:"reference"`
+	TransferID     string      `json:"transfer_id"`
+	BranchCode     string      `json:"branch_code"`
+	BusinessDate   pgtype.Date `json:"business_date"`
+	SequenceNumber int32       `json:"sequence_number"`
+}
+
+func (q *Queries) CreateTransferReference(ctx context.Context, arg CreateTransferReferenceParams) (CoreTransferReference, error) {
+	row := q.db.QueryRow(ctx, createTransferReference,
+		arg.Reference,
+		arg.TransferID,
+		arg.BranchCode,
+		arg.BusinessDate,
+		arg.SequenceNumber,
+	)
+	var i CoreTransferReference
+	err := row.Scan(
+		&i.ID,
+		&i.Reference,
+		&i.TransferID,
+		&i.BranchCode,
+		&i.BusinessDate,
+		&i.SequenceNumber,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferReference = `-- name: GetTransferReference :one
+SELECT id, reference, transfer_id, branch_code, business_date, sequence_number, created_at FROM core.transfer_references
+WHERE reference = $1
+`
+
+func (q *Queries) GetTransferReference(ctx context.Context, reference string) (CoreTransferReference, error) {
+	row := q.db.QueryRow(ctx, getTransferReference, reference)
+	var i CoreTransferReference
+	err := row.Scan(
+		&i.ID,
+		&i.Reference,
+		&i.TransferID,
+		&i.BranchCode,
+		&i.BusinessDate,
+		&i.SequenceNumber,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTransferReferenceByTransferID = `-- name: GetTransferReferenceByTransferID :one
+SELECT id, reference, transfer_id, branch_code, business_date, sequence_number, created_at FROM core.transfer_references
+WHERE transfer_id = $1
+`
+
+func (q *Queries) GetTransferReferenceByTransferID(ctx context.Context, transferID string) (CoreTransferReference, error) {
+	row := q.db.QueryRow(ctx, getTransferReferenceByTransferID, transferID)
+	var i CoreTransferReference
+	err := row.Scan(
+		&i.ID,
+		&i.Reference,
+		&i.TransferID,
+		&i.BranchCode,
+		&i.BusinessDate,
+		&i.SequenceNumber,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const nextTransferReferenceSequence = `-- name: NextTransferReferenceSequence :one
+INSERT INTO core.transfer_reference_sequences (
+    branch_code,
+    business_date,
+    last_sequence
+) VALUES (
+    $1, $2, 1
+)
+ON CONFLICT (branch_code, business_date)
+DO UPDATE SET last_sequence = core.transfer_reference_sequences.last_sequence + 1
+RETURNING last_sequence
+`
+
+type NextTransferReferenceSequenceParams struct {
+	BranchCode   string      `json:"branch_code"`
+	BusinessDate pgtype.Date `json:"business_date"`
+}
+
+func (q *Queries) NextTransferReferenceSequence(ctx context.Context, arg NextTransferReferenceSequenceParams) (int32, error) {
+	row := q.db.QueryRow(ctx, nextTransferReferenceSequence, arg.BranchCode, arg.BusinessDate)
+	var last_sequence int32
+	err := row.Scan(&last_sequence)
+	return last_sequence, err
+}
@@ -0,0 +1,126 @@
+package sqlite
+
+// queries holds the SQLite text of the queries pgsqlite.Translate cannot rewrite, by sqlc query name. Each keeps the
+// parameters and result columns of the generated query.
+var queries = map[string]string{
+	// Transactions hold the lock of the whole database, so there is no account lock to take
+	"AcquireAccountLock": `SELECT $1`,
+
+	// Balances are floats in SQLite; rounding to the scale of DECIMAL(19,4) keeps repeated changes exact
+	"AdjustAccountBalance": `UPDATE accounts
+SET
+    balance = ROUND(balance + $1, 4),
+    version = version + 1
+WHERE id = $2
+RETURNING id, balance, version`,
+
+	// || of JSONB sets the key, as json_set does
+	"CancelTransaction": `UPDATE transactions
+SET
+    status = 'cancelled',
+    updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'),
+    metadata = json_set(COALESCE(metadata, '{}'), '$.cancellation_reason', $2)
+WHERE id = $1 AND status = 'pending'
+RETURNING id, status, updated_at`,
+
+	"FailTransaction": `UPDATE transactions
+SET
+    status = 'failed',
+    updated_at = strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'),
+    metadata = json_set(COALESCE(metadata, '{}'), '$.failure_reason', $2)
+WHERE id = $1 AND status = 'pending'
+RETURNING id, status, updated_at`,
+
+	// The account erasure keeps the metadata keys of a JSON array parameter and removes the others
+	"AnonymizeAccountTransactions": `UPDATE transactions
+SET
+    description = NULL,
+    metadata = ` + keptMetadata("metadata", "$1") + `
+WHERE account_id = $2
+    AND (description IS NOT NULL OR ` + hasRemovedMetadata("metadata", "$1") + `)`,
+
+	"AnonymizeAccountTransfers": `UPDATE transfers
+SET
+    description = NULL,
+    metadata = ` + keptMetadata("metadata", "$1") + `
+WHERE (from_account_id = $2 OR to_account_id = $2)
+    AND (description IS NOT NULL OR ` + hasRemovedMetadata("metadata", "$1") + `)`,
+
+	"AnonymizeAccountCompensationAudits": `UPDATE compensation_audit_trail
+SET
+    compensation_reason = $1,
+    metadata = ` + keptMetadata("metadata", "$2") + `
+WHERE (original_transaction_id IN (SELECT id FROM transactions WHERE account_id = $3)
+        OR compensation_transaction_id IN (SELECT id FROM transactions WHERE account_id = $3))
+    AND (compensation_reason <> $1 OR ` + hasRemovedMetadata("metadata", "$2") + `)`,
+
+	"CountAccountPersonalData": `SELECT
+    (SELECT COUNT(*) FROM accounts a
+        WHERE a.id = $1 AND a.account_name <> $2) AS accounts,
+    (SELECT COUNT(*) FROM transactions t
+        WHERE t.account_id = $1
+            AND (t.description IS NOT NULL OR ` + hasRemovedMetadata("t.metadata", "$3") + `)) AS transactions,
+    (SELECT COUNT(*) FROM transfers tr
+        WHERE (tr.from_account_id = $1 OR tr.to_account_id = $1)
+            AND (tr.description IS NOT NULL OR ` + hasRemovedMetadata("tr.metadata", "$3") + `)) AS transfers,
+    (SELECT COUNT(*) FROM compensation_audit_trail c
+        WHERE (c.original_transaction_id IN (SELECT id FROM transactions WHERE account_id = $1)
+                OR c.compensation_transaction_id IN (SELECT id FROM transactions WHERE account_id = $1))
+            AND (c.compensation_reason <> $2 OR ` + hasRemovedMetadata("c.metadata", "$3") + `)) AS compensation_audits`,
+
+	// LATERAL joins are correlated subqueries; WHERE TRUE lets SQLite parse ON CONFLICT after a SELECT with a join
+	"UpsertEODBalances": `INSERT INTO eod_balances (
+    account_id,
+    business_date,
+    currency,
+    closing_balance,
+    entry_count,
+    last_sequence_number,
+    computed_at
+)
+SELECT
+    a.id,
+    $1,
+    a.currency,
+    COALESCE((
+        SELECT h.new_balance FROM account_balance_history h
+        WHERE h.account_id = a.id AND h.created_at < $2
+        ORDER BY h.sequence_number DESC
+        LIMIT 1
+    ), 0),
+    (
+        SELECT COUNT(*) FROM account_balance_history h
+        WHERE h.account_id = a.id AND h.created_at >= $3 AND h.created_at < $2
+    ),
+    COALESCE((
+        SELECT h.sequence_number FROM account_balance_history h
+        WHERE h.account_id = a.id AND h.created_at < $2
+        ORDER BY h.sequence_number DESC
+        LIMIT 1
+    ), 0),
+    strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')
+FROM accounts a
+WHERE a.created_at < $2
+ON CONFLICT (account_id, business_date) DO UPDATE SET
+    currency = EXCLUDED.currency,
+    closing_balance = EXCLUDED.closing_balance,
+    entry_count = EXCLUDED.entry_count,
+    last_sequence_number = EXCLUDED.last_sequence_number,
+    computed_at = EXCLUDED.computed_at`,
+}
+
+// keptMetadata returns the JSON object column with only the keys listed in the JSON array parameter, the SQLite of
+// metadata - ARRAY(SELECT k FROM jsonb_object_keys(metadata) AS k WHERE k <> ALL(kept)). json_each gives booleans as
+// integers, so they are turned back into JSON.
+func keptMetadata(column, kept string) string {
+	return `CASE WHEN ` + column + ` IS NULL THEN NULL ELSE (
+        SELECT json_group_object(m.key, CASE m.type WHEN 'true' THEN json('true') WHEN 'false' THEN json('false') ELSE m.value END)
+        FROM json_each(` + column + `) m
+        WHERE m.key IN (SELECT value FROM json_each(` + kept + `))
+    ) END`
+}
+
+// hasRemovedMetadata returns whether the JSON object column has a key keptMetadata removes
+func hasRemovedMetadata(column, kept string) string {
+	return `EXISTS (SELECT 1 FROM json_each(` + column + `) m WHERE m.key NOT IN (SELECT value FROM json_each(` + kept + `)))`
+}
@@ -0,0 +1,56 @@
+// Package sqlite implements store.IStore on a SQLite file, for running the service without Postgres. It runs the
+// sqlc-generated queries through pgsqlite, which rewrites them to SQLite, except those in queries.go written for
+// SQLite by hand.
+//
+// svc-balance opens the same file with its own queries, so both services see the same accounts and balances, as they
+// do on Postgres.
+package sqlite
+
+import (
+	"context"
+
+	"svc-transaction/store"
+	"svc-transaction/store/sqlc"
+
+	"pgsqlite"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Store struct {
+	*sqlc.Queries
+
+	logger *logrus.Logger
+
+	db *pgsqlite.DB
+}
+
+// NewStore opens the SQLite database at path, creating it when it does not exist; demoData loads the demo accounts
+// into a new database
+func NewStore(ctx context.Context, logger *logrus.Logger, path string, demoData bool) (store.IStore, error) {
+	db, err := pgsqlite.Open(ctx, path, pgsqlite.Options{DemoData: demoData, Queries: queries})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{
+		Queries: sqlc.New(db),
+
+		logger: logger,
+
+		db: db,
+	}, nil
+}
+
+// WithTx runs fn in a transaction; a panic in fn rolls it back and is re-raised
+func (store *Store) WithTx(ctx context.Context, fn func(sqlc.Querier) error) error {
+	return store.db.WithTx(ctx, func(tx *pgsqlite.Tx) error {
+		return fn(sqlc.New(tx))
+	})
+}
+
+// WithRetryTx runs fn in a transaction. Transactions take the database lock when they begin and run one at a time,
+// so they never fail on a serialization conflict and are not retried.
+func (store *Store) WithRetryTx(ctx context.Context, fn func(sqlc.Querier) error) error {
+	return store.WithTx(ctx, fn)
+}
@@ -0,0 +1,306 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"svc-transaction/service"
+	"svc-transaction/store/sqlc"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	johnDoe    = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	janeSmith  = uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+	lowBalance = uuid.MustParse("550e8400-e29b-41d4-a716-446655440006")
+	suspended  = uuid.MustParse("550e8400-e29b-41d4-a716-446655440007")
+)
+
+func newTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	return logger
+}
+
+// newTestStore opens a store on a new file with the demo data
+func newTestStore(t *testing.T) (*Store, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "temporal-flow-demo.db")
+
+	store, err := NewStore(context.Background(), newTestLogger(), path, true)
+	require.NoError(t, err)
+
+	return store.(*Store), path
+}
+
+func balance(t *testing.T, q sqlc.Querier, accountID uuid.UUID) decimal.Decimal {
+	t.Helper()
+
+	account, err := q.GetAccountByID(context.Background(), pgtype.UUID{Bytes: accountID, Valid: true})
+	require.NoError(t, err)
+
+	return decimal.NewFromBigInt(account.Balance.Int, account.Balance.Exp)
+}
+
+// TestStatements prepares every statement of the sqlc-generated code on SQLite, the counterpart of TestSchemaDrift:
+// a query the dialect rewrite turns into invalid SQLite, or one referring to a column schema.sql lacks, fails here
+func TestStatements(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestStore(t)
+	statements := generatedStatements(t)
+
+	names := make([]string, 0, len(statements))
+	for name := range statements {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if err := store.db.Prepare(context.Background(), statements[name]); err != nil {
+			t.Errorf("prepare %s: %v", name, err)
+		}
+	}
+
+	// Every hand-written query replaces a generated one
+	for name := range queries {
+		assert.Contains(t, statements, name)
+	}
+}
+
+// TestQueries runs every query with zero arguments on the demo data. The query may find no row or violate a
+// constraint, but it must bind its arguments and scan its columns.
+func TestQueries(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestStore(t)
+
+	// The unused GetCompensationStats scans AVG into a float64, which fails on Postgres too when no audit is recent
+	skipped := []string{"GetCompensationStats"}
+
+	querier := reflect.TypeOf((*sqlc.Querier)(nil)).Elem()
+	value := reflect.ValueOf(store)
+	for i := range querier.NumMethod() {
+		method := querier.Method(i)
+		if slices.Contains(skipped, method.Name) {
+			continue
+		}
+
+		args := []reflect.Value{reflect.ValueOf(context.Background())}
+		for j := 1; j < method.Type.NumIn(); j++ {
+			args = append(args, reflect.Zero(method.Type.In(j)))
+		}
+
+		results := value.MethodByName(method.Name).Call(args)
+		err, _ := results[len(results)-1].Interface().(error)
+
+		var pgErr *pgconn.PgError
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) && !errors.As(err, &pgErr) {
+			t.Errorf("%s: %v", method.Name, err)
+		}
+	}
+}
+
+// generatedStatements reads the queries of the sqlc-generated files by their name
+func generatedStatements(t *testing.T) map[string]string {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join("..", "sqlc", "*.sql.go"))
+	require.NoError(t, err)
+
+	statements := map[string]string{}
+	fileSet := token.NewFileSet()
+	for _, path := range paths {
+		file, err := parser.ParseFile(fileSet, path, nil, 0)
+		require.NoError(t, err)
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			literal, ok := node.(*ast.BasicLit)
+			if !ok || literal.Kind != token.STRING {
+				return true
+			}
+
+			sql, err := strconv.Unquote(literal.Value)
+			if err != nil || !strings.HasPrefix(sql, "-- name: ") {
+				return true
+			}
+
+			statements[strings.Fields(sql)[2]] = sql
+
+			return true
+		})
+	}
+
+	require.NotEmpty(t, statements)
+
+	return statements
+}
+
+func TestInternalTransfer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := newTestLogger()
+	store, path := newTestStore(t)
+	transactionService := service.NewService(logger, store)
+
+	params := service.InternalTransferParams{
+		TransferID:    uuid.NewString(),
+		FromAccountID: johnDoe,
+		ToAccountID:   janeSmith,
+		Amount:        decimal.RequireFromString("100.25"),
+		Currency:      "USD",
+	}
+	result, err := transactionService.InternalTransfer(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", result.Status)
+	assert.True(t, decimal.RequireFromString("4899.75").Equal(balance(t, store, johnDoe)))
+	assert.True(t, decimal.RequireFromString("10100.25").Equal(balance(t, store, janeSmith)))
+
+	// Another process opening the file sees the transfer, and retrying it there does not move funds again
+	reopened, err := NewStore(ctx, logger, path, true)
+	require.NoError(t, err)
+	reopenedService := service.NewService(logger, reopened)
+
+	retried, err := reopenedService.InternalTransfer(ctx, params)
+	require.NoError(t, err)
+	assert.Equal(t, result.DebitTransactionID, retried.DebitTransactionID)
+	assert.True(t, decimal.RequireFromString("4899.75").Equal(balance(t, reopened, johnDoe)))
+
+	// The seeded history and the entries of the transfer, chained by trigger_balance_history_chain, verify against
+	// service.BalanceHistoryChainHash
+	for _, accountID := range []uuid.UUID{johnDoe, janeSmith} {
+		report, err := reopenedService.GetReconciliationReport(ctx, accountID)
+		require.NoError(t, err)
+		assert.Equal(t, 4, report.EntriesChecked)
+		assert.Empty(t, report.Discrepancies)
+	}
+}
+
+func TestInternalTransferInsufficientFunds(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := newTestLogger()
+	store, _ := newTestStore(t)
+	transactionService := service.NewService(logger, store)
+
+	_, err := transactionService.InternalTransfer(ctx, service.InternalTransferParams{
+		TransferID:    uuid.NewString(),
+		FromAccountID: lowBalance,
+		ToAccountID:   johnDoe,
+		Amount:        decimal.NewFromInt(500),
+		Currency:      "USD",
+	})
+	require.Error(t, err)
+
+	assert.True(t, decimal.NewFromInt(100).Equal(balance(t, store, lowBalance)))
+	assert.True(t, decimal.NewFromInt(5000).Equal(balance(t, store, johnDoe)))
+}
+
+func TestWithoutDemoData(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, err := NewStore(ctx, newTestLogger(), filepath.Join(t.TempDir(), "temporal-flow-demo.db"), false)
+	require.NoError(t, err)
+
+	_, err = store.GetAccountByID(ctx, pgtype.UUID{Bytes: johnDoe, Valid: true})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// The reference data is loaded whatever the profile
+	rules, err := store.ListBusinessRules(ctx)
+	require.NoError(t, err)
+	assert.Len(t, rules, 2)
+}
+
+func TestWithTxRollback(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, _ := newTestStore(t)
+	errAbort := errors.New("abort")
+
+	var transactionID pgtype.UUID
+	err := store.WithTx(ctx, func(q sqlc.Querier) error {
+		row, err := q.CreateTransaction(ctx, sqlc.CreateTransactionParams{
+			AccountID:       pgtype.UUID{Bytes: johnDoe, Valid: true},
+			TransactionType: sqlc.CoreTransactionTypeDebit,
+			Amount:          pgtype.Numeric{Int: decimal.NewFromInt(50).Coefficient(), Valid: true},
+			Currency:        sqlc.CoreCurrencyCodeUSD,
+		})
+		require.NoError(t, err)
+		transactionID = row.ID
+
+		_, err = q.AdjustAccountBalance(ctx, sqlc.AdjustAccountBalanceParams{
+			ID:            pgtype.UUID{Bytes: johnDoe, Valid: true},
+			BalanceChange: pgtype.Numeric{Int: decimal.NewFromInt(-50).Coefficient(), Valid: true},
+		})
+		require.NoError(t, err)
+		assert.True(t, decimal.NewFromInt(4950).Equal(balance(t, q, johnDoe)))
+
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	assert.True(t, decimal.NewFromInt(5000).Equal(balance(t, store, johnDoe)))
+	_, err = store.GetTransactionByID(ctx, transactionID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestConstraints(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, _ := newTestStore(t)
+
+	create := func(accountID uuid.UUID, idempotencyKey string) error {
+		_, err := store.CreateTransaction(ctx, sqlc.CreateTransactionParams{
+			AccountID:       pgtype.UUID{Bytes: accountID, Valid: true},
+			TransactionType: sqlc.CoreTransactionTypeCredit,
+			Amount:          pgtype.Numeric{Int: decimal.NewFromInt(10).Coefficient(), Valid: true},
+			Currency:        sqlc.CoreCurrencyCodeUSD,
+			IdempotencyKey:  pgtype.Text{String: idempotencyKey, Valid: idempotencyKey != ""},
+		})
+
+		return err
+	}
+
+	var pgErr *pgconn.PgError
+
+	require.NoError(t, create(johnDoe, "key-1"))
+	require.ErrorAs(t, create(johnDoe, "key-1"), &pgErr)
+	assert.Equal(t, "23505", pgErr.Code)
+	assert.Equal(t, "transactions_idempotency_key_key", pgErr.ConstraintName)
+
+	// trigger_transactions_validation refuses transactions of inactive accounts
+	require.ErrorAs(t, create(suspended, ""), &pgErr)
+	assert.Equal(t, "P0001", pgErr.Code)
+
+	// The balance check of accounts keeps balances from going negative
+	_, err := store.AdjustAccountBalance(ctx, sqlc.AdjustAccountBalanceParams{
+		ID:            pgtype.UUID{Bytes: lowBalance, Valid: true},
+		BalanceChange: pgtype.Numeric{Int: decimal.NewFromInt(-101).Coefficient(), Valid: true},
+	})
+	require.ErrorAs(t, err, &pgErr)
+	assert.Equal(t, "23514", pgErr.Code)
+}
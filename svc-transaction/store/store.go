@@ -1,6 +1,7 @@
 package store
 
 import (
+	"context"
 	"sync"
 
 	"svc-transaction/store/sqlc"
@@ -11,6 +12,9 @@ import (
 
 type IStore interface {
 	sqlc.Querier
+
+	WithTx(ctx context.Context, fn func(sqlc.Querier) error) error
+	WithRetryTx(ctx context.Context, fn func(sqlc.Querier) error) error
 }
 
 type Store struct {
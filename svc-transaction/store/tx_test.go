@@ -0,0 +1,82 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"svc-transaction/store/migrations"
+	"svc-transaction/store/sqlc"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/sirupsen/logrus"
+)
+
+// TestWithTxPanic checks that a panic in the callback rolls the transaction back, releases its connection and
+// reaches the caller. Like TestSchemaDrift it needs TEST_POSTGRES_URL and skips when it is not set.
+func TestWithTxPanic(t *testing.T) {
+	connectionString := os.Getenv("TEST_POSTGRES_URL")
+	if connectionString == "" {
+		t.Skip("TEST_POSTGRES_URL is not set")
+	}
+
+	ctx := context.Background()
+	pool := newScratchDatabase(t, connectionString)
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	migrator, err := migrations.NewMigrator(logger, pool, "svc-transaction")
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	store := NewStore(logger, pool)
+
+	var accountID pgtype.UUID
+	errPanic := errors.New("callback panicked")
+
+	recovered := func() (recovered any) {
+		defer func() {
+			recovered = recover()
+		}()
+
+		_ = store.WithTx(ctx, func(q sqlc.Querier) error {
+			account, err := q.CreateAccount(ctx, sqlc.CreateAccountParams{
+				AccountNumber: "PANIC0000001",
+				AccountName:   "Panic Rollback",
+				Currency:      sqlc.CoreCurrencyCodeUSD,
+				AccountType:   sqlc.CoreAccountTypeChecking,
+			})
+			if err != nil {
+				t.Fatalf("CreateAccount() error = %v", err)
+			}
+			accountID = account.ID
+
+			panic(errPanic)
+		})
+
+		return nil
+	}()
+
+	if recovered != errPanic {
+		t.Fatalf("WithTx() recovered %v, want the panic of the callback re-raised", recovered)
+	}
+
+	if _, err := store.GetAccountByID(ctx, accountID); !errors.Is(err, pgx.ErrNoRows) {
+		t.Errorf("GetAccountByID() error = %v, want the account rolled back", err)
+	}
+	if acquired := pool.Stat().AcquiredConns(); acquired != 0 {
+		t.Errorf("%d connections still acquired after the panic, want 0", acquired)
+	}
+
+	// The store mutex is released, so the next transaction runs
+	if err := store.WithTx(ctx, func(q sqlc.Querier) error { return nil }); err != nil {
+		t.Errorf("WithTx() after the panic error = %v", err)
+	}
+}
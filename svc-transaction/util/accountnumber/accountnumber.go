@@ -0,0 +1,187 @@
+package accountnumber
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Checksum algorithms of an account number format
+const (
+	ChecksumNone  = "none"
+	ChecksumLuhn  = "luhn"  // Last digit is a Luhn check digit over the digits after the prefix
+	ChecksumMod97 = "mod97" // ISO 7064 MOD 97-10 as in IBANs: the characters after the prefix, letters as 10-35, leave 1
+)
+
+// ErrInvalidAccountNumber is matched by every FormatError
+var ErrInvalidAccountNumber = errors.New("invalid account number")
+
+// FormatError is returned for an account number that does not match the format of its tenant
+type FormatError struct {
+	Reason string // e.g. "must be 12 characters", to follow the name of the rejected field
+}
+
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrInvalidAccountNumber, e.Reason)
+}
+
+func (e *FormatError) Unwrap() error {
+	return ErrInvalidAccountNumber
+}
+
+// Checksum reports whether the characters of an account number after its prefix carry a valid check digit
+type Checksum func(body string) bool
+
+// checksums holds the algorithms formats can refer to; RegisterChecksum adds more
+var checksums = map[string]Checksum{
+	ChecksumLuhn:  Luhn,
+	ChecksumMod97: Mod97,
+}
+
+// RegisterChecksum makes a checksum algorithm available to formats under name. It is meant to be called from init
+// functions, before any Validator is created.
+func RegisterChecksum(name string, checksum Checksum) {
+	checksums[name] = checksum
+}
+
+// Format describes the account numbers of one tenant
+type Format struct {
+	Tenant    string // Empty for the default format, used by tenants without a format of their own
+	Prefix    string // e.g. ACC; empty for none
+	MinLength int    // Including the prefix; 0 for no minimum
+	MaxLength int    // Including the prefix; 0 for no maximum
+	Checksum  string // ChecksumNone (or empty), ChecksumLuhn, ChecksumMod97 or a registered name
+}
+
+// Validator checks account numbers against the format of their tenant. A nil Validator, like a tenant without a
+// format when there is no default format, accepts every account number.
+type Validator struct {
+	formats map[string]Format
+}
+
+// NewValidator creates a validator for formats, with at most one format per tenant
+func NewValidator(formats []Format) (*Validator, error) {
+	validator := &Validator{formats: make(map[string]Format, len(formats))}
+
+	for _, format := range formats {
+		if _, ok := validator.formats[format.Tenant]; ok {
+			return nil, fmt.Errorf("duplicate account number format for tenant %q", format.Tenant)
+		}
+
+		if format.Checksum == "" {
+			format.Checksum = ChecksumNone
+		}
+		if _, ok := checksums[format.Checksum]; !ok && format.Checksum != ChecksumNone {
+			return nil, fmt.Errorf("unknown account number checksum %q for tenant %q", format.Checksum, format.Tenant)
+		}
+
+		if format.MinLength < 0 || format.MaxLength < 0 || (format.MaxLength > 0 && format.MaxLength < format.MinLength) {
+			return nil, fmt.Errorf("invalid account number length %d-%d for tenant %q", format.MinLength, format.MaxLength, format.Tenant)
+		}
+		if format.MaxLength > 0 && format.MaxLength <= len(format.Prefix) {
+			return nil, fmt.Errorf("account number prefix %q leaves no room for digits for tenant %q", format.Prefix, format.Tenant)
+		}
+
+		validator.formats[format.Tenant] = format
+	}
+
+	return validator, nil
+}
+
+// Validate checks an account number against the format of tenant and returns a *FormatError if it does not match
+func (v *Validator) Validate(tenant, accountNumber string) error {
+	if v == nil {
+		return nil
+	}
+
+	format, ok := v.formats[tenant]
+	if !ok {
+		if format, ok = v.formats[""]; !ok {
+			return nil
+		}
+	}
+
+	switch {
+	case format.MinLength > 0 && len(accountNumber) < format.MinLength,
+		format.MaxLength > 0 && len(accountNumber) > format.MaxLength:
+		return &FormatError{Reason: lengthRule(format)}
+	case !strings.HasPrefix(accountNumber, format.Prefix):
+		return &FormatError{Reason: "must start with " + format.Prefix}
+	case accountNumber == format.Prefix:
+		return &FormatError{Reason: "must continue after the prefix " + format.Prefix}
+	}
+
+	// A mismatch is most likely a mistyped digit, caught before the number reaches a workflow
+	if checksum, ok := checksums[format.Checksum]; ok && !checksum(strings.TrimPrefix(accountNumber, format.Prefix)) {
+		return &FormatError{Reason: "has an invalid check digit"}
+	}
+
+	return nil
+}
+
+// lengthRule describes the allowed length of the account numbers of format
+func lengthRule(format Format) string {
+	switch {
+	case format.MinLength == format.MaxLength:
+		return fmt.Sprintf("must be %d characters", format.MinLength)
+	case format.MaxLength == 0:
+		return fmt.Sprintf("must be at least %d characters", format.MinLength)
+	case format.MinLength == 0:
+		return fmt.Sprintf("must be at most %d characters", format.MaxLength)
+	default:
+		return fmt.Sprintf("must be between %d and %d characters", format.MinLength, format.MaxLength)
+	}
+}
+
+// Luhn reports whether a string of digits ends with a valid Luhn check digit
+func Luhn(digits string) bool {
+	if len(digits) < 2 {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		digit := digits[len(digits)-1-i]
+		if digit < '0' || digit > '9' {
+			return false
+		}
+
+		value := int(digit - '0')
+		if i%2 == 1 {
+			value *= 2
+			if value > 9 {
+				value -= 9
+			}
+		}
+		sum += value
+	}
+
+	return sum%10 == 0
+}
+
+// Mod97 reports whether an alphanumeric string passes ISO 7064 MOD 97-10, reading letters as 10 (A) to 35 (Z)
+func Mod97(body string) bool {
+	if len(body) < 3 {
+		return false
+	}
+
+	var digits strings.Builder
+	for _, char := range strings.ToUpper(body) {
+		switch {
+		case char >= '0' && char <= '9':
+			digits.WriteRune(char)
+		case char >= 'A' && char <= 'Z':
+			fmt.Fprintf(&digits, "%d", char-'A'+10)
+		default:
+			return false
+		}
+	}
+
+	number, ok := new(big.Int).SetString(digits.String(), 10)
+	if !ok {
+		return false
+	}
+
+	return new(big.Int).Mod(number, big.NewInt(97)).Int64() == 1
+}
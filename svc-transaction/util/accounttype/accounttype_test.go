@@ -0,0 +1,49 @@
+package accounttype
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Type
+		wantErr bool
+	}{
+		{input: "", want: Checking},
+		{input: "checking", want: Checking},
+		{input: "savings", want: Savings},
+		{input: "brokerage", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Parse(%q) = %q, %v; want %q, error %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestCheckWithdrawal(t *testing.T) {
+	savings := PolicyOf(Savings)
+
+	if allowed, _ := savings.CheckWithdrawal(4); !allowed {
+		t.Error("CheckWithdrawal(4) rejected the fifth withdrawal of a savings account")
+	}
+	if allowed, message := savings.CheckWithdrawal(5); allowed {
+		t.Errorf("CheckWithdrawal(5) allowed a sixth withdrawal of a savings account: %s", message)
+	}
+	if allowed, _ := PolicyOf(Checking).CheckWithdrawal(1000); !allowed {
+		t.Error("CheckWithdrawal() limited a checking account")
+	}
+}
+
+func TestMonthStart(t *testing.T) {
+	// 00:30 on 1 April in UTC+2 is still March in UTC
+	at := time.Date(2026, 4, 1, 0, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+
+	if got, want := MonthStart(at), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("MonthStart() = %v, want %v", got, want)
+	}
+}
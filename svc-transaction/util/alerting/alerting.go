@@ -0,0 +1,267 @@
+// Package alerting evaluates threshold rules on in-process metrics and notifies webhook or Slack channels when they
+// start and stop firing, for deployments without Prometheus and Alertmanager.
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// Channel types
+const (
+	ChannelWebhook = "webhook" // JSON Alert posted to url
+	ChannelSlack   = "slack"   // Slack incoming-webhook message posted to url; without url it is only logged
+)
+
+// Alert states
+const (
+	StateFiring   = "firing"
+	StateResolved = "resolved"
+)
+
+// operators compares a metric value with the threshold of a rule
+var operators = map[string]func(value, threshold float64) bool{
+	">":  func(value, threshold float64) bool { return value > threshold },
+	">=": func(value, threshold float64) bool { return value >= threshold },
+	"<":  func(value, threshold float64) bool { return value < threshold },
+	"<=": func(value, threshold float64) bool { return value <= threshold },
+	"==": func(value, threshold float64) bool { return value == threshold },
+}
+
+// Channel is where alerts are delivered
+type Channel struct {
+	Name string `mapstructure:"name"`
+	Type string `mapstructure:"type"` // webhook or slack
+	URL  string `mapstructure:"url"`
+}
+
+// Rule fires when Metric compared with Threshold by Operator holds
+type Rule struct {
+	Name        string   `mapstructure:"name"`
+	Description string   `mapstructure:"description"`
+	Metric      string   `mapstructure:"metric"`
+	Operator    string   `mapstructure:"operator"` // >, >=, <, <= or ==
+	Threshold   float64  `mapstructure:"threshold"`
+	Severity    string   `mapstructure:"severity"`   // Free text passed on to the channels, e.g. warning or critical
+	TaskQueue   string   `mapstructure:"task_queue"` // Task queue of the workflow_task_backlog metric; empty is the service's own
+	Channels    []string `mapstructure:"channels"`   // Names of the channels notified; empty notifies every channel
+}
+
+// Rules is the content of the alerting rules file
+type Rules struct {
+	Channels []Channel `mapstructure:"channels"`
+	Rules    []Rule    `mapstructure:"rules"`
+}
+
+// Alert is one change of state of a rule, as delivered to its channels
+type Alert struct {
+	Rule        string    `json:"rule"`
+	State       string    `json:"state"` // firing or resolved
+	Severity    string    `json:"severity,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Metric      string    `json:"metric"`
+	Value       float64   `json:"value"`
+	Operator    string    `json:"operator"`
+	Threshold   float64   `json:"threshold"`
+	At          time.Time `json:"at"`
+}
+
+// Source reads the current value of a metric for rule
+type Source func(ctx context.Context, rule Rule) (float64, error)
+
+// LoadRules reads the rules file at path; YAML, JSON and TOML are recognised by the file extension
+func LoadRules(path string) (Rules, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+
+	if err := v.ReadInConfig(); err != nil {
+		return Rules{}, fmt.Errorf("failed to read alerting rules %s: %w", path, err)
+	}
+
+	var rules Rules
+	if err := v.Unmarshal(&rules); err != nil {
+		return Rules{}, fmt.Errorf("failed to parse alerting rules %s: %w", path, err)
+	}
+
+	return rules, nil
+}
+
+// Validate rejects rules that name an unknown metric, operator or channel, and duplicate names
+func (rules Rules) Validate(metrics []string) error {
+	channels := map[string]bool{}
+	for _, channel := range rules.Channels {
+		if channel.Name == "" {
+			return fmt.Errorf("alerting channel without a name")
+		}
+		if channels[channel.Name] {
+			return fmt.Errorf("duplicate alerting channel %q", channel.Name)
+		}
+		if channel.Type != ChannelWebhook && channel.Type != ChannelSlack {
+			return fmt.Errorf("alerting channel %q: unknown type %q, expected %s or %s", channel.Name, channel.Type, ChannelWebhook, ChannelSlack)
+		}
+		if channel.Type == ChannelWebhook && channel.URL == "" {
+			return fmt.Errorf("alerting channel %q: webhook channels need a url", channel.Name)
+		}
+		channels[channel.Name] = true
+	}
+
+	names := map[string]bool{}
+	for _, rule := range rules.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("alerting rule without a name")
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("duplicate alerting rule %q", rule.Name)
+		}
+		names[rule.Name] = true
+
+		if !slices.Contains(metrics, rule.Metric) {
+			return fmt.Errorf("alerting rule %q: unknown metric %q, expected one of %s", rule.Name, rule.Metric, strings.Join(metrics, ", "))
+		}
+		if _, ok := operators[rule.Operator]; !ok {
+			return fmt.Errorf("alerting rule %q: unknown operator %q", rule.Name, rule.Operator)
+		}
+		for _, channel := range rule.Channels {
+			if !channels[channel] {
+				return fmt.Errorf("alerting rule %q: unknown channel %q", rule.Name, channel)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Evaluator checks every rule against its metric and notifies the channels of a rule when it starts or stops firing.
+// A rule that keeps firing is not notified again.
+type Evaluator struct {
+	logger   *logrus.Logger
+	rules    Rules
+	sources  map[string]Source
+	notifier Notifier
+
+	mutex  sync.Mutex
+	firing map[string]bool // Rule name -> firing at the last evaluation
+}
+
+// NewEvaluator creates an evaluator of rules reading metrics from sources, after validating the rules against them
+func NewEvaluator(logger *logrus.Logger, rules Rules, sources map[string]Source, notifier Notifier) (*Evaluator, error) {
+	metrics := make([]string, 0, len(sources))
+	for metric := range sources {
+		metrics = append(metrics, metric)
+	}
+	slices.Sort(metrics)
+
+	if err := rules.Validate(metrics); err != nil {
+		return nil, err
+	}
+
+	return &Evaluator{
+		logger:   logger,
+		rules:    rules,
+		sources:  sources,
+		notifier: notifier,
+		firing:   map[string]bool{},
+	}, nil
+}
+
+// Run evaluates the rules every interval until ctx is done
+func (e *Evaluator) Run(ctx context.Context, interval time.Duration) {
+	e.logger.WithFields(logrus.Fields{
+		"rules":    len(e.rules.Rules),
+		"channels": len(e.rules.Channels),
+		"interval": interval.String(),
+	}).Info("Alerting evaluator started")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.Evaluate(ctx)
+		}
+	}
+}
+
+// Evaluate checks every rule once and returns the alerts it sent. A metric that cannot be read leaves the state of
+// its rule as it was.
+func (e *Evaluator) Evaluate(ctx context.Context) []Alert {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	var alerts []Alert
+	for _, rule := range e.rules.Rules {
+		logger := e.logger.WithFields(logrus.Fields{
+			"rule":   rule.Name,
+			"metric": rule.Metric,
+		})
+
+		value, err := e.sources[rule.Metric](ctx, rule)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to read alerting metric")
+			continue
+		}
+
+		firing := operators[rule.Operator](value, rule.Threshold)
+		if firing == e.firing[rule.Name] {
+			continue
+		}
+		e.firing[rule.Name] = firing
+
+		alert := Alert{
+			Rule:        rule.Name,
+			State:       StateResolved,
+			Severity:    rule.Severity,
+			Description: rule.Description,
+			Metric:      rule.Metric,
+			Value:       value,
+			Operator:    rule.Operator,
+			Threshold:   rule.Threshold,
+			At:          time.Now().UTC(),
+		}
+		if firing {
+			alert.State = StateFiring
+		}
+
+		logger.WithFields(logrus.Fields{
+			"state":     alert.State,
+			"value":     value,
+			"threshold": rule.Threshold,
+		}).Warn("Alert " + alert.State)
+
+		for _, channel := range e.channels(rule) {
+			if err := e.notifier.Notify(ctx, channel, alert); err != nil {
+				logger.WithError(err).WithField("channel", channel.Name).Error("Failed to deliver alert")
+			}
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	return alerts
+}
+
+// channels returns the channels rule notifies
+func (e *Evaluator) channels(rule Rule) []Channel {
+	if len(rule.Channels) == 0 {
+		return e.rules.Channels
+	}
+
+	channels := make([]Channel, 0, len(rule.Channels))
+	for _, channel := range e.rules.Channels {
+		if slices.Contains(rule.Channels, channel.Name) {
+			channels = append(channels, channel)
+		}
+	}
+
+	return channels
+}
@@ -0,0 +1,244 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotifier remembers the alerts delivered to every channel
+type recordingNotifier struct {
+	delivered map[string][]Alert
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, channel Channel, alert Alert) error {
+	n.delivered[channel.Name] = append(n.delivered[channel.Name], alert)
+
+	return nil
+}
+
+func quietLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	return logger
+}
+
+func TestLoadRules(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "alerts.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+channels:
+  - name: ops
+    type: webhook
+    url: http://receiver/alerts
+rules:
+  - name: backlog
+    metric: workflow_task_backlog
+    task_queue: transfer-task-queue
+    operator: ">"
+    threshold: 100
+    severity: critical
+    channels: [ops]
+`), 0o600))
+
+	rules, err := LoadRules(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, Rules{
+		Channels: []Channel{{Name: "ops", Type: ChannelWebhook, URL: "http://receiver/alerts"}},
+		Rules: []Rule{{
+			Name:      "backlog",
+			Metric:    "workflow_task_backlog",
+			TaskQueue: "transfer-task-queue",
+			Operator:  ">",
+			Threshold: 100,
+			Severity:  "critical",
+			Channels:  []string{"ops"},
+		}},
+	}, rules)
+
+	_, err = LoadRules(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestLoadRulesSample(t *testing.T) {
+	t.Parallel()
+
+	rules, err := LoadRules("../../alerts.sample.yaml")
+	require.NoError(t, err)
+
+	assert.NoError(t, rules.Validate([]string{
+		"compensation_rate",
+		"compensation_failure_ratio",
+		"failure_simulation_triggers",
+		"failure_simulation_tripped",
+		"workflow_task_backlog",
+	}))
+}
+
+func TestRulesValidate(t *testing.T) {
+	t.Parallel()
+
+	metrics := []string{"compensation_rate"}
+	webhook := Channel{Name: "ops", Type: ChannelWebhook, URL: "http://receiver"}
+	rule := Rule{Name: "rate", Metric: "compensation_rate", Operator: ">", Threshold: 1}
+
+	tests := []struct {
+		name    string
+		rules   Rules
+		wantErr string
+	}{
+		{name: "valid", rules: Rules{Channels: []Channel{webhook}, Rules: []Rule{rule}}},
+		{name: "slack_without_url", rules: Rules{Channels: []Channel{{Name: "chat", Type: ChannelSlack}}}},
+		{
+			name:    "unknown_channel_type",
+			rules:   Rules{Channels: []Channel{{Name: "mail", Type: "email"}}},
+			wantErr: `unknown type "email"`,
+		},
+		{
+			name:    "webhook_without_url",
+			rules:   Rules{Channels: []Channel{{Name: "ops", Type: ChannelWebhook}}},
+			wantErr: "need a url",
+		},
+		{
+			name:    "duplicate_channel",
+			rules:   Rules{Channels: []Channel{webhook, webhook}},
+			wantErr: `duplicate alerting channel "ops"`,
+		},
+		{
+			name:    "duplicate_rule",
+			rules:   Rules{Rules: []Rule{rule, rule}},
+			wantErr: `duplicate alerting rule "rate"`,
+		},
+		{
+			name:    "unknown_metric",
+			rules:   Rules{Rules: []Rule{{Name: "cpu", Metric: "cpu", Operator: ">"}}},
+			wantErr: `unknown metric "cpu"`,
+		},
+		{
+			name:    "unknown_operator",
+			rules:   Rules{Rules: []Rule{{Name: "rate", Metric: "compensation_rate", Operator: "!="}}},
+			wantErr: `unknown operator "!="`,
+		},
+		{
+			name:    "unknown_rule_channel",
+			rules:   Rules{Rules: []Rule{{Name: "rate", Metric: "compensation_rate", Operator: ">", Channels: []string{"pager"}}}},
+			wantErr: `unknown channel "pager"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.rules.Validate(metrics)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvaluatorFiresOnceAndResolves(t *testing.T) {
+	t.Parallel()
+
+	value := 0.0
+	readErr := error(nil)
+	sources := map[string]Source{
+		"compensation_rate": func(context.Context, Rule) (float64, error) { return value, readErr },
+	}
+
+	notifier := &recordingNotifier{delivered: map[string][]Alert{}}
+	evaluator, err := NewEvaluator(quietLogger(), Rules{
+		Channels: []Channel{
+			{Name: "ops", Type: ChannelWebhook, URL: "http://receiver"},
+			{Name: "chat", Type: ChannelSlack},
+		},
+		Rules: []Rule{
+			{Name: "rate", Metric: "compensation_rate", Operator: ">", Threshold: 10, Channels: []string{"chat"}},
+			{Name: "any_rate", Metric: "compensation_rate", Operator: ">=", Threshold: 1},
+		},
+	}, sources, notifier)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Nothing fires below both thresholds
+	assert.Empty(t, evaluator.Evaluate(ctx))
+
+	value = 5
+	alerts := evaluator.Evaluate(ctx)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "any_rate", alerts[0].Rule)
+	assert.Equal(t, StateFiring, alerts[0].State)
+
+	// A rule that keeps firing is not notified again
+	assert.Empty(t, evaluator.Evaluate(ctx))
+
+	value = 11
+	alerts = evaluator.Evaluate(ctx)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "rate", alerts[0].Rule)
+
+	// An unreadable metric leaves both rules firing
+	readErr = errors.New("database unavailable")
+	assert.Empty(t, evaluator.Evaluate(ctx))
+
+	readErr = nil
+	value = 0
+	alerts = evaluator.Evaluate(ctx)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, StateResolved, alerts[0].State)
+	assert.Equal(t, StateResolved, alerts[1].State)
+
+	// rate only notifies chat; any_rate names no channel, so it notifies both
+	assert.Len(t, notifier.delivered["chat"], 4)
+	assert.Len(t, notifier.delivered["ops"], 2)
+}
+
+func TestHTTPNotifier(t *testing.T) {
+	t.Parallel()
+
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		var decoded map[string]any
+		_ = json.Unmarshal(body, &decoded)
+		bodies = append(bodies, decoded)
+
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	notifier := NewHTTPNotifier(quietLogger(), time.Second)
+	alert := Alert{Rule: "backlog", State: StateFiring, Severity: "critical", Metric: "workflow_task_backlog", Value: 150, Operator: ">", Threshold: 100}
+	ctx := context.Background()
+
+	require.NoError(t, notifier.Notify(ctx, Channel{Name: "ops", Type: ChannelWebhook, URL: server.URL}, alert))
+	require.NoError(t, notifier.Notify(ctx, Channel{Name: "chat", Type: ChannelSlack, URL: server.URL}, alert))
+	require.NoError(t, notifier.Notify(ctx, Channel{Name: "stub", Type: ChannelSlack}, alert))
+	assert.ErrorContains(t, notifier.Notify(ctx, Channel{Name: "ops", Type: ChannelWebhook, URL: server.URL + "/broken"}, alert), "status 502")
+
+	require.Len(t, bodies, 3)
+	assert.Equal(t, "backlog", bodies[0]["rule"])
+	assert.Equal(t, 150.0, bodies[0]["value"])
+	assert.Equal(t, ":rotating_light: [firing] backlog: workflow_task_backlog is 150 (> 100), severity critical", bodies[1]["text"])
+}
@@ -0,0 +1,91 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Notifier delivers an alert to a channel
+type Notifier interface {
+	Notify(ctx context.Context, channel Channel, alert Alert) error
+}
+
+// HTTPNotifier posts alerts to webhook and Slack channels
+type HTTPNotifier struct {
+	logger *logrus.Logger
+	client *http.Client
+}
+
+// NewHTTPNotifier creates a notifier whose requests time out after timeout
+func NewHTTPNotifier(logger *logrus.Logger, timeout time.Duration) *HTTPNotifier {
+	return &HTTPNotifier{
+		logger: logger,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Notify posts alert to channel: webhook channels get the alert as JSON, Slack channels an incoming-webhook message.
+// A Slack channel without url only logs the message, which stands in for Slack in local setups.
+func (n *HTTPNotifier) Notify(ctx context.Context, channel Channel, alert Alert) error {
+	var payload any = alert
+	if channel.Type == ChannelSlack {
+		text := SlackText(alert)
+		if channel.URL == "" {
+			n.logger.WithFields(logrus.Fields{
+				"channel": channel.Name,
+				"rule":    alert.Rule,
+			}).Info("Slack alert (no url configured): " + text)
+
+			return nil
+		}
+
+		payload = map[string]string{"text": text}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, channel.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("alert channel returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// SlackText formats alert as a one-line Slack message
+func SlackText(alert Alert) string {
+	icon := ":rotating_light:"
+	if alert.State == StateResolved {
+		icon = ":white_check_mark:"
+	}
+
+	text := fmt.Sprintf("%s [%s] %s: %s is %g (%s %g)", icon, alert.State, alert.Rule, alert.Metric, alert.Value, alert.Operator, alert.Threshold)
+	if alert.Severity != "" {
+		text += ", severity " + alert.Severity
+	}
+	if alert.Description != "" {
+		text += " - " + alert.Description
+	}
+
+	return text
+}
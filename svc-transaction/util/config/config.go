@@ -3,14 +3,45 @@ package config
 import (
 	"fmt"
 
+	"profile"
+
 	"github.com/spf13/viper"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	App      App      `mapstructure:"app"`
-	DB       DB       `mapstructure:"db"`
-	Temporal Temporal `mapstructure:"temporal"`
+	App          App          `mapstructure:"app"`
+	Grpc         Grpc         `mapstructure:"grpc"`
+	DB           DB           `mapstructure:"db"`
+	Temporal     Temporal     `mapstructure:"temporal"`
+	Settlement   Settlement   `mapstructure:"settlement"`
+	Erasure      Erasure      `mapstructure:"erasure"`
+	Idempotency  Idempotency  `mapstructure:"idempotency"`
+	PendingSweep PendingSweep `mapstructure:"pending_sweep"`
+	Storage      Storage      `mapstructure:"storage"`
+	LedgerExport LedgerExport `mapstructure:"ledger_export"`
+	EODBalances  EODBalances  `mapstructure:"eod_balances"`
+	Latency      Latency      `mapstructure:"latency"`
+
+	CompensationReview CompensationReview `mapstructure:"compensation_review"`
+
+	FailureSimulation FailureSimulation `mapstructure:"failure_simulation"`
+
+	AccountNumbers AccountNumbers `mapstructure:"account_numbers"`
+
+	AmountPrecision AmountPrecision `mapstructure:"amount_precision"`
+
+	ExternalBank ExternalBank `mapstructure:"external_bank"`
+
+	TransferReadModel TransferReadModel `mapstructure:"transfer_read_model"`
+
+	BusinessRules BusinessRules `mapstructure:"business_rules"`
+
+	Alerting Alerting `mapstructure:"alerting"`
+
+	Scaling Scaling `mapstructure:"scaling"`
+
+	Profile profile.Profile `mapstructure:"-"` // From APP_PROFILE rather than config.json
 }
 
 // LoadConfig reads configuration from file or environment variables.
@@ -32,5 +63,10 @@ func LoadConfig(path string) (config Config, err error) {
 		return config, fmt.Errorf("failed to unmarshal configuration: %s", err)
 	}
 
+	config.Profile, err = profile.Load()
+	if err != nil {
+		return config, err
+	}
+
 	return
 }
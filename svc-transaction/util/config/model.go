@@ -3,9 +3,10 @@ package config
 // App config
 
 type App struct {
-	Name string `mapstructure:"name"`
-	Host string `mapstructure:"host"`
-	Port int    `mapstructure:"port"`
+	Name     string `mapstructure:"name"`
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	GrpcPort int    `mapstructure:"grpc_port"` // Ops gRPC service used by the api-gateway admin API; 0 disables it
 }
 
 // DB config
@@ -20,8 +21,39 @@ type PostgresConfig struct {
 	Pool             PostgresPool `mapstructure:"pool"`
 }
 
+// Migrations controls the embedded schema migrations
+type Migrations struct {
+	Auto bool `mapstructure:"auto"` // Apply pending migrations at startup; otherwise run the migrate command
+}
+
+// Store drivers selectable with db.driver
+const (
+	DriverPostgres = "postgres"
+	DriverMemory   = "memory" // In-memory tables seeded with the demo data, lost on restart; for demos and tests
+	DriverSQLite   = "sqlite" // The Postgres schema in a SQLite file both services can open; for laptop demos and CI
+)
+
+// SQLiteConfig locates the file of the sqlite driver
+type SQLiteConfig struct {
+	Path string `mapstructure:"path"` // Created with the demo data when missing
+}
+
 type DB struct {
-	Postgres PostgresConfig `mapstructure:"postgres"`
+	Driver     string         `mapstructure:"driver"` // postgres (default), memory or sqlite
+	Postgres   PostgresConfig `mapstructure:"postgres"`
+	SQLite     SQLiteConfig   `mapstructure:"sqlite"`
+	Migrations Migrations     `mapstructure:"migrations"`
+}
+
+// InMemory reports whether db.driver selects the in-memory store
+func (db DB) InMemory() bool {
+	return db.Driver == DriverMemory
+}
+
+// Migrated reports whether db.driver selects Postgres, whose schema the embedded migrations keep; the in-memory
+// store has no schema and the SQLite store creates its own
+func (db DB) Migrated() bool {
+	return db.Driver == "" || db.Driver == DriverPostgres
 }
 
 // Temporal config with performance optimization settings
@@ -36,8 +68,213 @@ type TemporalWorkerOptions struct {
 }
 
 type Temporal struct {
-	HostPort      string                `mapstructure:"host_port"`
-	Namespace     string                `mapstructure:"namespace"`
-	TaskQueue     string                `mapstructure:"task_queue"`
-	WorkerOptions TemporalWorkerOptions `mapstructure:"worker_options"`
+	HostPort        string                `mapstructure:"host_port"`
+	Namespace       string                `mapstructure:"namespace"`
+	TaskQueue       string                `mapstructure:"task_queue"`
+	TaskQueueSuffix string                `mapstructure:"task_queue_suffix"` // Worker fleet, e.g. v2 polls transaction-task-queue-v2; empty polls task_queue as is
+	WorkerOptions   TemporalWorkerOptions `mapstructure:"worker_options"`
+}
+
+// Settlement config
+
+type Settlement struct {
+	IntervalMinutes int    `mapstructure:"interval_minutes"` // 0 disables the settlement schedule
+	Format          string `mapstructure:"format"`           // csv or pacs008
+	MaxTransfers    int    `mapstructure:"max_transfers"`    // Largest number of transfers per settlement file
+}
+
+// Erasure config
+
+type Erasure struct {
+	RetentionDays int `mapstructure:"retention_days"` // Days a closed account is kept before its personal data can be erased; 0 uses the service default
+}
+
+// Idempotency config
+
+type Idempotency struct {
+	RetentionHours         int   `mapstructure:"retention_hours"`          // Hours an idempotency key stays registered; 0 uses the service default
+	CleanupIntervalMinutes int   `mapstructure:"cleanup_interval_minutes"` // 0 disables the cleanup schedule
+	CleanupBatchSize       int32 `mapstructure:"cleanup_batch_size"`       // Expired keys deleted per statement; 0 uses the service default
+}
+
+// PendingSweep config
+
+type PendingSweep struct {
+	IntervalMinutes   int   `mapstructure:"interval_minutes"`    // 0 disables the sweep schedule
+	StaleAfterMinutes int   `mapstructure:"stale_after_minutes"` // Minutes a transaction may stay pending before it is swept; 0 uses the service default
+	BatchSize         int32 `mapstructure:"batch_size"`          // Stale transactions resolved per run; 0 uses the service default
+}
+
+// Storage config
+
+type S3Storage struct {
+	Endpoint        string `mapstructure:"endpoint"` // e.g. https://s3.eu-west-1.amazonaws.com or http://minio:9000
+	Region          string `mapstructure:"region"`   // Empty is us-east-1
+	Bucket          string `mapstructure:"bucket"`
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+}
+
+type Storage struct {
+	Backend  string    `mapstructure:"backend"`   // local or s3; empty disables ledger exports and settlement file archiving
+	LocalDir string    `mapstructure:"local_dir"` // Root directory of the local backend
+	S3       S3Storage `mapstructure:"s3"`
+}
+
+// LedgerExport config
+
+type LedgerExport struct {
+	IntervalMinutes int    `mapstructure:"interval_minutes"` // 0 disables the export schedule
+	Format          string `mapstructure:"format"`           // csv or parquet
+	Prefix          string `mapstructure:"prefix"`           // Key prefix of the exported files; empty uses the service default
+	BatchSize       int32  `mapstructure:"batch_size"`       // Rows read per query; 0 uses the service default
+}
+
+// EODBalances config
+
+type EODBalances struct {
+	Enabled bool `mapstructure:"enabled"` // false disables the nightly schedule
+	Hour    int  `mapstructure:"hour"`    // UTC hour the previous business day is computed at
+	Minute  int  `mapstructure:"minute"`
+}
+
+// CompensationReview config
+
+type CompensationReview struct {
+	Enabled   bool  `mapstructure:"enabled"` // false disables the nightly schedule
+	Hour      int   `mapstructure:"hour"`    // UTC hour the pending compensations are reviewed at
+	Minute    int   `mapstructure:"minute"`
+	BatchSize int32 `mapstructure:"batch_size"` // Pending compensations reviewed per run; 0 uses the service default
+}
+
+// FailureSimulation config
+
+type FailureSimulation struct {
+	MaxFailurePercent        float64 `mapstructure:"max_failure_percent"`        // Operations failed per minute as a percentage of all operations; 0 for no cap
+	MinOperations            int     `mapstructure:"min_operations"`             // Operations of the minute before the cap applies
+	CompensationBacklogLimit int64   `mapstructure:"compensation_backlog_limit"` // Unfinished compensations of the last hour that switch failure simulation off; 0 disables the check
+	BacklogCheckSeconds      int     `mapstructure:"backlog_check_seconds"`      // Seconds between backlog counts; 0 uses the service default
+}
+
+// Latency config
+
+type Latency struct {
+	Profile string `mapstructure:"profile"` // none, fast, realistic, slow_bank or unresponsive_bank; empty is none
+}
+
+// AccountNumbers config
+
+// AccountNumberFormat describes the account numbers of one tenant
+type AccountNumberFormat struct {
+	Tenant    string `mapstructure:"tenant"` // Empty for the default format
+	Prefix    string `mapstructure:"prefix"`
+	MinLength int    `mapstructure:"min_length"` // Including the prefix; 0 for no minimum
+	MaxLength int    `mapstructure:"max_length"` // Including the prefix; 0 for no maximum
+	Checksum  string `mapstructure:"checksum"`   // none, luhn or mod97
+}
+
+type AccountNumbers struct {
+	Formats []AccountNumberFormat `mapstructure:"formats"` // Empty accepts any account number that fits the column
+}
+
+// AmountPrecision config
+
+// AmountPrecision is the NUMERIC(precision, scale) every amount must fit before it is stored
+type AmountPrecision struct {
+	Precision int32 `mapstructure:"precision"` // 0 uses NUMERIC(19,4), the type of the amount and balance columns
+	Scale     int32 `mapstructure:"scale"`
+}
+
+// ExternalBank config
+
+// ExternalBank locates the partner bank (svc-external-bank) that settles transfers in the external settlement step
+// of the transfer saga
+type ExternalBank struct {
+	Name           string `mapstructure:"name"`
+	Host           string `mapstructure:"host"` // Empty disables external settlement; its activities then reject every settlement
+	Port           int    `mapstructure:"port"`
+	TimeoutSeconds int    `mapstructure:"timeout_seconds"` // Deadline of each call to the partner; 0 uses the service default
+
+	Grpc GrpcClient `mapstructure:"grpc"`
+}
+
+// GrpcKeepalive makes the client ping an idle connection so a connection dropped by a proxy or a restarted server is
+// noticed before a call is sent on it
+type GrpcKeepalive struct {
+	TimeSeconds         int  `mapstructure:"time_seconds"`          // Ping after this long without activity; 0 disables pings
+	TimeoutSeconds      int  `mapstructure:"timeout_seconds"`       // Close the connection when a ping is not answered in time; 0 is 20 seconds
+	PermitWithoutStream bool `mapstructure:"permit_without_stream"` // Ping while no call is in flight; the server must allow it
+}
+
+// GrpcRetry retries calls that failed with UNAVAILABLE, within a budget shared by all calls of the connection
+type GrpcRetry struct {
+	MaxAttempts       int     `mapstructure:"max_attempts"`       // Including the first; 0 or 1 disables retries, at most 5
+	InitialBackoffMs  int     `mapstructure:"initial_backoff_ms"` // 0 is 100
+	MaxBackoffMs      int     `mapstructure:"max_backoff_ms"`     // 0 is 2000
+	BackoffMultiplier float64 `mapstructure:"backoff_multiplier"` // 0 is 2
+	BudgetTokens      int     `mapstructure:"budget_tokens"`      // Failures drain one token each; retries stop below half; 0 is 10
+	BudgetTokenRatio  float64 `mapstructure:"budget_token_ratio"` // Tokens given back by each success; 0 is 0.1
+}
+
+type GrpcClient struct {
+	Keepalive             GrpcKeepalive `mapstructure:"keepalive"`
+	Retry                 GrpcRetry     `mapstructure:"retry"`
+	MaxRecvMsgBytes       int           `mapstructure:"max_recv_msg_bytes"`      // 0 is 4 MiB
+	MaxSendMsgBytes       int           `mapstructure:"max_send_msg_bytes"`      // 0 is unlimited
+	ConnectTimeoutSeconds int           `mapstructure:"connect_timeout_seconds"` // Deadline of each connection attempt; 0 is 20 seconds
+}
+
+// TransferReadModel config
+
+// TransferReadModel projects the events transfer workflows record in core.transfer_events into
+// core.transfer_read_model, which serves GET /transfers without touching Temporal
+type TransferReadModel struct {
+	Enabled         bool  `mapstructure:"enabled"`          // false stops the projection; the read model keeps its rows
+	IntervalSeconds int   `mapstructure:"interval_seconds"` // Seconds between polls once the projection has caught up; 0 uses the service default
+	BatchSize       int32 `mapstructure:"batch_size"`       // Events applied per database transaction; 0 uses the service default
+}
+
+// BusinessRules config
+
+type BusinessRules struct {
+	RefreshSeconds int `mapstructure:"refresh_seconds"` // How long rules read from core.business_rules are used before they are read again; 0 reads them on every validation
+}
+
+// Alerting config
+
+type Alerting struct {
+	RulesFile       string `mapstructure:"rules_file"`       // YAML file of the channels and rules; empty disables alerting
+	IntervalSeconds int    `mapstructure:"interval_seconds"` // Seconds between evaluations; 0 is 30
+	TimeoutSeconds  int    `mapstructure:"timeout_seconds"`  // Timeout of an alert delivery; 0 is 5
+}
+
+// Scaling config
+
+type Scaling struct {
+	TargetBacklogPerWorker  int `mapstructure:"target_backlog_per_worker"`   // Tasks waiting on the task queue one worker is expected to drain; 0 is 100
+	TargetInFlightPerWorker int `mapstructure:"target_in_flight_per_worker"` // Activities one worker is expected to run at once; 0 is 40, half the default activity slots of a worker
+	MaxBacklogAgeSeconds    int `mapstructure:"max_backlog_age_seconds"`     // Ask for one more worker while the oldest task waits longer; 0 is 5
+	MinWorkers              int `mapstructure:"min_workers"`                 // 0 is 1
+	MaxWorkers              int `mapstructure:"max_workers"`                 // 0 is unbounded
+	WindowSeconds           int `mapstructure:"window_seconds"`              // Period activity latencies are averaged over; 0 is 60, at most 900
+}
+
+// Grpc config
+
+// GrpcServerKeepalive configures the HTTP/2 pings of the gRPC server and how long connections are kept
+type GrpcServerKeepalive struct {
+	TimeSeconds                  int  `mapstructure:"time_seconds"`                     // Ping a client after this long without activity; 0 is 2 hours
+	TimeoutSeconds               int  `mapstructure:"timeout_seconds"`                  // Close the connection when a ping is not answered in time; 0 is 20 seconds
+	MinTimeSeconds               int  `mapstructure:"min_time_seconds"`                 // Clients pinging more often are disconnected (too_many_pings); 0 is 5 minutes
+	PermitWithoutStream          bool `mapstructure:"permit_without_stream"`            // Accept client pings while no call is in flight
+	MaxConnectionIdleSeconds     int  `mapstructure:"max_connection_idle_seconds"`      // Close connections idle this long; 0 keeps them
+	MaxConnectionAgeSeconds      int  `mapstructure:"max_connection_age_seconds"`       // Close connections this old so clients rebalance; 0 keeps them
+	MaxConnectionAgeGraceSeconds int  `mapstructure:"max_connection_age_grace_seconds"` // Time given to calls in flight on an aged connection; 0 is unlimited
+}
+
+type Grpc struct {
+	Keepalive                GrpcServerKeepalive `mapstructure:"keepalive"`
+	MaxRecvMsgBytes          int                 `mapstructure:"max_recv_msg_bytes"`         // 0 is 4 MiB
+	MaxSendMsgBytes          int                 `mapstructure:"max_send_msg_bytes"`         // 0 is unlimited
+	ConnectionTimeoutSeconds int                 `mapstructure:"connection_timeout_seconds"` // Deadline of the connection handshake; 0 is 120 seconds
 }
@@ -0,0 +1,125 @@
+// Package ledgerexport writes ledger tables as CSV or Parquet files for analytics
+package ledgerexport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"svc-transaction/util/parquet"
+)
+
+// Format identifies the file format of an export
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatParquet Format = "parquet"
+)
+
+// ParseFormat validates an export file format name; empty is CSV
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case FormatCSV, "":
+		return FormatCSV, nil
+	case FormatParquet:
+		return FormatParquet, nil
+	default:
+		return "", fmt.Errorf("unsupported ledger export format: %s", value)
+	}
+}
+
+// ContentType returns the MIME type the file is stored with
+func (format Format) ContentType() string {
+	if format == FormatParquet {
+		return "application/vnd.apache.parquet"
+	}
+
+	return "text/csv"
+}
+
+// Extension returns the file extension of the format
+func (format Format) Extension() string {
+	return string(format)
+}
+
+// Writer writes the rows of one table. Values are given in column order: string, int64, time.Time or nil for
+// the empty value of an optional column.
+type Writer interface {
+	Write(values ...any) error
+	Close() error
+}
+
+// NewWriter returns a Writer of a table with the given columns to w in format. Close flushes the file; it does not
+// close w.
+func NewWriter(w io.Writer, format Format, columns []parquet.Column) (Writer, error) {
+	switch format {
+	case FormatParquet:
+		return parquet.NewWriter(w, columns), nil
+	case FormatCSV:
+		return newCSVWriter(w, columns)
+	default:
+		return nil, fmt.Errorf("unsupported ledger export format: %s", format)
+	}
+}
+
+type csvWriter struct {
+	writer  *csv.Writer
+	columns []parquet.Column
+	record  []string
+}
+
+func newCSVWriter(w io.Writer, columns []parquet.Column) (*csvWriter, error) {
+	writer := &csvWriter{
+		writer:  csv.NewWriter(w),
+		columns: columns,
+		record:  make([]string, len(columns)),
+	}
+
+	for i, column := range columns {
+		writer.record[i] = column.Name
+	}
+
+	if err := writer.writer.Write(writer.record); err != nil {
+		return nil, fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	return writer, nil
+}
+
+// Write formats timestamps as RFC 3339 in UTC and nil as an empty field
+func (writer *csvWriter) Write(values ...any) error {
+	if len(values) != len(writer.columns) {
+		return fmt.Errorf("row has %d values, want %d", len(values), len(writer.columns))
+	}
+
+	for i, value := range values {
+		switch value := value.(type) {
+		case nil:
+			if !writer.columns[i].Optional {
+				return fmt.Errorf("column %s is required", writer.columns[i].Name)
+			}
+
+			writer.record[i] = ""
+		case string:
+			writer.record[i] = value
+		case int64:
+			writer.record[i] = strconv.FormatInt(value, 10)
+		case time.Time:
+			writer.record[i] = value.UTC().Format(time.RFC3339Nano)
+		default:
+			return fmt.Errorf("column %s has unsupported value type %T", writer.columns[i].Name, value)
+		}
+	}
+
+	return writer.writer.Write(writer.record)
+}
+
+func (writer *csvWriter) Close() error {
+	writer.writer.Flush()
+
+	return writer.writer.Error()
+}
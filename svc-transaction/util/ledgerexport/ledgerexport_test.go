@@ -0,0 +1,66 @@
+package ledgerexport
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"svc-transaction/util/parquet"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testColumns = []parquet.Column{
+	{Name: "id", Type: parquet.String},
+	{Name: "sequence_number", Type: parquet.Int64},
+	{Name: "description", Type: parquet.String, Optional: true},
+	{Name: "created_at", Type: parquet.Timestamp},
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	for value, expected := range map[string]Format{"": FormatCSV, "CSV": FormatCSV, " parquet ": FormatParquet} {
+		format, err := ParseFormat(value)
+		require.NoError(t, err)
+		assert.Equal(t, expected, format)
+	}
+
+	_, err := ParseFormat("xlsx")
+	assert.Error(t, err)
+}
+
+func TestCSVWriter(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("WIB", 7*3600))
+
+	var file bytes.Buffer
+	writer, err := NewWriter(&file, FormatCSV, testColumns)
+	require.NoError(t, err)
+
+	require.NoError(t, writer.Write("a", int64(1), "coffee, large", createdAt))
+	require.NoError(t, writer.Write("b", int64(2), nil, createdAt))
+	assert.Error(t, writer.Write(nil, int64(3), nil, createdAt))
+	assert.Error(t, writer.Write("c", 3, nil, createdAt))
+	require.NoError(t, writer.Close())
+
+	assert.Equal(t, "id,sequence_number,description,created_at\n"+
+		"a,1,\"coffee, large\",2026-01-01T20:04:05Z\n"+
+		"b,2,,2026-01-01T20:04:05Z\n", file.String())
+}
+
+func TestParquetWriter(t *testing.T) {
+	t.Parallel()
+
+	var file bytes.Buffer
+	writer, err := NewWriter(&file, FormatParquet, testColumns)
+	require.NoError(t, err)
+
+	require.NoError(t, writer.Write("a", int64(1), nil, time.Now()))
+	require.NoError(t, writer.Close())
+
+	assert.True(t, bytes.HasPrefix(file.Bytes(), []byte("PAR1")))
+	assert.True(t, bytes.HasSuffix(file.Bytes(), []byte("PAR1")))
+}
@@ -0,0 +1,70 @@
+// Package lockwait records how long account mutations wait for their account lock, as a Prometheus-style histogram.
+package lockwait
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Bounds are the upper bounds of the histogram buckets; the implicit +Inf bucket is Snapshot.Count
+var Bounds = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+var (
+	buckets  = make([]atomic.Int64, len(Bounds)) // Non-cumulative counts, one per bound
+	count    atomic.Int64
+	sumNanos atomic.Int64
+	failures atomic.Int64
+)
+
+// Snapshot is a point-in-time copy of the lock wait histogram
+type Snapshot struct {
+	Buckets  []int64 // Cumulative counts, one per entry of Bounds
+	Count    int64
+	Sum      time.Duration
+	Failures int64
+}
+
+// Observe records the wait of one successful lock acquisition
+func Observe(wait time.Duration) {
+	for i, bound := range Bounds {
+		if wait <= bound {
+			buckets[i].Add(1)
+			break
+		}
+	}
+
+	count.Add(1)
+	sumNanos.Add(int64(wait))
+}
+
+// Fail records a lock acquisition that did not succeed, e.g. because the context was cancelled while waiting
+func Fail() {
+	failures.Add(1)
+}
+
+// Read returns the current histogram
+func Read() Snapshot {
+	snapshot := Snapshot{
+		Buckets:  make([]int64, len(Bounds)),
+		Count:    count.Load(),
+		Sum:      time.Duration(sumNanos.Load()),
+		Failures: failures.Load(),
+	}
+
+	var cumulative int64
+	for i := range Bounds {
+		cumulative += buckets[i].Load()
+		snapshot.Buckets[i] = cumulative
+	}
+
+	return snapshot
+}
@@ -0,0 +1,35 @@
+package lockwait
+
+import (
+	"testing"
+	"time"
+)
+
+func TestObserve(t *testing.T) {
+	before := Read()
+
+	Observe(500 * time.Microsecond)
+	Observe(20 * time.Millisecond)
+	Observe(10 * time.Second) // Only counted in the +Inf bucket
+	Fail()
+
+	after := Read()
+
+	if got := after.Count - before.Count; got != 3 {
+		t.Errorf("Count increased by %d, want 3", got)
+	}
+	if got := after.Sum - before.Sum; got != 10*time.Second+20*time.Millisecond+500*time.Microsecond {
+		t.Errorf("Sum increased by %v", got)
+	}
+	if got := after.Failures - before.Failures; got != 1 {
+		t.Errorf("Failures increased by %d, want 1", got)
+	}
+
+	// Buckets are cumulative: <=1ms holds one observation, <=50ms and above hold two
+	want := []int64{1, 1, 1, 2, 2, 2, 2, 2}
+	for i := range Bounds {
+		if got := after.Buckets[i] - before.Buckets[i]; got != want[i] {
+			t.Errorf("bucket <= %v increased by %d, want %d", Bounds[i], got, want[i])
+		}
+	}
+}
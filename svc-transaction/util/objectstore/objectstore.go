@@ -0,0 +1,151 @@
+// Package objectstore stores files on local disk or in an S3-compatible bucket (AWS S3, MinIO).
+package objectstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// Backend names accepted by the storage config
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// ErrInvalidKey is returned for object keys that are empty or escape the store
+var ErrInvalidKey = errors.New("invalid object key")
+
+// ErrNotFound is returned when no object exists under a key
+var ErrNotFound = errors.New("object not found")
+
+// Store reads and writes objects addressed by slash-separated keys
+type Store interface {
+	// Put stores size bytes read from body under key, replacing any existing object, and returns the location of
+	// the object, a file path or an s3:// URL
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error)
+
+	// Get opens the object stored under key; the caller must close it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// Options selects and configures a backend
+type Options struct {
+	Backend  string // BackendLocal, BackendS3 or empty for none
+	LocalDir string
+	S3       S3Options
+}
+
+// New returns the Store of the configured backend, or nil when no backend is configured
+func New(options Options) (Store, error) {
+	switch options.Backend {
+	case "":
+		return nil, nil
+	case BackendLocal:
+		return NewLocal(options.LocalDir)
+	case BackendS3:
+		return NewS3(options.S3, nil)
+	default:
+		return nil, fmt.Errorf("unsupported storage backend: %s", options.Backend)
+	}
+}
+
+// Object describes a stored object
+type Object struct {
+	Key      string `json:"key"`
+	Location string `json:"location"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+}
+
+// Write stores what write produces under key. The output is spooled to a temporary file while it is hashed, so
+// neither the caller nor the store has to hold it in memory and the store learns its size before the upload.
+func Write(ctx context.Context, store Store, key, contentType string, write func(io.Writer) error) (*Object, error) {
+	spool, err := os.CreateTemp("", "objectstore-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spool file: %w", err)
+	}
+	defer func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}()
+
+	hash := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(spool, hash)}
+
+	if err := write(counter); err != nil {
+		return nil, err
+	}
+
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to rewind spool file: %w", err)
+	}
+
+	location, err := store.Put(ctx, key, spool, counter.n, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Object{
+		Key:      key,
+		Location: location,
+		Size:     counter.n,
+		SHA256:   hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// Upload stores body, whose size does not need to be known, under key
+func Upload(ctx context.Context, store Store, key string, body io.Reader, contentType string) (*Object, error) {
+	return Write(ctx, store, key, contentType, func(w io.Writer) error {
+		if _, err := io.Copy(w, body); err != nil {
+			return fmt.Errorf("failed to read upload: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Download copies the object stored under key to w and returns the number of bytes copied
+func Download(ctx context.Context, store Store, key string, w io.Writer) (int64, error) {
+	body, err := store.Get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return n, fmt.Errorf("failed to download object: %w", err)
+	}
+
+	return n, nil
+}
+
+// cleanKey normalizes key and rejects keys that would resolve outside the store
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + strings.TrimSpace(key))[1:]
+	if cleaned == "" || cleaned != strings.TrimPrefix(strings.TrimSpace(key), "/") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+
+	return cleaned, nil
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (writer *countingWriter) Write(p []byte) (int, error) {
+	n, err := writer.w.Write(p)
+	writer.n += int64(n)
+
+	return n, err
+}
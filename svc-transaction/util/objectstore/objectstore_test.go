@@ -0,0 +1,229 @@
+package objectstore
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanKey(t *testing.T) {
+	t.Parallel()
+
+	for key, expected := range map[string]string{
+		"exports/2026/01/transactions.csv": "exports/2026/01/transactions.csv",
+		"/exports/a.csv":                   "exports/a.csv",
+	} {
+		cleaned, err := cleanKey(key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, cleaned)
+	}
+
+	for _, key := range []string{"", "/", "../secrets", "exports/../../secrets", "exports//a.csv"} {
+		_, err := cleanKey(key)
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
+
+func TestLocalPut(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store, err := NewLocal(dir)
+	require.NoError(t, err)
+
+	location, err := store.Put(context.Background(), "exports/transactions.csv", strings.NewReader("id\n1\n"), 5, "text/csv")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "exports", "transactions.csv"), location)
+
+	data, err := os.ReadFile(location)
+	require.NoError(t, err)
+	assert.Equal(t, "id\n1\n", string(data))
+
+	// A short body leaves neither the object nor the temporary file behind
+	_, err = store.Put(context.Background(), "exports/short.csv", strings.NewReader("id"), 5, "text/csv")
+	assert.Error(t, err)
+
+	entries, err := os.ReadDir(filepath.Join(dir, "exports"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestLocalGet(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = store.Put(context.Background(), "receipts/1.json", strings.NewReader("{}"), 2, "application/json")
+	require.NoError(t, err)
+
+	body, err := store.Get(context.Background(), "receipts/1.json")
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	assert.Equal(t, "{}", string(data))
+
+	_, err = store.Get(context.Background(), "receipts/2.json")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = store.Get(context.Background(), "../receipts/1.json")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestStreamingHelpers(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	object, err := Write(context.Background(), store, "exports/a.csv", "text/csv", func(w io.Writer) error {
+		_, err := io.WriteString(w, "abc")
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "exports/a.csv", object.Key)
+	assert.Equal(t, int64(3), object.Size)
+	// sha256("abc")
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", object.SHA256)
+
+	// Upload learns the size of a reader that does not report one
+	object, err = Upload(context.Background(), store, "uploads/b.csv", io.LimitReader(strings.NewReader("id\n1\n2\n"), 1<<20), "text/csv")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), object.Size)
+
+	var downloaded strings.Builder
+	n, err := Download(context.Background(), store, "uploads/b.csv", &downloaded)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), n)
+	assert.Equal(t, "id\n1\n2\n", downloaded.String())
+
+	_, err = Download(context.Background(), store, "uploads/missing.csv", &downloaded)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// A failing producer stores nothing
+	_, err = Write(context.Background(), store, "exports/failed.csv", "text/csv", func(io.Writer) error {
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+
+	_, err = store.Get(context.Background(), "exports/failed.csv")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	store, err := New(Options{})
+	require.NoError(t, err)
+	assert.Nil(t, store)
+
+	store, err = New(Options{Backend: BackendLocal, LocalDir: t.TempDir()})
+	require.NoError(t, err)
+	assert.IsType(t, &Local{}, store)
+
+	store, err = New(Options{Backend: BackendS3, S3: S3Options{Endpoint: "http://minio:9000", Bucket: "files"}})
+	require.NoError(t, err)
+	assert.IsType(t, &S3{}, store)
+
+	_, err = New(Options{Backend: "ftp"})
+	assert.Error(t, err)
+}
+
+// s3Error answers like S3 does when a request fails
+func s3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	io.WriteString(w, "<Error><Code>"+code+"</Code><Message>"+code+"</Message></Error>")
+}
+
+func TestS3Put(t *testing.T) {
+	t.Parallel()
+
+	var received *http.Request
+	var body string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		received, body = r, string(data)
+
+		if strings.Contains(r.URL.Path, "denied") {
+			s3Error(w, http.StatusForbidden, "AccessDenied")
+			return
+		}
+		w.Header().Set("ETag", `"etag"`)
+	}))
+	defer server.Close()
+
+	store, err := NewS3(S3Options{
+		Endpoint:        server.URL,
+		Bucket:          "ledger",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+	}, server.Client())
+	require.NoError(t, err)
+
+	location, err := store.Put(context.Background(), "exports/a b.parquet", strings.NewReader("PAR1"), 4, "application/vnd.apache.parquet")
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3://ledger/exports/a b.parquet", location)
+	assert.Equal(t, http.MethodPut, received.Method)
+	assert.Equal(t, "/ledger/exports/a%20b.parquet", received.URL.EscapedPath())
+	assert.Equal(t, "application/vnd.apache.parquet", received.Header.Get("Content-Type"))
+	// Over plain HTTP the client signs the payload in chunks
+	assert.Equal(t, "4", received.Header.Get("X-Amz-Decoded-Content-Length"))
+	assert.Contains(t, body, "\r\nPAR1\r\n")
+	assert.Regexp(t, `^AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/\d{8}/us-east-1/s3/aws4_request,`, received.Header.Get("Authorization"))
+
+	_, err = store.Put(context.Background(), "denied.csv", strings.NewReader("id"), 2, "text/csv")
+	assert.ErrorContains(t, err, "AccessDenied")
+
+	_, err = store.Put(context.Background(), "unknown-size.csv", strings.NewReader("id"), -1, "text/csv")
+	assert.Error(t, err)
+
+	_, err = NewS3(S3Options{Endpoint: server.URL + "/prefix", Bucket: "ledger"}, nil)
+	assert.Error(t, err)
+}
+
+func TestS3Get(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files/receipts/1.json":
+			w.Header().Set("Last-Modified", "Fri, 02 Jan 2026 03:04:05 GMT")
+			w.Header().Set("ETag", `"etag"`)
+			io.WriteString(w, "{}")
+		case "/files/denied.json":
+			s3Error(w, http.StatusForbidden, "AccessDenied")
+		default:
+			s3Error(w, http.StatusNotFound, "NoSuchKey")
+		}
+	}))
+	defer server.Close()
+
+	store, err := NewS3(S3Options{Endpoint: server.URL, Bucket: "files"}, server.Client())
+	require.NoError(t, err)
+
+	body, err := store.Get(context.Background(), "receipts/1.json")
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	assert.Equal(t, "{}", string(data))
+
+	_, err = store.Get(context.Background(), "receipts/2.json")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Stat sends a HEAD request, so the error carries the status rather than the XML code
+	_, err = store.Get(context.Background(), "denied.json")
+	assert.ErrorContains(t, err, "Access Denied")
+}
@@ -0,0 +1,110 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Options configures an S3-compatible bucket
+type S3Options struct {
+	Endpoint        string // Base URL, e.g. https://s3.eu-west-1.amazonaws.com or http://minio:9000
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3 stores objects in an S3-compatible bucket through the MinIO client, with path-style requests so MinIO and AWS
+// S3 are addressed the same way. Large objects are uploaded in parts by the client.
+type S3 struct {
+	options S3Options
+	client  *minio.Client
+}
+
+// NewS3 returns an S3 store for the bucket described by options; a nil client uses the default HTTP transport
+func NewS3(options S3Options, client *http.Client) (*S3, error) {
+	if options.Bucket == "" {
+		return nil, fmt.Errorf("s3 bucket is required")
+	}
+
+	if options.Region == "" {
+		options.Region = "us-east-1"
+	}
+
+	endpoint, err := url.Parse(strings.TrimSuffix(options.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" || endpoint.Path != "" {
+		return nil, fmt.Errorf("invalid s3 endpoint: %q", options.Endpoint)
+	}
+
+	minioOptions := &minio.Options{
+		Creds:        credentials.NewStaticV4(options.AccessKeyID, options.SecretAccessKey, ""),
+		Secure:       endpoint.Scheme == "https",
+		Region:       options.Region,
+		BucketLookup: minio.BucketLookupPath,
+	}
+	if client != nil {
+		minioOptions.Transport = client.Transport
+	}
+
+	minioClient, err := minio.New(endpoint.Host, minioOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 client: %w", err)
+	}
+
+	return &S3{options: options, client: minioClient}, nil
+}
+
+// Put uploads body; size must be known
+func (store *S3) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	if size < 0 {
+		return "", fmt.Errorf("s3 upload of %s requires the object size", key)
+	}
+
+	if _, err := store.client.PutObject(ctx, store.options.Bucket, key, body, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	}); err != nil {
+		return "", fmt.Errorf("failed to upload object: %w", err)
+	}
+
+	return "s3://" + store.options.Bucket + "/" + key, nil
+}
+
+// Get streams the object from the bucket; the body is read as the caller consumes it
+func (store *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := store.client.GetObject(ctx, store.options.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download object: %w", err)
+	}
+
+	// GetObject is lazy: Stat sends the request, so a missing object is reported here rather than on the first read
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+
+		var response minio.ErrorResponse
+		if errors.As(err, &response) && (response.Code == "NoSuchKey" || response.StatusCode == http.StatusNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+
+		return nil, fmt.Errorf("failed to download object: %w", err)
+	}
+
+	return object, nil
+}
@@ -0,0 +1,94 @@
+// Package pagination pages listings by keyset: a page ends with an opaque cursor that encodes the sort keys of its
+// last row, and the next page starts strictly after that row. Unlike offsets, pages neither skip nor repeat rows
+// when rows are added while a client pages through a listing.
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned for cursors that were not produced by Cursor.Encode
+var ErrInvalidCursor = errors.New("malformed cursor")
+
+// ErrInvalidLimit is returned for negative page sizes and page sizes above the maximum of a listing
+var ErrInvalidLimit = errors.New("invalid limit")
+
+// Cursor holds the sort keys of the last row of a page, for listings ordered by a timestamp with a unique tie
+// breaker, e.g. ORDER BY created_at DESC, id DESC
+type Cursor struct {
+	Time time.Time
+	ID   string
+}
+
+// Encode returns the cursor as an opaque URL-safe string
+func (cursor Cursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor.Time.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID))
+}
+
+// Decode parses a cursor returned by Encode
+func Decode(value string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	timestamp, id, found := strings.Cut(string(raw), "|")
+	if !found || id == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return Cursor{Time: parsed, ID: id}, nil
+}
+
+// Page is a validated page request
+type Page struct {
+	Limit int32   // Rows of the page
+	After *Cursor // Rows up to and including this one were returned by earlier pages; nil for the first page
+}
+
+// NewPage validates a page request: limit 0 selects defaultLimit, and an empty cursor the first page
+func NewPage(cursor string, limit, defaultLimit, maxLimit int32) (Page, error) {
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if limit < 1 || limit > maxLimit {
+		return Page{}, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidLimit, maxLimit)
+	}
+
+	page := Page{Limit: limit}
+	if cursor != "" {
+		after, err := Decode(cursor)
+		if err != nil {
+			return Page{}, err
+		}
+		page.After = &after
+	}
+
+	return page, nil
+}
+
+// FetchLimit is the row limit to query: one row more than the page tells whether another page follows
+func (page Page) FetchLimit() int32 {
+	return page.Limit + 1
+}
+
+// Next cuts rows fetched with FetchLimit down to the page and returns the cursor of the next page, or an empty
+// cursor when this is the last page
+func Next[T any](page Page, rows []T, key func(T) Cursor) ([]T, string) {
+	if len(rows) <= int(page.Limit) {
+		return rows, ""
+	}
+
+	rows = rows[:page.Limit]
+
+	return rows, key(rows[len(rows)-1]).Encode()
+}
@@ -0,0 +1,63 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := Cursor{Time: time.Date(2026, 3, 1, 9, 30, 0, 123456000, time.FixedZone("CET", 3600)), ID: "5f0c6f1e-2b1a-4c43-9d55-8f2f1c1b0a01"}
+
+	decoded, err := Decode(cursor.Encode())
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if !decoded.Time.Equal(cursor.Time) || decoded.ID != cursor.ID {
+		t.Errorf("Decode() = %+v, want %+v", decoded, cursor)
+	}
+
+	for _, value := range []string{
+		"not base64!",
+		base64.RawURLEncoding.EncodeToString([]byte("2026-03-01T09:30:00Z")),
+		base64.RawURLEncoding.EncodeToString([]byte("yesterday|id-1")),
+		base64.RawURLEncoding.EncodeToString([]byte("2026-03-01T09:30:00Z|")),
+	} {
+		if _, err := Decode(value); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Decode(%q) error = %v, want ErrInvalidCursor", value, err)
+		}
+	}
+}
+
+func TestPage(t *testing.T) {
+	page, err := NewPage("", 0, 50, 500)
+	if err != nil || page.Limit != 50 || page.After != nil || page.FetchLimit() != 51 {
+		t.Errorf("NewPage() = %+v, %v; want the first page of 50 rows", page, err)
+	}
+
+	for _, limit := range []int32{-1, 501} {
+		if _, err := NewPage("", limit, 50, 500); !errors.Is(err, ErrInvalidLimit) {
+			t.Errorf("NewPage(limit %d) error = %v, want ErrInvalidLimit", limit, err)
+		}
+	}
+
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	rows := []int{5, 4, 3}
+	key := func(row int) Cursor { return Cursor{Time: base.Add(time.Duration(row) * time.Hour), ID: "row"} }
+
+	page, _ = NewPage("", 2, 50, 500)
+	cut, next := Next(page, rows, key)
+	if len(cut) != 2 || next == "" {
+		t.Fatalf("Next() = %v, %q; want 2 rows and a cursor", cut, next)
+	}
+
+	page, err = NewPage(next, 2, 50, 500)
+	if err != nil || !page.After.Time.Equal(base.Add(4*time.Hour)) {
+		t.Errorf("NewPage(next) = %+v, %v; want the page after row 4", page, err)
+	}
+
+	if cut, next := Next(page, rows[2:], key); len(cut) != 1 || next != "" {
+		t.Errorf("Next() on the last page = %v, %q; want 1 row and no cursor", cut, next)
+	}
+}
@@ -0,0 +1,211 @@
+// Package parquet writes flat tables as Apache Parquet files with parquet-go. It fixes the schema the ledger export
+// needs: Snappy compressed columns of strings, 64-bit integers and millisecond timestamps, optionally nullable, in the
+// order they are declared.
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/encoding"
+)
+
+// DefaultRowGroupSize is the number of rows buffered in memory before a row group is written
+const DefaultRowGroupSize = 10000
+
+const createdBy = "svc-transaction"
+
+// Type is the logical type of a column
+type Type int
+
+const (
+	String    Type = iota // UTF-8 byte array; values are string
+	Int64                 // Signed 64-bit integer; values are int64
+	Timestamp             // Milliseconds since the Unix epoch in UTC; values are time.Time
+)
+
+// Column describes one column of the table
+type Column struct {
+	Name     string
+	Type     Type
+	Optional bool // Optional columns accept nil values
+}
+
+// ErrClosed is returned when writing to a closed Writer
+var ErrClosed = errors.New("parquet writer closed")
+
+// Writer writes rows to a Parquet file. Rows are buffered and written as a row group every RowGroupSize rows; Close
+// writes the last row group and the footer. It is not safe for concurrent use.
+type Writer struct {
+	RowGroupSize int // Rows per row group, DefaultRowGroupSize if zero
+
+	writer  *parquet.Writer
+	columns []Column
+	row     parquet.Row
+	rows    int // Rows in the buffered row group
+	closed  bool
+}
+
+// NewWriter returns a Writer of the given columns to w. Close must be called to complete the file; it does not
+// close w.
+func NewWriter(w io.Writer, columns []Column) *Writer {
+	fields := make(table, len(columns))
+	for i, column := range columns {
+		fields[i] = field{Node: column.node(), name: column.Name}
+	}
+
+	return &Writer{
+		writer: parquet.NewWriter(w,
+			parquet.NewSchema("schema", fields),
+			parquet.Compression(&parquet.Snappy),
+			parquet.CreatedBy(createdBy, "", ""),
+		),
+		columns: columns,
+		row:     make(parquet.Row, len(columns)),
+	}
+}
+
+// Write appends a row with one value per column, in column order
+func (writer *Writer) Write(values ...any) error {
+	if writer.closed {
+		return ErrClosed
+	}
+
+	if len(values) != len(writer.columns) {
+		return fmt.Errorf("row has %d values, want %d", len(values), len(writer.columns))
+	}
+
+	// The row is only handed to parquet-go once every value is valid, so a rejected row leaves nothing behind
+	for i, value := range values {
+		parquetValue, err := writer.columns[i].value(value)
+		if err != nil {
+			return err
+		}
+
+		definitionLevel := 0
+		if writer.columns[i].Optional && value != nil {
+			definitionLevel = 1
+		}
+		writer.row[i] = parquetValue.Level(0, definitionLevel, i)
+	}
+
+	if _, err := writer.writer.WriteRows([]parquet.Row{writer.row}); err != nil {
+		return err
+	}
+
+	writer.rows++
+
+	rowGroupSize := writer.RowGroupSize
+	if rowGroupSize <= 0 {
+		rowGroupSize = DefaultRowGroupSize
+	}
+
+	if writer.rows >= rowGroupSize {
+		writer.rows = 0
+
+		return writer.writer.Flush()
+	}
+
+	return nil
+}
+
+// Close writes the buffered rows and the file footer
+func (writer *Writer) Close() error {
+	if writer.closed {
+		return ErrClosed
+	}
+
+	writer.closed = true
+
+	return writer.writer.Close()
+}
+
+// node returns the parquet-go schema node of the column
+func (column Column) node() parquet.Node {
+	var node parquet.Node
+	switch column.Type {
+	case String:
+		node = parquet.String()
+	case Timestamp:
+		node = parquet.Timestamp(parquet.Millisecond)
+	default:
+		node = parquet.Int(64)
+	}
+
+	if column.Optional {
+		return parquet.Optional(node)
+	}
+
+	return node
+}
+
+// value converts a value given to Write to the parquet value of the column
+func (column Column) value(value any) (parquet.Value, error) {
+	if value == nil {
+		if !column.Optional {
+			return parquet.Value{}, fmt.Errorf("column %s is required", column.Name)
+		}
+
+		return parquet.NullValue(), nil
+	}
+
+	switch column.Type {
+	case String:
+		s, ok := value.(string)
+		if !ok {
+			return parquet.Value{}, fmt.Errorf("column %s expects string, got %T", column.Name, value)
+		}
+
+		return parquet.ByteArrayValue([]byte(s)), nil
+	case Int64:
+		n, ok := value.(int64)
+		if !ok {
+			return parquet.Value{}, fmt.Errorf("column %s expects int64, got %T", column.Name, value)
+		}
+
+		return parquet.Int64Value(n), nil
+	case Timestamp:
+		t, ok := value.(time.Time)
+		if !ok {
+			return parquet.Value{}, fmt.Errorf("column %s expects time.Time, got %T", column.Name, value)
+		}
+
+		return parquet.Int64Value(t.UnixMilli()), nil
+	default:
+		return parquet.Value{}, fmt.Errorf("column %s has unknown type %d", column.Name, column.Type)
+	}
+}
+
+// table is the root node of the schema. parquet.Group sorts its fields by name; table keeps the columns in the order
+// they were declared, so the file reads like the CSV export.
+type table []parquet.Field
+
+func (t table) ID() int                     { return 0 }
+func (t table) String() string              { return parquet.Group{}.String() }
+func (t table) Type() parquet.Type          { return parquet.Group{}.Type() }
+func (t table) Optional() bool              { return false }
+func (t table) Repeated() bool              { return false }
+func (t table) Required() bool              { return true }
+func (t table) Leaf() bool                  { return false }
+func (t table) Fields() []parquet.Field     { return t }
+func (t table) Encoding() encoding.Encoding { return nil }
+func (t table) Compression() compress.Codec { return nil }
+func (t table) GoType() reflect.Type        { return reflect.TypeOf(map[string]any{}) }
+
+// field is a named column of the table
+type field struct {
+	parquet.Node
+	name string
+}
+
+func (f field) Name() string { return f.name }
+
+// Value is only used to write Go values; the writer writes rows of parquet values
+func (f field) Value(base reflect.Value) reflect.Value {
+	return base.MapIndex(reflect.ValueOf(f.name))
+}
@@ -0,0 +1,112 @@
+package parquet
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openFile opens a written file with the parquet-go reader
+func openFile(t *testing.T, data []byte) *parquet.File {
+	t.Helper()
+
+	file, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	return file
+}
+
+// readRows reads every row of the file, across its row groups
+func readRows(t *testing.T, file *parquet.File) []parquet.Row {
+	t.Helper()
+
+	reader := parquet.NewReader(file)
+	defer reader.Close()
+
+	var rows []parquet.Row
+	buffer := make([]parquet.Row, 10)
+	for {
+		n, err := reader.ReadRows(buffer)
+		for _, row := range buffer[:n] {
+			rows = append(rows, row.Clone())
+		}
+		if err == io.EOF {
+			return rows
+		}
+		require.NoError(t, err)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 6_000_000, time.UTC)
+
+	var data bytes.Buffer
+	writer := NewWriter(&data, []Column{
+		{Name: "id", Type: String},
+		{Name: "amount", Type: Int64},
+		{Name: "reference", Type: String, Optional: true},
+		{Name: "created_at", Type: Timestamp},
+	})
+	writer.RowGroupSize = 2
+
+	require.NoError(t, writer.Write("a", int64(100), "REF1", createdAt))
+	require.NoError(t, writer.Write("b", int64(-5), nil, createdAt))
+	// A rejected row leaves the buffered columns untouched
+	require.Error(t, writer.Write("bad", int64(1), "REF", "not a time"))
+	require.Error(t, writer.Write("bad", int64(1)))
+	require.Error(t, writer.Write(nil, int64(1), nil, createdAt))
+	require.NoError(t, writer.Write("c", int64(7), nil, createdAt))
+	require.NoError(t, writer.Close())
+	assert.ErrorIs(t, writer.Write("d", int64(1), nil, createdAt), ErrClosed)
+
+	file := openFile(t, data.Bytes())
+
+	assert.Equal(t, int64(3), file.NumRows())
+	require.Len(t, file.RowGroups(), 2)
+	assert.Equal(t, int64(2), file.RowGroups()[0].NumRows())
+	assert.Contains(t, file.Metadata().CreatedBy, createdBy)
+
+	// The columns keep the declared order and types
+	fields := file.Schema().Fields()
+	require.Len(t, fields, 4)
+	for i, want := range []string{"id", "amount", "reference", "created_at"} {
+		assert.Equal(t, want, fields[i].Name())
+	}
+	assert.True(t, fields[0].Required())
+	assert.True(t, fields[2].Optional())
+	assert.Equal(t, parquet.String().Type().String(), fields[0].Type().String())
+	assert.Equal(t, parquet.Timestamp(parquet.Millisecond).Type().String(), fields[3].Type().String())
+
+	rows := readRows(t, file)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, "a", rows[0][0].String())
+	assert.Equal(t, int64(100), rows[0][1].Int64())
+	assert.Equal(t, "REF1", rows[0][2].String())
+	assert.Equal(t, createdAt.UnixMilli(), rows[0][3].Int64())
+
+	assert.Equal(t, int64(-5), rows[1][1].Int64())
+	assert.True(t, rows[1][2].IsNull())
+	assert.Equal(t, "c", rows[2][0].String())
+	assert.True(t, rows[2][2].IsNull())
+}
+
+func TestWriterEmpty(t *testing.T) {
+	t.Parallel()
+
+	var data bytes.Buffer
+	require.NoError(t, NewWriter(&data, []Column{{Name: "id", Type: String}}).Close())
+
+	file := openFile(t, data.Bytes())
+
+	assert.Equal(t, int64(0), file.NumRows())
+	assert.Empty(t, readRows(t, file))
+	assert.Equal(t, "id", file.Schema().Fields()[0].Name())
+}
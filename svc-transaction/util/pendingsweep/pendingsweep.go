@@ -0,0 +1,49 @@
+// Package pendingsweep counts what the pending-transaction sweeper did since the process started, for the metrics endpoint.
+package pendingsweep
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+var (
+	runs      atomic.Int64
+	completed atomic.Int64
+	failed    atomic.Int64
+	errored   atomic.Int64
+	lastRunAt atomic.Int64 // Unix nanoseconds; 0 before the first sweep
+)
+
+// Snapshot is a point-in-time copy of the sweeper counters
+type Snapshot struct {
+	Runs      int64
+	Completed int64 // Pending transactions completed because their balance change had been applied
+	Failed    int64 // Pending transactions marked failed because their balance change was never applied
+	Errors    int64 // Pending transactions the sweeper could not resolve, retried by the next sweep
+	LastRunAt time.Time
+}
+
+// Record adds the outcome of one sweep
+func Record(completedCount, failedCount, errorCount int) {
+	runs.Add(1)
+	completed.Add(int64(completedCount))
+	failed.Add(int64(failedCount))
+	errored.Add(int64(errorCount))
+	lastRunAt.Store(time.Now().UnixNano())
+}
+
+// Read returns the current counters
+func Read() Snapshot {
+	snapshot := Snapshot{
+		Runs:      runs.Load(),
+		Completed: completed.Load(),
+		Failed:    failed.Load(),
+		Errors:    errored.Load(),
+	}
+
+	if nanos := lastRunAt.Load(); nanos > 0 {
+		snapshot.LastRunAt = time.Unix(0, nanos)
+	}
+
+	return snapshot
+}
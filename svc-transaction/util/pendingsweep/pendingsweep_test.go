@@ -0,0 +1,32 @@
+package pendingsweep
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecord(t *testing.T) {
+	before := Read()
+	start := time.Now()
+
+	Record(2, 1, 0)
+	Record(0, 3, 1)
+
+	after := Read()
+
+	if got := after.Runs - before.Runs; got != 2 {
+		t.Errorf("Runs increased by %d, want 2", got)
+	}
+	if got := after.Completed - before.Completed; got != 2 {
+		t.Errorf("Completed increased by %d, want 2", got)
+	}
+	if got := after.Failed - before.Failed; got != 4 {
+		t.Errorf("Failed increased by %d, want 4", got)
+	}
+	if got := after.Errors - before.Errors; got != 1 {
+		t.Errorf("Errors increased by %d, want 1", got)
+	}
+	if after.LastRunAt.Before(start) {
+		t.Errorf("LastRunAt = %v, want at or after %v", after.LastRunAt, start)
+	}
+}
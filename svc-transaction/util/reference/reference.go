@@ -0,0 +1,114 @@
+package reference
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxSequence is the largest daily sequence number a branch can issue
+const MaxSequence = 999999
+
+// dateLayout is the business date part of a reference
+const dateLayout = "060102"
+
+var (
+	branchCodePattern = regexp.MustCompile(`^[0-9]{3}$`)
+	referencePattern  = regexp.MustCompile(`^([0-9]{6})([0-9]{3})([0-9]{6})([0-9])$`)
+)
+
+// Reference is a human-friendly transfer reference number, formatted as YYMMDD-BBB-NNNNNN-C:
+// business date, branch code, daily sequence number and a Luhn check digit over the preceding digits
+type Reference struct {
+	BusinessDate time.Time
+	BranchCode   string
+	Sequence     int
+}
+
+// ValidateBranchCode checks that a branch code can be embedded in a reference
+func ValidateBranchCode(branchCode string) error {
+	if !branchCodePattern.MatchString(branchCode) {
+		return fmt.Errorf("branch code must be 3 digits, got %q", branchCode)
+	}
+
+	return nil
+}
+
+// New validates the parts of a reference
+func New(businessDate time.Time, branchCode string, sequence int) (Reference, error) {
+	if err := ValidateBranchCode(branchCode); err != nil {
+		return Reference{}, err
+	}
+
+	if sequence < 1 || sequence > MaxSequence {
+		return Reference{}, fmt.Errorf("sequence must be between 1 and %d, got %d", MaxSequence, sequence)
+	}
+
+	year, month, day := businessDate.Date()
+
+	return Reference{
+		BusinessDate: time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+		BranchCode:   branchCode,
+		Sequence:     sequence,
+	}, nil
+}
+
+// String returns the reference as printed on receipts
+func (reference Reference) String() string {
+	digits := reference.digits()
+
+	return fmt.Sprintf("%s-%s-%s-%d", digits[0:6], digits[6:9], digits[9:15], checkDigit(digits))
+}
+
+// digits returns the reference without separators and check digit
+func (reference Reference) digits() string {
+	return fmt.Sprintf("%s%s%06d", reference.BusinessDate.Format(dateLayout), reference.BranchCode, reference.Sequence)
+}
+
+// Parse decodes a reference typed by a support agent. Spaces and hyphens are ignored,
+// and the check digit must match so that mistyped references are rejected before any lookup.
+func Parse(value string) (Reference, error) {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(value))
+
+	matches := referencePattern.FindStringSubmatch(normalized)
+	if matches == nil {
+		return Reference{}, fmt.Errorf("reference must have the format YYMMDD-BBB-NNNNNN-C, got %q", value)
+	}
+
+	if strconv.Itoa(checkDigit(normalized[:15])) != matches[4] {
+		return Reference{}, fmt.Errorf("reference %q has an invalid check digit", value)
+	}
+
+	businessDate, err := time.Parse(dateLayout, matches[1])
+	if err != nil {
+		return Reference{}, fmt.Errorf("reference %q has an invalid date: %w", value, err)
+	}
+
+	sequence, err := strconv.Atoi(matches[3])
+	if err != nil {
+		return Reference{}, fmt.Errorf("reference %q has an invalid sequence: %w", value, err)
+	}
+
+	return New(businessDate, matches[2], sequence)
+}
+
+// checkDigit computes the Luhn check digit of a string of decimal digits
+func checkDigit(digits string) int {
+	sum := 0
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+
+	return (10 - sum%10) % 10
+}
@@ -0,0 +1,79 @@
+package reference
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferenceString(t *testing.T) {
+	t.Parallel()
+
+	reference, err := New(time.Date(2025, 3, 14, 17, 45, 0, 0, time.UTC), "001", 42)
+	require.NoError(t, err)
+
+	assert.Equal(t, "250314-001-000042-2", reference.String())
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	businessDate := time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		branchCode string
+		sequence   int
+	}{
+		{name: "branch_too_short", branchCode: "01", sequence: 1},
+		{name: "branch_not_numeric", branchCode: "A01", sequence: 1},
+		{name: "sequence_zero", branchCode: "001", sequence: 0},
+		{name: "sequence_exhausted", branchCode: "001", sequence: MaxSequence + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(businessDate, tt.branchCode, tt.sequence)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       string
+		expected    string
+		expectError bool
+	}{
+		{name: "formatted", value: "250314-001-000042-2", expected: "250314-001-000042-2"},
+		{name: "without_separators", value: "2503140010000422", expected: "250314-001-000042-2"},
+		{name: "with_spaces", value: " 250314 001 000042 2 ", expected: "250314-001-000042-2"},
+		{name: "wrong_check_digit", value: "250314-001-000042-3", expectError: true},
+		{name: "transposed_digits", value: "250314-001-000024-2", expectError: true},
+		{name: "invalid_date", value: "251399-001-000042-0", expectError: true},
+		{name: "uuid", value: "0f8fad5b-d9cb-469f-a165-70867728950e", expectError: true},
+		{name: "empty", value: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reference, err := Parse(tt.value)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, reference.String())
+		})
+	}
+}
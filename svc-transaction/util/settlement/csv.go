@@ -0,0 +1,65 @@
+package settlement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"time"
+)
+
+// csvHeader lists the columns of a CSV settlement file
+var csvHeader = []string{
+	"file_reference",
+	"transaction_reference",
+	"transfer_id",
+	"completed_at",
+	"from_account",
+	"from_account_name",
+	"to_account",
+	"to_account_name",
+	"amount",
+	"currency",
+	"description",
+}
+
+// RenderCSV writes one row per transfer after a header row, followed by a trailer row carrying the control totals
+func RenderCSV(batch Batch) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range batch.Entries {
+		record := []string{
+			batch.Reference,
+			TransactionReference(entry.TransferID),
+			entry.TransferID,
+			entry.CompletedAt.UTC().Format(time.RFC3339),
+			entry.FromAccountNumber,
+			entry.FromAccountName,
+			entry.ToAccountNumber,
+			entry.ToAccountName,
+			entry.Amount.StringFixed(2),
+			entry.Currency,
+			entry.Description,
+		}
+
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	trailer := []string{"TRAILER", strconv.Itoa(len(batch.Entries)), batch.ControlSum().StringFixed(2)}
+	if err := writer.Write(trailer); err != nil {
+		return nil, err
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
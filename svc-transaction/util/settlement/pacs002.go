@@ -0,0 +1,98 @@
+package settlement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// StatusRejected is the group or transaction status code that rejects a settlement file or an individual transfer
+const StatusRejected = "RJCT"
+
+// Acknowledgement is the clearing counterparty's response to a settlement file
+type Acknowledgement struct {
+	MessageID             string
+	OriginalFileReference string
+	GroupStatus           string
+	GroupReason           string
+	Transactions          []TransactionAcknowledgement
+}
+
+// TransactionAcknowledgement is the status of a single transfer reported in an acknowledgement
+type TransactionAcknowledgement struct {
+	Reference string
+	Status    string
+	Reason    string
+}
+
+// Rejected reports whether the status code rejects the file or transfer it applies to
+func Rejected(status string) bool {
+	return strings.EqualFold(strings.TrimSpace(status), StatusRejected)
+}
+
+type pacs002Document struct {
+	XMLName xml.Name            `xml:"Document"`
+	Report  fiToFIPaymentStatus `xml:"FIToFIPmtStsRpt"`
+}
+
+type fiToFIPaymentStatus struct {
+	MessageID    string                   `xml:"GrpHdr>MsgId"`
+	Group        pacs002OriginalGroup     `xml:"OrgnlGrpInfAndSts"`
+	Transactions []pacs002TransactionInfo `xml:"TxInfAndSts"`
+}
+
+type pacs002OriginalGroup struct {
+	OriginalMessageID string   `xml:"OrgnlMsgId"`
+	GroupStatus       string   `xml:"GrpSts"`
+	Reasons           []string `xml:"StsRsnInf>AddtlInf"`
+}
+
+type pacs002TransactionInfo struct {
+	OriginalEndToEndID    string   `xml:"OrgnlEndToEndId"`
+	OriginalTransactionID string   `xml:"OrgnlTxId"`
+	TransactionStatus     string   `xml:"TxSts"`
+	Reasons               []string `xml:"StsRsnInf>AddtlInf"`
+}
+
+// ParsePacs002 decodes an ISO 20022 pacs.002 FIToFIPaymentStatusReport acknowledging a pacs.008 or CSV settlement file
+func ParsePacs002(data []byte) (*Acknowledgement, error) {
+	var document pacs002Document
+	if err := xml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("malformed pacs.002 XML: %w", err)
+	}
+
+	report := document.Report
+	if report.Group.OriginalMessageID == "" {
+		return nil, fmt.Errorf("OrgnlGrpInfAndSts/OrgnlMsgId is required")
+	}
+
+	acknowledgement := &Acknowledgement{
+		MessageID:             strings.TrimSpace(report.MessageID),
+		OriginalFileReference: strings.TrimSpace(report.Group.OriginalMessageID),
+		GroupStatus:           strings.TrimSpace(report.Group.GroupStatus),
+		GroupReason:           strings.Join(report.Group.Reasons, "; "),
+	}
+
+	for _, tx := range report.Transactions {
+		reference := tx.OriginalTransactionID
+		if reference == "" {
+			reference = tx.OriginalEndToEndID
+		}
+
+		if reference == "" {
+			return nil, fmt.Errorf("TxInfAndSts requires OrgnlTxId or OrgnlEndToEndId")
+		}
+
+		acknowledgement.Transactions = append(acknowledgement.Transactions, TransactionAcknowledgement{
+			Reference: strings.TrimSpace(reference),
+			Status:    strings.TrimSpace(tx.TransactionStatus),
+			Reason:    strings.Join(tx.Reasons, "; "),
+		})
+	}
+
+	if acknowledgement.GroupStatus == "" && len(acknowledgement.Transactions) == 0 {
+		return nil, fmt.Errorf("acknowledgement carries neither a group status nor transaction statuses")
+	}
+
+	return acknowledgement, nil
+}
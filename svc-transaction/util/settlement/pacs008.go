@@ -0,0 +1,105 @@
+package settlement
+
+import (
+	"encoding/xml"
+	"strconv"
+	"time"
+)
+
+// Pacs008Namespace is the XML namespace of the FIToFICustomerCreditTransfer version produced
+const Pacs008Namespace = "urn:iso:std:iso:20022:tech:xsd:pacs.008.001.08"
+
+// notProvided is used for agent identifiers, as both legs of an internal transfer are booked by this institution
+const notProvided = "NOTPROVIDED"
+
+// maxRemittanceLength is the ISO 20022 limit for unstructured remittance information
+const maxRemittanceLength = 140
+
+type pacs008Document struct {
+	XMLName   xml.Name                     `xml:"Document"`
+	Namespace string                       `xml:"xmlns,attr"`
+	Transfer  fiToFICustomerCreditTransfer `xml:"FIToFICstmrCdtTrf"`
+}
+
+type fiToFICustomerCreditTransfer struct {
+	GroupHeader  pacs008GroupHeader      `xml:"GrpHdr"`
+	Transactions []pacs008CreditTransfer `xml:"CdtTrfTxInf"`
+}
+
+type pacs008GroupHeader struct {
+	MessageID            string `xml:"MsgId"`
+	CreationDateTime     string `xml:"CreDtTm"`
+	NumberOfTransactions string `xml:"NbOfTxs"`
+	ControlSum           string `xml:"CtrlSum"`
+	SettlementMethod     string `xml:"SttlmInf>SttlmMtd"`
+}
+
+type pacs008CreditTransfer struct {
+	InstructionID              string        `xml:"PmtId>InstrId"`
+	EndToEndID                 string        `xml:"PmtId>EndToEndId"`
+	TransactionID              string        `xml:"PmtId>TxId"`
+	InterbankSettlementAmount  pacs008Amount `xml:"IntrBkSttlmAmt"`
+	InterbankSettlementDate    string        `xml:"IntrBkSttlmDt"`
+	ChargeBearer               string        `xml:"ChrgBr"`
+	DebtorName                 string        `xml:"Dbtr>Nm"`
+	DebtorAccount              string        `xml:"DbtrAcct>Id>Othr>Id"`
+	DebtorAgent                string        `xml:"DbtrAgt>FinInstnId>Othr>Id"`
+	CreditorAgent              string        `xml:"CdtrAgt>FinInstnId>Othr>Id"`
+	CreditorName               string        `xml:"Cdtr>Nm"`
+	CreditorAccount            string        `xml:"CdtrAcct>Id>Othr>Id"`
+	UnstructuredRemittanceInfo string        `xml:"RmtInf>Ustrd,omitempty"`
+}
+
+type pacs008Amount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// RenderPacs008 writes the batch as an ISO 20022 pacs.008 FIToFICustomerCreditTransfer message.
+// The batch reference becomes the message ID that acknowledgements refer to as OrgnlMsgId.
+func RenderPacs008(batch Batch) ([]byte, error) {
+	document := pacs008Document{
+		Namespace: Pacs008Namespace,
+		Transfer: fiToFICustomerCreditTransfer{
+			GroupHeader: pacs008GroupHeader{
+				MessageID:            batch.Reference,
+				CreationDateTime:     batch.CreatedAt.UTC().Format(time.RFC3339),
+				NumberOfTransactions: strconv.Itoa(len(batch.Entries)),
+				ControlSum:           batch.ControlSum().StringFixed(2),
+				SettlementMethod:     "CLRG",
+			},
+		},
+	}
+
+	for _, entry := range batch.Entries {
+		reference := TransactionReference(entry.TransferID)
+
+		remittance := entry.Description
+		if len(remittance) > maxRemittanceLength {
+			remittance = remittance[:maxRemittanceLength]
+		}
+
+		document.Transfer.Transactions = append(document.Transfer.Transactions, pacs008CreditTransfer{
+			InstructionID:              reference,
+			EndToEndID:                 reference,
+			TransactionID:              reference,
+			InterbankSettlementAmount:  pacs008Amount{Currency: entry.Currency, Value: entry.Amount.StringFixed(2)},
+			InterbankSettlementDate:    entry.CompletedAt.UTC().Format(time.DateOnly),
+			ChargeBearer:               "SLEV",
+			DebtorName:                 entry.FromAccountName,
+			DebtorAccount:              entry.FromAccountNumber,
+			DebtorAgent:                notProvided,
+			CreditorAgent:              notProvided,
+			CreditorName:               entry.ToAccountName,
+			CreditorAccount:            entry.ToAccountNumber,
+			UnstructuredRemittanceInfo: remittance,
+		})
+	}
+
+	body, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,106 @@
+package settlement
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Format identifies the layout of a settlement file
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatPacs008 Format = "pacs008"
+)
+
+// ParseFormat validates a settlement file format name
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatPacs008:
+		return FormatPacs008, nil
+	default:
+		return "", fmt.Errorf("unsupported settlement file format: %s", value)
+	}
+}
+
+// ContentType returns the MIME type used when the file is downloaded
+func (format Format) ContentType() string {
+	if format == FormatPacs008 {
+		return "application/xml"
+	}
+
+	return "text/csv"
+}
+
+// Extension returns the file extension used when the file is downloaded
+func (format Format) Extension() string {
+	if format == FormatPacs008 {
+		return "xml"
+	}
+
+	return "csv"
+}
+
+// Entry is a completed transfer to be settled
+type Entry struct {
+	TransferID        string
+	FromAccountNumber string
+	FromAccountName   string
+	ToAccountNumber   string
+	ToAccountName     string
+	Amount            decimal.Decimal
+	Currency          string
+	Description       string
+	CompletedAt       time.Time
+}
+
+// Batch is the set of transfers written to a single settlement file
+type Batch struct {
+	Reference string
+	CreatedAt time.Time
+	Entries   []Entry
+}
+
+// ControlSum returns the sum of all entry amounts regardless of currency, as required by ISO 20022 CtrlSum
+func (batch Batch) ControlSum() decimal.Decimal {
+	sum := decimal.Zero
+	for _, entry := range batch.Entries {
+		sum = sum.Add(entry.Amount)
+	}
+
+	return sum
+}
+
+// Render writes the batch in the requested format
+func Render(format Format, batch Batch) ([]byte, error) {
+	if len(batch.Entries) == 0 {
+		return nil, fmt.Errorf("settlement batch %s has no entries", batch.Reference)
+	}
+
+	switch format {
+	case FormatCSV:
+		return RenderCSV(batch)
+	case FormatPacs008:
+		return RenderPacs008(batch)
+	default:
+		return nil, fmt.Errorf("unsupported settlement file format: %s", format)
+	}
+}
+
+// TransactionReference converts a transfer ID to the identifier written to the file.
+// ISO 20022 identifiers are limited to 35 characters, so the hyphens of UUID transfer IDs are dropped.
+func TransactionReference(transferID string) string {
+	return strings.ReplaceAll(transferID, "-", "")
+}
+
+// MatchesTransfer reports whether an identifier from an acknowledgement refers to the given transfer
+func MatchesTransfer(reference, transferID string) bool {
+	reference = strings.TrimSpace(reference)
+
+	return reference == transferID || reference == TransactionReference(transferID)
+}
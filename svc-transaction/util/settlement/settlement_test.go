@@ -0,0 +1,175 @@
+package settlement
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBatch() Batch {
+	completedAt := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+
+	return Batch{
+		Reference: "STL20250314093000AB12CD34",
+		CreatedAt: completedAt.Add(time.Hour),
+		Entries: []Entry{
+			{
+				TransferID:        "0f8fad5b-d9cb-469f-a165-70867728950e",
+				FromAccountNumber: "ACC001000001",
+				FromAccountName:   "Alice",
+				ToAccountNumber:   "ACC001000002",
+				ToAccountName:     "Bob",
+				Amount:            decimal.RequireFromString("100.50"),
+				Currency:          "USD",
+				Description:       "Rent, March",
+				CompletedAt:       completedAt,
+			},
+			{
+				TransferID:        "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+				FromAccountNumber: "ACC001000002",
+				FromAccountName:   "Bob",
+				ToAccountNumber:   "ACC001000003",
+				ToAccountName:     "Carol",
+				Amount:            decimal.RequireFromString("25"),
+				Currency:          "USD",
+				CompletedAt:       completedAt,
+			},
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       string
+		expected    Format
+		expectError bool
+	}{
+		{name: "csv", value: "csv", expected: FormatCSV},
+		{name: "pacs008_mixed_case", value: " PACS008 ", expected: FormatPacs008},
+		{name: "empty", value: "", expectError: true},
+		{name: "unknown", value: "mt103", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			format, err := ParseFormat(tt.value)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
+
+func TestMatchesTransfer(t *testing.T) {
+	t.Parallel()
+
+	transferID := "0f8fad5b-d9cb-469f-a165-70867728950e"
+
+	assert.True(t, MatchesTransfer(transferID, transferID))
+	assert.True(t, MatchesTransfer("0f8fad5bd9cb469fa16570867728950e", transferID))
+	assert.True(t, MatchesTransfer(" 0f8fad5bd9cb469fa16570867728950e ", transferID))
+	assert.False(t, MatchesTransfer("7c9e6679742540de944be07fc1f90ae7", transferID))
+}
+
+func TestRenderCSV(t *testing.T) {
+	t.Parallel()
+
+	content, err := Render(FormatCSV, testBatch())
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 4)
+
+	assert.True(t, strings.HasPrefix(lines[0], "file_reference,transaction_reference,transfer_id"))
+	assert.Contains(t, lines[1], "STL20250314093000AB12CD34,0f8fad5bd9cb469fa16570867728950e,0f8fad5b-d9cb-469f-a165-70867728950e")
+	assert.Contains(t, lines[1], `100.50,USD,"Rent, March"`)
+	assert.Equal(t, "TRAILER,2,125.50", lines[3])
+}
+
+func TestRenderPacs008(t *testing.T) {
+	t.Parallel()
+
+	content, err := Render(FormatPacs008, testBatch())
+	require.NoError(t, err)
+
+	document := string(content)
+	assert.Contains(t, document, Pacs008Namespace)
+	assert.Contains(t, document, "<MsgId>STL20250314093000AB12CD34</MsgId>")
+	assert.Contains(t, document, "<NbOfTxs>2</NbOfTxs>")
+	assert.Contains(t, document, "<CtrlSum>125.50</CtrlSum>")
+	assert.Contains(t, document, "<TxId>0f8fad5bd9cb469fa16570867728950e</TxId>")
+	assert.Contains(t, document, `<IntrBkSttlmAmt Ccy="USD">100.50</IntrBkSttlmAmt>`)
+	assert.Contains(t, document, "<IntrBkSttlmDt>2025-03-14</IntrBkSttlmDt>")
+	assert.NotContains(t, document, "<RmtInf><Ustrd></Ustrd></RmtInf>")
+}
+
+func TestRenderEmptyBatch(t *testing.T) {
+	t.Parallel()
+
+	_, err := Render(FormatCSV, Batch{Reference: "STL-EMPTY"})
+	assert.Error(t, err)
+}
+
+func TestParsePacs002(t *testing.T) {
+	t.Parallel()
+
+	t.Run("partial_rejection", func(t *testing.T) {
+		t.Parallel()
+
+		data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pacs.002.001.10">
+  <FIToFIPmtStsRpt>
+    <GrpHdr><MsgId>ACK-001</MsgId></GrpHdr>
+    <OrgnlGrpInfAndSts>
+      <OrgnlMsgId>STL20250314093000AB12CD34</OrgnlMsgId>
+      <GrpSts>PART</GrpSts>
+    </OrgnlGrpInfAndSts>
+    <TxInfAndSts>
+      <OrgnlEndToEndId>7c9e6679742540de944be07fc1f90ae7</OrgnlEndToEndId>
+      <TxSts>RJCT</TxSts>
+      <StsRsnInf><AddtlInf>Account closed</AddtlInf></StsRsnInf>
+    </TxInfAndSts>
+  </FIToFIPmtStsRpt>
+</Document>`)
+
+		acknowledgement, err := ParsePacs002(data)
+		require.NoError(t, err)
+
+		assert.Equal(t, "ACK-001", acknowledgement.MessageID)
+		assert.Equal(t, "STL20250314093000AB12CD34", acknowledgement.OriginalFileReference)
+		assert.False(t, Rejected(acknowledgement.GroupStatus))
+		require.Len(t, acknowledgement.Transactions, 1)
+		assert.Equal(t, "7c9e6679742540de944be07fc1f90ae7", acknowledgement.Transactions[0].Reference)
+		assert.True(t, Rejected(acknowledgement.Transactions[0].Status))
+		assert.Equal(t, "Account closed", acknowledgement.Transactions[0].Reason)
+	})
+
+	t.Run("missing_original_message_id", func(t *testing.T) {
+		t.Parallel()
+
+		data := []byte(`<Document><FIToFIPmtStsRpt><OrgnlGrpInfAndSts><GrpSts>ACCP</GrpSts></OrgnlGrpInfAndSts></FIToFIPmtStsRpt></Document>`)
+
+		_, err := ParsePacs002(data)
+		assert.Error(t, err)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParsePacs002([]byte("not xml"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,198 @@
+// Package workerinterceptor applies the concerns every Temporal activity shares, so activities only hold their own
+// logic: a logger with the activity fields, a request ID, the latency and failure simulation hooks, a start
+// heartbeat, execution metrics and panic recovery.
+package workerinterceptor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"svc-transaction/util/crash"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/temporal"
+)
+
+// FailureHook injects a simulated failure before an activity runs. operation is the activity type and accountID
+// the account_id of the activity parameters, empty when they have none.
+type FailureHook func(ctx context.Context, operation string, accountID string) error
+
+// LatencyHook delays an activity before it runs, calling heartbeat while it waits so the activity can stay
+// within its heartbeat timeout
+type LatencyHook func(ctx context.Context, operation string, heartbeat func()) error
+
+// Options configures the interceptor
+type Options struct {
+	Logger *logrus.Logger
+
+	// FailureHook is called before every activity; nil disables failure simulation
+	FailureHook FailureHook
+
+	// LatencyHook is called before every activity, ahead of FailureHook; nil disables simulated latency
+	LatencyHook LatencyHook
+}
+
+// paramFields are the activity parameters copied into the log fields when present
+var paramFields = []string{"account_id", "transfer_id"}
+
+type contextKey int
+
+const (
+	loggerKey contextKey = iota
+	requestIDKey
+)
+
+// New returns the worker interceptor for the activities of this service
+func New(options Options) interceptor.WorkerInterceptor {
+	return &workerInterceptor{options: options}
+}
+
+// Logger returns the logger prepared for the running activity, or fallback outside an intercepted activity
+func Logger(ctx context.Context, fallback *logrus.Logger) *logrus.Entry {
+	if logger, ok := ctx.Value(loggerKey).(*logrus.Entry); ok {
+		return logger
+	}
+
+	return logrus.NewEntry(fallback)
+}
+
+// RequestID returns the request ID of the running activity attempt, or "" outside an intercepted activity.
+// It is "<workflow_id>/<activity_id>/<attempt>", so the logs of one attempt can be told from those of its retries.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+
+	return requestID
+}
+
+type workerInterceptor struct {
+	interceptor.WorkerInterceptorBase
+
+	options Options
+}
+
+func (i *workerInterceptor) InterceptActivity(ctx context.Context, next interceptor.ActivityInboundInterceptor) interceptor.ActivityInboundInterceptor {
+	return &activityInterceptor{
+		ActivityInboundInterceptorBase: interceptor.ActivityInboundInterceptorBase{Next: next},
+		options:                        i.options,
+	}
+}
+
+type activityInterceptor struct {
+	interceptor.ActivityInboundInterceptorBase
+
+	options Options
+}
+
+// ExecuteActivity runs the activity with its logger and request ID in ctx. A panic is turned into a retryable
+// INTERNAL application error, so Temporal retries the activity as usual.
+func (a *activityInterceptor) ExecuteActivity(ctx context.Context, in *interceptor.ExecuteActivityInput) (result any, err error) {
+	info := activity.GetInfo(ctx)
+	activityType := info.ActivityType.Name
+	requestID := fmt.Sprintf("%s/%s/%d", info.WorkflowExecution.ID, info.ActivityID, info.Attempt)
+
+	fields := logrus.Fields{
+		"[op]":          "activity.Activity." + activityType,
+		"activity_id":   info.ActivityID,
+		"activity_type": activityType,
+		"workflow_id":   info.WorkflowExecution.ID,
+		"run_id":        info.WorkflowExecution.RunID,
+		"attempt":       info.Attempt,
+		"request_id":    requestID,
+	}
+	params := activityParams(in.Args)
+	for _, name := range paramFields {
+		if value := params[name]; value != "" {
+			fields[name] = value
+		}
+	}
+	logger := a.options.Logger.WithFields(fields)
+
+	ctx = context.WithValue(ctx, loggerKey, logger)
+	ctx = context.WithValue(ctx, requestIDKey, requestID)
+
+	// The scheduled time of a retry is that of the first attempt, so only first attempts tell how long tasks wait
+	// for a worker
+	if info.Attempt == 1 && !info.ScheduledTime.IsZero() && !info.StartedTime.Before(info.ScheduledTime) {
+		observeScheduleToStart(activityType, info.StartedTime.Sub(info.ScheduledTime))
+	}
+
+	started := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			panicErr := crash.Report(logger, r)
+
+			result = nil
+			err = temporal.NewApplicationErrorWithCause("activity panicked", "INTERNAL", panicErr)
+			observe(activityType, OutcomePanicked, time.Since(started))
+		}
+	}()
+
+	logger.Info("Starting activity")
+
+	activity.RecordHeartbeat(ctx, activityType+"_started")
+
+	if a.options.LatencyHook != nil {
+		heartbeat := func() { activity.RecordHeartbeat(ctx, activityType+"_waiting") }
+		if err := a.options.LatencyHook(ctx, activityType, heartbeat); err != nil {
+			logger.WithError(err).Warn("Simulated latency interrupted")
+			observe(activityType, OutcomeFailed, time.Since(started))
+
+			return nil, err
+		}
+	}
+
+	if a.options.FailureHook != nil {
+		if err := a.options.FailureHook(ctx, activityType, params["account_id"]); err != nil {
+			logger.WithError(err).Warn("🚨 Failure simulation triggered")
+			observe(activityType, OutcomeFailed, time.Since(started))
+
+			return nil, err
+		}
+	}
+
+	result, err = a.Next.ExecuteActivity(ctx, in)
+
+	duration := time.Since(started)
+	if err != nil {
+		observe(activityType, OutcomeFailed, duration)
+		logger.WithError(err).WithField("duration", duration).Warn("Activity failed")
+
+		return result, err
+	}
+
+	observe(activityType, OutcomeCompleted, duration)
+	logger.WithField("duration", duration).Info("Activity completed")
+
+	return result, nil
+}
+
+// activityParams returns the string fields of the first activity argument, read through its JSON encoding
+// so any parameter struct works without implementing an interface
+func activityParams(args []any) map[string]string {
+	params := map[string]string{}
+	if len(args) == 0 {
+		return params
+	}
+
+	encoded, err := json.Marshal(args[0])
+	if err != nil {
+		return params
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return params
+	}
+
+	for name, value := range decoded {
+		if text, ok := value.(string); ok {
+			params[name] = text
+		}
+	}
+
+	return params
+}
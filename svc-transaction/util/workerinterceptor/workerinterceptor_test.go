@@ -0,0 +1,233 @@
+package workerinterceptor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/interceptor"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/worker"
+)
+
+type testParams struct {
+	AccountID  string `json:"account_id"`
+	TransferID string `json:"transfer_id"`
+}
+
+func newTestEnvironment(t *testing.T, hook FailureHook) *testsuite.TestActivityEnvironment {
+	t.Helper()
+
+	return newTestEnvironmentWithOptions(t, Options{FailureHook: hook})
+}
+
+func newTestEnvironmentWithOptions(t *testing.T, options Options) *testsuite.TestActivityEnvironment {
+	t.Helper()
+
+	options.Logger = logrus.New()
+	options.Logger.SetOutput(io.Discard)
+
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestActivityEnvironment()
+	env.SetWorkerOptions(worker.Options{
+		Interceptors: []interceptor.WorkerInterceptor{New(options)},
+	})
+
+	return env
+}
+
+func executions(activityType, outcome string) int64 {
+	for _, entry := range Read() {
+		if entry.ActivityType == activityType {
+			return entry.Executions[outcome]
+		}
+	}
+
+	return 0
+}
+
+func TestActivityContext(t *testing.T) {
+	env := newTestEnvironment(t, nil)
+
+	var fields logrus.Fields
+	var requestID string
+	ContextActivity := func(ctx context.Context, params testParams) error {
+		fields = Logger(ctx, nil).Data
+		requestID = RequestID(ctx)
+		return nil
+	}
+	env.RegisterActivityWithOptions(ContextActivity, activity.RegisterOptions{Name: "ContextActivity"})
+
+	before := executions("ContextActivity", OutcomeCompleted)
+
+	if _, err := env.ExecuteActivity("ContextActivity", testParams{AccountID: "account-1", TransferID: "transfer-1"}); err != nil {
+		t.Fatalf("ExecuteActivity() error = %v", err)
+	}
+
+	if fields["[op]"] != "activity.Activity.ContextActivity" {
+		t.Errorf("[op] = %v, want activity.Activity.ContextActivity", fields["[op]"])
+	}
+	if fields["account_id"] != "account-1" || fields["transfer_id"] != "transfer-1" {
+		t.Errorf("account_id, transfer_id = %v, %v, want account-1, transfer-1", fields["account_id"], fields["transfer_id"])
+	}
+	if requestID == "" || fields["request_id"] != requestID {
+		t.Errorf("request_id field = %v, RequestID() = %q, want the same non-empty ID", fields["request_id"], requestID)
+	}
+	if got := executions("ContextActivity", OutcomeCompleted) - before; got != 1 {
+		t.Errorf("completed executions increased by %d, want 1", got)
+	}
+}
+
+func TestFailureHook(t *testing.T) {
+	simulated := errors.New("simulated failure")
+
+	var hookOperation, hookAccount string
+	env := newTestEnvironment(t, func(_ context.Context, operation string, accountID string) error {
+		hookOperation, hookAccount = operation, accountID
+		return simulated
+	})
+
+	ran := false
+	HookedActivity := func(context.Context, testParams) error {
+		ran = true
+		return nil
+	}
+	env.RegisterActivityWithOptions(HookedActivity, activity.RegisterOptions{Name: "HookedActivity"})
+
+	before := executions("HookedActivity", OutcomeFailed)
+
+	if _, err := env.ExecuteActivity("HookedActivity", testParams{AccountID: "account-2"}); err == nil {
+		t.Fatal("ExecuteActivity() error = nil, want the simulated failure")
+	}
+
+	if ran {
+		t.Error("activity ran although the failure hook failed it")
+	}
+	if hookOperation != "HookedActivity" || hookAccount != "account-2" {
+		t.Errorf("hook called with %q, %q, want HookedActivity, account-2", hookOperation, hookAccount)
+	}
+	if got := executions("HookedActivity", OutcomeFailed) - before; got != 1 {
+		t.Errorf("failed executions increased by %d, want 1", got)
+	}
+}
+
+func TestLatencyHookRunsBeforeFailureHook(t *testing.T) {
+	var calls []string
+	env := newTestEnvironmentWithOptions(t, Options{
+		LatencyHook: func(_ context.Context, operation string, heartbeat func()) error {
+			heartbeat()
+			calls = append(calls, "latency:"+operation)
+			return nil
+		},
+		FailureHook: func(_ context.Context, operation string, _ string) error {
+			calls = append(calls, "failure:"+operation)
+			return nil
+		},
+	})
+
+	DelayedActivity := func(context.Context, testParams) error {
+		calls = append(calls, "activity")
+		return nil
+	}
+	env.RegisterActivityWithOptions(DelayedActivity, activity.RegisterOptions{Name: "DelayedActivity"})
+
+	if _, err := env.ExecuteActivity("DelayedActivity", testParams{}); err != nil {
+		t.Fatalf("ExecuteActivity() error = %v", err)
+	}
+
+	want := []string{"latency:DelayedActivity", "failure:DelayedActivity", "activity"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("calls = %v, want %v", calls, want)
+			break
+		}
+	}
+}
+
+func TestPanicIsRecovered(t *testing.T) {
+	env := newTestEnvironment(t, nil)
+
+	PanickingActivity := func(context.Context, testParams) error {
+		panic("boom")
+	}
+	env.RegisterActivityWithOptions(PanickingActivity, activity.RegisterOptions{Name: "PanickingActivity"})
+
+	before := executions("PanickingActivity", OutcomePanicked)
+
+	_, err := env.ExecuteActivity("PanickingActivity", testParams{})
+
+	var applicationErr *temporal.ApplicationError
+	if !errors.As(err, &applicationErr) || applicationErr.Type() != "INTERNAL" {
+		t.Fatalf("ExecuteActivity() error = %v, want an INTERNAL application error", err)
+	}
+	if got := executions("PanickingActivity", OutcomePanicked) - before; got != 1 {
+		t.Errorf("panicked executions increased by %d, want 1", got)
+	}
+}
+
+func TestLoggerFallback(t *testing.T) {
+	fallback := logrus.New()
+
+	if got := Logger(context.Background(), fallback); got.Logger != fallback || len(got.Data) != 0 {
+		t.Errorf("Logger() = %+v, want an empty entry of the fallback logger", got)
+	}
+	if got := RequestID(context.Background()); got != "" {
+		t.Errorf("RequestID() = %q, want empty", got)
+	}
+}
+
+func TestRecentLatencies(t *testing.T) {
+	env := newTestEnvironment(t, nil)
+
+	RecentActivity := func(context.Context, testParams) error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}
+	env.RegisterActivityWithOptions(RecentActivity, activity.RegisterOptions{Name: "RecentActivity"})
+
+	if _, err := env.ExecuteActivity("RecentActivity", testParams{}); err != nil {
+		t.Fatalf("ExecuteActivity() error = %v", err)
+	}
+
+	for _, latency := range Recent(time.Minute) {
+		if latency.ActivityType != "RecentActivity" {
+			continue
+		}
+
+		if latency.Executions != 1 || latency.AvgDurationSeconds < 0.01 || latency.MaxDurationSeconds < latency.AvgDurationSeconds {
+			t.Errorf("Recent() = %+v, want one execution of at least 10ms", latency)
+		}
+		return
+	}
+
+	t.Errorf("Recent() has no latencies of RecentActivity")
+}
+
+func TestAppendSampleDropsOldSamples(t *testing.T) {
+	now := time.Now()
+
+	samples := []sample{
+		{at: now.Add(-maxRecentWindow - time.Second), latency: time.Second},
+		{at: now.Add(-time.Minute), latency: 2 * time.Second},
+	}
+	samples = appendSample(samples, sample{at: now, latency: 3 * time.Second})
+
+	if len(samples) != 2 || samples[0].latency != 2*time.Second {
+		t.Errorf("appendSample() = %+v, want the sample older than the window dropped", samples)
+	}
+
+	for i := 0; i < maxRecentSamples+10; i++ {
+		samples = appendSample(samples, sample{at: now, latency: time.Millisecond})
+	}
+	if len(samples) != maxRecentSamples {
+		t.Errorf("appendSample() kept %d samples, want %d", len(samples), maxRecentSamples)
+	}
+}
@@ -0,0 +1,158 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"svc-transaction/activity"
+	"svc-transaction/service"
+
+	"github.com/shopspring/decimal"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// accountClosureDrainPollInterval is how often AccountClosureWorkflow re-counts in-flight transfers while draining
+const accountClosureDrainPollInterval = 15 * time.Second
+
+// AccountClosureWorkflowResults summarises a completed account closure
+type AccountClosureWorkflowResults struct {
+	AccountID       string          `json:"account_id"`
+	PreviousStatus  string          `json:"previous_status"`
+	DrainChecks     int             `json:"drain_checks"`
+	SweepTransferID string          `json:"sweep_transfer_id,omitempty"`
+	SweptAmount     decimal.Decimal `json:"swept_amount"`
+	Currency        string          `json:"currency"`
+}
+
+// AccountClosureWorkflow closes an account in four steps, each recorded in core.account_closure_audit_trail:
+//  1. block: suspend the account so no new transfer can debit or credit it
+//  2. drain: wait until no transfer workflow involving the account is running
+//  3. sweep: move the remaining balance to the sweep account
+//  4. close: mark the account closed
+//
+// If the transfers do not drain within the drain timeout, or a later step fails, the account gets its
+// previous status back and the workflow fails.
+func AccountClosureWorkflow(ctx workflow.Context, params service.AccountClosureWorkflowParams) (*AccountClosureWorkflowResults, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting AccountClosureWorkflow", "account_id", params.AccountID, "sweep_account_id", params.SweepAccountID)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    time.Minute,
+			MaximumAttempts:    5,
+		},
+	})
+
+	info := workflow.GetInfo(ctx)
+	activityParams := activity.AccountClosureActivityParams{
+		AccountID:      params.AccountID,
+		SweepAccountID: params.SweepAccountID,
+		WorkflowID:     info.WorkflowExecution.ID,
+		RunID:          info.WorkflowExecution.RunID,
+	}
+
+	// Step 1: Block new transfers
+	var blocked activity.BlockAccountForClosureActivityResults
+	if err := workflow.ExecuteActivity(ctx, "BlockAccountForClosure", activityParams).Get(ctx, &blocked); err != nil {
+		logger.Error("Failed to block account", "error", err)
+		return nil, err
+	}
+
+	results := &AccountClosureWorkflowResults{
+		AccountID:      params.AccountID,
+		PreviousStatus: blocked.PreviousStatus,
+	}
+
+	// From here on a failure must give the account its previous status back, even when the workflow was cancelled
+	reopen := func(cause error) error {
+		reopenCtx, _ := workflow.NewDisconnectedContext(ctx)
+
+		reopenParams := activity.ReopenAccountActivityParams{
+			AccountClosureActivityParams: activityParams,
+			PreviousStatus:               blocked.PreviousStatus,
+			Reason:                       cause.Error(),
+		}
+		if err := workflow.ExecuteActivity(reopenCtx, "ReopenAccount", reopenParams).Get(reopenCtx, nil); err != nil {
+			logger.Error("Failed to reopen account, it stays blocked", "error", err)
+			return errors.Join(cause, err)
+		}
+
+		return cause
+	}
+
+	// Step 2: Wait for in-flight transfers to finish
+	drainTimeout := params.DrainTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = service.DefaultAccountClosureDrainTimeout
+	}
+	drainStarted := workflow.Now(ctx)
+	deadline := drainStarted.Add(drainTimeout)
+
+	for {
+		var inFlight activity.CountInFlightTransfersActivityResults
+		if err := workflow.ExecuteActivity(ctx, "CountInFlightTransfers", activityParams).Get(ctx, &inFlight); err != nil {
+			logger.Error("Failed to count in-flight transfers", "error", err)
+			return nil, reopen(err)
+		}
+		results.DrainChecks++
+
+		if inFlight.Count == 0 {
+			break
+		}
+
+		remaining := deadline.Sub(workflow.Now(ctx))
+		if remaining <= 0 {
+			err := temporal.NewNonRetryableApplicationError(
+				fmt.Sprintf("%d transfers still in flight after %s", inFlight.Count, drainTimeout),
+				"DRAIN_TIMEOUT",
+				nil,
+			)
+			logger.Warn("In-flight transfers did not drain in time", "in_flight", inFlight.Count)
+			return nil, reopen(err)
+		}
+
+		logger.Info("Waiting for in-flight transfers", "in_flight", inFlight.Count)
+		if err := workflow.Sleep(ctx, min(accountClosureDrainPollInterval, remaining)); err != nil {
+			return nil, reopen(err)
+		}
+	}
+
+	drained := activity.RecordAccountClosureStepActivityParams{
+		AccountClosureActivityParams: activityParams,
+		Step:                         service.AccountClosureStepDrained,
+		Details: map[string]any{
+			"checks":     results.DrainChecks,
+			"waited_ms":  workflow.Now(ctx).Sub(drainStarted).Milliseconds(),
+			"timeout_ms": drainTimeout.Milliseconds(),
+		},
+	}
+	if err := workflow.ExecuteActivity(ctx, "RecordAccountClosureStep", drained).Get(ctx, nil); err != nil {
+		logger.Error("Failed to record drained step", "error", err)
+		return nil, reopen(err)
+	}
+
+	// Step 3: Sweep the remaining balance
+	var swept activity.SweepAccountBalanceActivityResults
+	if err := workflow.ExecuteActivity(ctx, "SweepAccountBalance", activityParams).Get(ctx, &swept); err != nil {
+		logger.Error("Failed to sweep account balance", "error", err)
+		return nil, reopen(err)
+	}
+	results.SweepTransferID = swept.TransferID
+	results.SweptAmount = swept.Amount
+	results.Currency = swept.Currency
+
+	// Step 4: Mark the account closed
+	if err := workflow.ExecuteActivity(ctx, "CloseAccount", activityParams).Get(ctx, nil); err != nil {
+		logger.Error("Failed to close account", "error", err)
+		return nil, reopen(err)
+	}
+
+	logger.Info("AccountClosureWorkflow completed", "account_id", params.AccountID, "swept_amount", swept.Amount.String(), "drain_checks", results.DrainChecks)
+
+	return results, nil
+}
@@ -0,0 +1,184 @@
+package worker
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"svc-transaction/activity"
+	"svc-transaction/service"
+	"svc-transaction/store/memory"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/mocks"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+// accountClosureActivities stands in for activity.Activity so the workflow can run without a database
+type accountClosureActivities struct{}
+
+func (*accountClosureActivities) BlockAccountForClosure(context.Context, activity.AccountClosureActivityParams) (*activity.BlockAccountForClosureActivityResults, error) {
+	return nil, nil
+}
+
+func (*accountClosureActivities) CountInFlightTransfers(context.Context, activity.AccountClosureActivityParams) (*activity.CountInFlightTransfersActivityResults, error) {
+	return nil, nil
+}
+
+func (*accountClosureActivities) RecordAccountClosureStep(context.Context, activity.RecordAccountClosureStepActivityParams) error {
+	return nil
+}
+
+func (*accountClosureActivities) SweepAccountBalance(context.Context, activity.AccountClosureActivityParams) (*activity.SweepAccountBalanceActivityResults, error) {
+	return nil, nil
+}
+
+func (*accountClosureActivities) CloseAccount(context.Context, activity.AccountClosureActivityParams) error {
+	return nil
+}
+
+func (*accountClosureActivities) ReopenAccount(context.Context, activity.ReopenAccountActivityParams) error {
+	return nil
+}
+
+func newAccountClosureTestEnv(t *testing.T) *testsuite.TestWorkflowEnvironment {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterActivity(&accountClosureActivities{})
+
+	t.Cleanup(func() { env.AssertExpectations(t) })
+
+	return env
+}
+
+func accountClosureParams() service.AccountClosureWorkflowParams {
+	return service.AccountClosureWorkflowParams{
+		AccountID:      "550e8400-e29b-41d4-a716-446655440001",
+		SweepAccountID: "550e8400-e29b-41d4-a716-446655440002",
+		DrainTimeout:   time.Minute,
+	}
+}
+
+func TestAccountClosureWorkflowDrainsAndSweeps(t *testing.T) {
+	t.Parallel()
+
+	env := newAccountClosureTestEnv(t)
+
+	env.OnActivity("BlockAccountForClosure", mock.Anything, mock.Anything).
+		Return(&activity.BlockAccountForClosureActivityResults{PreviousStatus: "active"}, nil).Once()
+	// One transfer is still running on the first check and has finished on the second
+	env.OnActivity("CountInFlightTransfers", mock.Anything, mock.Anything).
+		Return(&activity.CountInFlightTransfersActivityResults{Count: 1}, nil).Once()
+	env.OnActivity("CountInFlightTransfers", mock.Anything, mock.Anything).
+		Return(&activity.CountInFlightTransfersActivityResults{Count: 0}, nil).Once()
+	env.OnActivity("RecordAccountClosureStep", mock.Anything, mock.MatchedBy(func(params activity.RecordAccountClosureStepActivityParams) bool {
+		return params.Step == service.AccountClosureStepDrained
+	})).Return(nil).Once()
+	env.OnActivity("SweepAccountBalance", mock.Anything, mock.Anything).
+		Return(&activity.SweepAccountBalanceActivityResults{TransferID: "closure-sweep-run", Amount: decimal.RequireFromString("125.50"), Currency: "USD"}, nil).Once()
+	env.OnActivity("CloseAccount", mock.Anything, mock.Anything).Return(nil).Once()
+
+	env.ExecuteWorkflow(AccountClosureWorkflow, accountClosureParams())
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var results AccountClosureWorkflowResults
+	require.NoError(t, env.GetWorkflowResult(&results))
+	assert.Equal(t, "active", results.PreviousStatus)
+	assert.Equal(t, 2, results.DrainChecks)
+	assert.Equal(t, "closure-sweep-run", results.SweepTransferID)
+	assert.Equal(t, "125.5", results.SweptAmount.String())
+}
+
+func TestAccountClosureWorkflowReopensAfterDrainTimeout(t *testing.T) {
+	t.Parallel()
+
+	env := newAccountClosureTestEnv(t)
+
+	env.OnActivity("BlockAccountForClosure", mock.Anything, mock.Anything).
+		Return(&activity.BlockAccountForClosureActivityResults{PreviousStatus: "inactive"}, nil).Once()
+	env.OnActivity("CountInFlightTransfers", mock.Anything, mock.Anything).
+		Return(&activity.CountInFlightTransfersActivityResults{Count: 2}, nil)
+	env.OnActivity("ReopenAccount", mock.Anything, mock.MatchedBy(func(params activity.ReopenAccountActivityParams) bool {
+		return params.PreviousStatus == "inactive"
+	})).Return(nil).Once()
+
+	env.ExecuteWorkflow(AccountClosureWorkflow, accountClosureParams())
+
+	require.True(t, env.IsWorkflowCompleted())
+
+	var applicationErr *temporal.ApplicationError
+	require.ErrorAs(t, env.GetWorkflowError(), &applicationErr)
+	assert.Equal(t, "DRAIN_TIMEOUT", applicationErr.Type())
+
+	env.AssertActivityNotCalled(t, "SweepAccountBalance", mock.Anything, mock.Anything)
+	env.AssertActivityNotCalled(t, "CloseAccount", mock.Anything, mock.Anything)
+}
+
+func TestAccountClosureWorkflowDoesNotReopenWhenBlockIsRejected(t *testing.T) {
+	t.Parallel()
+
+	env := newAccountClosureTestEnv(t)
+
+	env.OnActivity("BlockAccountForClosure", mock.Anything, mock.Anything).
+		Return(nil, temporal.NewNonRetryableApplicationError("account is already closed", "ACCOUNT_CLOSURE_REJECTED", nil)).Once()
+
+	env.ExecuteWorkflow(AccountClosureWorkflow, accountClosureParams())
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.Error(t, env.GetWorkflowError())
+
+	env.AssertActivityNotCalled(t, "CountInFlightTransfers", mock.Anything, mock.Anything)
+	env.AssertActivityNotCalled(t, "ReopenAccount", mock.Anything, mock.Anything)
+}
+
+func TestAccountClosureWorkflowWaitsForTransferStartedByAccountNumber(t *testing.T) {
+	t.Parallel()
+
+	// A transfer flowngine started from ACC001 by account number, as the gateway requires, is still running
+	temporalClient := &mocks.Client{}
+	temporalClient.On("CountWorkflow", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, request *workflowservice.CountWorkflowExecutionsRequest) *workflowservice.CountWorkflowExecutionsResponse {
+			if strings.Contains(request.Query, "TransferSourceAccount IN (") && strings.Contains(request.Query, "'ACC001'") {
+				return &workflowservice.CountWorkflowExecutionsResponse{Count: 1}
+			}
+			return &workflowservice.CountWorkflowExecutionsResponse{}
+		}, nil)
+
+	svc := service.NewService(logrus.New(), memory.NewStore(logrus.New(), true))
+	svc.SetTemporalClient(temporalClient, "transaction-service")
+
+	env := newAccountClosureTestEnv(t)
+
+	env.OnActivity("BlockAccountForClosure", mock.Anything, mock.Anything).
+		Return(&activity.BlockAccountForClosureActivityResults{PreviousStatus: "active"}, nil).Once()
+	env.OnActivity("CountInFlightTransfers", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, params activity.AccountClosureActivityParams) (*activity.CountInFlightTransfersActivityResults, error) {
+			count, err := svc.CountInFlightTransfers(ctx, uuid.MustParse(params.AccountID))
+			if err != nil {
+				return nil, err
+			}
+			return &activity.CountInFlightTransfersActivityResults{Count: count}, nil
+		})
+	env.OnActivity("ReopenAccount", mock.Anything, mock.Anything).Return(nil).Once()
+
+	env.ExecuteWorkflow(AccountClosureWorkflow, accountClosureParams())
+
+	require.True(t, env.IsWorkflowCompleted())
+
+	var applicationErr *temporal.ApplicationError
+	require.ErrorAs(t, env.GetWorkflowError(), &applicationErr)
+	assert.Equal(t, "DRAIN_TIMEOUT", applicationErr.Type())
+
+	env.AssertActivityNotCalled(t, "SweepAccountBalance", mock.Anything, mock.Anything)
+	env.AssertActivityNotCalled(t, "CloseAccount", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+
+	"svc-transaction/activity"
+	"svc-transaction/service"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// AccountErasureWorkflowResults summarises a completed account erasure
+type AccountErasureWorkflowResults struct {
+	AccountID     string             `json:"account_id"`
+	CertificateID string             `json:"certificate_id"`
+	Digest        string             `json:"digest"`
+	Scrubbed      service.ErasedRows `json:"scrubbed"`
+	ErasedAt      time.Time          `json:"erased_at"`
+}
+
+// AccountErasureWorkflow erases the personal data of a closed account once its retention period has elapsed:
+//  1. anonymize: scrub the account name, descriptions, caller-supplied metadata and compensation reasons
+//  2. verify: check that no row of the account still holds personal data
+//  3. certify: record an erasure certificate in core.account_erasure_certificates
+//
+// The account and its amounts stay in place, so balances and the balance history remain verifiable.
+// No certificate is issued when verification finds personal data left.
+func AccountErasureWorkflow(ctx workflow.Context, params service.AccountErasureWorkflowParams) (*AccountErasureWorkflowResults, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting AccountErasureWorkflow", "account_id", params.AccountID)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    time.Minute,
+			MaximumAttempts:    5,
+		},
+	})
+
+	info := workflow.GetInfo(ctx)
+	activityParams := activity.AccountErasureActivityParams{
+		AccountID:  params.AccountID,
+		WorkflowID: info.WorkflowExecution.ID,
+		RunID:      info.WorkflowExecution.RunID,
+	}
+
+	// Step 1: Scrub personal data
+	var anonymized activity.AnonymizeAccountDataActivityResults
+	if err := workflow.ExecuteActivity(ctx, "AnonymizeAccountData", activityParams).Get(ctx, &anonymized); err != nil {
+		logger.Error("Failed to anonymize account data", "error", err)
+		return nil, err
+	}
+
+	// Step 2: Verify nothing is left
+	var verified activity.VerifyAccountErasureActivityResults
+	if err := workflow.ExecuteActivity(ctx, "VerifyAccountErasure", activityParams).Get(ctx, &verified); err != nil {
+		logger.Error("Failed to verify account erasure", "error", err)
+		return nil, err
+	}
+	if remaining := verified.Remaining.Total(); remaining > 0 {
+		logger.Error("Personal data left after anonymization", "remaining", verified.Remaining)
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("%d rows still hold personal data: %+v", remaining, verified.Remaining),
+			"ERASURE_VERIFICATION_FAILED",
+			nil,
+		)
+	}
+
+	// Step 3: Record the erasure certificate
+	certificateParams := activity.IssueAccountErasureCertificateActivityParams{
+		AccountErasureActivityParams: activityParams,
+		RequestedBy:                  params.RequestedBy,
+		ClosedAt:                     anonymized.ClosedAt,
+		Scrubbed:                     anonymized.Scrubbed,
+	}
+	var certificate activity.IssueAccountErasureCertificateActivityResults
+	if err := workflow.ExecuteActivity(ctx, "IssueAccountErasureCertificate", certificateParams).Get(ctx, &certificate); err != nil {
+		logger.Error("Failed to issue account erasure certificate", "error", err)
+		return nil, err
+	}
+
+	logger.Info("AccountErasureWorkflow completed", "account_id", params.AccountID, "certificate_id", certificate.CertificateID)
+
+	return &AccountErasureWorkflowResults{
+		AccountID:     params.AccountID,
+		CertificateID: certificate.CertificateID,
+		Digest:        certificate.Digest,
+		Scrubbed:      anonymized.Scrubbed,
+		ErasedAt:      certificate.ErasedAt,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"svc-transaction/activity"
+	"svc-transaction/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+// accountErasureActivities stands in for activity.Activity so the workflow can run without a database
+type accountErasureActivities struct{}
+
+func (*accountErasureActivities) AnonymizeAccountData(context.Context, activity.AccountErasureActivityParams) (*activity.AnonymizeAccountDataActivityResults, error) {
+	return nil, nil
+}
+
+func (*accountErasureActivities) VerifyAccountErasure(context.Context, activity.AccountErasureActivityParams) (*activity.VerifyAccountErasureActivityResults, error) {
+	return nil, nil
+}
+
+func (*accountErasureActivities) IssueAccountErasureCertificate(context.Context, activity.IssueAccountErasureCertificateActivityParams) (*activity.IssueAccountErasureCertificateActivityResults, error) {
+	return nil, nil
+}
+
+func newAccountErasureTestEnv(t *testing.T) *testsuite.TestWorkflowEnvironment {
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterActivity(&accountErasureActivities{})
+
+	t.Cleanup(func() { env.AssertExpectations(t) })
+
+	return env
+}
+
+func accountErasureParams() service.AccountErasureWorkflowParams {
+	return service.AccountErasureWorkflowParams{
+		AccountID:   "550e8400-e29b-41d4-a716-446655440001",
+		RequestedBy: "dpo@example.com",
+	}
+}
+
+func TestAccountErasureWorkflowIssuesCertificate(t *testing.T) {
+	t.Parallel()
+
+	env := newAccountErasureTestEnv(t)
+
+	closedAt := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	scrubbed := service.ErasedRows{Accounts: 1, Transactions: 3, Transfers: 1}
+
+	env.OnActivity("AnonymizeAccountData", mock.Anything, mock.Anything).
+		Return(&activity.AnonymizeAccountDataActivityResults{ClosedAt: closedAt, Scrubbed: scrubbed}, nil).Once()
+	env.OnActivity("VerifyAccountErasure", mock.Anything, mock.Anything).
+		Return(&activity.VerifyAccountErasureActivityResults{}, nil).Once()
+	env.OnActivity("IssueAccountErasureCertificate", mock.Anything, mock.MatchedBy(func(params activity.IssueAccountErasureCertificateActivityParams) bool {
+		return params.ClosedAt.Equal(closedAt) && params.Scrubbed == scrubbed && params.RequestedBy == "dpo@example.com"
+	})).Return(&activity.IssueAccountErasureCertificateActivityResults{CertificateID: "certificate", Digest: "digest"}, nil).Once()
+
+	env.ExecuteWorkflow(AccountErasureWorkflow, accountErasureParams())
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+
+	var results AccountErasureWorkflowResults
+	require.NoError(t, env.GetWorkflowResult(&results))
+	assert.Equal(t, "certificate", results.CertificateID)
+	assert.Equal(t, scrubbed, results.Scrubbed)
+}
+
+func TestAccountErasureWorkflowFailsWhenDataRemains(t *testing.T) {
+	t.Parallel()
+
+	env := newAccountErasureTestEnv(t)
+
+	env.OnActivity("AnonymizeAccountData", mock.Anything, mock.Anything).
+		Return(&activity.AnonymizeAccountDataActivityResults{}, nil).Once()
+	env.OnActivity("VerifyAccountErasure", mock.Anything, mock.Anything).
+		Return(&activity.VerifyAccountErasureActivityResults{Remaining: service.ErasedRows{Transfers: 1}}, nil).Once()
+
+	env.ExecuteWorkflow(AccountErasureWorkflow, accountErasureParams())
+
+	require.True(t, env.IsWorkflowCompleted())
+
+	var applicationErr *temporal.ApplicationError
+	require.ErrorAs(t, env.GetWorkflowError(), &applicationErr)
+	assert.Equal(t, "ERASURE_VERIFICATION_FAILED", applicationErr.Type())
+
+	env.AssertActivityNotCalled(t, "IssueAccountErasureCertificate", mock.Anything, mock.Anything)
+}
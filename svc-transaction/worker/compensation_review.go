@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"svc-transaction/activity"
+	"svc-transaction/service"
+	"svc-transaction/util/config"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	compensationReviewScheduleID = "compensation-review-schedule"
+	compensationReviewWorkflowID = "compensation-review-workflow"
+)
+
+// CompensationReviewWorkflow closes the loop on the compensations their workflow left pending: each one is
+// retried when something is still owed, closed when nothing is, and escalated to manual_required otherwise. The
+// counts are emitted as the daily report of the review. It is started by the nightly schedule and can also be
+// started manually from the Temporal UI.
+func CompensationReviewWorkflow(ctx workflow.Context, params activity.ListCompensationsForReviewActivityParams) (*service.CompensationReviewReport, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting CompensationReviewWorkflow", "batch_size", params.BatchSize)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    time.Minute,
+			MaximumAttempts:    5,
+		},
+	})
+
+	var pending activity.ListCompensationsForReviewActivityResults
+	if err := workflow.ExecuteActivity(ctx, "ListCompensationsForReview", params).Get(ctx, &pending); err != nil {
+		logger.Error("Listing compensations for review failed", "error", err)
+		return nil, err
+	}
+
+	report := service.CompensationReviewReport{
+		BusinessDate: workflow.Now(ctx).UTC().Format(service.BusinessDateFormat),
+	}
+
+	// One activity per compensation, so a failing one is retried on its own and does not hold up the others
+	for _, workflowID := range pending.WorkflowIDs {
+		var result service.CompensationReviewResult
+		err := workflow.ExecuteActivity(ctx, "ReviewCompensation", activity.ReviewCompensationActivityParams{
+			WorkflowID: workflowID,
+		}).Get(ctx, &result)
+		if err != nil {
+			logger.Error("Compensation review failed", "workflow_id", workflowID, "error", err)
+
+			report.Examined++
+			report.Errors++
+			continue
+		}
+
+		report.Add(result)
+	}
+
+	if err := workflow.ExecuteActivity(ctx, "RecordCompensationReviewReport", report).Get(ctx, nil); err != nil {
+		logger.Error("Recording compensation review report failed", "error", err)
+		return nil, err
+	}
+
+	logger.Info("CompensationReviewWorkflow completed",
+		"examined", report.Examined,
+		"retried", report.Retried,
+		"resolved", report.Resolved,
+		"escalated", report.Escalated,
+		"skipped", report.Skipped,
+		"errors", report.Errors,
+	)
+
+	return &report, nil
+}
+
+// EnsureCompensationReviewSchedule creates the Temporal schedule that runs CompensationReviewWorkflow every night at
+// the configured UTC time, or updates it to that time and batch size if it already exists
+func (w *Worker) EnsureCompensationReviewSchedule(ctx context.Context, compensationReviewConfig config.CompensationReview) error {
+	const op = "worker.Worker.EnsureCompensationReviewSchedule"
+
+	logger := w.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"schedule_id": compensationReviewScheduleID,
+		"hour":        compensationReviewConfig.Hour,
+		"minute":      compensationReviewConfig.Minute,
+		"batch_size":  compensationReviewConfig.BatchSize,
+	})
+
+	spec, err := dailySpec("compensation review", compensationReviewConfig.Hour, compensationReviewConfig.Minute, "End-of-day review of pending compensations")
+	if err != nil {
+		return err
+	}
+
+	action := &client.ScheduleWorkflowAction{
+		ID:        compensationReviewWorkflowID,
+		Workflow:  CompensationReviewWorkflow,
+		TaskQueue: w.taskQueue,
+		Args: []any{activity.ListCompensationsForReviewActivityParams{
+			BatchSize: compensationReviewConfig.BatchSize,
+		}},
+	}
+
+	return w.ensureSchedule(ctx, logger, compensationReviewScheduleID, "compensation review", spec, action)
+}
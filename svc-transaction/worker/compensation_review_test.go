@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"svc-transaction/activity"
+	"svc-transaction/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+// compensationReviewActivities stands in for activity.Activity so the workflow can run without a database
+type compensationReviewActivities struct{}
+
+func (*compensationReviewActivities) ListCompensationsForReview(context.Context, activity.ListCompensationsForReviewActivityParams) (*activity.ListCompensationsForReviewActivityResults, error) {
+	return nil, nil
+}
+
+func (*compensationReviewActivities) ReviewCompensation(context.Context, activity.ReviewCompensationActivityParams) (*service.CompensationReviewResult, error) {
+	return nil, nil
+}
+
+func (*compensationReviewActivities) RecordCompensationReviewReport(context.Context, service.CompensationReviewReport) error {
+	return nil
+}
+
+func TestCompensationReviewWorkflow(t *testing.T) {
+	t.Parallel()
+
+	var suite testsuite.WorkflowTestSuite
+	env := suite.NewTestWorkflowEnvironment()
+	env.RegisterActivity(&compensationReviewActivities{})
+	env.SetStartTime(time.Date(2026, 3, 2, 23, 45, 0, 0, time.UTC))
+
+	env.OnActivity("ListCompensationsForReview", mock.Anything, activity.ListCompensationsForReviewActivityParams{BatchSize: 10}).
+		Return(&activity.ListCompensationsForReviewActivityResults{WorkflowIDs: []string{"retry", "escalate", "broken"}}, nil).Once()
+
+	env.OnActivity("ReviewCompensation", mock.Anything, activity.ReviewCompensationActivityParams{WorkflowID: "retry"}).
+		Return(&service.CompensationReviewResult{WorkflowID: "retry", Outcome: service.CompensationReviewRetried}, nil).Once()
+	env.OnActivity("ReviewCompensation", mock.Anything, activity.ReviewCompensationActivityParams{WorkflowID: "escalate"}).
+		Return(&service.CompensationReviewResult{WorkflowID: "escalate", Outcome: service.CompensationReviewEscalated}, nil).Once()
+	env.OnActivity("ReviewCompensation", mock.Anything, activity.ReviewCompensationActivityParams{WorkflowID: "broken"}).
+		Return(nil, temporal.NewNonRetryableApplicationError("database unavailable", "TEST", errors.New("database unavailable")))
+
+	expected := service.CompensationReviewReport{
+		BusinessDate:         "2026-03-02",
+		Examined:             3,
+		Retried:              1,
+		Escalated:            1,
+		Errors:               1,
+		EscalatedWorkflowIDs: []string{"escalate"},
+	}
+	env.OnActivity("RecordCompensationReviewReport", mock.Anything, expected).Return(nil).Once()
+
+	env.ExecuteWorkflow(CompensationReviewWorkflow, activity.ListCompensationsForReviewActivityParams{BatchSize: 10})
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	env.AssertExpectations(t)
+
+	var report service.CompensationReviewReport
+	require.NoError(t, env.GetWorkflowResult(&report))
+	assert.Equal(t, expected, report)
+}
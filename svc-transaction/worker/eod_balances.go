@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"svc-transaction/activity"
+	"svc-transaction/service"
+	"svc-transaction/util/config"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	eodBalanceScheduleID = "eod-balance-schedule"
+	eodBalanceWorkflowID = "eod-balance-workflow"
+)
+
+// EODBalanceWorkflow stores the closing balance of every account for one business day (UTC). Started by the
+// nightly schedule it has no date and computes the day before; started on demand by the API it computes the
+// requested date again.
+func EODBalanceWorkflow(ctx workflow.Context, params service.EODBalanceWorkflowParams) (*activity.ComputeEODBalancesActivityResults, error) {
+	logger := workflow.GetLogger(ctx)
+
+	businessDate := params.BusinessDate
+	if businessDate.IsZero() {
+		today, _ := service.BusinessDay(workflow.Now(ctx))
+		businessDate = today.AddDate(0, 0, -1)
+	}
+
+	logger.Info("Starting EODBalanceWorkflow", "business_date", businessDate)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute * 30, // Every account is written by a single statement
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second * 5,
+			BackoffCoefficient: 2,
+			MaximumInterval:    time.Minute * 5,
+			MaximumAttempts:    5,
+		},
+	})
+
+	var result activity.ComputeEODBalancesActivityResults
+	err := workflow.ExecuteActivity(ctx, "ComputeEODBalances", activity.ComputeEODBalancesActivityParams{
+		BusinessDate: businessDate,
+	}).Get(ctx, &result)
+	if err != nil {
+		logger.Error("End-of-day balance run failed", "error", err)
+		return nil, err
+	}
+
+	logger.Info("EODBalanceWorkflow completed", "business_date", result.BusinessDate, "accounts", result.Accounts)
+
+	return &result, nil
+}
+
+// EnsureEODBalanceSchedule creates the Temporal schedule that runs EODBalanceWorkflow every night at the configured
+// UTC time, or updates it to that time if it already exists
+func (w *Worker) EnsureEODBalanceSchedule(ctx context.Context, eodBalancesConfig config.EODBalances) error {
+	const op = "worker.Worker.EnsureEODBalanceSchedule"
+
+	logger := w.logger.WithFields(logrus.Fields{
+		"[op]":        op,
+		"schedule_id": eodBalanceScheduleID,
+		"hour":        eodBalancesConfig.Hour,
+		"minute":      eodBalancesConfig.Minute,
+	})
+
+	spec, err := dailySpec("end-of-day balance", eodBalancesConfig.Hour, eodBalancesConfig.Minute, "Closing balances of the previous business day")
+	if err != nil {
+		return err
+	}
+
+	action := &client.ScheduleWorkflowAction{
+		ID:        eodBalanceWorkflowID,
+		Workflow:  service.EODBalanceWorkflowName,
+		TaskQueue: w.taskQueue,
+		Args:      []any{service.EODBalanceWorkflowParams{}},
+	}
+
+	return w.ensureSchedule(ctx, logger, eodBalanceScheduleID, "end-of-day balance", spec, action)
+}
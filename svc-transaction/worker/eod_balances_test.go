@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"svc-transaction/activity"
+	"svc-transaction/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+)
+
+// eodBalanceActivities stands in for activity.Activity so the workflow can run without a database
+type eodBalanceActivities struct{}
+
+func (*eodBalanceActivities) ComputeEODBalances(context.Context, activity.ComputeEODBalancesActivityParams) (*activity.ComputeEODBalancesActivityResults, error) {
+	return nil, nil
+}
+
+func TestEODBalanceWorkflow(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		params       service.EODBalanceWorkflowParams
+		businessDate time.Time
+	}{
+		"scheduled_computes_previous_day": {
+			params:       service.EODBalanceWorkflowParams{},
+			businessDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"requested_date": {
+			params:       service.EODBalanceWorkflowParams{BusinessDate: time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC)},
+			businessDate: time.Date(2026, 2, 14, 0, 0, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var suite testsuite.WorkflowTestSuite
+			env := suite.NewTestWorkflowEnvironment()
+			env.RegisterActivity(&eodBalanceActivities{})
+			env.SetStartTime(time.Date(2026, 3, 2, 0, 15, 0, 0, time.UTC))
+
+			env.OnActivity("ComputeEODBalances", mock.Anything, mock.MatchedBy(func(params activity.ComputeEODBalancesActivityParams) bool {
+				return params.BusinessDate.Equal(test.businessDate)
+			})).Return(&activity.ComputeEODBalancesActivityResults{
+				BusinessDate: test.businessDate.Format(service.BusinessDateFormat),
+				Accounts:     7,
+			}, nil).Once()
+
+			env.ExecuteWorkflow(EODBalanceWorkflow, test.params)
+
+			require.True(t, env.IsWorkflowCompleted())
+			require.NoError(t, env.GetWorkflowError())
+			env.AssertExpectations(t)
+
+			var results activity.ComputeEODBalancesActivityResults
+			require.NoError(t, env.GetWorkflowResult(&results))
+			assert.Equal(t, int64(7), results.Accounts)
+		})
+	}
+}
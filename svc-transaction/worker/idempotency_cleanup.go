@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"svc-transaction/activity"
+	"svc-transaction/util/config"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	idempotencyCleanupScheduleID = "idempotency-key-cleanup-schedule"
+	idempotencyCleanupWorkflowID = "idempotency-key-cleanup-workflow"
+)
+
+// IdempotencyKeyCleanupWorkflow deletes the expired keys of the idempotency key registry.
+// It is started by the cleanup schedule and can also be started manually from the Temporal UI.
+func IdempotencyKeyCleanupWorkflow(ctx workflow.Context, params activity.PurgeExpiredIdempotencyKeysActivityParams) (*activity.PurgeExpiredIdempotencyKeysActivityResults, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting IdempotencyKeyCleanupWorkflow", "batch_size", params.BatchSize)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute * 5, // A backlog of expired keys is deleted batch by batch
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    time.Minute,
+			MaximumAttempts:    5,
+		},
+	})
+
+	var result activity.PurgeExpiredIdempotencyKeysActivityResults
+	if err := workflow.ExecuteActivity(ctx, "PurgeExpiredIdempotencyKeys", params).Get(ctx, &result); err != nil {
+		logger.Error("Idempotency key cleanup failed", "error", err)
+		return nil, err
+	}
+
+	logger.Info("IdempotencyKeyCleanupWorkflow completed", "purged", result.Purged)
+
+	return &result, nil
+}
+
+// EnsureIdempotencyCleanupSchedule creates the Temporal schedule that periodically runs IdempotencyKeyCleanupWorkflow,
+// or updates it to the configured interval and batch size if it already exists
+func (w *Worker) EnsureIdempotencyCleanupSchedule(ctx context.Context, idempotencyConfig config.Idempotency) error {
+	const op = "worker.Worker.EnsureIdempotencyCleanupSchedule"
+
+	logger := w.logger.WithFields(logrus.Fields{
+		"[op]":             op,
+		"schedule_id":      idempotencyCleanupScheduleID,
+		"interval_minutes": idempotencyConfig.CleanupIntervalMinutes,
+		"batch_size":       idempotencyConfig.CleanupBatchSize,
+	})
+
+	action := &client.ScheduleWorkflowAction{
+		ID:        idempotencyCleanupWorkflowID,
+		Workflow:  IdempotencyKeyCleanupWorkflow,
+		TaskQueue: w.taskQueue,
+		Args: []any{activity.PurgeExpiredIdempotencyKeysActivityParams{
+			BatchSize: idempotencyConfig.CleanupBatchSize,
+		}},
+	}
+
+	spec := intervalSpec(time.Duration(idempotencyConfig.CleanupIntervalMinutes) * time.Minute)
+
+	return w.ensureSchedule(ctx, logger, idempotencyCleanupScheduleID, "idempotency key cleanup", spec, action)
+}
@@ -0,0 +1,93 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"svc-transaction/activity"
+	"svc-transaction/service"
+	"svc-transaction/util/config"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	ledgerExportScheduleID = "ledger-export-schedule"
+	ledgerExportWorkflowID = "ledger-export-workflow"
+)
+
+// LedgerExportWorkflow exports the transactions and balance history of a date range to the export storage.
+// Started by the export schedule it has no range and exports the last complete interval, e.g. yesterday for a daily
+// schedule; started on demand by the API it exports the requested range.
+func LedgerExportWorkflow(ctx workflow.Context, params service.LedgerExportWorkflowParams) (*activity.ExportLedgerSnapshotActivityResults, error) {
+	logger := workflow.GetLogger(ctx)
+
+	from, to := params.From, params.To
+	if from.IsZero() && to.IsZero() && params.Interval > 0 {
+		to = workflow.Now(ctx).UTC().Truncate(params.Interval)
+		from = to.Add(-params.Interval)
+	}
+
+	logger.Info("Starting LedgerExportWorkflow", "from", from, "to", to, "format", params.Format)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Hour, // Large ranges are read page by page and uploaded in one piece per table
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second * 5,
+			BackoffCoefficient: 2,
+			MaximumInterval:    time.Minute * 5,
+			MaximumAttempts:    5,
+		},
+	})
+
+	var result activity.ExportLedgerSnapshotActivityResults
+	err := workflow.ExecuteActivity(ctx, "ExportLedgerSnapshot", activity.ExportLedgerSnapshotActivityParams{
+		From:      from,
+		To:        to,
+		Format:    params.Format,
+		BatchSize: params.BatchSize,
+	}).Get(ctx, &result)
+	if err != nil {
+		logger.Error("Ledger export failed", "error", err)
+		return nil, err
+	}
+
+	for _, file := range result.Files {
+		logger.Info("Ledger table exported", "table", file.Table, "location", file.Location, "rows", file.Rows, "sha256", file.SHA256)
+	}
+
+	logger.Info("LedgerExportWorkflow completed", "from", from, "to", to, "files", len(result.Files))
+
+	return &result, nil
+}
+
+// EnsureLedgerExportSchedule creates the Temporal schedule that periodically runs LedgerExportWorkflow for the
+// interval that just ended, or updates it to the configured interval, format and batch size if it already exists
+func (w *Worker) EnsureLedgerExportSchedule(ctx context.Context, ledgerExportConfig config.LedgerExport) error {
+	const op = "worker.Worker.EnsureLedgerExportSchedule"
+
+	interval := time.Duration(ledgerExportConfig.IntervalMinutes) * time.Minute
+
+	logger := w.logger.WithFields(logrus.Fields{
+		"[op]":             op,
+		"schedule_id":      ledgerExportScheduleID,
+		"interval_minutes": ledgerExportConfig.IntervalMinutes,
+		"format":           ledgerExportConfig.Format,
+	})
+
+	action := &client.ScheduleWorkflowAction{
+		ID:        ledgerExportWorkflowID,
+		Workflow:  service.LedgerExportWorkflowName,
+		TaskQueue: w.taskQueue,
+		Args: []any{service.LedgerExportWorkflowParams{
+			Interval:  interval,
+			Format:    ledgerExportConfig.Format,
+			BatchSize: ledgerExportConfig.BatchSize,
+		}},
+	}
+
+	return w.ensureSchedule(ctx, logger, ledgerExportScheduleID, "ledger export", intervalSpec(interval), action)
+}
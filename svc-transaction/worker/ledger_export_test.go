@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"svc-transaction/activity"
+	"svc-transaction/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/testsuite"
+)
+
+// ledgerExportActivities stands in for activity.Activity so the workflow can run without a database
+type ledgerExportActivities struct{}
+
+func (*ledgerExportActivities) ExportLedgerSnapshot(context.Context, activity.ExportLedgerSnapshotActivityParams) (*activity.ExportLedgerSnapshotActivityResults, error) {
+	return nil, nil
+}
+
+func TestLedgerExportWorkflow(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		params   service.LedgerExportWorkflowParams
+		from, to time.Time
+	}{
+		"scheduled_exports_last_interval": {
+			params: service.LedgerExportWorkflowParams{Interval: 24 * time.Hour, Format: "parquet"},
+			from:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			to:     time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		},
+		"requested_range": {
+			params: service.LedgerExportWorkflowParams{
+				From:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:       time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+				Interval: 24 * time.Hour,
+				Format:   "parquet",
+			},
+			from: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var suite testsuite.WorkflowTestSuite
+			env := suite.NewTestWorkflowEnvironment()
+			env.RegisterActivity(&ledgerExportActivities{})
+			env.SetStartTime(time.Date(2026, 3, 2, 0, 5, 0, 0, time.UTC))
+
+			env.OnActivity("ExportLedgerSnapshot", mock.Anything, mock.MatchedBy(func(params activity.ExportLedgerSnapshotActivityParams) bool {
+				return params.From.Equal(test.from) && params.To.Equal(test.to) && params.Format == "parquet"
+			})).Return(&activity.ExportLedgerSnapshotActivityResults{
+				Format: "parquet",
+				Files:  []service.LedgerExportFile{{Table: service.LedgerExportTransactions, Rows: 3}},
+			}, nil).Once()
+
+			env.ExecuteWorkflow(LedgerExportWorkflow, test.params)
+
+			require.True(t, env.IsWorkflowCompleted())
+			require.NoError(t, env.GetWorkflowError())
+			env.AssertExpectations(t)
+
+			var results activity.ExportLedgerSnapshotActivityResults
+			require.NoError(t, env.GetWorkflowResult(&results))
+			assert.Len(t, results.Files, 1)
+		})
+	}
+}
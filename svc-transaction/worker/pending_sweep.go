@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"svc-transaction/activity"
+	"svc-transaction/util/config"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	pendingSweepScheduleID = "pending-transaction-sweep-schedule"
+	pendingSweepWorkflowID = "pending-transaction-sweep-workflow"
+)
+
+// PendingTransactionSweepWorkflow completes or fails the transactions stuck in pending beyond the stale threshold.
+// It is started by the sweep schedule and can also be started manually from the Temporal UI.
+func PendingTransactionSweepWorkflow(ctx workflow.Context, params activity.SweepPendingTransactionsActivityParams) (*activity.SweepPendingTransactionsActivityResults, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting PendingTransactionSweepWorkflow", "batch_size", params.BatchSize)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute * 5, // Every transaction of the batch is resolved in its own database transaction
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    time.Minute,
+			MaximumAttempts:    5,
+		},
+	})
+
+	var result activity.SweepPendingTransactionsActivityResults
+	if err := workflow.ExecuteActivity(ctx, "SweepPendingTransactions", params).Get(ctx, &result); err != nil {
+		logger.Error("Pending transaction sweep failed", "error", err)
+		return nil, err
+	}
+
+	logger.Info("PendingTransactionSweepWorkflow completed",
+		"examined", result.Examined,
+		"completed", result.Completed,
+		"failed", result.Failed,
+		"skipped", result.Skipped,
+		"errors", result.Errors,
+	)
+
+	return &result, nil
+}
+
+// EnsurePendingSweepSchedule creates the Temporal schedule that periodically runs PendingTransactionSweepWorkflow,
+// or updates it to the configured interval and batch size if it already exists
+func (w *Worker) EnsurePendingSweepSchedule(ctx context.Context, pendingSweepConfig config.PendingSweep) error {
+	const op = "worker.Worker.EnsurePendingSweepSchedule"
+
+	logger := w.logger.WithFields(logrus.Fields{
+		"[op]":             op,
+		"schedule_id":      pendingSweepScheduleID,
+		"interval_minutes": pendingSweepConfig.IntervalMinutes,
+		"batch_size":       pendingSweepConfig.BatchSize,
+	})
+
+	action := &client.ScheduleWorkflowAction{
+		ID:        pendingSweepWorkflowID,
+		Workflow:  PendingTransactionSweepWorkflow,
+		TaskQueue: w.taskQueue,
+		Args: []any{activity.SweepPendingTransactionsActivityParams{
+			BatchSize: pendingSweepConfig.BatchSize,
+		}},
+	}
+
+	spec := intervalSpec(time.Duration(pendingSweepConfig.IntervalMinutes) * time.Minute)
+
+	return w.ensureSchedule(ctx, logger, pendingSweepScheduleID, "pending transaction sweep", spec, action)
+}
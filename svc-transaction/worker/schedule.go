@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+)
+
+// intervalSpec runs a schedule every interval
+func intervalSpec(every time.Duration) client.ScheduleSpec {
+	return client.ScheduleSpec{
+		Intervals: []client.ScheduleIntervalSpec{
+			{Every: every},
+		},
+	}
+}
+
+// dailySpec runs a schedule every day at hour:minute UTC; name describes the schedule in the error of an invalid time
+func dailySpec(name string, hour, minute int, comment string) (client.ScheduleSpec, error) {
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return client.ScheduleSpec{}, fmt.Errorf("invalid %s time %02d:%02d", name, hour, minute)
+	}
+
+	return client.ScheduleSpec{
+		Calendars: []client.ScheduleCalendarSpec{
+			{
+				Hour:    []client.ScheduleRange{{Start: hour}},
+				Minute:  []client.ScheduleRange{{Start: minute}},
+				Comment: comment,
+			},
+		},
+	}, nil
+}
+
+// ensureSchedule creates the schedule id running action on spec, or updates it to them if it already exists. A run
+// still in progress when the next is due skips that one. name describes the schedule in logs and errors.
+func (w *Worker) ensureSchedule(ctx context.Context, logger *logrus.Entry, id, name string, spec client.ScheduleSpec, action *client.ScheduleWorkflowAction) error {
+	_, err := w.client.ScheduleClient().Create(ctx, client.ScheduleOptions{
+		ID:      id,
+		Spec:    spec,
+		Action:  action,
+		Overlap: enumspb.SCHEDULE_OVERLAP_POLICY_SKIP,
+	})
+	if err == nil {
+		logger.Info("Schedule created")
+
+		return nil
+	}
+
+	if !errors.Is(err, temporal.ErrScheduleAlreadyRunning) {
+		return fmt.Errorf("failed to create %s schedule: %w", name, err)
+	}
+
+	handle := w.client.ScheduleClient().GetHandle(ctx, id)
+	err = handle.Update(ctx, client.ScheduleUpdateOptions{
+		DoUpdate: func(input client.ScheduleUpdateInput) (*client.ScheduleUpdate, error) {
+			schedule := input.Description.Schedule
+			schedule.Spec = &spec
+			schedule.Action = action
+
+			return &client.ScheduleUpdate{Schedule: &schedule}, nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update %s schedule: %w", name, err)
+	}
+
+	logger.Info("Schedule updated")
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/mocks"
+	"go.temporal.io/sdk/temporal"
+)
+
+func newScheduleTestWorker(t *testing.T) (*Worker, *mocks.ScheduleClient) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	scheduleClient := mocks.NewScheduleClient(t)
+	temporalClient := mocks.NewClient(t)
+	temporalClient.On("ScheduleClient").Return(scheduleClient)
+
+	return &Worker{client: temporalClient, taskQueue: "transaction-service", logger: logger}, scheduleClient
+}
+
+func TestEnsureScheduleCreates(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	w, scheduleClient := newScheduleTestWorker(t)
+	action := &client.ScheduleWorkflowAction{ID: "sweep-workflow", Workflow: "Sweep"}
+
+	scheduleClient.On("Create", ctx, mock.MatchedBy(func(options client.ScheduleOptions) bool {
+		return options.ID == "sweep-schedule" && options.Action == action
+	})).Return(nil, nil)
+
+	err := w.ensureSchedule(ctx, logrus.NewEntry(w.logger), "sweep-schedule", "sweep", intervalSpec(0), action)
+	require.NoError(t, err)
+}
+
+func TestEnsureScheduleUpdatesExisting(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	w, scheduleClient := newScheduleTestWorker(t)
+	spec, err := dailySpec("review", 23, 30, "Nightly review")
+	require.NoError(t, err)
+	action := &client.ScheduleWorkflowAction{ID: "review-workflow", Workflow: "Review"}
+
+	handle := mocks.NewScheduleHandle(t)
+	scheduleClient.On("Create", ctx, mock.Anything).Return(nil, temporal.ErrScheduleAlreadyRunning)
+	scheduleClient.On("GetHandle", ctx, "review-schedule").Return(handle)
+
+	var updated *client.ScheduleUpdate
+	handle.On("Update", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		options := args.Get(1).(client.ScheduleUpdateOptions)
+
+		var err error
+		updated, err = options.DoUpdate(client.ScheduleUpdateInput{
+			Description: client.ScheduleDescription{Schedule: client.Schedule{}},
+		})
+		require.NoError(t, err)
+	}).Return(nil)
+
+	err = w.ensureSchedule(ctx, logrus.NewEntry(w.logger), "review-schedule", "review", spec, action)
+	require.NoError(t, err)
+
+	require.NotNil(t, updated)
+	assert.Equal(t, &spec, updated.Schedule.Spec)
+	assert.Equal(t, action, updated.Schedule.Action)
+}
+
+func TestEnsureScheduleCreateFails(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	w, scheduleClient := newScheduleTestWorker(t)
+	errUnavailable := errors.New("unavailable")
+
+	scheduleClient.On("Create", ctx, mock.Anything).Return(nil, errUnavailable)
+
+	err := w.ensureSchedule(ctx, logrus.NewEntry(w.logger), "sweep-schedule", "sweep", intervalSpec(0), &client.ScheduleWorkflowAction{})
+	require.ErrorIs(t, err, errUnavailable)
+	assert.Contains(t, err.Error(), "failed to create sweep schedule")
+}
+
+func TestDailySpecRejectsInvalidTime(t *testing.T) {
+	t.Parallel()
+
+	for _, hourMinute := range [][2]int{{24, 0}, {-1, 0}, {0, 60}, {0, -1}} {
+		_, err := dailySpec("review", hourMinute[0], hourMinute[1], "")
+		assert.ErrorContains(t, err, "invalid review time")
+	}
+}
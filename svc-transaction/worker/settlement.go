@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"svc-transaction/activity"
+	"svc-transaction/util/config"
+
+	"github.com/sirupsen/logrus"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	settlementScheduleID = "settlement-file-schedule"
+	settlementWorkflowID = "settlement-file-workflow"
+)
+
+// SettlementWorkflow generates one settlement file from the completed transfers that have not been settled yet.
+// It is started by the settlement schedule and can also be started manually from the Temporal UI.
+func SettlementWorkflow(ctx workflow.Context, params activity.GenerateSettlementFileActivityParams) (*activity.GenerateSettlementFileActivityResults, error) {
+	logger := workflow.GetLogger(ctx)
+	logger.Info("Starting SettlementWorkflow", "format", params.Format, "max_transfers", params.MaxTransfers)
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute * 5, // Large batches render and insert thousands of rows
+		HeartbeatTimeout:    time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    time.Minute,
+			MaximumAttempts:    5,
+		},
+	})
+
+	var result activity.GenerateSettlementFileActivityResults
+	if err := workflow.ExecuteActivity(ctx, "GenerateSettlementFile", params).Get(ctx, &result); err != nil {
+		logger.Error("Settlement file generation failed", "error", err)
+		return nil, err
+	}
+
+	logger.Info("SettlementWorkflow completed", "generated", result.Generated, "file_reference", result.FileReference, "transfer_count", result.TransferCount)
+
+	return &result, nil
+}
+
+// EnsureSettlementSchedule creates the Temporal schedule that periodically runs SettlementWorkflow,
+// or updates it to the configured interval and format if it already exists
+func (w *Worker) EnsureSettlementSchedule(ctx context.Context, settlementConfig config.Settlement) error {
+	const op = "worker.Worker.EnsureSettlementSchedule"
+
+	logger := w.logger.WithFields(logrus.Fields{
+		"[op]":             op,
+		"schedule_id":      settlementScheduleID,
+		"interval_minutes": settlementConfig.IntervalMinutes,
+		"format":           settlementConfig.Format,
+	})
+
+	action := &client.ScheduleWorkflowAction{
+		ID:        settlementWorkflowID,
+		Workflow:  SettlementWorkflow,
+		TaskQueue: w.taskQueue,
+		Args: []any{activity.GenerateSettlementFileActivityParams{
+			Format:       settlementConfig.Format,
+			MaxTransfers: settlementConfig.MaxTransfers,
+		}},
+	}
+
+	// The schedule skips a run while the previous one is still going, so two files are never generated concurrently
+	spec := intervalSpec(time.Duration(settlementConfig.IntervalMinutes) * time.Minute)
+
+	return w.ensureSchedule(ctx, logger, settlementScheduleID, "settlement", spec, action)
+}
@@ -6,11 +6,15 @@ import (
 	"time"
 
 	"svc-transaction/activity"
+	"svc-transaction/service"
 	"svc-transaction/util/config"
+	"svc-transaction/util/workerinterceptor"
 
 	"github.com/sirupsen/logrus"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/interceptor"
 	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
 )
 
 // Worker wraps the Temporal worker and client
@@ -29,6 +33,8 @@ func NewWorker(
 	taskQueue string,
 	activity *activity.Activity,
 	temporalConfig config.Temporal,
+	failureHook workerinterceptor.FailureHook,
+	latencyHook workerinterceptor.LatencyHook,
 ) (*Worker, error) {
 	// Create worker with performance-optimized options
 	workerOptions := worker.Options{
@@ -45,6 +51,11 @@ func NewWorker(
 		// Task timeout optimizations
 		MaxHeartbeatThrottleInterval:     60 * time.Second,
 		DefaultHeartbeatThrottleInterval: 30 * time.Second,
+
+		// Activity logging fields, request IDs, latency and failure simulation, metrics and panic recovery
+		Interceptors: []interceptor.WorkerInterceptor{
+			workerinterceptor.New(workerinterceptor.Options{Logger: logger, FailureHook: failureHook, LatencyHook: latencyHook}),
+		},
 	}
 
 	temporalWorker := worker.New(temporalClient, taskQueue, workerOptions)
@@ -75,6 +86,20 @@ func getOptimizedValue(configValue, defaultValue int) int {
 	return defaultValue
 }
 
+// registerWorkflows registers the workflows hosted by the transaction service
+func (w *Worker) registerWorkflows() {
+	w.worker.RegisterWorkflow(SettlementWorkflow)
+	w.worker.RegisterWorkflow(IdempotencyKeyCleanupWorkflow)
+	w.worker.RegisterWorkflow(PendingTransactionSweepWorkflow)
+	w.worker.RegisterWorkflow(CompensationReviewWorkflow)
+	w.worker.RegisterWorkflowWithOptions(AccountClosureWorkflow, workflow.RegisterOptions{Name: service.AccountClosureWorkflowName})
+	w.worker.RegisterWorkflowWithOptions(AccountErasureWorkflow, workflow.RegisterOptions{Name: service.AccountErasureWorkflowName})
+	w.worker.RegisterWorkflowWithOptions(LedgerExportWorkflow, workflow.RegisterOptions{Name: service.LedgerExportWorkflowName})
+	w.worker.RegisterWorkflowWithOptions(EODBalanceWorkflow, workflow.RegisterOptions{Name: service.EODBalanceWorkflowName})
+
+	w.logger.WithField("task_queue", w.taskQueue).Info("Temporal workflows registered successfully")
+}
+
 // registerActivities registers all activities with the worker
 func (w *Worker) registerActivities() {
 	activities := w.activity.GetActivities()
@@ -99,7 +124,8 @@ func (w *Worker) Run(ctx context.Context) error {
 
 	logger.Info("Starting Temporal worker")
 
-	// Register activities before starting
+	// Register workflows and activities before starting
+	w.registerWorkflows()
 	w.registerActivities()
 
 	// Start the worker